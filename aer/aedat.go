@@ -0,0 +1,204 @@
+package aer
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// =================================================================================
+// AEDAT FILES
+// =================================================================================
+
+const (
+	aedat2Magic     = "#!AER-DAT2.0"
+	aedat3Magic     = "#!AER-DAT3"
+	aedat4Magic     = "#!AER-DAT4"
+	aedat3EndHeader = "#!END-HEADER"
+
+	aedat3PacketHeaderSize = 28
+	aedat3PolarityEvent    = 1
+)
+
+// NewAEDATReader detects the AEDAT version from the header and returns a
+// reader for it. format describes AEDAT 2.0 addresses; AEDAT 3.1 polarity
+// events carry explicit coordinates and ignore it.
+func NewAEDATReader(r io.Reader, format AddressFormat) (EventReader, error) {
+	br := bufio.NewReader(r)
+	first, err := br.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("reading AEDAT header: %w", err)
+	}
+	version := strings.TrimSpace(first)
+
+	switch {
+	case strings.HasPrefix(version, aedat2Magic):
+		if err := skipHeaderLines(br, ""); err != nil {
+			return nil, err
+		}
+		return &aedat2Reader{r: br, format: format}, nil
+	case strings.HasPrefix(version, aedat3Magic):
+		if err := skipHeaderLines(br, aedat3EndHeader); err != nil {
+			return nil, err
+		}
+		return &aedat3Reader{r: br}, nil
+	case strings.HasPrefix(version, aedat4Magic):
+		return nil, fmt.Errorf("AEDAT 4.0 is not supported; convert to AEDAT 2.0 or 3.1")
+	default:
+		return nil, fmt.Errorf("unrecognised AEDAT header %q", version)
+	}
+}
+
+// skipHeaderLines consumes '#' comment lines up to and including end (or up
+// to the first non-comment byte when end is empty).
+func skipHeaderLines(br *bufio.Reader, end string) error {
+	for {
+		next, err := br.Peek(1)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading AEDAT header: %w", err)
+		}
+		if next[0] != '#' {
+			if end != "" {
+				return fmt.Errorf("AEDAT header missing %s", end)
+			}
+			return nil
+		}
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("reading AEDAT header: %w", err)
+		}
+		if end != "" && strings.HasPrefix(strings.TrimSpace(line), end) {
+			return nil
+		}
+	}
+}
+
+// aedat2Reader reads big-endian (address, timestamp) pairs.
+type aedat2Reader struct {
+	r      io.Reader
+	format AddressFormat
+	clock  timestampUnwrapper
+	buf    [8]byte
+}
+
+func (a *aedat2Reader) ReadEvent() (Event, error) {
+	if _, err := io.ReadFull(a.r, a.buf[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return Event{}, fmt.Errorf("truncated AEDAT 2.0 event: %w", err)
+		}
+		return Event{}, err
+	}
+	address := binary.BigEndian.Uint32(a.buf[0:4])
+	timestamp := a.clock.unwrap(binary.BigEndian.Uint32(a.buf[4:8]))
+	return a.format.Decode(address, timestamp)
+}
+
+// aedat3Reader reads little-endian event packets, skipping non-polarity
+// packets and invalid events.
+type aedat3Reader struct {
+	r       io.Reader
+	pending []Event
+}
+
+func (a *aedat3Reader) ReadEvent() (Event, error) {
+	for len(a.pending) == 0 {
+		if err := a.readPacket(); err != nil {
+			return Event{}, err
+		}
+	}
+	ev := a.pending[0]
+	a.pending = a.pending[1:]
+	return ev, nil
+}
+
+// readPacket loads the next packet's polarity events into pending.
+func (a *aedat3Reader) readPacket() error {
+	var header [aedat3PacketHeaderSize]byte
+	if _, err := io.ReadFull(a.r, header[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("truncated AEDAT 3 packet header: %w", err)
+		}
+		return err
+	}
+	eventType := int16(binary.LittleEndian.Uint16(header[0:2]))
+	eventSize := int(binary.LittleEndian.Uint32(header[4:8]))
+	overflow := int64(binary.LittleEndian.Uint32(header[12:16]))
+	capacity := int(binary.LittleEndian.Uint32(header[16:20]))
+	number := int(binary.LittleEndian.Uint32(header[20:24]))
+
+	if eventSize <= 0 || capacity < number {
+		return fmt.Errorf("corrupt AEDAT 3 packet: size %d, capacity %d, number %d", eventSize, capacity, number)
+	}
+	payload := make([]byte, eventSize*capacity)
+	if _, err := io.ReadFull(a.r, payload); err != nil {
+		return fmt.Errorf("truncated AEDAT 3 packet: %w", err)
+	}
+	if eventType != aedat3PolarityEvent || eventSize < 8 {
+		return nil
+	}
+
+	for i := 0; i < number; i++ {
+		raw := payload[i*eventSize:]
+		data := binary.LittleEndian.Uint32(raw[0:4])
+		if data&1 == 0 {
+			continue // invalidated event
+		}
+		a.pending = append(a.pending, Event{
+			Timestamp: overflow<<31 | int64(binary.LittleEndian.Uint32(raw[4:8])&0x7FFFFFFF),
+			Polarity:  data&(1<<1) != 0,
+			Y:         uint16((data >> 2) & 0x7FFF),
+			X:         uint16((data >> 17) & 0x7FFF),
+		})
+	}
+	return nil
+}
+
+// =================================================================================
+// AEDAT 2.0 WRITER
+// =================================================================================
+
+// AEDATWriter writes AEDAT 2.0 files readable by jAER and most AER tools.
+type AEDATWriter struct {
+	w      *bufio.Writer
+	format AddressFormat
+	buf    [8]byte
+}
+
+// NewAEDATWriter writes the AEDAT 2.0 header followed by the given comment
+// lines and returns a writer for events.
+func NewAEDATWriter(w io.Writer, format AddressFormat, comments ...string) (*AEDATWriter, error) {
+	bw := bufio.NewWriter(w)
+	lines := append([]string{aedat2Magic, "# Created by temporal-neuron"}, comments...)
+	for i, line := range lines {
+		if i > 0 && !strings.HasPrefix(line, "#") {
+			line = "# " + line
+		}
+		if _, err := bw.WriteString(line + "\r\n"); err != nil {
+			return nil, fmt.Errorf("writing AEDAT header: %w", err)
+		}
+	}
+	return &AEDATWriter{w: bw, format: format}, nil
+}
+
+// WriteEvent appends one event. Timestamps are stored modulo 2^32 µs.
+func (a *AEDATWriter) WriteEvent(ev Event) error {
+	address, err := a.format.Encode(ev)
+	if err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint32(a.buf[0:4], address)
+	binary.BigEndian.PutUint32(a.buf[4:8], uint32(ev.Timestamp))
+	_, err = a.w.Write(a.buf[:])
+	return err
+}
+
+// Flush writes buffered events to the underlying writer.
+func (a *AEDATWriter) Flush() error {
+	return a.w.Flush()
+}
@@ -0,0 +1,354 @@
+package aer
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// recordingNeuron captures received input signals.
+type recordingNeuron struct {
+	*component.BaseComponent
+	mu       sync.Mutex
+	received []types.NeuralSignal
+}
+
+func newRecordingNeuron(id string) *recordingNeuron {
+	return &recordingNeuron{BaseComponent: component.NewBaseComponent(id, types.TypeNeuron, types.Position3D{})}
+}
+
+func (r *recordingNeuron) Receive(msg types.NeuralSignal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, msg)
+}
+
+// sliceWriter collects events in memory.
+type sliceWriter struct{ events []Event }
+
+func (s *sliceWriter) WriteEvent(ev Event) error { s.events = append(s.events, ev); return nil }
+func (s *sliceWriter) Flush() error              { return nil }
+
+// sliceReader replays events from memory.
+type sliceReader struct{ events []Event }
+
+func (s *sliceReader) ReadEvent() (Event, error) {
+	if len(s.events) == 0 {
+		return Event{}, io.EOF
+	}
+	ev := s.events[0]
+	s.events = s.events[1:]
+	return ev, nil
+}
+
+// TestAEDAT2RoundTrip verifies writing and reading AEDAT 2.0 with the DVS128
+// address layout, including 32-bit timestamp wraparound.
+func TestAEDAT2RoundTrip(t *testing.T) {
+	events := []Event{
+		{Timestamp: 10, X: 0, Y: 0, Polarity: true},
+		{Timestamp: 1<<32 - 5, X: 127, Y: 64, Polarity: false},
+		{Timestamp: 1<<32 + 20, X: 3, Y: 127, Polarity: true}, // wrapped on disk
+	}
+
+	var buf bytes.Buffer
+	writer, err := NewAEDATWriter(&buf, DVS128Format, "recorded in test")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, ev := range events {
+		if err := writer.WriteEvent(ev); err != nil {
+			t.Fatalf("WriteEvent failed: %v", err)
+		}
+	}
+	writer.Flush()
+
+	if err := writer.WriteEvent(Event{X: 200}); err == nil {
+		t.Error("Expected error for event outside the sensor")
+	}
+
+	reader, err := NewAEDATReader(&buf, DVS128Format)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	if len(got) != len(events) {
+		t.Fatalf("Expected %d events, got %d", len(events), len(got))
+	}
+	for i := range events {
+		if got[i] != events[i] {
+			t.Errorf("Event %d: expected %+v, got %+v", i, events[i], got[i])
+		}
+	}
+}
+
+// TestAEDAT3PolarityPackets verifies packet parsing, skipping of other event
+// types and of invalidated events.
+func TestAEDAT3PolarityPackets(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString("#!AER-DAT3.1\r\n#Format: RAW\r\n#!END-HEADER\r\n")
+
+	writePacket := func(eventType int16, overflow uint32, payload [][2]uint32) {
+		header := make([]byte, aedat3PacketHeaderSize)
+		binary.LittleEndian.PutUint16(header[0:2], uint16(eventType))
+		binary.LittleEndian.PutUint32(header[4:8], 8)
+		binary.LittleEndian.PutUint32(header[12:16], overflow)
+		binary.LittleEndian.PutUint32(header[16:20], uint32(len(payload)))
+		binary.LittleEndian.PutUint32(header[20:24], uint32(len(payload)))
+		buf.Write(header)
+		for _, e := range payload {
+			buf.Write(binary.LittleEndian.AppendUint32(nil, e[0]))
+			buf.Write(binary.LittleEndian.AppendUint32(nil, e[1]))
+		}
+	}
+	polarity := func(x, y uint32, on, valid bool) uint32 {
+		data := x<<17 | y<<2
+		if on {
+			data |= 2
+		}
+		if valid {
+			data |= 1
+		}
+		return data
+	}
+
+	writePacket(2, 0, [][2]uint32{{1, 5}}) // special events: skipped
+	writePacket(aedat3PolarityEvent, 1, [][2]uint32{
+		{polarity(300, 200, true, true), 100},
+		{polarity(1, 1, false, false), 150}, // invalid
+		{polarity(10, 20, false, true), 200},
+	})
+
+	reader, err := NewAEDATReader(&buf, DVS128Format)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := ReadAll(reader)
+	if err != nil {
+		t.Fatalf("ReadAll failed: %v", err)
+	}
+	expected := []Event{
+		{Timestamp: 1<<31 + 100, X: 300, Y: 200, Polarity: true},
+		{Timestamp: 1<<31 + 200, X: 10, Y: 20, Polarity: false},
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d events, got %+v", len(expected), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Event %d: expected %+v, got %+v", i, expected[i], got[i])
+		}
+	}
+
+	if _, err := NewAEDATReader(bytes.NewBufferString("#!AER-DAT4.0\r\n"), DVS128Format); err == nil {
+		t.Error("Expected error for AEDAT 4.0")
+	}
+}
+
+// TestUDPStream verifies datagram batching, decoding and loss accounting.
+func TestUDPStream(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("UDP unavailable: %v", err)
+	}
+	defer server.Close()
+	client, err := net.Dial("udp", server.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer client.Close()
+
+	writer := NewUDPWriter(client, DVS128Format)
+	reader := NewUDPReader(server, DVS128Format)
+
+	total := AER_UDP_MAX_EVENTS + 5
+	for i := 0; i < total; i++ {
+		writer.WriteEvent(Event{Timestamp: int64(i), X: uint16(i % 128), Y: 1, Polarity: i%2 == 0})
+	}
+	writer.Flush()
+
+	server.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for i := 0; i < total; i++ {
+		ev, err := reader.ReadEvent()
+		if err != nil {
+			t.Fatalf("ReadEvent %d failed: %v", i, err)
+		}
+		if ev.Timestamp != int64(i) || ev.X != uint16(i%128) {
+			t.Fatalf("Event %d: unexpected %+v", i, ev)
+		}
+	}
+	stats := reader.GetStats()
+	if stats["datagrams_received"].(int64) != 2 || stats["datagrams_lost"].(int64) != 0 {
+		t.Errorf("Unexpected stats: %v", stats)
+	}
+
+	// A skipped sequence number is counted as loss
+	writer.seq += 3
+	writer.WriteEvent(Event{Timestamp: 1000})
+	writer.Flush()
+	if _, err := reader.ReadEvent(); err != nil {
+		t.Fatalf("ReadEvent failed: %v", err)
+	}
+	if lost := reader.GetStats()["datagrams_lost"].(int64); lost != 3 {
+		t.Errorf("Expected 3 lost datagrams, got %d", lost)
+	}
+}
+
+// TestPlayerAndRecorder drives neurons from events and records spikes back
+// into events with microsecond timestamps.
+func TestPlayerAndRecorder(t *testing.T) {
+	on := []component.MessageReceiver{newRecordingNeuron("on0"), newRecordingNeuron("on1")}
+	off := []component.MessageReceiver{newRecordingNeuron("off0"), newRecordingNeuron("off1")}
+
+	events := &sliceReader{events: []Event{
+		{Timestamp: 1000, X: 1, Y: 0, Polarity: true},
+		{Timestamp: 1500, X: 0, Y: 0, Polarity: false},
+		{Timestamp: 2000, X: 5, Y: 5, Polarity: true}, // outside the grid
+	}}
+	player, err := NewPlayer(events, GridMapper(2, on, off), PlayerConfig{Gain: 0.7})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := player.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	on1 := on[1].(*recordingNeuron)
+	off0 := off[0].(*recordingNeuron)
+	if len(on1.received) != 1 || on1.received[0].Value != 0.7 {
+		t.Fatalf("Expected one ON input of 0.7, got %+v", on1.received)
+	}
+	if len(off0.received) != 1 {
+		t.Fatalf("Expected one OFF input, got %+v", off0.received)
+	}
+	if spacing := off0.received[0].Timestamp.Sub(on1.received[0].Timestamp); spacing != 500*time.Microsecond {
+		t.Errorf("Expected 500µs spacing between signals, got %v", spacing)
+	}
+	if stats := player.GetStats(); stats["delivered"].(int64) != 2 || stats["dropped"].(int64) != 1 {
+		t.Errorf("Unexpected player stats: %v", stats)
+	}
+
+	out := &sliceWriter{}
+	start := time.Now()
+	recorder := NewRecorder(out, start)
+	recorder.AssignGrid([]string{"n0", "n1", "n2"}, 2)
+	callback := recorder.OutputCallback("n2")
+	callback.TransmitMessage(types.NeuralSignal{Value: 1, Timestamp: start.Add(1234 * time.Microsecond)})
+	recorder.Record("unassigned", start)
+
+	if len(out.events) != 1 {
+		t.Fatalf("Expected one output event, got %+v", out.events)
+	}
+	if ev := out.events[0]; ev.X != 0 || ev.Y != 1 || ev.Timestamp != 1234 || !ev.Polarity {
+		t.Errorf("Unexpected output event %+v", ev)
+	}
+}
+
+// loadedNeuron reports a settable input buffer fill and can be slow to
+// accept input.
+type loadedNeuron struct {
+	*recordingNeuron
+	occupancy atomic.Value // float64
+	delay     time.Duration
+}
+
+func newLoadedNeuron(id string, occupancy float64) *loadedNeuron {
+	n := &loadedNeuron{recordingNeuron: newRecordingNeuron(id)}
+	n.occupancy.Store(occupancy)
+	return n
+}
+
+func (l *loadedNeuron) GetInputQueueOccupancy() float64 { return l.occupancy.Load().(float64) }
+
+func (l *loadedNeuron) Receive(msg types.NeuralSignal) {
+	time.Sleep(l.delay)
+	l.recordingNeuron.Receive(msg)
+}
+
+// burst returns count ON events at pixel (0, 0) spaced by spacing µs.
+func burst(count int, spacing int64) *sliceReader {
+	events := make([]Event, count)
+	for i := range events {
+		events[i] = Event{Timestamp: int64(i) * spacing, Polarity: true}
+	}
+	return &sliceReader{events: events}
+}
+
+// TestPlayerBackpressure verifies that throttling waits for a congested
+// target to drain and that coalescing merges a congested target's events
+// into one signal carrying their summed gain.
+func TestPlayerBackpressure(t *testing.T) {
+	target := newLoadedNeuron("in", 0.95)
+	mapper := func(Event) component.MessageReceiver { return target }
+	throttled, _ := NewPlayer(burst(3, 10), mapper, PlayerConfig{Backpressure: BackpressureThrottle})
+	time.AfterFunc(30*time.Millisecond, func() { target.occupancy.Store(0.1) })
+	if err := throttled.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	report := throttled.Report()
+	if report.Throttled < 20*time.Millisecond || report.Signals != 3 || len(target.received) != 3 {
+		t.Errorf("Expected delivery after the target drained, got %+v", report)
+	}
+	if spacing := target.received[1].Timestamp.Sub(target.received[0].Timestamp); spacing != 10*time.Microsecond {
+		t.Errorf("Expected the schedule shifted as a whole, got spacing %v", spacing)
+	}
+
+	merged := newLoadedNeuron("merged", 0.9)
+	coalescing, _ := NewPlayer(burst(5, 10), func(Event) component.MessageReceiver { return merged },
+		PlayerConfig{Gain: 0.5, Backpressure: BackpressureCoalesce})
+	if err := coalescing.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(merged.received) != 1 || merged.received[0].Value != 2.5 {
+		t.Fatalf("Expected one signal of 2.5, got %+v", merged.received)
+	}
+	if report := coalescing.Report(); report.Events != 5 || report.Signals != 1 || report.Coalesced != 4 {
+		t.Errorf("Expected five events coalesced into one signal, got %+v", report)
+	}
+
+	if _, err := NewPlayer(burst(1, 1), mapper, PlayerConfig{HighWater: 1.5}); err == nil {
+		t.Error("Expected a high-water mark above 1 rejected")
+	}
+}
+
+// TestPlayerReportsInfeasibleRate verifies that a stream faster than its
+// target can absorb is reported, and stops replay under BackpressureFail.
+func TestPlayerReportsInfeasibleRate(t *testing.T) {
+	slow := newLoadedNeuron("slow", 0)
+	slow.delay = 5 * time.Millisecond
+	mapper := func(Event) component.MessageReceiver { return slow }
+
+	lagging, _ := NewPlayer(burst(6, 1000), mapper, PlayerConfig{Speed: 1, MaxLag: 2 * time.Millisecond})
+	if err := lagging.Run(context.Background()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	report := lagging.Report()
+	if report.Feasible || report.MaxLag <= 2*time.Millisecond || report.Signals != 6 {
+		t.Errorf("Expected all events delivered late and reported infeasible, got %+v", report)
+	}
+	if report.RequestedRate < 900 || report.AchievedRate >= report.RequestedRate {
+		t.Errorf("Expected ~1000 events/s requested and fewer achieved, got %+v", report)
+	}
+
+	failing, _ := NewPlayer(burst(6, 1000), mapper,
+		PlayerConfig{Speed: 1, MaxLag: 2 * time.Millisecond, Backpressure: BackpressureFail})
+	err := failing.Run(context.Background())
+	if !errors.Is(err, ErrRateInfeasible) {
+		t.Fatalf("Expected ErrRateInfeasible, got %v", err)
+	}
+	if signals := failing.Report().Signals; signals >= 6 {
+		t.Errorf("Expected replay stopped early, got %d signals", signals)
+	}
+}
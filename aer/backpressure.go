@@ -0,0 +1,200 @@
+package aer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// BACKPRESSURE
+// =================================================================================
+//
+// A recording can ask for more events per second than the network absorbs.
+// Without feedback the player falls ever further behind its schedule and
+// the neurons' input buffers overflow, so input is lost silently. Two load
+// signals are watched:
+//
+//   - lag: how far delivery is behind the event's scheduled time (Speed > 0)
+//   - congestion: the fill of the target's input buffer, for targets that
+//     report it (neuron.Neuron.GetInputQueueOccupancy)
+//
+// The Backpressure policy decides the response. BackpressureThrottle waits
+// for a congested target to drain and shifts the rest of the schedule by
+// the wait, slowing replay down instead of bursting. BackpressureCoalesce
+// merges the events of a congested target, or of all targets while lagging,
+// into one signal per target carrying their summed gain. BackpressureFail
+// stops with ErrRateInfeasible once lag exceeds MaxLag. Whatever the
+// policy, Report tells the requested and achieved rates and whether the
+// lag stayed within MaxLag.
+
+// Backpressure selects how a player responds to a network that cannot keep up.
+type Backpressure int
+
+const (
+	BackpressureNone     Backpressure = iota // Deliver late and keep going
+	BackpressureThrottle                     // Slow replay while targets are congested
+	BackpressureCoalesce                     // Merge events per target while congested or lagging
+	BackpressureFail                         // Stop with ErrRateInfeasible when lag exceeds MaxLag
+)
+
+// String returns the policy name.
+func (b Backpressure) String() string {
+	switch b {
+	case BackpressureNone:
+		return "none"
+	case BackpressureThrottle:
+		return "throttle"
+	case BackpressureCoalesce:
+		return "coalesce"
+	case BackpressureFail:
+		return "fail"
+	default:
+		return fmt.Sprintf("backpressure(%d)", int(b))
+	}
+}
+
+const (
+	PLAYER_DEFAULT_HIGH_WATER = 0.8                    // Target buffer fill counted as congested
+	PLAYER_DEFAULT_MAX_LAG    = 10 * time.Millisecond  // Lag still counted as keeping up
+	PLAYER_THROTTLE_POLL      = 100 * time.Microsecond // Congestion check interval while throttled
+	PLAYER_THROTTLE_MAX_WAIT  = time.Second            // Longest wait for one target to drain
+)
+
+// ErrRateInfeasible reports that the network cannot absorb the requested
+// input rate.
+var ErrRateInfeasible = errors.New("requested input rate is infeasible")
+
+// occupancyReporter is implemented by targets whose input buffer fill is
+// known (neuron.Neuron).
+type occupancyReporter interface {
+	GetInputQueueOccupancy() float64
+}
+
+// RateReport compares the input rate a replay asked for with what was
+// delivered.
+type RateReport struct {
+	Policy        Backpressure  `json:"policy"`
+	Events        int64         `json:"events"`         // Events read
+	Signals       int64         `json:"signals"`        // Signals delivered (coalesced events count once)
+	RequestedRate float64       `json:"requested_rate"` // Events/s asked for by the stream at Speed (0 = as fast as possible)
+	AchievedRate  float64       `json:"achieved_rate"`  // Events/s actually replayed
+	Lag           time.Duration `json:"lag"`            // Behind schedule at the last event
+	MaxLag        time.Duration `json:"max_lag"`        // Largest lag seen
+	Throttled     time.Duration `json:"throttled"`      // Time spent waiting for congested targets
+	Coalesced     int64         `json:"coalesced"`      // Events merged into another event's signal
+	Feasible      bool          `json:"feasible"`       // MaxLag stayed within the configured limit
+}
+
+// pendingSignal accumulates coalesced events for one target.
+type pendingSignal struct {
+	target component.MessageReceiver
+	at     time.Time
+	events int64
+}
+
+// congested reports whether target's input buffer is above the high-water
+// mark.
+func (p *Player) congested(target component.MessageReceiver) bool {
+	reporter, ok := target.(occupancyReporter)
+	return ok && reporter.GetInputQueueOccupancy() >= p.config.HighWater
+}
+
+// throttle waits until target drains below the high-water mark and returns
+// the time waited. It gives up after PLAYER_THROTTLE_MAX_WAIT, since a
+// stopped target never drains.
+func (p *Player) throttle(ctx context.Context, target component.MessageReceiver) (time.Duration, error) {
+	start := time.Now()
+	ticker := time.NewTicker(PLAYER_THROTTLE_POLL)
+	defer ticker.Stop()
+	for p.congested(target) && time.Since(start) < PLAYER_THROTTLE_MAX_WAIT {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return time.Since(start), ctx.Err()
+		}
+	}
+	waited := time.Since(start)
+	p.mu.Lock()
+	p.throttled += waited
+	p.mu.Unlock()
+	return waited, nil
+}
+
+// coalesce adds an event to its target's pending signal.
+func (p *Player) coalesce(target component.MessageReceiver, at time.Time) {
+	id := target.ID()
+	if pending, ok := p.pending[id]; ok {
+		pending.events++
+		return
+	}
+	p.pending[id] = &pendingSignal{target: target, at: at, events: 1}
+	p.pendingOrder = append(p.pendingOrder, id)
+}
+
+// flushPending delivers the pending signals, skipping congested targets
+// unless all is set.
+func (p *Player) flushPending(all bool) {
+	kept := p.pendingOrder[:0]
+	for _, id := range p.pendingOrder {
+		pending := p.pending[id]
+		if !all && p.congested(pending.target) {
+			kept = append(kept, id)
+			continue
+		}
+		delete(p.pending, id)
+		p.mu.Lock()
+		p.delivered++
+		p.coalesced += pending.events - 1
+		p.mu.Unlock()
+		pending.target.Receive(types.NeuralSignal{
+			Value:     p.config.Gain * float64(pending.events),
+			Timestamp: pending.at,
+			SourceID:  p.config.SourceID,
+			TargetID:  id,
+		})
+	}
+	p.pendingOrder = kept
+}
+
+// observeLag records how far the event due at due is behind schedule.
+func (p *Player) observeLag(due time.Time) time.Duration {
+	lag := time.Since(due)
+	if lag < 0 {
+		lag = 0
+	}
+	p.mu.Lock()
+	p.lag = lag
+	if lag > p.maxLag {
+		p.maxLag = lag
+	}
+	p.mu.Unlock()
+	return lag
+}
+
+// Report returns the rates and load responses of the replay so far.
+func (p *Player) Report() RateReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	report := RateReport{
+		Policy:    p.config.Backpressure,
+		Events:    p.events,
+		Signals:   p.delivered,
+		Lag:       p.lag,
+		MaxLag:    p.maxLag,
+		Throttled: p.throttled,
+		Coalesced: p.coalesced,
+		Feasible:  p.maxLag <= p.config.MaxLag,
+	}
+	if p.events > 1 && p.config.Speed > 0 && p.span > 0 {
+		report.RequestedRate = float64(p.events-1) / (p.span.Seconds() / p.config.Speed)
+	}
+	if p.events > 1 && p.elapsed > 0 {
+		report.AchievedRate = float64(p.events-1) / p.elapsed.Seconds()
+	}
+	return report
+}
@@ -0,0 +1,133 @@
+/*
+=================================================================================
+ADDRESS EVENT REPRESENTATION (AER)
+=================================================================================
+
+Event-based sensors such as Dynamic Vision Sensors (DVS) do not produce frames.
+Each pixel independently reports brightness changes as an event carrying its
+address, a polarity (ON = brighter, OFF = darker) and a microsecond timestamp.
+This is the same currency the network computes in: sparse, asynchronous,
+precisely timed spikes.
+
+The package provides:
+
+  - Readers and writers for AEDAT 2.0 and 3.1 files (jAER / DV recordings)
+  - UDP streams in the jAER network format for live sensors
+  - Input: a Player that replays events into neurons with their original
+    microsecond spacing
+  - Output: a Recorder that turns neuron spikes into AER events so networks
+    can drive AER displays, actuators or other neuromorphic hardware
+
+AEDAT 4.0 stores events in compressed FlatBuffers packets and is not supported;
+convert recordings with dv-processing or jAER to AEDAT 2.0/3.1.
+=================================================================================
+*/
+
+package aer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Event is one address event. Timestamp is in microseconds since the start of
+// the recording or stream.
+type Event struct {
+	Timestamp int64  `json:"t"`
+	X         uint16 `json:"x"`
+	Y         uint16 `json:"y"`
+	Polarity  bool   `json:"p"` // true = ON (brightness increase)
+}
+
+// EventReader yields events in timestamp order. ReadEvent returns io.EOF at
+// the end of the stream.
+type EventReader interface {
+	ReadEvent() (Event, error)
+}
+
+// EventWriter consumes events.
+type EventWriter interface {
+	WriteEvent(ev Event) error
+	Flush() error
+}
+
+// ReadAll drains r into a slice.
+func ReadAll(r EventReader) ([]Event, error) {
+	var events []Event
+	for {
+		ev, err := r.ReadEvent()
+		if errors.Is(err, io.EOF) {
+			return events, nil
+		}
+		if err != nil {
+			return events, err
+		}
+		events = append(events, ev)
+	}
+}
+
+// =================================================================================
+// ADDRESS LAYOUT
+// =================================================================================
+
+// AddressFormat describes how x, y and polarity are packed into a 32-bit AER
+// address in AEDAT 2.0 files and jAER UDP packets.
+type AddressFormat struct {
+	XShift, XBits uint
+	YShift, YBits uint
+	PolarityBit   uint
+	Width, Height uint16 // Sensor resolution; events outside are rejected
+}
+
+// DVS128Format is the jAER layout of the DVS128 sensor: polarity in bit 0,
+// x in bits 1-7, y in bits 8-14.
+var DVS128Format = AddressFormat{
+	XShift: 1, XBits: 7,
+	YShift: 8, YBits: 7,
+	PolarityBit: 0,
+	Width:       128, Height: 128,
+}
+
+// Decode unpacks an address.
+func (f AddressFormat) Decode(address uint32, timestamp int64) (Event, error) {
+	ev := Event{
+		Timestamp: timestamp,
+		X:         uint16((address >> f.XShift) & (1<<f.XBits - 1)),
+		Y:         uint16((address >> f.YShift) & (1<<f.YBits - 1)),
+		Polarity:  address&(1<<f.PolarityBit) != 0,
+	}
+	if ev.X >= f.Width || ev.Y >= f.Height {
+		return ev, fmt.Errorf("address %#x decodes to (%d, %d) outside %dx%d sensor", address, ev.X, ev.Y, f.Width, f.Height)
+	}
+	return ev, nil
+}
+
+// Encode packs an event address.
+func (f AddressFormat) Encode(ev Event) (uint32, error) {
+	if ev.X >= f.Width || ev.Y >= f.Height {
+		return 0, fmt.Errorf("event (%d, %d) outside %dx%d sensor", ev.X, ev.Y, f.Width, f.Height)
+	}
+	address := uint32(ev.X)<<f.XShift | uint32(ev.Y)<<f.YShift
+	if ev.Polarity {
+		address |= 1 << f.PolarityBit
+	}
+	return address, nil
+}
+
+// timestampUnwrapper extends 32-bit microsecond timestamps, which wrap about
+// every 71.6 minutes, to 64 bits.
+type timestampUnwrapper struct {
+	last     uint32
+	overflow int64
+	started  bool
+}
+
+// unwrap returns the monotonic 64-bit timestamp for ts.
+func (u *timestampUnwrapper) unwrap(ts uint32) int64 {
+	if u.started && ts < u.last && u.last-ts > 1<<31 {
+		u.overflow += 1 << 32
+	}
+	u.last, u.started = ts, true
+	return u.overflow + int64(ts)
+}
@@ -0,0 +1,296 @@
+package aer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// INPUT: EVENTS → NEURONS
+// =================================================================================
+
+// PixelMapper selects the neuron that receives an event; nil drops it.
+type PixelMapper func(ev Event) component.MessageReceiver
+
+// GridMapper maps pixel (x, y) to index y*width+x of on or off by polarity.
+// When off is nil both polarities drive the on population.
+func GridMapper(width int, on, off []component.MessageReceiver) PixelMapper {
+	return func(ev Event) component.MessageReceiver {
+		targets := on
+		if !ev.Polarity && off != nil {
+			targets = off
+		}
+		index := int(ev.Y)*width + int(ev.X)
+		if int(ev.X) >= width || index >= len(targets) {
+			return nil
+		}
+		return targets[index]
+	}
+}
+
+// PlayerConfig controls event replay.
+type PlayerConfig struct {
+	Gain     float64 // Signal value per event (0 = 1.0)
+	Speed    float64 // 1 = real time, 2 = twice as fast, 0 = as fast as possible
+	SourceID string  // Source ID stamped on signals (empty = "aer")
+
+	Backpressure Backpressure  // Response to a network that cannot keep up (see backpressure.go)
+	HighWater    float64       // Target input buffer fill counted as congested (0 = default)
+	MaxLag       time.Duration // Lag behind schedule still counted as keeping up (0 = default)
+}
+
+// Player replays an event stream into neurons, preserving the microsecond
+// spacing between events (scaled by Speed).
+type Player struct {
+	reader EventReader
+	mapper PixelMapper
+	config PlayerConfig
+
+	// Coalesced signals by target ID, in arrival order (owned by Run)
+	pending      map[string]*pendingSignal
+	pendingOrder []string
+
+	delivered int64
+	dropped   int64
+	events    int64
+	span      time.Duration // Stream time of the last event since the first
+	elapsed   time.Duration // Wall time of the last event since Run started
+	lag       time.Duration
+	maxLag    time.Duration
+	throttled time.Duration
+	coalesced int64
+	mu        sync.Mutex
+}
+
+// NewPlayer creates a player for reader.
+func NewPlayer(reader EventReader, mapper PixelMapper, config PlayerConfig) (*Player, error) {
+	if reader == nil || mapper == nil {
+		return nil, fmt.Errorf("player needs a reader and a pixel mapper")
+	}
+	if config.Speed < 0 {
+		return nil, fmt.Errorf("speed cannot be negative: %f", config.Speed)
+	}
+	if config.Gain == 0 {
+		config.Gain = 1.0
+	}
+	if config.SourceID == "" {
+		config.SourceID = "aer"
+	}
+	if config.Backpressure < BackpressureNone || config.Backpressure > BackpressureFail {
+		return nil, fmt.Errorf("unknown backpressure policy %d", config.Backpressure)
+	}
+	if config.HighWater < 0 || config.HighWater > 1 {
+		return nil, fmt.Errorf("high-water mark must be in [0, 1]: %f", config.HighWater)
+	}
+	if config.HighWater == 0 {
+		config.HighWater = PLAYER_DEFAULT_HIGH_WATER
+	}
+	if config.MaxLag < 0 {
+		return nil, fmt.Errorf("max lag cannot be negative: %v", config.MaxLag)
+	}
+	if config.MaxLag == 0 {
+		config.MaxLag = PLAYER_DEFAULT_MAX_LAG
+	}
+	return &Player{reader: reader, mapper: mapper, config: config, pending: make(map[string]*pendingSignal)}, nil
+}
+
+// Run replays events until the stream ends or ctx is cancelled. Signal
+// timestamps are the event times mapped onto the wall clock at replay start
+// (shifted by any throttling). With BackpressureFail, Run returns an error
+// wrapping ErrRateInfeasible once delivery lags more than MaxLag behind
+// schedule.
+func (p *Player) Run(ctx context.Context) error {
+	runStart := time.Now()
+	start := runStart
+	var first int64
+	started := false
+	defer p.flushPending(true)
+
+	for {
+		ev, err := p.reader.ReadEvent()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading events: %w", err)
+		}
+		if !started {
+			first, started = ev.Timestamp, true
+		}
+
+		offset := time.Duration(ev.Timestamp-first) * time.Microsecond
+		behind := false
+		if p.config.Speed > 0 {
+			due := start.Add(time.Duration(float64(offset) / p.config.Speed))
+			if wait := time.Until(due); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+					return ctx.Err()
+				}
+			}
+			lag := p.observeLag(due)
+			behind = lag > p.config.MaxLag
+			if behind && p.config.Backpressure == BackpressureFail {
+				return fmt.Errorf("event at %v is %v behind schedule: %w", offset, lag, ErrRateInfeasible)
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		p.mu.Lock()
+		p.events++
+		p.span = offset
+		p.elapsed = time.Since(runStart)
+		p.mu.Unlock()
+
+		target := p.mapper(ev)
+		if target == nil {
+			p.mu.Lock()
+			p.dropped++
+			p.mu.Unlock()
+			continue
+		}
+
+		switch p.config.Backpressure {
+		case BackpressureThrottle:
+			if p.congested(target) {
+				waited, err := p.throttle(ctx, target)
+				if err != nil {
+					return err
+				}
+				start = start.Add(waited)
+			}
+		case BackpressureCoalesce:
+			if _, merging := p.pending[target.ID()]; merging || behind || p.congested(target) {
+				p.coalesce(target, start.Add(offset))
+				continue
+			}
+			p.flushPending(false)
+		}
+		p.deliver(target, start.Add(offset))
+	}
+}
+
+// deliver sends one event to its mapped neuron.
+func (p *Player) deliver(target component.MessageReceiver, at time.Time) {
+	p.mu.Lock()
+	p.delivered++
+	p.mu.Unlock()
+
+	target.Receive(types.NeuralSignal{
+		Value:     p.config.Gain,
+		Timestamp: at,
+		SourceID:  p.config.SourceID,
+		TargetID:  target.ID(),
+	})
+}
+
+// GetStats returns replay counters for monitoring.
+func (p *Player) GetStats() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return map[string]interface{}{
+		"delivered": p.delivered,
+		"dropped":   p.dropped,
+		"events":    p.events,
+		"lag":       p.lag,
+		"max_lag":   p.maxLag,
+		"throttled": p.throttled,
+		"coalesced": p.coalesced,
+	}
+}
+
+// =================================================================================
+// OUTPUT: NEURON SPIKES → EVENTS
+// =================================================================================
+
+// Recorder emits an AER event for every spike of an assigned neuron.
+// Timestamps are microseconds since start.
+type Recorder struct {
+	writer    EventWriter
+	start     time.Time
+	addresses map[string]Event
+	written   int64
+	mu        sync.Mutex
+}
+
+// NewRecorder writes spike events to writer relative to start.
+func NewRecorder(writer EventWriter, start time.Time) *Recorder {
+	return &Recorder{writer: writer, start: start, addresses: make(map[string]Event)}
+}
+
+// Assign gives neuronID an output address.
+func (r *Recorder) Assign(neuronID string, x, y uint16, polarity bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.addresses[neuronID] = Event{X: x, Y: y, Polarity: polarity}
+}
+
+// AssignGrid lays out neuronIDs row-major on a grid of the given width,
+// all with ON polarity.
+func (r *Recorder) AssignGrid(neuronIDs []string, width int) {
+	for i, id := range neuronIDs {
+		r.Assign(id, uint16(i%width), uint16(i/width), true)
+	}
+}
+
+// Record writes the event for a spike of neuronID at time at. Spikes of
+// unassigned neurons are ignored.
+func (r *Recorder) Record(neuronID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ev, ok := r.addresses[neuronID]
+	if !ok {
+		return nil
+	}
+	ev.Timestamp = at.Sub(r.start).Microseconds()
+	if err := r.writer.WriteEvent(ev); err != nil {
+		return err
+	}
+	r.written++
+	return nil
+}
+
+// OutputCallback returns a callback recording spikes of neuronID. Attach it
+// with AddOutputCallback.
+func (r *Recorder) OutputCallback(neuronID string) types.OutputCallback {
+	return types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			at := msg.Timestamp
+			if at.IsZero() {
+				at = time.Now()
+			}
+			return r.Record(neuronID, at)
+		},
+		GetWeight:   func() float64 { return 1.0 },
+		GetDelay:    func() time.Duration { return 0 },
+		GetTargetID: func() string { return "aer_output" },
+	}
+}
+
+// Flush flushes the underlying writer.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.writer.Flush()
+}
+
+// GetStats returns output counters for monitoring.
+func (r *Recorder) GetStats() map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return map[string]interface{}{
+		"assigned": len(r.addresses),
+		"written":  r.written,
+	}
+}
@@ -0,0 +1,152 @@
+package aer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+// =================================================================================
+// UDP STREAMS (jAER AEUnicast FORMAT)
+// =================================================================================
+
+const (
+	// AER_UDP_MAX_EVENTS keeps datagrams under a typical 1500-byte MTU:
+	// 4-byte sequence number + 180 × 8-byte events = 1444 bytes.
+	AER_UDP_MAX_EVENTS = 180
+
+	udpSequenceSize = 4
+	udpEventSize    = 8
+)
+
+// UDPReader receives jAER AEUnicast datagrams: a big-endian sequence number
+// followed by big-endian (address, timestamp) pairs. Gaps in the sequence are
+// counted as lost datagrams.
+type UDPReader struct {
+	conn    net.PacketConn
+	format  AddressFormat
+	clock   timestampUnwrapper
+	buf     []byte
+	pending []Event
+
+	nextSeq  uint32
+	started  bool
+	lost     int64
+	received int64
+	mu       sync.Mutex
+}
+
+// NewUDPReader reads events from conn using format to decode addresses.
+func NewUDPReader(conn net.PacketConn, format AddressFormat) *UDPReader {
+	return &UDPReader{conn: conn, format: format, buf: make([]byte, 64*1024)}
+}
+
+// ReadEvent blocks until an event arrives. It returns the connection error
+// once conn is closed.
+func (u *UDPReader) ReadEvent() (Event, error) {
+	for len(u.pending) == 0 {
+		n, _, err := u.conn.ReadFrom(u.buf)
+		if err != nil {
+			return Event{}, err
+		}
+		u.decodeDatagram(u.buf[:n])
+	}
+	ev := u.pending[0]
+	u.pending = u.pending[1:]
+	return ev, nil
+}
+
+// decodeDatagram queues the valid events in one datagram.
+func (u *UDPReader) decodeDatagram(data []byte) {
+	if len(data) < udpSequenceSize {
+		return
+	}
+	seq := binary.BigEndian.Uint32(data[:udpSequenceSize])
+
+	u.mu.Lock()
+	if u.started && seq != u.nextSeq {
+		u.lost += int64(seq - u.nextSeq)
+	}
+	u.nextSeq, u.started = seq+1, true
+	u.received++
+	u.mu.Unlock()
+
+	for off := udpSequenceSize; off+udpEventSize <= len(data); off += udpEventSize {
+		address := binary.BigEndian.Uint32(data[off : off+4])
+		timestamp := u.clock.unwrap(binary.BigEndian.Uint32(data[off+4 : off+8]))
+		if ev, err := u.format.Decode(address, timestamp); err == nil {
+			u.pending = append(u.pending, ev)
+		}
+	}
+}
+
+// GetStats returns datagram counters for monitoring.
+func (u *UDPReader) GetStats() map[string]interface{} {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return map[string]interface{}{
+		"datagrams_received": u.received,
+		"datagrams_lost":     u.lost,
+	}
+}
+
+// UDPWriter batches events into AEUnicast datagrams.
+type UDPWriter struct {
+	w      io.Writer // Typically a connected *net.UDPConn
+	format AddressFormat
+	seq    uint32
+	buf    []byte
+	count  int
+	mu     sync.Mutex
+}
+
+// NewUDPWriter sends events to w, one datagram per AER_UDP_MAX_EVENTS events
+// or per Flush.
+func NewUDPWriter(w io.Writer, format AddressFormat) *UDPWriter {
+	return &UDPWriter{
+		w:      w,
+		format: format,
+		buf:    make([]byte, udpSequenceSize, udpSequenceSize+AER_UDP_MAX_EVENTS*udpEventSize),
+	}
+}
+
+// WriteEvent queues an event, sending a datagram when full.
+func (u *UDPWriter) WriteEvent(ev Event) error {
+	address, err := u.format.Encode(ev)
+	if err != nil {
+		return err
+	}
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.buf = binary.BigEndian.AppendUint32(u.buf, address)
+	u.buf = binary.BigEndian.AppendUint32(u.buf, uint32(ev.Timestamp))
+	u.count++
+	if u.count >= AER_UDP_MAX_EVENTS {
+		return u.flushLocked()
+	}
+	return nil
+}
+
+// Flush sends queued events immediately.
+func (u *UDPWriter) Flush() error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.flushLocked()
+}
+
+func (u *UDPWriter) flushLocked() error {
+	if u.count == 0 {
+		return nil
+	}
+	binary.BigEndian.PutUint32(u.buf[:udpSequenceSize], u.seq)
+	_, err := u.w.Write(u.buf)
+	u.seq++
+	u.buf = u.buf[:udpSequenceSize]
+	u.count = 0
+	if err != nil {
+		return fmt.Errorf("sending AER datagram: %w", err)
+	}
+	return nil
+}
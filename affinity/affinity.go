@@ -0,0 +1,274 @@
+/*
+=================================================================================
+AFFINITY - PINNING NEURON GROUPS TO OS THREADS AND NUMA NODES
+=================================================================================
+
+Every neuron runs its own goroutine, and the Go scheduler moves goroutines
+freely between OS threads and so between CPU sockets. On a multi-socket
+machine a spike from a neuron on one socket to a neuron on another crosses
+the interconnect on every channel send, and the message buffers of both
+sides bounce between the sockets' caches. Placing each population on one
+NUMA node keeps most traffic local: populations are typically densely
+connected inside and sparsely between.
+
+A Group is a set of CPUs. Goroutines started with Group.Go lock themselves
+to an OS thread (runtime.LockOSThread) and restrict that thread to the
+group's CPUs before running. A Pool keeps a fixed number of such pinned
+threads and runs submitted work on them. Groups are handed to the components
+that own goroutines:
+
+	nodes, _ := affinity.Nodes()
+	groups, _ := affinity.Spread([]string{"v1", "v2", "pfc"}) // round-robin over nodes
+	v1Pop.Pin(groups["v1"])                                     // network.Population, before Start
+	batchConfig.Affinity = groups["pfc"]                        // batch.Population stepping goroutine
+
+Each pinned goroutine holds an OS thread of its own, including while it is
+blocked, so pinning thousands of goroutine neurons costs thousands of
+threads (Go allows 10000 by default, see debug.SetMaxThreads). Large groups
+are better run as batch populations, whose single stepping goroutine is
+pinned.
+
+Thread affinity is set with sched_setaffinity on Linux, and NUMA nodes are
+read from /sys/devices/system/node. On other platforms Supported reports
+false: goroutines are still locked to threads of their own, but not
+restricted to CPUs, and Nodes reports one node with every CPU.
+=================================================================================
+*/
+
+package affinity
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// AFFINITY_MAX_CPUS is the largest CPU number + 1 a CPU set can contain.
+const AFFINITY_MAX_CPUS = 1024
+
+// CPUSet is a sorted list of CPU numbers.
+type CPUSet []int
+
+// ParseCPUList parses the kernel's list format ("0-3,8,10-11").
+func ParseCPUList(list string) (CPUSet, error) {
+	var cpus CPUSet
+	list = strings.TrimSpace(list)
+	if list == "" {
+		return cpus, nil
+	}
+	for _, part := range strings.Split(list, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		first, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU list %q: %w", list, err)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(to); err != nil {
+				return nil, fmt.Errorf("invalid CPU list %q: %w", list, err)
+			}
+		}
+		if first < 0 || last < first || last >= AFFINITY_MAX_CPUS {
+			return nil, fmt.Errorf("invalid CPU range %q", part)
+		}
+		for cpu := first; cpu <= last; cpu++ {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus.normalize(), nil
+}
+
+// normalize sorts the set and removes duplicates.
+func (c CPUSet) normalize() CPUSet {
+	sorted := append(CPUSet(nil), c...)
+	sort.Ints(sorted)
+	out := sorted[:0]
+	for i, cpu := range sorted {
+		if i == 0 || cpu != sorted[i-1] {
+			out = append(out, cpu)
+		}
+	}
+	return out
+}
+
+// String formats the set in the kernel's list format.
+func (c CPUSet) String() string {
+	var parts []string
+	for i := 0; i < len(c); {
+		j := i
+		for j+1 < len(c) && c[j+1] == c[j]+1 {
+			j++
+		}
+		if j == i {
+			parts = append(parts, strconv.Itoa(c[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", c[i], c[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// Node is a NUMA node and its CPUs.
+type Node struct {
+	ID   int
+	CPUs CPUSet
+}
+
+// Nodes returns the NUMA nodes with CPUs, sorted by ID.
+func Nodes() ([]Node, error) {
+	nodes, err := readNodes()
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		all := make(CPUSet, runtime.NumCPU())
+		for i := range all {
+			all[i] = i
+		}
+		nodes = []Node{{ID: 0, CPUs: all}}
+	}
+	return nodes, nil
+}
+
+// Supported reports whether threads can be restricted to CPUs on this
+// platform.
+func Supported() bool {
+	return affinitySupported
+}
+
+// Group is a named set of CPUs that goroutines are pinned to.
+type Group struct {
+	name string
+	cpus CPUSet
+
+	pinned  atomic.Int64 // Threads restricted so far
+	failed  atomic.Int64 // Threads that could not be restricted
+	running atomic.Int64 // Goroutines started by Go still running
+}
+
+// NewGroup creates a group for the given CPUs.
+func NewGroup(name string, cpus CPUSet) (*Group, error) {
+	cpus = cpus.normalize()
+	if len(cpus) == 0 {
+		return nil, fmt.Errorf("affinity group %s has no CPUs", name)
+	}
+	if cpus[0] < 0 || cpus[len(cpus)-1] >= AFFINITY_MAX_CPUS {
+		return nil, fmt.Errorf("affinity group %s: CPUs must be in [0, %d): %s", name, AFFINITY_MAX_CPUS, cpus)
+	}
+	return &Group{name: name, cpus: cpus}, nil
+}
+
+// NodeGroup creates a group for the CPUs of a NUMA node.
+func NodeGroup(name string, node int) (*Group, error) {
+	nodes, err := Nodes()
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.ID == node {
+			return NewGroup(name, n.CPUs)
+		}
+	}
+	return nil, fmt.Errorf("affinity group %s: no NUMA node %d", name, node)
+}
+
+// Spread assigns each name a group on one NUMA node, round-robin in the
+// order given, so consecutive populations land on different nodes.
+func Spread(names []string) (map[string]*Group, error) {
+	nodes, err := Nodes()
+	if err != nil {
+		return nil, err
+	}
+	groups := make(map[string]*Group, len(names))
+	for i, name := range names {
+		if _, dup := groups[name]; dup {
+			return nil, fmt.Errorf("affinity: %s assigned twice", name)
+		}
+		group, err := NewGroup(name, nodes[i%len(nodes)].CPUs)
+		if err != nil {
+			return nil, err
+		}
+		groups[name] = group
+	}
+	return groups, nil
+}
+
+// Name returns the group name.
+func (g *Group) Name() string {
+	return g.name
+}
+
+// CPUs returns the group's CPUs.
+func (g *Group) CPUs() CPUSet {
+	return append(CPUSet(nil), g.cpus...)
+}
+
+// Go runs fn on a new goroutine locked to an OS thread restricted to the
+// group's CPUs. If the thread cannot be restricted, fn runs on the locked
+// thread anyway (failures are counted where affinity is supported).
+func (g *Group) Go(fn func()) {
+	go func() {
+		// The goroutine never unlocks: a thread whose mask was changed must
+		// not return to the runtime's pool, and exiting while locked
+		// terminates it
+		runtime.LockOSThread()
+		if err := setThreadAffinity(g.cpus); err == nil {
+			g.pinned.Add(1)
+		} else if affinitySupported {
+			g.failed.Add(1)
+		}
+		g.running.Add(1)
+		defer g.running.Add(-1)
+		fn()
+	}()
+}
+
+// Pin locks the calling goroutine to its OS thread and restricts the
+// thread to the group's CPUs. release restores the thread's previous CPUs
+// and unlocks it.
+func (g *Group) Pin() (release func(), err error) {
+	runtime.LockOSThread()
+	previous, err := threadAffinity()
+	if err == nil {
+		err = setThreadAffinity(g.cpus)
+	}
+	if err != nil {
+		runtime.UnlockOSThread()
+		g.failed.Add(1)
+		return func() {}, fmt.Errorf("affinity group %s: %w", g.name, err)
+	}
+	g.pinned.Add(1)
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			if setThreadAffinity(previous) == nil {
+				runtime.UnlockOSThread()
+			}
+			// Otherwise the goroutine keeps the thread, so the restricted
+			// thread is discarded when it exits
+		})
+	}, nil
+}
+
+// GetStats returns the group's CPUs and goroutine counts.
+func (g *Group) GetStats() map[string]interface{} {
+	return map[string]interface{}{
+		"name":        g.name,
+		"cpus":        g.cpus.String(),
+		"pinned":      g.pinned.Load(),
+		"pin_failed":  g.failed.Load(),
+		"running":     g.running.Load(),
+		"restricting": affinitySupported,
+	}
+}
+
+// CurrentCPUs returns the CPUs the calling thread may run on. The result
+// only describes the calling goroutine when it is locked to its thread.
+func CurrentCPUs() (CPUSet, error) {
+	return threadAffinity()
+}
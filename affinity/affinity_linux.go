@@ -0,0 +1,79 @@
+//go:build linux
+
+package affinity
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// affinitySupported reports that sched_setaffinity restricts threads.
+const affinitySupported = true
+
+// cpuMask is the kernel's cpu_set_t for AFFINITY_MAX_CPUS CPUs.
+type cpuMask [AFFINITY_MAX_CPUS / 64]uint64
+
+// setThreadAffinity restricts the calling thread to cpus.
+func setThreadAffinity(cpus CPUSet) error {
+	var mask cpuMask
+	for _, cpu := range cpus {
+		mask[cpu/64] |= 1 << (cpu % 64)
+	}
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_SETAFFINITY, 0,
+		uintptr(len(mask)*8), uintptr(unsafe.Pointer(&mask[0])))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// threadAffinity returns the CPUs of the calling thread.
+func threadAffinity() (CPUSet, error) {
+	var mask cpuMask
+	_, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_GETAFFINITY, 0,
+		uintptr(len(mask)*8), uintptr(unsafe.Pointer(&mask[0])))
+	if errno != 0 {
+		return nil, errno
+	}
+	var cpus CPUSet
+	for cpu := 0; cpu < AFFINITY_MAX_CPUS; cpu++ {
+		if mask[cpu/64]&(1<<(cpu%64)) != 0 {
+			cpus = append(cpus, cpu)
+		}
+	}
+	return cpus, nil
+}
+
+// readNodes lists the NUMA nodes with CPUs from sysfs (none if sysfs has no
+// node information).
+func readNodes() ([]Node, error) {
+	paths, err := filepath.Glob("/sys/devices/system/node/node[0-9]*/cpulist")
+	if err != nil {
+		return nil, err
+	}
+	var nodes []Node
+	for _, path := range paths {
+		id, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(filepath.Dir(path)), "node"))
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		cpus, err := ParseCPUList(string(data))
+		if err != nil {
+			return nil, err
+		}
+		if len(cpus) > 0 {
+			nodes = append(nodes, Node{ID: id, CPUs: cpus})
+		}
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	return nodes, nil
+}
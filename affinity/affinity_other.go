@@ -0,0 +1,26 @@
+//go:build !linux
+
+package affinity
+
+import "errors"
+
+// affinitySupported reports that threads cannot be restricted to CPUs here.
+const affinitySupported = false
+
+// errUnsupported is returned where thread affinity is unavailable.
+var errUnsupported = errors.New("thread affinity is not supported on this platform")
+
+// setThreadAffinity is unavailable on this platform.
+func setThreadAffinity(cpus CPUSet) error {
+	return errUnsupported
+}
+
+// threadAffinity is unavailable on this platform.
+func threadAffinity() (CPUSet, error) {
+	return nil, errUnsupported
+}
+
+// readNodes reports no node information, so Nodes falls back to one node.
+func readNodes() ([]Node, error) {
+	return nil, nil
+}
@@ -0,0 +1,104 @@
+package affinity
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// TestCPUListAndNodes verifies parsing and formatting of CPU lists and the
+// node listing.
+func TestCPUListAndNodes(t *testing.T) {
+	cpus, err := ParseCPUList("8,0-3, 2,10-11\n")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cpus.String() != "0-3,8,10-11" || len(cpus) != 7 {
+		t.Errorf("Expected 0-3,8,10-11, got %s (%d CPUs)", cpus, len(cpus))
+	}
+	for _, bad := range []string{"a", "3-1", "-2", "0-5000"} {
+		if _, err := ParseCPUList(bad); err == nil {
+			t.Errorf("Expected %q to be rejected", bad)
+		}
+	}
+
+	nodes, err := Nodes()
+	if err != nil || len(nodes) == 0 || len(nodes[0].CPUs) == 0 {
+		t.Fatalf("Expected at least one node with CPUs, got %v (%v)", nodes, err)
+	}
+	groups, err := Spread([]string{"a", "b"})
+	if err != nil || groups["a"].CPUs().String() != nodes[0].CPUs.String() {
+		t.Fatalf("Expected the first group on the first node, got %v (%v)", groups, err)
+	}
+	if _, err := Spread([]string{"a", "a"}); err == nil {
+		t.Error("Expected error for a name assigned twice")
+	}
+	if _, err := NewGroup("empty", nil); err == nil {
+		t.Error("Expected error for a group without CPUs")
+	}
+}
+
+// TestGroupPinsGoroutines verifies that goroutines started by a group and
+// pool workers run on threads restricted to the group's CPUs.
+func TestGroupPinsGoroutines(t *testing.T) {
+	if !Supported() {
+		t.Skip("thread affinity not supported on this platform")
+	}
+	release, err := (&Group{name: "probe", cpus: CPUSet{0}}).Pin()
+	if err != nil {
+		t.Skipf("cannot restrict threads here: %v", err)
+	}
+	release()
+
+	allowed, err := CurrentCPUs()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	group, _ := NewGroup("first", allowed[:1])
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	var seen CPUSet
+	group.Go(func() {
+		defer wg.Done()
+		seen, _ = CurrentCPUs()
+	})
+	wg.Wait()
+	if seen.String() != group.CPUs().String() {
+		t.Errorf("Expected the goroutine restricted to %s, got %s", group.CPUs(), seen)
+	}
+
+	pool, err := NewPool(group, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var mismatched, done atomic.Int64
+	for i := 0; i < 50; i++ {
+		pool.Submit(func() {
+			if cpus, _ := CurrentCPUs(); cpus.String() != group.CPUs().String() {
+				mismatched.Add(1)
+			}
+			done.Add(1)
+		})
+	}
+	pool.Close()
+	if done.Load() != 50 || mismatched.Load() != 0 {
+		t.Errorf("Expected 50 tasks on pinned workers, got %d (%d unpinned)", done.Load(), mismatched.Load())
+	}
+	if err := pool.Submit(func() {}); err == nil {
+		t.Error("Expected error submitting to a closed pool")
+	}
+	if stats := group.GetStats(); stats["pinned"].(int64) != 3 || stats["pin_failed"].(int64) != 0 {
+		t.Errorf("Unexpected stats %v", stats)
+	}
+
+	// Pin restores the thread's previous CPUs on release
+	release, err = group.Pin()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	release()
+	if cpus, _ := CurrentCPUs(); cpus.String() != allowed.String() {
+		t.Errorf("Expected %s restored, got %s", allowed, cpus)
+	}
+}
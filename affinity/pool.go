@@ -0,0 +1,85 @@
+package affinity
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// =================================================================================
+// PINNED WORKER POOLS
+// =================================================================================
+//
+// Short tasks, such as the steps of several batch populations or the
+// per-shard work of a partitioned simulation, do not need a thread each. A
+// Pool runs them on a fixed number of pinned threads, so work for one NUMA
+// node stays on that node however many tasks are submitted.
+
+// AFFINITY_POOL_QUEUE_PER_WORKER sizes a pool's task queue.
+const AFFINITY_POOL_QUEUE_PER_WORKER = 64
+
+// Pool runs tasks on a fixed set of goroutines pinned to a group.
+type Pool struct {
+	group *Group
+	tasks chan func()
+
+	mu     sync.RWMutex
+	closed bool
+	wg     sync.WaitGroup
+
+	completed atomic.Int64
+}
+
+// NewPool starts workers pinned goroutines on group.
+func NewPool(group *Group, workers int) (*Pool, error) {
+	if group == nil {
+		return nil, fmt.Errorf("affinity pool needs a group")
+	}
+	if workers <= 0 {
+		return nil, fmt.Errorf("affinity pool needs workers: %d", workers)
+	}
+	p := &Pool{group: group, tasks: make(chan func(), workers*AFFINITY_POOL_QUEUE_PER_WORKER)}
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		group.Go(func() {
+			defer p.wg.Done()
+			for task := range p.tasks {
+				task()
+				p.completed.Add(1)
+			}
+		})
+	}
+	return p, nil
+}
+
+// Submit queues task, blocking while the queue is full.
+func (p *Pool) Submit(task func()) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		return fmt.Errorf("affinity pool %s is closed", p.group.name)
+	}
+	p.tasks <- task
+	return nil
+}
+
+// Close runs the queued tasks and stops the workers.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.tasks)
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// GetStats returns the group statistics and the tasks completed.
+func (p *Pool) GetStats() map[string]interface{} {
+	stats := p.group.GetStats()
+	stats["completed"] = p.completed.Load()
+	stats["queued"] = len(p.tasks)
+	return stats
+}
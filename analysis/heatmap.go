@@ -0,0 +1,327 @@
+/*
+=================================================================================
+ACTIVITY HEATMAPS - REGION × TIME SPIKE COUNTS
+=================================================================================
+
+A heatmap of regions (rows) against time bins (columns) is the quickest way to
+see how activity moves through a network over a long run: which layer lights
+up first, which region goes silent, where a seizure-like burst starts.
+
+ActivityHeatmap builds that matrix incrementally. Spikes are added one at a
+time with RecordSpike and only the per-region count of each bin is kept, so
+memory is bounded by regions × MaxBins no matter how long the run or how many
+spikes it produces. When the matrix is full it either:
+
+  - slides: the oldest bin is dropped and the heatmap covers the most recent
+    MaxBins × BinWidth, or
+  - compacts: adjacent bins are merged and the bin width doubles, so the
+    heatmap always covers the whole run at decreasing resolution.
+
+USAGE:
+
+	heatmap, _ := analysis.NewActivityHeatmap(analysis.HeatmapConfig{BinWidth: time.Second, Compact: true})
+	heatmap.AssignRegion("v1", v1NeuronIDs...)
+	heatmap.AssignRegion("v2", v2NeuronIDs...)
+	matrix.ListenForSignals(...) // call heatmap.RecordSpike(neuronID, at) per spike
+	...
+	snapshot := heatmap.Snapshot()
+	rates := snapshot.Rates() // Hz per neuron, regions × bins
+	err := snapshot.WriteCSV(file)
+=================================================================================
+*/
+
+package analysis
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ACTIVITY_HEATMAP_DEFAULT_MAX_BINS bounds the time bins kept when
+// HeatmapConfig.MaxBins is zero: an hour at one-second bins.
+const ACTIVITY_HEATMAP_DEFAULT_MAX_BINS = 3600
+
+// HeatmapConfig configures an ActivityHeatmap.
+type HeatmapConfig struct {
+	BinWidth time.Duration // Width of one time bin (initial width when compacting)
+	MaxBins  int           // Bins kept (0 = ACTIVITY_HEATMAP_DEFAULT_MAX_BINS)
+	Compact  bool          // Merge bins instead of dropping the oldest when full
+	Start    time.Time     // Start of the first bin (zero = time of the first spike)
+}
+
+// ActivityHeatmap accumulates spike counts per region and time bin. It is
+// safe for concurrent use.
+type ActivityHeatmap struct {
+	mu       sync.Mutex
+	config   HeatmapConfig
+	binWidth time.Duration
+	start    time.Time // Start of bin 0
+	started  bool
+
+	regions       []string         // Regions in assignment order
+	regionIndex   map[string]int   // Region -> row
+	regionSize    []int            // Neurons per region
+	neuronRegions map[string][]int // Neuron ID -> rows
+
+	bins  [][]float64 // Retained bins, oldest first; each holds one count per region
+	first int64       // Absolute index of bins[0]
+
+	recorded   int64 // Spikes counted in a bin
+	unassigned int64 // Spikes of neurons without a region
+	dropped    int64 // Spikes before the retained window
+}
+
+// NewActivityHeatmap creates an empty heatmap.
+func NewActivityHeatmap(config HeatmapConfig) (*ActivityHeatmap, error) {
+	if config.BinWidth <= 0 {
+		return nil, fmt.Errorf("heatmap bin width must be positive: %v", config.BinWidth)
+	}
+	if config.MaxBins < 0 {
+		return nil, fmt.Errorf("heatmap max bins cannot be negative: %d", config.MaxBins)
+	}
+	if config.MaxBins == 0 {
+		config.MaxBins = ACTIVITY_HEATMAP_DEFAULT_MAX_BINS
+	}
+	if config.Compact && config.MaxBins < 2 {
+		return nil, fmt.Errorf("compacting heatmap needs at least two bins: %d", config.MaxBins)
+	}
+	return &ActivityHeatmap{
+		config:        config,
+		binWidth:      config.BinWidth,
+		start:         config.Start,
+		started:       !config.Start.IsZero(),
+		regionIndex:   make(map[string]int),
+		neuronRegions: make(map[string][]int),
+	}, nil
+}
+
+// AssignRegion adds neurons to a named region, creating the region on first
+// use. A neuron may belong to several regions. Spikes recorded before the
+// assignment are not attributed retroactively.
+func (h *ActivityHeatmap) AssignRegion(region string, neuronIDs ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	row, ok := h.regionIndex[region]
+	if !ok {
+		row = len(h.regions)
+		h.regionIndex[region] = row
+		h.regions = append(h.regions, region)
+		h.regionSize = append(h.regionSize, 0)
+		for i := range h.bins {
+			h.bins[i] = append(h.bins[i], 0)
+		}
+	}
+	for _, id := range neuronIDs {
+		member := false
+		for _, r := range h.neuronRegions[id] {
+			member = member || r == row
+		}
+		if !member {
+			h.neuronRegions[id] = append(h.neuronRegions[id], row)
+			h.regionSize[row]++
+		}
+	}
+}
+
+// RecordSpike counts a spike of neuronID at time at.
+func (h *ActivityHeatmap) RecordSpike(neuronID string, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	rows := h.neuronRegions[neuronID]
+	if len(rows) == 0 {
+		h.unassigned++
+		return
+	}
+	bin, ok := h.binUnsafe(at)
+	if !ok {
+		h.dropped++
+		return
+	}
+	for _, row := range rows {
+		bin[row]++
+	}
+	h.recorded++
+}
+
+// Advance extends the heatmap with empty bins up to now, so silent periods
+// show up and a sliding window moves on without new spikes.
+func (h *ActivityHeatmap) Advance(now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.binUnsafe(now)
+}
+
+// binUnsafe returns the bin containing at, creating bins up to it and
+// sliding or compacting as needed. It reports false for times before the
+// retained window.
+func (h *ActivityHeatmap) binUnsafe(at time.Time) ([]float64, bool) {
+	if !h.started {
+		h.start, h.started = at, true
+	}
+	offset := at.Sub(h.start)
+	if offset < 0 {
+		return nil, false
+	}
+	index := int64(offset / h.binWidth)
+	for index >= h.first+int64(len(h.bins)) {
+		if len(h.bins) < h.config.MaxBins {
+			h.bins = append(h.bins, make([]float64, len(h.regions)))
+			continue
+		}
+		if h.config.Compact {
+			h.compactUnsafe()
+			index = int64(offset / h.binWidth)
+			continue
+		}
+		// Jump over a long silence instead of sliding bin by bin
+		if gap := index - (h.first + int64(len(h.bins))); gap >= int64(len(h.bins)) {
+			h.first += gap
+			h.bins = h.bins[:0]
+			continue
+		}
+		h.bins = append(h.bins[1:], make([]float64, len(h.regions)))
+		h.first++
+	}
+	if index < h.first {
+		return nil, false
+	}
+	return h.bins[index-h.first], true
+}
+
+// compactUnsafe merges pairs of bins and doubles the bin width. Compacting
+// heatmaps never slide, so bin 0 always starts at h.start.
+func (h *ActivityHeatmap) compactUnsafe() {
+	merged := h.bins[:0]
+	for i := 0; i < len(h.bins); i += 2 {
+		bin := h.bins[i]
+		if i+1 < len(h.bins) {
+			for row, count := range h.bins[i+1] {
+				bin[row] += count
+			}
+		}
+		merged = append(merged, bin)
+	}
+	h.bins = merged
+	h.binWidth *= 2
+}
+
+// BinWidth returns the current bin width, which grows when compacting.
+func (h *ActivityHeatmap) BinWidth() time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.binWidth
+}
+
+// Regions returns the region names in assignment order.
+func (h *ActivityHeatmap) Regions() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.regions...)
+}
+
+// Snapshot copies the current matrix.
+func (h *ActivityHeatmap) Snapshot() *Heatmap {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	snapshot := &Heatmap{
+		Regions:  append([]string(nil), h.regions...),
+		Sizes:    append([]int(nil), h.regionSize...),
+		Start:    h.start.Add(time.Duration(h.first) * h.binWidth),
+		BinWidth: h.binWidth,
+		Counts:   make([][]float64, len(h.regions)),
+	}
+	for row := range snapshot.Counts {
+		counts := make([]float64, len(h.bins))
+		for i, bin := range h.bins {
+			counts[i] = bin[row]
+		}
+		snapshot.Counts[row] = counts
+	}
+	return snapshot
+}
+
+// GetStats returns spike bookkeeping counters.
+func (h *ActivityHeatmap) GetStats() map[string]interface{} {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return map[string]interface{}{
+		"regions":    len(h.regions),
+		"bins":       len(h.bins),
+		"bin_width":  h.binWidth,
+		"recorded":   h.recorded,
+		"unassigned": h.unassigned,
+		"dropped":    h.dropped,
+	}
+}
+
+// =================================================================================
+// HEATMAP SNAPSHOTS
+// =================================================================================
+
+// Heatmap is a regions × bins matrix of spike counts. Counts[r][b] is the
+// number of spikes of region r in [Start + b·BinWidth, Start + (b+1)·BinWidth).
+type Heatmap struct {
+	Regions  []string
+	Sizes    []int // Neurons per region
+	Start    time.Time
+	BinWidth time.Duration
+	Counts   [][]float64
+}
+
+// Bins returns the number of time bins.
+func (m *Heatmap) Bins() int {
+	if len(m.Counts) == 0 {
+		return 0
+	}
+	return len(m.Counts[0])
+}
+
+// Rates returns the mean firing rate per neuron in Hz for every region and
+// bin. Regions without neurons have rate 0.
+func (m *Heatmap) Rates() [][]float64 {
+	rates := make([][]float64, len(m.Counts))
+	seconds := m.BinWidth.Seconds()
+	for r, counts := range m.Counts {
+		rates[r] = make([]float64, len(counts))
+		if m.Sizes[r] == 0 || seconds == 0 {
+			continue
+		}
+		for b, count := range counts {
+			rates[r][b] = count / (float64(m.Sizes[r]) * seconds)
+		}
+	}
+	return rates
+}
+
+// WriteCSV writes the firing rates with one region per row. The header row
+// holds each bin's start in seconds from Start.
+func (m *Heatmap) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := make([]string, m.Bins()+1)
+	header[0] = "region"
+	for b := 1; b < len(header); b++ {
+		header[b] = strconv.FormatFloat((time.Duration(b-1) * m.BinWidth).Seconds(), 'g', 6, 64)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for r, rates := range m.Rates() {
+		row := make([]string, len(rates)+1)
+		row[0] = m.Regions[r]
+		for b, rate := range rates {
+			row[b+1] = strconv.FormatFloat(rate, 'g', 6, 64)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
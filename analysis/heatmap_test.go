@@ -0,0 +1,95 @@
+package analysis
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestActivityHeatmapRegions verifies spikes are binned per region and
+// converted to per-neuron rates.
+func TestActivityHeatmapRegions(t *testing.T) {
+	start := time.Now()
+	heatmap, err := NewActivityHeatmap(HeatmapConfig{BinWidth: 100 * time.Millisecond, Start: start})
+	if err != nil {
+		t.Fatalf("Failed to create heatmap: %v", err)
+	}
+	heatmap.AssignRegion("v1", "a", "b")
+	heatmap.AssignRegion("v2", "c")
+
+	// v1 is active in the first bin, v2 in the third
+	heatmap.RecordSpike("a", start.Add(10*time.Millisecond))
+	heatmap.RecordSpike("b", start.Add(20*time.Millisecond))
+	heatmap.RecordSpike("c", start.Add(250*time.Millisecond))
+	heatmap.RecordSpike("unknown", start.Add(30*time.Millisecond))
+	heatmap.Advance(start.Add(450 * time.Millisecond))
+
+	snapshot := heatmap.Snapshot()
+	if snapshot.Bins() != 5 {
+		t.Fatalf("Expected 5 bins up to the advance time, got %d", snapshot.Bins())
+	}
+	rates := snapshot.Rates()
+	if rates[0][0] != 10 || rates[0][2] != 0 {
+		t.Errorf("Expected v1 at 10 Hz in bin 0 only, got %v", rates[0])
+	}
+	if rates[1][2] != 10 || rates[1][0] != 0 {
+		t.Errorf("Expected v2 at 10 Hz in bin 2 only, got %v", rates[1])
+	}
+	if stats := heatmap.GetStats(); stats["unassigned"].(int64) != 1 {
+		t.Errorf("Expected one unassigned spike, got %v", stats["unassigned"])
+	}
+
+	var buf bytes.Buffer
+	if err := snapshot.WriteCSV(&buf); err != nil {
+		t.Fatalf("CSV export failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "v1,10,") {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+}
+
+// TestActivityHeatmapBoundedMemory verifies long runs keep at most MaxBins,
+// either sliding the window or compacting the bins.
+func TestActivityHeatmapBoundedMemory(t *testing.T) {
+	start := time.Now()
+	sliding, _ := NewActivityHeatmap(HeatmapConfig{BinWidth: time.Second, MaxBins: 10, Start: start})
+	compact, _ := NewActivityHeatmap(HeatmapConfig{BinWidth: time.Second, MaxBins: 10, Compact: true, Start: start})
+	for _, h := range []*ActivityHeatmap{sliding, compact} {
+		h.AssignRegion("all", "n")
+	}
+
+	// One spike per second for an hour
+	for s := 0; s < 3600; s++ {
+		at := start.Add(time.Duration(s)*time.Second + time.Millisecond)
+		sliding.RecordSpike("n", at)
+		compact.RecordSpike("n", at)
+	}
+
+	window := sliding.Snapshot()
+	if window.Bins() != 10 || !window.Start.Equal(start.Add(3590*time.Second)) {
+		t.Errorf("Expected the last 10 seconds, got %d bins from %v", window.Bins(), window.Start.Sub(start))
+	}
+	for b, count := range window.Counts[0] {
+		if count != 1 {
+			t.Errorf("Expected one spike in sliding bin %d, got %v", b, count)
+		}
+	}
+	sliding.RecordSpike("n", start)
+	if sliding.GetStats()["dropped"].(int64) != 1 {
+		t.Error("Expected a spike before the window to be dropped")
+	}
+
+	whole := compact.Snapshot()
+	total := 0.0
+	for _, count := range whole.Counts[0] {
+		total += count
+	}
+	if whole.Bins() > 10 || total != 3600 || !whole.Start.Equal(start) {
+		t.Errorf("Expected all 3600 spikes in at most 10 bins from the start, got %v in %d bins", total, whole.Bins())
+	}
+	if whole.BinWidth != 512*time.Second {
+		t.Errorf("Expected bins to double up to 512s, got %v", whole.BinWidth)
+	}
+}
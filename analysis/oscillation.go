@@ -0,0 +1,295 @@
+/*
+=================================================================================
+OSCILLATION AND RHYTHM ANALYSIS
+=================================================================================
+
+E/I circuits generate population rhythms: theta (4-8 Hz) from slow inhibitory
+loops, gamma (30-80 Hz) from fast-spiking interneuron networks (PING/ING).
+These tools quantify such rhythms from spike times:
+
+  - BinSpikes: population activity as spike counts per time bin
+  - PowerSpectrum: Hann-windowed FFT power of the binned activity
+  - Spectrum.PeakFrequency / BandPower: dominant rhythm and band strength
+  - InstantaneousPhase: band-limited phase via an FFT Hilbert transform
+  - SpikePhaseLocking: phase-locking value (PLV) of spikes to a rhythm
+  - PhaseSynchrony: PLV between two populations' rhythms
+
+All functions are pure and operate on float64 slices so they can be applied to
+recordings from goroutine neurons, batch populations or external data.
+=================================================================================
+*/
+
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"time"
+)
+
+// Classic frequency bands (Hz)
+const (
+	THETA_BAND_LOW  = 4.0
+	THETA_BAND_HIGH = 8.0
+	ALPHA_BAND_LOW  = 8.0
+	ALPHA_BAND_HIGH = 12.0
+	BETA_BAND_LOW   = 13.0
+	BETA_BAND_HIGH  = 30.0
+	GAMMA_BAND_LOW  = 30.0
+	GAMMA_BAND_HIGH = 80.0
+)
+
+// BinSpikes counts spikes in consecutive bins of binWidth starting at start.
+// Spikes outside [start, start+duration) are ignored.
+func BinSpikes(spikes []time.Time, start time.Time, duration, binWidth time.Duration) ([]float64, error) {
+	if binWidth <= 0 || duration <= 0 {
+		return nil, fmt.Errorf("bin width and duration must be positive")
+	}
+	bins := make([]float64, int(duration/binWidth))
+	for _, t := range spikes {
+		offset := t.Sub(start)
+		if offset < 0 {
+			continue
+		}
+		idx := int(offset / binWidth)
+		if idx < len(bins) {
+			bins[idx]++
+		}
+	}
+	return bins, nil
+}
+
+// SampleRate returns the sampling rate (Hz) of signals binned at binWidth.
+func SampleRate(binWidth time.Duration) float64 {
+	return float64(time.Second) / float64(binWidth)
+}
+
+// =================================================================================
+// POWER SPECTRUM
+// =================================================================================
+
+// Spectrum holds one-sided spectral power.
+type Spectrum struct {
+	Frequencies []float64 // Hz
+	Power       []float64 // Power at each frequency
+	Resolution  float64   // Frequency spacing (Hz)
+}
+
+// PowerSpectrum computes the one-sided power spectrum of signal sampled at
+// sampleRate (Hz). The mean is removed and a Hann window applied; the signal
+// is zero-padded to the next power of two.
+func PowerSpectrum(signal []float64, sampleRate float64) (Spectrum, error) {
+	if len(signal) < 2 {
+		return Spectrum{}, fmt.Errorf("signal needs at least 2 samples, got %d", len(signal))
+	}
+	if sampleRate <= 0 {
+		return Spectrum{}, fmt.Errorf("sample rate must be positive: %f", sampleRate)
+	}
+
+	mean := 0.0
+	for _, v := range signal {
+		mean += v
+	}
+	mean /= float64(len(signal))
+
+	n := nextPowerOfTwo(len(signal))
+	data := make([]complex128, n)
+	for i, v := range signal {
+		window := 0.5 * (1 - math.Cos(2*math.Pi*float64(i)/float64(len(signal)-1)))
+		data[i] = complex((v-mean)*window, 0)
+	}
+	fft(data, false)
+
+	half := n/2 + 1
+	spectrum := Spectrum{
+		Frequencies: make([]float64, half),
+		Power:       make([]float64, half),
+		Resolution:  sampleRate / float64(n),
+	}
+	for k := 0; k < half; k++ {
+		spectrum.Frequencies[k] = float64(k) * spectrum.Resolution
+		magnitude := cmplx.Abs(data[k])
+		spectrum.Power[k] = magnitude * magnitude / float64(n)
+	}
+	return spectrum, nil
+}
+
+// PeakFrequency returns the frequency with the most power within [low, high] Hz.
+func (s Spectrum) PeakFrequency(low, high float64) (frequency, power float64) {
+	for k, f := range s.Frequencies {
+		if f < low || f > high {
+			continue
+		}
+		if s.Power[k] > power {
+			frequency, power = f, s.Power[k]
+		}
+	}
+	return frequency, power
+}
+
+// BandPower sums power within [low, high] Hz.
+func (s Spectrum) BandPower(low, high float64) float64 {
+	total := 0.0
+	for k, f := range s.Frequencies {
+		if f >= low && f <= high {
+			total += s.Power[k]
+		}
+	}
+	return total
+}
+
+// RelativeBandPower returns band power as a fraction of total (non-DC) power.
+func (s Spectrum) RelativeBandPower(low, high float64) float64 {
+	total := 0.0
+	for k := 1; k < len(s.Power); k++ {
+		total += s.Power[k]
+	}
+	if total == 0 {
+		return 0
+	}
+	return s.BandPower(low, high) / total
+}
+
+// =================================================================================
+// PHASE ANALYSIS
+// =================================================================================
+
+// InstantaneousPhase band-pass filters signal to [low, high] Hz and returns
+// the phase (radians, -π..π) of its analytic signal at every sample.
+func InstantaneousPhase(signal []float64, sampleRate, low, high float64) ([]float64, error) {
+	if len(signal) < 2 {
+		return nil, fmt.Errorf("signal needs at least 2 samples, got %d", len(signal))
+	}
+	if low < 0 || high <= low || high > sampleRate/2 {
+		return nil, fmt.Errorf("invalid band [%f, %f] Hz for sample rate %f", low, high, sampleRate)
+	}
+
+	n := nextPowerOfTwo(len(signal))
+	data := make([]complex128, n)
+	for i, v := range signal {
+		data[i] = complex(v, 0)
+	}
+	fft(data, false)
+
+	// Analytic signal restricted to the band: keep positive in-band
+	// frequencies (doubled), drop negative frequencies and everything else.
+	resolution := sampleRate / float64(n)
+	for k := range data {
+		f := float64(k) * resolution
+		if k > 0 && k < n/2 && f >= low && f <= high {
+			data[k] *= 2
+		} else {
+			data[k] = 0
+		}
+	}
+	fft(data, true)
+
+	phases := make([]float64, len(signal))
+	for i := range phases {
+		phases[i] = cmplx.Phase(data[i])
+	}
+	return phases, nil
+}
+
+// SpikePhaseLocking measures how consistently spikes occur at the same phase
+// of a rhythm. phases is the instantaneous phase of a reference signal binned
+// at binWidth from start (see InstantaneousPhase).
+//
+// Returns:
+//
+//	plv: phase-locking value, 0 (no locking) to 1 (perfect locking)
+//	meanPhase: preferred firing phase in radians
+func SpikePhaseLocking(spikes []time.Time, phases []float64, start time.Time, binWidth time.Duration) (plv, meanPhase float64) {
+	var sum complex128
+	count := 0
+	for _, t := range spikes {
+		offset := t.Sub(start)
+		if offset < 0 {
+			continue
+		}
+		idx := int(offset / binWidth)
+		if idx >= len(phases) {
+			continue
+		}
+		sum += cmplx.Rect(1, phases[idx])
+		count++
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	mean := sum / complex(float64(count), 0)
+	return cmplx.Abs(mean), cmplx.Phase(mean)
+}
+
+// PhaseSynchrony returns the phase-locking value between two phase series,
+// e.g. the gamma phase of two populations: 1 = constant phase lag.
+func PhaseSynchrony(phasesA, phasesB []float64) float64 {
+	n := len(phasesA)
+	if len(phasesB) < n {
+		n = len(phasesB)
+	}
+	if n == 0 {
+		return 0
+	}
+	var sum complex128
+	for i := 0; i < n; i++ {
+		sum += cmplx.Rect(1, phasesA[i]-phasesB[i])
+	}
+	return cmplx.Abs(sum / complex(float64(n), 0))
+}
+
+// =================================================================================
+// FFT
+// =================================================================================
+
+// nextPowerOfTwo returns the smallest power of two >= n.
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// fft performs an in-place iterative radix-2 FFT. len(data) must be a power
+// of two. The inverse transform is scaled by 1/n.
+func fft(data []complex128, inverse bool) {
+	n := len(data)
+
+	// Bit-reversal permutation
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+
+	sign := -1.0
+	if inverse {
+		sign = 1.0
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Rect(1, sign*2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				even := data[start+k]
+				odd := data[start+k+size/2] * w
+				data[start+k] = even + odd
+				data[start+k+size/2] = even - odd
+				w *= step
+			}
+		}
+	}
+
+	if inverse {
+		for i := range data {
+			data[i] /= complex(float64(n), 0)
+		}
+	}
+}
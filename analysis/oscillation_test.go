@@ -0,0 +1,91 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// rhythmicSpikes generates population spikes bursting at the given frequency,
+// always at the same phase of the cycle.
+func rhythmicSpikes(start time.Time, duration time.Duration, frequency float64, perCycle int) []time.Time {
+	period := time.Duration(float64(time.Second) / frequency)
+	var spikes []time.Time
+	for cycle := time.Duration(0); cycle < duration; cycle += period {
+		for i := 0; i < perCycle; i++ {
+			spikes = append(spikes, start.Add(cycle+period/4+time.Duration(i)*time.Millisecond/2))
+		}
+	}
+	return spikes
+}
+
+// TestPowerSpectrumFindsGamma verifies a 40 Hz population rhythm is detected.
+func TestPowerSpectrumFindsGamma(t *testing.T) {
+	start := time.Now()
+	duration := 2 * time.Second
+	binWidth := time.Millisecond
+	spikes := rhythmicSpikes(start, duration, 40, 5)
+
+	bins, err := BinSpikes(spikes, start, duration, binWidth)
+	if err != nil {
+		t.Fatalf("Binning failed: %v", err)
+	}
+	spectrum, err := PowerSpectrum(bins, SampleRate(binWidth))
+	if err != nil {
+		t.Fatalf("Spectrum failed: %v", err)
+	}
+
+	peak, _ := spectrum.PeakFrequency(1, 200)
+	if math.Abs(peak-40) > 2*spectrum.Resolution {
+		t.Errorf("Expected peak near 40 Hz, got %.2f Hz", peak)
+	}
+	if spectrum.BandPower(GAMMA_BAND_LOW, GAMMA_BAND_HIGH) <= spectrum.BandPower(THETA_BAND_LOW, THETA_BAND_HIGH) {
+		t.Error("Expected gamma band to dominate theta band")
+	}
+	if rel := spectrum.RelativeBandPower(GAMMA_BAND_LOW, GAMMA_BAND_HIGH); rel <= 0 || rel > 1 {
+		t.Errorf("Relative band power out of range: %f", rel)
+	}
+}
+
+// TestSpikePhaseLocking verifies locked spikes score high and random spikes low.
+func TestSpikePhaseLocking(t *testing.T) {
+	start := time.Now()
+	duration := 2 * time.Second
+	binWidth := time.Millisecond
+	sampleRate := SampleRate(binWidth)
+
+	// Reference theta rhythm (6 Hz sinusoid)
+	reference := make([]float64, int(duration/binWidth))
+	for i := range reference {
+		reference[i] = math.Sin(2 * math.Pi * 6 * float64(i) / sampleRate)
+	}
+	phases, err := InstantaneousPhase(reference, sampleRate, THETA_BAND_LOW, THETA_BAND_HIGH)
+	if err != nil {
+		t.Fatalf("Phase extraction failed: %v", err)
+	}
+
+	locked := rhythmicSpikes(start.Add(200*time.Millisecond), 1500*time.Millisecond, 6, 1)
+	plv, _ := SpikePhaseLocking(locked, phases, start, binWidth)
+	if plv < 0.8 {
+		t.Errorf("Expected strong phase locking, PLV %.2f", plv)
+	}
+
+	// Spikes spread evenly across one cycle have no preferred phase
+	var spread []time.Time
+	period := time.Second / 6
+	for i := 0; i < 60; i++ {
+		spread = append(spread, start.Add(500*time.Millisecond+time.Duration(i)*period/60))
+	}
+	if plv, _ := SpikePhaseLocking(spread, phases, start, binWidth); plv > 0.2 {
+		t.Errorf("Expected weak phase locking for uniform spikes, PLV %.2f", plv)
+	}
+
+	// A phase-shifted copy of the rhythm is perfectly synchronous
+	shifted := make([]float64, len(phases))
+	for i := range phases {
+		shifted[i] = phases[i] + 1.0
+	}
+	if sync := PhaseSynchrony(phases, shifted); math.Abs(sync-1) > 1e-9 {
+		t.Errorf("Expected synchrony 1 for constant phase lag, got %f", sync)
+	}
+}
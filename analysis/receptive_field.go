@@ -0,0 +1,266 @@
+/*
+=================================================================================
+RECEPTIVE FIELD RECONSTRUCTION AND WEIGHT-MAP EXPORT
+=================================================================================
+
+A neuron in a layer driven by encoded sensory input (e.g. one input neuron per
+MNIST pixel) learns, through STDP, a pattern of afferent weights. Arranging
+those weights in the spatial layout of the input reveals the neuron's receptive
+field - the stimulus it has become selective for.
+
+This file reconstructs receptive fields from synapse weights and exports them
+as grayscale PNG images (bright = strong) or CSV weight matrices.
+
+USAGE:
+
+	layout, _ := analysis.NewInputLayout(28, 28, pixelNeuronIDs)
+	fields := analysis.ReconstructReceptiveFields(layout, synapses)
+	err := analysis.ExportReceptiveFields("out/", fields)
+=================================================================================
+*/
+
+package analysis
+
+import (
+	"encoding/csv"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+)
+
+// InputLayout maps input neuron IDs onto a row-major 2D grid.
+type InputLayout struct {
+	Width    int
+	Height   int
+	InputIDs []string       // Row-major: index = y*Width + x
+	index    map[string]int // Input ID -> grid index
+}
+
+// NewInputLayout creates a layout for width*height input neurons.
+func NewInputLayout(width, height int, inputIDs []string) (InputLayout, error) {
+	if width <= 0 || height <= 0 {
+		return InputLayout{}, fmt.Errorf("layout dimensions must be positive: %dx%d", width, height)
+	}
+	if len(inputIDs) != width*height {
+		return InputLayout{}, fmt.Errorf("layout %dx%d needs %d input IDs, got %d", width, height, width*height, len(inputIDs))
+	}
+
+	index := make(map[string]int, len(inputIDs))
+	for i, id := range inputIDs {
+		if _, dup := index[id]; dup {
+			return InputLayout{}, fmt.Errorf("duplicate input ID in layout: %s", id)
+		}
+		index[id] = i
+	}
+	return InputLayout{Width: width, Height: height, InputIDs: inputIDs, index: index}, nil
+}
+
+// ReceptiveField is a neuron's afferent weights arranged in input space.
+type ReceptiveField struct {
+	NeuronID string
+	Width    int
+	Height   int
+	Weights  []float64 // Row-major; unconnected inputs are 0
+	Inputs   int       // Number of connected inputs
+}
+
+// At returns the weight at grid position (x, y).
+func (rf *ReceptiveField) At(x, y int) float64 {
+	return rf.Weights[y*rf.Width+x]
+}
+
+// Range returns the minimum and maximum weight.
+func (rf *ReceptiveField) Range() (float64, float64) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, w := range rf.Weights {
+		lo = math.Min(lo, w)
+		hi = math.Max(hi, w)
+	}
+	return lo, hi
+}
+
+// ReconstructReceptiveFields builds one field per post-synaptic neuron that
+// receives at least one synapse from the layout's inputs. Multiple synapses
+// between the same pair are summed. Fields are sorted by neuron ID.
+func ReconstructReceptiveFields(layout InputLayout, synapses []component.SynapticProcessor) []*ReceptiveField {
+	fields := make(map[string]*ReceptiveField)
+	for _, syn := range synapses {
+		idx, ok := layout.index[syn.GetPresynapticID()]
+		if !ok {
+			continue
+		}
+		postID := syn.GetPostsynapticID()
+		rf, exists := fields[postID]
+		if !exists {
+			rf = &ReceptiveField{
+				NeuronID: postID,
+				Width:    layout.Width,
+				Height:   layout.Height,
+				Weights:  make([]float64, layout.Width*layout.Height),
+			}
+			fields[postID] = rf
+		}
+		rf.Weights[idx] += syn.GetWeight()
+		rf.Inputs++
+	}
+
+	result := make([]*ReceptiveField, 0, len(fields))
+	for _, rf := range fields {
+		result = append(result, rf)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].NeuronID < result[j].NeuronID })
+	return result
+}
+
+// =================================================================================
+// IMAGE AND CSV EXPORT
+// =================================================================================
+
+// Image renders the field as grayscale, normalised to its own weight range.
+// Each weight becomes a scale*scale block of pixels.
+func (rf *ReceptiveField) Image(scale int) *image.Gray {
+	lo, hi := rf.Range()
+	return rf.ImageRange(lo, hi, scale)
+}
+
+// ImageRange renders the field with an explicit weight range so several
+// fields can share one intensity scale.
+func (rf *ReceptiveField) ImageRange(lo, hi float64, scale int) *image.Gray {
+	if scale < 1 {
+		scale = 1
+	}
+	img := image.NewGray(image.Rect(0, 0, rf.Width*scale, rf.Height*scale))
+	for y := 0; y < rf.Height; y++ {
+		for x := 0; x < rf.Width; x++ {
+			level := intensity(rf.At(x, y), lo, hi)
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray(x*scale+dx, y*scale+dy, color.Gray{Y: level})
+				}
+			}
+		}
+	}
+	return img
+}
+
+// intensity maps w in [lo, hi] to 0-255.
+func intensity(w, lo, hi float64) uint8 {
+	if hi <= lo {
+		return 0
+	}
+	v := (w - lo) / (hi - lo)
+	v = math.Max(0, math.Min(1, v))
+	return uint8(math.Round(v * 255))
+}
+
+// WritePNG encodes the field as a PNG image.
+func (rf *ReceptiveField) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, rf.Image(scale))
+}
+
+// WriteCSV writes the weight matrix with one grid row per CSV row.
+func (rf *ReceptiveField) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	row := make([]string, rf.Width)
+	for y := 0; y < rf.Height; y++ {
+		for x := 0; x < rf.Width; x++ {
+			row[x] = strconv.FormatFloat(rf.At(x, y), 'g', 6, 64)
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Montage tiles fields into one image with a shared intensity scale, cols
+// fields per row and a one-pixel black border between tiles.
+func Montage(fields []*ReceptiveField, cols, scale int) *image.Gray {
+	if len(fields) == 0 {
+		return image.NewGray(image.Rect(0, 0, 0, 0))
+	}
+	if cols < 1 {
+		cols = int(math.Ceil(math.Sqrt(float64(len(fields)))))
+	}
+	if scale < 1 {
+		scale = 1
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, rf := range fields {
+		l, h := rf.Range()
+		lo, hi = math.Min(lo, l), math.Max(hi, h)
+	}
+
+	tileW := fields[0].Width*scale + 1
+	tileH := fields[0].Height*scale + 1
+	rows := (len(fields) + cols - 1) / cols
+	montage := image.NewGray(image.Rect(0, 0, cols*tileW, rows*tileH))
+	for i, rf := range fields {
+		tile := rf.ImageRange(lo, hi, scale)
+		ox, oy := (i%cols)*tileW, (i/cols)*tileH
+		for y := 0; y < tile.Bounds().Dy(); y++ {
+			for x := 0; x < tile.Bounds().Dx(); x++ {
+				montage.SetGray(ox+x, oy+y, tile.GrayAt(x, y))
+			}
+		}
+	}
+	return montage
+}
+
+// ExportReceptiveFields writes <neuron>.png and <neuron>.csv for each field
+// plus a combined montage.png into dir, creating it if needed.
+func ExportReceptiveFields(dir string, fields []*ReceptiveField) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+
+	for _, rf := range fields {
+		base := filepath.Join(dir, safeFileName(rf.NeuronID))
+		if err := writeFile(base+".png", func(w io.Writer) error { return rf.WritePNG(w, 1) }); err != nil {
+			return err
+		}
+		if err := writeFile(base+".csv", rf.WriteCSV); err != nil {
+			return err
+		}
+	}
+
+	return writeFile(filepath.Join(dir, "montage.png"), func(w io.Writer) error {
+		return png.Encode(w, Montage(fields, 0, 1))
+	})
+}
+
+// writeFile creates path and fills it with write.
+func writeFile(path string, write func(io.Writer) error) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// safeFileName replaces path separators and other awkward characters.
+func safeFileName(id string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '_'
+		}
+		return r
+	}, id)
+}
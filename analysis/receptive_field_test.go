@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// buildDiagonalLayer connects a 4x4 input grid to one output neuron whose
+// weights are strong on the diagonal and weak elsewhere.
+func buildDiagonalLayer(t *testing.T) (InputLayout, []component.SynapticProcessor) {
+	t.Helper()
+	post := synapse.NewMockNeuron("out_0")
+	var ids []string
+	var synapses []component.SynapticProcessor
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			id := fmt.Sprintf("px_%d_%d", x, y)
+			ids = append(ids, id)
+			weight := 0.1
+			if x == y {
+				weight = 0.9
+			}
+			pre := synapse.NewMockNeuron(id)
+			synapses = append(synapses, synapse.NewBasicSynapse("syn_"+id, pre, post,
+				synapse.CreateDefaultSTDPConfig(), synapse.CreateDefaultPruningConfig(), weight, 0))
+		}
+	}
+	layout, err := NewInputLayout(4, 4, ids)
+	if err != nil {
+		t.Fatalf("Unexpected layout error: %v", err)
+	}
+	return layout, synapses
+}
+
+// TestReconstructReceptiveFields verifies weights land at their input positions.
+func TestReconstructReceptiveFields(t *testing.T) {
+	layout, synapses := buildDiagonalLayer(t)
+	fields := ReconstructReceptiveFields(layout, synapses)
+	if len(fields) != 1 {
+		t.Fatalf("Expected 1 receptive field, got %d", len(fields))
+	}
+	rf := fields[0]
+	if rf.NeuronID != "out_0" || rf.Inputs != 16 {
+		t.Errorf("Unexpected field %s with %d inputs", rf.NeuronID, rf.Inputs)
+	}
+	if rf.At(2, 2) != 0.9 || rf.At(3, 0) != 0.1 {
+		t.Errorf("Expected diagonal 0.9 and off-diagonal 0.1, got %f and %f", rf.At(2, 2), rf.At(3, 0))
+	}
+
+	if _, err := NewInputLayout(2, 2, []string{"a", "b", "c"}); err == nil {
+		t.Error("Expected error for mismatched layout size")
+	}
+}
+
+// TestReceptiveFieldExport verifies PNG and CSV output.
+func TestReceptiveFieldExport(t *testing.T) {
+	layout, synapses := buildDiagonalLayer(t)
+	rf := ReconstructReceptiveFields(layout, synapses)[0]
+
+	var buf bytes.Buffer
+	if err := rf.WritePNG(&buf, 3); err != nil {
+		t.Fatalf("PNG export failed: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatalf("PNG decode failed: %v", err)
+	}
+	if img.Bounds().Dx() != 12 || img.Bounds().Dy() != 12 {
+		t.Errorf("Expected 12x12 scaled image, got %v", img.Bounds())
+	}
+	if r, _, _, _ := img.At(4, 4).RGBA(); r != 0xffff {
+		t.Error("Expected strongest weight to render white")
+	}
+	if r, _, _, _ := img.At(9, 0).RGBA(); r != 0 {
+		t.Error("Expected weakest weight to render black")
+	}
+
+	buf.Reset()
+	if err := rf.WriteCSV(&buf); err != nil {
+		t.Fatalf("CSV export failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 || lines[0] != "0.9,0.1,0.1,0.1" {
+		t.Errorf("Unexpected CSV output: %q", buf.String())
+	}
+
+	dir := t.TempDir()
+	if err := ExportReceptiveFields(dir, []*ReceptiveField{rf}); err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	for _, name := range []string{"out_0.png", "out_0.csv", "montage.png"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected exported file %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,442 @@
+/*
+=================================================================================
+ANOMALY - STREAMING ANOMALY DETECTION FROM SPIKE PATTERN PREDICTION
+=================================================================================
+
+A worked example, and a template, for flagging anomalies in a numeric stream
+as it arrives. The network learns which spike pattern tends to follow which,
+and a sample whose pattern it did not expect is novel:
+
+  - Encoder: each sample is latency coded by forecast.LatencyEncoder, so the
+    value lives in which channels fire and how early.
+  - Input layer: one leaky integrate-and-fire neuron per channel
+    (batch.Population on a cosim.LockStep virtual clock) relays the encoder
+    spikes.
+  - Prediction synapses: every input neuron projects to a prediction unit of
+    every channel through a plastic synapse.BasicSynapse whose delay carries
+    the pattern into the next sample, arriving just before that sample's
+    spikes. STDP pairs each arrival with the spike of the channel it
+    predicts, so transitions that occur are potentiated; arrivals that find
+    their channel silent are depressed. The drive the prediction units
+    receive is the network's expectation of the next pattern.
+  - Scoring: the prediction error (1 - cosine similarity of expected drive
+    and observed activations) is turned into a z-score against a rolling
+    baseline of recent errors. Samples above the threshold are flagged.
+
+	detector, _ := anomaly.NewDetector(anomaly.Config{
+	    Encoder: forecast.NewLatencyEncoder(-1.2, 1.2, 24, 10*time.Millisecond),
+	    OnAnomaly: func(r anomaly.Result) { log.Printf("anomaly at %d: %.2f", r.Sample, r.Value) },
+	})
+	for x := range stream {
+	    r := detector.Step(x) // r.Anomaly, r.Score, r.Expected
+	}
+
+Learning never stops, so the detector follows a slowly drifting signal and
+a recurring "anomaly" stops being flagged once it has become normal. Each
+sample occupies SamplePeriod of virtual time, so a run is fast and
+reproducible.
+=================================================================================
+*/
+
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/forecast"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// Detector defaults, chosen for signals sampled every SamplePeriod that
+// change smoothly between samples.
+const (
+	ANOMALY_DEFAULT_SAMPLE_PERIOD  = 20 * time.Millisecond
+	ANOMALY_DEFAULT_RESOLUTION     = 1 * time.Millisecond
+	ANOMALY_DEFAULT_INPUT_WEIGHT   = 1.5 // Suprathreshold: every encoder spike is relayed
+	ANOMALY_DEFAULT_INITIAL_WEIGHT = 0.05
+	ANOMALY_DEFAULT_MAX_WEIGHT     = 1.0
+	ANOMALY_DEFAULT_LEARNING_RATE  = 0.05
+	ANOMALY_DEFAULT_DEPRESSION     = 0.02
+	ANOMALY_DEFAULT_WINDOW         = 200
+	ANOMALY_DEFAULT_THRESHOLD      = 4.0
+	ANOMALY_DEFAULT_WARMUP         = 300
+	ANOMALY_THRESHOLD              = 1.0
+	ANOMALY_DECAY_RATE             = 0.9
+	ANOMALY_REFRACTORY             = 2 * time.Millisecond
+
+	// ANOMALY_MIN_ERROR_SPREAD keeps z-scores finite when the baseline
+	// errors are nearly constant.
+	ANOMALY_MIN_ERROR_SPREAD = 0.01
+
+	anomalySpikeCallbackID = "anomaly_spikes"
+	anomalyInputSourceID   = "anomaly_encoder"
+)
+
+// =================================================================================
+// DETECTOR
+// =================================================================================
+//
+// Step feeds one sample. The prediction units have already received the
+// drive of the previous sample's spikes, which is read as the expectation
+// before the new sample is delivered. The virtual clock then advances by
+// SamplePeriod: the input layer relays the encoder spikes, which are
+// compared with the expectation, and the spikes travel on to the prediction
+// units for the next sample. Finally the prediction synapses learn from the
+// transition just observed.
+//
+// The prediction delay is SamplePeriod - MaxLatency - Resolution, so a
+// spike at latency l arrives at l - MaxLatency - Resolution into the next
+// sample: before every spike of that sample. STDP sees the arrival as
+// presynaptic and the predicted channel's spike as postsynaptic, always
+// causal, so each observed transition potentiates more the earlier (the
+// more strongly) both channels fired.
+
+// Config configures a Detector. Zero values select the defaults.
+type Config struct {
+	Encoder *forecast.LatencyEncoder // Input coding (required)
+
+	// Network
+	SamplePeriod  time.Duration // Virtual time per sample
+	Resolution    time.Duration // Virtual clock tick
+	InputWeight   float64       // Weight of encoder → input neuron connections
+	InitialWeight float64       // Starting weight of every prediction synapse
+	MaxWeight     float64       // Upper bound of prediction weights
+	LearningRate  float64       // STDP learning rate of prediction synapses
+	Depression    float64       // Fractional weight loss when a predicted channel stays silent
+
+	// Scoring
+	Window    int     // Errors in the rolling baseline
+	Threshold float64 // Z-score above which a sample is anomalous
+	Warmup    int     // Samples learned before anything is flagged
+
+	OnAnomaly func(Result) // Called for every flagged sample (optional)
+}
+
+// Result is the verdict on one sample.
+type Result struct {
+	Sample   int     // Index of the sample
+	Value    float64 // Observed value
+	Expected float64 // Value decoded from the expected pattern (NaN before anything was learned)
+	Decoded  float64 // Value decoded from the observed spikes
+	Error    float64 // Prediction error in [0, 1]
+	Score    float64 // Error z-score against the baseline
+	Anomaly  bool
+}
+
+// Detector is an input layer with learned prediction synapses and an error
+// scorer.
+type Detector struct {
+	config Config
+	runner *cosim.LockStep
+	layer  *batch.Population
+	epoch  time.Time
+	delay  time.Duration
+	units  []*predictionUnit
+	syn    [][]*synapse.BasicSynapse // [pre][post]
+
+	start    time.Time
+	observed []forecast.ChannelSpike // Spikes of the sample being fed
+	previous []forecast.ChannelSpike // Spikes of the sample before
+	spikes   int64
+
+	scorer  *Scorer
+	step    int
+	flagged int
+}
+
+// predictionUnit sums the drive that arrives for one channel.
+type predictionUnit struct {
+	*component.BaseComponent
+	drive float64
+}
+
+// Receive adds an arriving prediction.
+func (u *predictionUnit) Receive(msg types.NeuralSignal) {
+	u.drive += msg.Value
+}
+
+// NewDetector builds the network and scorer.
+func NewDetector(config Config) (*Detector, error) {
+	if config.Encoder == nil {
+		return nil, fmt.Errorf("anomaly detector needs an encoder")
+	}
+	if err := config.Encoder.Validate(); err != nil {
+		return nil, err
+	}
+	if err := applyDefaults(&config); err != nil {
+		return nil, err
+	}
+
+	epoch := time.Unix(0, 0)
+	runner, err := cosim.NewLockStep(epoch, config.Resolution)
+	if err != nil {
+		return nil, err
+	}
+	layer, err := batch.NewPopulation("anomaly_input", batch.PopulationConfig{
+		Size:             config.Encoder.Channels,
+		Threshold:        ANOMALY_THRESHOLD,
+		DecayRate:        ANOMALY_DECAY_RATE,
+		RefractoryPeriod: ANOMALY_REFRACTORY,
+		FireFactor:       1.0,
+		DelayScheduler:   runner.Schedule,
+	})
+	if err != nil {
+		return nil, err
+	}
+	runner.AddPopulation(layer)
+
+	d := &Detector{
+		config: config,
+		runner: runner,
+		layer:  layer,
+		epoch:  epoch,
+		delay:  config.SamplePeriod - config.Encoder.MaxLatency - config.Resolution,
+		scorer: NewScorer(config.Window, config.Threshold, config.Warmup),
+	}
+	if err := d.wire(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// applyDefaults fills zero settings and validates the rest.
+func applyDefaults(c *Config) error {
+	if c.SamplePeriod < 0 || c.Resolution < 0 || c.InputWeight < 0 || c.InitialWeight < 0 || c.MaxWeight < 0 ||
+		c.LearningRate < 0 || c.Window < 0 || c.Threshold < 0 || c.Warmup < 0 {
+		return fmt.Errorf("anomaly detector settings cannot be negative")
+	}
+	if c.Depression < 0 || c.Depression >= 1 {
+		return fmt.Errorf("anomaly detector depression must be in [0, 1): %f", c.Depression)
+	}
+
+	if c.SamplePeriod == 0 {
+		c.SamplePeriod = ANOMALY_DEFAULT_SAMPLE_PERIOD
+	}
+	if c.Resolution == 0 {
+		c.Resolution = ANOMALY_DEFAULT_RESOLUTION
+	}
+	if c.InputWeight == 0 {
+		c.InputWeight = ANOMALY_DEFAULT_INPUT_WEIGHT
+	}
+	if c.InitialWeight == 0 {
+		c.InitialWeight = ANOMALY_DEFAULT_INITIAL_WEIGHT
+	}
+	if c.MaxWeight == 0 {
+		c.MaxWeight = ANOMALY_DEFAULT_MAX_WEIGHT
+	}
+	if c.LearningRate == 0 {
+		c.LearningRate = ANOMALY_DEFAULT_LEARNING_RATE
+	}
+	if c.Depression == 0 {
+		c.Depression = ANOMALY_DEFAULT_DEPRESSION
+	}
+	if c.Window == 0 {
+		c.Window = ANOMALY_DEFAULT_WINDOW
+	}
+	if c.Threshold == 0 {
+		c.Threshold = ANOMALY_DEFAULT_THRESHOLD
+	}
+	if c.Warmup == 0 {
+		c.Warmup = ANOMALY_DEFAULT_WARMUP
+	}
+	if c.InitialWeight > c.MaxWeight {
+		return fmt.Errorf("initial weight %f exceeds the max weight %f", c.InitialWeight, c.MaxWeight)
+	}
+	if c.Encoder.MaxLatency+c.Resolution >= c.SamplePeriod {
+		return fmt.Errorf("encoder max latency %v plus the resolution %v must be shorter than the sample period %v",
+			c.Encoder.MaxLatency, c.Resolution, c.SamplePeriod)
+	}
+	return nil
+}
+
+// wire creates the prediction units and synapses and the spike callbacks of
+// the input layer.
+func (d *Detector) wire() error {
+	c := d.config
+	stdp := synapse.CreateDefaultSTDPConfig()
+	stdp.LearningRate = c.LearningRate
+	stdp.MinWeight = 0
+	stdp.MaxWeight = c.MaxWeight
+
+	d.units = make([]*predictionUnit, c.Encoder.Channels)
+	for j := range d.units {
+		d.units[j] = &predictionUnit{
+			BaseComponent: component.NewBaseComponent(fmt.Sprintf("anomaly_prediction_%d", j), types.TypeNeuron, types.Position3D{}),
+		}
+	}
+
+	d.syn = make([][]*synapse.BasicSynapse, c.Encoder.Channels)
+	for i := range d.syn {
+		i := i
+		d.syn[i] = make([]*synapse.BasicSynapse, c.Encoder.Channels)
+		for j, unit := range d.units {
+			syn, err := synapse.NewSynapse(fmt.Sprintf("anomaly_%d_%d", i, j), d.layer.Member(i), unit,
+				synapse.WithSTDPConfig(stdp), synapse.WithWeight(c.InitialWeight), synapse.WithDelay(d.delay))
+			if err != nil {
+				return fmt.Errorf("anomaly prediction synapses: %w", err)
+			}
+			d.syn[i][j] = syn
+		}
+		outgoing := d.syn[i]
+		d.layer.AddOutputCallback(i, anomalySpikeCallbackID, types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				d.observed = append(d.observed, forecast.ChannelSpike{Channel: i, Latency: msg.Timestamp.Sub(d.start)})
+				d.spikes++
+				for _, syn := range outgoing {
+					syn.Transmit(1)
+				}
+				return nil
+			},
+		})
+	}
+	return nil
+}
+
+// Step feeds the next sample and returns its verdict.
+func (d *Detector) Step(x float64) Result {
+	c := d.config
+	expected := make([]float64, len(d.units))
+	for j, unit := range d.units {
+		expected[j], unit.drive = unit.drive, 0
+	}
+
+	// Deliver the encoded sample and advance the network
+	d.start = d.runner.Now()
+	d.observed = d.observed[:0]
+	for _, spike := range c.Encoder.Encode(x) {
+		member := d.layer.Member(spike.Channel)
+		msg := types.NeuralSignal{
+			Value:     c.InputWeight,
+			Timestamp: d.start.Add(spike.Latency),
+			SourceID:  anomalyInputSourceID,
+			TargetID:  member.ID(),
+		}
+		if spike.Latency < c.Resolution {
+			member.Receive(msg)
+			continue
+		}
+		d.runner.Schedule(msg, member, spike.Latency)
+	}
+	d.runner.Step(c.SamplePeriod)
+
+	// Compare what arrived with what was expected
+	observed := Activations(c.Encoder, d.observed)
+	r := Result{
+		Sample:   d.step,
+		Value:    x,
+		Expected: Decode(c.Encoder, expected),
+		Decoded:  Decode(c.Encoder, observed),
+		Error:    PatternError(expected, observed),
+	}
+	r.Score, r.Anomaly = d.scorer.Add(r.Error)
+
+	d.learn()
+	d.previous = append(d.previous[:0], d.observed...)
+	d.step++
+	if r.Anomaly {
+		d.flagged++
+		if c.OnAnomaly != nil {
+			c.OnAnomaly(r)
+		}
+	}
+	return r
+}
+
+// learn pairs the arrivals of the previous sample's spikes with the spikes
+// of the sample just fed, and depresses arrivals whose channel stayed
+// silent.
+func (d *Detector) learn() {
+	post := make(map[int]time.Time, len(d.observed))
+	for _, spike := range d.observed {
+		post[spike.Channel] = d.start.Add(spike.Latency)
+	}
+	previousStart := d.start.Add(-d.config.SamplePeriod)
+	for _, pre := range d.previous {
+		arrival := previousStart.Add(pre.Latency + d.delay)
+		for j, syn := range d.syn[pre.Channel] {
+			at, fired := post[j]
+			if !fired {
+				syn.SetWeight(syn.GetWeight() * (1 - d.config.Depression))
+				continue
+			}
+			syn.ApplyPlasticity(types.PlasticityAdjustment{
+				DeltaT:       arrival.Sub(at),
+				LearningRate: d.config.LearningRate,
+				PostSynaptic: true,
+				PreSynaptic:  true,
+				Timestamp:    at,
+				EventType:    types.PlasticitySTDP,
+			})
+		}
+	}
+}
+
+// Run feeds a series and returns one verdict per sample.
+func (d *Detector) Run(series []float64) []Result {
+	results := make([]Result, len(series))
+	for i, x := range series {
+		results[i] = d.Step(x)
+	}
+	return results
+}
+
+// Weights returns the prediction weights, [pre][post].
+func (d *Detector) Weights() [][]float64 {
+	weights := make([][]float64, len(d.syn))
+	for i, row := range d.syn {
+		weights[i] = make([]float64, len(row))
+		for j, syn := range row {
+			weights[i][j] = syn.GetWeight()
+		}
+	}
+	return weights
+}
+
+// Synapses returns the prediction synapses, pre-major.
+func (d *Detector) Synapses() []*synapse.BasicSynapse {
+	var all []*synapse.BasicSynapse
+	for _, row := range d.syn {
+		all = append(all, row...)
+	}
+	return all
+}
+
+// Config returns the configuration with defaults applied.
+func (d *Detector) Config() Config {
+	return d.config
+}
+
+// Scorer returns the detector's scorer, e.g. to read its baseline.
+func (d *Detector) Scorer() *Scorer {
+	return d.scorer
+}
+
+// Elapsed returns the virtual time consumed so far.
+func (d *Detector) Elapsed() time.Duration {
+	return d.runner.Now().Sub(d.epoch)
+}
+
+// GetStats returns sample, spike and anomaly counts and the error baseline.
+func (d *Detector) GetStats() map[string]interface{} {
+	mean, std := d.scorer.Baseline()
+	var total float64
+	for _, row := range d.Weights() {
+		for _, w := range row {
+			total += w
+		}
+	}
+	return map[string]interface{}{
+		"samples":        d.step,
+		"spikes":         d.spikes,
+		"anomalies":      d.flagged,
+		"baseline_error": mean,
+		"baseline_std":   std,
+		"mean_weight":    total / math.Max(1, float64(len(d.syn)*len(d.syn))),
+	}
+}
@@ -0,0 +1,124 @@
+package anomaly
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/forecast"
+)
+
+// TestDetector_FlagsInjectedAnomalies learns a noisy periodic signal online
+// and checks that injected jumps are flagged while normal samples are not.
+func TestDetector_FlagsInjectedAnomalies(t *testing.T) {
+	const samples = 2000
+	rng := rand.New(rand.NewSource(1))
+	series := make([]float64, samples)
+	labels := make([]bool, samples)
+	for k := range series {
+		series[k] = math.Sin(2*math.Pi*float64(k)/40) + 0.03*rng.NormFloat64()
+	}
+	for _, k := range []int{550, 800, 1050, 1300, 1550, 1800} {
+		series[k] -= math.Copysign(0.9, series[k])
+		labels[k] = true
+	}
+
+	var reported int
+	detector, err := NewDetector(Config{
+		Encoder:   forecast.NewLatencyEncoder(-1.2, 1.2, 24, 10*time.Millisecond),
+		OnAnomaly: func(Result) { reported++ },
+	})
+	if err != nil {
+		t.Fatalf("Failed to create detector: %v", err)
+	}
+
+	results := detector.Run(series)
+	flags := make([]bool, samples)
+	flagged := 0
+	for i, r := range results {
+		flags[i] = r.Anomaly
+		if r.Anomaly {
+			flagged++
+		}
+		if r.Anomaly && i < ANOMALY_DEFAULT_WARMUP {
+			t.Errorf("Sample %d flagged during warmup", i)
+		}
+	}
+	// The sample after a jump is an unexpected transition too
+	eval := Evaluate(flags, labels, 1)
+	t.Logf("precision %.2f, recall %.2f, F1 %.2f, delay %.1f, %d false positives",
+		eval.Precision, eval.Recall, eval.F1, eval.MeanDelay, eval.FalsePositives)
+	if eval.Recall < 1 {
+		t.Errorf("Expected every injected anomaly to be flagged, recall %.2f", eval.Recall)
+	}
+	if eval.FalsePositives > 3 {
+		t.Errorf("Expected at most 3 false positives, got %d", eval.FalsePositives)
+	}
+	if reported != flagged || detector.GetStats()["anomalies"].(int) != flagged {
+		t.Errorf("Expected OnAnomaly for each of %d flags, got %d calls", flagged, reported)
+	}
+
+	// Learned prediction beats the untrained network on normal samples
+	var early, late float64
+	for i := 0; i < 100; i++ {
+		early += results[i+1].Error
+		late += results[samples-100+i].Error
+	}
+	if late >= 0.7*early {
+		t.Errorf("Expected prediction errors to fall with learning: %.3f early, %.3f late", early/100, late/100)
+	}
+	if r := results[samples-1]; math.Abs(r.Expected-r.Value) > 0.3 || math.Abs(r.Decoded-r.Value) > 0.1 {
+		t.Errorf("Expected decoded values near %.2f, got expected %.2f, decoded %.2f", r.Value, r.Expected, r.Decoded)
+	}
+	if got := detector.Elapsed(); got != samples*ANOMALY_DEFAULT_SAMPLE_PERIOD {
+		t.Errorf("Expected %v of virtual time, got %v", samples*ANOMALY_DEFAULT_SAMPLE_PERIOD, got)
+	}
+}
+
+// TestEvaluateAndScorer verifies event matching, z-scores and
+// configuration checks.
+func TestEvaluateAndScorer(t *testing.T) {
+	labels := []bool{false, true, false, false, false, true, false, false}
+	flags := []bool{false, false, true, false, true, false, false, false}
+	e := Evaluate(flags, labels, 1)
+	if e.TruePositives != 1 || e.FalseNegatives != 1 || e.FalsePositives != 1 || e.MeanDelay != 1 {
+		t.Errorf("Unexpected evaluation %+v", e)
+	}
+	if e.Precision != 0.5 || e.Recall != 0.5 || e.F1 != 0.5 {
+		t.Errorf("Expected precision, recall and F1 of 0.5, got %+v", e)
+	}
+
+	scorer := NewScorer(10, 3, 5)
+	for i := 0; i < 10; i++ {
+		if _, anomalous := scorer.Add(0.1 + 0.01*float64(i%2)); anomalous {
+			t.Errorf("Error %d flagged within the baseline", i)
+		}
+	}
+	score, anomalous := scorer.Add(0.5)
+	if !anomalous || score < 3 {
+		t.Errorf("Expected an outlier to be flagged, score %.1f", score)
+	}
+	if mean, _ := scorer.Baseline(); math.Abs(mean-0.105) > 1e-9 {
+		t.Errorf("Expected flagged errors kept out of the baseline, mean %.3f", mean)
+	}
+
+	encoder := forecast.NewLatencyEncoder(0, 1, 11, 10*time.Millisecond)
+	if got := DecodeSpikes(encoder, encoder.Encode(0.42)); math.Abs(got-0.42) > 0.02 {
+		t.Errorf("Expected spikes to decode to 0.42, got %.3f", got)
+	}
+	if PatternError([]float64{1, 0}, []float64{0, 1}) != 1 || PatternError([]float64{2, 0}, []float64{1, 0}) != 0 {
+		t.Error("Expected pattern errors of 1 for disjoint and 0 for proportional patterns")
+	}
+
+	for _, config := range []Config{
+		{},
+		{Encoder: encoder, Depression: 1},
+		{Encoder: encoder, InitialWeight: 2},
+		{Encoder: forecast.NewLatencyEncoder(0, 1, 11, 20*time.Millisecond)}, // no time left in the sample
+	} {
+		if _, err := NewDetector(config); err == nil {
+			t.Errorf("Expected config %+v to be rejected", config)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package anomaly
+
+import (
+	"math"
+
+	"github.com/SynapticNetworks/temporal-neuron/forecast"
+)
+
+// =================================================================================
+// DECODING
+// =================================================================================
+//
+// A channel's spike latency encodes its activation: the encoder fires at
+// (1 - activation) × MaxLatency, so the activation is read back as
+// 1 - latency / MaxLatency. The value is the activation-weighted mean of the
+// channels' preferred values (a population vector), which works equally for
+// observed spikes and for the graded drive the prediction units receive.
+
+// Activations returns the channel activations read from spike latencies.
+// Channels without a spike are 0.
+func Activations(encoder *forecast.LatencyEncoder, spikes []forecast.ChannelSpike) []float64 {
+	activations := make([]float64, encoder.Channels)
+	for _, spike := range spikes {
+		if spike.Channel < 0 || spike.Channel >= encoder.Channels {
+			continue
+		}
+		a := 1 - float64(spike.Latency)/float64(encoder.MaxLatency)
+		activations[spike.Channel] = math.Max(activations[spike.Channel], math.Max(0, math.Min(1, a)))
+	}
+	return activations
+}
+
+// Decode returns the value a set of channel activations (or drives)
+// represents, or NaN if all are zero.
+func Decode(encoder *forecast.LatencyEncoder, activations []float64) float64 {
+	var sum, weighted float64
+	for i, a := range activations {
+		if a <= 0 || i >= encoder.Channels {
+			continue
+		}
+		sum += a
+		weighted += a * encoder.Preferred(i)
+	}
+	if sum == 0 {
+		return math.NaN()
+	}
+	return weighted / sum
+}
+
+// DecodeSpikes returns the value a set of encoder spikes represents.
+func DecodeSpikes(encoder *forecast.LatencyEncoder, spikes []forecast.ChannelSpike) float64 {
+	return Decode(encoder, Activations(encoder, spikes))
+}
+
+// PatternError returns 1 - cosine similarity of the predicted and observed
+// patterns: 0 when the prediction has the observed shape, 1 when they share
+// no active channel. An empty prediction scores 1.
+func PatternError(predicted, observed []float64) float64 {
+	var dot, pp, oo float64
+	for i := range predicted {
+		if i >= len(observed) {
+			break
+		}
+		dot += predicted[i] * observed[i]
+		pp += predicted[i] * predicted[i]
+		oo += observed[i] * observed[i]
+	}
+	if pp == 0 || oo == 0 {
+		return 1
+	}
+	return 1 - dot/math.Sqrt(pp*oo)
+}
+
+// =================================================================================
+// SCORING
+// =================================================================================
+//
+// Prediction errors are never zero: the signal has noise and the learned
+// transitions blur neighbouring patterns together. What marks an anomaly is
+// an error far above the recent typical error, so the scorer reports a
+// z-score against a rolling baseline. Flagged samples are kept out of the
+// baseline, otherwise a long anomaly would raise the bar it is measured
+// against.
+
+// Scorer turns a stream of errors into z-scores and anomaly flags.
+type Scorer struct {
+	window    int
+	threshold float64
+	warmup    int
+
+	errors []float64 // Ring of baseline errors
+	next   int
+	count  int
+	sum    float64
+	sumSq  float64
+	seen   int
+}
+
+// NewScorer scores errors against the last window unflagged errors, flags
+// scores above threshold, and flags nothing during the first warmup errors.
+func NewScorer(window int, threshold float64, warmup int) *Scorer {
+	if window <= 1 {
+		window = 2
+	}
+	return &Scorer{window: window, threshold: threshold, warmup: warmup, errors: make([]float64, window)}
+}
+
+// Add scores err and reports whether it is anomalous.
+func (s *Scorer) Add(err float64) (score float64, anomalous bool) {
+	s.seen++
+	score = s.Score(err)
+	anomalous = s.seen > s.warmup && s.count > 1 && score > s.threshold
+	if !anomalous {
+		s.record(err)
+	}
+	return score, anomalous
+}
+
+// Score returns the z-score of err without recording it.
+func (s *Scorer) Score(err float64) float64 {
+	if s.count < 2 {
+		return 0
+	}
+	mean, std := s.Baseline()
+	return (err - mean) / math.Max(std, ANOMALY_MIN_ERROR_SPREAD)
+}
+
+// Baseline returns the mean and standard deviation of the baseline errors.
+func (s *Scorer) Baseline() (mean, std float64) {
+	if s.count == 0 {
+		return 0, 0
+	}
+	n := float64(s.count)
+	mean = s.sum / n
+	return mean, math.Sqrt(math.Max(0, s.sumSq/n-mean*mean))
+}
+
+// record adds err to the baseline.
+func (s *Scorer) record(err float64) {
+	if s.count == s.window {
+		old := s.errors[s.next]
+		s.sum -= old
+		s.sumSq -= old * old
+	} else {
+		s.count++
+	}
+	s.errors[s.next] = err
+	s.next = (s.next + 1) % s.window
+	s.sum += err
+	s.sumSq += err * err
+}
+
+// =================================================================================
+// EVALUATION
+// =================================================================================
+
+// Evaluation compares flags with labelled anomalies. Labels and flags are
+// matched as events: a labelled anomaly counts as detected if a flag falls
+// within Tolerance samples after it, and a flag is a false positive if no
+// labelled anomaly precedes it within Tolerance samples.
+type Evaluation struct {
+	Tolerance      int
+	TruePositives  int     // Labelled anomalies detected
+	FalseNegatives int     // Labelled anomalies missed
+	FalsePositives int     // Flags with no labelled anomaly
+	MeanDelay      float64 // Samples from label to first flag, over detections
+	Precision      float64 // Correct flags / all flags
+	Recall         float64 // Detected / labelled
+	F1             float64 // Harmonic mean of precision and recall
+}
+
+// Evaluate scores flags against labels; both are indexed by sample.
+func Evaluate(flags, labels []bool, tolerance int) Evaluation {
+	if tolerance < 0 {
+		tolerance = 0
+	}
+	e := Evaluation{Tolerance: tolerance}
+	n := len(flags)
+	if len(labels) < n {
+		n = len(labels)
+	}
+
+	var delays int
+	for i := 0; i < n; i++ {
+		if !labels[i] {
+			continue
+		}
+		detected := false
+		for k := i; k <= i+tolerance && k < n; k++ {
+			if flags[k] {
+				detected = true
+				delays += k - i
+				break
+			}
+		}
+		if detected {
+			e.TruePositives++
+		} else {
+			e.FalseNegatives++
+		}
+	}
+
+	correct, flagged := 0, 0
+	for i := 0; i < n; i++ {
+		if !flags[i] {
+			continue
+		}
+		flagged++
+		explained := false
+		for k := i; k >= i-tolerance && k >= 0; k-- {
+			if labels[k] {
+				explained = true
+				break
+			}
+		}
+		if explained {
+			correct++
+		} else {
+			e.FalsePositives++
+		}
+	}
+
+	if e.TruePositives > 0 {
+		e.MeanDelay = float64(delays) / float64(e.TruePositives)
+	}
+	if flagged > 0 {
+		e.Precision = float64(correct) / float64(flagged)
+	}
+	if labelled := e.TruePositives + e.FalseNegatives; labelled > 0 {
+		e.Recall = float64(e.TruePositives) / float64(labelled)
+	}
+	if e.Precision+e.Recall > 0 {
+		e.F1 = 2 * e.Precision * e.Recall / (e.Precision + e.Recall)
+	}
+	return e
+}
@@ -0,0 +1,429 @@
+/*
+=================================================================================
+BASALGANGLIA - ACTION SELECTION WITH REWARD-MODULATED STDP
+=================================================================================
+
+The basal ganglia select one action among competing candidates and learn
+from reward which action to select (Frank 2005; Gurney et al. 2001). This
+package builds a spiking action-selection module after that circuit:
+
+  - Cortex: one input neuron per state channel, driven by an encoder.
+  - Striatum: a D1 and a D2 pool of medium spiny neurons per action, both
+    receiving every cortical input through plastic synapses. The pools of
+    different actions inhibit each other, so one action wins.
+  - Direct pathway ("Go"): D1 inhibits the action's GPi pool.
+  - Indirect pathway ("NoGo"): D2 inhibits the action's GPe pool, which
+    otherwise inhibits GPi - D2 activity therefore strengthens GPi.
+  - Output: GPi fires tonically and holds the action's thalamic pool
+    silent. An action is selected when its D1 pool silences its GPi pool
+    and the thalamic pool is released; thalamic firing is the readout.
+
+Corticostriatal synapses learn with reward-modulated STDP (Izhikevich 2007):
+spike pairings leave an eligibility trace on each synapse, and a later
+dopamine signal (Reward) turns the trace into a weight change. Dopamine
+potentiates eligible D1 synapses and depresses eligible D2 synapses, and a
+dopamine dip does the reverse, so a rewarded action gains Go and loses NoGo
+support in the state it was taken in.
+
+All pools are batch.Population instances on one cosim.LockStep virtual
+clock, so runs are reproducible from Seed and traces decay in virtual time.
+The module plugs into the co-simulation and ROS 2 packages: its input is a
+ros2.Encoder, its outputs are ros2.RateDecoder instances, and ClosedLoop
+returns a cosim.ClosedLoop that trains the module on an Environment:
+
+	module, _ := basalganglia.New(basalganglia.Config{Inputs: 4, Actions: 2, Seed: 1})
+	action, _ := module.Act(state, 100*time.Millisecond)
+	module.Reward(reward)
+=================================================================================
+*/
+
+package basalganglia
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/ros2"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// Module defaults. Weights of the fixed pathways are the total input one
+// spike of a pool delivers to each neuron of the target pool.
+const (
+	BASALGANGLIA_DEFAULT_INPUTS        = 4
+	BASALGANGLIA_DEFAULT_ACTIONS       = 2
+	BASALGANGLIA_DEFAULT_POOL_SIZE     = 10
+	BASALGANGLIA_DEFAULT_INPUT_GAIN    = 0.2 // Input per tick for an encoded value of 1
+	BASALGANGLIA_DEFAULT_WEIGHT        = 0.3 // Initial corticostriatal weight
+	BASALGANGLIA_DEFAULT_MAX_WEIGHT    = 2.0
+	BASALGANGLIA_DEFAULT_LEARNING_RATE = 0.01
+	BASALGANGLIA_DEFAULT_STDP_TAU      = 20 * time.Millisecond
+	BASALGANGLIA_DEFAULT_ELIGIBILITY   = 200 * time.Millisecond
+	BASALGANGLIA_DEFAULT_DELAY         = 2 * time.Millisecond
+	BASALGANGLIA_DEFAULT_DECODE_WINDOW = 50 * time.Millisecond
+
+	BASALGANGLIA_DEFAULT_THRESHOLD    = 1.0
+	BASALGANGLIA_DEFAULT_MEMBRANE_TAU = 10 * time.Millisecond
+	BASALGANGLIA_DEFAULT_REFRACTORY   = 2 * time.Millisecond
+	BASALGANGLIA_DEFAULT_RESOLUTION   = 1 * time.Millisecond
+
+	// Poisson background: striatal noise drives exploration, tonic input
+	// keeps GPe, GPi and thalamus active at rest.
+	BASALGANGLIA_STRIATAL_NOISE_RATE   = 500.0 // Hz per neuron
+	BASALGANGLIA_STRIATAL_NOISE_WEIGHT = 0.1
+	BASALGANGLIA_TONIC_RATE            = 1000.0 // Hz per neuron
+	BASALGANGLIA_TONIC_WEIGHT          = 0.12
+	BASALGANGLIA_GPI_TONIC_WEIGHT      = 0.2
+
+	BASALGANGLIA_D1_GPI_WEIGHT  = -10.0
+	BASALGANGLIA_D2_GPE_WEIGHT  = -10.0
+	BASALGANGLIA_GPE_GPI_WEIGHT = -1.5
+	BASALGANGLIA_GPI_TH_WEIGHT  = -4.0
+	BASALGANGLIA_LATERAL_WEIGHT = -2.0 // Between the striatal pools of different actions
+
+	// BASALGANGLIA_LTD_RATIO is the size of depression relative to
+	// potentiation in the eligibility rule.
+	BASALGANGLIA_LTD_RATIO = 0.5
+
+	basalgangliaStimulusID = "basalganglia_input"
+	basalgangliaDecoderID  = "basalganglia_decoder"
+	basalgangliaRecorderID = "basalganglia_recorder"
+)
+
+// =================================================================================
+// CONFIGURATION
+// =================================================================================
+
+// Config describes a module. Zero values select the defaults.
+type Config struct {
+	Inputs   int // State channels (cortical input neurons)
+	Actions  int // Competing actions
+	PoolSize int // Neurons per action in each nucleus
+
+	InputGain    float64       // Input per tick for an encoded value of 1
+	Weight       float64       // Initial corticostriatal weight
+	MaxWeight    float64       // Upper bound of corticostriatal weights
+	LearningRate float64       // Weight change per unit of dopamine and eligibility
+	STDPTau      time.Duration // Time constant of the spike-pairing window
+	Eligibility  time.Duration // Time constant of the eligibility trace
+	Delay        time.Duration // Synaptic delay of every pathway
+	DecodeWindow time.Duration // Sliding window of the thalamic decoders
+
+	Threshold   float64       // Firing threshold of every neuron
+	MembraneTau time.Duration // Membrane time constant
+	Refractory  time.Duration // Absolute refractory period
+	Resolution  time.Duration // Virtual clock tick
+
+	Seed int64 // Seed for noise and tonic input
+}
+
+// DefaultConfig returns a module with four inputs and two actions.
+func DefaultConfig() Config {
+	return Config{
+		Inputs:       BASALGANGLIA_DEFAULT_INPUTS,
+		Actions:      BASALGANGLIA_DEFAULT_ACTIONS,
+		PoolSize:     BASALGANGLIA_DEFAULT_POOL_SIZE,
+		InputGain:    BASALGANGLIA_DEFAULT_INPUT_GAIN,
+		Weight:       BASALGANGLIA_DEFAULT_WEIGHT,
+		MaxWeight:    BASALGANGLIA_DEFAULT_MAX_WEIGHT,
+		LearningRate: BASALGANGLIA_DEFAULT_LEARNING_RATE,
+		STDPTau:      BASALGANGLIA_DEFAULT_STDP_TAU,
+		Eligibility:  BASALGANGLIA_DEFAULT_ELIGIBILITY,
+		Delay:        BASALGANGLIA_DEFAULT_DELAY,
+		DecodeWindow: BASALGANGLIA_DEFAULT_DECODE_WINDOW,
+		Threshold:    BASALGANGLIA_DEFAULT_THRESHOLD,
+		MembraneTau:  BASALGANGLIA_DEFAULT_MEMBRANE_TAU,
+		Refractory:   BASALGANGLIA_DEFAULT_REFRACTORY,
+		Resolution:   BASALGANGLIA_DEFAULT_RESOLUTION,
+	}
+}
+
+// applyDefaults fills zero settings and validates the rest.
+func applyDefaults(c *Config) error {
+	if c.Inputs < 0 || c.Actions < 0 || c.PoolSize < 0 || c.InputGain < 0 || c.Weight < 0 || c.MaxWeight < 0 ||
+		c.LearningRate < 0 || c.STDPTau < 0 || c.Eligibility < 0 || c.Delay < 0 || c.DecodeWindow < 0 ||
+		c.Threshold < 0 || c.MembraneTau < 0 || c.Refractory < 0 || c.Resolution < 0 {
+		return fmt.Errorf("basal ganglia settings cannot be negative")
+	}
+	defaults := DefaultConfig()
+	fill := func(value *int, fallback int) {
+		if *value == 0 {
+			*value = fallback
+		}
+	}
+	fillFloat := func(value *float64, fallback float64) {
+		if *value == 0 {
+			*value = fallback
+		}
+	}
+	fillDuration := func(value *time.Duration, fallback time.Duration) {
+		if *value == 0 {
+			*value = fallback
+		}
+	}
+	fill(&c.Inputs, defaults.Inputs)
+	fill(&c.Actions, defaults.Actions)
+	fill(&c.PoolSize, defaults.PoolSize)
+	fillFloat(&c.InputGain, defaults.InputGain)
+	fillFloat(&c.Weight, defaults.Weight)
+	fillFloat(&c.MaxWeight, defaults.MaxWeight)
+	fillFloat(&c.LearningRate, defaults.LearningRate)
+	fillDuration(&c.STDPTau, defaults.STDPTau)
+	fillDuration(&c.Eligibility, defaults.Eligibility)
+	fillDuration(&c.Delay, defaults.Delay)
+	fillDuration(&c.DecodeWindow, defaults.DecodeWindow)
+	fillFloat(&c.Threshold, defaults.Threshold)
+	fillDuration(&c.MembraneTau, defaults.MembraneTau)
+	fillDuration(&c.Refractory, defaults.Refractory)
+	fillDuration(&c.Resolution, defaults.Resolution)
+
+	if c.Actions < 2 {
+		return fmt.Errorf("action selection needs at least two actions: %d", c.Actions)
+	}
+	if c.Weight > c.MaxWeight {
+		return fmt.Errorf("initial weight %f exceeds the maximum weight %f", c.Weight, c.MaxWeight)
+	}
+	return nil
+}
+
+// =================================================================================
+// MODULE
+// =================================================================================
+
+// Module is a basal ganglia action-selection circuit on its own virtual
+// clock. It is not safe for concurrent use.
+type Module struct {
+	config Config
+	runner *cosim.LockStep
+	rng    *rand.Rand
+
+	cortex                     *batch.Population // One neuron per input
+	d1, d2, gpe, gpi, thalamus *batch.Population // PoolSize neurons per action
+
+	learner  *learner
+	decoders []*ros2.RateDecoder
+
+	encoder ros2.Encoder
+	state   []float64 // Held input, re-encoded every tick
+	counts  []int     // Thalamic spikes per action since the last reset
+}
+
+// New builds the module.
+func New(config Config) (*Module, error) {
+	if err := applyDefaults(&config); err != nil {
+		return nil, err
+	}
+	runner, err := cosim.NewLockStep(time.Unix(0, 0), config.Resolution)
+	if err != nil {
+		return nil, err
+	}
+	m := &Module{
+		config: config,
+		runner: runner,
+		rng:    rand.New(rand.NewSource(config.Seed)),
+		counts: make([]int, config.Actions),
+	}
+
+	channels := config.Actions * config.PoolSize
+	for _, nucleus := range []struct {
+		pop  **batch.Population
+		name string
+		size int
+	}{
+		{&m.cortex, "cortex", config.Inputs},
+		{&m.d1, "d1", channels},
+		{&m.d2, "d2", channels},
+		{&m.gpe, "gpe", channels},
+		{&m.gpi, "gpi", channels},
+		{&m.thalamus, "thalamus", channels},
+	} {
+		pop, err := batch.NewPopulation("basalganglia_"+nucleus.name, batch.PopulationConfig{
+			Size:             nucleus.size,
+			Threshold:        config.Threshold,
+			DecayRate:        math.Exp(-float64(config.Resolution) / float64(config.MembraneTau)),
+			RefractoryPeriod: config.Refractory,
+			FireFactor:       1.0,
+			DelayScheduler:   runner.Schedule,
+		})
+		if err != nil {
+			return nil, err
+		}
+		*nucleus.pop = pop
+	}
+
+	// Background input is drawn before the populations step
+	runner.AddStepper("basalganglia_background", m.background)
+	for _, pop := range []*batch.Population{m.cortex, m.d1, m.d2, m.gpe, m.gpi, m.thalamus} {
+		runner.AddPopulation(pop)
+	}
+
+	m.learner, err = newLearner(m)
+	if err != nil {
+		return nil, err
+	}
+	m.lateral(m.d1)
+	m.lateral(m.d2)
+	m.project(m.d1, m.gpi, BASALGANGLIA_D1_GPI_WEIGHT)
+	m.project(m.d2, m.gpe, BASALGANGLIA_D2_GPE_WEIGHT)
+	m.project(m.gpe, m.gpi, BASALGANGLIA_GPE_GPI_WEIGHT)
+	m.project(m.gpi, m.thalamus, BASALGANGLIA_GPI_TH_WEIGHT)
+
+	for a := 0; a < config.Actions; a++ {
+		decoder, err := ros2.NewRateDecoder(config.DecodeWindow, 1, 0, math.Inf(1))
+		if err != nil {
+			return nil, err
+		}
+		for k := 0; k < config.PoolSize; k++ {
+			member := m.thalamus.Member(a*config.PoolSize + k)
+			decoder.AddOutput(member.ID(), 1/float64(config.PoolSize))
+			member.AddOutputCallback(basalgangliaDecoderID, decoder.OutputCallback(member.ID()))
+			a := a
+			member.AddOutputCallback(basalgangliaRecorderID, types.OutputCallback{
+				TransmitMessage: func(types.NeuralSignal) error {
+					m.counts[a]++
+					return nil
+				},
+			})
+		}
+		m.decoders = append(m.decoders, decoder)
+	}
+
+	m.encoder = &ros2.RateEncoder{Targets: m.Inputs(), Min: 0, Max: 1, Gain: config.InputGain}
+	runner.AddStepper(basalgangliaStimulusID, m.drive)
+	return m, nil
+}
+
+// project connects every action channel of pre to the same channel of post.
+// A spike delivers weight/PoolSize to each neuron of the target pool.
+func (m *Module) project(pre, post *batch.Population, weight float64) {
+	size := m.config.PoolSize
+	for i := 0; i < pre.Size(); i++ {
+		channel := i / size
+		targets := make([]*batch.Member, size)
+		for k := range targets {
+			targets[k] = post.Member(channel*size + k)
+		}
+		pre.AddOutputCallback(i, post.ID(), types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				for _, target := range targets {
+					m.runner.Schedule(types.NeuralSignal{
+						Value:     msg.Value * weight / float64(size),
+						Timestamp: msg.Timestamp,
+						SourceID:  msg.SourceID,
+						TargetID:  target.ID(),
+					}, target, m.config.Delay)
+				}
+				return nil
+			},
+		})
+	}
+}
+
+// lateral connects every action channel of a striatal population to the
+// other channels, so the pools compete.
+func (m *Module) lateral(striatum *batch.Population) {
+	size := m.config.PoolSize
+	for i := 0; i < striatum.Size(); i++ {
+		channel := i / size
+		var targets []*batch.Member
+		for j := 0; j < striatum.Size(); j++ {
+			if j/size != channel {
+				targets = append(targets, striatum.Member(j))
+			}
+		}
+		striatum.AddOutputCallback(i, "basalganglia_lateral", types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				for _, target := range targets {
+					m.runner.Schedule(types.NeuralSignal{
+						Value:     msg.Value * BASALGANGLIA_LATERAL_WEIGHT / float64(size),
+						Timestamp: msg.Timestamp,
+						SourceID:  msg.SourceID,
+						TargetID:  target.ID(),
+					}, target, m.config.Delay)
+				}
+				return nil
+			},
+		})
+	}
+}
+
+// background injects Poisson noise into the striatum and tonic input into
+// GPe, GPi and thalamus.
+func (m *Module) background(now time.Time) {
+	dt := m.config.Resolution.Seconds()
+	poisson := func(pop *batch.Population, rate, weight float64) {
+		p := rate * dt
+		for i := 0; i < pop.Size(); i++ {
+			if m.rng.Float64() < p {
+				pop.Inject(i, weight)
+			}
+		}
+	}
+	poisson(m.d1, BASALGANGLIA_STRIATAL_NOISE_RATE, BASALGANGLIA_STRIATAL_NOISE_WEIGHT)
+	poisson(m.d2, BASALGANGLIA_STRIATAL_NOISE_RATE, BASALGANGLIA_STRIATAL_NOISE_WEIGHT)
+	poisson(m.gpe, BASALGANGLIA_TONIC_RATE, BASALGANGLIA_TONIC_WEIGHT)
+	poisson(m.gpi, BASALGANGLIA_TONIC_RATE, BASALGANGLIA_GPI_TONIC_WEIGHT)
+	poisson(m.thalamus, BASALGANGLIA_TONIC_RATE, BASALGANGLIA_TONIC_WEIGHT)
+}
+
+// drive re-encodes the held input.
+func (m *Module) drive(now time.Time) {
+	if len(m.state) > 0 {
+		m.encoder.Encode(m.state, basalgangliaStimulusID)
+	}
+}
+
+// Config returns the configuration with defaults applied.
+func (m *Module) Config() Config {
+	return m.config
+}
+
+// Clock returns the module's virtual clock.
+func (m *Module) Clock() *cosim.LockStep {
+	return m.runner
+}
+
+// Inputs returns the cortical input neurons, one per state channel, as
+// targets for a ros2 encoder.
+func (m *Module) Inputs() []component.MessageReceiver {
+	inputs := make([]component.MessageReceiver, m.config.Inputs)
+	for i := range inputs {
+		inputs[i] = m.cortex.Member(i)
+	}
+	return inputs
+}
+
+// Decoders returns one decoder per action. Each reads the mean firing rate
+// (Hz) of the action's thalamic pool over DecodeWindow.
+func (m *Module) Decoders() []*ros2.RateDecoder {
+	return append([]*ros2.RateDecoder(nil), m.decoders...)
+}
+
+// Synapses returns the plastic corticostriatal synapses onto the D1 or D2
+// pool of an action.
+func (m *Module) Synapses(action int, d2 bool) []*synapse.BasicSynapse {
+	return m.learner.synapsesOf(action, d2)
+}
+
+// Elapsed returns the virtual time consumed so far.
+func (m *Module) Elapsed() time.Duration {
+	return m.runner.Now().Sub(time.Unix(0, 0))
+}
+
+// GetStats returns the module's size, elapsed time and learning counters.
+func (m *Module) GetStats() map[string]interface{} {
+	return map[string]interface{}{
+		"inputs":    m.config.Inputs,
+		"actions":   m.config.Actions,
+		"pool_size": m.config.PoolSize,
+		"elapsed":   m.Elapsed(),
+		"rewards":   m.learner.rewards,
+		"synapses":  len(m.learner.synapses),
+	}
+}
@@ -0,0 +1,107 @@
+package basalganglia
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestActionSelectionLearning trains a two-state, two-action task with
+// Act and Reward: each state has one rewarded action, and the module must
+// learn both mappings through the direct and indirect pathways.
+func TestActionSelectionLearning(t *testing.T) {
+	module, err := New(Config{Inputs: 2, Actions: 2, Seed: 1})
+	if err != nil {
+		t.Fatalf("Failed to build module: %v", err)
+	}
+	if action, err := module.Act([]float64{0, 0}, 100*time.Millisecond); err != nil || action != -1 {
+		t.Errorf("Expected no action without input, got %d (%v)", action, err)
+	}
+
+	correct := 0
+	for trial := 0; trial < 40; trial++ {
+		state := []float64{0, 0}
+		state[trial%2] = 1
+		action, err := module.Act(state, 100*time.Millisecond)
+		if err != nil {
+			t.Fatalf("Trial %d: %v", trial, err)
+		}
+		dopamine := 0.0
+		switch {
+		case action == trial%2:
+			dopamine = 1
+			if trial >= 30 {
+				correct++
+			}
+		case action >= 0:
+			dopamine = -1
+		}
+		if _, err := module.Reward(dopamine); err != nil {
+			t.Fatalf("Trial %d: %v", trial, err)
+		}
+		module.Rest(200 * time.Millisecond)
+	}
+	if correct < 9 {
+		t.Errorf("Expected at least 9 of the last 10 choices correct, got %d", correct)
+	}
+	for input := 0; input < 2; input++ {
+		rewarded, _ := module.Preference(input, input)
+		other, _ := module.Preference(input, 1-input)
+		if rewarded <= other+0.5 {
+			t.Errorf("Expected input %d to prefer action %d: %.2f vs %.2f", input, input, rewarded, other)
+		}
+	}
+	if _, err := module.Reward(math.NaN()); err == nil {
+		t.Error("Expected error for a NaN dopamine signal")
+	}
+	if _, err := New(Config{Actions: 1}); err == nil {
+		t.Error("Expected error for a single action")
+	}
+}
+
+// bandit rewards the action that matches the state it presented last.
+type bandit struct {
+	state, steps, correct int
+}
+
+func (b *bandit) Step(action int) ([]float64, float64, bool) {
+	reward := 0.0
+	if action >= 0 {
+		reward = -1
+		if action == b.state {
+			reward = 1
+			if b.steps >= 60 {
+				b.correct++
+			}
+		}
+	}
+	b.steps++
+	b.state = (b.steps / 2) % 2 // Each state lasts two decisions
+	next := []float64{0, 0}
+	next[b.state] = 1
+	return next, reward, false
+}
+
+// TestClosedLoopControl trains the module on an environment through a
+// cosim.ClosedLoop with the thalamic decoders as output.
+func TestClosedLoopControl(t *testing.T) {
+	module, err := New(Config{Inputs: 2, Actions: 2, Seed: 2})
+	if err != nil {
+		t.Fatalf("Failed to build module: %v", err)
+	}
+	env := &bandit{}
+	loop, err := module.ClosedLoop(env, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Failed to build loop: %v", err)
+	}
+	if ticks, err := loop.Run(context.Background(), 80); err != nil || ticks != 80 {
+		t.Fatalf("Expected 80 ticks, got %d (%v)", ticks, err)
+	}
+	if env.correct < 15 {
+		t.Errorf("Expected at least 15 of the last 20 decisions correct, got %d", env.correct)
+	}
+	if module.Elapsed() != 8*time.Second {
+		t.Errorf("Expected 8s of virtual time, got %v", module.Elapsed())
+	}
+}
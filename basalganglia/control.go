@@ -0,0 +1,119 @@
+package basalganglia
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/ros2"
+)
+
+// =================================================================================
+// REINFORCEMENT-LEARNING CONTROL
+// =================================================================================
+//
+// The module holds the last encoded state and re-encodes it on every tick,
+// so a state drives the cortex for as long as it lasts, not for one tick.
+// The default encoder is a ros2.RateEncoder over the input neurons mapping
+// [0, 1] to [0, InputGain]; SetEncoder installs another ros2 encoder, e.g. a
+// PopulationEncoder for a scalar state.
+//
+// The selected action is the one whose thalamic pool fires most. Act runs
+// one decision directly; ClosedLoop runs an Environment in a cosim.ClosedLoop
+// with the thalamic decoders as output and the reward of each step fed back
+// as dopamine.
+
+// Environment is a task the module learns to control.
+type Environment interface {
+	// Step applies the selected action (-1 when no action was selected)
+	// and returns the next state, the reward for the action and whether
+	// the episode is over.
+	Step(action int) (state []float64, reward float64, done bool)
+}
+
+// SetEncoder replaces the encoder that drives the input neurons (see
+// Inputs).
+func (m *Module) SetEncoder(encoder ros2.Encoder) error {
+	if encoder == nil {
+		return fmt.Errorf("encoder is nil")
+	}
+	m.encoder = encoder
+	return nil
+}
+
+// Encode holds values as the module's input until the next call. It
+// implements cosim.Encoder and ros2.Encoder.
+func (m *Module) Encode(values []float64, sourceID string) {
+	m.state = append(m.state[:0], values...)
+}
+
+// Act presents state for duration and returns the action whose thalamic
+// pool fired most, or -1 if none fired.
+func (m *Module) Act(state []float64, duration time.Duration) (int, error) {
+	if duration <= 0 {
+		return -1, fmt.Errorf("decision time must be positive: %v", duration)
+	}
+	m.Encode(state, basalgangliaStimulusID)
+	for a := range m.counts {
+		m.counts[a] = 0
+	}
+	if err := m.runner.Step(duration); err != nil {
+		return -1, err
+	}
+	return selectAction(intsToFloats(m.counts)), nil
+}
+
+// Rest runs the module for duration without input, letting eligibility
+// traces of the last decision decay.
+func (m *Module) Rest(duration time.Duration) error {
+	m.state = m.state[:0]
+	return m.runner.Step(duration)
+}
+
+// ClosedLoop returns a control loop that trains the module on env: every
+// period, the most active thalamic decoder selects the action, env's reward
+// is delivered as dopamine and env's next state becomes the input.
+func (m *Module) ClosedLoop(env Environment, period time.Duration) (*cosim.ClosedLoop, error) {
+	if env == nil {
+		return nil, fmt.Errorf("closed loop needs an environment")
+	}
+	decoders := make([]cosim.Decoder, len(m.decoders))
+	for i, decoder := range m.decoders {
+		decoders[i] = decoder
+	}
+	return cosim.NewClosedLoop(cosim.ClosedLoopConfig{
+		Clock:    m.runner,
+		Period:   period,
+		Decoders: decoders,
+		Encoder:  m,
+		SourceID: basalgangliaStimulusID,
+		Controller: cosim.ControllerFunc(func(now time.Time, output []float64) ([]float64, bool) {
+			state, reward, done := env.Step(selectAction(output))
+			if reward != 0 {
+				m.Reward(reward)
+			}
+			return state, done
+		}),
+	})
+}
+
+// selectAction returns the index of the largest positive activity, or -1.
+func selectAction(activity []float64) int {
+	best, max := -1, 0.0
+	for a, value := range activity {
+		if value > max && !math.IsNaN(value) {
+			best, max = a, value
+		}
+	}
+	return best
+}
+
+// intsToFloats converts spike counts for selectAction.
+func intsToFloats(counts []int) []float64 {
+	values := make([]float64, len(counts))
+	for i, count := range counts {
+		values[i] = float64(count)
+	}
+	return values
+}
@@ -0,0 +1,209 @@
+package basalganglia
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// REWARD-MODULATED STDP
+// =================================================================================
+//
+// Every corticostriatal synapse keeps an eligibility trace c. Pairings
+// follow the usual STDP window of time constant STDPTau: a postsynaptic
+// spike adds the presynaptic trace to c (pre before post), a presynaptic
+// spike subtracts BASALGANGLIA_LTD_RATIO times the postsynaptic trace (post
+// before pre). c decays with time constant Eligibility, so the synapse stays
+// eligible for a while after the pairing. Reward(d) then changes the weight
+// by LearningRate·d·c on D1 synapses and by -LearningRate·d·c on D2
+// synapses (Izhikevich 2007; Frank 2005).
+//
+// The synapses' own eligibility traces run on the wall clock, so the module
+// keeps these traces itself, in virtual time. The synapses carry the
+// weights, with their plasticity disabled.
+
+// trace is an exponentially decaying value.
+type trace struct {
+	value float64
+	at    time.Time
+}
+
+// decayed returns the trace's value at now.
+func (t *trace) decayed(now time.Time, tau time.Duration) float64 {
+	if t.value == 0 {
+		return 0
+	}
+	return t.value * math.Exp(-float64(now.Sub(t.at))/float64(tau))
+}
+
+// add decays the trace to now and adds delta.
+func (t *trace) add(now time.Time, tau time.Duration, delta float64) {
+	t.value = t.decayed(now, tau) + delta
+	t.at = now
+}
+
+// plasticSynapse is one corticostriatal synapse and its eligibility.
+type plasticSynapse struct {
+	syn         *synapse.BasicSynapse
+	pre         int  // Cortical neuron
+	post        int  // Member of the D1 or D2 population
+	d2          bool // Indirect-pathway synapse
+	eligibility trace
+}
+
+// learner implements reward-modulated STDP on the corticostriatal synapses.
+type learner struct {
+	module   *Module
+	synapses []*plasticSynapse
+
+	outgoing [][]*plasticSynapse    // [cortical neuron]
+	incoming [2][][]*plasticSynapse // [D1/D2][striatal neuron]
+	pre      []trace                // [cortical neuron]
+	post     [2][]trace             // [D1/D2][striatal neuron]
+
+	rewards int64
+}
+
+// newLearner wires every cortical neuron to every D1 and D2 neuron.
+func newLearner(m *Module) (*learner, error) {
+	cfg := m.config
+	channels := cfg.Actions * cfg.PoolSize
+	l := &learner{
+		module:   m,
+		outgoing: make([][]*plasticSynapse, cfg.Inputs),
+		pre:      make([]trace, cfg.Inputs),
+	}
+	for p, striatum := range []*batch.Population{m.d1, m.d2} {
+		l.incoming[p] = make([][]*plasticSynapse, channels)
+		l.post[p] = make([]trace, channels)
+		for j := 0; j < channels; j++ {
+			for i := 0; i < cfg.Inputs; i++ {
+				syn, err := synapse.NewSynapse(fmt.Sprintf("basalganglia_%s_%d_%d", striatum.ID(), i, j),
+					m.cortex.Member(i), striatum.Member(j),
+					synapse.WithWeight(cfg.Weight), synapse.WithWeightBounds(0, cfg.MaxWeight),
+					synapse.WithDelay(cfg.Delay), synapse.WithPlasticityDisabled())
+				if err != nil {
+					return nil, fmt.Errorf("corticostriatal synapse: %w", err)
+				}
+				plastic := &plasticSynapse{syn: syn, pre: i, post: j, d2: p == 1}
+				l.synapses = append(l.synapses, plastic)
+				l.outgoing[i] = append(l.outgoing[i], plastic)
+				l.incoming[p][j] = append(l.incoming[p][j], plastic)
+			}
+			p, j := p, j
+			striatum.AddOutputCallback(j, "basalganglia_rstdp", types.OutputCallback{
+				TransmitMessage: func(msg types.NeuralSignal) error {
+					l.postSpike(p, j, msg.Timestamp)
+					return nil
+				},
+			})
+		}
+	}
+	for i := 0; i < cfg.Inputs; i++ {
+		i := i
+		m.cortex.AddOutputCallback(i, "basalganglia_corticostriatal", types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				l.preSpike(i, msg.Timestamp)
+				for _, plastic := range l.outgoing[i] {
+					plastic.syn.Transmit(msg.Value)
+				}
+				return nil
+			},
+		})
+	}
+	return l, nil
+}
+
+// preSpike records a cortical spike: post-before-pre pairings depress.
+func (l *learner) preSpike(i int, now time.Time) {
+	cfg := l.module.config
+	l.pre[i].add(now, cfg.STDPTau, 1)
+	for _, plastic := range l.outgoing[i] {
+		p := 0
+		if plastic.d2 {
+			p = 1
+		}
+		if post := l.post[p][plastic.post].decayed(now, cfg.STDPTau); post > 0 {
+			plastic.eligibility.add(now, cfg.Eligibility, -BASALGANGLIA_LTD_RATIO*post)
+		}
+	}
+}
+
+// postSpike records a striatal spike: pre-before-post pairings potentiate.
+func (l *learner) postSpike(p, j int, now time.Time) {
+	cfg := l.module.config
+	l.post[p][j].add(now, cfg.STDPTau, 1)
+	for _, plastic := range l.incoming[p][j] {
+		if pre := l.pre[plastic.pre].decayed(now, cfg.STDPTau); pre > 0 {
+			plastic.eligibility.add(now, cfg.Eligibility, pre)
+		}
+	}
+}
+
+// reward converts eligibility into weight changes and returns the summed
+// absolute change.
+func (l *learner) reward(dopamine float64, now time.Time) float64 {
+	cfg := l.module.config
+	total := 0.0
+	for _, plastic := range l.synapses {
+		delta := cfg.LearningRate * dopamine * plastic.eligibility.decayed(now, cfg.Eligibility)
+		if plastic.d2 {
+			delta = -delta
+		}
+		if delta == 0 {
+			continue
+		}
+		before := plastic.syn.GetWeight()
+		plastic.syn.SetWeight(before + delta)
+		total += math.Abs(plastic.syn.GetWeight() - before)
+	}
+	l.rewards++
+	return total
+}
+
+// synapsesOf returns the synapses onto the D1 or D2 pool of an action.
+func (l *learner) synapsesOf(action int, d2 bool) []*synapse.BasicSynapse {
+	size := l.module.config.PoolSize
+	var synapses []*synapse.BasicSynapse
+	for _, plastic := range l.synapses {
+		if plastic.d2 == d2 && plastic.post/size == action {
+			synapses = append(synapses, plastic.syn)
+		}
+	}
+	return synapses
+}
+
+// Reward delivers a dopamine signal: positive for a better outcome than
+// expected, negative for a worse one. Returns the summed absolute weight
+// change.
+func (m *Module) Reward(dopamine float64) (float64, error) {
+	if math.IsNaN(dopamine) || math.IsInf(dopamine, 0) {
+		return 0, fmt.Errorf("dopamine signal must be finite: %f", dopamine)
+	}
+	return m.learner.reward(dopamine, m.runner.Now()), nil
+}
+
+// Preference returns how strongly the module favours an action in a state
+// channel: the mean D1 weight from the input minus the mean D2 weight.
+func (m *Module) Preference(input, action int) (float64, error) {
+	if input < 0 || input >= m.config.Inputs || action < 0 || action >= m.config.Actions {
+		return 0, fmt.Errorf("input %d or action %d out of range", input, action)
+	}
+	var direct, indirect float64
+	for _, plastic := range m.learner.synapses {
+		if plastic.pre != input || plastic.post/m.config.PoolSize != action {
+			continue
+		}
+		if plastic.d2 {
+			indirect += plastic.syn.GetWeight()
+		} else {
+			direct += plastic.syn.GetWeight()
+		}
+	}
+	return (direct - indirect) / float64(m.config.PoolSize), nil
+}
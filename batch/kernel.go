@@ -0,0 +1,97 @@
+/*
+=================================================================================
+MEMBRANE KERNELS - DENSE BATCH UPDATE BACKENDS
+=================================================================================
+
+A kernel performs one membrane update step for an entire homogeneous
+population stored as struct-of-arrays. Keeping the per-neuron state in flat
+float64 slices is what makes the update vectorizable: the same three
+operations (integrate, decay, threshold) are applied element-wise with no
+per-neuron branching except the spike test.
+
+The portable kernel is pure Go with a 4-way unrolled inner loop. On amd64
+the AVX2 kernel (kernel_amd64.s) updates four neurons per instruction and is
+bit-identical to it. AcceleratedKernel picks the best backend for the
+machine; it is selected via PopulationConfig.Kernel, and the rest of the
+engine is backend-agnostic. The purego build tag leaves only the portable
+kernel. There is no cgo/GPU backend; one would implement the same interface
+in build-tagged files.
+=================================================================================
+*/
+
+package batch
+
+// MembraneKernel updates a dense population for one time step.
+//
+// Semantics per neuron i (matching the goroutine-based Neuron):
+//
+//	if refractoryUntil[i] > now: input is discarded
+//	else: potential[i] += input[i]
+//	if potential[i] >= threshold: neuron fires, potential reset happens in the caller
+//	potential[i] *= decay   (only for neurons that did not fire)
+//
+// Implementations must clear input[i] after consuming it and append the index
+// of every neuron that crossed threshold to fired, returning the extended slice.
+type MembraneKernel interface {
+	// Name identifies the backend for diagnostics.
+	Name() string
+
+	// Step integrates input into potential and reports threshold crossings.
+	Step(potential, input []float64, refractoryUntil []int64, now int64,
+		threshold, decay float64, fired []int) []int
+}
+
+// AcceleratedKernel returns the fastest kernel available on this machine and
+// whether it is accelerated. Without support it returns PortableKernel.
+func AcceleratedKernel() (MembraneKernel, bool) {
+	return acceleratedKernel()
+}
+
+// =================================================================================
+// PORTABLE KERNEL
+// =================================================================================
+
+// PortableKernel is the default pure-Go kernel.
+type PortableKernel struct{}
+
+// Name returns the backend identifier.
+func (PortableKernel) Name() string {
+	return "portable"
+}
+
+// Step performs the update with a 4-way unrolled loop over the dense arrays.
+func (PortableKernel) Step(potential, input []float64, refractoryUntil []int64, now int64,
+	threshold, decay float64, fired []int) []int {
+
+	n := len(potential)
+	input = input[:n]
+	refractoryUntil = refractoryUntil[:n]
+
+	i := 0
+	for ; i+4 <= n; i += 4 {
+		fired = stepOne(potential, input, refractoryUntil, i, now, threshold, decay, fired)
+		fired = stepOne(potential, input, refractoryUntil, i+1, now, threshold, decay, fired)
+		fired = stepOne(potential, input, refractoryUntil, i+2, now, threshold, decay, fired)
+		fired = stepOne(potential, input, refractoryUntil, i+3, now, threshold, decay, fired)
+	}
+	for ; i < n; i++ {
+		fired = stepOne(potential, input, refractoryUntil, i, now, threshold, decay, fired)
+	}
+	return fired
+}
+
+// stepOne applies the membrane update to a single element.
+func stepOne(potential, input []float64, refractoryUntil []int64, i int, now int64,
+	threshold, decay float64, fired []int) []int {
+
+	if refractoryUntil[i] <= now {
+		potential[i] += input[i]
+	}
+	input[i] = 0
+
+	if potential[i] >= threshold {
+		return append(fired, i)
+	}
+	potential[i] *= decay
+	return fired
+}
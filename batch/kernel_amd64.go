@@ -0,0 +1,87 @@
+//go:build amd64 && !purego
+
+package batch
+
+import "math/bits"
+
+// avx2ChunkGroups bounds the 4-lane groups handled per assembly call, so the
+// fired masks fit in a stack buffer.
+const avx2ChunkGroups = 256
+
+// avx2Supported reports whether the CPU and the OS support AVX2.
+var avx2Supported = detectAVX2()
+
+// AVX2Kernel updates four membranes per instruction with AVX2 assembly
+// (kernel_amd64.s). Results are bit-identical to PortableKernel; any tail
+// shorter than four neurons is updated by the portable code.
+type AVX2Kernel struct{}
+
+// Name returns the backend identifier.
+func (AVX2Kernel) Name() string {
+	return "avx2"
+}
+
+// Step performs the update in 4-lane groups and collects the fired indices
+// from the per-group lane masks.
+func (AVX2Kernel) Step(potential, input []float64, refractoryUntil []int64, now int64,
+	threshold, decay float64, fired []int) []int {
+
+	n := len(potential)
+	input = input[:n]
+	refractoryUntil = refractoryUntil[:n]
+
+	var masks [avx2ChunkGroups]uint8
+	i := 0
+	for n-i >= 4 {
+		groups := min((n-i)/4, avx2ChunkGroups)
+		stepAVX2(&potential[i], &input[i], &refractoryUntil[i], groups, now, threshold, decay, &masks[0])
+		for g, mask := range masks[:groups] {
+			for ; mask != 0; mask &= mask - 1 {
+				fired = append(fired, i+4*g+bits.TrailingZeros8(mask))
+			}
+		}
+		i += 4 * groups
+	}
+	for ; i < n; i++ {
+		fired = stepOne(potential, input, refractoryUntil, i, now, threshold, decay, fired)
+	}
+	return fired
+}
+
+// acceleratedKernel returns the AVX2 kernel when the machine supports it.
+func acceleratedKernel() (MembraneKernel, bool) {
+	if avx2Supported {
+		return AVX2Kernel{}, true
+	}
+	return PortableKernel{}, false
+}
+
+// detectAVX2 checks the CPUID feature bits and that the OS saves the YMM
+// registers (XCR0).
+func detectAVX2() bool {
+	maxLeaf, _, _, _ := cpuid(0, 0)
+	if maxLeaf < 7 {
+		return false
+	}
+	_, _, ecx1, _ := cpuid(1, 0)
+	const osxsave, avx = 1 << 27, 1 << 28
+	if ecx1&osxsave == 0 || ecx1&avx == 0 {
+		return false
+	}
+	if xcr0, _ := xgetbv(); xcr0&0x6 != 0x6 { // SSE and AVX state enabled
+		return false
+	}
+	_, ebx7, _, _ := cpuid(7, 0)
+	return ebx7&(1<<5) != 0
+}
+
+// stepAVX2 updates groups×4 membranes starting at the given elements and
+// writes one fired-lane mask per group to masks.
+//
+//go:noescape
+func stepAVX2(potential, input *float64, refractoryUntil *int64, groups int, now int64,
+	threshold, decay float64, masks *uint8)
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+
+func xgetbv() (eax, edx uint32)
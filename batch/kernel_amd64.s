@@ -0,0 +1,76 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// func stepAVX2(potential, input *float64, refractoryUntil *int64, groups int, now int64,
+//	threshold, decay float64, masks *uint8)
+//
+// Per 4-lane group, matching stepOne:
+//	sum      = potential + input
+//	potential = refractoryUntil > now ? potential : sum
+//	input    = 0
+//	fired    = potential >= threshold (ordered, so NaN never fires)
+//	potential = fired ? potential : potential * decay
+TEXT ·stepAVX2(SB), NOSPLIT, $0-64
+	MOVQ potential+0(FP), DI
+	MOVQ input+8(FP), SI
+	MOVQ refractoryUntil+16(FP), DX
+	MOVQ groups+24(FP), CX
+	VPBROADCASTQ now+32(FP), Y0
+	VBROADCASTSD threshold+40(FP), Y1
+	VBROADCASTSD decay+48(FP), Y2
+	MOVQ masks+56(FP), BX
+	VXORPD Y3, Y3, Y3
+
+loop:
+	TESTQ CX, CX
+	JZ    done
+
+	VMOVUPD (DI), Y4
+	VMOVUPD (SI), Y5
+	VMOVDQU (DX), Y6
+
+	// Integrate input outside the refractory period
+	VPCMPGTQ  Y0, Y6, Y7
+	VADDPD    Y5, Y4, Y9
+	VBLENDVPD Y7, Y4, Y9, Y4
+	VMOVUPD   Y3, (SI)
+
+	// Threshold test, then decay the members that did not fire
+	VCMPPD    $0x1D, Y1, Y4, Y10
+	VMULPD    Y2, Y4, Y11
+	VBLENDVPD Y10, Y4, Y11, Y4
+	VMOVUPD   Y4, (DI)
+
+	VMOVMSKPD Y10, AX
+	MOVB      AX, (BX)
+
+	ADDQ $32, DI
+	ADDQ $32, SI
+	ADDQ $32, DX
+	INCQ BX
+	DECQ CX
+	JMP  loop
+
+done:
+	VZEROUPPER
+	RET
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
@@ -0,0 +1,9 @@
+//go:build !amd64 || purego
+
+package batch
+
+// acceleratedKernel reports that no accelerated backend is built for this
+// architecture.
+func acceleratedKernel() (MembraneKernel, bool) {
+	return PortableKernel{}, false
+}
@@ -0,0 +1,69 @@
+package batch
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestAcceleratedKernelMatchesPortable runs both kernels on identical random
+// state, including sizes that leave a scalar tail and span several AVX2
+// chunks, and requires bit-identical potentials, cleared inputs and the same
+// fired indices.
+func TestAcceleratedKernelMatchesPortable(t *testing.T) {
+	accelerated, ok := AcceleratedKernel()
+	if !ok {
+		t.Skip("No accelerated kernel on this machine")
+	}
+
+	rng := rand.New(rand.NewSource(7))
+	const now = int64(1000)
+	for _, size := range []int{0, 1, 3, 4, 5, 17, 1024, 4099} {
+		potential := make([]float64, size)
+		input := make([]float64, size)
+		refractoryUntil := make([]int64, size)
+		for i := range potential {
+			potential[i] = rng.Float64()*2 - 0.5
+			input[i] = rng.Float64() - 0.3
+			refractoryUntil[i] = now + int64(rng.Intn(3)-1) // Before, at and after now
+		}
+		if size > 4 {
+			potential[1], input[2], potential[3] = math.NaN(), math.Inf(1), math.Copysign(0, -1)
+		}
+
+		wantPotential, wantInput := slices.Clone(potential), slices.Clone(input)
+		wantFired := PortableKernel{}.Step(wantPotential, wantInput, refractoryUntil, now, 1.0, 0.9, nil)
+		gotFired := accelerated.Step(potential, input, refractoryUntil, now, 1.0, 0.9, nil)
+
+		if !slices.Equal(gotFired, wantFired) {
+			t.Errorf("Size %d: expected fired %v, got %v", size, wantFired, gotFired)
+		}
+		for i := range potential {
+			same := math.Float64bits(potential[i]) == math.Float64bits(wantPotential[i]) ||
+				(math.IsNaN(potential[i]) && math.IsNaN(wantPotential[i]))
+			if !same || input[i] != 0 {
+				t.Errorf("Size %d, neuron %d: expected potential %g, got %g (input %g)",
+					size, i, wantPotential[i], potential[i], input[i])
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkAcceleratedKernelStep(b *testing.B) {
+	kernel, ok := AcceleratedKernel()
+	if !ok {
+		b.Skip("No accelerated kernel on this machine")
+	}
+	config := defaultTestConfig(100000)
+	config.Kernel = kernel
+	pop, _ := NewPopulation("bench", config)
+	now := time.Now()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pop.Inject(i%100000, 0.3)
+		pop.Step(now.Add(time.Duration(i) * time.Millisecond))
+	}
+}
@@ -0,0 +1,399 @@
+/*
+=================================================================================
+BATCH POPULATION - VECTORIZED HOMOGENEOUS NEURON GROUPS
+=================================================================================
+
+A Population simulates N identical threshold neurons with a single stepping
+goroutine instead of N goroutines. State lives in dense arrays updated by a
+MembraneKernel, which is what allows SIMD backends (see kernel.go) to be
+dropped in.
+
+INTEROPERABILITY AT POPULATION BOUNDARIES:
+  - Inbound:  Member(i) returns a component.MessageScheduler, so synapses from
+              goroutine-based neurons (or other populations) target members
+              exactly like ordinary neurons. Received values are accumulated
+              into the dense input array and consumed on the next step.
+  - Outbound: AddOutputCallback attaches the same types.OutputCallback used by
+              neuron.Neuron, so members drive regular synapses when they fire.
+  - Internal: Connect adds dense-path connections between members that are
+              delivered directly into the next step's input, bypassing
+              message passing entirely.
+
+Only the core integrate-and-fire dynamics are batched. Per-neuron features
+such as dendritic integration, homeostasis or STDP feedback require the
+goroutine-based neuron.
+=================================================================================
+*/
+
+package batch
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/affinity"
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+const (
+	// BATCH_DEFAULT_STEP_INTERVAL matches the goroutine neuron's decay tick.
+	BATCH_DEFAULT_STEP_INTERVAL = 1 * time.Millisecond
+)
+
+// DelayScheduler delivers msg to target after delay. It lets an external
+// clock (e.g. a lock-step co-simulation runner) own delayed deliveries
+// instead of wall-clock timers.
+type DelayScheduler func(msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration)
+
+// PopulationConfig describes a homogeneous population.
+type PopulationConfig struct {
+	Size             int             // Number of neurons
+	Threshold        float64         // Firing threshold shared by all members
+	DecayRate        float64         // Per-step membrane retention factor (0-1)
+	RefractoryPeriod time.Duration   // Absolute refractory period
+	FireFactor       float64         // Output = potential × FireFactor
+	StepInterval     time.Duration   // Simulation step when running (0 = default)
+	Kernel           MembraneKernel  // Update backend (nil = PortableKernel)
+	DelayScheduler   DelayScheduler  // Delayed delivery for members (nil = timers)
+	Affinity         *affinity.Group // Pins the stepping goroutine when running (nil = unpinned)
+}
+
+// internalConnection is a dense-path connection between two members.
+type internalConnection struct {
+	target int
+	weight float64
+}
+
+// Population is a batch-simulated group of identical neurons.
+type Population struct {
+	id     string
+	config PopulationConfig
+	kernel MembraneKernel
+
+	// Dense state (owned by the stepping goroutine; guarded by stepMutex)
+	potential       []float64
+	input           []float64
+	refractoryUntil []int64
+	fired           []int
+
+	// Pending input written by Receive, swapped in at each step
+	pending      []float64
+	pendingMutex sync.Mutex
+
+	members  []*Member
+	internal [][]internalConnection
+	outputs  []map[string]types.OutputCallback
+
+	spikeCount int64
+	stepCount  int64
+
+	stepMutex    sync.Mutex
+	outputsMutex sync.RWMutex
+
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+	running bool
+}
+
+// NewPopulation validates the configuration and allocates dense state.
+func NewPopulation(id string, config PopulationConfig) (*Population, error) {
+	if config.Size <= 0 {
+		return nil, fmt.Errorf("population size must be positive: %d", config.Size)
+	}
+	if config.Threshold <= 0 {
+		return nil, fmt.Errorf("threshold must be positive: %f", config.Threshold)
+	}
+	if config.DecayRate < 0 || config.DecayRate > 1 {
+		return nil, fmt.Errorf("decay rate must be in [0,1]: %f", config.DecayRate)
+	}
+	if config.FireFactor == 0 {
+		config.FireFactor = 1.0
+	}
+	if config.StepInterval <= 0 {
+		config.StepInterval = BATCH_DEFAULT_STEP_INTERVAL
+	}
+	kernel := config.Kernel
+	if kernel == nil {
+		kernel = PortableKernel{}
+	}
+
+	p := &Population{
+		id:              id,
+		config:          config,
+		kernel:          kernel,
+		potential:       make([]float64, config.Size),
+		input:           make([]float64, config.Size),
+		refractoryUntil: make([]int64, config.Size),
+		fired:           make([]int, 0, config.Size),
+		pending:         make([]float64, config.Size),
+		members:         make([]*Member, config.Size),
+		internal:        make([][]internalConnection, config.Size),
+		outputs:         make([]map[string]types.OutputCallback, config.Size),
+	}
+
+	for i := 0; i < config.Size; i++ {
+		p.members[i] = &Member{
+			BaseComponent: component.NewBaseComponent(fmt.Sprintf("%s_%d", id, i), types.TypeNeuron, types.Position3D{}),
+			population:    p,
+			index:         i,
+		}
+		p.outputs[i] = make(map[string]types.OutputCallback)
+	}
+
+	return p, nil
+}
+
+// ID returns the population identifier.
+func (p *Population) ID() string {
+	return p.id
+}
+
+// Size returns the number of members.
+func (p *Population) Size() int {
+	return p.config.Size
+}
+
+// KernelName reports which backend performs the dense update.
+func (p *Population) KernelName() string {
+	return p.kernel.Name()
+}
+
+// Member returns the boundary adapter for neuron i.
+func (p *Population) Member(i int) *Member {
+	if i < 0 || i >= len(p.members) {
+		return nil
+	}
+	return p.members[i]
+}
+
+// Connect adds a dense-path connection between two members. Spikes on this
+// path are added to the target's input on the following step.
+func (p *Population) Connect(from, to int, weight float64) error {
+	if from < 0 || from >= p.config.Size || to < 0 || to >= p.config.Size {
+		return fmt.Errorf("member index out of range: %d -> %d", from, to)
+	}
+	p.stepMutex.Lock()
+	defer p.stepMutex.Unlock()
+	p.internal[from] = append(p.internal[from], internalConnection{target: to, weight: weight})
+	return nil
+}
+
+// AddOutputCallback attaches an outgoing synapse to member i.
+func (p *Population) AddOutputCallback(i int, synapseID string, callback types.OutputCallback) error {
+	if i < 0 || i >= p.config.Size {
+		return fmt.Errorf("member index out of range: %d", i)
+	}
+	p.outputsMutex.Lock()
+	defer p.outputsMutex.Unlock()
+	p.outputs[i][synapseID] = callback
+	return nil
+}
+
+// RemoveOutputCallback detaches an outgoing synapse from member i.
+func (p *Population) RemoveOutputCallback(i int, synapseID string) {
+	if i < 0 || i >= p.config.Size {
+		return
+	}
+	p.outputsMutex.Lock()
+	defer p.outputsMutex.Unlock()
+	delete(p.outputs[i], synapseID)
+}
+
+// Inject adds input to member i for the next step.
+func (p *Population) Inject(i int, value float64) {
+	if i < 0 || i >= p.config.Size {
+		return
+	}
+	p.pendingMutex.Lock()
+	p.pending[i] += value
+	p.pendingMutex.Unlock()
+}
+
+// Potential returns the membrane potential of member i.
+func (p *Population) Potential(i int) float64 {
+	p.stepMutex.Lock()
+	defer p.stepMutex.Unlock()
+	if i < 0 || i >= p.config.Size {
+		return 0
+	}
+	return p.potential[i]
+}
+
+// Step advances the whole population by one time step and returns the
+// indices of members that fired. Outputs are dispatched before returning.
+func (p *Population) Step(now time.Time) []int {
+	p.stepMutex.Lock()
+
+	// Swap pending external input into the dense input array
+	p.pendingMutex.Lock()
+	for i, v := range p.pending {
+		p.input[i] += v
+		p.pending[i] = 0
+	}
+	p.pendingMutex.Unlock()
+
+	nowNanos := now.UnixNano()
+	p.fired = p.kernel.Step(p.potential, p.input, p.refractoryUntil, nowNanos,
+		p.config.Threshold, p.config.DecayRate, p.fired[:0])
+
+	// Reset fired members, route internal spikes and capture output values
+	fired := make([]int, len(p.fired))
+	values := make([]float64, len(p.fired))
+	refractoryEnd := nowNanos + int64(p.config.RefractoryPeriod)
+	for k, i := range p.fired {
+		fired[k] = i
+		values[k] = p.potential[i] * p.config.FireFactor
+		p.potential[i] = 0
+		p.refractoryUntil[i] = refractoryEnd
+		for _, conn := range p.internal[i] {
+			p.input[conn.target] += values[k] * conn.weight
+		}
+	}
+	p.spikeCount += int64(len(fired))
+	p.stepCount++
+	p.stepMutex.Unlock()
+
+	// Boundary outputs are dispatched without holding the step lock
+	for k, i := range fired {
+		p.dispatch(i, values[k], now)
+	}
+	return fired
+}
+
+// dispatch sends a member's spike through its outgoing synapses.
+func (p *Population) dispatch(i int, value float64, fireTime time.Time) {
+	p.outputsMutex.RLock()
+	callbacks := make(map[string]types.OutputCallback, len(p.outputs[i]))
+	for id, cb := range p.outputs[i] {
+		callbacks[id] = cb
+	}
+	p.outputsMutex.RUnlock()
+
+	sourceID := p.members[i].ID()
+	for synapseID, callback := range callbacks {
+		msg := types.NeuralSignal{
+			Value:                value,
+			Timestamp:            fireTime,
+			SourceID:             sourceID,
+			SynapseID:            synapseID,
+			NeurotransmitterType: types.LigandGlutamate,
+		}
+		if callback.GetTargetID != nil {
+			msg.TargetID = callback.GetTargetID()
+		}
+		if callback.TransmitMessage != nil {
+			callback.TransmitMessage(msg)
+		}
+	}
+}
+
+// Start runs Step on a ticker until Stop is called.
+func (p *Population) Start() error {
+	p.stepMutex.Lock()
+	if p.running {
+		p.stepMutex.Unlock()
+		return fmt.Errorf("population %s already running", p.id)
+	}
+	p.running = true
+	p.stopCh = make(chan struct{})
+	p.stepMutex.Unlock()
+
+	p.wg.Add(1)
+	run := func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.config.StepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-p.stopCh:
+				return
+			case now := <-ticker.C:
+				p.Step(now)
+			}
+		}
+	}
+	if p.config.Affinity != nil {
+		p.config.Affinity.Go(run)
+	} else {
+		go run()
+	}
+	return nil
+}
+
+// Stop halts the stepping goroutine.
+func (p *Population) Stop() error {
+	p.stepMutex.Lock()
+	if !p.running {
+		p.stepMutex.Unlock()
+		return nil
+	}
+	p.running = false
+	close(p.stopCh)
+	p.stepMutex.Unlock()
+
+	p.wg.Wait()
+	return nil
+}
+
+// GetStats returns aggregate counters for monitoring.
+func (p *Population) GetStats() map[string]interface{} {
+	p.stepMutex.Lock()
+	defer p.stepMutex.Unlock()
+	return map[string]interface{}{
+		"size":        p.config.Size,
+		"kernel":      p.kernel.Name(),
+		"steps":       p.stepCount,
+		"spike_count": p.spikeCount,
+		"running":     p.running,
+	}
+}
+
+// =================================================================================
+// MEMBER - BOUNDARY ADAPTER
+// =================================================================================
+
+// Member exposes one batch neuron as a component so it can be wired to
+// synapses and goroutine-based neurons.
+type Member struct {
+	*component.BaseComponent
+	population *Population
+	index      int
+}
+
+// Index returns the member's position in the population arrays.
+func (m *Member) Index() int {
+	return m.index
+}
+
+// Receive accumulates the signal into the member's input for the next step.
+func (m *Member) Receive(msg types.NeuralSignal) {
+	m.population.Inject(m.index, msg.Value)
+}
+
+// ScheduleDelayedDelivery lets members act as pre-synaptic neurons for
+// ordinary synapses. Delivery uses the configured DelayScheduler, or a timer
+// since members have no axon queue.
+func (m *Member) ScheduleDelayedDelivery(msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration) {
+	if delay <= 0 {
+		target.Receive(msg)
+		return
+	}
+	if scheduler := m.population.config.DelayScheduler; scheduler != nil {
+		scheduler(msg, target, delay)
+		return
+	}
+	time.AfterFunc(delay, func() { target.Receive(msg) })
+}
+
+// AddOutputCallback attaches an outgoing synapse to this member. This matches
+// the neuron.Neuron method so the matrix can wire members the same way.
+func (m *Member) AddOutputCallback(synapseID string, callback types.OutputCallback) {
+	m.population.AddOutputCallback(m.index, synapseID, callback)
+}
+
+// RemoveOutputCallback detaches an outgoing synapse from this member.
+func (m *Member) RemoveOutputCallback(synapseID string) {
+	m.population.RemoveOutputCallback(m.index, synapseID)
+}
@@ -0,0 +1,211 @@
+/*
+=================================================================================
+CHAOS - CONCURRENCY FAULT INJECTION AND INVARIANT CHECKING
+=================================================================================
+
+Every neuron runs its own goroutine, and most of the concurrency bugs that
+survive the race detector are orderings that never happen on an idle
+developer machine: an input integrated just after a spike, a delivery that
+overtakes an earlier one, a component shut down mid-run. Chaos mode makes
+those orderings common. A Monkey perturbs a running network:
+
+  - pauses: at the scheduling points of each neuron's processing loop it
+    sleeps for a random time up to MaxPause, or yields
+  - reordering: axonal deliveries get up to MaxJitter of extra delay, so
+    spikes overtake each other; a delivery is never earlier than its
+    configured delay, and FIFO neurons are never reordered
+  - cancellations: contexts handed out by Context are cancelled at a random
+    time, as a failing caller or a shutdown would
+
+A Checker watches the invariants that must survive any schedule and records
+each violation:
+
+	monkey, _ := chaos.New(chaos.Config{Seed: 7, PauseProbability: 0.2, MaxPause: time.Millisecond, MaxJitter: 500 * time.Microsecond})
+	monkey.Attach(neurons...)
+	checker := chaos.NewChecker()
+	checker.WatchNeurons(neurons...) // no spike within the refractory period
+	checker.WatchSynapses(synapses...) // weights keep their sign and bounds
+	... run the workload ...
+	if err := checker.Err(); err != nil {
+	    t.Fatalf("seed %d: %v", monkey.Seed(), err)
+	}
+
+The random sequence is reproducible from Seed, but the goroutine schedule
+is not, so a failing seed makes a failure likely to recur rather than
+certain. Chaos mode is for tests; it slows a network down by design.
+=================================================================================
+*/
+
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+)
+
+// CHAOS_DEFAULT_CANCEL_WITHIN bounds the time after which Context cancels
+// when CancelWithin is zero.
+const CHAOS_DEFAULT_CANCEL_WITHIN = 100 * time.Millisecond
+
+// ErrInjectedCancel is the cause of contexts cancelled by chaos mode.
+var ErrInjectedCancel = errors.New("chaos: injected cancellation")
+
+// Config sets the kinds and rates of perturbation. Zero disables each kind.
+type Config struct {
+	Seed int64 // Random seed (0 = from the clock; see Monkey.Seed)
+
+	PauseProbability float64       // Chance a scheduling point pauses, in [0, 1]
+	MaxPause         time.Duration // Longest pause (0 = yield only)
+
+	ReorderProbability float64       // Chance a delivery is delayed, in [0, 1] (0 = every delivery when MaxJitter > 0)
+	MaxJitter          time.Duration // Largest extra delivery delay
+
+	CancelProbability float64       // Chance a context from Context is cancelled early, in [0, 1]
+	CancelWithin      time.Duration // Cancellation happens within this time
+}
+
+// Monkey injects pauses, delivery jitter and cancellations. It implements
+// neuron.ChaosInjector and is safe for concurrent use.
+type Monkey struct {
+	config Config
+
+	mu  sync.Mutex
+	rng *rand.Rand
+
+	pauses   atomic.Int64
+	jittered atomic.Int64
+	cancels  atomic.Int64
+}
+
+// New creates a monkey.
+func New(config Config) (*Monkey, error) {
+	for name, p := range map[string]float64{
+		"pause": config.PauseProbability, "reorder": config.ReorderProbability, "cancel": config.CancelProbability,
+	} {
+		if math.IsNaN(p) || p < 0 || p > 1 {
+			return nil, fmt.Errorf("chaos %s probability must be in [0, 1]: %f", name, p)
+		}
+	}
+	if config.MaxPause < 0 || config.MaxJitter < 0 || config.CancelWithin < 0 {
+		return nil, fmt.Errorf("chaos durations cannot be negative: %+v", config)
+	}
+	if config.CancelWithin == 0 {
+		config.CancelWithin = CHAOS_DEFAULT_CANCEL_WITHIN
+	}
+	if config.Seed == 0 {
+		config.Seed = time.Now().UnixNano()
+	}
+	return &Monkey{config: config, rng: rand.New(rand.NewSource(config.Seed))}, nil
+}
+
+// Seed returns the seed in use, to reproduce a failing run.
+func (m *Monkey) Seed() int64 {
+	return m.config.Seed
+}
+
+// Config returns the configuration with defaults applied.
+func (m *Monkey) Config() Config {
+	return m.config
+}
+
+// Attach installs the monkey on neurons.
+func (m *Monkey) Attach(neurons ...*neuron.Neuron) {
+	for _, n := range neurons {
+		n.SetChaos(m)
+	}
+}
+
+// Detach removes chaos injection from neurons.
+func Detach(neurons ...*neuron.Neuron) {
+	for _, n := range neurons {
+		n.SetChaos(nil)
+	}
+}
+
+// chance reports whether an event of probability p happens.
+func (m *Monkey) chance(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.rng.Float64() < p
+}
+
+// duration returns a random duration in [0, max).
+func (m *Monkey) duration(max time.Duration) time.Duration {
+	if max <= 0 {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return time.Duration(m.rng.Int63n(int64(max)))
+}
+
+// Pause implements neuron.ChaosInjector.
+func (m *Monkey) Pause(neuronID string, point neuron.ChaosPoint) {
+	if !m.chance(m.config.PauseProbability) {
+		return
+	}
+	m.pauses.Add(1)
+	if pause := m.duration(m.config.MaxPause); pause > 0 {
+		time.Sleep(pause)
+		return
+	}
+	runtime.Gosched()
+}
+
+// Jitter implements neuron.ChaosInjector.
+func (m *Monkey) Jitter(neuronID, targetID string, delay time.Duration) time.Duration {
+	if m.config.MaxJitter <= 0 {
+		return 0
+	}
+	if m.config.ReorderProbability > 0 && !m.chance(m.config.ReorderProbability) {
+		return 0
+	}
+	jitter := m.duration(m.config.MaxJitter)
+	if jitter > 0 {
+		m.jittered.Add(1)
+	}
+	return jitter
+}
+
+// Context returns a child of parent that, with CancelProbability, is
+// cancelled with cause ErrInjectedCancel at a random time within
+// CancelWithin. Pass it to anything that runs until its context ends
+// (aer.Player.Run, ros2.Bridge.Run, a test's own workers).
+func (m *Monkey) Context(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	if !m.chance(m.config.CancelProbability) {
+		return ctx, func() { cancel(context.Canceled) }
+	}
+	timer := time.AfterFunc(m.duration(m.config.CancelWithin), func() {
+		if ctx.Err() == nil {
+			m.cancels.Add(1)
+		}
+		cancel(ErrInjectedCancel)
+	})
+	return ctx, func() {
+		timer.Stop()
+		cancel(context.Canceled)
+	}
+}
+
+// GetStats returns the number of injected perturbations.
+func (m *Monkey) GetStats() map[string]interface{} {
+	return map[string]interface{}{
+		"seed":     m.config.Seed,
+		"pauses":   m.pauses.Load(),
+		"jittered": m.jittered.Load(),
+		"cancels":  m.cancels.Load(),
+	}
+}
@@ -0,0 +1,111 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestChaosRunKeepsInvariants drives a plastic two-neuron circuit under
+// pauses and delivery jitter and checks that no invariant breaks.
+func TestChaosRunKeepsInvariants(t *testing.T) {
+	pre := neuron.NewNeuron("pre", 1.0, 0.95, 3*time.Millisecond, 1.0, 0, 0)
+	post := neuron.NewNeuron("post", 1.0, 0.95, 3*time.Millisecond, 1.0, 0, 0)
+	syn, err := synapse.NewSynapse("syn", pre, post, synapse.WithWeight(1.5), synapse.WithDelay(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pre.AddOutputCallback("syn", types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			syn.Transmit(msg.Value)
+			return nil
+		},
+		GetWeight:   syn.GetWeight,
+		GetDelay:    syn.GetDelay,
+		GetTargetID: syn.GetPostsynapticID,
+	})
+
+	monkey, err := New(Config{Seed: 42, PauseProbability: 0.3, MaxPause: 200 * time.Microsecond, MaxJitter: time.Millisecond})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	monkey.Attach(pre, post)
+	checker := NewChecker()
+	checker.WatchNeurons(pre, post)
+	checker.WatchSynapses(syn)
+	stop := checker.Start(5 * time.Millisecond)
+	defer stop()
+
+	pre.Start()
+	post.Start()
+	defer pre.Stop()
+	defer post.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+	for ctx.Err() == nil {
+		pre.Receive(types.NeuralSignal{Value: 1.5, SourceID: "drive", Timestamp: time.Now()})
+		time.Sleep(500 * time.Microsecond)
+	}
+
+	if err := checker.Err(); err != nil {
+		t.Fatalf("seed %d: %v", monkey.Seed(), err)
+	}
+	stats := monkey.GetStats()
+	if stats["pauses"].(int64) == 0 || stats["jittered"].(int64) == 0 {
+		t.Errorf("Expected pauses and jittered deliveries, got %v", stats)
+	}
+	if spikes := checker.GetStats()["spikes"].(int64); spikes == 0 {
+		t.Error("Expected the checker to observe spikes")
+	}
+}
+
+// TestCheckerAndCancellation verifies that violations are reported and that
+// injected cancellations carry their cause.
+func TestCheckerAndCancellation(t *testing.T) {
+	n := neuron.NewNeuron("n", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	syn, err := synapse.NewSynapse("s", n, n, synapse.WithWeight(0.5), synapse.WithWeightBounds(0, 1))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	checker := NewChecker()
+	checker.WatchNeurons(n)
+	checker.WatchSynapses(syn)
+	if err := checker.Err(); err != nil {
+		t.Fatalf("Expected no violations, got %v", err)
+	}
+
+	start := time.Now()
+	checker.recordSpike("n", start)
+	checker.recordSpike("n", start.Add(time.Millisecond))
+	checker.recordSpike("n", start.Add(10*time.Millisecond))
+	checker.synapses[0].negative = true // as if the weight had started negative
+	violations := checker.Check()
+	if len(violations) != 2 || violations[0].Invariant != InvariantRefractory || violations[1].Invariant != InvariantWeightSign {
+		t.Errorf("Expected refractory and sign violations, got %v", violations)
+	}
+	if checker.Err() == nil {
+		t.Error("Expected an error listing the violations")
+	}
+
+	monkey, _ := New(Config{Seed: 1, CancelProbability: 1, CancelWithin: 10 * time.Millisecond})
+	ctx, cancel := monkey.Context(context.Background())
+	defer cancel()
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected an injected cancellation")
+	}
+	if !errors.Is(context.Cause(ctx), ErrInjectedCancel) {
+		t.Errorf("Expected cause ErrInjectedCancel, got %v", context.Cause(ctx))
+	}
+
+	if _, err := New(Config{PauseProbability: 2}); err == nil {
+		t.Error("Expected error for probability above 1")
+	}
+}
@@ -0,0 +1,227 @@
+package chaos
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// INVARIANTS
+// =================================================================================
+//
+// Spikes are checked as they happen: the checker adds an output callback to
+// each watched neuron and compares every spike with the previous one.
+// Weights are sampled: Check reads every watched synapse, so call it while
+// the workload runs (or use Start) as well as at the end. The refractory
+// period and the weight sign are taken when a component is watched.
+
+// Invariant names a property that must hold under any schedule.
+type Invariant string
+
+const (
+	InvariantRefractory   Invariant = "refractory_spike" // A spike within the absolute refractory period
+	InvariantWeightSign   Invariant = "weight_sign"      // A weight changed sign (a non-negative weight went negative)
+	InvariantWeightBounds Invariant = "weight_bounds"    // A weight left the synapse's plasticity bounds
+)
+
+// checkerCallbackID names the output callback that reports spikes.
+const checkerCallbackID = "chaos_invariants"
+
+// Violation is one observed breach of an invariant.
+type Violation struct {
+	Invariant   Invariant `json:"invariant"`
+	ComponentID string    `json:"component_id"`
+	Time        time.Time `json:"time"`
+	Detail      string    `json:"detail"`
+}
+
+// String formats the violation.
+func (v Violation) String() string {
+	return fmt.Sprintf("%s %s: %s", v.Invariant, v.ComponentID, v.Detail)
+}
+
+// watchedNeuron is the spike state of one neuron.
+type watchedNeuron struct {
+	refractory time.Duration
+	lastSpike  time.Time
+}
+
+// watchedSynapse is a synapse and the sign its weight must keep.
+type watchedSynapse struct {
+	synapse  *synapse.BasicSynapse
+	negative bool
+}
+
+// Checker records invariant violations. It is safe for concurrent use.
+type Checker struct {
+	mu         sync.Mutex
+	neurons    map[string]*watchedNeuron
+	synapses   []watchedSynapse
+	violations []Violation
+	spikes     int64
+	checks     int64
+}
+
+// NewChecker creates a checker watching nothing.
+func NewChecker() *Checker {
+	return &Checker{neurons: make(map[string]*watchedNeuron)}
+}
+
+// WatchNeurons checks that the neurons never fire twice within their
+// absolute refractory period.
+func (c *Checker) WatchNeurons(neurons ...*neuron.Neuron) {
+	for _, n := range neurons {
+		id := n.ID()
+		c.mu.Lock()
+		c.neurons[id] = &watchedNeuron{refractory: n.GetRefractoryPeriod()}
+		c.mu.Unlock()
+
+		// The callback runs under the neuron's state lock, so it only
+		// touches the checker
+		n.AddOutputCallback(checkerCallbackID, types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				c.recordSpike(id, msg.Timestamp)
+				return nil
+			},
+			GetWeight:   func() float64 { return 0 },
+			GetDelay:    func() time.Duration { return 0 },
+			GetTargetID: func() string { return checkerCallbackID },
+		})
+	}
+}
+
+// WatchSynapses checks that the synapses' weights keep their current sign
+// and stay within their plasticity bounds.
+func (c *Checker) WatchSynapses(synapses ...*synapse.BasicSynapse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range synapses {
+		c.synapses = append(c.synapses, watchedSynapse{synapse: s, negative: s.GetWeight() < 0})
+	}
+}
+
+// recordSpike checks a spike of neuronID at t against the previous one.
+func (c *Checker) recordSpike(neuronID string, t time.Time) {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.spikes++
+	watched := c.neurons[neuronID]
+	if watched == nil {
+		return
+	}
+	if !watched.lastSpike.IsZero() {
+		if interval := t.Sub(watched.lastSpike); interval < watched.refractory {
+			c.violations = append(c.violations, Violation{
+				Invariant:   InvariantRefractory,
+				ComponentID: neuronID,
+				Time:        t,
+				Detail:      fmt.Sprintf("spike %v after the previous one, refractory period %v", interval, watched.refractory),
+			})
+		}
+	}
+	if t.After(watched.lastSpike) {
+		watched.lastSpike = t
+	}
+}
+
+// Check samples the weights of the watched synapses and returns all
+// violations recorded so far.
+func (c *Checker) Check() []Violation {
+	c.mu.Lock()
+	synapses := append([]watchedSynapse(nil), c.synapses...)
+	c.mu.Unlock()
+
+	var found []Violation
+	now := time.Now()
+	for _, watched := range synapses {
+		s := watched.synapse
+		weight := s.GetWeight()
+		config := s.GetPlasticityConfig()
+		if (weight < 0) != watched.negative && weight != 0 {
+			found = append(found, Violation{
+				Invariant:   InvariantWeightSign,
+				ComponentID: s.ID(),
+				Time:        now,
+				Detail:      fmt.Sprintf("weight %g changed sign", weight),
+			})
+		}
+		if config.MaxWeight > config.MinWeight && (weight < config.MinWeight || weight > config.MaxWeight) {
+			found = append(found, Violation{
+				Invariant:   InvariantWeightBounds,
+				ComponentID: s.ID(),
+				Time:        now,
+				Detail:      fmt.Sprintf("weight %g outside [%g, %g]", weight, config.MinWeight, config.MaxWeight),
+			})
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks++
+	c.violations = append(c.violations, found...)
+	return append([]Violation(nil), c.violations...)
+}
+
+// Start samples the weights every interval until the returned stop function
+// is called.
+func (c *Checker) Start(interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.Check()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// Violations returns the violations recorded so far without sampling.
+func (c *Checker) Violations() []Violation {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Violation(nil), c.violations...)
+}
+
+// Err samples the weights and returns an error listing every violation, or
+// nil if all invariants held.
+func (c *Checker) Err() error {
+	violations := c.Check()
+	if len(violations) == 0 {
+		return nil
+	}
+	lines := make([]string, len(violations))
+	for i, v := range violations {
+		lines[i] = v.String()
+	}
+	return fmt.Errorf("%d invariant violations:\n%s", len(violations), strings.Join(lines, "\n"))
+}
+
+// GetStats returns the number of observed spikes, weight samples and
+// violations.
+func (c *Checker) GetStats() map[string]interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return map[string]interface{}{
+		"neurons":    len(c.neurons),
+		"synapses":   len(c.synapses),
+		"spikes":     c.spikes,
+		"checks":     c.checks,
+		"violations": len(c.violations),
+	}
+}
@@ -0,0 +1,298 @@
+/*
+=================================================================================
+COCHLEA - AUDIO SPIKE ENCODING WITH AN AUDITORY FILTERBANK
+=================================================================================
+
+Sound reaches the brain as spike trains of auditory nerve fibres, each tuned
+to a narrow band of frequencies by its place on the basilar membrane. An
+Encoder models that front end so speech and keyword experiments can feed
+temporal neurons with the same kind of input:
+
+  - Filterbank: one gammatone filter per channel, centre frequencies evenly
+    spaced on the ERB-rate or mel scale between MinFreq and MaxFreq.
+  - Inner hair cells: half-wave rectification and power-law compression of
+    each filter output, so the channel's drive follows the positive
+    half-cycles of its band.
+  - Auditory nerve: a leaky integrate-and-fire unit per channel with a
+    refractory period and spike-frequency adaptation. Each spike raises the
+    unit's threshold, which relaxes over AdaptationTime, so an onset fires a
+    burst and a sustained sound a lower steady rate. Below a few kHz the fast
+    membrane phase-locks spikes to the waveform, as real fibres do.
+
+	encoder, _ := cochlea.NewEncoder(cochlea.Config{SampleRate: 16000})
+	for chunk := range audio { // PCM in any chunk size
+	    spikes := encoder.ProcessPCM16(chunk)
+	    cochlea.Schedule(spikes, inputs, 1.5, offset, runner.Schedule)
+	}
+
+Spike times count from the start of the stream, so chunked and one-shot
+processing give the same spikes. Events turns spikes into AER events (x =
+channel) for aer.Player and event-based tooling.
+=================================================================================
+*/
+
+package cochlea
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/aer"
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// Encoder defaults, chosen for speech at 16 kHz with input in [-1, 1].
+const (
+	COCHLEA_DEFAULT_CHANNELS        = 32
+	COCHLEA_DEFAULT_MIN_FREQ        = 100.0
+	COCHLEA_DEFAULT_MAX_FREQ        = 8000.0 // Capped at COCHLEA_MAX_FREQ_FRACTION of the sample rate
+	COCHLEA_DEFAULT_GAIN            = 1.0
+	COCHLEA_DEFAULT_COMPRESSION     = 0.3 // Power-law exponent of the hair cell
+	COCHLEA_DEFAULT_THRESHOLD       = 0.15
+	COCHLEA_DEFAULT_MEMBRANE_TIME   = 1 * time.Millisecond
+	COCHLEA_DEFAULT_REFRACTORY      = 1 * time.Millisecond
+	COCHLEA_DEFAULT_ADAPTATION_STEP = 0.02
+	COCHLEA_DEFAULT_ADAPTATION_TIME = 50 * time.Millisecond
+	COCHLEA_MAX_FREQ_FRACTION       = 0.45
+	COCHLEA_SOURCE_ID               = "cochlea"
+)
+
+// Config configures an Encoder. Zero values select the defaults, except
+// SampleRate, which is required.
+type Config struct {
+	SampleRate int     // Samples per second of the input
+	Channels   int     // Filterbank channels
+	MinFreq    float64 // Lowest centre frequency (Hz)
+	MaxFreq    float64 // Highest centre frequency (Hz)
+	Scale      Scale   // Spacing of centre frequencies
+
+	// Hair cell
+	Gain        float64 // Input scaling before compression
+	Compression float64 // Exponent of the power-law compression, in (0, 1]
+
+	// Auditory nerve
+	Threshold      float64       // Resting spike threshold
+	MembraneTime   time.Duration // Leak time constant
+	Refractory     time.Duration // Minimum time between spikes of a channel
+	AdaptationStep float64       // Threshold increase per spike
+	AdaptationTime time.Duration // Recovery time constant of the threshold
+}
+
+// Spike is one auditory nerve spike.
+type Spike struct {
+	Channel int           `json:"channel"`
+	Time    time.Duration `json:"time"` // Since the start of the stream
+}
+
+// channel is one channel's filter and nerve state.
+type channel struct {
+	filter     gammatone
+	potential  float64
+	adaptation float64 // Threshold increase from recent spikes
+	lastSpike  int64   // Sample index of the last spike (-1 = none)
+	spikes     int64
+}
+
+// Encoder converts audio into spike trains, one per channel. It keeps its
+// state between calls, so audio can be streamed in chunks. It is not safe
+// for concurrent use.
+type Encoder struct {
+	config  Config
+	centers []float64
+	chans   []channel
+
+	leak       float64 // Membrane decay per sample
+	recovery   float64 // Adaptation decay per sample
+	refractory int64   // Refractory period in samples
+	sample     int64   // Samples processed
+}
+
+// NewEncoder validates the configuration and builds the filterbank.
+func NewEncoder(config Config) (*Encoder, error) {
+	if err := applyDefaults(&config); err != nil {
+		return nil, err
+	}
+	fs := float64(config.SampleRate)
+	e := &Encoder{
+		config:     config,
+		centers:    config.Scale.CenterFrequencies(config.Channels, config.MinFreq, config.MaxFreq),
+		leak:       math.Exp(-1 / (fs * config.MembraneTime.Seconds())),
+		recovery:   math.Exp(-1 / (fs * config.AdaptationTime.Seconds())),
+		refractory: int64(math.Ceil(config.Refractory.Seconds() * fs)),
+	}
+	e.chans = make([]channel, config.Channels)
+	for i, cf := range e.centers {
+		e.chans[i] = channel{filter: newGammatone(cf, fs), lastSpike: -1}
+	}
+	return e, nil
+}
+
+// applyDefaults fills zero settings and validates the rest.
+func applyDefaults(c *Config) error {
+	if c.SampleRate <= 0 {
+		return fmt.Errorf("cochlea sample rate must be positive: %d", c.SampleRate)
+	}
+	if c.Channels < 0 || c.MinFreq < 0 || c.MaxFreq < 0 || c.Gain < 0 || c.Threshold < 0 ||
+		c.MembraneTime < 0 || c.Refractory < 0 || c.AdaptationStep < 0 || c.AdaptationTime < 0 {
+		return fmt.Errorf("cochlea settings cannot be negative")
+	}
+	if c.Scale != ScaleERB && c.Scale != ScaleMel {
+		return fmt.Errorf("unknown cochlea frequency scale: %v", c.Scale)
+	}
+	if c.Compression < 0 || c.Compression > 1 {
+		return fmt.Errorf("cochlea compression must be in (0, 1]: %f", c.Compression)
+	}
+
+	nyquistCap := COCHLEA_MAX_FREQ_FRACTION * float64(c.SampleRate)
+	if c.Channels == 0 {
+		c.Channels = COCHLEA_DEFAULT_CHANNELS
+	}
+	if c.MinFreq == 0 {
+		c.MinFreq = COCHLEA_DEFAULT_MIN_FREQ
+	}
+	if c.MaxFreq == 0 {
+		c.MaxFreq = math.Min(COCHLEA_DEFAULT_MAX_FREQ, nyquistCap)
+	}
+	if c.Gain == 0 {
+		c.Gain = COCHLEA_DEFAULT_GAIN
+	}
+	if c.Compression == 0 {
+		c.Compression = COCHLEA_DEFAULT_COMPRESSION
+	}
+	if c.Threshold == 0 {
+		c.Threshold = COCHLEA_DEFAULT_THRESHOLD
+	}
+	if c.MembraneTime == 0 {
+		c.MembraneTime = COCHLEA_DEFAULT_MEMBRANE_TIME
+	}
+	if c.Refractory == 0 {
+		c.Refractory = COCHLEA_DEFAULT_REFRACTORY
+	}
+	if c.AdaptationStep == 0 {
+		c.AdaptationStep = COCHLEA_DEFAULT_ADAPTATION_STEP
+	}
+	if c.AdaptationTime == 0 {
+		c.AdaptationTime = COCHLEA_DEFAULT_ADAPTATION_TIME
+	}
+	if c.MinFreq >= c.MaxFreq {
+		return fmt.Errorf("cochlea frequency range is empty: [%f, %f]", c.MinFreq, c.MaxFreq)
+	}
+	if c.MaxFreq > nyquistCap {
+		return fmt.Errorf("cochlea max frequency %f Hz is too close to the Nyquist frequency of %d Hz audio",
+			c.MaxFreq, c.SampleRate)
+	}
+	return nil
+}
+
+// Process encodes the next samples (nominally in [-1, 1]) and returns their
+// spikes in time order.
+func (e *Encoder) Process(samples []float64) []Spike {
+	var spikes []Spike
+	for _, x := range samples {
+		x *= e.config.Gain
+		for i := range e.chans {
+			ch := &e.chans[i]
+			drive := math.Pow(math.Max(0, ch.filter.filter(x)), e.config.Compression)
+
+			ch.potential = ch.potential*e.leak + drive*(1-e.leak)
+			ch.adaptation *= e.recovery
+			if ch.lastSpike >= 0 && e.sample-ch.lastSpike < e.refractory {
+				continue
+			}
+			if ch.potential >= e.config.Threshold+ch.adaptation {
+				ch.potential = 0
+				ch.adaptation += e.config.AdaptationStep
+				ch.lastSpike = e.sample
+				ch.spikes++
+				spikes = append(spikes, Spike{Channel: i, Time: e.Elapsed()})
+			}
+		}
+		e.sample++
+	}
+	return spikes
+}
+
+// ProcessPCM16 encodes signed 16-bit PCM samples.
+func (e *Encoder) ProcessPCM16(samples []int16) []Spike {
+	return e.Process(PCM16ToFloat(samples))
+}
+
+// CenterFrequencies returns each channel's centre frequency in Hz.
+func (e *Encoder) CenterFrequencies() []float64 {
+	return append([]float64(nil), e.centers...)
+}
+
+// Config returns the configuration with defaults applied.
+func (e *Encoder) Config() Config {
+	return e.config
+}
+
+// Elapsed returns the duration of the audio processed so far.
+func (e *Encoder) Elapsed() time.Duration {
+	return time.Duration(e.sample) * time.Second / time.Duration(e.config.SampleRate)
+}
+
+// Reset clears all filter and nerve state and restarts the stream clock.
+func (e *Encoder) Reset() {
+	fs := float64(e.config.SampleRate)
+	for i, cf := range e.centers {
+		e.chans[i] = channel{filter: newGammatone(cf, fs), lastSpike: -1}
+	}
+	e.sample = 0
+}
+
+// GetStats returns the audio processed and the spike count per channel.
+func (e *Encoder) GetStats() map[string]interface{} {
+	perChannel := make([]int64, len(e.chans))
+	var total int64
+	for i, ch := range e.chans {
+		perChannel[i] = ch.spikes
+		total += ch.spikes
+	}
+	return map[string]interface{}{
+		"channels":           len(e.chans),
+		"samples":            e.sample,
+		"elapsed":            e.Elapsed(),
+		"spikes":             total,
+		"spikes_per_channel": perChannel,
+	}
+}
+
+// =================================================================================
+// DELIVERY
+// =================================================================================
+
+// Schedule hands each spike to schedule (e.g. cosim.LockStep.Schedule) as
+// an input of value gain to its channel's target. Delays are measured from
+// offset, the stream time that corresponds to the scheduler's present;
+// spikes before it are delivered with no delay. Spikes of channels without
+// a target are skipped. It returns the number of spikes scheduled.
+func Schedule(spikes []Spike, targets []component.MessageReceiver, gain float64, offset time.Duration, schedule batch.DelayScheduler) int {
+	scheduled := 0
+	for _, spike := range spikes {
+		if spike.Channel < 0 || spike.Channel >= len(targets) || targets[spike.Channel] == nil {
+			continue
+		}
+		target := targets[spike.Channel]
+		schedule(types.NeuralSignal{
+			Value:    gain,
+			SourceID: COCHLEA_SOURCE_ID,
+			TargetID: target.ID(),
+		}, target, max(0, spike.Time-offset))
+		scheduled++
+	}
+	return scheduled
+}
+
+// Events converts spikes into AER events with the channel as x, y = 0 and ON
+// polarity, like the address events of silicon cochleas.
+func Events(spikes []Spike) []aer.Event {
+	events := make([]aer.Event, len(spikes))
+	for i, spike := range spikes {
+		events[i] = aer.Event{Timestamp: spike.Time.Microseconds(), X: uint16(spike.Channel), Polarity: true}
+	}
+	return events
+}
@@ -0,0 +1,168 @@
+package cochlea
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// tone returns duration of a sine at hz and amplitude, sampled at rate.
+func tone(hz, amplitude float64, duration time.Duration, rate int) []float64 {
+	samples := make([]float64, int(duration.Seconds()*float64(rate)))
+	for i := range samples {
+		samples[i] = amplitude * math.Sin(2*math.Pi*hz*float64(i)/float64(rate))
+	}
+	return samples
+}
+
+// TestEncoder_TonotopyAndAdaptation verifies that tones excite the channels
+// tuned to them, that responses adapt, and that streaming in chunks gives
+// the same spikes as one call.
+func TestEncoder_TonotopyAndAdaptation(t *testing.T) {
+	for _, hz := range []float64{500, 4000} {
+		encoder, err := NewEncoder(Config{SampleRate: 16000})
+		if err != nil {
+			t.Fatalf("Failed to create encoder: %v", err)
+		}
+		spikes := encoder.Process(tone(hz, 0.3, 300*time.Millisecond, 16000))
+		counts := make([]int, COCHLEA_DEFAULT_CHANNELS)
+		for _, s := range spikes {
+			counts[s.Channel]++
+		}
+		best := 0
+		for i := range counts {
+			if counts[i] > counts[best] {
+				best = i
+			}
+		}
+		cf := encoder.CenterFrequencies()[best]
+		if math.Abs(cf-hz)/hz > 0.15 {
+			t.Errorf("%.0f Hz: most spikes in channel %d tuned to %.0f Hz", hz, best, cf)
+		}
+		near := 0
+		for i := max(0, best-2); i <= min(len(counts)-1, best+2); i++ {
+			near += counts[i]
+		}
+		if near < len(spikes)*3/4 {
+			t.Errorf("%.0f Hz: only %d of %d spikes near the best channel", hz, near, len(spikes))
+		}
+
+		onset, sustained := 0, 0
+		for _, s := range spikes {
+			if s.Channel != best {
+				continue
+			}
+			if s.Time < 50*time.Millisecond {
+				onset++
+			} else if s.Time >= 250*time.Millisecond {
+				sustained++
+			}
+		}
+		t.Logf("%.0f Hz: channel %d (%.0f Hz), %d spikes in the first 50ms, %d in the last", hz, best, cf, onset, sustained)
+		if onset <= sustained || sustained == 0 {
+			t.Errorf("%.0f Hz: expected an adapting response, %d onset vs %d sustained spikes", hz, onset, sustained)
+		}
+	}
+
+	// Silence is silent; chunks match one-shot processing
+	encoder, _ := NewEncoder(Config{SampleRate: 16000, Scale: ScaleMel})
+	if spikes := encoder.Process(make([]float64, 1600)); len(spikes) != 0 {
+		t.Errorf("Expected no spikes for silence, got %d", len(spikes))
+	}
+	audio := tone(1000, 0.5, 100*time.Millisecond, 16000)
+	encoder.Reset()
+	whole := encoder.Process(audio)
+	encoder.Reset()
+	var chunked []Spike
+	for i := 0; i < len(audio); i += 333 {
+		chunked = append(chunked, encoder.Process(audio[i:min(len(audio), i+333)])...)
+	}
+	if len(whole) == 0 || !reflect.DeepEqual(whole, chunked) {
+		t.Errorf("Expected chunked processing to match: %d vs %d spikes", len(whole), len(chunked))
+	}
+	if encoder.Elapsed() != 100*time.Millisecond {
+		t.Errorf("Expected 100ms processed, got %v", encoder.Elapsed())
+	}
+
+	centers := encoder.CenterFrequencies()
+	if math.Abs(centers[0]-COCHLEA_DEFAULT_MIN_FREQ) > 1e-6 || math.Abs(centers[len(centers)-1]-7200) > 1e-6 {
+		t.Errorf("Expected mel centres from 100 Hz to 7200 Hz (0.45 × 16 kHz), got %.1f to %.1f", centers[0], centers[len(centers)-1])
+	}
+	for _, config := range []Config{
+		{},
+		{SampleRate: 16000, MinFreq: 5000, MaxFreq: 1000},
+		{SampleRate: 16000, MaxFreq: 8000},
+		{SampleRate: 16000, Compression: 2},
+		{SampleRate: 16000, Scale: Scale(5)},
+	} {
+		if _, err := NewEncoder(config); err == nil {
+			t.Errorf("Expected config %+v to be rejected", config)
+		}
+	}
+}
+
+// TestPCMInputAndDelivery verifies WAV decoding and spike delivery.
+func TestPCMInputAndDelivery(t *testing.T) {
+	// Stereo 16-bit WAV with an extra chunk before the data
+	var buf bytes.Buffer
+	frames := [][2]int16{{16384, 0}, {-32768, -32768}, {100, 300}}
+	write := func(v interface{}) { binary.Write(&buf, binary.LittleEndian, v) }
+	buf.WriteString("RIFF")
+	write(uint32(0))
+	buf.WriteString("WAVEfmt ")
+	write(uint32(16))
+	write([]uint16{1, 2})
+	write([]uint32{8000, 32000})
+	write([]uint16{4, 16})
+	buf.WriteString("LIST")
+	write(uint32(3))
+	buf.Write([]byte{1, 2, 3, 0}) // odd chunk plus pad byte
+	buf.WriteString("data")
+	write(uint32(len(frames) * 4))
+	write(frames)
+
+	wav, err := ReadWAV(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if wav.SampleRate != 8000 || wav.Channels != 2 || !reflect.DeepEqual(wav.Samples, []float64{0.25, -1, 200.0 / 32768}) {
+		t.Errorf("Unexpected WAV %+v", wav)
+	}
+	if _, err := ReadWAV(bytes.NewReader([]byte("RIFX....WAVE"))); err == nil {
+		t.Error("Expected error for a non-WAV file")
+	}
+
+	spikes := []Spike{{Channel: 1, Time: 5 * time.Millisecond}, {Channel: 0, Time: 12 * time.Millisecond}, {Channel: 3, Time: 13 * time.Millisecond}}
+	targets := []component.MessageReceiver{
+		&nullReceiver{component.NewBaseComponent("a0", types.TypeNeuron, types.Position3D{})},
+		&nullReceiver{component.NewBaseComponent("a1", types.TypeNeuron, types.Position3D{})},
+	}
+	var delays []time.Duration
+	var ids []string
+	n := Schedule(spikes, targets, 1.5, 10*time.Millisecond, func(msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration) {
+		if msg.Value != 1.5 || msg.SourceID != COCHLEA_SOURCE_ID {
+			t.Errorf("Unexpected input %+v", msg)
+		}
+		delays = append(delays, delay)
+		ids = append(ids, target.ID())
+	})
+	if n != 2 || !reflect.DeepEqual(delays, []time.Duration{0, 2 * time.Millisecond}) || !reflect.DeepEqual(ids, []string{"a1", "a0"}) {
+		t.Errorf("Expected 2 scheduled spikes, got %d: %v to %v", n, delays, ids)
+	}
+
+	events := Events(spikes)
+	if len(events) != 3 || events[2].X != 3 || events[2].Timestamp != 13000 || !events[2].Polarity {
+		t.Errorf("Unexpected events %+v", events)
+	}
+}
+
+// nullReceiver ignores inputs.
+type nullReceiver struct{ *component.BaseComponent }
+
+func (r *nullReceiver) Receive(types.NeuralSignal) {}
@@ -0,0 +1,117 @@
+package cochlea
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// =================================================================================
+// PCM INPUT
+// =================================================================================
+//
+// Audio usually arrives as signed 16-bit PCM, from a capture device or a WAV
+// file. ReadWAV handles the canonical RIFF layout with 16-bit integer
+// samples, which covers recordings made for speech datasets; other sample
+// formats should be converted first (e.g. with sox or ffmpeg).
+
+// PCM16ToFloat converts signed 16-bit samples to [-1, 1).
+func PCM16ToFloat(samples []int16) []float64 {
+	out := make([]float64, len(samples))
+	for i, s := range samples {
+		out[i] = float64(s) / 32768
+	}
+	return out
+}
+
+// WAV is decoded audio, mixed down to mono.
+type WAV struct {
+	SampleRate int
+	Channels   int       // Channels in the file
+	Samples    []float64 // Mono samples in [-1, 1)
+}
+
+// ReadWAV decodes a 16-bit PCM WAV file. Multi-channel audio is averaged to
+// mono.
+func ReadWAV(r io.Reader) (*WAV, error) {
+	var header struct {
+		RIFF [4]byte
+		Size uint32
+		WAVE [4]byte
+	}
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("reading WAV header: %w", err)
+	}
+	if string(header.RIFF[:]) != "RIFF" || string(header.WAVE[:]) != "WAVE" {
+		return nil, fmt.Errorf("not a RIFF WAVE file")
+	}
+
+	var format struct {
+		AudioFormat   uint16
+		Channels      uint16
+		SampleRate    uint32
+		ByteRate      uint32
+		BlockAlign    uint16
+		BitsPerSample uint16
+	}
+	haveFormat := false
+	for {
+		var chunk struct {
+			ID   [4]byte
+			Size uint32
+		}
+		if err := binary.Read(r, binary.LittleEndian, &chunk); err != nil {
+			return nil, fmt.Errorf("reading WAV chunk: %w", err)
+		}
+		switch string(chunk.ID[:]) {
+		case "fmt ":
+			if chunk.Size < 16 {
+				return nil, fmt.Errorf("WAV format chunk too short: %d bytes", chunk.Size)
+			}
+			if err := binary.Read(r, binary.LittleEndian, &format); err != nil {
+				return nil, fmt.Errorf("reading WAV format: %w", err)
+			}
+			if _, err := io.CopyN(io.Discard, r, int64(chunk.Size-16+chunk.Size%2)); err != nil {
+				return nil, fmt.Errorf("reading WAV format: %w", err)
+			}
+			if format.AudioFormat != 1 || format.BitsPerSample != 16 || format.Channels == 0 {
+				return nil, fmt.Errorf("unsupported WAV encoding: format %d, %d bits, %d channels",
+					format.AudioFormat, format.BitsPerSample, format.Channels)
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return nil, fmt.Errorf("WAV data before format chunk")
+			}
+			pcm := make([]int16, chunk.Size/2)
+			if err := binary.Read(r, binary.LittleEndian, pcm); err != nil {
+				return nil, fmt.Errorf("reading WAV data: %w", err)
+			}
+			return &WAV{
+				SampleRate: int(format.SampleRate),
+				Channels:   int(format.Channels),
+				Samples:    mixDown(PCM16ToFloat(pcm), int(format.Channels)),
+			}, nil
+		default:
+			if _, err := io.CopyN(io.Discard, r, int64(chunk.Size+chunk.Size%2)); err != nil {
+				return nil, fmt.Errorf("skipping WAV chunk %q: %w", chunk.ID[:], err)
+			}
+		}
+	}
+}
+
+// mixDown averages interleaved frames of n channels.
+func mixDown(samples []float64, n int) []float64 {
+	if n == 1 {
+		return samples
+	}
+	mono := make([]float64, len(samples)/n)
+	for i := range mono {
+		var sum float64
+		for c := 0; c < n; c++ {
+			sum += samples[i*n+c]
+		}
+		mono[i] = sum / float64(n)
+	}
+	return mono
+}
@@ -95,6 +95,7 @@ type Component interface {
 // and thread-safe metadata handling. This promotes code reuse and consistency across the simulation.
 type BaseComponent struct {
 	id            string                 // Unique identifier for this instance.
+	idRef         *types.IDRef           // Keeps id interned while the component lives (see types.IDRef).
 	componentType types.ComponentType    // The specific type of this component.
 	position      types.Position3D       // Current 3D spatial coordinates.
 	state         types.ComponentState   // Current operational state (e.g., Active, Stopped).
@@ -108,8 +109,10 @@ type BaseComponent struct {
 // base component with mandatory identification and spatial properties, setting
 // its initial state to `StateActive`.
 func NewBaseComponent(id string, componentType types.ComponentType, position types.Position3D) *BaseComponent {
+	idRef, id := types.DefaultIDs.Acquire(id)
 	return &BaseComponent{
 		id:            id,
+		idRef:         idRef,
 		componentType: componentType,
 		position:      position,
 		state:         types.StateActive,            // Components start as active by default.
@@ -128,6 +131,11 @@ func (bc *BaseComponent) ID() string {
 	return bc.id
 }
 
+// IDHandle returns the interned handle of the component's ID.
+func (bc *BaseComponent) IDHandle() types.IDHandle {
+	return bc.idRef.Handle()
+}
+
 // Type returns the categorical type of the base component. It is safe for concurrent access.
 func (bc *BaseComponent) Type() types.ComponentType {
 	return bc.componentType
@@ -1,6 +1,8 @@
 package component
 
 import (
+	"fmt"
+	"runtime"
 	"testing"
 	"time"
 
@@ -39,6 +41,117 @@ func TestNewBaseComponent(t *testing.T) {
 	}
 }
 
+func TestBaseComponentIDInterning(t *testing.T) {
+	a := NewBaseComponent("interned-component", types.TypeNeuron, types.Position3D{})
+	b := NewBaseComponent("interned-"+"component", types.TypeSynapse, types.Position3D{})
+	other := NewBaseComponent("interned-other", types.TypeNeuron, types.Position3D{})
+
+	if a.IDHandle() == 0 || a.IDHandle() != b.IDHandle() {
+		t.Errorf("Expected equal IDs to share a non-zero handle, got %d and %d", a.IDHandle(), b.IDHandle())
+	}
+	if a.IDHandle() == other.IDHandle() {
+		t.Error("Expected distinct IDs to get distinct handles")
+	}
+	if got := types.DefaultIDs.String(a.IDHandle()); got != "interned-component" {
+		t.Errorf("Expected the handle to resolve to the ID, got %q", got)
+	}
+	if !types.SameID(a.IDHandle(), a.ID(), b.IDHandle(), b.ID()) || types.SameID(a.IDHandle(), a.ID(), 0, "other") {
+		t.Error("SameID disagrees with the IDs")
+	}
+
+	registry := types.NewIDRegistry()
+	if handle, id := registry.Intern(""); handle != 0 || id != "" {
+		t.Errorf("Expected the empty ID to have handle 0, got %d", handle)
+	}
+	first, _ := registry.Intern("x")
+	second, _ := registry.Intern("y")
+	again, _ := registry.Intern("x")
+	if first != 1 || second != 2 || again != first || registry.Len() != 2 {
+		t.Errorf("Expected dense stable handles, got %d %d %d (len %d)", first, second, again, registry.Len())
+	}
+	if _, ok := registry.Lookup("z"); ok || registry.String(3) != "" {
+		t.Error("Expected unknown IDs and handles to be absent")
+	}
+}
+
+func TestIDRegistryReleasesIDs(t *testing.T) {
+	registry := types.NewIDRegistry()
+	first, _ := registry.Intern("x")
+	registry.Intern("x")
+
+	registry.Release(first)
+	if registry.String(first) != "x" {
+		t.Error("Expected the ID to stay interned while a reference remains")
+	}
+	registry.Release(first)
+	if _, ok := registry.Lookup("x"); ok || registry.String(first) != "" || registry.Len() != 0 {
+		t.Error("Expected the last release to free the ID")
+	}
+
+	// The freed slot is reused under a new handle, so the stale one stays dead
+	reused, _ := registry.Intern("y")
+	if reused == first || registry.String(first) != "" || registry.String(reused) != "y" {
+		t.Errorf("Expected a fresh handle for the reused slot, got %d (was %d)", reused, first)
+	}
+	registry.Release(first) // Stale releases are ignored
+	if registry.String(reused) != "y" {
+		t.Error("Expected a stale release not to free the new ID")
+	}
+	if !types.SameID(first, "z", reused, "z") {
+		t.Error("Expected SameID to fall back to the strings for a stale handle")
+	}
+}
+
+func TestIDRegistryRetiresExhaustedSlots(t *testing.T) {
+	registry := types.NewIDRegistry()
+
+	// Cycle one slot through every generation; no handle may repeat
+	seen := make(map[types.IDHandle]bool)
+	first, _ := registry.Intern("id-0")
+	handle := first
+	for i := 1; i < 256; i++ {
+		seen[handle] = true
+		registry.Release(handle)
+		handle, _ = registry.Intern(fmt.Sprintf("id-%d", i))
+		if seen[handle] {
+			t.Fatalf("Handle %d reused after %d releases", handle, i)
+		}
+	}
+	registry.Release(handle)
+
+	// The exhausted slot is retired: a new ID gets another slot and the
+	// first handle stays stale
+	next, _ := registry.Intern("after-wrap")
+	if next == first || seen[next] {
+		t.Errorf("Expected a fresh slot after the generations ran out, got %d", next)
+	}
+	if registry.String(first) != "" {
+		t.Errorf("Expected the first handle to stay stale, got %q", registry.String(first))
+	}
+}
+
+func TestBaseComponentReleasesIDWhenCollected(t *testing.T) {
+	func() {
+		comp := NewBaseComponent("short-lived-component", types.TypeSynapse, types.Position3D{})
+		if comp.IDHandle() == 0 {
+			t.Fatal("Expected an interned ID")
+		}
+	}()
+
+	// The reference is released by a finalizer after a collection
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		runtime.GC()
+		if _, ok := types.DefaultIDs.Lookup("short-lived-component"); !ok {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the ID to be released once the component was collected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
 func TestBaseComponentLifecycle(t *testing.T) {
 	comp := NewBaseComponent("test", types.TypeNeuron, types.Position3D{})
 
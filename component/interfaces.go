@@ -116,6 +116,12 @@ type SynapticProcessor interface {
 	UpdateWeight(event types.PlasticityEvent)
 }
 
+// IDHandled is implemented by components whose ID is interned
+// (BaseComponent and everything that embeds it). See types.IDRef.
+type IDHandled interface {
+	IDHandle() types.IDHandle
+}
+
 // ============================================================================
 // SPATIAL AWARENESS INTERFACES
 // ============================================================================
@@ -0,0 +1,191 @@
+package cosim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// =================================================================================
+// CLOSED-LOOP CONTROL
+// =================================================================================
+//
+// Behavioural tasks close the loop between the network and an environment:
+// the network's output moves a plant (a cart-pole, a robot arm, a simulated
+// animal), and the plant's new state becomes the network's next input. Each
+// control tick of a ClosedLoop:
+//
+//  1. decodes the network output at the current time,
+//  2. passes it to the user's Controller, which advances the environment and
+//     returns the next stimulus,
+//  3. encodes the stimulus into the network's input neurons,
+//  4. advances the clock by one control period.
+//
+// The clock is a LockStep for deterministic virtual time, or RealTime() for
+// goroutine-based neurons on the wall clock. ros2.RateDecoder and the ros2
+// encoders satisfy Decoder and Encoder.
+
+// Decoder reads one channel of network output at time now.
+type Decoder interface {
+	Value(now time.Time) float64
+}
+
+// Encoder delivers a stimulus to the network's input neurons.
+type Encoder interface {
+	Encode(values []float64, sourceID string)
+}
+
+// Controller maps decoded output to the next stimulus. Returning done ends
+// the loop before the stimulus is encoded.
+type Controller interface {
+	Control(now time.Time, output []float64) (stimulus []float64, done bool)
+}
+
+// ControllerFunc adapts a function to Controller.
+type ControllerFunc func(now time.Time, output []float64) (stimulus []float64, done bool)
+
+// Control implements Controller.
+func (f ControllerFunc) Control(now time.Time, output []float64) ([]float64, bool) {
+	return f(now, output)
+}
+
+// Clock advances the network between control ticks. LockStep implements it.
+type Clock interface {
+	Now() time.Time
+	Step(dt time.Duration) error
+}
+
+// realTimeClock paces control ticks on the wall clock. The next deadline is
+// kept so slow controllers do not accumulate drift.
+type realTimeClock struct {
+	next time.Time
+}
+
+// RealTime returns a clock that waits out each control period on the wall
+// clock while goroutine-based neurons run on their own.
+func RealTime() Clock {
+	return &realTimeClock{}
+}
+
+// Now implements Clock.
+func (c *realTimeClock) Now() time.Time { return time.Now() }
+
+// Step implements Clock.
+func (c *realTimeClock) Step(dt time.Duration) error {
+	if dt <= 0 {
+		return fmt.Errorf("step must be positive: %v", dt)
+	}
+	if c.next.IsZero() {
+		c.next = time.Now()
+	}
+	c.next = c.next.Add(dt)
+	time.Sleep(time.Until(c.next))
+	return nil
+}
+
+// ClosedLoopConfig wires a network into a control loop.
+type ClosedLoopConfig struct {
+	Clock      Clock         // nil = RealTime()
+	Period     time.Duration // Control tick (0 = COSIM_DEFAULT_RESOLUTION)
+	Decoders   []Decoder     // One per output channel
+	Encoder    Encoder       // Receives each stimulus (nil = controller drives inputs itself)
+	Controller Controller
+	SourceID   string // Source ID of encoded inputs (default "closed_loop")
+}
+
+// ClosedLoop runs a controller in lock-step with a network.
+type ClosedLoop struct {
+	config ClosedLoopConfig
+
+	mu           sync.Mutex
+	ticks        int64
+	lastOutput   []float64
+	lastStimulus []float64
+	running      bool
+}
+
+// NewClosedLoop validates the configuration and creates a loop.
+func NewClosedLoop(config ClosedLoopConfig) (*ClosedLoop, error) {
+	if config.Controller == nil {
+		return nil, fmt.Errorf("closed loop needs a controller")
+	}
+	if config.Period < 0 {
+		return nil, fmt.Errorf("control period cannot be negative: %v", config.Period)
+	}
+	for i, decoder := range config.Decoders {
+		if decoder == nil {
+			return nil, fmt.Errorf("decoder %d is nil", i)
+		}
+	}
+	if config.Period == 0 {
+		config.Period = COSIM_DEFAULT_RESOLUTION
+	}
+	if config.Clock == nil {
+		config.Clock = RealTime()
+	}
+	if config.SourceID == "" {
+		config.SourceID = "closed_loop"
+	}
+	return &ClosedLoop{config: config}, nil
+}
+
+// Run executes up to maxTicks control ticks (0 = until the controller is
+// done or ctx is cancelled) and returns the number of ticks completed.
+func (cl *ClosedLoop) Run(ctx context.Context, maxTicks int) (int, error) {
+	cl.mu.Lock()
+	if cl.running {
+		cl.mu.Unlock()
+		return 0, fmt.Errorf("closed loop is already running")
+	}
+	cl.running = true
+	cl.mu.Unlock()
+	defer func() {
+		cl.mu.Lock()
+		cl.running = false
+		cl.mu.Unlock()
+	}()
+
+	output := make([]float64, len(cl.config.Decoders))
+	for ticks := 0; maxTicks <= 0 || ticks < maxTicks; ticks++ {
+		if err := ctx.Err(); err != nil {
+			return ticks, err
+		}
+
+		now := cl.config.Clock.Now()
+		for i, decoder := range cl.config.Decoders {
+			output[i] = decoder.Value(now)
+		}
+		stimulus, done := cl.config.Controller.Control(now, append([]float64(nil), output...))
+		if done {
+			return ticks, nil
+		}
+		if cl.config.Encoder != nil && len(stimulus) > 0 {
+			cl.config.Encoder.Encode(stimulus, cl.config.SourceID)
+		}
+
+		if err := cl.config.Clock.Step(cl.config.Period); err != nil {
+			return ticks, fmt.Errorf("tick %d: %w", ticks, err)
+		}
+
+		cl.mu.Lock()
+		cl.ticks++
+		cl.lastOutput = append(cl.lastOutput[:0], output...)
+		cl.lastStimulus = append(cl.lastStimulus[:0], stimulus...)
+		cl.mu.Unlock()
+	}
+	return maxTicks, nil
+}
+
+// GetStats returns loop counters and the most recent output and stimulus.
+func (cl *ClosedLoop) GetStats() map[string]interface{} {
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	return map[string]interface{}{
+		"ticks":         cl.ticks,
+		"period":        cl.config.Period,
+		"running":       cl.running,
+		"last_output":   append([]float64(nil), cl.lastOutput...),
+		"last_stimulus": append([]float64(nil), cl.lastStimulus...),
+	}
+}
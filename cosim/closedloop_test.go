@@ -0,0 +1,105 @@
+package cosim
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/ros2"
+)
+
+// TestClosedLoopTracksTarget drives a one-dimensional plant towards a target
+// with a push-pull pair of neurons: the position error is rate-encoded, the
+// decoded rate difference moves the plant.
+func TestClosedLoopTracksTarget(t *testing.T) {
+	runner, err := NewLockStep(time.Unix(0, 0), time.Millisecond)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pop, err := batch.NewPopulation("motor", batch.PopulationConfig{
+		Size: 2, Threshold: 1, DecayRate: 1, DelayScheduler: runner.Schedule,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	runner.AddPopulation(pop)
+
+	decoder, err := ros2.NewRateDecoder(20*time.Millisecond, 0.01, -10, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, weight := range []float64{1, -1} {
+		decoder.AddOutput(pop.Member(i).ID(), weight)
+		pop.AddOutputCallback(i, "decoder", decoder.OutputCallback(pop.Member(i).ID()))
+	}
+	encoder := &ros2.RateEncoder{
+		Targets: []component.MessageReceiver{pop.Member(0), pop.Member(1)}, Min: 0, Max: 1, Gain: 0.5,
+	}
+
+	const target = 1.0
+	position := 0.0
+	var outputs []float64
+	loop, err := NewClosedLoop(ClosedLoopConfig{
+		Clock:    runner,
+		Period:   time.Millisecond,
+		Decoders: []Decoder{decoder},
+		Encoder:  encoder,
+		Controller: ControllerFunc(func(now time.Time, output []float64) ([]float64, bool) {
+			position += output[0] * time.Millisecond.Seconds()
+			outputs = append(outputs, output[0])
+			errPos := target - position
+			return []float64{math.Max(errPos, 0), math.Max(-errPos, 0)}, math.Abs(errPos) < 0.05
+		}),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ticks, err := loop.Run(context.Background(), 5000)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if ticks == 5000 || math.Abs(target-position) >= 0.05 {
+		t.Fatalf("Expected the plant to reach the target, got position %f after %d ticks", position, ticks)
+	}
+	if outputs[0] != 0 || runner.Now() != time.Unix(0, 0).Add(time.Duration(ticks)*time.Millisecond) {
+		t.Errorf("Expected one virtual millisecond per tick starting from silence")
+	}
+	if stats := loop.GetStats(); stats["ticks"] != int64(ticks) || stats["running"] != false {
+		t.Errorf("Unexpected stats: %v", stats)
+	}
+}
+
+// TestClosedLoopStopsAndValidates verifies tick limits, cancellation, real
+// time pacing and configuration checks.
+func TestClosedLoopStopsAndValidates(t *testing.T) {
+	if _, err := NewClosedLoop(ClosedLoopConfig{}); err == nil {
+		t.Error("Expected error without a controller")
+	}
+	if _, err := NewClosedLoop(ClosedLoopConfig{Controller: ControllerFunc(nil), Decoders: []Decoder{nil}}); err == nil {
+		t.Error("Expected error for a nil decoder")
+	}
+
+	calls := 0
+	idle := ControllerFunc(func(time.Time, []float64) ([]float64, bool) { calls++; return nil, false })
+	loop, err := NewClosedLoop(ClosedLoopConfig{Period: 2 * time.Millisecond, Controller: idle})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	start := time.Now()
+	if ticks, err := loop.Run(context.Background(), 5); err != nil || ticks != 5 || calls != 5 {
+		t.Errorf("Expected five ticks, got %d (calls %d, err %v)", ticks, calls, err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("Expected real-time pacing of at least 10ms, got %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if ticks, err := loop.Run(ctx, 0); err != context.Canceled || ticks != 0 {
+		t.Errorf("Expected immediate cancellation, got %d ticks and %v", ticks, err)
+	}
+}
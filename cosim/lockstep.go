@@ -0,0 +1,262 @@
+/*
+=================================================================================
+LOCK-STEP EXECUTION FOR HYBRID CO-SIMULATION
+=================================================================================
+
+Physics engines and robot simulators (Gazebo, MuJoCo, Webots) advance the world
+in discrete steps under their own control. To couple a spiking network to such
+a simulator, the network must advance by exactly the same amount of simulated
+time per step and then wait - it must not run ahead on the wall clock.
+
+LockStep owns a virtual clock. Each call to Step(dt) advances it in fixed
+resolution ticks; on every tick it first delivers delayed spikes that have
+come due on the virtual clock, then steps every registered component with the
+tick's virtual time. Sensor values can be injected between Step calls and
+actuator outputs read back, giving a deterministic sensorimotor loop:
+
+	for {
+	    pop.Inject(0, sensors.Read())
+	    runner.Step(world.Timestep())
+	    actuators.Write(readout)
+	    world.Step()
+	}
+
+Three kinds of component can be lock-stepped:
+
+  - neuron.Neuron, added with AddNeuron before it is started. The neuron
+    runs without its goroutine on the runner's clock (see
+    neuron.EnableStepping): membrane decay, refractory periods, STDP
+    feedback and every timestamp follow virtual time, and its axonal
+    deliveries go through Schedule. Synapses between lock-stepped neurons
+    read the same clock (synapse.WithClock(runner.Now)).
+  - batch.Population, added with AddPopulation; use runner.Schedule as its
+    DelayScheduler.
+  - Custom components added with AddStepper.
+
+Goroutine neurons that were started without AddNeuron keep running on the
+wall clock and are not advanced by Step.
+=================================================================================
+*/
+
+package cosim
+
+import (
+	"container/heap"
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/memory"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+const (
+	// COSIM_DEFAULT_RESOLUTION matches the neuron membrane update interval.
+	COSIM_DEFAULT_RESOLUTION = 1 * time.Millisecond
+)
+
+// StepFunc advances a component to virtual time now.
+type StepFunc func(now time.Time)
+
+// namedStepper is a registered component.
+type namedStepper struct {
+	name string
+	step StepFunc
+}
+
+// scheduledDelivery is a delayed spike pending on the virtual clock.
+type scheduledDelivery struct {
+	msg       types.NeuralSignal
+	target    component.MessageReceiver
+	deliverAt time.Time
+	seq       uint64 // Preserves scheduling order for equal times
+}
+
+// deliveryQueue is a min-heap ordered by delivery time.
+type deliveryQueue []scheduledDelivery
+
+func (q deliveryQueue) Len() int { return len(q) }
+func (q deliveryQueue) Less(i, j int) bool {
+	if q[i].deliverAt.Equal(q[j].deliverAt) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].deliverAt.Before(q[j].deliverAt)
+}
+func (q deliveryQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *deliveryQueue) Push(x interface{}) { *q = append(*q, x.(scheduledDelivery)) }
+func (q *deliveryQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// LockStep advances registered components on a virtual clock under external
+// control.
+type LockStep struct {
+	resolution time.Duration
+
+	now      time.Time
+	queue    deliveryQueue
+	seq      uint64
+	steppers []namedStepper
+
+	ticks     int64
+	delivered int64
+
+	mu     sync.Mutex // Guards clock, queue, steppers and counters
+	stepMu sync.Mutex // Serialises Step calls
+}
+
+// NewLockStep creates a runner starting at virtual time start.
+// A resolution of 0 uses COSIM_DEFAULT_RESOLUTION.
+func NewLockStep(start time.Time, resolution time.Duration) (*LockStep, error) {
+	if resolution < 0 {
+		return nil, fmt.Errorf("resolution cannot be negative: %v", resolution)
+	}
+	if resolution == 0 {
+		resolution = COSIM_DEFAULT_RESOLUTION
+	}
+	return &LockStep{resolution: resolution, now: start}, nil
+}
+
+// Now returns the current virtual time.
+func (ls *LockStep) Now() time.Time {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return ls.now
+}
+
+// Resolution returns the tick length.
+func (ls *LockStep) Resolution() time.Duration {
+	return ls.resolution
+}
+
+// AddStepper registers a component to be advanced every tick. Components are
+// stepped in registration order.
+func (ls *LockStep) AddStepper(name string, step StepFunc) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.steppers = append(ls.steppers, namedStepper{name: name, step: step})
+}
+
+// AddPopulation registers a batch population. Its PopulationConfig should
+// use ls.Schedule as DelayScheduler so synaptic delays follow virtual time.
+func (ls *LockStep) AddPopulation(pop *batch.Population) {
+	ls.AddStepper(pop.ID(), func(now time.Time) { pop.Step(now) })
+}
+
+// AddNeuron puts a neuron that has not been started on the runner's clock
+// and registers it: Step advances it and its axonal deliveries are queued
+// with Schedule. The neuron must still be started. Neurons update their
+// membrane once per neuron.MEMBRANE_TICK_INTERVAL, so the resolution must not
+// be coarser.
+func (ls *LockStep) AddNeuron(n *neuron.Neuron) error {
+	if ls.resolution > neuron.MEMBRANE_TICK_INTERVAL {
+		return fmt.Errorf("neuron %s: resolution %v is coarser than the membrane tick %v",
+			n.ID(), ls.resolution, neuron.MEMBRANE_TICK_INTERVAL)
+	}
+	if err := n.EnableStepping(neuron.SteppingConfig{Start: ls.Now(), Scheduler: ls.Schedule}); err != nil {
+		return err
+	}
+	ls.AddStepper(n.ID(), n.Step)
+	return nil
+}
+
+// Schedule queues msg for delivery to target after delay on the virtual
+// clock. It has the batch.DelayScheduler signature.
+func (ls *LockStep) Schedule(msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	ls.seq++
+	heap.Push(&ls.queue, scheduledDelivery{
+		msg:       msg,
+		target:    target,
+		deliverAt: ls.now.Add(delay),
+		seq:       ls.seq,
+	})
+}
+
+// Pending returns the number of queued deliveries.
+func (ls *LockStep) Pending() int {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return ls.queue.Len()
+}
+
+// Step advances the virtual clock by dt in resolution ticks and returns when
+// every component has processed the slice. A dt that is not a multiple of the
+// resolution ends with one shorter tick.
+func (ls *LockStep) Step(dt time.Duration) error {
+	if dt <= 0 {
+		return fmt.Errorf("step must be positive: %v", dt)
+	}
+	ls.stepMu.Lock()
+	defer ls.stepMu.Unlock()
+
+	for remaining := dt; remaining > 0; {
+		tick := ls.resolution
+		if remaining < tick {
+			tick = remaining
+		}
+		remaining -= tick
+		ls.tick(tick)
+	}
+	return nil
+}
+
+// tick advances one slice: deliver due spikes, then step components.
+// Callbacks run without holding ls.mu so they may call Schedule.
+func (ls *LockStep) tick(d time.Duration) {
+	ls.mu.Lock()
+	ls.now = ls.now.Add(d)
+	now := ls.now
+	var due []scheduledDelivery
+	for ls.queue.Len() > 0 && !ls.queue[0].deliverAt.After(now) {
+		due = append(due, heap.Pop(&ls.queue).(scheduledDelivery))
+	}
+	steppers := make([]namedStepper, len(ls.steppers))
+	copy(steppers, ls.steppers)
+	ls.ticks++
+	ls.delivered += int64(len(due))
+	ls.mu.Unlock()
+
+	for _, delivery := range due {
+		delivery.target.Receive(delivery.msg)
+	}
+	for _, s := range steppers {
+		s.step(now)
+	}
+}
+
+// MemoryUsage implements memory.Reporter with the delay queue, counted at
+// capacity.
+func (ls *LockStep) MemoryUsage() memory.Usage {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	return memory.Usage{
+		memory.CategoryDelayQueues: int64(cap(ls.queue)) * int64(unsafe.Sizeof(scheduledDelivery{})),
+	}
+}
+
+// GetStats returns runner counters for monitoring.
+func (ls *LockStep) GetStats() map[string]interface{} {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	names := make([]string, len(ls.steppers))
+	for i, s := range ls.steppers {
+		names[i] = s.name
+	}
+	return map[string]interface{}{
+		"virtual_time": ls.now,
+		"ticks":        ls.ticks,
+		"delivered":    ls.delivered,
+		"pending":      ls.queue.Len(),
+		"steppers":     names,
+		"resolution":   ls.resolution,
+	}
+}
@@ -0,0 +1,174 @@
+package cosim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestLockStepDeliversDelaysOnVirtualClock verifies that a synaptic delay is
+// honoured in virtual time regardless of how fast Step is called.
+func TestLockStepDeliversDelaysOnVirtualClock(t *testing.T) {
+	start := time.Unix(0, 0)
+	runner, err := NewLockStep(start, time.Millisecond)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pop, err := batch.NewPopulation("cosim", batch.PopulationConfig{
+		Size:             2,
+		Threshold:        1.0,
+		DecayRate:        1.0,
+		RefractoryPeriod: 2 * time.Millisecond,
+		DelayScheduler:   runner.Schedule,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	runner.AddPopulation(pop)
+
+	// Member 0 drives member 1 through a 5ms synapse
+	pre, post := pop.Member(0), pop.Member(1)
+	syn := synapse.NewBasicSynapse("cosim_syn", pre, post,
+		synapse.CreateDefaultSTDPConfig(), synapse.CreateDefaultPruningConfig(), 0.8, 5*time.Millisecond)
+	pre.AddOutputCallback(syn.ID(), types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			syn.Transmit(msg.Value)
+			return nil
+		},
+		GetWeight:   syn.GetWeight,
+		GetDelay:    syn.GetDelay,
+		GetTargetID: post.ID,
+	})
+
+	pop.Inject(0, 1.5)
+	if err := runner.Step(time.Millisecond); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	if runner.Pending() != 1 {
+		t.Fatalf("Expected one delayed spike queued, got %d", runner.Pending())
+	}
+
+	// 4ms later (virtual) the spike is still in flight
+	runner.Step(4 * time.Millisecond)
+	if spikes := pop.GetStats()["spike_count"].(int64); spikes != 1 {
+		t.Fatalf("Expected only the pre-synaptic spike so far, got %d", spikes)
+	}
+
+	// Crossing the 5ms boundary delivers it; member 1 fires on the same tick
+	runner.Step(2 * time.Millisecond)
+	if spikes := pop.GetStats()["spike_count"].(int64); spikes != 2 {
+		t.Errorf("Expected post-synaptic spike after virtual delay, got %d spikes", spikes)
+	}
+	if now := runner.Now(); now != start.Add(7*time.Millisecond) {
+		t.Errorf("Expected virtual time +7ms, got %v", now.Sub(start))
+	}
+	if ticks := runner.GetStats()["ticks"].(int64); ticks != 7 {
+		t.Errorf("Expected 7 ticks, got %d", ticks)
+	}
+}
+
+// TestLockStepPartialTicksAndOrdering verifies fractional steps and that
+// components are stepped in registration order with the tick's time.
+func TestLockStepPartialTicksAndOrdering(t *testing.T) {
+	start := time.Unix(100, 0)
+	runner, _ := NewLockStep(start, 2*time.Millisecond)
+
+	var order []string
+	var times []time.Duration
+	runner.AddStepper("world", func(now time.Time) {
+		order = append(order, "world")
+		times = append(times, now.Sub(start))
+	})
+	runner.AddStepper("brain", func(now time.Time) { order = append(order, "brain") })
+
+	if err := runner.Step(5 * time.Millisecond); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+
+	expected := []time.Duration{2 * time.Millisecond, 4 * time.Millisecond, 5 * time.Millisecond}
+	if len(times) != len(expected) {
+		t.Fatalf("Expected %d ticks, got %d", len(expected), len(times))
+	}
+	for i := range expected {
+		if times[i] != expected[i] {
+			t.Errorf("Tick %d: expected %v, got %v", i, expected[i], times[i])
+		}
+	}
+	if order[0] != "world" || order[1] != "brain" {
+		t.Errorf("Expected registration order, got %v", order)
+	}
+
+	if err := runner.Step(0); err == nil {
+		t.Error("Expected error for non-positive step")
+	}
+}
+
+// TestLockStepSteppedNeurons verifies that real neurons joined by a real
+// synapse fire on the virtual clock, with the synaptic delay in virtual time,
+// however long the wall clock takes between steps.
+func TestLockStepSteppedNeurons(t *testing.T) {
+	start := time.Unix(0, 0)
+	runner, _ := NewLockStep(start, time.Millisecond)
+
+	pre := neuron.NewNeuron("ls_pre", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	post := neuron.NewNeuron("ls_post", 0.5, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	for _, n := range []*neuron.Neuron{pre, post} {
+		if err := runner.AddNeuron(n); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		n.Start()
+		defer n.Stop()
+	}
+
+	syn, err := synapse.NewSynapse("ls_syn", pre, post,
+		synapse.WithWeight(0.8), synapse.WithDelay(2*time.Millisecond), synapse.WithClock(runner.Now))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pre.AddOutputCallback(syn.ID(), types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			syn.Transmit(msg.Value)
+			return nil
+		},
+		GetWeight:   syn.GetWeight,
+		GetDelay:    syn.GetDelay,
+		GetTargetID: syn.GetPostsynapticID,
+	})
+
+	runner.Step(9 * time.Millisecond)
+	pre.Receive(types.NeuralSignal{Value: 1.5, Timestamp: runner.Now()})
+	time.Sleep(5 * time.Millisecond) // Wall time does not advance the network
+	runner.Step(time.Millisecond)
+
+	if got := pre.GetSnapshot().LastSpike; !got.Equal(start.Add(10 * time.Millisecond)) {
+		t.Fatalf("Expected the pre-synaptic spike at 10ms, got %v", got.Sub(start))
+	}
+	if spikes := syn.GetPreSpikeTimes(); len(spikes) != 1 || !spikes[0].Equal(start.Add(10*time.Millisecond)) {
+		t.Errorf("Expected the synapse to record the spike at 10ms, got %v", spikes)
+	}
+	if runner.Pending() != 1 {
+		t.Fatalf("Expected the spike queued on the runner, got %d pending", runner.Pending())
+	}
+
+	runner.Step(time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if post.GetSnapshot().Spikes != 0 {
+		t.Fatal("Expected no post-synaptic spike before the 2ms delay elapsed")
+	}
+	runner.Step(time.Millisecond)
+	snapshot := post.GetSnapshot()
+	if snapshot.Spikes != 1 || !snapshot.LastSpike.Equal(start.Add(12*time.Millisecond)) {
+		t.Errorf("Expected one post-synaptic spike at 12ms, got %d at %v",
+			snapshot.Spikes, snapshot.LastSpike.Sub(start))
+	}
+
+	coarse, _ := NewLockStep(start, 2*time.Millisecond)
+	if err := coarse.AddNeuron(neuron.NewNeuron("coarse", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)); err == nil {
+		t.Error("Expected an error for a resolution coarser than the membrane tick")
+	}
+}
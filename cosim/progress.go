@@ -0,0 +1,256 @@
+package cosim
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+)
+
+// =================================================================================
+// PROGRESS REPORTING
+// =================================================================================
+//
+// A virtual clock runs as fast as the computation allows, so how long a run
+// of an hour of simulated time takes is only known once it is going.
+// Progress wraps a Clock and reports, at most once per wall-clock Interval:
+//
+//   - simulated time against the run's Target, and the fraction done
+//   - wall time, the speed (simulated seconds per wall second) and the
+//     estimated wall time to the target
+//   - spikes and plasticity events, read from caller-supplied counters
+//
+// Reports go to a callback; ProgressBar renders them as a one-line terminal
+// progress bar. Like TimeWarp, Progress is a Clock, so it can stand in for a
+// LockStep in a ClosedLoop. experiment.Config.Progress reports whole sweeps
+// in the same form.
+
+const (
+	// COSIM_DEFAULT_PROGRESS_INTERVAL is the wall time between reports.
+	COSIM_DEFAULT_PROGRESS_INTERVAL = time.Second
+
+	// COSIM_PROGRESS_BAR_WIDTH is the default bar length in characters.
+	COSIM_PROGRESS_BAR_WIDTH = 30
+)
+
+// ProgressReport is a snapshot of a run.
+type ProgressReport struct {
+	Simulated        time.Duration // Virtual time advanced so far
+	Target           time.Duration // Virtual time of the whole run (0 = open-ended)
+	Wall             time.Duration // Wall time since the run started
+	Spikes           int64         // From the Spikes counter
+	PlasticityEvents int64         // From the PlasticityEvents counter
+	Done             bool          // Final report
+}
+
+// Fraction returns the completed fraction of the target, or 0 without one.
+func (r ProgressReport) Fraction() float64 {
+	if r.Target <= 0 {
+		return 0
+	}
+	return math.Min(1, float64(r.Simulated)/float64(r.Target))
+}
+
+// Speed returns simulated seconds per wall second.
+func (r ProgressReport) Speed() float64 {
+	if r.Wall <= 0 {
+		return 0
+	}
+	return float64(r.Simulated) / float64(r.Wall)
+}
+
+// ETA estimates the wall time until the target is reached at the speed so
+// far. It returns 0 when done, and -1 when it cannot be estimated yet.
+func (r ProgressReport) ETA() time.Duration {
+	if r.Target <= 0 || r.Simulated <= 0 {
+		return -1
+	}
+	if r.Done || r.Simulated >= r.Target {
+		return 0
+	}
+	return time.Duration(float64(r.Wall) * float64(r.Target-r.Simulated) / float64(r.Simulated))
+}
+
+// String formats the report on one line.
+func (r ProgressReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v", r.Simulated.Round(time.Millisecond))
+	if r.Target > 0 {
+		fmt.Fprintf(&b, "/%v (%.0f%%)", r.Target.Round(time.Millisecond), 100*r.Fraction())
+	}
+	fmt.Fprintf(&b, " simulated in %v, %.2fx real time", r.Wall.Round(time.Millisecond), r.Speed())
+	if eta := r.ETA(); eta > 0 {
+		fmt.Fprintf(&b, ", ETA %v", eta.Round(time.Second))
+	}
+	fmt.Fprintf(&b, ", %d spikes, %d plasticity events", r.Spikes, r.PlasticityEvents)
+	return b.String()
+}
+
+// ProgressFunc receives progress reports.
+type ProgressFunc func(report ProgressReport)
+
+// ProgressConfig configures a Progress.
+type ProgressConfig struct {
+	Target   time.Duration // Virtual time of the whole run (0 = open-ended)
+	Interval time.Duration // Wall time between reports (0 = COSIM_DEFAULT_PROGRESS_INTERVAL)
+	Callback ProgressFunc  // Receives reports; required
+
+	Spikes           func() int64 // Optional spike counter, e.g. PopulationSpikes
+	PlasticityEvents func() int64 // Optional plasticity event counter
+}
+
+// Progress reports the progress of a virtual-clock run.
+type Progress struct {
+	base   Clock
+	config ProgressConfig
+
+	mu        sync.Mutex
+	start     time.Time // Virtual time at creation
+	wallStart time.Time
+	last      time.Time // Wall time of the last report
+	reports   int64
+	finished  bool
+}
+
+// NewProgress reports the progress of base. The run starts now: wall time
+// is measured from here, and simulated time from base's current time.
+func NewProgress(base Clock, config ProgressConfig) (*Progress, error) {
+	if base == nil {
+		return nil, fmt.Errorf("progress needs a clock")
+	}
+	if config.Callback == nil {
+		return nil, fmt.Errorf("progress needs a callback")
+	}
+	if config.Target < 0 || config.Interval < 0 {
+		return nil, fmt.Errorf("target and interval cannot be negative: %v, %v", config.Target, config.Interval)
+	}
+	if config.Interval == 0 {
+		config.Interval = COSIM_DEFAULT_PROGRESS_INTERVAL
+	}
+	now := time.Now()
+	return &Progress{
+		base:      base,
+		config:    config,
+		start:     base.Now(),
+		wallStart: now,
+		last:      now,
+	}, nil
+}
+
+// Now implements Clock with the virtual time of the wrapped clock.
+func (p *Progress) Now() time.Time {
+	return p.base.Now()
+}
+
+// Step implements Clock. It advances the wrapped clock by dt and reports if
+// the interval has passed since the last report.
+func (p *Progress) Step(dt time.Duration) error {
+	if err := p.base.Step(dt); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	due := time.Since(p.last) >= p.config.Interval
+	if due {
+		p.last = time.Now()
+		p.reports++
+	}
+	p.mu.Unlock()
+	if due {
+		p.config.Callback(p.Report())
+	}
+	return nil
+}
+
+// Report returns the current progress without calling the callback.
+func (p *Progress) Report() ProgressReport {
+	p.mu.Lock()
+	report := ProgressReport{
+		Simulated: p.base.Now().Sub(p.start),
+		Target:    p.config.Target,
+		Wall:      time.Since(p.wallStart),
+		Done:      p.finished,
+	}
+	p.mu.Unlock()
+	if p.config.Spikes != nil {
+		report.Spikes = p.config.Spikes()
+	}
+	if p.config.PlasticityEvents != nil {
+		report.PlasticityEvents = p.config.PlasticityEvents()
+	}
+	return report
+}
+
+// Finish sends the final report, marked Done. Later calls do nothing.
+func (p *Progress) Finish() {
+	p.mu.Lock()
+	if p.finished {
+		p.mu.Unlock()
+		return
+	}
+	p.finished = true
+	p.reports++
+	p.mu.Unlock()
+	p.config.Callback(p.Report())
+}
+
+// GetStats returns the latest report's figures for monitoring.
+func (p *Progress) GetStats() map[string]interface{} {
+	report := p.Report()
+	p.mu.Lock()
+	reports := p.reports
+	p.mu.Unlock()
+	return map[string]interface{}{
+		"simulated":         report.Simulated,
+		"target":            report.Target,
+		"wall":              report.Wall,
+		"speed":             report.Speed(),
+		"eta":               report.ETA(),
+		"spikes":            report.Spikes,
+		"plasticity_events": report.PlasticityEvents,
+		"reports":           reports,
+		"finished":          report.Done,
+	}
+}
+
+// PopulationSpikes returns a spike counter summing the populations' spike
+// counts, for ProgressConfig.Spikes.
+func PopulationSpikes(pops ...*batch.Population) func() int64 {
+	return func() int64 {
+		var total int64
+		for _, pop := range pops {
+			if count, ok := pop.GetStats()["spike_count"].(int64); ok {
+				total += count
+			}
+		}
+		return total
+	}
+}
+
+// ProgressBar renders reports as a terminal progress bar on w, redrawing one
+// line with a carriage return and ending it with a newline on the final
+// report. Open-ended runs show the report without a bar. A width of 0 uses
+// COSIM_PROGRESS_BAR_WIDTH.
+func ProgressBar(w io.Writer, width int) ProgressFunc {
+	if width <= 0 {
+		width = COSIM_PROGRESS_BAR_WIDTH
+	}
+	var mu sync.Mutex
+	return func(report ProgressReport) {
+		mu.Lock()
+		defer mu.Unlock()
+		line := report.String()
+		if report.Target > 0 {
+			filled := int(report.Fraction() * float64(width))
+			line = "[" + strings.Repeat("=", filled) + strings.Repeat(" ", width-filled) + "] " + line
+		}
+		end := ""
+		if report.Done {
+			end = "\n"
+		}
+		fmt.Fprintf(w, "\r%s\x1b[K%s", line, end)
+	}
+}
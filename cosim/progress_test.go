@@ -0,0 +1,80 @@
+package cosim
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+)
+
+// TestProgressReportsVirtualRun verifies periodic reports of simulated time,
+// spikes and plasticity events, the ETA and the terminal progress bar.
+func TestProgressReportsVirtualRun(t *testing.T) {
+	runner, _ := NewLockStep(time.Unix(0, 0), time.Millisecond)
+	pop, err := batch.NewPopulation("driven", batch.PopulationConfig{
+		Size: 4, Threshold: 1, DecayRate: 0.9, RefractoryPeriod: 2 * time.Millisecond,
+		DelayScheduler: runner.Schedule,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	runner.AddStepper("drive", func(time.Time) {
+		for i := 0; i < pop.Size(); i++ {
+			pop.Inject(i, 0.5)
+		}
+	})
+	runner.AddPopulation(pop)
+
+	var reports []ProgressReport
+	var events int64
+	progress, err := NewProgress(runner, ProgressConfig{
+		Target:           100 * time.Millisecond,
+		Interval:         time.Nanosecond, // report on every step
+		Callback:         func(report ProgressReport) { reports = append(reports, report) },
+		Spikes:           PopulationSpikes(pop),
+		PlasticityEvents: func() int64 { return events },
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		events += 3
+		if err := progress.Step(10 * time.Millisecond); err != nil {
+			t.Fatalf("Step failed: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	progress.Finish()
+	progress.Finish()
+
+	if len(reports) != 11 {
+		t.Fatalf("Expected 10 periodic reports and one final report, got %d", len(reports))
+	}
+	half := reports[4]
+	if half.Simulated != 50*time.Millisecond || half.Fraction() != 0.5 || half.PlasticityEvents != 15 {
+		t.Errorf("Unexpected report at half time: %+v", half)
+	}
+	if eta := half.ETA(); eta <= 0 || eta > 2*half.Wall {
+		t.Errorf("Expected an ETA close to the elapsed wall time %v, got %v", half.Wall, eta)
+	}
+	final := reports[10]
+	if !final.Done || final.ETA() != 0 || final.Spikes == 0 || final.Spikes < half.Spikes || final.Speed() <= 0 {
+		t.Errorf("Unexpected final report: %+v", final)
+	}
+
+	var out bytes.Buffer
+	bar := ProgressBar(&out, 10)
+	bar(half)
+	bar(final)
+	if text := out.String(); !strings.Contains(text, "[=====     ]") || !strings.Contains(text, "[==========]") ||
+		!strings.HasSuffix(text, "\n") || !strings.Contains(text, "plasticity events") {
+		t.Errorf("Unexpected progress bar output: %q", text)
+	}
+
+	if _, err := NewProgress(runner, ProgressConfig{}); err == nil {
+		t.Error("Expected error without a callback")
+	}
+}
@@ -0,0 +1,227 @@
+package cosim
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// =================================================================================
+// TIME WARPING
+// =================================================================================
+//
+// Fast dynamics (a burst, a delay line filling up) are over before anyone can
+// look at them at real time, and slow learning takes too long to watch.
+// TimeWarp paces a virtual clock against the wall clock at an adjustable
+// rate and can stop it:
+//
+//   - SetTimeScale(x) runs the network at x times real time, from
+//     COSIM_MIN_TIME_SCALE (slow motion) to COSIM_MAX_TIME_SCALE
+//     (fast-forward). Fast-forward is best effort: a network that cannot
+//     step that fast runs flat out, and Overruns counts the late steps.
+//   - Pause() stops the clock between two steps. Components stepped by the
+//     clock and deliveries queued with LockStep.Schedule freeze together,
+//     so nothing is lost or delivered early. Resume() continues from where
+//     the clock stopped.
+//
+// TimeWarp wraps a Clock (normally a LockStep), so it works as a
+// ClosedLoopConfig.Clock, or free-running with Run.
+//
+// Only the wrapped clock is warped. Neurons added with LockStep.AddNeuron
+// (or a whole network, see network.AttachLockStep) are warped with it: their
+// membrane, refractory and STDP timers and their axonal deliveries all run on
+// the virtual clock. Neurons running their own goroutine and batch
+// populations without a DelayScheduler (which fall back to time.AfterFunc)
+// run on the wall clock and keep running at real time while the warp is
+// paused or scaled.
+
+const (
+	// COSIM_MIN_TIME_SCALE is the slowest supported rate (10× slow motion).
+	COSIM_MIN_TIME_SCALE = 0.1
+
+	// COSIM_MAX_TIME_SCALE is the fastest supported rate.
+	COSIM_MAX_TIME_SCALE = 100.0
+)
+
+// TimeWarp paces a virtual clock against the wall clock.
+type TimeWarp struct {
+	base Clock
+
+	mu       sync.Mutex
+	scale    float64
+	paused   bool
+	resumed  chan struct{} // Closed on Resume; nil while running
+	next     time.Time     // Wall-clock deadline of the last step (zero = reset)
+	steps    int64
+	overruns int64
+	stepMu   sync.Mutex // Serialises Step calls
+}
+
+// NewTimeWarp paces base at real time (scale 1).
+func NewTimeWarp(base Clock) (*TimeWarp, error) {
+	if base == nil {
+		return nil, fmt.Errorf("time warp needs a clock")
+	}
+	return &TimeWarp{base: base, scale: 1}, nil
+}
+
+// Now implements Clock with the virtual time of the wrapped clock.
+func (w *TimeWarp) Now() time.Time {
+	return w.base.Now()
+}
+
+// Step implements Clock. It waits while paused, advances the wrapped clock by
+// dt, and then waits until dt / scale of wall time has passed since the
+// previous step.
+func (w *TimeWarp) Step(dt time.Duration) error {
+	return w.step(context.Background(), dt)
+}
+
+// Run advances the clock in steps of period until ctx is cancelled, for
+// networks without a controller. A period of 0 uses COSIM_DEFAULT_RESOLUTION.
+func (w *TimeWarp) Run(ctx context.Context, period time.Duration) error {
+	if period < 0 {
+		return fmt.Errorf("period cannot be negative: %v", period)
+	}
+	if period == 0 {
+		period = COSIM_DEFAULT_RESOLUTION
+	}
+	for {
+		if err := w.step(ctx, period); err != nil {
+			return err
+		}
+	}
+}
+
+// step is Step with cancellation while paused or pacing.
+func (w *TimeWarp) step(ctx context.Context, dt time.Duration) error {
+	if dt <= 0 {
+		return fmt.Errorf("step must be positive: %v", dt)
+	}
+	w.stepMu.Lock()
+	defer w.stepMu.Unlock()
+
+	if err := w.waitWhilePaused(ctx); err != nil {
+		return err
+	}
+	if err := w.base.Step(dt); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	now := time.Now()
+	if w.next.IsZero() {
+		w.next = now
+	}
+	w.next = w.next.Add(time.Duration(float64(dt) / w.scale))
+	if w.next.Before(now) {
+		// Falling behind: do not bunch up steps to catch up later
+		w.next = now
+		w.overruns++
+	}
+	deadline := w.next
+	w.steps++
+	w.mu.Unlock()
+
+	return sleepUntil(ctx, deadline)
+}
+
+// waitWhilePaused blocks until Resume or ctx is done.
+func (w *TimeWarp) waitWhilePaused(ctx context.Context) error {
+	for {
+		w.mu.Lock()
+		resumed := w.resumed
+		w.mu.Unlock()
+		if resumed == nil {
+			return ctx.Err()
+		}
+		select {
+		case <-resumed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// sleepUntil waits for the wall-clock deadline or ctx.
+func sleepUntil(ctx context.Context, deadline time.Time) error {
+	wait := time.Until(deadline)
+	if wait <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Pause stops the clock before the next step. A step in progress completes.
+func (w *TimeWarp) Pause() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.paused {
+		return
+	}
+	w.paused = true
+	w.resumed = make(chan struct{})
+}
+
+// Resume continues a paused clock. The pause does not count against the
+// pacing schedule.
+func (w *TimeWarp) Resume() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.paused {
+		return
+	}
+	w.paused = false
+	close(w.resumed)
+	w.resumed = nil
+	w.next = time.Time{}
+}
+
+// IsPaused reports whether the clock is paused.
+func (w *TimeWarp) IsPaused() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.paused
+}
+
+// SetTimeScale sets the rate of virtual time relative to the wall clock
+// (2 = twice as fast, 0.5 = half speed). It takes effect from the next step.
+func (w *TimeWarp) SetTimeScale(scale float64) error {
+	if math.IsNaN(scale) || scale < COSIM_MIN_TIME_SCALE || scale > COSIM_MAX_TIME_SCALE {
+		return fmt.Errorf("time scale must be in [%g, %g]: %g", COSIM_MIN_TIME_SCALE, COSIM_MAX_TIME_SCALE, scale)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.scale = scale
+	w.next = time.Time{}
+	return nil
+}
+
+// TimeScale returns the current rate.
+func (w *TimeWarp) TimeScale() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.scale
+}
+
+// GetStats returns pacing counters for monitoring.
+func (w *TimeWarp) GetStats() map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return map[string]interface{}{
+		"virtual_time": w.base.Now(),
+		"time_scale":   w.scale,
+		"paused":       w.paused,
+		"steps":        w.steps,
+		"overruns":     w.overruns,
+	}
+}
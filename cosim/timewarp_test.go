@@ -0,0 +1,117 @@
+package cosim
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestTimeWarpPacesVirtualTime verifies that slow motion and fast-forward
+// stretch and compress the wall time taken by the same virtual interval.
+func TestTimeWarpPacesVirtualTime(t *testing.T) {
+	runner, _ := NewLockStep(time.Unix(0, 0), time.Millisecond)
+	warp, err := NewTimeWarp(runner)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	measure := func(scale float64) time.Duration {
+		if err := warp.SetTimeScale(scale); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		start := time.Now()
+		for i := 0; i < 10; i++ {
+			if err := warp.Step(5 * time.Millisecond); err != nil {
+				t.Fatalf("Step failed: %v", err)
+			}
+		}
+		return time.Since(start)
+	}
+
+	slow := measure(0.5) // 50ms virtual over ~100ms
+	fast := measure(10)  // 50ms virtual over ~5ms
+	if slow < 90*time.Millisecond {
+		t.Errorf("Expected slow motion to take at least 90ms, took %v", slow)
+	}
+	if fast > slow/4 {
+		t.Errorf("Expected fast-forward well below %v, took %v", slow/4, fast)
+	}
+	if elapsed := runner.Now().Sub(time.Unix(0, 0)); elapsed != 100*time.Millisecond {
+		t.Errorf("Expected 100ms of virtual time regardless of scale, got %v", elapsed)
+	}
+
+	for _, scale := range []float64{0, 0.05, 150} {
+		if warp.SetTimeScale(scale) == nil {
+			t.Errorf("Expected scale %g to be rejected", scale)
+		}
+	}
+	if warp.TimeScale() != 10 {
+		t.Errorf("Expected a rejected scale to leave 10, got %g", warp.TimeScale())
+	}
+	if _, err := NewTimeWarp(nil); err == nil {
+		t.Error("Expected an error without a clock")
+	}
+}
+
+// TestTimeWarpPauseFreezesDelays verifies that a paused network neither
+// advances its clock nor delivers spikes in flight, and resumes where it
+// stopped.
+func TestTimeWarpPauseFreezesDelays(t *testing.T) {
+	runner, _ := NewLockStep(time.Unix(0, 0), time.Millisecond)
+	pop, err := batch.NewPopulation("warp", batch.PopulationConfig{
+		Size: 1, Threshold: 1.0, DecayRate: 1.0, DelayScheduler: runner.Schedule,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	runner.AddPopulation(pop)
+	warp, _ := NewTimeWarp(runner)
+
+	warp.Pause()
+	runner.Schedule(types.NeuralSignal{Value: 1.5, SourceID: "stimulus"}, pop.Member(0), 5*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- warp.Run(ctx, time.Millisecond) }()
+
+	time.Sleep(30 * time.Millisecond)
+	if !warp.IsPaused() || runner.Now() != time.Unix(0, 0) || runner.Pending() != 1 {
+		t.Fatalf("Expected the paused clock at 0 with the spike in flight, got %v and %d pending",
+			runner.Now().Sub(time.Unix(0, 0)), runner.Pending())
+	}
+
+	warp.Resume()
+	deadline := time.Now().Add(time.Second)
+	for pop.GetStats()["spike_count"].(int64) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the delayed spike to arrive after resuming")
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+
+	// Pausing a running loop stops it at a step boundary
+	warp.Pause()
+	time.Sleep(5 * time.Millisecond)
+	frozen := runner.Now()
+	time.Sleep(20 * time.Millisecond)
+	if runner.Now() != frozen {
+		t.Errorf("Expected the clock to stay at %v while paused, got %v", frozen, runner.Now())
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected cancellation while paused, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return after cancellation")
+	}
+	if steps := warp.GetStats()["steps"].(int64); steps == 0 {
+		t.Error("Expected steps to be counted")
+	}
+}
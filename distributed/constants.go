@@ -0,0 +1,31 @@
+package distributed
+
+import "time"
+
+// =================================================================================
+// DISTRIBUTION LAYER DEFAULTS
+// =================================================================================
+
+const (
+	// DISTRIBUTED_DEFAULT_FLUSH_INTERVAL controls how often buffered outbound
+	// spikes are sent to remote partitions. 1ms matches the finest temporal
+	// resolution used elsewhere in the simulation (neuron decay tick), so
+	// batching never adds more latency than the network already tolerates.
+	DISTRIBUTED_DEFAULT_FLUSH_INTERVAL = 1 * time.Millisecond
+
+	// DISTRIBUTED_DEFAULT_MAX_BATCH_SIZE triggers an early flush when a single
+	// partition's outbound buffer grows beyond this many spikes.
+	DISTRIBUTED_DEFAULT_MAX_BATCH_SIZE = 256
+
+	// DISTRIBUTED_UDP_MAX_DATAGRAM is the largest encoded batch the UDP
+	// transport will send. Larger batches must be split by the caller or sent
+	// over a stream transport.
+	DISTRIBUTED_UDP_MAX_DATAGRAM = 65000
+
+	// DISTRIBUTED_DIAL_TIMEOUT bounds connection attempts to peer partitions.
+	DISTRIBUTED_DIAL_TIMEOUT = 2 * time.Second
+
+	// DISTRIBUTED_WRITE_TIMEOUT bounds a single batch write to a peer, so a
+	// stalled peer cannot hold its connection (or Close) indefinitely.
+	DISTRIBUTED_WRITE_TIMEOUT = 2 * time.Second
+)
@@ -0,0 +1,357 @@
+package distributed
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// recordingReceiver is a minimal MessageReceiver that records arrivals.
+type recordingReceiver struct {
+	*component.BaseComponent
+	mu       sync.Mutex
+	received []types.NeuralSignal
+	arrivals []time.Time
+}
+
+func newRecordingReceiver(id string) *recordingReceiver {
+	return &recordingReceiver{
+		BaseComponent: component.NewBaseComponent(id, types.TypeNeuron, types.Position3D{}),
+	}
+}
+
+func (r *recordingReceiver) Receive(msg types.NeuralSignal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, msg)
+	r.arrivals = append(r.arrivals, time.Now())
+}
+
+func (r *recordingReceiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.received)
+}
+
+// waitForCount polls until the receiver has at least n messages or times out.
+func waitForCount(r *recordingReceiver, n int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if r.count() >= n {
+			return true
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return r.count() >= n
+}
+
+// twoPartitions returns a static partitioner placing "a_*" on A and "b_*" on B.
+func twoPartitions() *StaticPartitioner {
+	p := NewStaticPartitioner(NewHashPartitioner("A", "B"))
+	p.Assign("a_neuron", "A")
+	p.Assign("b_neuron", "B")
+	return p
+}
+
+func TestHashPartitionerIsDeterministic(t *testing.T) {
+	p1 := NewHashPartitioner("p0", "p1", "p2")
+	p2 := NewHashPartitioner("p0", "p1", "p2")
+
+	counts := make(map[string]int)
+	for i := 0; i < 300; i++ {
+		id := "neuron_" + string(rune('a'+i%26)) + time.Duration(i).String()
+		if p1.PartitionOf(id) != p2.PartitionOf(id) {
+			t.Fatalf("Partition assignment differs between instances for %s", id)
+		}
+		counts[p1.PartitionOf(id)]++
+	}
+
+	if len(counts) != 3 {
+		t.Errorf("Expected components spread over 3 partitions, got %v", counts)
+	}
+	if NewHashPartitioner().PartitionOf("x") != "" {
+		t.Error("Expected empty partition with no partitions configured")
+	}
+}
+
+func TestNodeRejectsForeignRegistration(t *testing.T) {
+	hub := NewMemoryHub()
+	node, err := NewNode(NodeConfig{
+		PartitionID: "A",
+		Partitioner: twoPartitions(),
+		Transport:   NewMemoryTransport(hub, "A"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create node: %v", err)
+	}
+
+	if err := node.RegisterLocal(newRecordingReceiver("b_neuron")); err == nil {
+		t.Error("Expected error registering a component owned by another partition")
+	}
+	if err := node.RegisterLocal(newRecordingReceiver("a_neuron")); err != nil {
+		t.Errorf("Unexpected error registering local component: %v", err)
+	}
+
+	if _, err := NewNode(NodeConfig{PartitionID: "A"}); err == nil {
+		t.Error("Expected error for missing partitioner and transport")
+	}
+}
+
+func TestDelayCompensationAcrossPartitions(t *testing.T) {
+	hub := NewMemoryHub()
+	partitioner := twoPartitions()
+	latency := 20 * time.Millisecond
+
+	nodeA, _ := NewNode(NodeConfig{
+		PartitionID: "A",
+		Partitioner: partitioner,
+		Transport:   NewMemoryTransport(hub, "A"),
+		LinkLatency: map[string]time.Duration{"B": latency},
+	})
+	nodeB, _ := NewNode(NodeConfig{
+		PartitionID: "B",
+		Partitioner: partitioner,
+		Transport:   NewMemoryTransport(hub, "B"),
+	})
+
+	target := newRecordingReceiver("b_neuron")
+	if err := nodeB.RegisterLocal(target); err != nil {
+		t.Fatalf("Failed to register target: %v", err)
+	}
+	if err := nodeA.Start(); err != nil {
+		t.Fatalf("Failed to start node A: %v", err)
+	}
+	defer nodeA.Stop()
+	if err := nodeB.Start(); err != nil {
+		t.Fatalf("Failed to start node B: %v", err)
+	}
+	defer nodeB.Stop()
+
+	// A 30ms synapse should only wait 10ms locally; the rest is the link
+	synapticDelay := 30 * time.Millisecond
+	if got := nodeA.CompensatedDelay("b_neuron", synapticDelay); got != 10*time.Millisecond {
+		t.Errorf("Expected compensated delay 10ms, got %v", got)
+	}
+	if got := nodeA.CompensatedDelay("a_neuron", synapticDelay); got != synapticDelay {
+		t.Errorf("Local targets should not be compensated, got %v", got)
+	}
+
+	proxy, err := nodeA.Resolve("b_neuron")
+	if err != nil {
+		t.Fatalf("Failed to resolve remote target: %v", err)
+	}
+	if _, ok := proxy.(*RemoteReceiver); !ok {
+		t.Fatalf("Expected RemoteReceiver proxy, got %T", proxy)
+	}
+
+	// The in-memory transport is near-instant, so node B must hold the spike
+	// until the configured link latency has elapsed
+	sent := time.Now()
+	proxy.Receive(types.NeuralSignal{Value: 1.5, SourceID: "a_neuron", Timestamp: sent})
+
+	if !waitForCount(target, 1, time.Second) {
+		t.Fatal("Remote spike was not delivered")
+	}
+	target.mu.Lock()
+	elapsed := target.arrivals[0].Sub(sent)
+	value := target.received[0].Value
+	target.mu.Unlock()
+
+	if elapsed < latency-2*time.Millisecond {
+		t.Errorf("Spike delivered after %v, expected at least ~%v", elapsed, latency)
+	}
+	if value != 1.5 {
+		t.Errorf("Expected value 1.5, got %f", value)
+	}
+
+	stats := nodeB.Stats()
+	if stats.SpikesReceived != 1 || stats.SpikesDelivered != 1 {
+		t.Errorf("Unexpected receiver stats: %+v", stats)
+	}
+}
+
+func TestStopCancelsHeldSpikes(t *testing.T) {
+	hub := NewMemoryHub()
+	partitioner := twoPartitions()
+	latency := 30 * time.Millisecond
+
+	nodeA, _ := NewNode(NodeConfig{
+		PartitionID: "A",
+		Partitioner: partitioner,
+		Transport:   NewMemoryTransport(hub, "A"),
+		LinkLatency: map[string]time.Duration{"B": latency},
+	})
+	nodeB, _ := NewNode(NodeConfig{
+		PartitionID: "B",
+		Partitioner: partitioner,
+		Transport:   NewMemoryTransport(hub, "B"),
+	})
+
+	target := newRecordingReceiver("b_neuron")
+	if err := nodeB.RegisterLocal(target); err != nil {
+		t.Fatalf("Failed to register target: %v", err)
+	}
+	if err := nodeA.Start(); err != nil {
+		t.Fatalf("Failed to start node A: %v", err)
+	}
+	defer nodeA.Stop()
+	if err := nodeB.Start(); err != nil {
+		t.Fatalf("Failed to start node B: %v", err)
+	}
+
+	proxy, err := nodeA.Resolve("b_neuron")
+	if err != nil {
+		t.Fatalf("Failed to resolve remote target: %v", err)
+	}
+	proxy.Receive(types.NeuralSignal{Value: 1.0, SourceID: "a_neuron", Timestamp: time.Now()})
+	nodeA.Flush()
+
+	// Node B now holds the spike until DeliverAt; stopping must cancel it
+	if got := nodeB.Stats().SpikesReceived; got != 1 {
+		t.Fatalf("Expected node B to hold 1 spike, got %d received", got)
+	}
+	if err := nodeB.Stop(); err != nil {
+		t.Fatalf("Failed to stop node B: %v", err)
+	}
+
+	time.Sleep(2 * latency)
+	if got := target.count(); got != 0 {
+		t.Errorf("Expected no delivery after Stop, got %d", got)
+	}
+	if got := nodeB.Stats().SpikesDelivered; got != 0 {
+		t.Errorf("Expected 0 spikes delivered, got %d", got)
+	}
+}
+
+func TestTCPTransportEndToEnd(t *testing.T) {
+	partitioner := twoPartitions()
+
+	transportB := NewTCPTransport("127.0.0.1:0", nil)
+	nodeB, _ := NewNode(NodeConfig{PartitionID: "B", Partitioner: partitioner, Transport: transportB})
+	target := newRecordingReceiver("b_neuron")
+	nodeB.RegisterLocal(target)
+	if err := nodeB.Start(); err != nil {
+		t.Fatalf("Failed to start node B: %v", err)
+	}
+	defer nodeB.Stop()
+
+	transportA := NewTCPTransport("127.0.0.1:0", map[string]string{"B": transportB.Addr()})
+	nodeA, _ := NewNode(NodeConfig{PartitionID: "A", Partitioner: partitioner, Transport: transportA})
+	if err := nodeA.Start(); err != nil {
+		t.Fatalf("Failed to start node A: %v", err)
+	}
+	defer nodeA.Stop()
+
+	proxy, _ := nodeA.Resolve("b_neuron")
+	for i := 0; i < 50; i++ {
+		proxy.Receive(types.NeuralSignal{Value: float64(i), SourceID: "a_neuron"})
+	}
+
+	if !waitForCount(target, 50, 2*time.Second) {
+		t.Fatalf("Expected 50 spikes over TCP, got %d", target.count())
+	}
+
+	// TCP preserves order per peer
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	for i, msg := range target.received {
+		if msg.Value != float64(i) {
+			t.Fatalf("Spike %d out of order: value %f", i, msg.Value)
+		}
+		if msg.TargetID != "b_neuron" {
+			t.Fatalf("Expected target ID to be filled in, got %q", msg.TargetID)
+		}
+	}
+}
+
+func TestUDPTransportEndToEnd(t *testing.T) {
+	partitioner := twoPartitions()
+
+	transportB, _ := NewUDPTransport("127.0.0.1:0", nil)
+	nodeB, _ := NewNode(NodeConfig{PartitionID: "B", Partitioner: partitioner, Transport: transportB})
+	target := newRecordingReceiver("b_neuron")
+	nodeB.RegisterLocal(target)
+	if err := nodeB.Start(); err != nil {
+		t.Fatalf("Failed to start node B: %v", err)
+	}
+	defer nodeB.Stop()
+
+	transportA, err := NewUDPTransport("127.0.0.1:0", map[string]string{"B": transportB.Addr()})
+	if err != nil {
+		t.Fatalf("Failed to create UDP transport: %v", err)
+	}
+	nodeA, _ := NewNode(NodeConfig{PartitionID: "A", Partitioner: partitioner, Transport: transportA})
+	if err := nodeA.Start(); err != nil {
+		t.Fatalf("Failed to start node A: %v", err)
+	}
+	defer nodeA.Stop()
+
+	proxy, _ := nodeA.Resolve("b_neuron")
+	proxy.Receive(types.NeuralSignal{Value: 2.0, SourceID: "a_neuron"})
+
+	// Loopback UDP is reliable in practice; a loss here indicates a bug
+	if !waitForCount(target, 1, 2*time.Second) {
+		t.Fatal("Expected spike over UDP loopback")
+	}
+}
+
+func TestSignalExtensionsOverTCP(t *testing.T) {
+	partitioner := twoPartitions()
+
+	transportB := NewTCPTransport("127.0.0.1:0", nil)
+	nodeB, _ := NewNode(NodeConfig{PartitionID: "B", Partitioner: partitioner, Transport: transportB})
+	target := newRecordingReceiver("b_neuron")
+	nodeB.RegisterLocal(target)
+	if err := nodeB.Start(); err != nil {
+		t.Fatalf("Failed to start node B: %v", err)
+	}
+	defer nodeB.Stop()
+
+	transportA := NewTCPTransport("127.0.0.1:0", map[string]string{"B": transportB.Addr()})
+	nodeA, _ := NewNode(NodeConfig{PartitionID: "A", Partitioner: partitioner, Transport: transportA})
+	if err := nodeA.Start(); err != nil {
+		t.Fatalf("Failed to start node A: %v", err)
+	}
+	defer nodeA.Stop()
+
+	base := types.NeuralSignal{Value: 1.0, SourceID: "a_neuron"}
+	tagged := base.WithMetadata("receptor", "NMDA")
+	if base.Metadata != nil || base.SchemaVersion() != types.SignalSchemaV1 {
+		t.Fatalf("WithMetadata modified the original signal: %+v", base)
+	}
+	tagged, err := tagged.WithPayload([]byte{0xca, 0xfe})
+	if err != nil {
+		t.Fatalf("WithPayload failed: %v", err)
+	}
+	if _, err := base.WithPayload(make([]byte, types.MaxSignalPayloadBytes+1)); err == nil {
+		t.Error("Expected oversized payload to be rejected")
+	}
+
+	proxy, _ := nodeA.Resolve("b_neuron")
+	proxy.Receive(types.NeuralSignal{Value: 2.0, SourceID: "a_neuron", Version: types.SignalSchemaCurrent + 1})
+	proxy.Receive(tagged)
+
+	if !waitForCount(target, 1, 2*time.Second) {
+		t.Fatalf("Expected the tagged spike over TCP, got %d", target.count())
+	}
+
+	// TCP preserves order, so the incompatible spike was handled first
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	if len(target.received) != 1 {
+		t.Fatalf("Expected only the compatible spike to be delivered, got %d", len(target.received))
+	}
+	msg := target.received[0]
+	if receptor, _ := msg.GetMetadata("receptor"); receptor != "NMDA" {
+		t.Errorf("Expected metadata to survive transport, got %v", msg.Metadata)
+	}
+	if string(msg.Payload) != "\xca\xfe" || msg.SchemaVersion() != types.SignalSchemaV2 {
+		t.Errorf("Expected v2 payload to survive transport, got version %d payload %x", msg.Version, msg.Payload)
+	}
+	if stats := nodeB.Stats(); stats.Incompatible != 1 {
+		t.Errorf("Expected 1 incompatible spike, got %d", stats.Incompatible)
+	}
+}
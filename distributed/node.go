@@ -0,0 +1,442 @@
+/*
+=================================================================================
+PARTITION NODE - LOCAL HOST FOR ONE SLICE OF THE NETWORK
+=================================================================================
+
+A Node runs in every process that hosts a partition. It:
+
+  - Owns the registry of local message receivers (neurons).
+  - Resolves any target ID to either the local receiver or a RemoteReceiver
+    proxy, so synapses can be wired exactly as in a single-process network.
+  - Buffers outbound spikes per partition and flushes them in batches.
+  - Delivers inbound spikes to local receivers at their intended time.
+
+DELAY COMPENSATION:
+Crossing a process boundary adds transport latency that a real axon would not
+have. The node absorbs it into the synaptic delay budget:
+
+  1. Sender side: CompensatedDelay(target, delay) shortens the delay used for
+     the local axonal queue by the expected link latency to the target's
+     partition.
+  2. The RemoteReceiver stamps each spike with DeliverAt = now + latency.
+  3. Receiver side: spikes arriving before DeliverAt are held until then;
+     spikes arriving late are delivered immediately and counted as late.
+
+The end-to-end delay therefore matches the configured synaptic delay whenever
+actual latency is at or below the configured estimate. DeliverAt is wall-clock
+time, so hosts should be NTP-synchronized; NodeConfig.ClockOffset corrects for
+a known residual skew.
+=================================================================================
+*/
+
+package distributed
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// NodeConfig configures a partition node.
+type NodeConfig struct {
+	PartitionID   string                   // This process's partition
+	Partitioner   Partitioner              // Shared component -> partition mapping
+	Transport     Transport                // Inter-partition spike transport
+	LinkLatency   map[string]time.Duration // Expected one-way latency per peer partition
+	ClockOffset   time.Duration            // Local clock minus cluster reference clock
+	FlushInterval time.Duration            // Outbound batching interval (0 = default)
+	MaxBatchSize  int                      // Early flush threshold per partition (0 = default)
+}
+
+// NodeStats summarizes inter-partition traffic.
+type NodeStats struct {
+	SpikesSent      int64 // Spikes handed to the transport
+	SpikesReceived  int64 // Spikes received from the transport
+	SpikesDelivered int64 // Inbound spikes delivered to local receivers
+	LateDeliveries  int64 // Inbound spikes that arrived after DeliverAt
+	UnknownTargets  int64 // Inbound spikes for targets not registered locally
+	Incompatible    int64 // Inbound spikes with a signal schema this build cannot read
+	SendErrors      int64 // Batches the transport failed to send
+	BatchesSent     int64 // Batches flushed to the transport
+}
+
+// Node hosts one partition of a distributed network.
+type Node struct {
+	config  NodeConfig
+	local   map[string]component.MessageReceiver
+	proxies map[string]*RemoteReceiver
+	outbox  map[string][]RemoteSpike
+	latency map[string]time.Duration
+	stats   NodeStats
+
+	started    bool
+	stopCh     chan struct{}
+	held       map[*time.Timer]struct{} // Early arrivals waiting for DeliverAt
+	deliveries sync.WaitGroup           // Held spikes being delivered
+	wg         sync.WaitGroup
+	mu         sync.Mutex
+}
+
+// NewNode validates the configuration and creates a node.
+func NewNode(config NodeConfig) (*Node, error) {
+	if config.PartitionID == "" {
+		return nil, fmt.Errorf("partition ID cannot be empty")
+	}
+	if config.Partitioner == nil {
+		return nil, fmt.Errorf("partitioner cannot be nil")
+	}
+	if config.Transport == nil {
+		return nil, fmt.Errorf("transport cannot be nil")
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = DISTRIBUTED_DEFAULT_FLUSH_INTERVAL
+	}
+	if config.MaxBatchSize <= 0 {
+		config.MaxBatchSize = DISTRIBUTED_DEFAULT_MAX_BATCH_SIZE
+	}
+
+	latency := make(map[string]time.Duration, len(config.LinkLatency))
+	for partition, d := range config.LinkLatency {
+		latency[partition] = d
+	}
+
+	return &Node{
+		config:  config,
+		local:   make(map[string]component.MessageReceiver),
+		proxies: make(map[string]*RemoteReceiver),
+		outbox:  make(map[string][]RemoteSpike),
+		latency: latency,
+		held:    make(map[*time.Timer]struct{}),
+	}, nil
+}
+
+// PartitionID returns the partition this node hosts.
+func (n *Node) PartitionID() string {
+	return n.config.PartitionID
+}
+
+// RegisterLocal adds a receiver hosted by this partition.
+// Returns an error if the partitioner assigns it elsewhere.
+func (n *Node) RegisterLocal(receiver component.MessageReceiver) error {
+	id := receiver.ID()
+	if owner := n.config.Partitioner.PartitionOf(id); owner != n.config.PartitionID {
+		return fmt.Errorf("component %s belongs to partition %s, not %s", id, owner, n.config.PartitionID)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.local[id] = receiver
+	return nil
+}
+
+// UnregisterLocal removes a local receiver.
+func (n *Node) UnregisterLocal(id string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.local, id)
+}
+
+// IsLocal reports whether componentID is owned by this partition.
+func (n *Node) IsLocal(componentID string) bool {
+	return n.config.Partitioner.PartitionOf(componentID) == n.config.PartitionID
+}
+
+// Resolve returns a receiver for targetID: the registered local receiver, or a
+// RemoteReceiver proxy when the target lives in another partition.
+func (n *Node) Resolve(targetID string) (component.MessageReceiver, error) {
+	partition := n.config.Partitioner.PartitionOf(targetID)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if partition == n.config.PartitionID {
+		receiver, exists := n.local[targetID]
+		if !exists {
+			return nil, fmt.Errorf("local component not registered: %s", targetID)
+		}
+		return receiver, nil
+	}
+
+	if proxy, exists := n.proxies[targetID]; exists {
+		return proxy, nil
+	}
+	proxy := newRemoteReceiver(n, targetID, partition)
+	n.proxies[targetID] = proxy
+	return proxy, nil
+}
+
+// SetLinkLatency updates the expected one-way latency to a peer partition,
+// e.g. from a periodic RTT/2 measurement.
+func (n *Node) SetLinkLatency(partition string, latency time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.latency[partition] = latency
+}
+
+// LinkLatency returns the expected one-way latency to a peer partition.
+func (n *Node) LinkLatency(partition string) time.Duration {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.latency[partition]
+}
+
+// CompensatedDelay returns the delay the sender should wait locally so that
+// local wait + transport latency equals the requested synaptic delay.
+// Local targets are returned unchanged; the result is never negative.
+func (n *Node) CompensatedDelay(targetID string, delay time.Duration) time.Duration {
+	partition := n.config.Partitioner.PartitionOf(targetID)
+	if partition == n.config.PartitionID {
+		return delay
+	}
+	compensated := delay - n.LinkLatency(partition)
+	if compensated < 0 {
+		return 0
+	}
+	return compensated
+}
+
+// Start begins listening for inbound spikes and flushing outbound batches.
+func (n *Node) Start() error {
+	n.mu.Lock()
+	if n.started {
+		n.mu.Unlock()
+		return fmt.Errorf("node %s already started", n.config.PartitionID)
+	}
+	n.started = true
+	n.stopCh = make(chan struct{})
+	n.mu.Unlock()
+
+	if err := n.config.Transport.Listen(n.handleBatch); err != nil {
+		n.mu.Lock()
+		n.started = false
+		n.mu.Unlock()
+		return err
+	}
+
+	n.wg.Add(1)
+	go n.flushLoop()
+	return nil
+}
+
+// Stop flushes pending spikes, stops background work and closes the transport.
+// Inbound spikes still held for their DeliverAt time are dropped; once Stop
+// returns nothing more is delivered to local receivers.
+func (n *Node) Stop() error {
+	n.mu.Lock()
+	if !n.started {
+		n.mu.Unlock()
+		return nil
+	}
+	n.started = false
+	close(n.stopCh)
+	for timer := range n.held {
+		timer.Stop()
+	}
+	n.held = make(map[*time.Timer]struct{})
+	n.mu.Unlock()
+
+	n.wg.Wait()
+	n.deliveries.Wait()
+	n.Flush()
+	return n.config.Transport.Close()
+}
+
+// Flush sends all buffered outbound spikes immediately.
+func (n *Node) Flush() {
+	n.mu.Lock()
+	pending := n.outbox
+	n.outbox = make(map[string][]RemoteSpike)
+	n.mu.Unlock()
+
+	for partition, spikes := range pending {
+		n.sendBatch(partition, spikes)
+	}
+}
+
+// Stats returns a snapshot of traffic counters.
+func (n *Node) Stats() NodeStats {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.stats
+}
+
+// =================================================================================
+// INTERNAL: OUTBOUND PATH
+// =================================================================================
+
+// enqueue buffers a spike for a remote partition, flushing early if the
+// partition's buffer is full.
+func (n *Node) enqueue(partition string, spike RemoteSpike) {
+	n.mu.Lock()
+	n.outbox[partition] = append(n.outbox[partition], spike)
+	var ready []RemoteSpike
+	if len(n.outbox[partition]) >= n.config.MaxBatchSize {
+		ready = n.outbox[partition]
+		delete(n.outbox, partition)
+	}
+	n.mu.Unlock()
+
+	if ready != nil {
+		n.sendBatch(partition, ready)
+	}
+}
+
+// sendBatch hands one batch to the transport and records the outcome.
+func (n *Node) sendBatch(partition string, spikes []RemoteSpike) {
+	if len(spikes) == 0 {
+		return
+	}
+	err := n.config.Transport.Send(partition, SpikeBatch{
+		SourcePartition: n.config.PartitionID,
+		TargetPartition: partition,
+		Spikes:          spikes,
+	})
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err != nil {
+		n.stats.SendErrors++
+		return
+	}
+	n.stats.BatchesSent++
+	n.stats.SpikesSent += int64(len(spikes))
+}
+
+// flushLoop periodically flushes outbound buffers.
+func (n *Node) flushLoop() {
+	defer n.wg.Done()
+	ticker := time.NewTicker(n.config.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-n.stopCh:
+			return
+		case <-ticker.C:
+			n.Flush()
+		}
+	}
+}
+
+// =================================================================================
+// INTERNAL: INBOUND PATH
+// =================================================================================
+
+// handleBatch delivers inbound spikes to local receivers, holding early
+// arrivals until their DeliverAt time. Spikes from a peer running a newer
+// signal schema are dropped rather than misread, as are spikes arriving while
+// the node is stopped.
+func (n *Node) handleBatch(batch SpikeBatch) {
+	now := time.Now()
+
+	for _, spike := range batch.Spikes {
+		n.mu.Lock()
+		n.stats.SpikesReceived++
+		if !n.started {
+			n.mu.Unlock()
+			continue
+		}
+		if spike.Signal.Validate() != nil {
+			n.stats.Incompatible++
+			n.mu.Unlock()
+			continue
+		}
+		receiver, exists := n.local[spike.Signal.TargetID]
+		if !exists {
+			n.stats.UnknownTargets++
+			n.mu.Unlock()
+			continue
+		}
+
+		var wait time.Duration
+		if !spike.DeliverAt.IsZero() {
+			wait = spike.DeliverAt.Add(n.config.ClockOffset).Sub(now)
+		}
+		if wait > 0 {
+			n.holdUnsafe(receiver, spike.Signal, wait)
+			n.mu.Unlock()
+			continue
+		}
+		if wait < 0 {
+			n.stats.LateDeliveries++
+		}
+		n.stats.SpikesDelivered++
+		n.mu.Unlock()
+
+		receiver.Receive(spike.Signal)
+	}
+}
+
+// holdUnsafe schedules delivery of an early spike after wait. The timer is
+// tracked so Stop can cancel it. Must be called with mu held.
+func (n *Node) holdUnsafe(receiver component.MessageReceiver, msg types.NeuralSignal, wait time.Duration) {
+	var timer *time.Timer
+	timer = time.AfterFunc(wait, func() {
+		n.mu.Lock()
+		if _, pending := n.held[timer]; !pending {
+			// Cancelled by Stop after firing
+			n.mu.Unlock()
+			return
+		}
+		delete(n.held, timer)
+		n.stats.SpikesDelivered++
+		n.deliveries.Add(1)
+		n.mu.Unlock()
+
+		defer n.deliveries.Done()
+		receiver.Receive(msg)
+	})
+	n.held[timer] = struct{}{}
+}
+
+// =================================================================================
+// REMOTE RECEIVER PROXY
+// =================================================================================
+
+// RemoteReceiver stands in for a neuron hosted by another partition. It
+// satisfies component.MessageReceiver so synapses and axonal queues can target
+// it exactly like a local neuron.
+type RemoteReceiver struct {
+	*component.BaseComponent
+	node      *Node
+	partition string
+}
+
+// newRemoteReceiver creates a proxy for targetID in partition.
+func newRemoteReceiver(node *Node, targetID, partition string) *RemoteReceiver {
+	proxy := &RemoteReceiver{
+		BaseComponent: component.NewBaseComponent(targetID, types.TypeNeuron, types.Position3D{}),
+		node:          node,
+		partition:     partition,
+	}
+	proxy.UpdateMetadata("partition", partition)
+	proxy.UpdateMetadata("remote", true)
+	return proxy
+}
+
+// Partition returns the partition hosting the real component.
+func (rr *RemoteReceiver) Partition() string {
+	return rr.partition
+}
+
+// Receive forwards the signal to the owning partition. The spike is stamped to
+// arrive one link latency from now, matching the delay already subtracted by
+// Node.CompensatedDelay.
+func (rr *RemoteReceiver) Receive(msg types.NeuralSignal) {
+	if msg.TargetID == "" {
+		msg.TargetID = rr.ID()
+	}
+	// Timestamps travel in the cluster reference clock
+	now := time.Now().Add(-rr.node.config.ClockOffset)
+	spike := RemoteSpike{
+		Signal: msg,
+		SentAt: now,
+	}
+	if latency := rr.node.LinkLatency(rr.partition); latency > 0 {
+		spike.DeliverAt = now.Add(latency)
+	}
+	rr.node.enqueue(rr.partition, spike)
+}
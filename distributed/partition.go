@@ -0,0 +1,136 @@
+/*
+=================================================================================
+NETWORK PARTITIONING
+=================================================================================
+
+A partition is a shared-nothing slice of the network hosted by one OS process
+(or machine). Every component ID maps to exactly one partition; spikes whose
+target lives in another partition are carried by a Transport.
+
+Partitioners must be deterministic: every process in the cluster has to agree
+on where a given neuron lives without coordination.
+=================================================================================
+*/
+
+package distributed
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// Partitioner assigns component IDs to partitions.
+type Partitioner interface {
+	// PartitionOf returns the partition ID that owns the given component.
+	PartitionOf(componentID string) string
+
+	// Partitions lists every partition known to the partitioner.
+	Partitions() []string
+}
+
+// =================================================================================
+// HASH PARTITIONER
+// =================================================================================
+
+// HashPartitioner spreads components uniformly across partitions using a
+// stable FNV-1a hash of the component ID. It needs no shared state, which makes
+// it the default choice for randomly connected networks.
+type HashPartitioner struct {
+	partitions []string
+}
+
+// NewHashPartitioner creates a partitioner over the given partition IDs.
+// The order of partitions matters and must be identical on every process.
+func NewHashPartitioner(partitions ...string) *HashPartitioner {
+	owned := make([]string, len(partitions))
+	copy(owned, partitions)
+	return &HashPartitioner{partitions: owned}
+}
+
+// PartitionOf returns the owning partition for componentID.
+// Returns an empty string if no partitions are configured.
+func (hp *HashPartitioner) PartitionOf(componentID string) string {
+	if len(hp.partitions) == 0 {
+		return ""
+	}
+	h := fnv.New32a()
+	h.Write([]byte(componentID))
+	return hp.partitions[h.Sum32()%uint32(len(hp.partitions))]
+}
+
+// Partitions returns a copy of the configured partition IDs.
+func (hp *HashPartitioner) Partitions() []string {
+	result := make([]string, len(hp.partitions))
+	copy(result, hp.partitions)
+	return result
+}
+
+// =================================================================================
+// STATIC PARTITIONER
+// =================================================================================
+
+// StaticPartitioner uses explicit assignments, falling back to another
+// partitioner for unassigned IDs. Use it to keep anatomically related
+// populations (e.g. a cortical column) on the same machine so that most
+// synapses stay local.
+type StaticPartitioner struct {
+	assignments map[string]string
+	fallback    Partitioner
+	mu          sync.RWMutex
+}
+
+// NewStaticPartitioner creates a static partitioner. fallback may be nil, in
+// which case unassigned components map to the empty partition.
+func NewStaticPartitioner(fallback Partitioner) *StaticPartitioner {
+	return &StaticPartitioner{
+		assignments: make(map[string]string),
+		fallback:    fallback,
+	}
+}
+
+// Assign pins componentID to partition.
+func (sp *StaticPartitioner) Assign(componentID, partition string) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	sp.assignments[componentID] = partition
+}
+
+// PartitionOf returns the pinned partition or defers to the fallback.
+func (sp *StaticPartitioner) PartitionOf(componentID string) string {
+	sp.mu.RLock()
+	partition, exists := sp.assignments[componentID]
+	sp.mu.RUnlock()
+
+	if exists {
+		return partition
+	}
+	if sp.fallback != nil {
+		return sp.fallback.PartitionOf(componentID)
+	}
+	return ""
+}
+
+// Partitions lists every partition referenced by assignments or the fallback.
+func (sp *StaticPartitioner) Partitions() []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0)
+
+	if sp.fallback != nil {
+		for _, p := range sp.fallback.Partitions() {
+			if !seen[p] {
+				seen[p] = true
+				result = append(result, p)
+			}
+		}
+	}
+
+	sp.mu.RLock()
+	defer sp.mu.RUnlock()
+	for _, p := range sp.assignments {
+		if !seen[p] {
+			seen[p] = true
+			result = append(result, p)
+		}
+	}
+	return result
+}
@@ -0,0 +1,264 @@
+/*
+=================================================================================
+SPIKE TRAFFIC COMPRESSION
+=================================================================================
+
+Streaming every spike as its own JSON message to a dashboard or another process
+costs ~150 bytes per event. Spike trains are highly regular (a fixed population
+of IDs and monotonically increasing times), so a stateful binary encoding is
+far cheaper:
+
+  - Neuron IDs are sent once per stream and afterwards referenced by a varint
+    index into a shared dictionary.
+  - Times are quantized to a configurable resolution and sent as varint deltas
+    from the previous spike.
+
+Dense activity typically encodes in 2-4 bytes per spike, a >10x reduction.
+
+PACKET LAYOUT (all integers are varints):
+
+  magic 'S' | version | resolution(ns) | new-id count | {len, bytes}... |
+  event count | base delta (signed) | {id index, time delta}...
+
+The dictionary and time base carry over between packets, so packets from one
+encoder must be decoded in order by a single decoder.
+=================================================================================
+*/
+
+package distributed
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	spikePacketMagic   byte = 'S'
+	spikePacketVersion byte = 1
+
+	minSpikeEventSize = 2 // One varint byte each for the ID index and the time delta
+)
+
+// SpikeEvent is a single spike for streaming purposes.
+type SpikeEvent struct {
+	NeuronID string    `json:"neuron_id"`
+	Time     time.Time `json:"time"`
+}
+
+// =================================================================================
+// ENCODER
+// =================================================================================
+
+// SpikeEncoder turns batches of spike events into compact packets.
+// Not safe for concurrent use.
+type SpikeEncoder struct {
+	resolution time.Duration
+	ids        map[string]uint64
+	lastTick   int64
+}
+
+// NewSpikeEncoder creates an encoder quantizing times to resolution.
+// A non-positive resolution defaults to one microsecond.
+func NewSpikeEncoder(resolution time.Duration) *SpikeEncoder {
+	if resolution <= 0 {
+		resolution = time.Microsecond
+	}
+	return &SpikeEncoder{
+		resolution: resolution,
+		ids:        make(map[string]uint64),
+	}
+}
+
+// Encode produces one packet for events. Events are sorted by time before
+// encoding; the input slice is not modified.
+func (e *SpikeEncoder) Encode(events []SpikeEvent) []byte {
+	sorted := make([]SpikeEvent, len(events))
+	copy(sorted, events)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	// Register IDs not yet known to the decoder
+	newIDs := make([]string, 0)
+	for _, ev := range sorted {
+		if _, known := e.ids[ev.NeuronID]; !known {
+			e.ids[ev.NeuronID] = uint64(len(e.ids))
+			newIDs = append(newIDs, ev.NeuronID)
+		}
+	}
+
+	buf := make([]byte, 0, 16+len(sorted)*4)
+	buf = append(buf, spikePacketMagic, spikePacketVersion)
+	buf = binary.AppendUvarint(buf, uint64(e.resolution))
+
+	buf = binary.AppendUvarint(buf, uint64(len(newIDs)))
+	for _, id := range newIDs {
+		buf = binary.AppendUvarint(buf, uint64(len(id)))
+		buf = append(buf, id...)
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(sorted)))
+	if len(sorted) == 0 {
+		return buf
+	}
+
+	firstTick := sorted[0].Time.UnixNano() / int64(e.resolution)
+	buf = binary.AppendVarint(buf, firstTick-e.lastTick)
+
+	prev := firstTick
+	for _, ev := range sorted {
+		tick := ev.Time.UnixNano() / int64(e.resolution)
+		buf = binary.AppendUvarint(buf, e.ids[ev.NeuronID])
+		buf = binary.AppendUvarint(buf, uint64(tick-prev))
+		prev = tick
+	}
+	e.lastTick = prev
+
+	return buf
+}
+
+// Reset clears the dictionary and time base. The paired decoder must be
+// reset at the same point in the stream.
+func (e *SpikeEncoder) Reset() {
+	e.ids = make(map[string]uint64)
+	e.lastTick = 0
+}
+
+// =================================================================================
+// DECODER
+// =================================================================================
+
+// SpikeDecoder reconstructs spike events from packets produced by a
+// SpikeEncoder. Not safe for concurrent use.
+type SpikeDecoder struct {
+	ids      []string
+	lastTick int64
+}
+
+// NewSpikeDecoder creates an empty decoder.
+func NewSpikeDecoder() *SpikeDecoder {
+	return &SpikeDecoder{ids: make([]string, 0)}
+}
+
+// Decode parses one packet. Times are reconstructed at the encoder's
+// resolution.
+func (d *SpikeDecoder) Decode(packet []byte) ([]SpikeEvent, error) {
+	if len(packet) < 2 || packet[0] != spikePacketMagic {
+		return nil, fmt.Errorf("not a spike packet")
+	}
+	if packet[1] != spikePacketVersion {
+		return nil, fmt.Errorf("unsupported spike packet version: %d", packet[1])
+	}
+	r := &packetReader{buf: packet[2:]}
+
+	resolution := int64(r.uvarint())
+	if r.err == nil && resolution <= 0 {
+		return nil, fmt.Errorf("invalid resolution in spike packet")
+	}
+
+	// New IDs are committed only once the whole packet has decoded, so a
+	// malformed packet leaves the dictionary untouched. Every ID takes at
+	// least its length byte and every event an index and a delta byte, which
+	// bounds the counts before anything is allocated.
+	newIDs := r.uvarint()
+	if r.err == nil && newIDs > uint64(len(r.buf)) {
+		return nil, fmt.Errorf("spike packet declares %d new IDs in %d bytes", newIDs, len(r.buf))
+	}
+	ids := d.ids
+	var added []string
+	for i := uint64(0); i < newIDs && r.err == nil; i++ {
+		added = append(added, string(r.bytes(r.uvarint())))
+	}
+	if len(added) > 0 {
+		ids = append(ids[:len(ids):len(ids)], added...)
+	}
+
+	count := r.uvarint()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if count == 0 {
+		d.ids = ids
+		return []SpikeEvent{}, nil
+	}
+
+	tick := d.lastTick + r.varint()
+	if r.err == nil && count > uint64(len(r.buf))/minSpikeEventSize {
+		return nil, fmt.Errorf("spike packet declares %d events in %d bytes", count, len(r.buf))
+	}
+	events := make([]SpikeEvent, 0, count)
+	for i := uint64(0); i < count && r.err == nil; i++ {
+		index := r.uvarint()
+		tick += int64(r.uvarint())
+		if r.err != nil {
+			break
+		}
+		if index >= uint64(len(ids)) {
+			return nil, fmt.Errorf("unknown neuron index %d in spike packet", index)
+		}
+		events = append(events, SpikeEvent{
+			NeuronID: ids[index],
+			Time:     time.Unix(0, tick*resolution),
+		})
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	d.ids = ids
+	d.lastTick = tick
+	return events, nil
+}
+
+// Reset clears the dictionary and time base.
+func (d *SpikeDecoder) Reset() {
+	d.ids = d.ids[:0]
+	d.lastTick = 0
+}
+
+// packetReader consumes varints with sticky error handling.
+type packetReader struct {
+	buf []byte
+	err error
+}
+
+func (r *packetReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.err = fmt.Errorf("truncated spike packet")
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *packetReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.err = fmt.Errorf("truncated spike packet")
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *packetReader) bytes(n uint64) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if uint64(len(r.buf)) < n {
+		r.err = fmt.Errorf("truncated spike packet")
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
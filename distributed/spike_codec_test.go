@@ -0,0 +1,157 @@
+package distributed
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// denseActivity generates spikes from a population firing at high rates.
+func denseActivity(neurons, spikes int, start time.Time) []SpikeEvent {
+	rng := rand.New(rand.NewSource(42))
+	events := make([]SpikeEvent, spikes)
+	t := start
+	for i := range events {
+		t = t.Add(time.Duration(rng.Intn(100)) * time.Microsecond)
+		events[i] = SpikeEvent{
+			NeuronID: fmt.Sprintf("neuron_%d", rng.Intn(neurons)),
+			Time:     t,
+		}
+	}
+	return events
+}
+
+func TestSpikeCodecRoundTrip(t *testing.T) {
+	encoder := NewSpikeEncoder(time.Microsecond)
+	decoder := NewSpikeDecoder()
+
+	start := time.Unix(1700000000, 0)
+	batches := [][]SpikeEvent{
+		denseActivity(50, 200, start),
+		denseActivity(80, 200, start.Add(time.Second)),
+		{},
+		// Out-of-order input is sorted by the encoder
+		{{NeuronID: "late", Time: start.Add(3 * time.Second)}, {NeuronID: "neuron_1", Time: start.Add(2 * time.Second)}},
+	}
+
+	for b, batch := range batches {
+		packet := encoder.Encode(batch)
+		decoded, err := decoder.Decode(packet)
+		if err != nil {
+			t.Fatalf("Batch %d: decode failed: %v", b, err)
+		}
+		if len(decoded) != len(batch) {
+			t.Fatalf("Batch %d: expected %d events, got %d", b, len(batch), len(decoded))
+		}
+
+		expected := make(map[string]int)
+		for _, ev := range batch {
+			expected[fmt.Sprintf("%s@%d", ev.NeuronID, ev.Time.UnixNano()/1000)]++
+		}
+		for i, ev := range decoded {
+			key := fmt.Sprintf("%s@%d", ev.NeuronID, ev.Time.UnixNano()/1000)
+			if expected[key] == 0 {
+				t.Fatalf("Batch %d: unexpected decoded event %s", b, key)
+			}
+			expected[key]--
+			if i > 0 && ev.Time.Before(decoded[i-1].Time) {
+				t.Fatalf("Batch %d: decoded events not in time order", b)
+			}
+		}
+	}
+}
+
+func TestSpikeCodecCompressionRatio(t *testing.T) {
+	events := denseActivity(1000, 10000, time.Now())
+
+	// Baseline: one JSON message per spike, as the raw stream would send
+	rawBytes := 0
+	for _, ev := range events {
+		msg, _ := json.Marshal(types.NeuralSignal{
+			Value:     1.0,
+			Timestamp: ev.Time,
+			SourceID:  ev.NeuronID,
+		})
+		rawBytes += len(msg)
+	}
+
+	encoder := NewSpikeEncoder(time.Microsecond)
+	compressedBytes := 0
+	for i := 0; i < len(events); i += 500 {
+		compressedBytes += len(encoder.Encode(events[i : i+500]))
+	}
+
+	ratio := float64(rawBytes) / float64(compressedBytes)
+	t.Logf("Raw: %d bytes, compressed: %d bytes, ratio %.1fx", rawBytes, compressedBytes, ratio)
+	if ratio < 10 {
+		t.Errorf("Expected >10x reduction for dense activity, got %.1fx", ratio)
+	}
+}
+
+func TestSpikeDecoderRejectsCorruptPackets(t *testing.T) {
+	encoder := NewSpikeEncoder(time.Microsecond)
+	packet := encoder.Encode(denseActivity(10, 20, time.Now()))
+
+	if _, err := NewSpikeDecoder().Decode([]byte("garbage")); err == nil {
+		t.Error("Expected error for bad magic")
+	}
+	if _, err := NewSpikeDecoder().Decode(packet[:len(packet)/2]); err == nil {
+		t.Error("Expected error for truncated packet")
+	}
+
+	// A second packet without its dictionary references unknown indices
+	second := encoder.Encode(denseActivity(10, 20, time.Now()))
+	if _, err := NewSpikeDecoder().Decode(second); err == nil {
+		t.Error("Expected error decoding packet without preceding dictionary")
+	}
+}
+
+// TestSpikeDecoderRejectsOversizedCount verifies that a packet declaring more
+// events than it can hold is rejected before anything is allocated.
+func TestSpikeDecoderRejectsOversizedCount(t *testing.T) {
+	packet := []byte{spikePacketMagic, spikePacketVersion}
+	packet = binary.AppendUvarint(packet, 1)     // Resolution
+	packet = binary.AppendUvarint(packet, 0)     // New IDs
+	packet = binary.AppendUvarint(packet, 1<<62) // Event count
+	packet = binary.AppendVarint(packet, 0)      // Base delta
+
+	if _, err := NewSpikeDecoder().Decode(packet); err == nil {
+		t.Error("Expected error for an event count larger than the packet")
+	}
+}
+
+// TestSpikeDecoderKeepsDictionaryOnTruncation verifies that new IDs from a
+// packet that fails to decode are not added to the dictionary.
+func TestSpikeDecoderKeepsDictionaryOnTruncation(t *testing.T) {
+	start := time.Now()
+	encoder := NewSpikeEncoder(time.Microsecond)
+	decoder := NewSpikeDecoder()
+	if _, err := decoder.Decode(encoder.Encode([]SpikeEvent{{NeuronID: "a", Time: start}})); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	packet := encoder.Encode([]SpikeEvent{
+		{NeuronID: "b", Time: start.Add(time.Millisecond)},
+		{NeuronID: "c", Time: start.Add(2 * time.Millisecond)},
+	})
+	if _, err := decoder.Decode(packet[:len(packet)-1]); err == nil {
+		t.Fatal("Expected error for truncated packet")
+	}
+	if len(decoder.ids) != 1 {
+		t.Errorf("Expected the dictionary to keep 1 ID, got %v", decoder.ids)
+	}
+
+	// The intact packet still decodes against the unchanged dictionary
+	events, err := decoder.Decode(packet)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(events) != 2 || events[0].NeuronID != "b" || events[1].NeuronID != "c" {
+		t.Errorf("Expected spikes from b and c, got %+v", events)
+	}
+}
@@ -0,0 +1,112 @@
+/*
+=================================================================================
+SPIKE TRANSPORT
+=================================================================================
+
+Transports move batches of spikes between partitions. They are deliberately
+minimal so that additional backends can be added as small adapters without
+touching the Node:
+
+  - Send delivers one batch to the named partition.
+  - Listen registers the single inbound handler and starts receiving.
+  - Close releases sockets and stops receiving.
+
+Bundled implementations:
+  - MemoryTransport: in-process hub, for tests and single-process sharding.
+  - TCPTransport:    reliable, ordered, newline-delimited JSON over TCP.
+  - UDPTransport:    lossy, one JSON batch per datagram, lowest latency.
+=================================================================================
+*/
+
+package distributed
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// RemoteSpike is a neural signal in flight between partitions.
+type RemoteSpike struct {
+	Signal    types.NeuralSignal `json:"signal"`     // The signal as produced by the sending synapse
+	SentAt    time.Time          `json:"sent_at"`    // When the sending partition handed it to the transport
+	DeliverAt time.Time          `json:"deliver_at"` // Intended arrival time at the target (zero = immediately)
+}
+
+// SpikeBatch groups spikes destined for one partition.
+type SpikeBatch struct {
+	SourcePartition string        `json:"source_partition"` // Sending partition
+	TargetPartition string        `json:"target_partition"` // Receiving partition
+	Spikes          []RemoteSpike `json:"spikes"`           // Spikes in send order
+}
+
+// BatchHandler is invoked for every batch received by a transport.
+type BatchHandler func(batch SpikeBatch)
+
+// Transport carries spike batches between partitions.
+type Transport interface {
+	Send(partition string, batch SpikeBatch) error
+	Listen(handler BatchHandler) error
+	Close() error
+}
+
+// =================================================================================
+// IN-MEMORY TRANSPORT
+// =================================================================================
+
+// MemoryHub connects MemoryTransports living in the same process.
+type MemoryHub struct {
+	handlers map[string]BatchHandler
+	mu       sync.RWMutex
+}
+
+// NewMemoryHub creates an empty hub.
+func NewMemoryHub() *MemoryHub {
+	return &MemoryHub{handlers: make(map[string]BatchHandler)}
+}
+
+// MemoryTransport delivers batches synchronously through a MemoryHub.
+type MemoryTransport struct {
+	hub       *MemoryHub
+	partition string
+}
+
+// NewMemoryTransport attaches a transport for partition to hub.
+func NewMemoryTransport(hub *MemoryHub, partition string) *MemoryTransport {
+	return &MemoryTransport{hub: hub, partition: partition}
+}
+
+// Send hands the batch to the target partition's handler.
+func (mt *MemoryTransport) Send(partition string, batch SpikeBatch) error {
+	mt.hub.mu.RLock()
+	handler, exists := mt.hub.handlers[partition]
+	mt.hub.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("partition %s is not listening", partition)
+	}
+	handler(batch)
+	return nil
+}
+
+// Listen registers this partition's handler on the hub.
+func (mt *MemoryTransport) Listen(handler BatchHandler) error {
+	mt.hub.mu.Lock()
+	defer mt.hub.mu.Unlock()
+
+	if _, exists := mt.hub.handlers[mt.partition]; exists {
+		return fmt.Errorf("partition %s already listening", mt.partition)
+	}
+	mt.hub.handlers[mt.partition] = handler
+	return nil
+}
+
+// Close detaches the partition from the hub.
+func (mt *MemoryTransport) Close() error {
+	mt.hub.mu.Lock()
+	defer mt.hub.mu.Unlock()
+	delete(mt.hub.handlers, mt.partition)
+	return nil
+}
@@ -0,0 +1,361 @@
+package distributed
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// =================================================================================
+// TCP TRANSPORT
+// =================================================================================
+
+// TCPTransport sends batches as newline-delimited JSON over persistent TCP
+// connections, one outbound connection per peer partition. Delivery is
+// reliable and ordered per peer. Each peer has its own connection lock, so a
+// slow or unreachable peer only delays sends to itself.
+type TCPTransport struct {
+	listenAddr string
+	peers      map[string]string    // partition -> address
+	conns      map[string]*peerConn // partition -> outbound connection
+	listener   net.Listener
+	inbound    map[net.Conn]bool
+	closed     bool
+	mu         sync.Mutex // Guards the maps and flags above; never held across I/O
+	wg         sync.WaitGroup
+}
+
+// peerConn is the outbound connection to one peer. mu serializes dialing
+// and writing for that peer only.
+type peerConn struct {
+	addr   string
+	conn   net.Conn
+	closed bool // Transport closed or peer address replaced
+	mu     sync.Mutex
+}
+
+// NewTCPTransport creates a TCP transport listening on listenAddr.
+// peers maps partition IDs to their listen addresses and may be extended later
+// with AddPeer.
+func NewTCPTransport(listenAddr string, peers map[string]string) *TCPTransport {
+	owned := make(map[string]string, len(peers))
+	for partition, addr := range peers {
+		owned[partition] = addr
+	}
+	return &TCPTransport{
+		listenAddr: listenAddr,
+		peers:      owned,
+		conns:      make(map[string]*peerConn),
+		inbound:    make(map[net.Conn]bool),
+	}
+}
+
+// AddPeer registers or updates the address of a peer partition. An open
+// connection to the old address is closed.
+func (tt *TCPTransport) AddPeer(partition, addr string) {
+	tt.mu.Lock()
+	tt.peers[partition] = addr
+	old := tt.conns[partition]
+	delete(tt.conns, partition)
+	tt.mu.Unlock()
+
+	if old != nil {
+		old.close()
+	}
+}
+
+// Addr returns the bound listen address, or "" before Listen succeeds.
+// Useful when listening on port 0.
+func (tt *TCPTransport) Addr() string {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+	if tt.listener == nil {
+		return ""
+	}
+	return tt.listener.Addr().String()
+}
+
+// Send encodes the batch onto the peer's connection, dialing on first use.
+// Dialing and writing hold only that peer's lock and are bounded by
+// DISTRIBUTED_DIAL_TIMEOUT and DISTRIBUTED_WRITE_TIMEOUT. A failed write
+// drops the connection so the next Send redials.
+func (tt *TCPTransport) Send(partition string, batch SpikeBatch) error {
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to encode batch: %w", err)
+	}
+	payload = append(payload, '\n')
+
+	tt.mu.Lock()
+	if tt.closed {
+		tt.mu.Unlock()
+		return fmt.Errorf("transport closed")
+	}
+	peer, exists := tt.conns[partition]
+	if !exists {
+		addr, known := tt.peers[partition]
+		if !known {
+			tt.mu.Unlock()
+			return fmt.Errorf("unknown peer partition: %s", partition)
+		}
+		peer = &peerConn{addr: addr}
+		tt.conns[partition] = peer
+	}
+	tt.mu.Unlock()
+
+	peer.mu.Lock()
+	defer peer.mu.Unlock()
+	if peer.closed {
+		return fmt.Errorf("connection to partition %s closed", partition)
+	}
+	if peer.conn == nil {
+		conn, err := net.DialTimeout("tcp", peer.addr, DISTRIBUTED_DIAL_TIMEOUT)
+		if err != nil {
+			return fmt.Errorf("failed to dial partition %s: %w", partition, err)
+		}
+		peer.conn = conn
+	}
+
+	peer.conn.SetWriteDeadline(time.Now().Add(DISTRIBUTED_WRITE_TIMEOUT))
+	if _, err := peer.conn.Write(payload); err != nil {
+		peer.conn.Close()
+		peer.conn = nil
+		return fmt.Errorf("failed to send to partition %s: %w", partition, err)
+	}
+	return nil
+}
+
+// close shuts the peer connection down for good, waiting for a send in
+// progress (bounded by the dial and write timeouts).
+func (pc *peerConn) close() {
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+	pc.closed = true
+	if pc.conn != nil {
+		pc.conn.Close()
+		pc.conn = nil
+	}
+}
+
+// Listen binds the listen address and dispatches inbound batches to handler.
+func (tt *TCPTransport) Listen(handler BatchHandler) error {
+	listener, err := net.Listen("tcp", tt.listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", tt.listenAddr, err)
+	}
+
+	tt.mu.Lock()
+	tt.listener = listener
+	tt.mu.Unlock()
+
+	tt.wg.Add(1)
+	go func() {
+		defer tt.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return // Listener closed
+			}
+
+			tt.mu.Lock()
+			if tt.closed {
+				tt.mu.Unlock()
+				conn.Close()
+				return
+			}
+			tt.inbound[conn] = true
+			tt.mu.Unlock()
+
+			tt.wg.Add(1)
+			go tt.readLoop(conn, handler)
+		}
+	}()
+	return nil
+}
+
+// readLoop decodes batches from one inbound connection until it closes.
+func (tt *TCPTransport) readLoop(conn net.Conn, handler BatchHandler) {
+	defer tt.wg.Done()
+	defer func() {
+		tt.mu.Lock()
+		delete(tt.inbound, conn)
+		tt.mu.Unlock()
+		conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var batch SpikeBatch
+		if err := json.Unmarshal(scanner.Bytes(), &batch); err != nil {
+			continue // Skip malformed frames rather than tearing down the link
+		}
+		handler(batch)
+	}
+}
+
+// Close stops listening and closes all connections.
+func (tt *TCPTransport) Close() error {
+	tt.mu.Lock()
+	if tt.closed {
+		tt.mu.Unlock()
+		return nil
+	}
+	tt.closed = true
+	if tt.listener != nil {
+		tt.listener.Close()
+	}
+	peers := tt.conns
+	tt.conns = make(map[string]*peerConn)
+	for conn := range tt.inbound {
+		conn.Close()
+	}
+	tt.mu.Unlock()
+
+	for _, peer := range peers {
+		peer.close()
+	}
+
+	tt.wg.Wait()
+	return nil
+}
+
+// =================================================================================
+// UDP TRANSPORT
+// =================================================================================
+
+// UDPTransport sends each batch as a single JSON datagram. There is no
+// retransmission: lost datagrams are lost spikes, which mirrors the failure
+// mode of unreliable biological transmission and keeps latency minimal.
+type UDPTransport struct {
+	listenAddr string
+	peers      map[string]*net.UDPAddr
+	conn       *net.UDPConn
+	closed     bool
+	mu         sync.Mutex
+	wg         sync.WaitGroup
+}
+
+// NewUDPTransport creates a UDP transport. peers maps partition IDs to
+// addresses; unresolvable addresses are reported by Send.
+func NewUDPTransport(listenAddr string, peers map[string]string) (*UDPTransport, error) {
+	ut := &UDPTransport{
+		listenAddr: listenAddr,
+		peers:      make(map[string]*net.UDPAddr, len(peers)),
+	}
+	for partition, addr := range peers {
+		if err := ut.AddPeer(partition, addr); err != nil {
+			return nil, err
+		}
+	}
+	return ut, nil
+}
+
+// AddPeer resolves and registers a peer partition address.
+func (ut *UDPTransport) AddPeer(partition, addr string) error {
+	resolved, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to resolve peer %s: %w", partition, err)
+	}
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	ut.peers[partition] = resolved
+	return nil
+}
+
+// Addr returns the bound listen address, or "" before Listen succeeds.
+func (ut *UDPTransport) Addr() string {
+	ut.mu.Lock()
+	defer ut.mu.Unlock()
+	if ut.conn == nil {
+		return ""
+	}
+	return ut.conn.LocalAddr().String()
+}
+
+// Send writes the batch as one datagram from the listening socket.
+func (ut *UDPTransport) Send(partition string, batch SpikeBatch) error {
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to encode batch: %w", err)
+	}
+	if len(payload) > DISTRIBUTED_UDP_MAX_DATAGRAM {
+		return fmt.Errorf("batch of %d bytes exceeds UDP datagram limit", len(payload))
+	}
+
+	ut.mu.Lock()
+	conn := ut.conn
+	addr, known := ut.peers[partition]
+	closed := ut.closed
+	ut.mu.Unlock()
+
+	if closed {
+		return fmt.Errorf("transport closed")
+	}
+	if conn == nil {
+		return fmt.Errorf("transport not listening")
+	}
+	if !known {
+		return fmt.Errorf("unknown peer partition: %s", partition)
+	}
+
+	if _, err := conn.WriteToUDP(payload, addr); err != nil {
+		return fmt.Errorf("failed to send to partition %s: %w", partition, err)
+	}
+	return nil
+}
+
+// Listen binds the UDP socket and dispatches inbound datagrams to handler.
+func (ut *UDPTransport) Listen(handler BatchHandler) error {
+	addr, err := net.ResolveUDPAddr("udp", ut.listenAddr)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", ut.listenAddr, err)
+	}
+	conn, err := net.ListenUDP("udp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", ut.listenAddr, err)
+	}
+
+	ut.mu.Lock()
+	ut.conn = conn
+	ut.mu.Unlock()
+
+	ut.wg.Add(1)
+	go func() {
+		defer ut.wg.Done()
+		buf := make([]byte, DISTRIBUTED_UDP_MAX_DATAGRAM+1)
+		for {
+			n, _, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return // Socket closed
+			}
+			var batch SpikeBatch
+			if err := json.Unmarshal(buf[:n], &batch); err != nil {
+				continue
+			}
+			handler(batch)
+		}
+	}()
+	return nil
+}
+
+// Close shuts down the socket.
+func (ut *UDPTransport) Close() error {
+	ut.mu.Lock()
+	if ut.closed {
+		ut.mu.Unlock()
+		return nil
+	}
+	ut.closed = true
+	conn := ut.conn
+	ut.mu.Unlock()
+
+	if conn != nil {
+		conn.Close()
+	}
+	ut.wg.Wait()
+	return nil
+}
@@ -0,0 +1,283 @@
+/*
+=================================================================================
+ENERGY - METABOLIC COST ACCOUNTING
+=================================================================================
+
+Brains run on about 20 W, and most of that pays for spikes and synaptic
+transmission (Attwell & Laughlin 2001). Neuromorphic hardware is judged by the
+same measure: energy per spike and per synaptic operation. A Meter charges a
+configurable cost for every action potential, every synaptic transmission and
+every plasticity weight update, and aggregates the totals per neuron, per
+synapse and per region:
+
+	meter, _ := energy.NewMeter(energy.BiologicalCosts())
+	matrix.SetEnergyMeter(meter)          // or n.SetEnergyMeter / s.SetEnergyMeter
+	meter.AssignRegion("v1", v1NeuronIDs...)
+	...
+	fmt.Println(meter.Region("v1").Energy, "ATP molecules")
+
+Transmission is charged to the synapse and its presynaptic neuron (vesicle
+release and postsynaptic currents are paid for by the sending neuron's
+activity). Plasticity is charged to the synapse and its postsynaptic neuron.
+Costs are in whatever unit the Costs use; the presets document theirs.
+
+Without a meter, components pay one atomic load per event.
+=================================================================================
+*/
+
+package energy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+const (
+	// ENERGY_ATP_PER_SPIKE is the ATP used to restore ion gradients after one
+	// action potential in a cortical neuron (Attwell & Laughlin 2001).
+	ENERGY_ATP_PER_SPIKE = 2.4e9
+
+	// ENERGY_ATP_PER_TRANSMISSION is the ATP for one vesicle release, pre- and
+	// postsynaptic (Attwell & Laughlin 2001).
+	ENERGY_ATP_PER_TRANSMISSION = 1.64e5
+
+	// ENERGY_ATP_PER_PLASTICITY is an order-of-magnitude estimate for one
+	// weight update (kinase activity and receptor trafficking).
+	ENERGY_ATP_PER_PLASTICITY = 1.0e6
+
+	// ENERGY_LOIHI_PJ_PER_SPIKE is the neuron update energy on Intel Loihi in
+	// picojoules (Davies et al. 2018).
+	ENERGY_LOIHI_PJ_PER_SPIKE = 52.0
+
+	// ENERGY_LOIHI_PJ_PER_TRANSMISSION is Loihi's energy per synaptic spike
+	// operation in picojoules.
+	ENERGY_LOIHI_PJ_PER_TRANSMISSION = 23.6
+
+	// ENERGY_LOIHI_PJ_PER_PLASTICITY is Loihi's energy per synaptic update in
+	// picojoules.
+	ENERGY_LOIHI_PJ_PER_PLASTICITY = 120.0
+)
+
+// Costs is the energy charged per event.
+type Costs struct {
+	Spike        float64 `json:"spike"`
+	Transmission float64 `json:"transmission"`
+	Plasticity   float64 `json:"plasticity"`
+}
+
+// BiologicalCosts returns costs in ATP molecules.
+func BiologicalCosts() Costs {
+	return Costs{
+		Spike:        ENERGY_ATP_PER_SPIKE,
+		Transmission: ENERGY_ATP_PER_TRANSMISSION,
+		Plasticity:   ENERGY_ATP_PER_PLASTICITY,
+	}
+}
+
+// LoihiCosts returns costs in picojoules for Intel Loihi.
+func LoihiCosts() Costs {
+	return Costs{
+		Spike:        ENERGY_LOIHI_PJ_PER_SPIKE,
+		Transmission: ENERGY_LOIHI_PJ_PER_TRANSMISSION,
+		Plasticity:   ENERGY_LOIHI_PJ_PER_PLASTICITY,
+	}
+}
+
+// Account is the activity and energy charged to a neuron, synapse or region.
+type Account struct {
+	Spikes           int64   `json:"spikes"`
+	Transmissions    int64   `json:"transmissions"`
+	PlasticityEvents int64   `json:"plasticity_events"`
+	Energy           float64 `json:"energy"`
+}
+
+// add accumulates another account.
+func (a *Account) add(other Account) {
+	a.Spikes += other.Spikes
+	a.Transmissions += other.Transmissions
+	a.PlasticityEvents += other.PlasticityEvents
+	a.Energy += other.Energy
+}
+
+// Meter accumulates energy costs. It is safe for concurrent use.
+type Meter struct {
+	mu       sync.Mutex
+	costs    Costs
+	neurons  map[string]*Account
+	synapses map[string]*Account
+	regions  map[string][]string // Region -> neuron IDs
+	total    Account
+}
+
+// NewMeter creates a meter charging the given costs.
+func NewMeter(costs Costs) (*Meter, error) {
+	if err := validateCosts(costs); err != nil {
+		return nil, err
+	}
+	return &Meter{
+		costs:    costs,
+		neurons:  make(map[string]*Account),
+		synapses: make(map[string]*Account),
+		regions:  make(map[string][]string),
+	}, nil
+}
+
+// validateCosts requires finite, non-negative costs.
+func validateCosts(costs Costs) error {
+	for name, cost := range map[string]float64{
+		"spike": costs.Spike, "transmission": costs.Transmission, "plasticity": costs.Plasticity,
+	} {
+		if math.IsNaN(cost) || math.IsInf(cost, 0) || cost < 0 {
+			return fmt.Errorf("%s cost must be finite and non-negative: %f", name, cost)
+		}
+	}
+	return nil
+}
+
+// GetCosts returns the meter's costs.
+func (m *Meter) GetCosts() Costs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.costs
+}
+
+// SetCosts changes the costs of future events; charged energy is kept.
+func (m *Meter) SetCosts(costs Costs) error {
+	if err := validateCosts(costs); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.costs = costs
+	return nil
+}
+
+// ChargeSpike charges one action potential to a neuron.
+func (m *Meter) ChargeSpike(neuronID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	charge := Account{Spikes: 1, Energy: m.costs.Spike}
+	m.accountUnsafe(m.neurons, neuronID).add(charge)
+	m.total.add(charge)
+}
+
+// ChargeTransmission charges one synaptic transmission to a synapse and its
+// presynaptic neuron.
+func (m *Meter) ChargeTransmission(synapseID, preNeuronID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	charge := Account{Transmissions: 1, Energy: m.costs.Transmission}
+	m.accountUnsafe(m.synapses, synapseID).add(charge)
+	m.accountUnsafe(m.neurons, preNeuronID).add(charge)
+	m.total.add(charge)
+}
+
+// ChargePlasticity charges one weight update to a synapse and its
+// postsynaptic neuron.
+func (m *Meter) ChargePlasticity(synapseID, postNeuronID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	charge := Account{PlasticityEvents: 1, Energy: m.costs.Plasticity}
+	m.accountUnsafe(m.synapses, synapseID).add(charge)
+	m.accountUnsafe(m.neurons, postNeuronID).add(charge)
+	m.total.add(charge)
+}
+
+// accountUnsafe returns the account for id, creating it if needed.
+// Must be called with m.mu held.
+func (m *Meter) accountUnsafe(accounts map[string]*Account, id string) *Account {
+	account, ok := accounts[id]
+	if !ok {
+		account = &Account{}
+		accounts[id] = account
+	}
+	return account
+}
+
+// AssignRegion adds neurons to a named region. A neuron may belong to
+// several regions.
+func (m *Meter) AssignRegion(region string, neuronIDs ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.regions[region] = append(m.regions[region], neuronIDs...)
+}
+
+// Neuron returns the account of a neuron (zero if it was never charged).
+func (m *Meter) Neuron(neuronID string) Account {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if account, ok := m.neurons[neuronID]; ok {
+		return *account
+	}
+	return Account{}
+}
+
+// Synapse returns the account of a synapse (zero if it was never charged).
+func (m *Meter) Synapse(synapseID string) Account {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if account, ok := m.synapses[synapseID]; ok {
+		return *account
+	}
+	return Account{}
+}
+
+// Region returns the summed accounts of a region's neurons.
+func (m *Meter) Region(region string) Account {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var sum Account
+	for _, id := range m.regions[region] {
+		if account, ok := m.neurons[id]; ok {
+			sum.add(*account)
+		}
+	}
+	return sum
+}
+
+// Regions returns the names of all regions, sorted.
+func (m *Meter) Regions() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]string, 0, len(m.regions))
+	for name := range m.regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Total returns the network-wide account. Energy counts every event once,
+// although transmissions and plasticity also appear in a synapse and a
+// neuron account.
+func (m *Meter) Total() Account {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.total
+}
+
+// Reset clears all accounts and keeps costs and regions.
+func (m *Meter) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.neurons = make(map[string]*Account)
+	m.synapses = make(map[string]*Account)
+	m.total = Account{}
+}
+
+// GetStats returns the meter's totals.
+func (m *Meter) GetStats() map[string]interface{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return map[string]interface{}{
+		"spikes":            m.total.Spikes,
+		"transmissions":     m.total.Transmissions,
+		"plasticity_events": m.total.PlasticityEvents,
+		"energy":            m.total.Energy,
+		"neurons":           len(m.neurons),
+		"synapses":          len(m.synapses),
+		"regions":           len(m.regions),
+	}
+}
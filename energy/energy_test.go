@@ -0,0 +1,63 @@
+package energy
+
+import (
+	"math"
+	"testing"
+)
+
+// TestMeterAccounting verifies per-neuron, per-synapse, region and total
+// accounting.
+func TestMeterAccounting(t *testing.T) {
+	meter, err := NewMeter(Costs{Spike: 10, Transmission: 2, Plasticity: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	meter.AssignRegion("v1", "a", "b")
+	meter.AssignRegion("v2", "c")
+
+	meter.ChargeSpike("a")
+	meter.ChargeTransmission("ab", "a")
+	meter.ChargeTransmission("ab", "a")
+	meter.ChargePlasticity("ab", "b")
+	meter.ChargeSpike("c")
+
+	if got := meter.Neuron("a"); got.Spikes != 1 || got.Transmissions != 2 || got.Energy != 14 {
+		t.Errorf("Unexpected account for a: %+v", got)
+	}
+	if got := meter.Synapse("ab"); got.Transmissions != 2 || got.PlasticityEvents != 1 || got.Energy != 5 {
+		t.Errorf("Unexpected account for ab: %+v", got)
+	}
+	if got := meter.Region("v1"); got.Energy != 15 || got.PlasticityEvents != 1 {
+		t.Errorf("Unexpected account for v1: %+v", got)
+	}
+	if got := meter.Total(); got.Energy != 25 || got.Spikes != 2 {
+		t.Errorf("Expected each event counted once in the total, got %+v", got)
+	}
+	if regions := meter.Regions(); len(regions) != 2 || regions[0] != "v1" {
+		t.Errorf("Unexpected regions %v", regions)
+	}
+
+	meter.Reset()
+	if meter.Total().Energy != 0 || meter.Neuron("a").Spikes != 0 || len(meter.Regions()) != 2 {
+		t.Error("Expected Reset to clear accounts and keep regions")
+	}
+}
+
+// TestMeterCosts verifies cost validation and presets.
+func TestMeterCosts(t *testing.T) {
+	for _, costs := range []Costs{{Spike: -1}, {Transmission: math.NaN()}, {Plasticity: math.Inf(1)}} {
+		if _, err := NewMeter(costs); err == nil {
+			t.Errorf("Expected error for %+v", costs)
+		}
+	}
+	meter, err := NewMeter(BiologicalCosts())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if meter.GetCosts().Spike <= meter.GetCosts().Transmission {
+		t.Error("Expected a spike to cost more than a transmission")
+	}
+	if err := meter.SetCosts(LoihiCosts()); err != nil || meter.GetCosts().Transmission != ENERGY_LOIHI_PJ_PER_TRANSMISSION {
+		t.Errorf("Expected Loihi costs, got %+v (%v)", meter.GetCosts(), err)
+	}
+}
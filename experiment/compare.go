@@ -0,0 +1,226 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// A/B COMPARISON OF PLASTICITY RULES
+// =================================================================================
+//
+// Compare runs one input protocol on networks that differ only in their
+// plasticity configuration. Every variant sees the same seeds, so wiring and
+// stimuli drawn from trial.Rand are identical across variants and the
+// differences in the report come from the learning rule alone.
+
+const (
+	// COMPARE_WEIGHT_BINS is the number of weight histogram bins per variant.
+	COMPARE_WEIGHT_BINS = 10
+
+	// compareVariantParam is the grid parameter selecting the variant.
+	compareVariantParam = "variant"
+)
+
+// Variant is one plasticity configuration under comparison.
+type Variant struct {
+	Name       string
+	Plasticity types.PlasticityConfig
+}
+
+// PlasticFactory builds the network for a trial using the given plasticity
+// configuration for every learning synapse, and registers those synapses with
+// trial.AddPlastic. It must not draw from trial.Rand differently per variant.
+type PlasticFactory func(trial *Trial, plasticity types.PlasticityConfig) error
+
+// CompareConfig describes an A/B (or A/B/n) comparison.
+type CompareConfig struct {
+	Factory    PlasticFactory
+	Variants   []Variant
+	Metrics    []Metric      // Task performance metrics, reported after the built-in ones
+	Seeds      int           // Repetitions per variant (0 = 1)
+	BaseSeed   int64         // Seed of the first repetition
+	Duration   time.Duration // Simulated time per trial
+	Resolution time.Duration // Virtual clock tick (0 = cosim default)
+	Workers    int           // Trials run in parallel (0 = GOMAXPROCS)
+}
+
+// VariantResult aggregates one variant across seeds.
+type VariantResult struct {
+	Name            string
+	Trials          int
+	Mean            map[string]float64
+	StdDev          map[string]float64
+	WeightHistogram []int // Final weights of all trials, binned by Comparison.WeightEdges
+}
+
+// Comparison is the side-by-side outcome of Compare.
+type Comparison struct {
+	Metrics     []string  // Metric names in report order
+	WeightEdges []float64 // Histogram bin edges (COMPARE_WEIGHT_BINS+1 values)
+	Variants    []VariantResult
+	Results     *Results // Per-trial table; the "variant" column indexes Variants
+}
+
+// comparisonMetrics are always reported: firing statistics, then weights.
+func comparisonMetrics() []Metric {
+	return []Metric{MeanRate(), ISICV(), WeightMean(), WeightStdDev(), WeightSaturation(), PlasticityEvents()}
+}
+
+// Compare runs every variant on the same protocol and seeds.
+func Compare(ctx context.Context, config CompareConfig) (*Comparison, error) {
+	if config.Factory == nil {
+		return nil, fmt.Errorf("comparison requires a network factory")
+	}
+	if len(config.Variants) < 2 {
+		return nil, fmt.Errorf("comparison requires at least two variants, got %d", len(config.Variants))
+	}
+	names := make(map[string]bool, len(config.Variants))
+	indices := make([]float64, len(config.Variants))
+	for i, variant := range config.Variants {
+		if variant.Name == "" || names[variant.Name] {
+			return nil, fmt.Errorf("variant %d needs a unique name", i)
+		}
+		if variant.Plasticity.MinWeight > variant.Plasticity.MaxWeight {
+			return nil, fmt.Errorf("variant %s: MinWeight %g above MaxWeight %g",
+				variant.Name, variant.Plasticity.MinWeight, variant.Plasticity.MaxWeight)
+		}
+		names[variant.Name] = true
+		indices[i] = float64(i)
+	}
+
+	// Keep each trial so final weights can be histogrammed
+	type trialKey struct {
+		variant int
+		seed    int64
+	}
+	var mu sync.Mutex
+	trials := make(map[trialKey]*Trial)
+
+	factory := func(trial *Trial) error {
+		index := int(trial.Params[compareVariantParam])
+		mu.Lock()
+		trials[trialKey{index, trial.Seed}] = trial
+		mu.Unlock()
+		return config.Factory(trial, config.Variants[index].Plasticity)
+	}
+
+	results, err := Run(ctx, Config{
+		Factory:    factory,
+		Grid:       Grid{compareVariantParam: indices},
+		Metrics:    append(comparisonMetrics(), config.Metrics...),
+		Seeds:      config.Seeds,
+		BaseSeed:   config.BaseSeed,
+		Duration:   config.Duration,
+		Resolution: config.Resolution,
+		Workers:    config.Workers,
+	})
+	if results == nil {
+		return nil, err
+	}
+
+	comparison := &Comparison{
+		Metrics:     results.Metrics,
+		WeightEdges: weightEdges(config.Variants),
+		Variants:    make([]VariantResult, len(config.Variants)),
+		Results:     results,
+	}
+	for i, variant := range config.Variants {
+		comparison.Variants[i] = VariantResult{
+			Name:            variant.Name,
+			Mean:            make(map[string]float64),
+			StdDev:          make(map[string]float64),
+			WeightHistogram: make([]int, COMPARE_WEIGHT_BINS),
+		}
+	}
+	for _, summary := range results.Summary() {
+		v := &comparison.Variants[int(summary.Params[compareVariantParam])]
+		v.Trials, v.Mean, v.StdDev = summary.Trials, summary.Mean, summary.StdDev
+	}
+	for _, row := range results.Rows {
+		if row.Err != nil {
+			continue
+		}
+		index := int(row.Params[compareVariantParam])
+		for _, w := range trials[trialKey{index, row.Seed}].weights() {
+			comparison.Variants[index].WeightHistogram[binIndex(comparison.WeightEdges, w)]++
+		}
+	}
+	return comparison, err
+}
+
+// weightEdges spans the union of all variants' weight bounds.
+func weightEdges(variants []Variant) []float64 {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, variant := range variants {
+		low = math.Min(low, variant.Plasticity.MinWeight)
+		high = math.Max(high, variant.Plasticity.MaxWeight)
+	}
+	if high <= low {
+		high = low + 1
+	}
+	edges := make([]float64, COMPARE_WEIGHT_BINS+1)
+	for i := range edges {
+		edges[i] = low + (high-low)*float64(i)/COMPARE_WEIGHT_BINS
+	}
+	return edges
+}
+
+// binIndex returns the histogram bin of w, clamping values outside the range.
+func binIndex(edges []float64, w float64) int {
+	bins := len(edges) - 1
+	bin := int((w - edges[0]) / (edges[bins] - edges[0]) * float64(bins))
+	if bin < 0 {
+		return 0
+	}
+	if bin >= bins {
+		return bins - 1
+	}
+	return bin
+}
+
+// Variant returns the result for a variant name, or nil.
+func (c *Comparison) Variant(name string) *VariantResult {
+	for i := range c.Variants {
+		if c.Variants[i].Name == name {
+			return &c.Variants[i]
+		}
+	}
+	return nil
+}
+
+// String renders metrics (mean ± std over seeds) and weight histograms side
+// by side, one column per variant.
+func (c *Comparison) String() string {
+	var b strings.Builder
+	writer := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+
+	header := []string{"metric"}
+	for _, v := range c.Variants {
+		header = append(header, fmt.Sprintf("%s (n=%d)", v.Name, v.Trials))
+	}
+	fmt.Fprintln(writer, strings.Join(header, "\t"))
+	for _, metric := range c.Metrics {
+		line := []string{metric}
+		for _, v := range c.Variants {
+			line = append(line, fmt.Sprintf("%.4g ± %.2g", v.Mean[metric], v.StdDev[metric]))
+		}
+		fmt.Fprintln(writer, strings.Join(line, "\t"))
+	}
+	for bin := 0; bin < len(c.WeightEdges)-1; bin++ {
+		line := []string{fmt.Sprintf("w[%.3g,%.3g)", c.WeightEdges[bin], c.WeightEdges[bin+1])}
+		for _, v := range c.Variants {
+			line = append(line, fmt.Sprintf("%d", v.WeightHistogram[bin]))
+		}
+		fmt.Fprintln(writer, strings.Join(line, "\t"))
+	}
+	writer.Flush()
+	return b.String()
+}
@@ -0,0 +1,128 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// patternTask wires ten inputs to one output. Inputs 0-4 fire together every
+// 20ms (the pattern); inputs 5-9 fire at random. Learning should favour the
+// pattern synapses.
+func patternTask(trial *Trial, plasticity types.PlasticityConfig) error {
+	input, err := batch.NewPopulation("in", batch.PopulationConfig{
+		Size: 10, Threshold: 1, DecayRate: 0, DelayScheduler: trial.Clock.Schedule,
+	})
+	if err != nil {
+		return err
+	}
+	output, err := batch.NewPopulation("out", batch.PopulationConfig{
+		Size: 1, Threshold: 1.5, DecayRate: 0.9, RefractoryPeriod: 3 * time.Millisecond,
+		DelayScheduler: trial.Clock.Schedule,
+	})
+	if err != nil {
+		return err
+	}
+
+	post := output.Member(0)
+	for i := 0; i < input.Size(); i++ {
+		pre := input.Member(i)
+		syn := synapse.NewBasicSynapse(fmt.Sprintf("s%d", i), pre, post, plasticity,
+			synapse.CreateDefaultPruningConfig(), 0.4, time.Millisecond)
+		pre.AddOutputCallback(syn.ID(), types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error { syn.Transmit(msg.Value); return nil },
+		})
+		trial.AddPlastic(syn)
+	}
+
+	tick := 0
+	trial.Clock.AddStepper("protocol", func(time.Time) {
+		tick++
+		if tick%20 == 0 {
+			for i := 0; i < 5; i++ {
+				input.Inject(i, 1.5)
+			}
+		}
+		for i := 5; i < 10; i++ {
+			if trial.Rand.Float64() < 0.05 {
+				input.Inject(i, 1.5)
+			}
+		}
+	})
+	trial.Monitor(input)
+	trial.Monitor(output)
+	return nil
+}
+
+// patternSelectivity is the mean pattern weight minus the mean noise weight.
+func patternSelectivity() Metric {
+	return Metric{Name: "selectivity", Measure: func(trial *Trial) float64 {
+		var pattern, noise float64
+		for i, syn := range trial.PlasticSynapses() {
+			if i < 5 {
+				pattern += syn.GetWeight() / 5
+			} else {
+				noise += syn.GetWeight() / 5
+			}
+		}
+		return pattern - noise
+	}}
+}
+
+// TestComparePlasticityRules verifies identical protocols per variant, the
+// built-in statistics and that STDP learns the pattern while a frozen
+// network does not.
+func TestComparePlasticityRules(t *testing.T) {
+	stdp := synapse.CreateDefaultSTDPConfig()
+	stdp.LearningRate = 0.05
+	frozen := stdp
+	frozen.Enabled = false
+
+	comparison, err := Compare(context.Background(), CompareConfig{
+		Factory:  patternTask,
+		Variants: []Variant{{Name: "stdp", Plasticity: stdp}, {Name: "frozen", Plasticity: frozen}},
+		Metrics:  []Metric{patternSelectivity()},
+		Seeds:    3,
+		Duration: 500 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+
+	learned, static := comparison.Variant("stdp"), comparison.Variant("frozen")
+	if learned == nil || static == nil || learned.Trials != 3 {
+		t.Fatalf("Unexpected variants: %+v", comparison.Variants)
+	}
+	if static.Mean["selectivity"] != 0 || static.Mean["plasticity_events"] != 0 {
+		t.Errorf("Frozen network must not learn: %v", static.Mean)
+	}
+	if learned.Mean["selectivity"] <= 0 || learned.Mean["plasticity_events"] == 0 {
+		t.Errorf("Expected STDP to favour the pattern: %v", learned.Mean)
+	}
+	if learned.Mean["mean_rate_hz"] <= 0 {
+		t.Errorf("Expected firing statistics, got %v", learned.Mean)
+	}
+
+	total := 0
+	for _, count := range learned.WeightHistogram {
+		total += count
+	}
+	if total != 30 || static.WeightHistogram[binIndex(comparison.WeightEdges, 0.4)] != 30 {
+		t.Errorf("Unexpected histograms: %v / %v", learned.WeightHistogram, static.WeightHistogram)
+	}
+
+	report := comparison.String()
+	if !strings.Contains(report, "stdp (n=3)") || !strings.Contains(report, "selectivity") {
+		t.Errorf("Unexpected report:\n%s", report)
+	}
+
+	if _, err := Compare(context.Background(), CompareConfig{Factory: patternTask, Variants: []Variant{{Name: "a"}}}); err == nil {
+		t.Error("Expected error for a single variant")
+	}
+}
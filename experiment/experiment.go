@@ -0,0 +1,271 @@
+/*
+=================================================================================
+EXPERIMENT - PARAMETER SWEEPS ON THE VIRTUAL CLOCK
+=================================================================================
+
+Tuning a spiking network usually means running the same circuit many times
+over a grid of parameters (thresholds, STDP rates, delays) with several random
+seeds, and comparing a few summary metrics. This package turns that loop into
+a declarative experiment:
+
+	results, err := experiment.Run(ctx, experiment.Config{
+	    Factory:  buildNetwork,
+	    Grid:     experiment.Grid{"threshold": {0.8, 1.0, 1.2}, "delay_ms": {1, 5}},
+	    Metrics:  []experiment.Metric{experiment.SpikeCount(), experiment.MeanRate()},
+	    Seeds:    5,
+	    Duration: time.Second,
+	})
+	results.WriteCSV(os.Stdout)
+
+Every trial runs on its own cosim.LockStep virtual clock, so trials are
+deterministic, independent of host load, and run in parallel across workers.
+The same seeds are reused for every grid point (common random numbers), which
+makes differences between parameter settings less noisy.
+=================================================================================
+*/
+
+package experiment
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+)
+
+// =================================================================================
+// PARAMETER GRID
+// =================================================================================
+
+// Params is one point of a parameter grid. Durations are given in
+// milliseconds by convention (see Duration).
+type Params map[string]float64
+
+// Get returns a parameter, or def if it is not part of the grid.
+func (p Params) Get(name string, def float64) float64 {
+	if value, ok := p[name]; ok {
+		return value
+	}
+	return def
+}
+
+// Duration interprets a parameter as milliseconds, or returns def if the
+// parameter is not part of the grid.
+func (p Params) Duration(name string, def time.Duration) time.Duration {
+	if value, ok := p[name]; ok {
+		return time.Duration(value * float64(time.Millisecond))
+	}
+	return def
+}
+
+// Grid maps parameter names to the values to sweep.
+type Grid map[string][]float64
+
+// Names returns the parameter names in sorted order.
+func (g Grid) Names() []string {
+	names := make([]string, 0, len(g))
+	for name := range g {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Points returns the cartesian product of all values. The last parameter
+// name (in sorted order) varies fastest. An empty grid has one empty point.
+func (g Grid) Points() []Params {
+	points := []Params{{}}
+	for _, name := range g.Names() {
+		var next []Params
+		for _, point := range points {
+			for _, value := range g[name] {
+				extended := make(Params, len(point)+1)
+				for k, v := range point {
+					extended[k] = v
+				}
+				extended[name] = value
+				next = append(next, extended)
+			}
+		}
+		points = next
+	}
+	return points
+}
+
+// validate rejects parameters without values.
+func (g Grid) validate() error {
+	for name, values := range g {
+		if len(values) == 0 {
+			return fmt.Errorf("parameter %s has no values", name)
+		}
+	}
+	return nil
+}
+
+// =================================================================================
+// TRIALS
+// =================================================================================
+
+// Spike is a recorded action potential.
+type Spike struct {
+	NeuronID string
+	Time     time.Time
+}
+
+// Trial is one run of the network for a grid point and seed. The factory
+// builds the network on Clock and registers what should be monitored.
+type Trial struct {
+	Params   Params
+	Seed     int64
+	Rand     *rand.Rand      // Seeded source for stimuli and wiring
+	Clock    *cosim.LockStep // Virtual clock the trial runs on
+	Duration time.Duration   // Simulated time per trial
+	Start    time.Time       // Virtual start time
+
+	mu         sync.Mutex
+	spikes     []Spike
+	monitored  int
+	plastic    []PlasticSynapse
+	plasticity *plasticityDriver
+}
+
+// newTrial creates a trial with a fresh virtual clock.
+func newTrial(params Params, seed int64, duration, resolution time.Duration) (*Trial, error) {
+	start := time.Unix(0, 0)
+	clock, err := cosim.NewLockStep(start, resolution)
+	if err != nil {
+		return nil, err
+	}
+	return &Trial{
+		Params:   params,
+		Seed:     seed,
+		Rand:     rand.New(rand.NewSource(seed)),
+		Clock:    clock,
+		Duration: duration,
+		Start:    start,
+	}, nil
+}
+
+// Monitor registers a population on the trial clock and records its spikes.
+// The population should use Clock.Schedule as its DelayScheduler.
+func (t *Trial) Monitor(pop *batch.Population) {
+	t.mu.Lock()
+	t.monitored += pop.Size()
+	t.mu.Unlock()
+
+	t.Clock.AddStepper(pop.ID(), func(now time.Time) {
+		for _, i := range pop.Step(now) {
+			t.RecordSpike(pop.Member(i).ID(), now)
+		}
+	})
+}
+
+// AddMonitored counts neurons whose spikes are recorded through RecordSpike
+// by custom steppers, for rate normalisation.
+func (t *Trial) AddMonitored(count int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.monitored += count
+}
+
+// RecordSpike records a spike of a monitored neuron.
+func (t *Trial) RecordSpike(neuronID string, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.spikes = append(t.spikes, Spike{NeuronID: neuronID, Time: at})
+}
+
+// Spikes returns a copy of the recorded spikes in recording order.
+func (t *Trial) Spikes() []Spike {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Spike(nil), t.spikes...)
+}
+
+// counts returns the number of recorded spikes and applied plasticity
+// events, for progress reporting.
+func (t *Trial) counts() (spikes, plasticity int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.plasticity != nil {
+		plasticity = t.plasticity.applied
+	}
+	return int64(len(t.spikes)), plasticity
+}
+
+// Monitored returns the number of monitored neurons.
+func (t *Trial) Monitored() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.monitored
+}
+
+// Factory builds the network for a trial: it creates components, registers
+// them on trial.Clock (directly or via Monitor) and installs stimuli.
+type Factory func(trial *Trial) error
+
+// =================================================================================
+// METRICS
+// =================================================================================
+
+// Metric computes one result column after a trial has run.
+type Metric struct {
+	Name    string
+	Measure func(trial *Trial) float64
+}
+
+// SpikeCount counts all recorded spikes.
+func SpikeCount() Metric {
+	return Metric{Name: "spike_count", Measure: func(trial *Trial) float64 {
+		return float64(len(trial.Spikes()))
+	}}
+}
+
+// MeanRate is the mean firing rate per monitored neuron in Hz.
+func MeanRate() Metric {
+	return Metric{Name: "mean_rate_hz", Measure: func(trial *Trial) float64 {
+		monitored := trial.Monitored()
+		if monitored == 0 || trial.Duration <= 0 {
+			return math.NaN()
+		}
+		return float64(len(trial.Spikes())) / float64(monitored) / trial.Duration.Seconds()
+	}}
+}
+
+// FirstSpikeLatency is the time to the first recorded spike in milliseconds
+// (NaN when nothing fired).
+func FirstSpikeLatency() Metric {
+	return Metric{Name: "first_spike_ms", Measure: func(trial *Trial) float64 {
+		spikes := trial.Spikes()
+		if len(spikes) == 0 {
+			return math.NaN()
+		}
+		first := spikes[0].Time
+		for _, spike := range spikes[1:] {
+			if spike.Time.Before(first) {
+				first = spike.Time
+			}
+		}
+		return float64(first.Sub(trial.Start)) / float64(time.Millisecond)
+	}}
+}
+
+// ActiveFraction is the fraction of monitored neurons that fired at least once.
+func ActiveFraction() Metric {
+	return Metric{Name: "active_fraction", Measure: func(trial *Trial) float64 {
+		monitored := trial.Monitored()
+		if monitored == 0 {
+			return math.NaN()
+		}
+		active := make(map[string]bool)
+		for _, spike := range trial.Spikes() {
+			active[spike.NeuronID] = true
+		}
+		return float64(len(active)) / float64(monitored)
+	}}
+}
@@ -0,0 +1,217 @@
+package experiment
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// noisyPopulation builds a population driven by seeded random input each tick.
+func noisyPopulation(trial *Trial) error {
+	pop, err := batch.NewPopulation("noisy", batch.PopulationConfig{
+		Size:             20,
+		Threshold:        trial.Params.Get("threshold", 1.0),
+		DecayRate:        0.9,
+		RefractoryPeriod: 2 * time.Millisecond,
+		DelayScheduler:   trial.Clock.Schedule,
+	})
+	if err != nil {
+		return err
+	}
+	trial.Clock.AddStepper("stimulus", func(now time.Time) {
+		for i := 0; i < pop.Size(); i++ {
+			pop.Inject(i, trial.Rand.Float64()*0.4)
+		}
+	})
+	trial.Monitor(pop)
+	return nil
+}
+
+// TestGridPoints verifies the cartesian product and its ordering.
+func TestGridPoints(t *testing.T) {
+	points := Grid{"b": {1, 2}, "a": {10, 20, 30}}.Points()
+	if len(points) != 6 {
+		t.Fatalf("Expected 6 points, got %d", len(points))
+	}
+	if points[0]["a"] != 10 || points[0]["b"] != 1 || points[1]["b"] != 2 || points[5]["a"] != 30 {
+		t.Errorf("Unexpected point order: %v", points)
+	}
+	if len(Grid{}.Points()) != 1 {
+		t.Error("Expected a single empty point for an empty grid")
+	}
+	if d := (Params{"delay_ms": 2.5}).Duration("delay_ms", 0); d != 2500*time.Microsecond {
+		t.Errorf("Expected 2.5ms, got %v", d)
+	}
+}
+
+// TestSweepIsDeterministicAndParallel verifies that results depend only on
+// parameters and seeds, not on the number of workers, and that lower
+// thresholds raise the firing rate.
+func TestSweepIsDeterministicAndParallel(t *testing.T) {
+	config := Config{
+		Factory:  noisyPopulation,
+		Grid:     Grid{"threshold": {0.8, 1.6}},
+		Metrics:  []Metric{SpikeCount(), MeanRate(), ActiveFraction()},
+		Seeds:    3,
+		BaseSeed: 42,
+		Duration: 250 * time.Millisecond,
+		Workers:  1,
+	}
+	serial, err := Run(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	config.Workers = 4
+	parallel, err := Run(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if len(serial.Rows) != 6 {
+		t.Fatalf("Expected 6 rows, got %d", len(serial.Rows))
+	}
+	if serial.String() != parallel.String() {
+		t.Errorf("Parallel results differ from serial:\n%s\n%s", serial, parallel)
+	}
+	if serial.Rows[0].Seed != 42 || serial.Rows[2].Seed != 44 || serial.Rows[3].Seed != 42 {
+		t.Errorf("Unexpected seed order: %v", serial.Rows)
+	}
+
+	summary := serial.Summary()
+	if len(summary) != 2 || summary[0].Trials != 3 {
+		t.Fatalf("Unexpected summary: %+v", summary)
+	}
+	low, high := summary[0].Mean["mean_rate_hz"], summary[1].Mean["mean_rate_hz"]
+	if !(low > high) || high <= 0 {
+		t.Errorf("Expected a higher rate for the lower threshold, got %.1f vs %.1f Hz", low, high)
+	}
+	if summary[0].Mean["active_fraction"] != 1 {
+		t.Errorf("Expected every neuron active at low threshold, got %v", summary[0].Mean["active_fraction"])
+	}
+}
+
+// TestSweepDelaysAndFailures verifies virtual-time delays show up in metrics
+// and failing trials are reported without aborting the sweep.
+func TestSweepDelaysAndFailures(t *testing.T) {
+	factory := func(trial *Trial) error {
+		if trial.Params["delay_ms"] < 0 {
+			return fmt.Errorf("negative delay")
+		}
+		pop, err := batch.NewPopulation("chain", batch.PopulationConfig{
+			Size: 2, Threshold: 1, DecayRate: 1, DelayScheduler: trial.Clock.Schedule,
+		})
+		if err != nil {
+			return err
+		}
+		pre, post := pop.Member(0), pop.Member(1)
+		syn := synapse.NewBasicSynapse("s", pre, post, synapse.CreateDefaultSTDPConfig(),
+			synapse.CreateDefaultPruningConfig(), 1.0, trial.Params.Duration("delay_ms", 0))
+		pre.AddOutputCallback(syn.ID(), types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error { syn.Transmit(msg.Value); return nil },
+		})
+
+		// Record only the downstream neuron
+		trial.AddMonitored(1)
+		trial.Clock.AddStepper("chain", func(now time.Time) {
+			for _, i := range pop.Step(now) {
+				if i == 1 {
+					trial.RecordSpike(post.ID(), now)
+				}
+			}
+		})
+		pop.Inject(0, 1.5)
+		return nil
+	}
+
+	results, err := Run(context.Background(), Config{
+		Factory:  factory,
+		Grid:     Grid{"delay_ms": {-1, 3, 8}},
+		Metrics:  []Metric{FirstSpikeLatency(), SpikeCount()},
+		Duration: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(results.Failed()) != 1 || results.Rows[0].Err == nil {
+		t.Fatalf("Expected the negative delay trial to fail, got %+v", results.Rows)
+	}
+	// Pre fires on the first 1ms tick; the post spike follows one delay later
+	if got := results.Rows[1].Metrics["first_spike_ms"]; got != 4 {
+		t.Errorf("Expected first spike at 4ms, got %v", got)
+	}
+	if got := results.Rows[2].Metrics["first_spike_ms"]; got != 9 {
+		t.Errorf("Expected first spike at 9ms, got %v", got)
+	}
+
+	var buf bytes.Buffer
+	if err := results.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if lines[0] != "delay_ms,seed,first_spike_ms,spike_count,error" || !strings.HasSuffix(lines[1], ",,,factory failed: negative delay") {
+		t.Errorf("Unexpected CSV:\n%s", buf.String())
+	}
+	if !math.IsNaN(FirstSpikeLatency().Measure(&Trial{})) {
+		t.Error("Expected NaN latency without spikes")
+	}
+
+	if _, err := Run(context.Background(), Config{Factory: factory, Duration: time.Millisecond}); err == nil {
+		t.Error("Expected error without metrics")
+	}
+}
+
+// TestSweepProgress verifies sweep-wide progress reports and the final report.
+func TestSweepProgress(t *testing.T) {
+	var mu sync.Mutex
+	var reports []cosim.ProgressReport
+	config := Config{
+		Factory:          noisyPopulation,
+		Grid:             Grid{"threshold": {0.8, 1.6}},
+		Metrics:          []Metric{SpikeCount()},
+		Seeds:            2,
+		Duration:         200 * time.Millisecond,
+		Workers:          2,
+		ProgressInterval: time.Millisecond,
+		Progress: func(report cosim.ProgressReport) {
+			mu.Lock()
+			defer mu.Unlock()
+			reports = append(reports, report)
+		},
+	}
+	results, err := Run(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(reports) == 0 {
+		t.Fatal("Expected progress reports")
+	}
+	final := reports[len(reports)-1]
+	if !final.Done || final.Simulated != 800*time.Millisecond || final.Target != 800*time.Millisecond {
+		t.Errorf("Expected a final report over 4 trials of 200ms, got %+v", final)
+	}
+	var spikes float64
+	for _, row := range results.Rows {
+		spikes += row.Metrics["spike_count"]
+	}
+	if final.Spikes != int64(spikes) {
+		t.Errorf("Expected %v spikes in the final report, got %d", spikes, final.Spikes)
+	}
+	for i, report := range reports[:len(reports)-1] {
+		if report.Done || (i > 0 && report.Simulated < reports[i-1].Simulated) {
+			t.Errorf("Unexpected intermediate report %d: %+v", i, report)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package experiment
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// PLASTICITY ON THE VIRTUAL CLOCK
+// =================================================================================
+//
+// Synapses learn from spike timing, but the neuron's STDP feedback runs on
+// wall-clock timers. For trials the runner pairs recorded spikes itself: after
+// every tick, each new spike of a plastic synapse's pre- or post-synaptic
+// neuron is paired with the partner's most recent spike (nearest-neighbour
+// pairing) and the synapse receives a PlasticityAdjustment with
+// Δt = t_pre - t_post in virtual time. Post-synaptic spikes are also passed to
+// synapses with a RecordPostSpike method, as a firing neuron would, so
+// activity-dependent rules such as metaplasticity see virtual time too.
+
+// PlasticSynapse is a synapse whose learning is driven by the trial.
+// synapse.BasicSynapse implements it.
+type PlasticSynapse interface {
+	ID() string
+	GetPresynapticID() string
+	GetPostsynapticID() string
+	GetWeight() float64
+	GetPlasticityConfig() types.PlasticityConfig
+	ApplyPlasticity(adjustment types.PlasticityAdjustment)
+}
+
+// plasticityDriver pairs recorded spikes for the trial's plastic synapses.
+type plasticityDriver struct {
+	byPre     map[string][]PlasticSynapse
+	byPost    map[string][]PlasticSynapse
+	lastSpike map[string]time.Time
+	cursor    int // Spikes already processed
+	applied   int64
+}
+
+// AddPlastic registers a synapse for virtual-clock STDP. Spikes of both
+// endpoints must be recorded (via Monitor or RecordSpike).
+func (t *Trial) AddPlastic(syn PlasticSynapse) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.plasticity == nil {
+		t.plasticity = &plasticityDriver{
+			byPre:     make(map[string][]PlasticSynapse),
+			byPost:    make(map[string][]PlasticSynapse),
+			lastSpike: make(map[string]time.Time),
+		}
+	}
+	t.plastic = append(t.plastic, syn)
+	t.plasticity.byPre[syn.GetPresynapticID()] = append(t.plasticity.byPre[syn.GetPresynapticID()], syn)
+	t.plasticity.byPost[syn.GetPostsynapticID()] = append(t.plasticity.byPost[syn.GetPostsynapticID()], syn)
+}
+
+// PlasticSynapses returns the synapses registered with AddPlastic.
+func (t *Trial) PlasticSynapses() []PlasticSynapse {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]PlasticSynapse(nil), t.plastic...)
+}
+
+// startPlasticity runs the pairing after every tick, once the factory has
+// registered all components.
+func (t *Trial) startPlasticity() {
+	t.mu.Lock()
+	driver := t.plasticity
+	t.mu.Unlock()
+	if driver != nil {
+		t.Clock.AddStepper("plasticity", func(time.Time) { t.pairSpikes(driver) })
+	}
+}
+
+// pairSpikes applies STDP for spikes recorded since the last call. Spikes of
+// one tick are paired against earlier spikes only; coincident spikes carry no
+// causal order and are skipped.
+func (t *Trial) pairSpikes(d *plasticityDriver) {
+	t.mu.Lock()
+	fresh := append([]Spike(nil), t.spikes[d.cursor:]...)
+	d.cursor = len(t.spikes)
+	t.mu.Unlock()
+
+	for _, spike := range fresh {
+		// Post-synaptic spike: potentiate synapses whose pre spiked before
+		for _, syn := range d.byPost[spike.NeuronID] {
+			if recorder, ok := syn.(interface{ RecordPostSpike(time.Time) }); ok {
+				recorder.RecordPostSpike(spike.Time)
+			}
+			if pre, ok := d.lastSpike[syn.GetPresynapticID()]; ok && pre.Before(spike.Time) {
+				d.apply(syn, pre.Sub(spike.Time), spike.Time)
+			}
+		}
+		// Pre-synaptic spike: depress synapses whose post spiked before
+		for _, syn := range d.byPre[spike.NeuronID] {
+			if post, ok := d.lastSpike[syn.GetPostsynapticID()]; ok && post.Before(spike.Time) {
+				d.apply(syn, spike.Time.Sub(post), spike.Time)
+			}
+		}
+	}
+	for _, spike := range fresh {
+		d.lastSpike[spike.NeuronID] = spike.Time
+	}
+}
+
+// apply delivers one pairing within the synapse's STDP window.
+func (d *plasticityDriver) apply(syn PlasticSynapse, deltaT time.Duration, at time.Time) {
+	config := syn.GetPlasticityConfig()
+	if !config.Enabled || (config.WindowSize > 0 && (deltaT >= config.WindowSize || -deltaT >= config.WindowSize)) {
+		return
+	}
+	syn.ApplyPlasticity(types.PlasticityAdjustment{
+		DeltaT:       deltaT,
+		LearningRate: config.LearningRate,
+		PostSynaptic: true,
+		PreSynaptic:  true,
+		Timestamp:    at,
+		EventType:    types.PlasticitySTDP,
+	})
+	d.applied++
+}
+
+// =================================================================================
+// WEIGHT AND FIRING METRICS
+// =================================================================================
+
+// weights returns the current weights of the plastic synapses.
+func (t *Trial) weights() []float64 {
+	plastic := t.PlasticSynapses()
+	weights := make([]float64, len(plastic))
+	for i, syn := range plastic {
+		weights[i] = syn.GetWeight()
+	}
+	return weights
+}
+
+// WeightMean is the mean final weight of the plastic synapses.
+func WeightMean() Metric {
+	return Metric{Name: "weight_mean", Measure: func(trial *Trial) float64 {
+		mean, _ := meanStdDev(trial.weights())
+		return mean
+	}}
+}
+
+// WeightStdDev is the standard deviation of the final plastic weights.
+func WeightStdDev() Metric {
+	return Metric{Name: "weight_std", Measure: func(trial *Trial) float64 {
+		_, std := meanStdDev(trial.weights())
+		return std
+	}}
+}
+
+// WeightSaturation is the fraction of plastic synapses that ended within 1%
+// of the weight range from either bound.
+func WeightSaturation() Metric {
+	return Metric{Name: "weight_saturated", Measure: func(trial *Trial) float64 {
+		plastic := trial.PlasticSynapses()
+		if len(plastic) == 0 {
+			return math.NaN()
+		}
+		saturated := 0
+		for _, syn := range plastic {
+			config := syn.GetPlasticityConfig()
+			margin := 0.01 * (config.MaxWeight - config.MinWeight)
+			if w := syn.GetWeight(); w <= config.MinWeight+margin || w >= config.MaxWeight-margin {
+				saturated++
+			}
+		}
+		return float64(saturated) / float64(len(plastic))
+	}}
+}
+
+// PlasticityEvents counts the STDP pairings applied during the trial.
+func PlasticityEvents() Metric {
+	return Metric{Name: "plasticity_events", Measure: func(trial *Trial) float64 {
+		trial.mu.Lock()
+		defer trial.mu.Unlock()
+		if trial.plasticity == nil {
+			return 0
+		}
+		return float64(trial.plasticity.applied)
+	}}
+}
+
+// ISICV is the mean coefficient of variation of inter-spike intervals over
+// neurons with at least three spikes (1 for Poisson firing, 0 for clockwork).
+func ISICV() Metric {
+	return Metric{Name: "isi_cv", Measure: func(trial *Trial) float64 {
+		byNeuron := make(map[string][]time.Time)
+		for _, spike := range trial.Spikes() {
+			byNeuron[spike.NeuronID] = append(byNeuron[spike.NeuronID], spike.Time)
+		}
+		var cvs []float64
+		for _, times := range byNeuron {
+			if len(times) < 3 {
+				continue
+			}
+			sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+			intervals := make([]float64, len(times)-1)
+			for i := 1; i < len(times); i++ {
+				intervals[i-1] = float64(times[i].Sub(times[i-1]))
+			}
+			mean, std := meanStdDev(intervals)
+			if mean > 0 {
+				cvs = append(cvs, std/mean)
+			}
+		}
+		mean, _ := meanStdDev(cvs)
+		return mean
+	}}
+}
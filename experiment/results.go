@@ -0,0 +1,179 @@
+package experiment
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// =================================================================================
+// TIDY RESULTS TABLE
+// =================================================================================
+
+// Row is the outcome of one trial.
+type Row struct {
+	Params  Params
+	Seed    int64
+	Metrics map[string]float64
+	Err     error
+}
+
+// Results is a tidy table: one row per trial, one column per parameter,
+// the seed, and one column per metric.
+type Results struct {
+	Parameters []string // Parameter columns, sorted
+	Metrics    []string // Metric columns, in configuration order
+	Rows       []Row
+}
+
+// Columns returns the table header.
+func (r *Results) Columns() []string {
+	columns := append([]string(nil), r.Parameters...)
+	columns = append(columns, "seed")
+	columns = append(columns, r.Metrics...)
+	return append(columns, "error")
+}
+
+// record formats one row as strings in column order.
+func (r *Results) record(row Row) []string {
+	record := make([]string, 0, len(r.Parameters)+len(r.Metrics)+2)
+	for _, name := range r.Parameters {
+		record = append(record, formatValue(row.Params[name]))
+	}
+	record = append(record, strconv.FormatInt(row.Seed, 10))
+	for _, name := range r.Metrics {
+		if row.Err != nil {
+			record = append(record, "")
+			continue
+		}
+		record = append(record, formatValue(row.Metrics[name]))
+	}
+	errText := ""
+	if row.Err != nil {
+		errText = row.Err.Error()
+	}
+	return append(record, errText)
+}
+
+// formatValue prints floats compactly; NaN is written as "NA".
+func formatValue(v float64) string {
+	if math.IsNaN(v) {
+		return "NA"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// WriteCSV writes the table with a header row.
+func (r *Results) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(r.Columns()); err != nil {
+		return err
+	}
+	for _, row := range r.Rows {
+		if err := writer.Write(r.record(row)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// String renders the table with aligned columns.
+func (r *Results) String() string {
+	var b strings.Builder
+	writer := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, strings.Join(r.Columns(), "\t"))
+	for _, row := range r.Rows {
+		fmt.Fprintln(writer, strings.Join(r.record(row), "\t"))
+	}
+	writer.Flush()
+	return b.String()
+}
+
+// Failed returns rows whose trial failed.
+func (r *Results) Failed() []Row {
+	var failed []Row
+	for _, row := range r.Rows {
+		if row.Err != nil {
+			failed = append(failed, row)
+		}
+	}
+	return failed
+}
+
+// SummaryRow aggregates all seeds of one grid point.
+type SummaryRow struct {
+	Params Params
+	Trials int                // Successful trials aggregated
+	Mean   map[string]float64 // Per metric, NaN values excluded
+	StdDev map[string]float64 // Sample standard deviation (0 for one trial)
+}
+
+// Summary aggregates the rows of each grid point across seeds, in grid order.
+func (r *Results) Summary() []SummaryRow {
+	var summary []SummaryRow
+	index := make(map[string]int)
+	values := make(map[string]map[string][]float64)
+
+	for _, row := range r.Rows {
+		if row.Err != nil {
+			continue
+		}
+		key := r.paramKey(row.Params)
+		i, exists := index[key]
+		if !exists {
+			i = len(summary)
+			index[key] = i
+			summary = append(summary, SummaryRow{Params: row.Params})
+			values[key] = make(map[string][]float64)
+		}
+		summary[i].Trials++
+		for _, name := range r.Metrics {
+			if v := row.Metrics[name]; !math.IsNaN(v) {
+				values[key][name] = append(values[key][name], v)
+			}
+		}
+	}
+
+	for key, i := range index {
+		summary[i].Mean = make(map[string]float64, len(r.Metrics))
+		summary[i].StdDev = make(map[string]float64, len(r.Metrics))
+		for _, name := range r.Metrics {
+			summary[i].Mean[name], summary[i].StdDev[name] = meanStdDev(values[key][name])
+		}
+	}
+	return summary
+}
+
+// paramKey identifies a grid point.
+func (r *Results) paramKey(params Params) string {
+	parts := make([]string, len(r.Parameters))
+	for i, name := range r.Parameters {
+		parts[i] = formatValue(params[name])
+	}
+	return strings.Join(parts, "|")
+}
+
+// meanStdDev returns the mean and sample standard deviation (NaN mean for no values).
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return math.NaN(), math.NaN()
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	if len(values) == 1 {
+		return mean, 0
+	}
+	squares := 0.0
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)-1))
+}
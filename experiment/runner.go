@@ -0,0 +1,242 @@
+package experiment
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+)
+
+// =================================================================================
+// SWEEP RUNNER
+// =================================================================================
+
+const (
+	// EXPERIMENT_STEP_CHUNK is how much virtual time is simulated between
+	// cancellation checks.
+	EXPERIMENT_STEP_CHUNK = 100 * time.Millisecond
+)
+
+// Config describes a parameter sweep.
+type Config struct {
+	Factory    Factory       // Builds the network for each trial
+	Grid       Grid          // Parameter values to sweep
+	Metrics    []Metric      // Result columns, in order
+	Seeds      int           // Repetitions per grid point (0 = 1)
+	BaseSeed   int64         // Seed of the first repetition; repetition i uses BaseSeed+i
+	Duration   time.Duration // Simulated time per trial
+	Resolution time.Duration // Virtual clock tick (0 = cosim default)
+	Workers    int           // Trials run in parallel (0 = GOMAXPROCS)
+
+	// Progress optionally receives sweep-wide reports every ProgressInterval
+	// of wall time (0 = cosim.COSIM_DEFAULT_PROGRESS_INTERVAL) and once when
+	// the sweep ends, e.g. cosim.ProgressBar(os.Stderr, 0). The target is the
+	// simulated time of all trials; spikes are recorded spikes.
+	Progress         cosim.ProgressFunc
+	ProgressInterval time.Duration
+}
+
+// validate checks the configuration and fills defaults.
+func (c *Config) validate() error {
+	if c.Factory == nil {
+		return fmt.Errorf("experiment requires a network factory")
+	}
+	if c.Duration <= 0 {
+		return fmt.Errorf("trial duration must be positive: %v", c.Duration)
+	}
+	if len(c.Metrics) == 0 {
+		return fmt.Errorf("experiment requires at least one metric")
+	}
+	seen := make(map[string]bool, len(c.Metrics))
+	for _, metric := range c.Metrics {
+		if metric.Name == "" || metric.Measure == nil {
+			return fmt.Errorf("metrics need a name and a measure function")
+		}
+		if seen[metric.Name] || c.Grid[metric.Name] != nil || metric.Name == "seed" || metric.Name == "error" {
+			return fmt.Errorf("duplicate result column %s", metric.Name)
+		}
+		seen[metric.Name] = true
+	}
+	if err := c.Grid.validate(); err != nil {
+		return err
+	}
+	if c.Seeds <= 0 {
+		c.Seeds = 1
+	}
+	if c.Workers <= 0 {
+		c.Workers = runtime.GOMAXPROCS(0)
+	}
+	if c.ProgressInterval < 0 {
+		return fmt.Errorf("progress interval cannot be negative: %v", c.ProgressInterval)
+	}
+	if c.ProgressInterval == 0 {
+		c.ProgressInterval = cosim.COSIM_DEFAULT_PROGRESS_INTERVAL
+	}
+	return nil
+}
+
+// sweepProgress accumulates the progress of all trials of a sweep.
+type sweepProgress struct {
+	target     time.Duration
+	start      time.Time
+	simulated  atomic.Int64 // Nanoseconds
+	spikes     atomic.Int64
+	plasticity atomic.Int64
+}
+
+// report returns the sweep-wide progress.
+func (p *sweepProgress) report(done bool) cosim.ProgressReport {
+	return cosim.ProgressReport{
+		Simulated:        time.Duration(p.simulated.Load()),
+		Target:           p.target,
+		Wall:             time.Since(p.start),
+		Spikes:           p.spikes.Load(),
+		PlasticityEvents: p.plasticity.Load(),
+		Done:             done,
+	}
+}
+
+// run calls callback every interval until stop is closed.
+func (p *sweepProgress) run(callback cosim.ProgressFunc, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			callback(p.report(false))
+		case <-stop:
+			return
+		}
+	}
+}
+
+// trialJob identifies one trial by its row index.
+type trialJob struct {
+	index  int
+	params Params
+	seed   int64
+}
+
+// Run executes every grid point for every seed and returns one row per trial,
+// ordered by grid point, then seed. A failing trial is recorded in its row's
+// Err and does not stop the sweep. If ctx is cancelled, Run returns the rows
+// completed so far together with ctx.Err().
+func Run(ctx context.Context, config Config) (*Results, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	points := config.Grid.Points()
+	results := &Results{
+		Parameters: config.Grid.Names(),
+		Metrics:    make([]string, len(config.Metrics)),
+		Rows:       make([]Row, len(points)*config.Seeds),
+	}
+	for i, metric := range config.Metrics {
+		results.Metrics[i] = metric.Name
+	}
+
+	progress := &sweepProgress{target: time.Duration(len(results.Rows)) * config.Duration, start: time.Now()}
+	if config.Progress != nil {
+		stop := make(chan struct{})
+		reporting := make(chan struct{})
+		go func() {
+			defer close(reporting)
+			progress.run(config.Progress, config.ProgressInterval, stop)
+		}()
+		defer func() {
+			close(stop)
+			<-reporting
+			config.Progress(progress.report(true))
+		}()
+	}
+
+	jobs := make(chan trialJob)
+	done := make([]bool, len(results.Rows))
+	var wg sync.WaitGroup
+	for w := 0; w < config.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				results.Rows[job.index] = runTrial(ctx, config, job, progress)
+				done[job.index] = true
+			}
+		}()
+	}
+
+dispatch:
+	for p, params := range points {
+		for s := 0; s < config.Seeds; s++ {
+			job := trialJob{index: p*config.Seeds + s, params: params, seed: config.BaseSeed + int64(s)}
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				break dispatch
+			}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		completed := results.Rows[:0]
+		for i, row := range results.Rows {
+			if done[i] && row.Err == nil {
+				completed = append(completed, row)
+			}
+		}
+		results.Rows = completed
+		return results, err
+	}
+	return results, nil
+}
+
+// runTrial builds, simulates and measures one trial.
+func runTrial(ctx context.Context, config Config, job trialJob, progress *sweepProgress) Row {
+	row := Row{Params: job.params, Seed: job.seed}
+
+	trial, err := newTrial(job.params, job.seed, config.Duration, config.Resolution)
+	if err != nil {
+		row.Err = err
+		return row
+	}
+	if err := config.Factory(trial); err != nil {
+		row.Err = fmt.Errorf("factory failed: %w", err)
+		return row
+	}
+	trial.startPlasticity()
+
+	var spikes, plasticity int64
+	for remaining := config.Duration; remaining > 0; {
+		if err := ctx.Err(); err != nil {
+			row.Err = err
+			return row
+		}
+		chunk := EXPERIMENT_STEP_CHUNK
+		if remaining < chunk {
+			chunk = remaining
+		}
+		if err := trial.Clock.Step(chunk); err != nil {
+			row.Err = err
+			return row
+		}
+		remaining -= chunk
+
+		progress.simulated.Add(int64(chunk))
+		nowSpikes, nowPlasticity := trial.counts()
+		progress.spikes.Add(nowSpikes - spikes)
+		progress.plasticity.Add(nowPlasticity - plasticity)
+		spikes, plasticity = nowSpikes, nowPlasticity
+	}
+
+	row.Metrics = make(map[string]float64, len(config.Metrics))
+	for _, metric := range config.Metrics {
+		row.Metrics[metric.Name] = metric.Measure(trial)
+	}
+	return row
+}
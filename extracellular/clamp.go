@@ -0,0 +1,64 @@
+package extracellular
+
+import (
+	"fmt"
+	"time"
+)
+
+// =================================================================================
+// CLAMP EXPERIMENTS
+// =================================================================================
+//
+// Electrophysiology protocols address neurons by ID: inject a current step
+// into one cell, hold another at a command potential. The matrix forwards
+// these to neurons that support clamping (neuron.Neuron); see neuron/clamp.go
+// for the semantics.
+
+// clampable is implemented by neurons that support current and voltage clamp.
+type clampable interface {
+	ClampCurrent(amplitude float64, duration time.Duration) error
+	ClampAccumulator(value float64) error
+	ReleaseClamp()
+}
+
+// ClampCurrent injects a constant drive into a neuron for the given duration.
+func (ecm *ExtracellularMatrix) ClampCurrent(neuronID string, amplitude float64, duration time.Duration) error {
+	target, err := ecm.clampTarget(neuronID)
+	if err != nil {
+		return err
+	}
+	return target.ClampCurrent(amplitude, duration)
+}
+
+// ClampAccumulator holds a neuron's membrane state at value until
+// ReleaseClamp.
+func (ecm *ExtracellularMatrix) ClampAccumulator(neuronID string, value float64) error {
+	target, err := ecm.clampTarget(neuronID)
+	if err != nil {
+		return err
+	}
+	return target.ClampAccumulator(value)
+}
+
+// ReleaseClamp removes any clamp from a neuron.
+func (ecm *ExtracellularMatrix) ReleaseClamp(neuronID string) error {
+	target, err := ecm.clampTarget(neuronID)
+	if err != nil {
+		return err
+	}
+	target.ReleaseClamp()
+	return nil
+}
+
+// clampTarget looks up a neuron that supports clamping.
+func (ecm *ExtracellularMatrix) clampTarget(neuronID string) (clampable, error) {
+	neuron, exists := ecm.GetNeuron(neuronID)
+	if !exists {
+		return nil, fmt.Errorf("neuron %s not found", neuronID)
+	}
+	target, ok := neuron.(clampable)
+	if !ok {
+		return nil, fmt.Errorf("neuron %s does not support clamping", neuronID)
+	}
+	return target, nil
+}
@@ -0,0 +1,78 @@
+package extracellular
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// clampMockNeuron records clamp calls.
+type clampMockNeuron struct {
+	*MockNeuron
+	amplitude float64
+	duration  time.Duration
+	holding   float64
+	released  bool
+}
+
+func (n *clampMockNeuron) ClampCurrent(amplitude float64, duration time.Duration) error {
+	n.amplitude, n.duration = amplitude, duration
+	return nil
+}
+
+func (n *clampMockNeuron) ClampAccumulator(value float64) error {
+	n.holding = value
+	return nil
+}
+
+func (n *clampMockNeuron) ReleaseClamp() {
+	n.released = true
+}
+
+// TestClampForwardsToNeurons verifies that the matrix forwards clamp
+// protocols by neuron ID and rejects unknown or unsupported neurons.
+func TestClampForwardsToNeurons(t *testing.T) {
+	matrix := NewExtracellularMatrix(ExtracellularMatrixConfig{
+		UpdateInterval: 10 * time.Millisecond,
+		MaxComponents:  10,
+	})
+	var clamped *clampMockNeuron
+	matrix.RegisterNeuronType("clampable", func(id string, config types.NeuronConfig, callbacks NeuronCallbacks) (component.NeuralComponent, error) {
+		clamped = &clampMockNeuron{MockNeuron: NewMockNeuron(id, config.Position, config.Receptors)}
+		return clamped, nil
+	})
+	matrix.RegisterNeuronType("plain", func(id string, config types.NeuronConfig, callbacks NeuronCallbacks) (component.NeuralComponent, error) {
+		return NewMockNeuron(id, config.Position, config.Receptors), nil
+	})
+
+	target, err := matrix.CreateNeuron(types.NeuronConfig{NeuronType: "clampable", Threshold: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	plain, err := matrix.CreateNeuron(types.NeuronConfig{NeuronType: "plain", Threshold: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := matrix.ClampCurrent(target.ID(), 0.3, 50*time.Millisecond); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := matrix.ClampAccumulator(target.ID(), -0.5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := matrix.ReleaseClamp(target.ID()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if clamped.amplitude != 0.3 || clamped.duration != 50*time.Millisecond || clamped.holding != -0.5 || !clamped.released {
+		t.Errorf("Expected clamp calls forwarded, got %+v", clamped)
+	}
+
+	if err := matrix.ClampCurrent("missing", 0.3, time.Second); err == nil {
+		t.Error("Expected error for unknown neuron")
+	}
+	if err := matrix.ClampAccumulator(plain.ID(), 0); err == nil {
+		t.Error("Expected error for neuron without clamp support")
+	}
+}
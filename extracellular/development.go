@@ -0,0 +1,382 @@
+package extracellular
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/topology"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+/*
+=================================================================================
+DEVELOPMENTAL NETWORK GROWTH
+=================================================================================
+
+BIOLOGICAL OVERVIEW:
+Cortical circuits are not wired by hand. During development neurons are born,
+migrate to their positions, and extend axons that contact nearby cells far more
+often than distant ones (connection probability falls roughly exponentially
+with distance, length constant ~100-200 μm). The resulting synaptic weights are
+heavy-tailed — approximately log-normal — with a few strong synapses and many
+weak ones. Activity then refines this scaffold: correlated pairs gain synapses
+and weak, unused contacts are eliminated.
+
+DEVELOPMENT PHASES:
+ 1. SeedPopulation: neurons are created at random positions in a cubic region
+ 2. Grow: each ordered pair is connected with probability
+    P0 * exp(-distance / LengthConstant); weights are drawn from a log-normal
+    distribution and delays are BaseDelay plus axonal conduction time
+ 3. Refine (repeatable): an optional Synaptogenesis controller adds synapses
+    between co-active neurons, and developmental synapses whose weight has
+    fallen below PruneThreshold are eliminated
+
+Stats() summarises the result so it can be compared against target statistics.
+
+=================================================================================
+*/
+
+// Developmental defaults for a local cortical microcircuit
+const (
+	DEVELOPMENT_DEFAULT_REGION_SIZE      = 300.0 // μm cube side
+	DEVELOPMENT_DEFAULT_PEAK_PROBABILITY = 0.3   // Connection probability at zero distance
+	DEVELOPMENT_DEFAULT_LENGTH_CONSTANT  = 150.0 // μm, exponential fall-off of connectivity
+	DEVELOPMENT_DEFAULT_LOG_WEIGHT_MEAN  = -1.0  // ln-space mean (median weight ≈ 0.37)
+	DEVELOPMENT_DEFAULT_LOG_WEIGHT_STD   = 0.8   // ln-space std (heavy tail)
+	DEVELOPMENT_DEFAULT_MAX_WEIGHT       = 3.0   // Clamp for the log-normal tail
+	DEVELOPMENT_DEFAULT_PRUNE_THRESHOLD  = 0.05  // Weights below this are eliminated
+)
+
+// DevelopmentConfig controls developmental network growth.
+type DevelopmentConfig struct {
+	NeuronType  string // Registered neuron factory for seeded neurons
+	SynapseType string // Registered synapse factory for grown synapses
+	NeuronCount int    // Size of the seed population
+
+	Origin     Position3D // Corner of the cubic growth region
+	RegionSize float64    // Side length of the region (μm)
+
+	PeakProbability float64 // Connection probability at zero distance
+	LengthConstant  float64 // Distance (μm) over which probability falls by 1/e
+
+	LogWeightMean float64 // Mean of ln(weight)
+	LogWeightStd  float64 // Standard deviation of ln(weight)
+	MaxWeight     float64 // Upper clamp for sampled weights
+
+	BaseDelay  time.Duration    // Synaptic delay added to conduction time
+	LigandType types.LigandType // Neurotransmitter of grown synapses
+
+	PruneThreshold float64 // Refinement eliminates synapses below this weight
+	Seed           int64   // RNG seed (0 = time-based)
+}
+
+// DefaultDevelopmentConfig returns cortical microcircuit defaults.
+func DefaultDevelopmentConfig(neuronType, synapseType string, neuronCount int) DevelopmentConfig {
+	return DevelopmentConfig{
+		NeuronType:      neuronType,
+		SynapseType:     synapseType,
+		NeuronCount:     neuronCount,
+		RegionSize:      DEVELOPMENT_DEFAULT_REGION_SIZE,
+		PeakProbability: DEVELOPMENT_DEFAULT_PEAK_PROBABILITY,
+		LengthConstant:  DEVELOPMENT_DEFAULT_LENGTH_CONSTANT,
+		LogWeightMean:   DEVELOPMENT_DEFAULT_LOG_WEIGHT_MEAN,
+		LogWeightStd:    DEVELOPMENT_DEFAULT_LOG_WEIGHT_STD,
+		MaxWeight:       DEVELOPMENT_DEFAULT_MAX_WEIGHT,
+		BaseDelay:       SYNAPTIC_DELAY,
+		LigandType:      types.LigandGlutamate,
+		PruneThreshold:  DEVELOPMENT_DEFAULT_PRUNE_THRESHOLD,
+	}
+}
+
+// ConnectivityStats summarises a developed network.
+type ConnectivityStats struct {
+	Neurons         int           `json:"neurons"`
+	Synapses        int           `json:"synapses"`
+	MeanOutDegree   float64       `json:"mean_out_degree"`
+	MeanWeight      float64       `json:"mean_weight"`
+	LogWeightMean   float64       `json:"log_weight_mean"` // Mean of ln(weight)
+	LogWeightStd    float64       `json:"log_weight_std"`  // Std of ln(weight)
+	MeanDistance    float64       `json:"mean_distance"`   // Mean connection length (μm)
+	MeanDelay       time.Duration `json:"mean_delay"`
+	DelayDistanceR  float64       `json:"delay_distance_r"` // Pearson correlation of delay and distance
+	SynapsesGrown   int           `json:"synapses_grown"`
+	SynapsesPruned  int           `json:"synapses_pruned"`
+	SynapsesRefined int           `json:"synapses_refined"` // Added by activity-dependent refinement
+}
+
+// developmentalSynapse records the geometry of a grown synapse.
+type developmentalSynapse struct {
+	id       string
+	preID    string
+	postID   string
+	distance float64
+	delay    time.Duration
+}
+
+// Development grows a network inside a matrix.
+type Development struct {
+	matrix *ExtracellularMatrix
+	config DevelopmentConfig
+	kernel topology.ExponentialKernel
+	rng    *rand.Rand
+
+	neuronIDs []string
+	positions map[string]Position3D
+	synapses  map[string]developmentalSynapse
+
+	grown   int
+	pruned  int
+	refined int
+}
+
+// NewDevelopment validates the config and prepares a development run.
+func NewDevelopment(matrix *ExtracellularMatrix, config DevelopmentConfig) (*Development, error) {
+	if matrix == nil {
+		return nil, fmt.Errorf("development requires a matrix")
+	}
+	if config.NeuronType == "" || config.SynapseType == "" {
+		return nil, fmt.Errorf("development requires neuron and synapse types")
+	}
+	if config.NeuronCount <= 0 {
+		return nil, fmt.Errorf("neuron count must be positive: %d", config.NeuronCount)
+	}
+	if config.RegionSize <= 0 || config.LengthConstant <= 0 {
+		return nil, fmt.Errorf("region size and length constant must be positive")
+	}
+	if config.PeakProbability < 0 || config.PeakProbability > 1 {
+		return nil, fmt.Errorf("peak probability must be in [0,1]: %f", config.PeakProbability)
+	}
+	if config.LogWeightStd < 0 || config.MaxWeight <= 0 {
+		return nil, fmt.Errorf("invalid weight distribution: std %f, max %f", config.LogWeightStd, config.MaxWeight)
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Development{
+		matrix:    matrix,
+		config:    config,
+		kernel:    topology.ExponentialKernel{Peak: config.PeakProbability, LengthConstant: config.LengthConstant},
+		rng:       rand.New(rand.NewSource(seed)),
+		positions: make(map[string]Position3D),
+		synapses:  make(map[string]developmentalSynapse),
+	}, nil
+}
+
+// Run performs seeding followed by distance-dependent growth.
+func (d *Development) Run() error {
+	if _, err := d.SeedPopulation(); err != nil {
+		return err
+	}
+	_, err := d.Grow()
+	return err
+}
+
+// SeedPopulation creates NeuronCount neurons at uniformly random positions.
+//
+// Returns:
+//
+//	IDs of the created neurons
+func (d *Development) SeedPopulation() ([]string, error) {
+	for i := 0; i < d.config.NeuronCount; i++ {
+		position := Position3D{
+			X: d.config.Origin.X + d.rng.Float64()*d.config.RegionSize,
+			Y: d.config.Origin.Y + d.rng.Float64()*d.config.RegionSize,
+			Z: d.config.Origin.Z + d.rng.Float64()*d.config.RegionSize,
+		}
+		neuron, err := d.matrix.CreateNeuron(types.NeuronConfig{
+			NeuronType: d.config.NeuronType,
+			Position:   position,
+			Threshold:  1.0,
+			Metadata:   map[string]interface{}{"origin": "development"},
+		})
+		if err != nil {
+			return d.neuronIDs, fmt.Errorf("seeding neuron %d: %w", i, err)
+		}
+		d.neuronIDs = append(d.neuronIDs, neuron.ID())
+		d.positions[neuron.ID()] = position
+	}
+	return d.neuronIDs, nil
+}
+
+// Grow connects seeded neurons with distance-dependent probability.
+//
+// Returns:
+//
+//	Number of synapses created
+func (d *Development) Grow() (int, error) {
+	created := 0
+	for _, preID := range d.neuronIDs {
+		for _, postID := range d.neuronIDs {
+			if preID == postID {
+				continue
+			}
+			distance := d.matrix.calculateSpatialDistance(d.positions[preID], d.positions[postID])
+			if d.rng.Float64() >= d.kernel.Probability(distance) {
+				continue
+			}
+
+			delay := d.config.BaseDelay + d.matrix.calculatePropagationDelay(distance)
+			synapse, err := d.matrix.CreateSynapse(types.SynapseConfig{
+				PresynapticID:     preID,
+				PostsynapticID:    postID,
+				InitialWeight:     d.sampleWeight(),
+				Delay:             delay,
+				LigandType:        d.config.LigandType,
+				PlasticityEnabled: true,
+				Position:          d.positions[postID],
+				SynapseType:       d.config.SynapseType,
+				Metadata:          map[string]interface{}{"origin": "development", "distance": distance},
+			})
+			if err != nil {
+				return created, fmt.Errorf("growing %s→%s: %w", preID, postID, err)
+			}
+			d.synapses[synapse.ID()] = developmentalSynapse{
+				id: synapse.ID(), preID: preID, postID: postID, distance: distance, delay: delay,
+			}
+			created++
+		}
+	}
+	d.grown += created
+	return created, nil
+}
+
+// sampleWeight draws a log-normal weight clamped to MaxWeight.
+func (d *Development) sampleWeight() float64 {
+	w := math.Exp(d.config.LogWeightMean + d.config.LogWeightStd*d.rng.NormFloat64())
+	return math.Min(w, d.config.MaxWeight)
+}
+
+// Refine performs one round of activity-dependent refinement. If sg is not
+// nil, its growth check runs first; synapses it creates are tracked as part of
+// the developed network. Developmental synapses whose weight has fallen below
+// PruneThreshold are then eliminated.
+//
+// Returns:
+//
+//	Number of synapses added and pruned
+func (d *Development) Refine(sg *Synaptogenesis, now time.Time) (added, pruned int) {
+	if sg != nil {
+		for _, id := range sg.Step(now) {
+			synapse, ok := d.matrix.GetSynapse(id)
+			if !ok {
+				continue
+			}
+			preID, postID := synapse.GetPresynapticID(), synapse.GetPostsynapticID()
+			distance := d.matrix.calculateSpatialDistance(d.positions[preID], d.positions[postID])
+			d.synapses[id] = developmentalSynapse{
+				id: id, preID: preID, postID: postID, distance: distance, delay: sg.config.Delay,
+			}
+			added++
+		}
+	}
+
+	ids := make([]string, 0, len(d.synapses))
+	for id := range d.synapses {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		synapse, ok := d.matrix.GetSynapse(id)
+		if !ok {
+			delete(d.synapses, id) // Removed elsewhere
+			continue
+		}
+		if synapse.GetWeight() < d.config.PruneThreshold {
+			d.matrix.mu.Lock()
+			delete(d.matrix.synapses, id)
+			d.matrix.mu.Unlock()
+			d.matrix.unregisterInputSynapse(synapse)
+			delete(d.synapses, id)
+			pruned++
+		}
+	}
+
+	d.refined += added
+	d.pruned += pruned
+	return added, pruned
+}
+
+// NeuronIDs returns the seeded neuron IDs.
+func (d *Development) NeuronIDs() []string {
+	ids := make([]string, len(d.neuronIDs))
+	copy(ids, d.neuronIDs)
+	return ids
+}
+
+// Stats summarises the developed network's connectivity.
+func (d *Development) Stats() ConnectivityStats {
+	stats := ConnectivityStats{
+		Neurons:         len(d.neuronIDs),
+		SynapsesGrown:   d.grown,
+		SynapsesPruned:  d.pruned,
+		SynapsesRefined: d.refined,
+	}
+
+	var weights, logWeights, distances, delays []float64
+	for id, record := range d.synapses {
+		synapse, ok := d.matrix.GetSynapse(id)
+		if !ok {
+			continue
+		}
+		w := synapse.GetWeight()
+		weights = append(weights, w)
+		if w > 0 {
+			logWeights = append(logWeights, math.Log(w))
+		}
+		distances = append(distances, record.distance)
+		delays = append(delays, float64(record.delay))
+	}
+
+	stats.Synapses = len(weights)
+	if stats.Neurons > 0 {
+		stats.MeanOutDegree = float64(stats.Synapses) / float64(stats.Neurons)
+	}
+	if stats.Synapses == 0 {
+		return stats
+	}
+
+	stats.MeanWeight, _ = meanStd(weights)
+	stats.LogWeightMean, stats.LogWeightStd = meanStd(logWeights)
+	stats.MeanDistance, _ = meanStd(distances)
+	meanDelay, _ := meanStd(delays)
+	stats.MeanDelay = time.Duration(meanDelay)
+	stats.DelayDistanceR = pearson(distances, delays)
+	return stats
+}
+
+// meanStd returns the mean and population standard deviation.
+func meanStd(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(variance / float64(len(values)))
+}
+
+// pearson returns the correlation coefficient of two equal-length series.
+func pearson(x, y []float64) float64 {
+	mx, sx := meanStd(x)
+	my, sy := meanStd(y)
+	if sx == 0 || sy == 0 {
+		return 0
+	}
+	var cov float64
+	for i := range x {
+		cov += (x[i] - mx) * (y[i] - my)
+	}
+	return cov / float64(len(x)) / (sx * sy)
+}
@@ -0,0 +1,116 @@
+package extracellular
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// newDevelopmentTestMatrix creates a matrix with mock neuron and synapse factories.
+func newDevelopmentTestMatrix() *ExtracellularMatrix {
+	matrix := NewExtracellularMatrix(ExtracellularMatrixConfig{
+		SpatialEnabled: true,
+		UpdateInterval: 10 * time.Millisecond,
+		MaxComponents:  5000,
+	})
+	matrix.RegisterNeuronType("dev_neuron", func(id string, config types.NeuronConfig, callbacks NeuronCallbacks) (component.NeuralComponent, error) {
+		n := NewMockNeuron(id, config.Position, config.Receptors)
+		n.SetCallbacks(callbacks)
+		return n, nil
+	})
+	matrix.RegisterSynapseType("dev_synapse", func(id string, config types.SynapseConfig, callbacks SynapseCallbacks) (component.SynapticProcessor, error) {
+		return NewMockSynapse(id, config.Position, config.PresynapticID, config.PostsynapticID, config.InitialWeight), nil
+	})
+	return matrix
+}
+
+// TestDevelopmentProducesBiologicalStatistics verifies that growth yields
+// log-normal weights, distance-biased connectivity and distance-dependent delays.
+func TestDevelopmentProducesBiologicalStatistics(t *testing.T) {
+	matrix := newDevelopmentTestMatrix()
+	config := DefaultDevelopmentConfig("dev_neuron", "dev_synapse", 60)
+	config.Seed = 42
+
+	dev, err := NewDevelopment(matrix, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := dev.Run(); err != nil {
+		t.Fatalf("Development failed: %v", err)
+	}
+
+	stats := dev.Stats()
+	t.Logf("Developed network: %+v", stats)
+
+	if stats.Neurons != 60 || stats.Synapses == 0 {
+		t.Fatalf("Expected 60 neurons and some synapses, got %d/%d", stats.Neurons, stats.Synapses)
+	}
+	if math.Abs(stats.LogWeightMean-config.LogWeightMean) > 0.25 {
+		t.Errorf("Expected ln-weight mean near %.2f, got %.2f", config.LogWeightMean, stats.LogWeightMean)
+	}
+	if math.Abs(stats.LogWeightStd-config.LogWeightStd) > 0.25 {
+		t.Errorf("Expected ln-weight std near %.2f, got %.2f", config.LogWeightStd, stats.LogWeightStd)
+	}
+	if stats.DelayDistanceR < 0.9 {
+		t.Errorf("Expected delays to track distance, correlation %.2f", stats.DelayDistanceR)
+	}
+
+	// Connections favour nearby neurons: mean connection length is shorter
+	// than the mean distance between all neuron pairs
+	ids := dev.NeuronIDs()
+	var total float64
+	var pairs int
+	for _, a := range ids {
+		for _, b := range ids {
+			if a != b {
+				total += matrix.calculateSpatialDistance(dev.positions[a], dev.positions[b])
+				pairs++
+			}
+		}
+	}
+	if stats.MeanDistance >= total/float64(pairs) {
+		t.Errorf("Expected distance-biased connectivity: connection length %.1f vs pair mean %.1f",
+			stats.MeanDistance, total/float64(pairs))
+	}
+}
+
+// TestDevelopmentRefinementPrunesWeakSynapses verifies refinement removes
+// synapses that have weakened below the prune threshold.
+func TestDevelopmentRefinementPrunesWeakSynapses(t *testing.T) {
+	matrix := newDevelopmentTestMatrix()
+	config := DefaultDevelopmentConfig("dev_neuron", "dev_synapse", 20)
+	config.Seed = 7
+	config.PeakProbability = 1.0
+
+	dev, err := NewDevelopment(matrix, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := dev.Run(); err != nil {
+		t.Fatalf("Development failed: %v", err)
+	}
+	before := dev.Stats().Synapses
+
+	// Weaken one developmental synapse
+	var weakened string
+	for id := range dev.synapses {
+		weakened = id
+		break
+	}
+	synapse, _ := matrix.GetSynapse(weakened)
+	synapse.SetWeight(config.PruneThreshold / 2)
+
+	_, pruned := dev.Refine(nil, time.Now())
+	if pruned < 1 {
+		t.Fatalf("Expected at least one pruned synapse, got %d", pruned)
+	}
+	if _, exists := matrix.GetSynapse(weakened); exists {
+		t.Error("Expected weakened synapse to be removed from matrix")
+	}
+	if after := dev.Stats(); after.Synapses != before-pruned || after.SynapsesPruned != pruned {
+		t.Errorf("Expected %d synapses after pruning, got %+v", before-pruned, after)
+	}
+}
@@ -0,0 +1,432 @@
+package extracellular
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+/*
+=================================================================================
+EXCITATION/INHIBITION BALANCE CONTROL
+=================================================================================
+
+BIOLOGICAL OVERVIEW:
+Cortical neurons receive excitation and inhibition in a roughly fixed
+proportion: when excitatory input rises, inhibitory input follows within
+milliseconds (Okun & Lampl 2008). On slower timescales inhibitory synapses
+onto a neuron are scaled up or down to restore that proportion (inhibitory
+plasticity, Vogels et al. 2011). When the balance is lost, recurrent
+excitation runs away into seizure-like activity.
+
+IMPLEMENTATION:
+The EIBalance controller observes firing (SignalFired broadcasts or
+RecordSpike) and keeps an exponentially smoothed firing rate per neuron. On
+every check it estimates, for each controlled unit, the excitatory and
+inhibitory drive
+
+	E = Σ weight × rate(pre)   over synapses from excitatory neurons
+	I = Σ weight × rate(pre)   over synapses from inhibitory neurons
+
+Neurons marked with MarkInhibitory are inhibitory; their outgoing synapses are
+inhibitory (Dale's law). A unit is a region assigned with AssignRegion, whose
+neurons' drives are pooled, or a single neuron that belongs to no region.
+
+If I/E differs from TargetRatio, every inhibitory synapse onto the unit is
+multiplied by
+
+	(TargetRatio × E / I) ^ Rate
+
+clamped to [1/MaxStep, MaxStep], so inhibition tracks excitation smoothly
+without oscillating. The unit's cumulative inhibitory gain is kept within
+[MinGain, MaxGain]. The factor applies to the current weights, so it composes
+with ongoing plasticity. Units without excitatory drive, or without any active
+inhibitory input to scale, are left alone.
+
+USAGE:
+
+	balance, err := extracellular.NewEIBalance(matrix, extracellular.DefaultEIBalanceConfig())
+	balance.MarkInhibitory(interneuronIDs...)
+	balance.AssignRegion("ca3", ca3NeuronIDs...) // optional: balance per region
+	balance.Attach()                             // listen for firing events
+	balance.Start()                              // periodic checks (or call Step manually)
+	defer balance.Stop()
+
+=================================================================================
+*/
+
+// E/I balance defaults
+const (
+	EI_BALANCE_DEFAULT_TARGET_RATIO   = 1.0 // Inhibitory drive equal to excitatory drive
+	EI_BALANCE_DEFAULT_RATE           = 0.5 // Fraction of the log-ratio error corrected per check
+	EI_BALANCE_DEFAULT_MAX_STEP       = 1.5 // Max weight change factor per check
+	EI_BALANCE_DEFAULT_MIN_GAIN       = 0.1
+	EI_BALANCE_DEFAULT_MAX_GAIN       = 10.0
+	EI_BALANCE_DEFAULT_RATE_TIME      = time.Second // Firing-rate smoothing time constant
+	EI_BALANCE_DEFAULT_CHECK_INTERVAL = 200 * time.Millisecond
+	EI_BALANCE_TOLERANCE              = 0.05 // Relative ratio error left uncorrected
+)
+
+// EIBalanceConfig controls inhibitory gain adjustment.
+type EIBalanceConfig struct {
+	TargetRatio   float64       // Desired inhibitory/excitatory drive ratio
+	Rate          float64       // Exponent of the correction per check (0-1]
+	MaxStep       float64       // Max factor by which weights change per check (> 1)
+	MinGain       float64       // Lower bound on a unit's cumulative inhibitory gain
+	MaxGain       float64       // Upper bound on a unit's cumulative inhibitory gain
+	RateTime      time.Duration // Time constant of the firing-rate estimate
+	CheckInterval time.Duration // Period of automatic checks
+}
+
+// DefaultEIBalanceConfig returns a balanced (I = E) target with gentle
+// corrections.
+func DefaultEIBalanceConfig() EIBalanceConfig {
+	return EIBalanceConfig{
+		TargetRatio:   EI_BALANCE_DEFAULT_TARGET_RATIO,
+		Rate:          EI_BALANCE_DEFAULT_RATE,
+		MaxStep:       EI_BALANCE_DEFAULT_MAX_STEP,
+		MinGain:       EI_BALANCE_DEFAULT_MIN_GAIN,
+		MaxGain:       EI_BALANCE_DEFAULT_MAX_GAIN,
+		RateTime:      EI_BALANCE_DEFAULT_RATE_TIME,
+		CheckInterval: EI_BALANCE_DEFAULT_CHECK_INTERVAL,
+	}
+}
+
+// EIDrive is the estimated drive of a controlled unit at the last check.
+type EIDrive struct {
+	Excitatory float64
+	Inhibitory float64
+	Gain       float64 // Cumulative factor applied to the unit's inhibitory weights
+}
+
+// Ratio returns I/E, or 0 without excitatory drive.
+func (d EIDrive) Ratio() float64 {
+	if d.Excitatory <= 0 {
+		return 0
+	}
+	return d.Inhibitory / d.Excitatory
+}
+
+// EIBalance scales inhibitory synapses to hold a target E/I drive ratio.
+// It implements SignalListener so it can observe SignalFired broadcasts.
+type EIBalance struct {
+	matrix *ExtracellularMatrix
+	config EIBalanceConfig
+
+	inhibitory map[string]bool   // Inhibitory neuron IDs
+	regions    map[string]string // Neuron ID -> region
+	counts     map[string]int    // Spikes since the last check
+	rates      map[string]float64
+	lastCheck  time.Time
+	gains      map[string]float64 // Unit -> cumulative inhibitory gain
+	drives     map[string]EIDrive // Unit -> drive at the last check
+
+	// Statistics
+	checks           int64
+	adjustments      int64
+	gainLimited      int64
+	synapsesScaled   int64
+	noInhibition     int64
+	lastMaxImbalance float64
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	running  bool
+	mu       sync.Mutex
+}
+
+// NewEIBalance creates a balance controller for the matrix.
+//
+// Returns:
+//
+//	The controller (not attached or started), or an error for invalid config
+func NewEIBalance(matrix *ExtracellularMatrix, config EIBalanceConfig) (*EIBalance, error) {
+	if matrix == nil {
+		return nil, fmt.Errorf("E/I balance requires a matrix")
+	}
+	if config.TargetRatio <= 0 {
+		return nil, fmt.Errorf("target E/I ratio must be positive: %f", config.TargetRatio)
+	}
+	if config.Rate <= 0 || config.Rate > 1 {
+		return nil, fmt.Errorf("E/I correction rate must be in (0,1]: %f", config.Rate)
+	}
+	if config.MaxStep <= 1 {
+		return nil, fmt.Errorf("E/I max step must exceed 1: %f", config.MaxStep)
+	}
+	if config.MinGain <= 0 || config.MaxGain < config.MinGain || config.MinGain > 1 || config.MaxGain < 1 {
+		return nil, fmt.Errorf("E/I gain bounds must satisfy 0 < min <= 1 <= max: [%f, %f]", config.MinGain, config.MaxGain)
+	}
+	if config.RateTime <= 0 {
+		config.RateTime = EI_BALANCE_DEFAULT_RATE_TIME
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = EI_BALANCE_DEFAULT_CHECK_INTERVAL
+	}
+
+	return &EIBalance{
+		matrix:     matrix,
+		config:     config,
+		inhibitory: make(map[string]bool),
+		regions:    make(map[string]string),
+		counts:     make(map[string]int),
+		rates:      make(map[string]float64),
+		gains:      make(map[string]float64),
+		drives:     make(map[string]EIDrive),
+	}, nil
+}
+
+// ID identifies the controller as a signal listener.
+func (eb *EIBalance) ID() string {
+	return "ei_balance_controller"
+}
+
+// Attach subscribes the controller to SignalFired broadcasts from the matrix.
+func (eb *EIBalance) Attach() {
+	eb.matrix.ListenForSignals([]SignalType{SignalFired}, eb)
+}
+
+// OnSignal records firing events.
+func (eb *EIBalance) OnSignal(signalType SignalType, sourceID string, data interface{}) {
+	if signalType == SignalFired {
+		eb.RecordSpike(sourceID, time.Now())
+	}
+}
+
+// RecordSpike counts a spike towards the neuron's rate estimate. Useful when
+// firing is observed outside the matrix signal system.
+func (eb *EIBalance) RecordSpike(neuronID string, at time.Time) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	eb.counts[neuronID]++
+}
+
+// MarkInhibitory declares neurons inhibitory; all their outgoing synapses
+// count as inhibitory drive and are the ones the controller scales.
+func (eb *EIBalance) MarkInhibitory(neuronIDs ...string) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	for _, id := range neuronIDs {
+		eb.inhibitory[id] = true
+	}
+}
+
+// AssignRegion pools neurons into one controlled unit. Neurons without a
+// region are controlled individually.
+func (eb *EIBalance) AssignRegion(region string, neuronIDs ...string) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	for _, id := range neuronIDs {
+		eb.regions[id] = region
+	}
+}
+
+// Step updates the rate estimates and rebalances every unit.
+//
+// Returns:
+//
+//	Number of inhibitory synapses whose weight was changed
+func (eb *EIBalance) Step(now time.Time) int {
+	// Read the topology before taking the controller lock; the matrix may
+	// deliver signals to OnSignal while holding its own lock
+	synapses := eb.matrix.ListSynapses()
+	weights := make(map[string]float64, len(synapses))
+	for _, syn := range synapses {
+		weights[syn.ID()] = syn.GetWeight()
+	}
+
+	eb.mu.Lock()
+	eb.checks++
+	eb.updateRates(now)
+
+	// Drive per unit and the inhibitory synapses onto it
+	drives := make(map[string]*EIDrive)
+	targets := make(map[string][]string) // Unit -> inhibitory synapse IDs
+	for _, syn := range synapses {
+		pre, post := syn.GetPresynapticID(), syn.GetPostsynapticID()
+		unit := eb.unit(post)
+		d := drives[unit]
+		if d == nil {
+			d = &EIDrive{}
+			drives[unit] = d
+		}
+		drive := weights[syn.ID()] * eb.rates[pre]
+		if eb.inhibitory[pre] {
+			d.Inhibitory += drive
+			targets[unit] = append(targets[unit], syn.ID())
+		} else {
+			d.Excitatory += drive
+		}
+	}
+
+	units := make([]string, 0, len(drives))
+	for unit := range drives {
+		units = append(units, unit)
+	}
+	sort.Strings(units) // Deterministic adjustment order
+
+	factors := make(map[string]float64)
+	eb.lastMaxImbalance = 0
+	for _, unit := range units {
+		d := drives[unit]
+		gain, ok := eb.gains[unit]
+		if !ok {
+			gain = 1
+		}
+		d.Gain = gain
+		eb.drives[unit] = *d
+		if d.Excitatory <= 0 {
+			continue
+		}
+		if d.Inhibitory <= 0 {
+			if len(targets[unit]) > 0 {
+				eb.noInhibition++
+			}
+			continue
+		}
+
+		desired := eb.config.TargetRatio * d.Excitatory / d.Inhibitory
+		eb.lastMaxImbalance = math.Max(eb.lastMaxImbalance, math.Abs(math.Log(desired)))
+		if math.Abs(desired-1) <= EI_BALANCE_TOLERANCE {
+			continue
+		}
+		factor := math.Pow(desired, eb.config.Rate)
+		factor = math.Max(1/eb.config.MaxStep, math.Min(eb.config.MaxStep, factor))
+		newGain := math.Max(eb.config.MinGain, math.Min(eb.config.MaxGain, gain*factor))
+		if newGain != gain*factor {
+			eb.gainLimited++
+		}
+		if newGain == gain {
+			continue
+		}
+		factors[unit] = newGain / gain
+		eb.gains[unit] = newGain
+		d.Gain = newGain
+		eb.drives[unit] = *d
+		eb.adjustments++
+	}
+	eb.mu.Unlock()
+
+	// Apply outside the controller lock; synapses take their own locks
+	scaled := 0
+	for _, unit := range units {
+		factor, ok := factors[unit]
+		if !ok {
+			continue
+		}
+		for _, id := range targets[unit] {
+			if syn, exists := eb.matrix.GetSynapse(id); exists {
+				syn.SetWeight(weights[id] * factor)
+				scaled++
+			}
+		}
+	}
+
+	eb.mu.Lock()
+	eb.synapsesScaled += int64(scaled)
+	eb.mu.Unlock()
+	return scaled
+}
+
+// updateRates folds the spike counts since the last check into the smoothed
+// rates. Caller must hold eb.mu.
+func (eb *EIBalance) updateRates(now time.Time) {
+	if eb.lastCheck.IsZero() {
+		eb.lastCheck = now.Add(-eb.config.CheckInterval)
+	}
+	elapsed := now.Sub(eb.lastCheck)
+	eb.lastCheck = now
+	if elapsed <= 0 {
+		return
+	}
+	alpha := 1 - math.Exp(-float64(elapsed)/float64(eb.config.RateTime))
+	for id, rate := range eb.rates {
+		eb.rates[id] = rate * (1 - alpha)
+	}
+	for id, count := range eb.counts {
+		eb.rates[id] += alpha * float64(count) / elapsed.Seconds()
+		delete(eb.counts, id)
+	}
+}
+
+// unit returns the controlled unit of a postsynaptic neuron. Caller must
+// hold eb.mu.
+func (eb *EIBalance) unit(neuronID string) string {
+	if region, ok := eb.regions[neuronID]; ok {
+		return region
+	}
+	return neuronID
+}
+
+// GetDrive returns the drive of a region or unassigned neuron at the last
+// check.
+func (eb *EIBalance) GetDrive(unit string) (EIDrive, bool) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	d, ok := eb.drives[unit]
+	return d, ok
+}
+
+// GetRate returns a neuron's smoothed firing rate in Hz.
+func (eb *EIBalance) GetRate(neuronID string) float64 {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	return eb.rates[neuronID]
+}
+
+// Start runs checks every CheckInterval until Stop is called.
+func (eb *EIBalance) Start() {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	if eb.running {
+		return
+	}
+	eb.running = true
+	eb.stopChan = make(chan struct{})
+
+	eb.wg.Add(1)
+	go func(stop chan struct{}) {
+		defer eb.wg.Done()
+		ticker := time.NewTicker(eb.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				eb.Step(now)
+			case <-stop:
+				return
+			}
+		}
+	}(eb.stopChan)
+}
+
+// Stop halts periodic checks.
+func (eb *EIBalance) Stop() {
+	eb.mu.Lock()
+	if !eb.running {
+		eb.mu.Unlock()
+		return
+	}
+	eb.running = false
+	close(eb.stopChan)
+	eb.mu.Unlock()
+	eb.wg.Wait()
+}
+
+// GetStats returns balance statistics for monitoring.
+func (eb *EIBalance) GetStats() map[string]interface{} {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+	return map[string]interface{}{
+		"checks":             eb.checks,
+		"adjustments":        eb.adjustments,
+		"gain_limited":       eb.gainLimited,
+		"synapses_scaled":    eb.synapsesScaled,
+		"no_inhibition":      eb.noInhibition,
+		"max_log_imbalance":  eb.lastMaxImbalance,
+		"inhibitory_neurons": len(eb.inhibitory),
+		"tracked_neurons":    len(eb.rates),
+		"running":            eb.running,
+	}
+}
@@ -0,0 +1,137 @@
+package extracellular
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// connectForBalance creates a pre→post synapse with the given weight.
+func connectForBalance(t *testing.T, matrix *ExtracellularMatrix, pre, post string, weight float64) string {
+	t.Helper()
+	syn, err := matrix.CreateSynapse(types.SynapseConfig{
+		PresynapticID:  pre,
+		PostsynapticID: post,
+		InitialWeight:  weight,
+		SynapseType:    "growth_synapse",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create synapse: %v", err)
+	}
+	return syn.ID()
+}
+
+// driveBalance feeds every neuron spikesPerCheck spikes and runs checks
+// checks at the default interval.
+func driveBalance(eb *EIBalance, neurons []string, spikesPerCheck, checks int, start time.Time) time.Time {
+	now := start
+	for c := 0; c < checks; c++ {
+		for _, id := range neurons {
+			for s := 0; s < spikesPerCheck; s++ {
+				eb.RecordSpike(id, now)
+			}
+		}
+		now = now.Add(EI_BALANCE_DEFAULT_CHECK_INTERVAL)
+		eb.Step(now)
+	}
+	return now
+}
+
+// TestEIBalanceRaisesWeakInhibition verifies that inhibition onto a neuron
+// is scaled until I/E reaches the target.
+func TestEIBalanceRaisesWeakInhibition(t *testing.T) {
+	matrix, ids := newSynaptogenesisTestMatrix(t, 4)
+	e1, e2, inh, post := ids[0], ids[1], ids[2], ids[3]
+	connectForBalance(t, matrix, e1, post, 1.0)
+	connectForBalance(t, matrix, e2, post, 1.0)
+	inhibitory := connectForBalance(t, matrix, inh, post, 0.5)
+
+	eb, err := NewEIBalance(matrix, DefaultEIBalanceConfig())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	eb.MarkInhibitory(inh)
+
+	// All three inputs fire at the same rate: E = 2.0·r, I = 0.5·r
+	driveBalance(eb, []string{e1, e2, inh}, 4, 20, time.Now())
+
+	drive, ok := eb.GetDrive(post)
+	if !ok {
+		t.Fatal("Expected a drive estimate for the postsynaptic neuron")
+	}
+	if math.Abs(drive.Ratio()-1) > 2*EI_BALANCE_TOLERANCE {
+		t.Errorf("Expected I/E near 1, got %.3f", drive.Ratio())
+	}
+	syn, _ := matrix.GetSynapse(inhibitory)
+	if w := syn.GetWeight(); math.Abs(w-2.0) > 0.2 {
+		t.Errorf("Expected the inhibitory weight to approach 2.0, got %.3f", w)
+	}
+	if math.Abs(drive.Gain-syn.GetWeight()/0.5) > 1e-9 {
+		t.Errorf("Gain %.3f does not match the weight change", drive.Gain)
+	}
+	if rate := eb.GetRate(e1); math.Abs(rate-20) > 1 {
+		t.Errorf("Expected a 20 Hz rate estimate, got %.2f", rate)
+	}
+}
+
+// TestEIBalanceRegionGainBounds verifies that regions are balanced as one
+// unit, the gain stays within its bounds and excitatory synapses are never
+// touched.
+func TestEIBalanceRegionGainBounds(t *testing.T) {
+	matrix, ids := newSynaptogenesisTestMatrix(t, 4)
+	exc, inh, a, b := ids[0], ids[1], ids[2], ids[3]
+	excA := connectForBalance(t, matrix, exc, a, 1.0)
+	connectForBalance(t, matrix, exc, b, 1.0)
+	inhA := connectForBalance(t, matrix, inh, a, 1.0)
+	inhB := connectForBalance(t, matrix, inh, b, 1.0)
+
+	config := DefaultEIBalanceConfig()
+	config.MinGain = 0.5
+	eb, err := NewEIBalance(matrix, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	eb.MarkInhibitory(inh)
+	eb.AssignRegion("ca3", a, b)
+
+	// Inhibition far stronger than excitation: E = 2·r, I = 2·10r
+	now := time.Now()
+	for c := 0; c < 20; c++ {
+		eb.RecordSpike(exc, now)
+		for s := 0; s < 10; s++ {
+			eb.RecordSpike(inh, now)
+		}
+		now = now.Add(EI_BALANCE_DEFAULT_CHECK_INTERVAL)
+		eb.Step(now)
+	}
+
+	drive, ok := eb.GetDrive("ca3")
+	if !ok {
+		t.Fatal("Expected a pooled drive for the region")
+	}
+	if drive.Gain != 0.5 {
+		t.Errorf("Expected the gain to stop at the 0.5 bound, got %.3f", drive.Gain)
+	}
+	for _, id := range []string{inhA, inhB} {
+		syn, _ := matrix.GetSynapse(id)
+		if math.Abs(syn.GetWeight()-0.5) > 1e-9 {
+			t.Errorf("Expected inhibitory synapse %s at 0.5, got %.3f", id, syn.GetWeight())
+		}
+	}
+	syn, _ := matrix.GetSynapse(excA)
+	if syn.GetWeight() != 1.0 {
+		t.Errorf("Excitatory weight changed to %.3f", syn.GetWeight())
+	}
+	if _, ok := eb.GetDrive(a); ok {
+		t.Error("Expected region members not to be controlled individually")
+	}
+	if eb.GetStats()["gain_limited"].(int64) == 0 {
+		t.Error("Expected the gain bound to be reported")
+	}
+
+	if _, err := NewEIBalance(matrix, EIBalanceConfig{TargetRatio: 1, Rate: 0.5, MaxStep: 1.5, MinGain: 2, MaxGain: 3}); err == nil {
+		t.Error("Expected a minimum gain above 1 to be rejected")
+	}
+}
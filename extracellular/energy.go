@@ -0,0 +1,50 @@
+package extracellular
+
+import (
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+)
+
+// =================================================================================
+// ENERGY ACCOUNTING
+// =================================================================================
+
+// energyMeterSetter is implemented by components with energy accounting
+// (neuron.Neuron, synapse.BasicSynapse).
+type energyMeterSetter interface {
+	SetEnergyMeter(meter *energy.Meter)
+}
+
+// SetEnergyMeter installs an energy meter on every neuron and synapse the
+// matrix manages, including those created later. A nil meter disables
+// accounting.
+func (ecm *ExtracellularMatrix) SetEnergyMeter(meter *energy.Meter) {
+	ecm.mu.Lock()
+	defer ecm.mu.Unlock()
+
+	ecm.energyMeter = meter
+	for _, neuron := range ecm.neurons {
+		if setter, ok := neuron.(energyMeterSetter); ok {
+			setter.SetEnergyMeter(meter)
+		}
+	}
+	for _, synapse := range ecm.synapses {
+		if setter, ok := synapse.(energyMeterSetter); ok {
+			setter.SetEnergyMeter(meter)
+		}
+	}
+}
+
+// GetEnergyMeter returns the matrix's energy meter, or nil.
+func (ecm *ExtracellularMatrix) GetEnergyMeter() *energy.Meter {
+	ecm.mu.RLock()
+	defer ecm.mu.RUnlock()
+	return ecm.energyMeter
+}
+
+// applyEnergyMeterUnsafe passes the matrix meter, if any, to a newly
+// registered component. Must be called with ecm.mu held.
+func (ecm *ExtracellularMatrix) applyEnergyMeterUnsafe(comp interface{}) {
+	if setter, ok := comp.(energyMeterSetter); ok && ecm.energyMeter != nil {
+		setter.SetEnergyMeter(ecm.energyMeter)
+	}
+}
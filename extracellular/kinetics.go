@@ -0,0 +1,52 @@
+package extracellular
+
+import (
+	"fmt"
+
+	"github.com/SynapticNetworks/temporal-neuron/kinetics"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// KINETICS SCALING
+// =================================================================================
+
+// SetKinetics runs the network in scaled biological time. Neurons and
+// synapses created afterwards get their time constants, decay rates and
+// delays scaled (see kinetics.Scaling.Neuron and Synapse), and spatial
+// conduction delays are scaled on every transmission. Components that
+// already exist are not changed. A nil scaling restores unscaled creation.
+func (ecm *ExtracellularMatrix) SetKinetics(scaling *kinetics.Scaling) {
+	ecm.kinetics.Store(scaling)
+}
+
+// GetKinetics returns the matrix's kinetics scaling, or nil.
+func (ecm *ExtracellularMatrix) GetKinetics() *kinetics.Scaling {
+	return ecm.kinetics.Load()
+}
+
+// scaleNeuronConfig applies the kinetics scaling, if any, to a neuron config.
+func (ecm *ExtracellularMatrix) scaleNeuronConfig(config types.NeuronConfig) (types.NeuronConfig, error) {
+	scaling := ecm.kinetics.Load()
+	if scaling == nil {
+		return config, nil
+	}
+	scaled, err := scaling.Neuron(config)
+	if err != nil {
+		return config, fmt.Errorf("kinetics scaling failed: %w", err)
+	}
+	return scaled, nil
+}
+
+// scaleSynapseConfig applies the kinetics scaling, if any, to a synapse config.
+func (ecm *ExtracellularMatrix) scaleSynapseConfig(config types.SynapseConfig) (types.SynapseConfig, error) {
+	scaling := ecm.kinetics.Load()
+	if scaling == nil {
+		return config, nil
+	}
+	scaled, err := scaling.Synapse(config)
+	if err != nil {
+		return config, fmt.Errorf("kinetics scaling failed: %w", err)
+	}
+	return scaled, nil
+}
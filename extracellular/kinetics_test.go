@@ -0,0 +1,89 @@
+package extracellular
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/kinetics"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestKineticsScalesCreatedComponents verifies that the matrix scales the
+// configs of new components and spatial delays, and rejects configs whose
+// durations cannot be represented at the scaling resolution.
+func TestKineticsScalesCreatedComponents(t *testing.T) {
+	matrix := NewExtracellularMatrix(ExtracellularMatrixConfig{
+		SpatialEnabled: true,
+		UpdateInterval: 10 * time.Millisecond,
+		MaxComponents:  100,
+	})
+	var neuronConfig types.NeuronConfig
+	var synapseConfig types.SynapseConfig
+	matrix.RegisterNeuronType("kin_neuron", func(id string, config types.NeuronConfig, callbacks NeuronCallbacks) (component.NeuralComponent, error) {
+		neuronConfig = config
+		return NewMockNeuron(id, config.Position, config.Receptors), nil
+	})
+	matrix.RegisterSynapseType("kin_synapse", func(id string, config types.SynapseConfig, callbacks SynapseCallbacks) (component.SynapticProcessor, error) {
+		synapseConfig = config
+		return NewMockSynapse(id, config.Position, config.PresynapticID, config.PostsynapticID, config.InitialWeight), nil
+	})
+
+	cell := types.NeuronConfig{
+		NeuronType:       "kin_neuron",
+		Threshold:        1,
+		DecayRate:        0.9,
+		RefractoryPeriod: 2 * time.Millisecond,
+		TargetFiringRate: 5,
+	}
+	pre, _ := matrix.CreateNeuron(cell)
+	cell.Position = types.Position3D{X: 1000}
+
+	scaling, err := kinetics.New(kinetics.Config{Factor: 4, Resolution: time.Microsecond})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	matrix.SetKinetics(scaling)
+	post, err := matrix.CreateNeuron(cell)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if neuronConfig.RefractoryPeriod != 500*time.Microsecond || neuronConfig.TargetFiringRate != 20 ||
+		math.Abs(neuronConfig.DecayRate-math.Pow(0.9, 4)) > 1e-12 {
+		t.Errorf("Expected scaled neuron config, got %+v", neuronConfig)
+	}
+
+	_, err = matrix.CreateSynapse(types.SynapseConfig{
+		SynapseType:      "kin_synapse",
+		PresynapticID:    pre.ID(),
+		PostsynapticID:   post.ID(),
+		InitialWeight:    0.5,
+		Delay:            2 * time.Millisecond,
+		PlasticityConfig: types.PlasticityConfig{TimeConstant: 20 * time.Millisecond, WindowSize: 100 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if synapseConfig.Delay != 500*time.Microsecond || synapseConfig.PlasticityConfig.TimeConstant != 5*time.Millisecond ||
+		synapseConfig.PlasticityConfig.WindowSize != 25*time.Millisecond {
+		t.Errorf("Expected scaled synapse config, got %+v", synapseConfig)
+	}
+
+	// 1000μm at the default axon speed is 0.5ms of conduction, scaled to 125μs
+	if delay := matrix.SynapticDelay(pre.ID(), post.ID(), "s", 0); delay != matrix.calculatePropagationDelay(1000)/4 {
+		t.Errorf("Expected scaled spatial delay %v, got %v", matrix.calculatePropagationDelay(1000)/4, delay)
+	}
+
+	// 0.3ms / 4 is 75μs, which a 100μs grid cannot represent
+	coarse, _ := kinetics.New(kinetics.Config{Factor: 4, Resolution: 100 * time.Microsecond})
+	matrix.SetKinetics(coarse)
+	cell.RefractoryPeriod = 300 * time.Microsecond
+	if _, err := matrix.CreateNeuron(cell); err == nil {
+		t.Error("Expected an error for a refractory period lost to rounding")
+	}
+	matrix.SetKinetics(nil)
+	if _, err := matrix.CreateNeuron(cell); err != nil || neuronConfig.RefractoryPeriod != 300*time.Microsecond {
+		t.Errorf("Expected unscaled creation after clearing, got %v, %+v", err, neuronConfig)
+	}
+}
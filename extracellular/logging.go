@@ -0,0 +1,54 @@
+package extracellular
+
+import (
+	"log/slog"
+)
+
+// =================================================================================
+// STRUCTURED LOGGING
+// =================================================================================
+
+// logHandlerSetter is implemented by components with structured logging
+// (neuron.Neuron, synapse.BasicSynapse).
+type logHandlerSetter interface {
+	SetLogHandler(handler slog.Handler)
+}
+
+// SetLogHandler installs a structured log handler on the matrix and on every
+// neuron and synapse it manages, including those created later. Wrap the
+// handler with logging.FilterComponents to follow individual neurons.
+// A nil handler disables component logging; matrix diagnostics then go to
+// slog.Default().
+func (ecm *ExtracellularMatrix) SetLogHandler(handler slog.Handler) {
+	ecm.mu.Lock()
+	defer ecm.mu.Unlock()
+
+	ecm.logHandler = handler
+	for _, neuron := range ecm.neurons {
+		if setter, ok := neuron.(logHandlerSetter); ok {
+			setter.SetLogHandler(handler)
+		}
+	}
+	for _, synapse := range ecm.synapses {
+		if setter, ok := synapse.(logHandlerSetter); ok {
+			setter.SetLogHandler(handler)
+		}
+	}
+}
+
+// applyLogHandlerUnsafe passes the matrix handler, if any, to a newly
+// registered component. Must be called with ecm.mu held.
+func (ecm *ExtracellularMatrix) applyLogHandlerUnsafe(comp interface{}) {
+	if setter, ok := comp.(logHandlerSetter); ok && ecm.logHandler != nil {
+		setter.SetLogHandler(ecm.logHandler)
+	}
+}
+
+// loggerUnsafe returns the logger for matrix diagnostics.
+// Must be called with ecm.mu held.
+func (ecm *ExtracellularMatrix) loggerUnsafe() *slog.Logger {
+	if ecm.logHandler == nil {
+		return slog.Default()
+	}
+	return slog.New(ecm.logHandler)
+}
@@ -38,12 +38,17 @@ package extracellular
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"math"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+	"github.com/SynapticNetworks/temporal-neuron/kinetics"
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+	"github.com/SynapticNetworks/temporal-neuron/memory"
 	"github.com/SynapticNetworks/temporal-neuron/types"
 )
 
@@ -84,10 +89,26 @@ type ExtracellularMatrix struct {
 
 	// === RESOURCE MANAGEMENT ===
 	maxComponents int // Maximum number of components (neurons + synapses) allowed#
+	maxNeurons    int // Maximum number of neurons, each running a goroutine (0 = no separate limit)
+
+	// === ID NAMESPACE ===
+	namespace string // Prefix of generated component IDs ("" = none)
 
 	// === BIOLOGICAL OBSERVER SYSTEM ===
 	observer atomic.Value // stores types.BiologicalObserver
 
+	// === STRUCTURED LOGGING ===
+	logHandler slog.Handler // Applied to created components (nil = disabled)
+
+	// === ENERGY ACCOUNTING ===
+	energyMeter *energy.Meter // Applied to created components (nil = disabled)
+
+	// === MEMORY BUDGET ===
+	memoryBudget *memory.Budget // Tracks created components (nil = disabled)
+
+	// === KINETICS SCALING ===
+	kinetics atomic.Pointer[kinetics.Scaling] // Applied to created components (nil = unscaled)
+
 	// === OPERATIONAL STATE ===
 	// Models the matrix's biological lifecycle and activity state
 	ctx     context.Context
@@ -103,6 +124,8 @@ type ExtracellularMatrixConfig struct {
 	SpatialEnabled  bool          // Enable 3D spatial organization and delays
 	UpdateInterval  time.Duration // Biological update frequency (metabolism rate)
 	MaxComponents   int           // Metabolic capacity limit for component support
+	MaxNeurons      int           // Neuron limit, and so goroutine limit (0 = only MaxComponents)
+	Namespace       string        // Prefix of generated IDs, keeping several matrices apart ("" = none)
 }
 
 // =================================================================================
@@ -183,6 +206,8 @@ func NewExtracellularMatrix(config ExtracellularMatrixConfig) *ExtracellularMatr
 		synapses: make(map[string]component.SynapticProcessor),
 
 		maxComponents: config.MaxComponents,
+		maxNeurons:    config.MaxNeurons,
+		namespace:     config.Namespace,
 
 		// Operational lifecycle management
 		ctx:     ctx,
@@ -281,6 +306,11 @@ func (ecm *ExtracellularMatrix) RegisterSynapseType(synapseType string, factory
 //
 // FIXED: Improved concurrency with fine-grained locking to reduce performance bottlenecks
 func (ecm *ExtracellularMatrix) CreateNeuron(config types.NeuronConfig) (component.NeuralComponent, error) {
+	config, err := ecm.scaleNeuronConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
 	// === PHASE 1: VALIDATION AND FACTORY LOOKUP (Quick, locked) ===
 	ecm.mu.Lock()
 
@@ -297,6 +327,14 @@ func (ecm *ExtracellularMatrix) CreateNeuron(config types.NeuronConfig) (compone
 		ecm.mu.Unlock()
 		return nil, fmt.Errorf("resource limit exceeded: cannot create neuron, already at maximum %d components", ecm.maxComponents)
 	}
+	if ecm.maxNeurons > 0 && len(ecm.neurons) >= ecm.maxNeurons {
+		ecm.mu.Unlock()
+		return nil, fmt.Errorf("resource limit exceeded: cannot create neuron, already at maximum %d neurons", ecm.maxNeurons)
+	}
+	if err := ecm.checkMemoryUnsafe("neuron"); err != nil {
+		ecm.mu.Unlock()
+		return nil, err
+	}
 
 	// Generate unique biological identifier while locked
 	neuronID := ecm.generateBiologicalNeuronID(config.NeuronType)
@@ -326,15 +364,24 @@ func (ecm *ExtracellularMatrix) CreateNeuron(config types.NeuronConfig) (compone
 	if currentComponentCount >= ecm.maxComponents {
 		return nil, fmt.Errorf("resource limit exceeded during integration: cannot register neuron, at maximum %d components", ecm.maxComponents)
 	}
+	if ecm.maxNeurons > 0 && len(ecm.neurons) >= ecm.maxNeurons {
+		return nil, fmt.Errorf("resource limit exceeded during integration: cannot register neuron, at maximum %d neurons", ecm.maxNeurons)
+	}
+	if err := ecm.admitMemoryUnsafe(neuronID, neuron); err != nil {
+		return nil, fmt.Errorf("cannot register neuron: %w", err)
+	}
 
 	// Integrate the new neuron into all biological coordination systems
 	err = ecm.integrateNeuronIntoBiologicalSystems(neuron, config)
 	if err != nil {
+		ecm.releaseMemoryUnsafe(neuronID)
 		return nil, fmt.Errorf("neural integration failed: %w", err)
 	}
 
 	// Register in active component tracking for ongoing biological coordination
 	ecm.neurons[neuronID] = neuron
+	ecm.applyLogHandlerUnsafe(neuron)
+	ecm.applyEnergyMeterUnsafe(neuron)
 
 	// After successful neuron creation and integration
 	componentInfo := types.ComponentInfo{
@@ -388,6 +435,11 @@ func (ecm *ExtracellularMatrix) CreateNeuron(config types.NeuronConfig) (compone
 //
 // FIXED: Improved concurrency with fine-grained locking to reduce performance bottlenecks
 func (ecm *ExtracellularMatrix) CreateSynapse(config types.SynapseConfig) (component.SynapticProcessor, error) {
+	config, err := ecm.scaleSynapseConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
 	// === PHASE 1: VALIDATION AND FACTORY LOOKUP (Quick, locked) ===
 	ecm.mu.Lock()
 
@@ -414,6 +466,10 @@ func (ecm *ExtracellularMatrix) CreateSynapse(config types.SynapseConfig) (compo
 		ecm.mu.Unlock()
 		return nil, fmt.Errorf("resource limit exceeded: cannot create synapse, already at maximum %d components", ecm.maxComponents)
 	}
+	if err := ecm.checkMemoryUnsafe("synapse"); err != nil {
+		ecm.mu.Unlock()
+		return nil, err
+	}
 
 	// Generate unique biological identifier while locked
 	synapseID := ecm.generateBiologicalSynapseID(config.SynapseType, config.PresynapticID, config.PostsynapticID)
@@ -440,15 +496,27 @@ func (ecm *ExtracellularMatrix) CreateSynapse(config types.SynapseConfig) (compo
 	if currentComponentCount >= ecm.maxComponents {
 		return nil, fmt.Errorf("resource limit exceeded during integration: cannot register synapse, at maximum %d components", ecm.maxComponents)
 	}
+	if err := ecm.admitMemoryUnsafe(synapseID, synapse); err != nil {
+		return nil, fmt.Errorf("cannot register synapse: %w", err)
+	}
 
 	// Integrate the new synapse into all biological coordination systems
 	err = ecm.integrateSynapseIntoBiologicalSystems(synapse, config)
 	if err != nil {
+		ecm.releaseMemoryUnsafe(synapseID)
 		return nil, fmt.Errorf("synaptic integration failed: %w", err)
 	}
 
 	// Register in active component tracking for ongoing biological coordination
 	ecm.synapses[synapseID] = synapse
+	ecm.applyLogHandlerUnsafe(synapse)
+	ecm.applyEnergyMeterUnsafe(synapse)
+
+	// Synapses that report their own events (e.g. dead targets) emit through
+	// the matrix observer
+	if reporter, ok := synapse.(biologicalEventReporter); ok {
+		reporter.SetBiologicalObserver(matrixEventForwarder{ecm})
+	}
 
 	// After successful synapse creation and integration
 	synapseInfo := types.SynapseInfo{
@@ -550,21 +618,7 @@ func (cb *matrixNeuronCallbacks) CreateSynapse(config types.SynapseCreationConfi
 // === SYNAPTIC PRUNING AND ELIMINATION ===
 // Models the biological process of synapse removal during optimization
 func (cb *matrixNeuronCallbacks) DeleteSynapse(synapseID string) error {
-	// Find the synapse first to ensure it exists
-	cb.matrix.mu.RLock()
-	_, exists := cb.matrix.synapses[synapseID]
-	cb.matrix.mu.RUnlock()
-
-	if !exists {
-		return fmt.Errorf("synapse %s not found", synapseID)
-	}
-
-	// Remove from the matrix
-	cb.matrix.mu.Lock()
-	delete(cb.matrix.synapses, synapseID)
-	cb.matrix.mu.Unlock()
-
-	return nil
+	return cb.matrix.DeleteSynapse(synapseID)
 }
 
 // === SYNAPTIC DISCOVERY AND CONNECTIVITY ANALYSIS ===
@@ -699,9 +753,6 @@ func (cb *matrixNeuronCallbacks) ApplyPlasticity(synapseID string, adjustment ty
 		DeltaT:    adjustment.DeltaT, // CRITICAL: Transfer the DeltaT value
 	}
 
-	// Debug log to verify the DeltaT is preserved
-	// fmt.Printf("MATRIX CALLBACK: Converting adjustment with DeltaT=%v to event with DeltaT=%v\n",adjustment.DeltaT, plasticityEvent.DeltaT)
-
 	synapse.UpdateWeight(plasticityEvent)
 	return nil
 }
@@ -1086,6 +1137,18 @@ func (ecm *ExtracellularMatrix) integrateSynapseIntoBiologicalSystems(synapse co
 	return nil
 }
 
+// unregisterInputSynapse removes a deleted synapse from its postsynaptic
+// neuron's input registry, if the neuron keeps one.
+func (ecm *ExtracellularMatrix) unregisterInputSynapse(synapse component.SynapticProcessor) {
+	ecm.mu.RLock()
+	postNeuron, exists := ecm.neurons[synapse.GetPostsynapticID()]
+	ecm.mu.RUnlock()
+
+	if neuronWithInputs, ok := postNeuron.(interface{ UnregisterInputSynapse(string) }); exists && ok {
+		neuronWithInputs.UnregisterInputSynapse(synapse.ID())
+	}
+}
+
 // =================================================================================
 // MATRIX LIFECYCLE MANAGEMENT
 // =================================================================================
@@ -1150,8 +1213,9 @@ func (ecm *ExtracellularMatrix) Start() error {
 		} else {
 			// Partial failure - log errors but continue with successful neurons
 			// In biological systems, some neurons may fail while others continue
-			fmt.Printf("Warning: %d of %d neurons failed to start (continuing with %d successful): %v\n",
-				len(startupErrors), totalNeurons, successfulStarts, startupErrors)
+			ecm.loggerUnsafe().Warn("some neurons failed to start",
+				logging.KeyRecord, logging.RecordDiagnostic, "failed", len(startupErrors),
+				"total", totalNeurons, "errors", fmt.Sprint(startupErrors))
 		}
 	}
 
@@ -1252,6 +1316,9 @@ func (ecm *ExtracellularMatrix) SynapticDelay(
 
 	// Convert spatial distance to axonal conduction delay
 	spatialDelay := ecm.calculatePropagationDelay(distance)
+	if scaling := ecm.kinetics.Load(); scaling != nil {
+		spatialDelay = scaling.Scale(spatialDelay)
+	}
 
 	// Return combined delay: synaptic processing + axonal conduction
 	return baseSynapticDelay + spatialDelay
@@ -1572,6 +1639,24 @@ func (ecm *ExtracellularMatrix) GetSynapse(synapseID string) (component.Synaptic
 	return synapse, exists
 }
 
+// DeleteSynapse removes a synapse from the matrix and from its postsynaptic
+// neuron's input registry. The presynaptic neuron keeps its output callback;
+// callers that prune a live synapse detach it there as well.
+func (ecm *ExtracellularMatrix) DeleteSynapse(synapseID string) error {
+	ecm.mu.Lock()
+	synapse, exists := ecm.synapses[synapseID]
+	if !exists {
+		ecm.mu.Unlock()
+		return fmt.Errorf("synapse %s not found", synapseID)
+	}
+	delete(ecm.synapses, synapseID)
+	ecm.releaseMemoryUnsafe(synapseID)
+	ecm.mu.Unlock()
+
+	ecm.unregisterInputSynapse(synapse)
+	return nil
+}
+
 // ListNeurons returns all active neurons in the biological network.
 //
 // BIOLOGICAL NETWORK CENSUS:
@@ -1625,7 +1710,7 @@ func (ecm *ExtracellularMatrix) ListSynapses() []component.SynapticProcessor {
 // Example: "pyramidal_l5_1716838290123456789" indicates a layer 5 pyramidal
 // neuron created at a specific developmental timepoint.
 func (ecm *ExtracellularMatrix) generateBiologicalNeuronID(neuronType string) string {
-	return fmt.Sprintf("%s_%d", neuronType, time.Now().UnixNano())
+	return ecm.namespacedID(fmt.Sprintf("%s_%d", neuronType, time.Now().UnixNano()))
 }
 
 // generateBiologicalSynapseID creates a unique identifier for a synapse based on connectivity.
@@ -1639,7 +1724,21 @@ func (ecm *ExtracellularMatrix) generateBiologicalNeuronID(neuronType string) st
 // Example: "excitatory_plastic_neuron1_to_neuron2_1716838290123456789"
 // indicates an excitatory plastic synapse from neuron1 to neuron2.
 func (ecm *ExtracellularMatrix) generateBiologicalSynapseID(synapseType, preID, postID string) string {
-	return fmt.Sprintf("%s_%s_to_%s_%d", synapseType, preID, postID, time.Now().UnixNano())
+	return ecm.namespacedID(fmt.Sprintf("%s_%s_to_%s_%d", synapseType, preID, postID, time.Now().UnixNano()))
+}
+
+// namespacedID prefixes id with the matrix namespace, if any, so matrices
+// sharing a process never generate the same ID.
+func (ecm *ExtracellularMatrix) namespacedID(id string) string {
+	if ecm.namespace == "" {
+		return id
+	}
+	return ecm.namespace + "/" + id
+}
+
+// Namespace returns the prefix of generated component IDs.
+func (ecm *ExtracellularMatrix) Namespace() string {
+	return ecm.namespace
 }
 
 // SetBiologicalObserver registers an observer for biological events
@@ -1649,6 +1748,23 @@ func (ecm *ExtracellularMatrix) SetBiologicalObserver(observer types.BiologicalO
 
 }
 
+// biologicalEventReporter is implemented by components that emit their own
+// biological events.
+type biologicalEventReporter interface {
+	SetBiologicalObserver(observer types.BiologicalObserver)
+}
+
+// matrixEventForwarder routes component events to whichever observer the
+// matrix has at emission time.
+type matrixEventForwarder struct {
+	ecm *ExtracellularMatrix
+}
+
+// Emit implements types.BiologicalObserver.
+func (f matrixEventForwarder) Emit(event types.BiologicalEvent) {
+	f.ecm.emitEvent(event)
+}
+
 // emitEvent safely emits an event if observer is registered (non-blocking)
 func (ecm *ExtracellularMatrix) emitEvent(event types.BiologicalEvent) {
 	if observer := ecm.observer.Load(); observer != nil {
@@ -0,0 +1,96 @@
+package extracellular
+
+import (
+	"fmt"
+
+	"github.com/SynapticNetworks/temporal-neuron/memory"
+)
+
+// =================================================================================
+// MEMORY BUDGET
+// =================================================================================
+//
+// With a budget installed, every neuron and synapse that estimates its own
+// memory (memory.Reporter) is tracked from creation until removal. Creation
+// is refused once the budget is exhausted or the new component does not fit,
+// so growth such as synaptogenesis and neurogenesis stops at the cap while
+// the existing network keeps running.
+
+// SetMemoryBudget tracks the current and all future neurons and synapses in
+// budget (nil stops tracking). It fails, and installs nothing, if the
+// current network does not fit.
+func (ecm *ExtracellularMatrix) SetMemoryBudget(budget *memory.Budget) error {
+	ecm.mu.Lock()
+	defer ecm.mu.Unlock()
+
+	if budget != nil {
+		var tracked []string
+		for id, reporter := range ecm.memoryReportersUnsafe() {
+			if err := budget.Track(id, reporter); err != nil {
+				for _, trackedID := range tracked {
+					budget.Untrack(trackedID)
+				}
+				return err
+			}
+			tracked = append(tracked, id)
+		}
+	}
+	if ecm.memoryBudget != nil && ecm.memoryBudget != budget {
+		for id := range ecm.memoryReportersUnsafe() {
+			ecm.memoryBudget.Untrack(id)
+		}
+	}
+	ecm.memoryBudget = budget
+	return nil
+}
+
+// GetMemoryBudget returns the matrix's memory budget, or nil.
+func (ecm *ExtracellularMatrix) GetMemoryBudget() *memory.Budget {
+	ecm.mu.RLock()
+	defer ecm.mu.RUnlock()
+	return ecm.memoryBudget
+}
+
+// memoryReportersUnsafe returns the components that report their memory.
+// Must be called with ecm.mu held.
+func (ecm *ExtracellularMatrix) memoryReportersUnsafe() map[string]memory.Reporter {
+	reporters := make(map[string]memory.Reporter)
+	for id, neuron := range ecm.neurons {
+		if reporter, ok := neuron.(memory.Reporter); ok {
+			reporters[id] = reporter
+		}
+	}
+	for id, synapse := range ecm.synapses {
+		if reporter, ok := synapse.(memory.Reporter); ok {
+			reporters[id] = reporter
+		}
+	}
+	return reporters
+}
+
+// checkMemoryUnsafe refuses creation early once the budget is exhausted.
+// Must be called with ecm.mu held.
+func (ecm *ExtracellularMatrix) checkMemoryUnsafe(kind string) error {
+	if ecm.memoryBudget != nil && ecm.memoryBudget.Exhausted() {
+		return fmt.Errorf("cannot create %s: %w", kind, memory.ErrBudgetExceeded)
+	}
+	return nil
+}
+
+// admitMemoryUnsafe tracks a new component, failing if it does not fit.
+// Must be called with ecm.mu held.
+func (ecm *ExtracellularMatrix) admitMemoryUnsafe(id string, comp interface{}) error {
+	reporter, ok := comp.(memory.Reporter)
+	if !ok || ecm.memoryBudget == nil {
+		return nil
+	}
+	return ecm.memoryBudget.Track(id, reporter)
+}
+
+// releaseMemoryUnsafe stops tracking a removed component. Must be called
+// with ecm.mu held.
+func (ecm *ExtracellularMatrix) releaseMemoryUnsafe(id string) {
+	if ecm.memoryBudget != nil {
+		ecm.memoryBudget.Untrack(id)
+	}
+}
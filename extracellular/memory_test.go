@@ -0,0 +1,70 @@
+package extracellular
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/memory"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// sizedMockSynapse is a mock synapse that reports a fixed size.
+type sizedMockSynapse struct{ *MockSynapse }
+
+func (s sizedMockSynapse) MemoryUsage() memory.Usage {
+	return memory.Usage{memory.CategorySynapses: 100}
+}
+
+// TestMemoryBudgetStopsGrowth verifies that synapse creation stops at the
+// budget and resumes once removal frees memory.
+func TestMemoryBudgetStopsGrowth(t *testing.T) {
+	matrix, ids := newSynaptogenesisTestMatrix(t, 4)
+	matrix.RegisterSynapseType("sized_synapse", func(id string, config types.SynapseConfig, callbacks SynapseCallbacks) (component.SynapticProcessor, error) {
+		return sizedMockSynapse{NewMockSynapse(id, config.Position, config.PresynapticID, config.PostsynapticID, config.InitialWeight)}, nil
+	})
+	connect := func(pre, post string) (component.SynapticProcessor, error) {
+		return matrix.CreateSynapse(types.SynapseConfig{SynapseType: "sized_synapse", PresynapticID: pre, PostsynapticID: post, InitialWeight: 0.5})
+	}
+	existing, err := connect(ids[0], ids[1])
+	if err != nil {
+		t.Fatalf("Failed to create synapse: %v", err)
+	}
+
+	budget, _ := memory.NewBudget(250)
+	if err := matrix.SetMemoryBudget(budget); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if budget.Used() != 100 {
+		t.Errorf("Expected the existing synapse to be tracked, got %d bytes", budget.Used())
+	}
+	if _, err := connect(ids[1], ids[2]); err != nil {
+		t.Fatalf("Expected a second synapse to fit: %v", err)
+	}
+	if _, err := connect(ids[2], ids[3]); !errors.Is(err, memory.ErrBudgetExceeded) {
+		t.Fatalf("Expected the third synapse to exceed the budget, got %v", err)
+	}
+	if len(matrix.ListSynapses()) != 2 || budget.Report().Rejected != 1 {
+		t.Errorf("Expected 2 synapses and one rejection, got %d and %d", len(matrix.ListSynapses()), budget.Report().Rejected)
+	}
+
+	// Removal frees the budget
+	if err := matrix.DeleteSynapse(existing.ID()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := connect(ids[2], ids[3]); err != nil {
+		t.Errorf("Expected growth to resume after deletion: %v", err)
+	}
+	if _, err := matrix.RemoveNeuron(ids[3]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if budget.Used() != 100 {
+		t.Errorf("Expected 100 bytes after removing a neuron with its synapse, got %d", budget.Used())
+	}
+
+	// A budget the network does not fit into is not installed
+	small, _ := memory.NewBudget(50)
+	if err := matrix.SetMemoryBudget(small); err == nil || matrix.GetMemoryBudget() != budget {
+		t.Errorf("Expected a too small budget to be refused, got %v", err)
+	}
+}
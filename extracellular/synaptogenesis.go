@@ -0,0 +1,523 @@
+package extracellular
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+/*
+=================================================================================
+ACTIVITY-DEPENDENT SYNAPTOGENESIS
+=================================================================================
+
+BIOLOGICAL OVERVIEW:
+"Neurons that fire together wire together" applies to structure as well as
+strength. Axons and dendrites continuously extend and retract filopodia; contacts
+between neurons whose activity is repeatedly correlated are stabilised into new
+synapses (driven by NMDA receptor activation and BDNF release), while
+uncorrelated contacts are withdrawn. Together with pruning (see microglia.go),
+this forms structural plasticity.
+
+IMPLEMENTATION:
+The Synaptogenesis controller listens to SignalFired broadcasts from the matrix
+and keeps a sliding window of recent spike times per neuron. On every growth
+check it measures the causal correlation of each ordered neuron pair (A fires,
+then B fires within CorrelationWindow) and, for strongly correlated pairs that
+are not yet connected, creates an A→B synapse through the matrix with
+probability GrowthProbability. Maximum in- and out-degree limits bound the
+growth, modelling the finite number of synaptic sites a neuron can support.
+
+SILENT SYNAPSES:
+With SilentThreshold set, new synapses start silent (NMDA-only, see the
+synapse package's maturation.go): they learn from the pairings that made
+them but deliver nothing until STDP has potentiated them by SilentThreshold.
+Contacts that keep predicting the target's firing are then unsilenced; the
+rest never perturb the circuit and are eventually pruned. Synapse types that
+do not support a silent stage are grown active.
+
+Correlation is the number of matched pre→post coincidences (each spike used at
+most once) normalised by sqrt(nPre * nPost), so it lies in [0, 1].
+
+DEGREE HOMEOSTASIS:
+Neurons keep their number of synaptic sites roughly constant over long periods:
+when connections are eliminated, the freed sites are re-occupied by new
+contacts. With TargetInDegree and/or TargetOutDegree set, growth is steered
+towards that target:
+
+  - neurons at or above their target receive no new growth on that side,
+  - candidates are ranked by the degree deficit of their neurons before
+    correlation, so under-connected neurons get first pick, and
+  - the growth probability rises with the deficit, from GrowthProbability for
+    a neuron at its target to 1 for a neuron with no connections.
+
+Degrees are read from the matrix on every check, so connections removed by
+pruning are replaced on the next checks and degree distributions stay stable
+during long runs. Correlation remains required: homeostasis decides where
+growth goes, not whether uncorrelated neurons are wired.
+
+USAGE:
+
+	sg, err := extracellular.NewSynaptogenesis(matrix, extracellular.DefaultSynaptogenesisConfig("excitatory"))
+	sg.Attach()   // listen for firing events
+	sg.Start()    // periodic growth checks (or call Step manually)
+	defer sg.Stop()
+
+=================================================================================
+*/
+
+// Synaptogenesis defaults based on cortical coincidence-detection timescales
+const (
+	SYNAPTOGENESIS_DEFAULT_CORRELATION_WINDOW = 20 * time.Millisecond // NMDA coincidence window
+	SYNAPTOGENESIS_DEFAULT_HISTORY_WINDOW     = 2 * time.Second       // Sliding window for correlation
+	SYNAPTOGENESIS_DEFAULT_THRESHOLD          = 0.5                   // Normalised correlation required
+	SYNAPTOGENESIS_DEFAULT_MIN_COINCIDENCES   = 3                     // Minimum evidence before growth
+	SYNAPTOGENESIS_DEFAULT_GROWTH_PROBABILITY = 0.5                   // Chance a candidate contact stabilises
+	SYNAPTOGENESIS_DEFAULT_MAX_DEGREE         = 50                    // Max new-growth in/out degree
+	SYNAPTOGENESIS_DEFAULT_INITIAL_WEIGHT     = 0.3                   // Nascent synapses start weak
+	SYNAPTOGENESIS_DEFAULT_CHECK_INTERVAL     = 500 * time.Millisecond
+	SYNAPTOGENESIS_MAX_SPIKES_PER_NEURON      = 1000 // Bound on stored history per neuron
+)
+
+// SynaptogenesisConfig controls activity-dependent synapse growth.
+type SynaptogenesisConfig struct {
+	CorrelationWindow    time.Duration // Max pre→post lag counted as a coincidence
+	HistoryWindow        time.Duration // Sliding window over which correlation is measured
+	CorrelationThreshold float64       // Minimum normalised correlation (0-1)
+	MinCoincidences      int           // Minimum coincidences before a pair is a candidate
+	GrowthProbability    float64       // Probability a candidate pair is connected per check
+	MaxInDegree          int           // Max incoming synapses of a target (0 = unlimited)
+	MaxOutDegree         int           // Max outgoing synapses of a source (0 = unlimited)
+	TargetInDegree       int           // Incoming synapses homeostasis aims for (0 = no target)
+	TargetOutDegree      int           // Outgoing synapses homeostasis aims for (0 = no target)
+	SynapseType          string        // Registered synapse factory used for new synapses
+	InitialWeight        float64       // Weight of newly grown synapses
+	SilentThreshold      float64       // > 0 grows silent synapses, unsilenced by this much potentiation
+	Delay                time.Duration // Base delay of newly grown synapses
+	LigandType           types.LigandType
+	CheckInterval        time.Duration // Period of automatic growth checks
+	Seed                 int64         // RNG seed (0 = time-based)
+}
+
+// DefaultSynaptogenesisConfig returns conservative defaults for the given
+// registered synapse type.
+func DefaultSynaptogenesisConfig(synapseType string) SynaptogenesisConfig {
+	return SynaptogenesisConfig{
+		CorrelationWindow:    SYNAPTOGENESIS_DEFAULT_CORRELATION_WINDOW,
+		HistoryWindow:        SYNAPTOGENESIS_DEFAULT_HISTORY_WINDOW,
+		CorrelationThreshold: SYNAPTOGENESIS_DEFAULT_THRESHOLD,
+		MinCoincidences:      SYNAPTOGENESIS_DEFAULT_MIN_COINCIDENCES,
+		GrowthProbability:    SYNAPTOGENESIS_DEFAULT_GROWTH_PROBABILITY,
+		MaxInDegree:          SYNAPTOGENESIS_DEFAULT_MAX_DEGREE,
+		MaxOutDegree:         SYNAPTOGENESIS_DEFAULT_MAX_DEGREE,
+		SynapseType:          synapseType,
+		InitialWeight:        SYNAPTOGENESIS_DEFAULT_INITIAL_WEIGHT,
+		Delay:                time.Millisecond,
+		LigandType:           types.LigandGlutamate,
+		CheckInterval:        SYNAPTOGENESIS_DEFAULT_CHECK_INTERVAL,
+	}
+}
+
+// Synaptogenesis grows synapses between co-active, unconnected neurons.
+// It implements SignalListener so it can observe SignalFired broadcasts.
+type Synaptogenesis struct {
+	matrix *ExtracellularMatrix
+	config SynaptogenesisConfig
+
+	spikes map[string][]time.Time // Recent spike times per neuron (ascending)
+	rng    *rand.Rand
+
+	// Statistics
+	checks          int64
+	candidatesFound int64
+	synapsesCreated int64
+	degreeRejected  int64
+	targetRejected  int64
+	creationErrors  int64
+	silentCreated   int64
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	running  bool
+	mu       sync.Mutex
+}
+
+// silentStarter is implemented by synapses that can start silent.
+type silentStarter interface {
+	StartSilent(threshold float64) error
+}
+
+// growthCandidate is a correlated, unconnected ordered neuron pair.
+type growthCandidate struct {
+	preID       string
+	postID      string
+	correlation float64
+}
+
+// NewSynaptogenesis creates a growth controller for the matrix.
+//
+// Returns:
+//
+//	The controller (not attached or started), or an error for invalid config
+func NewSynaptogenesis(matrix *ExtracellularMatrix, config SynaptogenesisConfig) (*Synaptogenesis, error) {
+	if matrix == nil {
+		return nil, fmt.Errorf("synaptogenesis requires a matrix")
+	}
+	if config.SynapseType == "" {
+		return nil, fmt.Errorf("synaptogenesis requires a synapse type")
+	}
+	if config.CorrelationWindow <= 0 || config.HistoryWindow <= 0 {
+		return nil, fmt.Errorf("synaptogenesis windows must be positive")
+	}
+	if config.CorrelationThreshold < 0 || config.CorrelationThreshold > 1 {
+		return nil, fmt.Errorf("correlation threshold must be in [0,1]: %f", config.CorrelationThreshold)
+	}
+	if config.GrowthProbability < 0 || config.GrowthProbability > 1 {
+		return nil, fmt.Errorf("growth probability must be in [0,1]: %f", config.GrowthProbability)
+	}
+	if config.MaxInDegree < 0 || config.MaxOutDegree < 0 {
+		return nil, fmt.Errorf("degree limits cannot be negative")
+	}
+	if config.TargetInDegree < 0 || config.TargetOutDegree < 0 {
+		return nil, fmt.Errorf("degree targets cannot be negative")
+	}
+	if config.MaxInDegree > 0 && config.TargetInDegree > config.MaxInDegree {
+		return nil, fmt.Errorf("target in-degree %d exceeds max in-degree %d", config.TargetInDegree, config.MaxInDegree)
+	}
+	if config.MaxOutDegree > 0 && config.TargetOutDegree > config.MaxOutDegree {
+		return nil, fmt.Errorf("target out-degree %d exceeds max out-degree %d", config.TargetOutDegree, config.MaxOutDegree)
+	}
+	if config.SilentThreshold < 0 {
+		return nil, fmt.Errorf("silent threshold cannot be negative: %f", config.SilentThreshold)
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = SYNAPTOGENESIS_DEFAULT_CHECK_INTERVAL
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Synaptogenesis{
+		matrix: matrix,
+		config: config,
+		spikes: make(map[string][]time.Time),
+		rng:    rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+// ID identifies the controller as a signal listener.
+func (sg *Synaptogenesis) ID() string {
+	return "synaptogenesis_controller"
+}
+
+// Attach subscribes the controller to SignalFired broadcasts from the matrix.
+func (sg *Synaptogenesis) Attach() {
+	sg.matrix.ListenForSignals([]SignalType{SignalFired}, sg)
+}
+
+// OnSignal records firing events.
+func (sg *Synaptogenesis) OnSignal(signalType SignalType, sourceID string, data interface{}) {
+	if signalType == SignalFired {
+		sg.RecordSpike(sourceID, time.Now())
+	}
+}
+
+// RecordSpike adds a spike to a neuron's history. Useful when firing is
+// observed outside the matrix signal system.
+func (sg *Synaptogenesis) RecordSpike(neuronID string, at time.Time) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+
+	history := append(sg.spikes[neuronID], at)
+	// Keep ascending order for out-of-order reports
+	for i := len(history) - 1; i > 0 && history[i].Before(history[i-1]); i-- {
+		history[i], history[i-1] = history[i-1], history[i]
+	}
+	if len(history) > SYNAPTOGENESIS_MAX_SPIKES_PER_NEURON {
+		history = history[len(history)-SYNAPTOGENESIS_MAX_SPIKES_PER_NEURON:]
+	}
+	sg.spikes[neuronID] = history
+}
+
+// Forget drops a neuron's spike history, e.g. after it died, so it is no
+// longer proposed as a growth partner.
+func (sg *Synaptogenesis) Forget(neuronID string) {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	delete(sg.spikes, neuronID)
+}
+
+// Step runs one growth check at the given time.
+//
+// Returns:
+//
+//	IDs of synapses created during this check
+func (sg *Synaptogenesis) Step(now time.Time) []string {
+	sg.mu.Lock()
+	sg.checks++
+	sg.trimHistory(now)
+	candidates := sg.findCandidates()
+	sg.candidatesFound += int64(len(candidates))
+	sg.mu.Unlock()
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	// Current topology from the matrix
+	connected := make(map[[2]string]bool)
+	inDegree := make(map[string]int)
+	outDegree := make(map[string]int)
+	for _, syn := range sg.matrix.ListSynapses() {
+		pre, post := syn.GetPresynapticID(), syn.GetPostsynapticID()
+		connected[[2]string{pre, post}] = true
+		outDegree[pre]++
+		inDegree[post]++
+	}
+	if sg.homeostatic() {
+		sg.rankByDeficit(candidates, inDegree, outDegree)
+	}
+
+	var created []string
+	for _, c := range candidates {
+		if connected[[2]string{c.preID, c.postID}] {
+			continue
+		}
+		if (sg.config.MaxOutDegree > 0 && outDegree[c.preID] >= sg.config.MaxOutDegree) ||
+			(sg.config.MaxInDegree > 0 && inDegree[c.postID] >= sg.config.MaxInDegree) {
+			sg.mu.Lock()
+			sg.degreeRejected++
+			sg.mu.Unlock()
+			continue
+		}
+
+		probability := sg.config.GrowthProbability
+		if sg.homeostatic() {
+			deficit, open := sg.degreeDeficit(c, inDegree, outDegree)
+			if !open {
+				sg.mu.Lock()
+				sg.targetRejected++
+				sg.mu.Unlock()
+				continue
+			}
+			probability += (1 - probability) * deficit
+		}
+
+		sg.mu.Lock()
+		grow := sg.rng.Float64() < probability
+		sg.mu.Unlock()
+		if !grow {
+			continue
+		}
+
+		synapse, err := sg.matrix.CreateSynapse(types.SynapseConfig{
+			PresynapticID:     c.preID,
+			PostsynapticID:    c.postID,
+			InitialWeight:     sg.config.InitialWeight,
+			Delay:             sg.config.Delay,
+			LigandType:        sg.config.LigandType,
+			PlasticityEnabled: true,
+			SynapseType:       sg.config.SynapseType,
+			Metadata: map[string]interface{}{
+				"origin":      "activity_dependent_synaptogenesis",
+				"correlation": c.correlation,
+			},
+		})
+		silent := false
+		if err == nil && sg.config.SilentThreshold > 0 {
+			if nascent, ok := synapse.(silentStarter); ok {
+				silent = nascent.StartSilent(sg.config.SilentThreshold) == nil
+			}
+		}
+		sg.mu.Lock()
+		if err != nil {
+			sg.creationErrors++
+			sg.mu.Unlock()
+			continue
+		}
+		sg.synapsesCreated++
+		if silent {
+			sg.silentCreated++
+		}
+		sg.mu.Unlock()
+
+		connected[[2]string{c.preID, c.postID}] = true
+		outDegree[c.preID]++
+		inDegree[c.postID]++
+		created = append(created, synapse.ID())
+	}
+	return created
+}
+
+// trimHistory drops spikes older than the history window.
+// Caller must hold sg.mu.
+func (sg *Synaptogenesis) trimHistory(now time.Time) {
+	cutoff := now.Add(-sg.config.HistoryWindow)
+	for id, history := range sg.spikes {
+		i := sort.Search(len(history), func(i int) bool { return !history[i].Before(cutoff) })
+		if i == len(history) {
+			delete(sg.spikes, id)
+			continue
+		}
+		sg.spikes[id] = history[i:]
+	}
+}
+
+// findCandidates returns ordered pairs above the correlation threshold,
+// strongest first. Caller must hold sg.mu.
+func (sg *Synaptogenesis) findCandidates() []growthCandidate {
+	ids := make([]string, 0, len(sg.spikes))
+	for id := range sg.spikes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids) // Deterministic order for seeded runs
+
+	var candidates []growthCandidate
+	for _, pre := range ids {
+		for _, post := range ids {
+			if pre == post {
+				continue
+			}
+			preSpikes, postSpikes := sg.spikes[pre], sg.spikes[post]
+			coincidences := countCoincidences(preSpikes, postSpikes, sg.config.CorrelationWindow)
+			if coincidences < sg.config.MinCoincidences || coincidences == 0 {
+				continue
+			}
+			correlation := float64(coincidences) / math.Sqrt(float64(len(preSpikes))*float64(len(postSpikes)))
+			if correlation >= sg.config.CorrelationThreshold {
+				candidates = append(candidates, growthCandidate{preID: pre, postID: post, correlation: correlation})
+			}
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].correlation > candidates[j].correlation
+	})
+	return candidates
+}
+
+// homeostatic reports whether a degree target is set.
+func (sg *Synaptogenesis) homeostatic() bool {
+	return sg.config.TargetInDegree > 0 || sg.config.TargetOutDegree > 0
+}
+
+// degreeDeficit returns the mean relative degree deficit of a candidate's
+// targeted sides, in [0, 1], and false if a neuron already meets its target.
+func (sg *Synaptogenesis) degreeDeficit(c growthCandidate, inDegree, outDegree map[string]int) (float64, bool) {
+	deficit, sides := 0.0, 0
+	if target := sg.config.TargetOutDegree; target > 0 {
+		if outDegree[c.preID] >= target {
+			return 0, false
+		}
+		deficit += float64(target-outDegree[c.preID]) / float64(target)
+		sides++
+	}
+	if target := sg.config.TargetInDegree; target > 0 {
+		if inDegree[c.postID] >= target {
+			return 0, false
+		}
+		deficit += float64(target-inDegree[c.postID]) / float64(target)
+		sides++
+	}
+	return deficit / float64(sides), true
+}
+
+// rankByDeficit orders candidates by degree deficit, most under-connected
+// first, keeping the correlation order among equal deficits.
+func (sg *Synaptogenesis) rankByDeficit(candidates []growthCandidate, inDegree, outDegree map[string]int) {
+	deficits := make(map[[2]string]float64, len(candidates))
+	for _, c := range candidates {
+		deficit, open := sg.degreeDeficit(c, inDegree, outDegree)
+		if !open {
+			deficit = -1
+		}
+		deficits[[2]string{c.preID, c.postID}] = deficit
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return deficits[[2]string{candidates[i].preID, candidates[i].postID}] >
+			deficits[[2]string{candidates[j].preID, candidates[j].postID}]
+	})
+}
+
+// countCoincidences matches each pre spike with the first unused post spike
+// that follows it within window. Both slices must be ascending.
+func countCoincidences(pre, post []time.Time, window time.Duration) int {
+	count := 0
+	j := 0
+	for _, tPre := range pre {
+		for j < len(post) && !post[j].After(tPre) {
+			j++
+		}
+		if j == len(post) {
+			break
+		}
+		if post[j].Sub(tPre) <= window {
+			count++
+			j++
+		}
+	}
+	return count
+}
+
+// Start runs growth checks every CheckInterval until Stop is called.
+func (sg *Synaptogenesis) Start() {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	if sg.running {
+		return
+	}
+	sg.running = true
+	sg.stopChan = make(chan struct{})
+
+	sg.wg.Add(1)
+	go func(stop chan struct{}) {
+		defer sg.wg.Done()
+		ticker := time.NewTicker(sg.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				sg.Step(now)
+			case <-stop:
+				return
+			}
+		}
+	}(sg.stopChan)
+}
+
+// Stop halts periodic growth checks.
+func (sg *Synaptogenesis) Stop() {
+	sg.mu.Lock()
+	if !sg.running {
+		sg.mu.Unlock()
+		return
+	}
+	sg.running = false
+	close(sg.stopChan)
+	sg.mu.Unlock()
+	sg.wg.Wait()
+}
+
+// GetStats returns growth statistics for monitoring.
+func (sg *Synaptogenesis) GetStats() map[string]interface{} {
+	sg.mu.Lock()
+	defer sg.mu.Unlock()
+	return map[string]interface{}{
+		"checks":           sg.checks,
+		"candidates_found": sg.candidatesFound,
+		"synapses_created": sg.synapsesCreated,
+		"degree_rejected":  sg.degreeRejected,
+		"target_rejected":  sg.targetRejected,
+		"creation_errors":  sg.creationErrors,
+		"silent_created":   sg.silentCreated,
+		"tracked_neurons":  len(sg.spikes),
+		"running":          sg.running,
+	}
+}
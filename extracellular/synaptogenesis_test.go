@@ -0,0 +1,252 @@
+package extracellular
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// newSynaptogenesisTestMatrix creates a matrix with mock factories and neurons.
+func newSynaptogenesisTestMatrix(t *testing.T, count int) (*ExtracellularMatrix, []string) {
+	t.Helper()
+	matrix := NewExtracellularMatrix(ExtracellularMatrixConfig{
+		UpdateInterval: 10 * time.Millisecond,
+		MaxComponents:  100,
+	})
+	matrix.RegisterNeuronType("growth_neuron", func(id string, config types.NeuronConfig, callbacks NeuronCallbacks) (component.NeuralComponent, error) {
+		n := NewMockNeuron(id, config.Position, config.Receptors)
+		n.SetCallbacks(callbacks)
+		return n, nil
+	})
+	matrix.RegisterSynapseType("growth_synapse", func(id string, config types.SynapseConfig, callbacks SynapseCallbacks) (component.SynapticProcessor, error) {
+		return NewMockSynapse(id, config.Position, config.PresynapticID, config.PostsynapticID, config.InitialWeight), nil
+	})
+
+	var ids []string
+	for i := 0; i < count; i++ {
+		n, err := matrix.CreateNeuron(types.NeuronConfig{NeuronType: "growth_neuron", Threshold: 1.0})
+		if err != nil {
+			t.Fatalf("Failed to create neuron: %v", err)
+		}
+		ids = append(ids, n.ID())
+	}
+	return matrix, ids
+}
+
+// TestSynaptogenesisConnectsCorrelatedPair verifies that a causally
+// correlated pair is connected in the pre→post direction only.
+func TestSynaptogenesisConnectsCorrelatedPair(t *testing.T) {
+	matrix, ids := newSynaptogenesisTestMatrix(t, 3)
+	a, b, c := ids[0], ids[1], ids[2]
+
+	config := DefaultSynaptogenesisConfig("growth_synapse")
+	config.GrowthProbability = 1.0
+	config.Seed = 1
+	sg, err := NewSynaptogenesis(matrix, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	base := time.Now()
+	for i := 0; i < 5; i++ {
+		t0 := base.Add(time.Duration(i) * 100 * time.Millisecond)
+		sg.RecordSpike(a, t0)
+		sg.RecordSpike(b, t0.Add(5*time.Millisecond))
+		sg.RecordSpike(c, t0.Add(50*time.Millisecond)) // Outside the coincidence window
+	}
+
+	created := sg.Step(base.Add(time.Second))
+	if len(created) != 1 {
+		t.Fatalf("Expected exactly one new synapse, got %d", len(created))
+	}
+	syn, ok := matrix.GetSynapse(created[0])
+	if !ok {
+		t.Fatal("Created synapse not registered with matrix")
+	}
+	if syn.GetPresynapticID() != a || syn.GetPostsynapticID() != b {
+		t.Errorf("Expected %s→%s, got %s→%s", a, b, syn.GetPresynapticID(), syn.GetPostsynapticID())
+	}
+
+	// Already-connected pairs are not duplicated
+	if again := sg.Step(base.Add(time.Second)); len(again) != 0 {
+		t.Errorf("Expected no duplicate growth, got %d new synapses", len(again))
+	}
+}
+
+// TestSynaptogenesisRespectsDegreeLimits verifies the out-degree cap.
+func TestSynaptogenesisRespectsDegreeLimits(t *testing.T) {
+	matrix, ids := newSynaptogenesisTestMatrix(t, 3)
+	hub, x, y := ids[0], ids[1], ids[2]
+
+	config := DefaultSynaptogenesisConfig("growth_synapse")
+	config.GrowthProbability = 1.0
+	config.MaxOutDegree = 1
+	config.Seed = 1
+	sg, err := NewSynaptogenesis(matrix, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	base := time.Now()
+	for i := 0; i < 5; i++ {
+		t0 := base.Add(time.Duration(i) * 100 * time.Millisecond)
+		sg.RecordSpike(hub, t0)
+		sg.RecordSpike(x, t0.Add(3*time.Millisecond))
+		sg.RecordSpike(y, t0.Add(60*time.Millisecond))
+		sg.RecordSpike(y, t0.Add(4*time.Millisecond))
+	}
+
+	sg.Step(base.Add(time.Second))
+
+	outgoing := 0
+	for _, syn := range matrix.ListSynapses() {
+		if syn.GetPresynapticID() == hub {
+			outgoing++
+		}
+	}
+	if outgoing != 1 {
+		t.Errorf("Expected hub out-degree capped at 1, got %d", outgoing)
+	}
+	if sg.GetStats()["degree_rejected"].(int64) == 0 {
+		t.Error("Expected degree-limited candidates to be counted")
+	}
+}
+
+// TestSynaptogenesisObservesFiringSignals verifies spikes are picked up from
+// matrix SignalFired broadcasts and expire with the history window.
+func TestSynaptogenesisObservesFiringSignals(t *testing.T) {
+	matrix, ids := newSynaptogenesisTestMatrix(t, 1)
+
+	config := DefaultSynaptogenesisConfig("growth_synapse")
+	config.HistoryWindow = 50 * time.Millisecond
+	sg, err := NewSynaptogenesis(matrix, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	sg.Attach()
+
+	matrix.SendSignal(SignalFired, ids[0], 1.0)
+	if tracked := sg.GetStats()["tracked_neurons"].(int); tracked != 1 {
+		t.Fatalf("Expected 1 tracked neuron after firing signal, got %d", tracked)
+	}
+
+	sg.Step(time.Now().Add(time.Second))
+	if tracked := sg.GetStats()["tracked_neurons"].(int); tracked != 0 {
+		t.Errorf("Expected expired spikes to be dropped, got %d tracked neurons", tracked)
+	}
+
+	if _, err := NewSynaptogenesis(matrix, SynaptogenesisConfig{}); err == nil {
+		t.Error("Expected error for empty configuration")
+	}
+}
+
+// TestSynaptogenesisDegreeHomeostasis verifies that growth goes to
+// under-connected neurons, skips neurons at their target and replaces pruned
+// connections.
+func TestSynaptogenesisDegreeHomeostasis(t *testing.T) {
+	matrix, ids := newSynaptogenesisTestMatrix(t, 4)
+	a, b, c, d := ids[0], ids[1], ids[2], ids[3]
+
+	// b already has its one incoming synapse
+	if _, err := matrix.CreateSynapse(types.SynapseConfig{
+		PresynapticID: d, PostsynapticID: b, InitialWeight: 0.5, SynapseType: "growth_synapse",
+	}); err != nil {
+		t.Fatalf("Failed to create synapse: %v", err)
+	}
+
+	config := DefaultSynaptogenesisConfig("growth_synapse")
+	config.GrowthProbability = 0 // only the deficit drives growth
+	config.TargetInDegree = 1
+	config.Seed = 1
+	sg, err := NewSynaptogenesis(matrix, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	base := time.Now()
+	for i := 0; i < 5; i++ {
+		t0 := base.Add(time.Duration(i) * 100 * time.Millisecond)
+		sg.RecordSpike(a, t0)
+		sg.RecordSpike(b, t0.Add(3*time.Millisecond))
+		sg.RecordSpike(c, t0.Add(3*time.Millisecond))
+	}
+
+	created := sg.Step(base.Add(time.Second))
+	if len(created) != 1 {
+		t.Fatalf("Expected one synapse onto the unconnected neuron, got %d", len(created))
+	}
+	syn, _ := matrix.GetSynapse(created[0])
+	if syn.GetPresynapticID() != a || syn.GetPostsynapticID() != c {
+		t.Errorf("Expected %s→%s, got %s→%s", a, c, syn.GetPresynapticID(), syn.GetPostsynapticID())
+	}
+	if sg.GetStats()["target_rejected"].(int64) == 0 {
+		t.Error("Expected growth onto the neuron at its target to be counted as rejected")
+	}
+
+	// Pruning frees the site and the next check refills it
+	if err := matrix.DeleteSynapse(created[0]); err != nil {
+		t.Fatalf("Failed to delete synapse: %v", err)
+	}
+	if regrown := sg.Step(base.Add(time.Second)); len(regrown) != 1 {
+		t.Errorf("Expected the pruned connection to be replaced, got %d new synapses", len(regrown))
+	}
+
+	config.MaxInDegree = 1
+	config.TargetInDegree = 2
+	if _, err := NewSynaptogenesis(matrix, config); err == nil {
+		t.Error("Expected a target above the degree limit to be rejected")
+	}
+}
+
+// silentMockSynapse records the unsilencing threshold it was started with.
+type silentMockSynapse struct {
+	*MockSynapse
+	threshold float64
+}
+
+func (s *silentMockSynapse) StartSilent(threshold float64) error {
+	s.threshold = threshold
+	return nil
+}
+
+// TestSynaptogenesisGrowsSilentSynapses verifies that SilentThreshold starts
+// new synapses silent when the synapse type supports it.
+func TestSynaptogenesisGrowsSilentSynapses(t *testing.T) {
+	matrix, ids := newSynaptogenesisTestMatrix(t, 2)
+	matrix.RegisterSynapseType("silent_synapse", func(id string, config types.SynapseConfig, callbacks SynapseCallbacks) (component.SynapticProcessor, error) {
+		return &silentMockSynapse{MockSynapse: NewMockSynapse(id, config.Position, config.PresynapticID, config.PostsynapticID, config.InitialWeight)}, nil
+	})
+
+	config := DefaultSynaptogenesisConfig("silent_synapse")
+	config.GrowthProbability = 1.0
+	config.SilentThreshold = 0.05
+	config.Seed = 1
+	sg, err := NewSynaptogenesis(matrix, config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	base := time.Now()
+	for i := 0; i < 5; i++ {
+		t0 := base.Add(time.Duration(i) * 100 * time.Millisecond)
+		sg.RecordSpike(ids[0], t0)
+		sg.RecordSpike(ids[1], t0.Add(5*time.Millisecond))
+	}
+	created := sg.Step(base.Add(time.Second))
+	if len(created) != 1 {
+		t.Fatalf("Expected one new synapse, got %d", len(created))
+	}
+	syn, _ := matrix.GetSynapse(created[0])
+	if nascent := syn.(*silentMockSynapse); nascent.threshold != 0.05 {
+		t.Errorf("Expected the synapse started silent at 0.05, got %f", nascent.threshold)
+	}
+	if stats := sg.GetStats(); stats["silent_created"].(int64) != 1 {
+		t.Errorf("Expected one silent synapse in stats, got %v", stats)
+	}
+
+	config.SilentThreshold = -1
+	if _, err := NewSynaptogenesis(matrix, config); err == nil {
+		t.Error("Expected error for a negative silent threshold")
+	}
+}
@@ -0,0 +1,492 @@
+package extracellular
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+/*
+=================================================================================
+NEURONAL TURNOVER - NEURON DEATH AND ADULT NEUROGENESIS
+=================================================================================
+
+BIOLOGICAL OVERVIEW:
+A few structures keep replacing their neurons throughout life. In the
+dentate gyrus of the hippocampus, adult-born granule cells are added every
+day and most of them die within weeks unless they are recruited by activity;
+the survivors integrate into the existing circuit and change what it
+encodes (Kempermann et al. 2004; Aimone et al. 2014). Modelling turnover
+needs two operations the matrix otherwise lacks: removing a neuron cleanly,
+and adding one that wires itself in.
+
+IMPLEMENTATION:
+RemoveNeuron takes a neuron out of the matrix together with every synapse
+it takes part in. Its incoming synapses are detached from their presynaptic
+neurons, so no spike is sent towards the dead cell, and its outgoing
+synapses are unregistered from their targets. The neuron is also removed
+from chemical signalling, gap-junction broadcasts, the astrocyte map and
+microglial surveillance, and then stopped.
+
+The Turnover controller applies death and birth as stochastic processes:
+
+  - Death: every eligible neuron dies with probability 1 - exp(-DeathRate·Δt)
+    per check, but never below MinNeurons eligible neurons.
+  - Birth: the number of new neurons per check is Poisson with mean
+    BirthRate·Δt, never above MaxNeurons eligible neurons. Newborns are
+    created from the Neuron template at a random position within Radius of
+    Center and receive InitialInputs and InitialOutputs random synapses, so
+    they start out with some drive. Activity-dependent synaptogenesis (see
+    synaptogenesis.go) then adds the connections their firing supports.
+
+Eligible restricts turnover to a region, for example neurons whose metadata
+marks them as granule cells; newborns should match it so they can die too.
+
+EVENTS:
+Every removal emits NeuronRemoved, with the reason ("removed" or
+"apoptosis") and the number of synapses removed. Every newborn emits
+NeuronBorn with the number of integration synapses, in addition to the
+NeuronCreated event of the factory.
+
+USAGE:
+
+	turnover, err := extracellular.NewTurnover(matrix, extracellular.TurnoverConfig{
+	    DeathRate: 0.01, BirthRate: 0.5, Neuron: granuleTemplate,
+	    SynapseType: "excitatory", InitialInputs: 5, Synaptogenesis: sg,
+	})
+	turnover.Start() // periodic checks (or call Step manually)
+	defer turnover.Stop()
+
+=================================================================================
+*/
+
+// Turnover defaults
+const (
+	TURNOVER_DEFAULT_CHECK_INTERVAL = 1 * time.Second
+	TURNOVER_DEFAULT_INITIAL_WEIGHT = 0.3 // Like nascent synapses of synaptogenesis
+	TURNOVER_DEFAULT_RADIUS         = 100.0
+)
+
+// Removal reasons reported in NeuronRemoved events.
+const (
+	RemovalReasonRemoved   = "removed"
+	RemovalReasonApoptosis = "apoptosis"
+)
+
+// RemoveNeuron removes a neuron and every synapse it takes part in, and
+// stops it.
+//
+// Returns:
+//
+//	The number of synapses removed, or an error if the neuron is unknown
+func (ecm *ExtracellularMatrix) RemoveNeuron(neuronID string) (int, error) {
+	return ecm.removeNeuron(neuronID, RemovalReasonRemoved)
+}
+
+// removeNeuron implements RemoveNeuron and reports reason in the event.
+func (ecm *ExtracellularMatrix) removeNeuron(neuronID, reason string) (int, error) {
+	ecm.mu.Lock()
+	neuron, exists := ecm.neurons[neuronID]
+	if !exists {
+		ecm.mu.Unlock()
+		return 0, fmt.Errorf("neuron %s not found", neuronID)
+	}
+	delete(ecm.neurons, neuronID)
+	ecm.releaseMemoryUnsafe(neuronID)
+	var incoming, outgoing []component.SynapticProcessor
+	for id, synapse := range ecm.synapses {
+		switch {
+		case synapse.GetPostsynapticID() == neuronID:
+			incoming = append(incoming, synapse)
+		case synapse.GetPresynapticID() == neuronID:
+			outgoing = append(outgoing, synapse)
+		default:
+			continue
+		}
+		delete(ecm.synapses, id)
+		ecm.releaseMemoryUnsafe(id)
+	}
+	// Presynaptic partners that must stop sending to the dead neuron
+	partners := make(map[string]component.NeuralComponent, len(incoming))
+	for _, synapse := range incoming {
+		if pre, ok := ecm.neurons[synapse.GetPresynapticID()]; ok {
+			partners[synapse.ID()] = pre
+		}
+	}
+	ecm.mu.Unlock()
+
+	for synapseID, pre := range partners {
+		if detacher, ok := pre.(interface{ RemoveOutputCallback(string) }); ok {
+			detacher.RemoveOutputCallback(synapseID)
+		}
+	}
+	for _, synapse := range outgoing {
+		ecm.unregisterInputSynapse(synapse)
+	}
+
+	if target, ok := neuron.(BindingTarget); ok {
+		ecm.chemicalModulator.UnregisterTarget(target)
+	}
+	if listener, ok := neuron.(SignalListener); ok {
+		ecm.signalMediator.RemoveListener(ecm.signalMediator.listenerTypes(neuronID), listener)
+	}
+	for _, coupled := range ecm.signalMediator.GetElectricalCouplings(neuronID) {
+		ecm.signalMediator.RemoveElectricalCoupling(neuronID, coupled)
+	}
+	ecm.microglia.RemoveComponent(neuronID) // Also clears the astrocyte map
+	neuron.Stop()
+
+	removed := len(incoming) + len(outgoing)
+	position := neuron.Position()
+	ecm.emitEvent(types.BiologicalEvent{
+		EventType:   types.NeuronRemoved,
+		SourceID:    neuronID,
+		Description: "neuron removed with its synapses",
+		Position:    &position,
+		Data:        map[string]interface{}{"reason": reason, "synapses_removed": removed},
+	})
+	return removed, nil
+}
+
+// listenerTypes returns the signal types a listener is registered for.
+func (sm *SignalMediator) listenerTypes(listenerID string) []SignalType {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+	var signalTypes []SignalType
+	for signalType, listeners := range sm.listeners {
+		for _, listener := range listeners {
+			if listener.ID() == listenerID {
+				signalTypes = append(signalTypes, signalType)
+				break
+			}
+		}
+	}
+	return signalTypes
+}
+
+// TurnoverConfig controls neuron death and neurogenesis.
+type TurnoverConfig struct {
+	DeathRate  float64 // Probability per second that an eligible neuron dies
+	BirthRate  float64 // Mean number of new neurons per second
+	MinNeurons int     // Deaths stop at this many eligible neurons
+	MaxNeurons int     // Births stop at this many eligible neurons (0 = unlimited)
+
+	// Eligible selects the neurons subject to turnover (nil = all neurons)
+	Eligible func(neuron component.NeuralComponent) bool
+
+	Neuron types.NeuronConfig // Template for newborns; NeuronType selects the factory
+	Center types.Position3D   // Centre of the region newborns are placed in
+	Radius float64            // Radius of that region in μm
+
+	SynapseType    string        // Registered synapse type of integration synapses
+	InitialInputs  int           // Random synapses onto each newborn
+	InitialOutputs int           // Random synapses from each newborn
+	InitialWeight  float64       // Weight of integration synapses
+	Delay          time.Duration // Delay of integration synapses
+
+	// Synaptogenesis, if set, forgets the spike history of dead neurons
+	Synaptogenesis *Synaptogenesis
+
+	CheckInterval time.Duration // Period of automatic checks
+	Seed          int64         // RNG seed (0 = time-based)
+}
+
+// Turnover kills and replaces neurons of a matrix.
+type Turnover struct {
+	matrix *ExtracellularMatrix
+	config TurnoverConfig
+	rng    *rand.Rand
+
+	lastCheck time.Time
+	newborns  map[string]time.Time // Living adult-born neurons and their birth time
+
+	// Statistics
+	checks             int64
+	deaths             int64
+	births             int64
+	synapsesRemoved    int64
+	integrationCreated int64
+	errors             int64
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	running  bool
+	mu       sync.Mutex
+}
+
+// NewTurnover creates a turnover controller (not started).
+func NewTurnover(matrix *ExtracellularMatrix, config TurnoverConfig) (*Turnover, error) {
+	if matrix == nil {
+		return nil, fmt.Errorf("turnover requires a matrix")
+	}
+	if config.DeathRate < 0 || config.BirthRate < 0 || math.IsNaN(config.DeathRate) || math.IsNaN(config.BirthRate) {
+		return nil, fmt.Errorf("turnover rates cannot be negative")
+	}
+	if config.MinNeurons < 0 || config.MaxNeurons < 0 || config.InitialInputs < 0 || config.InitialOutputs < 0 {
+		return nil, fmt.Errorf("turnover counts cannot be negative")
+	}
+	if config.MaxNeurons > 0 && config.MinNeurons > config.MaxNeurons {
+		return nil, fmt.Errorf("min neurons %d exceeds max neurons %d", config.MinNeurons, config.MaxNeurons)
+	}
+	if config.BirthRate > 0 && config.Neuron.NeuronType == "" {
+		return nil, fmt.Errorf("neurogenesis requires a neuron type")
+	}
+	if config.InitialInputs+config.InitialOutputs > 0 && config.SynapseType == "" {
+		return nil, fmt.Errorf("integration synapses require a synapse type")
+	}
+	if config.Radius < 0 || config.Delay < 0 {
+		return nil, fmt.Errorf("radius and delay cannot be negative")
+	}
+	if config.Radius == 0 {
+		config.Radius = TURNOVER_DEFAULT_RADIUS
+	}
+	if config.InitialWeight == 0 {
+		config.InitialWeight = TURNOVER_DEFAULT_INITIAL_WEIGHT
+	}
+	if config.CheckInterval <= 0 {
+		config.CheckInterval = TURNOVER_DEFAULT_CHECK_INTERVAL
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Turnover{
+		matrix:   matrix,
+		config:   config,
+		rng:      rand.New(rand.NewSource(seed)),
+		newborns: make(map[string]time.Time),
+	}, nil
+}
+
+// Step runs one check at the given time. The first check covers one
+// CheckInterval, later checks the time since the previous one.
+//
+// Returns:
+//
+//	IDs of the neurons that died and of the neurons that were born
+func (t *Turnover) Step(now time.Time) (died, born []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	elapsed := t.config.CheckInterval
+	if !t.lastCheck.IsZero() {
+		elapsed = now.Sub(t.lastCheck)
+	}
+	t.lastCheck = now
+	t.checks++
+	if elapsed <= 0 {
+		return nil, nil
+	}
+
+	eligible := t.eligibleNeurons()
+	deathProbability := 1 - math.Exp(-t.config.DeathRate*elapsed.Seconds())
+	for _, id := range eligible {
+		if len(eligible)-len(died) <= t.config.MinNeurons {
+			break
+		}
+		if t.rng.Float64() >= deathProbability {
+			continue
+		}
+		removed, err := t.matrix.removeNeuron(id, RemovalReasonApoptosis)
+		if err != nil {
+			t.errors++
+			continue
+		}
+		died = append(died, id)
+		delete(t.newborns, id)
+		t.deaths++
+		t.synapsesRemoved += int64(removed)
+		if t.config.Synaptogenesis != nil {
+			t.config.Synaptogenesis.Forget(id)
+		}
+	}
+
+	population := len(eligible) - len(died)
+	for n := t.poisson(t.config.BirthRate * elapsed.Seconds()); n > 0; n-- {
+		if t.config.MaxNeurons > 0 && population >= t.config.MaxNeurons {
+			break
+		}
+		id, err := t.spawn(now)
+		if err != nil {
+			t.errors++
+			continue
+		}
+		born = append(born, id)
+		population++
+	}
+	return died, born
+}
+
+// eligibleNeurons returns the IDs of neurons subject to turnover, sorted so
+// runs are reproducible from the seed.
+func (t *Turnover) eligibleNeurons() []string {
+	var ids []string
+	for _, neuron := range t.matrix.ListNeurons() {
+		if t.config.Eligible == nil || t.config.Eligible(neuron) {
+			ids = append(ids, neuron.ID())
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// poisson draws a Poisson-distributed count with the given mean.
+func (t *Turnover) poisson(mean float64) int {
+	if mean <= 0 {
+		return 0
+	}
+	limit, product, count := math.Exp(-mean), t.rng.Float64(), 0
+	for product > limit {
+		product *= t.rng.Float64()
+		count++
+	}
+	return count
+}
+
+// spawn creates one newborn neuron and its integration synapses.
+func (t *Turnover) spawn(now time.Time) (string, error) {
+	config := t.config.Neuron
+	config.Position = t.randomPosition()
+	config.Metadata = make(map[string]interface{}, len(t.config.Neuron.Metadata)+1)
+	for key, value := range t.config.Neuron.Metadata {
+		config.Metadata[key] = value
+	}
+	config.Metadata["born_at"] = now
+
+	neuron, err := t.matrix.CreateNeuron(config)
+	if err != nil {
+		return "", err
+	}
+	id := neuron.ID()
+	t.newborns[id] = now
+	t.births++
+
+	var partners []string
+	for _, other := range t.matrix.ListNeurons() {
+		if other.ID() != id {
+			partners = append(partners, other.ID())
+		}
+	}
+	sort.Strings(partners)
+	connected := 0
+	connect := func(pre, post string) {
+		_, err := t.matrix.CreateSynapse(types.SynapseConfig{
+			SynapseType:    t.config.SynapseType,
+			PresynapticID:  pre,
+			PostsynapticID: post,
+			InitialWeight:  t.config.InitialWeight,
+			Delay:          t.config.Delay,
+			LigandType:     types.LigandGlutamate,
+		})
+		if err != nil {
+			t.errors++
+			return
+		}
+		connected++
+	}
+	order := t.rng.Perm(len(partners))
+	for k := 0; k < t.config.InitialInputs && k < len(order); k++ {
+		connect(partners[order[k]], id)
+	}
+	order = t.rng.Perm(len(partners))
+	for k := 0; k < t.config.InitialOutputs && k < len(order); k++ {
+		connect(id, partners[order[k]])
+	}
+	t.integrationCreated += int64(connected)
+
+	t.matrix.emitEvent(types.BiologicalEvent{
+		EventType:   types.NeuronBorn,
+		SourceID:    id,
+		Description: "adult-born neuron integrated",
+		Position:    &config.Position,
+		Data:        map[string]interface{}{"integration_synapses": connected},
+	})
+	return id, nil
+}
+
+// randomPosition returns a uniform position in the sphere of Radius around
+// Center.
+func (t *Turnover) randomPosition() types.Position3D {
+	r := t.config.Radius * math.Cbrt(t.rng.Float64())
+	z := 2*t.rng.Float64() - 1
+	phi := 2 * math.Pi * t.rng.Float64()
+	s := math.Sqrt(1 - z*z)
+	return types.Position3D{
+		X: t.config.Center.X + r*s*math.Cos(phi),
+		Y: t.config.Center.Y + r*s*math.Sin(phi),
+		Z: t.config.Center.Z + r*z,
+	}
+}
+
+// Newborns returns the living adult-born neurons and their birth times.
+func (t *Turnover) Newborns() map[string]time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	newborns := make(map[string]time.Time, len(t.newborns))
+	for id, at := range t.newborns {
+		newborns[id] = at
+	}
+	return newborns
+}
+
+// Start runs checks every CheckInterval until Stop is called.
+func (t *Turnover) Start() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.running {
+		return
+	}
+	t.running = true
+	t.stopChan = make(chan struct{})
+
+	t.wg.Add(1)
+	go func(stop chan struct{}) {
+		defer t.wg.Done()
+		ticker := time.NewTicker(t.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				t.Step(now)
+			case <-stop:
+				return
+			}
+		}
+	}(t.stopChan)
+}
+
+// Stop halts periodic checks.
+func (t *Turnover) Stop() {
+	t.mu.Lock()
+	if !t.running {
+		t.mu.Unlock()
+		return
+	}
+	t.running = false
+	close(t.stopChan)
+	t.mu.Unlock()
+	t.wg.Wait()
+}
+
+// GetStats returns turnover statistics for monitoring.
+func (t *Turnover) GetStats() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return map[string]interface{}{
+		"checks":               t.checks,
+		"deaths":               t.deaths,
+		"births":               t.births,
+		"newborns_alive":       len(t.newborns),
+		"synapses_removed":     t.synapsesRemoved,
+		"integration_synapses": t.integrationCreated,
+		"errors":               t.errors,
+		"running":              t.running,
+	}
+}
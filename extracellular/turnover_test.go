@@ -0,0 +1,172 @@
+package extracellular
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// recordingObserver keeps every emitted event.
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []types.BiologicalEvent
+}
+
+func (r *recordingObserver) Emit(event types.BiologicalEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recordingObserver) ofType(eventType types.EventType) []types.BiologicalEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var matching []types.BiologicalEvent
+	for _, event := range r.events {
+		if event.EventType == eventType {
+			matching = append(matching, event)
+		}
+	}
+	return matching
+}
+
+// TestRemoveNeuronDetachesSynapses verifies that a removed neuron takes its
+// synapses with it and that presynaptic partners stop sending to it.
+func TestRemoveNeuronDetachesSynapses(t *testing.T) {
+	matrix, ids := newSynaptogenesisTestMatrix(t, 3)
+	observer := &recordingObserver{}
+	matrix.SetBiologicalObserver(observer)
+	a, b, c := ids[0], ids[1], ids[2]
+
+	connect := func(pre, post string) string {
+		syn, err := matrix.CreateSynapse(types.SynapseConfig{SynapseType: "growth_synapse", PresynapticID: pre, PostsynapticID: post, InitialWeight: 0.5})
+		if err != nil {
+			t.Fatalf("Failed to create synapse: %v", err)
+		}
+		neuron, _ := matrix.GetNeuron(pre)
+		neuron.(*MockNeuron).AddOutputCallback(syn.ID(), types.OutputCallback{})
+		return syn.ID()
+	}
+	ab := connect(a, b)
+	connect(b, c)
+	ac := connect(a, c)
+
+	removed, err := matrix.RemoveNeuron(b)
+	if err != nil || removed != 2 {
+		t.Fatalf("Expected 2 synapses removed, got %d (%v)", removed, err)
+	}
+	if _, ok := matrix.GetNeuron(b); ok {
+		t.Error("Removed neuron still registered")
+	}
+	if synapses := matrix.ListSynapses(); len(synapses) != 1 || synapses[0].ID() != ac {
+		t.Errorf("Expected only %s to remain, got %d synapses", ac, len(synapses))
+	}
+	pre, _ := matrix.GetNeuron(a)
+	for _, id := range pre.(*MockNeuron).GetConnections() {
+		if id == ab {
+			t.Errorf("Presynaptic neuron still sends through %s", ab)
+		}
+	}
+	if events := observer.ofType(types.NeuronRemoved); len(events) != 1 || events[0].SourceID != b {
+		t.Errorf("Expected one NeuronRemoved event for %s, got %v", b, events)
+	}
+	if _, err := matrix.RemoveNeuron(b); err == nil {
+		t.Error("Expected error when removing an unknown neuron")
+	}
+}
+
+// TestTurnoverDeathAndNeurogenesis runs turnover on a region and checks
+// deaths, integrated newborns, the population floor and the events.
+func TestTurnoverDeathAndNeurogenesis(t *testing.T) {
+	matrix, _ := newSynaptogenesisTestMatrix(t, 10)
+	observer := &recordingObserver{}
+	matrix.SetBiologicalObserver(observer)
+
+	sg, err := NewSynaptogenesis(matrix, DefaultSynaptogenesisConfig("growth_synapse"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	turnover, err := NewTurnover(matrix, TurnoverConfig{
+		DeathRate:      0.3,
+		BirthRate:      2,
+		MinNeurons:     6,
+		Neuron:         types.NeuronConfig{NeuronType: "growth_neuron", Threshold: 1.0},
+		Center:         types.Position3D{X: 500},
+		Radius:         50,
+		SynapseType:    "growth_synapse",
+		InitialInputs:  3,
+		InitialOutputs: 1,
+		Synaptogenesis: sg,
+		Seed:           1,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var died, born []string
+	base := time.Now()
+	for i := 1; i <= 5; i++ {
+		d, b := turnover.Step(base.Add(time.Duration(i) * time.Second))
+		died = append(died, d...)
+		born = append(born, b...)
+	}
+	if len(died) == 0 || len(born) == 0 {
+		t.Fatalf("Expected deaths and births over 5s, got %d and %d", len(died), len(born))
+	}
+	if n := len(matrix.ListNeurons()); n != 10-len(died)+len(born) || n < 6 {
+		t.Errorf("Expected %d neurons, got %d", 10-len(died)+len(born), n)
+	}
+
+	newborns := turnover.Newborns()
+	for id := range newborns {
+		neuron, _ := matrix.GetNeuron(id)
+		if position := neuron.Position(); position.X < 450 || position.X > 550 {
+			t.Errorf("Newborn %s outside the region: %+v", id, position)
+		}
+	}
+	for _, id := range died {
+		for _, syn := range matrix.ListSynapses() {
+			if syn.GetPresynapticID() == id || syn.GetPostsynapticID() == id {
+				t.Errorf("Synapse %s of dead neuron %s remains", syn.ID(), id)
+			}
+		}
+	}
+
+	if n := len(observer.ofType(types.NeuronRemoved)); n != len(died) {
+		t.Errorf("Expected %d NeuronRemoved events, got %d", len(died), n)
+	}
+	bornEvents := observer.ofType(types.NeuronBorn)
+	if len(bornEvents) != len(born) {
+		t.Errorf("Expected %d NeuronBorn events, got %d", len(born), len(bornEvents))
+	}
+	for _, event := range bornEvents {
+		data := event.Data.(map[string]interface{})
+		if data["integration_synapses"].(int) != 4 {
+			t.Errorf("Newborn %s integrated with %v synapses, expected 4", event.SourceID, data["integration_synapses"])
+		}
+	}
+	stats := turnover.GetStats()
+	if stats["deaths"].(int64) != int64(len(died)) || stats["births"].(int64) != int64(len(born)) {
+		t.Errorf("Stats disagree with steps: %v", stats)
+	}
+
+	// A death rate far above the birth rate stops at the floor
+	turnover.config.BirthRate = 0
+	turnover.config.DeathRate = 100
+	turnover.Step(base.Add(10 * time.Second))
+	if n := len(matrix.ListNeurons()); n != 6 {
+		t.Errorf("Expected turnover to stop at 6 neurons, got %d", n)
+	}
+
+	if _, err := NewTurnover(matrix, TurnoverConfig{BirthRate: 1}); err == nil {
+		t.Error("Expected error for neurogenesis without a neuron type")
+	}
+	if _, err := NewTurnover(matrix, TurnoverConfig{
+		Eligible: func(component.NeuralComponent) bool { return true }, MinNeurons: 5, MaxNeurons: 2,
+	}); err == nil {
+		t.Error("Expected error for min neurons above max neurons")
+	}
+}
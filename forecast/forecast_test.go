@@ -0,0 +1,92 @@
+package forecast
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestModel_ForecastsMackeyGlass learns the chaotic Mackey-Glass series
+// online and checks that the forecast beats persistence five samples ahead.
+func TestModel_ForecastsMackeyGlass(t *testing.T) {
+	const samples = 1500
+	series := MackeyGlass(samples, 17)
+	model, err := NewModel(Config{
+		Encoder: NewLatencyEncoder(0.2, 1.4, 20, 10*time.Millisecond),
+		Horizon: 5,
+		Size:    50,
+		Seed:    1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
+
+	predictions := model.Run(series)
+	if last := predictions[samples-1]; last.Sample != samples-1 || last.Target != samples+4 {
+		t.Errorf("Expected the last forecast for sample %d, got %+v", samples+4, last)
+	}
+	if got := model.Elapsed(); got != samples*FORECAST_DEFAULT_SAMPLE_PERIOD {
+		t.Errorf("Expected %v of virtual time, got %v", samples*FORECAST_DEFAULT_SAMPLE_PERIOD, got)
+	}
+	if perNeuron := float64(model.Spikes()) / samples / 50; perNeuron < 0.05 || perNeuron > 3 {
+		t.Errorf("Expected a sparsely active layer, got %.2f spikes per neuron per sample", perNeuron)
+	}
+
+	stats := model.Errors()
+	t.Logf("NRMSE %.3f (persistence %.3f), RMSE %.4f, MAE %.4f over %d samples",
+		stats.NRMSE, stats.PersistenceNRMSE, stats.RMSE, stats.MAE, stats.Samples)
+	if stats.Samples != FORECAST_DEFAULT_ERROR_WINDOW {
+		t.Errorf("Expected a full error window of %d, got %d", FORECAST_DEFAULT_ERROR_WINDOW, stats.Samples)
+	}
+	if stats.NRMSE >= 0.75*stats.PersistenceNRMSE {
+		t.Errorf("Expected the forecast to clearly beat persistence: NRMSE %.3f vs %.3f", stats.NRMSE, stats.PersistenceNRMSE)
+	}
+}
+
+// TestLatencyEncoder_AndRollingError verifies latency coding, rolling
+// metrics and configuration checks.
+func TestLatencyEncoder_AndRollingError(t *testing.T) {
+	encoder := NewLatencyEncoder(0, 1, 11, 10*time.Millisecond)
+	spikes := encoder.Encode(0.3)
+	if len(spikes) == 0 || spikes[0].Channel != 3 || spikes[0].Latency != 0 {
+		t.Fatalf("Expected channel 3 to fire first at latency 0, got %+v", spikes)
+	}
+	for i := 1; i < len(spikes); i++ {
+		if spikes[i].Latency < spikes[i-1].Latency {
+			t.Errorf("Expected spikes ordered by latency, got %+v", spikes)
+		}
+		if spikes[i].Latency > encoder.MaxLatency {
+			t.Errorf("Expected latencies within %v, got %v", encoder.MaxLatency, spikes[i].Latency)
+		}
+	}
+	if clamped := encoder.Encode(5); clamped[0].Channel != 10 {
+		t.Errorf("Expected values above the range to clamp to the last channel, got %+v", clamped)
+	}
+
+	rolling := NewRollingError(2)
+	rolling.Add(1, 0) // dropped from the window below
+	rolling.Add(2, 1)
+	rolling.Add(5, 3)
+	if rolling.Count() != 2 || rolling.Total() != 3 {
+		t.Errorf("Expected 2 samples in the window of 3 recorded, got %d of %d", rolling.Count(), rolling.Total())
+	}
+	if math.Abs(rolling.MAE()-1.5) > 1e-9 || math.Abs(rolling.RMSE()-math.Sqrt(2.5)) > 1e-9 {
+		t.Errorf("Expected MAE 1.5 and RMSE %.4f, got %.4f and %.4f", math.Sqrt(2.5), rolling.MAE(), rolling.RMSE())
+	}
+	if math.Abs(rolling.NRMSE()-math.Sqrt(2.5)) > 1e-9 { // targets 1 and 3 have standard deviation 1
+		t.Errorf("Expected NRMSE %.4f, got %.4f", math.Sqrt(2.5), rolling.NRMSE())
+	}
+
+	invalid := []Config{
+		{},
+		{Encoder: NewLatencyEncoder(1, 0, 10, time.Millisecond)},
+		{Encoder: encoder, InputDensity: 2},
+		{Encoder: encoder, Forgetting: 1.5},
+		{Encoder: NewLatencyEncoder(0, 1, 10, 50*time.Millisecond)}, // longer than the sample period
+	}
+	for _, config := range invalid {
+		if _, err := NewModel(config); err == nil {
+			t.Errorf("Expected config %+v to be rejected", config)
+		}
+	}
+}
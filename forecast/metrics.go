@@ -0,0 +1,98 @@
+package forecast
+
+import "math"
+
+// =================================================================================
+// ROLLING ERROR METRICS
+// =================================================================================
+
+// RollingError keeps forecast errors over the last Window samples.
+type RollingError struct {
+	window    int
+	errors    []float64 // Ring of prediction errors
+	actuals   []float64 // Ring of observed values
+	next      int
+	count     int
+	total     int
+	sumSq     float64
+	sumAbs    float64
+	sumActual float64
+	sumActSq  float64
+}
+
+// NewRollingError creates a tracker over the last window samples.
+func NewRollingError(window int) *RollingError {
+	if window <= 0 {
+		window = 1
+	}
+	return &RollingError{
+		window:  window,
+		errors:  make([]float64, window),
+		actuals: make([]float64, window),
+	}
+}
+
+// Add records a forecast and the value that was observed.
+func (r *RollingError) Add(predicted, actual float64) {
+	err := predicted - actual
+	if r.count == r.window {
+		old, oldActual := r.errors[r.next], r.actuals[r.next]
+		r.sumSq -= old * old
+		r.sumAbs -= math.Abs(old)
+		r.sumActual -= oldActual
+		r.sumActSq -= oldActual * oldActual
+	} else {
+		r.count++
+	}
+	r.errors[r.next], r.actuals[r.next] = err, actual
+	r.next = (r.next + 1) % r.window
+	r.total++
+	r.sumSq += err * err
+	r.sumAbs += math.Abs(err)
+	r.sumActual += actual
+	r.sumActSq += actual * actual
+}
+
+// Count returns the number of samples in the window.
+func (r *RollingError) Count() int {
+	return r.count
+}
+
+// Total returns the number of samples recorded since creation.
+func (r *RollingError) Total() int {
+	return r.total
+}
+
+// RMSE returns the root-mean-square error over the window.
+func (r *RollingError) RMSE() float64 {
+	if r.count == 0 {
+		return 0
+	}
+	return math.Sqrt(math.Max(0, r.sumSq) / float64(r.count))
+}
+
+// MAE returns the mean absolute error over the window.
+func (r *RollingError) MAE() float64 {
+	if r.count == 0 {
+		return 0
+	}
+	return math.Max(0, r.sumAbs) / float64(r.count)
+}
+
+// NRMSE returns the RMSE divided by the standard deviation of the observed
+// values in the window: 1 is no better than predicting the window mean. A
+// constant window returns +Inf unless the error is zero.
+func (r *RollingError) NRMSE() float64 {
+	if r.count == 0 {
+		return 0
+	}
+	mean := r.sumActual / float64(r.count)
+	variance := r.sumActSq/float64(r.count) - mean*mean
+	if variance <= 0 {
+		if r.RMSE() == 0 {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return r.RMSE() / math.Sqrt(variance)
+}
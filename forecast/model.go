@@ -0,0 +1,398 @@
+package forecast
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// Model defaults, chosen for signals sampled every SamplePeriod whose
+// relevant history spans a few samples.
+const (
+	FORECAST_DEFAULT_SIZE               = 100
+	FORECAST_DEFAULT_SAMPLE_PERIOD      = 20 * time.Millisecond
+	FORECAST_DEFAULT_RESOLUTION         = 1 * time.Millisecond
+	FORECAST_DEFAULT_INPUT_WEIGHT       = 0.6
+	FORECAST_DEFAULT_INPUT_DENSITY      = 0.3
+	FORECAST_DEFAULT_RECURRENT_WEIGHT   = 0.15
+	FORECAST_DEFAULT_RECURRENT_DENSITY  = 0.1
+	FORECAST_DEFAULT_INHIBITORY         = 0.2
+	FORECAST_DEFAULT_MAX_DELAY          = 30 * time.Millisecond
+	FORECAST_DEFAULT_THRESHOLD          = 1.0
+	FORECAST_DEFAULT_DECAY_RATE         = 0.9
+	FORECAST_DEFAULT_REFRACTORY         = 2 * time.Millisecond
+	FORECAST_DEFAULT_FORGETTING         = 0.9995
+	FORECAST_DEFAULT_REGULARIZATION     = 1.0
+	FORECAST_DEFAULT_ERROR_WINDOW       = 200
+	FORECAST_TRACE_PERIODS              = 2 // Trace time constant in sample periods (default)
+	forecastSpikeCallbackID             = "forecast_trace"
+	forecastInputSourceID               = "forecast_encoder"
+	forecastMinimumRecurrentDelayFactor = 1 // Minimum recurrent delay in resolution ticks
+)
+
+// =================================================================================
+// MODEL
+// =================================================================================
+//
+// Step feeds one sample: the encoder's spikes are delivered to the layer at
+// their latencies, the virtual clock advances by SamplePeriod, and the
+// filtered spike trains at the end of the period become the feature vector.
+// The readout is first trained on the features from Horizon samples ago,
+// whose target has just arrived, and then forecasts Horizon samples ahead
+// from the current features. The recurrent synapses are static; only the
+// readout learns.
+
+// Config configures a Model. Zero values select the defaults.
+type Config struct {
+	Encoder *LatencyEncoder // Input coding (required)
+	Horizon int             // Samples ahead to forecast (0 = 1)
+
+	// Recurrent layer
+	Size             int           // Neurons in the layer
+	SamplePeriod     time.Duration // Virtual time per sample
+	Resolution       time.Duration // Virtual clock tick
+	InputWeight      float64       // Mean weight of encoder → layer connections
+	InputDensity     float64       // Probability that a channel drives a neuron
+	RecurrentWeight  float64       // Mean magnitude of recurrent weights
+	RecurrentDensity float64       // Probability of a recurrent connection
+	Inhibitory       float64       // Fraction of neurons whose spikes inhibit their targets
+	MaxDelay         time.Duration // Recurrent delays are uniform in [Resolution, MaxDelay]
+	TraceTime        time.Duration // Time constant of the filtered spike trains (0 = 2 sample periods)
+
+	// Readout
+	Forgetting     float64 // RLS forgetting factor in (0, 1]
+	Regularization float64 // Initial RLS regularization (P = I / Regularization)
+	ErrorWindow    int     // Samples in the rolling error metrics
+
+	Seed int64 // Seed for the random wiring
+}
+
+// Prediction is the forecast made after a sample.
+type Prediction struct {
+	Sample int     // Index of the sample just fed
+	Target int     // Index of the sample being forecast (Sample + Horizon)
+	Value  float64 // Forecast value
+}
+
+// ErrorStats summarizes the rolling errors of the model and of a
+// persistence forecast over the same samples.
+type ErrorStats struct {
+	Samples          int     // Forecasts scored in the window
+	RMSE             float64 // Model root-mean-square error
+	MAE              float64 // Model mean absolute error
+	NRMSE            float64 // Model RMSE / standard deviation of the targets
+	PersistenceRMSE  float64 // RMSE of predicting the last observed value
+	PersistenceNRMSE float64 // NRMSE of predicting the last observed value
+}
+
+// Model is a recurrent layer with an online readout.
+type Model struct {
+	config  Config
+	runner  *cosim.LockStep
+	layer   *batch.Population
+	epoch   time.Time
+	inputs  [][]inputConnection // [channel] connections to layer neurons
+	synapse []*synapse.BasicSynapse
+
+	traces  []float64   // Filtered spike train per neuron
+	updated []time.Time // Last trace update per neuron
+	spikes  int64
+
+	readout  *rlsReadout
+	pending  [][]float64 // Feature vectors awaiting their target (oldest first)
+	forecast []float64   // Forecasts awaiting their target (oldest first)
+	samples  []float64   // Last Horizon samples, for the persistence baseline
+	step     int
+
+	modelErrors       *RollingError
+	persistenceErrors *RollingError
+}
+
+// inputConnection is an encoder channel's connection to one layer neuron.
+type inputConnection struct {
+	neuron int
+	weight float64
+}
+
+// NewModel builds the layer and readout.
+func NewModel(config Config) (*Model, error) {
+	if config.Encoder == nil {
+		return nil, fmt.Errorf("forecast model needs an encoder")
+	}
+	if err := config.Encoder.Validate(); err != nil {
+		return nil, err
+	}
+	if err := applyDefaults(&config); err != nil {
+		return nil, err
+	}
+
+	epoch := time.Unix(0, 0)
+	runner, err := cosim.NewLockStep(epoch, config.Resolution)
+	if err != nil {
+		return nil, err
+	}
+	layer, err := batch.NewPopulation("forecast_layer", batch.PopulationConfig{
+		Size:             config.Size,
+		Threshold:        FORECAST_DEFAULT_THRESHOLD,
+		DecayRate:        FORECAST_DEFAULT_DECAY_RATE,
+		RefractoryPeriod: FORECAST_DEFAULT_REFRACTORY,
+		FireFactor:       1.0,
+		DelayScheduler:   runner.Schedule,
+	})
+	if err != nil {
+		return nil, err
+	}
+	runner.AddPopulation(layer)
+
+	features := config.Size + config.Encoder.Channels + 1
+	m := &Model{
+		config:            config,
+		runner:            runner,
+		layer:             layer,
+		epoch:             epoch,
+		inputs:            make([][]inputConnection, config.Encoder.Channels),
+		traces:            make([]float64, config.Size),
+		updated:           make([]time.Time, config.Size),
+		readout:           newRLSReadout(features, config.Forgetting, config.Regularization),
+		modelErrors:       NewRollingError(config.ErrorWindow),
+		persistenceErrors: NewRollingError(config.ErrorWindow),
+	}
+	if err := m.wire(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// applyDefaults fills zero settings and validates the rest.
+func applyDefaults(c *Config) error {
+	if c.Horizon < 0 || c.Size < 0 || c.SamplePeriod < 0 || c.Resolution < 0 || c.MaxDelay < 0 ||
+		c.TraceTime < 0 || c.InputWeight < 0 || c.RecurrentWeight < 0 || c.ErrorWindow < 0 || c.Regularization < 0 {
+		return fmt.Errorf("forecast model settings cannot be negative")
+	}
+	for name, p := range map[string]float64{
+		"input density": c.InputDensity, "recurrent density": c.RecurrentDensity, "inhibitory fraction": c.Inhibitory,
+	} {
+		if p < 0 || p > 1 {
+			return fmt.Errorf("forecast model %s must be in [0, 1]: %f", name, p)
+		}
+	}
+	if c.Forgetting < 0 || c.Forgetting > 1 {
+		return fmt.Errorf("forecast model forgetting factor must be in (0, 1]: %f", c.Forgetting)
+	}
+
+	if c.Horizon == 0 {
+		c.Horizon = 1
+	}
+	if c.Size == 0 {
+		c.Size = FORECAST_DEFAULT_SIZE
+	}
+	if c.SamplePeriod == 0 {
+		c.SamplePeriod = FORECAST_DEFAULT_SAMPLE_PERIOD
+	}
+	if c.Resolution == 0 {
+		c.Resolution = FORECAST_DEFAULT_RESOLUTION
+	}
+	if c.InputWeight == 0 {
+		c.InputWeight = FORECAST_DEFAULT_INPUT_WEIGHT
+	}
+	if c.InputDensity == 0 {
+		c.InputDensity = FORECAST_DEFAULT_INPUT_DENSITY
+	}
+	if c.RecurrentWeight == 0 {
+		c.RecurrentWeight = FORECAST_DEFAULT_RECURRENT_WEIGHT
+	}
+	if c.RecurrentDensity == 0 {
+		c.RecurrentDensity = FORECAST_DEFAULT_RECURRENT_DENSITY
+	}
+	if c.Inhibitory == 0 {
+		c.Inhibitory = FORECAST_DEFAULT_INHIBITORY
+	}
+	if c.MaxDelay == 0 {
+		c.MaxDelay = FORECAST_DEFAULT_MAX_DELAY
+	}
+	if c.TraceTime == 0 {
+		c.TraceTime = FORECAST_TRACE_PERIODS * c.SamplePeriod
+	}
+	if c.Forgetting == 0 {
+		c.Forgetting = FORECAST_DEFAULT_FORGETTING
+	}
+	if c.Regularization == 0 {
+		c.Regularization = FORECAST_DEFAULT_REGULARIZATION
+	}
+	if c.ErrorWindow == 0 {
+		c.ErrorWindow = FORECAST_DEFAULT_ERROR_WINDOW
+	}
+	if c.Encoder.MaxLatency >= c.SamplePeriod {
+		return fmt.Errorf("encoder max latency %v must be shorter than the sample period %v", c.Encoder.MaxLatency, c.SamplePeriod)
+	}
+	if c.MaxDelay < c.Resolution {
+		return fmt.Errorf("max delay %v is shorter than the resolution %v", c.MaxDelay, c.Resolution)
+	}
+	return nil
+}
+
+// wire creates the random input and recurrent connections and the spike
+// trace callbacks.
+func (m *Model) wire() error {
+	c := m.config
+	rng := rand.New(rand.NewSource(c.Seed))
+
+	for ch := range m.inputs {
+		for j := 0; j < c.Size; j++ {
+			if rng.Float64() < c.InputDensity {
+				m.inputs[ch] = append(m.inputs[ch], inputConnection{neuron: j, weight: c.InputWeight * (0.5 + rng.Float64())})
+			}
+		}
+	}
+
+	ticks := int64(c.MaxDelay / c.Resolution)
+	for i := 0; i < c.Size; i++ {
+		i := i
+		sign := 1.0
+		if rng.Float64() < c.Inhibitory {
+			sign = -1
+		}
+		var outgoing []*synapse.BasicSynapse
+		for j := 0; j < c.Size; j++ {
+			if i == j || rng.Float64() >= c.RecurrentDensity {
+				continue
+			}
+			delay := time.Duration(forecastMinimumRecurrentDelayFactor+rng.Int63n(ticks)) * c.Resolution
+			weight := c.RecurrentWeight * (0.5 + rng.Float64())
+			syn, err := synapse.NewSynapse(fmt.Sprintf("forecast_%d_%d", i, j), m.layer.Member(i), m.layer.Member(j),
+				synapse.WithWeight(weight), synapse.WithWeightBounds(0, math.Max(weight, 1)),
+				synapse.WithDelay(delay), synapse.WithPlasticityDisabled())
+			if err != nil {
+				return fmt.Errorf("forecast layer: %w", err)
+			}
+			outgoing = append(outgoing, syn)
+		}
+		m.synapse = append(m.synapse, outgoing...)
+		m.layer.AddOutputCallback(i, forecastSpikeCallbackID, types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				m.spiked(i, msg.Timestamp)
+				for _, syn := range outgoing {
+					syn.Transmit(sign * msg.Value)
+				}
+				return nil
+			},
+		})
+	}
+	return nil
+}
+
+// spiked adds a spike to a neuron's filtered spike train.
+func (m *Model) spiked(i int, at time.Time) {
+	m.decayTrace(i, at)
+	m.traces[i]++
+	m.spikes++
+}
+
+// decayTrace decays a neuron's trace to at.
+func (m *Model) decayTrace(i int, at time.Time) {
+	if elapsed := at.Sub(m.updated[i]); elapsed > 0 {
+		m.traces[i] *= math.Exp(-float64(elapsed) / float64(m.config.TraceTime))
+		m.updated[i] = at
+	}
+}
+
+// Step feeds the next sample and returns the forecast Horizon samples ahead.
+func (m *Model) Step(x float64) Prediction {
+	// Score and learn from the forecast whose target has arrived
+	if len(m.pending) == m.config.Horizon {
+		m.modelErrors.Add(m.forecast[0], x)
+		m.persistenceErrors.Add(m.samples[0], x)
+		m.readout.train(m.pending[0], x)
+		m.pending, m.forecast, m.samples = m.pending[1:], m.forecast[1:], m.samples[1:]
+	}
+
+	// Deliver the encoded sample and advance the layer
+	start := m.runner.Now()
+	for _, spike := range m.config.Encoder.Encode(x) {
+		for _, conn := range m.inputs[spike.Channel] {
+			msg := types.NeuralSignal{
+				Value:     conn.weight,
+				Timestamp: start.Add(spike.Latency),
+				SourceID:  forecastInputSourceID,
+				TargetID:  m.layer.Member(conn.neuron).ID(),
+			}
+			if spike.Latency < m.config.Resolution {
+				m.layer.Member(conn.neuron).Receive(msg)
+				continue
+			}
+			m.runner.Schedule(msg, m.layer.Member(conn.neuron), spike.Latency)
+		}
+	}
+	m.runner.Step(m.config.SamplePeriod)
+
+	features := m.features(x)
+	value := m.readout.predict(features)
+	m.pending = append(m.pending, features)
+	m.forecast = append(m.forecast, value)
+	m.samples = append(m.samples, x)
+
+	p := Prediction{Sample: m.step, Target: m.step + m.config.Horizon, Value: value}
+	m.step++
+	return p
+}
+
+// Run feeds a series and returns one forecast per sample.
+func (m *Model) Run(series []float64) []Prediction {
+	predictions := make([]Prediction, len(series))
+	for i, x := range series {
+		predictions[i] = m.Step(x)
+	}
+	return predictions
+}
+
+// features returns the layer traces, the encoder activations for x and a
+// bias.
+func (m *Model) features(x float64) []float64 {
+	now := m.runner.Now()
+	features := make([]float64, 0, len(m.readout.weights))
+	for i := range m.traces {
+		m.decayTrace(i, now)
+		features = append(features, m.traces[i])
+	}
+	features = append(features, m.config.Encoder.Activations(x)...)
+	return append(features, 1)
+}
+
+// Errors returns the rolling error metrics.
+func (m *Model) Errors() ErrorStats {
+	return ErrorStats{
+		Samples:          m.modelErrors.Count(),
+		RMSE:             m.modelErrors.RMSE(),
+		MAE:              m.modelErrors.MAE(),
+		NRMSE:            m.modelErrors.NRMSE(),
+		PersistenceRMSE:  m.persistenceErrors.RMSE(),
+		PersistenceNRMSE: m.persistenceErrors.NRMSE(),
+	}
+}
+
+// Config returns the configuration with defaults applied.
+func (m *Model) Config() Config {
+	return m.config
+}
+
+// Spikes returns the number of layer spikes so far, e.g. to check that the
+// layer is neither silent nor saturated.
+func (m *Model) Spikes() int64 {
+	return m.spikes
+}
+
+// Elapsed returns the virtual time consumed so far.
+func (m *Model) Elapsed() time.Duration {
+	return m.runner.Now().Sub(m.epoch)
+}
+
+// Synapses returns the recurrent synapses.
+func (m *Model) Synapses() []*synapse.BasicSynapse {
+	return append([]*synapse.BasicSynapse(nil), m.synapse...)
+}
@@ -0,0 +1,77 @@
+package forecast
+
+// =================================================================================
+// RECURSIVE LEAST SQUARES READOUT
+// =================================================================================
+//
+// The readout is linear in the layer's features. Recursive least squares
+// updates the weights after every sample with the exact least-squares
+// solution over all samples so far, discounted by the forgetting factor λ:
+//
+//	k = P·f / (λ + fᵀ·P·f)
+//	w += k · (target - wᵀ·f)
+//	P = (P - k·fᵀ·P) / λ
+//
+// P starts at I / Regularization. λ < 1 lets the readout track a signal
+// whose statistics drift; λ = 1 weights all history equally.
+
+// rlsReadout is a linear readout trained by recursive least squares.
+type rlsReadout struct {
+	weights    []float64
+	p          [][]float64 // Inverse correlation matrix estimate
+	forgetting float64
+
+	pf []float64 // Scratch: P·f
+}
+
+// newRLSReadout creates a readout for n features.
+func newRLSReadout(n int, forgetting, regularization float64) *rlsReadout {
+	r := &rlsReadout{
+		weights:    make([]float64, n),
+		p:          make([][]float64, n),
+		forgetting: forgetting,
+		pf:         make([]float64, n),
+	}
+	for i := range r.p {
+		r.p[i] = make([]float64, n)
+		r.p[i][i] = 1 / regularization
+	}
+	return r
+}
+
+// predict returns wᵀ·f.
+func (r *rlsReadout) predict(features []float64) float64 {
+	y := 0.0
+	for i, f := range features {
+		y += r.weights[i] * f
+	}
+	return y
+}
+
+// train moves the readout towards target for features.
+func (r *rlsReadout) train(features []float64, target float64) {
+	n := len(r.weights)
+	denominator := r.forgetting
+	for i := 0; i < n; i++ {
+		sum := 0.0
+		for j, f := range features {
+			sum += r.p[i][j] * f
+		}
+		r.pf[i] = sum
+		denominator += features[i] * sum
+	}
+
+	err := target - r.predict(features)
+	for i := 0; i < n; i++ {
+		r.weights[i] += r.pf[i] / denominator * err
+	}
+
+	// P is symmetric, so fᵀ·P = (P·f)ᵀ
+	for i := 0; i < n; i++ {
+		ki := r.pf[i] / denominator
+		row := r.p[i]
+		for j := 0; j < n; j++ {
+			row[j] = (row[j] - ki*r.pf[j]) / r.forgetting
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// TestBTSPIntegration_InducedPlateau verifies that a plateau induced in the
+// post-synaptic neuron potentiates the input that was active just before it
+// and leaves a silent input unchanged.
+func TestBTSPIntegration_InducedPlateau(t *testing.T) {
+	post := neuron.NewNeuron("place_cell", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	active := neuron.NewNeuron("active_input", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	silent := neuron.NewNeuron("silent_input", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	for _, n := range []*neuron.Neuron{post, active, silent} {
+		if err := n.Start(); err != nil {
+			t.Fatalf("Failed to start %s: %v", n.ID(), err)
+		}
+		defer n.Stop()
+	}
+
+	inputs := make(map[string]*synapse.BasicSynapse)
+	for _, pre := range []*neuron.Neuron{active, silent} {
+		syn, err := synapse.NewSynapse(pre.ID()+"_syn", pre, post,
+			synapse.WithWeight(0.5), synapse.WithBTSP(synapse.CreateDefaultBTSPConfig()))
+		if err != nil {
+			t.Fatalf("Failed to create synapse: %v", err)
+		}
+		post.RegisterInputSynapse(syn.ID(), syn)
+		inputs[pre.ID()] = syn
+	}
+
+	var events []neuron.PlateauEvent
+	post.SetPlateauHandler(func(event neuron.PlateauEvent) { events = append(events, event) })
+
+	inputs["active_input"].Transmit(0.1)
+	time.Sleep(200 * time.Millisecond)
+	post.InducePlateau()
+
+	if len(events) != 1 || post.GetPlateauEventCount() != 1 {
+		t.Fatalf("Expected one plateau event, got %d", len(events))
+	}
+	if w := inputs["active_input"].GetWeight(); w <= 0.5 {
+		t.Errorf("Expected the active input to be potentiated, got %f", w)
+	}
+	if w := inputs["silent_input"].GetWeight(); w != 0.5 {
+		t.Errorf("Expected the silent input unchanged, got %f", w)
+	}
+}
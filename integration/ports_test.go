@@ -0,0 +1,47 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// TestPortsIntegration_ModulatorySynapseGatesGain verifies that a synapse
+// targeting a gain port raises the neuron's gain without depolarizing it.
+func TestPortsIntegration_ModulatorySynapseGatesGain(t *testing.T) {
+	pre := neuron.NewNeuron("ports_pre", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	post, err := neuron.NewNeuronWithOptions("ports_post",
+		neuron.WithInputPort(neuron.INPUT_PORT_MODULATORY, neuron.ModulatoryGainPortConfig()))
+	if err != nil {
+		t.Fatalf("Failed to create neuron: %v", err)
+	}
+	for _, n := range []*neuron.Neuron{pre, post} {
+		if err := n.Start(); err != nil {
+			t.Fatalf("Failed to start %s: %v", n.ID(), err)
+		}
+		defer n.Stop()
+	}
+
+	syn, err := synapse.NewSynapse("ports_syn", pre, post, synapse.WithWeight(1.0), synapse.WithDelay(0),
+		synapse.WithTargetPort(neuron.INPUT_PORT_MODULATORY))
+	if err != nil {
+		t.Fatalf("Failed to create synapse: %v", err)
+	}
+	syn.Transmit(1.0)
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, level, _ := post.GetInputPort(neuron.INPUT_PORT_MODULATORY); level > 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the modulatory synapse to raise the port level")
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+	if !post.GetLastFireTime().IsZero() {
+		t.Error("Expected modulatory input not to fire the neuron")
+	}
+}
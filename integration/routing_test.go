@@ -0,0 +1,55 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// TestRoutingIntegration_SynapseStampsHandle verifies that a synapse
+// registered with its post-synaptic neuron carries the assigned route handle
+// and that delivered spikes update the route.
+func TestRoutingIntegration_SynapseStampsHandle(t *testing.T) {
+	pre := neuron.NewNeuron("routing_pre", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	post := neuron.NewNeuron("routing_post", 100.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	for _, n := range []*neuron.Neuron{pre, post} {
+		if err := n.Start(); err != nil {
+			t.Fatalf("Failed to start %s: %v", n.ID(), err)
+		}
+		defer n.Stop()
+	}
+
+	syn, err := synapse.NewSynapse("routing_syn", pre, post, synapse.WithWeight(0.5), synapse.WithDelay(0))
+	if err != nil {
+		t.Fatalf("Failed to create synapse: %v", err)
+	}
+	post.RegisterInputSynapse(syn.ID(), syn)
+
+	handle, ok := post.LookupSynapseHandle(syn.ID())
+	if !ok || uint32(handle) != syn.GetRouteHandle() {
+		t.Fatalf("Expected the synapse to hold its route handle %d, got %d", handle, syn.GetRouteHandle())
+	}
+
+	for i := 0; i < 3; i++ {
+		syn.Transmit(1.0)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		route, _ := post.GetInputRouteByHandle(handle)
+		if route.Spikes == 3 {
+			if route.SourceID != pre.ID() || route.LastSpike.IsZero() {
+				t.Errorf("Expected a route from %s with a spike time, got %+v", pre.ID(), route)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected 3 routed spikes, got %+v", route)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if post.GetInputRouteCount() != 1 {
+		t.Errorf("Expected one route, got %d", post.GetInputRouteCount())
+	}
+}
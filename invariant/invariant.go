@@ -0,0 +1,126 @@
+/*
+=================================================================================
+INVARIANT - RUNTIME ASSERTIONS COMPILED INTO DEBUG BUILDS
+=================================================================================
+
+Some properties must hold at every step of a simulation, and a breach is a
+bug in the code rather than a modelling result: a weight outside its
+plasticity bounds, a spike history that runs backwards in time, a membrane
+accumulator that has become NaN or infinite. Once such a value exists it
+spreads silently through the network, so the useful moment to stop is the
+one where it first appears.
+
+The checks in this package are built with the "debug" build tag:
+
+	go test -tags debug ./...
+
+In a debug build a failed check panics with a *Violation naming the
+invariant, the component, the offending values and the stack. In a release
+build (no tag) every check is an empty function the compiler inlines away
+and Enabled is false, so call sites whose arguments cost something to
+compute guard them with it:
+
+	if invariant.Enabled && len(history) > 0 {
+	    invariant.SpikeOrder(id, history[len(history)-1], at)
+	}
+
+The checks are assertions, not validation. Code that accepts values from
+outside (configuration, user input) still validates and returns errors; the
+checks only state what that code guarantees afterwards.
+=================================================================================
+*/
+
+package invariant
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Name identifies a checked invariant.
+type Name string
+
+const (
+	WeightInRange Name = "weight_in_range" // A synaptic weight lies within [MinWeight, MaxWeight]
+	SpikeOrdered  Name = "spike_ordered"   // Spikes are recorded in non-decreasing time order
+	FiniteValue   Name = "finite_value"    // A state variable is neither NaN nor infinite
+)
+
+// Violation describes a failed check. Debug builds panic with it.
+type Violation struct {
+	Invariant Name               `json:"invariant"`
+	Component string             `json:"component"`
+	Detail    string             `json:"detail"`
+	Values    map[string]float64 `json:"values,omitempty"` // The values the check saw
+	Stack     string             `json:"stack,omitempty"`  // Goroutine stack at the check
+}
+
+// Error formats the violation with its values and stack.
+func (v *Violation) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "invariant %s violated by %s: %s", v.Invariant, v.Component, v.Detail)
+	if len(v.Values) > 0 {
+		keys := make([]string, 0, len(v.Values))
+		for key := range v.Values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		b.WriteString("\n  values:")
+		for _, key := range keys {
+			fmt.Fprintf(&b, " %s=%g", key, v.Values[key])
+		}
+	}
+	if v.Stack != "" {
+		b.WriteString("\n")
+		b.WriteString(v.Stack)
+	}
+	return b.String()
+}
+
+// checkWeight returns a violation if weight is not within [min, max].
+// Inverted bounds are a configuration error that validation reports (see
+// network.Validate); no weight can satisfy them, so they are not checked.
+func checkWeight(component string, weight, min, max float64) *Violation {
+	if (weight >= min && weight <= max) || min > max {
+		return nil
+	}
+	return &Violation{
+		Invariant: WeightInRange,
+		Component: component,
+		Detail:    fmt.Sprintf("weight %g outside [%g, %g]", weight, min, max),
+		Values:    map[string]float64{"weight": weight, "min": min, "max": max},
+	}
+}
+
+// checkSpikeOrder returns a violation if current is before previous.
+func checkSpikeOrder(component string, previous, current time.Time) *Violation {
+	if !current.Before(previous) {
+		return nil
+	}
+	return &Violation{
+		Invariant: SpikeOrdered,
+		Component: component,
+		Detail: fmt.Sprintf("spike at %s recorded after one at %s (%v earlier)",
+			current.Format(time.RFC3339Nano), previous.Format(time.RFC3339Nano), previous.Sub(current)),
+		Values: map[string]float64{
+			"previous_ns": float64(previous.UnixNano()),
+			"current_ns":  float64(current.UnixNano()),
+		},
+	}
+}
+
+// checkFinite returns a violation if value is NaN or infinite.
+func checkFinite(component, quantity string, value float64) *Violation {
+	if !math.IsNaN(value) && !math.IsInf(value, 0) {
+		return nil
+	}
+	return &Violation{
+		Invariant: FiniteValue,
+		Component: component,
+		Detail:    fmt.Sprintf("%s is %g", quantity, value),
+		Values:    map[string]float64{quantity: value},
+	}
+}
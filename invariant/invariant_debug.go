@@ -0,0 +1,36 @@
+//go:build debug
+
+package invariant
+
+import (
+	"runtime/debug"
+	"time"
+)
+
+// Enabled reports that checks run in this build.
+const Enabled = true
+
+// fail panics with v and the current stack, if v is a violation.
+func fail(v *Violation) {
+	if v == nil {
+		return
+	}
+	v.Stack = string(debug.Stack())
+	panic(v)
+}
+
+// Weight asserts that weight lies within [min, max].
+func Weight(component string, weight, min, max float64) {
+	fail(checkWeight(component, weight, min, max))
+}
+
+// SpikeOrder asserts that a spike at current is not earlier than the
+// previous one recorded by the same component.
+func SpikeOrder(component string, previous, current time.Time) {
+	fail(checkSpikeOrder(component, previous, current))
+}
+
+// Finite asserts that the named quantity is neither NaN nor infinite.
+func Finite(component, quantity string, value float64) {
+	fail(checkFinite(component, quantity, value))
+}
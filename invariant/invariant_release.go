@@ -0,0 +1,17 @@
+//go:build !debug
+
+package invariant
+
+import "time"
+
+// Enabled reports that checks are compiled out of this build.
+const Enabled = false
+
+// Weight is a no-op without the debug build tag.
+func Weight(component string, weight, min, max float64) {}
+
+// SpikeOrder is a no-op without the debug build tag.
+func SpikeOrder(component string, previous, current time.Time) {}
+
+// Finite is a no-op without the debug build tag.
+func Finite(component, quantity string, value float64) {}
@@ -0,0 +1,71 @@
+package invariant
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestChecks verifies the predicates behind each assertion and the
+// diagnostics of a violation.
+func TestChecks(t *testing.T) {
+	if v := checkWeight("s1", 0.5, 0, 1); v != nil {
+		t.Errorf("Expected 0.5 within [0, 1], got %v", v)
+	}
+	if v := checkWeight("s1", 0.5, 1, 0); v != nil {
+		t.Errorf("Expected inverted bounds to be left to validation, got %v", v)
+	}
+	for _, weight := range []float64{-0.1, 1.1, math.NaN()} {
+		if v := checkWeight("s1", weight, 0, 1); v == nil || v.Invariant != WeightInRange {
+			t.Errorf("Expected a violation for weight %g, got %v", weight, v)
+		}
+	}
+
+	now := time.Now()
+	if v := checkSpikeOrder("s1", now, now); v != nil {
+		t.Errorf("Expected simultaneous spikes to be ordered, got %v", v)
+	}
+	if v := checkSpikeOrder("s1", now, now.Add(-time.Millisecond)); v == nil || v.Invariant != SpikeOrdered {
+		t.Errorf("Expected an ordering violation, got %v", v)
+	}
+
+	if v := checkFinite("n1", "accumulator", -3); v != nil {
+		t.Errorf("Expected -3 to be finite, got %v", v)
+	}
+	v := checkFinite("n1", "accumulator", math.Inf(1))
+	if v == nil || v.Invariant != FiniteValue {
+		t.Fatalf("Expected a finiteness violation, got %v", v)
+	}
+	v.Stack = "goroutine 1 [running]:"
+	message := v.Error()
+	for _, want := range []string{"finite_value", "n1", "accumulator=+Inf", "goroutine 1"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("Expected %q in %q", want, message)
+		}
+	}
+}
+
+// TestAssertionsFollowBuild verifies that assertions panic with a Violation
+// exactly when the debug build tag is set.
+func TestAssertionsFollowBuild(t *testing.T) {
+	defer func() {
+		recovered := recover()
+		if !Enabled {
+			if recovered != nil {
+				t.Errorf("Expected no panic in a release build, got %v", recovered)
+			}
+			return
+		}
+		var v *Violation
+		if err, ok := recovered.(error); !ok || !errors.As(err, &v) || v.Component != "s1" || v.Stack == "" {
+			t.Errorf("Expected a Violation with a stack, got %v", recovered)
+		}
+	}()
+
+	Weight("s1", 0.5, 0, 1)
+	SpikeOrder("s1", time.Time{}, time.Now())
+	Finite("n1", "accumulator", 0)
+	Weight("s1", 2, 0, 1)
+}
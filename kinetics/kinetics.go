@@ -0,0 +1,208 @@
+/*
+=================================================================================
+KINETICS - GLOBAL TIME-CONSTANT SCALING
+=================================================================================
+
+Every process in a network runs on its own clock: membrane decay, refractory
+periods, STDP windows, transmission delays, pruning timeouts. To run the same
+network in compressed (or stretched) biological time, all of them have to
+change by the same factor, or the relative timing that learning depends on
+breaks. Temperature does exactly this in tissue: reaction rates change by Q10
+for every 10°C (typically 2-3), and the whole network speeds up together.
+
+A Scaling applies one factor to all kinetics:
+
+  - durations are divided by Factor (Factor 10 runs ten times faster),
+  - rates in Hz are multiplied by Factor,
+  - per-tick decay multipliers r become r^Factor, since the decay tick itself
+    is a fixed wall-clock interval.
+
+Short durations suffer from rounding: time.Duration counts nanoseconds, and a
+simulation only resolves multiples of its time step. Duration rounds to the
+nearest multiple of Resolution and reports an error when the result collapses
+to zero or misses the exact value by more than Tolerance, so a 1ms delay does
+not silently become 0 or 2 time steps.
+
+USAGE:
+
+	scaling, _ := kinetics.New(kinetics.Config{Factor: kinetics.Q10Factor(3, 37, 27)})
+	matrix.SetKinetics(scaling) // applied to every neuron and synapse created later
+	...
+	cfg, err := scaling.Neuron(neuronConfig) // or scale configs by hand
+=================================================================================
+*/
+
+package kinetics
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+const (
+	// KINETICS_DEFAULT_RESOLUTION is the rounding grid when Config.Resolution
+	// is zero: the resolution of time.Duration itself.
+	KINETICS_DEFAULT_RESOLUTION = time.Nanosecond
+
+	// KINETICS_DEFAULT_TOLERANCE is the largest relative rounding error
+	// accepted when Config.Tolerance is zero.
+	KINETICS_DEFAULT_TOLERANCE = 0.01
+)
+
+// Config configures a Scaling.
+type Config struct {
+	Factor     float64       // Speed-up of all kinetics (>1 = faster, <1 = slower)
+	Resolution time.Duration // Scaled durations are rounded to multiples of this (0 = 1ns)
+	Tolerance  float64       // Largest accepted relative rounding error (0 = KINETICS_DEFAULT_TOLERANCE)
+}
+
+// Scaling converts biological time constants to scaled ones. It is
+// immutable and safe for concurrent use.
+type Scaling struct {
+	config Config
+}
+
+// New creates a Scaling.
+func New(config Config) (*Scaling, error) {
+	if math.IsNaN(config.Factor) || math.IsInf(config.Factor, 0) || config.Factor <= 0 {
+		return nil, fmt.Errorf("kinetics factor must be positive and finite: %f", config.Factor)
+	}
+	if config.Resolution < 0 {
+		return nil, fmt.Errorf("kinetics resolution cannot be negative: %v", config.Resolution)
+	}
+	if math.IsNaN(config.Tolerance) || config.Tolerance < 0 {
+		return nil, fmt.Errorf("kinetics tolerance cannot be negative: %f", config.Tolerance)
+	}
+	if config.Resolution == 0 {
+		config.Resolution = KINETICS_DEFAULT_RESOLUTION
+	}
+	if config.Tolerance == 0 {
+		config.Tolerance = KINETICS_DEFAULT_TOLERANCE
+	}
+	return &Scaling{config: config}, nil
+}
+
+// Q10Factor returns the speed-up of a process with temperature coefficient
+// q10 at temperature relative to reference (both in °C).
+func Q10Factor(q10, temperature, reference float64) float64 {
+	return math.Pow(q10, (temperature-reference)/10)
+}
+
+// Config returns the configuration with defaults applied.
+func (s *Scaling) Config() Config {
+	return s.config
+}
+
+// Factor returns the speed-up factor.
+func (s *Scaling) Factor() float64 {
+	return s.config.Factor
+}
+
+// Scale divides d by the factor and rounds to the resolution without any
+// checks. Use it on hot paths where occasional rounding does not matter.
+func (s *Scaling) Scale(d time.Duration) time.Duration {
+	scaled, _ := s.round(d)
+	return scaled
+}
+
+// Duration divides d by the factor and rounds to the resolution. It returns
+// an error when a non-zero duration rounds to zero, deviates from the exact
+// value by more than the tolerance, or overflows.
+func (s *Scaling) Duration(d time.Duration) (time.Duration, error) {
+	scaled, exact := s.round(d)
+	if d == 0 {
+		return 0, nil
+	}
+	if math.Abs(exact) >= math.MaxInt64 {
+		return 0, fmt.Errorf("scaled duration of %v overflows", d)
+	}
+	if scaled == 0 {
+		return 0, fmt.Errorf("%v scaled by %g rounds to zero at resolution %v", d, s.config.Factor, s.config.Resolution)
+	}
+	if deviation := math.Abs(float64(scaled)-exact) / math.Abs(exact); deviation > s.config.Tolerance {
+		return 0, fmt.Errorf("%v scaled by %g rounds to %v, %.1f%% off the exact %v",
+			d, s.config.Factor, scaled, 100*deviation, time.Duration(exact))
+	}
+	return scaled, nil
+}
+
+// round returns d scaled and rounded to the resolution, and the exact value
+// in nanoseconds.
+func (s *Scaling) round(d time.Duration) (time.Duration, float64) {
+	exact := float64(d) / s.config.Factor
+	resolution := float64(s.config.Resolution)
+	rounded := math.Round(exact/resolution) * resolution
+	if math.Abs(rounded) >= math.MaxInt64 {
+		return time.Duration(math.Copysign(math.MaxInt64, rounded)), exact
+	}
+	return time.Duration(rounded), exact
+}
+
+// Rate scales a rate in Hz.
+func (s *Scaling) Rate(hz float64) float64 {
+	return hz * s.config.Factor
+}
+
+// DecayRate scales a per-tick decay multiplier in (0, 1]. A fixed tick
+// then covers Factor times as much biological time.
+func (s *Scaling) DecayRate(perTick float64) float64 {
+	return math.Pow(perTick, s.config.Factor)
+}
+
+// Neuron returns config with its refractory period, decay rate and target
+// firing rate scaled.
+func (s *Scaling) Neuron(config types.NeuronConfig) (types.NeuronConfig, error) {
+	refractory, err := s.Duration(config.RefractoryPeriod)
+	if err != nil {
+		return config, fmt.Errorf("refractory period: %w", err)
+	}
+	config.RefractoryPeriod = refractory
+	config.DecayRate = s.DecayRate(config.DecayRate)
+	config.TargetFiringRate = s.Rate(config.TargetFiringRate)
+	return config, nil
+}
+
+// Synapse returns config with its delay, STDP window and pruning
+// inactivity threshold scaled.
+func (s *Scaling) Synapse(config types.SynapseConfig) (types.SynapseConfig, error) {
+	delay, err := s.Duration(config.Delay)
+	if err != nil {
+		return config, fmt.Errorf("delay: %w", err)
+	}
+	config.Delay = delay
+	if config.PlasticityConfig, err = s.Plasticity(config.PlasticityConfig); err != nil {
+		return config, err
+	}
+	if config.PruningConfig, err = s.Pruning(config.PruningConfig); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// Plasticity returns config with the STDP time constant and window scaled.
+// The learning rate is per spike pair and stays unchanged.
+func (s *Scaling) Plasticity(config types.PlasticityConfig) (types.PlasticityConfig, error) {
+	tau, err := s.Duration(config.TimeConstant)
+	if err != nil {
+		return config, fmt.Errorf("STDP time constant: %w", err)
+	}
+	window, err := s.Duration(config.WindowSize)
+	if err != nil {
+		return config, fmt.Errorf("STDP window: %w", err)
+	}
+	config.TimeConstant, config.WindowSize = tau, window
+	return config, nil
+}
+
+// Pruning returns config with the inactivity threshold scaled.
+func (s *Scaling) Pruning(config types.PruningConfig) (types.PruningConfig, error) {
+	inactivity, err := s.Duration(config.InactivityThreshold)
+	if err != nil {
+		return config, fmt.Errorf("pruning inactivity threshold: %w", err)
+	}
+	config.InactivityThreshold = inactivity
+	return config, nil
+}
@@ -0,0 +1,100 @@
+package kinetics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestScalingPreservesRelativeTiming verifies that durations, rates and
+// decay multipliers scale consistently, so a decay over a scaled interval
+// equals the unscaled decay over the original interval.
+func TestScalingPreservesRelativeTiming(t *testing.T) {
+	factor := Q10Factor(3, 37, 27)
+	if factor != 3 {
+		t.Fatalf("Expected Q10 factor 3 for +10°C, got %f", factor)
+	}
+	scaling, err := New(Config{Factor: factor})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A 10ms time constant decays by 0.99 per 0.1ms tick; in compressed
+	// time the same tick covers three times as much biological time.
+	decay := scaling.DecayRate(0.99)
+	if math.Abs(math.Pow(decay, 10)-math.Pow(0.99, 30)) > 1e-12 {
+		t.Errorf("Expected consistent decay, got %f", decay)
+	}
+	if scaling.Rate(5) != 15 {
+		t.Errorf("Expected 15Hz, got %f", scaling.Rate(5))
+	}
+
+	config, err := scaling.Synapse(types.SynapseConfig{
+		Delay:            3 * time.Millisecond,
+		PlasticityConfig: types.PlasticityConfig{TimeConstant: 30 * time.Millisecond, WindowSize: 90 * time.Millisecond, LearningRate: 0.01},
+		PruningConfig:    types.PruningConfig{InactivityThreshold: 3 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.Delay != time.Millisecond || config.PlasticityConfig.TimeConstant != 10*time.Millisecond ||
+		config.PlasticityConfig.WindowSize != 30*time.Millisecond || config.PruningConfig.InactivityThreshold != time.Second {
+		t.Errorf("Expected durations divided by 3, got %+v", config)
+	}
+	if config.PlasticityConfig.LearningRate != 0.01 {
+		t.Errorf("Expected unchanged learning rate, got %f", config.PlasticityConfig.LearningRate)
+	}
+
+	slow, _ := New(Config{Factor: 0.5})
+	if d, err := slow.Duration(2 * time.Millisecond); err != nil || d != 4*time.Millisecond {
+		t.Errorf("Expected 4ms when slowed down, got %v (%v)", d, err)
+	}
+}
+
+// TestScalingRoundingChecks verifies that durations lost to rounding are
+// rejected while the unchecked Scale still rounds to the grid.
+func TestScalingRoundingChecks(t *testing.T) {
+	scaling, _ := New(Config{Factor: 3, Resolution: 100 * time.Microsecond})
+
+	if d, err := scaling.Duration(3 * time.Millisecond); err != nil || d != time.Millisecond {
+		t.Errorf("Expected exact 1ms, got %v (%v)", d, err)
+	}
+	// 2ms / 3 = 666.7μs rounds to 700μs: 5% off
+	if _, err := scaling.Duration(2 * time.Millisecond); err == nil {
+		t.Error("Expected rounding error above tolerance")
+	}
+	if scaling.Scale(2*time.Millisecond) != 700*time.Microsecond {
+		t.Errorf("Expected unchecked 700μs, got %v", scaling.Scale(2*time.Millisecond))
+	}
+	// 100μs / 3 rounds to zero
+	if _, err := scaling.Duration(100 * time.Microsecond); err == nil {
+		t.Error("Expected error for a duration rounding to zero")
+	}
+	if d, err := scaling.Duration(0); err != nil || d != 0 {
+		t.Errorf("Expected zero to stay zero, got %v (%v)", d, err)
+	}
+	loose, _ := New(Config{Factor: 3, Resolution: 100 * time.Microsecond, Tolerance: 0.1})
+	if _, err := loose.Duration(2 * time.Millisecond); err != nil {
+		t.Errorf("Expected 5%% rounding within 10%% tolerance: %v", err)
+	}
+
+	tiny, _ := New(Config{Factor: 1e12})
+	if _, err := tiny.Duration(time.Millisecond); err == nil {
+		t.Error("Expected error for a duration below 1ns")
+	}
+	if _, err := New(Config{Factor: 1e-12}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	huge, _ := New(Config{Factor: 1e-12})
+	if _, err := huge.Duration(time.Hour); err == nil {
+		t.Error("Expected overflow error")
+	}
+
+	for _, config := range []Config{{}, {Factor: -1}, {Factor: math.NaN()}, {Factor: 1, Resolution: -1}, {Factor: 1, Tolerance: -0.1}} {
+		if _, err := New(config); err == nil {
+			t.Errorf("Expected error for %+v", config)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+/*
+=================================================================================
+LOGGING - STRUCTURED RECORDS FROM NEURONS AND SYNAPSES
+=================================================================================
+
+Neurons and synapses emit structured log records through a standard
+log/slog handler instead of printing to stdout. Each component binds its
+identity to its logger, so every record carries the IDs needed to filter a
+large network down to the cells under study:
+
+	handler := slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: logging.LevelTrace})
+	n.SetLogHandler(logging.FilterComponents(handler, "pyr_1", "pyr_2"))
+
+Record kinds and their levels:
+
+	integration  LevelTrace   every input added to the membrane accumulator
+	transmission LevelTrace   every spike a synapse passes on
+	spike        Debug        every action potential
+	plasticity   Debug        STDP pairings and weight changes
+	diagnostic   Debug/Warn   internal debugging and recoverable problems
+	pathology    Warn/Info    network watchdog detections and recoveries
+
+Verbosity is the handler's level: Info shows only problems, Debug adds spikes
+and plasticity, LevelTrace adds per-input integration and transmission.
+=================================================================================
+*/
+
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// LevelTrace is below slog.LevelDebug and enables per-input records.
+const LevelTrace = slog.LevelDebug - 4
+
+// Attribute keys bound to component loggers.
+const (
+	KeyNeuronID     = "neuron_id"
+	KeySynapseID    = "synapse_id"
+	KeyPreNeuronID  = "pre_neuron_id"
+	KeyPostNeuronID = "post_neuron_id"
+	KeyRecord       = "record"
+)
+
+// Record kinds, stored under KeyRecord.
+const (
+	RecordSpike        = "spike"
+	RecordIntegration  = "integration"
+	RecordTransmission = "transmission"
+	RecordPlasticity   = "plasticity"
+	RecordDiagnostic   = "diagnostic"
+	RecordPathology    = "pathology"
+)
+
+// ForNeuron returns a logger for a neuron, or nil for a nil handler.
+func ForNeuron(handler slog.Handler, neuronID string) *slog.Logger {
+	if handler == nil {
+		return nil
+	}
+	return slog.New(handler).With(KeyNeuronID, neuronID)
+}
+
+// ForSynapse returns a logger for a synapse, or nil for a nil handler. Both
+// endpoint IDs are bound, so neuron filters also match connected synapses.
+func ForSynapse(handler slog.Handler, synapseID, preID, postID string) *slog.Logger {
+	if handler == nil {
+		return nil
+	}
+	return slog.New(handler).With(KeySynapseID, synapseID, KeyPreNeuronID, preID, KeyPostNeuronID, postID)
+}
+
+// Enabled reports whether logger is set and accepts level. Callers check it
+// before building attributes on hot paths.
+func Enabled(logger *slog.Logger, level slog.Level) bool {
+	return logger != nil && logger.Enabled(context.Background(), level)
+}
+
+// Log emits a record of the given kind if logger accepts level.
+func Log(logger *slog.Logger, level slog.Level, kind, msg string, args ...any) {
+	if !Enabled(logger, level) {
+		return
+	}
+	logger.Log(context.Background(), level, msg, append([]any{KeyRecord, kind}, args...)...)
+}
+
+// =================================================================================
+// FILTERING
+// =================================================================================
+
+// componentKeys are the attributes FilterComponents matches against.
+var componentKeys = map[string]bool{
+	KeyNeuronID:     true,
+	KeySynapseID:    true,
+	KeyPreNeuronID:  true,
+	KeyPostNeuronID: true,
+}
+
+// componentFilter passes only records that mention a selected component.
+type componentFilter struct {
+	next    slog.Handler
+	ids     map[string]bool
+	matched bool // A bound attribute already matched
+}
+
+// FilterComponents wraps handler so only records of the given neuron or
+// synapse IDs pass. A neuron ID also selects the synapses attached to it.
+func FilterComponents(handler slog.Handler, ids ...string) slog.Handler {
+	selected := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		selected[id] = true
+	}
+	return &componentFilter{next: handler, ids: selected}
+}
+
+func (f *componentFilter) Enabled(ctx context.Context, level slog.Level) bool {
+	return f.next.Enabled(ctx, level)
+}
+
+func (f *componentFilter) Handle(ctx context.Context, record slog.Record) error {
+	matched := f.matched
+	if !matched {
+		record.Attrs(func(attr slog.Attr) bool {
+			matched = f.matches(attr)
+			return !matched
+		})
+	}
+	if !matched {
+		return nil
+	}
+	return f.next.Handle(ctx, record)
+}
+
+func (f *componentFilter) WithAttrs(attrs []slog.Attr) slog.Handler {
+	matched := f.matched
+	for _, attr := range attrs {
+		matched = matched || f.matches(attr)
+	}
+	return &componentFilter{next: f.next.WithAttrs(attrs), ids: f.ids, matched: matched}
+}
+
+func (f *componentFilter) WithGroup(name string) slog.Handler {
+	return &componentFilter{next: f.next.WithGroup(name), ids: f.ids, matched: f.matched}
+}
+
+// matches reports whether attr names a selected component.
+func (f *componentFilter) matches(attr slog.Attr) bool {
+	return componentKeys[attr.Key] && f.ids[attr.Value.String()]
+}
+
+// recordFilter passes only records of selected kinds.
+type recordFilter struct {
+	next  slog.Handler
+	kinds map[string]bool
+}
+
+// FilterRecords wraps handler so only the given record kinds pass, e.g.
+// FilterRecords(h, RecordSpike) for a spike raster log. Records without a
+// kind are always passed.
+func FilterRecords(handler slog.Handler, kinds ...string) slog.Handler {
+	selected := make(map[string]bool, len(kinds))
+	for _, kind := range kinds {
+		selected[kind] = true
+	}
+	return &recordFilter{next: handler, kinds: selected}
+}
+
+func (f *recordFilter) Enabled(ctx context.Context, level slog.Level) bool {
+	return f.next.Enabled(ctx, level)
+}
+
+func (f *recordFilter) Handle(ctx context.Context, record slog.Record) error {
+	pass := true
+	record.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == KeyRecord {
+			pass = f.kinds[attr.Value.String()]
+			return false
+		}
+		return true
+	})
+	if !pass {
+		return nil
+	}
+	return f.next.Handle(ctx, record)
+}
+
+func (f *recordFilter) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &recordFilter{next: f.next.WithAttrs(attrs), kinds: f.kinds}
+}
+
+func (f *recordFilter) WithGroup(name string) slog.Handler {
+	return &recordFilter{next: f.next.WithGroup(name), kinds: f.kinds}
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// decodeRecords parses JSON handler output into one map per record.
+func decodeRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid record %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+// TestFilterComponents verifies records pass for selected neurons, including
+// synapses attached to them, whether IDs are bound or passed per record.
+func TestFilterComponents(t *testing.T) {
+	var buf bytes.Buffer
+	base := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: LevelTrace})
+	handler := FilterComponents(base, "n1")
+
+	Log(ForNeuron(handler, "n1"), slog.LevelDebug, RecordSpike, "spike")
+	Log(ForNeuron(handler, "n2"), slog.LevelDebug, RecordSpike, "spike")
+	Log(ForSynapse(handler, "s12", "n1", "n2"), slog.LevelDebug, RecordPlasticity, "weight updated")
+	Log(ForSynapse(handler, "s23", "n2", "n3"), slog.LevelDebug, RecordPlasticity, "weight updated")
+	slog.New(handler).Info("unbound", KeyNeuronID, "n1")
+
+	records := decodeRecords(t, &buf)
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d: %v", len(records), records)
+	}
+	if records[0][KeyNeuronID] != "n1" || records[0][KeyRecord] != RecordSpike {
+		t.Errorf("Unexpected spike record %v", records[0])
+	}
+	if records[1][KeySynapseID] != "s12" || records[1][KeyPostNeuronID] != "n2" {
+		t.Errorf("Unexpected synapse record %v", records[1])
+	}
+}
+
+// TestVerbosityAndRecordFilter verifies the handler level gates record kinds
+// and FilterRecords selects kinds.
+func TestVerbosityAndRecordFilter(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := ForNeuron(handler, "n1")
+
+	if Enabled(logger, LevelTrace) || !Enabled(logger, slog.LevelDebug) {
+		t.Error("Expected Debug but not Trace to be enabled")
+	}
+	if Enabled(nil, slog.LevelError) {
+		t.Error("Nil logger must be disabled")
+	}
+	Log(logger, LevelTrace, RecordIntegration, "input integrated")
+	Log(nil, slog.LevelError, RecordDiagnostic, "dropped")
+	if buf.Len() != 0 {
+		t.Errorf("Expected no output, got %q", buf.String())
+	}
+
+	logger = ForNeuron(FilterRecords(handler, RecordSpike), "n1")
+	Log(logger, slog.LevelDebug, RecordPlasticity, "weight updated")
+	Log(logger, slog.LevelDebug, RecordSpike, "spike", "output", 1.5)
+	records := decodeRecords(t, &buf)
+	if len(records) != 1 || records[0]["output"] != 1.5 {
+		t.Errorf("Expected only the spike record, got %v", records)
+	}
+	if ForNeuron(nil, "n1") != nil || ForSynapse(nil, "s", "a", "b") != nil {
+		t.Error("Expected nil logger for nil handler")
+	}
+}
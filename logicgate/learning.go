@@ -0,0 +1,377 @@
+package logicgate
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// REWARD-MODULATED STDP WITH A TEACHER
+// =================================================================================
+//
+// Every readout synapse sums an eligibility c over one presentation:
+// a postsynaptic spike adds the presynaptic trace (pre before post), a
+// presynaptic spike subtracts LOGICGATE_LTD_RATIO times the postsynaptic
+// trace (post before pre). Traces decay with STDPTau in virtual time.
+// Modulate(output, m) then changes each synapse onto the output's pool by
+// LearningRate·m·c. Every presentation starts from zero eligibility, so a
+// modulation always refers to the last example shown.
+//
+// The teacher is input injected into the output layer: TeacherGain per
+// tick into the pool of the taught answer and -TeacherGain into the other,
+// so the pairings of a taught presentation all lie on the correct pool.
+
+// trace is an exponentially decaying value.
+type trace struct {
+	value float64
+	at    time.Time
+}
+
+// decayed returns the trace's value at now.
+func (t *trace) decayed(now time.Time, tau time.Duration) float64 {
+	if t.value == 0 {
+		return 0
+	}
+	return t.value * math.Exp(-float64(now.Sub(t.at))/float64(tau))
+}
+
+// add decays the trace to now and adds delta.
+func (t *trace) add(now time.Time, tau time.Duration, delta float64) {
+	t.value = t.decayed(now, tau) + delta
+	t.at = now
+}
+
+// plasticSynapse is one readout synapse and its eligibility.
+type plasticSynapse struct {
+	syn         *synapse.BasicSynapse
+	pre         int // Hidden neuron
+	post        int // Output neuron
+	eligibility float64
+}
+
+// learner implements reward-modulated STDP on the readout synapses.
+type learner struct {
+	network  *Network
+	synapses []*plasticSynapse
+
+	outgoing [][]*plasticSynapse // [hidden neuron]
+	incoming [][]*plasticSynapse // [output neuron]
+	pre      []trace             // [hidden neuron]
+	post     []trace             // [output neuron]
+
+	modulations int64
+}
+
+// newLearner connects every hidden neuron to every output neuron with a
+// random initial weight.
+func newLearner(n *Network) (*learner, error) {
+	cfg := n.config
+	l := &learner{
+		network:  n,
+		outgoing: make([][]*plasticSynapse, n.hidden.Size()),
+		incoming: make([][]*plasticSynapse, n.output.Size()),
+		pre:      make([]trace, n.hidden.Size()),
+		post:     make([]trace, n.output.Size()),
+	}
+	for j := 0; j < n.output.Size(); j++ {
+		for h := 0; h < n.hidden.Size(); h++ {
+			syn, err := synapse.NewSynapse(fmt.Sprintf("logicgate_readout_%d_%d", h, j),
+				n.hidden.Member(h), n.output.Member(j),
+				synapse.WithWeight(n.rng.Float64()*cfg.Weight), synapse.WithWeightBounds(0, cfg.MaxWeight),
+				synapse.WithDelay(cfg.Delay), synapse.WithPlasticityDisabled())
+			if err != nil {
+				return nil, fmt.Errorf("readout synapse: %w", err)
+			}
+			plastic := &plasticSynapse{syn: syn, pre: h, post: j}
+			l.synapses = append(l.synapses, plastic)
+			l.outgoing[h] = append(l.outgoing[h], plastic)
+			l.incoming[j] = append(l.incoming[j], plastic)
+		}
+		j := j
+		n.output.AddOutputCallback(j, logicgateReadoutID, types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				l.postSpike(j, msg.Timestamp)
+				return nil
+			},
+		})
+	}
+	for h := 0; h < n.hidden.Size(); h++ {
+		h := h
+		n.hidden.AddOutputCallback(h, logicgateReadoutID, types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				l.preSpike(h, msg.Timestamp)
+				for _, plastic := range l.outgoing[h] {
+					plastic.syn.Transmit(msg.Value)
+				}
+				return nil
+			},
+		})
+	}
+	return l, nil
+}
+
+// preSpike records a hidden spike: post-before-pre pairings depress.
+func (l *learner) preSpike(h int, now time.Time) {
+	tau := l.network.config.STDPTau
+	l.pre[h].add(now, tau, 1)
+	for _, plastic := range l.outgoing[h] {
+		plastic.eligibility -= LOGICGATE_LTD_RATIO * l.post[plastic.post].decayed(now, tau)
+	}
+}
+
+// postSpike records an output spike: pre-before-post pairings potentiate.
+func (l *learner) postSpike(j int, now time.Time) {
+	tau := l.network.config.STDPTau
+	l.post[j].add(now, tau, 1)
+	for _, plastic := range l.incoming[j] {
+		plastic.eligibility += l.pre[plastic.pre].decayed(now, tau)
+	}
+}
+
+// reset clears eligibility and spike traces before a presentation.
+func (l *learner) reset() {
+	for _, plastic := range l.synapses {
+		plastic.eligibility = 0
+	}
+	for i := range l.pre {
+		l.pre[i] = trace{}
+	}
+	for i := range l.post {
+		l.post[i] = trace{}
+	}
+}
+
+// modulate converts the eligibility of the synapses onto a pool into weight
+// changes and returns the summed absolute change.
+func (l *learner) modulate(pool int, signal float64) float64 {
+	cfg := l.network.config
+	total := 0.0
+	for _, plastic := range l.synapses {
+		if plastic.post/cfg.PoolSize != pool || plastic.eligibility == 0 {
+			continue
+		}
+		before := plastic.syn.GetWeight()
+		plastic.syn.SetWeight(before + cfg.LearningRate*signal*plastic.eligibility)
+		total += math.Abs(plastic.syn.GetWeight() - before)
+	}
+	l.modulations++
+	return total
+}
+
+// synapsesOf returns the synapses onto a pool.
+func (l *learner) synapsesOf(pool int) []*synapse.BasicSynapse {
+	var synapses []*synapse.BasicSynapse
+	for _, plastic := range l.synapses {
+		if plastic.post/l.network.config.PoolSize == pool {
+			synapses = append(synapses, plastic.syn)
+		}
+	}
+	return synapses
+}
+
+// =================================================================================
+// EXAMPLES, PRESENTATION AND TRAINING
+// =================================================================================
+
+// Gate is a boolean function of the input bits.
+type Gate func(inputs []bool) bool
+
+// The standard gates, generalized to any number of inputs. XOR is parity.
+var (
+	AND Gate = func(inputs []bool) bool {
+		for _, in := range inputs {
+			if !in {
+				return false
+			}
+		}
+		return true
+	}
+	OR Gate = func(inputs []bool) bool {
+		for _, in := range inputs {
+			if in {
+				return true
+			}
+		}
+		return false
+	}
+	XOR Gate = func(inputs []bool) bool {
+		odd := false
+		for _, in := range inputs {
+			odd = odd != in
+		}
+		return odd
+	}
+	NAND Gate = func(inputs []bool) bool { return !AND(inputs) }
+	NOR  Gate = func(inputs []bool) bool { return !OR(inputs) }
+	XNOR Gate = func(inputs []bool) bool { return !XOR(inputs) }
+)
+
+// Example is one labelled input.
+type Example struct {
+	Inputs []bool `json:"inputs"`
+	Output bool   `json:"output"`
+}
+
+// TruthTable returns gate's examples for every combination of inputs bits,
+// in binary counting order with the first input as the most significant
+// bit.
+func TruthTable(gate Gate, inputs int) []Example {
+	if inputs <= 0 || inputs > LOGICGATE_MAX_INPUTS {
+		return nil
+	}
+	examples := make([]Example, 1<<inputs)
+	for row := range examples {
+		bits := make([]bool, inputs)
+		for i := range bits {
+			bits[i] = row&(1<<(inputs-1-i)) != 0
+		}
+		examples[row] = Example{Inputs: bits, Output: gate(bits)}
+	}
+	return examples
+}
+
+// TrialResult reports one training trial.
+type TrialResult struct {
+	Answered bool    // An output pool fired more than the other
+	Answer   bool    // The network's own answer before any teaching
+	Correct  bool    // Answered with the example's output
+	Taught   bool    // The teacher phase ran
+	Change   float64 // Summed absolute weight change
+}
+
+// present shows inputs for one presentation with the given pool taught
+// (-1 = none), then rests, and returns the output spike counts.
+func (n *Network) present(inputs []bool, teacher int) ([2]int, error) {
+	if len(inputs) != n.config.Inputs {
+		return [2]int{}, fmt.Errorf("expected %d inputs, got %d", n.config.Inputs, len(inputs))
+	}
+	n.active = n.active[:0]
+	for bit, value := range inputs {
+		neuron := 2 * bit
+		if value {
+			neuron++
+		}
+		n.active = append(n.active, neuron)
+	}
+	n.teacher = teacher
+	n.counts = [2]int{}
+	n.learner.reset()
+
+	err := n.runner.Step(n.config.Presentation)
+	n.active = n.active[:0]
+	n.teacher = -1
+	counts := n.counts
+	if err == nil {
+		err = n.runner.Step(n.config.Rest)
+	}
+	return counts, err
+}
+
+// answer decides between the pools; ok is false on a tie.
+func answer(counts [2]int) (output, ok bool) {
+	return counts[1] > counts[0], counts[0] != counts[1]
+}
+
+// Predict shows inputs without teaching or learning and returns the
+// answer; ok is false when neither pool fired more than the other.
+func (n *Network) Predict(inputs []bool) (output, ok bool, err error) {
+	counts, err := n.present(inputs, -1)
+	if err != nil {
+		return false, false, err
+	}
+	output, ok = answer(counts)
+	return output, ok, nil
+}
+
+// Teach shows inputs while the teacher drives the pool answering output.
+// The resulting eligibility is converted by Modulate.
+func (n *Network) Teach(inputs []bool, output bool) error {
+	_, err := n.present(inputs, poolOf(output))
+	return err
+}
+
+// Modulate applies a modulatory signal to the synapses onto the pool
+// answering output, weighting each by its eligibility from the last
+// presentation: positive signals strengthen the pairings, negative ones
+// weaken them. Returns the summed absolute weight change.
+func (n *Network) Modulate(output bool, signal float64) (float64, error) {
+	if math.IsNaN(signal) || math.IsInf(signal, 0) {
+		return 0, fmt.Errorf("modulatory signal must be finite: %f", signal)
+	}
+	return n.learner.modulate(poolOf(output), signal), nil
+}
+
+// Trial trains one example: the network answers, the correct pool's
+// pairings are rewarded and the other pool's punished, and if the answer
+// was wrong the teacher phase shows the example again and rewards the
+// taught pairings.
+func (n *Network) Trial(example Example) (TrialResult, error) {
+	counts, err := n.present(example.Inputs, -1)
+	if err != nil {
+		return TrialResult{}, err
+	}
+	var result TrialResult
+	result.Answer, result.Answered = answer(counts)
+	result.Correct = result.Answered && result.Answer == example.Output
+	result.Change = n.learner.modulate(poolOf(example.Output), 1) + n.learner.modulate(poolOf(!example.Output), -1)
+
+	if !result.Correct {
+		if err := n.Teach(example.Inputs, example.Output); err != nil {
+			return result, err
+		}
+		result.Taught = true
+		result.Change += n.learner.modulate(poolOf(example.Output), 1)
+	}
+	n.trials++
+	return result, nil
+}
+
+// Train runs epochs passes over examples, each in a fresh seeded order,
+// and returns the fraction of trials answered correctly in each epoch.
+func (n *Network) Train(examples []Example, epochs int) ([]float64, error) {
+	if len(examples) == 0 || epochs <= 0 {
+		return nil, fmt.Errorf("training needs examples and epochs: %d examples, %d epochs", len(examples), epochs)
+	}
+	history := make([]float64, 0, epochs)
+	order := make([]int, len(examples))
+	for i := range order {
+		order[i] = i
+	}
+	for epoch := 0; epoch < epochs; epoch++ {
+		n.rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+		correct := 0
+		for _, i := range order {
+			result, err := n.Trial(examples[i])
+			if err != nil {
+				return history, fmt.Errorf("epoch %d: %w", epoch, err)
+			}
+			if result.Correct {
+				correct++
+			}
+		}
+		history = append(history, float64(correct)/float64(len(examples)))
+	}
+	return history, nil
+}
+
+// Accuracy returns the fraction of examples predicted correctly.
+func (n *Network) Accuracy(examples []Example) (float64, error) {
+	if len(examples) == 0 {
+		return 0, fmt.Errorf("accuracy needs examples")
+	}
+	correct := 0
+	for _, example := range examples {
+		output, ok, err := n.Predict(example.Inputs)
+		if err != nil {
+			return 0, err
+		}
+		if ok && output == example.Output {
+			correct++
+		}
+	}
+	return float64(correct) / float64(len(examples)), nil
+}
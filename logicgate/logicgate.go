@@ -0,0 +1,376 @@
+/*
+=================================================================================
+LOGICGATE - LEARNING BOOLEAN FUNCTIONS FROM SPIKE EXAMPLES
+=================================================================================
+
+XOR is the classic test that a learning system can do more than a single
+threshold unit: no weighted sum of the two inputs separates its true cases
+from its false ones. This package learns XOR, AND, OR and the other gates
+from labelled spike examples, with every weight that decides the answer
+set by plasticity rather than by hand:
+
+  - Input: each bit drives one of two input neurons, "0" or "1", so every
+    example activates one neuron per bit.
+  - Hidden: conjunction detectors in the manner of cerebellar granule cells
+    (Marr 1969). Each hidden neuron receives a random pair of input neurons
+    and fires only when both fire, so each one answers to a combination of
+    bit values. These synapses are fixed; pairs are drawn from a seeded
+    shuffle so every pair is represented once Hidden reaches the number of
+    pairs.
+  - Output: a "false" pool and a "true" pool of PoolSize neurons. The pool
+    that fires most is the answer. Every hidden neuron projects to every
+    output neuron through a plastic synapse with a random initial weight.
+
+The readout learns with reward-modulated STDP (Izhikevich 2007) aided by a
+teacher signal. A trial presents an example and lets the network answer;
+pre-before-post pairings leave eligibility traces on the hidden-to-output
+synapses. A modulatory signal then rewards the eligible synapses onto the
+correct pool and punishes those onto the other pool. When the answer was
+wrong, the teacher phase follows: the example is presented again while
+the teacher drives the correct pool and holds the other silent, and the
+resulting pairings are rewarded (Teach, Modulate). Pairwise conjunctions
+make every two-input gate linearly separable for the readout, so training
+converges; with more inputs, functions of single bits and pairs of bits
+can be learned, parity cannot.
+
+All populations are batch.Population instances on one cosim.LockStep
+virtual clock, so runs are reproducible from Seed:
+
+	net, _ := logicgate.New(logicgate.Config{Seed: 1})
+	history, _ := net.Train(logicgate.TruthTable(logicgate.XOR, 2), 20)
+	answer, ok, _ := net.Predict([]bool{true, false}) // true, true
+=================================================================================
+*/
+
+package logicgate
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// Network defaults. Weights are the input one presynaptic spike delivers.
+const (
+	LOGICGATE_DEFAULT_INPUTS        = 2
+	LOGICGATE_DEFAULT_HIDDEN        = 24
+	LOGICGATE_DEFAULT_POOL_SIZE     = 5
+	LOGICGATE_DEFAULT_INPUT_GAIN    = 0.3 // Input per tick to an active input neuron
+	LOGICGATE_DEFAULT_HIDDEN_WEIGHT = 0.6 // Below threshold alone, above it in pairs
+	LOGICGATE_DEFAULT_WEIGHT        = 0.4 // Initial readout weights are uniform in [0, Weight)
+	LOGICGATE_DEFAULT_MAX_WEIGHT    = 1.5
+	LOGICGATE_DEFAULT_LEARNING_RATE = 0.02
+	LOGICGATE_DEFAULT_TEACHER_GAIN  = 0.4 // Input per tick to the taught pool
+	LOGICGATE_DEFAULT_STDP_TAU      = 20 * time.Millisecond
+	LOGICGATE_DEFAULT_DELAY         = 1 * time.Millisecond
+	LOGICGATE_DEFAULT_PRESENTATION  = 50 * time.Millisecond
+	LOGICGATE_DEFAULT_REST          = 50 * time.Millisecond
+
+	LOGICGATE_DEFAULT_THRESHOLD    = 1.0
+	LOGICGATE_DEFAULT_MEMBRANE_TAU = 5 * time.Millisecond
+	LOGICGATE_DEFAULT_REFRACTORY   = 2 * time.Millisecond
+	LOGICGATE_DEFAULT_RESOLUTION   = 1 * time.Millisecond
+
+	// LOGICGATE_LTD_RATIO is the size of depression relative to
+	// potentiation in the eligibility rule.
+	LOGICGATE_LTD_RATIO = 0.5
+
+	// LOGICGATE_MAX_INPUTS bounds the bits of a truth table (2^16 rows).
+	LOGICGATE_MAX_INPUTS = 16
+
+	logicgateStimulusID = "logicgate_input"
+	logicgateHiddenID   = "logicgate_hidden"
+	logicgateReadoutID  = "logicgate_readout"
+	logicgateCounterID  = "logicgate_counter"
+)
+
+// =================================================================================
+// CONFIGURATION
+// =================================================================================
+
+// Config describes a network. Zero values select the defaults.
+type Config struct {
+	Inputs   int // Input bits
+	Hidden   int // Conjunction detectors
+	PoolSize int // Output neurons per answer
+
+	InputGain    float64       // Input per tick to an active input neuron
+	HiddenWeight float64       // Fixed input-to-hidden weight
+	Weight       float64       // Upper bound of the random initial readout weights
+	MaxWeight    float64       // Upper bound of readout weights
+	LearningRate float64       // Weight change per unit of modulation and eligibility
+	TeacherGain  float64       // Input per tick driving the taught pool (negated for the other)
+	STDPTau      time.Duration // Time constant of the spike-pairing window
+	Delay        time.Duration // Synaptic delay of every pathway
+	Presentation time.Duration // How long one example is shown
+	Rest         time.Duration // Silence between examples
+
+	Threshold   float64       // Firing threshold of every neuron
+	MembraneTau time.Duration // Membrane time constant
+	Refractory  time.Duration // Absolute refractory period
+	Resolution  time.Duration // Virtual clock tick
+
+	Seed int64 // Seed for wiring, initial weights and example order
+}
+
+// DefaultConfig returns a network for two-input gates.
+func DefaultConfig() Config {
+	return Config{
+		Inputs:       LOGICGATE_DEFAULT_INPUTS,
+		Hidden:       LOGICGATE_DEFAULT_HIDDEN,
+		PoolSize:     LOGICGATE_DEFAULT_POOL_SIZE,
+		InputGain:    LOGICGATE_DEFAULT_INPUT_GAIN,
+		HiddenWeight: LOGICGATE_DEFAULT_HIDDEN_WEIGHT,
+		Weight:       LOGICGATE_DEFAULT_WEIGHT,
+		MaxWeight:    LOGICGATE_DEFAULT_MAX_WEIGHT,
+		LearningRate: LOGICGATE_DEFAULT_LEARNING_RATE,
+		TeacherGain:  LOGICGATE_DEFAULT_TEACHER_GAIN,
+		STDPTau:      LOGICGATE_DEFAULT_STDP_TAU,
+		Delay:        LOGICGATE_DEFAULT_DELAY,
+		Presentation: LOGICGATE_DEFAULT_PRESENTATION,
+		Rest:         LOGICGATE_DEFAULT_REST,
+		Threshold:    LOGICGATE_DEFAULT_THRESHOLD,
+		MembraneTau:  LOGICGATE_DEFAULT_MEMBRANE_TAU,
+		Refractory:   LOGICGATE_DEFAULT_REFRACTORY,
+		Resolution:   LOGICGATE_DEFAULT_RESOLUTION,
+	}
+}
+
+// applyDefaults fills zero settings and validates the rest.
+func applyDefaults(c *Config) error {
+	if c.Inputs < 0 || c.Hidden < 0 || c.PoolSize < 0 || c.InputGain < 0 || c.HiddenWeight < 0 || c.Weight < 0 ||
+		c.MaxWeight < 0 || c.LearningRate < 0 || c.TeacherGain < 0 || c.STDPTau < 0 || c.Delay < 0 ||
+		c.Presentation < 0 || c.Rest < 0 || c.Threshold < 0 || c.MembraneTau < 0 || c.Refractory < 0 || c.Resolution < 0 {
+		return fmt.Errorf("logic gate settings cannot be negative")
+	}
+	defaults := DefaultConfig()
+	fill := func(value *int, fallback int) {
+		if *value == 0 {
+			*value = fallback
+		}
+	}
+	fillFloat := func(value *float64, fallback float64) {
+		if *value == 0 {
+			*value = fallback
+		}
+	}
+	fillDuration := func(value *time.Duration, fallback time.Duration) {
+		if *value == 0 {
+			*value = fallback
+		}
+	}
+	fill(&c.Inputs, defaults.Inputs)
+	fill(&c.Hidden, defaults.Hidden)
+	fill(&c.PoolSize, defaults.PoolSize)
+	fillFloat(&c.InputGain, defaults.InputGain)
+	fillFloat(&c.HiddenWeight, defaults.HiddenWeight)
+	fillFloat(&c.Weight, defaults.Weight)
+	fillFloat(&c.MaxWeight, defaults.MaxWeight)
+	fillFloat(&c.LearningRate, defaults.LearningRate)
+	fillFloat(&c.TeacherGain, defaults.TeacherGain)
+	fillDuration(&c.STDPTau, defaults.STDPTau)
+	fillDuration(&c.Delay, defaults.Delay)
+	fillDuration(&c.Presentation, defaults.Presentation)
+	fillDuration(&c.Rest, defaults.Rest)
+	fillFloat(&c.Threshold, defaults.Threshold)
+	fillDuration(&c.MembraneTau, defaults.MembraneTau)
+	fillDuration(&c.Refractory, defaults.Refractory)
+	fillDuration(&c.Resolution, defaults.Resolution)
+
+	if c.Inputs > LOGICGATE_MAX_INPUTS {
+		return fmt.Errorf("at most %d inputs are supported: %d", LOGICGATE_MAX_INPUTS, c.Inputs)
+	}
+	if c.Weight > c.MaxWeight {
+		return fmt.Errorf("initial weight %f exceeds the maximum weight %f", c.Weight, c.MaxWeight)
+	}
+	return nil
+}
+
+// =================================================================================
+// NETWORK
+// =================================================================================
+
+// Network is a spiking network that learns a boolean function of its
+// inputs. It is not safe for concurrent use.
+type Network struct {
+	config Config
+	runner *cosim.LockStep
+	rng    *rand.Rand
+
+	input  *batch.Population // Two neurons per bit: "0" at 2i, "1" at 2i+1
+	hidden *batch.Population // Conjunction detectors
+	output *batch.Population // PoolSize "false" neurons, then PoolSize "true" neurons
+	pairs  [][2]int          // Input neurons of each hidden neuron
+
+	learner *learner
+
+	active  []int  // Input neurons driven while an example is shown
+	teacher int    // Pool driven by the teacher (-1 = none)
+	counts  [2]int // Output spikes per pool since the example was shown
+	trials  int64
+}
+
+// New builds the network.
+func New(config Config) (*Network, error) {
+	if err := applyDefaults(&config); err != nil {
+		return nil, err
+	}
+	runner, err := cosim.NewLockStep(time.Unix(0, 0), config.Resolution)
+	if err != nil {
+		return nil, err
+	}
+	n := &Network{
+		config:  config,
+		runner:  runner,
+		rng:     rand.New(rand.NewSource(config.Seed)),
+		teacher: -1,
+	}
+
+	for _, layer := range []struct {
+		pop  **batch.Population
+		name string
+		size int
+	}{
+		{&n.input, "input", 2 * config.Inputs},
+		{&n.hidden, "hidden", config.Hidden},
+		{&n.output, "output", 2 * config.PoolSize},
+	} {
+		pop, err := batch.NewPopulation("logicgate_"+layer.name, batch.PopulationConfig{
+			Size:             layer.size,
+			Threshold:        config.Threshold,
+			DecayRate:        math.Exp(-float64(config.Resolution) / float64(config.MembraneTau)),
+			RefractoryPeriod: config.Refractory,
+			FireFactor:       1.0,
+			DelayScheduler:   runner.Schedule,
+		})
+		if err != nil {
+			return nil, err
+		}
+		*layer.pop = pop
+	}
+
+	// Input is injected before the populations step
+	runner.AddStepper(logicgateStimulusID, n.drive)
+	for _, pop := range []*batch.Population{n.input, n.hidden, n.output} {
+		runner.AddPopulation(pop)
+	}
+
+	n.wireHidden()
+	if n.learner, err = newLearner(n); err != nil {
+		return nil, err
+	}
+	for i := 0; i < n.output.Size(); i++ {
+		pool := i / config.PoolSize
+		n.output.AddOutputCallback(i, logicgateCounterID, types.OutputCallback{
+			TransmitMessage: func(types.NeuralSignal) error {
+				n.counts[pool]++
+				return nil
+			},
+		})
+	}
+	return n, nil
+}
+
+// wireHidden gives each hidden neuron a pair of input neurons, cycling
+// through a seeded shuffle of all pairs, and connects the pair with the
+// fixed hidden weight.
+func (n *Network) wireHidden() {
+	inputs := n.input.Size()
+	var pairs [][2]int
+	for a := 0; a < inputs; a++ {
+		for b := a + 1; b < inputs; b++ {
+			pairs = append(pairs, [2]int{a, b})
+		}
+	}
+	n.rng.Shuffle(len(pairs), func(i, j int) { pairs[i], pairs[j] = pairs[j], pairs[i] })
+
+	targets := make([][]*batch.Member, inputs)
+	n.pairs = make([][2]int, n.hidden.Size())
+	for h := range n.pairs {
+		n.pairs[h] = pairs[h%len(pairs)]
+		for _, i := range n.pairs[h] {
+			targets[i] = append(targets[i], n.hidden.Member(h))
+		}
+	}
+	for i := 0; i < inputs; i++ {
+		members := targets[i]
+		n.input.AddOutputCallback(i, logicgateHiddenID, types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				for _, target := range members {
+					n.runner.Schedule(types.NeuralSignal{
+						Value:     msg.Value * n.config.HiddenWeight,
+						Timestamp: msg.Timestamp,
+						SourceID:  msg.SourceID,
+						TargetID:  target.ID(),
+					}, target, n.config.Delay)
+				}
+				return nil
+			},
+		})
+	}
+}
+
+// drive injects the shown example and the teacher signal.
+func (n *Network) drive(now time.Time) {
+	for _, i := range n.active {
+		n.input.Inject(i, n.config.InputGain)
+	}
+	if n.teacher < 0 {
+		return
+	}
+	for i := 0; i < n.output.Size(); i++ {
+		if i/n.config.PoolSize == n.teacher {
+			n.output.Inject(i, n.config.TeacherGain)
+		} else {
+			n.output.Inject(i, -n.config.TeacherGain)
+		}
+	}
+}
+
+// Config returns the configuration with defaults applied.
+func (n *Network) Config() Config {
+	return n.config
+}
+
+// Clock returns the network's virtual clock.
+func (n *Network) Clock() *cosim.LockStep {
+	return n.runner
+}
+
+// Synapses returns the plastic readout synapses onto the pool answering
+// output.
+func (n *Network) Synapses(output bool) []*synapse.BasicSynapse {
+	return n.learner.synapsesOf(poolOf(output))
+}
+
+// Elapsed returns the virtual time consumed so far.
+func (n *Network) Elapsed() time.Duration {
+	return n.runner.Now().Sub(time.Unix(0, 0))
+}
+
+// GetStats returns the network's size, elapsed time and learning counters.
+func (n *Network) GetStats() map[string]interface{} {
+	return map[string]interface{}{
+		"inputs":      n.config.Inputs,
+		"hidden":      n.config.Hidden,
+		"pool_size":   n.config.PoolSize,
+		"elapsed":     n.Elapsed(),
+		"trials":      n.trials,
+		"modulations": n.learner.modulations,
+		"synapses":    len(n.learner.synapses),
+	}
+}
+
+// poolOf returns the output pool answering output.
+func poolOf(output bool) int {
+	if output {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,94 @@
+package logicgate
+
+import (
+	"math"
+	"testing"
+)
+
+// TestLearnsLogicGates trains XOR, AND and OR from spike examples: the
+// untrained readout fails XOR, and after training every gate is answered
+// correctly on all four inputs.
+func TestLearnsLogicGates(t *testing.T) {
+	for _, gate := range []struct {
+		name string
+		gate Gate
+	}{{"xor", XOR}, {"and", AND}, {"or", OR}} {
+		net, err := New(Config{Seed: 1})
+		if err != nil {
+			t.Fatalf("Failed to build network: %v", err)
+		}
+		table := TruthTable(gate.gate, 2)
+		before, err := net.Accuracy(table)
+		if err != nil {
+			t.Fatalf("%s: %v", gate.name, err)
+		}
+		history, err := net.Train(table, 10)
+		if err != nil {
+			t.Fatalf("%s: %v", gate.name, err)
+		}
+		after, _ := net.Accuracy(table)
+		if after != 1 || history[len(history)-1] != 1 {
+			t.Errorf("%s: expected all four cases learned, got accuracy %.2f (history %v)", gate.name, after, history)
+		}
+		if gate.name == "xor" && before == 1 {
+			t.Error("Expected the untrained readout to fail XOR")
+		}
+	}
+}
+
+// TestTeachAndModulate verifies that a taught presentation leaves
+// eligibility only on the taught pool, that modulation moves those weights
+// in the signal's direction, and that Predict does not learn.
+func TestTeachAndModulate(t *testing.T) {
+	net, err := New(Config{Seed: 2})
+	if err != nil {
+		t.Fatalf("Failed to build network: %v", err)
+	}
+	sum := func(output bool) float64 {
+		total := 0.0
+		for _, syn := range net.Synapses(output) {
+			total += syn.GetWeight()
+		}
+		return total
+	}
+	trueBefore, falseBefore := sum(true), sum(false)
+
+	if err := net.Teach([]bool{true, false}, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if change, _ := net.Modulate(false, 1); change != 0 {
+		t.Errorf("Expected no eligibility on the silenced pool, changed %.3f", change)
+	}
+	if change, _ := net.Modulate(true, 1); change <= 0 || sum(true) <= trueBefore {
+		t.Errorf("Expected the taught pool strengthened, changed %.3f", change)
+	}
+	if err := net.Teach([]bool{true, false}, true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	strengthened := sum(true)
+	net.Modulate(true, -1)
+	if sum(true) >= strengthened {
+		t.Error("Expected a negative signal to weaken the taught pairings")
+	}
+
+	weights := sum(true) + sum(false)
+	if _, _, err := net.Predict([]bool{false, true}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sum(true)+sum(false) != weights || sum(false) != falseBefore {
+		t.Error("Expected Predict to leave the weights alone")
+	}
+
+	if _, err := net.Modulate(true, math.Inf(1)); err == nil {
+		t.Error("Expected error for an infinite signal")
+	}
+	if _, _, err := net.Predict([]bool{true}); err == nil {
+		t.Error("Expected error for the wrong number of inputs")
+	}
+	if rows := TruthTable(XOR, 3); len(rows) != 8 || !rows[7].Output || rows[3].Output {
+		t.Errorf("Expected three-input XOR to be parity, got %+v", rows)
+	}
+	if _, err := New(Config{Weight: 2, MaxWeight: 1}); err == nil {
+		t.Error("Expected error for an initial weight above the maximum")
+	}
+}
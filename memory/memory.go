@@ -0,0 +1,372 @@
+/*
+=================================================================================
+MEMORY - BUDGET ACCOUNTING
+=================================================================================
+
+Large networks run out of memory long before they run out of compute, and
+not only through neurons and synapses: axonal delay queues, spike histories
+kept for STDP and recorders grow with activity and duration. A Budget
+collects estimates of the bytes each component holds, by category, and
+optionally enforces a hard cap on the total:
+
+	budget, _ := memory.NewBudget(512 << 20) // 512 MiB
+	matrix.SetMemoryBudget(budget)           // tracks neurons and synapses
+	recorder.SetMemoryBudget(budget, "rec")  // caps recorder expansion
+	...
+	fmt.Print(budget.Measure())              // per-category report
+
+Components estimate their own usage through Reporter (neuron.Neuron,
+synapse.BasicSynapse, replay.Recorder, cosim.LockStep). Estimates count the
+fixed struct size and the capacity of the buffers a component owns, not
+shared or runtime overhead, so they are a lower bound useful for comparing
+configurations and catching runaway growth rather than an exact heap size.
+
+Tracked usage is cached: Track measures a component once and Measure
+refreshes every component. Growth that can be refused asks first: the
+matrix admits new neurons and synapses (and so synaptogenesis) with Track,
+and recorders reserve capacity with Reserve. Past the limit both fail with
+ErrBudgetExceeded, and the rejection is counted.
+=================================================================================
+*/
+
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Category groups memory by what it holds.
+type Category string
+
+const (
+	CategoryNeurons      Category = "neurons"       // Neuron state and output tables
+	CategorySynapses     Category = "synapses"      // Synapse state
+	CategoryDelayQueues  Category = "delay_queues"  // Spikes in flight
+	CategorySpikeHistory Category = "spike_history" // Spike times kept for plasticity
+	CategoryRecorders    Category = "recorders"     // Recorded activity
+)
+
+// Categories lists the categories in report order.
+var Categories = []Category{CategoryNeurons, CategorySynapses, CategoryDelayQueues, CategorySpikeHistory, CategoryRecorders}
+
+// ErrBudgetExceeded is returned when growth would exceed the limit.
+var ErrBudgetExceeded = errors.New("memory budget exceeded")
+
+// Usage is a number of bytes per category.
+type Usage map[Category]int64
+
+// Total returns the bytes over all categories.
+func (u Usage) Total() int64 {
+	var total int64
+	for _, bytes := range u {
+		total += bytes
+	}
+	return total
+}
+
+// add accumulates other into u.
+func (u Usage) add(other Usage, sign int64) {
+	for category, bytes := range other {
+		u[category] += sign * bytes
+	}
+}
+
+// Reporter estimates the memory a component holds.
+type Reporter interface {
+	MemoryUsage() Usage
+}
+
+// Report is a snapshot of a budget.
+type Report struct {
+	Limit       int64            `json:"limit"` // 0 = unlimited
+	Total       int64            `json:"total"`
+	Peak        int64            `json:"peak"`
+	Rejected    int64            `json:"rejected"` // Refused admissions and reservations
+	ByCategory  Usage            `json:"by_category"`
+	ByComponent map[string]Usage `json:"by_component"`
+}
+
+// String formats the report as a table of categories.
+func (r Report) String() string {
+	var b strings.Builder
+	for _, category := range Categories {
+		fmt.Fprintf(&b, "%-14s %12s\n", category, FormatBytes(r.ByCategory[category]))
+	}
+	limit := "unlimited"
+	if r.Limit > 0 {
+		limit = FormatBytes(r.Limit)
+	}
+	fmt.Fprintf(&b, "%-14s %12s of %s (peak %s, %d rejected)\n", "total", FormatBytes(r.Total), limit, FormatBytes(r.Peak), r.Rejected)
+	return b.String()
+}
+
+// Largest returns up to n component IDs by decreasing total usage.
+func (r Report) Largest(n int) []string {
+	ids := make([]string, 0, len(r.ByComponent))
+	for id := range r.ByComponent {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := r.ByComponent[ids[i]].Total(), r.ByComponent[ids[j]].Total()
+		if a != b {
+			return a > b
+		}
+		return ids[i] < ids[j]
+	})
+	if n >= 0 && n < len(ids) {
+		ids = ids[:n]
+	}
+	return ids
+}
+
+// FormatBytes formats a byte count with a binary unit.
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, suffix := float64(bytes), "KMGTPE"
+	i := -1
+	for value >= unit && i < len(suffix)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %ciB", value, suffix[i])
+}
+
+// component is a tracked reporter and its last measured usage.
+type component struct {
+	reporter Reporter
+	usage    Usage
+}
+
+// Budget accounts memory per component. It is safe for concurrent use.
+type Budget struct {
+	mu         sync.Mutex
+	limit      int64
+	components map[string]*component
+	reserved   map[string]Usage
+	byCategory Usage
+	total      int64
+	peak       int64
+	rejected   int64
+}
+
+// NewBudget creates a budget with a hard limit in bytes (0 = unlimited,
+// accounting only).
+func NewBudget(limit int64) (*Budget, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("memory limit cannot be negative: %d", limit)
+	}
+	return &Budget{
+		limit:      limit,
+		components: make(map[string]*component),
+		reserved:   make(map[string]Usage),
+		byCategory: make(Usage),
+	}, nil
+}
+
+// Limit returns the hard limit in bytes (0 = unlimited).
+func (b *Budget) Limit() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.limit
+}
+
+// SetLimit changes the limit. Lowering it below current usage refuses
+// further growth but frees nothing.
+func (b *Budget) SetLimit(limit int64) error {
+	if limit < 0 {
+		return fmt.Errorf("memory limit cannot be negative: %d", limit)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.limit = limit
+	return nil
+}
+
+// Used returns the accounted bytes.
+func (b *Budget) Used() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.total
+}
+
+// Exhausted reports whether no more bytes can be admitted.
+func (b *Budget) Exhausted() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.limit > 0 && b.total >= b.limit
+}
+
+// Track measures reporter and accounts its usage under id. It fails with
+// ErrBudgetExceeded, and tracks nothing, if the usage does not fit. Tracking
+// an id again replaces the previous entry.
+func (b *Budget) Track(id string, reporter Reporter) error {
+	if reporter == nil {
+		return fmt.Errorf("component %s has no memory reporter", id)
+	}
+	usage := reporter.MemoryUsage()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var previous int64
+	if old, ok := b.components[id]; ok {
+		previous = old.usage.Total()
+	}
+	if err := b.admitUnsafe(usage.Total() - previous); err != nil {
+		return fmt.Errorf("tracking %s: %w", id, err)
+	}
+	if old, ok := b.components[id]; ok {
+		b.accountUnsafe(old.usage, -1)
+	}
+	b.components[id] = &component{reporter: reporter, usage: usage}
+	b.accountUnsafe(usage, 1)
+	return nil
+}
+
+// Untrack removes a component and its reservations.
+func (b *Budget) Untrack(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c, ok := b.components[id]; ok {
+		b.accountUnsafe(c.usage, -1)
+		delete(b.components, id)
+	}
+	if reserved, ok := b.reserved[id]; ok {
+		b.accountUnsafe(reserved, -1)
+		delete(b.reserved, id)
+	}
+}
+
+// Reserve accounts bytes of category to id before they are allocated. It
+// fails with ErrBudgetExceeded if they do not fit.
+func (b *Budget) Reserve(id string, category Category, bytes int64) error {
+	if bytes < 0 {
+		return fmt.Errorf("reservation cannot be negative: %d", bytes)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err := b.admitUnsafe(bytes); err != nil {
+		return fmt.Errorf("reserving %d bytes of %s for %s: %w", bytes, category, id, err)
+	}
+	if b.reserved[id] == nil {
+		b.reserved[id] = make(Usage)
+	}
+	b.reserved[id][category] += bytes
+	b.accountUnsafe(Usage{category: bytes}, 1)
+	return nil
+}
+
+// Release returns reserved bytes of category. Releasing more than was
+// reserved releases everything.
+func (b *Budget) Release(id string, category Category, bytes int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	reserved := b.reserved[id]
+	if reserved == nil {
+		return
+	}
+	if bytes > reserved[category] || bytes < 0 {
+		bytes = reserved[category]
+	}
+	reserved[category] -= bytes
+	b.accountUnsafe(Usage{category: bytes}, -1)
+}
+
+// Measure refreshes every tracked component's usage and returns the report.
+// Measured growth is accounted even past the limit: it has happened already.
+func (b *Budget) Measure() Report {
+	b.mu.Lock()
+	components := make(map[string]*component, len(b.components))
+	for id, c := range b.components {
+		components[id] = c
+	}
+	b.mu.Unlock()
+
+	measured := make(map[string]Usage, len(components))
+	for id, c := range components {
+		measured[id] = c.reporter.MemoryUsage()
+	}
+
+	b.mu.Lock()
+	for id, usage := range measured {
+		if c, ok := b.components[id]; ok && c == components[id] {
+			b.accountUnsafe(c.usage, -1)
+			c.usage = usage
+			b.accountUnsafe(usage, 1)
+		}
+	}
+	b.mu.Unlock()
+	return b.Report()
+}
+
+// Report returns the accounted usage without measuring.
+func (b *Budget) Report() Report {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	report := Report{
+		Limit:       b.limit,
+		Total:       b.total,
+		Peak:        b.peak,
+		Rejected:    b.rejected,
+		ByCategory:  make(Usage, len(b.byCategory)),
+		ByComponent: make(map[string]Usage, len(b.components)+len(b.reserved)),
+	}
+	report.ByCategory.add(b.byCategory, 1)
+	for id, c := range b.components {
+		usage := make(Usage, len(c.usage))
+		usage.add(c.usage, 1)
+		report.ByComponent[id] = usage
+	}
+	for id, reserved := range b.reserved {
+		usage := report.ByComponent[id]
+		if usage == nil {
+			usage = make(Usage, len(reserved))
+			report.ByComponent[id] = usage
+		}
+		usage.add(reserved, 1)
+	}
+	return report
+}
+
+// GetStats returns the budget's totals.
+func (b *Budget) GetStats() map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := map[string]interface{}{
+		"limit":      b.limit,
+		"used":       b.total,
+		"peak":       b.peak,
+		"rejected":   b.rejected,
+		"components": len(b.components),
+	}
+	for _, category := range Categories {
+		stats[string(category)] = b.byCategory[category]
+	}
+	return stats
+}
+
+// admitUnsafe checks that bytes more fit and counts a rejection if not.
+// Must be called with b.mu held.
+func (b *Budget) admitUnsafe(bytes int64) error {
+	if b.limit > 0 && bytes > 0 && b.total+bytes > b.limit {
+		b.rejected++
+		return fmt.Errorf("%w: %d of %d bytes used, %d more requested", ErrBudgetExceeded, b.total, b.limit, bytes)
+	}
+	return nil
+}
+
+// accountUnsafe adds (sign 1) or removes (sign -1) usage from the totals.
+// Must be called with b.mu held.
+func (b *Budget) accountUnsafe(usage Usage, sign int64) {
+	b.byCategory.add(usage, sign)
+	b.total += sign * usage.Total()
+	if b.total > b.peak {
+		b.peak = b.total
+	}
+}
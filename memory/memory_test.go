@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fixedReporter reports a settable usage.
+type fixedReporter struct{ usage Usage }
+
+func (r *fixedReporter) MemoryUsage() Usage { return r.usage }
+
+// TestBudgetAccountingAndLimit verifies per-category accounting, the hard
+// limit on tracking and reservations, and measurement of grown components.
+func TestBudgetAccountingAndLimit(t *testing.T) {
+	budget, err := NewBudget(1000)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	neuron := &fixedReporter{Usage{CategoryNeurons: 300, CategoryDelayQueues: 100}}
+	synapse := &fixedReporter{Usage{CategorySynapses: 200, CategorySpikeHistory: 50}}
+	if err := budget.Track("n1", neuron); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := budget.Track("s1", synapse); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if budget.Used() != 650 {
+		t.Errorf("Expected 650 bytes used, got %d", budget.Used())
+	}
+
+	// Growth past the limit is refused and counted
+	err = budget.Track("s2", &fixedReporter{Usage{CategorySynapses: 400}})
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Expected ErrBudgetExceeded, got %v", err)
+	}
+	if err := budget.Reserve("rec", CategoryRecorders, 300); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := budget.Reserve("rec", CategoryRecorders, 100); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("Expected reservation past the limit to fail, got %v", err)
+	}
+	budget.Release("rec", CategoryRecorders, 200)
+	if budget.Used() != 750 || budget.Exhausted() {
+		t.Errorf("Expected 750 bytes after release, got %d", budget.Used())
+	}
+
+	// Measurement picks up growth even past the limit
+	neuron.usage = Usage{CategoryNeurons: 300, CategoryDelayQueues: 400}
+	report := budget.Measure()
+	if report.Total != 1050 || report.ByCategory[CategoryDelayQueues] != 400 || !budget.Exhausted() {
+		t.Errorf("Expected measured growth to 1050 bytes, got %+v", report)
+	}
+	if report.Rejected != 2 || report.Peak != 1050 || report.Largest(1)[0] != "n1" {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if text := report.String(); !strings.Contains(text, "delay_queues") || !strings.Contains(text, "1.0 KiB of 1000 B") {
+		t.Errorf("Unexpected report text:\n%s", text)
+	}
+
+	budget.Untrack("n1")
+	budget.Untrack("rec")
+	if budget.Used() != 250 || len(budget.Report().ByComponent) != 1 {
+		t.Errorf("Expected only the synapse left, got %d bytes", budget.Used())
+	}
+	if _, err := NewBudget(-1); err == nil {
+		t.Error("Expected error for a negative limit")
+	}
+}
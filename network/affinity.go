@@ -0,0 +1,54 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/SynapticNetworks/temporal-neuron/affinity"
+)
+
+// =================================================================================
+// THREAD AFFINITY
+// =================================================================================
+//
+// Populations are densely connected inside and sparsely between, so placing
+// each on one NUMA node keeps most spike traffic on one socket. Pin sets the
+// affinity group of every member (see neuron.SetAffinity); PinPopulations
+// spreads populations over the machine's nodes round-robin. Both take effect
+// when the neurons are next started.
+
+// pinnable is implemented by neurons whose goroutine can be pinned
+// (neuron.Neuron).
+type pinnable interface {
+	SetAffinity(group *affinity.Group)
+}
+
+// Pin pins the members' processing goroutines to group from their next
+// Start and returns the number of members pinned.
+func (p *Population) Pin(group *affinity.Group) int {
+	pinned := 0
+	for _, cell := range p.neurons {
+		if target, ok := cell.(pinnable); ok {
+			target.SetAffinity(group)
+			pinned++
+		}
+	}
+	return pinned
+}
+
+// PinPopulations assigns each population a NUMA node, round-robin in the
+// order given, and pins its members there. It returns the groups by
+// population ID.
+func PinPopulations(populations ...*Population) (map[string]*affinity.Group, error) {
+	ids := make([]string, len(populations))
+	for i, pop := range populations {
+		ids[i] = pop.ID()
+	}
+	groups, err := affinity.Spread(ids)
+	if err != nil {
+		return nil, fmt.Errorf("pinning populations: %w", err)
+	}
+	for _, pop := range populations {
+		pop.Pin(groups[pop.ID()])
+	}
+	return groups, nil
+}
@@ -0,0 +1,44 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestPinPopulations verifies that pinned populations start their neurons
+// through their affinity group.
+func TestPinPopulations(t *testing.T) {
+	builder := &testBuilder{}
+	cell := types.NeuronConfig{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond, FireFactor: 1.0}
+	v1, _ := NewPopulation(builder, "v1", PopulationConfig{Size: 3, Neuron: cell})
+	pfc, _ := NewPopulation(builder, "pfc", PopulationConfig{Size: 2, Neuron: cell})
+
+	groups, err := PinPopulations(v1, pfc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(groups) != 2 || groups["v1"].Name() != "v1" {
+		t.Fatalf("Expected a group per population, got %v", groups)
+	}
+	for _, cell := range v1.Neurons() {
+		if cell.(*neuron.Neuron).GetAffinity() != groups["v1"] {
+			t.Fatalf("Expected %s pinned to v1", cell.ID())
+		}
+	}
+
+	first := v1.Neuron(0).(*neuron.Neuron)
+	if err := first.Start(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer first.Stop()
+	deadline := time.Now().Add(time.Second)
+	for groups["v1"].GetStats()["running"].(int64) != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if running := groups["v1"].GetStats()["running"].(int64); running != 1 {
+		t.Errorf("Expected the neuron running on its group, got %d goroutines", running)
+	}
+}
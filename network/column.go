@@ -0,0 +1,323 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// CORTICAL MICROCOLUMN TEMPLATE
+// =================================================================================
+//
+// A cortical column is the standard scaffold for cortical models: thalamic
+// input arrives in L4, L4 drives L2/3, L2/3 drives L5, and L5 projects out
+// of the column. Every layer has its own excitatory cells and two classes of
+// local interneurons - PV basket cells for fast perisomatic inhibition and
+// SST Martinotti cells for slower dendritic inhibition. NewColumn builds
+// this circuit with one call:
+//
+//	column, _ := network.NewColumn(matrix, "barrel_c2", network.ColumnConfig{Size: 1000})
+//	column.Input().StimulateAll(thalamicDrive)  // L4 excitatory cells
+//	rates := column.Output().GetRates()         // L5 excitatory cells
+//
+// Layer sizes follow the cell counts of Potjans & Diesmann (2014), rescaled
+// to Size: L2/3 and L4 hold about 45% of the cells each, L5 the remaining
+// 10%, and 18-22% of each layer is inhibitory, split 60:40 between PV and
+// SST cells. Cells are placed in a cylinder of Radius at the layer's depth
+// below the pia (L2/3 150-450μm, L4 450-650μm, L5 650-1000μm).
+//
+// DefaultColumnConnections gives the wiring: connection probabilities after
+// Potjans & Diesmann (2014) and Thomson & Lamy (2007), intralaminar delays
+// of 1.5ms for excitation and 0.8ms for inhibition, and 2ms between layers.
+// Weights are drawn around the table value with a coefficient of variation
+// of COLUMN_WEIGHT_CV and delays jitter by ±COLUMN_DELAY_JITTER. Inhibitory
+// connections have negative weights and release GABA.
+//
+// Cells are built from the neuron archetypes (l23_pyramidal, pv_basket,
+// sst_martinotti). Their parameters are filled in, so builders that ignore
+// NeuronType still get calibrated cells; register the archetype factories
+// with the matrix to build them by name.
+
+// Column population names. Each layer has an excitatory (E), a PV and an
+// SST population.
+const (
+	ColumnL4E    = "L4E"
+	ColumnL4PV   = "L4PV"
+	ColumnL4SST  = "L4SST"
+	ColumnL23E   = "L23E"
+	ColumnL23PV  = "L23PV"
+	ColumnL23SST = "L23SST"
+	ColumnL5E    = "L5E"
+	ColumnL5PV   = "L5PV"
+	ColumnL5SST  = "L5SST"
+)
+
+// Column defaults.
+const (
+	COLUMN_DEFAULT_SIZE   = 500
+	COLUMN_DEFAULT_RADIUS = 150.0 // μm
+	COLUMN_PV_SHARE       = 0.6   // Fraction of a layer's interneurons that are PV cells
+	COLUMN_WEIGHT_CV      = 0.1   // Coefficient of variation of the weights
+	COLUMN_DELAY_JITTER   = 0.25  // Delays are uniform in Delay·(1 ± jitter)
+)
+
+// columnLayer describes one layer of the column.
+type columnLayer struct {
+	name               string
+	fraction           float64 // Share of the column's cells
+	inhibitoryFraction float64 // Share of the layer's cells that are interneurons
+	top, bottom        float64 // Depth below the pia in μm
+}
+
+// columnLayers lists the layers from the pia down. Fractions are the
+// Potjans & Diesmann (2014) cell counts of L2/3, L4 and L5.
+var columnLayers = []columnLayer{
+	{name: "L23", fraction: 0.443, inhibitoryFraction: 0.22, top: 150, bottom: 450},
+	{name: "L4", fraction: 0.458, inhibitoryFraction: 0.20, top: 450, bottom: 650},
+	{name: "L5", fraction: 0.099, inhibitoryFraction: 0.18, top: 650, bottom: 1000},
+}
+
+// ColumnConnection is one projection of the column.
+type ColumnConnection struct {
+	From, To    string        // Population names, e.g. ColumnL4E
+	Probability float64       // Connection probability per pair
+	Weight      float64       // Mean weight (negative = inhibitory)
+	Delay       time.Duration // Mean delay
+}
+
+// DefaultColumnConnections returns the default wiring of the column.
+func DefaultColumnConnections() []ColumnConnection {
+	const (
+		local      = 1500 * time.Microsecond
+		inhibitory = 800 * time.Microsecond
+		laminar    = 2 * time.Millisecond
+	)
+	connections := []ColumnConnection{
+		// Feedforward excitation L4 -> L2/3 -> L5, with feedforward inhibition
+		{From: ColumnL4E, To: ColumnL23E, Probability: 0.05, Weight: 0.15, Delay: laminar},
+		{From: ColumnL4E, To: ColumnL23PV, Probability: 0.05, Weight: 0.2, Delay: laminar},
+		{From: ColumnL23E, To: ColumnL5E, Probability: 0.1, Weight: 0.15, Delay: laminar},
+		{From: ColumnL23E, To: ColumnL5PV, Probability: 0.05, Weight: 0.2, Delay: laminar},
+	}
+	recurrent := map[string]float64{"L4": 0.05, "L23": 0.1, "L5": 0.08}
+	for _, layer := range columnLayers {
+		e, pv, sst := layer.name+"E", layer.name+"PV", layer.name+"SST"
+		connections = append(connections,
+			ColumnConnection{From: e, To: e, Probability: recurrent[layer.name], Weight: 0.15, Delay: local},
+			ColumnConnection{From: e, To: pv, Probability: 0.3, Weight: 0.2, Delay: local},
+			ColumnConnection{From: e, To: sst, Probability: 0.2, Weight: 0.15, Delay: local},
+			ColumnConnection{From: pv, To: e, Probability: 0.4, Weight: -0.6, Delay: inhibitory},
+			ColumnConnection{From: pv, To: pv, Probability: 0.3, Weight: -0.4, Delay: inhibitory},
+			ColumnConnection{From: sst, To: e, Probability: 0.3, Weight: -0.3, Delay: inhibitory},
+			ColumnConnection{From: sst, To: pv, Probability: 0.2, Weight: -0.3, Delay: inhibitory},
+		)
+	}
+	return connections
+}
+
+// ColumnConfig describes a column. Zero values select the defaults.
+type ColumnConfig struct {
+	Size   int     // Total number of cells (about; every population has at least one)
+	Radius float64 // Column radius in μm
+
+	// Cell templates; zero values use the l23_pyramidal, pv_basket and
+	// sst_martinotti archetypes
+	Excitatory, PV, SST types.NeuronConfig
+
+	Connections []ColumnConnection  // nil = DefaultColumnConnections()
+	Synapse     types.SynapseConfig // Template for all synapses; LigandType is set per population
+	Seed        int64               // Seeds placement, wiring, weights and delays
+}
+
+// Column is a cortical microcolumn.
+type Column struct {
+	id          string
+	order       []string // Population names, layer by layer from the pia
+	populations map[string]*Population
+	projections map[string]*Projection // "From->To"
+}
+
+// NewColumn builds a column with builder.
+func NewColumn(builder Builder, id string, config ColumnConfig) (*Column, error) {
+	if config.Size < 0 || config.Radius < 0 || math.IsNaN(config.Radius) {
+		return nil, fmt.Errorf("column %s: size and radius cannot be negative", id)
+	}
+	if config.Size == 0 {
+		config.Size = COLUMN_DEFAULT_SIZE
+	}
+	if config.Radius == 0 {
+		config.Radius = COLUMN_DEFAULT_RADIUS
+	}
+	if config.Connections == nil {
+		config.Connections = DefaultColumnConnections()
+	}
+	templates := map[string]types.NeuronConfig{"E": config.Excitatory, "PV": config.PV, "SST": config.SST}
+	for class, archetype := range map[string]string{"E": "l23_pyramidal", "PV": "pv_basket", "SST": "sst_martinotti"} {
+		if templates[class].NeuronType != "" || templates[class].Threshold != 0 {
+			continue
+		}
+		template, err := columnCellTemplate(archetype)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", id, err)
+		}
+		templates[class] = template
+	}
+
+	column := &Column{
+		id:          id,
+		populations: make(map[string]*Population),
+		projections: make(map[string]*Projection),
+	}
+	rng := rand.New(rand.NewSource(config.Seed))
+	for _, layer := range columnLayers {
+		cells := float64(config.Size) * layer.fraction
+		inhibitory := cells * layer.inhibitoryFraction
+		sizes := map[string]int{
+			"E":   columnPopulationSize(cells - inhibitory),
+			"PV":  columnPopulationSize(inhibitory * COLUMN_PV_SHARE),
+			"SST": columnPopulationSize(inhibitory * (1 - COLUMN_PV_SHARE)),
+		}
+		for _, class := range []string{"E", "PV", "SST"} {
+			name := layer.name + class
+			synapse := config.Synapse
+			synapse.LigandType = types.LigandGlutamate
+			if class != "E" {
+				synapse.LigandType = types.LigandGABA
+			}
+			positions := make([]types.Position3D, sizes[class])
+			for i := range positions {
+				// Uniform in the disc, uniform in depth; the pia is at Z = 0
+				r := config.Radius * math.Sqrt(rng.Float64())
+				theta := 2 * math.Pi * rng.Float64()
+				depth := layer.top + rng.Float64()*(layer.bottom-layer.top)
+				positions[i] = types.Position3D{X: r * math.Cos(theta), Y: r * math.Sin(theta), Z: -depth}
+			}
+			population, err := NewPopulation(builder, id+"_"+name, PopulationConfig{
+				Size:      sizes[class],
+				Neuron:    templates[class],
+				Positions: positions,
+				Synapse:   synapse,
+				Seed:      config.Seed + int64(len(column.order)) + 1,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %w", id, err)
+			}
+			column.order = append(column.order, name)
+			column.populations[name] = population
+		}
+	}
+
+	for _, connection := range config.Connections {
+		pre, post := column.populations[connection.From], column.populations[connection.To]
+		if pre == nil || post == nil {
+			return nil, fmt.Errorf("column %s: unknown population in connection %s->%s", id, connection.From, connection.To)
+		}
+		if connection.Delay < 0 {
+			return nil, fmt.Errorf("column %s: connection %s->%s: delay cannot be negative: %v", id, connection.From, connection.To, connection.Delay)
+		}
+		spread := math.Abs(connection.Weight) * COLUMN_WEIGHT_CV
+		jitter := time.Duration(float64(connection.Delay) * COLUMN_DELAY_JITTER)
+		projection, err := pre.ConnectRandom(post, connection.Probability,
+			NormalWeight(connection.Weight, spread), UniformDelay(connection.Delay-jitter, connection.Delay+jitter))
+		if err != nil {
+			return nil, fmt.Errorf("column %s: connection %s->%s: %w", id, connection.From, connection.To, err)
+		}
+		column.projections[connection.From+"->"+connection.To] = projection
+	}
+	return column, nil
+}
+
+// columnCellTemplate converts an archetype into a neuron template that
+// selects the archetype by name.
+func columnCellTemplate(name string) (types.NeuronConfig, error) {
+	archetype, err := neuron.LookupArchetype(name)
+	if err != nil {
+		return types.NeuronConfig{}, err
+	}
+	cell := archetype.Config
+	return types.NeuronConfig{
+		NeuronType:          archetype.Name,
+		Threshold:           cell.Threshold,
+		DecayRate:           cell.DecayRate,
+		RefractoryPeriod:    cell.RefractoryPeriod,
+		FireFactor:          cell.FireFactor,
+		TargetFiringRate:    cell.TargetFiringRate,
+		HomeostasisStrength: cell.HomeostasisStrength,
+		Receptors:           cell.Receptors,
+		ReleasedLigands:     cell.ReleasedLigands,
+	}, nil
+}
+
+// columnPopulationSize rounds a population size, keeping at least one cell.
+func columnPopulationSize(cells float64) int {
+	return int(math.Max(1, math.Round(cells)))
+}
+
+// ID returns the column identifier.
+func (c *Column) ID() string {
+	return c.id
+}
+
+// Population returns the named population (e.g. ColumnL23PV), or nil.
+func (c *Column) Population(name string) *Population {
+	return c.populations[name]
+}
+
+// Populations returns the populations layer by layer from the pia, E, PV
+// and SST within each layer.
+func (c *Column) Populations() []*Population {
+	populations := make([]*Population, len(c.order))
+	for i, name := range c.order {
+		populations[i] = c.populations[name]
+	}
+	return populations
+}
+
+// Projection returns the projection between two named populations, or nil
+// if the column does not wire them.
+func (c *Column) Projection(from, to string) *Projection {
+	return c.projections[from+"->"+to]
+}
+
+// Input returns the L4 excitatory cells, which receive thalamic input.
+func (c *Column) Input() *Population {
+	return c.populations[ColumnL4E]
+}
+
+// Output returns the L5 excitatory cells, which project out of the column.
+func (c *Column) Output() *Population {
+	return c.populations[ColumnL5E]
+}
+
+// Size returns the number of cells.
+func (c *Column) Size() int {
+	total := 0
+	for _, population := range c.populations {
+		total += population.Size()
+	}
+	return total
+}
+
+// GetStats returns the cell count of each population and the synapse count
+// of each projection.
+func (c *Column) GetStats() map[string]interface{} {
+	cells := make(map[string]int, len(c.populations))
+	for name, population := range c.populations {
+		cells[name] = population.Size()
+	}
+	synapses := make(map[string]int, len(c.projections))
+	for name, projection := range c.projections {
+		synapses[name] = projection.Size()
+	}
+	return map[string]interface{}{
+		"id":       c.id,
+		"size":     c.Size(),
+		"cells":    cells,
+		"synapses": synapses,
+	}
+}
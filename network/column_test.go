@@ -0,0 +1,54 @@
+package network
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCorticalColumn verifies the layer sizes, placement and default wiring
+// of a cortical column, and the rejection of bad connection tables.
+func TestCorticalColumn(t *testing.T) {
+	column, err := NewColumn(&testBuilder{}, "c", ColumnConfig{Size: 200, Seed: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(column.Populations()) != 9 || column.Size() < 195 || column.Size() > 205 {
+		t.Fatalf("Expected 9 populations of about 200 cells, got %d with %d cells", len(column.Populations()), column.Size())
+	}
+	if column.Input() != column.Population(ColumnL4E) || column.Output() != column.Population(ColumnL5E) {
+		t.Error("Expected input L4E and output L5E")
+	}
+	if l4, l5 := column.Population(ColumnL4E).Size(), column.Population(ColumnL5E).Size(); l4 < 3*l5 {
+		t.Errorf("Expected L4 to be much larger than L5, got %d and %d cells", l4, l5)
+	}
+	for _, n := range column.Output().Neurons() {
+		if z := n.Position().Z; z > -650 || z < -1000 {
+			t.Errorf("Expected L5 cell %s at 650-1000μm depth, got z=%f", n.ID(), z)
+		}
+	}
+
+	for _, connection := range DefaultColumnConnections() {
+		if column.Projection(connection.From, connection.To) == nil {
+			t.Errorf("Missing projection %s->%s", connection.From, connection.To)
+		}
+	}
+	feedforward := column.Projection(ColumnL4E, ColumnL23E)
+	pairs := float64(column.Input().Size() * column.Population(ColumnL23E).Size())
+	if density := float64(feedforward.Size()) / pairs; density < 0.03 || density > 0.07 {
+		t.Errorf("Expected L4E->L23E density near 0.05, got %.3f", density)
+	}
+	for _, syn := range feedforward.Synapses() {
+		if d := syn.GetDelay(); d < 1500*time.Microsecond || d > 2500*time.Microsecond {
+			t.Errorf("Expected delay within 2ms ± 25%%, got %v", d)
+		}
+	}
+
+	bad := []ColumnConnection{{From: ColumnL4E, To: "L6E", Probability: 0.1, Weight: 0.1}}
+	if _, err := NewColumn(&testBuilder{}, "bad", ColumnConfig{Size: 20, Connections: bad}); err == nil {
+		t.Error("Expected error for an unknown population")
+	}
+	bad = []ColumnConnection{{From: ColumnL4E, To: ColumnL23E, Probability: 1.5, Weight: 0.1}}
+	if _, err := NewColumn(&testBuilder{}, "bad", ColumnConfig{Size: 20, Connections: bad}); err == nil {
+		t.Error("Expected error for a probability above 1")
+	}
+}
@@ -0,0 +1,52 @@
+package network
+
+import (
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// =================================================================================
+// CONSOLIDATED SYNAPSES
+// =================================================================================
+
+// consolidatable is implemented by synapses with tagging and capture
+// (synapse.BasicSynapse).
+type consolidatable interface {
+	IsConsolidated() bool
+}
+
+// ConsolidatedSynapses returns the synapses whose weights are protected by
+// consolidation, sorted by ID.
+func (n *Network) ConsolidatedSynapses() []component.SynapticProcessor {
+	var consolidated []component.SynapticProcessor
+	for _, syn := range n.Synapses() {
+		if c, ok := syn.(consolidatable); ok && c.IsConsolidated() {
+			consolidated = append(consolidated, syn)
+		}
+	}
+	return consolidated
+}
+
+// ConsolidatedFraction is the fraction of synapses that are consolidated
+// (0 for a network without synapses).
+func (n *Network) ConsolidatedFraction() float64 {
+	total := len(n.source.ListSynapses())
+	if total == 0 {
+		return 0
+	}
+	return float64(len(n.ConsolidatedSynapses())) / float64(total)
+}
+
+// SynapseStages counts synapses per lifecycle stage (silent, active,
+// consolidated). Synapses without a lifecycle count as active.
+func (n *Network) SynapseStages() map[synapse.MaturationStage]int {
+	stages := make(map[synapse.MaturationStage]int)
+	for _, syn := range n.Synapses() {
+		if m, ok := syn.(synapse.Maturing); ok {
+			stages[m.GetMaturationStage()]++
+		} else {
+			stages[synapse.StageActive]++
+		}
+	}
+	return stages
+}
@@ -0,0 +1,41 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestConsolidatedSynapses verifies the consolidation query.
+func TestConsolidatedSynapses(t *testing.T) {
+	a, b := newTestNeuron("a"), newTestNeuron("b")
+	config := synapse.CreateDefaultConsolidationConfig()
+	config.Duration = time.Second
+	learned, err := synapse.NewSynapse("learned", a, b, synapse.WithWeight(1.5), synapse.WithConsolidation(config))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	start := time.Unix(0, 0)
+	for i := 0; i <= 2; i++ {
+		learned.ApplyPlasticity(types.PlasticityAdjustment{
+			DeltaT: -5 * time.Millisecond, LearningRate: 0.01, Timestamp: start.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	net := FromComponents([]component.NeuralComponent{a, b},
+		[]component.SynapticProcessor{learned, connect("static", a, b, 1.5, time.Millisecond)})
+	consolidated := net.ConsolidatedSynapses()
+	if len(consolidated) != 1 || consolidated[0].ID() != "learned" || net.ConsolidatedFraction() != 0.5 {
+		t.Errorf("Expected only 'learned' to be consolidated, got %d (fraction %f)", len(consolidated), net.ConsolidatedFraction())
+	}
+
+	nascent, _ := synapse.NewSynapse("nascent", a, b, synapse.WithSilentStart(0))
+	stages := FromComponents([]component.NeuralComponent{a, b},
+		[]component.SynapticProcessor{learned, nascent, connect("static", a, b, 1.5, time.Millisecond)}).SynapseStages()
+	if stages[synapse.StageSilent] != 1 || stages[synapse.StageActive] != 1 || stages[synapse.StageConsolidated] != 1 {
+		t.Errorf("Expected one synapse per stage, got %v", stages)
+	}
+}
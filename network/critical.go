@@ -0,0 +1,354 @@
+package network
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// =================================================================================
+// PLASTICITY SCHEDULES (CRITICAL PERIODS)
+// =================================================================================
+//
+// Developing circuits learn fastest during a critical period that opens and
+// closes at a different time in each area: visual cortex wires itself up
+// early, prefrontal cortex much later, and both keep a reduced adult
+// plasticity. A PlasticitySchedule models this. Each region is a set of
+// neurons, and the synapses onto them follow the region's CriticalPeriod:
+// their learning rate is scaled by Floor before Start, by Peak while the
+// period is open, and relaxes exponentially from Peak to Floor over Decay
+// after it closes:
+//
+//	scale(t) = Floor                                   t < Start
+//	           Peak                                    Start ≤ t < Start + Open
+//	           Floor + (Peak - Floor)·e^(-(t-Start-Open)/Decay)   later
+//
+// Periods can be given in code or declaratively as JSON:
+//
+//	[{"region": "V1", "open": "10m", "peak": 5, "decay": "20m", "floor": 0.2},
+//	 {"region": "PFC", "start": "30m", "open": "1h", "peak": 3, "floor": 0.5}]
+//
+// The schedule is applied when stepped, like a SilencingSchedule:
+//
+//	schedule := net.NewPlasticitySchedule(runner.Now())
+//	schedule.DefinePopulation(v1)
+//	schedule.Load(periods)
+//	runner.AddStepper("critical_periods", schedule.Step)
+//
+// Step writes each synapse's plasticity scale (synapse.PlasticityScalable),
+// which multiplies every learning rule's rate without touching its
+// configuration. A scale is only rewritten when it has moved by more than
+// PLASTICITY_SCHEDULE_TOLERANCE, and the network's synapse list is only
+// walked then or every PLASTICITY_SCHEDULE_REFRESH, so synapses grown
+// during the run are picked up without a walk on every tick. A synapse onto
+// a neuron in several regions follows the largest scale. Synapses onto
+// neurons outside every scheduled region are left alone.
+
+const (
+	// PLASTICITY_SCHEDULE_TOLERANCE is the relative change of a region's
+	// scale that triggers rewriting its synapses.
+	PLASTICITY_SCHEDULE_TOLERANCE = 0.01
+
+	// PLASTICITY_SCHEDULE_REFRESH is how often the schedule looks for new
+	// synapses while no scale is changing.
+	PLASTICITY_SCHEDULE_REFRESH = 1 * time.Second
+)
+
+// CriticalPeriod is one region's plasticity over simulated time.
+type CriticalPeriod struct {
+	Region string        `json:"region"`
+	Start  time.Duration `json:"-"`     // Offset from the schedule start at which the period opens
+	Open   time.Duration `json:"-"`     // How long the period stays fully open
+	Peak   float64       `json:"peak"`  // Learning rate scale while open
+	Decay  time.Duration `json:"-"`     // Time constant of the closing (0 = closes at once)
+	Floor  float64       `json:"floor"` // Scale before the period and in the adult
+}
+
+// Validate checks the period.
+func (c CriticalPeriod) Validate() error {
+	if c.Region == "" {
+		return fmt.Errorf("critical period needs a region")
+	}
+	if c.Start < 0 || c.Open < 0 || c.Decay < 0 {
+		return fmt.Errorf("critical period %s: durations cannot be negative", c.Region)
+	}
+	for name, v := range map[string]float64{"peak": c.Peak, "floor": c.Floor} {
+		if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("critical period %s: %s scale must be non-negative and finite: %f", c.Region, name, v)
+		}
+	}
+	return nil
+}
+
+// Scale returns the learning rate scale elapsed after the schedule start.
+func (c CriticalPeriod) Scale(elapsed time.Duration) float64 {
+	switch {
+	case elapsed < c.Start:
+		return c.Floor
+	case elapsed < c.Start+c.Open:
+		return c.Peak
+	case c.Decay == 0:
+		return c.Floor
+	}
+	since := elapsed - c.Start - c.Open
+	return c.Floor + (c.Peak-c.Floor)*math.Exp(-float64(since)/float64(c.Decay))
+}
+
+// MarshalJSON writes durations as strings ("10m").
+func (c CriticalPeriod) MarshalJSON() ([]byte, error) {
+	type plain CriticalPeriod
+	return json.Marshal(struct {
+		plain
+		Start string `json:"start,omitempty"`
+		Open  string `json:"open"`
+		Decay string `json:"decay,omitempty"`
+	}{plain: plain(c), Start: durationString(c.Start), Open: c.Open.String(), Decay: durationString(c.Decay)})
+}
+
+// UnmarshalJSON reads durations written as strings.
+func (c *CriticalPeriod) UnmarshalJSON(data []byte) error {
+	type plain CriticalPeriod
+	var wire struct {
+		plain
+		Start string `json:"start,omitempty"`
+		Open  string `json:"open"`
+		Decay string `json:"decay,omitempty"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*c = CriticalPeriod(wire.plain)
+	for _, field := range []struct {
+		text   string
+		target *time.Duration
+		name   string
+	}{{wire.Start, &c.Start, "start"}, {wire.Open, &c.Open, "open"}, {wire.Decay, &c.Decay, "decay"}} {
+		if field.text == "" {
+			continue
+		}
+		d, err := time.ParseDuration(field.text)
+		if err != nil {
+			return fmt.Errorf("critical period %s: %s: %w", c.Region, field.name, err)
+		}
+		*field.target = d
+	}
+	return nil
+}
+
+// durationString formats d, or returns "" for zero so it is omitted.
+func durationString(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+// ParseCriticalPeriods reads a JSON array of periods and validates each.
+func ParseCriticalPeriods(data []byte) ([]CriticalPeriod, error) {
+	var periods []CriticalPeriod
+	if err := json.Unmarshal(data, &periods); err != nil {
+		return nil, fmt.Errorf("parsing critical periods: %w", err)
+	}
+	for _, period := range periods {
+		if err := period.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return periods, nil
+}
+
+// PlasticitySchedule applies critical periods to the synapses of a network.
+type PlasticitySchedule struct {
+	network *Network
+	start   time.Time
+
+	mu       sync.Mutex
+	regions  map[string]map[string]bool // Region -> neuron IDs
+	periods  map[string]CriticalPeriod  // Region -> period
+	current  map[string]float64         // Region -> scale last written
+	applied  map[string]float64         // Synapse ID -> scale last written
+	walked   time.Time                  // Last walk over the synapses
+	written  int64
+	synapses int
+}
+
+// NewPlasticitySchedule creates a schedule for the network whose offsets
+// count from start.
+func (n *Network) NewPlasticitySchedule(start time.Time) *PlasticitySchedule {
+	return &PlasticitySchedule{
+		network: n,
+		start:   start,
+		regions: make(map[string]map[string]bool),
+		periods: make(map[string]CriticalPeriod),
+		current: make(map[string]float64),
+		applied: make(map[string]float64),
+	}
+}
+
+// DefineRegion names a set of neurons. Adding neurons to an existing region
+// extends it.
+func (s *PlasticitySchedule) DefineRegion(name string, neurons []component.NeuralComponent) error {
+	if name == "" {
+		return fmt.Errorf("region name cannot be empty")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	members := s.regions[name]
+	if members == nil {
+		members = make(map[string]bool, len(neurons))
+		s.regions[name] = members
+	}
+	for _, neuron := range neurons {
+		members[neuron.ID()] = true
+	}
+	delete(s.current, name) // Rewrite at the next step
+	return nil
+}
+
+// DefinePopulation defines a region named after the population.
+func (s *PlasticitySchedule) DefinePopulation(pop *Population) error {
+	if pop == nil {
+		return fmt.Errorf("plasticity schedule needs a population")
+	}
+	return s.DefineRegion(pop.ID(), pop.Neurons())
+}
+
+// SetCriticalPeriod schedules a defined region, replacing its previous
+// period.
+func (s *PlasticitySchedule) SetCriticalPeriod(period CriticalPeriod) error {
+	if err := period.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.regions[period.Region] == nil {
+		return fmt.Errorf("critical period for undefined region %s", period.Region)
+	}
+	s.periods[period.Region] = period
+	delete(s.current, period.Region)
+	return nil
+}
+
+// Load schedules every period of a JSON array (see ParseCriticalPeriods).
+// Nothing is scheduled if any period is invalid or names an undefined
+// region.
+func (s *PlasticitySchedule) Load(data []byte) error {
+	periods, err := ParseCriticalPeriods(data)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	for _, period := range periods {
+		if s.regions[period.Region] == nil {
+			s.mu.Unlock()
+			return fmt.Errorf("critical period for undefined region %s", period.Region)
+		}
+	}
+	s.mu.Unlock()
+	for _, period := range periods {
+		if err := s.SetCriticalPeriod(period); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Periods returns the scheduled periods sorted by region.
+func (s *PlasticitySchedule) Periods() []CriticalPeriod {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	periods := make([]CriticalPeriod, 0, len(s.periods))
+	for _, period := range s.periods {
+		periods = append(periods, period)
+	}
+	sort.Slice(periods, func(i, j int) bool { return periods[i].Region < periods[j].Region })
+	return periods
+}
+
+// Scale returns a region's scale at now (1 for unscheduled regions).
+func (s *PlasticitySchedule) Scale(region string, now time.Time) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	period, ok := s.periods[region]
+	if !ok {
+		return 1
+	}
+	return period.Scale(now.Sub(s.start))
+}
+
+// Step writes the plasticity scales for time now.
+func (s *PlasticitySchedule) Step(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elapsed := now.Sub(s.start)
+	changed := false
+	scales := make(map[string]float64, len(s.periods))
+	for region, period := range s.periods {
+		scale := period.Scale(elapsed)
+		last, written := s.current[region]
+		if !written || math.Abs(scale-last) > PLASTICITY_SCHEDULE_TOLERANCE*math.Max(last, scale) {
+			changed = true
+		} else {
+			scale = last
+		}
+		scales[region] = scale
+	}
+	if len(scales) == 0 || (!changed && now.Sub(s.walked) < PLASTICITY_SCHEDULE_REFRESH) {
+		return
+	}
+
+	s.synapses = 0
+	for _, syn := range s.network.Synapses() {
+		scalable, ok := syn.(synapse.PlasticityScalable)
+		if !ok {
+			continue
+		}
+		post := syn.GetPostsynapticID()
+		scale, member := 0.0, false
+		for region, regionScale := range scales {
+			if s.regions[region][post] {
+				scale, member = math.Max(scale, regionScale), true
+			}
+		}
+		if !member {
+			continue
+		}
+		s.synapses++
+		if last, ok := s.applied[syn.ID()]; ok && last == scale {
+			continue
+		}
+		if scalable.SetPlasticityScale(scale) == nil {
+			s.applied[syn.ID()] = scale
+			s.written++
+		}
+	}
+	for region, scale := range scales {
+		s.current[region] = scale
+	}
+	s.walked = now
+}
+
+// GetStats returns the regions, their current scales and the synapses
+// scheduled.
+func (s *PlasticitySchedule) GetStats() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	scales := make(map[string]float64, len(s.current))
+	for region, scale := range s.current {
+		scales[region] = scale
+	}
+	return map[string]interface{}{
+		"regions":           len(s.regions),
+		"scheduled_regions": len(s.periods),
+		"scales":            scales,
+		"synapses":          s.synapses,
+		"scale_writes":      s.written,
+	}
+}
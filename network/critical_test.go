@@ -0,0 +1,71 @@
+package network
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestPlasticityScheduleCriticalPeriods verifies that a declarative
+// schedule opens and closes a region's critical period on a lock-step
+// clock and leaves synapses onto other regions alone.
+func TestPlasticityScheduleCriticalPeriods(t *testing.T) {
+	builder := &testBuilder{}
+	cell := types.NeuronConfig{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond, FireFactor: 1.0}
+	input, _ := NewPopulation(builder, "input", PopulationConfig{Size: 2, Neuron: cell})
+	v1, _ := NewPopulation(builder, "v1", PopulationConfig{Size: 1, Neuron: cell})
+	pfc, _ := NewPopulation(builder, "pfc", PopulationConfig{Size: 1, Neuron: cell})
+	toV1, _ := input.ConnectAllToAll(v1, ConstantWeight(0.5), nil)
+	toPFC, _ := input.ConnectAllToAll(pfc, ConstantWeight(0.5), nil)
+	sensory := toV1.Synapses()[0].(*synapse.BasicSynapse)
+	frontal := toPFC.Synapses()[0].(*synapse.BasicSynapse)
+
+	runner, _ := cosim.NewLockStep(time.Unix(0, 0), 100*time.Millisecond)
+	schedule := New(builder).NewPlasticitySchedule(runner.Now())
+	schedule.DefinePopulation(v1)
+	if err := schedule.Load([]byte(`[{"region": "v1", "start": "1s", "open": "2s", "peak": 4, "decay": "1s", "floor": 0.5}]`)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := schedule.Load([]byte(`[{"region": "pfc", "open": "1s", "peak": 2}]`)); err == nil {
+		t.Error("Expected error for an undefined region")
+	}
+	if _, err := ParseCriticalPeriods([]byte(`[{"region": "v1", "open": "1s", "peak": -1}]`)); err == nil {
+		t.Error("Expected error for a negative scale")
+	}
+	runner.AddStepper("critical_periods", schedule.Step)
+
+	expect := func(when string, scale float64) {
+		t.Helper()
+		if got := sensory.GetPlasticityScale(); math.Abs(got-scale) > PLASTICITY_SCHEDULE_TOLERANCE*scale {
+			t.Errorf("%s: expected scale %.3f, got %.3f", when, scale, got)
+		}
+		if frontal.GetPlasticityScale() != 1 {
+			t.Errorf("%s: expected the unscheduled region left alone", when)
+		}
+	}
+	runner.Step(500 * time.Millisecond)
+	expect("before the period", 0.5)
+	runner.Step(time.Second) // t = 1.5s
+	expect("while open", 4)
+	runner.Step(2 * time.Second) // t = 3.5s, 0.5s after closing
+	expect("closing", 0.5+3.5*math.Exp(-0.5))
+	runner.Step(10 * time.Second)
+	expect("adult", 0.5)
+
+	periods := schedule.Periods()
+	if len(periods) != 1 || periods[0].Decay != time.Second {
+		t.Fatalf("Unexpected periods %+v", periods)
+	}
+	data, _ := json.Marshal(periods)
+	if parsed, err := ParseCriticalPeriods(data); err != nil || parsed[0] != periods[0] {
+		t.Errorf("Expected the periods to round-trip through JSON, got %+v (%v) from %s", parsed, err, data)
+	}
+	if stats := schedule.GetStats(); stats["synapses"].(int) != 2 {
+		t.Errorf("Expected 2 scheduled synapses, got %v", stats)
+	}
+}
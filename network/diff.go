@@ -0,0 +1,227 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =================================================================================
+// STATE SNAPSHOTS AND DIFFS
+// =================================================================================
+//
+// Learning experiments are hard to regression-test: the outcome is a few
+// thousand weights, not a single number. A Snapshot records the learned state
+// (weights, delays and thresholds) and Diff compares two snapshots, ignoring
+// changes below an epsilon. Snapshots marshal to JSON, so a test can store a
+// golden file once and compare every later run against it:
+//
+//	runLearningTrial()
+//	var golden network.Snapshot
+//	json.Unmarshal(goldenJSON, &golden)
+//	diff := network.Diff(&golden, net.Snapshot(), network.DefaultDiffConfig())
+//	if !diff.IsEmpty() {
+//	    t.Errorf("learning changed:\n%s", diff)
+//	}
+
+const (
+	// DIFF_DEFAULT_WEIGHT_EPSILON ignores weight changes at floating-point
+	// noise level.
+	DIFF_DEFAULT_WEIGHT_EPSILON = 1e-9
+
+	// DIFF_DEFAULT_THRESHOLD_EPSILON ignores threshold changes at
+	// floating-point noise level.
+	DIFF_DEFAULT_THRESHOLD_EPSILON = 1e-9
+)
+
+// NeuronState is the part of a neuron's state a Snapshot records.
+type NeuronState struct {
+	Threshold float64 `json:"threshold"`
+}
+
+// SynapseState is the part of a synapse's state a Snapshot records.
+type SynapseState struct {
+	PreID  string        `json:"pre_id"`
+	PostID string        `json:"post_id"`
+	Weight float64       `json:"weight"`
+	Delay  time.Duration `json:"delay"`
+}
+
+// Snapshot is a copy of a network's learned state, keyed by component ID.
+type Snapshot struct {
+	Time     time.Time               `json:"time"`
+	Neurons  map[string]NeuronState  `json:"neurons"`
+	Synapses map[string]SynapseState `json:"synapses"`
+}
+
+// Snapshot records the current thresholds, weights and delays. Like
+// ExportWeights it reads each component under its own lock.
+func (n *Network) Snapshot() *Snapshot {
+	s := &Snapshot{
+		Time:     time.Now(),
+		Neurons:  make(map[string]NeuronState),
+		Synapses: make(map[string]SynapseState),
+	}
+	for _, neuron := range n.source.ListNeurons() {
+		state := NeuronState{}
+		if t, ok := neuron.(thresholdSource); ok {
+			state.Threshold = t.GetThreshold()
+		}
+		s.Neurons[neuron.ID()] = state
+	}
+	for _, syn := range n.source.ListSynapses() {
+		s.Synapses[syn.ID()] = SynapseState{
+			PreID:  syn.GetPresynapticID(),
+			PostID: syn.GetPostsynapticID(),
+			Weight: syn.GetWeight(),
+			Delay:  syn.GetDelay(),
+		}
+	}
+	return s
+}
+
+// DiffConfig sets the tolerances of Diff.
+type DiffConfig struct {
+	WeightEpsilon    float64 // Weight changes up to this size are ignored
+	ThresholdEpsilon float64 // Threshold changes up to this size are ignored
+}
+
+// DefaultDiffConfig ignores only floating-point noise.
+func DefaultDiffConfig() DiffConfig {
+	return DiffConfig{
+		WeightEpsilon:    DIFF_DEFAULT_WEIGHT_EPSILON,
+		ThresholdEpsilon: DIFF_DEFAULT_THRESHOLD_EPSILON,
+	}
+}
+
+// ValueChange is a changed weight or threshold.
+type ValueChange struct {
+	ID     string  `json:"id"`
+	Before float64 `json:"before"`
+	After  float64 `json:"after"`
+}
+
+// Delta returns After - Before.
+func (c ValueChange) Delta() float64 {
+	return c.After - c.Before
+}
+
+// DelayChange is a changed synaptic delay.
+type DelayChange struct {
+	ID     string        `json:"id"`
+	Before time.Duration `json:"before"`
+	After  time.Duration `json:"after"`
+}
+
+// StateDiff lists the differences between two snapshots. All lists are
+// sorted by ID, so equal diffs compare equal.
+type StateDiff struct {
+	NeuronsAdded     []string      `json:"neurons_added,omitempty"`
+	NeuronsRemoved   []string      `json:"neurons_removed,omitempty"`
+	SynapsesAdded    []string      `json:"synapses_added,omitempty"`
+	SynapsesRemoved  []string      `json:"synapses_removed,omitempty"`
+	WeightChanges    []ValueChange `json:"weight_changes,omitempty"`
+	ThresholdChanges []ValueChange `json:"threshold_changes,omitempty"`
+	DelayChanges     []DelayChange `json:"delay_changes,omitempty"`
+}
+
+// Diff compares snapshot before with snapshot after. A synapse whose
+// endpoints changed is reported as removed and added.
+func Diff(before, after *Snapshot, config DiffConfig) *StateDiff {
+	d := &StateDiff{}
+
+	for id, old := range before.Neurons {
+		current, ok := after.Neurons[id]
+		if !ok {
+			d.NeuronsRemoved = append(d.NeuronsRemoved, id)
+			continue
+		}
+		if changed(old.Threshold, current.Threshold, config.ThresholdEpsilon) {
+			d.ThresholdChanges = append(d.ThresholdChanges, ValueChange{ID: id, Before: old.Threshold, After: current.Threshold})
+		}
+	}
+	for id := range after.Neurons {
+		if _, ok := before.Neurons[id]; !ok {
+			d.NeuronsAdded = append(d.NeuronsAdded, id)
+		}
+	}
+
+	for id, old := range before.Synapses {
+		current, ok := after.Synapses[id]
+		if !ok || current.PreID != old.PreID || current.PostID != old.PostID {
+			d.SynapsesRemoved = append(d.SynapsesRemoved, id)
+			if ok {
+				d.SynapsesAdded = append(d.SynapsesAdded, id)
+			}
+			continue
+		}
+		if changed(old.Weight, current.Weight, config.WeightEpsilon) {
+			d.WeightChanges = append(d.WeightChanges, ValueChange{ID: id, Before: old.Weight, After: current.Weight})
+		}
+		if old.Delay != current.Delay {
+			d.DelayChanges = append(d.DelayChanges, DelayChange{ID: id, Before: old.Delay, After: current.Delay})
+		}
+	}
+	for id := range after.Synapses {
+		if _, ok := before.Synapses[id]; !ok {
+			d.SynapsesAdded = append(d.SynapsesAdded, id)
+		}
+	}
+
+	sort.Strings(d.NeuronsAdded)
+	sort.Strings(d.NeuronsRemoved)
+	sort.Strings(d.SynapsesAdded)
+	sort.Strings(d.SynapsesRemoved)
+	sort.Slice(d.WeightChanges, func(i, j int) bool { return d.WeightChanges[i].ID < d.WeightChanges[j].ID })
+	sort.Slice(d.ThresholdChanges, func(i, j int) bool { return d.ThresholdChanges[i].ID < d.ThresholdChanges[j].ID })
+	sort.Slice(d.DelayChanges, func(i, j int) bool { return d.DelayChanges[i].ID < d.DelayChanges[j].ID })
+	return d
+}
+
+// changed reports a difference beyond epsilon. NaN differs from everything
+// except NaN.
+func changed(before, after, epsilon float64) bool {
+	if math.IsNaN(before) || math.IsNaN(after) {
+		return math.IsNaN(before) != math.IsNaN(after)
+	}
+	return math.Abs(after-before) > epsilon
+}
+
+// IsEmpty reports whether the snapshots matched within the tolerances.
+func (d *StateDiff) IsEmpty() bool {
+	return len(d.NeuronsAdded) == 0 && len(d.NeuronsRemoved) == 0 &&
+		len(d.SynapsesAdded) == 0 && len(d.SynapsesRemoved) == 0 &&
+		len(d.WeightChanges) == 0 && len(d.ThresholdChanges) == 0 && len(d.DelayChanges) == 0
+}
+
+// String lists the differences, one per line.
+func (d *StateDiff) String() string {
+	if d.IsEmpty() {
+		return "no differences"
+	}
+	var b strings.Builder
+	for _, id := range d.NeuronsAdded {
+		fmt.Fprintf(&b, "+ neuron %s\n", id)
+	}
+	for _, id := range d.NeuronsRemoved {
+		fmt.Fprintf(&b, "- neuron %s\n", id)
+	}
+	for _, id := range d.SynapsesAdded {
+		fmt.Fprintf(&b, "+ synapse %s\n", id)
+	}
+	for _, id := range d.SynapsesRemoved {
+		fmt.Fprintf(&b, "- synapse %s\n", id)
+	}
+	for _, c := range d.WeightChanges {
+		fmt.Fprintf(&b, "~ weight %s: %g -> %g (%+g)\n", c.ID, c.Before, c.After, c.Delta())
+	}
+	for _, c := range d.ThresholdChanges {
+		fmt.Fprintf(&b, "~ threshold %s: %g -> %g (%+g)\n", c.ID, c.Before, c.After, c.Delta())
+	}
+	for _, c := range d.DelayChanges {
+		fmt.Fprintf(&b, "~ delay %s: %v -> %v\n", c.ID, c.Before, c.After)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,57 @@
+package network
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+)
+
+// TestSnapshotDiff verifies that diffs report added and removed components,
+// changes beyond epsilon, and survive a JSON round trip of the golden state.
+func TestSnapshotDiff(t *testing.T) {
+	a, b, c := newTestNeuron("a"), newTestNeuron("b"), newTestNeuron("c")
+	ab := connect("ab", a, b, 0.5, time.Millisecond)
+	bc := connect("bc", b, c, 0.5, time.Millisecond)
+	net := FromComponents([]component.NeuralComponent{a, b, c}, []component.SynapticProcessor{ab, bc})
+
+	data, err := json.Marshal(net.Snapshot())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var golden Snapshot
+	if err := json.Unmarshal(data, &golden); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if diff := Diff(&golden, net.Snapshot(), DefaultDiffConfig()); !diff.IsEmpty() {
+		t.Fatalf("Expected no differences, got:\n%s", diff)
+	}
+
+	ab.SetWeight(0.5 + 1e-12)
+	bc.SetWeight(0.8)
+	d := newTestNeuron("d")
+	ca := connect("ca", c, a, 0.3, time.Millisecond)
+	changed := FromComponents([]component.NeuralComponent{a, b, d}, []component.SynapticProcessor{ab, bc, ca})
+
+	diff := Diff(&golden, changed.Snapshot(), DefaultDiffConfig())
+	if len(diff.WeightChanges) != 1 || diff.WeightChanges[0].ID != "bc" || math.Abs(diff.WeightChanges[0].Delta()-0.3) > 1e-9 {
+		t.Errorf("Expected only bc beyond epsilon, got %+v", diff.WeightChanges)
+	}
+	if len(diff.SynapsesAdded) != 1 || diff.SynapsesAdded[0] != "ca" || len(diff.SynapsesRemoved) != 0 {
+		t.Errorf("Expected ca added, got %+v", diff)
+	}
+	if len(diff.NeuronsAdded) != 1 || diff.NeuronsAdded[0] != "d" || len(diff.NeuronsRemoved) != 1 || diff.NeuronsRemoved[0] != "c" {
+		t.Errorf("Expected d added and c removed, got %+v", diff)
+	}
+	if !strings.Contains(diff.String(), "~ weight bc: 0.5 -> 0.8") {
+		t.Errorf("Unexpected diff text:\n%s", diff)
+	}
+
+	loose := Diff(&golden, changed.Snapshot(), DiffConfig{WeightEpsilon: 0.5})
+	if len(loose.WeightChanges) != 0 {
+		t.Errorf("Expected weight changes within a loose epsilon to be ignored, got %+v", loose.WeightChanges)
+	}
+}
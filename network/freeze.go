@@ -0,0 +1,116 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// PLASTICITY FREEZE
+// =================================================================================
+//
+// Evaluation phases need fixed weights. FreezePlasticity turns STDP off on
+// every synapse and remembers each synapse's own Enabled flag, so Unfreeze
+// restores static and plastic synapses alike. Only the flag is saved: other
+// plasticity parameters changed during the freeze are kept. Freezes nest;
+// the network is restored when the outermost freeze ends. Synapses created
+// during a freeze are not affected by it.
+
+// plasticityConfigurable is implemented by synapses with switchable STDP
+// (synapse.BasicSynapse).
+type plasticityConfigurable interface {
+	GetPlasticityConfig() types.PlasticityConfig
+	SetPlasticityConfig(config types.PlasticityConfig) error
+}
+
+// FreezePlasticity disables STDP on all synapses until the matching Unfreeze.
+func (n *Network) FreezePlasticity() error {
+	n.freezeMutex.Lock()
+	defer n.freezeMutex.Unlock()
+
+	if n.freezeDepth > 0 {
+		n.freezeDepth++
+		return nil
+	}
+
+	frozen := make(map[string]bool)
+	for _, syn := range n.Synapses() {
+		plastic, ok := syn.(plasticityConfigurable)
+		if !ok {
+			continue
+		}
+		config := plastic.GetPlasticityConfig()
+		frozen[syn.ID()] = config.Enabled
+		if !config.Enabled {
+			continue
+		}
+		config.Enabled = false
+		if err := plastic.SetPlasticityConfig(config); err != nil {
+			n.restorePlasticity(frozen)
+			return fmt.Errorf("failed to freeze synapse %s: %w", syn.ID(), err)
+		}
+	}
+	n.frozen = frozen
+	n.freezeDepth = 1
+	return nil
+}
+
+// Unfreeze ends a FreezePlasticity. The outermost call restores each
+// synapse's previous STDP setting.
+func (n *Network) Unfreeze() error {
+	n.freezeMutex.Lock()
+	defer n.freezeMutex.Unlock()
+
+	if n.freezeDepth == 0 {
+		return fmt.Errorf("plasticity is not frozen")
+	}
+	n.freezeDepth--
+	if n.freezeDepth > 0 {
+		return nil
+	}
+	err := n.restorePlasticity(n.frozen)
+	n.frozen = nil
+	return err
+}
+
+// IsPlasticityFrozen reports whether a freeze is in effect.
+func (n *Network) IsPlasticityFrozen() bool {
+	n.freezeMutex.Lock()
+	defer n.freezeMutex.Unlock()
+	return n.freezeDepth > 0
+}
+
+// WithFrozenPlasticity runs fn with plasticity frozen and unfreezes
+// afterwards, also when fn panics.
+func (n *Network) WithFrozenPlasticity(fn func()) (err error) {
+	if err := n.FreezePlasticity(); err != nil {
+		return err
+	}
+	defer func() {
+		if unfreezeErr := n.Unfreeze(); err == nil {
+			err = unfreezeErr
+		}
+	}()
+	fn()
+	return nil
+}
+
+// restorePlasticity sets the saved Enabled flags back. Must be called with
+// freezeMutex held.
+func (n *Network) restorePlasticity(saved map[string]bool) error {
+	var firstErr error
+	for _, syn := range n.Synapses() {
+		enabled, ok := saved[syn.ID()]
+		plastic, configurable := syn.(plasticityConfigurable)
+		if !ok || !enabled || !configurable {
+			continue
+		}
+		config := plastic.GetPlasticityConfig()
+		config.Enabled = true
+		if err := plastic.SetPlasticityConfig(config); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to unfreeze synapse %s: %w", syn.ID(), err)
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,59 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestFreezePlasticity verifies that a freeze disables STDP everywhere, nests,
+// and restores each synapse's own setting, also after a panic.
+func TestFreezePlasticity(t *testing.T) {
+	a, b := newTestNeuron("a"), newTestNeuron("b")
+	plastic := synapse.NewBasicSynapse("plastic", a, b, synapse.CreateDefaultSTDPConfig(),
+		synapse.CreateDefaultPruningConfig(), 0.5, time.Millisecond)
+	static := connect("static", a, b, 0.5, time.Millisecond)
+	net := FromComponents([]component.NeuralComponent{a, b}, []component.SynapticProcessor{plastic, static})
+
+	if err := net.Unfreeze(); err == nil {
+		t.Error("Expected Unfreeze without a freeze to fail")
+	}
+	if err := net.FreezePlasticity(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plastic.GetPlasticityConfig().Enabled || !net.IsPlasticityFrozen() {
+		t.Fatal("Expected STDP disabled during the freeze")
+	}
+	plastic.ApplyPlasticity(types.PlasticityAdjustment{DeltaT: -5 * time.Millisecond, LearningRate: 0.1})
+	if plastic.GetWeight() != 0.5 {
+		t.Errorf("Expected frozen weight 0.5, got %f", plastic.GetWeight())
+	}
+
+	// A nested freeze keeps the outer one in effect
+	if err := net.WithFrozenPlasticity(func() {}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if plastic.GetPlasticityConfig().Enabled {
+		t.Error("Expected inner unfreeze to leave plasticity frozen")
+	}
+	if err := net.Unfreeze(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !plastic.GetPlasticityConfig().Enabled {
+		t.Error("Expected STDP restored on the plastic synapse")
+	}
+	if static.(*synapse.BasicSynapse).GetPlasticityConfig().Enabled {
+		t.Error("Expected the static synapse to stay static")
+	}
+
+	func() {
+		defer func() { recover() }()
+		net.WithFrozenPlasticity(func() { panic("evaluation failed") })
+	}()
+	if !plastic.GetPlasticityConfig().Enabled || net.IsPlasticityFrozen() {
+		t.Error("Expected plasticity restored after a panic")
+	}
+}
@@ -0,0 +1,493 @@
+package network
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// =================================================================================
+// GRAPH EXPORT (DOT, GRAPHML, GEXF)
+// =================================================================================
+//
+// Graph visualization tools read standard formats: Graphviz reads DOT,
+// yEd and Cytoscape read GraphML, and Gephi reads GEXF, including dynamic
+// graphs that animate over time. Graph() captures the network as nodes and
+// edges with their attributes, and the writers emit one format each:
+//
+//	graph := net.Graph()
+//	graph.WriteGraphML(file) // or WriteDOT, WriteGEXF
+//
+// Learning is best watched over time. A GraphRecorder captures time slices
+// during a run and writes them as one dynamic GEXF file, in which every
+// weight, threshold and firing rate changes from slice to slice and synapses
+// appear and disappear as they are created and pruned:
+//
+//	recorder := network.NewGraphRecorder(net)
+//	for epoch := 0; epoch < epochs; epoch++ {
+//	    train()
+//	    recorder.Record(time.Now())
+//	}
+//	recorder.WriteGEXF(file)
+
+// GRAPH_NODE_TYPE_KEY is the metadata key read for a node's type. Neurons
+// without it are typed by their component type.
+const GRAPH_NODE_TYPE_KEY = "neuron_type"
+
+// GraphNode is a neuron and its attributes.
+type GraphNode struct {
+	ID         string  `json:"id"`
+	Type       string  `json:"type"`
+	Threshold  float64 `json:"threshold"`   // 0 when the neuron does not expose one
+	FiringRate float64 `json:"firing_rate"` // Hz
+	X, Y, Z    float64 `json:"-"`           // Position
+}
+
+// GraphEdge is a synapse and its attributes.
+type GraphEdge struct {
+	ID           string        `json:"id"`
+	Source       string        `json:"source"`
+	Target       string        `json:"target"`
+	Weight       float64       `json:"weight"`
+	Delay        time.Duration `json:"delay"`
+	LastActivity time.Time     `json:"last_activity"` // Zero when never active
+}
+
+// Graph is a network captured for export. Nodes and edges are sorted by ID.
+type Graph struct {
+	Time  time.Time   `json:"time"`
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// Graph captures the network's neurons and synapses with their current
+// attributes. Each component is read under its own lock, so on a running
+// network the graph is not an atomic snapshot.
+func (n *Network) Graph() *Graph {
+	g := &Graph{Time: time.Now()}
+	for _, neuron := range n.source.ListNeurons() {
+		node := GraphNode{
+			ID:         neuron.ID(),
+			Type:       neuron.Type().String(),
+			FiringRate: neuron.GetActivityLevel(),
+		}
+		if nodeType, ok := neuron.GetMetadata()[GRAPH_NODE_TYPE_KEY].(string); ok && nodeType != "" {
+			node.Type = nodeType
+		}
+		if t, ok := neuron.(thresholdSource); ok {
+			node.Threshold = t.GetThreshold()
+		}
+		position := neuron.Position()
+		node.X, node.Y, node.Z = position.X, position.Y, position.Z
+		g.Nodes = append(g.Nodes, node)
+	}
+	for _, syn := range n.source.ListSynapses() {
+		g.Edges = append(g.Edges, GraphEdge{
+			ID:           syn.ID(),
+			Source:       syn.GetPresynapticID(),
+			Target:       syn.GetPostsynapticID(),
+			Weight:       syn.GetWeight(),
+			Delay:        syn.GetDelay(),
+			LastActivity: syn.GetLastActivity(),
+		})
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	sort.Slice(g.Edges, func(i, j int) bool { return g.Edges[i].ID < g.Edges[j].ID })
+	return g
+}
+
+// formatFloat formats attribute values compactly and round-trippably.
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// delayMs returns a delay in milliseconds, the unit all formats use.
+func delayMs(d time.Duration) string {
+	return formatFloat(float64(d) / float64(time.Millisecond))
+}
+
+// lastActivity formats a timestamp as RFC 3339 ("" when zero).
+func lastActivity(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// =================================================================================
+// DOT
+// =================================================================================
+
+// WriteDOT writes the graph in Graphviz DOT format. Edge pen widths follow
+// the weight magnitudes, so `dot -Tsvg` shows strong synapses, excitatory or
+// inhibitory, as thick lines.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph network {\n")
+	for _, node := range g.Nodes {
+		fmt.Fprintf(&b, "  %s [type=%s, threshold=%s, firing_rate=%s];\n",
+			dotQuote(node.ID), dotQuote(node.Type), dotNumber(node.Threshold), dotNumber(node.FiringRate))
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [id=%s, weight=%s, delay_ms=%s, penwidth=%s",
+			dotQuote(edge.Source), dotQuote(edge.Target), dotQuote(edge.ID),
+			dotNumber(edge.Weight), dotNumber(float64(edge.Delay)/float64(time.Millisecond)),
+			dotNumber(0.5+math.Abs(edge.Weight)))
+		if at := lastActivity(edge.LastActivity); at != "" {
+			fmt.Fprintf(&b, ", last_activity=%s", dotQuote(at))
+		}
+		b.WriteString("];\n")
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dotQuote returns s as a DOT quoted string. DOT only escapes the quote
+// (and the backslash before it); other characters, including non-ASCII
+// ones, are written as they are.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// dotNumber formats v as a DOT numeral, which has no exponent form.
+func dotNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// =================================================================================
+// GRAPHML
+// =================================================================================
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLDocument struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   struct {
+		ID          string        `xml:"id,attr"`
+		EdgeDefault string        `xml:"edgedefault,attr"`
+		Nodes       []graphMLNode `xml:"node"`
+		Edges       []graphMLEdge `xml:"edge"`
+	} `xml:"graph"`
+}
+
+// WriteGraphML writes the graph in GraphML format. Delays are in
+// milliseconds and last activity is an RFC 3339 timestamp.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	doc := graphMLDocument{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "type", For: "node", AttrName: "type", AttrType: "string"},
+			{ID: "threshold", For: "node", AttrName: "threshold", AttrType: "double"},
+			{ID: "firing_rate", For: "node", AttrName: "firing_rate", AttrType: "double"},
+			{ID: "weight", For: "edge", AttrName: "weight", AttrType: "double"},
+			{ID: "delay_ms", For: "edge", AttrName: "delay_ms", AttrType: "double"},
+			{ID: "last_activity", For: "edge", AttrName: "last_activity", AttrType: "string"},
+		},
+	}
+	doc.Graph.ID = "network"
+	doc.Graph.EdgeDefault = "directed"
+	for _, node := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{ID: node.ID, Data: []graphMLData{
+			{Key: "type", Value: node.Type},
+			{Key: "threshold", Value: formatFloat(node.Threshold)},
+			{Key: "firing_rate", Value: formatFloat(node.FiringRate)},
+		}})
+	}
+	for _, edge := range g.Edges {
+		data := []graphMLData{
+			{Key: "weight", Value: formatFloat(edge.Weight)},
+			{Key: "delay_ms", Value: delayMs(edge.Delay)},
+		}
+		if at := lastActivity(edge.LastActivity); at != "" {
+			data = append(data, graphMLData{Key: "last_activity", Value: at})
+		}
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{ID: edge.ID, Source: edge.Source, Target: edge.Target, Data: data})
+	}
+	return writeXML(w, doc)
+}
+
+// writeXML writes an indented XML document with declaration.
+func writeXML(w io.Writer, doc interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// =================================================================================
+// GEXF
+// =================================================================================
+
+type gexfAttribute struct {
+	ID    string `xml:"id,attr"`
+	Title string `xml:"title,attr"`
+	Type  string `xml:"type,attr"`
+}
+
+type gexfAttributes struct {
+	Class      string          `xml:"class,attr"`
+	Mode       string          `xml:"mode,attr,omitempty"`
+	Attributes []gexfAttribute `xml:"attribute"`
+}
+
+type gexfValue struct {
+	For   string `xml:"for,attr"`
+	Value string `xml:"value,attr"`
+	Start string `xml:"start,attr,omitempty"`
+	End   string `xml:"end,attr,omitempty"`
+}
+
+type gexfSpell struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+type gexfSpells struct {
+	Spells []gexfSpell `xml:"spell"`
+}
+
+type gexfNode struct {
+	ID       string        `xml:"id,attr"`
+	Label    string        `xml:"label,attr"`
+	Values   []gexfValue   `xml:"attvalues>attvalue"`
+	Spells   *gexfSpells   `xml:"spells"`
+	Position *gexfPosition `xml:"viz:position"`
+}
+
+type gexfPosition struct {
+	X float64 `xml:"x,attr"`
+	Y float64 `xml:"y,attr"`
+	Z float64 `xml:"z,attr"`
+}
+
+type gexfEdge struct {
+	ID     string      `xml:"id,attr"`
+	Source string      `xml:"source,attr"`
+	Target string      `xml:"target,attr"`
+	Weight string      `xml:"weight,attr"`
+	Values []gexfValue `xml:"attvalues>attvalue"`
+	Spells *gexfSpells `xml:"spells"`
+}
+
+type gexfDocument struct {
+	XMLName xml.Name `xml:"gexf"`
+	XMLNS   string   `xml:"xmlns,attr"`
+	VizNS   string   `xml:"xmlns:viz,attr"`
+	Version string   `xml:"version,attr"`
+	Graph   struct {
+		Mode            string           `xml:"mode,attr"`
+		DefaultEdgeType string           `xml:"defaultedgetype,attr"`
+		TimeFormat      string           `xml:"timeformat,attr,omitempty"`
+		Attributes      []gexfAttributes `xml:"attributes"`
+		Nodes           []gexfNode       `xml:"nodes>node"`
+		Edges           []gexfEdge       `xml:"edges>edge"`
+	} `xml:"graph"`
+}
+
+// newGEXFDocument returns an empty GEXF 1.3 document with the attribute
+// declarations (mode "static" or "dynamic").
+func newGEXFDocument(mode string) *gexfDocument {
+	doc := &gexfDocument{XMLNS: "http://gexf.net/1.3", VizNS: "http://gexf.net/1.3/viz", Version: "1.3"}
+	doc.Graph.Mode = mode
+	doc.Graph.DefaultEdgeType = "directed"
+	attributeMode := ""
+	if mode == "dynamic" {
+		doc.Graph.TimeFormat = "double"
+		attributeMode = "dynamic"
+	}
+	doc.Graph.Attributes = []gexfAttributes{
+		{Class: "node", Mode: attributeMode, Attributes: []gexfAttribute{
+			{ID: "type", Title: "type", Type: "string"},
+			{ID: "threshold", Title: "threshold", Type: "double"},
+			{ID: "firing_rate", Title: "firing_rate", Type: "double"},
+		}},
+		{Class: "edge", Mode: attributeMode, Attributes: []gexfAttribute{
+			{ID: "delay_ms", Title: "delay_ms", Type: "double"},
+			{ID: "last_activity", Title: "last_activity", Type: "string"},
+		}},
+	}
+	return doc
+}
+
+// nodeValues returns a node's attribute values, limited to [start, end)
+// when start is not empty.
+func nodeValues(node GraphNode, start, end string) []gexfValue {
+	return []gexfValue{
+		{For: "type", Value: node.Type, Start: start, End: end},
+		{For: "threshold", Value: formatFloat(node.Threshold), Start: start, End: end},
+		{For: "firing_rate", Value: formatFloat(node.FiringRate), Start: start, End: end},
+	}
+}
+
+// edgeValues returns an edge's attribute values, limited to [start, end)
+// when start is not empty. Weights are GEXF's own edge weight; in dynamic
+// graphs they are also kept as the "weight" attribute Gephi animates.
+func edgeValues(edge GraphEdge, start, end string) []gexfValue {
+	values := []gexfValue{{For: "delay_ms", Value: delayMs(edge.Delay), Start: start, End: end}}
+	if at := lastActivity(edge.LastActivity); at != "" {
+		values = append(values, gexfValue{For: "last_activity", Value: at, Start: start, End: end})
+	}
+	if start != "" {
+		values = append(values, gexfValue{For: "weight", Value: formatFloat(edge.Weight), Start: start, End: end})
+	}
+	return values
+}
+
+// WriteGEXF writes the graph in static GEXF 1.3 format, with neuron
+// positions as viz coordinates.
+func (g *Graph) WriteGEXF(w io.Writer) error {
+	doc := newGEXFDocument("static")
+	for _, node := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, gexfNode{
+			ID: node.ID, Label: node.ID, Values: nodeValues(node, "", ""),
+			Position: &gexfPosition{X: node.X, Y: node.Y, Z: node.Z},
+		})
+	}
+	for _, edge := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, gexfEdge{
+			ID: edge.ID, Source: edge.Source, Target: edge.Target,
+			Weight: formatFloat(edge.Weight), Values: edgeValues(edge, "", ""),
+		})
+	}
+	return writeXML(w, doc)
+}
+
+// =================================================================================
+// DYNAMIC GRAPHS
+// =================================================================================
+
+// GraphRecorder captures time slices of a network for a dynamic GEXF graph.
+// It is not safe for concurrent use.
+type GraphRecorder struct {
+	network *Network
+	slices  []*Graph
+}
+
+// NewGraphRecorder creates a recorder for net.
+func NewGraphRecorder(net *Network) *GraphRecorder {
+	return &GraphRecorder{network: net}
+}
+
+// Record captures a slice at time at. Slices must be recorded in time order.
+func (r *GraphRecorder) Record(at time.Time) error {
+	if n := len(r.slices); n > 0 && !at.After(r.slices[n-1].Time) {
+		return fmt.Errorf("graph slice at %v is not after the previous slice at %v", at, r.slices[n-1].Time)
+	}
+	slice := r.network.Graph()
+	slice.Time = at
+	r.slices = append(r.slices, slice)
+	return nil
+}
+
+// Slices returns the recorded slices, oldest first.
+func (r *GraphRecorder) Slices() []*Graph {
+	return append([]*Graph(nil), r.slices...)
+}
+
+// WriteGEXF writes the slices as one dynamic GEXF graph. Times are seconds
+// since the first slice; each slice's values hold until the next slice, and
+// the last slice lasts as long as the one before it (one second if it is
+// the only one). Nodes and edges exist during the slices that contain them.
+func (r *GraphRecorder) WriteGEXF(w io.Writer) error {
+	if len(r.slices) == 0 {
+		return fmt.Errorf("no graph slices recorded")
+	}
+	bounds := make([]string, len(r.slices)+1)
+	origin := r.slices[0].Time
+	for i, slice := range r.slices {
+		bounds[i] = formatFloat(slice.Time.Sub(origin).Seconds())
+	}
+	last := 1.0
+	if n := len(r.slices); n > 1 {
+		last = r.slices[n-1].Time.Sub(r.slices[n-2].Time).Seconds()
+	}
+	bounds[len(r.slices)] = formatFloat(r.slices[len(r.slices)-1].Time.Sub(origin).Seconds() + last)
+
+	doc := newGEXFDocument("dynamic")
+	edgeAttributes := &doc.Graph.Attributes[1]
+	edgeAttributes.Attributes = append(edgeAttributes.Attributes, gexfAttribute{ID: "weight", Title: "weight", Type: "double"})
+
+	nodes := make(map[string]*gexfNode)
+	edges := make(map[string]*gexfEdge)
+	var nodeOrder, edgeOrder []string
+	// extend adds slice i to a spell list, merging consecutive slices
+	extend := func(spells *gexfSpells, i int) *gexfSpells {
+		if spells == nil {
+			spells = &gexfSpells{}
+		}
+		if n := len(spells.Spells); n > 0 && spells.Spells[n-1].End == bounds[i] {
+			spells.Spells[n-1].End = bounds[i+1]
+			return spells
+		}
+		spells.Spells = append(spells.Spells, gexfSpell{Start: bounds[i], End: bounds[i+1]})
+		return spells
+	}
+	for i, slice := range r.slices {
+		for _, node := range slice.Nodes {
+			gn, ok := nodes[node.ID]
+			if !ok {
+				gn = &gexfNode{ID: node.ID, Label: node.ID}
+				nodes[node.ID] = gn
+				nodeOrder = append(nodeOrder, node.ID)
+			}
+			gn.Spells = extend(gn.Spells, i)
+			gn.Values = append(gn.Values, nodeValues(node, bounds[i], bounds[i+1])...)
+		}
+		for _, edge := range slice.Edges {
+			ge, ok := edges[edge.ID]
+			if !ok {
+				ge = &gexfEdge{ID: edge.ID, Source: edge.Source, Target: edge.Target}
+				edges[edge.ID] = ge
+				edgeOrder = append(edgeOrder, edge.ID)
+			}
+			ge.Weight = formatFloat(edge.Weight)
+			ge.Spells = extend(ge.Spells, i)
+			ge.Values = append(ge.Values, edgeValues(edge, bounds[i], bounds[i+1])...)
+		}
+	}
+	sort.Strings(nodeOrder)
+	sort.Strings(edgeOrder)
+	for _, id := range nodeOrder {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, *nodes[id])
+	}
+	for _, id := range edgeOrder {
+		doc.Graph.Edges = append(doc.Graph.Edges, *edges[id])
+	}
+	return writeXML(w, doc)
+}
@@ -0,0 +1,151 @@
+package network
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+)
+
+// TestGraphExport verifies node and edge attributes in the DOT, GraphML and
+// static GEXF exports.
+func TestGraphExport(t *testing.T) {
+	a, b := newTestNeuron("a"), newTestNeuron("b")
+	a.UpdateMetadata(GRAPH_NODE_TYPE_KEY, "pyramidal")
+	ab := connect("ab", a, b, 0.5, 2*time.Millisecond)
+	net := FromComponents([]component.NeuralComponent{b, a}, []component.SynapticProcessor{ab})
+
+	graph := net.Graph()
+	if len(graph.Nodes) != 2 || graph.Nodes[0].ID != "a" || graph.Nodes[0].Type != "pyramidal" || graph.Nodes[1].Type != "Neuron" {
+		t.Fatalf("Unexpected nodes: %+v", graph.Nodes)
+	}
+	if graph.Nodes[0].Threshold != 1.0 || len(graph.Edges) != 1 || graph.Edges[0].Delay != 2*time.Millisecond {
+		t.Fatalf("Unexpected attributes: %+v", graph)
+	}
+
+	var dot strings.Builder
+	if err := graph.WriteDOT(&dot); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, want := range []string{"digraph network {", `"a" [type="pyramidal", threshold=1`, `"a" -> "b" [id="ab", weight=0.5, delay_ms=2`} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("Expected DOT to contain %q:\n%s", want, dot.String())
+		}
+	}
+
+	var graphML strings.Builder
+	if err := graph.WriteGraphML(&graphML); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var parsed graphMLDocument
+	if err := xml.Unmarshal([]byte(graphML.String()), &parsed); err != nil {
+		t.Fatalf("GraphML does not parse: %v", err)
+	}
+	if len(parsed.Graph.Nodes) != 2 || len(parsed.Graph.Edges) != 1 || parsed.Graph.Edges[0].Source != "a" {
+		t.Fatalf("Unexpected GraphML structure: %+v", parsed.Graph)
+	}
+	edgeData := map[string]string{}
+	for _, d := range parsed.Graph.Edges[0].Data {
+		edgeData[d.Key] = d.Value
+	}
+	if edgeData["weight"] != "0.5" || edgeData["delay_ms"] != "2" {
+		t.Errorf("Unexpected GraphML edge data: %v", edgeData)
+	}
+
+	var gexf strings.Builder
+	if err := graph.WriteGEXF(&gexf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(gexf.String(), `<graph mode="static" defaultedgetype="directed">`) ||
+		!strings.Contains(gexf.String(), `<edge id="ab" source="a" target="b" weight="0.5">`) ||
+		strings.Contains(gexf.String(), "<spells>") {
+		t.Errorf("Unexpected static GEXF:\n%s", gexf.String())
+	}
+}
+
+// TestGraphDOTInhibitoryAndUnicode verifies that inhibitory edges get a
+// positive pen width and that IDs are escaped the way DOT reads them.
+func TestGraphDOTInhibitoryAndUnicode(t *testing.T) {
+	graph := &Graph{
+		Nodes: []GraphNode{{ID: "pv→pyr", Type: `say "hi"\x`}, {ID: "pyr"}},
+		Edges: []GraphEdge{{ID: "gaba\tab", Source: "pv→pyr", Target: "pyr", Weight: -1.5, Delay: time.Millisecond / 4}},
+	}
+	var dot strings.Builder
+	if err := graph.WriteDOT(&dot); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`"pv→pyr" [type="say \"hi\"\\x"`,
+		`"pv→pyr" -> "pyr" [id="gaba` + "\t" + `ab", weight=-1.5, delay_ms=0.25, penwidth=2];`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("Expected DOT to contain %q:\n%s", want, dot.String())
+		}
+	}
+	if strings.Contains(dot.String(), `\u`) {
+		t.Errorf("Expected non-ASCII IDs written as they are:\n%s", dot.String())
+	}
+}
+
+// TestGraphRecorderDynamicGEXF verifies that time slices become spells and
+// time-bounded attribute values in a dynamic GEXF graph.
+func TestGraphRecorderDynamicGEXF(t *testing.T) {
+	a, b := newTestNeuron("a"), newTestNeuron("b")
+	ab := connect("ab", a, b, 0.5, time.Millisecond)
+	source := &testBuilder{neurons: map[string]*neuron.Neuron{"a": a, "b": b}, synapses: []component.SynapticProcessor{ab}}
+	recorder := NewGraphRecorder(New(source))
+
+	if err := recorder.WriteGEXF(&strings.Builder{}); err == nil {
+		t.Error("Expected error without slices")
+	}
+	start := time.Unix(1000, 0)
+	recorder.Record(start)
+	ab.SetWeight(0.9)
+	ba := connect("ba", b, a, 0.2, time.Millisecond)
+	source.synapses = append(source.synapses, ba)
+	recorder.Record(start.Add(2 * time.Second))
+	source.synapses = source.synapses[:1]
+	recorder.Record(start.Add(4 * time.Second))
+	if err := recorder.Record(start.Add(time.Second)); err == nil {
+		t.Error("Expected error for a slice out of order")
+	}
+	if len(recorder.Slices()) != 3 {
+		t.Fatalf("Expected 3 slices, got %d", len(recorder.Slices()))
+	}
+
+	var out strings.Builder
+	if err := recorder.WriteGEXF(&out); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var doc gexfDocument
+	if err := xml.Unmarshal([]byte(out.String()), &doc); err != nil {
+		t.Fatalf("GEXF does not parse: %v", err)
+	}
+	if doc.Graph.Mode != "dynamic" || doc.Graph.TimeFormat != "double" || len(doc.Graph.Edges) != 2 {
+		t.Fatalf("Unexpected dynamic graph: %+v", doc.Graph)
+	}
+
+	edges := map[string]gexfEdge{}
+	for _, e := range doc.Graph.Edges {
+		edges[e.ID] = e
+	}
+	// ab exists throughout; ba only during the middle slice
+	if s := edges["ab"].Spells.Spells; len(s) != 1 || s[0].Start != "0" || s[0].End != "6" {
+		t.Errorf("Expected ab over [0, 6], got %+v", s)
+	}
+	if s := edges["ba"].Spells.Spells; len(s) != 1 || s[0].Start != "2" || s[0].End != "4" {
+		t.Errorf("Expected ba over [2, 4], got %+v", s)
+	}
+	weights := []string{}
+	for _, v := range edges["ab"].Values {
+		if v.For == "weight" {
+			weights = append(weights, v.Start+":"+v.Value)
+		}
+	}
+	if strings.Join(weights, " ") != "0:0.5 2:0.9 4:0.9" {
+		t.Errorf("Unexpected weight timeline: %v", weights)
+	}
+}
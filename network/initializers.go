@@ -0,0 +1,278 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/topology"
+)
+
+// =================================================================================
+// WEIGHT INITIALIZERS
+// =================================================================================
+//
+// Initial weights shape what a network does before any learning, so they
+// should follow the data where data exist:
+//
+//   - Cortical EPSP amplitudes are log-normal: most connections are weak and a
+//     few are strong (Song et al. 2005). LogNormalWeight takes the mean and
+//     standard deviation of the weights themselves; CorticalEPSPWeight uses
+//     the fit to layer 5 pairs, scaled from millivolts to weight units.
+//   - Fan-in scaling keeps the summed input of a neuron independent of the
+//     number of its inputs. HeWeight and XavierWeight use the variances of
+//     He et al. (2015) and Glorot & Bengio (2010). Both are centred on zero;
+//     excitatory projections fold them with MagnitudeWeight, which keeps the
+//     second moment and therefore the scaling.
+//   - Connection strength falls with distance as connection probability does.
+//     DistanceDecayWeight attenuates any distribution exponentially; it is a
+//     SpatialWeightDistribution, used by Projection.InitializeWeights and by
+//     topology.SpatialConfig.Weights.
+//
+// Every distribution draws from the generator's RNG (PopulationConfig.Seed,
+// topology.SpatialConfig.Seed or the seed passed to InitializeWeights), so
+// initial conditions are reproducible.
+
+// Log-normal fit to layer 5 EPSP amplitudes in mV (Song et al. 2005): the
+// mean and standard deviation of ln(EPSP). The mean amplitude is 0.77 mV.
+const (
+	CORTICAL_EPSP_LOG_MEAN  = -0.702
+	CORTICAL_EPSP_LOG_SIGMA = 0.9355
+)
+
+// SpatialWeightDistribution draws the weight of one new synapse between
+// neurons distance μm apart.
+type SpatialWeightDistribution func(rng *rand.Rand, distance float64) float64
+
+// LogNormalWeight draws log-normal weights with the given mean and standard
+// deviation (of the weights, not of their logarithm). A non-positive mean
+// gives zero weights.
+func LogNormalWeight(mean, stdDev float64) WeightDistribution {
+	if mean <= 0 {
+		return ConstantWeight(0)
+	}
+	sigma := math.Sqrt(math.Log1p(stdDev * stdDev / (mean * mean)))
+	mu := math.Log(mean) - sigma*sigma/2
+	return func(rng *rand.Rand) float64 { return math.Exp(mu + sigma*rng.NormFloat64()) }
+}
+
+// CorticalEPSPWeight draws weights from the cortical EPSP distribution,
+// multiplying amplitudes in mV by scale (weight per mV).
+func CorticalEPSPWeight(scale float64) WeightDistribution {
+	return func(rng *rand.Rand) float64 {
+		return scale * math.Exp(CORTICAL_EPSP_LOG_MEAN+CORTICAL_EPSP_LOG_SIGMA*rng.NormFloat64())
+	}
+}
+
+// HeWeight draws normal weights with mean zero and standard deviation
+// gain·√(2/fanIn). fanIn is the expected number of inputs per neuron:
+// the source size for all-to-all wiring, probability × size for random.
+func HeWeight(fanIn int, gain float64) WeightDistribution {
+	if fanIn <= 0 {
+		return ConstantWeight(0)
+	}
+	return NormalWeight(0, gain*math.Sqrt(2/float64(fanIn)))
+}
+
+// XavierWeight draws uniform weights in [-a, a) with a =
+// gain·√(6/(fanIn+fanOut)), balancing the variance of inputs and outputs.
+func XavierWeight(fanIn, fanOut int, gain float64) WeightDistribution {
+	if fanIn+fanOut <= 0 {
+		return ConstantWeight(0)
+	}
+	a := gain * math.Sqrt(6/float64(fanIn+fanOut))
+	return UniformWeight(-a, a)
+}
+
+// MagnitudeWeight returns the absolute value of base's draws, for
+// excitatory synapses, which cannot carry negative weights.
+func MagnitudeWeight(base WeightDistribution) WeightDistribution {
+	return func(rng *rand.Rand) float64 { return math.Abs(base(rng)) }
+}
+
+// ClippedWeight bounds base's draws to [min, max], e.g. to cut the long
+// tail of a log-normal distribution at the synapses' maximum weight.
+func ClippedWeight(base WeightDistribution, min, max float64) WeightDistribution {
+	return func(rng *rand.Rand) float64 { return math.Max(min, math.Min(max, base(rng))) }
+}
+
+// DistanceDecayWeight scales base's draws by exp(-distance/lengthConstant).
+// A non-positive length constant disables the decay.
+func DistanceDecayWeight(base WeightDistribution, lengthConstant float64) SpatialWeightDistribution {
+	return func(rng *rand.Rand, distance float64) float64 {
+		weight := base(rng)
+		if lengthConstant > 0 {
+			weight *= math.Exp(-distance / lengthConstant)
+		}
+		return weight
+	}
+}
+
+// InitializeWeights redraws every weight from weights, given the distance
+// between the synapse's neurons, with an RNG seeded by seed. Synapses are
+// visited in ID order, so a seed reproduces the same weights. Plastic
+// synapses clamp the result to their weight bounds. Returns the number of
+// weights set.
+func (p *Projection) InitializeWeights(weights SpatialWeightDistribution, seed int64) (int, error) {
+	if weights == nil {
+		return 0, fmt.Errorf("weight distribution is required")
+	}
+	rng := rand.New(rand.NewSource(seed))
+	set := 0
+	for _, syn := range p.Synapses() {
+		pre := p.pre.neuronByID(syn.GetPresynapticID())
+		post := p.post.neuronByID(syn.GetPostsynapticID())
+		if pre == nil || post == nil {
+			return set, fmt.Errorf("synapse %s: neuron no longer in its population", syn.ID())
+		}
+		weight := weights(rng, topology.Distance(pre.Position(), post.Position()))
+		if math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return set, fmt.Errorf("synapse %s: weight must be finite: %f", syn.ID(), weight)
+		}
+		syn.SetWeight(weight)
+		set++
+	}
+	return set, nil
+}
+
+// =================================================================================
+// DELAY INITIALIZERS
+// =================================================================================
+//
+// One constant delay per projection synchronizes its inputs artificially.
+// Measured delays spread out:
+//
+//   - Local cortical connections have latencies of about 1-2 ms with a right
+//     skew, which a gamma distribution captures. GammaDelay takes mean and
+//     coefficient of variation; CorticalLocalDelay is the preset.
+//   - Long-range delays follow from axon length and conduction velocity.
+//     DistanceDelay wraps a topology.DelayModel and adds relative jitter for
+//     the variable path length of real axons; CorticalLongRangeDelay uses
+//     myelinated fibres.
+//   - JitteredDelay spreads a fixed delay uniformly, for models that want a
+//     nominal delay without exact synchrony.
+//
+// As with weights, every draw comes from the generator's RNG.
+
+// Local cortical delay preset: gamma distributed with a mean of 1.5 ms and a
+// coefficient of variation of 0.5.
+const (
+	CORTICAL_LOCAL_DELAY_MEAN = 1500 * time.Microsecond
+	CORTICAL_LOCAL_DELAY_CV   = 0.5
+
+	// CORTICAL_LONG_RANGE_DELAY_JITTER is the relative spread of long-range
+	// delays around the conduction delay.
+	CORTICAL_LONG_RANGE_DELAY_JITTER = 0.1
+)
+
+// SpatialDelayDistribution draws the delay of one new synapse between
+// neurons distance μm apart.
+type SpatialDelayDistribution func(rng *rand.Rand, distance float64) time.Duration
+
+// GammaDelay draws gamma-distributed delays with the given mean and
+// coefficient of variation. A non-positive cv gives the mean.
+func GammaDelay(mean time.Duration, cv float64) DelayDistribution {
+	if cv <= 0 || mean <= 0 {
+		return ConstantDelay(mean)
+	}
+	shape := 1 / (cv * cv)
+	scale := float64(mean) / shape
+	return func(rng *rand.Rand) time.Duration {
+		return time.Duration(gamma(rng, shape) * scale)
+	}
+}
+
+// CorticalLocalDelay draws delays of local cortical connections.
+func CorticalLocalDelay() DelayDistribution {
+	return GammaDelay(CORTICAL_LOCAL_DELAY_MEAN, CORTICAL_LOCAL_DELAY_CV)
+}
+
+// JitteredDelay draws delays uniformly from [delay-jitter, delay+jitter),
+// never below zero.
+func JitteredDelay(delay, jitter time.Duration) DelayDistribution {
+	if jitter <= 0 {
+		return ConstantDelay(delay)
+	}
+	return func(rng *rand.Rand) time.Duration {
+		d := delay - jitter + time.Duration(rng.Int63n(int64(2*jitter)))
+		if d < 0 {
+			return 0
+		}
+		return d
+	}
+}
+
+// DistanceDelay derives delays from distance with model and multiplies them
+// by 1 + jitter·N(0, 1), never below the synaptic delay.
+func DistanceDelay(model topology.DelayModel, jitter float64) SpatialDelayDistribution {
+	return func(rng *rand.Rand, distance float64) time.Duration {
+		conduction := topology.ConductionDelay(distance, model.Velocity)
+		if jitter > 0 {
+			conduction = time.Duration(math.Max(0, float64(conduction)*(1+jitter*rng.NormFloat64())))
+		}
+		return model.SynapticDelay + conduction
+	}
+}
+
+// CorticalLongRangeDelay derives delays of long-range cortical connections
+// over myelinated axons.
+func CorticalLongRangeDelay() SpatialDelayDistribution {
+	return DistanceDelay(topology.DelayModel{
+		SynapticDelay: topology.DEFAULT_SYNAPTIC_DELAY,
+		Velocity:      topology.VELOCITY_MYELINATED_MEDIUM,
+	}, CORTICAL_LONG_RANGE_DELAY_JITTER)
+}
+
+// delaySetter is implemented by synapses with a settable delay
+// (synapse.BasicSynapse).
+type delaySetter interface {
+	SetDelay(delay time.Duration)
+}
+
+// InitializeDelays redraws every delay from delays, given the distance
+// between the synapse's neurons, with an RNG seeded by seed. Synapses
+// without a settable delay are skipped. Returns the number of delays set.
+func (p *Projection) InitializeDelays(delays SpatialDelayDistribution, seed int64) (int, error) {
+	if delays == nil {
+		return 0, fmt.Errorf("delay distribution is required")
+	}
+	rng := rand.New(rand.NewSource(seed))
+	set := 0
+	for _, syn := range p.Synapses() {
+		settable, ok := syn.(delaySetter)
+		if !ok {
+			continue
+		}
+		pre := p.pre.neuronByID(syn.GetPresynapticID())
+		post := p.post.neuronByID(syn.GetPostsynapticID())
+		if pre == nil || post == nil {
+			return set, fmt.Errorf("synapse %s: neuron no longer in its population", syn.ID())
+		}
+		settable.SetDelay(delays(rng, topology.Distance(pre.Position(), post.Position())))
+		set++
+	}
+	return set, nil
+}
+
+// gamma draws from a gamma distribution with unit scale (Marsaglia & Tsang
+// 2000). Shapes below 1 are boosted by U^(1/shape).
+func gamma(rng *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		return gamma(rng, shape+1) * math.Pow(rng.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := rng.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := rng.Float64()
+		if math.Log(u) < x*x/2+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package network
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/topology"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestWeightInitializers verifies the moments of the log-normal and
+// fan-in-scaled distributions, seeded reproducibility and distance-decayed
+// initialization of a projection.
+func TestWeightInitializers(t *testing.T) {
+	moments := func(weights WeightDistribution) (mean, stdDev float64) {
+		rng := rand.New(rand.NewSource(1))
+		const n = 20000
+		var sum, sumSq float64
+		for i := 0; i < n; i++ {
+			w := weights(rng)
+			sum += w
+			sumSq += w * w
+		}
+		mean = sum / n
+		return mean, math.Sqrt(sumSq/n - mean*mean)
+	}
+
+	if mean, sd := moments(LogNormalWeight(0.5, 0.4)); math.Abs(mean-0.5) > 0.02 || math.Abs(sd-0.4) > 0.04 {
+		t.Errorf("Expected log-normal mean 0.5 and SD 0.4, got %.3f and %.3f", mean, sd)
+	}
+	if mean, _ := moments(CorticalEPSPWeight(1)); math.Abs(mean-0.77) > 0.04 {
+		t.Errorf("Expected cortical EPSP mean 0.77 mV, got %.3f", mean)
+	}
+	if _, sd := moments(HeWeight(50, 1)); math.Abs(sd-0.2) > 0.01 {
+		t.Errorf("Expected He SD 0.2 for fan-in 50, got %.3f", sd)
+	}
+	if _, sd := moments(XavierWeight(100, 200, 1)); math.Abs(sd-math.Sqrt(2.0/300)) > 0.005 {
+		t.Errorf("Expected Xavier variance 2/300, got SD %.3f", sd)
+	}
+	if mean, _ := moments(MagnitudeWeight(HeWeight(50, 1))); mean <= 0 {
+		t.Errorf("Expected folded weights to be positive, got mean %.3f", mean)
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		if w := ClippedWeight(CorticalEPSPWeight(1), 0, 1)(rng); w < 0 || w > 1 {
+			t.Fatalf("Clipped weight outside [0, 1]: %f", w)
+		}
+	}
+
+	builder := &testBuilder{}
+	cell := types.NeuronConfig{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond, FireFactor: 1.0}
+	pre, _ := NewPopulation(builder, "pre", PopulationConfig{Size: 2, Neuron: cell,
+		Positions: []types.Position3D{{X: 0}, {X: 100}}})
+	post, _ := NewPopulation(builder, "post", PopulationConfig{Size: 1, Neuron: cell,
+		Positions: []types.Position3D{{X: 0, Y: 10}}})
+	proj, err := pre.ConnectAllToAll(post, LogNormalWeight(0.5, 0.2), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decay := DistanceDecayWeight(ConstantWeight(1), 50)
+	if n, err := proj.InitializeWeights(decay, 1); err != nil || n != 2 {
+		t.Fatalf("Expected 2 weights set, got %d (%v)", n, err)
+	}
+	weights := proj.Weights()
+	near, far := math.Exp(-10.0/50), math.Exp(-math.Hypot(100, 10)/50)
+	if math.Abs(weights[0]-near) > 1e-9 || math.Abs(weights[1]-far) > 1e-9 {
+		t.Errorf("Expected weights %.4f and %.4f, got %v", near, far, weights)
+	}
+
+	jittered := DistanceDecayWeight(LogNormalWeight(0.5, 0.2), 50)
+	proj.InitializeWeights(jittered, 7)
+	first := proj.Weights()
+	proj.InitializeWeights(jittered, 7)
+	if again := proj.Weights(); again[0] != first[0] || again[1] != first[1] {
+		t.Errorf("Expected the same seed to reproduce weights, got %v and %v", first, again)
+	}
+	if _, err := proj.InitializeWeights(nil, 1); err == nil {
+		t.Error("Expected error for a nil distribution")
+	}
+}
+
+// TestDelayInitializers verifies the gamma, jittered and distance-derived
+// delay distributions and distance-based initialization of a projection.
+func TestDelayInitializers(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 20000
+	var sum, sumSq float64
+	local := CorticalLocalDelay()
+	for i := 0; i < n; i++ {
+		d := float64(local(rng))
+		if d < 0 {
+			t.Fatalf("Negative gamma delay: %v", time.Duration(d))
+		}
+		sum += d
+		sumSq += d * d
+	}
+	mean := sum / n
+	cv := math.Sqrt(sumSq/n-mean*mean) / mean
+	if math.Abs(mean-float64(CORTICAL_LOCAL_DELAY_MEAN)) > 0.03*float64(CORTICAL_LOCAL_DELAY_MEAN) || math.Abs(cv-CORTICAL_LOCAL_DELAY_CV) > 0.03 {
+		t.Errorf("Expected gamma mean 1.5ms and CV 0.5, got %v and %.3f", time.Duration(mean), cv)
+	}
+	sum = 0
+	skewed := GammaDelay(time.Millisecond, 2) // shape 0.25
+	for i := 0; i < n; i++ {
+		sum += float64(skewed(rng))
+	}
+	if mean := sum / n; math.Abs(mean-float64(time.Millisecond)) > 0.1*float64(time.Millisecond) {
+		t.Errorf("Expected gamma mean 1ms for a shape below 1, got %v", time.Duration(mean))
+	}
+
+	jittered := JitteredDelay(2*time.Millisecond, 500*time.Microsecond)
+	for i := 0; i < 100; i++ {
+		if d := jittered(rng); d < 1500*time.Microsecond || d >= 2500*time.Microsecond {
+			t.Fatalf("Jittered delay outside 2±0.5ms: %v", d)
+		}
+	}
+
+	model := topology.DelayModel{SynapticDelay: time.Millisecond, Velocity: 1000}
+	if d := DistanceDelay(model, 0)(rng, 2000); d != 3*time.Millisecond {
+		t.Errorf("Expected 1ms + 2mm at 1 m/s = 3ms, got %v", d)
+	}
+	if d := CorticalLongRangeDelay()(rng, 0); d != topology.DEFAULT_SYNAPTIC_DELAY {
+		t.Errorf("Expected the synaptic delay at zero distance, got %v", d)
+	}
+
+	builder := &testBuilder{}
+	cell := types.NeuronConfig{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond, FireFactor: 1.0}
+	pre, _ := NewPopulation(builder, "pre", PopulationConfig{Size: 2, Neuron: cell,
+		Positions: []types.Position3D{{X: 0}, {X: 3000}}})
+	post, _ := NewPopulation(builder, "post", PopulationConfig{Size: 1, Neuron: cell})
+	proj, err := pre.ConnectAllToAll(post, ConstantWeight(0.5), CorticalLocalDelay())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count, err := proj.InitializeDelays(DistanceDelay(model, 0), 1); err != nil || count != 2 {
+		t.Fatalf("Expected 2 delays set, got %d (%v)", count, err)
+	}
+	synapses := proj.Synapses()
+	if synapses[0].GetDelay() != time.Millisecond || synapses[1].GetDelay() != 4*time.Millisecond {
+		t.Errorf("Expected delays 1ms and 4ms, got %v and %v", synapses[0].GetDelay(), synapses[1].GetDelay())
+	}
+	if _, err := proj.InitializeDelays(nil, 1); err == nil {
+		t.Error("Expected error for a nil distribution")
+	}
+}
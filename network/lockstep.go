@@ -0,0 +1,71 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+)
+
+// =================================================================================
+// LOCK-STEP MODE
+// =================================================================================
+//
+// Lock-stepping is enabled per neuron (see cosim.LockStep.AddNeuron): the
+// neuron leaves its goroutine and advances only when the runner steps it.
+// AttachLockStep puts the whole network on one runner - every neuron is
+// registered with it and every synapse reads its clock, so spike times,
+// synaptic delays and STDP all follow virtual time:
+//
+//	runner, _ := cosim.NewLockStep(time.Unix(0, 0), time.Millisecond)
+//	net.AttachLockStep(runner)
+//	matrix.Start()
+//	runner.Step(10 * time.Millisecond)
+//
+// It must run before the neurons are started. Components added later are not
+// attached.
+
+// clocked is implemented by synapses with a replaceable time source
+// (synapse.BasicSynapse).
+type clocked interface {
+	SetClock(now func() time.Time)
+}
+
+// AttachLockStep registers every neuron with runner and sets every synapse
+// to its clock. Returns the number of neurons attached.
+func (n *Network) AttachLockStep(runner *cosim.LockStep) (int, error) {
+	attached := 0
+	for _, cell := range n.Neurons() {
+		target, ok := cell.(*neuron.Neuron)
+		if !ok {
+			continue
+		}
+		if err := runner.AddNeuron(target); err != nil {
+			return attached, fmt.Errorf("neuron %s: %w", cell.ID(), err)
+		}
+		attached++
+	}
+	for _, syn := range n.Synapses() {
+		if target, ok := syn.(clocked); ok {
+			target.SetClock(runner.Now)
+		}
+	}
+
+	warp, err := cosim.NewTimeWarp(runner)
+	if err != nil {
+		return attached, err
+	}
+	n.lockStepMutex.Lock()
+	n.lockStep = runner
+	n.warp = warp
+	n.lockStepMutex.Unlock()
+	return attached, nil
+}
+
+// LockStepRunner returns the attached runner (nil = none).
+func (n *Network) LockStepRunner() *cosim.LockStep {
+	n.lockStepMutex.Lock()
+	defer n.lockStepMutex.Unlock()
+	return n.lockStep
+}
@@ -0,0 +1,63 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestAttachLockStep verifies that an attached network's neurons and
+// synapses run on the runner's virtual clock.
+func TestAttachLockStep(t *testing.T) {
+	start := time.Unix(0, 0)
+	runner, _ := cosim.NewLockStep(start, time.Millisecond)
+
+	pre, post := newTestNeuron("pre"), newTestNeuron("post")
+	syn := connect("pre_post", pre, post, 1.2, 3*time.Millisecond).(*synapse.BasicSynapse)
+	pre.AddOutputCallback(syn.ID(), types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			syn.Transmit(msg.Value)
+			return nil
+		},
+		GetWeight:   syn.GetWeight,
+		GetDelay:    syn.GetDelay,
+		GetTargetID: syn.GetPostsynapticID,
+	})
+	net := FromComponents([]component.NeuralComponent{pre, post}, []component.SynapticProcessor{syn})
+
+	if attached, err := net.AttachLockStep(runner); err != nil || attached != 2 {
+		t.Fatalf("Expected 2 neurons attached, got %d (%v)", attached, err)
+	}
+	if net.LockStepRunner() != runner {
+		t.Error("Expected the runner to be recorded")
+	}
+	for _, cell := range []*neuron.Neuron{pre, post} {
+		cell.Start()
+		defer cell.Stop()
+	}
+
+	runner.Step(4 * time.Millisecond)
+	pre.Receive(types.NeuralSignal{Value: 1.5})
+	runner.Step(time.Millisecond)
+	if spikes := syn.GetPreSpikeTimes(); len(spikes) != 1 || !spikes[0].Equal(start.Add(5*time.Millisecond)) {
+		t.Fatalf("Expected the synapse to record the spike at 5ms, got %v", spikes)
+	}
+
+	runner.Step(3 * time.Millisecond)
+	if got := post.GetSnapshot().LastSpike; !got.Equal(start.Add(8 * time.Millisecond)) {
+		t.Errorf("Expected the post-synaptic spike at 8ms, got %v", got.Sub(start))
+	}
+
+	// A started neuron cannot join
+	late := newTestNeuron("late")
+	late.Start()
+	defer late.Stop()
+	if _, err := FromComponents([]component.NeuralComponent{late}, nil).AttachLockStep(runner); err == nil {
+		t.Error("Expected an error attaching a running neuron")
+	}
+}
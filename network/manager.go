@@ -0,0 +1,228 @@
+package network
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+	"github.com/SynapticNetworks/temporal-neuron/extracellular"
+	"github.com/SynapticNetworks/temporal-neuron/memory"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// NETWORK MANAGER - SEVERAL ISOLATED NETWORKS IN ONE PROCESS
+// =================================================================================
+//
+// A service running several experiments at once needs them not to see each
+// other. Each network a NetworkManager hosts gets its own matrix and with it
+// everything that is per-matrix:
+//
+//   - IDs: the matrix generates IDs under the network's name ("v1/..."), so
+//     IDs from different networks never collide, including in the
+//     process-wide ID interning registry (types.DefaultIDs)
+//   - events: the network's observer sees only its own matrix's events
+//   - metrics: a memory budget and an energy meter per network, and log
+//     records carrying a "network" attribute
+//   - quotas: Quota.Goroutines caps the neurons, each of which runs one
+//     goroutine; Quota.Memory is the hard limit of the memory budget
+//
+// Quotas bound what the network's matrix creates. Components wired by hand
+// outside the matrix are not counted.
+
+// Quota limits the resources of one managed network. Zero fields are
+// unlimited.
+type Quota struct {
+	Goroutines int   // Neurons, each running one goroutine
+	Memory     int64 // Bytes of tracked component memory (see memory.Budget)
+}
+
+// ManagedConfig configures a managed network.
+type ManagedConfig struct {
+	Matrix     extracellular.ExtracellularMatrixConfig // Namespace and MaxNeurons are set from the name and quota
+	Quota      Quota
+	Observer   types.BiologicalObserver // Receives this network's events only (nil = none)
+	LogHandler slog.Handler             // Gets a "network" attribute (nil = disabled)
+	Costs      *energy.Costs            // Energy costs (nil = energy.BiologicalCosts)
+}
+
+// ManagedNetwork is one network hosted by a NetworkManager.
+type ManagedNetwork struct {
+	name   string
+	quota  Quota
+	matrix *extracellular.ExtracellularMatrix
+	view   *Network
+	budget *memory.Budget
+	meter  *energy.Meter
+}
+
+// Name returns the network's name, which is also its ID namespace.
+func (m *ManagedNetwork) Name() string {
+	return m.name
+}
+
+// Matrix returns the network's matrix, through which components are created.
+func (m *ManagedNetwork) Matrix() *extracellular.ExtracellularMatrix {
+	return m.matrix
+}
+
+// Network returns the whole-network view over the matrix.
+func (m *ManagedNetwork) Network() *Network {
+	return m.view
+}
+
+// Memory returns the network's memory budget.
+func (m *ManagedNetwork) Memory() *memory.Budget {
+	return m.budget
+}
+
+// Energy returns the network's energy meter.
+func (m *ManagedNetwork) Energy() *energy.Meter {
+	return m.meter
+}
+
+// Quota returns the network's quota.
+func (m *ManagedNetwork) Quota() Quota {
+	return m.quota
+}
+
+// GetStats returns the network's component counts, quota and metrics.
+func (m *ManagedNetwork) GetStats() map[string]interface{} {
+	total := m.meter.Total()
+	return map[string]interface{}{
+		"name":            m.name,
+		"neurons":         len(m.matrix.ListNeurons()),
+		"synapses":        len(m.matrix.ListSynapses()),
+		"goroutine_quota": m.quota.Goroutines,
+		"memory_quota":    m.quota.Memory,
+		"memory":          m.budget.GetStats(),
+		"energy":          total.Energy,
+		"spikes":          total.Spikes,
+	}
+}
+
+// NetworkManager hosts independent networks by name.
+type NetworkManager struct {
+	mu       sync.Mutex
+	networks map[string]*ManagedNetwork
+}
+
+// NewNetworkManager creates an empty manager.
+func NewNetworkManager() *NetworkManager {
+	return &NetworkManager{networks: make(map[string]*ManagedNetwork)}
+}
+
+// Create adds a network under name. The name must be unique and must not
+// contain "/", which separates the namespace from generated IDs.
+func (nm *NetworkManager) Create(name string, config ManagedConfig) (*ManagedNetwork, error) {
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid network name %q", name)
+	}
+	if config.Quota.Goroutines < 0 || config.Quota.Memory < 0 {
+		return nil, fmt.Errorf("network %s: quotas cannot be negative: %+v", name, config.Quota)
+	}
+
+	costs := energy.BiologicalCosts()
+	if config.Costs != nil {
+		costs = *config.Costs
+	}
+	meter, err := energy.NewMeter(costs)
+	if err != nil {
+		return nil, fmt.Errorf("network %s: %w", name, err)
+	}
+	budget, err := memory.NewBudget(config.Quota.Memory)
+	if err != nil {
+		return nil, fmt.Errorf("network %s: %w", name, err)
+	}
+
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	if _, exists := nm.networks[name]; exists {
+		return nil, fmt.Errorf("network %s already exists", name)
+	}
+
+	matrixConfig := config.Matrix
+	matrixConfig.Namespace = name
+	matrixConfig.MaxNeurons = config.Quota.Goroutines
+	matrix := extracellular.NewExtracellularMatrix(matrixConfig)
+	if err := matrix.SetMemoryBudget(budget); err != nil {
+		return nil, fmt.Errorf("network %s: %w", name, err)
+	}
+	matrix.SetEnergyMeter(meter)
+	if config.Observer != nil {
+		matrix.SetBiologicalObserver(config.Observer)
+	}
+	if config.LogHandler != nil {
+		matrix.SetLogHandler(config.LogHandler.WithAttrs([]slog.Attr{slog.String("network", name)}))
+	}
+
+	managed := &ManagedNetwork{
+		name:   name,
+		quota:  config.Quota,
+		matrix: matrix,
+		view:   New(matrix),
+		budget: budget,
+		meter:  meter,
+	}
+	nm.networks[name] = managed
+	return managed, nil
+}
+
+// Get returns the network with the given name.
+func (nm *NetworkManager) Get(name string) (*ManagedNetwork, bool) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	managed, ok := nm.networks[name]
+	return managed, ok
+}
+
+// Names returns the names of the hosted networks, sorted.
+func (nm *NetworkManager) Names() []string {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+	names := make([]string, 0, len(nm.networks))
+	for name := range nm.networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Remove stops a network and removes it from the manager.
+func (nm *NetworkManager) Remove(name string) error {
+	nm.mu.Lock()
+	managed, ok := nm.networks[name]
+	delete(nm.networks, name)
+	nm.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("network %s not found", name)
+	}
+	return managed.matrix.Stop()
+}
+
+// StopAll stops every hosted network and returns the first error.
+func (nm *NetworkManager) StopAll() error {
+	var first error
+	for _, name := range nm.Names() {
+		if managed, ok := nm.Get(name); ok {
+			if err := managed.matrix.Stop(); err != nil && first == nil {
+				first = fmt.Errorf("network %s: %w", name, err)
+			}
+		}
+	}
+	return first
+}
+
+// GetStats returns the statistics of every hosted network by name.
+func (nm *NetworkManager) GetStats() map[string]interface{} {
+	stats := make(map[string]interface{})
+	for _, name := range nm.Names() {
+		if managed, ok := nm.Get(name); ok {
+			stats[name] = managed.GetStats()
+		}
+	}
+	return stats
+}
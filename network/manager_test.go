@@ -0,0 +1,103 @@
+package network
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/extracellular"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// eventCounter records the source IDs of biological events.
+type eventCounter struct {
+	mu      sync.Mutex
+	sources []string
+}
+
+func (e *eventCounter) Emit(event types.BiologicalEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sources = append(e.sources, event.SourceID)
+}
+
+// TestNetworkManager verifies that managed networks get namespaced IDs,
+// their own events and metrics, and enforce their quotas.
+func TestNetworkManager(t *testing.T) {
+	manager := NewNetworkManager()
+	defer manager.StopAll()
+
+	observers := map[string]*eventCounter{"alpha": {}, "beta": {}}
+	for name, observer := range observers {
+		managed, err := manager.Create(name, ManagedConfig{
+			Matrix:   extracellular.ExtracellularMatrixConfig{MaxComponents: 100},
+			Quota:    Quota{Goroutines: 2},
+			Observer: observer,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		managed.Matrix().RegisterNeuronType("basic", func(id string, config types.NeuronConfig, callbacks component.NeuronCallbacks) (component.NeuralComponent, error) {
+			n := newTestNeuron(id)
+			n.SetCallbacks(callbacks)
+			return n, nil
+		})
+	}
+	if _, err := manager.Create("alpha", ManagedConfig{}); err == nil {
+		t.Error("Expected error for duplicate network name")
+	}
+	if _, err := manager.Create("a/b", ManagedConfig{}); err == nil {
+		t.Error("Expected error for name containing a slash")
+	}
+	if names := manager.Names(); len(names) != 2 || names[0] != "alpha" || names[1] != "beta" {
+		t.Fatalf("Unexpected names %v", names)
+	}
+
+	ids := make(map[string]bool)
+	for _, name := range manager.Names() {
+		managed, _ := manager.Get(name)
+		for i := 0; i < 2; i++ {
+			n, err := managed.Matrix().CreateNeuron(types.NeuronConfig{NeuronType: "basic", Threshold: 1.0})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(n.ID(), name+"/") || ids[n.ID()] {
+				t.Errorf("Expected a unique ID in namespace %s, got %s", name, n.ID())
+			}
+			ids[n.ID()] = true
+		}
+		// The third neuron exceeds the goroutine quota
+		if _, err := managed.Matrix().CreateNeuron(types.NeuronConfig{NeuronType: "basic", Threshold: 1.0}); err == nil {
+			t.Errorf("Expected the goroutine quota of %s to refuse a third neuron", name)
+		}
+		if managed.Memory().Used() <= 0 {
+			t.Errorf("Expected memory of %s to be accounted", name)
+		}
+	}
+
+	// Each observer sees its own network's events only
+	for name, observer := range observers {
+		observer.mu.Lock()
+		if len(observer.sources) != 2 {
+			t.Errorf("Expected 2 events for %s, got %v", name, observer.sources)
+		}
+		for _, source := range observer.sources {
+			if !strings.HasPrefix(source, name+"/") {
+				t.Errorf("Observer of %s received event from %s", name, source)
+			}
+		}
+		observer.mu.Unlock()
+	}
+
+	stats := manager.GetStats()
+	if alpha, ok := stats["alpha"].(map[string]interface{}); !ok || alpha["neurons"] != 2 {
+		t.Errorf("Unexpected stats %v", stats["alpha"])
+	}
+	if err := manager.Remove("beta"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := manager.Get("beta"); ok {
+		t.Error("Expected beta removed")
+	}
+}
@@ -0,0 +1,181 @@
+package network
+
+import (
+	"fmt"
+	"math"
+)
+
+// =================================================================================
+// MULTI-PARENT WEIGHT MERGE
+// =================================================================================
+//
+// Parallel training runs copies of one network on different data shards and
+// combines what they learned. MergeWeights combines the weight matrices of
+// networks with identical topology:
+//
+//   - MergeAverage takes the weighted mean of each synapse's weights, with
+//     one mixing coefficient per parent (default equal, normalized to sum 1).
+//   - MergeMax keeps each synapse's largest weight across parents.
+//
+// Networks built independently have different neuron and synapse IDs, so
+// parents are aligned by neuron index (ID order) and, within each neuron pair,
+// by entry order. Topology is identical when every parent has the same number
+// of neurons and the same synapses per index pair; anything else is an error.
+// The merged matrix keeps the first parent's IDs. Network.MergeFrom applies
+// the merge to a receiving network with the same topology, for example a
+// fresh copy or one of the parents.
+
+// MergeMode selects how parent weights are combined.
+type MergeMode string
+
+const (
+	// MergeAverage takes the weighted mean.
+	MergeAverage MergeMode = "average"
+
+	// MergeMax takes the largest weight.
+	MergeMax MergeMode = "max"
+)
+
+// MergeConfig describes a merge.
+type MergeConfig struct {
+	Mode    MergeMode // Default MergeAverage
+	Weights []float64 // MergeAverage mixing coefficients, one per parent (nil = equal)
+}
+
+// MergeWeights combines the weight matrices of parents with identical
+// topology.
+func MergeWeights(parents []*WeightMatrix, config MergeConfig) (*WeightMatrix, error) {
+	if len(parents) == 0 {
+		return nil, fmt.Errorf("merge needs at least one parent")
+	}
+	if parents[0] == nil {
+		return nil, fmt.Errorf("parent 0 is nil")
+	}
+	return mergeInto(parents[0], parents, config)
+}
+
+// MergeFrom sets the network's weights to the merge of the parents' weights.
+// The network must have the parents' topology; its own weights take no part
+// unless it is listed as a parent. Returns the number of weights set.
+func (n *Network) MergeFrom(parents []*Network, config MergeConfig) (int, error) {
+	if len(parents) == 0 {
+		return 0, fmt.Errorf("merge needs at least one parent")
+	}
+	matrices := make([]*WeightMatrix, len(parents))
+	for i, parent := range parents {
+		if parent == nil {
+			return 0, fmt.Errorf("parent %d is nil", i)
+		}
+		matrices[i] = parent.ExportWeights()
+	}
+	merged, err := mergeInto(n.ExportWeights(), matrices, config)
+	if err != nil {
+		return 0, err
+	}
+	return n.ImportWeights(merged)
+}
+
+// mergeInto combines the parents' weights on the layout of reference, whose
+// IDs the result keeps.
+func mergeInto(reference *WeightMatrix, parents []*WeightMatrix, config MergeConfig) (*WeightMatrix, error) {
+	mixing, err := mergeCoefficients(len(parents), config)
+	if err != nil {
+		return nil, err
+	}
+	if err := reference.validateShape(); err != nil {
+		return nil, err
+	}
+
+	// Entry k of the reference corresponds to the same pair occurrence in
+	// every parent
+	type pair struct{ row, col int }
+	aligned := make([][]int, len(parents))
+	for p, parent := range parents {
+		if parent == nil {
+			return nil, fmt.Errorf("parent %d is nil", p)
+		}
+		if err := parent.validateShape(); err != nil {
+			return nil, fmt.Errorf("parent %d: %w", p, err)
+		}
+		if parent.Size() != reference.Size() || parent.NNZ() != reference.NNZ() {
+			return nil, fmt.Errorf("parent %d has %d neurons and %d synapses, expected %d and %d",
+				p, parent.Size(), parent.NNZ(), reference.Size(), reference.NNZ())
+		}
+		entries := make(map[pair][]int)
+		for k := range parent.Data {
+			key := pair{parent.Row[k], parent.Col[k]}
+			entries[key] = append(entries[key], k)
+		}
+		aligned[p] = make([]int, reference.NNZ())
+		for k := range reference.Data {
+			key := pair{reference.Row[k], reference.Col[k]}
+			if len(entries[key]) == 0 {
+				return nil, fmt.Errorf("parent %d has no synapse left for neuron pair (%d,%d)", p, key.row, key.col)
+			}
+			aligned[p][k] = entries[key][0]
+			entries[key] = entries[key][1:]
+		}
+	}
+
+	merged := &WeightMatrix{
+		NeuronIDs:  append([]string(nil), reference.NeuronIDs...),
+		Row:        append([]int(nil), reference.Row...),
+		Col:        append([]int(nil), reference.Col...),
+		Data:       make([]float64, reference.NNZ()),
+		SynapseIDs: append([]string(nil), reference.SynapseIDs...),
+	}
+	for k := range merged.Data {
+		value := 0.0
+		if config.Mode == MergeMax {
+			value = math.Inf(-1)
+		}
+		for p, parent := range parents {
+			weight := parent.Data[aligned[p][k]]
+			if math.IsNaN(weight) || math.IsInf(weight, 0) {
+				return nil, fmt.Errorf("parent %d entry %d: invalid weight %f", p, aligned[p][k], weight)
+			}
+			if config.Mode == MergeMax {
+				value = math.Max(value, weight)
+			} else {
+				value += mixing[p] * weight
+			}
+		}
+		merged.Data[k] = value
+	}
+	return merged, nil
+}
+
+// mergeCoefficients validates the mode and returns normalized mixing
+// coefficients.
+func mergeCoefficients(parents int, config MergeConfig) ([]float64, error) {
+	switch config.Mode {
+	case "", MergeAverage, MergeMax:
+	default:
+		return nil, fmt.Errorf("unknown merge mode: %q", config.Mode)
+	}
+	if config.Weights == nil {
+		mixing := make([]float64, parents)
+		for i := range mixing {
+			mixing[i] = 1 / float64(parents)
+		}
+		return mixing, nil
+	}
+	if len(config.Weights) != parents {
+		return nil, fmt.Errorf("%d merge weights for %d parents", len(config.Weights), parents)
+	}
+	total := 0.0
+	for i, w := range config.Weights {
+		if math.IsNaN(w) || math.IsInf(w, 0) || w < 0 {
+			return nil, fmt.Errorf("merge weight %d must be non-negative and finite: %f", i, w)
+		}
+		total += w
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("merge weights sum to zero")
+	}
+	mixing := make([]float64, parents)
+	for i, w := range config.Weights {
+		mixing[i] = w / total
+	}
+	return mixing, nil
+}
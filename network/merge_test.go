@@ -0,0 +1,68 @@
+package network
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestMergeTrainedNetworks verifies weighted averaging and max-selection
+// across independently built copies, alignment despite differing IDs, and
+// rejection of mismatched topologies.
+func TestMergeTrainedNetworks(t *testing.T) {
+	cell := types.NeuronConfig{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond, FireFactor: 1.0}
+	build := func(weight float64, size int) (*Network, *Projection) {
+		builder := &testBuilder{}
+		a, _ := NewPopulation(builder, "a", PopulationConfig{Size: 2, Neuron: cell})
+		b, _ := NewPopulation(builder, "b", PopulationConfig{Size: size, Neuron: cell})
+		proj, err := a.ConnectAllToAll(b, ConstantWeight(weight), nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return New(builder), proj
+	}
+	shardA, projA := build(0.2, 2)
+	shardB, _ := build(0.6, 2)
+	child, childProj := build(0, 2)
+	projA.Synapses()[0].SetWeight(1.0) // one synapse learned more on shard A
+
+	merged, err := MergeWeights([]*WeightMatrix{shardA.ExportWeights(), shardB.ExportWeights()},
+		MergeConfig{Weights: []float64{3, 1}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if merged.NNZ() != 4 || math.Abs(merged.Data[0]-0.9) > 1e-9 || math.Abs(merged.Data[1]-0.3) > 1e-9 {
+		t.Errorf("Expected 3:1 averages 0.9 and 0.3, got %v", merged.Data)
+	}
+
+	if n, err := child.MergeFrom([]*Network{shardA, shardB}, MergeConfig{Mode: MergeMax}); err != nil || n != 4 {
+		t.Fatalf("Expected 4 merged weights, got %d (%v)", n, err)
+	}
+	weights := childProj.Weights()
+	if weights[0] != 1.0 || weights[1] != 0.6 || weights[3] != 0.6 {
+		t.Errorf("Expected max-selected weights, got %v", weights)
+	}
+	if n, err := child.MergeFrom([]*Network{shardA, shardB}, MergeConfig{}); err != nil || n != 4 {
+		t.Fatalf("Unexpected result: %d (%v)", n, err)
+	}
+	if weights := childProj.Weights(); math.Abs(weights[0]-0.8) > 1e-9 || math.Abs(weights[1]-0.4) > 1e-9 {
+		t.Errorf("Expected equal averages ignoring the receiver's weights, got %v", weights)
+	}
+
+	other, _ := build(0.5, 3)
+	if _, err := child.MergeFrom([]*Network{shardA, other}, MergeConfig{}); err == nil {
+		t.Error("Expected error for a mismatched topology")
+	}
+	for _, config := range []MergeConfig{
+		{Mode: "median"},
+		{Weights: []float64{1}},
+		{Weights: []float64{0, 0}},
+		{Weights: []float64{-1, 2}},
+	} {
+		if _, err := child.MergeFrom([]*Network{shardA, shardB}, config); err == nil {
+			t.Errorf("Expected error for config %+v", config)
+		}
+	}
+}
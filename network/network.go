@@ -0,0 +1,89 @@
+/*
+=================================================================================
+NETWORK - WHOLE-NETWORK OPERATIONS
+=================================================================================
+
+Neurons and synapses are autonomous; the extracellular matrix coordinates them
+but deliberately does not impose global policies. Some operations, however,
+are naturally about the network as a whole: checking its structure before it
+starts, or inspecting its connectivity. The network package provides these on
+top of any source of components - usually an ExtracellularMatrix, or plain
+slices of neurons and synapses for hand-wired circuits.
+
+	net := network.New(matrix)
+	if report := net.Validate(); report.HasErrors() {
+	    log.Fatal(report)
+	}
+	matrix.Start()
+=================================================================================
+*/
+
+package network
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+)
+
+// Source enumerates the components of a network. ExtracellularMatrix
+// implements it.
+type Source interface {
+	ListNeurons() []component.NeuralComponent
+	ListSynapses() []component.SynapticProcessor
+}
+
+// staticSource is a fixed set of components.
+type staticSource struct {
+	neurons  []component.NeuralComponent
+	synapses []component.SynapticProcessor
+}
+
+func (s staticSource) ListNeurons() []component.NeuralComponent    { return s.neurons }
+func (s staticSource) ListSynapses() []component.SynapticProcessor { return s.synapses }
+
+// Network is a view over a set of neurons and synapses. Components are read
+// from the source on every operation, so the view follows a live matrix.
+type Network struct {
+	source Source
+
+	// Plasticity freeze (see freeze.go)
+	freezeMutex sync.Mutex
+	freezeDepth int
+	frozen      map[string]bool // Synapse ID -> STDP enabled before the freeze
+
+	// Serializes weight transaction commits (see transaction.go)
+	commitMutex sync.Mutex
+
+	// Lock-step runner and its pacing (nil = wall clock, see lockstep.go
+	// and timewarp.go)
+	lockStepMutex sync.Mutex
+	lockStep      *cosim.LockStep
+	warp          *cosim.TimeWarp
+}
+
+// New creates a network view over source.
+func New(source Source) *Network {
+	return &Network{source: source}
+}
+
+// FromComponents creates a network view over fixed component slices.
+func FromComponents(neurons []component.NeuralComponent, synapses []component.SynapticProcessor) *Network {
+	return New(staticSource{neurons: neurons, synapses: synapses})
+}
+
+// Neurons returns the network's neurons sorted by ID.
+func (n *Network) Neurons() []component.NeuralComponent {
+	neurons := append([]component.NeuralComponent(nil), n.source.ListNeurons()...)
+	sort.Slice(neurons, func(i, j int) bool { return neurons[i].ID() < neurons[j].ID() })
+	return neurons
+}
+
+// Synapses returns the network's synapses sorted by ID.
+func (n *Network) Synapses() []component.SynapticProcessor {
+	synapses := append([]component.SynapticProcessor(nil), n.source.ListSynapses()...)
+	sort.Slice(synapses, func(i, j int) bool { return synapses[i].ID() < synapses[j].ID() })
+	return synapses
+}
@@ -0,0 +1,67 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// newTestNeuron creates a neuron with standard parameters.
+func newTestNeuron(id string) *neuron.Neuron {
+	return neuron.NewNeuron(id, 1.0, 0.95, 2*time.Millisecond, 1.0, 0, 0)
+}
+
+// connect creates a static synapse between two neurons.
+func connect(id string, pre, post *neuron.Neuron, weight float64, delay time.Duration) component.SynapticProcessor {
+	config := synapse.CreateDefaultSTDPConfig()
+	config.Enabled = false
+	return synapse.NewBasicSynapse(id, pre, post, config, synapse.CreateDefaultPruningConfig(), weight, delay)
+}
+
+// testBuilder creates hand-wired neurons and synapses in place of a matrix.
+type testBuilder struct {
+	neurons  map[string]*neuron.Neuron
+	synapses []component.SynapticProcessor
+}
+
+func (b *testBuilder) CreateNeuron(config types.NeuronConfig) (component.NeuralComponent, error) {
+	if b.neurons == nil {
+		b.neurons = make(map[string]*neuron.Neuron)
+	}
+	n := neuron.NewNeuron(fmt.Sprintf("n%d", len(b.neurons)), config.Threshold, config.DecayRate,
+		config.RefractoryPeriod, config.FireFactor, 0, 0)
+	n.SetPosition(config.Position)
+	b.neurons[n.ID()] = n
+	return n, nil
+}
+
+func (b *testBuilder) CreateSynapse(config types.SynapseConfig) (component.SynapticProcessor, error) {
+	syn := connect(fmt.Sprintf("s%d", len(b.synapses)), b.neurons[config.PresynapticID],
+		b.neurons[config.PostsynapticID], config.InitialWeight, config.Delay)
+	b.synapses = append(b.synapses, syn)
+	return syn, nil
+}
+
+func (b *testBuilder) DeleteSynapse(synapseID string) error {
+	for i, syn := range b.synapses {
+		if syn.ID() == synapseID {
+			b.synapses = append(b.synapses[:i], b.synapses[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("synapse %s not found", synapseID)
+}
+
+func (b *testBuilder) ListNeurons() []component.NeuralComponent {
+	neurons := make([]component.NeuralComponent, 0, len(b.neurons))
+	for _, n := range b.neurons {
+		neurons = append(neurons, n)
+	}
+	return neurons
+}
+
+func (b *testBuilder) ListSynapses() []component.SynapticProcessor { return b.synapses }
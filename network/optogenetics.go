@@ -0,0 +1,211 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// OPTOGENETIC STIMULATION
+// =================================================================================
+//
+// Optogenetics makes chosen cells light-sensitive (the opsin is expressed in
+// one cell type or projection) and then drives them with patterned light:
+// channelrhodopsin depolarizes illuminated cells, halorhodopsin and
+// archaerhodopsin hyperpolarize them. Digital mirror devices project
+// arbitrary 2D masks that change frame by frame.
+//
+// An OptogeneticStimulator models such an experiment on spatially embedded
+// neurons. A LightPattern gives the light intensity at each position and
+// time; MaskVideo builds one from a sequence of pixel masks over the tissue's
+// X-Y plane. Every call to Step delivers Gain × intensity to each expressing
+// neuron, positive for activating and negative for inhibiting opsins, so the
+// stimulator runs as a stepper on a virtual clock or from a ticker on the
+// wall clock:
+//
+//	video, _ := network.MaskVideo(frames, 50*time.Millisecond, true)
+//	stim, _ := network.NewOptogeneticStimulator(network.OptogeneticConfig{
+//	    Targets: exc.Neurons(), Opsin: network.OpsinActivating, Pattern: video, Gain: 0.2,
+//	})
+//	runner.AddStepper("light", stim.Step)
+
+// OpsinType selects the sign of the light-evoked drive.
+type OpsinType string
+
+const (
+	// OpsinActivating depolarizes illuminated cells (channelrhodopsin).
+	OpsinActivating OpsinType = "activating"
+
+	// OpsinInhibiting hyperpolarizes illuminated cells (halorhodopsin,
+	// archaerhodopsin).
+	OpsinInhibiting OpsinType = "inhibiting"
+)
+
+// LightPattern returns the light intensity (0 = dark, 1 = full) at a
+// position, elapsed time after stimulation onset.
+type LightPattern func(elapsed time.Duration, position types.Position3D) float64
+
+// LightMask is one frame of light over the X-Y plane. Pixel (col, row)
+// covers X in [OriginX + col·PixelSize, OriginX + (col+1)·PixelSize) and Y
+// likewise; positions outside the mask are dark. Z is ignored.
+type LightMask struct {
+	OriginX, OriginY float64   // Corner of pixel (0, 0) in μm
+	PixelSize        float64   // Pixel edge length in μm
+	Width, Height    int       // Pixels per row and number of rows
+	Intensity        []float64 // Row-major, Width × Height values in [0, 1]
+}
+
+// Validate checks the mask dimensions and intensities.
+func (m LightMask) Validate() error {
+	if m.Width <= 0 || m.Height <= 0 {
+		return fmt.Errorf("light mask must have positive dimensions: %dx%d", m.Width, m.Height)
+	}
+	if math.IsNaN(m.PixelSize) || math.IsInf(m.PixelSize, 0) || m.PixelSize <= 0 {
+		return fmt.Errorf("light mask pixel size must be positive: %f", m.PixelSize)
+	}
+	if len(m.Intensity) != m.Width*m.Height {
+		return fmt.Errorf("light mask has %d intensities for %dx%d pixels", len(m.Intensity), m.Width, m.Height)
+	}
+	for i, v := range m.Intensity {
+		if math.IsNaN(v) || v < 0 || v > 1 {
+			return fmt.Errorf("light mask intensity %d must be in [0, 1]: %f", i, v)
+		}
+	}
+	return nil
+}
+
+// At returns the intensity at (x, y).
+func (m LightMask) At(x, y float64) float64 {
+	col := math.Floor((x - m.OriginX) / m.PixelSize)
+	row := math.Floor((y - m.OriginY) / m.PixelSize)
+	if col < 0 || row < 0 || col >= float64(m.Width) || row >= float64(m.Height) {
+		return 0
+	}
+	return m.Intensity[int(row)*m.Width+int(col)]
+}
+
+// MaskVideo plays frames for frameDuration each. After the last frame the
+// video restarts when loop is set and goes dark otherwise.
+func MaskVideo(frames []LightMask, frameDuration time.Duration, loop bool) (LightPattern, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("mask video needs at least one frame")
+	}
+	if frameDuration <= 0 {
+		return nil, fmt.Errorf("frame duration must be positive: %v", frameDuration)
+	}
+	for i, frame := range frames {
+		if err := frame.Validate(); err != nil {
+			return nil, fmt.Errorf("frame %d: %w", i, err)
+		}
+	}
+	frames = append([]LightMask(nil), frames...)
+	return func(elapsed time.Duration, position types.Position3D) float64 {
+		if elapsed < 0 {
+			return 0
+		}
+		index := int(elapsed / frameDuration)
+		if index >= len(frames) {
+			if !loop {
+				return 0
+			}
+			index %= len(frames)
+		}
+		return frames[index].At(position.X, position.Y)
+	}, nil
+}
+
+// OptogeneticConfig describes a stimulation experiment.
+type OptogeneticConfig struct {
+	Name    string                      // Source ID of the light-evoked signals (default "optogenetics")
+	Targets []component.NeuralComponent // Neurons expressing the opsin
+	Opsin   OpsinType                   // Sign of the drive
+	Pattern LightPattern                // Light over space and time
+	Gain    float64                     // Drive per step at full intensity (> 0)
+	Onset   time.Time                   // Stimulation onset; zero = first Step
+}
+
+// OptogeneticStats counts the stimulator's work.
+type OptogeneticStats struct {
+	Steps       int64 // Calls to Step
+	Pulses      int64 // Light-evoked signals delivered
+	Illuminated int   // Targets lit in the last step
+}
+
+// OptogeneticStimulator drives expressing neurons with patterned light.
+type OptogeneticStimulator struct {
+	config OptogeneticConfig
+	sign   float64
+
+	mu    sync.Mutex
+	onset time.Time
+	stats OptogeneticStats
+}
+
+// NewOptogeneticStimulator validates config and creates a stimulator.
+func NewOptogeneticStimulator(config OptogeneticConfig) (*OptogeneticStimulator, error) {
+	if len(config.Targets) == 0 {
+		return nil, fmt.Errorf("optogenetic stimulation needs at least one target")
+	}
+	if config.Pattern == nil {
+		return nil, fmt.Errorf("optogenetic stimulation needs a light pattern")
+	}
+	if math.IsNaN(config.Gain) || math.IsInf(config.Gain, 0) || config.Gain <= 0 {
+		return nil, fmt.Errorf("optogenetic gain must be positive and finite: %f", config.Gain)
+	}
+	sign := 1.0
+	switch config.Opsin {
+	case OpsinActivating:
+	case OpsinInhibiting:
+		sign = -1
+	default:
+		return nil, fmt.Errorf("unknown opsin type: %q", config.Opsin)
+	}
+	if config.Name == "" {
+		config.Name = "optogenetics"
+	}
+	config.Targets = append([]component.NeuralComponent(nil), config.Targets...)
+	return &OptogeneticStimulator{config: config, sign: sign, onset: config.Onset}, nil
+}
+
+// Step illuminates the targets for time now.
+func (s *OptogeneticStimulator) Step(now time.Time) {
+	s.mu.Lock()
+	if s.onset.IsZero() {
+		s.onset = now
+	}
+	elapsed := now.Sub(s.onset)
+	s.mu.Unlock()
+
+	illuminated := 0
+	for _, target := range s.config.Targets {
+		intensity := s.config.Pattern(elapsed, target.Position())
+		if intensity <= 0 {
+			continue
+		}
+		illuminated++
+		target.Receive(types.NeuralSignal{
+			Value:     s.sign * s.config.Gain * math.Min(intensity, 1),
+			Timestamp: now,
+			SourceID:  s.config.Name,
+			TargetID:  target.ID(),
+		})
+	}
+
+	s.mu.Lock()
+	s.stats.Steps++
+	s.stats.Pulses += int64(illuminated)
+	s.stats.Illuminated = illuminated
+	s.mu.Unlock()
+}
+
+// GetStats returns the stimulation counters.
+func (s *OptogeneticStimulator) GetStats() OptogeneticStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stats
+}
@@ -0,0 +1,114 @@
+package network
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// litNeuron records the light-evoked input it receives.
+type litNeuron struct {
+	*neuron.Neuron
+	received []float64
+}
+
+func (n *litNeuron) Receive(msg types.NeuralSignal) { n.received = append(n.received, msg.Value) }
+
+// TestLightMaskVideo verifies pixel lookup, frame timing and looping.
+func TestLightMaskVideo(t *testing.T) {
+	left := LightMask{OriginX: 0, OriginY: 0, PixelSize: 10, Width: 2, Height: 1, Intensity: []float64{1, 0}}
+	right := LightMask{OriginX: 0, OriginY: 0, PixelSize: 10, Width: 2, Height: 1, Intensity: []float64{0, 0.5}}
+	if got := left.At(5, 5); got != 1 {
+		t.Errorf("Expected lit pixel, got %f", got)
+	}
+	if got := left.At(25, 5) + left.At(-1, 5) + left.At(5, 10); got != 0 {
+		t.Errorf("Expected dark outside the mask, got %f", got)
+	}
+
+	at := func(pattern LightPattern, ms int, x float64) float64 {
+		return pattern(time.Duration(ms)*time.Millisecond, types.Position3D{X: x, Y: 5})
+	}
+	once, err := MaskVideo([]LightMask{left, right}, 10*time.Millisecond, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if at(once, 5, 5) != 1 || at(once, 15, 5) != 0 || at(once, 15, 15) != 0.5 || at(once, 25, 5) != 0 {
+		t.Error("Expected frames in order and darkness after the video")
+	}
+	looped, _ := MaskVideo([]LightMask{left, right}, 10*time.Millisecond, true)
+	if at(looped, 25, 5) != 1 || at(looped, 35, 15) != 0.5 {
+		t.Error("Expected the looped video to restart")
+	}
+
+	bad := left
+	bad.Intensity = []float64{1}
+	if _, err := MaskVideo([]LightMask{bad}, time.Millisecond, false); err == nil {
+		t.Error("Expected error for a mask with missing pixels")
+	}
+	bad.Intensity = []float64{1, 2}
+	if bad.Validate() == nil {
+		t.Error("Expected error for intensity above 1")
+	}
+	if _, err := MaskVideo([]LightMask{left}, 0, false); err == nil {
+		t.Error("Expected error for zero frame duration")
+	}
+}
+
+// TestOptogeneticStimulation verifies that only lit expressing neurons are
+// driven, with the opsin's sign, following the pattern on a virtual clock.
+func TestOptogeneticStimulation(t *testing.T) {
+	cells := make([]*litNeuron, 3)
+	targets := make([]component.NeuralComponent, 3)
+	for i := range cells {
+		cells[i] = &litNeuron{Neuron: newTestNeuron(fmt.Sprintf("c%d", i))}
+		cells[i].SetPosition(types.Position3D{X: float64(i) * 10, Y: 5})
+		targets[i] = cells[i]
+	}
+	// Frame 0 lights cell 0 fully and cell 1 at half; frame 1 lights cell 2
+	frames := []LightMask{
+		{PixelSize: 10, Width: 3, Height: 1, Intensity: []float64{1, 0.5, 0}},
+		{PixelSize: 10, Width: 3, Height: 1, Intensity: []float64{0, 0, 1}},
+	}
+	video, _ := MaskVideo(frames, 5*time.Millisecond, false)
+
+	runner, _ := cosim.NewLockStep(time.Unix(0, 0), time.Millisecond)
+	stim, err := NewOptogeneticStimulator(OptogeneticConfig{Targets: targets, Opsin: OpsinActivating, Pattern: video, Gain: 0.2})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	runner.AddStepper("light", stim.Step)
+	runner.Step(10 * time.Millisecond)
+
+	if len(cells[0].received) != 5 || len(cells[1].received) != 5 || len(cells[2].received) != 5 {
+		t.Fatalf("Expected five pulses per lit frame, got %d %d %d",
+			len(cells[0].received), len(cells[1].received), len(cells[2].received))
+	}
+	if cells[0].received[0] != 0.2 || cells[1].received[0] != 0.1 {
+		t.Errorf("Expected drive proportional to intensity, got %f and %f", cells[0].received[0], cells[1].received[0])
+	}
+	if stats := stim.GetStats(); stats.Steps != 10 || stats.Pulses != 15 || stats.Illuminated != 1 {
+		t.Errorf("Unexpected stats: %+v", stats)
+	}
+
+	inhibiting, _ := NewOptogeneticStimulator(OptogeneticConfig{Targets: targets[:1], Opsin: OpsinInhibiting, Pattern: video, Gain: 0.3})
+	inhibiting.Step(time.Unix(0, 0))
+	if last := cells[0].received[len(cells[0].received)-1]; last != -0.3 {
+		t.Errorf("Expected hyperpolarizing drive -0.3, got %f", last)
+	}
+
+	for _, config := range []OptogeneticConfig{
+		{Opsin: OpsinActivating, Pattern: video, Gain: 0.2},
+		{Targets: targets, Opsin: OpsinActivating, Gain: 0.2},
+		{Targets: targets, Opsin: OpsinActivating, Pattern: video},
+		{Targets: targets, Opsin: "thermal", Pattern: video, Gain: 0.2},
+	} {
+		if _, err := NewOptogeneticStimulator(config); err == nil {
+			t.Errorf("Expected error for config %+v", config)
+		}
+	}
+}
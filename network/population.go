@@ -0,0 +1,286 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// POPULATIONS
+// =================================================================================
+//
+// Large models are built from groups of identical neurons: an input layer, an
+// excitatory pool, an inhibitory pool. A Population creates N neurons from one
+// configuration and offers the operations models apply to the whole group,
+// so callers do not loop over individual neurons:
+//
+//	exc, _ := network.NewPopulation(matrix, "exc", network.PopulationConfig{Size: 800, Neuron: cfg})
+//	inh, _ := network.NewPopulation(matrix, "inh", network.PopulationConfig{Size: 200, Neuron: cfg})
+//	proj, _ := exc.ConnectAllToAll(inh, network.UniformWeight(0.1, 0.3), network.ConstantDelay(2*time.Millisecond))
+//	exc.StimulateAll(drive)
+//	rates := exc.GetRates()
+//
+// Members are ordinary goroutine-based neurons registered with the builder,
+// so everything else (validation, weight export, plasticity) sees them as
+// usual. batch.Population is the alternative when members only need
+// integrate-and-fire dynamics and should share one goroutine.
+
+// Builder creates neurons and synapses. ExtracellularMatrix implements it.
+type Builder interface {
+	CreateNeuron(config types.NeuronConfig) (component.NeuralComponent, error)
+	CreateSynapse(config types.SynapseConfig) (component.SynapticProcessor, error)
+}
+
+// WeightDistribution draws the weight of one new synapse.
+type WeightDistribution func(rng *rand.Rand) float64
+
+// DelayDistribution draws the delay of one new synapse.
+type DelayDistribution func(rng *rand.Rand) time.Duration
+
+// ConstantWeight gives every synapse the same weight.
+func ConstantWeight(weight float64) WeightDistribution {
+	return func(*rand.Rand) float64 { return weight }
+}
+
+// UniformWeight draws weights uniformly from [min, max).
+func UniformWeight(min, max float64) WeightDistribution {
+	return func(rng *rand.Rand) float64 { return min + rng.Float64()*(max-min) }
+}
+
+// NormalWeight draws weights from a normal distribution.
+func NormalWeight(mean, stdDev float64) WeightDistribution {
+	return func(rng *rand.Rand) float64 { return mean + rng.NormFloat64()*stdDev }
+}
+
+// ConstantDelay gives every synapse the same delay.
+func ConstantDelay(delay time.Duration) DelayDistribution {
+	return func(*rand.Rand) time.Duration { return delay }
+}
+
+// UniformDelay draws delays uniformly from [min, max).
+func UniformDelay(min, max time.Duration) DelayDistribution {
+	return func(rng *rand.Rand) time.Duration {
+		if max <= min {
+			return min
+		}
+		return min + time.Duration(rng.Int63n(int64(max-min)))
+	}
+}
+
+// PopulationConfig describes a group of identical neurons.
+type PopulationConfig struct {
+	Size      int                // Number of neurons
+	Neuron    types.NeuronConfig // Shared by all members; NeuronType selects the builder's factory
+	Positions []types.Position3D // Optional, one per member (overrides Neuron.Position)
+
+	// Synapse is the template for synapses leaving this population. Pre and
+	// post IDs, weight and delay are filled in per connection.
+	Synapse types.SynapseConfig
+
+	Seed int64 // Seeds the weight and delay distributions
+}
+
+// Population is a group of neurons created from one configuration.
+type Population struct {
+	id      string
+	builder Builder
+	config  PopulationConfig
+	neurons []component.NeuralComponent
+
+	rngMutex sync.Mutex
+	rng      *rand.Rand
+}
+
+// NewPopulation creates config.Size neurons with builder.
+func NewPopulation(builder Builder, id string, config PopulationConfig) (*Population, error) {
+	if builder == nil {
+		return nil, fmt.Errorf("population %s: builder is required", id)
+	}
+	if config.Size <= 0 {
+		return nil, fmt.Errorf("population %s: size must be positive: %d", id, config.Size)
+	}
+	if len(config.Positions) != 0 && len(config.Positions) != config.Size {
+		return nil, fmt.Errorf("population %s: %d positions for %d neurons", id, len(config.Positions), config.Size)
+	}
+
+	p := &Population{
+		id:      id,
+		builder: builder,
+		config:  config,
+		neurons: make([]component.NeuralComponent, config.Size),
+		rng:     rand.New(rand.NewSource(config.Seed)),
+	}
+	for i := range p.neurons {
+		neuronConfig := config.Neuron
+		if len(config.Positions) != 0 {
+			neuronConfig.Position = config.Positions[i]
+		}
+		neuron, err := builder.CreateNeuron(neuronConfig)
+		if err != nil {
+			return nil, fmt.Errorf("population %s: neuron %d: %w", id, i, err)
+		}
+		p.neurons[i] = neuron
+	}
+	return p, nil
+}
+
+// ID returns the population identifier.
+func (p *Population) ID() string {
+	return p.id
+}
+
+// Size returns the number of members.
+func (p *Population) Size() int {
+	return len(p.neurons)
+}
+
+// Neuron returns member i, or nil if i is out of range.
+func (p *Population) Neuron(i int) component.NeuralComponent {
+	if i < 0 || i >= len(p.neurons) {
+		return nil
+	}
+	return p.neurons[i]
+}
+
+// Neurons returns the members in creation order.
+func (p *Population) Neurons() []component.NeuralComponent {
+	return append([]component.NeuralComponent(nil), p.neurons...)
+}
+
+// StimulateAll delivers values[i] to member i. Zero values are skipped.
+func (p *Population) StimulateAll(values []float64) error {
+	if len(values) != len(p.neurons) {
+		return fmt.Errorf("population %s: %d values for %d neurons", p.id, len(values), len(p.neurons))
+	}
+	now := time.Now()
+	for i, value := range values {
+		if value == 0 {
+			continue
+		}
+		p.neurons[i].Receive(types.NeuralSignal{
+			Value:     value,
+			Timestamp: now,
+			SourceID:  p.id,
+			TargetID:  p.neurons[i].ID(),
+		})
+	}
+	return nil
+}
+
+// GetRates returns each member's current firing rate (Hz), in member order.
+func (p *Population) GetRates() []float64 {
+	rates := make([]float64, len(p.neurons))
+	for i, neuron := range p.neurons {
+		rates[i] = neuron.GetActivityLevel()
+	}
+	return rates
+}
+
+// ConnectAllToAll connects every member to every member of other, drawing
+// each weight and delay from the distributions (nil delays = the synapse
+// template's delay). Connecting a population to itself skips autapses.
+// Returns the created synapses as a projection, also when creation fails
+// part way.
+func (p *Population) ConnectAllToAll(other *Population, weights WeightDistribution, delays DelayDistribution) (*Projection, error) {
+	return p.connect(other, 1, weights, delays)
+}
+
+// ConnectRandom connects each pair of members independently with the given
+// probability, the Erdős–Rényi wiring used by anatomical connection
+// probability tables. Otherwise it behaves like ConnectAllToAll.
+func (p *Population) ConnectRandom(other *Population, probability float64, weights WeightDistribution, delays DelayDistribution) (*Projection, error) {
+	if math.IsNaN(probability) || probability < 0 || probability > 1 {
+		return nil, fmt.Errorf("population %s: connection probability must be in [0, 1]: %f", p.id, probability)
+	}
+	return p.connect(other, probability, weights, delays)
+}
+
+// connect creates the synapses of ConnectAllToAll and ConnectRandom. Pairs
+// are only drawn for probabilities below 1, so all-to-all wiring consumes
+// the same random numbers as before.
+func (p *Population) connect(other *Population, probability float64, weights WeightDistribution, delays DelayDistribution) (*Projection, error) {
+	if other == nil {
+		return nil, fmt.Errorf("population %s: target population is nil", p.id)
+	}
+	if weights == nil {
+		return nil, fmt.Errorf("population %s: weight distribution is required", p.id)
+	}
+
+	expected := int(math.Ceil(probability * float64(len(p.neurons)*len(other.neurons))))
+	synapses := make([]component.SynapticProcessor, 0, expected)
+	var err error
+connect:
+	for _, pre := range p.neurons {
+		for _, post := range other.neurons {
+			if pre == post {
+				continue
+			}
+			config := p.config.Synapse
+			config.PresynapticID = pre.ID()
+			config.PostsynapticID = post.ID()
+
+			p.rngMutex.Lock()
+			if probability < 1 && p.rng.Float64() >= probability {
+				p.rngMutex.Unlock()
+				continue
+			}
+			config.InitialWeight = weights(p.rng)
+			if delays != nil {
+				config.Delay = delays(p.rng)
+			}
+			p.rngMutex.Unlock()
+
+			syn, createErr := p.builder.CreateSynapse(config)
+			if createErr != nil {
+				err = fmt.Errorf("population %s: %s->%s: %w", p.id, pre.ID(), post.ID(), createErr)
+				break connect
+			}
+			synapses = append(synapses, syn)
+		}
+	}
+
+	projection, projErr := NewProjection(p, other, synapses)
+	if err == nil {
+		err = projErr
+	}
+	return projection, err
+}
+
+// memberIDs returns the set of member IDs.
+func (p *Population) memberIDs() map[string]bool {
+	ids := make(map[string]bool, len(p.neurons))
+	for _, neuron := range p.neurons {
+		ids[neuron.ID()] = true
+	}
+	return ids
+}
+
+// neuronByID returns the member with the given ID, or nil.
+func (p *Population) neuronByID(id string) component.NeuralComponent {
+	for _, neuron := range p.neurons {
+		if neuron.ID() == id {
+			return neuron
+		}
+	}
+	return nil
+}
+
+// GetStats returns the population size and its mean firing rate.
+func (p *Population) GetStats() map[string]interface{} {
+	rates := p.GetRates()
+	total := 0.0
+	for _, rate := range rates {
+		total += rate
+	}
+	return map[string]interface{}{
+		"id":        p.id,
+		"size":      len(p.neurons),
+		"mean_rate": total / float64(len(rates)),
+	}
+}
@@ -0,0 +1,58 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestPopulation verifies creation, all-to-all wiring with distributions,
+// vectorized stimulation and rate readout.
+func TestPopulation(t *testing.T) {
+	builder := &testBuilder{}
+	cell := types.NeuronConfig{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond, FireFactor: 1.0}
+	in, err := NewPopulation(builder, "in", PopulationConfig{Size: 3, Neuron: cell, Seed: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	out, err := NewPopulation(builder, "out", PopulationConfig{Size: 2, Neuron: cell})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	proj, err := in.ConnectAllToAll(out, UniformWeight(0.2, 0.4), UniformDelay(time.Millisecond, 3*time.Millisecond))
+	if err != nil || proj.Size() != 6 {
+		t.Fatalf("Expected 6 synapses, got %v (%v)", proj, err)
+	}
+	for _, syn := range proj.Synapses() {
+		if w, d := syn.GetWeight(), syn.GetDelay(); w < 0.2 || w >= 0.4 || d < time.Millisecond || d >= 3*time.Millisecond {
+			t.Errorf("Synapse %s outside distributions: %f, %v", syn.ID(), w, d)
+		}
+	}
+	recurrent, err := out.ConnectAllToAll(out, ConstantWeight(0.1), nil)
+	if err != nil || recurrent.Size() != 2 {
+		t.Errorf("Expected 2 recurrent synapses without autapses, got %v (%v)", recurrent, err)
+	}
+
+	for _, n := range in.Neurons() {
+		n.Start()
+		defer n.Stop()
+	}
+	if err := in.StimulateAll([]float64{1}); err == nil {
+		t.Error("Expected error for a value count mismatch")
+	}
+	if err := in.StimulateAll([]float64{2.0, 0, 2.0}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	rates := in.GetRates()
+	if rates[0] <= 0 || rates[1] != 0 || rates[2] <= 0 {
+		t.Errorf("Expected stimulated members to fire, got rates %v", rates)
+	}
+
+	if _, err := NewPopulation(builder, "bad", PopulationConfig{Size: 2, Neuron: cell, Positions: make([]types.Position3D, 1)}); err == nil {
+		t.Error("Expected error for a position count mismatch")
+	}
+}
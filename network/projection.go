@@ -0,0 +1,322 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// PROJECTIONS
+// =================================================================================
+//
+// A projection is the bundle of synapses from one population to another, the
+// unit in which anatomy describes connectivity (thalamocortical, L4→L2/3) and
+// in which models tune it. Operations apply to every synapse of the bundle:
+//
+//	proj, _ := exc.ConnectAllToAll(inh, network.UniformWeight(0.1, 0.3), nil)
+//	proj.ScaleWeights(0.5)
+//	proj.PruneByPercentile(20)         // drop the weakest fifth
+//	hist := proj.WeightHistogram(20)
+//
+// Each synapse is read and written under its own lock, so bulk operations are
+// safe on a running network but are not atomic across the projection.
+
+// synapseDeleter is implemented by builders that can remove synapses
+// (ExtracellularMatrix).
+type synapseDeleter interface {
+	DeleteSynapse(synapseID string) error
+}
+
+// outputDetacher is implemented by neurons that can drop an output callback.
+type outputDetacher interface {
+	RemoveOutputCallback(synapseID string)
+}
+
+// WeightHistogram counts weights in equal-width bins, in the layout of NumPy's
+// histogram: bin i spans [Edges[i], Edges[i+1]), the last bin is closed.
+type WeightHistogram struct {
+	Edges  []float64 `json:"edges"`
+	Counts []int     `json:"counts"`
+}
+
+// Projection is the set of synapses from one population to another.
+type Projection struct {
+	pre, post *Population
+
+	mu       sync.RWMutex
+	synapses []component.SynapticProcessor // Sorted by ID
+
+	// net is a view over the projection's synapses; it provides the freeze
+	net *Network
+}
+
+// NewProjection groups synapses from pre to post. Every synapse must connect
+// a member of pre to a member of post.
+func NewProjection(pre, post *Population, synapses []component.SynapticProcessor) (*Projection, error) {
+	if pre == nil || post == nil {
+		return nil, fmt.Errorf("projection needs both populations")
+	}
+	preIDs, postIDs := pre.memberIDs(), post.memberIDs()
+	for _, syn := range synapses {
+		if !preIDs[syn.GetPresynapticID()] || !postIDs[syn.GetPostsynapticID()] {
+			return nil, fmt.Errorf("synapse %s connects %s->%s, outside %s->%s", syn.ID(),
+				syn.GetPresynapticID(), syn.GetPostsynapticID(), pre.ID(), post.ID())
+		}
+	}
+
+	p := &Projection{
+		pre:      pre,
+		post:     post,
+		synapses: append([]component.SynapticProcessor(nil), synapses...),
+	}
+	sort.Slice(p.synapses, func(i, j int) bool { return p.synapses[i].ID() < p.synapses[j].ID() })
+	p.net = New(p)
+	return p, nil
+}
+
+// Projection collects the network's synapses from pre to post.
+func (n *Network) Projection(pre, post *Population) (*Projection, error) {
+	if pre == nil || post == nil {
+		return nil, fmt.Errorf("projection needs both populations")
+	}
+	preIDs, postIDs := pre.memberIDs(), post.memberIDs()
+	var synapses []component.SynapticProcessor
+	for _, syn := range n.Synapses() {
+		if preIDs[syn.GetPresynapticID()] && postIDs[syn.GetPostsynapticID()] {
+			synapses = append(synapses, syn)
+		}
+	}
+	return NewProjection(pre, post, synapses)
+}
+
+// Pre returns the source population.
+func (p *Projection) Pre() *Population { return p.pre }
+
+// Post returns the target population.
+func (p *Projection) Post() *Population { return p.post }
+
+// Size returns the number of synapses.
+func (p *Projection) Size() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.synapses)
+}
+
+// Synapses returns the projection's synapses sorted by ID.
+func (p *Projection) Synapses() []component.SynapticProcessor {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return append([]component.SynapticProcessor(nil), p.synapses...)
+}
+
+// ListNeurons implements Source with the members of both populations.
+func (p *Projection) ListNeurons() []component.NeuralComponent {
+	neurons := p.pre.Neurons()
+	if p.post != p.pre {
+		neurons = append(neurons, p.post.Neurons()...)
+	}
+	return neurons
+}
+
+// ListSynapses implements Source.
+func (p *Projection) ListSynapses() []component.SynapticProcessor {
+	return p.Synapses()
+}
+
+// Weights returns the synapse weights in synapse ID order.
+func (p *Projection) Weights() []float64 {
+	synapses := p.Synapses()
+	weights := make([]float64, len(synapses))
+	for i, syn := range synapses {
+		weights[i] = syn.GetWeight()
+	}
+	return weights
+}
+
+// ScaleWeights multiplies every weight by factor. Plastic synapses clamp the
+// result to their weight bounds.
+func (p *Projection) ScaleWeights(factor float64) error {
+	if math.IsNaN(factor) || math.IsInf(factor, 0) || factor < 0 {
+		return fmt.Errorf("scale factor must be finite and non-negative: %f", factor)
+	}
+	for _, syn := range p.Synapses() {
+		syn.SetWeight(syn.GetWeight() * factor)
+	}
+	return nil
+}
+
+// SetPlasticityConfig gives every synapse the same STDP configuration.
+// Synapses without configurable plasticity are skipped; the first rejected
+// configuration is returned after the rest have been updated.
+func (p *Projection) SetPlasticityConfig(config types.PlasticityConfig) error {
+	var firstErr error
+	for _, syn := range p.Synapses() {
+		plastic, ok := syn.(plasticityConfigurable)
+		if !ok {
+			continue
+		}
+		if err := plastic.SetPlasticityConfig(config); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("synapse %s: %w", syn.ID(), err)
+		}
+	}
+	return firstErr
+}
+
+// prioritizable is implemented by synapses with a delivery priority class
+// (synapse.BasicSynapse).
+type prioritizable interface {
+	SetPriority(priority types.Priority) error
+}
+
+// SetPriority gives every synapse of the projection the same delivery
+// priority class, e.g. types.PriorityCritical for a sensory pathway. Returns
+// the number of synapses configured.
+func (p *Projection) SetPriority(priority types.Priority) (int, error) {
+	if err := priority.Validate(); err != nil {
+		return 0, err
+	}
+	configured := 0
+	for _, syn := range p.Synapses() {
+		if target, ok := syn.(prioritizable); ok {
+			if err := target.SetPriority(priority); err != nil {
+				return configured, fmt.Errorf("synapse %s: %w", syn.ID(), err)
+			}
+			configured++
+		}
+	}
+	return configured, nil
+}
+
+// UseMiddleware appends transmission middleware to every synapse of the
+// projection. Returns the number of synapses configured.
+func (p *Projection) UseMiddleware(middleware ...synapse.Middleware) int {
+	return synapse.UseMiddlewareOn(p.Synapses(), nil, middleware...)
+}
+
+// FreezePlasticity disables STDP on the projection until the matching
+// Unfreeze. It behaves like Network.FreezePlasticity.
+func (p *Projection) FreezePlasticity() error {
+	return p.net.FreezePlasticity()
+}
+
+// Unfreeze ends a FreezePlasticity.
+func (p *Projection) Unfreeze() error {
+	return p.net.Unfreeze()
+}
+
+// IsPlasticityFrozen reports whether a freeze of the projection is in effect.
+func (p *Projection) IsPlasticityFrozen() bool {
+	return p.net.IsPlasticityFrozen()
+}
+
+// PruneByPercentile removes the weakest percentile percent of the synapses
+// (ties broken by synapse ID). Pruned synapses are detached from their
+// presynaptic neuron and deleted from the builder when it supports deletion.
+// Returns the IDs of the pruned synapses.
+func (p *Projection) PruneByPercentile(percentile float64) ([]string, error) {
+	if math.IsNaN(percentile) || percentile < 0 || percentile > 100 {
+		return nil, fmt.Errorf("percentile must be in [0, 100]: %f", percentile)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ranked := append([]component.SynapticProcessor(nil), p.synapses...)
+	weights := make(map[string]float64, len(ranked))
+	for _, syn := range ranked {
+		weights[syn.ID()] = syn.GetWeight()
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return weights[ranked[i].ID()] < weights[ranked[j].ID()] })
+
+	count := int(math.Floor(float64(len(ranked)) * percentile / 100))
+	pruned := make(map[string]bool, count)
+	ids := make([]string, 0, count)
+	deleter, _ := p.pre.builder.(synapseDeleter)
+	var err error
+	for _, syn := range ranked[:count] {
+		if detacher, ok := p.pre.neuronByID(syn.GetPresynapticID()).(outputDetacher); ok {
+			detacher.RemoveOutputCallback(syn.ID())
+		}
+		if deleter != nil {
+			if err = deleter.DeleteSynapse(syn.ID()); err != nil {
+				err = fmt.Errorf("failed to prune synapse %s: %w", syn.ID(), err)
+				break
+			}
+		}
+		pruned[syn.ID()] = true
+		ids = append(ids, syn.ID())
+	}
+
+	kept := p.synapses[:0]
+	for _, syn := range p.synapses {
+		if !pruned[syn.ID()] {
+			kept = append(kept, syn)
+		}
+	}
+	p.synapses = kept
+	sort.Strings(ids)
+	return ids, err
+}
+
+// WeightHistogram counts the weights in bins equal-width bins between the
+// smallest and largest weight.
+func (p *Projection) WeightHistogram(bins int) WeightHistogram {
+	if bins <= 0 {
+		bins = 1
+	}
+	hist := WeightHistogram{Edges: make([]float64, bins+1), Counts: make([]int, bins)}
+	weights := p.Weights()
+	if len(weights) == 0 {
+		return hist
+	}
+
+	low, high := weights[0], weights[0]
+	for _, w := range weights {
+		low, high = math.Min(low, w), math.Max(high, w)
+	}
+	width := (high - low) / float64(bins)
+	for i := range hist.Edges {
+		hist.Edges[i] = low + float64(i)*width
+	}
+	hist.Edges[bins] = high
+
+	for _, w := range weights {
+		bin := bins - 1
+		if width > 0 {
+			bin = int((w - low) / width)
+			if bin >= bins {
+				bin = bins - 1
+			}
+		}
+		hist.Counts[bin]++
+	}
+	return hist
+}
+
+// GetStats returns the projection's size and weight summary.
+func (p *Projection) GetStats() map[string]interface{} {
+	weights := p.Weights()
+	stats := map[string]interface{}{
+		"pre":      p.pre.ID(),
+		"post":     p.post.ID(),
+		"synapses": len(weights),
+		"frozen":   p.IsPlasticityFrozen(),
+	}
+	if len(weights) > 0 {
+		sum, low, high := 0.0, weights[0], weights[0]
+		for _, w := range weights {
+			sum += w
+			low, high = math.Min(low, w), math.Max(high, w)
+		}
+		stats["mean_weight"] = sum / float64(len(weights))
+		stats["min_weight"] = low
+		stats["max_weight"] = high
+	}
+	return stats
+}
@@ -0,0 +1,120 @@
+package network
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestProjection verifies bulk weight scaling, STDP configuration, freezing,
+// percentile pruning and weight histograms on a projection.
+func TestProjection(t *testing.T) {
+	builder := &testBuilder{}
+	cell := types.NeuronConfig{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond, FireFactor: 1.0}
+	a, _ := NewPopulation(builder, "a", PopulationConfig{Size: 5, Neuron: cell, Seed: 7})
+	b, _ := NewPopulation(builder, "b", PopulationConfig{Size: 2, Neuron: cell})
+	if _, err := b.ConnectAllToAll(a, ConstantWeight(0.9), nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	proj, err := a.ConnectAllToAll(b, UniformWeight(0.1, 0.5), nil)
+	if err != nil || proj.Size() != 10 {
+		t.Fatalf("Expected 10 synapses, got %v (%v)", proj, err)
+	}
+
+	// The network view finds the same bundle and ignores the reverse direction
+	found, err := New(builder).Projection(a, b)
+	if err != nil || found.Size() != 10 {
+		t.Fatalf("Expected to find 10 a->b synapses, got %v (%v)", found, err)
+	}
+
+	before := proj.Weights()
+	if err := proj.ScaleWeights(2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i, w := range proj.Weights() {
+		if math.Abs(w-2*before[i]) > 1e-9 {
+			t.Errorf("Synapse %d: expected %f, got %f", i, 2*before[i], w)
+		}
+	}
+	if proj.ScaleWeights(-1) == nil {
+		t.Error("Expected error for a negative scale factor")
+	}
+
+	config := synapse.CreateDefaultSTDPConfig()
+	config.LearningRate = 0.02
+	if err := proj.SetPlasticityConfig(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := proj.FreezePlasticity(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, syn := range proj.Synapses() {
+		if syn.(*synapse.BasicSynapse).GetPlasticityConfig().Enabled {
+			t.Fatal("Expected STDP disabled during the freeze")
+		}
+	}
+	proj.Unfreeze()
+	if cfg := proj.Synapses()[0].(*synapse.BasicSynapse).GetPlasticityConfig(); !cfg.Enabled || cfg.LearningRate != 0.02 {
+		t.Errorf("Expected shared STDP config restored, got %+v", cfg)
+	}
+
+	if proj.UseMiddleware(synapse.ScaleMiddleware(0.5)) != 10 {
+		t.Error("Expected middleware on all 10 synapses")
+	}
+
+	hist := proj.WeightHistogram(4)
+	total := 0
+	for _, count := range hist.Counts {
+		total += count
+	}
+	if total != 10 || len(hist.Edges) != 5 || hist.Edges[0] > hist.Edges[4] {
+		t.Errorf("Unexpected histogram: %+v", hist)
+	}
+
+	weights := proj.Weights()
+	sort.Float64s(weights)
+	pruned, err := proj.PruneByPercentile(30)
+	if err != nil || len(pruned) != 3 || proj.Size() != 7 {
+		t.Fatalf("Expected 3 of 10 pruned, got %v (%v)", pruned, err)
+	}
+	for _, w := range proj.Weights() {
+		if w < weights[3] {
+			t.Errorf("Weight %f survived below the 30th percentile %f", w, weights[3])
+		}
+	}
+	if len(builder.synapses) != 17 {
+		t.Errorf("Expected pruned synapses deleted from the builder, %d left", len(builder.synapses))
+	}
+}
+
+// TestProjectionPriority verifies that a projection's synapses take one
+// priority class and that unknown classes are rejected.
+func TestProjectionPriority(t *testing.T) {
+	cell := types.NeuronConfig{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond, FireFactor: 1.0}
+	builder := &testBuilder{}
+	sensors, _ := NewPopulation(builder, "sensors", PopulationConfig{Size: 2, Neuron: cell})
+	motor, _ := NewPopulation(builder, "motor", PopulationConfig{Size: 1, Neuron: cell})
+	proj, err := sensors.ConnectAllToAll(motor, ConstantWeight(0.5), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if n, err := proj.SetPriority(types.PriorityCritical); err != nil || n != 2 {
+		t.Fatalf("Expected 2 synapses configured, got %d (%v)", n, err)
+	}
+	for _, syn := range proj.Synapses() {
+		if got := syn.(*synapse.BasicSynapse).GetPriority(); got != types.PriorityCritical {
+			t.Errorf("Expected critical priority on %s, got %v", syn.ID(), got)
+		}
+	}
+	if _, err := proj.SetPriority(types.Priority(3)); err == nil {
+		t.Error("Expected error for unknown priority")
+	}
+	if report := New(builder).DeadlineReport(); len(report.Drops) != 3 || report.Drops[types.PriorityCritical] != 0 {
+		t.Errorf("Expected zero drops for every priority class, got %v", report.Drops)
+	}
+}
@@ -0,0 +1,89 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// REAL-TIME MODE
+// =================================================================================
+//
+// Real-time mode is enforced per neuron (see neuron.SetLatencyBudget): each
+// neuron checks its inputs against the latency budget and sheds background
+// input when it falls behind. EnableRealTime applies one budget to the whole
+// network, and DeadlineReport sums the neurons' counters so a control loop
+// can check after every tick whether the network kept up. The report also
+// sums the inputs each neuron lost to load, per priority class.
+
+// latencyBudgeted is implemented by neurons with real-time mode
+// (neuron.Neuron).
+type latencyBudgeted interface {
+	SetLatencyBudget(budget neuron.LatencyBudget) error
+	GetDeadlineStats() neuron.DeadlineStats
+	GetPriorityDrops() map[types.Priority]int64
+}
+
+// DeadlineReport summarizes real-time mode across the network.
+type DeadlineReport struct {
+	Checked    int64         // Inputs checked against their budget
+	Misses     int64         // Inputs over budget
+	Shed       int64         // Background inputs dropped
+	MaxLatency time.Duration // Largest latency seen by any neuron
+	Missed     []string      // Neurons with at least one miss, sorted by ID
+	Overloaded []string      // Neurons currently shedding background input, sorted by ID
+
+	Drops map[types.Priority]int64 // Inputs lost to full buffers or shedding, per priority class
+}
+
+// EnableRealTime sets the latency budget of every neuron that supports
+// real-time mode; a zero budget disables it. Returns the number of neurons
+// configured.
+func (n *Network) EnableRealTime(budget neuron.LatencyBudget) (int, error) {
+	if budget.Budget < 0 {
+		return 0, fmt.Errorf("latency budget cannot be negative: %v", budget.Budget)
+	}
+	configured := 0
+	for _, cell := range n.Neurons() {
+		target, ok := cell.(latencyBudgeted)
+		if !ok {
+			continue
+		}
+		if err := target.SetLatencyBudget(budget); err != nil {
+			return configured, fmt.Errorf("neuron %s: %w", cell.ID(), err)
+		}
+		configured++
+	}
+	return configured, nil
+}
+
+// DeadlineReport returns the summed real-time counters of all neurons.
+func (n *Network) DeadlineReport() DeadlineReport {
+	report := DeadlineReport{Drops: make(map[types.Priority]int64)}
+	for _, cell := range n.Neurons() {
+		target, ok := cell.(latencyBudgeted)
+		if !ok {
+			continue
+		}
+		stats := target.GetDeadlineStats()
+		report.Checked += stats.Checked
+		report.Misses += stats.Misses
+		report.Shed += stats.Shed
+		if stats.MaxLatency > report.MaxLatency {
+			report.MaxLatency = stats.MaxLatency
+		}
+		if stats.Misses > 0 {
+			report.Missed = append(report.Missed, cell.ID())
+		}
+		if stats.Overloaded {
+			report.Overloaded = append(report.Overloaded, cell.ID())
+		}
+		for priority, drops := range target.GetPriorityDrops() {
+			report.Drops[priority] += drops
+		}
+	}
+	return report
+}
@@ -0,0 +1,45 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestRealTimeDeadlineReport verifies that the network-wide budget reaches
+// every neuron and that the report sums their deadline misses.
+func TestRealTimeDeadlineReport(t *testing.T) {
+	fast, slow := newTestNeuron("fast"), newTestNeuron("slow")
+	net := FromComponents([]component.NeuralComponent{fast, slow}, nil)
+
+	if n, err := net.EnableRealTime(neuron.LatencyBudget{Budget: 10 * time.Millisecond}); err != nil || n != 2 {
+		t.Fatalf("Expected 2 neurons configured, got %d (%v)", n, err)
+	}
+	if _, err := net.EnableRealTime(neuron.LatencyBudget{Budget: -time.Millisecond}); err == nil {
+		t.Error("Expected error for negative budget")
+	}
+	for _, cell := range []*neuron.Neuron{fast, slow} {
+		if err := cell.Start(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer cell.Stop()
+	}
+
+	fast.Receive(types.NeuralSignal{Value: 0.1, SourceID: "sensor", Timestamp: time.Now()})
+	slow.Receive(types.NeuralSignal{Value: 0.1, SourceID: "sensor", Timestamp: time.Now().Add(-time.Second)})
+	deadline := time.Now().Add(time.Second)
+	for net.DeadlineReport().Checked < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	report := net.DeadlineReport()
+	if report.Checked != 2 || report.Misses != 1 || len(report.Missed) != 1 || report.Missed[0] != "slow" {
+		t.Errorf("Expected one miss on slow, got %+v", report)
+	}
+	if report.MaxLatency < time.Second {
+		t.Errorf("Expected max latency of at least 1s, got %v", report.MaxLatency)
+	}
+}
@@ -0,0 +1,127 @@
+package network
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// =================================================================================
+// TRANSIENT SILENCING SCHEDULE
+// =================================================================================
+//
+// Perturbation experiments block a pathway for a period ("silence L4→L2/3
+// from 2s to 5s") and compare activity before, during and after. A
+// SilencingSchedule holds such windows, each over a set of synapses or a
+// projection, and applies them when it is stepped:
+//
+//	schedule := network.NewSilencingSchedule(runner.Now())
+//	schedule.BlockProjection(l4ToL23, 2*time.Second, 5*time.Second)
+//	runner.AddStepper("silencing", schedule.Step) // cosim.LockStep
+//
+// Step takes the current time, so the schedule follows a virtual clock when
+// registered as a stepper and the wall clock when called from a ticker.
+// Windows are half-open [From, To) offsets from the schedule's start, and a
+// synapse is silenced while any window covering it is active. The schedule
+// only writes a synapse's state when its own decision changes, so synapses
+// silenced by hand outside their windows are left alone. Synapses that do
+// not support conduction block (synapse.Silenceable) are skipped.
+
+// SilencingWindow is one scheduled block.
+type SilencingWindow struct {
+	Name     string
+	From, To time.Duration // Offsets from the schedule start, [From, To)
+	Synapses []component.SynapticProcessor
+}
+
+// SilencingSchedule applies silencing windows over time.
+type SilencingSchedule struct {
+	start time.Time
+
+	mu      sync.Mutex
+	windows []SilencingWindow
+	applied map[string]bool // Synapse ID -> last state written by the schedule
+}
+
+// NewSilencingSchedule creates a schedule whose offsets count from start.
+func NewSilencingSchedule(start time.Time) *SilencingSchedule {
+	return &SilencingSchedule{start: start, applied: make(map[string]bool)}
+}
+
+// Block silences synapses during [from, to). Returns the number of synapses
+// that support silencing.
+func (s *SilencingSchedule) Block(name string, from, to time.Duration, synapses []component.SynapticProcessor) (int, error) {
+	if from < 0 || to <= from {
+		return 0, fmt.Errorf("silencing window %s must satisfy 0 <= from < to: [%v, %v)", name, from, to)
+	}
+	var targets []component.SynapticProcessor
+	for _, syn := range synapses {
+		if _, ok := syn.(synapse.Silenceable); ok {
+			targets = append(targets, syn)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.windows = append(s.windows, SilencingWindow{Name: name, From: from, To: to, Synapses: targets})
+	return len(targets), nil
+}
+
+// BlockProjection silences every synapse of a projection during [from, to).
+func (s *SilencingSchedule) BlockProjection(p *Projection, from, to time.Duration) (int, error) {
+	if p == nil {
+		return 0, fmt.Errorf("silencing needs a projection")
+	}
+	return s.Block(p.Pre().ID()+"->"+p.Post().ID(), from, to, p.Synapses())
+}
+
+// Windows returns the scheduled windows in the order they were added.
+func (s *SilencingSchedule) Windows() []SilencingWindow {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SilencingWindow(nil), s.windows...)
+}
+
+// Active returns the names of the windows active at now.
+func (s *SilencingSchedule) Active(now time.Time) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var names []string
+	for _, window := range s.windows {
+		if s.covers(window, now) {
+			names = append(names, window.Name)
+		}
+	}
+	return names
+}
+
+// Step silences and restores synapses for time now.
+func (s *SilencingSchedule) Step(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	silenced := make(map[string]bool)
+	targets := make(map[string]synapse.Silenceable)
+	for _, window := range s.windows {
+		active := s.covers(window, now)
+		for _, syn := range window.Synapses {
+			targets[syn.ID()] = syn.(synapse.Silenceable)
+			silenced[syn.ID()] = silenced[syn.ID()] || active
+		}
+	}
+	for id, target := range targets {
+		if s.applied[id] != silenced[id] {
+			target.SetSilenced(silenced[id])
+			s.applied[id] = silenced[id]
+		}
+	}
+}
+
+// covers reports whether window is active at now.
+func (s *SilencingSchedule) covers(window SilencingWindow, now time.Time) bool {
+	offset := now.Sub(s.start)
+	return offset >= window.From && offset < window.To
+}
@@ -0,0 +1,67 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestSilencingScheduleOnVirtualClock verifies that scheduled windows
+// silence and restore a projection on a lock-step clock, that overlapping
+// windows extend the block and that hand-set silencing is left alone.
+func TestSilencingScheduleOnVirtualClock(t *testing.T) {
+	builder := &testBuilder{}
+	cell := types.NeuronConfig{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond, FireFactor: 1.0}
+	a, _ := NewPopulation(builder, "a", PopulationConfig{Size: 2, Neuron: cell})
+	b, _ := NewPopulation(builder, "b", PopulationConfig{Size: 1, Neuron: cell})
+	proj, err := a.ConnectAllToAll(b, ConstantWeight(0.5), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	first, second := proj.Synapses()[0].(*synapse.BasicSynapse), proj.Synapses()[1].(*synapse.BasicSynapse)
+
+	runner, _ := cosim.NewLockStep(time.Unix(0, 0), time.Millisecond)
+	schedule := NewSilencingSchedule(runner.Now())
+	if n, err := schedule.BlockProjection(proj, 20*time.Millisecond, 50*time.Millisecond); err != nil || n != 2 {
+		t.Fatalf("Expected 2 silenceable synapses, got %d (%v)", n, err)
+	}
+	if _, err := schedule.Block("late", 40*time.Millisecond, 70*time.Millisecond, []component.SynapticProcessor{second}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := schedule.Block("bad", 10*time.Millisecond, 10*time.Millisecond, nil); err == nil {
+		t.Error("Expected error for an empty window")
+	}
+	runner.AddStepper("silencing", schedule.Step)
+
+	runner.Step(10 * time.Millisecond)
+	if first.IsSilenced() || second.IsSilenced() {
+		t.Error("Expected transmission before the window")
+	}
+	runner.Step(20 * time.Millisecond)
+	if !first.IsSilenced() || !second.IsSilenced() {
+		t.Error("Expected the projection silenced during the window")
+	}
+	runner.Step(15 * time.Millisecond) // t = 45ms
+	if active := schedule.Active(runner.Now()); len(active) != 2 || active[0] != "a->b" || active[1] != "late" {
+		t.Errorf("Expected both windows active, got %v", active)
+	}
+	runner.Step(15 * time.Millisecond) // t = 60ms
+	if first.IsSilenced() || !second.IsSilenced() {
+		t.Error("Expected only the overlapping window to remain")
+	}
+	runner.Step(20 * time.Millisecond)
+	if second.IsSilenced() {
+		t.Error("Expected transmission restored after all windows")
+	}
+
+	// Silencing by hand outside the windows is not overridden
+	first.SetSilenced(true)
+	runner.Step(10 * time.Millisecond)
+	if !first.IsSilenced() {
+		t.Error("Expected hand-set silencing left alone")
+	}
+}
@@ -0,0 +1,497 @@
+package network
+
+import (
+	"container/heap"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/affinity"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// SPIKE BATCHING BETWEEN POPULATIONS
+// =================================================================================
+//
+// When population A projects densely onto population B, every spike of A
+// becomes one channel send per synapse, each waking a different goroutine
+// of B, often on another NUMA node. Batching replaces those sends with one
+// message per batch:
+//
+//	shard, _ := network.NewSpikeShard(b, network.SpikeShardConfig{Affinity: groups["b"]})
+//	shard.Start()
+//	outbox, _ := proj.BatchDelivery(shard, network.SpikeBatchConfig{})
+//	defer outbox.Close() // before shard.Stop
+//
+// The projection's synapses hand their spikes to the outbox (see
+// synapse.SetSpikeSink), which packs them into a SpikeBatch of (target,
+// signal, delay) tuples. A batch is sent when it holds MaxSize spikes or
+// FlushInterval after its first spike. The shard, one goroutine for the
+// receiving population (pinned next to it if given an affinity group),
+// decodes each batch and delivers to its members, holding delayed spikes
+// until they are due. Several projections into the same population share
+// its shard.
+//
+// The time a spike spends in the outbox is subtracted from its delay, so
+// spikes with delays longer than FlushInterval arrive on time; faster
+// spikes arrive up to FlushInterval late. When the shard's queue is full or
+// the shard is stopped, the sender decodes the batch itself, so no spike is
+// lost.
+
+const (
+	SPIKE_BATCH_DEFAULT_MAX_SIZE       = 256
+	SPIKE_BATCH_DEFAULT_FLUSH_INTERVAL = 500 * time.Microsecond
+	SPIKE_SHARD_DEFAULT_QUEUE          = 64 // Batches
+)
+
+// BatchedSpike is one spike in a batch.
+type BatchedSpike struct {
+	Target int                // Member index in the receiving population
+	Signal types.NeuralSignal // Value plus source and synapse IDs for STDP
+	Delay  time.Duration      // Remaining delay when the batch was sent
+}
+
+// SpikeBatch carries spikes from one projection to the receiving shard.
+type SpikeBatch struct {
+	Source string // Sending population
+	Spikes []BatchedSpike
+}
+
+// =================================================================================
+// RECEIVING SHARD
+// =================================================================================
+
+// SpikeShardConfig configures the decoding side of a population.
+type SpikeShardConfig struct {
+	Queue    int             // Batches buffered before senders decode inline (0 = default)
+	Affinity *affinity.Group // Pins the decoding goroutine (nil = unpinned)
+}
+
+// shardSpike is a decoded spike waiting for its delay.
+type shardSpike struct {
+	due    time.Time
+	seq    uint64 // Preserves batch order for equal times
+	target int
+	signal types.NeuralSignal
+}
+
+// shardQueue is a min-heap ordered by due time.
+type shardQueue []shardSpike
+
+func (q shardQueue) Len() int { return len(q) }
+func (q shardQueue) Less(i, j int) bool {
+	if q[i].due.Equal(q[j].due) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].due.Before(q[j].due)
+}
+func (q shardQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *shardQueue) Push(x interface{}) { *q = append(*q, x.(shardSpike)) }
+func (q *shardQueue) Pop() interface{} {
+	old := *q
+	item := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return item
+}
+
+// SpikeShard decodes spike batches for one population on a goroutine of
+// its own.
+type SpikeShard struct {
+	population *Population
+	index      map[string]int // Member ID -> index
+	config     SpikeShardConfig
+	input      chan SpikeBatch
+
+	mu      sync.RWMutex // Guards running against sends
+	running bool
+	stopCh  chan struct{}
+	wg      sync.WaitGroup
+
+	queue shardQueue // Owned by the decoding goroutine
+	seq   uint64
+
+	batches   atomic.Int64
+	spikes    atomic.Int64
+	delivered atomic.Int64
+	pending   atomic.Int64
+	inline    atomic.Int64 // Batches decoded by senders
+}
+
+// NewSpikeShard creates the shard of a population. Start runs it.
+func NewSpikeShard(population *Population, config SpikeShardConfig) (*SpikeShard, error) {
+	if population == nil {
+		return nil, fmt.Errorf("spike shard needs a population")
+	}
+	if config.Queue < 0 {
+		return nil, fmt.Errorf("spike shard %s: queue must not be negative: %d", population.ID(), config.Queue)
+	}
+	if config.Queue == 0 {
+		config.Queue = SPIKE_SHARD_DEFAULT_QUEUE
+	}
+	index := make(map[string]int, population.Size())
+	for i, member := range population.neurons {
+		index[member.ID()] = i
+	}
+	return &SpikeShard{
+		population: population,
+		index:      index,
+		config:     config,
+		input:      make(chan SpikeBatch, config.Queue),
+	}, nil
+}
+
+// Population returns the receiving population.
+func (s *SpikeShard) Population() *Population {
+	return s.population
+}
+
+// Start runs the decoding goroutine.
+func (s *SpikeShard) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return fmt.Errorf("spike shard %s already running", s.population.ID())
+	}
+	s.running = true
+	s.stopCh = make(chan struct{})
+	s.wg.Add(1)
+	if s.config.Affinity != nil {
+		s.config.Affinity.Go(s.run)
+	} else {
+		go s.run()
+	}
+	return nil
+}
+
+// Stop halts the decoding goroutine. Queued batches and spikes still
+// waiting for their delay are handed to timers, so they are delivered as
+// if the shard had kept running.
+func (s *SpikeShard) Stop() error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return nil
+	}
+	s.running = false
+	close(s.stopCh)
+	s.mu.Unlock()
+	s.wg.Wait()
+	return nil
+}
+
+// Deliver queues a batch for decoding. The caller decodes it when the
+// queue is full or the shard is not running.
+func (s *SpikeShard) Deliver(batch SpikeBatch) {
+	if len(batch.Spikes) == 0 {
+		return
+	}
+	s.batches.Add(1)
+	s.spikes.Add(int64(len(batch.Spikes)))
+
+	s.mu.RLock()
+	if s.running {
+		select {
+		case s.input <- batch:
+			s.mu.RUnlock()
+			return
+		default:
+		}
+	}
+	s.mu.RUnlock()
+	s.inline.Add(1)
+	s.decodeDetached(batch.Spikes, time.Now())
+}
+
+// run decodes batches and releases delayed spikes until Stop.
+func (s *SpikeShard) run() {
+	defer s.wg.Done()
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			s.drain()
+			return
+		case batch := <-s.input:
+			s.decode(batch.Spikes, time.Now())
+		case now := <-timer.C:
+			s.release(now)
+		}
+		if s.queue.Len() > 0 {
+			timer.Reset(time.Until(s.queue[0].due))
+		}
+	}
+}
+
+// decode delivers due spikes and queues the others.
+func (s *SpikeShard) decode(spikes []BatchedSpike, now time.Time) {
+	for _, spike := range spikes {
+		if spike.Target < 0 || spike.Target >= len(s.population.neurons) {
+			continue
+		}
+		if spike.Delay <= 0 {
+			s.population.neurons[spike.Target].Receive(spike.Signal)
+			s.delivered.Add(1)
+			continue
+		}
+		s.seq++
+		heap.Push(&s.queue, shardSpike{due: now.Add(spike.Delay), seq: s.seq, target: spike.Target, signal: spike.Signal})
+		s.pending.Add(1)
+	}
+}
+
+// release delivers the queued spikes due by now.
+func (s *SpikeShard) release(now time.Time) {
+	for s.queue.Len() > 0 && !s.queue[0].due.After(now) {
+		spike := heap.Pop(&s.queue).(shardSpike)
+		s.pending.Add(-1)
+		s.population.neurons[spike.target].Receive(spike.signal)
+		s.delivered.Add(1)
+	}
+}
+
+// drain hands queued batches and pending spikes to timers on Stop.
+func (s *SpikeShard) drain() {
+	now := time.Now()
+	for len(s.input) > 0 {
+		s.decodeDetached((<-s.input).Spikes, now)
+	}
+	for s.queue.Len() > 0 {
+		spike := heap.Pop(&s.queue).(shardSpike)
+		s.pending.Add(-1)
+		s.decodeDetached([]BatchedSpike{{Target: spike.target, Signal: spike.signal, Delay: spike.due.Sub(now)}}, now)
+	}
+}
+
+// decodeDetached delivers spikes without the decoding goroutine, using a
+// timer per delayed spike.
+func (s *SpikeShard) decodeDetached(spikes []BatchedSpike, now time.Time) {
+	for _, spike := range spikes {
+		if spike.Target < 0 || spike.Target >= len(s.population.neurons) {
+			continue
+		}
+		target, signal := s.population.neurons[spike.Target], spike.Signal
+		if spike.Delay <= 0 {
+			target.Receive(signal)
+			s.delivered.Add(1)
+			continue
+		}
+		time.AfterFunc(spike.Delay, func() {
+			target.Receive(signal)
+			s.delivered.Add(1)
+		})
+	}
+}
+
+// GetStats returns batch and spike counts.
+func (s *SpikeShard) GetStats() map[string]interface{} {
+	s.mu.RLock()
+	running := s.running
+	s.mu.RUnlock()
+	batches, spikes := s.batches.Load(), s.spikes.Load()
+	meanBatch := 0.0
+	if batches > 0 {
+		meanBatch = float64(spikes) / float64(batches)
+	}
+	return map[string]interface{}{
+		"population":     s.population.ID(),
+		"running":        running,
+		"batches":        batches,
+		"spikes":         spikes,
+		"mean_batch":     meanBatch,
+		"delivered":      s.delivered.Load(),
+		"pending":        s.pending.Load(),
+		"queued":         len(s.input),
+		"inline_batches": s.inline.Load(),
+	}
+}
+
+// =================================================================================
+// SENDING OUTBOX
+// =================================================================================
+
+// SpikeBatchConfig configures the sending side of a projection.
+type SpikeBatchConfig struct {
+	MaxSize       int           // Spikes per batch before it is sent early (0 = default)
+	FlushInterval time.Duration // Longest a spike waits for its batch (0 = default)
+}
+
+// SpikeOutbox collects a projection's spikes into batches for the target's
+// shard. It implements synapse.SpikeSink.
+type SpikeOutbox struct {
+	projection *Projection
+	shard      *SpikeShard
+	config     SpikeBatchConfig
+	synapses   []synapse.SpikeSinkUser
+
+	mu         sync.Mutex
+	pending    []BatchedSpike
+	enqueuedAt []time.Time
+	closed     bool
+
+	stopCh chan struct{}
+	done   chan struct{}
+
+	spikes   atomic.Int64
+	batches  atomic.Int64
+	declined atomic.Int64 // Spikes left to the synapse
+}
+
+// BatchDelivery routes the projection's spikes through an outbox to shard,
+// which must belong to the projection's target population. Synapses that
+// do not support batching keep delivering directly. Close restores direct
+// delivery.
+func (p *Projection) BatchDelivery(shard *SpikeShard, config SpikeBatchConfig) (*SpikeOutbox, error) {
+	if shard == nil || shard.population != p.post {
+		return nil, fmt.Errorf("projection %s->%s: shard must belong to %s", p.pre.ID(), p.post.ID(), p.post.ID())
+	}
+	if config.MaxSize < 0 || config.FlushInterval < 0 {
+		return nil, fmt.Errorf("projection %s->%s: invalid batch config %+v", p.pre.ID(), p.post.ID(), config)
+	}
+	if config.MaxSize == 0 {
+		config.MaxSize = SPIKE_BATCH_DEFAULT_MAX_SIZE
+	}
+	if config.FlushInterval == 0 {
+		config.FlushInterval = SPIKE_BATCH_DEFAULT_FLUSH_INTERVAL
+	}
+
+	o := &SpikeOutbox{
+		projection: p,
+		shard:      shard,
+		config:     config,
+		stopCh:     make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	for _, syn := range p.Synapses() {
+		if user, ok := syn.(synapse.SpikeSinkUser); ok {
+			o.synapses = append(o.synapses, user)
+		}
+	}
+	if len(o.synapses) == 0 {
+		return nil, fmt.Errorf("projection %s->%s has no synapses supporting batched delivery", p.pre.ID(), p.post.ID())
+	}
+	for _, syn := range o.synapses {
+		syn.SetSpikeSink(o)
+	}
+	go o.flushLoop()
+	return o, nil
+}
+
+// EnqueueSpike implements synapse.SpikeSink.
+func (o *SpikeOutbox) EnqueueSpike(msg types.NeuralSignal, delay time.Duration) bool {
+	target, ok := o.shard.index[msg.TargetID]
+	if !ok {
+		o.declined.Add(1)
+		return false
+	}
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		o.declined.Add(1)
+		return false
+	}
+	o.pending = append(o.pending, BatchedSpike{Target: target, Signal: msg, Delay: delay})
+	o.enqueuedAt = append(o.enqueuedAt, time.Now())
+	var batch SpikeBatch
+	if len(o.pending) >= o.config.MaxSize {
+		batch = o.takeUnsafe()
+	}
+	o.mu.Unlock()
+	o.spikes.Add(1)
+	o.send(batch)
+	return true
+}
+
+// Flush sends the spikes collected so far.
+func (o *SpikeOutbox) Flush() {
+	o.mu.Lock()
+	batch := o.takeUnsafe()
+	o.mu.Unlock()
+	o.send(batch)
+}
+
+// takeUnsafe empties the outbox into a batch, charging each spike's wait
+// against its delay. Caller must hold o.mu.
+func (o *SpikeOutbox) takeUnsafe() SpikeBatch {
+	batch := SpikeBatch{Source: o.projection.pre.ID(), Spikes: o.pending}
+	now := time.Now()
+	for i := range batch.Spikes {
+		batch.Spikes[i].Delay -= now.Sub(o.enqueuedAt[i])
+	}
+	o.pending = nil
+	o.enqueuedAt = o.enqueuedAt[:0]
+	return batch
+}
+
+// send hands a non-empty batch to the shard.
+func (o *SpikeOutbox) send(batch SpikeBatch) {
+	if len(batch.Spikes) == 0 {
+		return
+	}
+	o.batches.Add(1)
+	o.shard.Deliver(batch)
+}
+
+// flushLoop sends partial batches every FlushInterval until Close.
+func (o *SpikeOutbox) flushLoop() {
+	defer close(o.done)
+	ticker := time.NewTicker(o.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-o.stopCh:
+			return
+		case <-ticker.C:
+			o.Flush()
+		}
+	}
+}
+
+// Close restores direct delivery on the projection's synapses and sends
+// the spikes still collected.
+func (o *SpikeOutbox) Close() {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return
+	}
+	o.closed = true
+	o.mu.Unlock()
+
+	for _, syn := range o.synapses {
+		if syn.GetSpikeSink() == synapse.SpikeSink(o) {
+			syn.SetSpikeSink(nil)
+		}
+	}
+	close(o.stopCh)
+	<-o.done
+	o.Flush()
+}
+
+// GetStats returns the spikes batched, the batches sent and the spikes left
+// to direct delivery.
+func (o *SpikeOutbox) GetStats() map[string]interface{} {
+	spikes, batches := o.spikes.Load(), o.batches.Load()
+	meanBatch := 0.0
+	if batches > 0 {
+		meanBatch = float64(spikes) / float64(batches)
+	}
+	return map[string]interface{}{
+		"projection": o.projection.pre.ID() + "->" + o.projection.post.ID(),
+		"synapses":   len(o.synapses),
+		"spikes":     spikes,
+		"batches":    batches,
+		"mean_batch": meanBatch,
+		"declined":   o.declined.Load(),
+	}
+}
+
+// Shard returns the receiving shard.
+func (o *SpikeOutbox) Shard() *SpikeShard {
+	return o.shard
+}
@@ -0,0 +1,87 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestSpikeBatchDelivery verifies that a batched projection delivers every
+// spike through the receiving shard, immediate ones at once and delayed
+// ones after their delay, and that Close restores direct delivery.
+func TestSpikeBatchDelivery(t *testing.T) {
+	builder := &testBuilder{}
+	cell := types.NeuronConfig{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond, FireFactor: 1.0}
+	a, _ := NewPopulation(builder, "a", PopulationConfig{Size: 2, Neuron: cell})
+	b, _ := NewPopulation(builder, "b", PopulationConfig{Size: 3, Neuron: cell})
+	proj, err := a.ConnectAllToAll(b, ConstantWeight(0.2), ConstantDelay(0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := proj.BatchDelivery(&SpikeShard{population: a}, SpikeBatchConfig{}); err == nil {
+		t.Error("Expected a shard of the wrong population rejected")
+	}
+	shard, err := NewSpikeShard(b, SpikeShardConfig{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := shard.Start(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer shard.Stop()
+	outbox, err := proj.BatchDelivery(shard, SpikeBatchConfig{MaxSize: 100, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer outbox.Close()
+
+	waitDelivered := func(count int64) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for shard.GetStats()["delivered"].(int64) < count && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if got := shard.GetStats()["delivered"].(int64); got != count {
+			t.Fatalf("Expected %d spikes delivered, got %d", count, got)
+		}
+	}
+
+	// One spike from each sender: six synapses, one batch
+	for _, syn := range proj.Synapses() {
+		syn.Transmit(1.0)
+	}
+	if shard.GetStats()["batches"].(int64) != 0 {
+		t.Error("Expected spikes held until the batch is flushed")
+	}
+	outbox.Flush()
+	waitDelivered(6)
+	stats := outbox.GetStats()
+	if stats["spikes"].(int64) != 6 || stats["batches"].(int64) != 1 || stats["declined"].(int64) != 0 {
+		t.Errorf("Expected six spikes in one batch, got %v", stats)
+	}
+	for _, member := range b.Neurons() {
+		if _, ok := member.GetMetadata()["last_message"]; !ok {
+			t.Errorf("Expected %s to receive its spikes", member.ID())
+		}
+	}
+
+	// Delayed spikes wait in the shard, not in the sender
+	shard.Deliver(SpikeBatch{Source: "a", Spikes: []BatchedSpike{
+		{Target: 0, Signal: types.NeuralSignal{Value: 0.2, TargetID: b.Neuron(0).ID()}, Delay: 20 * time.Millisecond},
+	}})
+	time.Sleep(5 * time.Millisecond)
+	if shard.GetStats()["delivered"].(int64) != 6 || shard.GetStats()["pending"].(int64) != 1 {
+		t.Errorf("Expected the delayed spike pending, got %v", shard.GetStats())
+	}
+	waitDelivered(7)
+
+	outbox.Close()
+	for _, syn := range proj.Synapses() {
+		if syn.(synapse.SpikeSinkUser).GetSpikeSink() != nil {
+			t.Fatalf("Expected %s back on direct delivery", syn.ID())
+		}
+	}
+}
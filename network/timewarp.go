@@ -0,0 +1,105 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+)
+
+// =================================================================================
+// PAUSE AND TIME SCALE
+// =================================================================================
+//
+// A network attached to a lock-step runner (see AttachLockStep) advances only
+// when its clock is stepped, so pausing the clock pauses the network: every
+// neuron's membrane, refractory and STDP timers and every spike in flight on
+// an axon or synapse stop together, and continue from the same virtual time
+// on Resume. Run paces the runner against the wall clock through a
+// cosim.TimeWarp, which also sets the rate:
+//
+//	net.AttachLockStep(runner)
+//	matrix.Start()
+//	go net.Run(ctx, time.Millisecond)
+//	net.SetTimeScale(0.1) // 10× slow motion
+//	net.Pause()
+//	// ... inspect the network ...
+//	net.Resume()
+//
+// The controls return an error for a network on the wall clock.
+
+// timeWarp returns the pacing of the attached runner.
+func (n *Network) timeWarp() (*cosim.TimeWarp, error) {
+	n.lockStepMutex.Lock()
+	defer n.lockStepMutex.Unlock()
+	if n.warp == nil {
+		return nil, fmt.Errorf("network is not attached to a lock-step runner")
+	}
+	return n.warp, nil
+}
+
+// Run advances the attached runner in steps of period at the current time
+// scale until ctx is cancelled. A period of 0 uses the default resolution.
+func (n *Network) Run(ctx context.Context, period time.Duration) error {
+	warp, err := n.timeWarp()
+	if err != nil {
+		return err
+	}
+	return warp.Run(ctx, period)
+}
+
+// Clock returns the paced clock of the attached runner, for driving the
+// network from a cosim.ClosedLoop instead of Run.
+func (n *Network) Clock() (cosim.Clock, error) {
+	warp, err := n.timeWarp()
+	if err != nil {
+		return nil, err
+	}
+	return warp, nil
+}
+
+// Pause freezes the network at the next step boundary.
+func (n *Network) Pause() error {
+	warp, err := n.timeWarp()
+	if err != nil {
+		return err
+	}
+	warp.Pause()
+	return nil
+}
+
+// Resume continues a paused network from the virtual time it stopped at.
+func (n *Network) Resume() error {
+	warp, err := n.timeWarp()
+	if err != nil {
+		return err
+	}
+	warp.Resume()
+	return nil
+}
+
+// IsPaused reports whether the network is paused.
+func (n *Network) IsPaused() bool {
+	warp, err := n.timeWarp()
+	return err == nil && warp.IsPaused()
+}
+
+// SetTimeScale sets the rate of virtual time relative to the wall clock,
+// from cosim.COSIM_MIN_TIME_SCALE to cosim.COSIM_MAX_TIME_SCALE.
+func (n *Network) SetTimeScale(scale float64) error {
+	warp, err := n.timeWarp()
+	if err != nil {
+		return err
+	}
+	return warp.SetTimeScale(scale)
+}
+
+// TimeScale returns the current rate (1 for a network on the wall clock).
+func (n *Network) TimeScale() float64 {
+	warp, err := n.timeWarp()
+	if err != nil {
+		return 1
+	}
+	return warp.TimeScale()
+}
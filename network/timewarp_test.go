@@ -0,0 +1,93 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestNetworkPauseFreezesNeuronsAndDelays verifies that pausing an attached
+// network stops neuron clocks and spikes in flight, and that the delay is
+// kept in virtual time across the pause.
+func TestNetworkPauseFreezesNeuronsAndDelays(t *testing.T) {
+	runner, _ := cosim.NewLockStep(time.Unix(0, 0), time.Millisecond)
+	pre, post := newTestNeuron("pre"), newTestNeuron("post")
+	syn := connect("pre_post", pre, post, 1.2, 40*time.Millisecond).(*synapse.BasicSynapse)
+	pre.AddOutputCallback(syn.ID(), types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			syn.Transmit(msg.Value)
+			return nil
+		},
+		GetWeight:   syn.GetWeight,
+		GetDelay:    syn.GetDelay,
+		GetTargetID: syn.GetPostsynapticID,
+	})
+	net := FromComponents([]component.NeuralComponent{pre, post}, []component.SynapticProcessor{syn})
+
+	if err := net.Pause(); err == nil {
+		t.Error("Expected an error pausing a network on the wall clock")
+	}
+	if _, err := net.AttachLockStep(runner); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, cell := range []*neuron.Neuron{pre, post} {
+		cell.Start()
+		defer cell.Stop()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- net.Run(ctx, time.Millisecond) }()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	pre.Receive(types.NeuralSignal{Value: 1.5})
+	deadline := time.Now().Add(time.Second)
+	for runner.Pending() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the spike in flight")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := net.Pause(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond) // Let the step in progress finish
+	frozen, neuronTime := runner.Now(), post.Now()
+	time.Sleep(60 * time.Millisecond) // Longer than the synaptic delay
+	if !net.IsPaused() || !runner.Now().Equal(frozen) || !post.Now().Equal(neuronTime) {
+		t.Fatalf("Expected the clocks frozen at %v, got runner %v and neuron %v",
+			frozen, runner.Now(), post.Now())
+	}
+	if runner.Pending() != 1 || post.GetSnapshot().Spikes != 0 {
+		t.Fatalf("Expected the spike still in flight while paused")
+	}
+
+	if err := net.SetTimeScale(1000); err == nil {
+		t.Error("Expected an error for a time scale above the maximum")
+	}
+	if err := net.SetTimeScale(10); err != nil || net.TimeScale() != 10 {
+		t.Fatalf("Expected time scale 10, got %g (%v)", net.TimeScale(), err)
+	}
+	if err := net.Resume(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for post.GetSnapshot().Spikes == 0 {
+		if time.Now().After(deadline.Add(time.Second)) {
+			t.Fatal("Expected the delayed spike to arrive after resuming")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if got := post.GetSnapshot().LastSpike.Sub(pre.GetSnapshot().LastSpike); got != 40*time.Millisecond {
+		t.Errorf("Expected the post-synaptic spike 40ms of virtual time later, got %v", got)
+	}
+}
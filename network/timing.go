@@ -0,0 +1,58 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/timing"
+)
+
+// =================================================================================
+// TIMING SOURCE
+// =================================================================================
+//
+// The timing mode is set per neuron (see neuron.SetTiming). SetTiming applies
+// one mode to the whole network and measures the resolution the mode achieves
+// at the axonal tick on this machine, so a simulation can report it at
+// startup and check it against the shortest delay in the model:
+//
+//	report, _ := net.SetTiming(timing.Config{Mode: timing.ModePrecise})
+//	log.Println(report.Resolution)
+//	if !report.Resolution.Meets(100 * time.Microsecond) { ... }
+
+// timed is implemented by neurons with a selectable timing source
+// (neuron.Neuron).
+type timed interface {
+	SetTiming(config timing.Config) error
+}
+
+// TimingReport is the result of SetTiming.
+type TimingReport struct {
+	Configured int               // Neurons switched to the mode
+	Resolution timing.Resolution // Measured at neuron.AXON_TICK_INTERVAL
+}
+
+// SetTiming sets the timing mode of every neuron that supports it and
+// measures the mode's resolution.
+func (n *Network) SetTiming(config timing.Config) (TimingReport, error) {
+	if err := config.Validate(); err != nil {
+		return TimingReport{}, err
+	}
+	var report TimingReport
+	for _, cell := range n.Neurons() {
+		target, ok := cell.(timed)
+		if !ok {
+			continue
+		}
+		if err := target.SetTiming(config); err != nil {
+			return report, fmt.Errorf("neuron %s: %w", cell.ID(), err)
+		}
+		report.Configured++
+	}
+	resolution, err := timing.Measure(config, neuron.AXON_TICK_INTERVAL, 0)
+	if err != nil {
+		return report, err
+	}
+	report.Resolution = resolution
+	return report, nil
+}
@@ -0,0 +1,75 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/timing"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// arrivalRecorder reports when each input arrives.
+type arrivalRecorder struct {
+	*neuron.Neuron
+	arrivals chan time.Time
+}
+
+func (r *arrivalRecorder) Receive(types.NeuralSignal) { r.arrivals <- time.Now() }
+
+// TestSetTiming verifies that a timing mode reaches every neuron, is measured,
+// and can be switched while axonal deliveries are running.
+func TestSetTiming(t *testing.T) {
+	sender, other := newTestNeuron("sender"), newTestNeuron("other")
+	net := FromComponents([]component.NeuralComponent{sender, other}, nil)
+
+	report, err := net.SetTiming(timing.Config{Mode: timing.ModePrecise})
+	if err != nil || report.Configured != 2 {
+		t.Fatalf("Expected 2 neurons configured, got %d (%v)", report.Configured, err)
+	}
+	if report.Resolution.Mode != timing.ModePrecise || report.Resolution.Requested != neuron.AXON_TICK_INTERVAL || report.Resolution.Samples == 0 {
+		t.Errorf("Unexpected resolution %+v", report.Resolution)
+	}
+	if other.GetTiming().Mode != timing.ModePrecise {
+		t.Error("Expected every neuron switched to precise timing")
+	}
+	if _, err := net.SetTiming(timing.Config{Granularity: -1}); err == nil {
+		t.Error("Expected error for negative granularity")
+	}
+
+	if err := sender.Start(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer sender.Stop()
+	target := &arrivalRecorder{Neuron: other, arrivals: make(chan time.Time, 1)}
+	deliver := func(delay time.Duration) time.Duration {
+		sent := time.Now()
+		sender.ScheduleDelayedDelivery(types.NeuralSignal{Value: 1}, target, delay)
+		select {
+		case arrived := <-target.arrivals:
+			return arrived.Sub(sent)
+		case <-time.After(time.Second):
+			t.Fatal("Delivery never arrived")
+			return 0
+		}
+	}
+	if latency := deliver(500 * time.Microsecond); latency < 500*time.Microsecond {
+		t.Errorf("Precise delivery arrived early after %v", latency)
+	}
+
+	// Coarse timing, switched live, rounds delays up to its granule
+	if _, err := net.SetTiming(timing.Config{Mode: timing.ModeCoarse, Granularity: 20 * time.Millisecond}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	var slowest time.Duration
+	for i := 0; i < 3; i++ {
+		if latency := deliver(500 * time.Microsecond); latency > slowest {
+			slowest = latency
+		}
+	}
+	if slowest < 1500*time.Microsecond || slowest > 100*time.Millisecond {
+		t.Errorf("Expected coarse deliveries to wait for 20ms ticks, slowest took %v", slowest)
+	}
+}
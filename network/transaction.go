@@ -0,0 +1,202 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// =================================================================================
+// WEIGHT TRANSACTIONS
+// =================================================================================
+//
+// An external optimizer that writes weights while STDP runs interleaves with
+// learning: a weight read, updated and written back loses every plasticity
+// change in between. A WeightTransaction makes such updates optimistic.
+// Reads and writes are collected without locking, each synapse remembering
+// the weight version it was first seen at. Commit then:
+//
+//  1. pauses plasticity on the affected synapses (in synapse ID order),
+//  2. checks that none of their weights changed since they were seen,
+//  3. writes every staged weight with compare-and-set, and
+//  4. resumes plasticity.
+//
+// If any weight moved, nothing is written and Commit returns an error
+// wrapping ErrWeightConflict; UpdateWeights reruns the update on fresh
+// values in that case. Commits on the same network are serialized. A plain
+// SetWeight racing a commit is detected by the compare-and-set, and the
+// weights already written by the commit are rolled back.
+
+// WEIGHT_TRANSACTION_MAX_ATTEMPTS bounds the retries of UpdateWeights.
+const WEIGHT_TRANSACTION_MAX_ATTEMPTS = 5
+
+// ErrWeightConflict reports that a weight changed between read and commit.
+var ErrWeightConflict = errors.New("weight changed since it was read")
+
+// WeightTransaction stages weight updates for an atomic commit.
+type WeightTransaction struct {
+	network  *Network
+	synapses map[string]component.SynapticProcessor
+	versions map[string]uint64  // Synapse ID -> version when first seen
+	writes   map[string]float64 // Synapse ID -> staged weight
+	done     bool
+}
+
+// BeginWeights starts a weight transaction.
+func (n *Network) BeginWeights() *WeightTransaction {
+	synapses := make(map[string]component.SynapticProcessor)
+	for _, syn := range n.Synapses() {
+		synapses[syn.ID()] = syn
+	}
+	return &WeightTransaction{
+		network:  n,
+		synapses: synapses,
+		versions: make(map[string]uint64),
+		writes:   make(map[string]float64),
+	}
+}
+
+// Read returns a synapse's weight: the staged one if written in this
+// transaction, otherwise the current one.
+func (tx *WeightTransaction) Read(synapseID string) (float64, error) {
+	if weight, ok := tx.writes[synapseID]; ok {
+		return weight, nil
+	}
+	syn, err := tx.track(synapseID)
+	if err != nil {
+		return 0, err
+	}
+	weight, version := syn.GetWeightVersion()
+	if seen, ok := tx.versions[synapseID]; ok && seen != version {
+		return 0, fmt.Errorf("synapse %s: %w", synapseID, ErrWeightConflict)
+	}
+	tx.versions[synapseID] = version
+	return weight, nil
+}
+
+// Write stages a weight for commit.
+func (tx *WeightTransaction) Write(synapseID string, weight float64) error {
+	if math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return fmt.Errorf("synapse %s: invalid weight %f", synapseID, weight)
+	}
+	syn, err := tx.track(synapseID)
+	if err != nil {
+		return err
+	}
+	if _, ok := tx.versions[synapseID]; !ok {
+		_, tx.versions[synapseID] = syn.GetWeightVersion()
+	}
+	tx.writes[synapseID] = weight
+	return nil
+}
+
+// track checks that the transaction is open and the synapse supports
+// versioned writes.
+func (tx *WeightTransaction) track(synapseID string) (synapse.WeightTransactional, error) {
+	if tx.done {
+		return nil, fmt.Errorf("weight transaction already finished")
+	}
+	syn, ok := tx.synapses[synapseID]
+	if !ok {
+		return nil, fmt.Errorf("synapse %s not in network", synapseID)
+	}
+	versioned, ok := syn.(synapse.WeightTransactional)
+	if !ok {
+		return nil, fmt.Errorf("synapse %s does not support weight transactions", synapseID)
+	}
+	return versioned, nil
+}
+
+// Commit applies the staged weights atomically with respect to plasticity
+// and other commits. It returns an error wrapping ErrWeightConflict, and
+// writes nothing, if a weight read or written changed since it was first
+// seen.
+func (tx *WeightTransaction) Commit() error {
+	if tx.done {
+		return fmt.Errorf("weight transaction already finished")
+	}
+	tx.done = true
+	if len(tx.writes) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(tx.versions))
+	for id := range tx.versions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	affected := make([]synapse.WeightTransactional, len(ids))
+	for i, id := range ids {
+		affected[i] = tx.synapses[id].(synapse.WeightTransactional)
+	}
+
+	tx.network.commitMutex.Lock()
+	defer tx.network.commitMutex.Unlock()
+	for _, syn := range affected {
+		syn.PausePlasticity()
+	}
+	defer func() {
+		for _, syn := range affected {
+			syn.ResumePlasticity()
+		}
+	}()
+
+	type undo struct {
+		syn     synapse.WeightTransactional
+		version uint64
+		weight  float64
+	}
+	for i, syn := range affected {
+		if _, version := syn.GetWeightVersion(); version != tx.versions[ids[i]] {
+			return fmt.Errorf("synapse %s: %w", ids[i], ErrWeightConflict)
+		}
+	}
+	var written []undo
+	for i, syn := range affected {
+		weight, ok := tx.writes[ids[i]]
+		if !ok {
+			continue
+		}
+		previous, _ := syn.GetWeightVersion()
+		version, ok := syn.CompareAndSetWeight(tx.versions[ids[i]], weight)
+		if !ok {
+			for j := len(written) - 1; j >= 0; j-- {
+				written[j].syn.CompareAndSetWeight(written[j].version, written[j].weight)
+			}
+			return fmt.Errorf("synapse %s: %w", ids[i], ErrWeightConflict)
+		}
+		written = append(written, undo{syn: syn, version: version, weight: previous})
+	}
+	return nil
+}
+
+// Rollback discards the staged weights.
+func (tx *WeightTransaction) Rollback() {
+	tx.done = true
+	tx.writes = nil
+}
+
+// UpdateWeights runs update in a transaction and commits it, rerunning it
+// on fresh weights after a conflict, up to WEIGHT_TRANSACTION_MAX_ATTEMPTS
+// times. An error returned by update rolls the transaction back.
+func (n *Network) UpdateWeights(update func(tx *WeightTransaction) error) error {
+	var err error
+	for attempt := 0; attempt < WEIGHT_TRANSACTION_MAX_ATTEMPTS; attempt++ {
+		tx := n.BeginWeights()
+		if err = update(tx); err != nil {
+			tx.Rollback()
+			if errors.Is(err, ErrWeightConflict) {
+				continue
+			}
+			return err
+		}
+		if err = tx.Commit(); !errors.Is(err, ErrWeightConflict) {
+			return err
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", WEIGHT_TRANSACTION_MAX_ATTEMPTS, err)
+}
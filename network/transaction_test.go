@@ -0,0 +1,74 @@
+package network
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestWeightTransactionDetectsLearning verifies that a commit is refused
+// when STDP moved a weight after it was read, and that UpdateWeights
+// reruns the update on fresh weights.
+func TestWeightTransactionDetectsLearning(t *testing.T) {
+	a, b := newTestNeuron("a"), newTestNeuron("b")
+	plastic, _ := synapse.NewSynapse("plastic", a, b, synapse.WithWeight(0.5))
+	net := FromComponents([]component.NeuralComponent{a, b},
+		[]component.SynapticProcessor{plastic, connect("static", a, b, 0.2, time.Millisecond)})
+	learn := func() {
+		plastic.ApplyPlasticity(types.PlasticityAdjustment{
+			DeltaT: -5 * time.Millisecond, LearningRate: 0.1, Timestamp: time.Unix(0, 0),
+		})
+	}
+
+	tx := net.BeginWeights()
+	weight, err := tx.Read("plastic")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tx.Write("plastic", weight+0.1)
+	tx.Write("static", 0.3)
+	learn()
+	learned := plastic.GetWeight()
+	if err := tx.Commit(); !errors.Is(err, ErrWeightConflict) {
+		t.Fatalf("Expected a conflict, got %v", err)
+	}
+	if plastic.GetWeight() != learned || net.Synapses()[1].GetWeight() != 0.2 {
+		t.Error("Expected a conflicting commit to write nothing")
+	}
+	if err := tx.Write("static", 0.3); err == nil {
+		t.Error("Expected error writing to a finished transaction")
+	}
+
+	attempts, base := 0, 0.0
+	err = net.UpdateWeights(func(tx *WeightTransaction) error {
+		attempts++
+		weight, err := tx.Read("plastic")
+		if err != nil {
+			return err
+		}
+		if attempts == 1 {
+			learn() // Learning interleaves with the first attempt only
+		}
+		base = weight
+		if err := tx.Write("plastic", weight+0.1); err != nil {
+			return err
+		}
+		return tx.Write("static", 0.3)
+	})
+	if err != nil || attempts != 2 {
+		t.Fatalf("Expected success on the second attempt, got %v after %d", err, attempts)
+	}
+	if base <= learned || plastic.GetWeight() != base+0.1 || net.Synapses()[1].GetWeight() != 0.3 {
+		t.Errorf("Expected the update applied on top of learning (%f), got %f and %f", base, plastic.GetWeight(), net.Synapses()[1].GetWeight())
+	}
+	if plastic.IsPlasticityPaused() {
+		t.Error("Expected plasticity resumed after the commit")
+	}
+	if err := net.BeginWeights().Write("ghost", 1); err == nil {
+		t.Error("Expected error for an unknown synapse")
+	}
+}
@@ -0,0 +1,499 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+)
+
+// =================================================================================
+// STRUCTURAL VALIDATION
+// =================================================================================
+
+const (
+	// VALIDATION_MAX_PLAUSIBLE_DELAY is the longest delay not flagged as
+	// implausible. The slowest unmyelinated cortical axons need ~100-200ms
+	// over long distances; delays far beyond are almost always unit errors.
+	VALIDATION_MAX_PLAUSIBLE_DELAY = 500 * time.Millisecond
+
+	// VALIDATION_DELAY_OUTLIER_FACTOR flags delays this many times above the
+	// network's median delay.
+	VALIDATION_DELAY_OUTLIER_FACTOR = 10.0
+
+	// validationMaxListed bounds the IDs listed in one issue message.
+	validationMaxListed = 5
+)
+
+// Severity ranks validation issues.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // The network cannot behave as intended
+	SeverityWarning Severity = "warning" // Probably a wiring mistake
+	SeverityInfo    Severity = "info"    // Structural fact worth knowing
+)
+
+// Issue codes reported by Validate.
+const (
+	IssueDuplicateID          = "duplicate_id"
+	IssueMissingNeuron        = "missing_neuron"
+	IssueInvalidThreshold     = "invalid_threshold"
+	IssueInvalidWeight        = "invalid_weight"
+	IssueInvertedWeightBounds = "inverted_weight_bounds"
+	IssueWeightOutOfBounds    = "weight_out_of_bounds"
+	IssueNegativeDelay        = "negative_delay"
+	IssueImplausibleDelay     = "implausible_delay"
+	IssueDelayOutlier         = "delay_outlier"
+	IssueOrphanNeuron         = "orphan_neuron"
+	IssueUnknownInput         = "unknown_input"
+	IssueUnreachable          = "unreachable_neuron"
+	IssueDisconnected         = "disconnected_subnetworks"
+	IssueAutapse              = "autapse"
+	IssueZeroDelayCycle       = "zero_delay_cycle"
+	IssueRecurrentCycle       = "recurrent_cycle"
+)
+
+// ValidationIssue is one finding of Validate.
+type ValidationIssue struct {
+	Severity    Severity `json:"severity"`
+	Code        string   `json:"code"`
+	ComponentID string   `json:"component_id,omitempty"`
+	Message     string   `json:"message"`
+}
+
+// ValidationReport collects all findings for a network.
+type ValidationReport struct {
+	Neurons  int               `json:"neurons"`
+	Synapses int               `json:"synapses"`
+	Issues   []ValidationIssue `json:"issues"`
+}
+
+// add records an issue.
+func (r *ValidationReport) add(severity Severity, code, componentID, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, ValidationIssue{
+		Severity:    severity,
+		Code:        code,
+		ComponentID: componentID,
+		Message:     fmt.Sprintf(format, args...),
+	})
+}
+
+// filter returns issues of one severity.
+func (r *ValidationReport) filter(severity Severity) []ValidationIssue {
+	var out []ValidationIssue
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+// Errors returns issues that make the network invalid.
+func (r *ValidationReport) Errors() []ValidationIssue { return r.filter(SeverityError) }
+
+// Warnings returns issues that are probably wiring mistakes.
+func (r *ValidationReport) Warnings() []ValidationIssue { return r.filter(SeverityWarning) }
+
+// HasErrors reports whether any error-level issue was found.
+func (r *ValidationReport) HasErrors() bool { return len(r.Errors()) > 0 }
+
+// ByCode returns the issues with the given code.
+func (r *ValidationReport) ByCode(code string) []ValidationIssue {
+	var out []ValidationIssue
+	for _, issue := range r.Issues {
+		if issue.Code == code {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+// Err returns an error summarising error-level issues, or nil.
+func (r *ValidationReport) Err() error {
+	errs := r.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("network validation failed with %d error(s); first: %s", len(errs), errs[0].Message)
+}
+
+// String lists all issues, errors first.
+func (r *ValidationReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "network: %d neurons, %d synapses, %d issue(s)\n", r.Neurons, r.Synapses, len(r.Issues))
+	for _, severity := range []Severity{SeverityError, SeverityWarning, SeverityInfo} {
+		for _, issue := range r.filter(severity) {
+			fmt.Fprintf(&b, "  %-7s %-24s %s\n", issue.Severity, issue.Code, issue.Message)
+		}
+	}
+	return b.String()
+}
+
+// ValidationConfig tunes Validate.
+type ValidationConfig struct {
+	// Inputs are neurons driven from outside the network (sensors, encoders).
+	// Empty means every neuron without incoming synapses is an input.
+	Inputs []string
+
+	MaxPlausibleDelay  time.Duration // Longer delays are flagged (0 = default)
+	DelayOutlierFactor float64       // Delays above factor × median are flagged (0 = default)
+}
+
+// DefaultValidationConfig returns the standard validation settings.
+func DefaultValidationConfig() ValidationConfig {
+	return ValidationConfig{
+		MaxPlausibleDelay:  VALIDATION_MAX_PLAUSIBLE_DELAY,
+		DelayOutlierFactor: VALIDATION_DELAY_OUTLIER_FACTOR,
+	}
+}
+
+// thresholdSource is implemented by neurons exposing their firing threshold.
+type thresholdSource interface {
+	GetThreshold() float64
+}
+
+// edge is a validated connection between neuron indices.
+type edge struct {
+	to    int
+	delay time.Duration
+}
+
+// Validate checks the network structure with default settings. Run it before
+// Start() to catch wiring mistakes early.
+func (n *Network) Validate() *ValidationReport {
+	return n.ValidateWith(DefaultValidationConfig())
+}
+
+// ValidateWith checks the network structure with the given settings.
+func (n *Network) ValidateWith(config ValidationConfig) *ValidationReport {
+	if config.MaxPlausibleDelay <= 0 {
+		config.MaxPlausibleDelay = VALIDATION_MAX_PLAUSIBLE_DELAY
+	}
+	if config.DelayOutlierFactor <= 0 {
+		config.DelayOutlierFactor = VALIDATION_DELAY_OUTLIER_FACTOR
+	}
+
+	neurons := n.Neurons()
+	synapses := n.Synapses()
+	report := &ValidationReport{Neurons: len(neurons), Synapses: len(synapses)}
+
+	index := validateNeurons(neurons, report)
+	adjacency, inDegree := validateSynapses(synapses, index, config, report)
+	ids := make([]string, len(index))
+	for id, i := range index {
+		ids[i] = id
+	}
+
+	validateReachability(ids, adjacency, inDegree, config.Inputs, report)
+	validateCycles(ids, adjacency, report)
+	return report
+}
+
+// validateNeurons checks IDs and thresholds and returns the neuron index.
+func validateNeurons(neurons []component.NeuralComponent, report *ValidationReport) map[string]int {
+	index := make(map[string]int, len(neurons))
+	for _, neuron := range neurons {
+		id := neuron.ID()
+		if _, exists := index[id]; exists {
+			report.add(SeverityError, IssueDuplicateID, id, "neuron ID %s is used more than once", id)
+			continue
+		}
+		index[id] = len(index)
+
+		if src, ok := neuron.(thresholdSource); ok {
+			if threshold := src.GetThreshold(); threshold <= 0 || math.IsNaN(threshold) {
+				report.add(SeverityError, IssueInvalidThreshold, id,
+					"neuron %s has threshold %g; it fires on any input or never integrates", id, threshold)
+			}
+		}
+	}
+	return index
+}
+
+// validateSynapses checks endpoints, weights and delays, and builds the
+// connectivity graph from the usable synapses.
+func validateSynapses(synapses []component.SynapticProcessor, index map[string]int,
+	config ValidationConfig, report *ValidationReport) ([][]edge, []int) {
+
+	adjacency := make([][]edge, len(index))
+	inDegree := make([]int, len(index))
+	seen := make(map[string]bool, len(synapses))
+	var delays []time.Duration
+
+	for _, syn := range synapses {
+		id := syn.ID()
+		if seen[id] {
+			report.add(SeverityError, IssueDuplicateID, id, "synapse ID %s is used more than once", id)
+			continue
+		}
+		seen[id] = true
+
+		pre, okPre := index[syn.GetPresynapticID()]
+		post, okPost := index[syn.GetPostsynapticID()]
+		if !okPre || !okPost {
+			missing := syn.GetPresynapticID()
+			if okPre {
+				missing = syn.GetPostsynapticID()
+			}
+			report.add(SeverityError, IssueMissingNeuron, id,
+				"synapse %s references neuron %s, which is not in the network", id, missing)
+			continue
+		}
+
+		weight := syn.GetWeight()
+		cfg := syn.GetPlasticityConfig()
+		switch {
+		case math.IsNaN(weight) || math.IsInf(weight, 0):
+			report.add(SeverityError, IssueInvalidWeight, id, "synapse %s has weight %v", id, weight)
+		case cfg.MinWeight > cfg.MaxWeight:
+			report.add(SeverityError, IssueInvertedWeightBounds, id,
+				"synapse %s has MinWeight %g above MaxWeight %g", id, cfg.MinWeight, cfg.MaxWeight)
+		case cfg.Enabled && (weight < cfg.MinWeight || weight > cfg.MaxWeight):
+			report.add(SeverityWarning, IssueWeightOutOfBounds, id,
+				"synapse %s weight %g lies outside its plasticity bounds [%g, %g]", id, weight, cfg.MinWeight, cfg.MaxWeight)
+		}
+
+		delay := syn.GetDelay()
+		switch {
+		case delay < 0:
+			report.add(SeverityError, IssueNegativeDelay, id, "synapse %s has negative delay %v", id, delay)
+		case delay > config.MaxPlausibleDelay:
+			report.add(SeverityWarning, IssueImplausibleDelay, id,
+				"synapse %s delay %v exceeds %v; check units", id, delay, config.MaxPlausibleDelay)
+		}
+		if delay > 0 {
+			delays = append(delays, delay)
+		}
+
+		adjacency[pre] = append(adjacency[pre], edge{to: post, delay: delay})
+		inDegree[post]++
+	}
+
+	validateDelayDistribution(synapses, delays, config, report)
+	return adjacency, inDegree
+}
+
+// validateDelayDistribution flags delays far above the network's median.
+func validateDelayDistribution(synapses []component.SynapticProcessor, delays []time.Duration,
+	config ValidationConfig, report *ValidationReport) {
+
+	if len(delays) < 3 {
+		return
+	}
+	sorted := append([]time.Duration(nil), delays...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	median := sorted[len(sorted)/2]
+	limit := time.Duration(float64(median) * config.DelayOutlierFactor)
+
+	for _, syn := range synapses {
+		delay := syn.GetDelay()
+		if delay > limit && delay <= config.MaxPlausibleDelay {
+			report.add(SeverityWarning, IssueDelayOutlier, syn.ID(),
+				"synapse %s delay %v is over %gx the median delay %v", syn.ID(), delay, config.DelayOutlierFactor, median)
+		}
+	}
+}
+
+// validateReachability reports orphans, neurons no input can reach, and
+// disconnected subnetworks.
+func validateReachability(ids []string, adjacency [][]edge, inDegree []int, inputs []string, report *ValidationReport) {
+	count := len(ids)
+	orphan := make([]bool, count)
+	outDegree := make([]int, count)
+	for i, edges := range adjacency {
+		outDegree[i] = len(edges)
+	}
+	for i := range ids {
+		if inDegree[i] == 0 && outDegree[i] == 0 {
+			orphan[i] = true
+			report.add(SeverityWarning, IssueOrphanNeuron, ids[i], "neuron %s has no synapses", ids[i])
+		}
+	}
+
+	// Roots: declared inputs, or neurons without incoming synapses
+	var roots []int
+	if len(inputs) > 0 {
+		position := make(map[string]int, count)
+		for i, id := range ids {
+			position[id] = i
+		}
+		for _, id := range inputs {
+			if i, ok := position[id]; ok {
+				roots = append(roots, i)
+			} else {
+				report.add(SeverityError, IssueUnknownInput, id, "declared input %s is not in the network", id)
+			}
+		}
+	} else {
+		for i := range ids {
+			if inDegree[i] == 0 && !orphan[i] {
+				roots = append(roots, i)
+			}
+		}
+	}
+
+	reached := make([]bool, count)
+	queue := append([]int(nil), roots...)
+	for _, r := range roots {
+		reached[r] = true
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, e := range adjacency[current] {
+			if !reached[e.to] {
+				reached[e.to] = true
+				queue = append(queue, e.to)
+			}
+		}
+	}
+	for i := range ids {
+		if !reached[i] && !orphan[i] {
+			report.add(SeverityWarning, IssueUnreachable, ids[i],
+				"neuron %s cannot be reached from any input; only recurrent activity can drive it", ids[i])
+		}
+	}
+
+	// Weakly connected subnetworks among connected neurons
+	undirected := make([][]int, count)
+	for from, edges := range adjacency {
+		for _, e := range edges {
+			undirected[from] = append(undirected[from], e.to)
+			undirected[e.to] = append(undirected[e.to], from)
+		}
+	}
+	component := make([]int, count)
+	for i := range component {
+		component[i] = -1
+	}
+	var sizes []int
+	for start := range ids {
+		if orphan[start] || component[start] >= 0 {
+			continue
+		}
+		label := len(sizes)
+		sizes = append(sizes, 0)
+		stack := []int{start}
+		component[start] = label
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			sizes[label]++
+			for _, next := range undirected[current] {
+				if component[next] < 0 {
+					component[next] = label
+					stack = append(stack, next)
+				}
+			}
+		}
+	}
+	if len(sizes) > 1 {
+		sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+		report.add(SeverityInfo, IssueDisconnected, "",
+			"network splits into %d unconnected subnetworks of sizes %v", len(sizes), sizes)
+	}
+}
+
+// validateCycles reports autapses, recurrent loops and loops that close with
+// zero total delay (instantaneous feedback).
+func validateCycles(ids []string, adjacency [][]edge, report *ValidationReport) {
+	zeroDelay := make([][]edge, len(adjacency))
+	for from, edges := range adjacency {
+		for _, e := range edges {
+			if e.to == from {
+				report.add(SeverityWarning, IssueAutapse, ids[from],
+					"neuron %s connects to itself (delay %v)", ids[from], e.delay)
+				continue
+			}
+			if e.delay == 0 {
+				zeroDelay[from] = append(zeroDelay[from], e)
+			}
+		}
+	}
+
+	for _, scc := range stronglyConnected(adjacency) {
+		if len(scc) > 1 {
+			report.add(SeverityInfo, IssueRecurrentCycle, ids[scc[0]],
+				"%d neurons form a recurrent loop: %s", len(scc), listIDs(ids, scc))
+		}
+	}
+	for _, scc := range stronglyConnected(zeroDelay) {
+		if len(scc) > 1 {
+			report.add(SeverityWarning, IssueZeroDelayCycle, ids[scc[0]],
+				"%d neurons form a loop with zero delay, causing instantaneous feedback: %s", len(scc), listIDs(ids, scc))
+		}
+	}
+}
+
+// stronglyConnected returns the strongly connected components (Tarjan).
+// Members of each component are sorted by index.
+func stronglyConnected(adjacency [][]edge) [][]int {
+	count := len(adjacency)
+	order := make([]int, count)
+	low := make([]int, count)
+	onStack := make([]bool, count)
+	for i := range order {
+		order[i] = -1
+	}
+	var stack []int
+	var result [][]int
+	next := 0
+
+	var visit func(v int)
+	visit = func(v int) {
+		order[v], low[v] = next, next
+		next++
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, e := range adjacency[v] {
+			w := e.to
+			if order[w] < 0 {
+				visit(w)
+				low[v] = min(low[v], low[w])
+			} else if onStack[w] {
+				low[v] = min(low[v], order[w])
+			}
+		}
+
+		if low[v] == order[v] {
+			var scc []int
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				scc = append(scc, w)
+				if w == v {
+					break
+				}
+			}
+			sort.Ints(scc)
+			result = append(result, scc)
+		}
+	}
+
+	for v := 0; v < count; v++ {
+		if order[v] < 0 {
+			visit(v)
+		}
+	}
+	return result
+}
+
+// listIDs formats up to validationMaxListed neuron IDs.
+func listIDs(ids []string, members []int) string {
+	names := make([]string, 0, validationMaxListed)
+	for i, m := range members {
+		if i == validationMaxListed {
+			names = append(names, fmt.Sprintf("... (%d more)", len(members)-validationMaxListed))
+			break
+		}
+		names = append(names, ids[m])
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,143 @@
+package network
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// TestValidateCleanNetwork verifies a feed-forward chain reports no issues.
+func TestValidateCleanNetwork(t *testing.T) {
+	a, b, c := newTestNeuron("a"), newTestNeuron("b"), newTestNeuron("c")
+	net := FromComponents(
+		[]component.NeuralComponent{c, a, b},
+		[]component.SynapticProcessor{
+			connect("ab", a, b, 0.5, 2*time.Millisecond),
+			connect("bc", b, c, 0.5, 3*time.Millisecond),
+		})
+
+	report := net.Validate()
+	if len(report.Issues) != 0 {
+		t.Fatalf("Expected no issues, got:\n%s", report)
+	}
+	if report.Err() != nil || report.Neurons != 3 || report.Synapses != 2 {
+		t.Errorf("Unexpected report: %+v", report)
+	}
+	if ids := net.Neurons(); ids[0].ID() != "a" || ids[2].ID() != "c" {
+		t.Error("Expected neurons sorted by ID")
+	}
+}
+
+// TestValidateStructuralErrors verifies missing endpoints, invalid thresholds
+// and inverted weight bounds are errors.
+func TestValidateStructuralErrors(t *testing.T) {
+	a, b := newTestNeuron("a"), newTestNeuron("b")
+	ghost := newTestNeuron("ghost")
+	zero := neuron.NewNeuron("zero", 0, 0.95, 2*time.Millisecond, 1.0, 0, 0)
+
+	inverted := synapse.CreateDefaultSTDPConfig()
+	inverted.MinWeight, inverted.MaxWeight = 2.0, 1.0
+	invertedSyn := synapse.NewBasicSynapse("inv", a, b, inverted, synapse.CreateDefaultPruningConfig(), 1.5, time.Millisecond)
+
+	net := FromComponents(
+		[]component.NeuralComponent{a, b, zero},
+		[]component.SynapticProcessor{
+			connect("ab", a, b, 0.5, time.Millisecond),
+			connect("a-ghost", a, ghost, 0.5, time.Millisecond),
+			connect("bz", b, zero, 0.5, time.Millisecond),
+			invertedSyn,
+		})
+
+	report := net.Validate()
+	for _, code := range []string{IssueMissingNeuron, IssueInvalidThreshold, IssueInvertedWeightBounds} {
+		if len(report.ByCode(code)) != 1 {
+			t.Errorf("Expected one %s issue, got:\n%s", code, report)
+		}
+	}
+	if issue := report.ByCode(IssueMissingNeuron); len(issue) == 1 && !strings.Contains(issue[0].Message, "ghost") {
+		t.Errorf("Expected missing neuron to be named, got %q", issue[0].Message)
+	}
+	if !report.HasErrors() || report.Err() == nil {
+		t.Error("Expected report to fail")
+	}
+}
+
+// TestValidateConnectivity verifies orphans, unreachable neurons, separate
+// subnetworks, cycles and delay outliers are detected.
+func TestValidateConnectivity(t *testing.T) {
+	in, x, y := newTestNeuron("in"), newTestNeuron("x"), newTestNeuron("y")
+	p, q := newTestNeuron("p"), newTestNeuron("q")
+	self := newTestNeuron("self")
+	orphan := newTestNeuron("orphan")
+	u, v := newTestNeuron("u"), newTestNeuron("v")
+
+	net := FromComponents(
+		[]component.NeuralComponent{in, x, y, p, q, self, orphan, u, v},
+		[]component.SynapticProcessor{
+			connect("in-x", in, x, 0.5, 2*time.Millisecond),
+			connect("x-y", x, y, 0.5, 2*time.Millisecond),
+			connect("y-x", y, x, 0.5, 2*time.Millisecond), // Delayed recurrence: informational
+			connect("p-q", p, q, 0.5, 0),                  // Zero-delay loop, not driven by any input
+			connect("q-p", q, p, 0.5, 0),
+			connect("self", self, self, 0.5, 2*time.Millisecond),
+			connect("u-v", u, v, 0.5, 80*time.Millisecond), // Outlier against ~2ms median
+			connect("slow", in, y, 0.5, 2*time.Second),
+		})
+
+	report := net.ValidateWith(ValidationConfig{Inputs: []string{"in", "u", "missing"}})
+
+	expected := map[string]int{
+		IssueOrphanNeuron:     1,
+		IssueUnknownInput:     1,
+		IssueUnreachable:      3, // p, q and self
+		IssueRecurrentCycle:   2, // x-y and p-q
+		IssueZeroDelayCycle:   1,
+		IssueAutapse:          1,
+		IssueDelayOutlier:     1,
+		IssueImplausibleDelay: 1,
+		IssueDisconnected:     1,
+	}
+	for code, count := range expected {
+		if got := len(report.ByCode(code)); got != count {
+			t.Errorf("Expected %d %s issue(s), got %d:\n%s", count, code, got, report)
+		}
+	}
+	if issue := report.ByCode(IssueOrphanNeuron); len(issue) == 1 && issue[0].ComponentID != "orphan" {
+		t.Errorf("Expected orphan neuron to be reported, got %+v", issue[0])
+	}
+	if issue := report.ByCode(IssueDelayOutlier); len(issue) == 1 && issue[0].ComponentID != "u-v" {
+		t.Errorf("Expected u-v as delay outlier, got %+v", issue[0])
+	}
+	if report.ByCode(IssueZeroDelayCycle)[0].Severity != SeverityWarning {
+		t.Error("Expected zero-delay loops to be warnings")
+	}
+	if len(report.Errors()) != 1 {
+		t.Errorf("Expected only the unknown input to be an error, got %+v", report.Errors())
+	}
+}
+
+// TestValidateWithoutDeclaredInputs verifies neurons without incoming synapses
+// act as inputs when none are declared.
+func TestValidateWithoutDeclaredInputs(t *testing.T) {
+	a, b, c := newTestNeuron("a"), newTestNeuron("b"), newTestNeuron("c")
+	net := New(staticSource{
+		neurons: []component.NeuralComponent{a, b, c},
+		synapses: []component.SynapticProcessor{
+			connect("ab", a, b, 0.5, time.Millisecond),
+			connect("bc", b, c, 0.5, time.Millisecond),
+			connect("cb", c, b, 0.5, time.Millisecond),
+		},
+	})
+
+	report := net.Validate()
+	if len(report.ByCode(IssueUnreachable)) != 0 {
+		t.Errorf("Expected all neurons reachable from a, got:\n%s", report)
+	}
+	if len(report.Warnings()) != 0 || report.HasErrors() {
+		t.Errorf("Expected only informational issues, got:\n%s", report)
+	}
+}
@@ -0,0 +1,545 @@
+package network
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/analysis"
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// PATHOLOGY WATCHDOG
+// =================================================================================
+//
+// Recurrent networks with plasticity can drift into regimes that make a run
+// worthless: runaway excitation (seizure-like saturation), total silence, or
+// a rhythm that locks the whole population into one frequency. A Watchdog
+// watches population activity and reacts when that happens:
+//
+//	wd, _ := net.NewWatchdog(network.WatchdogConfig{
+//	    MaxRate:          80,                       // Hz per neuron, sustained over RateWindow
+//	    SilenceAfter:     2 * time.Second,
+//	    LockPower:        0.4,                      // share of power in one narrow band
+//	    InhibitionPulse:  1.0,                      // intervention on runaway and lock-up
+//	    FreezePlasticity: true,                     // frozen while any pathology lasts
+//	    OnEvent:          func(e types.NetworkEvent) { ... },
+//	})
+//	... wd.RecordSpike(neuronID, at) for every spike ...
+//	wd.Start() // or call Step(now) from the simulation loop
+//
+// Spikes are kept as counts per BinWidth over Window, so memory does not
+// grow with activity. Detections:
+//
+//   - runaway: the mean rate over RateWindow exceeds MaxRate
+//   - silence: no spike for SilenceAfter
+//   - oscillation lock-up: over a full Window, at least LockPower of the
+//     activity's spectral power lies within LockBandwidth of a single peak
+//     in [LockMinFrequency, LockMaxFrequency], for LockSustain
+//
+// Onset emits a types.NetworkEvent (burst, quiescence or oscillation), calls
+// OnPathology and applies the configured interventions: an inhibitory pulse
+// to every neuron, repeated every PulseInterval while a runaway or lock-up
+// lasts, and a plasticity freeze held until every pathology has cleared.
+// Clearing emits a NetworkRecovery event. Events are also logged as
+// "pathology" records when LogHandler is set.
+
+// Pathology is a pathological activity regime.
+type Pathology string
+
+const (
+	PathologyRunaway         Pathology = "runaway"
+	PathologySilence         Pathology = "silence"
+	PathologyOscillationLock Pathology = "oscillation_lock"
+)
+
+// Watchdog defaults
+const (
+	WATCHDOG_DEFAULT_WINDOW         = time.Second
+	WATCHDOG_DEFAULT_BIN_WIDTH      = 5 * time.Millisecond
+	WATCHDOG_DEFAULT_RATE_WINDOW    = 200 * time.Millisecond
+	WATCHDOG_DEFAULT_LOCK_BANDWIDTH = 2.0 // Hz either side of the peak
+	WATCHDOG_DEFAULT_LOCK_MIN_HZ    = 2.0
+	WATCHDOG_DEFAULT_LOCK_MAX_HZ    = 100.0
+	WATCHDOG_DEFAULT_LOCK_SUSTAIN   = 500 * time.Millisecond
+	WATCHDOG_DEFAULT_PULSE_INTERVAL = 100 * time.Millisecond
+	WATCHDOG_DEFAULT_CHECK_INTERVAL = 50 * time.Millisecond
+	WATCHDOG_SOURCE_ID              = "network_watchdog"
+)
+
+// WatchdogConfig selects detectors and interventions. A zero threshold
+// disables its detector.
+type WatchdogConfig struct {
+	Window   time.Duration // Activity history kept
+	BinWidth time.Duration // Resolution of the history
+
+	MaxRate    float64       // Runaway threshold in Hz per neuron (0 = off)
+	RateWindow time.Duration // Averaging period of the rate
+
+	SilenceAfter time.Duration // Silence threshold (0 = off)
+
+	LockPower        float64 // Fraction of spectral power in the peak band (0 = off)
+	LockBandwidth    float64 // Half-width of the peak band (Hz)
+	LockMinFrequency float64 // Lowest rhythm considered (Hz)
+	LockMaxFrequency float64 // Highest rhythm considered (Hz)
+	LockSustain      time.Duration
+
+	InhibitionPulse  float64       // Inhibitory input sent to every neuron on runaway and lock-up (0 = off)
+	PulseInterval    time.Duration // Repetition of the pulse while the pathology lasts
+	FreezePlasticity bool          // Freeze plasticity while any pathology lasts
+
+	CheckInterval time.Duration // Period of automatic checks
+
+	OnEvent     func(event types.NetworkEvent)                      // Every onset and recovery
+	OnPathology func(pathology Pathology, event types.NetworkEvent) // Onset only: user intervention
+	LogHandler  slog.Handler
+}
+
+// pathologyState tracks one detector.
+type pathologyState struct {
+	active    bool
+	since     time.Time // Onset, or start of the pending condition
+	pending   bool      // Condition true but not yet sustained
+	lastPulse time.Time
+	episodes  int64
+}
+
+// Watchdog detects pathological activity regimes and intervenes.
+type Watchdog struct {
+	network *Network
+	config  WatchdogConfig
+	logger  *slog.Logger
+
+	mu        sync.Mutex
+	bins      []float64 // Ring of spike counts
+	binStart  time.Time // Start of the newest bin
+	newest    int       // Index of the newest bin
+	filled    time.Duration
+	started   bool
+	lastSpike time.Time
+	states    map[Pathology]*pathologyState
+	frozen    bool
+
+	// Statistics
+	spikes       int64
+	checks       int64
+	pulses       int64
+	freezeErrors int64
+
+	stopChan chan struct{}
+	wg       sync.WaitGroup
+	running  bool
+}
+
+// NewWatchdog creates a watchdog over the network. Interventions act on the
+// network's neurons and synapses as they are at the time of the action.
+func (n *Network) NewWatchdog(config WatchdogConfig) (*Watchdog, error) {
+	if config.Window == 0 {
+		config.Window = WATCHDOG_DEFAULT_WINDOW
+	}
+	if config.BinWidth == 0 {
+		config.BinWidth = WATCHDOG_DEFAULT_BIN_WIDTH
+	}
+	if config.RateWindow == 0 {
+		config.RateWindow = WATCHDOG_DEFAULT_RATE_WINDOW
+	}
+	if config.LockBandwidth == 0 {
+		config.LockBandwidth = WATCHDOG_DEFAULT_LOCK_BANDWIDTH
+	}
+	if config.LockMinFrequency == 0 {
+		config.LockMinFrequency = WATCHDOG_DEFAULT_LOCK_MIN_HZ
+	}
+	if config.LockMaxFrequency == 0 {
+		config.LockMaxFrequency = WATCHDOG_DEFAULT_LOCK_MAX_HZ
+	}
+	if config.LockSustain == 0 {
+		config.LockSustain = WATCHDOG_DEFAULT_LOCK_SUSTAIN
+	}
+	if config.PulseInterval == 0 {
+		config.PulseInterval = WATCHDOG_DEFAULT_PULSE_INTERVAL
+	}
+	if config.CheckInterval == 0 {
+		config.CheckInterval = WATCHDOG_DEFAULT_CHECK_INTERVAL
+	}
+	if err := validateWatchdogConfig(config); err != nil {
+		return nil, err
+	}
+
+	w := &Watchdog{
+		network: n,
+		config:  config,
+		bins:    make([]float64, int(config.Window/config.BinWidth)),
+		states: map[Pathology]*pathologyState{
+			PathologyRunaway:         {},
+			PathologySilence:         {},
+			PathologyOscillationLock: {},
+		},
+	}
+	if config.LogHandler != nil {
+		w.logger = slog.New(config.LogHandler).With("watchdog", WATCHDOG_SOURCE_ID)
+	}
+	return w, nil
+}
+
+// validateWatchdogConfig checks a config with defaults applied.
+func validateWatchdogConfig(config WatchdogConfig) error {
+	if config.Window < 0 || config.BinWidth < 0 || config.RateWindow < 0 || config.SilenceAfter < 0 ||
+		config.LockSustain < 0 || config.PulseInterval < 0 || config.CheckInterval < 0 {
+		return fmt.Errorf("watchdog durations cannot be negative")
+	}
+	if config.Window < 2*config.BinWidth {
+		return fmt.Errorf("watchdog window %v needs at least two bins of %v", config.Window, config.BinWidth)
+	}
+	if config.RateWindow > config.Window || config.RateWindow < config.BinWidth {
+		return fmt.Errorf("watchdog rate window %v must lie in [%v, %v]", config.RateWindow, config.BinWidth, config.Window)
+	}
+	if config.MaxRate < 0 || config.InhibitionPulse < 0 {
+		return fmt.Errorf("watchdog rate threshold and inhibition pulse cannot be negative")
+	}
+	if config.LockPower < 0 || config.LockPower > 1 {
+		return fmt.Errorf("watchdog lock power must be in [0, 1]: %f", config.LockPower)
+	}
+	nyquist := analysis.SampleRate(config.BinWidth) / 2
+	if config.LockPower > 0 && (config.LockMinFrequency >= config.LockMaxFrequency || config.LockMaxFrequency > nyquist) {
+		return fmt.Errorf("watchdog lock band [%f, %f] Hz invalid for %v bins", config.LockMinFrequency, config.LockMaxFrequency, config.BinWidth)
+	}
+	return nil
+}
+
+// RecordSpike counts a spike of any network neuron.
+func (w *Watchdog) RecordSpike(neuronID string, at time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.advance(at)
+	if at.Before(w.binStart) {
+		// Late spike: count it in its bin if still in the window
+		back := int(w.binStart.Sub(at)/w.config.BinWidth) + 1
+		if back >= len(w.bins) {
+			return
+		}
+		w.bins[(w.newest-back+len(w.bins))%len(w.bins)]++
+	} else {
+		w.bins[w.newest]++
+	}
+	if at.After(w.lastSpike) {
+		w.lastSpike = at
+	}
+	w.spikes++
+}
+
+// advance moves the ring so its newest bin contains now. Caller must hold
+// w.mu.
+func (w *Watchdog) advance(now time.Time) {
+	if !w.started {
+		w.started = true
+		w.binStart = now
+		w.lastSpike = now // Silence is measured from the start of observation
+		return
+	}
+	steps := int(now.Sub(w.binStart) / w.config.BinWidth)
+	if steps <= 0 {
+		return
+	}
+	if steps > len(w.bins) {
+		// Skip a long gap at once
+		for i := range w.bins {
+			w.bins[i] = 0
+		}
+		w.binStart = w.binStart.Add(time.Duration(steps-len(w.bins)) * w.config.BinWidth)
+		w.filled += time.Duration(steps-len(w.bins)) * w.config.BinWidth
+		steps = len(w.bins)
+	}
+	for s := 0; s < steps; s++ {
+		w.newest = (w.newest + 1) % len(w.bins)
+		w.bins[w.newest] = 0
+		w.binStart = w.binStart.Add(w.config.BinWidth)
+		w.filled += w.config.BinWidth
+	}
+}
+
+// history returns the last count bins, oldest first, excluding the bin in
+// progress. Caller must hold w.mu.
+func (w *Watchdog) history(count int) []float64 {
+	if count > len(w.bins)-1 {
+		count = len(w.bins) - 1
+	}
+	out := make([]float64, count)
+	for i := 0; i < count; i++ {
+		out[i] = w.bins[(w.newest-count+i+len(w.bins))%len(w.bins)]
+	}
+	return out
+}
+
+// Step evaluates every detector at now and applies interventions.
+//
+// Returns:
+//
+//	The pathologies active after the check
+func (w *Watchdog) Step(now time.Time) []Pathology {
+	neurons := len(w.network.source.ListNeurons())
+
+	w.mu.Lock()
+	w.checks++
+	w.advance(now)
+
+	var events []types.NetworkEvent
+	var pulse, freeze, unfreeze bool
+
+	// Runaway: mean rate over RateWindow
+	if w.config.MaxRate > 0 && neurons > 0 && w.filled >= w.config.RateWindow {
+		recent := w.history(int(w.config.RateWindow / w.config.BinWidth))
+		rate := sum(recent) / float64(neurons) / (time.Duration(len(recent)) * w.config.BinWidth).Seconds()
+		if event := w.update(PathologyRunaway, rate > w.config.MaxRate, 0, now, rate); event != nil {
+			events = append(events, *event)
+		}
+	}
+
+	// Silence: time since the last spike
+	if w.config.SilenceAfter > 0 {
+		quiet := now.Sub(w.lastSpike)
+		if event := w.update(PathologySilence, quiet >= w.config.SilenceAfter, 0, now, quiet.Seconds()); event != nil {
+			events = append(events, *event)
+		}
+	}
+
+	// Oscillation lock-up: spectral concentration over the whole window
+	if w.config.LockPower > 0 && w.filled >= w.config.Window {
+		share, frequency := w.lockShare()
+		if event := w.update(PathologyOscillationLock, share >= w.config.LockPower, w.config.LockSustain, now, share); event != nil {
+			event.Metadata["frequency"] = frequency
+			events = append(events, *event)
+		}
+	}
+
+	var active []Pathology
+	anyActive := false
+	for _, p := range []Pathology{PathologyRunaway, PathologySilence, PathologyOscillationLock} {
+		state := w.states[p]
+		if !state.active {
+			continue
+		}
+		active = append(active, p)
+		anyActive = true
+		if p != PathologySilence && w.config.InhibitionPulse > 0 && now.Sub(state.lastPulse) >= w.config.PulseInterval {
+			state.lastPulse = now
+			pulse = true
+		}
+	}
+	if w.config.FreezePlasticity {
+		if anyActive && !w.frozen {
+			w.frozen, freeze = true, true
+		} else if !anyActive && w.frozen {
+			w.frozen, unfreeze = false, true
+		}
+	}
+	if pulse {
+		w.pulses++
+	}
+	w.mu.Unlock()
+
+	// Interventions and callbacks run without the watchdog lock
+	if pulse {
+		w.inhibit(now)
+	}
+	if freeze {
+		if err := w.network.FreezePlasticity(); err != nil {
+			w.freezeFailed(err)
+		}
+	}
+	if unfreeze {
+		if err := w.network.Unfreeze(); err != nil {
+			w.freezeFailed(err)
+		}
+	}
+	for _, event := range events {
+		w.emit(event)
+		if event.EventType != types.NetworkRecovery && w.config.OnPathology != nil {
+			w.config.OnPathology(Pathology(event.Metadata["pathology"].(string)), event)
+		}
+	}
+	return active
+}
+
+// update advances a detector's state machine. sustain is how long the
+// condition must hold before onset. Returns the onset or recovery event, if
+// any. Caller must hold w.mu.
+func (w *Watchdog) update(p Pathology, condition bool, sustain time.Duration, now time.Time, value float64) *types.NetworkEvent {
+	state := w.states[p]
+	switch {
+	case condition && !state.active:
+		if !state.pending {
+			state.pending, state.since = true, now
+		}
+		if now.Sub(state.since) < sustain {
+			return nil
+		}
+		state.active, state.pending = true, false
+		state.since = now
+		state.episodes++
+		event := w.event(onsetEventType(p), p, now, 0, value)
+		return &event
+	case !condition && state.active:
+		state.active = false
+		event := w.event(types.NetworkRecovery, p, now, now.Sub(state.since), value)
+		return &event
+	case !condition:
+		state.pending = false
+	}
+	return nil
+}
+
+// onsetEventType maps a pathology to its network event type.
+func onsetEventType(p Pathology) types.NetworkEventType {
+	switch p {
+	case PathologyRunaway:
+		return types.NetworkBurst
+	case PathologySilence:
+		return types.NetworkQuiescence
+	default:
+		return types.NetworkOscillation
+	}
+}
+
+// event builds a network event. Caller must hold w.mu.
+func (w *Watchdog) event(eventType types.NetworkEventType, p Pathology, now time.Time, duration time.Duration, value float64) types.NetworkEvent {
+	return types.NetworkEvent{
+		EventType: eventType,
+		Timestamp: now,
+		Duration:  duration,
+		Intensity: value,
+		TriggerID: WATCHDOG_SOURCE_ID,
+		Metadata: map[string]interface{}{
+			"pathology": string(p),
+			"episode":   w.states[p].episodes,
+		},
+	}
+}
+
+// lockShare returns the fraction of spectral power within LockBandwidth of
+// the strongest rhythm, and that rhythm's frequency. Caller must hold w.mu.
+func (w *Watchdog) lockShare() (float64, float64) {
+	activity := w.history(len(w.bins) - 1)
+	if sum(activity) == 0 {
+		return 0, 0
+	}
+	spectrum, err := analysis.PowerSpectrum(activity, analysis.SampleRate(w.config.BinWidth))
+	if err != nil {
+		return 0, 0
+	}
+	peak, power := spectrum.PeakFrequency(w.config.LockMinFrequency, w.config.LockMaxFrequency)
+	if power == 0 {
+		return 0, 0
+	}
+	return spectrum.RelativeBandPower(peak-w.config.LockBandwidth, peak+w.config.LockBandwidth), peak
+}
+
+// inhibit sends the inhibitory pulse to every neuron.
+func (w *Watchdog) inhibit(now time.Time) {
+	for _, neuron := range w.network.source.ListNeurons() {
+		neuron.Receive(types.NeuralSignal{
+			Value:     -w.config.InhibitionPulse,
+			Timestamp: now,
+			SourceID:  WATCHDOG_SOURCE_ID,
+			TargetID:  neuron.ID(),
+		})
+	}
+}
+
+// emit delivers an event to the callback and the log.
+func (w *Watchdog) emit(event types.NetworkEvent) {
+	if w.config.OnEvent != nil {
+		w.config.OnEvent(event)
+	}
+	level, msg := slog.LevelWarn, "pathology detected"
+	if event.EventType == types.NetworkRecovery {
+		level, msg = slog.LevelInfo, "pathology cleared"
+	}
+	logging.Log(w.logger, level, logging.RecordPathology, msg,
+		"pathology", event.Metadata["pathology"], "event", string(event.EventType),
+		"intensity", event.Intensity, "duration", event.Duration)
+}
+
+// freezeFailed counts and logs a failed plasticity freeze or unfreeze.
+func (w *Watchdog) freezeFailed(err error) {
+	w.mu.Lock()
+	w.freezeErrors++
+	w.mu.Unlock()
+	logging.Log(w.logger, slog.LevelWarn, logging.RecordPathology, "plasticity intervention failed", "error", err)
+}
+
+// Active reports whether a pathology is currently detected.
+func (w *Watchdog) Active(p Pathology) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	state, ok := w.states[p]
+	return ok && state.active
+}
+
+// Start runs checks every CheckInterval until Stop is called.
+func (w *Watchdog) Start() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.running {
+		return
+	}
+	w.running = true
+	w.stopChan = make(chan struct{})
+
+	w.wg.Add(1)
+	go func(stop chan struct{}) {
+		defer w.wg.Done()
+		ticker := time.NewTicker(w.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				w.Step(now)
+			case <-stop:
+				return
+			}
+		}
+	}(w.stopChan)
+}
+
+// Stop halts periodic checks. A freeze applied by the watchdog stays in
+// effect until the network is unfrozen.
+func (w *Watchdog) Stop() {
+	w.mu.Lock()
+	if !w.running {
+		w.mu.Unlock()
+		return
+	}
+	w.running = false
+	close(w.stopChan)
+	w.mu.Unlock()
+	w.wg.Wait()
+}
+
+// GetStats returns detection statistics for monitoring.
+func (w *Watchdog) GetStats() map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return map[string]interface{}{
+		"spikes":            w.spikes,
+		"checks":            w.checks,
+		"runaway_episodes":  w.states[PathologyRunaway].episodes,
+		"silence_episodes":  w.states[PathologySilence].episodes,
+		"lock_episodes":     w.states[PathologyOscillationLock].episodes,
+		"inhibition_pulses": w.pulses,
+		"plasticity_frozen": w.frozen,
+		"freeze_errors":     w.freezeErrors,
+		"running":           w.running,
+	}
+}
+
+// sum adds up values.
+func sum(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,158 @@
+package network
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestWatchdogRunawayAndSilence verifies detection, interventions and
+// recovery for runaway excitation and silence.
+func TestWatchdogRunawayAndSilence(t *testing.T) {
+	a, b := newTestNeuron("a"), newTestNeuron("b")
+	plastic := synapse.NewBasicSynapse("ab", a, b, synapse.CreateDefaultSTDPConfig(), synapse.CreateDefaultPruningConfig(), 0.5, time.Millisecond)
+	net := FromComponents([]component.NeuralComponent{a, b}, []component.SynapticProcessor{plastic})
+
+	var events []types.NetworkEvent
+	var onsets []Pathology
+	wd, err := net.NewWatchdog(WatchdogConfig{
+		MaxRate:          50,
+		RateWindow:       100 * time.Millisecond,
+		SilenceAfter:     200 * time.Millisecond,
+		InhibitionPulse:  1.0,
+		FreezePlasticity: true,
+		OnEvent:          func(e types.NetworkEvent) { events = append(events, e) },
+		OnPathology:      func(p Pathology, e types.NetworkEvent) { onsets = append(onsets, p) },
+	})
+	if err != nil {
+		t.Fatalf("Failed to create watchdog: %v", err)
+	}
+
+	// run feeds both neurons at the given interval and checks every 50ms
+	now := time.Now()
+	run := func(interval, duration time.Duration) {
+		end := now.Add(duration)
+		next := now
+		for tick := 1; now.Before(end); tick++ {
+			if interval > 0 && !now.Before(next) {
+				wd.RecordSpike("a", now)
+				wd.RecordSpike("b", now)
+				next = next.Add(interval)
+			}
+			now = now.Add(5 * time.Millisecond)
+			if tick%10 == 0 {
+				wd.Step(now)
+			}
+		}
+		wd.Step(now)
+	}
+
+	run(5*time.Millisecond, 300*time.Millisecond) // 200 Hz
+	if !wd.Active(PathologyRunaway) {
+		t.Fatal("Expected runaway to be detected at 200 Hz")
+	}
+	if !net.IsPlasticityFrozen() {
+		t.Error("Expected plasticity frozen during runaway")
+	}
+	if pulses := wd.GetStats()["inhibition_pulses"].(int64); pulses < 2 {
+		t.Errorf("Expected repeated inhibition pulses, got %d", pulses)
+	}
+
+	run(100*time.Millisecond, 300*time.Millisecond) // 10 Hz
+	if wd.Active(PathologyRunaway) || net.IsPlasticityFrozen() {
+		t.Error("Expected recovery and unfrozen plasticity at 10 Hz")
+	}
+
+	run(0, 300*time.Millisecond)
+	if !wd.Active(PathologySilence) {
+		t.Error("Expected silence to be detected")
+	}
+	run(5*time.Millisecond, 10*time.Millisecond)
+	if wd.Active(PathologySilence) {
+		t.Error("Expected silence to clear after a spike")
+	}
+
+	kinds := make([]types.NetworkEventType, len(events))
+	for i, e := range events {
+		kinds[i] = e.EventType
+	}
+	expected := []types.NetworkEventType{types.NetworkBurst, types.NetworkRecovery, types.NetworkQuiescence, types.NetworkRecovery}
+	if fmt.Sprint(kinds) != fmt.Sprint(expected) {
+		t.Errorf("Expected events %v, got %v", expected, kinds)
+	}
+	if fmt.Sprint(onsets) != fmt.Sprint([]Pathology{PathologyRunaway, PathologySilence}) {
+		t.Errorf("Unexpected OnPathology calls: %v", onsets)
+	}
+}
+
+// TestWatchdogOscillationLock verifies a population locked into a 10 Hz
+// rhythm is detected and irregular activity is not.
+func TestWatchdogOscillationLock(t *testing.T) {
+	neurons := make([]component.NeuralComponent, 20)
+	for i := range neurons {
+		neurons[i] = newTestNeuron(fmt.Sprintf("n%d", i))
+	}
+	net := FromComponents(neurons, nil)
+
+	detect := func(spikeTimes func(start time.Time) []time.Time) (bool, float64) {
+		var frequency float64
+		wd, err := net.NewWatchdog(WatchdogConfig{
+			LockPower:   0.4,
+			LockSustain: 200 * time.Millisecond,
+			OnPathology: func(p Pathology, e types.NetworkEvent) { frequency = e.Metadata["frequency"].(float64) },
+		})
+		if err != nil {
+			t.Fatalf("Failed to create watchdog: %v", err)
+		}
+		start := time.Now()
+		wd.Step(start)
+		for _, at := range spikeTimes(start) {
+			wd.RecordSpike("n0", at)
+		}
+		for tick := time.Duration(0); tick <= 2*time.Second; tick += 50 * time.Millisecond {
+			wd.Step(start.Add(tick))
+		}
+		return wd.Active(PathologyOscillationLock), frequency
+	}
+
+	// Bursts every 100ms with 10ms Gaussian jitter
+	rhythmic := func(start time.Time) []time.Time {
+		var times []time.Time
+		for cycle := 0; cycle < 20; cycle++ {
+			for k := -20; k <= 20; k++ {
+				offset := float64(k) / 2 // ms
+				n := int(math.Round(4 * math.Exp(-offset*offset/200)))
+				for i := 0; i < n; i++ {
+					times = append(times, start.Add(time.Duration(cycle)*100*time.Millisecond+time.Duration((50+offset)*float64(time.Millisecond))))
+				}
+			}
+		}
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+		return times
+	}
+	locked, frequency := detect(rhythmic)
+	if !locked || math.Abs(frequency-10) > 2 {
+		t.Errorf("Expected a 10 Hz lock-up, got locked=%v at %.1f Hz", locked, frequency)
+	}
+
+	// Irregular activity: deterministic pseudo-random times
+	irregular := func(start time.Time) []time.Time {
+		var times []time.Time
+		x := uint32(12345)
+		for i := 0; i < 800; i++ {
+			x = x*1664525 + 1013904223
+			times = append(times, start.Add(time.Duration(x%2000)*time.Millisecond))
+		}
+		sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+		return times
+	}
+	if locked, _ := detect(irregular); locked {
+		t.Error("Expected irregular activity not to count as lock-up")
+	}
+}
@@ -0,0 +1,208 @@
+package network
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+)
+
+// =================================================================================
+// SPARSE WEIGHT IMPORT/EXPORT
+// =================================================================================
+//
+// Weights leave and enter the network as sparse matrices indexed by neuron
+// position, so they can be round-tripped through NumPy/SciPy:
+//
+//	scipy.sparse.coo_matrix((m.Data, (m.Row, m.Col)), shape=(m.Size(), m.Size()))
+//	scipy.sparse.csr_matrix((c.Data, c.Indices, c.IndPtr), shape=(c.Size(), c.Size()))
+//
+// Row is the presynaptic and Col the postsynaptic neuron index; NeuronIDs maps
+// indices back to neurons. Each synapse reads its weight under its own lock, so
+// export and import are safe while the network runs. The export is not a
+// single atomic snapshot across synapses that are learning at the same time.
+
+// WeightMatrix is a network's weights in coordinate (COO) format, one entry
+// per synapse.
+type WeightMatrix struct {
+	NeuronIDs  []string  `json:"neuron_ids"`            // Index -> neuron ID
+	Row        []int     `json:"row"`                   // Presynaptic neuron index
+	Col        []int     `json:"col"`                   // Postsynaptic neuron index
+	Data       []float64 `json:"data"`                  // Synaptic weight
+	SynapseIDs []string  `json:"synapse_ids,omitempty"` // Optional, matches entries to synapses exactly
+}
+
+// CSRMatrix is a network's weights in compressed sparse row format.
+type CSRMatrix struct {
+	NeuronIDs  []string  `json:"neuron_ids"`
+	IndPtr     []int     `json:"indptr"`  // Row i spans Indices[IndPtr[i]:IndPtr[i+1]]
+	Indices    []int     `json:"indices"` // Postsynaptic neuron index
+	Data       []float64 `json:"data"`
+	SynapseIDs []string  `json:"synapse_ids,omitempty"`
+}
+
+// Size returns the number of neurons (rows and columns).
+func (m *WeightMatrix) Size() int { return len(m.NeuronIDs) }
+
+// NNZ returns the number of stored entries.
+func (m *WeightMatrix) NNZ() int { return len(m.Data) }
+
+// Size returns the number of neurons (rows and columns).
+func (c *CSRMatrix) Size() int { return len(c.NeuronIDs) }
+
+// ToCSR converts the matrix to CSR format. Entries within a row keep their
+// relative order.
+func (m *WeightMatrix) ToCSR() (*CSRMatrix, error) {
+	if err := m.validateShape(); err != nil {
+		return nil, err
+	}
+	size := m.Size()
+	csr := &CSRMatrix{
+		NeuronIDs: append([]string(nil), m.NeuronIDs...),
+		IndPtr:    make([]int, size+1),
+		Indices:   make([]int, len(m.Data)),
+		Data:      make([]float64, len(m.Data)),
+	}
+	if len(m.SynapseIDs) > 0 {
+		csr.SynapseIDs = make([]string, len(m.Data))
+	}
+	for _, row := range m.Row {
+		csr.IndPtr[row+1]++
+	}
+	for i := 0; i < size; i++ {
+		csr.IndPtr[i+1] += csr.IndPtr[i]
+	}
+	next := append([]int(nil), csr.IndPtr[:size]...)
+	for k, row := range m.Row {
+		pos := next[row]
+		next[row]++
+		csr.Indices[pos] = m.Col[k]
+		csr.Data[pos] = m.Data[k]
+		if csr.SynapseIDs != nil {
+			csr.SynapseIDs[pos] = m.SynapseIDs[k]
+		}
+	}
+	return csr, nil
+}
+
+// validateShape checks that the entry slices agree and indices are in range.
+func (m *WeightMatrix) validateShape() error {
+	if len(m.Row) != len(m.Data) || len(m.Col) != len(m.Data) {
+		return fmt.Errorf("malformed weight matrix: %d rows, %d cols, %d values", len(m.Row), len(m.Col), len(m.Data))
+	}
+	if len(m.SynapseIDs) != 0 && len(m.SynapseIDs) != len(m.Data) {
+		return fmt.Errorf("malformed weight matrix: %d synapse IDs for %d values", len(m.SynapseIDs), len(m.Data))
+	}
+	for k := range m.Data {
+		if m.Row[k] < 0 || m.Row[k] >= m.Size() || m.Col[k] < 0 || m.Col[k] >= m.Size() {
+			return fmt.Errorf("entry %d: index (%d,%d) outside %d neurons", k, m.Row[k], m.Col[k], m.Size())
+		}
+	}
+	return nil
+}
+
+// ToCOO converts the matrix to coordinate format.
+func (c *CSRMatrix) ToCOO() (*WeightMatrix, error) {
+	if len(c.IndPtr) != c.Size()+1 || c.IndPtr[0] != 0 || c.IndPtr[c.Size()] != len(c.Data) || len(c.Indices) != len(c.Data) {
+		return nil, fmt.Errorf("malformed CSR matrix: %d neurons, %d indptr, %d indices, %d values",
+			c.Size(), len(c.IndPtr), len(c.Indices), len(c.Data))
+	}
+	m := &WeightMatrix{
+		NeuronIDs:  append([]string(nil), c.NeuronIDs...),
+		Row:        make([]int, 0, len(c.Data)),
+		Col:        append([]int(nil), c.Indices...),
+		Data:       append([]float64(nil), c.Data...),
+		SynapseIDs: append([]string(nil), c.SynapseIDs...),
+	}
+	for row := 0; row < c.Size(); row++ {
+		if c.IndPtr[row+1] < c.IndPtr[row] {
+			return nil, fmt.Errorf("malformed CSR matrix: indptr decreases at row %d", row)
+		}
+		for k := c.IndPtr[row]; k < c.IndPtr[row+1]; k++ {
+			m.Row = append(m.Row, row)
+		}
+	}
+	return m, nil
+}
+
+// ExportWeights returns the weights of all synapses between neurons of the
+// network. Neurons are indexed in ID order and entries follow synapse ID
+// order. Synapses with an endpoint outside the network are skipped (Validate
+// reports them).
+func (n *Network) ExportWeights() *WeightMatrix {
+	neurons := n.Neurons()
+	m := &WeightMatrix{NeuronIDs: make([]string, len(neurons))}
+	index := make(map[string]int, len(neurons))
+	for i, neuron := range neurons {
+		m.NeuronIDs[i] = neuron.ID()
+		index[neuron.ID()] = i
+	}
+
+	for _, syn := range n.Synapses() {
+		row, okPre := index[syn.GetPresynapticID()]
+		col, okPost := index[syn.GetPostsynapticID()]
+		if !okPre || !okPost {
+			continue
+		}
+		m.Row = append(m.Row, row)
+		m.Col = append(m.Col, col)
+		m.Data = append(m.Data, syn.GetWeight())
+		m.SynapseIDs = append(m.SynapseIDs, syn.ID())
+	}
+	return m
+}
+
+// ImportWeights sets the weights of existing synapses from m. With
+// SynapseIDs, each entry names its synapse and must match its endpoints.
+// Without, entries are matched to the synapses between their neuron pair in
+// synapse ID order (one entry per synapse, as from a dense ANN weight matrix).
+// The whole matrix is checked before any weight changes; synapses without an
+// entry keep their weights. Returns the number of weights set.
+func (n *Network) ImportWeights(m *WeightMatrix) (int, error) {
+	if err := m.validateShape(); err != nil {
+		return 0, err
+	}
+
+	type pair struct{ pre, post string }
+	byID := make(map[string]component.SynapticProcessor)
+	byPair := make(map[pair][]component.SynapticProcessor)
+	for _, syn := range n.Synapses() {
+		byID[syn.ID()] = syn
+		key := pair{syn.GetPresynapticID(), syn.GetPostsynapticID()}
+		byPair[key] = append(byPair[key], syn)
+	}
+
+	targets := make([]component.SynapticProcessor, len(m.Data))
+	used := make(map[pair]int)
+	for k, weight := range m.Data {
+		if math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return 0, fmt.Errorf("entry %d: invalid weight %f", k, weight)
+		}
+		key := pair{m.NeuronIDs[m.Row[k]], m.NeuronIDs[m.Col[k]]}
+
+		if len(m.SynapseIDs) != 0 {
+			syn, ok := byID[m.SynapseIDs[k]]
+			if !ok {
+				return 0, fmt.Errorf("entry %d: synapse %s not in network", k, m.SynapseIDs[k])
+			}
+			if syn.GetPresynapticID() != key.pre || syn.GetPostsynapticID() != key.post {
+				return 0, fmt.Errorf("entry %d: synapse %s connects %s->%s, not %s->%s", k, syn.ID(),
+					syn.GetPresynapticID(), syn.GetPostsynapticID(), key.pre, key.post)
+			}
+			targets[k] = syn
+			continue
+		}
+
+		candidates := byPair[key]
+		if used[key] >= len(candidates) {
+			return 0, fmt.Errorf("entry %d: no synapse %s->%s left to assign (%d available)", k, key.pre, key.post, len(candidates))
+		}
+		targets[k] = candidates[used[key]]
+		used[key]++
+	}
+
+	for k, syn := range targets {
+		syn.SetWeight(m.Data[k])
+	}
+	return len(targets), nil
+}
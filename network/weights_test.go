@@ -0,0 +1,72 @@
+package network
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+)
+
+// TestWeightsRoundTrip verifies COO/CSR export and import by pair and by
+// synapse ID, and that invalid matrices change nothing.
+func TestWeightsRoundTrip(t *testing.T) {
+	a, b, c := newTestNeuron("a"), newTestNeuron("b"), newTestNeuron("c")
+	ghost := newTestNeuron("ghost")
+	net := FromComponents([]component.NeuralComponent{c, b, a},
+		[]component.SynapticProcessor{
+			connect("ab", a, b, 0.1, time.Millisecond),
+			connect("ac", a, c, 0.2, time.Millisecond),
+			connect("cb", c, b, 0.3, time.Millisecond),
+			connect("a-ghost", a, ghost, 0.4, time.Millisecond),
+		})
+
+	m := net.ExportWeights()
+	if m.Size() != 3 || m.NNZ() != 3 || m.NeuronIDs[0] != "a" {
+		t.Fatalf("Unexpected export: %+v", m)
+	}
+	if m.Row[2] != 2 || m.Col[2] != 1 || m.Data[2] != 0.3 || m.SynapseIDs[2] != "cb" {
+		t.Errorf("Expected c->b as (2,1)=0.3, got (%d,%d)=%f", m.Row[2], m.Col[2], m.Data[2])
+	}
+
+	csr, err := m.ToCSR()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := csr.IndPtr; len(got) != 4 || got[1] != 2 || got[2] != 2 || got[3] != 3 {
+		t.Errorf("Unexpected indptr %v", got)
+	}
+
+	// Initialization from an external matrix: no synapse IDs, matched by pair
+	back, err := csr.ToCOO()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	back.SynapseIDs = nil
+	for k := range back.Data {
+		back.Data[k] *= 2
+	}
+	if count, err := net.ImportWeights(back); err != nil || count != 3 {
+		t.Fatalf("Expected 3 weights imported, got %d (%v)", count, err)
+	}
+	if w := net.ExportWeights().Data; w[0] != 0.2 || w[1] != 0.4 || w[2] != 0.6 {
+		t.Errorf("Expected doubled weights, got %v", w)
+	}
+
+	// Invalid entries are rejected before anything changes
+	bad := net.ExportWeights()
+	bad.Data[0] = 0.9
+	bad.SynapseIDs[2] = "ab"
+	if _, err := net.ImportWeights(bad); err == nil {
+		t.Error("Expected endpoint mismatch to fail")
+	}
+	bad = &WeightMatrix{NeuronIDs: []string{"a", "b"}, Row: []int{1}, Col: []int{0}, Data: []float64{0.5}}
+	if _, err := net.ImportWeights(bad); err == nil {
+		t.Error("Expected entry without a synapse to fail")
+	}
+	if w := net.ExportWeights().Data[0]; w != 0.2 {
+		t.Errorf("Expected weights unchanged after failed import, got %f", w)
+	}
+	if _, err := (&CSRMatrix{NeuronIDs: []string{"a"}, IndPtr: []int{0}}).ToCOO(); err == nil {
+		t.Error("Expected malformed CSR to fail")
+	}
+}
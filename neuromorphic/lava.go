@@ -0,0 +1,132 @@
+package neuromorphic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+// =================================================================================
+// INTEL LOIHI (LAVA) EXPORT
+// =================================================================================
+
+// Loihi 2 fixed-point limits as exposed by Lava's LIF and DelayDense processes.
+const (
+	LOIHI_TIMESTEP        = 1 * time.Millisecond // One algorithmic time step per neuron tick
+	LOIHI_WEIGHT_MAX      = 127                  // 8-bit signed synaptic weights
+	LOIHI_THRESHOLD_MAX   = 1<<17 - 1            // 17-bit unsigned membrane threshold
+	LOIHI_DECAY_UNITS     = 4096                 // du/dv are 12-bit fractions of 4096
+	LOIHI_MAX_DELAY_STEPS = 62                   // Longest synaptic delay on chip
+)
+
+// LavaConnection is one synapse in Lava index space.
+type LavaConnection struct {
+	Pre    int `json:"pre"`
+	Post   int `json:"post"`
+	Weight int `json:"weight"`
+	Delay  int `json:"delay"` // Time steps
+}
+
+// LavaNetwork describes a single LIFRefractory population and its recurrent
+// DelayDense/Sparse connectivity. Array parameters are indexed like NeuronIDs.
+type LavaNetwork struct {
+	Target           string           `json:"target"`
+	TimestepMs       float64          `json:"timestep_ms"`
+	NeuronIDs        []string         `json:"neuron_ids"`
+	Du               int              `json:"du"` // Current decay: inputs act for one step only
+	Dv               []int            `json:"dv"`
+	Vth              []int            `json:"vth"`
+	RefractoryPeriod []int            `json:"refractory_period"`
+	WeightScale      float64          `json:"weight_scale"` // Integer units per model weight unit
+	Connections      []LavaConnection `json:"connections"`
+}
+
+// WriteJSON writes the network description.
+func (n *LavaNetwork) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(n)
+}
+
+// ExportLava maps spec onto Loihi 2 LIF neurons via Lava. Weights and
+// thresholds share one scale chosen so the strongest synapse uses the full
+// 8-bit range.
+func ExportLava(spec *NetworkSpec) (*LavaNetwork, *CompatibilityReport, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid network: %w", err)
+	}
+
+	report := &CompatibilityReport{Target: "lava-loihi2", Neurons: len(spec.Neurons)}
+	index := spec.neuronIndex()
+	synapses := resolveSynapses(spec, index, report)
+
+	// Common scale: strongest weight → LOIHI_WEIGHT_MAX, bounded by threshold range
+	maxWeight, maxThreshold := 0.0, 0.0
+	for _, s := range synapses {
+		maxWeight = math.Max(maxWeight, math.Abs(s.weight))
+	}
+	for _, n := range spec.Neurons {
+		maxThreshold = math.Max(maxThreshold, n.Threshold)
+	}
+	scale := 1.0
+	if maxWeight > 0 {
+		scale = LOIHI_WEIGHT_MAX / maxWeight
+	}
+	if maxThreshold*scale > LOIHI_THRESHOLD_MAX {
+		scale = LOIHI_THRESHOLD_MAX / maxThreshold
+		report.add(SeverityApproximated, FeatureWeightPrecision, "network",
+			"threshold/weight ratio exceeds on-chip range; weights use fewer than 8 bits")
+	}
+
+	network := &LavaNetwork{
+		Target:           report.Target,
+		TimestepMs:       float64(LOIHI_TIMESTEP) / float64(time.Millisecond),
+		NeuronIDs:        make([]string, len(spec.Neurons)),
+		Du:               LOIHI_DECAY_UNITS - 1,
+		Dv:               make([]int, len(spec.Neurons)),
+		Vth:              make([]int, len(spec.Neurons)),
+		RefractoryPeriod: make([]int, len(spec.Neurons)),
+		WeightScale:      scale,
+		Connections:      make([]LavaConnection, 0, len(synapses)),
+	}
+
+	worstThreshold := precisionTracker{}
+	for i, n := range spec.Neurons {
+		network.NeuronIDs[i] = n.ID
+		network.Dv[i] = int(math.Round((1 - n.DecayRate) * LOIHI_DECAY_UNITS))
+		if network.Dv[i] >= LOIHI_DECAY_UNITS {
+			network.Dv[i] = LOIHI_DECAY_UNITS - 1
+		}
+		network.Vth[i] = int(math.Max(1, math.Round(n.Threshold*scale)))
+		worstThreshold.observe(n.ID, n.Threshold, float64(network.Vth[i])/scale)
+		network.RefractoryPeriod[i] = timestepsFor(n.RefractoryPeriod, LOIHI_TIMESTEP, 0, report, n.ID, "refractory period")
+		reportNeuronFeatures(report, n)
+	}
+	worstThreshold.report(report, "threshold")
+
+	worstWeight := precisionTracker{}
+	for _, s := range synapses {
+		weight := int(math.Round(s.weight * scale))
+		worstWeight.observe(s.spec.ID, s.weight, float64(weight)/scale)
+
+		delay := timestepsFor(s.spec.Delay, LOIHI_TIMESTEP, 1, report, s.spec.ID, "delay")
+		if delay > LOIHI_MAX_DELAY_STEPS {
+			report.add(SeverityApproximated, FeatureDelayRange, s.spec.ID,
+				"delay of %d steps clamped to %d", delay, LOIHI_MAX_DELAY_STEPS)
+			delay = LOIHI_MAX_DELAY_STEPS
+		}
+		if _, ok := s.spec.Features[FeatureSTDP]; ok {
+			report.add(SeverityUnsupported, FeatureSTDP, s.spec.ID,
+				"on-chip learning rules are not exported; weight frozen at %g", s.spec.Weight)
+		}
+		network.Connections = append(network.Connections, LavaConnection{
+			Pre: s.pre, Post: s.post, Weight: weight, Delay: delay,
+		})
+	}
+	worstWeight.report(report, "weight")
+
+	report.Synapses = len(network.Connections)
+	return network, report, nil
+}
@@ -0,0 +1,163 @@
+package neuromorphic
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// testNetwork builds three neurons: two plain LIF neurons and one with
+// homeostasis, connected by static and plastic synapses.
+func testNetwork(t *testing.T) *NetworkSpec {
+	t.Helper()
+	a := neuron.NewNeuron("a", 1.0, 0.9, 2*time.Millisecond, 1.0, 0, 0)
+	b := neuron.NewNeuron("b", 2.0, 1.0, 3*time.Millisecond, 2.0, 0, 0)
+	c := neuron.NewNeuron("c", 1.5, 0.95, 2*time.Millisecond, 1.0, 5.0, 0.2)
+
+	static := synapse.CreateDefaultSTDPConfig()
+	static.Enabled = false
+	static.MinWeight = -1.0 // Allow an inhibitory connection
+	pruning := synapse.CreateDefaultPruningConfig()
+	synapses := []component.SynapticProcessor{
+		synapse.NewBasicSynapse("ab", a, b, static, pruning, 0.5, 2*time.Millisecond),
+		synapse.NewBasicSynapse("bc", b, c, static, pruning, -0.25, 1500*time.Microsecond),
+		synapse.NewBasicSynapse("ca", c, a, synapse.CreateDefaultSTDPConfig(), pruning, 0.8, 200*time.Millisecond),
+	}
+	return Capture([]component.NeuralComponent{c, a, b}, synapses, DefaultCaptureDefaults())
+}
+
+// TestCaptureReadsNeuronParameters verifies parameters and features are
+// captured from live components and sorted by ID.
+func TestCaptureReadsNeuronParameters(t *testing.T) {
+	spec := testNetwork(t)
+
+	if len(spec.Neurons) != 3 || spec.Neurons[0].ID != "a" || spec.Neurons[2].ID != "c" {
+		t.Fatalf("Expected neurons sorted a, b, c, got %+v", spec.Neurons)
+	}
+	b := spec.Neurons[1]
+	if b.Threshold != 2.0 || b.DecayRate != 1.0 || b.RefractoryPeriod != 3*time.Millisecond || b.FireFactor != 2.0 {
+		t.Errorf("Unexpected parameters for b: %+v", b)
+	}
+	if _, ok := spec.Neurons[2].Features[FeatureHomeostasis]; !ok {
+		t.Error("Expected homeostasis feature on c")
+	}
+	if spec.Synapses[2].Plasticity == nil || spec.Synapses[0].Plasticity != nil {
+		t.Error("Expected plasticity only on synapse ca")
+	}
+	if err := spec.Validate(); err != nil {
+		t.Errorf("Expected valid spec, got %v", err)
+	}
+}
+
+// TestExportLava verifies fixed-point mapping, delay handling and the report.
+func TestExportLava(t *testing.T) {
+	spec := testNetwork(t)
+	spec.Synapses = append(spec.Synapses, SynapseSpec{ID: "ghost", PreID: "a", PostID: "missing", Weight: 1})
+
+	network, report, err := ExportLava(spec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Strongest effective weight is ca (0.8); bc is -0.25 × fire factor 2 = -0.5
+	if math.Abs(network.WeightScale-LOIHI_WEIGHT_MAX/0.8) > 1e-9 {
+		t.Errorf("Expected scale from strongest weight, got %f", network.WeightScale)
+	}
+	if network.Dv[0] != 410 || network.Dv[1] != 0 {
+		t.Errorf("Expected dv 410 (decay 0.9) and 0 (no leak), got %v", network.Dv[:2])
+	}
+	if network.RefractoryPeriod[1] != 3 {
+		t.Errorf("Expected 3-step refractory period, got %d", network.RefractoryPeriod[1])
+	}
+
+	connections := make(map[int]LavaConnection)
+	for _, c := range network.Connections {
+		connections[c.Pre] = c
+	}
+	if bc := connections[1]; bc.Weight != -79 || bc.Delay != 2 {
+		t.Errorf("Expected bc weight -79 and rounded delay 2, got %+v", bc)
+	}
+	if ca := connections[2]; ca.Weight != LOIHI_WEIGHT_MAX || ca.Delay != LOIHI_MAX_DELAY_STEPS {
+		t.Errorf("Expected ca at full weight and clamped delay, got %+v", ca)
+	}
+
+	counts := report.CountByFeature()
+	for _, feature := range []string{FeatureHomeostasis, FeatureSTDP, FeatureDelayRange, FeatureTimestep, FeatureUnknownEndpoint} {
+		if counts[feature] == 0 {
+			t.Errorf("Expected %s in report, got %v", feature, counts)
+		}
+	}
+	if report.Synapses != 3 || report.Dropped != 1 || report.Exact() {
+		t.Errorf("Unexpected report totals: %+v", report)
+	}
+	if !strings.Contains(report.String(), "unsupported") {
+		t.Errorf("Expected summary to list unsupported features:\n%s", report)
+	}
+
+	var buf bytes.Buffer
+	if err := network.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+	var decoded LavaNetwork
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil || len(decoded.Connections) != 3 {
+		t.Errorf("Expected round-trippable JSON, got %v", err)
+	}
+}
+
+// TestExportSpiNNaker verifies LIF conversion, receptor split and STDP mapping.
+func TestExportSpiNNaker(t *testing.T) {
+	spec := testNetwork(t)
+	network, report, err := ExportSpiNNaker(spec)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tauA := network.Parameters["tau_m"][0]
+	if math.Abs(tauA-(-1/math.Log(0.9))) > 1e-9 {
+		t.Errorf("Expected tau_m from decay 0.9, got %f", tauA)
+	}
+	if network.Parameters["tau_m"][1] != SPINNAKER_MAX_TAU_M_MS {
+		t.Errorf("Expected non-leaky stand-in tau_m, got %f", network.Parameters["tau_m"][1])
+	}
+
+	var inhibitory, plastic *PyNNProjection
+	for i := range network.Projections {
+		p := &network.Projections[i]
+		if p.Receptor == "inhibitory" {
+			inhibitory = p
+		}
+		if p.Plasticity != nil {
+			plastic = p
+		}
+	}
+	if inhibitory == nil || inhibitory.Connections[0][2] != 0.5 {
+		t.Fatalf("Expected inhibitory projection with magnitude 0.5, got %+v", inhibitory)
+	}
+	if plastic == nil || plastic.Connections[0][3] != 144 {
+		t.Fatalf("Expected plastic projection with 144ms clamped delay, got %+v", plastic)
+	}
+	cfg := synapse.CreateDefaultSTDPConfig()
+	if plastic.Plasticity.AMinus != cfg.LearningRate*cfg.AsymmetryRatio {
+		t.Errorf("Expected A_minus from asymmetry ratio, got %f", plastic.Plasticity.AMinus)
+	}
+
+	for _, issue := range report.Unsupported() {
+		if issue.Feature == FeatureSTDP {
+			t.Error("STDP should be approximated, not unsupported, on SpiNNaker")
+		}
+	}
+	if report.CountByFeature()[FeatureHomeostasis] != 1 {
+		t.Errorf("Expected homeostasis reported once, got %v", report.CountByFeature())
+	}
+
+	if _, _, err := ExportSpiNNaker(&NetworkSpec{}); err == nil {
+		t.Error("Expected error for empty network")
+	}
+}
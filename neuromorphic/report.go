@@ -0,0 +1,201 @@
+package neuromorphic
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =================================================================================
+// COMPATIBILITY REPORT
+// =================================================================================
+
+// Severity classifies how a feature was handled by an exporter.
+type Severity string
+
+const (
+	// SeverityApproximated marks features mapped with a loss of precision
+	// (quantised weights, rounded or clamped delays, model substitutions).
+	SeverityApproximated Severity = "approximated"
+	// SeverityUnsupported marks features the target cannot represent; they are
+	// dropped from the export.
+	SeverityUnsupported Severity = "unsupported"
+)
+
+// Issue records one feature of one component that did not map exactly.
+type Issue struct {
+	Severity    Severity `json:"severity"`
+	Feature     string   `json:"feature"`
+	ComponentID string   `json:"component_id"`
+	Detail      string   `json:"detail"`
+}
+
+// CompatibilityReport lists everything an export could not reproduce exactly.
+type CompatibilityReport struct {
+	Target   string  `json:"target"`
+	Neurons  int     `json:"neurons"`
+	Synapses int     `json:"synapses"` // Synapses included in the export
+	Dropped  int     `json:"dropped"`  // Synapses left out of the export
+	Issues   []Issue `json:"issues"`
+}
+
+// add records an issue.
+func (r *CompatibilityReport) add(severity Severity, feature, componentID, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, Issue{
+		Severity:    severity,
+		Feature:     feature,
+		ComponentID: componentID,
+		Detail:      fmt.Sprintf(format, args...),
+	})
+}
+
+// Exact reports whether the export reproduces the network without changes.
+func (r *CompatibilityReport) Exact() bool {
+	return len(r.Issues) == 0
+}
+
+// Unsupported returns the issues whose features were dropped.
+func (r *CompatibilityReport) Unsupported() []Issue {
+	var out []Issue
+	for _, issue := range r.Issues {
+		if issue.Severity == SeverityUnsupported {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+// CountByFeature returns the number of issues per feature.
+func (r *CompatibilityReport) CountByFeature() map[string]int {
+	counts := make(map[string]int)
+	for _, issue := range r.Issues {
+		counts[issue.Feature]++
+	}
+	return counts
+}
+
+// String summarises the report, one line per feature and severity.
+func (r *CompatibilityReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s export: %d neurons, %d synapses (%d dropped)\n", r.Target, r.Neurons, r.Synapses, r.Dropped)
+	if r.Exact() {
+		b.WriteString("  all features supported exactly\n")
+		return b.String()
+	}
+
+	type key struct {
+		severity Severity
+		feature  string
+	}
+	groups := make(map[key][]Issue)
+	for _, issue := range r.Issues {
+		k := key{issue.Severity, issue.Feature}
+		groups[k] = append(groups[k], issue)
+	}
+	keys := make([]key, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].severity != keys[j].severity {
+			return keys[i].severity > keys[j].severity // unsupported first
+		}
+		return keys[i].feature < keys[j].feature
+	})
+	for _, k := range keys {
+		issues := groups[k]
+		fmt.Fprintf(&b, "  %-12s %-20s %4d component(s), e.g. %s: %s\n",
+			k.severity, k.feature, len(issues), issues[0].ComponentID, issues[0].Detail)
+	}
+	return b.String()
+}
+
+// reportNeuronFeatures records the neuron features no exporter maps.
+func reportNeuronFeatures(r *CompatibilityReport, n NeuronSpec) {
+	if detail, ok := n.Features[FeatureHomeostasis]; ok {
+		r.add(SeverityUnsupported, FeatureHomeostasis, n.ID, "threshold adaptation (%s) dropped; threshold frozen at %g", detail, n.Threshold)
+	}
+	if detail, ok := n.Features[FeatureDendriticMode]; ok {
+		r.add(SeverityUnsupported, FeatureDendriticMode, n.ID, "%s integration replaced by point-neuron summation", detail)
+	}
+	if detail, ok := n.Features[FeatureChemical]; ok {
+		r.add(SeverityUnsupported, FeatureChemical, n.ID, "%s dropped; no neuromodulator channel", detail)
+	}
+}
+
+// =================================================================================
+// SHARED TRANSLATION HELPERS
+// =================================================================================
+
+// PRECISION_TOLERANCE is the relative quantisation error reported as lossy.
+const PRECISION_TOLERANCE = 0.01
+
+// resolvedSynapse is a synapse whose endpoints exist in the spec.
+type resolvedSynapse struct {
+	spec      SynapseSpec
+	pre, post int
+	weight    float64 // Weight with the presynaptic fire factor folded in
+}
+
+// resolveSynapses maps synapse endpoints to neuron indices, dropping synapses
+// to neurons outside the spec. Fire factors scale the spike amplitude, which
+// is equivalent to scaling every outgoing weight.
+func resolveSynapses(spec *NetworkSpec, index map[string]int, r *CompatibilityReport) []resolvedSynapse {
+	out := make([]resolvedSynapse, 0, len(spec.Synapses))
+	for _, s := range spec.Synapses {
+		pre, okPre := index[s.PreID]
+		post, okPost := index[s.PostID]
+		if !okPre || !okPost {
+			r.add(SeverityUnsupported, FeatureUnknownEndpoint, s.ID,
+				"connects %s → %s, which is outside the exported neurons", s.PreID, s.PostID)
+			r.Dropped++
+			continue
+		}
+		out = append(out, resolvedSynapse{
+			spec:   s,
+			pre:    pre,
+			post:   post,
+			weight: s.Weight * spec.Neurons[pre].FireFactor,
+		})
+	}
+	return out
+}
+
+// timestepsFor converts d to whole time steps of length step, at least min.
+func timestepsFor(d, step time.Duration, min int, r *CompatibilityReport, id, what string) int {
+	steps := int(math.Round(float64(d) / float64(step)))
+	if d%step != 0 {
+		r.add(SeverityApproximated, FeatureTimestep, id, "%s %v rounded to %d × %v", what, d, steps, step)
+	}
+	if steps < min {
+		r.add(SeverityApproximated, FeatureTimestep, id, "%s %v raised to the minimum of %d step(s)", what, d, min)
+		steps = min
+	}
+	return steps
+}
+
+// precisionTracker finds the worst relative quantisation error.
+type precisionTracker struct {
+	worstID    string
+	worstError float64
+}
+
+// observe records one original/quantised pair.
+func (p *precisionTracker) observe(id string, original, quantised float64) {
+	if original == 0 {
+		return
+	}
+	if e := math.Abs(quantised-original) / math.Abs(original); e > p.worstError {
+		p.worstID, p.worstError = id, e
+	}
+}
+
+// report adds an issue when the worst error exceeds PRECISION_TOLERANCE.
+func (p *precisionTracker) report(r *CompatibilityReport, what string) {
+	if p.worstError > PRECISION_TOLERANCE {
+		r.add(SeverityApproximated, FeatureWeightPrecision, p.worstID,
+			"%s quantisation error up to %.1f%%", what, p.worstError*100)
+	}
+}
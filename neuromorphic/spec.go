@@ -0,0 +1,230 @@
+/*
+=================================================================================
+NEUROMORPHIC EXPORT - HARDWARE-NEUTRAL NETWORK SPECIFICATION
+=================================================================================
+
+Neuromorphic chips implement a fixed neuron model with discrete time steps and
+quantised parameters. Exporting a temporal-neuron network therefore happens in
+two stages:
+
+ 1. Capture: live neurons and synapses are read into a NetworkSpec, a plain
+    description of thresholds, membrane decay, refractory periods, weights,
+    delays and the optional features each component uses.
+ 2. Translate: a target exporter (Lava for Intel Loihi, PyNN for SpiNNaker)
+    maps the spec onto the target's neuron model and fixed-point ranges, and
+    records every feature it cannot represent in a CompatibilityReport.
+
+The threshold neuron maps naturally onto a current-based leaky integrate-and-
+fire (LIF) model: inputs add to the membrane, the membrane decays by DecayRate
+every millisecond, and crossing Threshold emits a spike followed by a reset
+and the refractory period.
+=================================================================================
+*/
+
+package neuromorphic
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// Feature names recorded on components and used in compatibility reports.
+const (
+	FeatureHomeostasis     = "homeostasis"
+	FeatureDendriticMode   = "dendritic_mode"
+	FeatureChemical        = "chemical_signaling"
+	FeatureSTDP            = "stdp"
+	FeatureFireFactor      = "fire_factor"
+	FeatureUnknownEndpoint = "unknown_endpoint"
+	FeatureWeightPrecision = "weight_precision"
+	FeatureTimestep        = "timestep_resolution"
+	FeatureDelayRange      = "delay_range"
+)
+
+// NeuronSpec describes one threshold neuron.
+type NeuronSpec struct {
+	ID               string            `json:"id"`
+	Threshold        float64           `json:"threshold"`
+	DecayRate        float64           `json:"decay_rate"` // Membrane factor applied every millisecond
+	RefractoryPeriod time.Duration     `json:"refractory_period"`
+	FireFactor       float64           `json:"fire_factor"`
+	Features         map[string]string `json:"features,omitempty"` // Optional features in use, with detail
+}
+
+// SynapseSpec describes one weighted, delayed connection.
+type SynapseSpec struct {
+	ID         string                  `json:"id"`
+	PreID      string                  `json:"pre_id"`
+	PostID     string                  `json:"post_id"`
+	Weight     float64                 `json:"weight"`
+	Delay      time.Duration           `json:"delay"`
+	Plasticity *types.PlasticityConfig `json:"plasticity,omitempty"` // Nil for static synapses
+	Features   map[string]string       `json:"features,omitempty"`
+}
+
+// NetworkSpec is a hardware-neutral snapshot of a network.
+type NetworkSpec struct {
+	Neurons  []NeuronSpec  `json:"neurons"`
+	Synapses []SynapseSpec `json:"synapses"`
+}
+
+// neuronIndex returns neuron positions by ID.
+func (s *NetworkSpec) neuronIndex() map[string]int {
+	index := make(map[string]int, len(s.Neurons))
+	for i, n := range s.Neurons {
+		index[n.ID] = i
+	}
+	return index
+}
+
+// Validate checks the spec for values no target can represent.
+func (s *NetworkSpec) Validate() error {
+	if len(s.Neurons) == 0 {
+		return fmt.Errorf("network has no neurons")
+	}
+	seen := make(map[string]bool, len(s.Neurons))
+	for _, n := range s.Neurons {
+		if seen[n.ID] {
+			return fmt.Errorf("duplicate neuron ID %s", n.ID)
+		}
+		seen[n.ID] = true
+		if n.Threshold <= 0 {
+			return fmt.Errorf("neuron %s has non-positive threshold %f", n.ID, n.Threshold)
+		}
+		if n.DecayRate < 0 || n.DecayRate > 1 {
+			return fmt.Errorf("neuron %s has decay rate %f outside [0, 1]", n.ID, n.DecayRate)
+		}
+	}
+	for _, syn := range s.Synapses {
+		if syn.Delay < 0 {
+			return fmt.Errorf("synapse %s has negative delay", syn.ID)
+		}
+	}
+	return nil
+}
+
+// =================================================================================
+// CAPTURE FROM LIVE COMPONENTS
+// =================================================================================
+
+// Optional accessors probed on neurons. neuron.Neuron implements all of them;
+// other components fall back to CaptureDefaults.
+type (
+	thresholdSource   interface{ GetThreshold() float64 }
+	decaySource       interface{ GetDecayRate() float64 }
+	refractorySource  interface{ GetRefractoryPeriod() time.Duration }
+	fireFactorSource  interface{ GetFireFactor() float64 }
+	homeostasisSource interface{ GetHomeostasisStrength() float64 }
+	dendriteSource    interface {
+		GetDendriticMode() neuron.DendriticIntegrationMode
+	}
+	receptorSource interface{ GetReceptors() []types.LigandType }
+	ligandSource   interface{ GetReleasedLigands() []types.LigandType }
+)
+
+// CaptureDefaults supplies parameters for neurons that do not expose them.
+type CaptureDefaults struct {
+	Threshold        float64
+	DecayRate        float64
+	RefractoryPeriod time.Duration
+}
+
+// DefaultCaptureDefaults returns the parameters of a typical cortical neuron.
+func DefaultCaptureDefaults() CaptureDefaults {
+	return CaptureDefaults{Threshold: 1.0, DecayRate: 0.95, RefractoryPeriod: 2 * time.Millisecond}
+}
+
+// Capture builds a NetworkSpec from live components. Neurons and synapses are
+// sorted by ID so exports are reproducible.
+func Capture(neurons []component.NeuralComponent, synapses []component.SynapticProcessor, defaults CaptureDefaults) *NetworkSpec {
+	spec := &NetworkSpec{
+		Neurons:  make([]NeuronSpec, 0, len(neurons)),
+		Synapses: make([]SynapseSpec, 0, len(synapses)),
+	}
+
+	for _, n := range neurons {
+		ns := NeuronSpec{
+			ID:               n.ID(),
+			Threshold:        defaults.Threshold,
+			DecayRate:        defaults.DecayRate,
+			RefractoryPeriod: defaults.RefractoryPeriod,
+			FireFactor:       1.0,
+			Features:         make(map[string]string),
+		}
+		if src, ok := n.(thresholdSource); ok {
+			ns.Threshold = src.GetThreshold()
+		}
+		if src, ok := n.(decaySource); ok {
+			ns.DecayRate = src.GetDecayRate()
+		}
+		if src, ok := n.(refractorySource); ok {
+			ns.RefractoryPeriod = src.GetRefractoryPeriod()
+		}
+		if src, ok := n.(fireFactorSource); ok {
+			ns.FireFactor = src.GetFireFactor()
+		}
+		if ns.FireFactor != 1.0 {
+			ns.Features[FeatureFireFactor] = fmt.Sprintf("%g", ns.FireFactor)
+		}
+		if src, ok := n.(homeostasisSource); ok && src.GetHomeostasisStrength() > 0 {
+			ns.Features[FeatureHomeostasis] = fmt.Sprintf("strength %g", src.GetHomeostasisStrength())
+		}
+		if name := dendriticModeName(n); name != "" && name != "PassiveMembrane" {
+			ns.Features[FeatureDendriticMode] = name
+		}
+		if count := chemicalBindingCount(n); count > 0 {
+			ns.Features[FeatureChemical] = fmt.Sprintf("%d ligand bindings", count)
+		}
+		spec.Neurons = append(spec.Neurons, ns)
+	}
+
+	for _, s := range synapses {
+		ss := SynapseSpec{
+			ID:       s.ID(),
+			PreID:    s.GetPresynapticID(),
+			PostID:   s.GetPostsynapticID(),
+			Weight:   s.GetWeight(),
+			Delay:    s.GetDelay(),
+			Features: make(map[string]string),
+		}
+		if cfg := s.GetPlasticityConfig(); cfg.Enabled {
+			ss.Plasticity = &cfg
+			ss.Features[FeatureSTDP] = fmt.Sprintf("rate %g, tau %v", cfg.LearningRate, cfg.TimeConstant)
+		}
+		spec.Synapses = append(spec.Synapses, ss)
+	}
+
+	sort.Slice(spec.Neurons, func(i, j int) bool { return spec.Neurons[i].ID < spec.Neurons[j].ID })
+	sort.Slice(spec.Synapses, func(i, j int) bool { return spec.Synapses[i].ID < spec.Synapses[j].ID })
+	return spec
+}
+
+// dendriticModeName returns the name of a non-default dendritic mode.
+func dendriticModeName(n component.NeuralComponent) string {
+	src, ok := n.(dendriteSource)
+	if !ok {
+		return ""
+	}
+	mode := src.GetDendriticMode()
+	if mode == nil {
+		return ""
+	}
+	return mode.Name()
+}
+
+// chemicalBindingCount returns the number of receptors plus released ligands.
+func chemicalBindingCount(n component.NeuralComponent) int {
+	count := 0
+	if src, ok := n.(receptorSource); ok {
+		count += len(src.GetReceptors())
+	}
+	if src, ok := n.(ligandSource); ok {
+		count += len(src.GetReleasedLigands())
+	}
+	return count
+}
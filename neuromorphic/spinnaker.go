@@ -0,0 +1,176 @@
+package neuromorphic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+)
+
+// =================================================================================
+// SPINNAKER (PyNN / sPyNNaker) EXPORT
+// =================================================================================
+
+// sPyNNaker limits for the default 1ms machine time step.
+const (
+	SPINNAKER_TIMESTEP        = 1 * time.Millisecond
+	SPINNAKER_MAX_DELAY_STEPS = 144 // Using delay extension populations
+	SPINNAKER_MAX_TAU_M_MS    = 1e4 // Stand-in for a non-leaky membrane
+	SPINNAKER_MIN_TAU_M_MS    = 0.1 // Stand-in for a membrane that resets every step
+	SPINNAKER_CELL_TYPE       = "IF_curr_delta"
+)
+
+// PyNNSTDP describes a SpikePairRule with AdditiveWeightDependence.
+type PyNNSTDP struct {
+	TauPlus  float64 `json:"tau_plus"`
+	TauMinus float64 `json:"tau_minus"`
+	APlus    float64 `json:"A_plus"`
+	AMinus   float64 `json:"A_minus"`
+	WMin     float64 `json:"w_min"`
+	WMax     float64 `json:"w_max"`
+}
+
+// PyNNProjection is a FromListConnector projection within the population.
+// Connections are (pre index, post index, weight, delay ms); weights are
+// non-negative with the sign carried by Receptor.
+type PyNNProjection struct {
+	Receptor    string       `json:"receptor_type"`
+	Connections [][4]float64 `json:"connections"`
+	Plasticity  *PyNNSTDP    `json:"plasticity,omitempty"`
+}
+
+// PyNNNetwork describes one population with per-neuron parameter arrays and
+// its recurrent projections, ready for sPyNNaker.
+type PyNNNetwork struct {
+	Target      string               `json:"target"`
+	TimestepMs  float64              `json:"timestep_ms"`
+	CellType    string               `json:"cell_type"`
+	NeuronIDs   []string             `json:"neuron_ids"`
+	Parameters  map[string][]float64 `json:"parameters"`
+	Projections []PyNNProjection     `json:"projections"`
+}
+
+// WriteJSON writes the network description.
+func (n *PyNNNetwork) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(n)
+}
+
+// ExportSpiNNaker maps spec onto IF_curr_delta neurons, whose instantaneous
+// membrane jumps match the threshold neuron's input summation. The per-step
+// decay factor becomes the membrane time constant tau_m = -dt / ln(decay).
+func ExportSpiNNaker(spec *NetworkSpec) (*PyNNNetwork, *CompatibilityReport, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid network: %w", err)
+	}
+
+	report := &CompatibilityReport{Target: "spinnaker-pynn", Neurons: len(spec.Neurons)}
+	count := len(spec.Neurons)
+	network := &PyNNNetwork{
+		Target:     report.Target,
+		TimestepMs: float64(SPINNAKER_TIMESTEP) / float64(time.Millisecond),
+		CellType:   SPINNAKER_CELL_TYPE,
+		NeuronIDs:  make([]string, count),
+		Parameters: map[string][]float64{
+			"tau_m":      make([]float64, count),
+			"v_thresh":   make([]float64, count),
+			"v_rest":     make([]float64, count),
+			"v_reset":    make([]float64, count),
+			"tau_refrac": make([]float64, count),
+			"cm":         make([]float64, count),
+		},
+	}
+
+	stepMs := network.TimestepMs
+	for i, n := range spec.Neurons {
+		network.NeuronIDs[i] = n.ID
+		network.Parameters["tau_m"][i] = membraneTimeConstant(n, stepMs, report)
+		network.Parameters["v_thresh"][i] = n.Threshold
+		network.Parameters["cm"][i] = 1.0
+		network.Parameters["tau_refrac"][i] = float64(timestepsFor(n.RefractoryPeriod, SPINNAKER_TIMESTEP, 0, report, n.ID, "refractory period")) * stepMs
+		reportNeuronFeatures(report, n)
+	}
+
+	// Group synapses into projections by receptor and plasticity rule
+	groups := make(map[string]*PyNNProjection)
+	var keys []string
+	for _, s := range resolveSynapses(spec, spec.neuronIndex(), report) {
+		delay := timestepsFor(s.spec.Delay, SPINNAKER_TIMESTEP, 1, report, s.spec.ID, "delay")
+		if delay > SPINNAKER_MAX_DELAY_STEPS {
+			report.add(SeverityApproximated, FeatureDelayRange, s.spec.ID,
+				"delay of %d steps clamped to %d", delay, SPINNAKER_MAX_DELAY_STEPS)
+			delay = SPINNAKER_MAX_DELAY_STEPS
+		}
+
+		receptor := "excitatory"
+		if s.weight < 0 {
+			receptor = "inhibitory"
+		}
+		stdp := pairRuleFor(s.spec)
+		key := receptor
+		if stdp != nil {
+			key = fmt.Sprintf("%s/%+v", receptor, *stdp)
+		}
+		projection, ok := groups[key]
+		if !ok {
+			projection = &PyNNProjection{Receptor: receptor, Plasticity: stdp}
+			groups[key] = projection
+			keys = append(keys, key)
+			if stdp != nil {
+				report.add(SeverityApproximated, FeatureSTDP, s.spec.ID,
+					"mapped to pair-based SpikePairRule; window cutoff %v and neuromodulated learning not represented",
+					s.spec.Plasticity.WindowSize)
+			}
+		}
+		projection.Connections = append(projection.Connections,
+			[4]float64{float64(s.pre), float64(s.post), math.Abs(s.weight), float64(delay) * stepMs})
+		report.Synapses++
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		network.Projections = append(network.Projections, *groups[key])
+	}
+	return network, report, nil
+}
+
+// membraneTimeConstant converts a per-step decay factor into tau_m (ms).
+func membraneTimeConstant(n NeuronSpec, stepMs float64, r *CompatibilityReport) float64 {
+	switch {
+	case n.DecayRate >= 1:
+		r.add(SeverityApproximated, FeatureTimestep, n.ID,
+			"non-leaky membrane approximated with tau_m %gms", SPINNAKER_MAX_TAU_M_MS)
+		return SPINNAKER_MAX_TAU_M_MS
+	case n.DecayRate <= 0:
+		r.add(SeverityApproximated, FeatureTimestep, n.ID,
+			"memoryless membrane approximated with tau_m %gms", SPINNAKER_MIN_TAU_M_MS)
+		return SPINNAKER_MIN_TAU_M_MS
+	}
+	tau := -stepMs / math.Log(n.DecayRate)
+	return math.Max(SPINNAKER_MIN_TAU_M_MS, math.Min(SPINNAKER_MAX_TAU_M_MS, tau))
+}
+
+// pairRuleFor translates a synapse's STDP configuration. The LTD amplitude is
+// the learning rate times the asymmetry ratio, as in the synapse package.
+func pairRuleFor(s SynapseSpec) *PyNNSTDP {
+	if s.Plasticity == nil {
+		return nil
+	}
+	cfg := s.Plasticity
+	tau := float64(cfg.TimeConstant) / float64(time.Millisecond)
+	wMin, wMax := math.Abs(cfg.MinWeight), math.Abs(cfg.MaxWeight)
+	if wMin > wMax {
+		wMin, wMax = wMax, wMin
+	}
+	return &PyNNSTDP{
+		TauPlus:  tau,
+		TauMinus: tau,
+		APlus:    cfg.LearningRate,
+		AMinus:   cfg.LearningRate * cfg.AsymmetryRatio,
+		WMin:     wMin,
+		WMax:     wMax,
+	}
+}
@@ -0,0 +1,393 @@
+package neuron
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// ADAPTIVE INPUT BUFFER
+// =================================================================================
+//
+// The input channel's capacity is fixed when the neuron is created, and a Go
+// channel cannot be resized. A neuron sized for its average input rate loses
+// the peaks of bursty input, counted only in its priority drops. The
+// adaptive buffer puts a growable overflow queue behind the channel: input
+// that finds the channel full is queued there, and the processing loop moves
+// it into the channel as slots free up, so the order of arrival is kept.
+//
+// The overflow capacity follows the observed load. Demand in each Window is
+// the larger of the peak occupancy plus the input that did not fit, and the
+// arrivals the buffer must hold at the observed rate for Horizon:
+//
+//   - demand above GrowAt of the capacity grows it to at least twice its
+//     size; an arrival that finds the buffer full grows it at once
+//   - demand below ShrinkAt for ShrinkAfter consecutive windows halves it
+//
+// The gap between GrowAt and ShrinkAt and the run of quiet windows are the
+// hysteresis that keeps the capacity from oscillating around a boundary.
+// The capacity never exceeds MaxCapacity and never falls below the
+// channel's own. Windows are evaluated as input arrives and is processed.
+// The overflow queue releases its memory whenever it drains.
+//
+// Each resize is logged as a diagnostic at Info level and passed to
+// OnResize, so a workload that keeps growing the buffer is visible before
+// it reaches MaxCapacity and starts dropping again. Critical input keeps its
+// own lane (see priority.go) and does not use the overflow queue.
+
+// AdaptiveBufferConfig configures the adaptive input buffer. Zero fields
+// use the ADAPTIVE_BUFFER_DEFAULT_* values.
+type AdaptiveBufferConfig struct {
+	Enabled     bool
+	MaxCapacity int           // Largest total capacity, channel included (0 = default factor × channel capacity)
+	Window      time.Duration // Arrival rate observation window
+	Horizon     time.Duration // Burst at the observed rate the buffer must hold
+	GrowAt      float64       // Share of capacity demand must exceed to grow, in (0, 1]
+	ShrinkAt    float64       // Share of capacity demand must stay below to shrink, below GrowAt
+	ShrinkAfter int           // Consecutive quiet windows before shrinking
+
+	// OnResize is called after each resize on the goroutine that caused it
+	// (a sender or the processing loop). It must not block.
+	OnResize func(event BufferResizeEvent)
+}
+
+// BufferResizeEvent describes one change of the input buffer's capacity.
+type BufferResizeEvent struct {
+	NeuronID string    `json:"neuron_id"`
+	Time     time.Time `json:"time"`
+	From     int       `json:"from"`   // Total capacity before
+	To       int       `json:"to"`     // Total capacity after
+	Rate     float64   `json:"rate"`   // Observed arrival rate (messages/s)
+	Demand   int       `json:"demand"` // Slots the observed load needed
+}
+
+// AdaptiveBufferStats reports the state of the adaptive input buffer.
+type AdaptiveBufferStats struct {
+	Enabled  bool    `json:"enabled"`
+	Capacity int     `json:"capacity"` // Total capacity, channel included
+	Queued   int     `json:"queued"`   // Messages waiting in the overflow queue
+	Rate     float64 `json:"rate"`     // Arrival rate over the last complete window (messages/s)
+	Grows    int64   `json:"grows"`
+	Shrinks  int64   `json:"shrinks"`
+	Dropped  int64   `json:"dropped"` // Arrivals that found the buffer full at MaxCapacity
+}
+
+// adaptiveBuffer is the overflow queue and its sizing state.
+type adaptiveBuffer struct {
+	config AdaptiveBufferConfig
+	base   int // Capacity of the input channel
+
+	mu      sync.Mutex
+	retired bool // Replaced by SetAdaptiveInputBuffer; senders reload
+	limit   int  // Overflow capacity
+	queue   []types.NeuralSignal
+	head    int
+
+	windowStart time.Time
+	arrivals    int
+	peak        int
+	overflowed  int
+	quiet       int
+	stats       AdaptiveBufferStats
+}
+
+// SetAdaptiveInputBuffer enables, reconfigures or (with Enabled false)
+// disables the adaptive input buffer. Reconfiguring keeps the queued input.
+// Disabling moves what fits into the input channel and drops the rest,
+// counted in the priority drops.
+func (n *Neuron) SetAdaptiveInputBuffer(config AdaptiveBufferConfig) error {
+	base := cap(n.inputBuffer)
+	var next *adaptiveBuffer
+	if config.Enabled {
+		if err := applyAdaptiveBufferDefaults(&config, base); err != nil {
+			return err
+		}
+		next = &adaptiveBuffer{config: config, base: base}
+		next.stats.Enabled = true
+		next.stats.Capacity = base
+	}
+
+	previous := n.adaptiveBuffer.Load()
+	if previous == nil {
+		n.adaptiveBuffer.Store(next)
+		return nil
+	}
+
+	// Retire the previous queue under its lock so that no sender appends
+	// to it after its input has been handed over
+	previous.mu.Lock()
+	previous.retired = true
+	pending := previous.queue[previous.head:]
+	previous.queue, previous.head = nil, 0
+	if next != nil {
+		next.queue = append(next.queue, pending...)
+		next.limit = max(min(previous.limit, next.config.MaxCapacity-base), len(pending))
+		next.stats.Capacity = base + next.limit
+		pending = nil
+	}
+	n.adaptiveBuffer.Store(next)
+	previous.mu.Unlock()
+
+	for _, msg := range pending {
+		select {
+		case n.inputBuffer <- msg:
+		default:
+			n.recordPriorityDrop(msg.Priority)
+		}
+	}
+	n.refillInputBuffer()
+	return nil
+}
+
+// GetAdaptiveInputBuffer returns the configuration and whether the adaptive
+// input buffer is enabled.
+func (n *Neuron) GetAdaptiveInputBuffer() (AdaptiveBufferConfig, bool) {
+	buffer := n.adaptiveBuffer.Load()
+	if buffer == nil {
+		return AdaptiveBufferConfig{}, false
+	}
+	return buffer.config, true
+}
+
+// GetAdaptiveBufferStats returns the adaptive buffer's state (zero when it
+// is disabled).
+func (n *Neuron) GetAdaptiveBufferStats() AdaptiveBufferStats {
+	buffer := n.adaptiveBuffer.Load()
+	if buffer == nil {
+		return AdaptiveBufferStats{}
+	}
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	stats := buffer.stats
+	stats.Queued = len(buffer.queue) - buffer.head
+	return stats
+}
+
+// applyAdaptiveBufferDefaults fills zero fields and validates the result.
+func applyAdaptiveBufferDefaults(config *AdaptiveBufferConfig, base int) error {
+	if config.MaxCapacity == 0 {
+		config.MaxCapacity = ADAPTIVE_BUFFER_DEFAULT_MAX_FACTOR * base
+	}
+	if config.Window == 0 {
+		config.Window = ADAPTIVE_BUFFER_DEFAULT_WINDOW
+	}
+	if config.Horizon == 0 {
+		config.Horizon = ADAPTIVE_BUFFER_DEFAULT_HORIZON
+	}
+	if config.GrowAt == 0 {
+		config.GrowAt = ADAPTIVE_BUFFER_DEFAULT_GROW_AT
+	}
+	if config.ShrinkAt == 0 {
+		config.ShrinkAt = ADAPTIVE_BUFFER_DEFAULT_SHRINK_AT
+	}
+	if config.ShrinkAfter == 0 {
+		config.ShrinkAfter = ADAPTIVE_BUFFER_DEFAULT_SHRINK_AFTER
+	}
+
+	switch {
+	case config.MaxCapacity < base:
+		return fmt.Errorf("adaptive buffer max capacity %d is below the input channel capacity %d", config.MaxCapacity, base)
+	case config.Window < 0 || config.Horizon < 0:
+		return fmt.Errorf("adaptive buffer window and horizon must be positive: %v, %v", config.Window, config.Horizon)
+	case math.IsNaN(config.GrowAt) || config.GrowAt <= 0 || config.GrowAt > 1:
+		return fmt.Errorf("adaptive buffer grow threshold must be in (0, 1]: %f", config.GrowAt)
+	case math.IsNaN(config.ShrinkAt) || config.ShrinkAt < 0 || config.ShrinkAt >= config.GrowAt:
+		return fmt.Errorf("adaptive buffer shrink threshold must be in [0, %f): %f", config.GrowAt, config.ShrinkAt)
+	case config.ShrinkAfter < 0:
+		return fmt.Errorf("adaptive buffer shrink delay must be positive: %d", config.ShrinkAfter)
+	}
+	return nil
+}
+
+// enqueueAdaptive queues msg on the input channel, or behind it in the
+// overflow queue, and reports whether it was accepted.
+func (n *Neuron) enqueueAdaptive(msg types.NeuralSignal) bool {
+	for {
+		buffer := n.adaptiveBuffer.Load()
+		if buffer == nil {
+			select {
+			case n.inputBuffer <- msg:
+				return true
+			default:
+				return false
+			}
+		}
+
+		now := n.now()
+		buffer.mu.Lock()
+		if buffer.retired {
+			buffer.mu.Unlock()
+			continue
+		}
+		event := buffer.observeUnsafe(now, len(n.inputBuffer))
+		buffer.arrivals++
+
+		accepted := false
+		if buffer.head == len(buffer.queue) {
+			select {
+			case n.inputBuffer <- msg:
+				accepted = true
+			default:
+			}
+		}
+		if !accepted {
+			if len(buffer.queue)-buffer.head >= buffer.limit {
+				// A full buffer grows at once rather than waiting for the
+				// window to end
+				demand := len(n.inputBuffer) + buffer.limit + 1
+				if grown := buffer.growUnsafe(now, demand); grown != nil {
+					event = grown
+				}
+			}
+			if len(buffer.queue)-buffer.head < buffer.limit {
+				buffer.queue = append(buffer.queue, msg)
+				accepted = true
+			} else {
+				buffer.overflowed++
+				buffer.stats.Dropped++
+			}
+		}
+		if occupancy := len(n.inputBuffer) + len(buffer.queue) - buffer.head; occupancy > buffer.peak {
+			buffer.peak = occupancy
+		}
+		buffer.mu.Unlock()
+
+		n.emitBufferResize(buffer, event)
+		return accepted
+	}
+}
+
+// refillInputBuffer moves queued overflow into the input channel as far as
+// it has room. The processing loop calls it after taking input.
+func (n *Neuron) refillInputBuffer() {
+	buffer := n.adaptiveBuffer.Load()
+	if buffer == nil {
+		return
+	}
+	buffer.mu.Lock()
+	if buffer.retired {
+		buffer.mu.Unlock()
+		return
+	}
+refill:
+	for buffer.head < len(buffer.queue) {
+		select {
+		case n.inputBuffer <- buffer.queue[buffer.head]:
+			buffer.queue[buffer.head] = types.NeuralSignal{}
+			buffer.head++
+		default:
+			break refill
+		}
+	}
+	if buffer.head == len(buffer.queue) {
+		buffer.queue, buffer.head = nil, 0
+	}
+	event := buffer.observeUnsafe(n.now(), len(n.inputBuffer))
+	buffer.mu.Unlock()
+
+	n.emitBufferResize(buffer, event)
+}
+
+// adaptiveOccupancy returns the fill of channel and overflow queue
+// together, and false when the adaptive buffer is disabled.
+func (n *Neuron) adaptiveOccupancy() (float64, bool) {
+	buffer := n.adaptiveBuffer.Load()
+	if buffer == nil {
+		return 0, false
+	}
+	buffer.mu.Lock()
+	defer buffer.mu.Unlock()
+	queued := len(n.inputBuffer) + len(buffer.queue) - buffer.head
+	return float64(queued) / float64(buffer.base+buffer.limit), true
+}
+
+// observeUnsafe closes the observation window if it has ended and resizes
+// for its demand. occupancy is the current fill of the input channel. This
+// method must be called with mu held.
+func (b *adaptiveBuffer) observeUnsafe(now time.Time, occupancy int) *BufferResizeEvent {
+	if b.windowStart.IsZero() {
+		b.windowStart = now
+		return nil
+	}
+	elapsed := now.Sub(b.windowStart)
+	if elapsed < b.config.Window {
+		return nil
+	}
+
+	rate := float64(b.arrivals) / elapsed.Seconds()
+	demand := max(b.peak+b.overflowed, int(math.Ceil(rate*b.config.Horizon.Seconds())))
+	occupancy += len(b.queue) - b.head
+	b.stats.Rate = rate
+	b.windowStart, b.arrivals, b.peak, b.overflowed = now, 0, occupancy, 0
+
+	capacity := b.base + b.limit
+	switch {
+	case float64(demand) > b.config.GrowAt*float64(capacity):
+		b.quiet = 0
+		return b.growUnsafe(now, demand)
+	case float64(demand) < b.config.ShrinkAt*float64(capacity):
+		b.quiet++
+		if b.quiet < b.config.ShrinkAfter {
+			return nil
+		}
+		b.quiet = 0
+		target := max(capacity/2, b.base, occupancy, b.neededUnsafe(demand))
+		return b.resizeUnsafe(now, min(target, capacity), demand)
+	default:
+		b.quiet = 0
+		return nil
+	}
+}
+
+// growUnsafe grows the capacity to at least twice its size and enough for
+// demand. This method must be called with mu held.
+func (b *adaptiveBuffer) growUnsafe(now time.Time, demand int) *BufferResizeEvent {
+	capacity := b.base + b.limit
+	return b.resizeUnsafe(now, max(2*capacity, b.neededUnsafe(demand)), demand)
+}
+
+// neededUnsafe returns the capacity at which demand sits at the grow
+// threshold.
+func (b *adaptiveBuffer) neededUnsafe(demand int) int {
+	return int(math.Ceil(float64(demand) / b.config.GrowAt))
+}
+
+// resizeUnsafe sets the total capacity, bounded by MaxCapacity, and returns
+// the event (nil if the capacity is unchanged). This method must be called
+// with mu held.
+func (b *adaptiveBuffer) resizeUnsafe(now time.Time, target, demand int) *BufferResizeEvent {
+	target = min(target, b.config.MaxCapacity)
+	from := b.base + b.limit
+	if target == from {
+		return nil
+	}
+	if target > from {
+		b.stats.Grows++
+	} else {
+		b.stats.Shrinks++
+	}
+	b.limit = target - b.base
+	b.stats.Capacity = target
+	return &BufferResizeEvent{Time: now, From: from, To: target, Rate: b.stats.Rate, Demand: demand}
+}
+
+// emitBufferResize logs event and passes it to the OnResize handler.
+func (n *Neuron) emitBufferResize(buffer *adaptiveBuffer, event *BufferResizeEvent) {
+	if event == nil {
+		return
+	}
+	event.NeuronID = n.ID()
+	if n.logEnabled(slog.LevelInfo) {
+		n.logf(slog.LevelInfo, logging.RecordDiagnostic, "input buffer resized",
+			"from", event.From, "to", event.To, "rate", event.Rate, "demand", event.Demand)
+	}
+	if buffer.config.OnResize != nil {
+		buffer.config.OnResize(*event)
+	}
+}
@@ -0,0 +1,26 @@
+package neuron
+
+import (
+	"github.com/SynapticNetworks/temporal-neuron/affinity"
+)
+
+// =================================================================================
+// THREAD AFFINITY
+// =================================================================================
+//
+// On multi-socket machines, neurons that exchange many spikes should run on
+// the same NUMA node. SetAffinity starts the neuron's processing goroutine
+// through an affinity.Group, which locks it to an OS thread restricted to
+// the group's CPUs. The pinned thread is held for the neuron's lifetime.
+// The setting takes effect at the next Start; a running neuron is not moved.
+
+// SetAffinity pins the processing goroutine to group from the next Start
+// (nil = let the Go scheduler place it).
+func (n *Neuron) SetAffinity(group *affinity.Group) {
+	n.affinity.Store(group)
+}
+
+// GetAffinity returns the group the neuron is pinned to (nil = unpinned).
+func (n *Neuron) GetAffinity() *affinity.Group {
+	return n.affinity.Load()
+}
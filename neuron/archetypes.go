@@ -0,0 +1,248 @@
+package neuron
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// NEURON ARCHETYPE LIBRARY
+// =================================================================================
+//
+// The presets in options.go give plausible parameters for broad cell classes.
+// An archetype goes further: its parameters are calibrated so that the f-I
+// response of the model matches published electrophysiology of one cell
+// type, and the targets it was calibrated against travel with it:
+//
+//   - RateAtTwiceRheobase is the firing rate for a 500ms current step at
+//     twice the rheobase - the usual way datasets compare f-I curves across
+//     cells of different input resistance.
+//   - Adaptation is the last interspike interval divided by the first in the
+//     same step (1 = regular, >1 = adapting).
+//
+// The model is a leaky integrator, so amplitudes are in model units and only
+// relative rheobases carry over: PV basket cells need about 2.5 times the
+// drive of L2/3 pyramidal cells, Martinotti cells about half. Adaptation
+// comes from a homeostatic threshold with a near-zero target rate
+// (ARCHETYPE_ADAPTATION_TARGET_RATE). Validate re-runs the calibration with
+// Characterize, so a change to the neuron model that breaks an archetype
+// shows up as a failed target.
+//
+// Archetypes are selected by name: Option for NewNeuronWithOptions, Factory
+// for the matrix's RegisterNeuronType, after which populations request them
+// through types.NeuronConfig.NeuronType.
+
+// ArchetypeTargets are the published response features an archetype is
+// calibrated to, as [min, max] ranges.
+type ArchetypeTargets struct {
+	RateAtTwiceRheobase [2]float64 // Hz for a 500ms step at twice the rheobase
+	Adaptation          [2]float64 // Last / first interspike interval in that step
+}
+
+// Archetype is a neuron parameter set calibrated to a cell type.
+type Archetype struct {
+	Name        string
+	Description string
+	Reference   string // Source of the targets
+	Config      NeuronConfig
+	Targets     ArchetypeTargets
+}
+
+// archetypes is the curated library, keyed by name.
+var archetypes = map[string]Archetype{
+	"l23_pyramidal": {
+		Name:        "l23_pyramidal",
+		Description: "Layer 2/3 regular-spiking pyramidal cell: moderate rates, clear adaptation",
+		Reference:   "Connors & Gutnick 1990; Gouwens et al. 2019 (Allen Cell Types)",
+		Config: NeuronConfig{
+			Threshold:           1.0,
+			DecayRate:           0.97,
+			RefractoryPeriod:    3 * time.Millisecond,
+			FireFactor:          EXCITATORY_FIRE_FACTOR_DEFAULT,
+			TargetFiringRate:    ARCHETYPE_ADAPTATION_TARGET_RATE,
+			HomeostasisStrength: 0.2,
+			ReleasedLigands:     []types.LigandType{types.LigandGlutamate},
+			Receptors:           []types.LigandType{types.LigandGlutamate, types.LigandGABA, types.LigandDopamine},
+		},
+		Targets: ArchetypeTargets{RateAtTwiceRheobase: [2]float64{15, 40}, Adaptation: [2]float64{1.5, 3.5}},
+	},
+	"pv_basket": {
+		Name:        "pv_basket",
+		Description: "Parvalbumin-positive fast-spiking basket cell: high rheobase, steep f-I curve, no adaptation",
+		Reference:   "Kawaguchi 1995; Gouwens et al. 2019 (Allen Cell Types)",
+		Config: NeuronConfig{
+			Threshold:        0.5,
+			DecayRate:        0.85,
+			RefractoryPeriod: 1 * time.Millisecond,
+			FireFactor:       INHIBITORY_FIRE_FACTOR_DEFAULT,
+			ReleasedLigands:  []types.LigandType{types.LigandGABA},
+			Receptors:        []types.LigandType{types.LigandGlutamate, types.LigandGABA, types.LigandSerotonin},
+		},
+		Targets: ArchetypeTargets{RateAtTwiceRheobase: [2]float64{100, 250}, Adaptation: [2]float64{0.8, 1.3}},
+	},
+	"sst_martinotti": {
+		Name:        "sst_martinotti",
+		Description: "Somatostatin-positive Martinotti cell: low rheobase, low rates, strong adaptation",
+		Reference:   "Kawaguchi & Kubota 1997; Silberberg & Markram 2007",
+		Config: NeuronConfig{
+			Threshold:           1.0,
+			DecayRate:           0.985,
+			RefractoryPeriod:    3 * time.Millisecond,
+			FireFactor:          INHIBITORY_FIRE_FACTOR_DEFAULT,
+			TargetFiringRate:    ARCHETYPE_ADAPTATION_TARGET_RATE,
+			HomeostasisStrength: 0.4,
+			ReleasedLigands:     []types.LigandType{types.LigandGABA},
+			Receptors:           []types.LigandType{types.LigandGlutamate, types.LigandGABA, types.LigandAcetylcholine},
+		},
+		Targets: ArchetypeTargets{RateAtTwiceRheobase: [2]float64{8, 30}, Adaptation: [2]float64{1.5, 4}},
+	},
+	"thalamic_relay": {
+		Name:        "thalamic_relay",
+		Description: "Thalamocortical relay cell in tonic mode: faithful relay with weak adaptation",
+		Reference:   "Jahnsen & Llinás 1984; McCormick & Huguenard 1992",
+		Config: NeuronConfig{
+			Threshold:           1.0,
+			DecayRate:           0.96,
+			RefractoryPeriod:    3 * time.Millisecond,
+			FireFactor:          EXCITATORY_FIRE_FACTOR_DEFAULT,
+			TargetFiringRate:    ARCHETYPE_ADAPTATION_TARGET_RATE,
+			HomeostasisStrength: 0.05,
+			ReleasedLigands:     []types.LigandType{types.LigandGlutamate},
+			Receptors:           []types.LigandType{types.LigandGlutamate, types.LigandGABA, types.LigandAcetylcholine},
+		},
+		Targets: ArchetypeTargets{RateAtTwiceRheobase: [2]float64{30, 80}, Adaptation: [2]float64{1.0, 1.8}},
+	},
+}
+
+// archetypeProbes are the step amplitudes of a validation, as multiples of
+// the predicted rheobase.
+var archetypeProbes = []float64{0.8, 1.5, 2, 3}
+
+// Archetypes returns the library sorted by name.
+func Archetypes() []Archetype {
+	names := make([]string, 0, len(archetypes))
+	for name := range archetypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	library := make([]Archetype, len(names))
+	for i, name := range names {
+		library[i], _ = LookupArchetype(name)
+	}
+	return library
+}
+
+// LookupArchetype returns the archetype with the given name.
+func LookupArchetype(name string) (Archetype, error) {
+	archetype, ok := archetypes[name]
+	if !ok {
+		return Archetype{}, fmt.Errorf("unknown neuron archetype: %q", name)
+	}
+	archetype.Config.ReleasedLigands = append([]types.LigandType(nil), archetype.Config.ReleasedLigands...)
+	archetype.Config.Receptors = append([]types.LigandType(nil), archetype.Config.Receptors...)
+	return archetype, nil
+}
+
+// Option configures a neuron built by NewNeuronWithOptions as the
+// archetype. Like the presets, it should come before other options.
+func (a Archetype) Option() NeuronOption {
+	return func(c *NeuronConfig) {
+		c.Threshold = a.Config.Threshold
+		c.DecayRate = a.Config.DecayRate
+		c.RefractoryPeriod = a.Config.RefractoryPeriod
+		c.FireFactor = a.Config.FireFactor
+		c.TargetFiringRate = a.Config.TargetFiringRate
+		c.HomeostasisStrength = a.Config.HomeostasisStrength
+		c.ReleasedLigands = append([]types.LigandType(nil), a.Config.ReleasedLigands...)
+		c.Receptors = append([]types.LigandType(nil), a.Config.Receptors...)
+		WithMetadata("archetype", a.Name)(c)
+	}
+}
+
+// Factory returns a matrix neuron factory for the archetype, for
+// registration under its name with RegisterNeuronType. The archetype's
+// parameters replace the requested ones; position, metadata and chemistry
+// set in the request are kept.
+func (a Archetype) Factory() func(id string, config types.NeuronConfig, callbacks component.NeuronCallbacks) (component.NeuralComponent, error) {
+	return func(id string, request types.NeuronConfig, callbacks component.NeuronCallbacks) (component.NeuralComponent, error) {
+		var config NeuronConfig
+		a.Option()(&config)
+		config.Position = request.Position
+		if len(request.ReleasedLigands) > 0 {
+			config.ReleasedLigands = request.ReleasedLigands
+		}
+		if len(request.Receptors) > 0 {
+			config.Receptors = request.Receptors
+		}
+		for key, value := range request.Metadata {
+			WithMetadata(key, value)(&config)
+		}
+		return CallbackNeuronFactory(id, config, callbacks)
+	}
+}
+
+// ArchetypeValidation is the outcome of checking an archetype against its
+// targets.
+type ArchetypeValidation struct {
+	Archetype           string
+	Characterization    *Characterization
+	RateAtTwiceRheobase float64
+	Adaptation          float64
+	Failures            []string // Empty when every target is met
+}
+
+// Passed reports whether every target was met.
+func (v *ArchetypeValidation) Passed() bool {
+	return len(v.Failures) == 0
+}
+
+// Validate characterizes the archetype with steps around its predicted
+// rheobase and checks the response against the targets. A validation takes
+// about CHARACTERIZATION_STEP_DEFAULT of wall-clock time.
+func (a Archetype) Validate() (*ArchetypeValidation, error) {
+	rheobase := a.Config.Threshold * (1 - a.Config.DecayRate)
+	amplitudes := make([]float64, len(archetypeProbes))
+	for i, probe := range archetypeProbes {
+		amplitudes[i] = probe * rheobase
+	}
+	result, err := Characterize(func(id string) (*Neuron, error) {
+		return NewNeuronWithOptions(id, a.Option())
+	}, CharacterizationConfig{Amplitudes: amplitudes})
+	if err != nil {
+		return nil, fmt.Errorf("characterizing %s: %w", a.Name, err)
+	}
+
+	validation := &ArchetypeValidation{Archetype: a.Name, Characterization: result}
+	subthreshold, twice := result.Points[0], result.Points[2]
+	validation.RateAtTwiceRheobase = twice.Rate
+	validation.Adaptation = twice.Adaptation
+	if subthreshold.Spikes > 0 {
+		validation.Failures = append(validation.Failures,
+			fmt.Sprintf("fired %d spikes below the predicted rheobase", subthreshold.Spikes))
+	}
+	for i := 1; i < len(result.Points); i++ {
+		if result.Points[i].Rate < result.Points[i-1].Rate {
+			validation.Failures = append(validation.Failures,
+				fmt.Sprintf("rate falls from %.1f to %.1f Hz as drive increases", result.Points[i-1].Rate, result.Points[i].Rate))
+		}
+	}
+	if !inArchetypeRange(twice.Rate, a.Targets.RateAtTwiceRheobase) {
+		validation.Failures = append(validation.Failures,
+			fmt.Sprintf("rate at twice rheobase %.1f Hz outside %v", twice.Rate, a.Targets.RateAtTwiceRheobase))
+	}
+	if !inArchetypeRange(twice.Adaptation, a.Targets.Adaptation) {
+		validation.Failures = append(validation.Failures,
+			fmt.Sprintf("adaptation %.2f outside %v", twice.Adaptation, a.Targets.Adaptation))
+	}
+	return validation, nil
+}
+
+// inArchetypeRange reports whether v lies in [bounds[0], bounds[1]].
+func inArchetypeRange(v float64, bounds [2]float64) bool {
+	return !math.IsNaN(v) && v >= bounds[0] && v <= bounds[1]
+}
@@ -0,0 +1,77 @@
+package neuron
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestArchetypes_MatchPublishedTargets verifies that every archetype in the
+// library still meets its calibration targets.
+func TestArchetypes_MatchPublishedTargets(t *testing.T) {
+	library := Archetypes()
+	if len(library) != 4 || library[0].Name != "l23_pyramidal" {
+		t.Fatalf("Expected 4 archetypes sorted by name, got %d", len(library))
+	}
+
+	// Validations run on the wall clock, so run them side by side
+	var wg sync.WaitGroup
+	results := make([]*ArchetypeValidation, len(library))
+	errs := make([]error, len(library))
+	for i, archetype := range library {
+		wg.Add(1)
+		go func(i int, archetype Archetype) {
+			defer wg.Done()
+			results[i], errs[i] = archetype.Validate()
+		}(i, archetype)
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		if errs[i] != nil {
+			t.Fatalf("Unexpected error for %s: %v", library[i].Name, errs[i])
+		}
+		if !result.Passed() {
+			t.Errorf("%s misses its targets: %v", result.Archetype, result.Failures)
+		}
+	}
+	// Fast-spiking cells fire far faster than adapting cells at equal relative drive
+	if results[1].RateAtTwiceRheobase < 2*results[0].RateAtTwiceRheobase {
+		t.Errorf("Expected pv_basket much faster than l23_pyramidal, got %.1f and %.1f Hz",
+			results[1].RateAtTwiceRheobase, results[0].RateAtTwiceRheobase)
+	}
+}
+
+// TestArchetypes_SelectByName verifies lookup, the construction option and
+// the matrix factory.
+func TestArchetypes_SelectByName(t *testing.T) {
+	if _, err := LookupArchetype("purkinje"); err == nil {
+		t.Error("Expected error for unknown archetype")
+	}
+	pv, err := LookupArchetype("pv_basket")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	n, err := NewNeuronWithOptions("pv_1", pv.Option())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n.GetThreshold() != 0.5 || n.GetDecayRate() != 0.85 || n.GetMetadata()["archetype"] != "pv_basket" {
+		t.Errorf("Expected pv_basket parameters, got threshold %f decay %f", n.GetThreshold(), n.GetDecayRate())
+	}
+
+	position := types.Position3D{X: 10, Y: 20}
+	built, err := pv.Factory()("pv_2", types.NeuronConfig{
+		NeuronType: "pv_basket", Threshold: 3.0, Position: position, RefractoryPeriod: time.Second,
+	}, NewMockNeuronCallbacks(NewMockMatrix()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cell := built.(*Neuron)
+	if cell.GetThreshold() != 0.5 || cell.Position() != position {
+		t.Errorf("Expected archetype parameters at the requested position, got threshold %f at %+v", cell.GetThreshold(), cell.Position())
+	}
+}
@@ -31,10 +31,16 @@ type delayedMessage struct {
 //	target: The post-synaptic neuron to receive the types.
 //	delay: Total delay including synaptic and spatial components.
 func ScheduleDelayedDelivery(deliveryQueue chan<- delayedMessage, msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration) {
+	scheduleDeliveryAt(deliveryQueue, msg, target, time.Now().Add(delay))
+}
+
+// scheduleDeliveryAt queues a message for delivery at an absolute time, so
+// a neuron on a virtual clock (see stepping.go) can schedule against it.
+func scheduleDeliveryAt(deliveryQueue chan<- delayedMessage, msg types.NeuralSignal, target component.MessageReceiver, deliveryTime time.Time) {
 	delayedMsg := delayedMessage{
 		message:      msg,
 		target:       target,
-		deliveryTime: time.Now().Add(delay),
+		deliveryTime: deliveryTime,
 	}
 
 	// Attempt to queue for axonal delivery (non-blocking).
@@ -85,7 +91,9 @@ drainLoop:
 
 	// Sort pending deliveries by delivery time for efficient processing.
 	// This allows delivering ready messages sequentially and breaking early.
-	sort.Slice(pending, func(i, j int) bool {
+	// The sort is stable, so messages due at the same time keep their
+	// scheduling order.
+	sort.SliceStable(pending, func(i, j int) bool {
 		return pending[i].deliveryTime.Before(pending[j].deliveryTime)
 	})
 
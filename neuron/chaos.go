@@ -0,0 +1,96 @@
+package neuron
+
+import (
+	"time"
+)
+
+// =================================================================================
+// CHAOS TESTING HOOKS
+// =================================================================================
+//
+// The race detector finds unsynchronized memory access, but not logic that
+// only holds under the scheduling a test happens to get: a spike that
+// always arrives after the refractory check on an idle machine, or two
+// deliveries that always land in send order. A ChaosInjector perturbs a
+// neuron to widen the schedules a test explores (see the chaos package):
+//
+//   - Pause is called at the scheduling points of the processing loop, with
+//     no locks held, and may sleep or yield
+//   - Jitter adds delay to axonal deliveries, so spikes to different
+//     targets, or successive spikes, can arrive in another order. Deliveries
+//     are only ever later than their configured delay, and neurons with FIFO
+//     delivery (see ordering.go) are not reordered
+//
+// Without an injector these hooks cost one atomic load.
+
+// ChaosPoint identifies a scheduling point where a neuron may be paused.
+type ChaosPoint int
+
+const (
+	ChaosBeforeIntegration ChaosPoint = iota // Input taken from the buffer, not yet integrated
+	ChaosBeforeDelivery                      // Due axonal deliveries about to be sent
+)
+
+// String returns the point name.
+func (p ChaosPoint) String() string {
+	switch p {
+	case ChaosBeforeIntegration:
+		return "before_integration"
+	case ChaosBeforeDelivery:
+		return "before_delivery"
+	default:
+		return "unknown"
+	}
+}
+
+// ChaosInjector perturbs a neuron's scheduling for concurrency testing.
+type ChaosInjector interface {
+	// Pause is called at a scheduling point. It must return eventually.
+	Pause(neuronID string, point ChaosPoint)
+
+	// Jitter returns extra delay (>= 0) for a delivery to targetID
+	// scheduled with the given axonal delay.
+	Jitter(neuronID, targetID string, delay time.Duration) time.Duration
+}
+
+// chaosRef boxes an injector for atomic storage.
+type chaosRef struct {
+	injector ChaosInjector
+}
+
+// SetChaos installs a chaos injector (nil removes it).
+func (n *Neuron) SetChaos(injector ChaosInjector) {
+	if injector == nil {
+		n.chaos.Store(nil)
+		return
+	}
+	n.chaos.Store(&chaosRef{injector: injector})
+}
+
+// GetChaos returns the installed chaos injector, or nil.
+func (n *Neuron) GetChaos() ChaosInjector {
+	if ref := n.chaos.Load(); ref != nil {
+		return ref.injector
+	}
+	return nil
+}
+
+// chaosPause pauses at point if an injector is installed. It must be called
+// without locks held.
+func (n *Neuron) chaosPause(point ChaosPoint) {
+	if ref := n.chaos.Load(); ref != nil {
+		ref.injector.Pause(n.ID(), point)
+	}
+}
+
+// chaosDelay returns delay plus the injector's jitter for target.
+func (n *Neuron) chaosDelay(targetID string, delay time.Duration) time.Duration {
+	ref := n.chaos.Load()
+	if ref == nil {
+		return delay
+	}
+	if jitter := ref.injector.Jitter(n.ID(), targetID, delay); jitter > 0 {
+		return delay + jitter
+	}
+	return delay
+}
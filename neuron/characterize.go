@@ -0,0 +1,267 @@
+package neuron
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// =================================================================================
+// F-I CURVE AND RHEOBASE CHARACTERIZATION
+// =================================================================================
+//
+// Cell classes are told apart by their response to current steps: regular
+// spiking pyramidal cells fire slowly and adapt, fast-spiking interneurons
+// fire at high rates with a steep f-I curve and little adaptation, and
+// delayed-firing cells respond with a long first-spike latency. Characterize
+// runs the standard protocol on a neuron configuration so it can be compared
+// against such classes:
+//
+//   - For every amplitude a fresh neuron is built, started and current-clamped
+//     (see clamp.go) for StepDuration. All steps run concurrently in wall-clock
+//     time, so a sweep takes about one StepDuration regardless of its length.
+//   - Each point reports the spike count, the firing rate, the first-spike
+//     latency from step onset, and the mean, coefficient of variation and
+//     adaptation of the interspike intervals.
+//   - The rheobase is bracketed by the largest silent and the smallest firing
+//     amplitude of the sweep, and the f-I gain is the least-squares slope of
+//     rate over amplitude above it.
+//
+// Goroutine neurons run on wall-clock tickers, so counts vary slightly with
+// host load. Undefined values (no spike, fewer than two intervals) are NaN.
+
+// CellFactory builds a fresh neuron for one characterization step.
+type CellFactory func(id string) (*Neuron, error)
+
+// CharacterizationConfig describes an f-I sweep.
+type CharacterizationConfig struct {
+	Amplitudes   []float64     // Clamp amplitudes (input per decay tick), any order
+	StepDuration time.Duration // Length of each current step (0 = CHARACTERIZATION_STEP_DEFAULT)
+}
+
+// FIPoint is the response to one current step.
+type FIPoint struct {
+	Amplitude  float64         `json:"amplitude"`
+	Spikes     int             `json:"spikes"`
+	Rate       float64         `json:"rate_hz"`
+	Latency    time.Duration   `json:"latency"`     // First spike after step onset (0 = no spike)
+	MeanISI    time.Duration   `json:"mean_isi"`    // Mean interspike interval (0 = fewer than two spikes)
+	ISICV      float64         `json:"isi_cv"`      // Coefficient of variation of the intervals (NaN = undefined)
+	Adaptation float64         `json:"adaptation"`  // Last interval / first interval (NaN = undefined, >1 = adapting)
+	SpikeTimes []time.Duration `json:"spike_times"` // Spike times relative to step onset
+}
+
+// Characterization is the result of an f-I sweep.
+type Characterization struct {
+	Points            []FIPoint     `json:"points"`             // Sorted by amplitude
+	Rheobase          float64       `json:"rheobase"`           // Smallest firing amplitude (NaN = never fired)
+	RheobaseLower     float64       `json:"rheobase_lower"`     // Largest silent amplitude below it (NaN = none)
+	PredictedRheobase float64       `json:"predicted_rheobase"` // threshold·(1-decay) of the unmodulated cell
+	Gain              float64       `json:"gain"`               // f-I slope above rheobase in Hz per unit (NaN = undefined)
+	StepDuration      time.Duration `json:"step_duration"`
+}
+
+// Characterize measures the f-I curve, rheobase and spike latencies of the
+// neurons built by factory.
+func Characterize(factory CellFactory, config CharacterizationConfig) (*Characterization, error) {
+	if factory == nil {
+		return nil, fmt.Errorf("characterization needs a cell factory")
+	}
+	if len(config.Amplitudes) == 0 {
+		return nil, fmt.Errorf("characterization needs at least one amplitude")
+	}
+	if config.StepDuration < 0 {
+		return nil, fmt.Errorf("step duration cannot be negative: %v", config.StepDuration)
+	}
+	if config.StepDuration == 0 {
+		config.StepDuration = CHARACTERIZATION_STEP_DEFAULT
+	}
+
+	for _, amplitude := range config.Amplitudes {
+		if math.IsNaN(amplitude) || math.IsInf(amplitude, 0) {
+			return nil, fmt.Errorf("clamp amplitude must be finite: %f", amplitude)
+		}
+	}
+
+	amplitudes := append([]float64(nil), config.Amplitudes...)
+	sort.Float64s(amplitudes)
+
+	cells := make([]*Neuron, len(amplitudes))
+	for i, amplitude := range amplitudes {
+		cell, err := factory(fmt.Sprintf("fi_%d", i))
+		if err != nil {
+			return nil, fmt.Errorf("building cell for amplitude %g: %w", amplitude, err)
+		}
+		cells[i] = cell
+	}
+
+	result := &Characterization{
+		Points:            make([]FIPoint, len(amplitudes)),
+		PredictedRheobase: cells[0].GetThreshold() * (1 - cells[0].GetDecayRate()),
+		StepDuration:      config.StepDuration,
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(cells))
+	for i := range cells {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			result.Points[i], errs[i] = runCurrentStep(cells[i], amplitudes[i], config.StepDuration)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result.Rheobase, result.RheobaseLower = math.NaN(), math.NaN()
+	for _, point := range result.Points {
+		if point.Spikes > 0 {
+			result.Rheobase = point.Amplitude
+			break
+		}
+		result.RheobaseLower = point.Amplitude
+	}
+	result.Gain = fiGain(result.Points)
+	return result, nil
+}
+
+// runCurrentStep clamps a fresh neuron for one step and analyses its spikes.
+func runCurrentStep(cell *Neuron, amplitude float64, duration time.Duration) (FIPoint, error) {
+	if err := cell.Start(); err != nil {
+		return FIPoint{}, err
+	}
+	defer cell.Stop()
+
+	onset := time.Now()
+	if err := cell.ClampCurrent(amplitude, duration); err != nil {
+		return FIPoint{}, err
+	}
+	time.Sleep(duration)
+	cell.ReleaseClamp()
+	end := time.Now()
+
+	cell.activityMutex.RLock()
+	var spikes []time.Duration
+	for _, at := range cell.homeostatic.firingHistory {
+		if !at.Before(onset) && !at.After(end) {
+			spikes = append(spikes, at.Sub(onset))
+		}
+	}
+	cell.activityMutex.RUnlock()
+
+	point := FIPoint{
+		Amplitude:  amplitude,
+		Spikes:     len(spikes),
+		Rate:       float64(len(spikes)) / duration.Seconds(),
+		ISICV:      math.NaN(),
+		Adaptation: math.NaN(),
+		SpikeTimes: spikes,
+	}
+	if len(spikes) > 0 {
+		point.Latency = spikes[0]
+	}
+	if len(spikes) > 1 {
+		intervals := make([]float64, len(spikes)-1)
+		mean := 0.0
+		for i := range intervals {
+			intervals[i] = float64(spikes[i+1] - spikes[i])
+			mean += intervals[i] / float64(len(intervals))
+		}
+		variance := 0.0
+		for _, isi := range intervals {
+			variance += (isi - mean) * (isi - mean) / float64(len(intervals))
+		}
+		point.MeanISI = time.Duration(mean)
+		if mean > 0 {
+			point.ISICV = math.Sqrt(variance) / mean
+		}
+		if intervals[0] > 0 {
+			point.Adaptation = intervals[len(intervals)-1] / intervals[0]
+		}
+	}
+	return point, nil
+}
+
+// fiGain returns the least-squares slope of rate over amplitude for the
+// firing points, or NaN with fewer than two.
+func fiGain(points []FIPoint) float64 {
+	var xs, ys []float64
+	for _, point := range points {
+		if point.Spikes > 0 {
+			xs = append(xs, point.Amplitude)
+			ys = append(ys, point.Rate)
+		}
+	}
+	if len(xs) < 2 {
+		return math.NaN()
+	}
+	meanX, meanY := 0.0, 0.0
+	for i := range xs {
+		meanX += xs[i] / float64(len(xs))
+		meanY += ys[i] / float64(len(ys))
+	}
+	covariance, variance := 0.0, 0.0
+	for i := range xs {
+		covariance += (xs[i] - meanX) * (ys[i] - meanY)
+		variance += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+	if variance == 0 {
+		return math.NaN()
+	}
+	return covariance / variance
+}
+
+// WriteCSV writes the f-I curve, one row per amplitude. Undefined values are
+// written as NA.
+func (c *Characterization) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	header := []string{"amplitude", "spikes", "rate_hz", "latency_ms", "mean_isi_ms", "isi_cv", "adaptation"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, point := range c.Points {
+		latency, meanISI := math.NaN(), math.NaN()
+		if point.Spikes > 0 {
+			latency = durationMilliseconds(point.Latency)
+		}
+		if point.Spikes > 1 {
+			meanISI = durationMilliseconds(point.MeanISI)
+		}
+		record := []string{
+			formatCharacterizationValue(point.Amplitude),
+			strconv.Itoa(point.Spikes),
+			formatCharacterizationValue(point.Rate),
+			formatCharacterizationValue(latency),
+			formatCharacterizationValue(meanISI),
+			formatCharacterizationValue(point.ISICV),
+			formatCharacterizationValue(point.Adaptation),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// durationMilliseconds converts d to fractional milliseconds.
+func durationMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// formatCharacterizationValue formats a CSV cell, writing NaN as NA.
+func formatCharacterizationValue(v float64) string {
+	if math.IsNaN(v) {
+		return "NA"
+	}
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
@@ -0,0 +1,97 @@
+package neuron
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCell builds a plain integrate-and-fire cell: threshold 1, decay 0.9,
+// rheobase 0.1.
+func testCell(id string) (*Neuron, error) {
+	return NewNeuron(id, 1.0, 0.9, 2*time.Millisecond, 1.0, 0, 0), nil
+}
+
+// TestCharacterize_FICurveAndRheobase verifies that a sweep brackets the
+// rheobase, that rate rises and latency falls with the amplitude, and that
+// the f-I gain is positive.
+func TestCharacterize_FICurveAndRheobase(t *testing.T) {
+	result, err := Characterize(testCell, CharacterizationConfig{
+		Amplitudes:   []float64{0.5, 0.05, 0.2},
+		StepDuration: 200 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result.Points) != 3 || result.Points[0].Amplitude != 0.05 || result.Points[2].Amplitude != 0.5 {
+		t.Fatalf("Expected points sorted by amplitude, got %+v", result.Points)
+	}
+	silent, slow, fast := result.Points[0], result.Points[1], result.Points[2]
+	if silent.Spikes != 0 || slow.Spikes == 0 || fast.Rate <= slow.Rate {
+		t.Errorf("Expected a rising f-I curve, got rates %f %f %f", silent.Rate, slow.Rate, fast.Rate)
+	}
+	if result.Rheobase != 0.2 || result.RheobaseLower != 0.05 {
+		t.Errorf("Expected rheobase bracketed by (0.05, 0.2], got (%f, %f]", result.RheobaseLower, result.Rheobase)
+	}
+	if math.Abs(result.PredictedRheobase-0.1) > 1e-9 {
+		t.Errorf("Expected predicted rheobase 0.1, got %f", result.PredictedRheobase)
+	}
+	if fast.Latency <= 0 || fast.Latency >= slow.Latency {
+		t.Errorf("Expected shorter latency at higher drive, got %v and %v", slow.Latency, fast.Latency)
+	}
+	if !(result.Gain > 0) {
+		t.Errorf("Expected positive f-I gain, got %f", result.Gain)
+	}
+	// A leaky integrator without adaptation fires regularly
+	if slow.MeanISI <= 0 || slow.ISICV > 0.5 || !math.IsNaN(silent.ISICV) {
+		t.Errorf("Expected regular firing statistics, got %+v / %+v", slow, silent)
+	}
+}
+
+// TestCharacterize_ValidationAndCSV verifies configuration errors, factory
+// error propagation and the CSV export.
+func TestCharacterize_ValidationAndCSV(t *testing.T) {
+	for _, config := range []CharacterizationConfig{
+		{},
+		{Amplitudes: []float64{0.2}, StepDuration: -time.Millisecond},
+		{Amplitudes: []float64{math.NaN()}},
+	} {
+		if _, err := Characterize(testCell, config); err == nil {
+			t.Errorf("Expected error for config %+v", config)
+		}
+	}
+	if _, err := Characterize(nil, CharacterizationConfig{Amplitudes: []float64{0.2}}); err == nil {
+		t.Error("Expected error for nil factory")
+	}
+	broken := errors.New("broken")
+	_, err := Characterize(func(string) (*Neuron, error) { return nil, broken }, CharacterizationConfig{Amplitudes: []float64{0.2}})
+	if !errors.Is(err, broken) {
+		t.Errorf("Expected factory error, got %v", err)
+	}
+
+	result, err := Characterize(testCell, CharacterizationConfig{
+		Amplitudes:   []float64{0.0, 0.5},
+		StepDuration: 50 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := result.WriteCSV(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || lines[0] != "amplitude,spikes,rate_hz,latency_ms,mean_isi_ms,isi_cv,adaptation" {
+		t.Fatalf("Unexpected CSV:\n%s", buf.String())
+	}
+	if lines[1] != "0,0,0,NA,NA,NA,NA" {
+		t.Errorf("Expected NA for the silent step, got %q", lines[1])
+	}
+	if strings.Contains(lines[2], "NA") {
+		t.Errorf("Expected defined values for the firing step, got %q", lines[2])
+	}
+}
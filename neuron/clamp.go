@@ -0,0 +1,144 @@
+package neuron
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// =================================================================================
+// CURRENT AND VOLTAGE CLAMP
+// =================================================================================
+//
+// Electrophysiologists characterise a cell by taking control of one side of
+// its membrane equation and measuring the other:
+//
+//   - Current clamp injects a constant current and records the spikes it
+//     evokes. Sweeping the amplitude gives the f-I curve (firing rate against
+//     input current). ClampCurrent adds a fixed amount to the accumulator on
+//     every decay tick (1ms) for the given duration, on top of synaptic input.
+//     The membrane settles where decay balances the drive, at amplitude/(1-r)
+//     for decay rate r, so the rheobase (the smallest amplitude that fires the
+//     neuron) is threshold·(1-r).
+//   - Voltage clamp holds the membrane at a command potential and records the
+//     current needed to keep it there. ClampAccumulator pins the accumulator
+//     at a value: after every input and every decay tick it is reset to the
+//     holding value, and the correction is summed in ClampState.Charge. The
+//     neuron does not fire while voltage-clamped, even when holding above
+//     threshold, just as a clamped cell cannot produce an action potential.
+//
+// Only one clamp is active at a time; a new clamp replaces the previous one.
+// ReleaseClamp removes it and leaves the accumulator where it is.
+
+// ClampMode identifies the active clamp.
+type ClampMode int
+
+const (
+	// ClampNone means the neuron is not clamped.
+	ClampNone ClampMode = iota
+
+	// ClampModeCurrent injects a constant current.
+	ClampModeCurrent
+
+	// ClampModeVoltage holds the accumulator at a fixed value.
+	ClampModeVoltage
+)
+
+// String returns the mode name.
+func (m ClampMode) String() string {
+	switch m {
+	case ClampNone:
+		return "none"
+	case ClampModeCurrent:
+		return "current"
+	case ClampModeVoltage:
+		return "voltage"
+	default:
+		return fmt.Sprintf("ClampMode(%d)", int(m))
+	}
+}
+
+// ClampState reports the active clamp.
+type ClampState struct {
+	Mode      ClampMode `json:"mode"`
+	Amplitude float64   `json:"amplitude"` // Current clamp: input per decay tick
+	Until     time.Time `json:"until"`     // Current clamp: end of the injection
+	Holding   float64   `json:"holding"`   // Voltage clamp: command accumulator value
+	Charge    float64   `json:"charge"`    // Total input added by the clamp (negative = removed)
+}
+
+// ClampCurrent injects amplitude into the accumulator on every decay tick
+// for the given duration.
+func (n *Neuron) ClampCurrent(amplitude float64, duration time.Duration) error {
+	if math.IsNaN(amplitude) || math.IsInf(amplitude, 0) {
+		return fmt.Errorf("clamp amplitude must be finite: %f", amplitude)
+	}
+	if duration <= 0 {
+		return fmt.Errorf("clamp duration must be positive: %v", duration)
+	}
+
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	n.clamp = &ClampState{
+		Mode:      ClampModeCurrent,
+		Amplitude: amplitude,
+		Until:     n.now().Add(duration),
+	}
+	return nil
+}
+
+// ClampAccumulator holds the accumulator at value until ReleaseClamp.
+func (n *Neuron) ClampAccumulator(value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return fmt.Errorf("clamp holding value must be finite: %f", value)
+	}
+
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	n.clamp = &ClampState{Mode: ClampModeVoltage, Holding: value}
+	n.holdClampUnsafe()
+	return nil
+}
+
+// ReleaseClamp removes any clamp. Read GetClampState first for the charge.
+func (n *Neuron) ReleaseClamp() {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	n.clamp = nil
+}
+
+// GetClampState returns the active clamp (Mode ClampNone when unclamped).
+func (n *Neuron) GetClampState() ClampState {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	if n.clamp == nil {
+		return ClampState{}
+	}
+	return *n.clamp
+}
+
+// injectClampCurrentUnsafe adds one tick of clamp current. An expired
+// current clamp is removed.
+// This method must be called with stateMutex already locked.
+func (n *Neuron) injectClampCurrentUnsafe(now time.Time) {
+	if n.clamp == nil || n.clamp.Mode != ClampModeCurrent {
+		return
+	}
+	if now.After(n.clamp.Until) {
+		n.clamp = nil
+		return
+	}
+	n.clamp.Charge += n.integrateUnsafe(n.clamp.Amplitude)
+}
+
+// holdClampUnsafe resets a voltage-clamped accumulator to the holding value
+// and reports whether firing is suppressed.
+// This method must be called with stateMutex already locked.
+func (n *Neuron) holdClampUnsafe() bool {
+	if n.clamp == nil || n.clamp.Mode != ClampModeVoltage {
+		return false
+	}
+	n.clamp.Charge += n.clamp.Holding - n.accumulator
+	n.accumulator = n.clamp.Holding
+	return true
+}
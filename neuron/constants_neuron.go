@@ -148,6 +148,24 @@ const (
 
 	// INHIBITORY_FIRE_FACTOR_DEFAULT for interneuron output scaling
 	INHIBITORY_FIRE_FACTOR_DEFAULT = 1.2 // Slightly stronger to provide effective inhibition
+
+	// FAST_SPIKING_DECAY_RATE models the low membrane time constant of
+	// parvalbumin-positive basket cells (~5-10ms vs ~20ms for pyramidal cells)
+	FAST_SPIKING_DECAY_RATE = 0.90
+
+	// FAST_SPIKING_REFRACTORY_PERIOD allows sustained firing above 200 Hz
+	// Biological basis: Kv3 channels give PV interneurons very brief spikes
+	FAST_SPIKING_REFRACTORY_PERIOD = 2 * time.Millisecond
+
+	// SENSORY_THRESHOLD_DEFAULT for primary sensory neurons
+	// Biological basis: Receptor neurons respond to weak stimuli
+	SENSORY_THRESHOLD_DEFAULT = 0.6
+
+	// SENSORY_DECAY_RATE_DEFAULT keeps sensory responses transient
+	SENSORY_DECAY_RATE_DEFAULT = 0.90
+
+	// SENSORY_REFRACTORY_PERIOD_DEFAULT for rapidly adapting sensory afferents
+	SENSORY_REFRACTORY_PERIOD_DEFAULT = 3 * time.Millisecond
 )
 
 // ============================================================================
@@ -169,6 +187,10 @@ const (
 	// SENSORY_NEURON_TARGET_RATE for sensory processing neurons
 	SENSORY_NEURON_TARGET_RATE = 8.0
 
+	// FAST_SPIKING_TARGET_RATE for parvalbumin-positive interneurons
+	// Biological range: 20-100 Hz during active cortical states
+	FAST_SPIKING_TARGET_RATE = 40.0
+
 	// HOMEOSTASIS_STRENGTH_DEFAULT controls how aggressively neurons
 	// adjust their thresholds to maintain target rates
 	HOMEOSTASIS_STRENGTH_DEFAULT = 0.2
@@ -223,3 +245,166 @@ const (
 	BDNF_CONCENTRATION_SCALE  = 0.02 // μM/Hz - BDNF concentration scaling factor
 	BDNF_BASELINE_RELEASE     = 0.01 // μM - minimal BDNF concentration
 )
+
+// ============================================================================
+// HYPERPOLARIZATION CONSTANTS
+// ============================================================================
+
+const (
+	// INHIBITION_FLOOR_DEFAULT is the suggested lower bound of the accumulator
+	// relative to rest (see SetInhibitionFloor). With the default threshold of
+	// 1.0 it places the inhibitory reversal potential one threshold distance
+	// below rest, roughly GABA-A reversal (-75mV) against a -65mV rest and
+	// -55mV spike threshold.
+	INHIBITION_FLOOR_DEFAULT = -1.0
+)
+
+// ============================================================================
+// HETEROSYNAPTIC PLASTICITY CONSTANTS
+// ============================================================================
+
+const (
+	// HETEROSYNAPTIC_POTENTIATION_THRESHOLD_DEFAULT is the weight gain of a
+	// single input in one STDP feedback round that triggers depression of the
+	// neuron's other inputs.
+	HETEROSYNAPTIC_POTENTIATION_THRESHOLD_DEFAULT = 0.005
+
+	// HETEROSYNAPTIC_DEPRESSION_RATIO_DEFAULT removes half of the strong gain
+	// from the non-stimulated inputs: mild depression, not full conservation.
+	HETEROSYNAPTIC_DEPRESSION_RATIO_DEFAULT = 0.5
+)
+
+// ============================================================================
+// STATE SNAPSHOT CONSTANTS
+// ============================================================================
+
+const (
+	// NEURON_SNAPSHOT_VERSION is the layout version of NeuronSnapshot. It is
+	// increased whenever fields change meaning or are removed, so stored
+	// snapshots can be recognised.
+	NEURON_SNAPSHOT_VERSION = 1
+)
+
+// ============================================================================
+// DENDRITIC PLATEAU CONSTANTS
+// ============================================================================
+
+const (
+	// PLATEAU_THRESHOLD_DEFAULT is the local dendritic potential, in
+	// accumulator units, that counts as a plateau: twice the default firing
+	// threshold, reached only by strong clustered or sustained input.
+	PLATEAU_THRESHOLD_DEFAULT = 2.0
+
+	// PLATEAU_MIN_DURATION_DEFAULT separates plateaus from brief dendritic
+	// spikes, which last a few milliseconds.
+	PLATEAU_MIN_DURATION_DEFAULT = 20 * time.Millisecond
+
+	// PLATEAU_THRESHOLD_REDUCTION_DEFAULT lowers the firing threshold by a
+	// third during the plateau.
+	PLATEAU_THRESHOLD_REDUCTION_DEFAULT = 0.3
+
+	// PLATEAU_DURATION_DEFAULT matches the ~300ms plateaus that drive
+	// behavioral-timescale plasticity in CA1 (Bittner et al. 2017).
+	PLATEAU_DURATION_DEFAULT = 300 * time.Millisecond
+
+	// PLATEAU_TIME_CONSTANT_DEFAULT is the decay of the local dendritic
+	// potential, a cortical dendritic membrane time constant.
+	PLATEAU_TIME_CONSTANT_DEFAULT = 20 * time.Millisecond
+)
+
+// ============================================================================
+// INPUT PORT CONSTANTS
+// ============================================================================
+
+const (
+	// INPUT_PORT_APICAL_SCALE_DEFAULT attenuates apical input on its way to
+	// the soma; distal tuft input arrives at a fraction of its local size.
+	INPUT_PORT_APICAL_SCALE_DEFAULT = 0.5
+
+	// INPUT_PORT_SENSITIVITY_DEFAULT is the gain or threshold change per unit
+	// of modulatory level.
+	INPUT_PORT_SENSITIVITY_DEFAULT = 0.5
+
+	// INPUT_PORT_TIME_CONSTANT_DEFAULT is the decay of a modulatory level,
+	// the slow time course of metabotropic receptor effects.
+	INPUT_PORT_TIME_CONSTANT_DEFAULT = 200 * time.Millisecond
+
+	// INPUT_PORT_MIN_THRESHOLD_FACTOR keeps threshold ports from lowering the
+	// firing threshold below a tenth of its base value.
+	INPUT_PORT_MIN_THRESHOLD_FACTOR = 0.1
+)
+
+// ============================================================================
+// GAIN MODULATION CONSTANTS
+// ============================================================================
+
+const (
+	// GAIN_MODULATOR_TIME_CONSTANT_DEFAULT is the decay of a bound gain
+	// modulator, the slow time course of neuromodulatory gain effects.
+	GAIN_MODULATOR_TIME_CONSTANT_DEFAULT = 500 * time.Millisecond
+)
+
+// ============================================================================
+// CHARACTERIZATION CONSTANTS
+// ============================================================================
+
+const (
+	// CHARACTERIZATION_STEP_DEFAULT is the length of each current step in an
+	// f-I sweep, long enough for adaptation to show in the intervals.
+	CHARACTERIZATION_STEP_DEFAULT = 500 * time.Millisecond
+)
+
+// ============================================================================
+// INPUT PRIORITY CONSTANTS
+// ============================================================================
+
+const (
+	// INPUT_CRITICAL_BUFFER_SIZE is the capacity of the lane for critical
+	// input, which is processed ahead of the shared input buffer.
+	INPUT_CRITICAL_BUFFER_SIZE = 32
+
+	// INPUT_BACKGROUND_HEADROOM is the fill level of the input buffer above
+	// which background input is dropped, keeping the rest free for normal
+	// input.
+	INPUT_BACKGROUND_HEADROOM = 0.75
+)
+
+// ============================================================================
+// ADAPTIVE INPUT BUFFER CONSTANTS
+// ============================================================================
+
+const (
+	// ADAPTIVE_BUFFER_DEFAULT_WINDOW is the period over which the arrival
+	// rate is observed before the capacity is reconsidered.
+	ADAPTIVE_BUFFER_DEFAULT_WINDOW = 100 * time.Millisecond
+
+	// ADAPTIVE_BUFFER_DEFAULT_HORIZON is the burst, at the observed arrival
+	// rate, that the buffer must be able to hold.
+	ADAPTIVE_BUFFER_DEFAULT_HORIZON = 10 * time.Millisecond
+
+	// ADAPTIVE_BUFFER_DEFAULT_GROW_AT and ADAPTIVE_BUFFER_DEFAULT_SHRINK_AT
+	// are the shares of capacity that demand must exceed to grow, or stay
+	// below to shrink. The gap between them is the hysteresis.
+	ADAPTIVE_BUFFER_DEFAULT_GROW_AT   = 0.8
+	ADAPTIVE_BUFFER_DEFAULT_SHRINK_AT = 0.25
+
+	// ADAPTIVE_BUFFER_DEFAULT_SHRINK_AFTER is the number of consecutive
+	// quiet windows before the capacity is halved.
+	ADAPTIVE_BUFFER_DEFAULT_SHRINK_AFTER = 3
+
+	// ADAPTIVE_BUFFER_DEFAULT_MAX_FACTOR bounds the default maximum capacity
+	// as a multiple of the input channel's capacity.
+	ADAPTIVE_BUFFER_DEFAULT_MAX_FACTOR = 16
+)
+
+// ============================================================================
+// ARCHETYPE CONSTANTS
+// ============================================================================
+
+const (
+	// ARCHETYPE_ADAPTATION_TARGET_RATE is the homeostatic target rate of
+	// adapting archetypes. A target this low turns homeostasis into
+	// spike-frequency adaptation: every spike raises the threshold, and a
+	// silent cell lowers it only slowly.
+	ARCHETYPE_ADAPTATION_TARGET_RATE = 0.01
+)
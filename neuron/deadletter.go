@@ -0,0 +1,62 @@
+package neuron
+
+import (
+	"errors"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// DEAD-LETTER HANDLING FOR CLOSED NEURONS
+// =================================================================================
+//
+// Once Stop() has run, a neuron no longer processes input and its axonal
+// delivery queue is closed. Messages that still arrive - spikes in flight on
+// delayed synapses, or synapses that have not yet noticed the shutdown - are
+// rejected instead of being silently buffered or panicking on the closed
+// queue. Each rejected message is counted and, if configured, handed to a
+// dead-letter handler for logging or rerouting.
+
+// ErrNeuronClosed is the reason given for messages rejected after Stop().
+var ErrNeuronClosed = errors.New("neuron is closed")
+
+// DeadLetterHandler receives messages a closed neuron could not accept.
+// Handlers run synchronously on the sender's goroutine and must not block.
+type DeadLetterHandler func(msg types.NeuralSignal, reason error)
+
+// IsClosed reports whether Stop() has been called.
+func (n *Neuron) IsClosed() bool {
+	return n.closed.Load()
+}
+
+// SetDeadLetterHandler installs a handler for rejected messages (nil removes it).
+func (n *Neuron) SetDeadLetterHandler(handler DeadLetterHandler) {
+	if handler == nil {
+		n.deadLetterHandler.Store(nil)
+		return
+	}
+	n.deadLetterHandler.Store(&handler)
+}
+
+// GetDeadLetterCount returns the number of messages rejected since Stop().
+func (n *Neuron) GetDeadLetterCount() int64 {
+	return n.deadLetters.Load()
+}
+
+// rejectMessage records a message that arrived after shutdown.
+func (n *Neuron) rejectMessage(msg types.NeuralSignal) {
+	n.deadLetters.Add(1)
+	if handler := n.deadLetterHandler.Load(); handler != nil {
+		(*handler)(msg, ErrNeuronClosed)
+	}
+}
+
+// markClosed flags the neuron as closed. It waits for in-progress
+// ScheduleDelayedDelivery calls, so none can send on the axonal queue after
+// Stop() closes it.
+func (n *Neuron) markClosed() {
+	n.lifecycleMutex.Lock()
+	defer n.lifecycleMutex.Unlock()
+	n.closed.Store(true)
+	n.UpdateMetadata("closed_at", n.now())
+}
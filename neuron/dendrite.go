@@ -2,11 +2,13 @@ package neuron
 
 import (
 	"fmt"
+	"log/slog"
 	"math"
 	"math/rand"
 	"sync"
 	"time"
 
+	"github.com/SynapticNetworks/temporal-neuron/logging"
 	"github.com/SynapticNetworks/temporal-neuron/types"
 )
 
@@ -687,6 +689,9 @@ type BiologicalTemporalSummationMode struct {
 
 	// === ION CHANNEL CHAIN ===
 	channelChain []IonChannel // Ion channel processing pipeline
+
+	// === TIME SOURCE (wall clock unless the neuron is stepped) ===
+	clock clockSource
 }
 
 // TimestampedInput represents a synaptic input with precise temporal information.
@@ -725,7 +730,7 @@ func NewBiologicalTemporalSummationMode(config BiologicalConfig) *BiologicalTemp
 
 // Handle buffers input with timestamp for realistic temporal processing.
 func (m *BiologicalTemporalSummationMode) Handle(msg types.NeuralSignal) *IntegratedPotential {
-	now := time.Now()
+	now := m.clock.Now()
 
 	// === ION CHANNEL PROCESSING ===
 	currentMsg := &msg
@@ -791,7 +796,7 @@ func (m *BiologicalTemporalSummationMode) AddChannel(channel IonChannel) {
 // Process performs biologically realistic temporal integration with exponential decay.
 func (m *BiologicalTemporalSummationMode) Process(state MembraneSnapshot) *IntegratedPotential {
 	// Use helper method for consistent processing
-	totalExcitation, totalInhibition, channelContributions := m.processDecayedComponents(time.Now(), nil)
+	totalExcitation, totalInhibition, channelContributions := m.processDecayedComponents(m.clock.Now(), nil)
 
 	// Combine excitatory and inhibitory components
 	netCurrent := totalExcitation - totalInhibition
@@ -988,6 +993,16 @@ func (m *BiologicalTemporalSummationMode) Close() {
 	m.channelChain = nil
 }
 
+// setClock replaces the mode's time source (see stepping.go). Input buffered
+// on the old clock is discarded.
+func (m *BiologicalTemporalSummationMode) setClock(now func() time.Time) {
+	m.bufferMutex.Lock()
+	defer m.bufferMutex.Unlock()
+	m.clock.set(now)
+	m.buffer = m.buffer[:0]
+	m.lastProcessTime = m.clock.Now()
+}
+
 // ----------------------------------------------------------------------------
 // 4. ShuntingInhibitionMode (Divisive Inhibitory Effects)
 // ----------------------------------------------------------------------------
@@ -1019,7 +1034,7 @@ func NewShuntingInhibitionMode(strength float64, config BiologicalConfig) *Shunt
 // Process implements divisive inhibition on the integrated signals.
 func (m *ShuntingInhibitionMode) Process(state MembraneSnapshot) *IntegratedPotential {
 	// Get decayed components using parent method
-	totalExcitation, totalInhibition, channelContributions := m.processDecayedComponents(time.Now(), nil)
+	totalExcitation, totalInhibition, channelContributions := m.processDecayedComponents(m.clock.Now(), nil)
 
 	// Early exit if no significant input
 	if math.Abs(totalExcitation) < DENDRITE_CURRENT_NOISE_FLOOR && math.Abs(totalInhibition) < DENDRITE_CURRENT_NOISE_FLOOR {
@@ -1112,19 +1127,19 @@ func NewActiveDendriteMode(config ActiveDendriteConfig, bioConfig BiologicalConf
 		case *NMDADetectorConfig:
 			detector, err := CreateNMDACoincidenceDetector("active_dendrite_nmda", detectorConfig)
 			if err != nil {
-				fmt.Printf("Warning: Failed to create NMDA coincidence detector: %v\n", err)
+				slog.Warn("failed to create NMDA coincidence detector", logging.KeyRecord, logging.RecordDiagnostic, "error", err)
 			} else {
 				mode.coincidenceDetector = detector
 			}
 		case *SimpleTemporalDetectorConfig:
 			detector, err := CreateSimpleTemporalCoincidenceDetector("active_dendrite_simple", detectorConfig)
 			if err != nil {
-				fmt.Printf("Warning: Failed to create simple temporal coincidence detector: %v\n", err)
+				slog.Warn("failed to create simple temporal coincidence detector", logging.KeyRecord, logging.RecordDiagnostic, "error", err)
 			} else {
 				mode.coincidenceDetector = detector
 			}
 		default:
-			fmt.Printf("Warning: Unknown coincidence detector config type: %T\n", detectorConfig)
+			slog.Warn("unknown coincidence detector config type", logging.KeyRecord, logging.RecordDiagnostic, "type", fmt.Sprintf("%T", detectorConfig))
 		}
 	}
 
@@ -1140,7 +1155,7 @@ func (m *ActiveDendriteMode) Handle(msg types.NeuralSignal) *IntegratedPotential
 	if msg.Timestamp.IsZero() {
 		// If a signal has no timestamp, use the current time.
 		// This protects older tests that may not set a timestamp.
-		arrivalTime = time.Now()
+		arrivalTime = m.clock.Now()
 	} else {
 		// Otherwise, respect the timestamp from the signal.
 		arrivalTime = msg.Timestamp
@@ -1223,7 +1238,7 @@ func (m *ActiveDendriteMode) Process(state MembraneSnapshot) *IntegratedPotentia
 	}
 
 	// Step 2: Process with saturation (this will clear the buffer)
-	totalExcitation, totalInhibition, channelContributions := m.processDecayedComponents(time.Now(), saturator)
+	totalExcitation, totalInhibition, channelContributions := m.processDecayedComponents(m.clock.Now(), saturator)
 
 	// Early exit if no significant input using constant
 	if math.Abs(totalExcitation) < DENDRITE_CURRENT_NOISE_FLOOR && math.Abs(totalInhibition) < DENDRITE_CURRENT_NOISE_FLOOR {
@@ -0,0 +1,24 @@
+package neuron
+
+import (
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+)
+
+// =================================================================================
+// ENERGY ACCOUNTING
+// =================================================================================
+//
+// With an energy meter installed, each action potential is charged to the
+// neuron. Transmission and plasticity are charged by the synapses, which need
+// their own meter (see synapse.BasicSynapse.SetEnergyMeter); the matrix
+// installs one meter on all components at once.
+
+// SetEnergyMeter installs an energy meter (nil disables accounting).
+func (n *Neuron) SetEnergyMeter(meter *energy.Meter) {
+	n.energyMeter.Store(meter)
+}
+
+// GetEnergyMeter returns the neuron's energy meter, or nil.
+func (n *Neuron) GetEnergyMeter() *energy.Meter {
+	return n.energyMeter.Load()
+}
@@ -2,9 +2,11 @@ package neuron
 
 import (
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/energy"
 	"github.com/SynapticNetworks/temporal-neuron/types"
 )
 
@@ -50,8 +52,44 @@ type NeuronConfig struct {
 	EnableAutoPruning    bool          // Automatically prune dysfunctional synapses
 	PruningCheckInterval time.Duration // How often to check for pruning candidates
 
+	// Heterosynaptic depression of non-stimulated inputs
+	Heterosynaptic HeterosynapticConfig
+
+	// Hyperpolarization bound (InhibitionFloorNone = unbounded)
+	InhibitionFloorMode InhibitionFloorMode
+	InhibitionFloor     float64 // Floor or reversal potential relative to rest (negative)
+
+	// Handling of input during the refractory period
+	RefractoryInputPolicy RefractoryInputPolicy
+	RefractoryAttenuation float64 // Input scale for RefractoryInputAttenuate, in [0, 1)
+
+	// Dendritic plateau detection
+	Plateau PlateauConfig
+
+	// Named input ports (nil = all input is somatic)
+	InputPorts map[string]InputPortConfig
+
+	// Gain modulation of synaptic input (Gain 0 = unity gain)
+	Gain           float64
+	GainModulators map[types.LigandType]GainModulatorConfig
+
 	// Metadata
 	Metadata map[string]interface{}
+
+	// Per-target FIFO ordering of outgoing spikes (see ordering.go)
+	FIFODelivery bool
+
+	// Real-time mode (nil = disabled, see realtime.go)
+	LatencyBudget *LatencyBudget
+
+	// Adaptive input buffer (see adaptive_buffer.go)
+	AdaptiveBuffer AdaptiveBufferConfig
+
+	// Structured logging (nil = disabled)
+	LogHandler slog.Handler
+
+	// Energy accounting (nil = disabled)
+	EnergyMeter *energy.Meter
 }
 
 // === CONFIGURATION HELPERS ===
@@ -170,6 +208,21 @@ func CallbackNeuronFactory(id string, config NeuronConfig, callbacks component.N
 		config.HomeostasisStrength,
 	)
 
+	// === INJECT ENHANCED MATRIX CALLBACKS ===
+	neuron.SetCallbacks(callbacks)
+
+	if err := applyNeuronConfig(neuron, config); err != nil {
+		return nil, err
+	}
+
+	// FIXED: Return as component.NeuralComponent interface
+	return neuron, nil
+}
+
+// applyNeuronConfig applies the optional parts of a NeuronConfig (position,
+// chemistry, dendrites and plasticity features) to a freshly constructed neuron.
+// Shared by the factories and NewNeuronWithOptions.
+func applyNeuronConfig(neuron *Neuron, config NeuronConfig) error {
 	// Set position
 	neuron.SetPosition(config.Position)
 
@@ -177,9 +230,6 @@ func CallbackNeuronFactory(id string, config NeuronConfig, callbacks component.N
 	neuron.SetReceptors(config.Receptors)
 	neuron.SetReleasedLigands(config.ReleasedLigands)
 
-	// === INJECT ENHANCED MATRIX CALLBACKS ===
-	neuron.SetCallbacks(callbacks)
-
 	// Configure synaptic scaling
 	if config.EnableSynapticScaling {
 		neuron.EnableSynapticScaling(
@@ -193,7 +243,7 @@ func CallbackNeuronFactory(id string, config NeuronConfig, callbacks component.N
 	if config.DendriticMode != nil {
 		err := neuron.SetDendriticMode(config.DendriticMode)
 		if err != nil {
-			return nil, fmt.Errorf("failed to set dendritic mode: %w", err)
+			return fmt.Errorf("failed to set dendritic mode: %w", err)
 		}
 	}
 
@@ -210,13 +260,74 @@ func CallbackNeuronFactory(id string, config NeuronConfig, callbacks component.N
 		neuron.EnableAutoPruning(config.PruningCheckInterval)
 	}
 
+	if config.Heterosynaptic.Enabled {
+		if err := neuron.SetHeterosynapticPlasticity(config.Heterosynaptic); err != nil {
+			return fmt.Errorf("failed to configure heterosynaptic plasticity: %w", err)
+		}
+	}
+
+	if config.InhibitionFloorMode != InhibitionFloorNone {
+		if err := neuron.SetInhibitionFloor(config.InhibitionFloorMode, config.InhibitionFloor); err != nil {
+			return fmt.Errorf("failed to set inhibition floor: %w", err)
+		}
+	}
+
+	if config.RefractoryInputPolicy != RefractoryInputDropOnArrival {
+		if err := neuron.SetRefractoryInputPolicy(config.RefractoryInputPolicy, config.RefractoryAttenuation); err != nil {
+			return fmt.Errorf("failed to set refractory input policy: %w", err)
+		}
+	}
+
+	if config.Plateau.Enabled {
+		if err := neuron.SetPlateauDetection(config.Plateau); err != nil {
+			return fmt.Errorf("failed to configure plateau detection: %w", err)
+		}
+	}
+
+	for name, port := range config.InputPorts {
+		if err := neuron.SetInputPort(name, port); err != nil {
+			return fmt.Errorf("failed to configure input port: %w", err)
+		}
+	}
+
+	if config.Gain != 0 {
+		if err := neuron.SetGain(config.Gain); err != nil {
+			return fmt.Errorf("failed to configure gain: %w", err)
+		}
+	}
+	for ligand, modulator := range config.GainModulators {
+		if err := neuron.SetGainModulator(ligand, modulator); err != nil {
+			return fmt.Errorf("failed to configure gain modulator: %w", err)
+		}
+	}
+
+	if config.FIFODelivery {
+		neuron.SetFIFODelivery(true)
+	}
+	if config.LatencyBudget != nil {
+		if err := neuron.SetLatencyBudget(*config.LatencyBudget); err != nil {
+			return fmt.Errorf("failed to configure real-time mode: %w", err)
+		}
+	}
+	if config.AdaptiveBuffer.Enabled {
+		if err := neuron.SetAdaptiveInputBuffer(config.AdaptiveBuffer); err != nil {
+			return fmt.Errorf("failed to configure adaptive input buffer: %w", err)
+		}
+	}
+
 	// Set metadata
 	for key, value := range config.Metadata {
 		neuron.UpdateMetadata(key, value)
 	}
 
-	// FIXED: Return as component.NeuralComponent interface
-	return neuron, nil
+	if config.LogHandler != nil {
+		neuron.SetLogHandler(config.LogHandler)
+	}
+	if config.EnergyMeter != nil {
+		neuron.SetEnergyMeter(config.EnergyMeter)
+	}
+
+	return nil
 }
 
 // === VALIDATION FUNCTIONS ===
@@ -1,8 +1,10 @@
 package neuron
 
 import (
+	"log/slog"
 	"time"
 
+	"github.com/SynapticNetworks/temporal-neuron/logging"
 	"github.com/SynapticNetworks/temporal-neuron/types"
 )
 
@@ -32,14 +34,15 @@ This separation ensures clean responsibilities and eliminates duplication.
 // fireUnsafe handles the complete firing process including all subsystem coordination
 // This method must be called with stateMutex already locked
 func (n *Neuron) fireUnsafe() {
-	//fmt.Printf("NEURON DEBUG: Neuron %s firing, notifying %d output callbacks\n", n.ID(), len(n.outputCallbacks))
+	now := n.now()
 
-	now := time.Now()
-
-	// Early return if in refractory period
-	if !n.lastFireTime.IsZero() && now.Sub(n.lastFireTime) < n.refractoryPeriod {
+	// Early return if in refractory period; the suppressed spike is counted
+	// (see refractory.go)
+	if n.inRefractoryUnsafe(now) {
+		n.suppressedSpikes.Add(1)
 		return
 	}
+	n.spikeCount.Add(1)
 
 	// NEW: Record spike in history
 	n.spikeHistoryMutex.Lock()
@@ -57,6 +60,7 @@ func (n *Neuron) fireUnsafe() {
 
 	// Calculate output value before releasing lock
 	outputValue := n.accumulator * n.fireFactor
+	accumulator, threshold := n.accumulator, n.threshold
 
 	// Update calcium level
 	n.homeostatic.calciumLevel += n.homeostatic.calciumIncrement
@@ -85,6 +89,15 @@ func (n *Neuron) fireUnsafe() {
 	}
 	n.activityMutex.Unlock()
 
+	if n.logEnabled(slog.LevelDebug) {
+		n.logf(slog.LevelDebug, logging.RecordSpike, "spike",
+			"time", now, "output", outputValue, "accumulator", accumulator, "threshold", threshold)
+	}
+	if meter := n.energyMeter.Load(); meter != nil {
+		meter.ChargeSpike(n.ID())
+	}
+	n.recordFirstSpike(now)
+
 	// === STEP 3: External callbacks (without any locks) ===
 	// Perform matrix callbacks without holding any locks
 	// === STEP 3: External callbacks (without any locks) ===
@@ -93,7 +106,7 @@ func (n *Neuron) fireUnsafe() {
 		// Get connection count
 		var connectionCount int
 		n.outputsMutex.RLock()
-		connectionCount = len(n.outputCallbacks)
+		connectionCount = n.outputCallbacks.len()
 		n.outputsMutex.RUnlock()
 
 		// Get activity level safely
@@ -160,14 +173,9 @@ func (n *Neuron) fireUnsafe() {
 // transmitToOutputSynapsesWithDelay sends signals to all connected synapses with realistic delays
 func (n *Neuron) transmitToOutputSynapsesWithDelay(outputValue float64, fireTime time.Time) {
 	// Take a snapshot of callbacks to minimize lock duration
-	var callbacks map[string]types.OutputCallback
-
 	// LOCK OPTIMIZATION: Minimize lock scope to just the copy operation
 	n.outputsMutex.RLock()
-	callbacks = make(map[string]types.OutputCallback, len(n.outputCallbacks))
-	for id, callback := range n.outputCallbacks {
-		callbacks[id] = callback
-	}
+	outputs := n.outputCallbacks.snapshot()
 	n.outputsMutex.RUnlock()
 
 	// Safely capture neuron ID without a lock (ID is immutable)
@@ -177,13 +185,16 @@ func (n *Neuron) transmitToOutputSynapsesWithDelay(outputValue float64, fireTime
 	ntType := n.getPrimaryNeurotransmitter()
 
 	// Process each output callback without holding any locks
-	for synapseID, callback := range callbacks {
+	for _, output := range outputs {
+		callback := output.callback
+
 		// Create the message
 		msg := types.NeuralSignal{
 			Value:                outputValue,
 			Timestamp:            fireTime,
 			SourceID:             sourceID,
-			SynapseID:            synapseID,
+			SynapseID:            output.synapseID,
+			SynapseIDHandle:      output.ref.Handle(),
 			TargetID:             callback.GetTargetID(),
 			NeurotransmitterType: ntType,
 		}
@@ -251,7 +262,7 @@ func (n *Neuron) GetFiringStatus() map[string]interface{} {
 
 	// Get connection count safely
 	n.outputsMutex.RLock()
-	outputCount := len(n.outputCallbacks)
+	outputCount := n.outputCallbacks.len()
 	n.outputsMutex.RUnlock()
 
 	// Get state-related information
@@ -265,11 +276,11 @@ func (n *Neuron) GetFiringStatus() map[string]interface{} {
 	n.stateMutex.Unlock()
 
 	// Calculate if in refractory period
-	inRefractory := !lastFireTime.IsZero() && time.Since(lastFireTime) < refractoryPeriod
+	inRefractory := !lastFireTime.IsZero() && n.now().Sub(lastFireTime) < refractoryPeriod
 
 	// Count recent spikes from the copy we made
 	recentSpikeCount := 0
-	cutoff := time.Now().Add(-5 * time.Second)
+	cutoff := n.now().Add(-5 * time.Second)
 	for i := len(firingHistory) - 1; i >= 0; i-- {
 		if firingHistory[i].After(cutoff) {
 			recentSpikeCount++
@@ -284,9 +295,11 @@ func (n *Neuron) GetFiringStatus() map[string]interface{} {
 	// Construct the status response
 	status := map[string]interface{}{
 		"last_fire_time":      lastFireTime,
-		"time_since_fire":     time.Since(lastFireTime),
+		"time_since_fire":     n.now().Sub(lastFireTime),
 		"refractory_period":   refractoryPeriod,
 		"in_refractory":       inRefractory,
+		"suppressed_spikes":   n.suppressedSpikes.Load(),
+		"non_finite_inputs":   n.nonFiniteInputs.Load(),
 		"current_firing_rate": currentRate,
 		"target_firing_rate":  targetRate,
 		"calcium_level":       calciumLevel,
@@ -326,12 +339,13 @@ func (n *Neuron) GetOutputConnectionInfo() map[string]interface{} {
 
 	// Get connection information with minimized lock time
 	n.outputsMutex.RLock()
-	connectionCount := len(n.outputCallbacks)
+	connectionCount := n.outputCallbacks.len()
 
 	// Build connection map efficiently
 	connections := make(map[string]interface{}, connectionCount)
-	for synapseID, callback := range n.outputCallbacks {
-		connections[synapseID] = map[string]interface{}{
+	for _, output := range n.outputCallbacks.entries {
+		callback := output.callback
+		connections[output.synapseID] = map[string]interface{}{
 			"target_id": callback.GetTargetID(),
 			"weight":    callback.GetWeight(),
 			"delay":     callback.GetDelay(),
@@ -0,0 +1,129 @@
+package neuron
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// FIRST-SPIKE LATENCY READOUT
+// =================================================================================
+//
+// In latency coding the information is carried by when a neuron first fires
+// after stimulus onset, not by how often (Thorpe et al. 2001; Gollisch &
+// Meister 2008). A stimulus marker arms the readout. The first spike after the
+// marker is reported as its latency, and later spikes in the same trial are
+// ignored until the next marker or ResetLatencyReadout.
+//
+// The marker is an ordinary message receiver, so stimulus onsets can be
+// scheduled with the same machinery as the stimulus itself, for example
+// input.ScheduleDelayedDelivery(signal, n.StimulusMarker(), onset). Onset is
+// the marker's delivery time. MarkStimulus sets it directly.
+
+// FirstSpikeLatency is the result of one latency-coding trial.
+type FirstSpikeLatency struct {
+	NeuronID string        `json:"neuron_id"`
+	Stimulus time.Time     `json:"stimulus"` // Stimulus onset (marker delivery)
+	Spike    time.Time     `json:"spike"`    // First spike after onset
+	Latency  time.Duration `json:"latency"`
+}
+
+// firstSpikeReadout is the per-neuron readout state.
+type firstSpikeReadout struct {
+	mu       sync.Mutex
+	stimulus time.Time
+	result   *FirstSpikeLatency
+	channel  chan<- FirstSpikeLatency
+}
+
+// MarkStimulus starts a trial with stimulus onset at the given time (zero =
+// now). A previous result is discarded.
+func (n *Neuron) MarkStimulus(at time.Time) {
+	if at.IsZero() {
+		at = n.now()
+	}
+	r := &n.firstSpike
+	r.mu.Lock()
+	r.stimulus, r.result = at, nil
+	n.firstSpikeArmed.Store(true)
+	r.mu.Unlock()
+}
+
+// StimulusMarker returns a receiver that calls MarkStimulus on delivery.
+func (n *Neuron) StimulusMarker() component.MessageReceiver {
+	return stimulusMarker{n}
+}
+
+// GetFirstSpikeLatency returns the current trial's result, if the neuron has
+// fired since the last stimulus marker.
+func (n *Neuron) GetFirstSpikeLatency() (FirstSpikeLatency, bool) {
+	r := &n.firstSpike
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.result == nil {
+		return FirstSpikeLatency{}, false
+	}
+	return *r.result, true
+}
+
+// ResetLatencyReadout ends the current trial and clears its result.
+func (n *Neuron) ResetLatencyReadout() {
+	r := &n.firstSpike
+	r.mu.Lock()
+	r.stimulus, r.result = time.Time{}, nil
+	n.firstSpikeArmed.Store(false)
+	r.mu.Unlock()
+}
+
+// SetLatencyChannel also sends each trial result to ch (nil = none). Sends
+// never block: results are dropped while the channel is full.
+func (n *Neuron) SetLatencyChannel(ch chan<- FirstSpikeLatency) {
+	r := &n.firstSpike
+	r.mu.Lock()
+	r.channel = ch
+	r.mu.Unlock()
+}
+
+// recordFirstSpike completes an armed trial. Called for every spike; costs one
+// atomic load while no trial is running.
+func (n *Neuron) recordFirstSpike(spikeTime time.Time) {
+	if !n.firstSpikeArmed.Load() {
+		return
+	}
+	r := &n.firstSpike
+	r.mu.Lock()
+	if r.result != nil || r.stimulus.IsZero() || spikeTime.Before(r.stimulus) {
+		r.mu.Unlock()
+		return
+	}
+	result := FirstSpikeLatency{
+		NeuronID: n.ID(),
+		Stimulus: r.stimulus,
+		Spike:    spikeTime,
+		Latency:  spikeTime.Sub(r.stimulus),
+	}
+	r.result = &result
+	ch := r.channel
+	n.firstSpikeArmed.Store(false)
+	r.mu.Unlock()
+
+	if ch != nil {
+		select {
+		case ch <- result:
+		default:
+		}
+	}
+}
+
+// stimulusMarker marks stimulus onset when a message is delivered to it.
+type stimulusMarker struct {
+	*Neuron
+}
+
+// Receive marks the stimulus instead of integrating the message.
+func (m stimulusMarker) Receive(types.NeuralSignal) {
+	m.MarkStimulus(m.now())
+}
@@ -0,0 +1,180 @@
+package neuron
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// GAIN MODULATION
+// =================================================================================
+//
+// Attention scales a neuron's response to its inputs rather than adding to
+// them: an attended stimulus evokes a proportionally larger response, an
+// unattended one a smaller response, and the tuning stays the same
+// (McAdams & Maunsell 1999). Gain modulation multiplies every synaptic input
+// after dendritic integration by the neuron's effective gain:
+//
+//	gain = base × Π_modulators max(0, 1 + Sensitivity × level) × Π_ports (see ports.go)
+//
+//   - SetGain sets the base gain directly, e.g. from an experiment script
+//     (1 = unmodulated, 0 = silenced).
+//   - SetGainModulator makes a neuromodulator drive the gain. Each Bind of
+//     that ligand sets its level to the bound concentration, which then
+//     decays with TimeConstant, so the effect fades when release stops. The
+//     ligand does not need to be one of the neuron's receptors; if it is,
+//     its usual depolarizing effect applies as well.
+//   - Gain input ports (InputPortGain) let a modulatory synapse drive it.
+//
+// Gain applies to synaptic input only. Chemical and gap-junction effects on
+// the membrane are not scaled.
+
+// GainModulatorConfig couples the gain to a neuromodulator.
+type GainModulatorConfig struct {
+	Sensitivity  float64       `json:"sensitivity"`   // Gain change per unit concentration (negative = suppressive)
+	TimeConstant time.Duration `json:"time_constant"` // Decay of the bound level (0 = default)
+}
+
+// gainState holds the base gain and the modulator levels. Guarded by
+// stateMutex.
+type gainState struct {
+	base       float64
+	modulators map[types.LigandType]*gainModulator
+}
+
+// gainModulator is one neuromodulator's contribution.
+type gainModulator struct {
+	config  GainModulatorConfig
+	level   float64
+	updated time.Time
+}
+
+// validateGain checks a base gain.
+func validateGain(gain float64) error {
+	if math.IsNaN(gain) || math.IsInf(gain, 0) || gain < 0 {
+		return fmt.Errorf("gain must be finite and non-negative: %f", gain)
+	}
+	return nil
+}
+
+// validateGainModulator checks a gain modulator configuration.
+func validateGainModulator(ligand types.LigandType, config GainModulatorConfig) error {
+	if math.IsNaN(config.Sensitivity) || math.IsInf(config.Sensitivity, 0) {
+		return fmt.Errorf("gain modulator %s: sensitivity must be finite: %f", ligand, config.Sensitivity)
+	}
+	if config.TimeConstant < 0 {
+		return fmt.Errorf("gain modulator %s: time constant cannot be negative: %v", ligand, config.TimeConstant)
+	}
+	return nil
+}
+
+// SetGain sets the base gain applied to synaptic input (1 = unmodulated).
+func (n *Neuron) SetGain(gain float64) error {
+	if err := validateGain(gain); err != nil {
+		return err
+	}
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	n.gainStateUnsafe().base = gain
+	return nil
+}
+
+// GetGain returns the base gain.
+func (n *Neuron) GetGain() float64 {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	if n.gain == nil {
+		return 1
+	}
+	return n.gain.base
+}
+
+// SetGainModulator lets ligand drive the gain (see GainModulatorConfig).
+// Replacing a modulator resets its level.
+func (n *Neuron) SetGainModulator(ligand types.LigandType, config GainModulatorConfig) error {
+	if err := validateGainModulator(ligand, config); err != nil {
+		return err
+	}
+	if config.TimeConstant == 0 {
+		config.TimeConstant = GAIN_MODULATOR_TIME_CONSTANT_DEFAULT
+	}
+
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	gain := n.gainStateUnsafe()
+	if gain.modulators == nil {
+		gain.modulators = make(map[types.LigandType]*gainModulator)
+	}
+	gain.modulators[ligand] = &gainModulator{config: config}
+	return nil
+}
+
+// RemoveGainModulator stops ligand from affecting the gain.
+func (n *Neuron) RemoveGainModulator(ligand types.LigandType) {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	if n.gain != nil {
+		delete(n.gain.modulators, ligand)
+	}
+}
+
+// GetEffectiveGain returns the gain currently applied to synaptic input,
+// including neuromodulators and gain ports.
+func (n *Neuron) GetEffectiveGain() float64 {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.inputGainUnsafe(n.now())
+}
+
+// gainStateUnsafe returns the gain state, creating it at unity gain.
+// This method must be called with stateMutex held.
+func (n *Neuron) gainStateUnsafe() *gainState {
+	if n.gain == nil {
+		n.gain = &gainState{base: 1}
+	}
+	return n.gain
+}
+
+// bindGainModulatorUnsafe records a bound concentration for a gain
+// modulator. Returns false if ligand does not modulate the gain.
+// This method must be called with stateMutex held.
+func (n *Neuron) bindGainModulatorUnsafe(ligand types.LigandType, concentration float64, now time.Time) bool {
+	if n.gain == nil {
+		return false
+	}
+	modulator, ok := n.gain.modulators[ligand]
+	if !ok {
+		return false
+	}
+	modulator.level = concentration
+	modulator.updated = now
+	return true
+}
+
+// inputGainUnsafe returns the combined gain for synaptic input.
+// This method must be called with stateMutex held.
+func (n *Neuron) inputGainUnsafe(now time.Time) float64 {
+	gain := 1.0
+	if n.gain != nil {
+		gain = n.gain.base
+		for _, modulator := range n.gain.modulators {
+			gain *= modulator.factor(now)
+		}
+	}
+	if n.inputPorts != nil {
+		gain *= n.portGainUnsafe(now)
+	}
+	return gain
+}
+
+// factor decays the level to now and returns the modulator's gain factor.
+func (m *gainModulator) factor(now time.Time) float64 {
+	if elapsed := now.Sub(m.updated); elapsed > 0 && !m.updated.IsZero() {
+		m.level *= math.Exp(-float64(elapsed) / float64(m.config.TimeConstant))
+		m.updated = now
+	}
+	return math.Max(0, 1+m.config.Sensitivity*m.level)
+}
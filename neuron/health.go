@@ -1,8 +1,6 @@
 package neuron
 
 import (
-	"time"
-
 	"github.com/SynapticNetworks/temporal-neuron/component"
 )
 
@@ -21,7 +19,7 @@ func (n *Neuron) GetHealthMetrics() component.HealthMetrics {
 
 	// Get connection count
 	n.outputsMutex.RLock()
-	connectionCount := len(n.outputCallbacks)
+	connectionCount := n.outputCallbacks.len()
 	n.outputsMutex.RUnlock()
 
 	// Calculate processing load based on recent activity and system state
@@ -37,7 +35,7 @@ func (n *Neuron) GetHealthMetrics() component.HealthMetrics {
 		ActivityLevel:   activityLevel,
 		ConnectionCount: connectionCount,
 		ProcessingLoad:  processingLoad,
-		LastHealthCheck: time.Now(),
+		LastHealthCheck: n.now(),
 		HealthScore:     healthScore,
 		Issues:          issues,
 	}
@@ -55,7 +53,7 @@ func (n *Neuron) calculateProcessingLoad() float64 {
 
 	// Load from homeostatic processing
 	homeostaticLoad := 0.0
-	if time.Since(n.homeostatic.lastHomeostaticUpdate) < n.homeostatic.homeostaticInterval {
+	if n.now().Sub(n.homeostatic.lastHomeostaticUpdate) < n.homeostatic.homeostaticInterval {
 		homeostaticLoad = 0.1 // Active homeostatic processing
 	}
 
@@ -63,7 +61,7 @@ func (n *Neuron) calculateProcessingLoad() float64 {
 	scalingLoad := 0.0
 	if n.synapticScaling != nil && n.synapticScaling.Config.Enabled {
 		// Check if scaling is actively running
-		if time.Since(n.synapticScaling.Config.LastScalingUpdate) < n.synapticScaling.Config.ScalingInterval {
+		if n.now().Sub(n.synapticScaling.Config.LastScalingUpdate) < n.synapticScaling.Config.ScalingInterval {
 			scalingLoad = 0.05 // Active synaptic scaling
 		}
 	}
@@ -253,7 +251,7 @@ func (n *Neuron) identifyHealthIssues(activityLevel, processingLoad float64, con
 	}
 
 	// === TEMPORAL ISSUES ===
-	if !n.lastFireTime.IsZero() && time.Since(n.lastFireTime) > 10*n.refractoryPeriod {
+	if !n.lastFireTime.IsZero() && n.now().Sub(n.lastFireTime) > 10*n.refractoryPeriod {
 		issues = append(issues, "prolonged_silence")
 	}
 
@@ -335,7 +333,7 @@ func (n *Neuron) identifyHealthIssues(activityLevel, processingLoad float64, con
 // calculateCurrentFiringRateUnsafe calculates the current firing rate
 // This method must be called with stateMutex already locked
 func (n *Neuron) calculateCurrentFiringRateUnsafe() float64 {
-	now := time.Now()
+	now := n.now()
 	recentFires := 0
 
 	// Count spikes within the activity window
@@ -0,0 +1,122 @@
+package neuron
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+)
+
+// =================================================================================
+// HETEROSYNAPTIC PLASTICITY
+// =================================================================================
+//
+// STDP is homosynaptic: only synapses whose inputs took part in a spike pair
+// change. In cortex and hippocampus, strong potentiation of one input is also
+// accompanied by mild depression of the neuron's other, non-stimulated inputs
+// (Lynch et al. 1977; Chistiakova et al. 2014), as if the synapses competed for
+// a limited pool of resources. This keeps the total synaptic drive bounded
+// without waiting for slow homeostatic scaling.
+//
+// After each STDP feedback round the neuron compares the weights of its
+// registered input synapses (see inputs.go) before and after. If any input
+// gained at least PotentiationThreshold, the summed strong gain times
+// DepressionRatio is removed from the inputs whose weights did not change,
+// in proportion to their current weight. A DepressionRatio of 1 conserves
+// the neuron's total input weight.
+
+// HeterosynapticConfig configures heterosynaptic depression.
+type HeterosynapticConfig struct {
+	Enabled               bool    `json:"enabled"`
+	PotentiationThreshold float64 `json:"potentiation_threshold"` // Weight gain in one feedback round that triggers the rule
+	DepressionRatio       float64 `json:"depression_ratio"`       // Total depression as a fraction of the strong gain (0-1)
+}
+
+// DefaultHeterosynapticConfig returns an enabled rule with typical values.
+func DefaultHeterosynapticConfig() HeterosynapticConfig {
+	return HeterosynapticConfig{
+		Enabled:               true,
+		PotentiationThreshold: HETEROSYNAPTIC_POTENTIATION_THRESHOLD_DEFAULT,
+		DepressionRatio:       HETEROSYNAPTIC_DEPRESSION_RATIO_DEFAULT,
+	}
+}
+
+// validateHeterosynapticConfig checks an enabled configuration.
+func validateHeterosynapticConfig(config HeterosynapticConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if math.IsNaN(config.PotentiationThreshold) || config.PotentiationThreshold <= 0 {
+		return fmt.Errorf("heterosynaptic potentiation threshold must be positive: %f", config.PotentiationThreshold)
+	}
+	if math.IsNaN(config.DepressionRatio) || config.DepressionRatio < 0 || config.DepressionRatio > 1 {
+		return fmt.Errorf("heterosynaptic depression ratio must be in [0,1]: %f", config.DepressionRatio)
+	}
+	return nil
+}
+
+// SetHeterosynapticPlasticity configures the rule (Enabled false turns it off).
+func (n *Neuron) SetHeterosynapticPlasticity(config HeterosynapticConfig) error {
+	if err := validateHeterosynapticConfig(config); err != nil {
+		return err
+	}
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	n.heterosynaptic = config
+	return nil
+}
+
+// GetHeterosynapticPlasticity returns the rule's configuration.
+func (n *Neuron) GetHeterosynapticPlasticity() HeterosynapticConfig {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.heterosynaptic
+}
+
+// GetHeterosynapticEventCount returns how often the rule depressed other inputs.
+func (n *Neuron) GetHeterosynapticEventCount() int64 {
+	return n.heterosynapticEvents.Load()
+}
+
+// withHeterosynapticPlasticity runs an STDP feedback round and then applies
+// heterosynaptic depression for the weight gains it produced. Must be called
+// without neuron locks held.
+func (n *Neuron) withHeterosynapticPlasticity(feedback func()) {
+	config := n.GetHeterosynapticPlasticity()
+	if !config.Enabled {
+		feedback()
+		return
+	}
+
+	inputs := n.GetInputSynapses()
+	before := make([]float64, len(inputs))
+	for i, syn := range inputs {
+		before[i] = syn.GetWeight()
+	}
+
+	feedback()
+
+	var gain, unchangedWeight float64
+	unchanged := make([]int, 0, len(inputs))
+	for i, syn := range inputs {
+		delta := syn.GetWeight() - before[i]
+		if delta >= config.PotentiationThreshold {
+			gain += delta
+		} else if delta == 0 {
+			unchanged = append(unchanged, i)
+			unchangedWeight += before[i]
+		}
+	}
+	if gain == 0 || unchangedWeight <= 0 || config.DepressionRatio == 0 {
+		return
+	}
+
+	depression := gain * config.DepressionRatio
+	for _, i := range unchanged {
+		inputs[i].SetWeight(before[i] - depression*before[i]/unchangedWeight)
+	}
+	n.heterosynapticEvents.Add(1)
+	n.logf(slog.LevelDebug, logging.RecordPlasticity, "heterosynaptic depression",
+		"gain", gain, "depression", depression, "depressed_synapses", len(unchanged))
+}
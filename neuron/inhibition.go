@@ -0,0 +1,128 @@
+package neuron
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/SynapticNetworks/temporal-neuron/invariant"
+)
+
+// =================================================================================
+// HYPERPOLARIZATION BOUNDS
+// =================================================================================
+//
+// The accumulator is a membrane potential relative to rest (0). Without a
+// bound, sustained inhibition drives it arbitrarily negative and the neuron
+// needs an unrealistically long time to recover. Real inhibitory currents are
+// limited by their reversal potential (about -70 to -80mV for GABA-A, roughly
+// one threshold distance below rest), so two bounds are offered:
+//
+//   - InhibitionFloorClamp: a hard lower clamp on the accumulator.
+//   - InhibitionFloorReversal: conductance-style saturation. Inhibitory input
+//     is scaled by the driving force (V - E_rev) / (0 - E_rev), so it has full
+//     effect at rest, grows on a depolarized membrane (shunting) and vanishes
+//     as the membrane approaches E_rev. The result is also clamped at E_rev
+//     so large discrete inputs cannot overshoot.
+//
+// Both bounds apply to every input path (synaptic, dendritic, chemical and
+// gap-junction). Excitatory input is never affected. The default is
+// InhibitionFloorNone, which keeps the historical unbounded behaviour.
+
+// InhibitionFloorMode selects how hyperpolarization is bounded.
+type InhibitionFloorMode int
+
+const (
+	// InhibitionFloorNone leaves the accumulator unbounded below.
+	InhibitionFloorNone InhibitionFloorMode = iota
+
+	// InhibitionFloorClamp clamps the accumulator at the floor.
+	InhibitionFloorClamp
+
+	// InhibitionFloorReversal treats the floor as the inhibitory reversal
+	// potential and scales inhibition by the driving force.
+	InhibitionFloorReversal
+)
+
+// String returns the mode name.
+func (m InhibitionFloorMode) String() string {
+	switch m {
+	case InhibitionFloorNone:
+		return "none"
+	case InhibitionFloorClamp:
+		return "clamp"
+	case InhibitionFloorReversal:
+		return "reversal"
+	default:
+		return fmt.Sprintf("InhibitionFloorMode(%d)", int(m))
+	}
+}
+
+// SetInhibitionFloor bounds hyperpolarization. floor is relative to rest and
+// must be negative unless mode is InhibitionFloorNone. An accumulator already
+// below the new floor is raised to it.
+func (n *Neuron) SetInhibitionFloor(mode InhibitionFloorMode, floor float64) error {
+	if err := validateInhibitionFloor(mode, floor); err != nil {
+		return err
+	}
+
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	n.inhibitionMode = mode
+	n.inhibitionFloor = floor
+	if mode != InhibitionFloorNone && n.accumulator < floor {
+		n.accumulator = floor
+	}
+	return nil
+}
+
+// GetInhibitionFloor returns the hyperpolarization mode and floor.
+func (n *Neuron) GetInhibitionFloor() (InhibitionFloorMode, float64) {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.inhibitionMode, n.inhibitionFloor
+}
+
+// validateInhibitionFloor checks a mode/floor pair.
+func validateInhibitionFloor(mode InhibitionFloorMode, floor float64) error {
+	switch mode {
+	case InhibitionFloorNone:
+		return nil
+	case InhibitionFloorClamp, InhibitionFloorReversal:
+		if math.IsNaN(floor) || math.IsInf(floor, 0) || floor >= 0 {
+			return fmt.Errorf("inhibition floor must be negative and finite: %f", floor)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown inhibition floor mode: %v", mode)
+	}
+}
+
+// integrateUnsafe adds an input to the accumulator, applying the
+// hyperpolarization bound. Returns the effective change. Input that is
+// NaN or infinite, or that would overflow the accumulator, is discarded and
+// counted (see GetNonFiniteInputCount), so the accumulator stays finite.
+// This method must be called with stateMutex already locked.
+func (n *Neuron) integrateUnsafe(value float64) float64 {
+	if !isFinite(value) || !isFinite(n.accumulator+value) {
+		n.nonFiniteInputs.Add(1)
+		return 0
+	}
+	before := n.accumulator
+	if value >= 0 || n.inhibitionMode == InhibitionFloorNone {
+		n.accumulator += value
+		invariant.Finite(n.ID(), "accumulator", n.accumulator)
+		return value
+	}
+
+	floor := n.inhibitionFloor
+	if n.inhibitionMode == InhibitionFloorReversal {
+		// Driving force relative to the driving force at rest
+		value *= math.Max(0, (n.accumulator-floor)/-floor)
+	}
+	n.accumulator += value
+	if n.accumulator < floor {
+		n.accumulator = math.Min(floor, before)
+	}
+	invariant.Finite(n.ID(), "accumulator", n.accumulator)
+	return n.accumulator - before
+}
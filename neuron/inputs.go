@@ -0,0 +1,67 @@
+package neuron
+
+import (
+	"sort"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+)
+
+// =================================================================================
+// INPUT SYNAPSE REGISTRY
+// =================================================================================
+//
+// Output synapses are known through their callbacks, but a neuron only sees
+// its inputs as messages. Rules that act on all inputs of a neuron at once
+// (heterosynaptic plasticity) need direct access to those synapses. The
+// extracellular matrix registers every synapse with its post-synaptic neuron
+// when it creates it and unregisters it on deletion; hand-wired circuits
+// call RegisterInputSynapse themselves. Registration also assigns the
+// synapse's route in the routing table (see routing.go).
+
+// RegisterInputSynapse records a synapse that targets this neuron.
+func (n *Neuron) RegisterInputSynapse(synapseID string, synapse component.SynapticProcessor) {
+	if synapse == nil {
+		return
+	}
+	n.inputsMutex.Lock()
+	if n.inputSynapses == nil {
+		n.inputSynapses = make(map[string]component.SynapticProcessor)
+	}
+	n.inputSynapses[synapseID] = synapse
+	n.inputsMutex.Unlock()
+
+	n.assignRoute(synapseID, synapse)
+}
+
+// UnregisterInputSynapse forgets an input synapse and releases its route.
+func (n *Neuron) UnregisterInputSynapse(synapseID string) {
+	n.inputsMutex.Lock()
+	synapse := n.inputSynapses[synapseID]
+	delete(n.inputSynapses, synapseID)
+	n.inputsMutex.Unlock()
+
+	n.routes.unregister(synapseID)
+	if receiver, ok := synapse.(routeHandleReceiver); ok {
+		receiver.SetRouteHandle(0)
+	}
+}
+
+// GetInputSynapses returns the registered input synapses sorted by ID.
+func (n *Neuron) GetInputSynapses() []component.SynapticProcessor {
+	n.inputsMutex.RLock()
+	inputs := make([]component.SynapticProcessor, 0, len(n.inputSynapses))
+	for _, synapse := range n.inputSynapses {
+		inputs = append(inputs, synapse)
+	}
+	n.inputsMutex.RUnlock()
+
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].ID() < inputs[j].ID() })
+	return inputs
+}
+
+// GetInputSynapseCount returns the number of registered input synapses.
+func (n *Neuron) GetInputSynapseCount() int {
+	n.inputsMutex.RLock()
+	defer n.inputsMutex.RUnlock()
+	return len(n.inputSynapses)
+}
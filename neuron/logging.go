@@ -0,0 +1,45 @@
+package neuron
+
+import (
+	"log/slog"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+)
+
+// =================================================================================
+// STRUCTURED LOGGING
+// =================================================================================
+//
+// A neuron logs through an optional slog.Handler. Records carry the neuron ID
+// (logging.KeyNeuronID) and a record kind (logging.KeyRecord):
+//
+//   - integration (logging.LevelTrace): each input added to the accumulator
+//   - spike (slog.LevelDebug): each action potential
+//   - plasticity (slog.LevelDebug): STDP pairings found during feedback
+//   - diagnostic (slog.LevelWarn): recoverable problems
+//
+// Without a handler, logging costs one atomic load per record site.
+
+// SetLogHandler installs a structured log handler (nil disables logging).
+// The handler also receives records from the neuron's STDP signaling system.
+func (n *Neuron) SetLogHandler(handler slog.Handler) {
+	logger := logging.ForNeuron(handler, n.ID())
+	n.logger.Store(logger)
+	n.stdpSystem.logger.Store(logger)
+}
+
+// GetLogger returns the neuron's logger, or nil if logging is disabled.
+func (n *Neuron) GetLogger() *slog.Logger {
+	return n.logger.Load()
+}
+
+// logf emits a record of the given kind at level.
+func (n *Neuron) logf(level slog.Level, kind, msg string, args ...any) {
+	logging.Log(n.logger.Load(), level, kind, msg, args...)
+}
+
+// logEnabled reports whether records at level would be emitted. Hot paths
+// check it before computing record attributes.
+func (n *Neuron) logEnabled(level slog.Level) bool {
+	return logging.Enabled(n.logger.Load(), level)
+}
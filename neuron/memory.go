@@ -0,0 +1,40 @@
+package neuron
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/SynapticNetworks/temporal-neuron/memory"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// MEMORY ACCOUNTING
+// =================================================================================
+//
+// A neuron owns its struct, the input buffer and output table (neurons), the
+// axonal delivery queue (delay queues) and the spike history kept for STDP.
+// Buffers are counted at capacity, since that is what is allocated.
+
+// outputEntryBytes approximates one output table entry: the slice entry, its
+// ID reference and the handle index.
+const outputEntryBytes = int64(unsafe.Sizeof(outputEntry{})+unsafe.Sizeof(types.IDRef{})) + 16
+
+// MemoryUsage implements memory.Reporter.
+func (n *Neuron) MemoryUsage() memory.Usage {
+	n.outputsMutex.RLock()
+	outputs := int64(n.outputCallbacks.len())
+	n.outputsMutex.RUnlock()
+
+	n.spikeHistoryMutex.RLock()
+	history := int64(cap(n.spikeHistory))
+	n.spikeHistoryMutex.RUnlock()
+
+	return memory.Usage{
+		memory.CategoryNeurons: int64(unsafe.Sizeof(*n)) +
+			int64(cap(n.inputBuffer))*int64(unsafe.Sizeof(types.NeuralSignal{})) +
+			outputs*outputEntryBytes,
+		memory.CategoryDelayQueues:  int64(cap(n.deliveryQueue)) * int64(unsafe.Sizeof(delayedMessage{})),
+		memory.CategorySpikeHistory: history * int64(unsafe.Sizeof(time.Time{})),
+	}
+}
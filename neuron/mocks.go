@@ -499,8 +499,6 @@ func (msp *MockSynapticProcessor) RecordPreSpike(t time.Time) {
 	if len(msp.preSpikes) > msp.maxSpikeHistory {
 		msp.preSpikes = msp.preSpikes[1:] // Remove oldest
 	}
-
-	//fmt.Printf("SYNAPSE RECORDING: %s recording pre-spike at %v\n", msp.synapseID, t)
 }
 
 // RecordPostSpike records a post-synaptic spike
@@ -515,8 +513,6 @@ func (msp *MockSynapticProcessor) RecordPostSpike(t time.Time) {
 	if len(msp.postSpikes) > msp.maxSpikeHistory {
 		msp.postSpikes = msp.postSpikes[1:] // Remove oldest
 	}
-
-	//fmt.Printf("SYNAPSE RECORDING: %s recording post-spike at %v\n", msp.synapseID, t)
 }
 
 // Update Transmit to also record pre-spike
@@ -536,8 +532,6 @@ func (msp *MockSynapticProcessor) Transmit(signalValue float64) {
 		msp.preSpikes = msp.preSpikes[1:]
 	}
 	msp.spikeTimingMutex.Unlock()
-
-	// fmt.Printf("SYNAPSE DEBUG: Synapse %s received transmission signal of strength %.2f\n",msp.synapseID, signalValue)
 }
 
 // ============================================================================
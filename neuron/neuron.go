@@ -3,10 +3,16 @@ package neuron
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/SynapticNetworks/temporal-neuron/affinity"
 	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+	"github.com/SynapticNetworks/temporal-neuron/timing"
 	"github.com/SynapticNetworks/temporal-neuron/types"
 )
 
@@ -61,6 +67,39 @@ type Neuron struct {
 	lastFireTime time.Time
 	inputBuffer  chan types.NeuralSignal
 
+	// === INPUT PRIORITY (see priority.go) ===
+	criticalBuffer chan types.NeuralSignal
+	priorityDrops  [3]atomic.Int64 // Indexed by priority - PriorityBackground
+
+	// === HYPERPOLARIZATION BOUND (see inhibition.go) ===
+	inhibitionMode  InhibitionFloorMode
+	inhibitionFloor float64
+
+	// === EXPERIMENT CLAMP (see clamp.go, nil = unclamped) ===
+	clamp *ClampState
+
+	// === ADAPTIVE INPUT BUFFER (see adaptive_buffer.go, nil = disabled) ===
+	adaptiveBuffer atomic.Pointer[adaptiveBuffer]
+
+	// === REFRACTORY INPUT POLICY (see refractory.go) ===
+	refractoryPolicy      RefractoryInputPolicy
+	refractoryAttenuation float64
+	refractoryDrops       atomic.Int64
+	suppressedSpikes      atomic.Int64 // Threshold reached while refractory
+	spikeCount            atomic.Int64 // Spikes fired since creation
+	nonFiniteInputs       atomic.Int64 // NaN or infinite inputs discarded
+
+	// === DENDRITIC PLATEAU DETECTION (see plateau.go, nil = disabled) ===
+	plateau        *plateauState
+	plateauEvents  atomic.Int64
+	plateauHandler atomic.Pointer[PlateauHandler]
+
+	// === INPUT PORTS (see ports.go, nil = all input is somatic) ===
+	inputPorts map[string]*inputPort
+
+	// === GAIN MODULATION (see gain.go, nil = unity gain) ===
+	gain *gainState
+
 	// === HOMEOSTATIC SYSTEM ===
 	homeostatic HomeostaticMetrics
 
@@ -85,15 +124,61 @@ type Neuron struct {
 	deliveryQueue     chan delayedMessage
 
 	// === CALLBACK-BASED OUTPUTS (NO SYNAPSE DEPENDENCY) ===
-	outputCallbacks map[string]types.OutputCallback
+	outputCallbacks outputTable // Output synapses (see outputs.go)
 
 	// === INJECTED MATRIX CALLBACKS ===
 	matrixCallbacks component.NeuronCallbacks
 
 	// === LIFECYCLE MANAGEMENT ===
-	ctx       context.Context
-	cancel    context.CancelFunc
-	closeOnce sync.Once
+	ctx            context.Context
+	cancel         context.CancelFunc
+	closeOnce      sync.Once
+	closed         atomic.Bool
+	lifecycleMutex sync.RWMutex // Orders axonal scheduling against queue closure
+
+	// === DEAD-LETTER HANDLING ===
+	deadLetters       atomic.Int64
+	deadLetterHandler atomic.Pointer[DeadLetterHandler]
+
+	// === DELIVERY ORDERING (nil = unordered, see ordering.go) ===
+	fifo atomic.Pointer[fifoOrdering]
+
+	// === CHAOS TESTING (nil = disabled, see chaos.go) ===
+	chaos atomic.Pointer[chaosRef]
+
+	// === REAL-TIME MODE (nil = disabled, see realtime.go) ===
+	realTime atomic.Pointer[realTimeMode]
+
+	// === VIRTUAL CLOCK (nil = wall clock and own goroutine, see stepping.go) ===
+	stepped atomic.Pointer[steppedClock]
+
+	// === TIMING SOURCE (nil = system timers, see timing.go) ===
+	timingConfig  atomic.Pointer[timing.Config]
+	timingChanged chan struct{}
+
+	// === THREAD AFFINITY (nil = unpinned, see affinity.go) ===
+	affinity atomic.Pointer[affinity.Group]
+
+	// === STRUCTURED LOGGING (nil = disabled) ===
+	logger atomic.Pointer[slog.Logger]
+
+	// === ENERGY ACCOUNTING (nil = disabled) ===
+	energyMeter atomic.Pointer[energy.Meter]
+
+	// === FIRST-SPIKE LATENCY READOUT (see firstspike.go) ===
+	firstSpike      firstSpikeReadout
+	firstSpikeArmed atomic.Bool // Fast path: a trial is waiting for its first spike
+
+	// === INPUT SYNAPSE REGISTRY (see inputs.go) ===
+	inputSynapses map[string]component.SynapticProcessor
+	inputsMutex   sync.RWMutex
+
+	// === INPUT ROUTING TABLE (see routing.go) ===
+	routes routingTable
+
+	// === HETEROSYNAPTIC PLASTICITY (see heterosynaptic.go) ===
+	heterosynaptic       HeterosynapticConfig
+	heterosynapticEvents atomic.Int64
 
 	// === CUSTOM BEHAVIORS (OPTIONAL) ===
 	customBehaviors *CustomBehaviors
@@ -156,8 +241,9 @@ func NewNeuron(id string, threshold float64, decayRate float64, refractoryPeriod
 		maxSpikeHistory: 20, // Store 20 recent spikes
 
 		// Initialize processing
-		inputBuffer:     make(chan types.NeuralSignal, 100),
-		outputCallbacks: make(map[string]types.OutputCallback),
+		inputBuffer:    make(chan types.NeuralSignal, 100),
+		criticalBuffer: make(chan types.NeuralSignal, INPUT_CRITICAL_BUFFER_SIZE),
+		timingChanged:  make(chan struct{}, 1),
 
 		// Initialize homeostatic system
 		homeostatic: HomeostaticMetrics{
@@ -209,22 +295,24 @@ func (n *Neuron) GetReceptors() []types.LigandType {
 }
 
 func (n *Neuron) Bind(ligandType types.LigandType, sourceID string, concentration float64) {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+
+	// Gain modulators listen regardless of the receptor list (see gain.go)
+	n.bindGainModulatorUnsafe(ligandType, concentration, n.now())
 	if !n.hasReceptor(ligandType) {
 		return
 	}
 
-	n.stateMutex.Lock()
-	defer n.stateMutex.Unlock()
-
 	// Apply chemical effect
 	effect := n.calculateChemicalEffect(ligandType, concentration)
-	n.accumulator += effect
+	n.integrateUnsafe(effect)
 
 	// Update activity
-	n.UpdateMetadata("last_chemical_input", time.Now())
+	n.UpdateMetadata("last_chemical_input", n.now())
 
 	// Check firing (delegated to processing pipeline for consistency)
-	if n.accumulator >= n.threshold {
+	if !n.holdClampUnsafe() && n.accumulator >= n.firingThresholdUnsafe() {
 		n.fireUnsafe() // Implemented in firing.go
 		n.resetAccumulatorUnsafe()
 	}
@@ -242,9 +330,9 @@ func (n *Neuron) OnSignal(signalType types.SignalType, sourceID string, data int
 		// Gap junction synchronization
 		if value, ok := data.(float64); ok {
 			n.stateMutex.Lock()
-			n.accumulator += value * 0.1 // Small sync effect
+			n.integrateUnsafe(value * 0.1) // Small sync effect
 			// Check firing after gap junction input
-			if n.accumulator >= n.threshold {
+			if !n.holdClampUnsafe() && n.accumulator >= n.firingThresholdUnsafe() {
 				n.fireUnsafe() // Implemented in firing.go
 				n.resetAccumulatorUnsafe()
 			}
@@ -274,24 +362,34 @@ func (n *Neuron) GetSignalTypes() []types.SignalType {
 // The Receive method needs to protect the lastFireTime read with mutex
 // MessageReceiver interface
 func (n *Neuron) Receive(msg types.NeuralSignal) {
+	// Closed neurons reject input instead of buffering it forever
+	if n.closed.Load() {
+		n.rejectMessage(msg)
+		return
+	}
+
+	// A NaN or infinite value would poison the accumulator for good
+	if !isFinite(msg.Value) {
+		n.nonFiniteInputs.Add(1)
+		return
+	}
+
 	// Check refractory period with proper synchronization
 	n.stateMutex.Lock()
-	inRefractory := !n.lastFireTime.IsZero() && time.Since(n.lastFireTime) < n.refractoryPeriod
+	accepted := n.acceptOnArrivalUnsafe(n.now())
 	n.stateMutex.Unlock()
 
-	if inRefractory {
+	if !accepted || n.shedOnArrival(msg) {
 		return
 	}
 
 	// Update component activity
-	n.UpdateMetadata("last_message", time.Now())
+	n.UpdateMetadata("last_message", n.now())
 
-	// Queue for processing (actual processing happens in processing.go)
-	select {
-	case n.inputBuffer <- msg:
-		// Successfully queued
-	default:
-		// Buffer full - message lost (biologically realistic)
+	// Queue for processing (actual processing happens in processing.go).
+	// A full buffer loses the message (biologically realistic)
+	if !n.enqueueInput(msg) {
+		n.recordPriorityDrop(msg.Priority)
 	}
 }
 
@@ -317,7 +415,7 @@ func (n *Neuron) calculateCurrentFiringRateSafe() float64 {
 	}
 
 	// Calculate rate from copied history (safe from mutations)
-	now := time.Now()
+	now := n.now()
 	windowSize := activityWindow
 	if windowSize <= 0 {
 		windowSize = 10 * time.Second // Default fallback
@@ -370,10 +468,38 @@ func (n *Neuron) SetThreshold(threshold float64) {
 	n.threshold = threshold
 }
 
+// GetDecayRate returns the per-millisecond membrane decay factor
+func (n *Neuron) GetDecayRate() float64 {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.decayRate
+}
+
+// GetRefractoryPeriod returns the absolute refractory period
+func (n *Neuron) GetRefractoryPeriod() time.Duration {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.refractoryPeriod
+}
+
+// GetFireFactor returns the output spike amplitude multiplier
+func (n *Neuron) GetFireFactor() float64 {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.fireFactor
+}
+
+// GetHomeostasisStrength returns the threshold adaptation strength (0 = disabled)
+func (n *Neuron) GetHomeostasisStrength() float64 {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.homeostatic.homeostasisStrength
+}
+
 func (n *Neuron) GetConnectionCount() int {
 	n.outputsMutex.RLock()
 	defer n.outputsMutex.RUnlock()
-	return len(n.outputCallbacks)
+	return n.outputCallbacks.len()
 }
 
 // ============================================================================
@@ -404,7 +530,7 @@ func (n *Neuron) EnableSynapticScaling(targetStrength, scalingRate float64, inte
 		"target_strength": targetStrength,
 		"scaling_rate":    scalingRate,
 		"interval":        interval,
-		"timestamp":       time.Now(),
+		"timestamp":       n.now(),
 	})
 
 	return nil
@@ -416,7 +542,7 @@ func (n *Neuron) DisableSynapticScaling() error {
 	}
 
 	n.synapticScaling.DisableScaling()
-	n.UpdateMetadata("synaptic_scaling_disabled", time.Now())
+	n.UpdateMetadata("synaptic_scaling_disabled", n.now())
 
 	return nil
 }
@@ -438,7 +564,7 @@ func (n *Neuron) EnableSTDPFeedback(feedbackDelay time.Duration, learningRate fl
 	n.UpdateMetadata("stdp_feedback_enabled", map[string]interface{}{
 		"feedback_delay": feedbackDelay,
 		"learning_rate":  learningRate,
-		"timestamp":      time.Now(),
+		"timestamp":      n.now(),
 	})
 }
 
@@ -446,7 +572,7 @@ func (n *Neuron) DisableSTDPFeedback() {
 	// Simply forward to the STDP system
 	n.stdpSystem.Disable()
 
-	n.UpdateMetadata("stdp_feedback_disabled", time.Now())
+	n.UpdateMetadata("stdp_feedback_disabled", n.now())
 }
 
 func (n *Neuron) EnableAutoHomeostasis(checkInterval time.Duration) {
@@ -457,7 +583,7 @@ func (n *Neuron) EnableAutoHomeostasis(checkInterval time.Duration) {
 
 	n.UpdateMetadata("auto_homeostasis_enabled", map[string]interface{}{
 		"check_interval": checkInterval,
-		"timestamp":      time.Now(),
+		"timestamp":      n.now(),
 	})
 }
 
@@ -466,7 +592,7 @@ func (n *Neuron) DisableAutoHomeostasis() {
 	defer n.stateMutex.Unlock()
 
 	n.scalingCheckInterval = 0 // 0 means disabled
-	n.UpdateMetadata("auto_homeostasis_disabled", time.Now())
+	n.UpdateMetadata("auto_homeostasis_disabled", n.now())
 }
 
 func (n *Neuron) EnableAutoPruning(checkInterval time.Duration) {
@@ -477,7 +603,7 @@ func (n *Neuron) EnableAutoPruning(checkInterval time.Duration) {
 
 	n.UpdateMetadata("auto_pruning_enabled", map[string]interface{}{
 		"check_interval": checkInterval,
-		"timestamp":      time.Now(),
+		"timestamp":      n.now(),
 	})
 }
 
@@ -486,7 +612,7 @@ func (n *Neuron) DisableAutoPruning() {
 	defer n.stateMutex.Unlock()
 
 	n.pruningCheckInterval = 0 // 0 means disabled
-	n.UpdateMetadata("auto_pruning_disabled", time.Now())
+	n.UpdateMetadata("auto_pruning_disabled", n.now())
 }
 
 // IsSTDPFeedbackEnabled returns whether STDP feedback is enabled
@@ -528,10 +654,13 @@ func (n *Neuron) SetDendriticMode(mode DendriticIntegrationMode) error {
 	}
 
 	n.dendrite = mode
+	if clocked, ok := mode.(clockedIntegration); ok && n.IsStepped() {
+		clocked.setClock(n.Now)
+	}
 
 	n.UpdateMetadata("dendritic_mode_changed", map[string]interface{}{
 		"new_mode":  mode.Name(),
-		"timestamp": time.Now(),
+		"timestamp": n.now(),
 	})
 
 	return nil
@@ -545,20 +674,24 @@ func (n *Neuron) GetDendriticMode() DendriticIntegrationMode {
 // CALLBACK MANAGEMENT
 // ============================================================================
 
+// SetCallbacks installs the matrix callbacks. The field is written under
+// stateMutex, as the processing loop reads it concurrently.
 func (n *Neuron) SetCallbacks(callbacks component.NeuronCallbacks) {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
 	n.matrixCallbacks = callbacks
 }
 
 func (n *Neuron) AddOutputCallback(synapseID string, callback types.OutputCallback) {
 	n.outputsMutex.Lock()
 	defer n.outputsMutex.Unlock()
-	n.outputCallbacks[synapseID] = callback
+	n.outputCallbacks.set(synapseID, callback)
 }
 
 func (n *Neuron) RemoveOutputCallback(synapseID string) {
 	n.outputsMutex.Lock()
 	defer n.outputsMutex.Unlock()
-	delete(n.outputCallbacks, synapseID)
+	n.outputCallbacks.remove(synapseID)
 }
 
 // ConnectToNeuron creates a synapse connection to another neuron via matrix callbacks
@@ -600,7 +733,7 @@ func (n *Neuron) ConnectToNeuron(targetNeuronID string, weight float64, synapseT
 		"synapse_id": synapseID,
 		"weight":     weight,
 		"type":       synapseType,
-		"timestamp":  time.Now(),
+		"timestamp":  n.now(),
 	})
 
 	return nil
@@ -626,18 +759,22 @@ func (n *Neuron) ConnectToNeuron(targetNeuronID string, weight float64, synapseT
 // SendSTDPFeedback triggers STDP feedback to update synaptic weights
 // This method ensures proper usage of spike history for both LTP and LTD
 func (n *Neuron) SendSTDPFeedback() {
+	n.withHeterosynapticPlasticity(n.sendSTDPFeedback)
+}
+
+// sendSTDPFeedback applies pair-based STDP to every input synapse
+func (n *Neuron) sendSTDPFeedback() {
 	// Get my ID and the matrix callbacks
 	myID := n.ID()
 	callbacks := n.matrixCallbacks
 
 	if callbacks == nil {
-		fmt.Printf("STDP Error: No matrix callbacks available for neuron %s\n", myID)
+		n.logf(slog.LevelWarn, logging.RecordDiagnostic, "STDP feedback without matrix callbacks")
 		return
 	}
 
 	// Check if STDP is enabled
 	if !n.stdpSystem.IsEnabled() {
-		// fmt.Printf("STDP Debug: STDP is disabled for neuron %s\n", myID)
 		return
 	}
 
@@ -656,7 +793,7 @@ func (n *Neuron) SendSTDPFeedback() {
 
 	// If neuron has never fired, we can't do STDP
 	if lastFireTime.IsZero() && spikeCount == 0 {
-		fmt.Printf("STDP Debug: No firing history for neuron %s, skipping STDP\n", myID)
+		n.logf(slog.LevelDebug, logging.RecordPlasticity, "no firing history, skipping STDP")
 		return
 	}
 
@@ -667,7 +804,7 @@ func (n *Neuron) SendSTDPFeedback() {
 		Direction: &incomingDirection,
 	})
 
-	fmt.Printf("STDP Debug: Found %d incoming synapses to examine\n", len(synapses))
+	n.logf(slog.LevelDebug, logging.RecordPlasticity, "examining incoming synapses for STDP", "synapses", len(synapses))
 
 	// For each synapse, manually look for LTD and LTP patterns
 	for _, synInfo := range synapses {
@@ -684,8 +821,6 @@ func (n *Neuron) SendSTDPFeedback() {
 			preSpikes := spikesGetter.GetPreSpikeTimes()
 			postSpikes := spikesGetter.GetPostSpikeTimes()
 
-			//fmt.Printf("STDP History: Synapse=%s has %d pre-spikes and %d post-spikes\n",synInfo.ID, len(preSpikes), len(postSpikes))
-
 			// Skip if we don't have both pre and post spikes
 			if len(preSpikes) == 0 || len(postSpikes) == 0 {
 				continue
@@ -721,7 +856,8 @@ func (n *Neuron) SendSTDPFeedback() {
 
 			// If we found a good LTD pair, explicitly apply it
 			if foundLtd {
-				fmt.Printf("STDP Found LTD: post=%v, pre=%v, deltaT=%v\n", bestLtdPostSpike, bestLtdPreSpike, bestLtdDeltaT)
+				n.logf(slog.LevelDebug, logging.RecordPlasticity, "STDP LTD pairing",
+					logging.KeySynapseID, synInfo.ID, "post_spike", bestLtdPostSpike, "pre_spike", bestLtdPreSpike, "delta_t", bestLtdDeltaT)
 
 				// Create and apply LTD adjustment
 				ltdAdjustment := types.PlasticityAdjustment{
@@ -738,7 +874,6 @@ func (n *Neuron) SendSTDPFeedback() {
 					ApplyPlasticity(types.PlasticityAdjustment)
 				}); ok {
 					adjuster.ApplyPlasticity(ltdAdjustment)
-					// fmt.Printf("STDP Applied LTD: deltaT=%v, learning_rate=%.4f\n",bestLtdDeltaT, n.stdpSystem.learningRate)
 				}
 
 				// Since we've found and applied LTD, we can skip the rest of the processing
@@ -751,7 +886,8 @@ func (n *Neuron) SendSTDPFeedback() {
 			if len(postSpikes) > 0 {
 				// Use the last post-spike time
 				lastPostSpike := postSpikes[len(postSpikes)-1]
-				fmt.Printf("STDP Fallback: Using last post-spike at %v\n", lastPostSpike)
+				n.logf(slog.LevelDebug, logging.RecordPlasticity, "STDP fallback to last post-spike",
+					logging.KeySynapseID, synInfo.ID, "post_spike", lastPostSpike)
 
 				// Delegate to standard STDP processing
 				n.stdpSystem.DeliverFeedbackNow(myID, callbacks, lastPostSpike)
@@ -830,7 +966,7 @@ func (n *Neuron) PerformHomeostasisScaling() {
 		"target_rate":     targetRate,
 		"scaling_factor":  scalingFactor,
 		"synapses_scaled": len(incomingSynapses),
-		"timestamp":       time.Now(),
+		"timestamp":       n.now(),
 	})
 }
 
@@ -1017,7 +1153,15 @@ func (n *Neuron) Start() error {
 	}
 
 	n.SetState(types.StateActive)
-	go n.Run() // Run() method is in processing.go
+	if clock := n.stepped.Load(); clock != nil {
+		clock.started.Store(true) // Advanced by Step (see stepping.go)
+		return nil
+	}
+	if group := n.affinity.Load(); group != nil {
+		group.Go(n.Run) // Pinned to the group's CPUs (see affinity.go)
+	} else {
+		go n.Run() // Run() method is in processing.go
+	}
 	return nil
 }
 
@@ -1025,6 +1169,7 @@ func (n *Neuron) Stop() error {
 	var lastErr error
 
 	n.closeOnce.Do(func() {
+		n.markClosed()
 		n.SetState(types.StateStopped)
 
 		// Signal cancellation first
@@ -1035,12 +1180,15 @@ func (n *Neuron) Stop() error {
 		// Wait a moment for the goroutine to notice cancellation
 		time.Sleep(10 * time.Millisecond)
 
-		// Clear callbacks to break circular references
+		// Clear callbacks to break circular references. The processing loop
+		// may still be finishing a tick, so clear under its lock
+		n.stateMutex.Lock()
 		n.matrixCallbacks = nil
+		n.stateMutex.Unlock()
 
 		// Clear output callbacks
 		n.outputsMutex.Lock()
-		n.outputCallbacks = make(map[string]types.OutputCallback)
+		n.outputCallbacks.clear()
 		n.outputsMutex.Unlock()
 
 		// Close synaptic scaling with error handling
@@ -1131,8 +1279,26 @@ func (n *Neuron) SetSynapseWeight(synapseID string, weight float64) error {
 // This method queues messages for delayed delivery without spawning goroutines.
 // ScheduleDelayedDelivery implements the SynapseNeuronInterface requirement
 func (n *Neuron) ScheduleDelayedDelivery(msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration) {
+	// The axonal queue is closed by Stop(); spikes sent through a closed
+	// neuron's axon become dead letters
+	n.lifecycleMutex.RLock()
+	defer n.lifecycleMutex.RUnlock()
+	if n.closed.Load() {
+		n.rejectMessage(msg)
+		return
+	}
+
+	if fifo := n.fifo.Load(); fifo != nil {
+		n.scheduleOrdered(fifo, msg, target, delay)
+		return
+	}
+	if scheduler := n.stepScheduler(); scheduler != nil {
+		scheduler(msg, target, n.chaosDelay(target.ID(), delay))
+		return
+	}
+
 	// Use your existing axon delivery mechanism
-	ScheduleDelayedDelivery(n.deliveryQueue, msg, target, delay)
+	scheduleDeliveryAt(n.deliveryQueue, msg, target, n.now().Add(n.chaosDelay(target.ID(), delay)))
 }
 
 // SetLastFireTime sets the neuron's last fire time (for testing)
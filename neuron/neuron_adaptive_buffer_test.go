@@ -0,0 +1,100 @@
+package neuron
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestAdaptiveInputBuffer verifies that a burst beyond the channel capacity
+// grows the buffer instead of dropping input, that queued input reaches the
+// channel in order, and that the capacity shrinks again once the load falls.
+func TestAdaptiveInputBuffer(t *testing.T) {
+	n := NewNeuron("bursty", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	base := cap(n.inputBuffer)
+
+	var mu sync.Mutex
+	var events []BufferResizeEvent
+	err := n.SetAdaptiveInputBuffer(AdaptiveBufferConfig{
+		Enabled:     true,
+		MaxCapacity: 4 * base,
+		Window:      5 * time.Millisecond,
+		Horizon:     time.Nanosecond,
+		ShrinkAfter: 1,
+		OnResize: func(event BufferResizeEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Nothing is processed yet, so a burst of three channels' worth queues
+	burst := 3 * base
+	for i := 0; i < burst; i++ {
+		n.Receive(types.NeuralSignal{Value: float64(i), SourceID: "burst"})
+	}
+	stats := n.GetAdaptiveBufferStats()
+	if stats.Capacity < burst || stats.Queued != burst-base || stats.Grows == 0 {
+		t.Fatalf("Expected the buffer to grow to hold the burst, got %+v", stats)
+	}
+	if drops := n.GetPriorityDrops()[types.PriorityNormal]; drops != 0 {
+		t.Errorf("Expected no drops during the burst, got %d", drops)
+	}
+	mu.Lock()
+	if len(events) == 0 || events[0].From != base || events[0].NeuronID != "bursty" {
+		t.Errorf("Expected a growth event from %d, got %+v", base, events)
+	}
+	mu.Unlock()
+
+	// Beyond MaxCapacity input is dropped again
+	for i := 0; i < 2*base; i++ {
+		n.Receive(types.NeuralSignal{Value: float64(burst + i), SourceID: "burst"})
+	}
+	if stats := n.GetAdaptiveBufferStats(); stats.Capacity != 4*base || stats.Dropped != int64(base) {
+		t.Errorf("Expected capacity %d with %d dropped, got %+v", 4*base, base, stats)
+	}
+
+	// Draining the channel as the processing loop does keeps arrival order
+	for i := 0; i < 4*base; i++ {
+		msg := <-n.inputBuffer
+		n.refillInputBuffer()
+		if msg.Value != float64(i) {
+			t.Fatalf("Expected message %d, got %v", i, msg.Value)
+		}
+	}
+	if stats := n.GetAdaptiveBufferStats(); stats.Queued != 0 || len(n.inputBuffer) != 0 {
+		t.Fatalf("Expected the buffer drained, got %+v", stats)
+	}
+
+	// Quiet windows shrink the capacity back towards the channel's own
+	for i := 0; i < 4; i++ {
+		time.Sleep(6 * time.Millisecond)
+		n.Receive(types.NeuralSignal{Value: 1, SourceID: "trickle"})
+		<-n.inputBuffer
+	}
+	stats = n.GetAdaptiveBufferStats()
+	if stats.Shrinks == 0 || stats.Capacity >= 4*base || stats.Capacity < base {
+		t.Errorf("Expected the capacity to shrink, got %+v", stats)
+	}
+	mu.Lock()
+	last := events[len(events)-1]
+	mu.Unlock()
+	if last.To >= last.From {
+		t.Errorf("Expected the last event to be a shrink, got %+v", last)
+	}
+
+	if err := n.SetAdaptiveInputBuffer(AdaptiveBufferConfig{Enabled: true, GrowAt: 0.5, ShrinkAt: 0.6}); err == nil {
+		t.Error("Expected error for shrink threshold above grow threshold")
+	}
+	if err := n.SetAdaptiveInputBuffer(AdaptiveBufferConfig{}); err != nil {
+		t.Fatalf("Unexpected error disabling: %v", err)
+	}
+	if _, enabled := n.GetAdaptiveInputBuffer(); enabled {
+		t.Error("Expected the adaptive buffer disabled")
+	}
+}
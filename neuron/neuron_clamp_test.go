@@ -0,0 +1,95 @@
+package neuron
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// spikesPerTicks runs decay ticks by hand and returns the number of spikes.
+func spikesPerTicks(n *Neuron, ticks int) int {
+	before := len(n.homeostatic.firingHistory)
+	for i := 0; i < ticks; i++ {
+		n.processDecayAndHomeostasis()
+	}
+	return len(n.homeostatic.firingHistory) - before
+}
+
+// TestClamp_CurrentClampFICurve verifies that current clamp is silent below
+// the rheobase, that the firing rate grows with the amplitude, and that the
+// clamp expires.
+func TestClamp_CurrentClampFICurve(t *testing.T) {
+	// Threshold 1, decay 0.9: rheobase 0.1
+	var counts []int
+	for _, amplitude := range []float64{0.09, 0.2, 0.5} {
+		n := NewNeuron("fi", 1.0, 0.9, 0, 1.0, 0, 0)
+		if err := n.ClampCurrent(amplitude, time.Hour); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		counts = append(counts, spikesPerTicks(n, 100))
+		if state := n.GetClampState(); state.Mode != ClampModeCurrent || state.Charge <= 0 {
+			t.Errorf("Expected an active current clamp with injected charge, got %+v", state)
+		}
+	}
+	if counts[0] != 0 || counts[1] == 0 || counts[2] <= counts[1] {
+		t.Errorf("Expected an f-I curve with rheobase 0.1, got %v", counts)
+	}
+
+	n := NewNeuron("expiry", 1.0, 0.9, 0, 1.0, 0, 0)
+	if err := n.ClampCurrent(0.5, time.Millisecond); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	if spikesPerTicks(n, 10) != 0 || n.GetClampState().Mode != ClampNone {
+		t.Errorf("Expected an expired clamp, got %+v", n.GetClampState())
+	}
+
+	for _, bad := range []struct {
+		amplitude float64
+		duration  time.Duration
+	}{{math.NaN(), time.Second}, {math.Inf(1), time.Second}, {0.5, 0}, {0.5, -time.Second}} {
+		if err := n.ClampCurrent(bad.amplitude, bad.duration); err == nil {
+			t.Errorf("Expected error for amplitude %f, duration %v", bad.amplitude, bad.duration)
+		}
+	}
+}
+
+// TestClamp_VoltageClampHoldsAndMeasures verifies that voltage clamp holds
+// the accumulator, suppresses firing, measures the clamp charge and releases.
+func TestClamp_VoltageClampHoldsAndMeasures(t *testing.T) {
+	n := NewNeuron("vc", 1.0, 0.9, 0, 1.0, 0, 0)
+	if err := n.ClampAccumulator(1.5); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n.accumulator != 1.5 {
+		t.Errorf("Expected accumulator stepped to 1.5, got %f", n.accumulator)
+	}
+
+	// Input above threshold is absorbed by the clamp
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.5, SourceID: "glu", Timestamp: time.Now()})
+	if got := spikesPerTicks(n, 1); got != 0 || n.accumulator != 1.5 {
+		t.Errorf("Expected clamped accumulator 1.5 without spikes, got %f and %d spikes", n.accumulator, got)
+	}
+	// Step +1.5, input -0.5, leak +0.15
+	if state := n.GetClampState(); state.Mode != ClampModeVoltage || math.Abs(state.Charge-1.15) > 1e-9 {
+		t.Errorf("Expected clamp charge 1.15, got %+v", state)
+	}
+	if len(n.homeostatic.firingHistory) != 0 {
+		t.Errorf("Expected no spikes while clamped, got %d", len(n.homeostatic.firingHistory))
+	}
+
+	// Released above threshold, the neuron fires on the next tick
+	n.ReleaseClamp()
+	if n.GetClampState().Mode != ClampNone {
+		t.Errorf("Expected no clamp after release, got %v", n.GetClampState().Mode)
+	}
+	if got := spikesPerTicks(n, 1); got != 1 {
+		t.Errorf("Expected a spike after release, got %d", got)
+	}
+
+	if err := n.ClampAccumulator(math.NaN()); err == nil {
+		t.Error("Expected error for NaN holding value")
+	}
+}
@@ -0,0 +1,99 @@
+package neuron
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestNeuronDeadLetter_RejectsAfterStop verifies that a stopped neuron rejects
+// input and axonal scheduling gracefully and reports each rejected message.
+func TestNeuronDeadLetter_RejectsAfterStop(t *testing.T) {
+	n := NewNeuron("dead_letter", 1.0, 0.95, 5*time.Millisecond, 1.0, 5.0, 0.1)
+	if err := n.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	target := NewNeuron("target", 1.0, 0.95, 5*time.Millisecond, 1.0, 5.0, 0.1)
+
+	var mu sync.Mutex
+	var rejected []types.NeuralSignal
+	n.SetDeadLetterHandler(func(msg types.NeuralSignal, reason error) {
+		if !errors.Is(reason, ErrNeuronClosed) {
+			t.Errorf("Expected ErrNeuronClosed, got %v", reason)
+		}
+		mu.Lock()
+		rejected = append(rejected, msg)
+		mu.Unlock()
+	})
+
+	n.Receive(types.NeuralSignal{Value: 0.1, SourceID: "before"})
+	if n.GetDeadLetterCount() != 0 || n.IsClosed() {
+		t.Fatal("Running neuron must not reject messages")
+	}
+
+	n.Stop()
+	if !n.IsClosed() {
+		t.Fatal("Expected neuron to report closed after Stop")
+	}
+
+	n.Receive(types.NeuralSignal{Value: 0.5, SourceID: "late_input"})
+	// Scheduling on the closed axonal queue must not panic
+	n.ScheduleDelayedDelivery(types.NeuralSignal{Value: 0.5, SourceID: "late_spike"}, target, time.Millisecond)
+
+	if count := n.GetDeadLetterCount(); count != 2 {
+		t.Errorf("Expected 2 dead letters, got %d", count)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(rejected) != 2 || rejected[0].SourceID != "late_input" || rejected[1].SourceID != "late_spike" {
+		t.Errorf("Unexpected dead letters: %+v", rejected)
+	}
+}
+
+// TestNeuronDeadLetter_ConcurrentStop races axonal scheduling against Stop to
+// verify the closed delivery queue is never written.
+func TestNeuronDeadLetter_ConcurrentStop(t *testing.T) {
+	n := NewNeuron("racing", 1.0, 0.95, 5*time.Millisecond, 1.0, 5.0, 0.1)
+	n.Start()
+	target := NewNeuron("target", 1.0, 0.95, 5*time.Millisecond, 1.0, 5.0, 0.1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				n.ScheduleDelayedDelivery(types.NeuralSignal{Value: 0.1}, target, time.Millisecond)
+			}
+		}()
+	}
+	n.Stop()
+	wg.Wait()
+
+	n.ScheduleDelayedDelivery(types.NeuralSignal{Value: 0.1}, target, time.Millisecond)
+	if n.GetDeadLetterCount() == 0 {
+		t.Error("Expected scheduling after Stop to be counted as dead letter")
+	}
+}
+
+// TestNeuronDeadLetter_StopClearsCallbacks verifies that Stop clears the
+// matrix callbacks while the processing loop may still read them (run with
+// -race).
+func TestNeuronDeadLetter_StopClearsCallbacks(t *testing.T) {
+	n := NewNeuron("clearing", 1.0, 0.95, 5*time.Millisecond, 1.0, 5.0, 0.1)
+	n.SetCallbacks(NewMockNeuronCallbacks(NewMockMatrix()))
+	n.Start()
+
+	// Let the loop read the callbacks on a few decay ticks
+	time.Sleep(5 * time.Millisecond)
+	n.Stop()
+
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	if n.matrixCallbacks != nil {
+		t.Error("Expected Stop to clear the matrix callbacks")
+	}
+}
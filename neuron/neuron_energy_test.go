@@ -0,0 +1,37 @@
+package neuron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestNeuronEnergyMeter verifies that spikes are charged to the neuron.
+func TestNeuronEnergyMeter(t *testing.T) {
+	meter, err := energy.NewMeter(energy.BiologicalCosts())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	n, err := NewNeuronWithOptions("metered", WithThreshold(1.0), WithEnergyMeter(meter))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.6, SourceID: "in", Timestamp: time.Now()})
+	if meter.Neuron("metered").Spikes != 0 {
+		t.Error("Expected no charge below threshold")
+	}
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.6, SourceID: "in", Timestamp: time.Now()})
+	if got := meter.Neuron("metered"); got.Spikes != 1 || got.Energy != energy.ENERGY_ATP_PER_SPIKE {
+		t.Errorf("Expected one spike charged, got %+v", got)
+	}
+
+	n.SetEnergyMeter(nil)
+	time.Sleep(n.GetRefractoryPeriod())
+	n.processIncomingMessage(types.NeuralSignal{Value: 1.5, SourceID: "in", Timestamp: time.Now()})
+	if meter.Total().Spikes != 1 {
+		t.Error("Expected no charge after removing the meter")
+	}
+}
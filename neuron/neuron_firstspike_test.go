@@ -0,0 +1,57 @@
+package neuron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestFirstSpikeLatency verifies that only the first spike after a stimulus
+// marker is reported, and that markers and resets start new trials.
+func TestFirstSpikeLatency(t *testing.T) {
+	n, err := NewNeuronWithOptions("latency", WithThreshold(1.0), WithRefractoryPeriod(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	results := make(chan FirstSpikeLatency, 4)
+	n.SetLatencyChannel(results)
+	spike := func() {
+		time.Sleep(2 * time.Millisecond) // Past the refractory period
+		n.processIncomingMessage(types.NeuralSignal{Value: 1.5, SourceID: "in", Timestamp: time.Now()})
+	}
+
+	spike()
+	if _, ok := n.GetFirstSpikeLatency(); ok {
+		t.Error("Expected no result without a stimulus marker")
+	}
+
+	n.StimulusMarker().Receive(types.NeuralSignal{})
+	time.Sleep(5 * time.Millisecond)
+	spike()
+	first, ok := n.GetFirstSpikeLatency()
+	if !ok || first.Latency < 5*time.Millisecond || first.NeuronID != "latency" {
+		t.Fatalf("Expected a latency of at least 5ms, got %+v (ok=%v)", first, ok)
+	}
+	spike()
+	if again, _ := n.GetFirstSpikeLatency(); again != first || len(results) != 1 {
+		t.Errorf("Expected later spikes to be ignored, got %+v and %d results", again, len(results))
+	}
+	if sent := <-results; sent != first {
+		t.Errorf("Expected the channel to receive %+v, got %+v", first, sent)
+	}
+
+	// A marker in the future does not match earlier spikes
+	n.MarkStimulus(time.Now().Add(time.Hour))
+	spike()
+	if _, ok := n.GetFirstSpikeLatency(); ok {
+		t.Error("Expected spikes before onset to be ignored")
+	}
+
+	n.MarkStimulus(time.Time{})
+	n.ResetLatencyReadout()
+	spike()
+	if _, ok := n.GetFirstSpikeLatency(); ok || len(results) != 0 {
+		t.Error("Expected no result after reset")
+	}
+}
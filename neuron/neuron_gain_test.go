@@ -0,0 +1,91 @@
+package neuron
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestGain_ScalesSynapticInput verifies base gain, neuromodulator-driven
+// gain and their combination with gain ports.
+func TestGain_ScalesSynapticInput(t *testing.T) {
+	n, err := NewNeuronWithOptions("gain",
+		WithGain(2.0),
+		WithGainModulator(types.LigandDopamine, GainModulatorConfig{Sensitivity: 0.5, TimeConstant: time.Hour}),
+		WithInputPort("attention", InputPortConfig{Mode: InputPortGain, Scale: 1, TimeConstant: time.Hour}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n.GetGain() != 2.0 {
+		t.Fatalf("Expected base gain 2, got %f", n.GetGain())
+	}
+
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.1, SourceID: "basal"})
+	if got := accumulatorOf(n); math.Abs(got-0.2) > 1e-9 {
+		t.Errorf("Expected input doubled to 0.2, got %f", got)
+	}
+
+	// Dopamine is not a receptor here: it changes the gain, not the potential
+	before := accumulatorOf(n)
+	n.Bind(types.LigandDopamine, "vta", 2.0)
+	if accumulatorOf(n) != before {
+		t.Errorf("Expected the modulator to leave the accumulator at %f, got %f", before, accumulatorOf(n))
+	}
+	if got := n.GetEffectiveGain(); math.Abs(got-4.0) > 1e-6 { // 2 × (1 + 0.5 × 2)
+		t.Errorf("Expected effective gain 4, got %f", got)
+	}
+
+	// Gain ports multiply on top
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.5, SourceID: "pulvinar", Port: "attention"})
+	if got := n.GetEffectiveGain(); math.Abs(got-6.0) > 1e-6 { // 4 × (1 + 1 × 0.5)
+		t.Errorf("Expected effective gain 6, got %f", got)
+	}
+
+	// Zero gain silences synaptic input
+	n.RemoveGainModulator(types.LigandDopamine)
+	n.RemoveInputPort("attention")
+	if err := n.SetGain(0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	before = accumulatorOf(n)
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.5, SourceID: "basal"})
+	if accumulatorOf(n) != before {
+		t.Errorf("Expected zero gain to block input, accumulator moved from %f to %f", before, accumulatorOf(n))
+	}
+}
+
+// TestGain_ModulatorDecayAndValidation verifies modulator decay, the
+// non-negative gain floor and configuration checks.
+func TestGain_ModulatorDecayAndValidation(t *testing.T) {
+	start := time.Now()
+	modulator := &gainModulator{config: GainModulatorConfig{Sensitivity: 1, TimeConstant: 10 * time.Millisecond}, level: 1, updated: start}
+	if got := modulator.factor(start.Add(10 * time.Millisecond)); math.Abs(got-(1+math.Exp(-1))) > 1e-9 {
+		t.Errorf("Expected factor 1 + 1/e after one time constant, got %f", got)
+	}
+
+	n := NewNeuron("gain_floor", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	if n.GetGain() != 1 || n.GetEffectiveGain() != 1 {
+		t.Errorf("Expected unity gain by default, got %f / %f", n.GetGain(), n.GetEffectiveGain())
+	}
+	if err := n.SetGainModulator(types.LigandSerotonin, GainModulatorConfig{Sensitivity: -1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	n.Bind(types.LigandSerotonin, "raphe", 5)
+	if got := n.GetEffectiveGain(); got != 0 {
+		t.Errorf("Expected suppressive gain floored at 0, got %f", got)
+	}
+
+	for _, gain := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if err := n.SetGain(gain); err == nil {
+			t.Errorf("Expected gain %f to be rejected", gain)
+		}
+	}
+	if err := n.SetGainModulator(types.LigandDopamine, GainModulatorConfig{TimeConstant: -time.Second}); err == nil {
+		t.Error("Expected a negative time constant to be rejected")
+	}
+	if _, err := NewNeuronWithOptions("gain_invalid", WithGain(-2)); err == nil {
+		t.Error("Expected an invalid gain option to be rejected")
+	}
+}
@@ -0,0 +1,90 @@
+package neuron
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// heterosynapticInputs registers three mock input synapses with the given weights.
+func heterosynapticInputs(n *Neuron, weights ...float64) []*MockSynapticProcessor {
+	inputs := make([]*MockSynapticProcessor, len(weights))
+	for i, w := range weights {
+		inputs[i] = NewMockSynapticProcessor(string(rune('a' + i)))
+		inputs[i].SetWeight(w)
+		n.RegisterInputSynapse(inputs[i].ID(), inputs[i])
+	}
+	return inputs
+}
+
+// TestHeterosynaptic_DepressesUnchangedInputs verifies that a strong gain on
+// one input is taken from the others in proportion to their weight.
+func TestHeterosynaptic_DepressesUnchangedInputs(t *testing.T) {
+	config := DefaultHeterosynapticConfig()
+	config.DepressionRatio = 1.0
+	n, err := NewNeuronWithOptions("hetero", WithHeterosynapticPlasticity(config))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	inputs := heterosynapticInputs(n, 1.0, 1.0, 2.0)
+
+	n.withHeterosynapticPlasticity(func() { inputs[0].SetWeight(1.3) })
+
+	if got := inputs[1].GetWeight(); math.Abs(got-0.9) > 1e-9 {
+		t.Errorf("Expected input b depressed to 0.9, got %f", got)
+	}
+	if got := inputs[2].GetWeight(); math.Abs(got-1.8) > 1e-9 {
+		t.Errorf("Expected input c depressed to 1.8, got %f", got)
+	}
+	total := inputs[0].GetWeight() + inputs[1].GetWeight() + inputs[2].GetWeight()
+	if math.Abs(total-4.0) > 1e-9 {
+		t.Errorf("Expected total weight conserved at 4.0, got %f", total)
+	}
+	if n.GetHeterosynapticEventCount() != 1 {
+		t.Errorf("Expected one heterosynaptic event, got %d", n.GetHeterosynapticEventCount())
+	}
+
+	// Gains below the threshold leave the other inputs alone
+	n.withHeterosynapticPlasticity(func() { inputs[0].SetWeight(1.301) })
+	if got := inputs[1].GetWeight(); math.Abs(got-0.9) > 1e-9 {
+		t.Errorf("Expected no depression below threshold, got %f", got)
+	}
+
+	// Unregistered synapses are no longer affected
+	n.UnregisterInputSynapse("c")
+	if n.GetInputSynapseCount() != 2 {
+		t.Fatalf("Expected two inputs after unregistering, got %d", n.GetInputSynapseCount())
+	}
+	n.withHeterosynapticPlasticity(func() { inputs[0].SetWeight(1.5) })
+	if got := inputs[2].GetWeight(); math.Abs(got-1.8) > 1e-9 {
+		t.Errorf("Expected unregistered input unchanged, got %f", got)
+	}
+	if got := inputs[1].GetWeight(); math.Abs(got-0.701) > 1e-9 {
+		t.Errorf("Expected remaining input to absorb the depression, got %f", got)
+	}
+}
+
+// TestHeterosynaptic_DisabledAndValidation verifies the default-off behavior
+// and configuration checks.
+func TestHeterosynaptic_DisabledAndValidation(t *testing.T) {
+	n := NewNeuron("plain", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	inputs := heterosynapticInputs(n, 1.0, 1.0)
+	n.withHeterosynapticPlasticity(func() { inputs[0].SetWeight(2.0) })
+	if inputs[1].GetWeight() != 1.0 {
+		t.Errorf("Expected no heterosynaptic depression by default, got %f", inputs[1].GetWeight())
+	}
+
+	bad := DefaultHeterosynapticConfig()
+	bad.DepressionRatio = 1.5
+	if err := n.SetHeterosynapticPlasticity(bad); err == nil {
+		t.Error("Expected error for depression ratio above 1")
+	}
+	bad = DefaultHeterosynapticConfig()
+	bad.PotentiationThreshold = 0
+	if _, err := NewNeuronWithOptions("bad", WithHeterosynapticPlasticity(bad)); err == nil {
+		t.Error("Expected error for non-positive threshold")
+	}
+	if err := n.SetHeterosynapticPlasticity(HeterosynapticConfig{}); err != nil {
+		t.Errorf("Expected disabled config to be accepted: %v", err)
+	}
+}
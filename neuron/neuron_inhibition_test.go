@@ -0,0 +1,114 @@
+package neuron
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// inhibit delivers count inhibitory inputs of the given strength.
+func inhibit(n *Neuron, count int, value float64) {
+	for i := 0; i < count; i++ {
+		n.processIncomingMessage(types.NeuralSignal{Value: value, SourceID: "gaba", Timestamp: time.Now()})
+	}
+}
+
+// TestInhibitionFloor_Modes verifies that sustained inhibition is unbounded by
+// default, clamped at the floor, or saturates towards the reversal potential.
+func TestInhibitionFloor_Modes(t *testing.T) {
+	unbounded := NewNeuron("unbounded", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	inhibit(unbounded, 20, -0.5)
+	if got := unbounded.accumulator; math.Abs(got+10) > 1e-9 {
+		t.Errorf("Expected unbounded accumulator -10, got %f", got)
+	}
+
+	clamped, err := NewNeuronWithOptions("clamped", WithInhibitionFloor(INHIBITION_FLOOR_DEFAULT))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	inhibit(clamped, 20, -0.5)
+	if got := clamped.accumulator; got != INHIBITION_FLOOR_DEFAULT {
+		t.Errorf("Expected clamp at %f, got %f", INHIBITION_FLOOR_DEFAULT, got)
+	}
+
+	reversal, err := NewNeuronWithOptions("reversal", WithReversalPotential(-1.0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Full effect at rest, half the driving force left after the first input
+	inhibit(reversal, 1, -0.5)
+	if got := reversal.accumulator; math.Abs(got+0.5) > 1e-9 {
+		t.Errorf("Expected full inhibition at rest, got %f", got)
+	}
+	inhibit(reversal, 1, -0.5)
+	if got := reversal.accumulator; math.Abs(got+0.75) > 1e-9 {
+		t.Errorf("Expected driving-force scaled inhibition -0.75, got %f", got)
+	}
+	inhibit(reversal, 50, -5.0)
+	if got := reversal.accumulator; got < -1.0 || got > -0.99 {
+		t.Errorf("Expected saturation at the reversal potential, got %f", got)
+	}
+
+	// Excitation is never scaled and recovers from the bounded floor
+	reversal.processIncomingMessage(types.NeuralSignal{Value: 0.5, SourceID: "glu", Timestamp: time.Now()})
+	if got := reversal.accumulator; got < -0.51 || got > -0.49 {
+		t.Errorf("Expected unscaled excitation, got %f", got)
+	}
+}
+
+// TestInhibitionFloor_Configuration verifies setter, getter and validation.
+func TestInhibitionFloor_Configuration(t *testing.T) {
+	n := NewNeuron("config", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	if mode, _ := n.GetInhibitionFloor(); mode != InhibitionFloorNone {
+		t.Errorf("Expected unbounded default, got %v", mode)
+	}
+
+	inhibit(n, 4, -1.0)
+	if err := n.SetInhibitionFloor(InhibitionFloorClamp, -2.0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n.accumulator != -2.0 {
+		t.Errorf("Expected existing hyperpolarization raised to floor, got %f", n.accumulator)
+	}
+	if mode, floor := n.GetInhibitionFloor(); mode != InhibitionFloorClamp || floor != -2.0 {
+		t.Errorf("Expected (clamp, -2), got (%v, %f)", mode, floor)
+	}
+
+	for _, floor := range []float64{0, 0.5, math.NaN(), math.Inf(-1)} {
+		if err := n.SetInhibitionFloor(InhibitionFloorReversal, floor); err == nil {
+			t.Errorf("Expected error for floor %f", floor)
+		}
+	}
+	if err := n.SetInhibitionFloor(InhibitionFloorMode(7), -1); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+	if _, err := NewNeuronWithOptions("bad", WithInhibitionFloor(1.0)); err == nil {
+		t.Error("Expected options validation to reject a positive floor")
+	}
+}
+
+// TestNonFiniteInputsDiscarded verifies that NaN and infinite inputs, and
+// input that would overflow the accumulator, leave it unchanged and are
+// counted, both on arrival and at integration.
+func TestNonFiniteInputsDiscarded(t *testing.T) {
+	n := NewNeuron("finite", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+
+	n.Receive(types.NeuralSignal{Value: math.Inf(1), SourceID: "syn"})
+	n.Receive(types.NeuralSignal{Value: math.NaN(), SourceID: "syn"})
+	inhibit(n, 1, math.Inf(-1))
+	inhibit(n, 1, 0.5)
+	if got := n.accumulator; got != 0.5 {
+		t.Errorf("Expected only the finite input integrated, got %g", got)
+	}
+
+	n.accumulator = math.MaxFloat64
+	if got := n.integrateUnsafe(math.MaxFloat64); got != 0 || n.accumulator != math.MaxFloat64 {
+		t.Errorf("Expected overflowing input discarded, got change %g and accumulator %g", got, n.accumulator)
+	}
+
+	if got := n.GetNonFiniteInputCount(); got != 4 {
+		t.Errorf("Expected 4 discarded inputs, got %d", got)
+	}
+}
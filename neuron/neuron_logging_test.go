@@ -0,0 +1,58 @@
+package neuron
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestNeuronLogging_IntegrationAndSpikeRecords verifies structured records for
+// integrated inputs and spikes, and that verbosity follows the handler level.
+func TestNeuronLogging_IntegrationAndSpikeRecords(t *testing.T) {
+	var buf bytes.Buffer
+	handler := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: logging.LevelTrace})
+	n, err := NewNeuronWithOptions("logged", WithThreshold(1.0), WithLogHandler(handler))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.6, SourceID: "in", Timestamp: time.Now()})
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.6, SourceID: "in", Timestamp: time.Now()})
+
+	var kinds []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid record %q: %v", line, err)
+		}
+		if record[logging.KeyNeuronID] != "logged" {
+			t.Errorf("Record without neuron ID: %v", record)
+		}
+		kinds = append(kinds, record[logging.KeyRecord].(string))
+	}
+	// The spike is logged while the second input is processed
+	expected := []string{logging.RecordIntegration, logging.RecordSpike, logging.RecordIntegration}
+	if strings.Join(kinds, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected records %v, got %v", expected, kinds)
+	}
+
+	// At Debug level only the spike remains
+	buf.Reset()
+	n.SetLogHandler(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	time.Sleep(n.GetRefractoryPeriod())
+	n.processIncomingMessage(types.NeuralSignal{Value: 1.5, SourceID: "in", Timestamp: time.Now()})
+	if strings.Count(buf.String(), "\n") != 1 || !strings.Contains(buf.String(), logging.RecordSpike) {
+		t.Errorf("Expected a single spike record, got %q", buf.String())
+	}
+
+	n.SetLogHandler(nil)
+	if n.GetLogger() != nil {
+		t.Error("Expected logging to be disabled")
+	}
+}
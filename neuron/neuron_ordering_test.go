@@ -0,0 +1,129 @@
+package neuron
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// orderRecorder records the values it receives, in arrival order.
+type orderRecorder struct {
+	*component.BaseComponent
+	mu     sync.Mutex
+	values []float64
+}
+
+func newOrderRecorder(id string) *orderRecorder {
+	return &orderRecorder{BaseComponent: component.NewBaseComponent(id, types.TypeNeuron, types.Position3D{})}
+}
+
+func (r *orderRecorder) Receive(msg types.NeuralSignal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values = append(r.values, msg.Value)
+}
+
+func (r *orderRecorder) received() []float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]float64(nil), r.values...)
+}
+
+// TestFIFODelivery_PreventsOvertaking verifies that a spike with a shorter
+// delay overtakes an earlier one by default, and is held back and counted
+// with FIFO delivery enabled.
+func TestFIFODelivery_PreventsOvertaking(t *testing.T) {
+	send := func(n *Neuron, target *orderRecorder) []float64 {
+		if err := n.Start(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer n.Stop()
+		n.ScheduleDelayedDelivery(types.NeuralSignal{Value: 1}, target, 20*time.Millisecond)
+		n.ScheduleDelayedDelivery(types.NeuralSignal{Value: 2}, target, 2*time.Millisecond)
+		time.Sleep(50 * time.Millisecond)
+		return target.received()
+	}
+
+	unordered := NewNeuron("unordered", 1, 0.95, 5*time.Millisecond, 1, 5, 0.1)
+	if got := send(unordered, newOrderRecorder("a")); len(got) != 2 || got[0] != 2 {
+		t.Errorf("Expected the shorter delay to overtake, got %v", got)
+	}
+	if stats := unordered.GetDeliveryOrderStats(); stats != (DeliveryOrderStats{}) {
+		t.Errorf("Expected no ordering stats while disabled, got %+v", stats)
+	}
+
+	ordered, err := NewNeuronWithOptions("ordered", WithFIFODelivery())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !ordered.FIFODelivery() {
+		t.Fatal("Expected FIFO delivery from the option")
+	}
+	if got := send(ordered, newOrderRecorder("b")); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("Expected send order [1 2], got %v", got)
+	}
+	stats := ordered.GetDeliveryOrderStats()
+	if stats.Scheduled != 2 || stats.Reordered != 1 || stats.Unordered != 0 {
+		t.Errorf("Expected one prevented reordering, got %+v", stats)
+	}
+
+	// Ordering is per target: another target is not held back
+	other := NewNeuron("per_target", 1, 0.95, 5*time.Millisecond, 1, 5, 0.1)
+	other.SetFIFODelivery(true)
+	other.Start()
+	defer other.Stop()
+	slow, fast := newOrderRecorder("slow"), newOrderRecorder("fast")
+	other.ScheduleDelayedDelivery(types.NeuralSignal{Value: 1}, slow, 30*time.Millisecond)
+	other.ScheduleDelayedDelivery(types.NeuralSignal{Value: 2}, fast, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	if len(fast.received()) != 1 || len(slow.received()) != 0 {
+		t.Errorf("Expected only the fast target served after 10ms, got %v and %v", fast.received(), slow.received())
+	}
+	if other.GetDeliveryOrderStats().Reordered != 0 {
+		t.Errorf("Expected no reordering across targets, got %+v", other.GetDeliveryOrderStats())
+	}
+}
+
+// TestFIFODelivery_ZeroDelaySynapse verifies that a zero-delay synapse on a
+// FIFO neuron cannot overtake a delayed spike to the same target, and that
+// spikes due at the same time keep their scheduling order.
+func TestFIFODelivery_ZeroDelaySynapse(t *testing.T) {
+	pre := NewNeuron("fifo_pre", 1, 0.95, 5*time.Millisecond, 1, 5, 0.1)
+	pre.SetFIFODelivery(true)
+	pre.Start()
+	defer pre.Stop()
+	post := newOrderRecorder("fifo_post")
+
+	delayed, err := synapse.NewSynapse("delayed", pre, post, synapse.WithWeight(1), synapse.WithDelay(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	immediate, err := synapse.NewSynapse("immediate", pre, post, synapse.WithWeight(1), synapse.WithDelay(0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	delayed.Transmit(1)
+	immediate.Transmit(2)
+	immediate.Transmit(3)
+	if got := post.received(); len(got) != 0 {
+		t.Fatalf("Expected zero-delay spikes to wait behind the delayed one, got %v", got)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if got := post.received(); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("Expected send order [1 2 3], got %v", got)
+	}
+	if stats := pre.GetDeliveryOrderStats(); stats.Reordered != 2 {
+		t.Errorf("Expected two prevented reorderings, got %+v", stats)
+	}
+
+	pre.SetFIFODelivery(false)
+	immediate.Transmit(4)
+	if got := post.received(); len(got) != 4 || got[3] != 4 {
+		t.Errorf("Expected immediate delivery after disabling, got %v", got)
+	}
+}
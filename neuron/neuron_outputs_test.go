@@ -0,0 +1,54 @@
+package neuron
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestOutputs_FiringStampsIDHandles verifies that a spike reaches every
+// output callback with the synapse's interned ID handle, and that replacing
+// and removing callbacks keeps the dense table consistent.
+func TestOutputs_FiringStampsIDHandles(t *testing.T) {
+	n := NewNeuron("outputs", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	var received []types.NeuralSignal
+	callback := func(target string) types.OutputCallback {
+		return types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				received = append(received, msg)
+				return nil
+			},
+			GetWeight:   func() float64 { return 1 },
+			GetDelay:    func() time.Duration { return time.Millisecond },
+			GetTargetID: func() string { return target },
+		}
+	}
+	for i := 0; i < 4; i++ {
+		n.AddOutputCallback(fmt.Sprintf("out_%d", i), callback(fmt.Sprintf("post_%d", i)))
+	}
+	n.AddOutputCallback("out_3", callback("post_replaced"))
+	n.RemoveOutputCallback("out_1")
+	n.RemoveOutputCallback("unknown")
+	if n.GetConnectionCount() != 3 {
+		t.Fatalf("Expected 3 outputs, got %d", n.GetConnectionCount())
+	}
+
+	n.transmitToOutputSynapsesWithDelay(1.0, time.Now())
+	sort.Slice(received, func(i, j int) bool { return received[i].SynapseID < received[j].SynapseID })
+	targets := map[string]string{"out_0": "post_0", "out_2": "post_2", "out_3": "post_replaced"}
+	if len(received) != len(targets) {
+		t.Fatalf("Expected %d signals, got %d", len(targets), len(received))
+	}
+	for _, msg := range received {
+		handle, ok := types.DefaultIDs.Lookup(msg.SynapseID)
+		if !ok || msg.SynapseIDHandle != handle {
+			t.Errorf("Expected %s stamped with its ID handle %d, got %d", msg.SynapseID, handle, msg.SynapseIDHandle)
+		}
+		if msg.TargetID != targets[msg.SynapseID] {
+			t.Errorf("Expected %s to reach %s, got %s", msg.SynapseID, targets[msg.SynapseID], msg.TargetID)
+		}
+	}
+}
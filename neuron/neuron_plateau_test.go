@@ -0,0 +1,76 @@
+package neuron
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestDendriticPlateau verifies that a sustained local depolarization emits
+// one plateau event per episode and lowers the firing threshold.
+func TestDendriticPlateau(t *testing.T) {
+	config := PlateauConfig{
+		Enabled:            true,
+		Threshold:          2.0,
+		MinDuration:        5 * time.Millisecond,
+		ThresholdReduction: 0.3,
+		Duration:           200 * time.Millisecond,
+		TimeConstant:       time.Second, // Keep the potential up for the test
+	}
+	n, err := NewNeuronWithOptions("plateau", WithPlateauDetection(config))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var events []PlateauEvent
+	n.SetPlateauHandler(func(event PlateauEvent) { events = append(events, event) })
+
+	// Below threshold: no plateau however long it lasts
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.5, SourceID: "drive", Timestamp: time.Now()})
+	n.processDecayAndHomeostasis()
+	time.Sleep(10 * time.Millisecond)
+	n.processDecayAndHomeostasis()
+	if len(events) != 0 {
+		t.Fatalf("Expected no plateau for weak input, got %d", len(events))
+	}
+
+	// Strong input must be sustained for MinDuration before the event
+	n.processIncomingMessage(types.NeuralSignal{Value: 2.0, SourceID: "drive", Timestamp: time.Now()})
+	n.processDecayAndHomeostasis()
+	if len(events) != 0 || n.IsPlateauActive() {
+		t.Fatal("Expected no plateau before MinDuration")
+	}
+	time.Sleep(10 * time.Millisecond)
+	n.processDecayAndHomeostasis()
+	n.processDecayAndHomeostasis()
+	if len(events) != 1 || n.GetPlateauEventCount() != 1 {
+		t.Fatalf("Expected exactly one plateau event, got %d", len(events))
+	}
+
+	event := events[0]
+	if event.NeuronID != "plateau" || event.Time.Sub(event.Onset) < config.MinDuration || event.LocalPotential < config.Threshold {
+		t.Errorf("Unexpected event %+v", event)
+	}
+	if !n.IsPlateauActive() {
+		t.Error("Expected plateau to be active")
+	}
+	n.stateMutex.Lock()
+	threshold := n.threshold
+	n.stateMutex.Unlock()
+	if got := n.GetFiringThreshold(); math.Abs(got-threshold*0.7) > 1e-9 {
+		t.Errorf("Expected firing threshold %f during plateau, got %f", threshold*0.7, got)
+	}
+
+	// Disabling ends the plateau
+	if err := n.SetPlateauDetection(PlateauConfig{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n.IsPlateauActive() || n.GetFiringThreshold() != threshold {
+		t.Error("Expected disabling to restore the threshold")
+	}
+
+	if _, err := NewNeuronWithOptions("bad", WithPlateauDetection(PlateauConfig{Enabled: true, Threshold: 1, Duration: time.Second, ThresholdReduction: 1})); err == nil {
+		t.Error("Expected error for threshold reduction 1.0")
+	}
+}
@@ -0,0 +1,117 @@
+package neuron
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// accumulatorOf reads the accumulator under the state lock.
+func accumulatorOf(n *Neuron) float64 {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.accumulator
+}
+
+// TestInputPorts_IntegrationRules verifies additive, gain and threshold
+// ports and that unported input stays somatic.
+func TestInputPorts_IntegrationRules(t *testing.T) {
+	n, err := NewNeuronWithOptions("ports",
+		WithInputPort(INPUT_PORT_APICAL, ApicalPortConfig()),
+		WithInputPort("gain", InputPortConfig{Mode: InputPortGain, Scale: 0.5, TimeConstant: time.Hour}),
+		WithInputPort("excitability", InputPortConfig{Mode: InputPortThreshold, Scale: 0.25, TimeConstant: time.Hour}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ports := n.GetInputPorts(); len(ports) != 3 || ports[0] != INPUT_PORT_APICAL {
+		t.Fatalf("Expected three sorted ports, got %v", ports)
+	}
+	threshold := n.GetThreshold()
+
+	// Apical input is attenuated
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.4, SourceID: "tuft", Port: INPUT_PORT_APICAL})
+	if got := accumulatorOf(n); math.Abs(got-0.4*INPUT_PORT_APICAL_SCALE_DEFAULT) > 1e-9 {
+		t.Errorf("Expected attenuated apical input %f, got %f", 0.4*INPUT_PORT_APICAL_SCALE_DEFAULT, got)
+	}
+
+	// Modulatory input changes the gain, not the potential
+	before := accumulatorOf(n)
+	n.processIncomingMessage(types.NeuralSignal{Value: 2.0, SourceID: "neuromodulator", Port: "gain"})
+	if accumulatorOf(n) != before {
+		t.Errorf("Expected gain input to leave the accumulator at %f, got %f", before, accumulatorOf(n))
+	}
+	if _, level, _ := n.GetInputPort("gain"); math.Abs(level-2.0) > 1e-6 {
+		t.Errorf("Expected gain level 2, got %f", level)
+	}
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.1, SourceID: "basal"}) // gain 1 + 0.5 × 2
+	if got := accumulatorOf(n) - before; math.Abs(got-0.2) > 1e-6 {
+		t.Errorf("Expected somatic input doubled to 0.2, got %f", got)
+	}
+
+	// Unknown ports are somatic
+	before = accumulatorOf(n)
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.1, SourceID: "basal", Port: "unknown"})
+	if got := accumulatorOf(n) - before; math.Abs(got-0.2) > 1e-6 {
+		t.Errorf("Expected unknown-port input treated as somatic, got %f", got)
+	}
+
+	// Raising excitability lowers the threshold until the potential suffices
+	if !n.GetLastFireTime().IsZero() {
+		t.Fatal("Expected no spike yet")
+	}
+	n.processIncomingMessage(types.NeuralSignal{Value: 2.0, SourceID: "neuromodulator", Port: "excitability"})
+	if got := n.GetFiringThreshold(); math.Abs(got-threshold*0.5) > 1e-6 {
+		t.Errorf("Expected threshold halved to %f, got %f", threshold*0.5, got)
+	}
+	if n.GetLastFireTime().IsZero() {
+		t.Errorf("Expected the lowered threshold to fire the neuron at potential %f", before+0.2)
+	}
+
+	// Removing a port makes its input somatic again
+	n.RemoveInputPort("gain")
+	if _, _, ok := n.GetInputPort("gain"); ok {
+		t.Error("Expected the gain port removed")
+	}
+}
+
+// TestInputPorts_LevelDecayAndValidation verifies modulatory decay, the
+// threshold floor and configuration checks.
+func TestInputPorts_LevelDecayAndValidation(t *testing.T) {
+	port := &inputPort{config: InputPortConfig{Mode: InputPortGain, Scale: 1, TimeConstant: 10 * time.Millisecond}}
+	start := time.Now()
+	port.decayTo(start)
+	port.level = 1
+	port.decayTo(start.Add(10 * time.Millisecond))
+	if math.Abs(port.level-math.Exp(-1)) > 1e-9 {
+		t.Errorf("Expected level 1/e after one time constant, got %f", port.level)
+	}
+
+	n := NewNeuron("ports_floor", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	if err := n.SetInputPort("excitability", InputPortConfig{Mode: InputPortThreshold, Scale: 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config, _, _ := n.GetInputPort("excitability"); config.TimeConstant != INPUT_PORT_TIME_CONSTANT_DEFAULT {
+		t.Errorf("Expected the default time constant, got %v", config.TimeConstant)
+	}
+	n.processIncomingMessage(types.NeuralSignal{Value: 10, Port: "excitability"})
+	if got := n.GetFiringThreshold(); math.Abs(got-INPUT_PORT_MIN_THRESHOLD_FACTOR) > 1e-6 {
+		t.Errorf("Expected the threshold floored at %f, got %f", INPUT_PORT_MIN_THRESHOLD_FACTOR, got)
+	}
+
+	invalid := map[string]InputPortConfig{
+		"":       {},
+		"mode":   {Mode: InputPortMode(7)},
+		"scale":  {Scale: math.NaN()},
+		"negtau": {Mode: InputPortGain, TimeConstant: -time.Second},
+	}
+	for name, config := range invalid {
+		if err := n.SetInputPort(name, config); err == nil {
+			t.Errorf("Expected port %q %+v to be rejected", name, config)
+		}
+	}
+	if _, err := NewNeuronWithOptions("ports_invalid", WithInputPort("bad", InputPortConfig{Mode: -1})); err == nil {
+		t.Error("Expected an invalid port option to be rejected")
+	}
+}
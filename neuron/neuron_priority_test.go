@@ -0,0 +1,70 @@
+package neuron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestInputPriority_BoundedBuffer verifies that background input leaves
+// headroom for normal input, that critical input has its own lane when the
+// shared buffer is full, and that losses are counted per priority.
+func TestInputPriority_BoundedBuffer(t *testing.T) {
+	n := NewNeuron("prio", 10, 0.95, 0, 1.0, 0, 0)
+	signal := func(priority types.Priority) types.NeuralSignal {
+		return types.NeuralSignal{Value: 0.01, SourceID: "src", Timestamp: time.Now(), Priority: priority}
+	}
+
+	headroom := int(INPUT_BACKGROUND_HEADROOM * float64(cap(n.inputBuffer)))
+	for i := 0; i < headroom; i++ {
+		n.Receive(signal(types.PriorityBackground))
+	}
+	n.Receive(signal(types.PriorityBackground))
+	n.Receive(signal(types.PriorityNormal))
+	if len(n.inputBuffer) != headroom+1 {
+		t.Errorf("Expected background input to stop at %d queued, got %d", headroom, len(n.inputBuffer))
+	}
+
+	for len(n.inputBuffer) < cap(n.inputBuffer) {
+		n.Receive(signal(types.PriorityNormal))
+	}
+	n.Receive(signal(types.PriorityNormal))
+	n.Receive(signal(types.PriorityCritical))
+	if len(n.criticalBuffer) != 1 {
+		t.Errorf("Expected critical input in its own lane, got %d", len(n.criticalBuffer))
+	}
+
+	drops := n.GetPriorityDrops()
+	if drops[types.PriorityBackground] != 1 || drops[types.PriorityNormal] != 1 || drops[types.PriorityCritical] != 0 {
+		t.Errorf("Unexpected drops per priority: %v", drops)
+	}
+
+	// Critical input is integrated before the shared buffer is served
+	n.processCriticalInputs()
+	if len(n.criticalBuffer) != 0 || n.accumulator != 0.01 {
+		t.Errorf("Expected the critical lane drained first, got %d left and accumulator %f", len(n.criticalBuffer), n.accumulator)
+	}
+}
+
+// TestInputPriority_RealTimeShedding verifies that real-time mode sheds
+// background-priority input but never critical input from a background
+// source.
+func TestInputPriority_RealTimeShedding(t *testing.T) {
+	n, err := NewNeuronWithOptions("prio", WithThreshold(10), WithLatencyBudget(LatencyBudget{
+		Budget: time.Millisecond, Shed: true, Background: []string{"noise"},
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	late := time.Now().Add(-time.Second)
+
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.5, SourceID: "sensor", Timestamp: late, Priority: types.PriorityBackground})
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.3, SourceID: "noise", Timestamp: late, Priority: types.PriorityCritical})
+	if n.accumulator != 0.3 || n.GetDeadlineStats().Shed != 1 {
+		t.Errorf("Expected only the critical input integrated, got %f and %+v", n.accumulator, n.GetDeadlineStats())
+	}
+	if drops := n.GetPriorityDrops(); drops[types.PriorityBackground] != 1 || drops[types.PriorityCritical] != 0 {
+		t.Errorf("Expected one shed background input, got %v", drops)
+	}
+}
@@ -0,0 +1,83 @@
+package neuron
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestLatencyBudget_DeadlineMisses verifies that late input is counted as a
+// miss, that late background input is shed while late sensory input is
+// still integrated, and that input within budget clears the overload.
+func TestLatencyBudget_DeadlineMisses(t *testing.T) {
+	n, err := NewNeuronWithOptions("rt", WithThreshold(10), WithLatencyBudget(LatencyBudget{
+		Budget: 5 * time.Millisecond, Shed: true, Background: []string{"noise"},
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	late := time.Now().Add(-20 * time.Millisecond)
+
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.5, SourceID: "noise", Timestamp: late})
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.3, SourceID: "sensor", Timestamp: late})
+	if math.Abs(n.accumulator-0.3) > 1e-9 {
+		t.Errorf("Expected only the sensory input integrated, got %f", n.accumulator)
+	}
+	stats := n.GetDeadlineStats()
+	if stats.Checked != 2 || stats.Misses != 2 || stats.Shed != 1 || !stats.Overloaded {
+		t.Errorf("Unexpected stats after late input: %+v", stats)
+	}
+	if stats.MaxLatency < 20*time.Millisecond {
+		t.Errorf("Expected max latency of at least 20ms, got %v", stats.MaxLatency)
+	}
+
+	// Timely input is integrated and ends the overload
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.2, SourceID: "noise", Timestamp: time.Now()})
+	stats = n.GetDeadlineStats()
+	if math.Abs(n.accumulator-0.5) > 1e-9 || stats.Misses != 2 || stats.Overloaded {
+		t.Errorf("Expected timely background input integrated, got %f and %+v", n.accumulator, stats)
+	}
+
+	// Untimestamped input skips the check; disabling clears the counters
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.1, SourceID: "noise"})
+	if n.GetDeadlineStats().Checked != 3 {
+		t.Error("Expected untimestamped input to skip the deadline check")
+	}
+	if err := n.SetLatencyBudget(LatencyBudget{Budget: -time.Millisecond}); err == nil {
+		t.Error("Expected error for negative budget")
+	}
+	if err := n.SetLatencyBudget(LatencyBudget{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, enabled := n.GetLatencyBudget(); enabled || n.GetDeadlineStats() != (DeadlineStats{}) {
+		t.Error("Expected real-time mode disabled")
+	}
+}
+
+// TestLatencyBudget_ShedOnArrival verifies that an overloaded neuron drops
+// background input before queuing it until the backlog drains.
+func TestLatencyBudget_ShedOnArrival(t *testing.T) {
+	n := NewNeuron("rt", 10, 0.95, 0, 1.0, 0, 0)
+	if err := n.SetLatencyBudget(LatencyBudget{Budget: time.Millisecond, Shed: true, Background: []string{"noise"}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	n.Receive(types.NeuralSignal{Value: 0.1, SourceID: "sensor", Timestamp: time.Now()})
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.1, SourceID: "sensor", Timestamp: time.Now().Add(-time.Second)})
+
+	n.Receive(types.NeuralSignal{Value: 0.1, SourceID: "noise", Timestamp: time.Now()})
+	n.Receive(types.NeuralSignal{Value: 0.1, SourceID: "sensor", Timestamp: time.Now()})
+	if len(n.inputBuffer) != 2 || n.GetDeadlineStats().Shed != 1 {
+		t.Errorf("Expected background input shed on arrival, got %d queued and %+v", len(n.inputBuffer), n.GetDeadlineStats())
+	}
+
+	// Once the backlog has been processed, background input is accepted again
+	for len(n.inputBuffer) > 0 {
+		<-n.inputBuffer
+	}
+	n.Receive(types.NeuralSignal{Value: 0.1, SourceID: "noise", Timestamp: time.Now()})
+	if len(n.inputBuffer) != 1 || n.GetDeadlineStats().Overloaded {
+		t.Error("Expected background input accepted after the backlog drained")
+	}
+}
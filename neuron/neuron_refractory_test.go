@@ -0,0 +1,88 @@
+package neuron
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestRefractoryInputPolicy verifies that queued input processed just after a
+// spike is integrated by default, discarded, or attenuated.
+func TestRefractoryInputPolicy(t *testing.T) {
+	spikeThenInput := func(n *Neuron) float64 {
+		n.processIncomingMessage(types.NeuralSignal{Value: 1.5, SourceID: "drive", Timestamp: time.Now()})
+		n.processIncomingMessage(types.NeuralSignal{Value: 0.5, SourceID: "drive", Timestamp: time.Now()})
+		return n.accumulator
+	}
+
+	legacy := NewNeuron("legacy", 1.0, 0.95, 50*time.Millisecond, 1.0, 0, 0)
+	if got := spikeThenInput(legacy); got != 0.5 {
+		t.Errorf("Expected queued input integrated by default, got %f", got)
+	}
+
+	discard, err := NewNeuronWithOptions("discard", WithRefractoryPeriod(50*time.Millisecond),
+		WithRefractoryInputPolicy(RefractoryInputDiscard, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := spikeThenInput(discard); got != 0 || discard.GetRefractoryDropCount() != 1 {
+		t.Errorf("Expected queued input discarded, got %f (%d drops)", got, discard.GetRefractoryDropCount())
+	}
+
+	attenuate, err := NewNeuronWithOptions("attenuate", WithRefractoryPeriod(50*time.Millisecond),
+		WithRefractoryInputPolicy(RefractoryInputAttenuate, 0.2))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := spikeThenInput(attenuate); math.Abs(got-0.1) > 1e-9 {
+		t.Errorf("Expected attenuated input 0.1, got %f", got)
+	}
+	if policy, factor := attenuate.GetRefractoryInputPolicy(); policy != RefractoryInputAttenuate || factor != 0.2 {
+		t.Errorf("Unexpected policy %v (%f)", policy, factor)
+	}
+
+	// Only attenuation accepts input on arrival during refractoriness
+	discard.Receive(types.NeuralSignal{Value: 0.5, SourceID: "drive"})
+	if discard.GetRefractoryDropCount() != 2 || len(discard.inputBuffer) != 0 {
+		t.Error("Expected message dropped on arrival")
+	}
+	attenuate.Receive(types.NeuralSignal{Value: 0.5, SourceID: "drive"})
+	if len(attenuate.inputBuffer) != 1 {
+		t.Error("Expected message queued for attenuation")
+	}
+
+	if _, err := NewNeuronWithOptions("bad", WithRefractoryInputPolicy(RefractoryInputAttenuate, 1.0)); err == nil {
+		t.Error("Expected error for attenuation 1.0")
+	}
+}
+
+// TestSuppressedSpikeAccounting verifies that threshold crossings during the
+// refractory period are counted and reported in snapshots.
+func TestSuppressedSpikeAccounting(t *testing.T) {
+	n := NewNeuron("busy", 1.0, 0.95, 50*time.Millisecond, 1.0, 0, 0)
+	for i := 0; i < 4; i++ {
+		n.processIncomingMessage(types.NeuralSignal{Value: 1.5, SourceID: "drive", Timestamp: time.Now()})
+	}
+	if n.GetSpikeCount() != 1 || n.GetSuppressedSpikeCount() != 3 {
+		t.Errorf("Expected 1 spike and 3 suppressed, got %d and %d", n.GetSpikeCount(), n.GetSuppressedSpikeCount())
+	}
+
+	snapshot := n.GetSnapshot()
+	if snapshot.Spikes != 1 || snapshot.SuppressedSpikes != 3 || snapshot.SuppressedFraction() != 0.75 {
+		t.Errorf("Unexpected snapshot counters: %+v", snapshot)
+	}
+	if state := n.GetNeuronState(); state["suppressed_spikes"] != int64(3) {
+		t.Errorf("Expected suppressed spikes in map state, got %v", state["suppressed_spikes"])
+	}
+
+	// Sub-threshold input during refractoriness is not a suppressed spike
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.5, SourceID: "drive", Timestamp: time.Now()})
+	if n.GetSuppressedSpikeCount() != 3 {
+		t.Errorf("Expected sub-threshold input not counted, got %d", n.GetSuppressedSpikeCount())
+	}
+	if (NeuronSnapshot{}).SuppressedFraction() != 0 {
+		t.Error("Expected zero fraction without threshold crossings")
+	}
+}
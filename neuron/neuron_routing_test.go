@@ -0,0 +1,142 @@
+package neuron
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// handleRecordingSynapse is a mock synapse that keeps its route handle.
+type handleRecordingSynapse struct {
+	*MockSynapticProcessor
+	handle uint32
+}
+
+func (s *handleRecordingSynapse) SetRouteHandle(handle uint32) {
+	s.handle = handle
+}
+
+// TestRouting_RegistrationAssignsAndReusesHandles verifies that registration
+// hands out dense handles, passes them to the synapse, and that
+// unregistering frees a handle for the next synapse.
+func TestRouting_RegistrationAssignsAndReusesHandles(t *testing.T) {
+	n := NewNeuron("routing", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+
+	synapses := make([]*handleRecordingSynapse, 3)
+	for i := range synapses {
+		synapses[i] = &handleRecordingSynapse{MockSynapticProcessor: NewMockSynapticProcessor(fmt.Sprintf("syn_%d", i))}
+		n.RegisterInputSynapse(synapses[i].ID(), synapses[i])
+		if want := uint32(i + 1); synapses[i].handle != want {
+			t.Errorf("Expected handle %d for %s, got %d", want, synapses[i].ID(), synapses[i].handle)
+		}
+	}
+	if handle, ok := n.LookupSynapseHandle("syn_1"); !ok || handle != 2 {
+		t.Errorf("Expected syn_1 at handle 2, got %d (%v)", handle, ok)
+	}
+
+	n.UnregisterInputSynapse("syn_1")
+	if synapses[1].handle != 0 {
+		t.Errorf("Expected the unregistered synapse's handle cleared, got %d", synapses[1].handle)
+	}
+	if _, ok := n.GetInputRouteByHandle(2); ok {
+		t.Error("Expected no route at a freed handle")
+	}
+
+	replacement := &handleRecordingSynapse{MockSynapticProcessor: NewMockSynapticProcessor("syn_new")}
+	n.RegisterInputSynapse(replacement.ID(), replacement)
+	if replacement.handle != 2 {
+		t.Errorf("Expected the freed handle 2 to be reused, got %d", replacement.handle)
+	}
+	if n.GetInputRouteCount() != 3 {
+		t.Errorf("Expected 3 routes, got %d", n.GetInputRouteCount())
+	}
+}
+
+// TestRouting_DeliveryUpdatesRoute verifies per-synapse state for stamped,
+// stale and unstamped signals, and that only registered synapses are routed.
+func TestRouting_DeliveryUpdatesRoute(t *testing.T) {
+	n := NewNeuron("routing", 100.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	syn := &handleRecordingSynapse{MockSynapticProcessor: NewMockSynapticProcessor("syn_a")}
+	n.RegisterInputSynapse(syn.ID(), syn)
+
+	before := time.Now()
+	n.processIncomingMessage(types.NeuralSignal{
+		Value: 0.1, SourceID: "pre_a", SynapseID: "syn_a",
+		SynapseHandle: syn.handle, NeurotransmitterType: types.LigandGlutamate,
+	})
+	route, ok := n.GetInputRoute("syn_a")
+	if !ok || route.Spikes != 1 || route.Receptor != types.LigandGlutamate || route.LastSpike.Before(before) {
+		t.Fatalf("Expected one glutamate spike on syn_a, got %+v", route)
+	}
+
+	// A stale handle pointing at another route must not be credited to it
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.1, SourceID: "pre_b", SynapseID: "syn_b", SynapseHandle: syn.handle})
+	if route, _ := n.GetInputRoute("syn_a"); route.Spikes != 1 {
+		t.Errorf("Expected syn_a untouched by a mismatched handle, got %d spikes", route.Spikes)
+	}
+
+	// Unregistered synapses get no route, so the table cannot grow without bound
+	if _, ok := n.GetInputRoute("syn_b"); ok {
+		t.Error("Expected no route for the unregistered syn_b")
+	}
+
+	// Interned handles decide ownership when both sides have one
+	synA, _ := types.InternID("syn_a")
+	synC, _ := types.InternID("syn_c")
+	n.RegisterInputSynapse("syn_a", syn) // refreshes the route
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.1, SynapseID: "syn_a", SynapseIDHandle: synA, SynapseHandle: syn.handle})
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.1, SynapseID: "syn_c", SynapseIDHandle: synC, SynapseHandle: syn.handle})
+	if route, _ := n.GetInputRoute("syn_a"); route.Spikes != 2 {
+		t.Errorf("Expected syn_a credited only for its own handle, got %d spikes", route.Spikes)
+	}
+
+	// Signals without a synapse are not routed
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.1, SourceID: "direct"})
+	if n.GetInputRouteCount() != 1 {
+		t.Errorf("Expected 1 route, got %d", n.GetInputRouteCount())
+	}
+
+	// Unregistering removes the route; later signals do not bring it back
+	n.UnregisterInputSynapse("syn_a")
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.1, SynapseID: "syn_a", SynapseHandle: syn.handle})
+	if n.GetInputRouteCount() != 0 {
+		t.Errorf("Expected no routes after unregistering, got %d", n.GetInputRouteCount())
+	}
+}
+
+// BenchmarkRouting_DenseFanIn measures route bookkeeping for a neuron with
+// 20,000 inputs by stamped route handle, by interned ID handle and by string.
+func BenchmarkRouting_DenseFanIn(b *testing.B) {
+	const inputs = 20000
+	var table routingTable
+	msgs := make([]types.NeuralSignal, inputs)
+	for i := range msgs {
+		id := fmt.Sprintf("syn_%05d", i)
+		handle := table.register(id, "pre")
+		key, _ := types.DefaultIDs.Lookup(id)
+		msgs[i] = types.NeuralSignal{SynapseID: id, SourceID: "pre", SynapseHandle: uint32(handle), SynapseIDHandle: key}
+	}
+	now := time.Now()
+
+	b.Run("handle", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			table.record(&msgs[i%inputs], now)
+		}
+	})
+	b.Run("key", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			msg := msgs[i%inputs]
+			msg.SynapseHandle = 0
+			table.record(&msg, now)
+		}
+	})
+	b.Run("id", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			msg := msgs[i%inputs]
+			msg.SynapseHandle, msg.SynapseIDHandle = 0, 0
+			table.record(&msg, now)
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package neuron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestNeuronSnapshot verifies the typed snapshot and its deprecated map form.
+func TestNeuronSnapshot(t *testing.T) {
+	n := NewNeuron("snap", 1.0, 0.95, 10*time.Millisecond, 1.0, 5.0, 0)
+	before := n.GetSnapshot()
+	if before.Version != NEURON_SNAPSHOT_VERSION || before.NeuronID != "snap" || !before.LastSpike.IsZero() {
+		t.Fatalf("Unexpected initial snapshot: %+v", before)
+	}
+	if !before.RefractoryUntil.IsZero() || before.InRefractory() {
+		t.Error("Expected no refractory period before the first spike")
+	}
+
+	n.processIncomingMessage(types.NeuralSignal{Value: 0.4, SourceID: "in", Timestamp: time.Now()})
+	n.processIncomingMessage(types.NeuralSignal{Value: 1.2, SourceID: "in", Timestamp: time.Now()})
+	after := n.GetSnapshot()
+	if after.LastSpike.IsZero() || after.RefractoryUntil != after.LastSpike.Add(10*time.Millisecond) {
+		t.Errorf("Expected refractory period after the spike, got %+v", after)
+	}
+	if !after.InRefractory() || after.FiringRate <= 0 || after.TargetRate != 5.0 {
+		t.Errorf("Unexpected post-spike snapshot: %+v", after)
+	}
+
+	// Snapshots are copies
+	after.Threshold = 42
+	if n.GetThreshold() == 42 {
+		t.Error("Expected snapshot changes not to affect the neuron")
+	}
+	if before.Accumulator != 0 {
+		t.Errorf("Expected earlier snapshot unchanged, got accumulator %f", before.Accumulator)
+	}
+
+	state := n.GetNeuronState()
+	if state["neuron_id"] != "snap" || state["in_refractory"] != true || state["version"] != NEURON_SNAPSHOT_VERSION {
+		t.Errorf("Unexpected map state: %v", state)
+	}
+}
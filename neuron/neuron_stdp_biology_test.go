@@ -14,9 +14,11 @@ STDP NEURON BIOLOGY TEST SUITE
 
 This file contains tests for STDP-based biological learning mechanisms:
 1. Hebbian learning (neurons that fire together, wire together)
-2. STDP learning windows (LTP/LTD curves)
-3. Integration with other plasticity mechanisms
-4. Learning consolidation
+2. Integration with other plasticity mechanisms
+3. Learning consolidation
+
+The STDP learning window and scheduled feedback are tested on a virtual
+clock in the neurontest package (stdp_test.go).
 
 All tests use the prefix TestSTDPNeuronBiology_ for easy isolation.
 
@@ -154,128 +156,6 @@ func TestSTDPNeuronBiology_HebbianRule(t *testing.T) {
 	t.Log("✓ Hebbian learning rule test completed")
 }
 
-// TestSTDPNeuronBiology_LearningWindow tests the STDP learning window
-// (LTP for pre-before-post, LTD for post-before-pre)
-func TestSTDPNeuronBiology_LearningWindow(t *testing.T) {
-	// Create a neuron with STDP
-	neuron := NewNeuron(
-		"stdp_window_test",
-		1.0,
-		0.95,
-		5*time.Millisecond,
-		2.0,
-		3.0,
-		0.2,
-	)
-
-	neuron.EnableSTDPFeedback(20*time.Millisecond, 0.1)
-
-	// Verify STDP is properly enabled
-	if !neuron.IsSTDPFeedbackEnabled() {
-		t.Fatalf("STDP should be enabled but IsSTDPFeedbackEnabled() returned false")
-	}
-
-	// Create mock matrix
-	mockMatrix := NewMockMatrix()
-	mockCallbacks := NewMockNeuronCallbacks(mockMatrix)
-	neuron.SetCallbacks(mockCallbacks)
-
-	// Start the neuron
-	neuron.Start()
-	defer neuron.Stop()
-
-	// Test spike timing differences across the full STDP window
-	// These different timing values test both LTP and LTD in the STDP curve
-	timingDifferences := []time.Duration{
-		-50 * time.Millisecond, // Pre long before post (weak potentiation)
-		-30 * time.Millisecond, // Pre well before post (moderate potentiation)
-		-10 * time.Millisecond, // Pre just before post (maximum potentiation)
-		-5 * time.Millisecond,  // Pre very close before post (strong potentiation)
-		0 * time.Millisecond,   // Simultaneous (border case)
-		5 * time.Millisecond,   // Post just before pre (strong depression)
-		10 * time.Millisecond,  // Post before pre (maximum depression)
-		30 * time.Millisecond,  // Post well before pre (moderate depression)
-		50 * time.Millisecond,  // Post long before pre (weak depression)
-	}
-
-	// Map to store results for DeltaT
-	results := make(map[time.Duration]time.Duration)
-
-	for _, deltaT := range timingDifferences {
-		// Calculate LastActivity time based on deltaT
-		lastActivity := time.Now().Add(deltaT)
-
-		// Create a test synapse with this timing
-		testSynapse := types.SynapseInfo{
-			ID:               "timing_test_synapse",
-			SourceID:         "source",
-			TargetID:         neuron.ID(),
-			Weight:           0.5,
-			LastActivity:     lastActivity,
-			LastTransmission: lastActivity, // Use LastActivity for simplicity
-		}
-
-		// Setup mock and clear previous adjustments
-		mockMatrix.ClearPlasticityAdjustments()
-		mockMatrix.SetSynapseList([]types.SynapseInfo{testSynapse})
-
-		// CRITICAL: Get the mock synapse and record a pre-spike with the proper timing
-		// This simulates the pre-synaptic neuron firing at the specific time we want
-		synObj, err := mockCallbacks.GetSynapse("timing_test_synapse")
-		if err != nil {
-			t.Logf("Warning: Couldn't get synapse: %v", err)
-		} else {
-			// Record pre-spike on the synapse with timing based on deltaT
-			if recorder, ok := synObj.(interface{ RecordPreSpike(time.Time) }); ok {
-				now := time.Now()
-				preTime := now.Add(deltaT) // For negative deltaT, pre comes before now
-				recorder.RecordPreSpike(preTime)
-				t.Logf("Recorded pre-spike at %v for deltaT = %v test", preTime, deltaT)
-			} else {
-				t.Logf("Warning: Synapse doesn't support RecordPreSpike")
-			}
-		}
-
-		// IMPORTANT: Trigger neuron firing to naturally activate STDP
-		// This creates the post-synaptic spike that interacts with the pre-spike
-		SendTestSignal(neuron, "window_test_trigger", 2.0) // Strong signal to ensure firing
-
-		// Wait for STDP processing to complete
-		time.Sleep(50 * time.Millisecond)
-
-		// Check adjustment
-		adjustments := mockMatrix.GetPlasticityAdjustments()
-		if len(adjustments) == 0 {
-			t.Fatalf("No plasticity adjustment for deltaT = %v", deltaT)
-		}
-
-		// Store the measured DeltaT for analysis
-		results[deltaT] = adjustments[0].DeltaT
-	}
-
-	// Verify the STDP curve shape
-	t.Log("STDP Window Results:")
-	t.Log("-------------------")
-	t.Log("Input DeltaT (ms) | Measured DeltaT (ms) | Sign Match")
-
-	for _, deltaT := range timingDifferences {
-		resultDeltaT := results[deltaT]
-		signMatch := (deltaT < 0 && resultDeltaT < 0) || (deltaT > 0 && resultDeltaT > 0) || (deltaT == 0)
-
-		t.Logf("%15.2f | %19.2f | %t",
-			float64(deltaT)/float64(time.Millisecond),
-			float64(resultDeltaT)/float64(time.Millisecond),
-			signMatch)
-
-		// Check if sign is correct (negative for LTP, positive for LTD)
-		if !signMatch && deltaT != 0 {
-			t.Errorf("Expected same sign for input DeltaT %v and measured DeltaT %v", deltaT, resultDeltaT)
-		}
-	}
-
-	t.Log("✓ STDP learning window test completed")
-}
-
 // TestSTDPNeuronBiology_NaturalScheduling tests the natural STDP scheduling
 // that occurs when a neuron fires
 func TestSTDPNeuronBiology_NaturalScheduling(t *testing.T) {
@@ -929,84 +809,3 @@ func TestSTDPNeuronBiology_BasicFunctionality(t *testing.T) {
 
 	t.Log("✓ Basic STDP functionality works correctly")
 }
-
-// TestSTDPNeuronBiology_ScheduledFeedback tests the automatic STDP feedback scheduling
-func TestSTDPNeuronBiology_ScheduledFeedback(t *testing.T) {
-	// Create a neuron with STDP
-	neuron := NewNeuron(
-		"stdp_schedule_test",
-		0.5, // low threshold to ensure firing
-		0.95,
-		5*time.Millisecond,
-		2.0,
-		3.0,
-		0.2,
-	)
-
-	// Enable STDP with short delay
-	feedbackDelay := 20 * time.Millisecond
-	neuron.EnableSTDPFeedback(feedbackDelay, 0.1)
-
-	// Create mock matrix
-	mockMatrix := NewMockMatrix()
-	mockCallbacks := NewMockNeuronCallbacks(mockMatrix)
-	neuron.SetCallbacks(mockCallbacks)
-
-	// Setup a test synapse
-	testSynapse := types.SynapseInfo{
-		ID:               "schedule_test_synapse",
-		SourceID:         "source",
-		TargetID:         neuron.ID(),
-		Weight:           0.5,
-		LastActivity:     time.Now().Add(-5 * time.Millisecond),
-		LastTransmission: time.Now().Add(-5 * time.Millisecond), // Pre-synaptic spike 5ms ago
-	}
-
-	mockMatrix.SetSynapseList([]types.SynapseInfo{testSynapse})
-
-	// Record a pre-spike on the synapse
-	synObj, err := mockCallbacks.GetSynapse("schedule_test_synapse")
-	if err == nil {
-		if recorder, ok := synObj.(interface{ RecordPreSpike(time.Time) }); ok {
-			preTime := time.Now().Add(-5 * time.Millisecond)
-			recorder.RecordPreSpike(preTime)
-			t.Logf("Recorded pre-spike at %v for scheduled feedback test", preTime)
-		}
-	}
-
-	// Start the neuron
-	neuron.Start()
-	defer neuron.Stop()
-
-	// Trigger firing with strong signal
-	testSignal := types.NeuralSignal{
-		Value:     2.0, // Strong signal to ensure firing
-		Timestamp: time.Now(),
-		SourceID:  "test",
-		TargetID:  neuron.ID(),
-	}
-
-	t.Log("Sending signal to trigger firing")
-	neuron.Receive(testSignal)
-
-	// Wait a bit to allow for firing processing
-	time.Sleep(10 * time.Millisecond)
-
-	// No STDP adjustments yet - too early
-	adjustmentsBefore := mockMatrix.GetPlasticityAdjustments()
-	if len(adjustmentsBefore) > 0 {
-		t.Errorf("Expected no STDP adjustments yet, got %d", len(adjustmentsBefore))
-	}
-
-	// Wait for scheduled feedback to execute
-	// This tests the automatic scheduling of STDP after firing
-	time.Sleep(feedbackDelay + 10*time.Millisecond)
-
-	// Now we should have STDP adjustments
-	adjustmentsAfter := mockMatrix.GetPlasticityAdjustments()
-	if len(adjustmentsAfter) == 0 {
-		t.Error("Expected STDP adjustments after scheduled time")
-	}
-
-	t.Log("✓ Scheduled STDP feedback works correctly")
-}
@@ -25,7 +25,9 @@ This file contains tests for Spike-Timing-Dependent Plasticity (STDP):
 5. Performance tests
 
 All tests use the prefix TestSTDPNeuronBasic_ for easy filtering.
-Tests are organized into logical categories.
+Tests are organized into logical categories. The STDP timing curve and
+scheduled feedback are tested on a virtual clock in the neurontest package
+(stdp_test.go).
 
 =================================================================================
 */
@@ -270,126 +272,6 @@ func TestSTDPNeuronBasic_LearningRateEffects(t *testing.T) {
 	t.Log("✓ STDP learning rate effects test completed successfully")
 }
 
-// TestSTDPNeuronBasic_TimingCurve verifies that the STDP timing curve behaves correctly
-// for different pre-post spike timing differences
-func TestSTDPNeuronBasic_TimingCurve(t *testing.T) {
-	// Create a neuron with STDP
-	neuron := NewNeuron(
-		"stdp_curve_test",
-		1.0,
-		0.95,
-		5*time.Millisecond,
-		2.0,
-		3.0,
-		0.2,
-	)
-
-	neuron.EnableSTDPFeedback(20*time.Millisecond, 0.1)
-
-	// Create mock matrix
-	mockMatrix := NewMockMatrix()
-	mockCallbacks := NewMockNeuronCallbacks(mockMatrix)
-	neuron.SetCallbacks(mockCallbacks)
-
-	// Start the neuron
-	neuron.Start()
-	defer neuron.Stop()
-
-	// Test various spike timing differences
-	timingDifferences := []time.Duration{
-		-30 * time.Millisecond, // Pre well before post - potentiation
-		-20 * time.Millisecond, // Pre before post - potentiation
-		-10 * time.Millisecond, // Pre just before post - max potentiation
-		-5 * time.Millisecond,  // Pre just before post - strong potentiation
-		0 * time.Millisecond,   // Simultaneous - border case
-		5 * time.Millisecond,   // Post just before pre - depression
-		10 * time.Millisecond,  // Post before pre - depression
-		20 * time.Millisecond,  // Post well before pre - depression
-		30 * time.Millisecond,  // Post well before pre - weak depression
-	}
-
-	// Map to store results
-	results := make(map[time.Duration]float64)
-
-	for _, deltaT := range timingDifferences {
-		// Calculate LastActivity time based on deltaT
-		// Negative deltaT: pre fired before post (LastActivity in past)
-		// Positive deltaT: post fired before pre (LastActivity in future)
-		lastActivity := time.Now().Add(deltaT)
-
-		// Create a test synapse with this timing
-		testSynapse := types.SynapseInfo{
-			ID:               "timing_test_synapse",
-			SourceID:         "source",
-			TargetID:         neuron.ID(),
-			Weight:           0.5,
-			LastActivity:     lastActivity,
-			LastTransmission: lastActivity, // Use LastActivity for simplicity
-		}
-
-		// Setup mock and clear previous adjustments
-		mockMatrix.ClearPlasticityAdjustments()
-		mockMatrix.SetSynapseList([]types.SynapseInfo{testSynapse})
-
-		// CRITICAL FIX: Record pre-spike on the synapse with proper timing
-		synObj, err := mockCallbacks.GetSynapse("timing_test_synapse")
-		if err == nil {
-			if recorder, ok := synObj.(interface{ RecordPreSpike(time.Time) }); ok {
-				recorder.RecordPreSpike(lastActivity)
-				t.Logf("Recorded pre-spike at %v for deltaT = %v test", lastActivity, deltaT)
-			}
-		}
-
-		// Record post-spike now (or create it by triggering neuron to fire)
-		// For negative deltaT (pre before post), trigger neuron firing now
-		// For positive deltaT (post before pre), we need to record post-spike first
-
-		// Create post-spike at current time
-		if deltaT >= 0 {
-			// For post-before-pre (positive deltaT), directly stimulate neuron
-			SendTestSignal(neuron, "curve_test_trigger", 2.0)
-		} else {
-			// For pre-before-post (negative deltaT), fire the neuron to create post-spike
-			SendTestSignal(neuron, "curve_test_trigger", 2.0)
-		}
-
-		// Allow time for firing
-		time.Sleep(10 * time.Millisecond)
-
-		// Trigger STDP
-		neuron.SendSTDPFeedback()
-
-		// Wait for STDP processing
-		time.Sleep(20 * time.Millisecond)
-
-		// Check adjustment
-		adjustments := mockMatrix.GetPlasticityAdjustments()
-		if len(adjustments) == 0 {
-			t.Fatalf("No plasticity adjustment for deltaT = %v", deltaT)
-		}
-
-		// Store the adjustment
-		results[deltaT] = float64(adjustments[0].DeltaT.Milliseconds())
-	}
-
-	// Verify the STDP curve shape
-	// For negative deltaT (pre before post), should be negative values
-	// For positive deltaT (post before pre), should be positive values
-	for deltaT, resultDeltaT := range results {
-		t.Logf("DeltaT = %v ms, Measured = %.2f ms", deltaT.Milliseconds(), resultDeltaT)
-
-		// Check if sign is correct
-		if deltaT < 0 && resultDeltaT > 0 {
-			t.Errorf("Expected negative DeltaT for pre-before-post timing, got %.2f", resultDeltaT)
-		}
-		if deltaT > 0 && resultDeltaT < 0 {
-			t.Errorf("Expected positive DeltaT for post-before-pre timing, got %.2f", resultDeltaT)
-		}
-	}
-
-	t.Log("✓ STDP timing curve test completed successfully")
-}
-
 // TestSTDPNeuronBasic_DisableEnable tests enabling and disabling STDP
 func TestSTDPNeuronBasic_DisableEnable(t *testing.T) {
 	// Create a neuron
@@ -506,89 +388,6 @@ func TestSTDPNeuronBasic_DisableEnable(t *testing.T) {
 	t.Log("✓ STDP disable/enable test completed successfully")
 }
 
-// TestSTDPNeuronBasic_ScheduledFeedback tests the automatic STDP feedback scheduling
-func TestSTDPNeuronBasic_ScheduledFeedback(t *testing.T) {
-	// Create a neuron with STDP
-	neuron := NewNeuron(
-		"stdp_schedule_test",
-		0.5, // low threshold to ensure firing
-		0.95,
-		5*time.Millisecond,
-		2.0,
-		3.0,
-		0.2,
-	)
-
-	// Enable STDP with short delay
-	feedbackDelay := 20 * time.Millisecond
-	neuron.EnableSTDPFeedback(feedbackDelay, 0.1)
-
-	// Create mock matrix
-	mockMatrix := NewMockMatrix()
-	mockCallbacks := NewMockNeuronCallbacks(mockMatrix)
-	neuron.SetCallbacks(mockCallbacks)
-
-	// Setup a test synapse
-	testSynapse := types.SynapseInfo{
-		ID:               "schedule_test_synapse",
-		SourceID:         "source",
-		TargetID:         neuron.ID(),
-		Weight:           0.5,
-		LastActivity:     time.Now().Add(-5 * time.Millisecond),
-		LastTransmission: time.Now().Add(-5 * time.Millisecond), // Pre-synaptic spike 5ms ago
-	}
-
-	mockMatrix.SetSynapseList([]types.SynapseInfo{testSynapse})
-
-	// CRITICAL FIX: Record pre-spike on the synapse
-	synObj, err := mockCallbacks.GetSynapse("schedule_test_synapse")
-	if err == nil {
-		if recorder, ok := synObj.(interface{ RecordPreSpike(time.Time) }); ok {
-			preTime := time.Now().Add(-5 * time.Millisecond)
-			recorder.RecordPreSpike(preTime)
-			t.Logf("Recorded pre-spike at %v for scheduled feedback test", preTime)
-		}
-	}
-
-	// Start the neuron
-	neuron.Start()
-	defer neuron.Stop()
-
-	// Trigger firing with strong signal
-	testSignal := types.NeuralSignal{
-		Value:     2.0, // Strong signal to ensure firing
-		Timestamp: time.Now(),
-		SourceID:  "test",
-		TargetID:  neuron.ID(),
-	}
-
-	t.Log("Sending signal to trigger firing")
-	neuron.Receive(testSignal)
-
-	// Wait a bit to allow for firing processing
-	time.Sleep(10 * time.Millisecond)
-
-	// No STDP adjustments yet - too early
-	initialAdjustments := mockMatrix.GetPlasticityAdjustments()
-	initialCount := len(initialAdjustments)
-
-	// Wait for scheduled STDP to occur
-	t.Logf("Waiting for scheduled STDP (delay: %v)", feedbackDelay)
-	time.Sleep(feedbackDelay + 50*time.Millisecond) // Add extra time for processing
-
-	// Should have new adjustments now
-	finalAdjustments := mockMatrix.GetPlasticityAdjustments()
-	finalCount := len(finalAdjustments)
-
-	t.Logf("Adjustments before delay: %d, after delay: %d", initialCount, finalCount)
-
-	if finalCount <= initialCount {
-		t.Error("Expected additional STDP adjustments from scheduled feedback")
-	}
-
-	t.Log("✓ STDP scheduled feedback test completed successfully")
-}
-
 // ============================================================================
 // CONCURRENCY AND DEADLOCK PREVENTION TESTS
 // ============================================================================
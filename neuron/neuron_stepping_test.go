@@ -0,0 +1,135 @@
+package neuron
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// newSteppedNeuron starts a neuron on a virtual clock at epoch.
+func newSteppedNeuron(t *testing.T, id string, epoch time.Time, scheduler DelayScheduler) *Neuron {
+	t.Helper()
+	n := NewNeuron(id, 1.0, 0.9, 5*time.Millisecond, 1.0, 5, 0)
+	if err := n.EnableStepping(SteppingConfig{Start: epoch, Scheduler: scheduler}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { n.Stop() })
+	return n
+}
+
+// TestSteppedNeuron_AdvancesOnlyWhenStepped verifies that decay, firing and
+// refractoriness of a stepped neuron follow the virtual clock, however much
+// wall time passes.
+func TestSteppedNeuron_AdvancesOnlyWhenStepped(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	n := newSteppedNeuron(t, "stepped", epoch, nil)
+	at := func(ms int) time.Time { return epoch.Add(time.Duration(ms) * time.Millisecond) }
+
+	n.Receive(types.NeuralSignal{Value: 0.5})
+	time.Sleep(5 * time.Millisecond) // No goroutine: nothing happens
+	if got := n.GetSnapshot().Accumulator; got != 0 {
+		t.Fatalf("Expected input to wait for a step, got accumulator %g", got)
+	}
+
+	n.Step(at(1))
+	if got := n.GetSnapshot().Accumulator; math.Abs(got-0.45) > 1e-9 {
+		t.Errorf("Expected 0.5 decayed by one tick (0.45), got %g", got)
+	}
+	n.Step(at(10))
+	if got, want := n.GetSnapshot().Accumulator, 0.5*math.Pow(0.9, 10); math.Abs(got-want) > 1e-9 {
+		t.Errorf("Expected ten ticks of decay (%g), got %g", want, got)
+	}
+	if got := n.Now(); !got.Equal(at(10)) {
+		t.Errorf("Expected the neuron's clock at 10ms, got %v", got.Sub(epoch))
+	}
+
+	// Fire at 11ms; input 3ms later is suppressed by the 5ms refractory period
+	n.Receive(types.NeuralSignal{Value: 2})
+	n.Step(at(11))
+	n.Step(at(14))
+	n.Receive(types.NeuralSignal{Value: 2})
+	n.Step(at(15))
+	n.Step(at(17))
+	n.Receive(types.NeuralSignal{Value: 2})
+	n.Step(at(18))
+
+	snapshot := n.GetSnapshot()
+	if snapshot.Spikes != 2 {
+		t.Errorf("Expected spikes at 11ms and 18ms only, got %d", snapshot.Spikes)
+	}
+	if !snapshot.LastSpike.Equal(at(18)) {
+		t.Errorf("Expected the last spike at 18ms, got %v", snapshot.LastSpike.Sub(epoch))
+	}
+}
+
+// TestSteppedNeuron_AxonalDelaysFollowVirtualTime verifies that delayed
+// spikes are delivered when the virtual clock reaches them, from the
+// neuron's own queue or through an external scheduler.
+func TestSteppedNeuron_AxonalDelaysFollowVirtualTime(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	at := func(ms int) time.Time { return epoch.Add(time.Duration(ms) * time.Millisecond) }
+
+	n := newSteppedNeuron(t, "axon", epoch, nil)
+	target := newOrderRecorder("target")
+	n.Step(at(5))
+	n.ScheduleDelayedDelivery(types.NeuralSignal{Value: 1}, target, 3*time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	n.Step(at(7))
+	if got := target.received(); len(got) != 0 {
+		t.Fatalf("Expected no delivery before 8ms, got %v", got)
+	}
+	n.Step(at(8))
+	if got := target.received(); len(got) != 1 {
+		t.Errorf("Expected delivery at 8ms, got %v", got)
+	}
+
+	var scheduled []time.Duration
+	external := newSteppedNeuron(t, "external", epoch, func(msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration) {
+		scheduled = append(scheduled, delay)
+	})
+	external.ScheduleDelayedDelivery(types.NeuralSignal{Value: 1}, target, 4*time.Millisecond)
+	if len(scheduled) != 1 || scheduled[0] != 4*time.Millisecond {
+		t.Errorf("Expected the delay handed to the scheduler, got %v", scheduled)
+	}
+}
+
+// TestSteppedNeuron_Lifecycle verifies that stepping must be enabled before
+// Start and that steps before Start do nothing.
+func TestSteppedNeuron_Lifecycle(t *testing.T) {
+	epoch := time.Unix(0, 0)
+	n := NewNeuron("lifecycle", 1.0, 0.9, 5*time.Millisecond, 1.0, 5, 0)
+	if n.IsStepped() {
+		t.Fatal("Expected a new neuron on the wall clock")
+	}
+	if err := n.EnableStepping(SteppingConfig{Start: epoch}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := n.EnableStepping(SteppingConfig{Start: epoch}); err == nil {
+		t.Error("Expected an error enabling stepping twice")
+	}
+
+	n.Receive(types.NeuralSignal{Value: 2})
+	n.Step(epoch.Add(time.Millisecond))
+	if n.GetSnapshot().Spikes != 0 {
+		t.Error("Expected no processing before Start")
+	}
+	n.Start()
+	defer n.Stop()
+	n.Step(epoch.Add(2 * time.Millisecond))
+	if n.GetSnapshot().Spikes != 1 {
+		t.Error("Expected the queued input to fire after Start")
+	}
+
+	running := NewNeuron("running", 1.0, 0.9, 5*time.Millisecond, 1.0, 5, 0)
+	running.Start()
+	defer running.Stop()
+	if err := running.EnableStepping(SteppingConfig{Start: epoch}); err == nil {
+		t.Error("Expected an error enabling stepping on a running neuron")
+	}
+}
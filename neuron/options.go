@@ -0,0 +1,390 @@
+package neuron
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+/*
+=================================================================================
+FUNCTIONAL-OPTIONS CONSTRUCTION AND BIOLOGICAL PRESETS
+=================================================================================
+
+NewNeuron takes seven positional float/duration parameters, which makes it easy
+to swap threshold and decay or fire factor and target rate without a compiler
+error. NewNeuronWithOptions builds the same neuron from named options, validates
+the result, and offers presets that set threshold, membrane decay, refractory
+period and homeostatic adaptation consistently for common cell classes.
+
+Options are applied in order, so presets should come first:
+
+	n, err := neuron.NewNeuronWithOptions("pyr_1",
+	    neuron.PyramidalCell(),
+	    neuron.WithThreshold(1.2))
+
+=================================================================================
+*/
+
+// NeuronOption configures a neuron created by NewNeuronWithOptions.
+type NeuronOption func(*NeuronConfig)
+
+// NewNeuronWithOptions creates a neuron from functional options.
+//
+// Defaults (before options) match a plain excitatory neuron created with
+// NewNeuron: EXCITATORY_* membrane constants, EXCITATORY_TARGET_RATE_DEFAULT
+// and HOMEOSTASIS_STRENGTH_DEFAULT, with no optional plasticity enabled.
+//
+// Returns:
+//
+//	The configured neuron (not started), or an error for invalid settings
+func NewNeuronWithOptions(id string, opts ...NeuronOption) (*Neuron, error) {
+	config := NeuronConfig{
+		Threshold:           EXCITATORY_THRESHOLD_DEFAULT,
+		DecayRate:           EXCITATORY_DECAY_RATE_DEFAULT,
+		RefractoryPeriod:    EXCITATORY_REFRACTORY_PERIOD_DEFAULT,
+		FireFactor:          EXCITATORY_FIRE_FACTOR_DEFAULT,
+		TargetFiringRate:    EXCITATORY_TARGET_RATE_DEFAULT,
+		HomeostasisStrength: HOMEOSTASIS_STRENGTH_DEFAULT,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	if err := validateNeuronConfig(id, config); err != nil {
+		return nil, err
+	}
+
+	neuron := NewNeuron(
+		id,
+		config.Threshold,
+		config.DecayRate,
+		config.RefractoryPeriod,
+		config.FireFactor,
+		config.TargetFiringRate,
+		config.HomeostasisStrength,
+	)
+
+	if err := applyNeuronConfig(neuron, config); err != nil {
+		return nil, err
+	}
+	return neuron, nil
+}
+
+// validateNeuronConfig rejects settings that would produce a non-functional neuron.
+func validateNeuronConfig(id string, config NeuronConfig) error {
+	if id == "" {
+		return fmt.Errorf("neuron ID cannot be empty")
+	}
+	if math.IsNaN(config.Threshold) || config.Threshold <= 0 {
+		return fmt.Errorf("neuron %s: threshold must be positive: %f", id, config.Threshold)
+	}
+	if math.IsNaN(config.DecayRate) || config.DecayRate <= 0 || config.DecayRate > 1 {
+		return fmt.Errorf("neuron %s: decay rate must be in (0,1]: %f", id, config.DecayRate)
+	}
+	if config.RefractoryPeriod < 0 {
+		return fmt.Errorf("neuron %s: refractory period cannot be negative: %v", id, config.RefractoryPeriod)
+	}
+	if math.IsNaN(config.FireFactor) || config.FireFactor <= 0 {
+		return fmt.Errorf("neuron %s: fire factor must be positive: %f", id, config.FireFactor)
+	}
+	if config.TargetFiringRate < 0 {
+		return fmt.Errorf("neuron %s: target firing rate cannot be negative: %f", id, config.TargetFiringRate)
+	}
+	if config.HomeostasisStrength < 0 {
+		return fmt.Errorf("neuron %s: homeostasis strength cannot be negative: %f", id, config.HomeostasisStrength)
+	}
+	if config.EnableSynapticScaling && (config.TargetInputStrength <= 0 || config.ScalingRate <= 0 || config.ScalingInterval <= 0) {
+		return fmt.Errorf("neuron %s: synaptic scaling requires positive target, rate and interval", id)
+	}
+	if config.EnableSTDPFeedback && config.STDPLearningRate < 0 {
+		return fmt.Errorf("neuron %s: STDP learning rate cannot be negative: %f", id, config.STDPLearningRate)
+	}
+	if config.EnableAutoScaling && config.ScalingCheckInterval <= 0 {
+		return fmt.Errorf("neuron %s: auto homeostasis requires a positive check interval", id)
+	}
+	if config.EnableAutoPruning && config.PruningCheckInterval <= 0 {
+		return fmt.Errorf("neuron %s: auto pruning requires a positive check interval", id)
+	}
+	if err := validateHeterosynapticConfig(config.Heterosynaptic); err != nil {
+		return fmt.Errorf("neuron %s: %w", id, err)
+	}
+	if err := validateInhibitionFloor(config.InhibitionFloorMode, config.InhibitionFloor); err != nil {
+		return fmt.Errorf("neuron %s: %w", id, err)
+	}
+	if err := validateRefractoryInputPolicy(config.RefractoryInputPolicy, config.RefractoryAttenuation); err != nil {
+		return fmt.Errorf("neuron %s: %w", id, err)
+	}
+	if err := validatePlateauConfig(config.Plateau); err != nil {
+		return fmt.Errorf("neuron %s: %w", id, err)
+	}
+	for name, port := range config.InputPorts {
+		if err := validateInputPort(name, port); err != nil {
+			return fmt.Errorf("neuron %s: %w", id, err)
+		}
+	}
+	if config.Gain != 0 {
+		if err := validateGain(config.Gain); err != nil {
+			return fmt.Errorf("neuron %s: %w", id, err)
+		}
+	}
+	for ligand, modulator := range config.GainModulators {
+		if err := validateGainModulator(ligand, modulator); err != nil {
+			return fmt.Errorf("neuron %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// INDIVIDUAL OPTIONS
+// ============================================================================
+
+// WithConfig replaces the whole configuration, e.g. with DefaultLearningConfig().
+func WithConfig(config NeuronConfig) NeuronOption {
+	return func(c *NeuronConfig) { *c = config }
+}
+
+// WithThreshold sets the firing threshold.
+func WithThreshold(threshold float64) NeuronOption {
+	return func(c *NeuronConfig) { c.Threshold = threshold }
+}
+
+// WithDecayRate sets the per-millisecond membrane retention factor (0-1].
+func WithDecayRate(decayRate float64) NeuronOption {
+	return func(c *NeuronConfig) { c.DecayRate = decayRate }
+}
+
+// WithRefractoryPeriod sets the absolute refractory period.
+func WithRefractoryPeriod(period time.Duration) NeuronOption {
+	return func(c *NeuronConfig) { c.RefractoryPeriod = period }
+}
+
+// WithFireFactor sets the output amplitude multiplier.
+func WithFireFactor(factor float64) NeuronOption {
+	return func(c *NeuronConfig) { c.FireFactor = factor }
+}
+
+// WithAdaptation sets the homeostatic target rate (Hz) and strength, which
+// together control how the threshold adapts to sustained activity.
+func WithAdaptation(targetFiringRate, homeostasisStrength float64) NeuronOption {
+	return func(c *NeuronConfig) {
+		c.TargetFiringRate = targetFiringRate
+		c.HomeostasisStrength = homeostasisStrength
+	}
+}
+
+// WithPosition sets the neuron's spatial position.
+func WithPosition(position types.Position3D) NeuronOption {
+	return func(c *NeuronConfig) { c.Position = position }
+}
+
+// WithReceptors sets the ligands this neuron responds to.
+func WithReceptors(receptors ...types.LigandType) NeuronOption {
+	return func(c *NeuronConfig) { c.Receptors = receptors }
+}
+
+// WithReleasedLigands sets the neurotransmitters this neuron releases.
+func WithReleasedLigands(ligands ...types.LigandType) NeuronOption {
+	return func(c *NeuronConfig) { c.ReleasedLigands = ligands }
+}
+
+// WithDendriticMode sets the dendritic integration strategy.
+func WithDendriticMode(mode DendriticIntegrationMode) NeuronOption {
+	return func(c *NeuronConfig) { c.DendriticMode = mode }
+}
+
+// WithSynapticScaling enables synaptic scaling with the given parameters.
+func WithSynapticScaling(targetStrength, scalingRate float64, interval time.Duration) NeuronOption {
+	return func(c *NeuronConfig) {
+		c.EnableSynapticScaling = true
+		c.TargetInputStrength = targetStrength
+		c.ScalingRate = scalingRate
+		c.ScalingInterval = interval
+	}
+}
+
+// WithSTDPFeedback enables automatic STDP feedback after firing.
+func WithSTDPFeedback(feedbackDelay time.Duration, learningRate float64) NeuronOption {
+	return func(c *NeuronConfig) {
+		c.EnableSTDPFeedback = true
+		c.STDPFeedbackDelay = feedbackDelay
+		c.STDPLearningRate = learningRate
+	}
+}
+
+// WithAutoHomeostasis enables periodic automatic homeostatic scaling.
+func WithAutoHomeostasis(checkInterval time.Duration) NeuronOption {
+	return func(c *NeuronConfig) {
+		c.EnableAutoScaling = true
+		c.ScalingCheckInterval = checkInterval
+	}
+}
+
+// WithAutoPruning enables periodic pruning of dysfunctional synapses.
+func WithAutoPruning(checkInterval time.Duration) NeuronOption {
+	return func(c *NeuronConfig) {
+		c.EnableAutoPruning = true
+		c.PruningCheckInterval = checkInterval
+	}
+}
+
+// WithMetadata attaches a metadata entry to the neuron.
+func WithMetadata(key string, value interface{}) NeuronOption {
+	return func(c *NeuronConfig) {
+		if c.Metadata == nil {
+			c.Metadata = make(map[string]interface{})
+		}
+		c.Metadata[key] = value
+	}
+}
+
+// WithHeterosynapticPlasticity enables depression of non-stimulated inputs
+// when another input is strongly potentiated (see DefaultHeterosynapticConfig).
+func WithHeterosynapticPlasticity(config HeterosynapticConfig) NeuronOption {
+	return func(c *NeuronConfig) { c.Heterosynaptic = config }
+}
+
+// WithInhibitionFloor clamps hyperpolarization at floor (negative, relative to rest).
+func WithInhibitionFloor(floor float64) NeuronOption {
+	return func(c *NeuronConfig) {
+		c.InhibitionFloorMode = InhibitionFloorClamp
+		c.InhibitionFloor = floor
+	}
+}
+
+// WithReversalPotential saturates inhibition as the membrane approaches the
+// inhibitory reversal potential (negative, relative to rest).
+func WithReversalPotential(reversal float64) NeuronOption {
+	return func(c *NeuronConfig) {
+		c.InhibitionFloorMode = InhibitionFloorReversal
+		c.InhibitionFloor = reversal
+	}
+}
+
+// WithRefractoryInputPolicy sets how input during the refractory period is
+// handled (see SetRefractoryInputPolicy).
+func WithRefractoryInputPolicy(policy RefractoryInputPolicy, attenuation float64) NeuronOption {
+	return func(c *NeuronConfig) {
+		c.RefractoryInputPolicy = policy
+		c.RefractoryAttenuation = attenuation
+	}
+}
+
+// WithPlateauDetection enables dendritic plateau events that transiently
+// lower the firing threshold (see DefaultPlateauConfig).
+func WithPlateauDetection(config PlateauConfig) NeuronOption {
+	return func(c *NeuronConfig) { c.Plateau = config }
+}
+
+// WithInputPort adds a named input port (see SetInputPort).
+func WithInputPort(name string, config InputPortConfig) NeuronOption {
+	return func(c *NeuronConfig) {
+		if c.InputPorts == nil {
+			c.InputPorts = make(map[string]InputPortConfig)
+		}
+		c.InputPorts[name] = config
+	}
+}
+
+// WithGain sets the base gain of synaptic input (see SetGain).
+func WithGain(gain float64) NeuronOption {
+	return func(c *NeuronConfig) { c.Gain = gain }
+}
+
+// WithGainModulator lets a neuromodulator drive the gain (see
+// SetGainModulator).
+func WithGainModulator(ligand types.LigandType, config GainModulatorConfig) NeuronOption {
+	return func(c *NeuronConfig) {
+		if c.GainModulators == nil {
+			c.GainModulators = make(map[types.LigandType]GainModulatorConfig)
+		}
+		c.GainModulators[ligand] = config
+	}
+}
+
+// WithFIFODelivery keeps each target's spikes in the order they were sent
+// (see SetFIFODelivery).
+func WithFIFODelivery() NeuronOption {
+	return func(c *NeuronConfig) { c.FIFODelivery = true }
+}
+
+// WithLatencyBudget enables real-time mode (see SetLatencyBudget).
+func WithLatencyBudget(budget LatencyBudget) NeuronOption {
+	return func(c *NeuronConfig) { c.LatencyBudget = &budget }
+}
+
+// WithAdaptiveInputBuffer enables the adaptive input buffer (see
+// SetAdaptiveInputBuffer).
+func WithAdaptiveInputBuffer(config AdaptiveBufferConfig) NeuronOption {
+	return func(c *NeuronConfig) {
+		config.Enabled = true
+		c.AdaptiveBuffer = config
+	}
+}
+
+// WithLogHandler attaches a structured log handler (see SetLogHandler).
+func WithLogHandler(handler slog.Handler) NeuronOption {
+	return func(c *NeuronConfig) { c.LogHandler = handler }
+}
+
+// WithEnergyMeter charges the neuron's spikes to meter (see SetEnergyMeter).
+func WithEnergyMeter(meter *energy.Meter) NeuronOption {
+	return func(c *NeuronConfig) { c.EnergyMeter = meter }
+}
+
+// ============================================================================
+// BIOLOGICAL PRESETS
+// ============================================================================
+
+// PyramidalCell configures a cortical pyramidal neuron: moderate threshold,
+// slow membrane decay (~20ms time constant), 10ms refractory period, low
+// target rate (1-10 Hz) and glutamate release.
+func PyramidalCell() NeuronOption {
+	return func(c *NeuronConfig) {
+		c.Threshold = EXCITATORY_THRESHOLD_DEFAULT
+		c.DecayRate = EXCITATORY_DECAY_RATE_DEFAULT
+		c.RefractoryPeriod = EXCITATORY_REFRACTORY_PERIOD_DEFAULT
+		c.FireFactor = EXCITATORY_FIRE_FACTOR_DEFAULT
+		c.TargetFiringRate = EXCITATORY_TARGET_RATE_DEFAULT
+		c.HomeostasisStrength = HOMEOSTASIS_STRENGTH_DEFAULT
+		c.ReleasedLigands = []types.LigandType{types.LigandGlutamate}
+		c.Receptors = []types.LigandType{types.LigandGlutamate, types.LigandGABA, types.LigandDopamine}
+	}
+}
+
+// FastSpikingInterneuron configures a parvalbumin-positive basket cell: lower
+// threshold, fast membrane decay, 2ms refractory period for >200 Hz firing,
+// high target rate and strong GABA output.
+func FastSpikingInterneuron() NeuronOption {
+	return func(c *NeuronConfig) {
+		c.Threshold = INHIBITORY_THRESHOLD_DEFAULT
+		c.DecayRate = FAST_SPIKING_DECAY_RATE
+		c.RefractoryPeriod = FAST_SPIKING_REFRACTORY_PERIOD
+		c.FireFactor = INHIBITORY_FIRE_FACTOR_DEFAULT
+		c.TargetFiringRate = FAST_SPIKING_TARGET_RATE
+		c.HomeostasisStrength = HOMEOSTASIS_STRENGTH_DEFAULT
+		c.ReleasedLigands = []types.LigandType{types.LigandGABA}
+		c.Receptors = []types.LigandType{types.LigandGlutamate, types.LigandGABA, types.LigandSerotonin}
+	}
+}
+
+// SensoryNeuron configures a primary sensory afferent: low threshold for weak
+// stimuli, transient responses, short refractory period and weak adaptation so
+// the stimulus encoding is not flattened by homeostasis.
+func SensoryNeuron() NeuronOption {
+	return func(c *NeuronConfig) {
+		c.Threshold = SENSORY_THRESHOLD_DEFAULT
+		c.DecayRate = SENSORY_DECAY_RATE_DEFAULT
+		c.RefractoryPeriod = SENSORY_REFRACTORY_PERIOD_DEFAULT
+		c.FireFactor = EXCITATORY_FIRE_FACTOR_DEFAULT
+		c.TargetFiringRate = SENSORY_NEURON_TARGET_RATE
+		c.HomeostasisStrength = HOMEOSTASIS_STRENGTH_CONSERVATIVE
+		c.ReleasedLigands = []types.LigandType{types.LigandGlutamate}
+		c.Receptors = []types.LigandType{types.LigandGlutamate}
+	}
+}
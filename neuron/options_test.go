@@ -0,0 +1,140 @@
+package neuron
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestNewNeuronWithOptionsDefaults verifies the option-free neuron matches
+// the plain excitatory defaults.
+func TestNewNeuronWithOptionsDefaults(t *testing.T) {
+	n, err := NewNeuronWithOptions("opt_default")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if n.GetThreshold() != EXCITATORY_THRESHOLD_DEFAULT {
+		t.Errorf("Expected threshold %f, got %f", EXCITATORY_THRESHOLD_DEFAULT, n.GetThreshold())
+	}
+	if n.decayRate != EXCITATORY_DECAY_RATE_DEFAULT {
+		t.Errorf("Expected decay %f, got %f", EXCITATORY_DECAY_RATE_DEFAULT, n.decayRate)
+	}
+	if n.refractoryPeriod != EXCITATORY_REFRACTORY_PERIOD_DEFAULT {
+		t.Errorf("Expected refractory %v, got %v", EXCITATORY_REFRACTORY_PERIOD_DEFAULT, n.refractoryPeriod)
+	}
+	if n.State() != types.StateInactive {
+		t.Errorf("Expected neuron to start inactive, got %v", n.State())
+	}
+}
+
+// TestNewNeuronWithOptionsOverrides verifies options apply in order and the
+// optional features are configured.
+func TestNewNeuronWithOptionsOverrides(t *testing.T) {
+	position := types.Position3D{X: 1, Y: 2, Z: 3}
+	n, err := NewNeuronWithOptions("opt_override",
+		PyramidalCell(),
+		WithThreshold(1.5),
+		WithAdaptation(12.0, 0.3),
+		WithPosition(position),
+		WithSTDPFeedback(5*time.Millisecond, 0.02),
+		WithMetadata("layer", "L5"),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if n.GetThreshold() != 1.5 {
+		t.Errorf("Expected overridden threshold 1.5, got %f", n.GetThreshold())
+	}
+	if n.homeostatic.targetFiringRate != 12.0 || n.homeostatic.homeostasisStrength != 0.3 {
+		t.Errorf("Expected adaptation (12.0, 0.3), got (%f, %f)",
+			n.homeostatic.targetFiringRate, n.homeostatic.homeostasisStrength)
+	}
+	if n.Position() != position {
+		t.Errorf("Expected position %v, got %v", position, n.Position())
+	}
+	if !n.stdpSystem.IsEnabled() {
+		t.Error("Expected STDP feedback to be enabled")
+	}
+	if n.GetMetadata()["layer"] != "L5" {
+		t.Error("Expected metadata to be applied")
+	}
+	if len(n.GetReleasedLigands()) != 1 || n.GetReleasedLigands()[0] != types.LigandGlutamate {
+		t.Errorf("Expected pyramidal cell to release glutamate, got %v", n.GetReleasedLigands())
+	}
+}
+
+// TestNewNeuronWithOptionsValidation verifies invalid settings are rejected.
+func TestNewNeuronWithOptionsValidation(t *testing.T) {
+	cases := []struct {
+		name    string
+		opts    []NeuronOption
+		wantErr string
+	}{
+		{"ZeroThreshold", []NeuronOption{WithThreshold(0)}, "threshold"},
+		{"DecayAboveOne", []NeuronOption{WithDecayRate(1.2)}, "decay rate"},
+		{"NegativeRefractory", []NeuronOption{WithRefractoryPeriod(-time.Millisecond)}, "refractory"},
+		{"ZeroFireFactor", []NeuronOption{WithFireFactor(0)}, "fire factor"},
+		{"NegativeTargetRate", []NeuronOption{WithAdaptation(-1, 0.2)}, "target firing rate"},
+		{"BadScaling", []NeuronOption{WithSynapticScaling(1.0, 0, time.Second)}, "synaptic scaling"},
+		{"BadPruningInterval", []NeuronOption{WithAutoPruning(0)}, "auto pruning"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewNeuronWithOptions("invalid", tc.opts...)
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected error mentioning %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := NewNeuronWithOptions(""); err == nil {
+		t.Error("Expected error for empty ID")
+	}
+}
+
+// TestNeuronPresetsBiologicalOrdering verifies the presets differ in the
+// biologically expected directions.
+func TestNeuronPresetsBiologicalOrdering(t *testing.T) {
+	pyramidal, err := NewNeuronWithOptions("pyr", PyramidalCell())
+	if err != nil {
+		t.Fatalf("Pyramidal preset failed: %v", err)
+	}
+	interneuron, err := NewNeuronWithOptions("fs", FastSpikingInterneuron())
+	if err != nil {
+		t.Fatalf("Fast-spiking preset failed: %v", err)
+	}
+	sensory, err := NewNeuronWithOptions("sens", SensoryNeuron())
+	if err != nil {
+		t.Fatalf("Sensory preset failed: %v", err)
+	}
+
+	// Fast-spiking cells recover faster and target higher rates than pyramidal cells
+	if interneuron.refractoryPeriod >= pyramidal.refractoryPeriod {
+		t.Error("Expected fast-spiking interneuron to have a shorter refractory period")
+	}
+	if interneuron.homeostatic.targetFiringRate <= pyramidal.homeostatic.targetFiringRate {
+		t.Error("Expected fast-spiking interneuron to target a higher firing rate")
+	}
+	if interneuron.decayRate >= pyramidal.decayRate {
+		t.Error("Expected fast-spiking interneuron to have faster membrane decay")
+	}
+	if interneuron.getPrimaryNeurotransmitter() != types.LigandGABA {
+		t.Error("Expected fast-spiking interneuron to release GABA")
+	}
+
+	// Sensory neurons are the most excitable and adapt weakly
+	if sensory.GetThreshold() >= interneuron.GetThreshold() {
+		t.Error("Expected sensory neuron to have the lowest threshold")
+	}
+	if sensory.homeostatic.homeostasisStrength >= pyramidal.homeostatic.homeostasisStrength {
+		t.Error("Expected sensory neuron to adapt more weakly than pyramidal cells")
+	}
+}
@@ -0,0 +1,114 @@
+package neuron
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// SPIKE ORDERING GUARANTEES
+// =================================================================================
+//
+// Every neuron delivers its outgoing spikes from one axonal queue, dispatched
+// by its own processing loop. By default the ordering guarantees are:
+//
+//   - Spikes leave the queue in order of delivery time. Spikes due at the
+//     same time leave in the order they were scheduled.
+//   - Delivery time is scheduling time plus delay, so a later spike with a
+//     shorter delay overtakes an earlier one. Delays differ between the
+//     synapses to one target, and change on one synapse through SetDelay,
+//     conduction changes, spatial moves or fault injection.
+//   - Zero-delay synapses bypass the queue and arrive before anything queued.
+//   - When the queue is full, spikes are delivered immediately.
+//   - Spikes from different neurons are not ordered relative to each other.
+//
+// Sequence-learning experiments often need more: that a target receives a
+// source's spikes in the order they were sent. SetFIFODelivery enforces this
+// per (source, target) pair. A spike that would overtake an earlier spike to
+// the same target is held back until that spike's delivery time. Zero-delay
+// spikes go through the queue as well and arrive at the next axon tick
+// (AXON_TICK_INTERVAL). Each held-back spike is counted in
+// DeliveryOrderStats.Reordered and logged as a diagnostic at Warn level. A
+// nonzero count means that timing differences were absorbed into delay, which
+// shifts spike timing seen by the target.
+
+// DeliveryOrderStats counts the work of FIFO delivery ordering.
+type DeliveryOrderStats struct {
+	Scheduled int64 // Spikes scheduled while ordering was enabled
+	Reordered int64 // Spikes held back so they would not overtake an earlier spike
+	Unordered int64 // Spikes delivered immediately because the queue was full
+}
+
+// fifoOrdering tracks the latest scheduled delivery per target.
+type fifoOrdering struct {
+	mu    sync.Mutex
+	last  map[string]time.Time // Target ID -> latest scheduled delivery time
+	stats DeliveryOrderStats
+}
+
+// SetFIFODelivery enables or disables per-target FIFO ordering of outgoing
+// spikes. Enabling resets the statistics.
+func (n *Neuron) SetFIFODelivery(enabled bool) {
+	if !enabled {
+		n.fifo.Store(nil)
+		return
+	}
+	n.fifo.Store(&fifoOrdering{last: make(map[string]time.Time)})
+}
+
+// FIFODelivery reports whether per-target FIFO ordering is enabled.
+// Synapses use it to route zero-delay spikes through the axonal queue.
+func (n *Neuron) FIFODelivery() bool {
+	return n.fifo.Load() != nil
+}
+
+// GetDeliveryOrderStats returns the FIFO ordering counters (zero when
+// ordering is disabled).
+func (n *Neuron) GetDeliveryOrderStats() DeliveryOrderStats {
+	fifo := n.fifo.Load()
+	if fifo == nil {
+		return DeliveryOrderStats{}
+	}
+	fifo.mu.Lock()
+	defer fifo.mu.Unlock()
+	return fifo.stats
+}
+
+// scheduleOrdered queues a spike so it cannot overtake earlier spikes to the
+// same target. The ordering lock is held while queuing, so the queue
+// receives spikes in sequence order.
+func (n *Neuron) scheduleOrdered(fifo *fifoOrdering, msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration) {
+	deliveryTime := n.now().Add(delay)
+	targetID := target.ID()
+
+	fifo.mu.Lock()
+	defer fifo.mu.Unlock()
+
+	fifo.stats.Scheduled++
+	if last, ok := fifo.last[targetID]; ok && deliveryTime.Before(last) {
+		fifo.stats.Reordered++
+		if n.logEnabled(slog.LevelWarn) {
+			n.logf(slog.LevelWarn, logging.RecordDiagnostic, "spike held back to preserve delivery order",
+				"target", targetID, "delay", delay, "held", last.Sub(deliveryTime))
+		}
+		deliveryTime = last
+	}
+	fifo.last[targetID] = deliveryTime
+
+	if scheduler := n.stepScheduler(); scheduler != nil {
+		scheduler(msg, target, deliveryTime.Sub(n.now()))
+		return
+	}
+
+	select {
+	case n.deliveryQueue <- delayedMessage{message: msg, target: target, deliveryTime: deliveryTime}:
+	default:
+		fifo.stats.Unordered++
+		target.Receive(msg)
+	}
+}
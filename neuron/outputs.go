@@ -0,0 +1,96 @@
+package neuron
+
+import "github.com/SynapticNetworks/temporal-neuron/types"
+
+// =================================================================================
+// OUTPUT SYNAPSE TABLE
+// =================================================================================
+//
+// Every spike walks all output callbacks. The table keeps them in a dense
+// slice, so the firing path copies one slice instead of rebuilding a
+// string-keyed map per spike, and stamps each signal with the synapse's
+// interned ID handle (types.IDHandle). The index from handle to slot is
+// used by AddOutputCallback and RemoveOutputCallback; strings are resolved
+// to handles only there, at the API boundary.
+//
+// Each entry holds a types.IDRef, so a synapse ID stays interned while the
+// neuron has its callback and is released with it. The table is guarded by
+// the neuron's outputsMutex.
+
+// outputEntry is one output synapse.
+type outputEntry struct {
+	synapseID string // Interned copy of the synapse ID
+	ref       *types.IDRef
+	callback  types.OutputCallback
+}
+
+// outputTable holds the output callbacks of a neuron.
+type outputTable struct {
+	index   map[types.IDHandle]int // Synapse ID handle -> entries index
+	entries []outputEntry
+}
+
+// find returns the index of synapseID. IDs without a handle (a full
+// registry) are found by scanning.
+func (t *outputTable) find(synapseID string) (int, bool) {
+	if handle, ok := types.DefaultIDs.Lookup(synapseID); ok {
+		i, found := t.index[handle]
+		return i, found
+	}
+	for i := range t.entries {
+		if t.entries[i].ref.Handle() == 0 && t.entries[i].synapseID == synapseID {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// set adds or replaces the callback of synapseID.
+func (t *outputTable) set(synapseID string, callback types.OutputCallback) {
+	if i, ok := t.find(synapseID); ok {
+		t.entries[i].callback = callback
+		return
+	}
+	ref, id := types.DefaultIDs.Acquire(synapseID)
+	if handle := ref.Handle(); handle != 0 {
+		if t.index == nil {
+			t.index = make(map[types.IDHandle]int)
+		}
+		t.index[handle] = len(t.entries)
+	}
+	t.entries = append(t.entries, outputEntry{synapseID: id, ref: ref, callback: callback})
+}
+
+// remove deletes the callback of synapseID, moving the last entry into its
+// slot.
+func (t *outputTable) remove(synapseID string) {
+	i, ok := t.find(synapseID)
+	if !ok {
+		return
+	}
+	delete(t.index, t.entries[i].ref.Handle())
+	last := len(t.entries) - 1
+	if i != last {
+		t.entries[i] = t.entries[last]
+		if handle := t.entries[i].ref.Handle(); handle != 0 {
+			t.index[handle] = i
+		}
+	}
+	t.entries[last] = outputEntry{}
+	t.entries = t.entries[:last]
+}
+
+// clear removes all callbacks.
+func (t *outputTable) clear() {
+	*t = outputTable{}
+}
+
+// len returns the number of output synapses.
+func (t *outputTable) len() int {
+	return len(t.entries)
+}
+
+// snapshot returns a copy of the entries for use without the lock.
+func (t *outputTable) snapshot() []outputEntry {
+	return append([]outputEntry(nil), t.entries...)
+}
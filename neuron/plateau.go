@@ -0,0 +1,277 @@
+package neuron
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+)
+
+// =================================================================================
+// DENDRITIC PLATEAU POTENTIALS
+// =================================================================================
+//
+// Strong, sustained input to a dendritic branch recruits NMDA receptors and
+// voltage-gated calcium channels and produces a plateau potential: a local
+// depolarization lasting tens to hundreds of milliseconds. In CA1 a single
+// plateau lowers the somatic firing threshold for its duration and opens the
+// seconds-long window of behavioral-timescale synaptic plasticity (Bittner et
+// al. 2017), which is why experiments need to see the events themselves.
+//
+// The neuron keeps a local dendritic potential: every synaptic input after
+// dendritic integration is added to it, and it decays with TimeConstant. When
+// the potential stays at or above Threshold for MinDuration, a plateau event
+// is emitted and the firing threshold is lowered by ThresholdReduction (a
+// fraction of the threshold) for Duration. The potential must fall below
+// Threshold before the next plateau can start, so one sustained episode
+// yields one event.
+//
+// Detection runs on the 1ms decay tick, so MinDuration has millisecond
+// resolution. Events go to the plateau handler, which runs after the neuron's
+// locks are released, and to every registered input synapse that accepts an
+// instructive signal (behavioral-timescale plasticity, see synapse.SetBTSP).
+// InducePlateau emits an event directly, like the somatic current injection
+// used to induce place fields experimentally.
+
+// PlateauConfig configures plateau detection.
+type PlateauConfig struct {
+	Enabled            bool          `json:"enabled"`
+	Threshold          float64       `json:"threshold"`           // Local potential that counts as a plateau (accumulator units)
+	MinDuration        time.Duration `json:"min_duration"`        // How long the potential must stay above Threshold
+	ThresholdReduction float64       `json:"threshold_reduction"` // Fraction of the firing threshold removed during the plateau, in [0, 1)
+	Duration           time.Duration `json:"duration"`            // How long the threshold stays lowered
+	TimeConstant       time.Duration `json:"time_constant"`       // Decay of the local potential (0 = default)
+}
+
+// PlateauEvent describes one detected plateau potential.
+type PlateauEvent struct {
+	NeuronID           string    `json:"neuron_id"`
+	Onset              time.Time `json:"onset"`               // When the local potential crossed Threshold
+	Time               time.Time `json:"time"`                // When the plateau was detected (Onset + MinDuration)
+	LocalPotential     float64   `json:"local_potential"`     // Local potential at detection
+	ThresholdReduction float64   `json:"threshold_reduction"` // Fraction removed from the firing threshold
+	Until              time.Time `json:"until"`               // End of the lowered threshold
+}
+
+// PlateauHandler receives plateau events. Handlers run on the neuron's
+// processing goroutine and must not block.
+type PlateauHandler func(event PlateauEvent)
+
+// plateauReceiver is implemented by input synapses that learn from plateaus
+// (synapse.BasicSynapse with BTSP enabled).
+type plateauReceiver interface {
+	ApplyPlateau(at time.Time)
+}
+
+// plateauState is the detector state. Guarded by stateMutex.
+type plateauState struct {
+	config     PlateauConfig
+	potential  float64   // Local dendritic potential
+	updated    time.Time // Last decay of potential
+	aboveSince time.Time // Start of the current suprathreshold episode (zero = below)
+	emitted    bool      // Current episode already produced an event
+	until      time.Time // End of the lowered threshold
+}
+
+// DefaultPlateauConfig returns enabled detection with CA1-like timing.
+func DefaultPlateauConfig() PlateauConfig {
+	return PlateauConfig{
+		Enabled:            true,
+		Threshold:          PLATEAU_THRESHOLD_DEFAULT,
+		MinDuration:        PLATEAU_MIN_DURATION_DEFAULT,
+		ThresholdReduction: PLATEAU_THRESHOLD_REDUCTION_DEFAULT,
+		Duration:           PLATEAU_DURATION_DEFAULT,
+		TimeConstant:       PLATEAU_TIME_CONSTANT_DEFAULT,
+	}
+}
+
+// validatePlateauConfig checks an enabled configuration.
+func validatePlateauConfig(config PlateauConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if math.IsNaN(config.Threshold) || config.Threshold <= 0 {
+		return fmt.Errorf("plateau threshold must be positive: %f", config.Threshold)
+	}
+	if config.MinDuration < 0 {
+		return fmt.Errorf("plateau minimum duration cannot be negative: %v", config.MinDuration)
+	}
+	if math.IsNaN(config.ThresholdReduction) || config.ThresholdReduction < 0 || config.ThresholdReduction >= 1 {
+		return fmt.Errorf("plateau threshold reduction must be in [0, 1): %f", config.ThresholdReduction)
+	}
+	if config.Duration <= 0 {
+		return fmt.Errorf("plateau duration must be positive: %v", config.Duration)
+	}
+	if config.TimeConstant < 0 {
+		return fmt.Errorf("plateau time constant cannot be negative: %v", config.TimeConstant)
+	}
+	return nil
+}
+
+// SetPlateauDetection configures plateau detection (Enabled false turns it
+// off and ends a plateau in progress).
+func (n *Neuron) SetPlateauDetection(config PlateauConfig) error {
+	if err := validatePlateauConfig(config); err != nil {
+		return err
+	}
+	if config.TimeConstant == 0 {
+		config.TimeConstant = PLATEAU_TIME_CONSTANT_DEFAULT
+	}
+
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	if !config.Enabled {
+		n.plateau = nil
+		return nil
+	}
+	if n.plateau == nil {
+		n.plateau = &plateauState{updated: n.now()}
+	}
+	n.plateau.config = config
+	return nil
+}
+
+// GetPlateauDetection returns the detection configuration.
+func (n *Neuron) GetPlateauDetection() PlateauConfig {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	if n.plateau == nil {
+		return PlateauConfig{}
+	}
+	return n.plateau.config
+}
+
+// SetPlateauHandler installs a handler for plateau events (nil removes it).
+func (n *Neuron) SetPlateauHandler(handler PlateauHandler) {
+	if handler == nil {
+		n.plateauHandler.Store(nil)
+		return
+	}
+	n.plateauHandler.Store(&handler)
+}
+
+// GetPlateauEventCount returns the number of plateaus detected.
+func (n *Neuron) GetPlateauEventCount() int64 {
+	return n.plateauEvents.Load()
+}
+
+// IsPlateauActive reports whether a plateau currently lowers the threshold.
+func (n *Neuron) IsPlateauActive() bool {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.plateau != nil && n.now().Before(n.plateau.until)
+}
+
+// InducePlateau emits a plateau event now, regardless of dendritic input.
+// The firing threshold is lowered only when plateau detection is enabled.
+func (n *Neuron) InducePlateau() {
+	now := n.now()
+	event := &PlateauEvent{NeuronID: n.ID(), Onset: now, Time: now, Until: now}
+
+	n.stateMutex.Lock()
+	if p := n.plateau; p != nil {
+		n.decayPlateauUnsafe(now)
+		p.until = now.Add(p.config.Duration)
+		event.LocalPotential = p.potential
+		event.ThresholdReduction = p.config.ThresholdReduction
+		event.Until = p.until
+	}
+	n.stateMutex.Unlock()
+
+	n.plateauEvents.Add(1)
+	n.emitPlateau(event)
+}
+
+// GetFiringThreshold returns the threshold the accumulator is compared
+// against, including any plateau reduction and threshold input ports.
+func (n *Neuron) GetFiringThreshold() float64 {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.firingThresholdUnsafe()
+}
+
+// firingThresholdUnsafe returns the threshold lowered by an active plateau
+// and moved by threshold input ports (see ports.go).
+// This method must be called with stateMutex held.
+func (n *Neuron) firingThresholdUnsafe() float64 {
+	threshold := n.threshold
+	if n.inputPorts != nil {
+		threshold *= n.portThresholdFactorUnsafe(n.now())
+	}
+	if n.plateau == nil || !n.now().Before(n.plateau.until) {
+		return threshold
+	}
+	return threshold * (1 - n.plateau.config.ThresholdReduction)
+}
+
+// addPlateauInputUnsafe adds dendritic input to the local potential.
+// This method must be called with stateMutex held.
+func (n *Neuron) addPlateauInputUnsafe(value float64, now time.Time) {
+	if n.plateau == nil {
+		return
+	}
+	n.decayPlateauUnsafe(now)
+	n.plateau.potential += value
+}
+
+// decayPlateauUnsafe decays the local potential to now.
+// This method must be called with stateMutex held.
+func (n *Neuron) decayPlateauUnsafe(now time.Time) {
+	p := n.plateau
+	if elapsed := now.Sub(p.updated); elapsed > 0 {
+		p.potential *= math.Exp(-float64(elapsed) / float64(p.config.TimeConstant))
+		p.updated = now
+	}
+}
+
+// detectPlateauUnsafe advances the detector and returns the event when a
+// plateau starts. This method must be called with stateMutex held.
+func (n *Neuron) detectPlateauUnsafe(now time.Time) *PlateauEvent {
+	if n.plateau == nil {
+		return nil
+	}
+	n.decayPlateauUnsafe(now)
+
+	p := n.plateau
+	if p.potential < p.config.Threshold {
+		p.aboveSince = time.Time{}
+		p.emitted = false
+		return nil
+	}
+	if p.aboveSince.IsZero() {
+		p.aboveSince = now
+	}
+	if p.emitted || now.Sub(p.aboveSince) < p.config.MinDuration {
+		return nil
+	}
+
+	p.emitted = true
+	p.until = now.Add(p.config.Duration)
+	n.plateauEvents.Add(1)
+	return &PlateauEvent{
+		NeuronID:           n.ID(),
+		Onset:              p.aboveSince,
+		Time:               now,
+		LocalPotential:     p.potential,
+		ThresholdReduction: p.config.ThresholdReduction,
+		Until:              p.until,
+	}
+}
+
+// emitPlateau reports a plateau event. Must be called without neuron locks
+// held.
+func (n *Neuron) emitPlateau(event *PlateauEvent) {
+	n.UpdateMetadata("last_dendritic_plateau", event.Time)
+	n.logf(slog.LevelDebug, logging.RecordIntegration, "dendritic plateau",
+		"onset", event.Onset, "local_potential", event.LocalPotential, "until", event.Until)
+	if handler := n.plateauHandler.Load(); handler != nil {
+		(*handler)(*event)
+	}
+	for _, input := range n.GetInputSynapses() {
+		if receiver, ok := input.(plateauReceiver); ok {
+			receiver.ApplyPlateau(event.Time)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package neuron
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// =================================================================================
+// INPUT PORTS
+// =================================================================================
+//
+// By default every synaptic input is summed into the accumulator. Real
+// neurons treat inputs differently depending on where and how they arrive:
+// basal input drives the soma, apical input is attenuated on its way down
+// and can multiply the response to basal input, and neuromodulatory input
+// hardly depolarizes at all but changes gain and excitability. Input ports
+// give a neuron named inputs with their own integration rule:
+//
+//   - InputPortAdditive: the input is scaled by Scale and summed like
+//     ordinary input (e.g. attenuated apical input).
+//   - InputPortGain: the input feeds a modulatory level that decays with
+//     TimeConstant; all additive input is multiplied by 1 + Scale × level.
+//   - InputPortThreshold: the modulatory level moves the firing threshold,
+//     which is multiplied by 1 - Scale × level (positive Scale lowers it).
+//
+// Modulatory ports never change the accumulator directly. Several gain or
+// threshold ports combine multiplicatively. The gain is never negative and
+// the threshold never falls below INPUT_PORT_MIN_THRESHOLD_FACTOR of its
+// base value.
+//
+// Signals name their port in NeuralSignal.Port (synapses stamp it, see
+// synapse.WithTargetPort). Signals without a port, or for a port the neuron
+// does not have, are ordinary somatic input, so a neuron without ports
+// behaves exactly as before.
+
+// Conventional port names.
+const (
+	INPUT_PORT_SOMA       = "soma"
+	INPUT_PORT_APICAL     = "apical"
+	INPUT_PORT_MODULATORY = "modulatory"
+)
+
+// InputPortMode selects how a port integrates its input.
+type InputPortMode int
+
+const (
+	// InputPortAdditive sums scaled input into the accumulator.
+	InputPortAdditive InputPortMode = iota
+
+	// InputPortGain multiplies additive input by the port's level.
+	InputPortGain
+
+	// InputPortThreshold shifts the firing threshold by the port's level.
+	InputPortThreshold
+)
+
+// String returns the mode name.
+func (m InputPortMode) String() string {
+	switch m {
+	case InputPortAdditive:
+		return "additive"
+	case InputPortGain:
+		return "gain"
+	case InputPortThreshold:
+		return "threshold"
+	default:
+		return fmt.Sprintf("InputPortMode(%d)", int(m))
+	}
+}
+
+// InputPortConfig configures one input port.
+type InputPortConfig struct {
+	Mode         InputPortMode `json:"mode"`
+	Scale        float64       `json:"scale"`         // Input multiplier (additive) or sensitivity per unit level (gain, threshold)
+	TimeConstant time.Duration `json:"time_constant"` // Decay of the modulatory level (gain, threshold; 0 = default)
+}
+
+// inputPort is a port with its modulatory level. Guarded by stateMutex.
+type inputPort struct {
+	config  InputPortConfig
+	level   float64
+	updated time.Time
+}
+
+// ApicalPortConfig returns an additive port that attenuates input by
+// INPUT_PORT_APICAL_SCALE_DEFAULT.
+func ApicalPortConfig() InputPortConfig {
+	return InputPortConfig{Mode: InputPortAdditive, Scale: INPUT_PORT_APICAL_SCALE_DEFAULT}
+}
+
+// ModulatoryGainPortConfig returns a gain port with default sensitivity and
+// decay.
+func ModulatoryGainPortConfig() InputPortConfig {
+	return InputPortConfig{
+		Mode:         InputPortGain,
+		Scale:        INPUT_PORT_SENSITIVITY_DEFAULT,
+		TimeConstant: INPUT_PORT_TIME_CONSTANT_DEFAULT,
+	}
+}
+
+// validateInputPort checks a port name and configuration.
+func validateInputPort(name string, config InputPortConfig) error {
+	if name == "" {
+		return fmt.Errorf("input port name cannot be empty")
+	}
+	if config.Mode < InputPortAdditive || config.Mode > InputPortThreshold {
+		return fmt.Errorf("input port %s: unknown mode %v", name, config.Mode)
+	}
+	if math.IsNaN(config.Scale) || math.IsInf(config.Scale, 0) {
+		return fmt.Errorf("input port %s: scale must be finite: %f", name, config.Scale)
+	}
+	if config.TimeConstant < 0 {
+		return fmt.Errorf("input port %s: time constant cannot be negative: %v", name, config.TimeConstant)
+	}
+	return nil
+}
+
+// SetInputPort adds or replaces a named input port. Replacing a port resets
+// its modulatory level.
+func (n *Neuron) SetInputPort(name string, config InputPortConfig) error {
+	if err := validateInputPort(name, config); err != nil {
+		return err
+	}
+	if config.Mode != InputPortAdditive && config.TimeConstant == 0 {
+		config.TimeConstant = INPUT_PORT_TIME_CONSTANT_DEFAULT
+	}
+
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	if n.inputPorts == nil {
+		n.inputPorts = make(map[string]*inputPort)
+	}
+	n.inputPorts[name] = &inputPort{config: config}
+	return nil
+}
+
+// RemoveInputPort removes a port; its input becomes ordinary somatic input.
+func (n *Neuron) RemoveInputPort(name string) {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	delete(n.inputPorts, name)
+}
+
+// GetInputPorts returns the configured port names, sorted.
+func (n *Neuron) GetInputPorts() []string {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	names := make([]string, 0, len(n.inputPorts))
+	for name := range n.inputPorts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetInputPort returns a port's configuration and current modulatory level
+// (always 0 for additive ports).
+func (n *Neuron) GetInputPort(name string) (InputPortConfig, float64, bool) {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	port, ok := n.inputPorts[name]
+	if !ok {
+		return InputPortConfig{}, 0, false
+	}
+	port.decayTo(n.now())
+	return port.config, port.level, true
+}
+
+// routeToPortUnsafe applies the port of a signal. It returns the scaled
+// value and true for input that reaches the accumulator, or false when a
+// modulatory port absorbed it. This method must be called with stateMutex
+// held.
+func (n *Neuron) routeToPortUnsafe(portName string, value float64, now time.Time) (float64, bool) {
+	port, ok := n.inputPorts[portName]
+	if !ok || portName == "" {
+		return value, true
+	}
+	if port.config.Mode == InputPortAdditive {
+		return value * port.config.Scale, true
+	}
+	port.decayTo(now)
+	port.level += value
+	return 0, false
+}
+
+// portGainUnsafe returns the combined gain of all gain ports.
+// This method must be called with stateMutex held.
+func (n *Neuron) portGainUnsafe(now time.Time) float64 {
+	gain := 1.0
+	for _, port := range n.inputPorts {
+		if port.config.Mode == InputPortGain {
+			port.decayTo(now)
+			gain *= math.Max(0, 1+port.config.Scale*port.level)
+		}
+	}
+	return gain
+}
+
+// portThresholdFactorUnsafe returns the combined threshold factor of all
+// threshold ports. This method must be called with stateMutex held.
+func (n *Neuron) portThresholdFactorUnsafe(now time.Time) float64 {
+	factor := 1.0
+	for _, port := range n.inputPorts {
+		if port.config.Mode == InputPortThreshold {
+			port.decayTo(now)
+			factor *= 1 - port.config.Scale*port.level
+		}
+	}
+	return math.Max(INPUT_PORT_MIN_THRESHOLD_FACTOR, factor)
+}
+
+// decayTo decays the modulatory level to now.
+func (p *inputPort) decayTo(now time.Time) {
+	if p.config.Mode == InputPortAdditive {
+		return
+	}
+	if elapsed := now.Sub(p.updated); elapsed > 0 && !p.updated.IsZero() {
+		p.level *= math.Exp(-float64(elapsed) / float64(p.config.TimeConstant))
+	}
+	if now.After(p.updated) {
+		p.updated = now
+	}
+}
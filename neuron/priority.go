@@ -0,0 +1,95 @@
+package neuron
+
+import (
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// INPUT PRIORITY
+// =================================================================================
+//
+// Input arrives on a bounded buffer, and a neuron that falls behind loses
+// input. Signals carry a priority class (types.Priority, stamped by the
+// transmitting synapse) that decides what is lost first:
+//
+//   - Critical input (sensory and feedback pathways) has its own lane of
+//     INPUT_CRITICAL_BUFFER_SIZE, processed ahead of the shared buffer. When
+//     the lane is full it falls back to the shared buffer.
+//   - Normal input uses the shared buffer.
+//   - Background input (noise, tonic drive) is only queued while the shared
+//     buffer is below INPUT_BACKGROUND_HEADROOM, so it never takes the last
+//     free slots from normal input. Real-time mode sheds it first as well
+//     (see realtime.go).
+//
+// Every input lost to a full buffer or to load shedding is counted per
+// priority class (GetPriorityDrops). Refractory drops are a policy, not
+// load, and are counted separately.
+
+// enqueueInput queues msg by priority and reports whether it was accepted.
+func (n *Neuron) enqueueInput(msg types.NeuralSignal) bool {
+	switch {
+	case msg.Priority >= types.PriorityCritical:
+		select {
+		case n.criticalBuffer <- msg:
+			return true
+		default:
+		}
+	case msg.Priority <= types.PriorityBackground:
+		if n.GetInputQueueOccupancy() >= INPUT_BACKGROUND_HEADROOM {
+			return false
+		}
+	}
+	if n.adaptiveBuffer.Load() != nil {
+		return n.enqueueAdaptive(msg)
+	}
+	select {
+	case n.inputBuffer <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// processCriticalInputs integrates the critical input queued so far.
+func (n *Neuron) processCriticalInputs() {
+	for {
+		select {
+		case msg := <-n.criticalBuffer:
+			n.processIncomingMessage(msg)
+		default:
+			return
+		}
+	}
+}
+
+// recordPriorityDrop counts an input lost to load.
+func (n *Neuron) recordPriorityDrop(priority types.Priority) {
+	if priority < types.PriorityBackground {
+		priority = types.PriorityBackground
+	}
+	if priority > types.PriorityCritical {
+		priority = types.PriorityCritical
+	}
+	n.priorityDrops[priority-types.PriorityBackground].Add(1)
+}
+
+// GetPriorityDrops returns the number of inputs lost to a full buffer or to
+// load shedding, per priority class.
+func (n *Neuron) GetPriorityDrops() map[types.Priority]int64 {
+	drops := make(map[types.Priority]int64, len(n.priorityDrops))
+	for i := range n.priorityDrops {
+		drops[types.PriorityBackground+types.Priority(i)] = n.priorityDrops[i].Load()
+	}
+	return drops
+}
+
+// GetInputQueueOccupancy returns the fill of the shared input buffer in
+// [0, 1], the load signal stimulus sources throttle on (see
+// aer.PlayerConfig.Backpressure). With the adaptive buffer it covers the
+// overflow queue and the current capacity.
+func (n *Neuron) GetInputQueueOccupancy() float64 {
+	if occupancy, ok := n.adaptiveOccupancy(); ok {
+		return occupancy
+	}
+	return float64(len(n.inputBuffer)) / float64(cap(n.inputBuffer))
+}
@@ -4,6 +4,8 @@ import (
 	"math"
 	"time"
 
+	"github.com/SynapticNetworks/temporal-neuron/invariant"
+	"github.com/SynapticNetworks/temporal-neuron/logging"
 	"github.com/SynapticNetworks/temporal-neuron/types"
 )
 
@@ -33,15 +35,24 @@ All firing logic is delegated to firing.go to maintain separation of concerns.
 // Run is the main background processing loop that coordinates all neuron subsystems
 func (n *Neuron) Run() {
 	// Setup timing for different processing phases
-	decayTicker := time.NewTicker(1 * time.Millisecond) // Fast membrane decay
-	axonTicker := time.NewTicker(AXON_TICK_INTERVAL)    // Axonal delivery processing
+	decayTicker := time.NewTicker(MEMBRANE_TICK_INTERVAL)     // Fast membrane decay (see stepping.go)
+	axonTicker := n.GetTiming().NewTicker(AXON_TICK_INTERVAL) // Axonal delivery processing (see timing.go)
 
 	defer decayTicker.Stop()
-	defer axonTicker.Stop()
+	defer func() { axonTicker.Stop() }()
 
 	for {
 		select {
+		case msg := <-n.criticalBuffer:
+			n.chaosPause(ChaosBeforeIntegration)
+			n.processIncomingMessage(msg)
+
 		case msg := <-n.inputBuffer:
+			// Overflow takes the freed slot (see adaptive_buffer.go), and
+			// critical input queued meanwhile goes first
+			n.refillInputBuffer()
+			n.chaosPause(ChaosBeforeIntegration)
+			n.processCriticalInputs()
 			n.processIncomingMessage(msg)
 
 		case <-decayTicker.C:
@@ -52,8 +63,13 @@ func (n *Neuron) Run() {
 			n.processScheduledSTDPFeedback()
 
 		case <-axonTicker.C:
+			n.chaosPause(ChaosBeforeDelivery)
 			n.processAxonalDeliveries()
 
+		case <-n.timingChanged:
+			axonTicker.Stop()
+			axonTicker = n.GetTiming().NewTicker(AXON_TICK_INTERVAL)
+
 		case <-n.ctx.Done():
 			return
 		}
@@ -62,9 +78,11 @@ func (n *Neuron) Run() {
 
 func (n *Neuron) processScheduledSTDPFeedback() {
 
-	// Get neuron ID and callbacks
+	// Get neuron ID and callbacks (Stop clears them concurrently)
 	neuronID := n.ID()
+	n.stateMutex.Lock()
 	callbacks := n.matrixCallbacks
+	n.stateMutex.Unlock()
 
 	// Skip if no callbacks available
 	if callbacks == nil {
@@ -72,11 +90,18 @@ func (n *Neuron) processScheduledSTDPFeedback() {
 	}
 
 	// Check and deliver feedback if it's time
-	feedbackDelivered := n.stdpSystem.CheckAndDeliverFeedback(neuronID, callbacks)
+	// Heterosynaptic plasticity compares input weights around the delivery,
+	// so only wrap ticks on which feedback is actually due
+	var feedbackDelivered bool
+	if n.stdpSystem.isFeedbackDue() {
+		n.withHeterosynapticPlasticity(func() {
+			feedbackDelivered = n.stdpSystem.CheckAndDeliverFeedback(neuronID, callbacks)
+		})
+	}
 
 	// Update metadata if feedback was delivered
 	if feedbackDelivered {
-		n.UpdateMetadata("scheduled_stdp_feedback_delivered", time.Now())
+		n.UpdateMetadata("scheduled_stdp_feedback_delivered", n.now())
 	}
 }
 
@@ -86,6 +111,11 @@ func (n *Neuron) processScheduledSTDPFeedback() {
 
 // processIncomingMessage handles incoming synaptic messages through the full processing pipeline
 func (n *Neuron) processIncomingMessage(msg types.NeuralSignal) {
+	// Real-time mode may shed background input that missed its deadline
+	if !n.checkDeadline(msg, n.now()) {
+		return
+	}
+
 	// Check if the neuron has a dendrite system that needs to be accessed
 	hasDendrite := n.dendrite != nil
 
@@ -95,10 +125,41 @@ func (n *Neuron) processIncomingMessage(msg types.NeuralSignal) {
 	synapticScaling = n.synapticScaling
 	n.stateMutex.Unlock()
 
+	// Integration record is emitted after the state lock is released
+	var record []any
+	defer func() {
+		if record != nil {
+			n.logf(logging.LevelTrace, logging.RecordIntegration, "input integrated", record...)
+		}
+	}()
+
 	// Start processing the message
 	n.stateMutex.Lock()
 	defer n.stateMutex.Unlock()
 
+	// Per-synapse bookkeeping through the routing table (own lock)
+	n.routes.record(&msg, n.now())
+
+	// Queued input may be discarded or attenuated if the neuron has fired since
+	if !n.refractoryInputUnsafe(&msg, n.now()) {
+		return
+	}
+
+	// === STEP 0: INPUT PORT ===
+	// Modulatory ports absorb their input into gain or threshold; a lowered
+	// threshold can make the neuron fire on its current potential
+	if n.inputPorts != nil {
+		value, additive := n.routeToPortUnsafe(msg.Port, msg.Value, n.now())
+		if !additive {
+			if !n.holdClampUnsafe() && n.accumulator > 0 && n.accumulator >= n.firingThresholdUnsafe() {
+				n.fireUnsafe()
+				n.resetAccumulatorUnsafe()
+			}
+			return
+		}
+		msg.Value = value
+	}
+
 	// === STEP 1: DENDRITIC INTEGRATION ===
 	var finalValue float64
 
@@ -112,7 +173,7 @@ func (n *Neuron) processIncomingMessage(msg types.NeuralSignal) {
 
 			// Update metadata with dendritic computation details
 			if dendriticResult.DendriticSpike {
-				n.UpdateMetadata("last_dendritic_spike", time.Now())
+				n.UpdateMetadata("last_dendritic_spike", n.now())
 			}
 			if dendriticResult.NonlinearAmplification != 0 {
 				n.UpdateMetadata("nonlinear_amplification", dendriticResult.NonlinearAmplification)
@@ -126,11 +187,27 @@ func (n *Neuron) processIncomingMessage(msg types.NeuralSignal) {
 		finalValue = n.applySynapticScalingToMessageWithSystem(msg, synapticScaling)
 	}
 
+	// Gain modulation and gain ports scale the integrated input (see gain.go)
+	if n.gain != nil || n.inputPorts != nil {
+		finalValue *= n.inputGainUnsafe(n.now())
+	}
+
 	// === STEP 2: ACCUMULATOR INTEGRATION ===
-	n.accumulator += finalValue
+	// Finite input can still overflow through scaling and gain
+	if !isFinite(finalValue) {
+		n.nonFiniteInputs.Add(1)
+		return
+	}
+	n.addPlateauInputUnsafe(finalValue, n.now())
+	finalValue = n.integrateUnsafe(finalValue)
+	if n.logEnabled(logging.LevelTrace) {
+		record = []any{"source_id", msg.SourceID, "input", msg.Value, "effective", finalValue,
+			"accumulator", n.accumulator, "threshold", n.threshold}
+	}
 
 	// === STEP 3: FIRING DECISION ===
-	if n.accumulator >= n.threshold {
+	// A voltage clamp absorbs the input and suppresses firing (see clamp.go)
+	if !n.holdClampUnsafe() && n.accumulator >= n.firingThresholdUnsafe() {
 		n.fireUnsafe() // Implemented in firing.go
 		n.resetAccumulatorUnsafe()
 	}
@@ -177,12 +254,22 @@ func (n *Neuron) processDecayAndHomeostasis() {
 	synapticScaling = n.synapticScaling
 	n.stateMutex.Unlock()
 
+	// Plateau events are emitted after the state lock is released
+	var plateau *PlateauEvent
+	defer func() {
+		if plateau != nil {
+			n.emitPlateau(plateau)
+		}
+	}()
+
 	// Now acquire the main lock for state updates
 	n.stateMutex.Lock()
 	defer n.stateMutex.Unlock()
 
 	// === STEP 1: BASIC MEMBRANE DECAY ===
 	n.accumulator *= n.decayRate
+	n.injectClampCurrentUnsafe(n.now())
+	invariant.Finite(n.ID(), "accumulator", n.accumulator)
 
 	// === STEP 2: CALCIUM DYNAMICS ===
 	n.homeostatic.calciumLevel *= n.homeostatic.calciumDecayRate
@@ -197,17 +284,18 @@ func (n *Neuron) processDecayAndHomeostasis() {
 			IntracellularCalcium: n.homeostatic.calciumLevel,
 			LastSpikeTime:        n.lastFireTime,
 			RecentSpikeCount:     len(n.homeostatic.firingHistory),
-			BackPropagatingSpike: !n.lastFireTime.IsZero() && time.Since(n.lastFireTime) < 5*time.Millisecond,
+			BackPropagatingSpike: !n.lastFireTime.IsZero() && n.now().Sub(n.lastFireTime) < 5*time.Millisecond,
 		}
 
 		// Process any buffered dendritic inputs
 		dendriticResult := n.dendrite.Process(state)
-		if dendriticResult != nil {
-			n.accumulator += dendriticResult.NetCurrent
+		if dendriticResult != nil && isFinite(dendriticResult.NetCurrent) {
+			n.addPlateauInputUnsafe(dendriticResult.NetCurrent, n.now())
+			n.integrateUnsafe(dendriticResult.NetCurrent)
 
 			// Track dendritic computation metadata
 			if dendriticResult.DendriticSpike {
-				n.UpdateMetadata("last_dendritic_spike", time.Now())
+				n.UpdateMetadata("last_dendritic_spike", n.now())
 			}
 
 			// Update calcium from dendritic activity
@@ -241,25 +329,40 @@ func (n *Neuron) processDecayAndHomeostasis() {
 		}
 	}
 
-	// === STEP 5: CHECK FIRING AFTER ALL PROCESSING ===
-	if n.accumulator >= n.threshold {
+	// === STEP 5: PLATEAU DETECTION ===
+	// A sustained local depolarization lowers the threshold checked below
+	plateau = n.detectPlateauUnsafe(n.now())
+
+	// === STEP 6: CHECK FIRING AFTER ALL PROCESSING ===
+	if !n.holdClampUnsafe() && n.accumulator >= n.firingThresholdUnsafe() {
 		n.fireUnsafe() // Implemented in firing.go
 		n.resetAccumulatorUnsafe()
 	}
 
-	// === STEP 6: HOMEOSTATIC THRESHOLD ADJUSTMENT ===
+	// === STEP 7: HOMEOSTATIC THRESHOLD ADJUSTMENT ===
 	if n.shouldPerformHomeostaticUpdateUnsafe() {
 		n.performHomeostaticAdjustmentUnsafe()
 	}
 }
 
+// isFinite reports whether v is neither NaN nor infinite.
+func isFinite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// GetNonFiniteInputCount returns the number of inputs discarded because
+// their value, on arrival or after scaling and gain, was NaN or infinite.
+func (n *Neuron) GetNonFiniteInputCount() int64 {
+	return n.nonFiniteInputs.Load()
+}
+
 // ============================================================================
 // AXONAL DELIVERY PROCESSING
 // ============================================================================
 
 // processAxonalDeliveries handles delayed message delivery through axons
 func (n *Neuron) processAxonalDeliveries() {
-	now := time.Now()
+	now := n.now()
 
 	// Minimize lock duration by copying what we need
 	var pendingDeliveries []delayedMessage
@@ -286,7 +389,7 @@ func (n *Neuron) processAxonalDeliveries() {
 // shouldPerformHomeostaticUpdateUnsafe checks if it's time for homeostatic adjustment
 // Must be called with stateMutex already locked
 func (n *Neuron) shouldPerformHomeostaticUpdateUnsafe() bool {
-	return time.Since(n.homeostatic.lastHomeostaticUpdate) >= n.homeostatic.homeostaticInterval
+	return n.now().Sub(n.homeostatic.lastHomeostaticUpdate) >= n.homeostatic.homeostaticInterval
 }
 
 // performHomeostaticAdjustmentUnsafe adjusts firing threshold based on activity
@@ -317,7 +420,7 @@ func (n *Neuron) performHomeostaticAdjustmentUnsafe() {
 	if math.Abs(newThreshold-n.threshold) > 0.001 {
 		oldThreshold := n.threshold
 		n.threshold = newThreshold
-		n.homeostatic.lastHomeostaticUpdate = time.Now()
+		n.homeostatic.lastHomeostaticUpdate = n.now()
 
 		// Update metadata for monitoring
 		n.UpdateMetadata("homeostatic_adjustment", map[string]interface{}{
@@ -395,7 +498,7 @@ func (n *Neuron) GetProcessingStatus() map[string]interface{} {
 	// Add connection information with separate lock
 	n.outputsMutex.RLock()
 	status["connections"] = map[string]interface{}{
-		"output_count": len(n.outputCallbacks),
+		"output_count": n.outputCallbacks.len(),
 	}
 	n.outputsMutex.RUnlock()
 
@@ -497,7 +600,7 @@ func (n *Neuron) GetSubsystemHealth() map[string]interface{} {
 
 	// Connection health with separate lock
 	n.outputsMutex.RLock()
-	connectionCount := len(n.outputCallbacks)
+	connectionCount := n.outputCallbacks.len()
 	n.outputsMutex.RUnlock()
 
 	health["connectivity"] = map[string]interface{}{
@@ -584,6 +687,6 @@ func (n *Neuron) GetPerformanceMetrics() map[string]interface{} {
 		"buffer_utilization":      bufferUtilization,
 		"axonal_backlog":          pendingDeliveries,
 		"efficiency_score":        efficiency,
-		"timestamp":               time.Now(),
+		"timestamp":               n.now(),
 	}
 }
@@ -0,0 +1,180 @@
+package neuron
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// LATENCY-BUDGETED REAL-TIME MODE
+// =================================================================================
+//
+// A robot controller needs each sensory spike integrated within a fixed time,
+// not eventually. SetLatencyBudget puts a neuron in real-time mode: the
+// latency of every input - from the signal's Timestamp to its integration -
+// is checked against the budget. The latency includes synaptic and axonal
+// delays, so the budget covers the whole path from the sensor. Each input
+// over budget is counted as a deadline miss and logged as a diagnostic at
+// Warn level.
+//
+// With Shed set, the neuron also sheds load to get back within budget.
+// Background input - signals of types.PriorityBackground, and normal-priority
+// signals from the Background sources (noise generators, tonic drive) - goes
+// first:
+//
+//   - A background input that has already missed its deadline is dropped
+//     instead of being integrated, so it does not delay inputs behind it.
+//   - After a miss the neuron is overloaded, and background inputs are
+//     dropped on arrival until an input meets its deadline again or the
+//     input buffer drains.
+//
+// All other inputs are always integrated, late or not. Shed input is counted
+// in the neuron's priority drops (see priority.go). Signals without a
+// Timestamp are integrated without a deadline check.
+
+// LatencyBudget configures real-time mode.
+type LatencyBudget struct {
+	Budget     time.Duration // Maximum latency from signal timestamp to integration
+	Shed       bool          // Drop background input while over budget
+	Background []string      // Source IDs whose normal-priority input may be shed
+}
+
+// DeadlineStats counts the work of real-time mode.
+type DeadlineStats struct {
+	Checked     int64         // Inputs checked against the budget
+	Misses      int64         // Inputs integrated or dropped after their deadline
+	Shed        int64         // Background inputs dropped
+	MaxLatency  time.Duration // Largest latency seen
+	LastLatency time.Duration // Latency of the last checked input
+	Overloaded  bool          // Background input is currently being shed
+}
+
+// realTimeMode holds the budget and counters of an enabled real-time mode.
+type realTimeMode struct {
+	budget     LatencyBudget
+	background map[string]bool
+	overloaded atomic.Bool
+
+	mu    sync.Mutex
+	stats DeadlineStats
+}
+
+// SetLatencyBudget enables real-time mode with the given budget, or disables
+// it for a zero budget. Enabling resets the statistics.
+func (n *Neuron) SetLatencyBudget(budget LatencyBudget) error {
+	if budget.Budget < 0 {
+		return fmt.Errorf("latency budget cannot be negative: %v", budget.Budget)
+	}
+	if budget.Budget == 0 {
+		n.realTime.Store(nil)
+		return nil
+	}
+	budget.Background = append([]string(nil), budget.Background...)
+	mode := &realTimeMode{budget: budget, background: make(map[string]bool, len(budget.Background))}
+	for _, source := range budget.Background {
+		mode.background[source] = true
+	}
+	n.realTime.Store(mode)
+	return nil
+}
+
+// GetLatencyBudget returns the budget and whether real-time mode is enabled.
+func (n *Neuron) GetLatencyBudget() (LatencyBudget, bool) {
+	mode := n.realTime.Load()
+	if mode == nil {
+		return LatencyBudget{}, false
+	}
+	budget := mode.budget
+	budget.Background = append([]string(nil), budget.Background...)
+	return budget, true
+}
+
+// GetDeadlineStats returns the real-time counters (zero when real-time mode
+// is disabled).
+func (n *Neuron) GetDeadlineStats() DeadlineStats {
+	mode := n.realTime.Load()
+	if mode == nil {
+		return DeadlineStats{}
+	}
+	mode.mu.Lock()
+	defer mode.mu.Unlock()
+	stats := mode.stats
+	stats.Overloaded = mode.overloaded.Load()
+	return stats
+}
+
+// shedOnArrival reports whether background input should be dropped before
+// it is queued because the neuron is overloaded.
+func (n *Neuron) shedOnArrival(msg types.NeuralSignal) bool {
+	mode := n.realTime.Load()
+	if mode == nil || !mode.budget.Shed || !mode.overloaded.Load() {
+		return false
+	}
+	if len(n.inputBuffer) == 0 {
+		// The backlog has drained
+		mode.overloaded.Store(false)
+		return false
+	}
+	if !mode.isBackground(msg) {
+		return false
+	}
+	mode.mu.Lock()
+	mode.stats.Shed++
+	mode.mu.Unlock()
+	n.recordPriorityDrop(msg.Priority)
+	return true
+}
+
+// checkDeadline measures the input's latency at now and reports whether it
+// should be integrated.
+func (n *Neuron) checkDeadline(msg types.NeuralSignal, now time.Time) bool {
+	mode := n.realTime.Load()
+	if mode == nil || msg.Timestamp.IsZero() {
+		return true
+	}
+	latency := now.Sub(msg.Timestamp)
+	missed := latency > mode.budget.Budget
+	shed := missed && mode.budget.Shed && mode.isBackground(msg)
+	mode.overloaded.Store(missed)
+
+	mode.mu.Lock()
+	mode.stats.Checked++
+	mode.stats.LastLatency = latency
+	if latency > mode.stats.MaxLatency {
+		mode.stats.MaxLatency = latency
+	}
+	if missed {
+		mode.stats.Misses++
+	}
+	if shed {
+		mode.stats.Shed++
+	}
+	mode.mu.Unlock()
+
+	if shed {
+		n.recordPriorityDrop(msg.Priority)
+	}
+	if missed && n.logEnabled(slog.LevelWarn) {
+		n.logf(slog.LevelWarn, logging.RecordDiagnostic, "input deadline missed",
+			"source", msg.SourceID, "latency", latency, "budget", mode.budget.Budget, "shed", shed)
+	}
+	return !shed
+}
+
+// isBackground reports whether msg may be shed.
+func (m *realTimeMode) isBackground(msg types.NeuralSignal) bool {
+	switch {
+	case msg.Priority <= types.PriorityBackground:
+		return true
+	case msg.Priority >= types.PriorityCritical:
+		return false
+	default:
+		return m.background[msg.SourceID]
+	}
+}
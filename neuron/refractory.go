@@ -0,0 +1,146 @@
+package neuron
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// REFRACTORY INPUT HANDLING
+// =================================================================================
+//
+// During the absolute refractory period sodium channels are inactivated and no
+// input can bring the neuron to fire. Receive has always dropped messages that
+// arrive in that window, but messages already queued when the neuron fires are
+// still integrated, spending the full dendritic pipeline on charge that the
+// reset is about to discard. Under high-rate bombardment that is most of the
+// work. The policy decides what happens to input during refractoriness:
+//
+//   - RefractoryInputDropOnArrival (default): only Receive checks, which keeps
+//     the historical behaviour.
+//   - RefractoryInputDiscard: queued messages are also discarded when they
+//     are processed during the refractory period, before any dendritic work.
+//   - RefractoryInputAttenuate: nothing is dropped; input is scaled by the
+//     attenuation factor, a simple model of the reduced but non-zero
+//     excitability just after a spike.
+//
+// The policy applies to synaptic messages. Chemical, gap-junction and
+// dendritic plateau currents are not affected.
+
+// RefractoryInputPolicy selects how input during refractoriness is handled.
+type RefractoryInputPolicy int
+
+const (
+	// RefractoryInputDropOnArrival drops messages received while refractory.
+	RefractoryInputDropOnArrival RefractoryInputPolicy = iota
+
+	// RefractoryInputDiscard also discards queued messages processed while
+	// refractory.
+	RefractoryInputDiscard
+
+	// RefractoryInputAttenuate scales input processed while refractory.
+	RefractoryInputAttenuate
+)
+
+// String returns the policy name.
+func (p RefractoryInputPolicy) String() string {
+	switch p {
+	case RefractoryInputDropOnArrival:
+		return "drop_on_arrival"
+	case RefractoryInputDiscard:
+		return "discard"
+	case RefractoryInputAttenuate:
+		return "attenuate"
+	default:
+		return fmt.Sprintf("RefractoryInputPolicy(%d)", int(p))
+	}
+}
+
+// SetRefractoryInputPolicy sets how input during refractoriness is handled.
+// attenuation is used by RefractoryInputAttenuate and must be in [0, 1).
+func (n *Neuron) SetRefractoryInputPolicy(policy RefractoryInputPolicy, attenuation float64) error {
+	if err := validateRefractoryInputPolicy(policy, attenuation); err != nil {
+		return err
+	}
+
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	n.refractoryPolicy = policy
+	n.refractoryAttenuation = attenuation
+	return nil
+}
+
+// GetRefractoryInputPolicy returns the policy and attenuation factor.
+func (n *Neuron) GetRefractoryInputPolicy() (RefractoryInputPolicy, float64) {
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	return n.refractoryPolicy, n.refractoryAttenuation
+}
+
+// GetRefractoryDropCount returns the number of messages dropped or discarded
+// because the neuron was refractory.
+func (n *Neuron) GetRefractoryDropCount() int64 {
+	return n.refractoryDrops.Load()
+}
+
+// GetSuppressedSpikeCount returns how often the threshold was reached
+// during the refractory period, when the neuron could not fire. A count
+// that grows with the spike count means the input drives the neuron faster
+// than the refractory period allows: the extra drive is lost in the reset.
+func (n *Neuron) GetSuppressedSpikeCount() int64 {
+	return n.suppressedSpikes.Load()
+}
+
+// GetSpikeCount returns the number of spikes fired since creation.
+func (n *Neuron) GetSpikeCount() int64 {
+	return n.spikeCount.Load()
+}
+
+// validateRefractoryInputPolicy checks a policy/attenuation pair.
+func validateRefractoryInputPolicy(policy RefractoryInputPolicy, attenuation float64) error {
+	switch policy {
+	case RefractoryInputDropOnArrival, RefractoryInputDiscard:
+		return nil
+	case RefractoryInputAttenuate:
+		if math.IsNaN(attenuation) || attenuation < 0 || attenuation >= 1 {
+			return fmt.Errorf("refractory attenuation must be in [0, 1): %f", attenuation)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown refractory input policy: %v", policy)
+	}
+}
+
+// inRefractoryUnsafe reports whether the neuron is in its absolute
+// refractory period. This method must be called with stateMutex held.
+func (n *Neuron) inRefractoryUnsafe(now time.Time) bool {
+	return !n.lastFireTime.IsZero() && now.Sub(n.lastFireTime) < n.refractoryPeriod
+}
+
+// acceptOnArrivalUnsafe applies the policy in Receive. Returns false when the
+// message must be dropped. This method must be called with stateMutex held.
+func (n *Neuron) acceptOnArrivalUnsafe(now time.Time) bool {
+	if n.refractoryPolicy == RefractoryInputAttenuate || !n.inRefractoryUnsafe(now) {
+		return true
+	}
+	n.refractoryDrops.Add(1)
+	return false
+}
+
+// refractoryInputUnsafe applies the policy to a queued message before
+// integration. Returns false when the message must be discarded.
+// This method must be called with stateMutex held.
+func (n *Neuron) refractoryInputUnsafe(msg *types.NeuralSignal, now time.Time) bool {
+	if n.refractoryPolicy == RefractoryInputDropOnArrival || !n.inRefractoryUnsafe(now) {
+		return true
+	}
+	if n.refractoryPolicy == RefractoryInputDiscard {
+		n.refractoryDrops.Add(1)
+		return false
+	}
+	msg.Value *= n.refractoryAttenuation
+	return true
+}
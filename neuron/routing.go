@@ -0,0 +1,212 @@
+package neuron
+
+import (
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// INPUT ROUTING TABLE
+// =================================================================================
+//
+// A cortical pyramidal cell receives on the order of ten thousand synapses,
+// and per-synapse bookkeeping (when did this input last spike, which
+// receptor does it drive) must not cost a string-keyed map lookup for every
+// delivered spike. The routing table gives each input synapse a compact
+// integer handle, an index into a slice of route entries:
+//
+//   - RegisterInputSynapse assigns the handle and hands it to synapses that
+//     accept one (synapse.BasicSynapse.SetRouteHandle). Such synapses stamp it
+//     on every signal as SynapseHandle, so delivery indexes the slice directly.
+//   - Signals without a route handle (other synapse implementations) are
+//     looked up by their interned synapse ID handle (SynapseIDHandle), and
+//     by the SynapseID string only when they carry no ID handle (a signal
+//     from another process). Only registered synapses have routes: signals
+//     from unknown synapses are not tracked, so the table is bounded by the
+//     registered inputs and shrinks when synapses are unregistered.
+//   - A stamped handle is only trusted when the entry still belongs to the
+//     signal's synapse, so a handle that outlived its synapse is harmless.
+//     The check compares interned ID handles (types.SameID). Handles of
+//     unregistered synapses are reused.
+//
+// Each route holds a types.IDRef, so its synapse ID stays interned while the
+// route exists and is released with it.
+//
+// The table has its own mutex and never calls out while holding it.
+
+// SynapseHandle is a neuron-local index for one input synapse (0 = none).
+type SynapseHandle uint32
+
+// InputRoute is the per-synapse state kept by the routing table.
+type InputRoute struct {
+	Handle    SynapseHandle    `json:"handle"`
+	SynapseID string           `json:"synapse_id"`
+	SourceID  string           `json:"source_id"`  // Pre-synaptic neuron
+	Receptor  types.LigandType `json:"receptor"`   // Neurotransmitter of the last signal
+	LastSpike time.Time        `json:"last_spike"` // Arrival of the last signal (zero = none yet)
+	Spikes    int64            `json:"spikes"`     // Signals delivered through this route
+
+	ref *types.IDRef // Reference to the interned SynapseID
+}
+
+// routeHandleReceiver is implemented by synapses that stamp their handle on
+// delivered signals (synapse.BasicSynapse).
+type routeHandleReceiver interface {
+	SetRouteHandle(handle uint32)
+}
+
+// routingTable maps input synapses to dense route entries.
+type routingTable struct {
+	mu      sync.Mutex
+	byKey   map[types.IDHandle]SynapseHandle // Interned SynapseID -> handle, for delivery
+	handles map[string]SynapseHandle         // SynapseID -> handle, for the API and foreign signals
+	routes  []InputRoute                     // routes[handle-1]; unused entries have an empty SynapseID
+	free    []SynapseHandle                  // Handles of removed routes, reused first
+}
+
+// registerUnsafe returns the handle for synapseID, creating the route if
+// needed. Must be called with mu held.
+func (t *routingTable) registerUnsafe(synapseID string, sourceID string) SynapseHandle {
+	if handle, ok := t.handles[synapseID]; ok {
+		if sourceID != "" {
+			t.routes[handle-1].SourceID = sourceID
+		}
+		return handle
+	}
+	if t.handles == nil {
+		t.handles = make(map[string]SynapseHandle)
+		t.byKey = make(map[types.IDHandle]SynapseHandle)
+	}
+	ref, synapseID := types.DefaultIDs.Acquire(synapseID)
+
+	var handle SynapseHandle
+	if n := len(t.free); n > 0 {
+		handle, t.free = t.free[n-1], t.free[:n-1]
+	} else {
+		t.routes = append(t.routes, InputRoute{})
+		handle = SynapseHandle(len(t.routes))
+	}
+	t.routes[handle-1] = InputRoute{Handle: handle, SynapseID: synapseID, SourceID: sourceID, ref: ref}
+	t.handles[synapseID] = handle
+	if key := ref.Handle(); key != 0 {
+		t.byKey[key] = handle
+	}
+	return handle
+}
+
+// register is registerUnsafe under the table lock.
+func (t *routingTable) register(synapseID string, sourceID string) SynapseHandle {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.registerUnsafe(synapseID, sourceID)
+}
+
+// unregister removes the route of synapseID and frees its handle.
+func (t *routingTable) unregister(synapseID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	handle, ok := t.handles[synapseID]
+	if !ok {
+		return
+	}
+	delete(t.handles, synapseID)
+	delete(t.byKey, t.routes[handle-1].ref.Handle())
+	t.routes[handle-1] = InputRoute{}
+	t.free = append(t.free, handle)
+}
+
+// record updates the route of a delivered signal. The stamped handle is used
+// when it still belongs to the signal's synapse; otherwise the route is
+// looked up by ID handle, or by SynapseID for signals without one. Signals
+// from unregistered synapses are ignored.
+func (t *routingTable) record(msg *types.NeuralSignal, now time.Time) {
+	if msg.SynapseID == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	handle := SynapseHandle(msg.SynapseHandle)
+	if handle == 0 || int(handle) > len(t.routes) || !t.routes[handle-1].owns(msg) {
+		var ok bool
+		if msg.SynapseIDHandle != 0 {
+			handle, ok = t.byKey[msg.SynapseIDHandle]
+		}
+		if !ok {
+			// No ID handle, or a stale one from a synapse that was recreated
+			if handle, ok = t.handles[msg.SynapseID]; !ok {
+				return
+			}
+		}
+	}
+	route := &t.routes[handle-1]
+	route.Receptor = msg.NeurotransmitterType
+	route.LastSpike = now
+	route.Spikes++
+}
+
+// owns reports whether a signal belongs to the route.
+func (r *InputRoute) owns(msg *types.NeuralSignal) bool {
+	return r.SynapseID != "" && types.SameID(r.ref.Handle(), r.SynapseID, msg.SynapseIDHandle, msg.SynapseID)
+}
+
+// lookup returns a copy of the route for synapseID.
+func (t *routingTable) lookup(synapseID string) (InputRoute, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	handle, ok := t.handles[synapseID]
+	if !ok {
+		return InputRoute{}, false
+	}
+	return t.routes[handle-1], true
+}
+
+// get returns a copy of the route for handle.
+func (t *routingTable) get(handle SynapseHandle) (InputRoute, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if handle == 0 || int(handle) > len(t.routes) || t.routes[handle-1].SynapseID == "" {
+		return InputRoute{}, false
+	}
+	return t.routes[handle-1], true
+}
+
+// size returns the number of live routes.
+func (t *routingTable) size() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.handles)
+}
+
+// assignRoute gives a registered input synapse its handle.
+func (n *Neuron) assignRoute(synapseID string, synapse component.SynapticProcessor) {
+	handle := n.routes.register(synapseID, synapse.GetPresynapticID())
+	if receiver, ok := synapse.(routeHandleReceiver); ok {
+		receiver.SetRouteHandle(uint32(handle))
+	}
+}
+
+// LookupSynapseHandle returns the handle of an input synapse.
+func (n *Neuron) LookupSynapseHandle(synapseID string) (SynapseHandle, bool) {
+	route, ok := n.routes.lookup(synapseID)
+	return route.Handle, ok
+}
+
+// GetInputRoute returns the routing state of an input synapse.
+func (n *Neuron) GetInputRoute(synapseID string) (InputRoute, bool) {
+	return n.routes.lookup(synapseID)
+}
+
+// GetInputRouteByHandle returns the routing state for a handle.
+func (n *Neuron) GetInputRouteByHandle(handle SynapseHandle) (InputRoute, bool) {
+	return n.routes.get(handle)
+}
+
+// GetInputRouteCount returns the number of routes, one per registered input
+// synapse.
+func (n *Neuron) GetInputRouteCount() int {
+	return n.routes.size()
+}
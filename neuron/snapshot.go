@@ -0,0 +1,105 @@
+package neuron
+
+import (
+	"time"
+)
+
+// =================================================================================
+// TYPED STATE SNAPSHOT
+// =================================================================================
+//
+// Monitoring and checkpointing code used to read neuron state from maps keyed
+// by strings, which costs an allocation per key and fails silently on a typo
+// or a changed value type. GetSnapshot returns the same state as a plain
+// struct. It is a value with no shared references, so callers may keep and
+// modify it freely. Version identifies the layout (NEURON_SNAPSHOT_VERSION).
+
+// NeuronSnapshot is a consistent copy of a neuron's dynamic state.
+type NeuronSnapshot struct {
+	Version  int       `json:"version"`
+	NeuronID string    `json:"neuron_id"`
+	Time     time.Time `json:"time"` // When the snapshot was taken
+
+	Accumulator     float64   `json:"accumulator"`      // Membrane potential relative to rest
+	Threshold       float64   `json:"threshold"`        // Current (homeostatically adjusted) threshold
+	BaseThreshold   float64   `json:"base_threshold"`   // Threshold the neuron was created with
+	Calcium         float64   `json:"calcium"`          // Intracellular calcium level
+	LastSpike       time.Time `json:"last_spike"`       // Zero if the neuron never fired
+	RefractoryUntil time.Time `json:"refractory_until"` // End of the absolute refractory period (zero if never fired)
+	FiringRate      float64   `json:"firing_rate"`      // Hz over the activity window
+	TargetRate      float64   `json:"target_rate"`      // Homeostatic target (Hz)
+
+	Spikes           int64 `json:"spikes"`            // Spikes fired since creation
+	SuppressedSpikes int64 `json:"suppressed_spikes"` // Threshold reached while refractory
+	RefractoryDrops  int64 `json:"refractory_drops"`  // Input dropped or discarded while refractory
+}
+
+// SuppressedFraction returns the share of threshold crossings that fell in
+// the refractory period and produced no spike. Values well above zero mean
+// the input rate exceeds what the refractory period allows.
+func (s NeuronSnapshot) SuppressedFraction() float64 {
+	crossings := s.Spikes + s.SuppressedSpikes
+	if crossings == 0 {
+		return 0
+	}
+	return float64(s.SuppressedSpikes) / float64(crossings)
+}
+
+// InRefractory reports whether the neuron was refractory when the snapshot
+// was taken.
+func (s NeuronSnapshot) InRefractory() bool {
+	return s.Time.Before(s.RefractoryUntil)
+}
+
+// GetSnapshot returns the neuron's current state.
+func (n *Neuron) GetSnapshot() NeuronSnapshot {
+	// The rate takes its own locks, so read it before the state lock
+	rate := n.GetActivityLevel()
+
+	n.stateMutex.Lock()
+	defer n.stateMutex.Unlock()
+	snapshot := NeuronSnapshot{
+		Version:       NEURON_SNAPSHOT_VERSION,
+		NeuronID:      n.ID(),
+		Time:          n.now(),
+		Accumulator:   n.accumulator,
+		Threshold:     n.threshold,
+		BaseThreshold: n.baseThreshold,
+		Calcium:       n.homeostatic.calciumLevel,
+		LastSpike:     n.lastFireTime,
+		FiringRate:    rate,
+		TargetRate:    n.homeostatic.targetFiringRate,
+
+		Spikes:           n.spikeCount.Load(),
+		SuppressedSpikes: n.suppressedSpikes.Load(),
+		RefractoryDrops:  n.refractoryDrops.Load(),
+	}
+	if !n.lastFireTime.IsZero() {
+		snapshot.RefractoryUntil = n.lastFireTime.Add(n.refractoryPeriod)
+	}
+	return snapshot
+}
+
+// GetNeuronState returns the snapshot as a map keyed by the JSON field names.
+//
+// Deprecated: use GetSnapshot, which is typed and does not allocate a map.
+func (n *Neuron) GetNeuronState() map[string]interface{} {
+	s := n.GetSnapshot()
+	return map[string]interface{}{
+		"version":           s.Version,
+		"neuron_id":         s.NeuronID,
+		"time":              s.Time,
+		"accumulator":       s.Accumulator,
+		"threshold":         s.Threshold,
+		"base_threshold":    s.BaseThreshold,
+		"calcium":           s.Calcium,
+		"last_spike":        s.LastSpike,
+		"refractory_until":  s.RefractoryUntil,
+		"firing_rate":       s.FiringRate,
+		"target_rate":       s.TargetRate,
+		"in_refractory":     s.InRefractory(),
+		"spikes":            s.Spikes,
+		"suppressed_spikes": s.SuppressedSpikes,
+		"refractory_drops":  s.RefractoryDrops,
+	}
+}
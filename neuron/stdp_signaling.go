@@ -1,12 +1,14 @@
 package neuron
 
 import (
-	"fmt"
+	"log/slog"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/logging"
 	"github.com/SynapticNetworks/temporal-neuron/types"
 )
 
@@ -37,6 +39,12 @@ type STDPSignalingSystem struct {
 	// Statistics
 	totalFeedbackEvents int
 
+	// Structured logging of pairings (nil = disabled)
+	logger atomic.Pointer[slog.Logger]
+
+	// Time source (wall clock unless the neuron is stepped, see stepping.go)
+	clock clockSource
+
 	// Thread safety
 	mutex sync.Mutex
 }
@@ -126,7 +134,7 @@ func (s *STDPSignalingSystem) CheckAndDeliverFeedback(neuronID string, callbacks
 	s.mutex.Lock()
 
 	// Quick exit conditions
-	if !s.enabled || callbacks == nil || s.scheduledTime.IsZero() || !time.Now().After(s.scheduledTime) {
+	if !s.enabled || callbacks == nil || s.scheduledTime.IsZero() || !s.clock.Now().After(s.scheduledTime) {
 		s.mutex.Unlock()
 		return false
 	}
@@ -145,6 +153,13 @@ func (s *STDPSignalingSystem) CheckAndDeliverFeedback(neuronID string, callbacks
 	return feedbackCount > 0
 }
 
+// isFeedbackDue reports whether CheckAndDeliverFeedback would deliver now
+func (s *STDPSignalingSystem) isFeedbackDue() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.enabled && !s.scheduledTime.IsZero() && s.clock.Now().After(s.scheduledTime)
+}
+
 // This is a replacement implementation for the processSTDPFeedbackWithSpikeHistory method
 // in the STDPSignalingSystem struct located in stdp_signaling.go
 // Improved implementation for processSTDPFeedbackWithSpikeHistory
@@ -174,8 +189,6 @@ func (s *STDPSignalingSystem) processSTDPFeedbackWithSpikeHistory(neuronID strin
 
 	feedbackCount := 0
 
-	//fmt.Printf("STDP Feedback: Processing for neuron=%s, postSpikeTime=%v with %d incoming synapses\n",neuronID, postSpikeTime, len(synapses))
-
 	// Process each synapse using spike history
 	for _, synapseInfo := range synapses {
 		// Get the actual synapse object
@@ -193,8 +206,6 @@ func (s *STDPSignalingSystem) processSTDPFeedbackWithSpikeHistory(neuronID strin
 			preSpikes := spikesGetter.GetPreSpikeTimes()
 			postSpikes := spikesGetter.GetPostSpikeTimes()
 
-			//fmt.Printf("STDP History: Synapse=%s has %d pre-spikes and %d post-spikes\n",synapseInfo.ID, len(preSpikes), len(postSpikes))
-
 			// If no spikes, try fallback to LastActivity/LastTransmission
 			if len(preSpikes) == 0 || len(postSpikes) == 0 {
 				// Fallback to old method
@@ -290,10 +301,9 @@ func (s *STDPSignalingSystem) processSTDPFeedbackWithSpikeHistory(neuronID strin
 				if foundLTD && foundLTP {
 					// Both patterns found - prefer LTD if it involves more recent spikes
 					// or if the timing is more clear (larger absolute deltaT)
-					ltdRecent := ltdPostSpike.After(time.Now().Add(-200*time.Millisecond)) &&
-						ltdPreSpike.After(time.Now().Add(-200*time.Millisecond))
-					ltpRecent := ltpPreSpike.After(time.Now().Add(-200*time.Millisecond)) &&
-						ltpPostSpike.After(time.Now().Add(-200*time.Millisecond))
+					recentCutoff := s.clock.Now().Add(-200 * time.Millisecond)
+					ltdRecent := ltdPostSpike.After(recentCutoff) && ltdPreSpike.After(recentCutoff)
+					ltpRecent := ltpPreSpike.After(recentCutoff) && ltpPostSpike.After(recentCutoff)
 
 					// If both are recent, prefer the one with clearer timing
 					if ltdRecent && ltpRecent {
@@ -324,7 +334,8 @@ func (s *STDPSignalingSystem) processSTDPFeedbackWithSpikeHistory(neuronID strin
 					spikePairDesc = "LTP (only pattern found)"
 				} else {
 					// Neither pattern found - fall back to closest spike
-					fmt.Printf("STDP Warning: No clear LTP or LTD pattern found for synapse %s\n", synapseInfo.ID)
+					logging.Log(s.logger.Load(), slog.LevelDebug, logging.RecordPlasticity, "no clear STDP pairing",
+						logging.KeySynapseID, synapseInfo.ID)
 					continue
 				}
 
@@ -332,15 +343,11 @@ func (s *STDPSignalingSystem) processSTDPFeedbackWithSpikeHistory(neuronID strin
 				if applyLTD {
 					finalDeltaT = ltdDeltaT // Positive for LTD
 					finalTimestamp = ltdPostSpike
-					//fmt.Printf("STDP Debug: Found LTD pair - post=%v, pre=%v, deltaT=%v (%s)\n",ltdPostSpike, ltdPreSpike, ltdDeltaT, spikePairDesc)
 				} else {
 					finalDeltaT = ltpDeltaT // Negative for LTP
 					finalTimestamp = ltpPostSpike
-					//fmt.Printf("STDP Debug: Found LTP pair - pre=%v, post=%v, deltaT=%v (%s)\n",ltpPreSpike, ltpPostSpike, ltpDeltaT, spikePairDesc)
 				}
 
-				_ = spikePairDesc
-
 				// Apply the chosen STDP adjustment
 				adjustment := types.PlasticityAdjustment{
 					DeltaT:       finalDeltaT,
@@ -354,14 +361,12 @@ func (s *STDPSignalingSystem) processSTDPFeedbackWithSpikeHistory(neuronID strin
 				// Apply plasticity
 				err := callbacks.ApplyPlasticity(synapseInfo.ID, adjustment)
 				if err == nil {
-					if applyLTD {
-						//fmt.Printf("STDP LTD Applied: Synapse %s adjusted with deltaT=%v (positive = LTD)\n",synapseInfo.ID, finalDeltaT)
-					} else {
-						//fmt.Printf("STDP LTP Applied: Synapse %s adjusted with deltaT=%v (negative = LTP)\n",synapseInfo.ID, finalDeltaT)
-					}
+					logging.Log(s.logger.Load(), slog.LevelDebug, logging.RecordPlasticity, "STDP adjustment applied",
+						logging.KeySynapseID, synapseInfo.ID, "delta_t", finalDeltaT, "pairing", spikePairDesc)
 					feedbackCount++
 				} else {
-					fmt.Printf("STDP Error: Failed to apply plasticity: %v\n", err)
+					logging.Log(s.logger.Load(), slog.LevelWarn, logging.RecordDiagnostic, "failed to apply STDP adjustment",
+						logging.KeySynapseID, synapseInfo.ID, "error", err)
 				}
 			}
 		} else {
@@ -419,7 +424,7 @@ func (s *STDPSignalingSystem) processSTDPFeedbackWithSpikeHistory(neuronID strin
 func (s *STDPSignalingSystem) DeliverFeedbackNow(neuronID string, callbacks component.NeuronCallbacks, postFiringTime time.Time) int {
 	// Use provided time or current time as fallback
 	if postFiringTime.IsZero() {
-		postFiringTime = time.Now()
+		postFiringTime = s.clock.Now()
 	}
 	return s.processSTDPFeedbackWithSpikeHistory(neuronID, callbacks, postFiringTime)
 }
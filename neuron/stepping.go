@@ -0,0 +1,207 @@
+package neuron
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// LOCK-STEP EXECUTION ON A VIRTUAL CLOCK
+// =================================================================================
+//
+// A started neuron normally runs its own goroutine against the wall clock: a
+// membrane ticker decays the accumulator and delivers due STDP feedback, and
+// the axon ticker delivers delayed spikes. A co-simulation (see
+// cosim.LockStep) needs the opposite - the neuron may only advance when told
+// to, and by exactly the simulated interval.
+//
+// EnableStepping switches a neuron that has not been started to a virtual
+// clock. Start then activates it without launching the goroutine, and
+// Step(now) does the goroutine's work up to now, one membrane tick at a time:
+//
+//   - queued input is integrated at the tick's virtual time,
+//   - the membrane decays once per MEMBRANE_TICK_INTERVAL (the decay rate is
+//     defined per tick, so the time constant is the same as in Run),
+//   - STDP feedback and axonal deliveries fall due on the virtual clock.
+//
+// Every time the neuron reads - refractory periods, plateau, gain and port
+// windows, homeostatic and scaling intervals, spike and STDP timestamps,
+// axonal delivery times, biological dendrite decay - comes from Now, which
+// for a stepped neuron is the time of the current step. Synapses attached to
+// it should read the same clock (synapse.WithClock(n.Now)), so their
+// pre-spike times pair with the neuron's post-spike times.
+//
+// With a Scheduler, spikes the neuron sends through its axon are handed to
+// the runner's delay queue instead of the neuron's own, so every delivery in
+// the network lands at the start of its tick regardless of the order in
+// which neurons are stepped.
+//
+// Step must not be called concurrently with itself; Receive and the getters
+// stay safe to call from other goroutines.
+
+const (
+	// MEMBRANE_TICK_INTERVAL is the membrane update interval: decay,
+	// homeostasis and STDP feedback checks run once per tick.
+	MEMBRANE_TICK_INTERVAL = 1 * time.Millisecond
+)
+
+// DelayScheduler delivers msg to target after delay on an external clock.
+type DelayScheduler func(msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration)
+
+// SteppingConfig configures lock-step execution.
+type SteppingConfig struct {
+	Start     time.Time      // Virtual time before the first step
+	Scheduler DelayScheduler // Axonal delivery (nil = the neuron's queue on the virtual clock)
+}
+
+// steppedClock is the virtual time of a stepped neuron.
+type steppedClock struct {
+	now       atomic.Int64 // Unix nanoseconds of the current step
+	started   atomic.Bool  // Start was called; Step is a no-op before
+	scheduler DelayScheduler
+
+	mu       sync.Mutex // Serialises Step
+	lastTick time.Time  // Time of the last membrane tick
+}
+
+// time returns the current virtual time.
+func (c *steppedClock) time() time.Time {
+	return time.Unix(0, c.now.Load())
+}
+
+// clockSource is a replaceable time source for the neuron's subsystems.
+type clockSource struct {
+	now atomic.Pointer[func() time.Time]
+}
+
+// Now returns the source's time (the wall clock when unset).
+func (c *clockSource) Now() time.Time {
+	if now := c.now.Load(); now != nil {
+		return (*now)()
+	}
+	return time.Now()
+}
+
+// set replaces the time source (nil = wall clock).
+func (c *clockSource) set(now func() time.Time) {
+	if now == nil {
+		c.now.Store(nil)
+		return
+	}
+	c.now.Store(&now)
+}
+
+// clockedIntegration is implemented by dendritic modes that keep time.
+type clockedIntegration interface {
+	setClock(now func() time.Time)
+}
+
+// EnableStepping puts a neuron that has not been started on a virtual clock
+// starting at config.Start. It cannot be undone.
+func (n *Neuron) EnableStepping(config SteppingConfig) error {
+	if n.closed.Load() || n.State() != types.StateInactive {
+		return fmt.Errorf("neuron %s: stepping must be enabled before Start", n.ID())
+	}
+	if n.stepped.Load() != nil {
+		return fmt.Errorf("neuron %s: stepping is already enabled", n.ID())
+	}
+	clock := &steppedClock{scheduler: config.Scheduler, lastTick: config.Start}
+	clock.now.Store(config.Start.UnixNano())
+	n.stepped.Store(clock)
+
+	// Restart the wall-clock timers of the subsystems on the virtual clock
+	n.stdpSystem.clock.set(n.Now)
+	n.stateMutex.Lock()
+	n.homeostatic.lastHomeostaticUpdate = config.Start
+	scaling := n.synapticScaling
+	dendrite := n.dendrite
+	n.stateMutex.Unlock()
+	if scaling != nil {
+		scaling.setClock(n.Now)
+	}
+	if clocked, ok := dendrite.(clockedIntegration); ok {
+		clocked.setClock(n.Now)
+	}
+	return nil
+}
+
+// IsStepped reports whether the neuron runs on a virtual clock.
+func (n *Neuron) IsStepped() bool {
+	return n.stepped.Load() != nil
+}
+
+// Now returns the neuron's current time: the time of the current step for a
+// stepped neuron, the wall clock otherwise.
+func (n *Neuron) Now() time.Time {
+	return n.now()
+}
+
+// now is the time source for everything the neuron times.
+func (n *Neuron) now() time.Time {
+	if clock := n.stepped.Load(); clock != nil {
+		return clock.time()
+	}
+	return time.Now()
+}
+
+// Step advances a started, stepped neuron to now, doing the work of Run for
+// every membrane tick that has come due. It has the cosim.StepFunc signature.
+// Times before the current step are ignored.
+func (n *Neuron) Step(now time.Time) {
+	clock := n.stepped.Load()
+	if clock == nil || !clock.started.Load() || n.closed.Load() {
+		return
+	}
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	for tick := clock.lastTick.Add(MEMBRANE_TICK_INTERVAL); !tick.After(now); tick = tick.Add(MEMBRANE_TICK_INTERVAL) {
+		clock.now.Store(tick.UnixNano())
+		clock.lastTick = tick
+		n.processAxonalDeliveries()
+		n.processStepInputs()
+		n.processDecayAndHomeostasis()
+		n.processScheduledSTDPFeedback()
+	}
+
+	// A step between ticks integrates input without decaying the membrane
+	if now.After(clock.time()) {
+		clock.now.Store(now.UnixNano())
+		n.processAxonalDeliveries()
+		n.processStepInputs()
+	}
+}
+
+// processStepInputs integrates all queued input, critical input first, as
+// the Run loop would have done since the last step.
+func (n *Neuron) processStepInputs() {
+	for {
+		select {
+		case msg := <-n.criticalBuffer:
+			n.processIncomingMessage(msg)
+			continue
+		default:
+		}
+		select {
+		case msg := <-n.inputBuffer:
+			n.refillInputBuffer()
+			n.processCriticalInputs()
+			n.processIncomingMessage(msg)
+		default:
+			return
+		}
+	}
+}
+
+// stepScheduler returns the external delay scheduler of a stepped neuron.
+func (n *Neuron) stepScheduler() DelayScheduler {
+	if clock := n.stepped.Load(); clock != nil {
+		return clock.scheduler
+	}
+	return nil
+}
@@ -87,6 +87,9 @@ type SynapticScalingState struct {
 
 	// === CONFIGURATION ===
 	Config SynapticScalingConfig // Current scaling configuration
+
+	// Time source (wall clock unless the neuron is stepped, see stepping.go)
+	clock clockSource
 }
 
 // InputActivity represents a single synaptic input event for scaling calculations
@@ -142,7 +145,20 @@ func (s *SynapticScalingState) EnableScaling(targetStrength, scalingRate float64
 	s.Config.TargetInputStrength = targetStrength
 	s.Config.ScalingRate = scalingRate
 	s.Config.ScalingInterval = interval
-	s.Config.LastScalingUpdate = time.Now()
+	s.Config.LastScalingUpdate = s.clock.Now()
+}
+
+// setClock replaces the time source (see stepping.go) and restarts the
+// scaling interval on it. Activity recorded on the old clock is discarded.
+func (s *SynapticScalingState) setClock(now func() time.Time) {
+	s.clock.set(now)
+	s.inputActivityMutex.Lock()
+	s.InputActivityHistory = make(map[string][]InputActivity)
+	s.LastActivityCleanup = s.clock.Now()
+	s.inputActivityMutex.Unlock()
+	s.mu.Lock()
+	s.Config.LastScalingUpdate = s.clock.Now()
+	s.mu.Unlock()
 }
 
 // DisableScaling turns off synaptic scaling (preserves existing gains)
@@ -158,7 +174,7 @@ func (s *SynapticScalingState) SetScalingParameters(config SynapticScalingConfig
 	defer s.mu.Unlock()
 	s.Config = config
 	if s.Config.Enabled && s.Config.LastScalingUpdate.IsZero() {
-		s.Config.LastScalingUpdate = time.Now()
+		s.Config.LastScalingUpdate = s.clock.Now()
 	}
 }
 
@@ -261,7 +277,7 @@ func (s *SynapticScalingState) RecordInputActivity(sourceID string, effectiveSig
 		return
 	}
 
-	now := time.Now()
+	now := s.clock.Now()
 
 	// Create activity record
 	activity := InputActivity{
@@ -312,7 +328,7 @@ func (s *SynapticScalingState) GetRecentActivityStrength(sourceID string) float6
 	}
 
 	// Calculate average recent activity
-	cutoff := time.Now().Add(-s.Config.ActivitySamplingWindow)
+	cutoff := s.clock.Now().Add(-s.Config.ActivitySamplingWindow)
 	var sum float64
 	var count int
 
@@ -341,7 +357,7 @@ func (s *SynapticScalingState) PerformScaling(calciumLevel, recentFiringRate flo
 	result := SynapticScalingResult{
 		ScalingPerformed: false,
 		ScalingFactor:    1.0,
-		Timestamp:        time.Now(),
+		Timestamp:        s.clock.Now(),
 		Reason:           "scaling_disabled",
 	}
 
@@ -431,7 +447,7 @@ func (s *SynapticScalingState) calculateAverageInputStrength() (float64, int) {
 		}
 
 		// Calculate average recent activity for this source
-		cutoff := time.Now().Add(-s.Config.ActivitySamplingWindow)
+		cutoff := s.clock.Now().Add(-s.Config.ActivitySamplingWindow)
 		var activitySum float64
 		var activityCount int
 
@@ -490,7 +506,7 @@ func (s *SynapticScalingState) hasRecentActivity(sourceID string) bool {
 		return false
 	}
 
-	cutoff := time.Now().Add(-s.Config.ActivitySamplingWindow)
+	cutoff := s.clock.Now().Add(-s.Config.ActivitySamplingWindow)
 	for _, activity := range activities {
 		if activity.Timestamp.After(cutoff) {
 			return true
@@ -538,7 +554,7 @@ func (s *SynapticScalingState) GetScalingStatus() map[string]interface{} {
 		"total_source_count":    len(s.InputGains),
 		"last_scaling_update":   s.Config.LastScalingUpdate,
 		"scaling_interval":      s.Config.ScalingInterval,
-		"time_until_next":       s.Config.ScalingInterval - time.Since(s.Config.LastScalingUpdate),
+		"time_until_next":       s.Config.ScalingInterval - s.clock.Now().Sub(s.Config.LastScalingUpdate),
 		"scaling_history_count": len(s.Config.ScalingHistory),
 	}
 }
@@ -549,7 +565,7 @@ func (s *SynapticScalingState) GetInputActivitySummary() map[string]interface{}
 	defer s.inputActivityMutex.RUnlock()
 
 	summary := make(map[string]interface{})
-	cutoff := time.Now().Add(-s.Config.ActivitySamplingWindow)
+	cutoff := s.clock.Now().Add(-s.Config.ActivitySamplingWindow)
 
 	for sourceID, activities := range s.InputActivityHistory {
 		var recentCount int
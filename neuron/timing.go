@@ -0,0 +1,49 @@
+package neuron
+
+import (
+	"fmt"
+
+	"github.com/SynapticNetworks/temporal-neuron/timing"
+)
+
+// =================================================================================
+// TIMING SOURCE
+// =================================================================================
+//
+// Axonal delays are honoured by a worker that checks the delivery queue every
+// AXON_TICK_INTERVAL (100µs). On platforms whose timers overshoot by a
+// millisecond, that check - and with it every sub-millisecond delay - runs
+// late by the overshoot. SetTiming selects how the worker waits (see the
+// timing package for the trade-offs):
+//
+//   - timing.ModeSystem (default) uses runtime timers.
+//   - timing.ModePrecise spins through the last part of every tick, keeping
+//     deliveries within microseconds of their due time at the cost of CPU.
+//   - timing.ModeCoarse checks once per granule and delivers everything that
+//     fell due since, so delays are rounded up to the granule.
+//
+// Membrane decay and homeostasis keep their millisecond runtime ticker: the
+// decay rate is defined per tick, so changing the tick would change the
+// membrane time constant.
+
+// SetTiming selects the timing mode of axonal delivery. A running neuron
+// switches at its next loop iteration.
+func (n *Neuron) SetTiming(config timing.Config) error {
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("neuron %s: %w", n.ID(), err)
+	}
+	n.timingConfig.Store(&config)
+	select {
+	case n.timingChanged <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// GetTiming returns the timing mode of axonal delivery.
+func (n *Neuron) GetTiming() timing.Config {
+	if config := n.timingConfig.Load(); config != nil {
+		return *config
+	}
+	return timing.Config{}
+}
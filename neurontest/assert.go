@@ -0,0 +1,127 @@
+package neurontest
+
+import (
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// ASSERTIONS
+// =================================================================================
+
+// Assertions report failures with t.Errorf, so one test can check several
+// expectations; they return whether the check passed for callers that want
+// to stop early.
+
+// AssertSpikesAt checks that p fired exactly len(want) times, each spike
+// within tolerance of the expected offset. With no times it asserts silence.
+func (h *Harness) AssertSpikesAt(p *Probe, tolerance time.Duration, want ...time.Duration) bool {
+	h.t.Helper()
+	got := p.Spikes()
+	if len(got) != len(want) {
+		h.t.Errorf("%s: expected %d spikes at %v, got %d at %v", p.id, len(want), want, len(got), got)
+		return false
+	}
+	ok := true
+	for i := range want {
+		if diff := got[i] - want[i]; diff > tolerance || -diff > tolerance {
+			h.t.Errorf("%s: expected spike %d at %v ± %v, got %v", p.id, i, want[i], tolerance, got[i])
+			ok = false
+		}
+	}
+	return ok
+}
+
+// AssertSpikeCount checks the number of spikes of p.
+func (h *Harness) AssertSpikeCount(p *Probe, want int) bool {
+	h.t.Helper()
+	if got := len(p.Spikes()); got != want {
+		h.t.Errorf("%s: expected %d spikes, got %d", p.id, want, got)
+		return false
+	}
+	return true
+}
+
+// AssertWeight checks that the weight of syn is within tolerance of want.
+func (h *Harness) AssertWeight(syn *synapse.BasicSynapse, want, tolerance float64) bool {
+	h.t.Helper()
+	if got := syn.GetWeight(); math.Abs(got-want) > tolerance {
+		h.t.Errorf("%s: expected weight %g ± %g, got %g", syn.ID(), want, tolerance, got)
+		return false
+	}
+	return true
+}
+
+// AssertWeightAbove checks that the weight of syn exceeds floor, e.g. the
+// initial weight after a potentiating protocol.
+func (h *Harness) AssertWeightAbove(syn *synapse.BasicSynapse, floor float64) bool {
+	h.t.Helper()
+	if got := syn.GetWeight(); got <= floor {
+		h.t.Errorf("%s: expected weight above %g, got %g", syn.ID(), floor, got)
+		return false
+	}
+	return true
+}
+
+// AssertWeightBelow checks that the weight of syn is under ceiling.
+func (h *Harness) AssertWeightBelow(syn *synapse.BasicSynapse, ceiling float64) bool {
+	h.t.Helper()
+	if got := syn.GetWeight(); got >= ceiling {
+		h.t.Errorf("%s: expected weight below %g, got %g", syn.ID(), ceiling, got)
+		return false
+	}
+	return true
+}
+
+// =================================================================================
+// SCRIPTED PROTOCOLS
+// =================================================================================
+
+// Pairing is a spike-pairing protocol: Repetitions pre/post pairs separated
+// by Interval, with the pre-synaptic spike DeltaT after the post-synaptic
+// spike (negative = pre before post, potentiating).
+type Pairing struct {
+	DeltaT      time.Duration
+	Repetitions int
+	Interval    time.Duration
+}
+
+// RunPairing applies p to syn on the virtual clock, starting at the current
+// time, and advances the clock past the last pair. Spikes are recorded on
+// the synapse and paired with ApplyPlasticity at their virtual times,
+// without neurons or transmission, so the result depends only on the
+// plasticity rule.
+func (h *Harness) RunPairing(syn *synapse.BasicSynapse, p Pairing) {
+	h.t.Helper()
+	if p.Repetitions <= 0 || p.Interval <= p.DeltaT || p.Interval <= -p.DeltaT {
+		h.t.Fatalf("neurontest: pairing needs repetitions and an interval longer than Δt: %+v", p)
+	}
+	lead := p.DeltaT
+	if lead > 0 {
+		lead = 0
+	}
+	config := syn.GetPlasticityConfig()
+	for i := 0; i < p.Repetitions; i++ {
+		post := h.Now() - lead
+		pre := post + p.DeltaT
+		if pre < post {
+			syn.RecordPreSpike(h.At(pre))
+			syn.RecordPostSpike(h.At(post))
+		} else {
+			syn.RecordPostSpike(h.At(post))
+			syn.RecordPreSpike(h.At(pre))
+		}
+		syn.ApplyPlasticity(types.PlasticityAdjustment{
+			DeltaT:       p.DeltaT,
+			LearningRate: config.LearningRate,
+			PostSynaptic: true,
+			PreSynaptic:  true,
+			Timestamp:    h.At(post),
+			EventType:    types.PlasticitySTDP,
+		})
+		h.Run(p.Interval)
+	}
+}
@@ -0,0 +1,472 @@
+/*
+=================================================================================
+NEURONTEST - VIRTUAL-CLOCK TEST HARNESS
+=================================================================================
+
+A harness for testing neurons, synapse rules and circuit timing on a
+virtual clock (cosim.LockStep). Every spike happens at an exact, repeatable
+time and a test takes as long as the computation, not the simulated
+interval:
+
+	h := neurontest.New(t)
+	pre := h.Neuron("pre", neurontest.DefaultNeuronConfig())
+	post := h.Neuron("post", neurontest.DefaultNeuronConfig())
+	syn := h.Connect("syn", pre, post, synapse.WithWeight(1.5), synapse.WithDelay(2*time.Millisecond))
+
+	h.InjectAt(pre, 10*time.Millisecond, 1.5)
+	h.RunUntil(20 * time.Millisecond)
+
+	h.AssertSpikesAt(pre, 0, 10*time.Millisecond)
+	h.AssertSpikesAt(post, 0, 12*time.Millisecond)
+	h.AssertWeightAbove(syn, 1.5) // pre before post: potentiated
+
+Times are offsets from the harness epoch. A harness neuron is one of two
+kinds, both advancing only when the clock steps:
+
+  - Neuron creates a leaky integrate-and-fire batch.Population of one, for
+    testing synapse rules and circuit timing.
+  - Spawn runs a real neuron.Neuron on the clock (see neuron.EnableStepping),
+    for testing the neuron itself: its dendrites, refractoriness and its own
+    STDP feedback run on virtual time.
+
+Synapses are ordinary synapse.BasicSynapse instances reading the harness
+clock, so delays and spike histories are in virtual time. STDP onto a batch
+neuron is applied by the harness from the recorded spike times (see
+Connect); a spawned neuron applies STDP to its input synapses itself, as it
+would in a network.
+
+=================================================================================
+*/
+
+package neurontest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+const (
+	// NEURONTEST_DEFAULT_RESOLUTION is the virtual clock tick.
+	NEURONTEST_DEFAULT_RESOLUTION = 1 * time.Millisecond
+
+	// NEURONTEST_DEFAULT_THRESHOLD, NEURONTEST_DEFAULT_DECAY_RATE and
+	// NEURONTEST_DEFAULT_REFRACTORY describe DefaultNeuronConfig.
+	NEURONTEST_DEFAULT_THRESHOLD  = 1.0
+	NEURONTEST_DEFAULT_DECAY_RATE = 0.95
+	NEURONTEST_DEFAULT_REFRACTORY = 2 * time.Millisecond
+
+	// probeCallbackID names the output callback that records a probe's spikes.
+	probeCallbackID = "neurontest_probe"
+)
+
+// Harness owns a virtual clock and the components of one test.
+type Harness struct {
+	t      testing.TB
+	runner *cosim.LockStep
+	epoch  time.Time
+
+	mu    sync.Mutex
+	conns []*connection // Every synapse, for the spawned neurons' callbacks
+}
+
+// New creates a harness at the default resolution.
+func New(t testing.TB) *Harness {
+	t.Helper()
+	return NewWithResolution(t, NEURONTEST_DEFAULT_RESOLUTION)
+}
+
+// NewWithResolution creates a harness whose clock ticks by resolution.
+// Spikes happen on tick boundaries.
+func NewWithResolution(t testing.TB, resolution time.Duration) *Harness {
+	t.Helper()
+	epoch := time.Unix(0, 0)
+	runner, err := cosim.NewLockStep(epoch, resolution)
+	if err != nil {
+		t.Fatalf("neurontest: %v", err)
+	}
+	return &Harness{t: t, runner: runner, epoch: epoch}
+}
+
+// Runner returns the underlying lock-step runner, e.g. to add custom
+// steppers or to schedule deliveries directly.
+func (h *Harness) Runner() *cosim.LockStep {
+	return h.runner
+}
+
+// Now returns the virtual time elapsed since the epoch.
+func (h *Harness) Now() time.Duration {
+	return h.runner.Now().Sub(h.epoch)
+}
+
+// At converts an offset from the epoch to an absolute virtual time.
+func (h *Harness) At(offset time.Duration) time.Time {
+	return h.epoch.Add(offset)
+}
+
+// Run advances the clock by d.
+func (h *Harness) Run(d time.Duration) {
+	h.t.Helper()
+	if d <= 0 {
+		return
+	}
+	if err := h.runner.Step(d); err != nil {
+		h.t.Fatalf("neurontest: %v", err)
+	}
+}
+
+// RunUntil advances the clock to the offset at. Times in the past are a
+// no-op.
+func (h *Harness) RunUntil(at time.Duration) {
+	h.t.Helper()
+	h.Run(at - h.Now())
+}
+
+// =================================================================================
+// NEURONS
+// =================================================================================
+
+// NeuronConfig describes a harness neuron.
+type NeuronConfig struct {
+	Threshold        float64       // Firing threshold
+	DecayRate        float64       // Membrane retention per tick (0-1)
+	RefractoryPeriod time.Duration // Absolute refractory period
+}
+
+// DefaultNeuronConfig returns a threshold-1 neuron with a slow leak.
+func DefaultNeuronConfig() NeuronConfig {
+	return NeuronConfig{
+		Threshold:        NEURONTEST_DEFAULT_THRESHOLD,
+		DecayRate:        NEURONTEST_DEFAULT_DECAY_RATE,
+		RefractoryPeriod: NEURONTEST_DEFAULT_REFRACTORY,
+	}
+}
+
+// Probe is a harness neuron that records its spike times.
+type Probe struct {
+	h    *Harness
+	id   string
+	pop  *batch.Population // Batch neuron (nil for a spawned neuron)
+	cell *neuron.Neuron    // Spawned neuron (nil for a batch neuron)
+
+	mu      sync.Mutex
+	spikes  []time.Duration
+	inputs  []*connection
+	outputs []*connection
+}
+
+// connection is a synapse between two probes with its virtual spike history.
+type connection struct {
+	syn  *synapse.BasicSynapse
+	pre  *Probe
+	post *Probe
+}
+
+// Neuron creates a neuron stepped by the harness clock.
+func (h *Harness) Neuron(id string, config NeuronConfig) *Probe {
+	h.t.Helper()
+	pop, err := batch.NewPopulation(id, batch.PopulationConfig{
+		Size:             1,
+		Threshold:        config.Threshold,
+		DecayRate:        config.DecayRate,
+		RefractoryPeriod: config.RefractoryPeriod,
+		DelayScheduler:   h.runner.Schedule,
+	})
+	if err != nil {
+		h.t.Fatalf("neurontest: neuron %s: %v", id, err)
+	}
+	p := &Probe{h: h, id: id, pop: pop}
+	pop.AddOutputCallback(0, probeCallbackID, types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			p.fired(msg)
+			return nil
+		},
+	})
+	h.runner.AddPopulation(pop)
+	return p
+}
+
+// Spawn runs cell on the harness clock and starts it; the cell must not have
+// been started. The harness replaces its matrix callbacks with ones that
+// know the harness synapses, so the cell records post-synaptic spikes on its
+// input synapses and applies its STDP feedback to them. The cell is stopped
+// when the test ends.
+func (h *Harness) Spawn(cell *neuron.Neuron) *Probe {
+	h.t.Helper()
+	if err := h.runner.AddNeuron(cell); err != nil {
+		h.t.Fatalf("neurontest: %v", err)
+	}
+	p := &Probe{h: h, id: cell.ID(), cell: cell}
+	cell.SetCallbacks(h.callbacks())
+	cell.AddOutputCallback(probeCallbackID, types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			p.fired(msg)
+			return nil
+		},
+		GetWeight:   func() float64 { return 0 },
+		GetDelay:    func() time.Duration { return 0 },
+		GetTargetID: func() string { return "" },
+	})
+	if err := cell.Start(); err != nil {
+		h.t.Fatalf("neurontest: neuron %s: %v", cell.ID(), err)
+	}
+	h.t.Cleanup(func() { cell.Stop() })
+	return p
+}
+
+// ID returns the probe's ID.
+func (p *Probe) ID() string {
+	return p.id
+}
+
+// Member returns the underlying batch member, for wiring to other parts of
+// the library (nil for a spawned neuron, see Cell).
+func (p *Probe) Member() *batch.Member {
+	if p.pop == nil {
+		return nil
+	}
+	return p.pop.Member(0)
+}
+
+// Cell returns the spawned neuron (nil for a batch neuron).
+func (p *Probe) Cell() *neuron.Neuron {
+	return p.cell
+}
+
+// Potential returns the membrane potential.
+func (p *Probe) Potential() float64 {
+	if p.cell != nil {
+		return p.cell.GetSnapshot().Accumulator
+	}
+	return p.pop.Potential(0)
+}
+
+// target returns the component that receives the probe's input.
+func (p *Probe) target() component.MessageScheduler {
+	if p.cell != nil {
+		return p.cell
+	}
+	return p.pop.Member(0)
+}
+
+// Spikes returns the spike times as offsets from the epoch.
+func (p *Probe) Spikes() []time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]time.Duration(nil), p.spikes...)
+}
+
+// InjectAt delivers value to p at the offset at, so a suprathreshold value
+// makes p fire at exactly that time. An injection at the current time lands
+// on the next tick; injections in the past are a test error.
+func (h *Harness) InjectAt(p *Probe, at time.Duration, value float64) {
+	h.t.Helper()
+	delay := at - h.Now()
+	if delay < 0 {
+		h.t.Fatalf("neurontest: injection at %v is before the current time %v", at, h.Now())
+	}
+	msg := types.NeuralSignal{
+		Value:                value,
+		Timestamp:            h.At(at),
+		SourceID:             "neurontest",
+		TargetID:             p.id,
+		NeurotransmitterType: types.LigandGlutamate,
+	}
+	if delay == 0 {
+		p.target().Receive(msg)
+		return
+	}
+	h.runner.Schedule(msg, p.target(), delay)
+}
+
+// =================================================================================
+// SYNAPSES
+// =================================================================================
+
+// Connect creates a synapse from pre to post with synapse.NewSynapse
+// options; it reads the harness clock. A spike of pre is transmitted through
+// it, arriving after the synapse delay on the virtual clock.
+//
+// While the synapse's STDP is enabled, STDP onto a batch neuron is applied by
+// the harness: it pairs the recorded virtual spike times within the STDP
+// window and applies them with ApplyPlasticity, each post spike potentiating
+// with every earlier pre spike and each pre spike depressing with every
+// earlier post spike. A spawned post-synaptic neuron applies its own STDP
+// feedback instead (see neuron.EnableSTDPFeedback).
+func (h *Harness) Connect(id string, pre, post *Probe, opts ...synapse.SynapseOption) *synapse.BasicSynapse {
+	h.t.Helper()
+	opts = append(opts[:len(opts):len(opts)], synapse.WithClock(h.runner.Now))
+	syn, err := synapse.NewSynapse(id, pre.target(), post.target(), opts...)
+	if err != nil {
+		h.t.Fatalf("neurontest: %v", err)
+	}
+	if pre.cell != nil {
+		pre.cell.AddOutputCallback(syn.ID(), types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				syn.Transmit(msg.Value)
+				return nil
+			},
+			GetWeight:   syn.GetWeight,
+			GetDelay:    syn.GetDelay,
+			GetTargetID: syn.GetPostsynapticID,
+		})
+	}
+	conn := &connection{syn: syn, pre: pre, post: post}
+	h.mu.Lock()
+	h.conns = append(h.conns, conn)
+	h.mu.Unlock()
+	pre.mu.Lock()
+	pre.outputs = append(pre.outputs, conn)
+	pre.mu.Unlock()
+	post.mu.Lock()
+	post.inputs = append(post.inputs, conn)
+	post.mu.Unlock()
+	return syn
+}
+
+// fired records a spike, applies harness STDP and transmits on the outputs
+// of a batch neuron; a spawned neuron transmits through its own callbacks.
+func (p *Probe) fired(msg types.NeuralSignal) {
+	at := msg.Timestamp.Sub(p.h.epoch)
+	p.mu.Lock()
+	p.spikes = append(p.spikes, at)
+	inputs := append([]*connection(nil), p.inputs...)
+	outputs := append([]*connection(nil), p.outputs...)
+	p.mu.Unlock()
+
+	for _, conn := range inputs {
+		conn.pair(conn.pre.spikesBefore(at), []time.Duration{at})
+	}
+	for _, conn := range outputs {
+		conn.pair([]time.Duration{at}, conn.post.spikesBefore(at))
+		if p.cell == nil {
+			conn.syn.Transmit(msg.Value)
+		}
+	}
+}
+
+// spikesBefore returns the spikes strictly before at.
+func (p *Probe) spikesBefore(at time.Duration) []time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var before []time.Duration
+	for _, spike := range p.spikes {
+		if spike < at {
+			before = append(before, spike)
+		}
+	}
+	return before
+}
+
+// pair applies STDP for every pre/post pair within the window, unless the
+// post-synaptic neuron is spawned and applies its own.
+func (c *connection) pair(pre, post []time.Duration) {
+	config := c.syn.GetPlasticityConfig()
+	if !config.Enabled || c.post.cell != nil {
+		return
+	}
+	epoch := c.pre.h.epoch
+	for _, tPre := range pre {
+		for _, tPost := range post {
+			deltaT := tPre - tPost
+			if deltaT == 0 || deltaT > config.WindowSize || -deltaT > config.WindowSize {
+				continue
+			}
+			c.syn.ApplyPlasticity(types.PlasticityAdjustment{
+				DeltaT:       deltaT,
+				LearningRate: config.LearningRate,
+				PostSynaptic: true,
+				PreSynaptic:  true,
+				Timestamp:    epoch.Add(tPost),
+				EventType:    types.PlasticitySTDP,
+			})
+		}
+	}
+}
+
+// =================================================================================
+// SPAWNED NEURON CALLBACKS
+// =================================================================================
+
+// callbacks returns the matrix services of a spawned neuron: the harness
+// synapses and their plasticity. Synapses are listed without activity times,
+// so the neuron's STDP pairs only spikes recorded in their spike histories.
+func (h *Harness) callbacks() *neuron.NeuronCallbacks {
+	return &neuron.NeuronCallbacks{
+		ListSynapsesFunc: h.listSynapses,
+		GetSynapseFunc: func(id string) (component.SynapticProcessor, error) {
+			syn, err := h.synapse(id)
+			if err != nil {
+				return nil, err
+			}
+			return syn, nil
+		},
+		ApplyPlasticityFunc: func(id string, adjustment types.PlasticityAdjustment) error {
+			syn, err := h.synapse(id)
+			if err != nil {
+				return err
+			}
+			syn.ApplyPlasticity(adjustment)
+			return nil
+		},
+		GetSynapseWeightFunc: func(id string) (float64, error) {
+			syn, err := h.synapse(id)
+			if err != nil {
+				return 0, err
+			}
+			return syn.GetWeight(), nil
+		},
+		SetSynapseWeightFunc: func(id string, weight float64) error {
+			syn, err := h.synapse(id)
+			if err != nil {
+				return err
+			}
+			syn.SetWeight(weight)
+			return nil
+		},
+	}
+}
+
+// listSynapses returns the harness synapses matching the source and target
+// of criteria.
+func (h *Harness) listSynapses(criteria types.SynapseCriteria) []types.SynapseInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var infos []types.SynapseInfo
+	for _, conn := range h.conns {
+		if criteria.SourceID != nil && *criteria.SourceID != conn.pre.id {
+			continue
+		}
+		if criteria.TargetID != nil && *criteria.TargetID != conn.post.id {
+			continue
+		}
+		infos = append(infos, types.SynapseInfo{
+			ID:       conn.syn.ID(),
+			SourceID: conn.pre.id,
+			TargetID: conn.post.id,
+			Weight:   conn.syn.GetWeight(),
+			Delay:    conn.syn.GetDelay(),
+		})
+	}
+	return infos
+}
+
+// synapse returns the harness synapse with the given ID.
+func (h *Harness) synapse(id string) (*synapse.BasicSynapse, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, conn := range h.conns {
+		if conn.syn.ID() == id {
+			return conn.syn, nil
+		}
+	}
+	return nil, fmt.Errorf("neurontest: no synapse %s", id)
+}
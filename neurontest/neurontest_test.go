@@ -0,0 +1,139 @@
+package neurontest
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// TestHarness_SpikeTimingAndSTDP verifies exact virtual spike times through a
+// delayed synapse and that causal and acausal pairs are learned.
+func TestHarness_SpikeTimingAndSTDP(t *testing.T) {
+	h := New(t)
+	pre := h.Neuron("pre", DefaultNeuronConfig())
+	post := h.Neuron("post", DefaultNeuronConfig())
+	syn := h.Connect("syn", pre, post, synapse.WithWeight(1.5), synapse.WithDelay(2*time.Millisecond))
+
+	h.InjectAt(pre, 10*time.Millisecond, 1.5)
+	h.RunUntil(20 * time.Millisecond)
+
+	h.AssertSpikesAt(pre, 0, 10*time.Millisecond)
+	h.AssertSpikesAt(post, 0, 12*time.Millisecond)
+	h.AssertWeightAbove(syn, 1.5)
+	if h.Now() != 20*time.Millisecond {
+		t.Errorf("Expected the clock at 20ms, got %v", h.Now())
+	}
+
+	// Post before pre depresses a weak synapse that cannot drive post itself
+	weakPre := h.Neuron("weak_pre", DefaultNeuronConfig())
+	weakPost := h.Neuron("weak_post", DefaultNeuronConfig())
+	weak := h.Connect("weak", weakPre, weakPost, synapse.WithWeight(0.3))
+	h.InjectAt(weakPost, 25*time.Millisecond, 1.5)
+	h.InjectAt(weakPre, 30*time.Millisecond, 1.5)
+	h.RunUntil(40 * time.Millisecond)
+
+	h.AssertSpikesAt(weakPost, 0, 25*time.Millisecond)
+	h.AssertWeightBelow(weak, 0.3)
+}
+
+// TestHarness_PairingIsDeterministic verifies that scripted protocols
+// potentiate or depress and give identical results on every run.
+func TestHarness_PairingIsDeterministic(t *testing.T) {
+	run := func(deltaT time.Duration) float64 {
+		h := New(t)
+		syn := h.Connect("pairing", h.Neuron("pre", DefaultNeuronConfig()), h.Neuron("post", DefaultNeuronConfig()),
+			synapse.WithWeight(0.5))
+		h.RunPairing(syn, Pairing{DeltaT: deltaT, Repetitions: 20, Interval: 100 * time.Millisecond})
+		if h.Now() != 2*time.Second {
+			t.Errorf("Expected the protocol to take 2s of virtual time, took %v", h.Now())
+		}
+		return syn.GetWeight()
+	}
+
+	ltp, ltd := run(-10*time.Millisecond), run(10*time.Millisecond)
+	if ltp <= 0.5 || ltd >= 0.5 {
+		t.Errorf("Expected potentiation and depression around 0.5, got %f and %f", ltp, ltd)
+	}
+	if again := run(-10 * time.Millisecond); again != ltp {
+		t.Errorf("Expected identical weights on every run, got %f and %f", ltp, again)
+	}
+}
+
+// TestHarness_SpawnedNeuronTiming verifies that a spawned neuron fires at
+// exact virtual times when connected to and from batch neurons.
+func TestHarness_SpawnedNeuronTiming(t *testing.T) {
+	h := New(t)
+	source := h.Neuron("source", DefaultNeuronConfig())
+	cell := h.Spawn(neuron.NewNeuron("cell", 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0))
+	sink := h.Neuron("sink", DefaultNeuronConfig())
+	h.Connect("in", source, cell, synapse.WithWeight(1.5), synapse.WithDelay(2*time.Millisecond))
+	h.Connect("out", cell, sink, synapse.WithWeight(1.5), synapse.WithDelay(3*time.Millisecond))
+
+	h.InjectAt(source, 10*time.Millisecond, 1.5)
+	h.RunUntil(20 * time.Millisecond)
+
+	h.AssertSpikesAt(source, 0, 10*time.Millisecond)
+	h.AssertSpikesAt(cell, 0, 12*time.Millisecond)
+	h.AssertSpikesAt(sink, 0, 15*time.Millisecond)
+	if cell.Cell() == nil || cell.Member() != nil || source.Cell() != nil {
+		t.Error("Expected Cell for the spawned neuron and Member for batch neurons only")
+	}
+
+	h.InjectAt(cell, 25*time.Millisecond, 0.5)
+	h.RunUntil(25 * time.Millisecond)
+	if got := cell.Potential(); math.Abs(got-0.5*0.95) > 1e-9 {
+		t.Errorf("Expected the input decayed by its tick (0.475), got %g", got)
+	}
+}
+
+// failureRecorder captures assertion failures instead of failing the test.
+type failureRecorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *failureRecorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// TestHarness_AssertionsReportMismatches verifies that assertions fail on
+// wrong spike times, counts and weights, and pass within tolerance.
+func TestHarness_AssertionsReportMismatches(t *testing.T) {
+	recorder := &failureRecorder{TB: t}
+	h := New(recorder)
+	p := h.Neuron("probe", DefaultNeuronConfig())
+	syn := h.Connect("syn", p, h.Neuron("target", DefaultNeuronConfig()), synapse.WithWeight(0.5))
+	h.InjectAt(p, 5*time.Millisecond, 1.5) // too weak to drive the target
+	h.RunUntil(10 * time.Millisecond)
+
+	passes := []bool{
+		h.AssertSpikesAt(p, time.Millisecond, 6*time.Millisecond),
+		h.AssertSpikeCount(p, 1),
+		h.AssertWeight(syn, 0.5, 1e-9),
+	}
+	fails := []bool{
+		h.AssertSpikesAt(p, 0, 6*time.Millisecond),
+		h.AssertSpikesAt(p, 0),
+		h.AssertSpikeCount(p, 2),
+		h.AssertWeight(syn, 0.6, 0.01),
+		h.AssertWeightAbove(syn, 0.5),
+		h.AssertWeightBelow(syn, 0.5),
+	}
+	for i, ok := range passes {
+		if !ok {
+			t.Errorf("Expected assertion %d to pass", i)
+		}
+	}
+	for i, ok := range fails {
+		if ok {
+			t.Errorf("Expected assertion %d to fail", i)
+		}
+	}
+	if len(recorder.failures) != len(fails) {
+		t.Errorf("Expected %d reported failures, got %v", len(fails), recorder.failures)
+	}
+}
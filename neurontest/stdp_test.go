@@ -0,0 +1,130 @@
+package neurontest
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// These tests drive real neurons and their own STDP feedback on the virtual
+// clock. They replace the neuron package's sleep-based timing tests, which
+// recorded spike times relative to time.Now and waited for the feedback.
+
+// newSTDPCell creates a neuron without homeostasis that fires on a single
+// 1.5 input and, with a feedback delay, applies STDP to its input synapses.
+func newSTDPCell(id string, feedbackDelay time.Duration) *neuron.Neuron {
+	cell := neuron.NewNeuron(id, 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+	if feedbackDelay > 0 {
+		cell.EnableSTDPFeedback(feedbackDelay, 0.1)
+	}
+	return cell
+}
+
+// pairSpikes fires pre and post at exact virtual times through a 0.5 synapse
+// and returns the weight change the post-synaptic neuron applied.
+func pairSpikes(t *testing.T, tPre, tPost, feedbackDelay time.Duration) float64 {
+	t.Helper()
+	h := New(t)
+	pre := h.Spawn(newSTDPCell("pre", 0))
+	post := h.Spawn(newSTDPCell("post", feedbackDelay))
+	syn := h.Connect("syn", pre, post, synapse.WithWeight(0.5), synapse.WithDelay(0))
+
+	h.InjectAt(pre, tPre, 1.5)
+	h.InjectAt(post, tPost, 1.5)
+	h.RunUntil(max(tPre, tPost) + feedbackDelay + 10*time.Millisecond)
+
+	h.AssertSpikesAt(pre, 0, tPre)
+	h.AssertSpikesAt(post, 0, tPost)
+	return syn.GetWeight() - 0.5
+}
+
+// TestSTDPNeuron_TimingCurve verifies that the neuron's STDP feedback
+// potentiates pre-before-post pairs, depresses post-before-pre pairs, and
+// that both effects shrink as the spikes move apart.
+func TestSTDPNeuron_TimingCurve(t *testing.T) {
+	const tPost = 50 * time.Millisecond
+	feedbackDelay := 50 * time.Millisecond
+	deltas := []time.Duration{
+		-30 * time.Millisecond, -20 * time.Millisecond, -10 * time.Millisecond, -5 * time.Millisecond,
+		5 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond, 30 * time.Millisecond,
+	}
+
+	changes := make(map[time.Duration]float64)
+	for _, deltaT := range deltas {
+		change := pairSpikes(t, tPost+deltaT, tPost, feedbackDelay)
+		changes[deltaT] = change
+		t.Logf("Δt = %v: weight change %+.5f", deltaT, change)
+
+		if deltaT < 0 && change <= 0 {
+			t.Errorf("Δt = %v: expected potentiation for pre before post, got %+.5f", deltaT, change)
+		}
+		if deltaT > 0 && change >= 0 {
+			t.Errorf("Δt = %v: expected depression for post before pre, got %+.5f", deltaT, change)
+		}
+	}
+
+	// Closer pairs change the weight more on both sides of the curve
+	for i := 1; i < len(deltas); i++ {
+		closer, farther := deltas[i], deltas[i-1]
+		if closer > 0 {
+			closer, farther = deltas[i-1], deltas[i]
+		}
+		if (closer < 0) != (farther < 0) {
+			continue
+		}
+		if math.Abs(changes[closer]) <= math.Abs(changes[farther]) {
+			t.Errorf("Expected |change| at %v (%.5f) above |change| at %v (%.5f)",
+				closer, changes[closer], farther, changes[farther])
+		}
+	}
+
+	// The same pair always learns the same amount
+	if again := pairSpikes(t, tPost-10*time.Millisecond, tPost, feedbackDelay); again != changes[-10*time.Millisecond] {
+		t.Errorf("Expected identical weight changes on every run, got %.5f and %.5f",
+			changes[-10*time.Millisecond], again)
+	}
+}
+
+// TestSTDPNeuron_LearningWindow verifies that pairs outside the synapse's
+// STDP window leave the weight unchanged.
+func TestSTDPNeuron_LearningWindow(t *testing.T) {
+	outside := synapse.STDP_DEFAULT_WINDOW_SIZE + 20*time.Millisecond
+	feedbackDelay := outside + 10*time.Millisecond
+
+	if change := pairSpikes(t, 10*time.Millisecond, 10*time.Millisecond+outside, feedbackDelay); change != 0 {
+		t.Errorf("Expected no change for pre %v before post, got %+.5f", outside, change)
+	}
+	if change := pairSpikes(t, 10*time.Millisecond+outside, 10*time.Millisecond, feedbackDelay); change != 0 {
+		t.Errorf("Expected no change for post %v before pre, got %+.5f", outside, change)
+	}
+	if change := pairSpikes(t, 10*time.Millisecond, 20*time.Millisecond, feedbackDelay); change <= 0 {
+		t.Errorf("Expected a pair inside the window to potentiate with the same feedback delay, got %+.5f", change)
+	}
+}
+
+// TestSTDPNeuron_ScheduledFeedback verifies that a neuron applies STDP
+// exactly when its feedback delay has elapsed after the post-synaptic spike.
+func TestSTDPNeuron_ScheduledFeedback(t *testing.T) {
+	const feedbackDelay = 20 * time.Millisecond
+	h := New(t)
+	pre := h.Spawn(newSTDPCell("pre", 0))
+	post := h.Spawn(newSTDPCell("post", feedbackDelay))
+	syn := h.Connect("syn", pre, post, synapse.WithWeight(0.5), synapse.WithDelay(0))
+
+	h.InjectAt(pre, 10*time.Millisecond, 1.5)
+	h.InjectAt(post, 15*time.Millisecond, 1.5)
+	h.RunUntil(15*time.Millisecond + feedbackDelay)
+
+	h.AssertSpikesAt(pre, 0, 10*time.Millisecond)
+	h.AssertSpikesAt(post, 0, 15*time.Millisecond)
+	if spikes := syn.GetPostSpikeTimes(); len(spikes) != 1 || !spikes[0].Equal(h.At(15*time.Millisecond)) {
+		t.Errorf("Expected the post-synaptic spike recorded at 15ms, got %v", spikes)
+	}
+	h.AssertWeight(syn, 0.5, 0) // Feedback is not due yet
+
+	h.Run(time.Millisecond)
+	h.AssertWeightAbove(syn, 0.5)
+}
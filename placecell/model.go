@@ -0,0 +1,200 @@
+package placecell
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/neuron"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// =================================================================================
+// PLACE CELL MODEL
+// =================================================================================
+//
+// Every input of the encoder projects to every place cell through a BTSP
+// synapse. Run walks a trajectory on a virtual clock: at each sample the
+// inputs spike as Poisson processes at their encoder rates, and each cell
+// receives an instructive plateau when the animal reaches its plateau
+// location, like the current injection Bittner et al. used to induce fields.
+// Spikes and plateaus go straight to the synapses (RecordPreSpike and
+// ApplyPlateau) with virtual timestamps, so a run of minutes takes
+// milliseconds and is reproducible from Seed. The neurons are wired but never
+// started.
+
+// Config configures a Model.
+type Config struct {
+	Encoder          SpatialEncoder     // Spatial input (required)
+	PlateauLocations []Point            // One place cell per location, where its plateau is delivered (required)
+	PlateauRadius    float64            // Distance from the location that triggers the plateau (0 = default)
+	Plateaus         int                // Plateaus per cell (0 = 1; a single one forms a field)
+	PlateauInterval  time.Duration      // Minimum spacing of one cell's plateaus (0 = default)
+	InitialWeight    float64            // Weight of every synapse before learning (0 = default)
+	BTSP             synapse.BTSPConfig // Plasticity rule (zero value = synapse.CreateDefaultBTSPConfig)
+	Seed             int64              // Seed for input spike generation
+}
+
+// Model is a population of place cells learning from spatial input.
+type Model struct {
+	config   Config
+	inputs   []*neuron.Neuron
+	cells    []*neuron.Neuron
+	synapses [][]*synapse.BasicSynapse // [cell][input]
+	rng      *rand.Rand
+
+	epoch    time.Time         // Virtual time of the first sample of the first Run
+	elapsed  time.Duration     // Virtual time consumed by previous Runs
+	plateaus [][]time.Duration // Plateau times per cell since epoch
+}
+
+// NewModel wires the encoder inputs to one place cell per plateau location.
+func NewModel(config Config) (*Model, error) {
+	if config.Encoder == nil || config.Encoder.Size() == 0 {
+		return nil, fmt.Errorf("place cell model needs a spatial encoder with inputs")
+	}
+	if len(config.PlateauLocations) == 0 {
+		return nil, fmt.Errorf("place cell model needs at least one plateau location")
+	}
+	if config.PlateauRadius < 0 || config.Plateaus < 0 || config.PlateauInterval < 0 || config.InitialWeight < 0 {
+		return nil, fmt.Errorf("place cell model settings cannot be negative")
+	}
+	if config.PlateauRadius == 0 {
+		config.PlateauRadius = PLACECELL_DEFAULT_PLATEAU_RADIUS
+	}
+	if config.Plateaus == 0 {
+		config.Plateaus = 1
+	}
+	if config.PlateauInterval == 0 {
+		config.PlateauInterval = PLACECELL_DEFAULT_PLATEAU_INTERVAL
+	}
+	if config.InitialWeight == 0 {
+		config.InitialWeight = PLACECELL_DEFAULT_INITIAL_WEIGHT
+	}
+	if !config.BTSP.Enabled {
+		config.BTSP = synapse.CreateDefaultBTSPConfig()
+	}
+
+	m := &Model{
+		config:   config,
+		rng:      rand.New(rand.NewSource(config.Seed)),
+		epoch:    time.Unix(0, 0),
+		plateaus: make([][]time.Duration, len(config.PlateauLocations)),
+	}
+	for i := 0; i < config.Encoder.Size(); i++ {
+		m.inputs = append(m.inputs, neuron.NewNeuron(fmt.Sprintf("input_%d", i), 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0))
+	}
+	for c := range config.PlateauLocations {
+		cell := neuron.NewNeuron(fmt.Sprintf("place_%d", c), 1.0, 0.95, 5*time.Millisecond, 1.0, 0, 0)
+		row := make([]*synapse.BasicSynapse, len(m.inputs))
+		for i, input := range m.inputs {
+			syn, err := synapse.NewSynapse(fmt.Sprintf("%s_%s", input.ID(), cell.ID()), input, cell,
+				synapse.WithWeight(config.InitialWeight), synapse.WithBTSP(config.BTSP))
+			if err != nil {
+				return nil, fmt.Errorf("place cell %d: %w", c, err)
+			}
+			cell.RegisterInputSynapse(syn.ID(), syn)
+			row[i] = syn
+		}
+		m.cells = append(m.cells, cell)
+		m.synapses = append(m.synapses, row)
+	}
+	return m, nil
+}
+
+// Run feeds a trajectory to the model. Successive runs continue the virtual
+// clock, so laps can be split across calls.
+func (m *Model) Run(trajectory Trajectory) error {
+	if err := trajectory.validate(); err != nil {
+		return err
+	}
+	if len(trajectory) == 0 {
+		return nil
+	}
+
+	start := m.elapsed - trajectory[0].Time
+	for i, sample := range trajectory {
+		now := start + sample.Time
+		at := m.epoch.Add(now)
+
+		dt := trajectory.step(i).Seconds()
+		for input, rate := range m.config.Encoder.Rates(sample.Position) {
+			if m.rng.Float64() >= rate*dt {
+				continue
+			}
+			for c := range m.cells {
+				m.synapses[c][input].RecordPreSpike(at)
+			}
+		}
+
+		for c, location := range m.config.PlateauLocations {
+			if sample.Position.Distance(location) > m.config.PlateauRadius || !m.plateauDue(c, now) {
+				continue
+			}
+			m.plateaus[c] = append(m.plateaus[c], now)
+			for _, syn := range m.synapses[c] {
+				syn.ApplyPlateau(at)
+			}
+		}
+	}
+	m.elapsed = start + trajectory.Duration() + trajectory.step(len(trajectory)-1)
+	return nil
+}
+
+// plateauDue reports whether cell c may receive another plateau at now.
+func (m *Model) plateauDue(c int, now time.Duration) bool {
+	times := m.plateaus[c]
+	if len(times) >= m.config.Plateaus {
+		return false
+	}
+	return len(times) == 0 || now-times[len(times)-1] >= m.config.PlateauInterval
+}
+
+// Cells returns the place cell neurons.
+func (m *Model) Cells() []*neuron.Neuron {
+	return append([]*neuron.Neuron(nil), m.cells...)
+}
+
+// Inputs returns the input neurons in encoder order.
+func (m *Model) Inputs() []*neuron.Neuron {
+	return append([]*neuron.Neuron(nil), m.inputs...)
+}
+
+// Synapses returns a cell's input synapses in encoder order.
+func (m *Model) Synapses(cell int) []*synapse.BasicSynapse {
+	return append([]*synapse.BasicSynapse(nil), m.synapses[cell]...)
+}
+
+// Weights returns a cell's input weights in encoder order.
+func (m *Model) Weights(cell int) []float64 {
+	weights := make([]float64, len(m.synapses[cell]))
+	for i, syn := range m.synapses[cell] {
+		weights[i] = syn.GetWeight()
+	}
+	return weights
+}
+
+// Plateaus returns the virtual times of a cell's plateaus.
+func (m *Model) Plateaus(cell int) []time.Duration {
+	return append([]time.Duration(nil), m.plateaus[cell]...)
+}
+
+// Drive returns a cell's synaptic drive at p: the encoder rates weighted by
+// the cell's input weights (Hz × weight).
+func (m *Model) Drive(cell int, p Point) float64 {
+	drive := 0.0
+	for i, rate := range m.config.Encoder.Rates(p) {
+		drive += rate * m.synapses[cell][i].GetWeight()
+	}
+	return drive
+}
+
+// TuningMap samples a cell's drive on an nx x ny grid of bin centres over a
+// width x height environment (ny 1 for a track). The map has no occupancy.
+func (m *Model) TuningMap(cell int, width, height float64, nx, ny int) RateMap {
+	rm := newRateMap(width, height, nx, ny)
+	for bin := range rm.Rates {
+		rm.Rates[bin] = m.Drive(cell, rm.BinCenter(bin))
+	}
+	return rm
+}
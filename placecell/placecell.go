@@ -0,0 +1,270 @@
+/*
+=================================================================================
+PLACECELL - PLACE FIELD FORMATION FROM SPATIAL INPUT AND BTSP
+=================================================================================
+
+Hippocampal place cells fire at one location of an environment. In CA1 a
+field can form in a single traversal: a dendritic plateau potential at some
+location potentiates every spatially tuned input that was active in the
+seconds around it (behavioral-timescale plasticity, Bittner et al. 2017).
+Because the BTSP kernel favours inputs active before the plateau, the new
+field sits slightly before the plateau location in the direction of travel.
+
+This package is a worked example of that experiment:
+
+  - Trajectories: a 1D linear track with repeated laps, or a 2D random walk.
+  - Spatial encoders: Gaussian place-like inputs, or grid-cell-like inputs
+    (three-cosine hexagonal model, Solstad et al. 2006).
+  - A Model that wires every input to every place cell with BTSP synapses,
+    runs a trajectory on a virtual clock (so seconds-scale learning takes
+    milliseconds) and delivers one instructive plateau per cell at a chosen
+    location.
+  - Analysis: occupancy-normalised rate maps from spike times, tuning maps
+    from learned weights, field extraction, Skaggs spatial information, and
+    CSV/PNG export for plotting.
+
+	track := placecell.LinearTrack(2.0, 0.25, 5, 10*time.Millisecond)
+	model, _ := placecell.NewModel(placecell.Config{
+	    Encoder:          placecell.NewLinearEncoder(2.0, 40, 0.08, 5),
+	    PlateauLocations: []placecell.Point{{X: 0.5}, {X: 1.5}},
+	})
+	model.Run(track)
+	tuning := model.TuningMap(0, 2.0, 0, 40, 1)
+	field := tuning.Field(0.5)
+=================================================================================
+*/
+
+package placecell
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	// PLACECELL_DEFAULT_PLATEAU_RADIUS is how close (m) the animal must come
+	// to a cell's plateau location to trigger the plateau.
+	PLACECELL_DEFAULT_PLATEAU_RADIUS = 0.05
+
+	// PLACECELL_DEFAULT_PLATEAU_INTERVAL is the minimum spacing of one cell's
+	// plateaus, longer than the BTSP kernel so plateaus do not overlap.
+	PLACECELL_DEFAULT_PLATEAU_INTERVAL = 5 * time.Second
+
+	// PLACECELL_DEFAULT_INITIAL_WEIGHT is the weight of every input before
+	// learning: weak, untuned synapses.
+	PLACECELL_DEFAULT_INITIAL_WEIGHT = 0.1
+
+	// PLACECELL_RANDOM_WALK_TURN is the standard deviation (radians per
+	// second) of heading changes in RandomWalk.
+	PLACECELL_RANDOM_WALK_TURN = 2.0
+)
+
+// =================================================================================
+// TRAJECTORIES
+// =================================================================================
+
+// Point is a position in the environment (m). 1D tracks use X only.
+type Point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// Distance returns the Euclidean distance to q.
+func (p Point) Distance(q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+// Sample is the position at a time since the start of the trajectory.
+type Sample struct {
+	Time     time.Duration `json:"time"`
+	Position Point         `json:"position"`
+}
+
+// Trajectory is a sequence of samples in increasing time order.
+type Trajectory []Sample
+
+// Duration returns the time of the last sample.
+func (t Trajectory) Duration() time.Duration {
+	if len(t) == 0 {
+		return 0
+	}
+	return t[len(t)-1].Time
+}
+
+// validate checks that times are strictly increasing.
+func (t Trajectory) validate() error {
+	for i := 1; i < len(t); i++ {
+		if t[i].Time <= t[i-1].Time {
+			return fmt.Errorf("trajectory sample %d at %v is not after %v", i, t[i].Time, t[i-1].Time)
+		}
+	}
+	return nil
+}
+
+// step returns the time covered by sample i (its distance to the next
+// sample; the last sample repeats the previous step).
+func (t Trajectory) step(i int) time.Duration {
+	switch {
+	case len(t) < 2:
+		return 0
+	case i+1 < len(t):
+		return t[i+1].Time - t[i].Time
+	default:
+		return t[i].Time - t[i-1].Time
+	}
+}
+
+// LinearTrack runs laps along a track of length (m) at constant speed (m/s),
+// sampled every dt. Each lap starts again at 0, as on a treadmill belt.
+func LinearTrack(length, speed float64, laps int, dt time.Duration) Trajectory {
+	if length <= 0 || speed <= 0 || laps <= 0 || dt <= 0 {
+		return nil
+	}
+	duration := time.Duration(float64(laps) * length / speed * float64(time.Second))
+	trajectory := make(Trajectory, 0, int(duration/dt))
+	for t := time.Duration(0); t < duration; t += dt {
+		trajectory = append(trajectory, Sample{Time: t, Position: Point{X: math.Mod(speed*t.Seconds(), length)}})
+	}
+	return trajectory
+}
+
+// RandomWalk explores a width x height box (m) at constant speed (m/s) with
+// a smoothly turning heading, reflecting off the walls.
+func RandomWalk(width, height, speed float64, duration, dt time.Duration, seed int64) Trajectory {
+	if width <= 0 || height <= 0 || speed <= 0 || duration <= 0 || dt <= 0 {
+		return nil
+	}
+	rng := rand.New(rand.NewSource(seed))
+	position := Point{X: width / 2, Y: height / 2}
+	heading := rng.Float64() * 2 * math.Pi
+	turn := PLACECELL_RANDOM_WALK_TURN * math.Sqrt(dt.Seconds())
+
+	trajectory := make(Trajectory, 0, int(duration/dt))
+	for t := time.Duration(0); t < duration; t += dt {
+		trajectory = append(trajectory, Sample{Time: t, Position: position})
+		heading += rng.NormFloat64() * turn
+		position.X += speed * dt.Seconds() * math.Cos(heading)
+		position.Y += speed * dt.Seconds() * math.Sin(heading)
+		if position.X < 0 || position.X > width {
+			position.X = reflect(position.X, width)
+			heading = math.Pi - heading
+		}
+		if position.Y < 0 || position.Y > height {
+			position.Y = reflect(position.Y, height)
+			heading = -heading
+		}
+	}
+	return trajectory
+}
+
+// reflect mirrors v back into [0, limit].
+func reflect(v, limit float64) float64 {
+	if v < 0 {
+		return math.Min(-v, limit)
+	}
+	return math.Max(2*limit-v, 0)
+}
+
+// =================================================================================
+// SPATIAL ENCODERS
+// =================================================================================
+
+// SpatialEncoder gives the firing rate (Hz) of each input at a position.
+type SpatialEncoder interface {
+	Size() int
+	Rates(p Point) []float64
+}
+
+// GaussianEncoder has one input per centre with a Gaussian tuning curve.
+type GaussianEncoder struct {
+	Centers []Point
+	Width   float64 // Tuning curve standard deviation (m)
+	MaxRate float64 // Rate at the centre (Hz)
+}
+
+// NewLinearEncoder spaces n inputs evenly along a track of length (m).
+func NewLinearEncoder(length float64, n int, width, maxRate float64) *GaussianEncoder {
+	centers := make([]Point, n)
+	for i := range centers {
+		centers[i] = Point{X: (float64(i) + 0.5) * length / float64(n)}
+	}
+	return &GaussianEncoder{Centers: centers, Width: width, MaxRate: maxRate}
+}
+
+// NewGaussianGrid places nx*ny inputs on a regular grid over a box (m).
+func NewGaussianGrid(width, height float64, nx, ny int, tuningWidth, maxRate float64) *GaussianEncoder {
+	centers := make([]Point, 0, nx*ny)
+	for y := 0; y < ny; y++ {
+		for x := 0; x < nx; x++ {
+			centers = append(centers, Point{
+				X: (float64(x) + 0.5) * width / float64(nx),
+				Y: (float64(y) + 0.5) * height / float64(ny),
+			})
+		}
+	}
+	return &GaussianEncoder{Centers: centers, Width: tuningWidth, MaxRate: maxRate}
+}
+
+// Size implements SpatialEncoder.
+func (e *GaussianEncoder) Size() int {
+	return len(e.Centers)
+}
+
+// Rates implements SpatialEncoder.
+func (e *GaussianEncoder) Rates(p Point) []float64 {
+	rates := make([]float64, len(e.Centers))
+	for i, c := range e.Centers {
+		d := p.Distance(c)
+		rates[i] = e.MaxRate * math.Exp(-d*d/(2*e.Width*e.Width))
+	}
+	return rates
+}
+
+// GridEncoder models medial entorhinal grid cells: each input fires on a
+// hexagonal lattice given by the sum of three plane waves 60° apart.
+type GridEncoder struct {
+	Spacings     []float64 // Lattice spacing per cell (m)
+	Orientations []float64 // Lattice orientation per cell (radians)
+	Phases       []Point   // Lattice offset per cell
+	MaxRate      float64   // Rate at a lattice vertex (Hz)
+}
+
+// NewGridEncoder creates cellsPerModule grid cells for each spacing (one
+// module per spacing, sharing a random orientation) with random phases.
+func NewGridEncoder(spacings []float64, cellsPerModule int, maxRate float64, seed int64) *GridEncoder {
+	rng := rand.New(rand.NewSource(seed))
+	e := &GridEncoder{MaxRate: maxRate}
+	for _, spacing := range spacings {
+		orientation := rng.Float64() * math.Pi / 3
+		for i := 0; i < cellsPerModule; i++ {
+			e.Spacings = append(e.Spacings, spacing)
+			e.Orientations = append(e.Orientations, orientation)
+			e.Phases = append(e.Phases, Point{X: rng.Float64() * spacing, Y: rng.Float64() * spacing})
+		}
+	}
+	return e
+}
+
+// Size implements SpatialEncoder.
+func (e *GridEncoder) Size() int {
+	return len(e.Spacings)
+}
+
+// Rates implements SpatialEncoder. Each rate is normalised so that lattice
+// vertices fire at MaxRate and the minimum is 0.
+func (e *GridEncoder) Rates(p Point) []float64 {
+	rates := make([]float64, len(e.Spacings))
+	for i, spacing := range e.Spacings {
+		k := 4 * math.Pi / (math.Sqrt(3) * spacing)
+		dx, dy := p.X-e.Phases[i].X, p.Y-e.Phases[i].Y
+		sum := 0.0
+		for j := 0; j < 3; j++ {
+			angle := e.Orientations[i] + float64(j)*math.Pi/3 - math.Pi/6
+			sum += math.Cos(k * (dx*math.Cos(angle) + dy*math.Sin(angle)))
+		}
+		rates[i] = e.MaxRate * (sum + 1.5) / 4.5
+	}
+	return rates
+}
@@ -0,0 +1,182 @@
+package placecell
+
+import (
+	"bytes"
+	"encoding/csv"
+	"image/png"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestModel_LinearTrackFieldsFormBeforePlateaus runs laps on a linear track
+// with one plateau per cell and checks that each cell's tuning peaks at or
+// shortly before its plateau location, in the order of the locations.
+func TestModel_LinearTrackFieldsFormBeforePlateaus(t *testing.T) {
+	const length = 2.0
+	locations := []Point{{X: 0.5}, {X: 1.0}, {X: 1.5}}
+	model, err := NewModel(Config{
+		Encoder:          NewLinearEncoder(length, 40, 0.08, 5),
+		PlateauLocations: locations,
+		Seed:             1,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
+
+	before := model.TuningMap(0, length, 0, 40, 1)
+	if err := model.Run(LinearTrack(length, 0.25, 3, 10*time.Millisecond)); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	previous := 0.0
+	for c, location := range locations {
+		if got := len(model.Plateaus(c)); got != 1 {
+			t.Fatalf("Cell %d: expected one plateau, got %d", c, got)
+		}
+		field := model.TuningMap(c, length, 0, 40, 1).Field(0.5)
+		t.Logf("Cell %d: plateau at %.2f, peak %.2f, centre %.2f, size %.2f", c, location.X, field.Peak.X, field.Center.X, field.Size)
+		if field.Peak.X > location.X+0.1 || field.Peak.X < location.X-0.5 {
+			t.Errorf("Cell %d: expected peak near %.2f, got %.2f", c, location.X, field.Peak.X)
+		}
+		if field.Center.X >= location.X {
+			t.Errorf("Cell %d: expected field centre before the plateau at %.2f, got %.2f", c, location.X, field.Center.X)
+		}
+		if field.Center.X <= previous {
+			t.Errorf("Cell %d: expected fields ordered along the track", c)
+		}
+		previous = field.Center.X
+	}
+
+	after := model.TuningMap(0, length, 0, 40, 1)
+	if after.SpatialInformation() <= before.SpatialInformation() {
+		t.Errorf("Expected learning to increase spatial information: %f -> %f",
+			before.SpatialInformation(), after.SpatialInformation())
+	}
+}
+
+// TestModel_RunContinuesClock verifies that split runs keep plateaus apart
+// on one virtual clock and that invalid trajectories are rejected.
+func TestModel_RunContinuesClock(t *testing.T) {
+	model, err := NewModel(Config{
+		Encoder:          NewLinearEncoder(1, 10, 0.1, 10),
+		PlateauLocations: []Point{{X: 0.5}},
+		Plateaus:         2,
+		PlateauInterval:  time.Second,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create model: %v", err)
+	}
+	lap := LinearTrack(1, 0.5, 1, 10*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		if err := model.Run(lap); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	}
+
+	plateaus := model.Plateaus(0)
+	if len(plateaus) != 2 {
+		t.Fatalf("Expected the plateau limit of 2, got %v", plateaus)
+	}
+	if gap := plateaus[1] - plateaus[0]; gap < 1900*time.Millisecond || gap > 2100*time.Millisecond {
+		t.Errorf("Expected plateaus one lap (2s) apart, got %v", gap)
+	}
+
+	if err := model.Run(Trajectory{{Time: time.Second}, {Time: time.Second}}); err == nil {
+		t.Error("Expected a non-increasing trajectory to be rejected")
+	}
+	if _, err := NewModel(Config{PlateauLocations: []Point{{}}}); err == nil {
+		t.Error("Expected a model without encoder to be rejected")
+	}
+}
+
+// TestRateMap_SyntheticField computes a rate map from spikes emitted only
+// in one stretch of the track and checks the field, information and export.
+func TestRateMap_SyntheticField(t *testing.T) {
+	track := LinearTrack(2, 0.5, 4, 10*time.Millisecond)
+	var spikes []time.Duration
+	for _, sample := range track {
+		if sample.Position.X >= 0.9 && sample.Position.X < 1.1 {
+			spikes = append(spikes, sample.Time)
+		}
+	}
+
+	rm := ComputeRateMap(track, spikes, 2, 0, 20, 1)
+	field := rm.Field(0.5)
+	if math.Abs(field.Center.X-1.0) > 0.05 || math.Abs(field.Size-0.2) > 0.01 {
+		t.Errorf("Expected a 0.2m field at 1.0, got %.2fm at %.2f", field.Size, field.Center.X)
+	}
+	if math.Abs(field.PeakRate-100) > 1 {
+		t.Errorf("Expected 100 Hz in the field (one spike per 10ms sample), got %f", field.PeakRate)
+	}
+	if info := rm.SpatialInformation(); math.Abs(info-math.Log2(10)) > 0.05 {
+		t.Errorf("Expected log2(10) bits for a field covering a tenth of the track, got %f", info)
+	}
+
+	var buf bytes.Buffer
+	if err := rm.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(rows) != 21 {
+		t.Fatalf("Expected header plus 20 rows, got %d (%v)", len(rows), err)
+	}
+
+	buf.Reset()
+	if err := rm.WritePNG(&buf, 4); err != nil {
+		t.Fatalf("WritePNG failed: %v", err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil || img.Bounds().Dx() != 80 || img.Bounds().Dy() != 4 {
+		t.Fatalf("Expected an 80x4 image, got %v (%v)", img.Bounds(), err)
+	}
+}
+
+// TestEncodersAndRandomWalk checks the 2D trajectory stays in its box, that
+// Gaussian rates peak at their centres and that grid rates repeat with the
+// lattice spacing.
+func TestEncodersAndRandomWalk(t *testing.T) {
+	walk := RandomWalk(1, 1, 0.2, 300*time.Second, 20*time.Millisecond, 3)
+	for _, sample := range walk {
+		if p := sample.Position; p.X < 0 || p.X > 1 || p.Y < 0 || p.Y > 1 {
+			t.Fatalf("Random walk left the arena at %v: %+v", sample.Time, p)
+		}
+	}
+	if occupied := ComputeRateMap(walk, nil, 1, 1, 5, 5); countPositive(occupied.Occupancy) < 20 {
+		t.Errorf("Expected the walk to cover most of the arena, visited %d of 25 bins", countPositive(occupied.Occupancy))
+	}
+
+	gaussian := NewGaussianGrid(1, 1, 4, 4, 0.1, 20)
+	if rates := gaussian.Rates(gaussian.Centers[5]); rates[5] != 20 || rates[0] >= rates[5] {
+		t.Errorf("Expected input 5 at its maximum at its centre, got %v", rates)
+	}
+
+	grid := NewGridEncoder([]float64{0.3, 0.5}, 3, 10, 7)
+	if grid.Size() != 6 {
+		t.Fatalf("Expected 6 grid inputs, got %d", grid.Size())
+	}
+	for i := 0; i < grid.Size(); i++ {
+		origin := grid.Phases[i]
+		vertex := Point{
+			X: origin.X + grid.Spacings[i]*math.Cos(grid.Orientations[i]),
+			Y: origin.Y + grid.Spacings[i]*math.Sin(grid.Orientations[i]),
+		}
+		between := Point{X: (origin.X + vertex.X) / 2, Y: (origin.Y + vertex.Y) / 2}
+		if a, b := grid.Rates(origin)[i], grid.Rates(vertex)[i]; math.Abs(a-10) > 1e-9 || math.Abs(b-10) > 1e-9 {
+			t.Errorf("Input %d: expected peak rate at lattice vertices, got %f and %f", i, a, b)
+		}
+		if mid := grid.Rates(between)[i]; mid > 5 {
+			t.Errorf("Input %d: expected low rate between vertices, got %f", i, mid)
+		}
+	}
+}
+
+func countPositive(values []float64) int {
+	count := 0
+	for _, v := range values {
+		if v > 0 {
+			count++
+		}
+	}
+	return count
+}
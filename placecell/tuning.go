@@ -0,0 +1,261 @@
+package placecell
+
+import (
+	"encoding/csv"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// =================================================================================
+// SPATIAL TUNING ANALYSIS
+// =================================================================================
+//
+// A RateMap bins the environment into nx x ny equal bins (ny 1 for a track)
+// and holds one value per bin: a firing rate computed from spike times and
+// the time spent in each bin (ComputeRateMap), or a model cell's synaptic
+// drive (Model.TuningMap). Fields are the bins above a fraction of the peak;
+// spatial information follows Skaggs et al. (1993):
+//
+//	I = Σ p_i (λ_i/λ) log2(λ_i/λ)   bits per spike
+//
+// with p_i the occupancy probability of bin i, λ_i its rate and λ the mean
+// rate. Maps without occupancy weight all bins equally.
+
+// RateMap is a spatial tuning curve (1D) or map (2D).
+type RateMap struct {
+	Width     float64   `json:"width"`               // Environment size along X (m)
+	Height    float64   `json:"height"`              // Environment size along Y (m, 0 for a track)
+	NX        int       `json:"nx"`                  // Bins along X
+	NY        int       `json:"ny"`                  // Bins along Y
+	Rates     []float64 `json:"rates"`               // Row-major: bin = y*NX + x
+	Occupancy []float64 `json:"occupancy,omitempty"` // Seconds spent per bin (nil = uniform)
+}
+
+// PlaceField summarises the bins of a map above a fraction of its peak.
+type PlaceField struct {
+	Peak     Point   `json:"peak"`      // Centre of the peak bin
+	PeakRate float64 `json:"peak_rate"` // Value of the peak bin
+	Center   Point   `json:"center"`    // Rate-weighted centroid of the field bins
+	Size     float64 `json:"size"`      // Field length (1D, m) or area (2D, m²)
+	Bins     int     `json:"bins"`      // Number of field bins
+}
+
+// newRateMap returns an empty map; sizes below one bin become one bin.
+func newRateMap(width, height float64, nx, ny int) RateMap {
+	nx, ny = max(nx, 1), max(ny, 1)
+	return RateMap{Width: width, Height: height, NX: nx, NY: ny, Rates: make([]float64, nx*ny)}
+}
+
+// ComputeRateMap bins spikes (times since the start of the trajectory) by
+// the position at which they occurred and divides by the time spent in each
+// bin. Unvisited bins have rate 0.
+func ComputeRateMap(trajectory Trajectory, spikes []time.Duration, width, height float64, nx, ny int) RateMap {
+	rm := newRateMap(width, height, nx, ny)
+	rm.Occupancy = make([]float64, len(rm.Rates))
+	if len(trajectory) == 0 {
+		return rm
+	}
+	for i, sample := range trajectory {
+		rm.Occupancy[rm.Bin(sample.Position)] += trajectory.step(i).Seconds()
+	}
+
+	counts := make([]float64, len(rm.Rates))
+	for _, spike := range spikes {
+		i := sort.Search(len(trajectory), func(i int) bool { return trajectory[i].Time > spike }) - 1
+		if i < 0 || spike > trajectory.Duration()+trajectory.step(len(trajectory)-1) {
+			continue
+		}
+		counts[rm.Bin(trajectory[i].Position)]++
+	}
+	for bin, count := range counts {
+		if rm.Occupancy[bin] > 0 {
+			rm.Rates[bin] = count / rm.Occupancy[bin]
+		}
+	}
+	return rm
+}
+
+// Bin returns the bin containing p; positions outside are clamped.
+func (rm RateMap) Bin(p Point) int {
+	x := binIndex(p.X, rm.Width, rm.NX)
+	y := binIndex(p.Y, rm.Height, rm.NY)
+	return y*rm.NX + x
+}
+
+// binIndex maps v in [0, size] to one of n bins.
+func binIndex(v, size float64, n int) int {
+	if size <= 0 || n <= 1 {
+		return 0
+	}
+	i := int(math.Floor(v / size * float64(n)))
+	return max(0, min(n-1, i))
+}
+
+// BinCenter returns the centre of a bin.
+func (rm RateMap) BinCenter(bin int) Point {
+	x, y := bin%rm.NX, bin/rm.NX
+	return Point{
+		X: (float64(x) + 0.5) * rm.Width / float64(rm.NX),
+		Y: (float64(y) + 0.5) * rm.Height / float64(rm.NY),
+	}
+}
+
+// At returns the value of bin (x, y).
+func (rm RateMap) At(x, y int) float64 {
+	return rm.Rates[y*rm.NX+x]
+}
+
+// Peak returns the centre and value of the largest bin.
+func (rm RateMap) Peak() (Point, float64) {
+	if len(rm.Rates) == 0 {
+		return Point{}, 0
+	}
+	best := 0
+	for bin, rate := range rm.Rates {
+		if rate > rm.Rates[best] {
+			best = bin
+		}
+	}
+	return rm.BinCenter(best), rm.Rates[best]
+}
+
+// Field extracts the bins at or above fraction of the peak (after removing
+// the map's minimum, so a flat baseline does not count as field).
+func (rm RateMap) Field(fraction float64) PlaceField {
+	peak, peakRate := rm.Peak()
+	field := PlaceField{Peak: peak, PeakRate: peakRate}
+	if len(rm.Rates) == 0 {
+		return field
+	}
+	low := rm.Rates[0]
+	for _, rate := range rm.Rates {
+		low = math.Min(low, rate)
+	}
+	if peakRate <= low {
+		return field
+	}
+
+	threshold := low + fraction*(peakRate-low)
+	var sum, sumX, sumY float64
+	for bin, rate := range rm.Rates {
+		if rate < threshold {
+			continue
+		}
+		c := rm.BinCenter(bin)
+		weight := rate - low
+		sum += weight
+		sumX += weight * c.X
+		sumY += weight * c.Y
+		field.Bins++
+	}
+	if sum > 0 {
+		field.Center = Point{X: sumX / sum, Y: sumY / sum}
+	}
+	binSize := rm.Width / float64(rm.NX)
+	if rm.Height > 0 {
+		binSize *= rm.Height / float64(rm.NY)
+	}
+	field.Size = float64(field.Bins) * binSize
+	return field
+}
+
+// SpatialInformation returns the Skaggs information in bits per spike.
+func (rm RateMap) SpatialInformation() float64 {
+	total, mean := 0.0, 0.0
+	for bin, rate := range rm.Rates {
+		p := rm.occupancyWeight(bin)
+		total += p
+		mean += p * rate
+	}
+	if total <= 0 || mean <= 0 {
+		return 0
+	}
+	mean /= total
+
+	info := 0.0
+	for bin, rate := range rm.Rates {
+		if rate <= 0 {
+			continue
+		}
+		p := rm.occupancyWeight(bin) / total
+		info += p * rate / mean * math.Log2(rate/mean)
+	}
+	return info
+}
+
+// occupancyWeight returns the unnormalised occupancy of a bin.
+func (rm RateMap) occupancyWeight(bin int) float64 {
+	if rm.Occupancy == nil {
+		return 1
+	}
+	return rm.Occupancy[bin]
+}
+
+// =================================================================================
+// IMAGE AND CSV EXPORT
+// =================================================================================
+
+// Image renders the map as grayscale normalised to its own range (bright =
+// high), one scale*scale block per bin with y increasing downwards.
+func (rm RateMap) Image(scale int) *image.Gray {
+	if scale < 1 {
+		scale = 1
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, rate := range rm.Rates {
+		lo, hi = math.Min(lo, rate), math.Max(hi, rate)
+	}
+	img := image.NewGray(image.Rect(0, 0, rm.NX*scale, rm.NY*scale))
+	for y := 0; y < rm.NY; y++ {
+		for x := 0; x < rm.NX; x++ {
+			level := uint8(0)
+			if hi > lo {
+				level = uint8(math.Round((rm.At(x, y) - lo) / (hi - lo) * 255))
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetGray(x*scale+dx, y*scale+dy, color.Gray{Y: level})
+				}
+			}
+		}
+	}
+	return img
+}
+
+// WritePNG encodes the map as a PNG image.
+func (rm RateMap) WritePNG(w io.Writer, scale int) error {
+	return png.Encode(w, rm.Image(scale))
+}
+
+// WriteCSV writes one row per bin with its centre, value and occupancy,
+// ready for plotting tools.
+func (rm RateMap) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"x", "y", "rate", "occupancy"}); err != nil {
+		return err
+	}
+	for bin, rate := range rm.Rates {
+		c := rm.BinCenter(bin)
+		occupancy := ""
+		if rm.Occupancy != nil {
+			occupancy = strconv.FormatFloat(rm.Occupancy[bin], 'g', 6, 64)
+		}
+		row := []string{
+			strconv.FormatFloat(c.X, 'g', 6, 64),
+			strconv.FormatFloat(c.Y, 'g', 6, 64),
+			strconv.FormatFloat(rate, 'g', 6, 64),
+			occupancy,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
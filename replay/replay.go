@@ -0,0 +1,314 @@
+/*
+=================================================================================
+REPLAY - CAPTURE AND OFFLINE REACTIVATION OF SPIKE PATTERNS
+=================================================================================
+
+During slow-wave sleep the hippocampus replays sequences experienced while
+awake, time-compressed by roughly 5-20x (Wilson & McNaughton 1994; Nádasdy
+et al. 1999). Replay is thought to drive systems consolidation: the same
+synapses are exercised again, offline, under a different plasticity regime.
+
+This package supports such experiments in two halves:
+
+  - A Recorder captures spikes during "wake" operation, either by attaching
+    to neurons (it registers an output callback) or by recording spikes
+    reported by a simulation loop. Capture cuts a time window into a Pattern.
+  - A Session runs an offline "sleep" phase: it switches the plastic synapses
+    to sleep plasticity parameters, re-injects compressed patterns into the
+    same neurons, and restores the wake parameters afterwards.
+
+	rec := replay.NewRecorder(0)
+	rec.Attach(n1); rec.Attach(n2)
+	// ... wake phase ...
+	pattern := rec.Capture("maze_run", wakeStart, wakeEnd)
+
+	session, _ := replay.NewSession(replay.SessionConfig{Targets: targets, Synapses: plastic})
+	session.Sleep()
+	session.Replay(0, pattern)
+	// ... wait for the replay to run ...
+	session.Wake()
+=================================================================================
+*/
+
+package replay
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/SynapticNetworks/temporal-neuron/memory"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+const (
+	// REPLAY_DEFAULT_CAPACITY is the number of spikes a recorder keeps.
+	REPLAY_DEFAULT_CAPACITY = 100000
+
+	// REPLAY_MIN_GROWTH is the smallest storage, in spikes, a budgeted
+	// recorder allocates.
+	REPLAY_MIN_GROWTH = 1024
+
+	// REPLAY_DEFAULT_COMPRESSION is the replay speed-up. Hippocampal
+	// sharp-wave ripple replay runs roughly 5-20x faster than experience.
+	REPLAY_DEFAULT_COMPRESSION = 10.0
+
+	// REPLAY_DEFAULT_AMPLITUDE is the input injected per replayed spike,
+	// chosen to cross the default threshold of 1.0.
+	REPLAY_DEFAULT_AMPLITUDE = 1.5
+
+	// REPLAY_RECORDER_CALLBACK_ID identifies the recorder's output callback.
+	REPLAY_RECORDER_CALLBACK_ID = "replay_recorder"
+
+	// REPLAY_SOURCE_ID is the SourceID of injected replay messages.
+	REPLAY_SOURCE_ID = "replay"
+)
+
+// =================================================================================
+// PATTERNS
+// =================================================================================
+
+// Event is one spike of a pattern, relative to the pattern start.
+type Event struct {
+	NeuronID string        `json:"neuron_id"`
+	Offset   time.Duration `json:"offset"`
+}
+
+// Pattern is a spatiotemporal spike pattern with events sorted by offset.
+type Pattern struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Events   []Event       `json:"events"`
+}
+
+// Compressed returns the pattern with all offsets divided by factor
+// (factor > 1 speeds it up). Spike order is preserved.
+func (p Pattern) Compressed(factor float64) (Pattern, error) {
+	if !(factor > 0) {
+		return Pattern{}, fmt.Errorf("compression factor must be positive: %f", factor)
+	}
+	compressed := Pattern{
+		Name:     p.Name,
+		Duration: time.Duration(float64(p.Duration) / factor),
+		Events:   make([]Event, len(p.Events)),
+	}
+	for i, event := range p.Events {
+		compressed.Events[i] = Event{NeuronID: event.NeuronID, Offset: time.Duration(float64(event.Offset) / factor)}
+	}
+	return compressed, nil
+}
+
+// Neurons returns the IDs of the neurons taking part, sorted.
+func (p Pattern) Neurons() []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, event := range p.Events {
+		if !seen[event.NeuronID] {
+			seen[event.NeuronID] = true
+			ids = append(ids, event.NeuronID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// =================================================================================
+// RECORDER
+// =================================================================================
+
+// SpikeSource is a neuron the recorder can attach to. neuron.Neuron and
+// batch.Member implement it.
+type SpikeSource interface {
+	ID() string
+	AddOutputCallback(synapseID string, callback types.OutputCallback)
+	RemoveOutputCallback(synapseID string)
+}
+
+// spike is a recorded action potential.
+type spike struct {
+	neuronID string
+	at       time.Time
+}
+
+// spikeBytes is the storage of one recorded spike, without the interned ID.
+const spikeBytes = int64(unsafe.Sizeof(spike{}))
+
+// Recorder captures spikes during wake operation. When full, the oldest
+// spikes are dropped.
+type Recorder struct {
+	mu       sync.Mutex
+	spikes   []spike
+	capacity int
+	dropped  int64
+	attached map[string]SpikeSource
+
+	budget   *memory.Budget // Caps growth of spikes (nil = capacity only)
+	budgetID string
+	reserved int64 // Bytes reserved for spikes' backing array
+}
+
+// NewRecorder creates a recorder holding up to capacity spikes
+// (0 = REPLAY_DEFAULT_CAPACITY).
+func NewRecorder(capacity int) *Recorder {
+	if capacity <= 0 {
+		capacity = REPLAY_DEFAULT_CAPACITY
+	}
+	return &Recorder{capacity: capacity, attached: make(map[string]SpikeSource)}
+}
+
+// Record stores a spike, e.g. from a simulation loop stepping a population.
+func (r *Recorder) Record(neuronID string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	switch {
+	case len(r.spikes) >= r.capacity:
+		// Drop the oldest half at once to keep appends amortised O(1)
+		r.dropOldestUnsafe(len(r.spikes) - r.capacity/2)
+	case len(r.spikes) == cap(r.spikes) && !r.growUnsafe():
+		// Over the memory budget: behave as if full at the current size
+		if len(r.spikes) == 0 {
+			r.dropped++
+			return
+		}
+		r.dropOldestUnsafe(len(r.spikes) - len(r.spikes)/2)
+	}
+	r.spikes = append(r.spikes, spike{neuronID: neuronID, at: at})
+}
+
+// dropOldestUnsafe discards the n oldest spikes. Must be called with r.mu
+// held.
+func (r *Recorder) dropOldestUnsafe(n int) {
+	r.spikes = append(r.spikes[:0], r.spikes[n:]...)
+	r.dropped += int64(n)
+}
+
+// growUnsafe doubles the backing array, up to capacity, if the memory budget
+// admits it. A recorder without a budget always grows. Must be called with
+// r.mu held.
+func (r *Recorder) growUnsafe() bool {
+	if r.budget == nil {
+		return true
+	}
+	size := 2 * cap(r.spikes)
+	if size < REPLAY_MIN_GROWTH {
+		size = REPLAY_MIN_GROWTH
+	}
+	if size > r.capacity {
+		size = r.capacity
+	}
+	bytes := int64(size-cap(r.spikes)) * spikeBytes
+	if err := r.budget.Reserve(r.budgetID, memory.CategoryRecorders, bytes); err != nil {
+		return false
+	}
+	r.reserved += bytes
+	grown := make([]spike, len(r.spikes), size)
+	copy(grown, r.spikes)
+	r.spikes = grown
+	return true
+}
+
+// SetMemoryBudget accounts the recorder's storage to budget under id and
+// stops it from growing past the budget: a recorder that cannot grow drops
+// its oldest spikes as if it were full. Storage recorded so far is
+// reserved at once. A nil budget releases the reservation.
+func (r *Recorder) SetMemoryBudget(budget *memory.Budget, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.budget != nil {
+		r.budget.Release(r.budgetID, memory.CategoryRecorders, r.reserved)
+		r.reserved = 0
+	}
+	r.budget, r.budgetID = budget, id
+	if budget == nil {
+		return nil
+	}
+	bytes := int64(cap(r.spikes)) * spikeBytes
+	if err := budget.Reserve(id, memory.CategoryRecorders, bytes); err != nil {
+		r.budget = nil
+		return err
+	}
+	r.reserved = bytes
+	return nil
+}
+
+// MemoryUsage implements memory.Reporter for recorders tracked without
+// SetMemoryBudget.
+func (r *Recorder) MemoryUsage() memory.Usage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return memory.Usage{memory.CategoryRecorders: int64(cap(r.spikes)) * spikeBytes}
+}
+
+// Attach records every spike of src through an output callback registered
+// as REPLAY_RECORDER_CALLBACK_ID. The callback counts as an output connection.
+func (r *Recorder) Attach(src SpikeSource) {
+	id := src.ID()
+	src.AddOutputCallback(REPLAY_RECORDER_CALLBACK_ID, types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			r.Record(id, msg.Timestamp)
+			return nil
+		},
+		GetWeight:   func() float64 { return 0 },
+		GetDelay:    func() time.Duration { return 0 },
+		GetTargetID: func() string { return "" },
+	})
+
+	r.mu.Lock()
+	r.attached[id] = src
+	r.mu.Unlock()
+}
+
+// Detach stops recording all attached neurons.
+func (r *Recorder) Detach() {
+	r.mu.Lock()
+	attached := r.attached
+	r.attached = make(map[string]SpikeSource)
+	r.mu.Unlock()
+
+	for _, src := range attached {
+		src.RemoveOutputCallback(REPLAY_RECORDER_CALLBACK_ID)
+	}
+}
+
+// Len returns the number of stored spikes.
+func (r *Recorder) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.spikes)
+}
+
+// Dropped returns the number of spikes discarded because the recorder was full.
+func (r *Recorder) Dropped() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dropped
+}
+
+// Reset discards all stored spikes.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spikes = nil
+	r.dropped = 0
+	if r.budget != nil {
+		r.budget.Release(r.budgetID, memory.CategoryRecorders, r.reserved)
+		r.reserved = 0
+	}
+}
+
+// Capture returns the spikes in [from, to) as a pattern starting at from.
+func (r *Recorder) Capture(name string, from, to time.Time) Pattern {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	pattern := Pattern{Name: name, Duration: to.Sub(from)}
+	for _, s := range r.spikes {
+		if !s.at.Before(from) && s.at.Before(to) {
+			pattern.Events = append(pattern.Events, Event{NeuronID: s.neuronID, Offset: s.at.Sub(from)})
+		}
+	}
+	sort.SliceStable(pattern.Events, func(i, j int) bool { return pattern.Events[i].Offset < pattern.Events[j].Offset })
+	return pattern
+}
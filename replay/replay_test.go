@@ -0,0 +1,187 @@
+package replay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/memory"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+)
+
+// sequenceNetwork builds a three-neuron population on a virtual clock with a
+// recorder attached to every member.
+func sequenceNetwork(t *testing.T) (*cosim.LockStep, *batch.Population, *Recorder) {
+	clock, err := cosim.NewLockStep(time.Unix(0, 0), time.Millisecond)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pop, err := batch.NewPopulation("seq", batch.PopulationConfig{
+		Size: 3, Threshold: 1, DecayRate: 0, DelayScheduler: clock.Schedule,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	clock.AddPopulation(pop)
+
+	recorder := NewRecorder(0)
+	for i := 0; i < pop.Size(); i++ {
+		recorder.Attach(pop.Member(i))
+	}
+	return clock, pop, recorder
+}
+
+// TestRecordAndReplayCompressed verifies capture during wake and compressed,
+// order-preserving replay during sleep.
+func TestRecordAndReplayCompressed(t *testing.T) {
+	clock, pop, recorder := sequenceNetwork(t)
+	wakeStart := clock.Now()
+
+	// Wake: the sequence 0 -> 1 -> 2 at 100ms intervals
+	tick := 0
+	clock.AddStepper("wake", func(time.Time) {
+		if tick%100 == 0 && tick < 300 {
+			pop.Inject(tick/100, 1.5)
+		}
+		tick++
+	})
+	if err := clock.Step(400 * time.Millisecond); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+	wakeEnd := clock.Now()
+
+	pattern := recorder.Capture("sequence", wakeStart, wakeEnd)
+	if len(pattern.Events) != 3 || pattern.Duration != 400*time.Millisecond {
+		t.Fatalf("Expected a three-spike pattern, got %+v", pattern)
+	}
+	if gap := pattern.Events[2].Offset - pattern.Events[0].Offset; gap != 200*time.Millisecond {
+		t.Errorf("Expected 200ms between first and last spike, got %v", gap)
+	}
+
+	// Sleep: replay twice, ten times faster, with sleep plasticity
+	syn := synapse.NewBasicSynapse("s01", pop.Member(0), pop.Member(1), synapse.CreateDefaultSTDPConfig(),
+		synapse.CreateDefaultPruningConfig(), 0.5, time.Millisecond)
+	targets := make(map[string]component.MessageReceiver)
+	for i := 0; i < pop.Size(); i++ {
+		targets[pop.Member(i).ID()] = pop.Member(i)
+	}
+	session, err := NewSession(SessionConfig{
+		Targets:    targets,
+		Synapses:   []PlasticSynapse{syn},
+		Plasticity: SleepPlasticity(2, 1),
+		Scheduler:  clock.Schedule,
+		Now:        clock.Now,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := session.Replay(0, pattern); err == nil {
+		t.Error("Expected replay outside sleep to fail")
+	}
+	if err := session.Sleep(); err != nil {
+		t.Fatalf("Sleep failed: %v", err)
+	}
+	wakeRate := synapse.CreateDefaultSTDPConfig().LearningRate
+	if rate := syn.GetPlasticityConfig().LearningRate; rate != 2*wakeRate {
+		t.Errorf("Expected sleep learning rate %f, got %f", 2*wakeRate, rate)
+	}
+
+	sleepStart := clock.Now()
+	total, err := session.Replay(20*time.Millisecond, pattern, pattern)
+	if err != nil {
+		t.Fatalf("Replay failed: %v", err)
+	}
+	if total != 100*time.Millisecond {
+		t.Errorf("Expected two 40ms replays and a 20ms gap, got %v", total)
+	}
+	if err := clock.Step(total + 10*time.Millisecond); err != nil {
+		t.Fatalf("Step failed: %v", err)
+	}
+
+	replayed := recorder.Capture("replayed", sleepStart, clock.Now())
+	if len(replayed.Events) != 6 {
+		t.Fatalf("Expected six replayed spikes, got %+v", replayed.Events)
+	}
+	for i, event := range replayed.Events[:3] {
+		if event.NeuronID != pattern.Events[i].NeuronID {
+			t.Errorf("Replay %d: expected %s, got %s", i, pattern.Events[i].NeuronID, event.NeuronID)
+		}
+	}
+	if gap := replayed.Events[2].Offset - replayed.Events[0].Offset; gap != 20*time.Millisecond {
+		t.Errorf("Expected 20ms compressed sequence, got %v", gap)
+	}
+	if gap := replayed.Events[3].Offset - replayed.Events[0].Offset; gap != 60*time.Millisecond {
+		t.Errorf("Expected second replay 60ms after the first, got %v", gap)
+	}
+
+	if err := session.Wake(); err != nil {
+		t.Fatalf("Wake failed: %v", err)
+	}
+	if rate := syn.GetPlasticityConfig().LearningRate; rate != wakeRate {
+		t.Errorf("Expected restored learning rate %f, got %f", wakeRate, rate)
+	}
+	if stats := session.GetStats(); stats["replays"] != int64(2) || stats["spikes_sent"] != int64(6) {
+		t.Errorf("Unexpected stats: %v", stats)
+	}
+
+	recorder.Detach()
+	pop.Inject(0, 1.5)
+	clock.Step(time.Millisecond)
+	if recorder.Len() != 9 {
+		t.Errorf("Expected no recording after Detach, got %d spikes", recorder.Len())
+	}
+}
+
+// TestReplayRejectsUnknownNeurons verifies validation before scheduling.
+func TestReplayRejectsUnknownNeurons(t *testing.T) {
+	clock, pop, _ := sequenceNetwork(t)
+	session, err := NewSession(SessionConfig{
+		Targets:   map[string]component.MessageReceiver{pop.Member(0).ID(): pop.Member(0)},
+		Scheduler: clock.Schedule,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	session.Sleep()
+	pattern := Pattern{Name: "p", Duration: time.Second, Events: []Event{
+		{NeuronID: pop.Member(0).ID()}, {NeuronID: "elsewhere", Offset: time.Millisecond},
+	}}
+	if _, err := session.Replay(0, pattern); err == nil || clock.Pending() != 0 {
+		t.Errorf("Expected rejection without scheduling, got err=%v pending=%d", err, clock.Pending())
+	}
+	if _, err := NewSession(SessionConfig{}); err == nil {
+		t.Error("Expected error without targets")
+	}
+}
+
+// TestRecorderMemoryBudget verifies that a budgeted recorder stops growing
+// at the budget and drops its oldest spikes instead.
+func TestRecorderMemoryBudget(t *testing.T) {
+	budget, _ := memory.NewBudget(2 * REPLAY_MIN_GROWTH * spikeBytes)
+	rec := NewRecorder(0)
+	if err := rec.SetMemoryBudget(budget, "recorder"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	start := time.Unix(0, 0)
+	for i := 0; i < 5*REPLAY_MIN_GROWTH; i++ {
+		rec.Record("n1", start.Add(time.Duration(i)*time.Millisecond))
+	}
+	if rec.Len() > 2*REPLAY_MIN_GROWTH || rec.Dropped() == 0 {
+		t.Errorf("Expected the recorder to stay within the budget, holding %d spikes with %d dropped", rec.Len(), rec.Dropped())
+	}
+	if used := budget.Used(); used != 2*REPLAY_MIN_GROWTH*spikeBytes {
+		t.Errorf("Expected the whole budget reserved, got %d bytes", used)
+	}
+	last := rec.Capture("tail", start, start.Add(time.Hour))
+	if n := len(last.Events); n == 0 || last.Events[n-1].Offset != time.Duration(5*REPLAY_MIN_GROWTH-1)*time.Millisecond {
+		t.Errorf("Expected the newest spike to be kept, got %d spikes", n)
+	}
+
+	rec.Reset()
+	if budget.Used() != 0 {
+		t.Errorf("Expected reset to release the reservation, got %d bytes", budget.Used())
+	}
+}
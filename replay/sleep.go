@@ -0,0 +1,217 @@
+package replay
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// SLEEP SESSIONS
+// =================================================================================
+
+// PlasticSynapse is a synapse whose plasticity parameters change during sleep.
+// synapse.BasicSynapse implements it.
+type PlasticSynapse interface {
+	ID() string
+	GetPlasticityConfig() types.PlasticityConfig
+	SetPlasticityConfig(config types.PlasticityConfig) error
+}
+
+// SessionConfig describes an offline replay phase.
+type SessionConfig struct {
+	Targets     map[string]component.MessageReceiver // Neurons to reactivate, by ID
+	Synapses    []PlasticSynapse                     // Synapses switched to sleep plasticity
+	Compression float64                              // Time compression of replayed patterns (0 = REPLAY_DEFAULT_COMPRESSION)
+	Amplitude   float64                              // Input injected per replayed spike (0 = REPLAY_DEFAULT_AMPLITUDE)
+
+	// Plasticity maps each synapse's wake parameters to its sleep parameters
+	// (nil = unchanged). See SleepPlasticity.
+	Plasticity func(wake types.PlasticityConfig) types.PlasticityConfig
+
+	Scheduler batch.DelayScheduler // Delivers injections (nil = wall-clock timers); cosim.LockStep.Schedule fits
+	Now       func() time.Time     // Clock for message timestamps (nil = time.Now)
+}
+
+// SleepPlasticity scales the learning rate and the STDP time constants for
+// the sleep phase. A windowScale of 1 keeps the wake window, so compressed
+// replay brings pairings that were too far apart while awake into range.
+func SleepPlasticity(learningScale, windowScale float64) func(types.PlasticityConfig) types.PlasticityConfig {
+	return func(wake types.PlasticityConfig) types.PlasticityConfig {
+		sleep := wake
+		sleep.LearningRate *= learningScale
+		sleep.TimeConstant = time.Duration(float64(wake.TimeConstant) * windowScale)
+		sleep.WindowSize = time.Duration(float64(wake.WindowSize) * windowScale)
+		return sleep
+	}
+}
+
+// Session switches a network between wake and sleep and replays patterns.
+type Session struct {
+	config SessionConfig
+
+	mu       sync.Mutex
+	asleep   bool
+	saved    map[string]types.PlasticityConfig
+	replays  int64
+	injected int64
+}
+
+// NewSession validates the configuration and fills defaults.
+func NewSession(config SessionConfig) (*Session, error) {
+	if len(config.Targets) == 0 {
+		return nil, fmt.Errorf("replay session requires target neurons")
+	}
+	if config.Compression < 0 || config.Amplitude < 0 {
+		return nil, fmt.Errorf("compression and amplitude cannot be negative: %f, %f", config.Compression, config.Amplitude)
+	}
+	if config.Compression == 0 {
+		config.Compression = REPLAY_DEFAULT_COMPRESSION
+	}
+	if config.Amplitude == 0 {
+		config.Amplitude = REPLAY_DEFAULT_AMPLITUDE
+	}
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+	return &Session{config: config}, nil
+}
+
+// Sleep saves every synapse's wake parameters and applies the sleep
+// parameters. If a synapse rejects its sleep parameters, the ones already
+// switched are restored.
+func (s *Session) Sleep() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.asleep {
+		return fmt.Errorf("session is already asleep")
+	}
+
+	s.saved = make(map[string]types.PlasticityConfig, len(s.config.Synapses))
+	for _, syn := range s.config.Synapses {
+		wake := syn.GetPlasticityConfig()
+		s.saved[syn.ID()] = wake
+		if s.config.Plasticity == nil {
+			continue
+		}
+		if err := syn.SetPlasticityConfig(s.config.Plasticity(wake)); err != nil {
+			s.restoreUnsafe()
+			return fmt.Errorf("synapse %s rejected sleep plasticity: %w", syn.ID(), err)
+		}
+	}
+	s.asleep = true
+	return nil
+}
+
+// Wake restores the wake plasticity parameters. Replayed spikes still in
+// flight are delivered under wake parameters.
+func (s *Session) Wake() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.asleep {
+		return fmt.Errorf("session is not asleep")
+	}
+	s.asleep = false
+	return s.restoreUnsafe()
+}
+
+// restoreUnsafe puts back the saved wake parameters. Caller holds s.mu.
+func (s *Session) restoreUnsafe() error {
+	var firstErr error
+	for _, syn := range s.config.Synapses {
+		if wake, ok := s.saved[syn.ID()]; ok {
+			if err := syn.SetPlasticityConfig(wake); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("failed to restore synapse %s: %w", syn.ID(), err)
+			}
+		}
+	}
+	s.saved = nil
+	return firstErr
+}
+
+// IsAsleep reports whether the session is in the sleep phase.
+func (s *Session) IsAsleep() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.asleep
+}
+
+// Replay schedules the patterns back to back, each compressed by the
+// session's factor and separated by gap, and returns the total replay
+// duration. Each event injects Amplitude into its neuron. Every neuron must
+// be a session target; nothing is scheduled otherwise.
+func (s *Session) Replay(gap time.Duration, patterns ...Pattern) (time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.asleep {
+		return 0, fmt.Errorf("replay requires the sleep phase")
+	}
+	if gap < 0 {
+		return 0, fmt.Errorf("gap cannot be negative: %v", gap)
+	}
+
+	compressed := make([]Pattern, len(patterns))
+	for i, pattern := range patterns {
+		for _, id := range pattern.Neurons() {
+			if s.config.Targets[id] == nil {
+				return 0, fmt.Errorf("pattern %s: neuron %s is not a replay target", pattern.Name, id)
+			}
+		}
+		var err error
+		if compressed[i], err = pattern.Compressed(s.config.Compression); err != nil {
+			return 0, err
+		}
+	}
+
+	now := s.config.Now()
+	var start time.Duration
+	for i, pattern := range compressed {
+		if i > 0 {
+			start += gap
+		}
+		for _, event := range pattern.Events {
+			delay := start + event.Offset
+			msg := types.NeuralSignal{
+				Value:     s.config.Amplitude,
+				Timestamp: now.Add(delay),
+				SourceID:  REPLAY_SOURCE_ID,
+				TargetID:  event.NeuronID,
+			}
+			s.schedule(msg, s.config.Targets[event.NeuronID], delay)
+			s.injected++
+		}
+		start += pattern.Duration
+		s.replays++
+	}
+	return start, nil
+}
+
+// schedule delivers msg through the configured scheduler or a timer.
+func (s *Session) schedule(msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration) {
+	if s.config.Scheduler != nil {
+		s.config.Scheduler(msg, target, delay)
+		return
+	}
+	time.AfterFunc(delay, func() {
+		msg.Timestamp = time.Now()
+		target.Receive(msg)
+	})
+}
+
+// GetStats returns session counters for monitoring.
+func (s *Session) GetStats() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return map[string]interface{}{
+		"asleep":       s.asleep,
+		"replays":      s.replays,
+		"spikes_sent":  s.injected,
+		"compression":  s.config.Compression,
+		"synapses":     len(s.config.Synapses),
+		"target_count": len(s.config.Targets),
+	}
+}
@@ -0,0 +1,93 @@
+package retina
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// =================================================================================
+// CENTER-SURROUND FILTERING
+// =================================================================================
+//
+// Retinal ganglion cells compare the light in a small centre with the light
+// in a larger surround. A difference of Gaussians (DoG) models this receptive
+// field: G(σc) * L - w·G(σs) * L. With the surround weight w = 1 the
+// response to uniform light is zero, so only edges, spots and texture
+// respond. Computed on log luminance, the response depends on contrast
+// ratios and not on the absolute light level, like photoreceptor adaptation.
+
+// RETINA_KERNEL_RADIUS is the Gaussian kernel half-width in standard
+// deviations.
+const RETINA_KERNEL_RADIUS = 3
+
+// gaussianKernel returns a normalized 1-D Gaussian of standard deviation
+// sigma.
+func gaussianKernel(sigma float64) []float64 {
+	radius := int(math.Ceil(RETINA_KERNEL_RADIUS * sigma))
+	kernel := make([]float64, 2*radius+1)
+	var sum float64
+	for i := range kernel {
+		d := float64(i - radius)
+		kernel[i] = math.Exp(-d * d / (2 * sigma * sigma))
+		sum += kernel[i]
+	}
+	for i := range kernel {
+		kernel[i] /= sum
+	}
+	return kernel
+}
+
+// blur convolves a width × height image with kernel along both axes.
+// Borders are extended by repeating the edge pixels, so uniform images
+// stay uniform.
+func blur(src []float64, width, height int, kernel []float64) []float64 {
+	radius := len(kernel) / 2
+	tmp := make([]float64, len(src))
+	for y := 0; y < height; y++ {
+		row := src[y*width : (y+1)*width]
+		for x := 0; x < width; x++ {
+			var sum float64
+			for k, w := range kernel {
+				sum += w * row[clamp(x+k-radius, width)]
+			}
+			tmp[y*width+x] = sum
+		}
+	}
+	out := make([]float64, len(src))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var sum float64
+			for k, w := range kernel {
+				sum += w * tmp[clamp(y+k-radius, height)*width+x]
+			}
+			out[y*width+x] = sum
+		}
+	}
+	return out
+}
+
+// clamp limits i to [0, n).
+func clamp(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+// Luminance converts an image into row-major luminance in [0, 1].
+func Luminance(img image.Image) (pixels []float64, width, height int) {
+	bounds := img.Bounds()
+	width, height = bounds.Dx(), bounds.Dy()
+	pixels = make([]float64, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			gray := color.Gray16Model.Convert(img.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.Gray16)
+			pixels[y*width+x] = float64(gray.Y) / 0xffff
+		}
+	}
+	return pixels, width, height
+}
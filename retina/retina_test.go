@@ -0,0 +1,158 @@
+package retina
+
+import (
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/aer"
+)
+
+// scene returns a 32×32 frame with background level bg and a square of
+// level square over [12, 20)².
+func scene(bg, square float64) []float64 {
+	pixels := make([]float64, 32*32)
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			pixels[y*32+x] = bg
+			if x >= 12 && x < 20 && y >= 12 && y < 20 {
+				pixels[y*32+x] = square
+			}
+		}
+	}
+	return pixels
+}
+
+// TestEncoder_CenterSurroundChanges verifies that appearing edges produce
+// ON and OFF events on the right sides, while a static scene produces none
+// and a global illumination change next to none.
+func TestEncoder_CenterSurroundChanges(t *testing.T) {
+	encoder, err := NewEncoder(Config{Width: 32, Height: 32})
+	if err != nil {
+		t.Fatalf("Failed to create encoder: %v", err)
+	}
+	frame := 33 * time.Millisecond
+	if events, _ := encoder.Encode(Frame{Pixels: scene(0.1, 0.1)}); len(events) != 0 {
+		t.Fatalf("Expected the first frame to set the reference silently, got %d events", len(events))
+	}
+
+	// The square appears: ON-centre cells inside its edge, OFF-centre cells outside
+	events, err := encoder.Encode(Frame{Pixels: scene(0.1, 0.25), Time: frame})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var on, off int
+	for i, ev := range events {
+		inside := ev.X >= 12 && ev.X < 20 && ev.Y >= 12 && ev.Y < 20
+		if ev.Polarity {
+			on++
+			if !inside {
+				t.Errorf("ON event outside the square at (%d, %d)", ev.X, ev.Y)
+			}
+		} else {
+			off++
+			if inside {
+				t.Errorf("OFF event inside the square at (%d, %d)", ev.X, ev.Y)
+			}
+		}
+		if ev.Timestamp <= 0 || ev.Timestamp > frame.Microseconds() || (i > 0 && ev.Timestamp < events[i-1].Timestamp) {
+			t.Errorf("Event %d at %dµs is outside the frame interval or out of order", i, ev.Timestamp)
+		}
+	}
+	if on == 0 || off == 0 {
+		t.Fatalf("Expected ON and OFF events, got %d and %d", on, off)
+	}
+	if events[0].Timestamp >= events[len(events)-1].Timestamp {
+		t.Error("Expected events spread over the frame interval")
+	}
+
+	// Nothing changes, then the light doubles
+	if events, _ := encoder.Encode(Frame{Pixels: scene(0.1, 0.25), Time: 2 * frame}); len(events) != 0 {
+		t.Errorf("Expected no events for a static scene, got %d", len(events))
+	}
+	// Cells left just below threshold by the square may tip over
+	global, _ := encoder.Encode(Frame{Pixels: scene(0.2, 0.5), Time: 3 * frame})
+	if len(global)*10 > on+off {
+		t.Errorf("Expected few events for a global illumination change, got %d (square: %d)", len(global), on+off)
+	}
+	stats := encoder.GetStats()
+	if stats["frames"].(int64) != 4 || stats["on_events"].(int64)+stats["off_events"].(int64) != int64(on+off+len(global)) {
+		t.Errorf("Unexpected stats %v", stats)
+	}
+
+	if _, err := encoder.Encode(Frame{Pixels: scene(0.1, 0.25), Time: frame}); err == nil {
+		t.Error("Expected error for a frame older than the previous one")
+	}
+	if _, err := encoder.Encode(Frame{Pixels: make([]float64, 10), Time: 4 * frame}); err == nil {
+		t.Error("Expected error for a frame of the wrong size")
+	}
+	for _, config := range []Config{
+		{},
+		{Width: 32, Height: 32, CenterSigma: 3, SurroundSigma: 2},
+		{Width: 32, Height: 32, SurroundWeight: 1.5},
+	} {
+		if _, err := NewEncoder(config); err == nil {
+			t.Errorf("Expected config %+v to be rejected", config)
+		}
+	}
+}
+
+// TestReader_StreamsStridedEvents verifies the aer.EventReader adapter, the
+// ganglion grid and image conversion.
+func TestReader_StreamsStridedEvents(t *testing.T) {
+	encoder, _ := NewEncoder(Config{Width: 32, Height: 32, Stride: 2})
+	if w, h := encoder.GridSize(); w != 16 || h != 16 {
+		t.Fatalf("Expected a 16x16 grid, got %dx%d", w, h)
+	}
+
+	// A bright bar moving right one pixel per frame
+	next := 0
+	source := func() (Frame, error) {
+		if next == 10 {
+			return Frame{}, io.EOF
+		}
+		pixels := make([]float64, 32*32)
+		for y := 0; y < 32; y++ {
+			for x := 8 + next; x < 12+next; x++ {
+				pixels[y*32+x] = 0.8
+			}
+		}
+		next++
+		return Frame{Pixels: pixels, Time: time.Duration(next) * 20 * time.Millisecond}, nil
+	}
+	events, err := aer.ReadAll(NewReader(encoder, source))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(events) == 0 {
+		t.Fatal("Expected events from the moving bar")
+	}
+	meanX := func(from, to int64) float64 {
+		var sum, n float64
+		for _, ev := range events {
+			if ev.Timestamp >= from && ev.Timestamp < to && ev.Polarity {
+				sum += float64(ev.X)
+				n++
+			}
+		}
+		return sum / n
+	}
+	for _, ev := range events {
+		if ev.X >= 16 || ev.Y >= 16 {
+			t.Fatalf("Event outside the grid at (%d, %d)", ev.X, ev.Y)
+		}
+	}
+	if early, late := meanX(0, 80000), meanX(140000, 220000); !(late > early) {
+		t.Errorf("Expected ON events to follow the bar to the right: mean x %.1f then %.1f", early, late)
+	}
+
+	img := image.NewGray(image.Rect(0, 0, 2, 1))
+	img.SetGray(1, 0, color.Gray{Y: 255})
+	pixels, w, h := Luminance(img)
+	if w != 2 || h != 1 || pixels[0] != 0 || math.Abs(pixels[1]-1) > 1e-9 {
+		t.Errorf("Unexpected luminance %v (%dx%d)", pixels, w, h)
+	}
+}
@@ -0,0 +1,366 @@
+package ros2
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// SENSOR ENCODERS
+// =================================================================================
+
+// Encoder converts sensor values into input signals for neurons.
+type Encoder interface {
+	// Encode delivers values (one sample of a sensor message) to neurons.
+	Encode(values []float64, sourceID string)
+}
+
+// RateEncoder maps each value linearly onto the input current of one neuron:
+// value Min → 0, value Max → Gain. Values outside the range are clamped.
+// Stronger stimuli therefore drive higher firing rates.
+type RateEncoder struct {
+	Targets []component.MessageReceiver // One neuron per value index
+	Min     float64
+	Max     float64
+	Gain    float64
+	Invert  bool // Map Min → Gain (e.g. proximity from range sensors)
+}
+
+// Encode implements Encoder.
+func (e *RateEncoder) Encode(values []float64, sourceID string) {
+	now := time.Now()
+	for i, v := range values {
+		if i >= len(e.Targets) {
+			return
+		}
+		level := normalise(v, e.Min, e.Max)
+		if e.Invert {
+			level = 1 - level
+		}
+		if level <= 0 {
+			continue
+		}
+		e.Targets[i].Receive(types.NeuralSignal{
+			Value:     level * e.Gain,
+			Timestamp: now,
+			SourceID:  sourceID,
+			TargetID:  e.Targets[i].ID(),
+		})
+	}
+}
+
+// PopulationEncoder encodes a single scalar (the first value) with Gaussian
+// tuning curves evenly spaced over [Min, Max], as in motor and head-direction
+// populations: each neuron responds most strongly near its preferred value.
+type PopulationEncoder struct {
+	Targets []component.MessageReceiver
+	Min     float64
+	Max     float64
+	Width   float64 // Tuning curve standard deviation (0 = spacing between centres)
+	Gain    float64
+}
+
+// Encode implements Encoder.
+func (e *PopulationEncoder) Encode(values []float64, sourceID string) {
+	if len(values) == 0 || len(e.Targets) == 0 {
+		return
+	}
+	now := time.Now()
+	for i, target := range e.Targets {
+		center := e.center(i)
+		width := e.Width
+		if width <= 0 {
+			width = e.spacing()
+		}
+		d := values[0] - center
+		activation := e.Gain * math.Exp(-d*d/(2*width*width))
+		if activation < 1e-3*e.Gain {
+			continue
+		}
+		target.Receive(types.NeuralSignal{
+			Value:     activation,
+			Timestamp: now,
+			SourceID:  sourceID,
+			TargetID:  target.ID(),
+		})
+	}
+}
+
+// center returns the preferred value of neuron i.
+func (e *PopulationEncoder) center(i int) float64 {
+	if len(e.Targets) == 1 {
+		return (e.Min + e.Max) / 2
+	}
+	return e.Min + float64(i)*e.spacing()
+}
+
+// spacing returns the distance between adjacent preferred values.
+func (e *PopulationEncoder) spacing() float64 {
+	if len(e.Targets) <= 1 {
+		return e.Max - e.Min
+	}
+	return (e.Max - e.Min) / float64(len(e.Targets)-1)
+}
+
+// normalise maps v from [lo, hi] to [0, 1].
+func normalise(v, lo, hi float64) float64 {
+	if hi <= lo || math.IsNaN(v) {
+		return 0
+	}
+	return math.Max(0, math.Min(1, (v-lo)/(hi-lo)))
+}
+
+// =================================================================================
+// MOTOR DECODERS
+// =================================================================================
+
+// RateDecoder converts the firing rates of output neurons into a command value.
+// Each output neuron contributes its rate (Hz) over the sliding window times
+// its weight; the sum is scaled by Gain and clamped to [Min, Max]. A push-pull
+// pair (weights +1 and -1) yields a signed command such as angular velocity.
+type RateDecoder struct {
+	Window time.Duration
+	Gain   float64
+	Min    float64
+	Max    float64
+
+	weights map[string]float64
+	spikes  map[string][]time.Time
+	mu      sync.Mutex
+}
+
+// NewRateDecoder creates a decoder over the given sliding window.
+func NewRateDecoder(window time.Duration, gain, min, max float64) (*RateDecoder, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("decoder window must be positive: %v", window)
+	}
+	if min > max {
+		return nil, fmt.Errorf("decoder min %f exceeds max %f", min, max)
+	}
+	return &RateDecoder{
+		Window:  window,
+		Gain:    gain,
+		Min:     min,
+		Max:     max,
+		weights: make(map[string]float64),
+		spikes:  make(map[string][]time.Time),
+	}, nil
+}
+
+// AddOutput registers an output neuron with its decoding weight.
+func (d *RateDecoder) AddOutput(neuronID string, weight float64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.weights[neuronID] = weight
+}
+
+// RecordSpike notes that an output neuron fired.
+func (d *RateDecoder) RecordSpike(neuronID string, at time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.weights[neuronID]; !ok {
+		return
+	}
+	d.spikes[neuronID] = append(d.spikes[neuronID], at)
+}
+
+// OutputCallback returns a callback that records spikes of neuronID. Attach
+// it to the neuron with AddOutputCallback so firing drives the decoder.
+func (d *RateDecoder) OutputCallback(neuronID string) types.OutputCallback {
+	return types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			at := msg.Timestamp
+			if at.IsZero() {
+				at = time.Now()
+			}
+			d.RecordSpike(neuronID, at)
+			return nil
+		},
+		GetWeight:   func() float64 { return 1.0 },
+		GetDelay:    func() time.Duration { return 0 },
+		GetTargetID: func() string { return "ros2_decoder" },
+	}
+}
+
+// Value returns the decoded command at time now.
+func (d *RateDecoder) Value(now time.Time) float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sum := 0.0
+	for id, times := range d.spikes {
+		d.spikes[id] = inWindow(times, now, d.Window)
+		rate := float64(len(d.spikes[id])) / d.Window.Seconds()
+		sum += rate * d.weights[id]
+	}
+	return math.Max(d.Min, math.Min(d.Max, sum*d.Gain))
+}
+
+// inWindow drops the spike times outside (now-window, now], reusing the
+// slice.
+func inWindow(times []time.Time, now time.Time, window time.Duration) []time.Time {
+	cutoff := now.Add(-window)
+	kept := times[:0]
+	for _, t := range times {
+		if t.After(cutoff) && !t.After(now) {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
+
+// PopulationVectorDecoder decodes a direction from an output population, as
+// motor cortex is read out in reaching studies: each neuron has a preferred
+// direction, and the command is the average of the preferred directions
+// weighted by the neurons' firing rates over the sliding window. Rates at or
+// below Baseline do not vote. Because every active neuron contributes, the
+// result moves continuously as activity shifts across the population rather
+// than jumping between the directions of individual neurons.
+//
+// With unit preferred directions the length of the average lies in [0, 1]
+// and measures agreement: 1 when only neurons with one preferred direction
+// fire, near 0 when activity is spread evenly. The average is scaled by
+// Gain and, if Tau > 0, low-pass filtered with time constant Tau between
+// successive calls to Vector, so the command decays smoothly to zero when
+// the population falls silent.
+type PopulationVectorDecoder struct {
+	Window   time.Duration
+	Tau      time.Duration // Smoothing time constant (0 = none)
+	Gain     float64
+	Baseline float64 // Rate (Hz) subtracted before weighting
+
+	dims      int
+	preferred map[string][]float64
+	spikes    map[string][]time.Time
+	smoothed  []float64
+	last      time.Time
+	mu        sync.Mutex
+}
+
+// NewPopulationVectorDecoder creates a decoder of dims-dimensional vectors
+// over the given sliding window.
+func NewPopulationVectorDecoder(dims int, window, tau time.Duration, gain float64) (*PopulationVectorDecoder, error) {
+	if dims <= 0 {
+		return nil, fmt.Errorf("decoder dimensions must be positive: %d", dims)
+	}
+	if window <= 0 {
+		return nil, fmt.Errorf("decoder window must be positive: %v", window)
+	}
+	if tau < 0 {
+		return nil, fmt.Errorf("decoder smoothing must not be negative: %v", tau)
+	}
+	return &PopulationVectorDecoder{
+		Window:    window,
+		Tau:       tau,
+		Gain:      gain,
+		dims:      dims,
+		preferred: make(map[string][]float64),
+		spikes:    make(map[string][]time.Time),
+		smoothed:  make([]float64, dims),
+	}, nil
+}
+
+// CircularDirections returns n unit vectors evenly spaced around the circle,
+// starting along +x, as preferred directions for a planar population.
+func CircularDirections(n int) [][]float64 {
+	directions := make([][]float64, n)
+	for i := range directions {
+		angle := 2 * math.Pi * float64(i) / float64(n)
+		directions[i] = []float64{math.Cos(angle), math.Sin(angle)}
+	}
+	return directions
+}
+
+// AddOutput registers an output neuron with its preferred direction.
+func (d *PopulationVectorDecoder) AddOutput(neuronID string, preferred []float64) error {
+	if len(preferred) != d.dims {
+		return fmt.Errorf("preferred direction of %s has %d dimensions, decoder has %d", neuronID, len(preferred), d.dims)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.preferred[neuronID] = append([]float64(nil), preferred...)
+	return nil
+}
+
+// RecordSpike notes that an output neuron fired.
+func (d *PopulationVectorDecoder) RecordSpike(neuronID string, at time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.preferred[neuronID]; !ok {
+		return
+	}
+	d.spikes[neuronID] = append(d.spikes[neuronID], at)
+}
+
+// OutputCallback returns a callback that records spikes of neuronID. Attach
+// it to the neuron with AddOutputCallback so firing drives the decoder.
+func (d *PopulationVectorDecoder) OutputCallback(neuronID string) types.OutputCallback {
+	return types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			at := msg.Timestamp
+			if at.IsZero() {
+				at = time.Now()
+			}
+			d.RecordSpike(neuronID, at)
+			return nil
+		},
+		GetWeight:   func() float64 { return 1.0 },
+		GetDelay:    func() time.Duration { return 0 },
+		GetTargetID: func() string { return "ros2_decoder" },
+	}
+}
+
+// Vector returns the decoded command at time now. Calls should move
+// forward in time; an earlier now returns the last command unchanged.
+func (d *PopulationVectorDecoder) Vector(now time.Time) []float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if !d.last.IsZero() && now.Before(d.last) {
+		return append([]float64(nil), d.smoothed...)
+	}
+
+	average := make([]float64, d.dims)
+	total := 0.0
+	for id, times := range d.spikes {
+		d.spikes[id] = inWindow(times, now, d.Window)
+		vote := float64(len(d.spikes[id]))/d.Window.Seconds() - d.Baseline
+		if vote <= 0 {
+			continue
+		}
+		for i, c := range d.preferred[id] {
+			average[i] += vote * c
+		}
+		total += vote
+	}
+	for i := range average {
+		if total > 0 {
+			average[i] *= d.Gain / total
+		}
+	}
+
+	alpha := 1.0
+	if d.Tau > 0 && !d.last.IsZero() {
+		alpha = 1 - math.Exp(-now.Sub(d.last).Seconds()/d.Tau.Seconds())
+	}
+	for i := range d.smoothed {
+		d.smoothed[i] += alpha * (average[i] - d.smoothed[i])
+	}
+	d.last = now
+	return append([]float64(nil), d.smoothed...)
+}
+
+// Angle returns the direction of the first two components of the decoded
+// command in radians, in (-π, π].
+func (d *PopulationVectorDecoder) Angle(now time.Time) float64 {
+	v := d.Vector(now)
+	if len(v) < 2 {
+		return 0
+	}
+	return math.Atan2(v[1], v[0])
+}
@@ -0,0 +1,95 @@
+package ros2
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// =================================================================================
+// ROS2 MESSAGE TYPES AND FIELD EXTRACTORS
+// =================================================================================
+
+// Common ROS2 message type names
+const (
+	TypeLaserScan  = "sensor_msgs/msg/LaserScan"
+	TypeJointState = "sensor_msgs/msg/JointState"
+	TypeImu        = "sensor_msgs/msg/Imu"
+	TypeRange      = "sensor_msgs/msg/Range"
+	TypeFloat64    = "std_msgs/msg/Float64"
+	TypeTwist      = "geometry_msgs/msg/Twist"
+)
+
+// Vector3 mirrors geometry_msgs/msg/Vector3.
+type Vector3 struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// Twist mirrors geometry_msgs/msg/Twist, the standard velocity command.
+type Twist struct {
+	Linear  Vector3 `json:"linear"`
+	Angular Vector3 `json:"angular"`
+}
+
+// Float64 mirrors std_msgs/msg/Float64.
+type Float64 struct {
+	Data float64 `json:"data"`
+}
+
+// Extractor pulls the numeric values to encode out of a message payload.
+type Extractor func(msg json.RawMessage) ([]float64, error)
+
+// LaserScanRanges extracts sensor_msgs/LaserScan ranges.
+func LaserScanRanges(msg json.RawMessage) ([]float64, error) {
+	var scan struct {
+		Ranges []float64 `json:"ranges"`
+	}
+	if err := json.Unmarshal(msg, &scan); err != nil {
+		return nil, fmt.Errorf("decoding LaserScan: %w", err)
+	}
+	return scan.Ranges, nil
+}
+
+// JointStatePositions extracts sensor_msgs/JointState positions.
+func JointStatePositions(msg json.RawMessage) ([]float64, error) {
+	var state struct {
+		Position []float64 `json:"position"`
+	}
+	if err := json.Unmarshal(msg, &state); err != nil {
+		return nil, fmt.Errorf("decoding JointState: %w", err)
+	}
+	return state.Position, nil
+}
+
+// ImuAngularVelocity extracts sensor_msgs/Imu angular velocity as [x, y, z].
+func ImuAngularVelocity(msg json.RawMessage) ([]float64, error) {
+	var imu struct {
+		AngularVelocity Vector3 `json:"angular_velocity"`
+	}
+	if err := json.Unmarshal(msg, &imu); err != nil {
+		return nil, fmt.Errorf("decoding Imu: %w", err)
+	}
+	v := imu.AngularVelocity
+	return []float64{v.X, v.Y, v.Z}, nil
+}
+
+// RangeValue extracts sensor_msgs/Range range.
+func RangeValue(msg json.RawMessage) ([]float64, error) {
+	var r struct {
+		Range float64 `json:"range"`
+	}
+	if err := json.Unmarshal(msg, &r); err != nil {
+		return nil, fmt.Errorf("decoding Range: %w", err)
+	}
+	return []float64{r.Range}, nil
+}
+
+// Float64Data extracts std_msgs/Float64 data.
+func Float64Data(msg json.RawMessage) ([]float64, error) {
+	var f Float64
+	if err := json.Unmarshal(msg, &f); err != nil {
+		return nil, fmt.Errorf("decoding Float64: %w", err)
+	}
+	return []float64{f.Data}, nil
+}
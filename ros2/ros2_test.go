@@ -0,0 +1,245 @@
+package ros2
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// recordingNeuron captures received input signals.
+type recordingNeuron struct {
+	*component.BaseComponent
+	mu       sync.Mutex
+	received []types.NeuralSignal
+}
+
+func newRecordingNeuron(id string) *recordingNeuron {
+	return &recordingNeuron{BaseComponent: component.NewBaseComponent(id, types.TypeNeuron, types.Position3D{})}
+}
+
+func (r *recordingNeuron) Receive(msg types.NeuralSignal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.received = append(r.received, msg)
+}
+
+func (r *recordingNeuron) values() []float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]float64, len(r.received))
+	for i, msg := range r.received {
+		out[i] = msg.Value
+	}
+	return out
+}
+
+// TestEncoders verifies linear rate coding and Gaussian population coding.
+func TestEncoders(t *testing.T) {
+	near, far := newRecordingNeuron("near"), newRecordingNeuron("far")
+	rate := &RateEncoder{
+		Targets: []component.MessageReceiver{near, far},
+		Min:     0, Max: 10, Gain: 2.0, Invert: true,
+	}
+	rate.Encode([]float64{1, 10}, "scan")
+	if v := near.values(); len(v) != 1 || math.Abs(v[0]-1.8) > 1e-9 {
+		t.Errorf("Expected inverted rate input 1.8 for close obstacle, got %v", v)
+	}
+	if v := far.values(); len(v) != 0 {
+		t.Errorf("Expected no input at maximum range, got %v", v)
+	}
+
+	pop := make([]*recordingNeuron, 5)
+	targets := make([]component.MessageReceiver, 5)
+	for i := range pop {
+		pop[i] = newRecordingNeuron(string(rune('a' + i)))
+		targets[i] = pop[i]
+	}
+	encoder := &PopulationEncoder{Targets: targets, Min: -1, Max: 1, Gain: 1.0}
+	encoder.Encode([]float64{0.5}, "joint") // Preferred value of neuron 3
+
+	peak := pop[3].values()
+	if len(peak) != 1 || math.Abs(peak[0]-1.0) > 1e-9 {
+		t.Fatalf("Expected full activation at preferred value, got %v", peak)
+	}
+	if side := pop[2].values(); len(side) != 1 || side[0] >= peak[0] {
+		t.Errorf("Expected weaker activation for neighbour, got %v", side)
+	}
+}
+
+// TestRateDecoderPushPull verifies signed decoding over a sliding window.
+func TestRateDecoderPushPull(t *testing.T) {
+	decoder, err := NewRateDecoder(100*time.Millisecond, 0.01, -1, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	decoder.AddOutput("left", 1)
+	decoder.AddOutput("right", -1)
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		decoder.RecordSpike("left", now.Add(-time.Duration(i)*10*time.Millisecond))
+	}
+	callback := decoder.OutputCallback("right")
+	callback.TransmitMessage(types.NeuralSignal{Value: 1, Timestamp: now})
+	decoder.RecordSpike("unregistered", now)
+
+	// left 50Hz, right 10Hz → (50-10)*0.01 = 0.4
+	if v := decoder.Value(now); math.Abs(v-0.4) > 1e-9 {
+		t.Errorf("Expected decoded value 0.4, got %f", v)
+	}
+	// All spikes leave the window
+	if v := decoder.Value(now.Add(time.Second)); v != 0 {
+		t.Errorf("Expected 0 after window, got %f", v)
+	}
+
+	if _, err := NewRateDecoder(0, 1, 0, 1); err == nil {
+		t.Error("Expected error for zero window")
+	}
+}
+
+// TestPopulationVectorDecoder verifies that rate-weighted preferred
+// directions interpolate between neurons and that smoothing decays the
+// command when the population falls silent.
+func TestPopulationVectorDecoder(t *testing.T) {
+	decoder, err := NewPopulationVectorDecoder(2, 100*time.Millisecond, 0, 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ids := []string{"east", "north", "west", "south"}
+	for i, direction := range CircularDirections(len(ids)) {
+		if err := decoder.AddOutput(ids[i], direction); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := decoder.AddOutput("bad", []float64{1}); err == nil {
+		t.Error("Expected error for preferred direction of wrong dimension")
+	}
+
+	// east 30Hz, north 30Hz → average (0.5, 0.5), angle π/4
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		decoder.RecordSpike("east", now.Add(-time.Duration(i)*10*time.Millisecond))
+		decoder.RecordSpike("north", now.Add(-time.Duration(i)*10*time.Millisecond))
+	}
+	v := decoder.Vector(now)
+	if math.Abs(v[0]-0.5) > 1e-9 || math.Abs(v[1]-0.5) > 1e-9 {
+		t.Errorf("Expected vector (0.5, 0.5), got %v", v)
+	}
+	if angle := decoder.Angle(now); math.Abs(angle-math.Pi/4) > 1e-9 {
+		t.Errorf("Expected angle π/4, got %f", angle)
+	}
+
+	// Shifting activity towards north turns the vector continuously
+	decoder.RecordSpike("north", now.Add(time.Millisecond))
+	if angle := decoder.Angle(now.Add(time.Millisecond)); angle <= math.Pi/4 || angle >= math.Pi/2 {
+		t.Errorf("Expected angle between π/4 and π/2, got %f", angle)
+	}
+
+	// With smoothing the command decays instead of dropping to zero
+	smooth, _ := NewPopulationVectorDecoder(2, 50*time.Millisecond, 100*time.Millisecond, 1)
+	smooth.AddOutput("east", []float64{1, 0})
+	smooth.RecordSpike("east", now)
+	if v := smooth.Vector(now); v[0] != 1 {
+		t.Fatalf("Expected first command to equal the population vector, got %v", v)
+	}
+	later := smooth.Vector(now.Add(100 * time.Millisecond))
+	if math.Abs(later[0]-math.Exp(-1)) > 1e-9 {
+		t.Errorf("Expected command e^-1 one time constant after silence, got %v", later)
+	}
+
+	if _, err := NewPopulationVectorDecoder(0, time.Second, 0, 1); err == nil {
+		t.Error("Expected error for zero dimensions")
+	}
+}
+
+// TestBridgeSensorToMotorLoop runs the bridge against an in-process rosbridge
+// peer: a LaserScan reaches the input neurons and a Twist is published.
+func TestBridgeSensorToMotorLoop(t *testing.T) {
+	client, server := NewPipe()
+	bridge := NewBridge(client)
+
+	left, right := newRecordingNeuron("left"), newRecordingNeuron("right")
+	encoder := &RateEncoder{Targets: []component.MessageReceiver{left, right}, Min: 0, Max: 4, Gain: 1}
+	if err := bridge.SubscribeSensor("/scan", TypeLaserScan, LaserScanRanges, encoder); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	turn, _ := NewRateDecoder(time.Second, 0.1, -1, 1)
+	turn.AddOutput("motor", 1)
+	turn.RecordSpike("motor", time.Now())
+	err := bridge.PublishCommand("/cmd_vel", TypeTwist, 5*time.Millisecond, func(now time.Time) interface{} {
+		return Twist{Angular: Vector3{Z: turn.Value(now)}}
+	})
+	if err != nil {
+		t.Fatalf("PublishCommand failed: %v", err)
+	}
+
+	// The peer sees the subscribe and advertise operations first
+	for _, op := range []string{"subscribe", "advertise"} {
+		data, _ := server.ReadMessage()
+		var msg rosbridgeMessage
+		json.Unmarshal(data, &msg)
+		if msg.Op != op {
+			t.Fatalf("Expected %s op, got %s", op, msg.Op)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- bridge.Run(ctx) }()
+
+	server.WriteMessage([]byte(`{"op":"publish","topic":"/scan","msg":{"ranges":[2.0,4.0]}}`))
+	server.WriteMessage([]byte(`{"op":"publish","topic":"/odom","msg":{}}`))
+	server.WriteMessage([]byte(`not json`))
+	for start := time.Now(); bridge.GetStats()["decode_errors"].(int64) == 0; {
+		if time.Since(start) > 2*time.Second {
+			t.Fatal("Timed out waiting for incoming messages to be handled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var twist Twist
+	deadline := time.After(2 * time.Second)
+	for {
+		readCh := make(chan []byte, 1)
+		go func() {
+			data, _ := server.ReadMessage()
+			readCh <- data
+		}()
+		select {
+		case data := <-readCh:
+			var msg rosbridgeMessage
+			json.Unmarshal(data, &msg)
+			if msg.Op == "publish" && msg.Topic == "/cmd_vel" {
+				json.Unmarshal(msg.Msg, &twist)
+			}
+		case <-deadline:
+			t.Fatal("Timed out waiting for command")
+		}
+		if twist.Angular.Z != 0 {
+			break
+		}
+	}
+	if math.Abs(twist.Angular.Z-0.1) > 1e-9 {
+		t.Errorf("Expected angular.z 0.1, got %f", twist.Angular.Z)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run returned error after cancel: %v", err)
+	}
+
+	if v := left.values(); len(v) != 1 || math.Abs(v[0]-0.5) > 1e-9 {
+		t.Errorf("Expected scan input 0.5 on left neuron, got %v", v)
+	}
+	stats := bridge.GetStats()
+	if stats["encoded"].(int64) != 1 || stats["unknown_topics"].(int64) != 1 || stats["decode_errors"].(int64) != 1 {
+		t.Errorf("Unexpected bridge stats: %v", stats)
+	}
+}
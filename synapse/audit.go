@@ -0,0 +1,313 @@
+package synapse
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// PLASTICITY AUDIT LOG
+// =================================================================================
+
+// Weights are the end product of many small updates from several rules, and
+// the final value alone does not say why a synapse strengthened. The audit
+// log records every weight change of a synapse together with its cause:
+//
+//   - the rule (STDP, BTSP, neuromodulation, or a direct SetWeight);
+//   - the triggering spike pair where the rule has one: pre and post spike
+//     times and Δt = t_pre - t_post (for BTSP, the last pre-synaptic spike
+//     and the plateau);
+//   - for neuromodulation, the ligand and concentration;
+//   - the weight before and after.
+//
+// A sink assigns each record a sequence number, and each record links to the
+// previous change of the same synapse (Previous), so the history of one
+// synapse can be walked back from any record. AuditLog keeps the most recent
+// records in memory; AuditWriter streams JSON lines to a file for post-hoc
+// analysis (ReadAuditRecords loads them again). One sink is normally shared
+// by all synapses of a network.
+//
+// Auditing is opt-in: without a sink the learning paths pay a single atomic
+// load. Records are delivered after the synapse lock is released.
+
+// AuditRule names the rule behind a weight change.
+type AuditRule string
+
+const (
+	AuditRuleSTDP            AuditRule = "stdp"            // Spike-timing dependent plasticity
+	AuditRuleBTSP            AuditRule = "btsp"            // Behavioral-timescale plasticity
+	AuditRuleInhibitorySTDP  AuditRule = "istdp"           // Inhibitory STDP toward a rate setpoint
+	AuditRuleNeuromodulation AuditRule = "neuromodulation" // Eligibility × neuromodulator (three-factor)
+	AuditRuleSetWeight       AuditRule = "set_weight"      // Direct assignment
+)
+
+// PlasticityRecord describes one weight change and what caused it.
+type PlasticityRecord struct {
+	Seq           uint64           `json:"seq"`                     // Position in the sink (from 1)
+	Previous      uint64           `json:"previous,omitempty"`      // Seq of the synapse's previous change (0 = none)
+	Time          time.Time        `json:"time"`                    // When the change took effect (rule time on virtual clocks)
+	SynapseID     string           `json:"synapse_id"`              // Changed synapse
+	PreID         string           `json:"pre_id"`                  // Pre-synaptic neuron
+	PostID        string           `json:"post_id"`                 // Post-synaptic neuron
+	Rule          AuditRule        `json:"rule"`                    // Rule that changed the weight
+	PreSpike      time.Time        `json:"pre_spike"`               // Triggering pre-synaptic spike (zero = none)
+	PostSpike     time.Time        `json:"post_spike"`              // Triggering post-synaptic spike or plateau (zero = none)
+	DeltaT        time.Duration    `json:"delta_t,omitempty"`       // PreSpike - PostSpike
+	Modulator     types.LigandType `json:"modulator,omitempty"`     // Neuromodulator (neuromodulation rule)
+	Concentration float64          `json:"concentration,omitempty"` // Neuromodulator concentration
+	OldWeight     float64          `json:"old_weight"`
+	NewWeight     float64          `json:"new_weight"`
+}
+
+// Change returns NewWeight - OldWeight.
+func (r PlasticityRecord) Change() float64 {
+	return r.NewWeight - r.OldWeight
+}
+
+// AuditSink receives plasticity records. Append assigns and returns the
+// record's sequence number. Implementations must be safe for concurrent use.
+type AuditSink interface {
+	Append(record PlasticityRecord) uint64
+}
+
+// auditTarget holds a synapse's sink and the link to its last record.
+type auditTarget struct {
+	sink     AuditSink
+	mu       sync.Mutex
+	previous uint64
+}
+
+// SetAuditSink starts recording weight changes to sink (nil stops).
+func (s *BasicSynapse) SetAuditSink(sink AuditSink) {
+	if sink == nil {
+		s.audit.Store(nil)
+		return
+	}
+	s.audit.Store(&auditTarget{sink: sink})
+}
+
+// GetAuditSink returns the installed sink (nil when auditing is off).
+func (s *BasicSynapse) GetAuditSink() AuditSink {
+	if target := s.audit.Load(); target != nil {
+		return target.sink
+	}
+	return nil
+}
+
+// AuditSinkUser is implemented by synapses that can record their weight
+// changes.
+type AuditSinkUser interface {
+	SetAuditSink(sink AuditSink)
+}
+
+// SetAuditSinkOn installs sink on every synapse accepted by selector (nil
+// selects all). Synapses without auditing support are skipped. Returns the
+// number configured.
+func SetAuditSinkOn(synapses []component.SynapticProcessor, selector func(component.SynapticProcessor) bool,
+	sink AuditSink) int {
+
+	configured := 0
+	for _, syn := range synapses {
+		if selector != nil && !selector(syn) {
+			continue
+		}
+		if user, ok := syn.(AuditSinkUser); ok {
+			user.SetAuditSink(sink)
+			configured++
+		}
+	}
+	return configured
+}
+
+// auditing reports whether a sink is installed.
+func (s *BasicSynapse) auditing() bool {
+	return s.audit.Load() != nil
+}
+
+// newPlasticityRecord starts a record for a weight change. Identity fields
+// are filled in by auditChange, so it is safe under the synapse mutex.
+func newPlasticityRecord(rule AuditRule, at time.Time, oldWeight, newWeight float64) *PlasticityRecord {
+	return &PlasticityRecord{Time: at, Rule: rule, OldWeight: oldWeight, NewWeight: newWeight}
+}
+
+// withSpikes sets the triggering spike pair.
+func (r *PlasticityRecord) withSpikes(pre, post time.Time) *PlasticityRecord {
+	r.PreSpike, r.PostSpike = pre, post
+	if !pre.IsZero() && !post.IsZero() {
+		r.DeltaT = pre.Sub(post)
+	}
+	return r
+}
+
+// withModulator sets the neuromodulator behind the change.
+func (r *PlasticityRecord) withModulator(ligand types.LigandType, concentration float64) *PlasticityRecord {
+	r.Modulator, r.Concentration = ligand, concentration
+	return r
+}
+
+// auditChange sends a record to the sink, linking it to the synapse's
+// previous record. Must be called without the synapse mutex held.
+func (s *BasicSynapse) auditChange(record *PlasticityRecord) {
+	target := s.audit.Load()
+	if target == nil || record == nil {
+		return
+	}
+	record.SynapseID = s.id
+	record.PreID = s.GetPresynapticID()
+	record.PostID = s.GetPostsynapticID()
+
+	target.mu.Lock()
+	defer target.mu.Unlock()
+	record.Previous = target.previous
+	target.previous = target.sink.Append(*record)
+}
+
+// =================================================================================
+// SINKS
+// =================================================================================
+
+// AuditLog keeps the most recent records in a bounded ring.
+type AuditLog struct {
+	mu      sync.Mutex
+	records []PlasticityRecord
+	next    int
+	filled  bool
+	seq     uint64
+}
+
+// NewAuditLog creates a ring of capacity records (0 = AUDIT_DEFAULT_CAPACITY).
+func NewAuditLog(capacity int) *AuditLog {
+	if capacity <= 0 {
+		capacity = AUDIT_DEFAULT_CAPACITY
+	}
+	return &AuditLog{records: make([]PlasticityRecord, capacity)}
+}
+
+// Append implements AuditSink, overwriting the oldest record when full.
+func (l *AuditLog) Append(record PlasticityRecord) uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.seq++
+	record.Seq = l.seq
+	l.records[l.next] = record
+	l.next = (l.next + 1) % len(l.records)
+	if l.next == 0 {
+		l.filled = true
+	}
+	return record.Seq
+}
+
+// Records returns the retained records, oldest first.
+func (l *AuditLog) Records() []PlasticityRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.filled {
+		return append([]PlasticityRecord(nil), l.records[:l.next]...)
+	}
+	return append(append([]PlasticityRecord(nil), l.records[l.next:]...), l.records[:l.next]...)
+}
+
+// Synapse returns the retained records of one synapse, oldest first.
+func (l *AuditLog) Synapse(synapseID string) []PlasticityRecord {
+	var records []PlasticityRecord
+	for _, record := range l.Records() {
+		if record.SynapseID == synapseID {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// Lookup returns the record with sequence number seq if it is retained.
+func (l *AuditLog) Lookup(seq uint64) (PlasticityRecord, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	retained := uint64(l.next)
+	if l.filled {
+		retained = uint64(len(l.records))
+	}
+	if seq == 0 || seq > l.seq || l.seq-seq >= retained {
+		return PlasticityRecord{}, false
+	}
+	index := (l.next - int(l.seq-seq) - 1 + len(l.records)) % len(l.records)
+	return l.records[index], true
+}
+
+// History follows the Previous links back from seq and returns the chain of
+// changes to that synapse, most recent first, as far as it is retained.
+func (l *AuditLog) History(seq uint64) []PlasticityRecord {
+	var chain []PlasticityRecord
+	for seq != 0 {
+		record, ok := l.Lookup(seq)
+		if !ok {
+			break
+		}
+		chain = append(chain, record)
+		seq = record.Previous
+	}
+	return chain
+}
+
+// Total returns the number of records appended, including overwritten ones.
+func (l *AuditLog) Total() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.seq
+}
+
+// AuditWriter streams records as JSON lines, e.g. to a file.
+type AuditWriter struct {
+	mu  sync.Mutex
+	w   io.Writer
+	enc *json.Encoder
+	seq uint64
+	err error
+}
+
+// NewAuditWriter writes records to w. The caller owns w and closes it.
+func NewAuditWriter(w io.Writer) *AuditWriter {
+	return &AuditWriter{w: w, enc: json.NewEncoder(w)}
+}
+
+// Append implements AuditSink. After the first write error records are
+// still numbered but no longer written; see Err.
+func (a *AuditWriter) Append(record PlasticityRecord) uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.seq++
+	record.Seq = a.seq
+	if a.err == nil {
+		a.err = a.enc.Encode(record)
+	}
+	return record.Seq
+}
+
+// Err returns the first write error.
+func (a *AuditWriter) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// ReadAuditRecords reads JSON-lines records written by an AuditWriter.
+func ReadAuditRecords(r io.Reader) ([]PlasticityRecord, error) {
+	var records []PlasticityRecord
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record PlasticityRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+	return records, scanner.Err()
+}
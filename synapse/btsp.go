@@ -0,0 +1,201 @@
+package synapse
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+)
+
+// =================================================================================
+// BEHAVIORAL-TIMESCALE SYNAPTIC PLASTICITY (BTSP)
+// =================================================================================
+
+// STDP pairs spikes within tens of milliseconds. Place fields in CA1 form in
+// a single trial instead: one dendritic plateau potential in the
+// post-synaptic neuron potentiates every input that was active within
+// seconds of it (Bittner et al. 2017). The plasticity kernel is asymmetric;
+// inputs active before the plateau gain more and over a longer window
+// (τ ≈ 1.3s) than inputs active after it (τ ≈ 0.7s).
+//
+// The rule is implemented with two traces:
+//
+//   - An eligibility trace, incremented by every pre-synaptic spike and
+//     decaying with TauBefore. When ApplyPlateau delivers the instructive
+//     signal, the trace value (capped at 1) is the kernel for all inputs
+//     before the plateau.
+//   - An instructive trace, set by the plateau and decaying with TauAfter.
+//     Every later pre-synaptic spike uses its value as the kernel, until it
+//     has decayed below 1% (about 4.6 × TauAfter).
+//
+// Each kernel potentiates with a soft bound:
+//
+//	Δw = LearningRate × kernel × (MaxWeight - w)
+//
+// so repeated plateaus saturate instead of running away. Weight bounds and
+// the on/off switch come from the plasticity configuration, so
+// FreezePlasticity also stops BTSP. Post-synaptic neurons deliver plateaus
+// to their registered input synapses; see neuron.SetPlateauDetection.
+
+// BTSPConfig configures behavioral-timescale plasticity.
+type BTSPConfig struct {
+	Enabled      bool          `json:"enabled"`
+	LearningRate float64       `json:"learning_rate"` // Fraction of the distance to MaxWeight gained per unit kernel (0-1]
+	TauBefore    time.Duration `json:"tau_before"`    // Kernel decay for input before the plateau
+	TauAfter     time.Duration `json:"tau_after"`     // Kernel decay for input after the plateau
+}
+
+// btspTracker holds the two traces. Guarded by the synapse mutex.
+type btspTracker struct {
+	config      BTSPConfig
+	eligibility float64   // Pre-synaptic eligibility trace
+	updatedAt   time.Time // Time of the last eligibility update
+	plateauAt   time.Time // Last instructive signal (zero = none)
+	lastSpike   time.Time // Last pre-synaptic spike (zero = none)
+}
+
+// CreateDefaultBTSPConfig returns an enabled configuration with the kernel
+// time constants measured in CA1.
+func CreateDefaultBTSPConfig() BTSPConfig {
+	return BTSPConfig{
+		Enabled:      true,
+		LearningRate: BTSP_DEFAULT_LEARNING_RATE,
+		TauBefore:    BTSP_DEFAULT_TAU_BEFORE,
+		TauAfter:     BTSP_DEFAULT_TAU_AFTER,
+	}
+}
+
+// validateBTSPConfig checks an enabled configuration.
+func validateBTSPConfig(config BTSPConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if math.IsNaN(config.LearningRate) || config.LearningRate <= 0 || config.LearningRate > 1 {
+		return fmt.Errorf("BTSP learning rate must be in (0, 1]: %f", config.LearningRate)
+	}
+	if config.TauBefore <= 0 || config.TauAfter <= 0 {
+		return fmt.Errorf("BTSP time constants must be positive: %v, %v", config.TauBefore, config.TauAfter)
+	}
+	return nil
+}
+
+// SetBTSP enables (or, with Enabled false, disables) behavioral-timescale
+// plasticity. Traces start empty.
+func (s *BasicSynapse) SetBTSP(config BTSPConfig) error {
+	if err := validateBTSPConfig(config); err != nil {
+		return fmt.Errorf("synapse %s: %w", s.id, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !config.Enabled {
+		s.btsp = nil
+		return nil
+	}
+	s.btsp = &btspTracker{config: config}
+	return nil
+}
+
+// GetBTSPConfig returns the BTSP configuration (Enabled is false when it is off).
+func (s *BasicSynapse) GetBTSPConfig() BTSPConfig {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.btsp == nil {
+		return BTSPConfig{}
+	}
+	return s.btsp.config
+}
+
+// ApplyPlateau delivers an instructive plateau signal from the post-synaptic
+// neuron at time at. Inputs active in the seconds before are potentiated now;
+// inputs arriving afterwards are potentiated as they arrive. Ignored when
+// BTSP is off.
+func (s *BasicSynapse) ApplyPlateau(at time.Time) {
+	if at.IsZero() {
+		at = s.now()
+	}
+
+	s.mutex.Lock()
+	b := s.btsp
+	if b == nil {
+		s.mutex.Unlock()
+		return
+	}
+	b.decayTo(at)
+	b.plateauAt = at
+	update := s.applyBTSPUnsafe(math.Min(b.eligibility, 1), at)
+	if update != nil {
+		update.withSpikes(b.lastSpike, at)
+	}
+	stageChanges := s.takeStageChangesUnsafe()
+	s.mutex.Unlock()
+
+	s.reportBTSP(update)
+	s.reportStageChanges(stageChanges)
+}
+
+// recordBTSPSpikeUnsafe feeds a pre-synaptic spike at now into the rule and
+// returns the resulting update (nil if none). The synapse mutex must be held.
+func (s *BasicSynapse) recordBTSPSpikeUnsafe(now time.Time) *PlasticityRecord {
+	b := s.btsp
+	b.decayTo(now)
+	b.eligibility++
+	b.lastSpike = now
+
+	if b.plateauAt.IsZero() {
+		return nil
+	}
+	kernel := math.Exp(-float64(now.Sub(b.plateauAt)) / float64(b.config.TauAfter))
+	if kernel < BTSP_KERNEL_CUTOFF {
+		b.plateauAt = time.Time{}
+		return nil
+	}
+	update := s.applyBTSPUnsafe(kernel, now)
+	if update != nil {
+		update.withSpikes(now, b.plateauAt)
+	}
+	return update
+}
+
+// applyBTSPUnsafe potentiates by kernel with a soft bound and returns the
+// update (nil if the weight was left alone). The synapse mutex must be held.
+func (s *BasicSynapse) applyBTSPUnsafe(kernel float64, at time.Time) *PlasticityRecord {
+	if !s.stdpConfig.Enabled || kernel <= 0 {
+		return nil
+	}
+	rate := s.btsp.config.LearningRate * s.learningRateScaleUnsafe()
+	oldWeight := s.loadWeight()
+	newWeight := oldWeight + rate*kernel*(s.stdpConfig.MaxWeight-oldWeight)
+	newWeight = math.Max(s.stdpConfig.MinWeight, math.Min(s.stdpConfig.MaxWeight, newWeight))
+
+	s.storeWeight(newWeight)
+	s.lastPlasticityEvent = s.now()
+	s.observeWeightChangeUnsafe(oldWeight, newWeight, at)
+	return newPlasticityRecord(AuditRuleBTSP, at, oldWeight, newWeight)
+}
+
+// reportBTSP logs, charges and audits a BTSP update. Must be called without
+// the synapse mutex held.
+func (s *BasicSynapse) reportBTSP(update *PlasticityRecord) {
+	if update == nil {
+		return
+	}
+	s.logf(slog.LevelDebug, logging.RecordPlasticity, "BTSP weight updated",
+		"old_weight", update.OldWeight, "new_weight", update.NewWeight)
+	s.chargePlasticity()
+	s.auditChange(update)
+}
+
+// decayTo advances the eligibility trace to t. Out-of-order times are ignored.
+func (b *btspTracker) decayTo(t time.Time) {
+	if b.updatedAt.IsZero() {
+		b.updatedAt = t
+		return
+	}
+	if elapsed := t.Sub(b.updatedAt); elapsed > 0 {
+		b.eligibility *= math.Exp(-float64(elapsed) / float64(b.config.TauBefore))
+		b.updatedAt = t
+	}
+}
@@ -0,0 +1,454 @@
+package synapse
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+	"github.com/SynapticNetworks/temporal-neuron/topology"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// FUNCTIONAL-OPTIONS CONSTRUCTOR WITH VALIDATION
+// =================================================================================
+
+// SynapseOption configures a synapse created by NewSynapse.
+// Options are applied in order, so presets should come first and individual
+// overrides after them.
+type SynapseOption func(*synapseSettings)
+
+// synapseSettings collects option values before validation.
+type synapseSettings struct {
+	weight           float64
+	delay            time.Duration
+	stdpConfig       types.PlasticityConfig
+	pruningConfig    PruningConfig
+	extracellular    ExtracellularMatrix
+	eligibilityDecay time.Duration
+	delayModel       *topology.DelayModel // Derive delay from neuron positions when set
+	conduction       *ConductionConfig    // Derive delay from path length and conduction velocity when set
+	observer         types.BiologicalObserver
+	pruneDeadTarget  bool
+	logHandler       slog.Handler
+	energyMeter      *energy.Meter
+	auditSink        AuditSink
+	targetPort       string
+	priority         types.Priority
+	middleware       []Middleware
+	metaplasticity   MetaplasticityConfig
+	consolidation    ConsolidationConfig
+	forgetting       ForgettingConfig
+	btsp             BTSPConfig
+	istdp            InhibitorySTDPConfig
+	quantal          QuantalConfig
+	retention        RetentionPolicy
+	silent           bool
+	unsilenceAt      float64
+	clock            func() time.Time // Time source (nil = wall clock)
+}
+
+// NewSynapse creates a BasicSynapse from functional options.
+// Unlike NewBasicSynapse, which silently clamps invalid arguments, NewSynapse
+// rejects nonsensical combinations with a descriptive error.
+//
+// Defaults (before options): default STDP and pruning configs, weight
+// PRESET_CORTICAL_EXCITATORY_WEIGHT and SYNAPSE_DEFAULT_TRANSMISSION_DELAY.
+//
+// Example:
+//
+//	syn, err := synapse.NewSynapse("s1", pre, post,
+//	    synapse.WithGABAergicFast(),
+//	    synapse.WithWeight(1.2))
+//
+// Returns:
+//
+//	The configured synapse, or an error describing the first invalid setting
+func NewSynapse(id string, pre component.MessageScheduler, post component.MessageReceiver,
+	opts ...SynapseOption) (*BasicSynapse, error) {
+
+	settings := synapseSettings{
+		weight:           PRESET_CORTICAL_EXCITATORY_WEIGHT,
+		delay:            SYNAPSE_DEFAULT_TRANSMISSION_DELAY,
+		stdpConfig:       CreateDefaultSTDPConfig(),
+		pruningConfig:    CreateDefaultPruningConfig(),
+		eligibilityDecay: ELIGIBILITY_TRACE_DEFAULT_DECAY,
+		retention:        CreateDefaultRetentionPolicy(),
+	}
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	if settings.delayModel != nil && pre != nil && post != nil {
+		settings.delay = settings.delayModel.Delay(pre.Position(), post.Position())
+	}
+	if settings.conduction != nil {
+		resolved := resolveConduction(*settings.conduction, pre, post)
+		settings.conduction = &resolved
+		settings.delay = resolved.Delay()
+	}
+
+	if err := validateSynapseSettings(id, pre, post, settings); err != nil {
+		return nil, err
+	}
+
+	syn := NewBasicSynapseWithMatrix(id, pre, post, settings.stdpConfig, settings.pruningConfig,
+		settings.weight, settings.delay, settings.extracellular)
+	if settings.clock != nil {
+		syn.SetClock(settings.clock)
+	}
+	syn.SetEligibilityDecay(settings.eligibilityDecay)
+	syn.SetBiologicalObserver(settings.observer)
+	syn.SetPruneOnDeadTarget(settings.pruneDeadTarget)
+	if settings.logHandler != nil {
+		syn.SetLogHandler(settings.logHandler)
+	}
+	if settings.energyMeter != nil {
+		syn.SetEnergyMeter(settings.energyMeter)
+	}
+	syn.UseMiddleware(settings.middleware...)
+	if err := syn.SetMetaplasticity(settings.metaplasticity); err != nil {
+		return nil, err
+	}
+	if err := syn.SetConsolidation(settings.consolidation); err != nil {
+		return nil, err
+	}
+	if err := syn.SetForgetting(settings.forgetting); err != nil {
+		return nil, err
+	}
+	if err := syn.SetBTSP(settings.btsp); err != nil {
+		return nil, err
+	}
+	if err := syn.SetInhibitorySTDP(settings.istdp); err != nil {
+		return nil, err
+	}
+	if err := syn.SetQuantalRelease(settings.quantal); err != nil {
+		return nil, err
+	}
+	if err := syn.SetRetentionPolicy(settings.retention); err != nil {
+		return nil, err
+	}
+	if settings.silent {
+		if err := syn.StartSilent(settings.unsilenceAt); err != nil {
+			return nil, err
+		}
+	}
+	if settings.conduction != nil {
+		if err := syn.SetConduction(*settings.conduction); err != nil {
+			return nil, err
+		}
+	}
+	syn.SetAuditSink(settings.auditSink)
+	syn.SetTargetPort(settings.targetPort)
+	if err := syn.SetPriority(settings.priority); err != nil {
+		return nil, err
+	}
+	return syn, nil
+}
+
+// validateSynapseSettings checks every setting for biological and numerical sanity.
+func validateSynapseSettings(id string, pre component.MessageScheduler, post component.MessageReceiver,
+	settings synapseSettings) error {
+
+	if id == "" {
+		return fmt.Errorf("synapse ID cannot be empty")
+	}
+	if pre == nil {
+		return fmt.Errorf("synapse %s: pre-synaptic neuron cannot be nil", id)
+	}
+	if post == nil {
+		return fmt.Errorf("synapse %s: post-synaptic neuron cannot be nil", id)
+	}
+	if settings.delay < 0 {
+		return fmt.Errorf("synapse %s: delay cannot be negative: %v", id, settings.delay)
+	}
+	if settings.conduction != nil {
+		if err := validateConductionConfig(*settings.conduction); err != nil {
+			return fmt.Errorf("synapse %s: %w", id, err)
+		}
+	}
+
+	cfg := settings.stdpConfig
+	if math.IsNaN(cfg.MinWeight) || math.IsNaN(cfg.MaxWeight) || math.IsNaN(settings.weight) {
+		return fmt.Errorf("synapse %s: weights cannot be NaN", id)
+	}
+	if cfg.MinWeight < 0 {
+		return fmt.Errorf("synapse %s: MinWeight cannot be negative: %f", id, cfg.MinWeight)
+	}
+	if cfg.MinWeight > cfg.MaxWeight {
+		return fmt.Errorf("synapse %s: MinWeight %f exceeds MaxWeight %f", id, cfg.MinWeight, cfg.MaxWeight)
+	}
+	if settings.weight < cfg.MinWeight || settings.weight > cfg.MaxWeight {
+		return fmt.Errorf("synapse %s: weight %f outside bounds [%f, %f]", id, settings.weight, cfg.MinWeight, cfg.MaxWeight)
+	}
+	if cfg.LearningRate < 0 {
+		return fmt.Errorf("synapse %s: learning rate cannot be negative: %f", id, cfg.LearningRate)
+	}
+	if cfg.Enabled {
+		if cfg.TimeConstant <= 0 {
+			return fmt.Errorf("synapse %s: STDP time constant must be positive: %v", id, cfg.TimeConstant)
+		}
+		if cfg.WindowSize <= 0 {
+			return fmt.Errorf("synapse %s: STDP window must be positive: %v", id, cfg.WindowSize)
+		}
+		if cfg.AsymmetryRatio <= 0 {
+			return fmt.Errorf("synapse %s: asymmetry ratio must be positive: %f", id, cfg.AsymmetryRatio)
+		}
+	}
+	if settings.pruningConfig.WeightThreshold < 0 {
+		return fmt.Errorf("synapse %s: pruning weight threshold cannot be negative: %f", id, settings.pruningConfig.WeightThreshold)
+	}
+	if settings.pruningConfig.InactivityThreshold < 0 {
+		return fmt.Errorf("synapse %s: pruning inactivity threshold cannot be negative: %v", id, settings.pruningConfig.InactivityThreshold)
+	}
+	if err := validatePruningProbation(settings.pruningConfig); err != nil {
+		return fmt.Errorf("synapse %s: %w", id, err)
+	}
+	if settings.eligibilityDecay <= 0 {
+		return fmt.Errorf("synapse %s: eligibility decay must be positive: %v", id, settings.eligibilityDecay)
+	}
+	if err := validateMetaplasticityConfig(settings.metaplasticity); err != nil {
+		return fmt.Errorf("synapse %s: %w", id, err)
+	}
+	if err := validateConsolidationConfig(settings.consolidation); err != nil {
+		return fmt.Errorf("synapse %s: %w", id, err)
+	}
+	if err := validateForgettingConfig(settings.forgetting); err != nil {
+		return fmt.Errorf("synapse %s: %w", id, err)
+	}
+	if err := validateBTSPConfig(settings.btsp); err != nil {
+		return fmt.Errorf("synapse %s: %w", id, err)
+	}
+	if err := validateInhibitorySTDPConfig(settings.istdp); err != nil {
+		return fmt.Errorf("synapse %s: %w", id, err)
+	}
+	if err := validateQuantalConfig(settings.quantal); err != nil {
+		return fmt.Errorf("synapse %s: %w", id, err)
+	}
+	return nil
+}
+
+// =================================================================================
+// INDIVIDUAL OPTIONS
+// =================================================================================
+
+// WithWeight sets the initial synaptic weight.
+func WithWeight(weight float64) SynapseOption {
+	return func(s *synapseSettings) { s.weight = weight }
+}
+
+// WithDelay sets a fixed transmission delay, overriding an earlier WithDistanceDelay.
+func WithDelay(delay time.Duration) SynapseOption {
+	return func(s *synapseSettings) {
+		s.delay = delay
+		s.delayModel = nil
+		s.conduction = nil
+	}
+}
+
+// WithDistanceDelay derives the transmission delay from the Euclidean distance
+// between the pre- and post-synaptic neurons and the model's conduction
+// velocity, replacing any fixed delay.
+func WithDistanceDelay(model topology.DelayModel) SynapseOption {
+	return func(s *synapseSettings) {
+		s.delayModel = &model
+		s.conduction = nil
+	}
+}
+
+// WithConduction derives the delay from axon path length, conduction velocity
+// and myelination (see ConductionConfig), replacing any other delay. The
+// myelination factor can later be changed with SetMyelination.
+func WithConduction(config ConductionConfig) SynapseOption {
+	return func(s *synapseSettings) {
+		s.conduction = &config
+		s.delayModel = nil
+	}
+}
+
+// WithSTDPConfig replaces the full plasticity configuration.
+func WithSTDPConfig(config types.PlasticityConfig) SynapseOption {
+	return func(s *synapseSettings) { s.stdpConfig = config }
+}
+
+// WithPruningConfig replaces the pruning configuration.
+func WithPruningConfig(config PruningConfig) SynapseOption {
+	return func(s *synapseSettings) { s.pruningConfig = config }
+}
+
+// WithLearningRate overrides the STDP learning rate.
+func WithLearningRate(rate float64) SynapseOption {
+	return func(s *synapseSettings) { s.stdpConfig.LearningRate = rate }
+}
+
+// WithWeightBounds overrides the STDP weight bounds.
+func WithWeightBounds(minWeight, maxWeight float64) SynapseOption {
+	return func(s *synapseSettings) {
+		s.stdpConfig.MinWeight = minWeight
+		s.stdpConfig.MaxWeight = maxWeight
+	}
+}
+
+// WithPlasticityDisabled turns off STDP for a fixed-weight synapse.
+func WithPlasticityDisabled() SynapseOption {
+	return func(s *synapseSettings) { s.stdpConfig.Enabled = false }
+}
+
+// WithEligibilityDecay sets the eligibility trace time constant.
+func WithEligibilityDecay(decay time.Duration) SynapseOption {
+	return func(s *synapseSettings) { s.eligibilityDecay = decay }
+}
+
+// WithExtracellularMatrix enables spatial delay calculation via the matrix.
+func WithExtracellularMatrix(matrix ExtracellularMatrix) SynapseOption {
+	return func(s *synapseSettings) { s.extracellular = matrix }
+}
+
+// WithBiologicalObserver sets the observer receiving synapse events.
+func WithBiologicalObserver(observer types.BiologicalObserver) SynapseOption {
+	return func(s *synapseSettings) { s.observer = observer }
+}
+
+// WithDeadTargetPruning makes the synapse detach itself and request pruning
+// once its post-synaptic neuron is closed.
+func WithDeadTargetPruning() SynapseOption {
+	return func(s *synapseSettings) { s.pruneDeadTarget = true }
+}
+
+// WithMetaplasticity enables the BCM-like sliding modification threshold
+// (see CreateDefaultMetaplasticityConfig).
+func WithMetaplasticity(config MetaplasticityConfig) SynapseOption {
+	return func(s *synapseSettings) { s.metaplasticity = config }
+}
+
+// WithConsolidation enables synaptic tagging and capture
+// (see CreateDefaultConsolidationConfig).
+func WithConsolidation(config ConsolidationConfig) SynapseOption {
+	return func(s *synapseSettings) { s.consolidation = config }
+}
+
+// WithForgetting enables passive weight decay toward a baseline
+// (see CreateDefaultForgettingConfig).
+func WithForgetting(config ForgettingConfig) SynapseOption {
+	return func(s *synapseSettings) { s.forgetting = config }
+}
+
+// WithBTSP enables behavioral-timescale plasticity driven by post-synaptic
+// plateau potentials (see CreateDefaultBTSPConfig).
+func WithBTSP(config BTSPConfig) SynapseOption {
+	return func(s *synapseSettings) { s.btsp = config }
+}
+
+// WithInhibitorySTDP makes the synapse learn with the inhibitory rule of
+// Vogels et al. (2011) instead of pair-based STDP
+// (see CreateDefaultInhibitorySTDPConfig).
+func WithInhibitorySTDP(config InhibitorySTDPConfig) SynapseOption {
+	return func(s *synapseSettings) { s.istdp = config }
+}
+
+// WithQuantalRelease draws each transmitted amplitude from a finite vesicle
+// pool (see CreateDefaultQuantalConfig).
+func WithQuantalRelease(config QuantalConfig) SynapseOption {
+	return func(s *synapseSettings) { s.quantal = config }
+}
+
+// WithSilentStart creates a silent synapse that is unsilenced by net
+// potentiation of threshold (see StartSilent).
+func WithSilentStart(threshold float64) SynapseOption {
+	return func(s *synapseSettings) {
+		s.silent = true
+		s.unsilenceAt = threshold
+	}
+}
+
+// WithRetentionPolicy bounds the spike histories kept for STDP
+// (see CreateDefaultRetentionPolicy).
+func WithRetentionPolicy(policy RetentionPolicy) SynapseOption {
+	return func(s *synapseSettings) { s.retention = policy }
+}
+
+// WithLogHandler attaches a structured log handler (see SetLogHandler).
+func WithLogHandler(handler slog.Handler) SynapseOption {
+	return func(s *synapseSettings) { s.logHandler = handler }
+}
+
+// WithEnergyMeter charges transmissions and weight updates to meter (see
+// SetEnergyMeter).
+func WithEnergyMeter(meter *energy.Meter) SynapseOption {
+	return func(s *synapseSettings) { s.energyMeter = meter }
+}
+
+// WithTargetPort contacts the named input port of the post-synaptic neuron
+// (see SetTargetPort).
+func WithTargetPort(port string) SynapseOption {
+	return func(s *synapseSettings) { s.targetPort = port }
+}
+
+// WithPriority sets the delivery class of the synapse's signals (see
+// SetPriority).
+func WithPriority(priority types.Priority) SynapseOption {
+	return func(s *synapseSettings) { s.priority = priority }
+}
+
+// WithAuditSink records every weight change to sink (see SetAuditSink).
+func WithAuditSink(sink AuditSink) SynapseOption {
+	return func(s *synapseSettings) { s.auditSink = sink }
+}
+
+// WithMiddleware appends transmission middleware (see UseMiddleware).
+func WithMiddleware(middleware ...Middleware) SynapseOption {
+	return func(s *synapseSettings) { s.middleware = append(s.middleware, middleware...) }
+}
+
+// =================================================================================
+// BIOLOGICAL PRESETS
+// =================================================================================
+
+// WithCorticalExcitatory configures a glutamatergic (AMPA) cortical synapse:
+// moderate weight, ~1ms delay and classic asymmetric STDP.
+func WithCorticalExcitatory() SynapseOption {
+	return func(s *synapseSettings) {
+		s.weight = PRESET_CORTICAL_EXCITATORY_WEIGHT
+		s.delay = PRESET_CORTICAL_EXCITATORY_DELAY
+		s.stdpConfig = CreateDefaultSTDPConfig()
+		s.stdpConfig.LearningRate = PRESET_CORTICAL_EXCITATORY_LEARNING_RATE
+		s.stdpConfig.TimeConstant = PRESET_CORTICAL_EXCITATORY_TIME_CONSTANT
+		s.stdpConfig.WindowSize = PRESET_CORTICAL_EXCITATORY_WINDOW
+		s.pruningConfig = CreateDefaultPruningConfig()
+		s.eligibilityDecay = ELIGIBILITY_TRACE_DEFAULT_DECAY
+	}
+}
+
+// WithGABAergicFast configures a fast perisomatic GABA-A synapse: strong,
+// sub-millisecond delay, narrow symmetric STDP window and conservative pruning.
+func WithGABAergicFast() SynapseOption {
+	return func(s *synapseSettings) {
+		s.weight = PRESET_GABA_FAST_WEIGHT
+		s.delay = PRESET_GABA_FAST_DELAY
+		s.stdpConfig = CreateDefaultSTDPConfig()
+		s.stdpConfig.LearningRate = PRESET_GABA_FAST_LEARNING_RATE
+		s.stdpConfig.TimeConstant = PRESET_GABA_FAST_TIME_CONSTANT
+		s.stdpConfig.WindowSize = PRESET_GABA_FAST_WINDOW
+		s.stdpConfig.AsymmetryRatio = PRESET_GABA_FAST_ASYMMETRY
+		s.pruningConfig = CreateConservativePruningConfig()
+		s.eligibilityDecay = ELIGIBILITY_TRACE_DEFAULT_DECAY
+	}
+}
+
+// WithNeuromodulatorySlow configures a slow modulatory synapse: weak, long
+// delay, broad STDP window and a long eligibility trace for reward learning.
+func WithNeuromodulatorySlow() SynapseOption {
+	return func(s *synapseSettings) {
+		s.weight = PRESET_NEUROMOD_SLOW_WEIGHT
+		s.delay = PRESET_NEUROMOD_SLOW_DELAY
+		s.stdpConfig = CreateDefaultSTDPConfig()
+		s.stdpConfig.LearningRate = PRESET_NEUROMOD_SLOW_LEARNING_RATE
+		s.stdpConfig.TimeConstant = PRESET_NEUROMOD_SLOW_TIME_CONSTANT
+		s.stdpConfig.WindowSize = PRESET_NEUROMOD_SLOW_WINDOW
+		s.pruningConfig = CreateConservativePruningConfig()
+		s.eligibilityDecay = PRESET_NEUROMOD_SLOW_ELIGIBILITY_DECAY
+	}
+}
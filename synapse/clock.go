@@ -0,0 +1,60 @@
+package synapse
+
+import (
+	"time"
+)
+
+// =================================================================================
+// TIME SOURCE
+// =================================================================================
+//
+// A synapse timestamps its transmissions and pre- and post-synaptic spikes,
+// and decays its eligibility trace, GABA effects and pruning modifiers over
+// elapsed time. All of it reads the synapse's clock, the wall clock by
+// default. Between neurons stepped on a virtual clock (see
+// neuron.EnableStepping and cosim.LockStep) the synapse must read the same
+// clock: otherwise its pre-spike times and the neuron's post-spike times are
+// on different time bases and STDP pairs nothing.
+//
+// Delays are not timed here: the pre-synaptic neuron's axon schedules them,
+// on its own clock. Forgetting keeps the clock of its ForgettingConfig.
+
+// WithClock reads time from now instead of the wall clock.
+func WithClock(now func() time.Time) SynapseOption {
+	return func(s *synapseSettings) { s.clock = now }
+}
+
+// SetClock replaces the time source (nil = wall clock). The decay timers
+// restart at the new clock's current time and the spike histories are
+// cleared, since times on the old clock cannot be compared with the new one.
+func (s *BasicSynapse) SetClock(now func() time.Time) {
+	if now == nil {
+		s.clock.Store(nil)
+	} else {
+		s.clock.Store(&now)
+	}
+	at := s.now()
+
+	s.mutex.Lock()
+	s.eligibilityTimestamp = at
+	s.gabaTimestamp = at
+	s.gabaLongTermRecoveryTime = at
+	s.gabaSTDPTimestamp = at
+	s.lastPlasticityEvent = at
+	s.lastTransmission = at
+	s.pruningModifierDecayTime = at
+	s.mutex.Unlock()
+
+	s.spikeTimingMutex.Lock()
+	s.preSpikeTimes = s.preSpikeTimes[:0]
+	s.postSpikeTimes = s.postSpikeTimes[:0]
+	s.spikeTimingMutex.Unlock()
+}
+
+// now returns the synapse's current time.
+func (s *BasicSynapse) now() time.Time {
+	if now := s.clock.Load(); now != nil {
+		return (*now)()
+	}
+	return time.Now()
+}
@@ -0,0 +1,143 @@
+package synapse
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/topology"
+)
+
+// =================================================================================
+// CONDUCTION VELOCITY AND MYELINATION
+// =================================================================================
+//
+// A synapse's delay can be described physically instead of as a raw duration:
+// the axon's path length, the conduction velocity of the bare axon, and a
+// myelination factor that multiplies that velocity. The delay is then
+//
+//	SynapticDelay + PathLength / (Velocity × Myelination)
+//
+// Myelination is not fixed in the adult brain: oligodendrocytes add myelin to
+// active axons and so tune conduction delays (Fields 2015). SetMyelination
+// changes the factor at runtime and recomputes the delay, so a learning rule
+// can implement activity-dependent myelination. Setting a fixed delay with
+// SetDelay ends conduction-based timing.
+
+// ConductionConfig describes an axon by its physical properties.
+type ConductionConfig struct {
+	PathLength    float64       `json:"path_length"`    // Axon length in μm (0 = distance between the neurons)
+	Velocity      float64       `json:"velocity"`       // Unmyelinated conduction velocity (μm/ms)
+	Myelination   float64       `json:"myelination"`    // Velocity multiplier (1 = bare axon, 0 = 1)
+	SynapticDelay time.Duration `json:"synaptic_delay"` // Fixed release and diffusion delay
+}
+
+// CreateDefaultConductionConfig returns an unmyelinated cortical axon whose
+// path length is the distance between the neurons.
+func CreateDefaultConductionConfig() ConductionConfig {
+	return ConductionConfig{
+		Velocity:      topology.DEFAULT_CONDUCTION_VELOCITY,
+		Myelination:   CONDUCTION_UNMYELINATED_FACTOR,
+		SynapticDelay: topology.DEFAULT_SYNAPTIC_DELAY,
+	}
+}
+
+// Delay returns the total transmission delay.
+func (c ConductionConfig) Delay() time.Duration {
+	return c.SynapticDelay + topology.ConductionDelay(c.PathLength, c.Velocity*c.Myelination)
+}
+
+// EffectiveVelocity returns the myelinated conduction velocity (μm/ms).
+func (c ConductionConfig) EffectiveVelocity() float64 {
+	return c.Velocity * c.Myelination
+}
+
+// validateConductionConfig checks the physical parameters.
+func validateConductionConfig(config ConductionConfig) error {
+	if math.IsNaN(config.PathLength) || math.IsInf(config.PathLength, 0) || config.PathLength < 0 {
+		return fmt.Errorf("path length must be finite and non-negative: %f", config.PathLength)
+	}
+	if math.IsNaN(config.Velocity) || math.IsInf(config.Velocity, 0) || config.Velocity <= 0 {
+		return fmt.Errorf("conduction velocity must be positive: %f", config.Velocity)
+	}
+	if err := validateMyelination(config.Myelination); err != nil {
+		return err
+	}
+	if config.SynapticDelay < 0 {
+		return fmt.Errorf("synaptic delay cannot be negative: %v", config.SynapticDelay)
+	}
+	return nil
+}
+
+// validateMyelination checks a myelination factor.
+func validateMyelination(factor float64) error {
+	if math.IsNaN(factor) || factor <= 0 || factor > CONDUCTION_MAX_MYELINATION {
+		return fmt.Errorf("myelination factor must be in (0, %.0f]: %f", CONDUCTION_MAX_MYELINATION, factor)
+	}
+	return nil
+}
+
+// resolveConduction fills in defaults: a zero myelination factor means a bare
+// axon and a zero path length the straight-line distance between the neurons.
+func resolveConduction(config ConductionConfig, pre component.MessageScheduler, post component.MessageReceiver) ConductionConfig {
+	if config.Myelination == 0 {
+		config.Myelination = CONDUCTION_UNMYELINATED_FACTOR
+	}
+	if config.PathLength == 0 && pre != nil && post != nil {
+		config.PathLength = topology.Distance(pre.Position(), post.Position())
+	}
+	return config
+}
+
+// SetConduction switches the synapse to conduction-based timing and sets its
+// delay from config.
+func (s *BasicSynapse) SetConduction(config ConductionConfig) error {
+	config = resolveConduction(config, s.preSynapticNeuron, s.postSynapticNeuron)
+	if err := validateConductionConfig(config); err != nil {
+		return fmt.Errorf("synapse %s: %w", s.id, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.conduction = &config
+	s.delay = config.Delay()
+	return nil
+}
+
+// GetConduction returns the conduction parameters, and false when the synapse
+// uses a fixed delay.
+func (s *BasicSynapse) GetConduction() (ConductionConfig, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.conduction == nil {
+		return ConductionConfig{}, false
+	}
+	return *s.conduction, true
+}
+
+// SetMyelination changes the myelination factor and recomputes the delay.
+func (s *BasicSynapse) SetMyelination(factor float64) error {
+	if err := validateMyelination(factor); err != nil {
+		return fmt.Errorf("synapse %s: %w", s.id, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.conduction == nil {
+		return fmt.Errorf("synapse %s: myelination needs conduction-based timing (SetConduction)", s.id)
+	}
+	s.conduction.Myelination = factor
+	s.delay = s.conduction.Delay()
+	return nil
+}
+
+// GetMyelination returns the myelination factor (0 with a fixed delay).
+func (s *BasicSynapse) GetMyelination() float64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.conduction == nil {
+		return 0
+	}
+	return s.conduction.Myelination
+}
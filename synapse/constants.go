@@ -139,4 +139,169 @@ type PruningConfig struct {
 	Enabled             bool          `json:"enabled"`              // Whether pruning is active
 	WeightThreshold     float64       `json:"weight_threshold"`     // Minimum weight to avoid pruning
 	InactivityThreshold time.Duration `json:"inactivity_threshold"` // Time since last activity to prune
+
+	// Hysteresis (see probation.go): a synapse meeting the criteria above is
+	// marked and only pruned if it stays marked for ProbationPeriod
+	ConfirmThreshold float64       `json:"confirm_threshold,omitempty"` // Weight a marked synapse must regain to be cleared (0 = WeightThreshold)
+	ProbationPeriod  time.Duration `json:"probation_period,omitempty"`  // Time a marked synapse has to recover (0 = prune at once)
 }
+
+// Synapse presets used by the functional-options constructor (NewSynapse)
+const (
+	// Cortical excitatory (AMPA-dominated glutamatergic) synapse
+	// Fast local transmission with classic asymmetric STDP (Bi & Poo, 1998)
+	PRESET_CORTICAL_EXCITATORY_WEIGHT        float64       = 0.5
+	PRESET_CORTICAL_EXCITATORY_DELAY         time.Duration = 1 * time.Millisecond
+	PRESET_CORTICAL_EXCITATORY_LEARNING_RATE float64       = 0.01
+	PRESET_CORTICAL_EXCITATORY_TIME_CONSTANT time.Duration = 20 * time.Millisecond
+	PRESET_CORTICAL_EXCITATORY_WINDOW        time.Duration = 100 * time.Millisecond
+
+	// Fast GABAergic (GABA-A, basket cell) synapse
+	// Perisomatic inhibition is fast, strong and comparatively stable
+	PRESET_GABA_FAST_WEIGHT        float64       = 0.8
+	PRESET_GABA_FAST_DELAY         time.Duration = 500 * time.Microsecond
+	PRESET_GABA_FAST_LEARNING_RATE float64       = 0.005
+	PRESET_GABA_FAST_TIME_CONSTANT time.Duration = 10 * time.Millisecond
+	PRESET_GABA_FAST_WINDOW        time.Duration = 50 * time.Millisecond
+	PRESET_GABA_FAST_ASYMMETRY     float64       = 1.0 // Symmetric inhibitory STDP
+
+	// Slow neuromodulatory (volume transmission, e.g. dopaminergic) synapse
+	// Slow metabotropic signalling with long eligibility for reward learning
+	PRESET_NEUROMOD_SLOW_WEIGHT            float64       = 0.3
+	PRESET_NEUROMOD_SLOW_DELAY             time.Duration = 5 * time.Millisecond
+	PRESET_NEUROMOD_SLOW_LEARNING_RATE     float64       = 0.002
+	PRESET_NEUROMOD_SLOW_TIME_CONSTANT     time.Duration = 50 * time.Millisecond
+	PRESET_NEUROMOD_SLOW_WINDOW            time.Duration = 200 * time.Millisecond
+	PRESET_NEUROMOD_SLOW_ELIGIBILITY_DECAY time.Duration = 1 * time.Second
+)
+
+// Spike history retention
+const (
+	// SPIKE_HISTORY_DEFAULT_MAX_COUNT is the number of recent spikes a
+	// synapse keeps per side under the default retention policy.
+	SPIKE_HISTORY_DEFAULT_MAX_COUNT int = 20
+)
+
+// Latency instrumentation
+const (
+	// LATENCY_DEFAULT_SAMPLE_CAPACITY is the number of recent deliveries kept
+	// for latency percentiles when tracking is enabled.
+	LATENCY_DEFAULT_SAMPLE_CAPACITY int = 1024
+)
+
+// Plasticity audit log
+const (
+	// AUDIT_DEFAULT_CAPACITY is the number of recent weight changes an
+	// AuditLog keeps when no capacity is given.
+	AUDIT_DEFAULT_CAPACITY int = 10000
+)
+
+// Metaplasticity (BCM-like sliding threshold)
+const (
+	// METAPLASTICITY_DEFAULT_TARGET_RATE is the post-synaptic rate at which
+	// STDP is left unmodified. Typical cortical pyramidal rates are 1-10Hz.
+	METAPLASTICITY_DEFAULT_TARGET_RATE float64 = 5.0
+
+	// METAPLASTICITY_DEFAULT_TIME_CONSTANT is the averaging window of the
+	// activity estimate. The BCM threshold must slide slower than weights
+	// change but faster than runaway; seconds to minutes in vivo.
+	METAPLASTICITY_DEFAULT_TIME_CONSTANT time.Duration = 10 * time.Second
+
+	// METAPLASTICITY_DEFAULT_MAX_SCALE bounds the LTP/LTD scaling factors
+	// to [1/4, 4].
+	METAPLASTICITY_DEFAULT_MAX_SCALE float64 = 4.0
+)
+
+// Consolidation (synaptic tagging and capture)
+const (
+	// CONSOLIDATION_DEFAULT_WEIGHT_THRESHOLD tags synapses potentiated to
+	// half of STDP_DEFAULT_MAX_WEIGHT.
+	CONSOLIDATION_DEFAULT_WEIGHT_THRESHOLD float64 = 1.0
+
+	// CONSOLIDATION_DEFAULT_DURATION is how long a tag must persist before
+	// capture. Late-phase LTP needs 1-3 hours in vitro; scaled down here.
+	CONSOLIDATION_DEFAULT_DURATION time.Duration = 60 * time.Second
+
+	// CONSOLIDATION_DEFAULT_ACTIVITY_WINDOW is the longest gap between
+	// plasticity events before the tag decays.
+	CONSOLIDATION_DEFAULT_ACTIVITY_WINDOW time.Duration = 10 * time.Second
+
+	// CONSOLIDATION_DEFAULT_PROTECTION_FACTOR scales learning once consolidated.
+	CONSOLIDATION_DEFAULT_PROTECTION_FACTOR float64 = 0.1
+)
+
+// Passive forgetting
+const (
+	// FORGETTING_DEFAULT_TIME_CONSTANT is the decay time constant of an
+	// unconsolidated weight. Early-phase LTP without protein synthesis
+	// decays within a few hours (Frey & Morris 1997: 3-6h).
+	FORGETTING_DEFAULT_TIME_CONSTANT time.Duration = 4 * time.Hour
+)
+
+// Silent synapse maturation
+const (
+	// MATURATION_DEFAULT_UNSILENCE_THRESHOLD is the net potentiation that
+	// unsilences a silent synapse, about seven causal pairings at the
+	// default STDP learning rate.
+	MATURATION_DEFAULT_UNSILENCE_THRESHOLD float64 = 0.02
+)
+
+// Behavioral-timescale plasticity (BTSP)
+const (
+	// BTSP_DEFAULT_LEARNING_RATE moves a weight 20% of the way to MaxWeight
+	// for a full kernel; a few plateaus establish a place field.
+	BTSP_DEFAULT_LEARNING_RATE float64 = 0.2
+
+	// BTSP_DEFAULT_TAU_BEFORE is the kernel decay for input before the
+	// plateau, fitted to CA1 recordings (Bittner et al. 2017: 1.31s).
+	BTSP_DEFAULT_TAU_BEFORE time.Duration = 1300 * time.Millisecond
+
+	// BTSP_DEFAULT_TAU_AFTER is the kernel decay for input after the
+	// plateau (Bittner et al. 2017: 0.69s).
+	BTSP_DEFAULT_TAU_AFTER time.Duration = 700 * time.Millisecond
+
+	// BTSP_KERNEL_CUTOFF ends the post-plateau window once the kernel has
+	// decayed below 1%.
+	BTSP_KERNEL_CUTOFF float64 = 0.01
+)
+
+// Inhibitory STDP (Vogels et al. 2011)
+const (
+	// ISTDP_DEFAULT_LEARNING_RATE matches the fast GABAergic preset's STDP
+	// learning rate.
+	ISTDP_DEFAULT_LEARNING_RATE float64 = 0.005
+
+	// ISTDP_DEFAULT_TARGET_RATE is the post-synaptic firing rate setpoint
+	// (Vogels et al. 2011: 5Hz).
+	ISTDP_DEFAULT_TARGET_RATE float64 = 5.0
+
+	// ISTDP_DEFAULT_TIME_CONSTANT is the decay of both spike traces
+	// (Vogels et al. 2011: 20ms).
+	ISTDP_DEFAULT_TIME_CONSTANT time.Duration = 20 * time.Millisecond
+)
+
+// Quantal release
+const (
+	// QUANTAL_DEFAULT_SITES is the readily-releasable pool of a typical
+	// cortical bouton (5-10 docked vesicles).
+	QUANTAL_DEFAULT_SITES int = 8
+
+	// QUANTAL_DEFAULT_RELEASE_PROBABILITY is the per-vesicle release
+	// probability of a moderately reliable cortical synapse.
+	QUANTAL_DEFAULT_RELEASE_PROBABILITY float64 = 0.5
+
+	// QUANTAL_DEFAULT_RECOVERY_TIME is the refill time constant of an empty
+	// release site (about 0.5-1s; Tsodyks & Markram 1997: 800ms).
+	QUANTAL_DEFAULT_RECOVERY_TIME time.Duration = 800 * time.Millisecond
+)
+
+// Conduction velocity and myelination
+const (
+	// CONDUCTION_UNMYELINATED_FACTOR is the myelination factor of a bare axon.
+	CONDUCTION_UNMYELINATED_FACTOR float64 = 1.0
+
+	// CONDUCTION_MAX_MYELINATION bounds the myelination factor. Saltatory
+	// conduction speeds axons up by roughly 5-50× over bare axons of similar
+	// diameter (0.5-2 m/s to 10-100 m/s).
+	CONDUCTION_MAX_MYELINATION float64 = 50.0
+)
@@ -0,0 +1,110 @@
+package synapse
+
+import (
+	"log/slog"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// DEAD TARGET DETECTION
+// =================================================================================
+//
+// A synapse whose post-synaptic neuron has been closed can no longer do
+// anything useful - biologically, a terminal whose target cell has died. On
+// the first transmission after the target closes, the synapse:
+//
+//  1. stops delivering (spikes are counted as dead-target drops),
+//  2. emits a SynapseDeadTarget event to its biological observer, and
+//  3. if dead-target pruning is enabled, detaches itself from the
+//     pre-synaptic neuron and reports ShouldPrune() == true so the matrix
+//     removes it during the next pruning pass.
+
+// closedChecker is implemented by neurons with explicit shutdown tracking.
+type closedChecker interface {
+	IsClosed() bool
+}
+
+// outputDetacher is implemented by neurons that can drop an output callback.
+type outputDetacher interface {
+	RemoveOutputCallback(synapseID string)
+}
+
+// SetBiologicalObserver registers an observer for synapse events such as
+// SynapseDeadTarget (nil disables emission).
+func (s *BasicSynapse) SetBiologicalObserver(observer types.BiologicalObserver) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.observer = observer
+}
+
+// SetPruneOnDeadTarget enables self-pruning once the target is found closed.
+func (s *BasicSynapse) SetPruneOnDeadTarget(enabled bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.pruneOnDeadTarget = enabled
+}
+
+// IsTargetDead reports whether the post-synaptic neuron was found closed.
+func (s *BasicSynapse) IsTargetDead() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.targetDead
+}
+
+// GetDeadTargetDrops returns the number of spikes not delivered because the
+// target was closed.
+func (s *BasicSynapse) GetDeadTargetDrops() int64 {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.deadTargetDrops
+}
+
+// targetClosed checks the post-synaptic neuron's lifecycle.
+func (s *BasicSynapse) targetClosed() bool {
+	if checker, ok := s.postSynapticNeuron.(closedChecker); ok {
+		return checker.IsClosed()
+	}
+	return s.postSynapticNeuron.State() == types.StateStopped
+}
+
+// handleDeadTarget records a dropped spike and, on first detection, emits the
+// event and optionally detaches the synapse. It returns true when the spike
+// must not be delivered.
+func (s *BasicSynapse) handleDeadTarget() bool {
+	if !s.targetClosed() {
+		return false
+	}
+
+	s.mutex.Lock()
+	s.deadTargetDrops++
+	firstDetection := !s.targetDead
+	s.targetDead = true
+	observer := s.observer
+	prune := s.pruneOnDeadTarget
+	s.mutex.Unlock()
+
+	if !firstDetection {
+		return true
+	}
+
+	s.logf(slog.LevelWarn, logging.RecordDiagnostic, "post-synaptic target closed; transmission stopped",
+		"self_pruning", prune)
+	if observer != nil {
+		observer.Emit(types.BiologicalEvent{
+			Timestamp:   s.now(),
+			EventType:   types.SynapseDeadTarget,
+			SourceID:    s.id,
+			TargetID:    s.postSynapticNeuron.ID(),
+			Description: "post-synaptic target closed; transmission stopped",
+			Data:        map[string]interface{}{"self_pruning": prune},
+		})
+	}
+	if prune {
+		if detacher, ok := s.preSynapticNeuron.(outputDetacher); ok {
+			detacher.RemoveOutputCallback(s.id)
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package synapse
+
+import (
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+)
+
+// =================================================================================
+// ENERGY ACCOUNTING
+// =================================================================================
+//
+// With an energy meter installed, each transmission that reaches the delivery
+// stage is charged to the synapse and its presynaptic neuron, and each
+// learning weight update (STDP or neuromodulation) to the synapse and its
+// postsynaptic neuron. Transmissions dropped by fault injection or stopped
+// at a closed target cost nothing.
+
+// SetEnergyMeter installs an energy meter (nil disables accounting).
+func (s *BasicSynapse) SetEnergyMeter(meter *energy.Meter) {
+	s.energyMeter.Store(meter)
+}
+
+// GetEnergyMeter returns the synapse's energy meter, or nil.
+func (s *BasicSynapse) GetEnergyMeter() *energy.Meter {
+	return s.energyMeter.Load()
+}
+
+// chargeTransmission charges one transmission if a meter is installed.
+func (s *BasicSynapse) chargeTransmission() {
+	if meter := s.energyMeter.Load(); meter != nil {
+		meter.ChargeTransmission(s.id, s.preSynapticNeuron.ID())
+	}
+}
+
+// chargePlasticity charges one weight update if a meter is installed.
+func (s *BasicSynapse) chargePlasticity() {
+	if meter := s.energyMeter.Load(); meter != nil {
+		meter.ChargePlasticity(s.id, s.postSynapticNeuron.ID())
+	}
+}
@@ -0,0 +1,212 @@
+package synapse
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// TRANSMISSION FAULT INJECTION
+// =================================================================================
+
+// FaultConfig describes probabilistic faults applied to transmitted spikes.
+// It is a robustness-testing tool: it lets users measure how learned circuits
+// degrade under synaptic noise, vesicle release failure or partial damage.
+//
+// Biological analogues:
+//   - Drop:      release failure (cortical synapses fail 50-90% of the time)
+//   - Duplicate: multivesicular release
+//   - Delay:     conduction slowing from demyelination
+//   - Corrupt:   quantal amplitude variability
+//
+// All probabilities are evaluated independently per transmission, in the order
+// drop, corrupt, delay, duplicate. A dropped spike is never duplicated.
+type FaultConfig struct {
+	DropProbability      float64       // Probability a spike is silently lost (0-1)
+	DuplicateProbability float64       // Probability a spike is delivered twice (0-1)
+	DelayProbability     float64       // Probability of additional delivery delay (0-1)
+	MaxExtraDelay        time.Duration // Upper bound of the uniform extra delay
+	CorruptProbability   float64       // Probability the spike amplitude is perturbed (0-1)
+	CorruptionMagnitude  float64       // Relative amplitude noise: value × (1 ± U(0, magnitude))
+	Seed                 int64         // RNG seed for reproducible runs (0 = time-based)
+}
+
+// FaultStats counts the faults applied by a synapse since injection was enabled.
+type FaultStats struct {
+	Transmissions int64 // Spikes that reached the fault injector
+	Dropped       int64 // Spikes dropped
+	Duplicated    int64 // Spikes delivered twice
+	Delayed       int64 // Spikes given extra delay
+	Corrupted     int64 // Spikes with perturbed amplitude
+}
+
+// faultInjector holds the per-synapse RNG and counters.
+type faultInjector struct {
+	config FaultConfig
+	rng    *rand.Rand
+	stats  FaultStats
+	mu     sync.Mutex
+}
+
+// validateFaultConfig rejects probabilities outside [0,1], negative bounds
+// and NaN, which every range comparison would let through.
+func validateFaultConfig(config FaultConfig) error {
+	probabilities := map[string]float64{
+		"drop":      config.DropProbability,
+		"duplicate": config.DuplicateProbability,
+		"delay":     config.DelayProbability,
+		"corrupt":   config.CorruptProbability,
+	}
+	for name, p := range probabilities {
+		if math.IsNaN(p) || p < 0 || p > 1 {
+			return fmt.Errorf("%s probability must be in [0,1]: %f", name, p)
+		}
+	}
+	if config.MaxExtraDelay < 0 {
+		return fmt.Errorf("max extra delay cannot be negative: %v", config.MaxExtraDelay)
+	}
+	if math.IsNaN(config.CorruptionMagnitude) || config.CorruptionMagnitude < 0 {
+		return fmt.Errorf("corruption magnitude cannot be negative: %f", config.CorruptionMagnitude)
+	}
+	return nil
+}
+
+// apply decides the fate of one spike. It returns the (possibly modified)
+// message and delay, and how many copies to deliver (0, 1 or 2).
+func (fi *faultInjector) apply(msg types.NeuralSignal, delay time.Duration) (types.NeuralSignal, time.Duration, int) {
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+
+	fi.stats.Transmissions++
+
+	if fi.rng.Float64() < fi.config.DropProbability {
+		fi.stats.Dropped++
+		return msg, delay, 0
+	}
+
+	if fi.rng.Float64() < fi.config.CorruptProbability {
+		noise := (fi.rng.Float64()*2 - 1) * fi.config.CorruptionMagnitude
+		msg.Value *= 1 + noise
+		fi.stats.Corrupted++
+	}
+
+	if fi.config.MaxExtraDelay > 0 && fi.rng.Float64() < fi.config.DelayProbability {
+		delay += time.Duration(fi.rng.Int63n(int64(fi.config.MaxExtraDelay) + 1))
+		fi.stats.Delayed++
+	}
+
+	copies := 1
+	if fi.rng.Float64() < fi.config.DuplicateProbability {
+		copies = 2
+		fi.stats.Duplicated++
+	}
+
+	return msg, delay, copies
+}
+
+// SetFaultInjection enables probabilistic transmission faults on this synapse,
+// replacing any previous configuration and resetting fault statistics.
+//
+// Parameters:
+//
+//	config: Fault probabilities and magnitudes
+//
+// Returns:
+//
+//	An error if the configuration is invalid
+func (s *BasicSynapse) SetFaultInjection(config FaultConfig) error {
+	if err := validateFaultConfig(config); err != nil {
+		return err
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	s.faults.Store(&faultInjector{
+		config: config,
+		rng:    rand.New(rand.NewSource(seed)),
+	})
+	return nil
+}
+
+// ClearFaultInjection disables fault injection, restoring normal transmission.
+func (s *BasicSynapse) ClearFaultInjection() {
+	s.faults.Store(nil)
+}
+
+// GetFaultStats returns fault counters since injection was last enabled.
+// Returns zero stats if injection is disabled.
+func (s *BasicSynapse) GetFaultStats() FaultStats {
+	injector := s.faults.Load()
+	if injector == nil {
+		return FaultStats{}
+	}
+	injector.mu.Lock()
+	defer injector.mu.Unlock()
+	return injector.stats
+}
+
+// FaultInjectable is implemented by synapses that support fault injection.
+type FaultInjectable interface {
+	SetFaultInjection(config FaultConfig) error
+	ClearFaultInjection()
+	GetFaultStats() FaultStats
+}
+
+// InjectFaults enables fault injection on every synapse accepted by selector
+// (nil selects all). Synapses that do not support injection are skipped.
+// Each synapse receives its own RNG; when config.Seed is set, seeds are
+// derived per synapse (see faultSeed) so runs stay reproducible.
+//
+// Returns:
+//
+//	The number of synapses configured, or an error for invalid configuration
+func InjectFaults(synapses []component.SynapticProcessor, config FaultConfig,
+	selector func(component.SynapticProcessor) bool) (int, error) {
+
+	if err := validateFaultConfig(config); err != nil {
+		return 0, err
+	}
+
+	configured := 0
+	for i, syn := range synapses {
+		if selector != nil && !selector(syn) {
+			continue
+		}
+		injectable, ok := syn.(FaultInjectable)
+		if !ok {
+			continue
+		}
+		perSynapse := config
+		if config.Seed != 0 {
+			perSynapse.Seed = faultSeed(config.Seed, i)
+		}
+		if err := injectable.SetFaultInjection(perSynapse); err != nil {
+			return configured, err
+		}
+		configured++
+	}
+	return configured, nil
+}
+
+// faultSeed derives the seed of the synapse at index from a base seed with
+// the splitmix64 finalizer. The result is never 0, which would select a
+// time-based seed and break reproducibility.
+func faultSeed(seed int64, index int) int64 {
+	z := uint64(seed) + uint64(index+1)*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	z ^= z >> 31
+	if z == 0 {
+		return 1
+	}
+	return int64(z)
+}
@@ -0,0 +1,186 @@
+package synapse
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// =================================================================================
+// PASSIVE FORGETTING - SLOW WEIGHT DECAY TOWARD A BASELINE
+// =================================================================================
+
+// Without activity a learned weight is kept forever, while behavioural
+// memories fade over hours to days unless they are rehearsed or consolidated.
+// Forgetting models that as a passive relaxation of the weight toward a
+// baseline, independent of STDP and of any spiking:
+//
+//	w(t) = Baseline + (w(t0) - Baseline) · exp(-(t - t0) / TimeConstant)
+//
+// A network holds many synapses and the timescale is hours, so there are no
+// per-synapse timers. The decay is applied lazily from an anchor: the weight,
+// the time it refers to and the effective time constant, replaced as a whole.
+// GetWeight, GetWeightVersion and Transmit compute the decayed weight from
+// the anchor without taking the synapse mutex, so the lock-free weight read
+// holds with forgetting enabled. Writers (plasticity, pruning, SetWeight)
+// settle the weight into storage under the mutex before changing it.
+// Settling is passive: it does not count as a weight change for
+// CompareAndSetWeight, audit or consolidation.
+//
+// Time always comes from Now (nil = time.Now), so a simulation can run hours
+// of virtual time in seconds. Spike and adjustment timestamps do not move the
+// forgetting clock. Consolidated synapses (see consolidation.go) forget
+// ProtectionFactor times as fast.
+
+// ForgettingConfig configures passive weight decay.
+type ForgettingConfig struct {
+	Enabled      bool             `json:"enabled"`
+	Baseline     float64          `json:"baseline"`      // Weight the synapse relaxes to (clamped to the plasticity bounds)
+	TimeConstant time.Duration    `json:"time_constant"` // Time for the distance to the baseline to fall to 1/e
+	Now          func() time.Time `json:"-"`             // Clock for lazy decay (nil = time.Now)
+}
+
+// forgettingTracker holds the decay anchor. Both the tracker and the anchor
+// pointers are read atomically; anchors are replaced under the synapse mutex.
+type forgettingTracker struct {
+	config ForgettingConfig
+	anchor atomic.Pointer[forgettingAnchor]
+}
+
+// forgettingAnchor is the immutable state the decay runs from, so lock-free
+// readers see weight, time and time constant together.
+type forgettingAnchor struct {
+	weight   float64
+	baseline float64   // Baseline clamped to the plasticity bounds
+	at       time.Time // Time weight refers to
+	tau      float64   // Effective time constant in nanoseconds (+Inf: no decay)
+}
+
+// weightAt returns the decayed weight at t; times before the anchor return
+// the anchored weight.
+func (a *forgettingAnchor) weightAt(t time.Time) float64 {
+	elapsed := t.Sub(a.at)
+	if elapsed <= 0 {
+		return a.weight
+	}
+	return a.baseline + (a.weight-a.baseline)*math.Exp(-float64(elapsed)/a.tau)
+}
+
+// CreateDefaultForgettingConfig returns an enabled configuration decaying
+// toward PRESET_CORTICAL_EXCITATORY_WEIGHT over FORGETTING_DEFAULT_TIME_CONSTANT.
+func CreateDefaultForgettingConfig() ForgettingConfig {
+	return ForgettingConfig{
+		Enabled:      true,
+		Baseline:     PRESET_CORTICAL_EXCITATORY_WEIGHT,
+		TimeConstant: FORGETTING_DEFAULT_TIME_CONSTANT,
+	}
+}
+
+// validateForgettingConfig checks an enabled configuration.
+func validateForgettingConfig(config ForgettingConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if math.IsNaN(config.Baseline) || math.IsInf(config.Baseline, 0) {
+		return fmt.Errorf("forgetting baseline must be finite: %f", config.Baseline)
+	}
+	if config.TimeConstant <= 0 {
+		return fmt.Errorf("forgetting time constant must be positive: %v", config.TimeConstant)
+	}
+	return nil
+}
+
+// now returns the tracker's current time.
+func (f *forgettingTracker) now() time.Time {
+	if f.config.Now != nil {
+		return f.config.Now()
+	}
+	return time.Now()
+}
+
+// SetForgetting enables (or, with Enabled false, disables) passive weight
+// decay. Decay starts from the current weight at the configured clock's now.
+func (s *BasicSynapse) SetForgetting(config ForgettingConfig) error {
+	if err := validateForgettingConfig(config); err != nil {
+		return fmt.Errorf("synapse %s: %w", s.id, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.settleForgettingUnsafe()
+	if !config.Enabled {
+		s.forgetting.Store(nil)
+		return nil
+	}
+	f := &forgettingTracker{config: config}
+	s.anchorForgettingUnsafe(f, s.loadWeight(), f.now())
+	s.forgetting.Store(f)
+	return nil
+}
+
+// GetForgettingConfig returns the forgetting configuration (Enabled is false
+// when it is off).
+func (s *BasicSynapse) GetForgettingConfig() ForgettingConfig {
+	if f := s.forgetting.Load(); f != nil {
+		return f.config
+	}
+	return ForgettingConfig{}
+}
+
+// currentWeight returns the weight with forgetting applied up to the clock's
+// current time, without taking the synapse mutex or storing anything.
+func (s *BasicSynapse) currentWeight() float64 {
+	f := s.forgetting.Load()
+	if f == nil {
+		return s.loadWeight()
+	}
+	return f.anchor.Load().weightAt(f.now())
+}
+
+// settleForgetting stores the decayed weight for readers that take the
+// synapse mutex. Does nothing when forgetting is disabled.
+func (s *BasicSynapse) settleForgetting() {
+	if s.forgetting.Load() == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.settleForgettingUnsafe()
+}
+
+// settleForgettingUnsafe stores the weight decayed to the clock's current
+// time and re-anchors it, picking up changed bounds or consolidation. The
+// version is not advanced (decay is not a write).
+// This method must be called with the synapse mutex held for writing.
+func (s *BasicSynapse) settleForgettingUnsafe() {
+	f := s.forgetting.Load()
+	if f == nil {
+		return
+	}
+	anchor := f.anchor.Load()
+	at := f.now()
+	if !at.After(anchor.at) {
+		at = anchor.at
+	}
+	weight := anchor.weightAt(at)
+	s.weightBits.Store(math.Float64bits(weight))
+	s.anchorForgettingUnsafe(f, weight, at)
+}
+
+// anchorForgettingUnsafe starts the decay of weight at time at with the
+// current bounds and consolidation state.
+// This method must be called with the synapse mutex held for writing.
+func (s *BasicSynapse) anchorForgettingUnsafe(f *forgettingTracker, weight float64, at time.Time) {
+	tau := float64(f.config.TimeConstant)
+	if s.consolidation != nil && s.consolidation.state.Consolidated {
+		// A zero protection factor stops forgetting (tau = +Inf)
+		tau /= s.consolidation.config.ProtectionFactor
+	}
+	f.anchor.Store(&forgettingAnchor{
+		weight:   weight,
+		baseline: math.Max(s.stdpConfig.MinWeight, math.Min(s.stdpConfig.MaxWeight, f.config.Baseline)),
+		at:       at,
+		tau:      tau,
+	})
+}
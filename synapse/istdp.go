@@ -0,0 +1,190 @@
+package synapse
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+)
+
+// =================================================================================
+// INHIBITORY SPIKE-TIMING DEPENDENT PLASTICITY (iSTDP)
+// =================================================================================
+
+// The classic STDP rule is asymmetric and was measured at excitatory
+// synapses; applied to an inhibitory synapse it strengthens inhibition onto
+// neurons that are already silenced and so does nothing to keep excitation
+// and inhibition balanced. Vogels et al. (2011) proposed a symmetric Hebbian
+// rule for inhibitory synapses that steers the post-synaptic neuron toward a
+// firing-rate setpoint ρ0, and with it balances E/I while excitatory
+// synapses learn. Each side keeps a spike trace (x_pre, x_post), incremented
+// by 1 per spike and decaying with TimeConstant τ:
+//
+//	pre spike:   Δ|w| = η (x_post - α)     α = 2 ρ0 τ
+//	post spike:  Δ|w| = η x_pre
+//
+// Near-coincident spikes in either order strengthen inhibition. Every pre
+// spike also weakens it by ηα, so a post-synaptic neuron firing above ρ0
+// gains inhibition and one firing below loses it, until it fires at ρ0.
+//
+// The rule changes the magnitude of the weight and keeps its sign (Dale's
+// law): inhibitory synapses may carry positive magnitudes (WithGABAergicFast)
+// or negative weights (network.DefaultColumnConnections), and either stays
+// inhibitory. The sign is taken when the rule is enabled. The rule replaces
+// pair-based STDP on the synapse, so ApplyPlasticity adjustments are
+// ignored. Spikes come from Transmit and RecordPreSpike on the pre-synaptic
+// side and from RecordPostSpike (called by firing neurons on their incoming
+// synapses) on the post-synaptic side, so the rule runs on virtual clocks.
+
+// InhibitorySTDPConfig configures the Vogels et al. (2011) rule.
+type InhibitorySTDPConfig struct {
+	Enabled      bool          `json:"enabled"`
+	LearningRate float64       `json:"learning_rate"` // η: magnitude change per unit trace
+	TargetRate   float64       `json:"target_rate"`   // ρ0: post-synaptic firing rate setpoint (Hz)
+	TimeConstant time.Duration `json:"time_constant"` // τ of both spike traces
+}
+
+// istdpTracker holds the spike traces. Guarded by the synapse mutex.
+type istdpTracker struct {
+	config    InhibitorySTDPConfig
+	alpha     float64   // Depression per pre-synaptic spike, 2 ρ0 τ
+	negative  bool      // Sign the weight keeps
+	pre, post float64   // Spike traces
+	updatedAt time.Time // Time the traces refer to
+}
+
+// CreateDefaultInhibitorySTDPConfig returns an enabled configuration with the
+// setpoint and trace time constant of Vogels et al. (2011).
+func CreateDefaultInhibitorySTDPConfig() InhibitorySTDPConfig {
+	return InhibitorySTDPConfig{
+		Enabled:      true,
+		LearningRate: ISTDP_DEFAULT_LEARNING_RATE,
+		TargetRate:   ISTDP_DEFAULT_TARGET_RATE,
+		TimeConstant: ISTDP_DEFAULT_TIME_CONSTANT,
+	}
+}
+
+// validateInhibitorySTDPConfig checks an enabled configuration.
+func validateInhibitorySTDPConfig(config InhibitorySTDPConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if math.IsNaN(config.LearningRate) || config.LearningRate <= 0 {
+		return fmt.Errorf("iSTDP learning rate must be positive: %f", config.LearningRate)
+	}
+	if math.IsNaN(config.TargetRate) || math.IsInf(config.TargetRate, 0) || config.TargetRate < 0 {
+		return fmt.Errorf("iSTDP target rate cannot be negative: %f", config.TargetRate)
+	}
+	if config.TimeConstant <= 0 {
+		return fmt.Errorf("iSTDP time constant must be positive: %v", config.TimeConstant)
+	}
+	return nil
+}
+
+// SetInhibitorySTDP selects (or, with Enabled false, deselects) the
+// inhibitory rule. The weight's current sign is kept from now on; a zero
+// weight is inhibitory-negative if the bounds allow no positive weight.
+// Traces start empty.
+func (s *BasicSynapse) SetInhibitorySTDP(config InhibitorySTDPConfig) error {
+	if err := validateInhibitorySTDPConfig(config); err != nil {
+		return fmt.Errorf("synapse %s: %w", s.id, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !config.Enabled {
+		s.istdp = nil
+		return nil
+	}
+	weight := s.loadWeight()
+	s.istdp = &istdpTracker{
+		config:   config,
+		alpha:    2 * config.TargetRate * config.TimeConstant.Seconds(),
+		negative: weight < 0 || (weight == 0 && s.stdpConfig.MaxWeight <= 0),
+	}
+	return nil
+}
+
+// GetInhibitorySTDPConfig returns the iSTDP configuration (Enabled is false
+// when it is off).
+func (s *BasicSynapse) GetInhibitorySTDPConfig() InhibitorySTDPConfig {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.istdp == nil {
+		return InhibitorySTDPConfig{}
+	}
+	return s.istdp.config
+}
+
+// recordISTDPPreSpikeUnsafe applies the pre-synaptic half of the rule at
+// time at and returns the update (nil if none). The synapse mutex must be held.
+func (s *BasicSynapse) recordISTDPPreSpikeUnsafe(at time.Time) *PlasticityRecord {
+	r := s.istdp
+	r.decayTo(at)
+	update := s.applyISTDPUnsafe(r.post-r.alpha, at)
+	r.pre++
+	return update
+}
+
+// recordISTDPPostSpikeUnsafe applies the post-synaptic half of the rule at
+// time at and returns the update (nil if none). The synapse mutex must be held.
+func (s *BasicSynapse) recordISTDPPostSpikeUnsafe(at time.Time) *PlasticityRecord {
+	r := s.istdp
+	r.decayTo(at)
+	update := s.applyISTDPUnsafe(r.pre, at)
+	r.post++
+	return update
+}
+
+// applyISTDPUnsafe changes the weight magnitude by η·drive, keeping the sign,
+// and returns the update (nil if the weight was left alone). The synapse
+// mutex must be held.
+func (s *BasicSynapse) applyISTDPUnsafe(drive float64, at time.Time) *PlasticityRecord {
+	if !s.stdpConfig.Enabled || drive == 0 {
+		return nil
+	}
+	r := s.istdp
+	oldWeight := s.loadWeight()
+	magnitude := math.Max(0, math.Abs(oldWeight)+r.config.LearningRate*s.learningRateScaleUnsafe()*drive)
+	newWeight := magnitude
+	if r.negative {
+		newWeight = -magnitude
+	}
+	newWeight = math.Max(s.stdpConfig.MinWeight, math.Min(s.stdpConfig.MaxWeight, newWeight))
+	if newWeight == oldWeight {
+		return nil
+	}
+
+	s.storeWeight(newWeight)
+	s.lastPlasticityEvent = s.now()
+	s.observeWeightChangeUnsafe(oldWeight, newWeight, at)
+	return newPlasticityRecord(AuditRuleInhibitorySTDP, at, oldWeight, newWeight)
+}
+
+// reportISTDP logs, charges and audits an iSTDP update. Must be called
+// without the synapse mutex held.
+func (s *BasicSynapse) reportISTDP(update *PlasticityRecord) {
+	if update == nil {
+		return
+	}
+	s.logf(slog.LevelDebug, logging.RecordPlasticity, "iSTDP weight updated",
+		"old_weight", update.OldWeight, "new_weight", update.NewWeight)
+	s.chargePlasticity()
+	s.auditChange(update)
+}
+
+// decayTo advances both traces to t. Out-of-order times are ignored.
+func (r *istdpTracker) decayTo(t time.Time) {
+	if r.updatedAt.IsZero() {
+		r.updatedAt = t
+		return
+	}
+	if elapsed := t.Sub(r.updatedAt); elapsed > 0 {
+		decay := math.Exp(-float64(elapsed) / float64(r.config.TimeConstant))
+		r.pre *= decay
+		r.post *= decay
+		r.updatedAt = t
+	}
+}
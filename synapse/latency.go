@@ -0,0 +1,233 @@
+package synapse
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// TRANSMISSION LATENCY INSTRUMENTATION
+// =================================================================================
+
+// STDP depends on spike timing differences of a few milliseconds. When the Go
+// scheduler is loaded, delayed deliveries can arrive noticeably later than the
+// configured synaptic delay, silently shifting every pre/post timing pair.
+// Latency tracking measures the actual delivery latency of each spike against
+// the delay the synapse requested, so this jitter can be detected.
+//
+// Tracking is opt-in: when disabled the transmission path pays a single atomic
+// load. When enabled, every delivery is routed through a lightweight probe that
+// timestamps arrival at the post-synaptic neuron.
+
+// latencyBucketBounds are the upper bounds of the jitter histogram buckets.
+// The last bucket is open-ended.
+var latencyBucketBounds = []time.Duration{
+	10 * time.Microsecond,
+	50 * time.Microsecond,
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	1 * time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+}
+
+// LatencyBucket is one bucket of the jitter histogram.
+type LatencyBucket struct {
+	UpperBound time.Duration `json:"upper_bound"` // Inclusive upper bound (0 = open-ended last bucket)
+	Count      int64         `json:"count"`       // Deliveries whose jitter fell in this bucket
+}
+
+// LatencyStats summarizes measured delivery latency for a synapse.
+// Latency is the time from Transmit handing off a spike to its arrival at the
+// post-synaptic neuron. Jitter is latency minus the requested delay.
+// Percentiles are computed over the most recent samples.
+type LatencyStats struct {
+	Samples         int64           `json:"samples"`          // Total deliveries measured
+	ConfiguredDelay time.Duration   `json:"configured_delay"` // Most recent requested delay
+	MeanLatency     time.Duration   `json:"mean_latency"`     // Mean over recent samples
+	P50Latency      time.Duration   `json:"p50_latency"`
+	P90Latency      time.Duration   `json:"p90_latency"`
+	P99Latency      time.Duration   `json:"p99_latency"`
+	MaxLatency      time.Duration   `json:"max_latency"`
+	MeanJitter      time.Duration   `json:"mean_jitter"`
+	P99Jitter       time.Duration   `json:"p99_jitter"`
+	MaxJitter       time.Duration   `json:"max_jitter"`
+	Histogram       []LatencyBucket `json:"histogram"` // Jitter distribution over all samples
+}
+
+// latencySample is one measured delivery.
+type latencySample struct {
+	latency time.Duration
+	jitter  time.Duration
+}
+
+// latencyTracker stores recent samples in a ring buffer plus a cumulative
+// jitter histogram.
+type latencyTracker struct {
+	samples         []latencySample
+	next            int
+	filled          bool
+	total           int64
+	buckets         []int64
+	configuredDelay time.Duration
+	mu              sync.Mutex
+}
+
+// newLatencyTracker creates a tracker retaining capacity recent samples.
+func newLatencyTracker(capacity int) *latencyTracker {
+	if capacity <= 0 {
+		capacity = LATENCY_DEFAULT_SAMPLE_CAPACITY
+	}
+	return &latencyTracker{
+		samples: make([]latencySample, capacity),
+		buckets: make([]int64, len(latencyBucketBounds)+1),
+	}
+}
+
+// record adds one measurement.
+func (lt *latencyTracker) record(latency, expected time.Duration) {
+	jitter := latency - expected
+
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	lt.samples[lt.next] = latencySample{latency: latency, jitter: jitter}
+	lt.next++
+	if lt.next == len(lt.samples) {
+		lt.next = 0
+		lt.filled = true
+	}
+	lt.total++
+	lt.configuredDelay = expected
+
+	bucket := len(latencyBucketBounds)
+	for i, bound := range latencyBucketBounds {
+		if jitter <= bound {
+			bucket = i
+			break
+		}
+	}
+	lt.buckets[bucket]++
+}
+
+// stats computes a summary snapshot.
+func (lt *latencyTracker) stats() LatencyStats {
+	lt.mu.Lock()
+	count := lt.next
+	if lt.filled {
+		count = len(lt.samples)
+	}
+	recent := make([]latencySample, count)
+	copy(recent, lt.samples[:count])
+	stats := LatencyStats{
+		Samples:         lt.total,
+		ConfiguredDelay: lt.configuredDelay,
+		Histogram:       make([]LatencyBucket, len(lt.buckets)),
+	}
+	for i, c := range lt.buckets {
+		if i < len(latencyBucketBounds) {
+			stats.Histogram[i].UpperBound = latencyBucketBounds[i]
+		}
+		stats.Histogram[i].Count = c
+	}
+	lt.mu.Unlock()
+
+	if count == 0 {
+		return stats
+	}
+
+	latencies := make([]time.Duration, count)
+	jitters := make([]time.Duration, count)
+	var latencySum, jitterSum time.Duration
+	for i, s := range recent {
+		latencies[i] = s.latency
+		jitters[i] = s.jitter
+		latencySum += s.latency
+		jitterSum += s.jitter
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	sort.Slice(jitters, func(i, j int) bool { return jitters[i] < jitters[j] })
+
+	stats.MeanLatency = latencySum / time.Duration(count)
+	stats.P50Latency = percentileDuration(latencies, 0.50)
+	stats.P90Latency = percentileDuration(latencies, 0.90)
+	stats.P99Latency = percentileDuration(latencies, 0.99)
+	stats.MaxLatency = latencies[count-1]
+	stats.MeanJitter = jitterSum / time.Duration(count)
+	stats.P99Jitter = percentileDuration(jitters, 0.99)
+	stats.MaxJitter = jitters[count-1]
+	return stats
+}
+
+// percentileDuration returns the nearest-rank percentile of sorted values.
+func percentileDuration(sorted []time.Duration, p float64) time.Duration {
+	index := int(p*float64(len(sorted))+0.5) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+// latencyProbe wraps the post-synaptic target for one delivery and records
+// its arrival time. Embedding the target keeps the full component interface.
+type latencyProbe struct {
+	component.MessageReceiver
+	tracker  *latencyTracker
+	sentAt   time.Time
+	expected time.Duration
+}
+
+// Receive records the measured latency and forwards to the real target.
+func (p *latencyProbe) Receive(msg types.NeuralSignal) {
+	p.tracker.record(time.Since(p.sentAt), p.expected)
+	p.MessageReceiver.Receive(msg)
+}
+
+// EnableLatencyTracking starts measuring delivery latency for this synapse,
+// discarding any previous measurements.
+//
+// Parameters:
+//
+//	capacity: Number of recent samples kept for percentiles (<=0 uses the default)
+func (s *BasicSynapse) EnableLatencyTracking(capacity int) {
+	s.latency.Store(newLatencyTracker(capacity))
+}
+
+// DisableLatencyTracking stops measuring latency and discards measurements.
+func (s *BasicSynapse) DisableLatencyTracking() {
+	s.latency.Store(nil)
+}
+
+// GetLatencyStats returns latency and jitter percentiles.
+// Returns zero stats if tracking is disabled.
+func (s *BasicSynapse) GetLatencyStats() LatencyStats {
+	tracker := s.latency.Load()
+	if tracker == nil {
+		return LatencyStats{}
+	}
+	return tracker.stats()
+}
+
+// deliveryTarget returns the receiver for one delivery, wrapped in a latency
+// probe when tracking is enabled.
+func (s *BasicSynapse) deliveryTarget(expected time.Duration) component.MessageReceiver {
+	tracker := s.latency.Load()
+	if tracker == nil {
+		return s.postSynapticNeuron
+	}
+	return &latencyProbe{
+		MessageReceiver: s.postSynapticNeuron,
+		tracker:         tracker,
+		sentAt:          time.Now(),
+		expected:        expected,
+	}
+}
@@ -0,0 +1,46 @@
+package synapse
+
+import (
+	"log/slog"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+)
+
+// =================================================================================
+// STRUCTURED LOGGING
+// =================================================================================
+//
+// A synapse logs through an optional slog.Handler. Records carry the synapse
+// ID and both endpoint neuron IDs, so logging.FilterComponents on a neuron ID
+// also selects the synapses attached to it:
+//
+//   - transmission (logging.LevelTrace): each spike passed on
+//   - plasticity (slog.LevelDebug): each STDP weight update
+//   - diagnostic (slog.LevelWarn): dead targets and other problems
+
+// SetLogHandler installs a structured log handler (nil disables logging).
+func (s *BasicSynapse) SetLogHandler(handler slog.Handler) {
+	var preID, postID string
+	if s.preSynapticNeuron != nil {
+		preID = s.preSynapticNeuron.ID()
+	}
+	if s.postSynapticNeuron != nil {
+		postID = s.postSynapticNeuron.ID()
+	}
+	s.logger.Store(logging.ForSynapse(handler, s.id, preID, postID))
+}
+
+// GetLogger returns the synapse's logger, or nil if logging is disabled.
+func (s *BasicSynapse) GetLogger() *slog.Logger {
+	return s.logger.Load()
+}
+
+// logf emits a record of the given kind at level.
+func (s *BasicSynapse) logf(level slog.Level, kind, msg string, args ...any) {
+	logging.Log(s.logger.Load(), level, kind, msg, args...)
+}
+
+// logEnabled reports whether records at level would be emitted.
+func (s *BasicSynapse) logEnabled(level slog.Level) bool {
+	return logging.Enabled(s.logger.Load(), level)
+}
@@ -0,0 +1,206 @@
+package synapse
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/logging"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// SYNAPSE MATURATION - SILENT, ACTIVE, CONSOLIDATED
+// =================================================================================
+//
+// Many newly formed glutamatergic synapses are silent (Isaac et al. 1995,
+// Liao et al. 1995): they contain NMDA receptors but no AMPA receptors. At
+// rest the NMDA channels are blocked by magnesium, so the synapse passes no
+// current, yet when its input coincides with post-synaptic firing driven by
+// other inputs, the NMDA receptors detect the coincidence and LTP inserts
+// AMPA receptors. The synapse is unsilenced and from then on transmits
+// normally. Silent synapses let development and synaptogenesis add contacts
+// without perturbing the circuit: only contacts whose activity already
+// predicts the target's firing start to drive it.
+//
+// A synapse moves through three stages:
+//
+//	silent --potentiation--> active --tagging and capture--> consolidated
+//
+// A silent synapse records its pre-synaptic spikes, eligibility and BTSP
+// input as usual, so every learning rule sees it, but delivers nothing. Its
+// weight is the strength AMPA insertion will express. Net potentiation from
+// STDP and BTSP is accumulated (depression subtracts, down to zero), and once
+// it reaches the unsilencing threshold the synapse becomes active. Active
+// synapses become consolidated when consolidation (see consolidation.go)
+// captures their tag. Synapses that never started silent begin as active.
+//
+// Every stage change is logged and emitted to the biological observer as a
+// SynapseUnsilenced or SynapseConsolidated event.
+
+// MaturationStage is a synapse's place in the silent → active →
+// consolidated lifecycle.
+type MaturationStage string
+
+const (
+	StageSilent       MaturationStage = "silent"       // NMDA-only: learns but does not transmit
+	StageActive       MaturationStage = "active"       // Transmits and learns normally
+	StageConsolidated MaturationStage = "consolidated" // Tag captured; learning is damped
+)
+
+// MaturationState describes a synapse's lifecycle.
+type MaturationState struct {
+	Stage        MaturationStage `json:"stage"`
+	Potentiation float64         `json:"potentiation"`  // Net potentiation while silent
+	Threshold    float64         `json:"threshold"`     // Potentiation that unsilences (0 = never silent)
+	SilentSpikes int64           `json:"silent_spikes"` // Spikes not delivered while silent
+	UnsilencedAt time.Time       `json:"unsilenced_at"` // When the synapse became active (zero = never silent or still silent)
+}
+
+// StageChange is one lifecycle transition.
+type StageChange struct {
+	From   MaturationStage
+	To     MaturationStage
+	Weight float64
+	At     time.Time
+}
+
+// maturationTracker holds the silent stage. Guarded by the synapse mutex.
+type maturationTracker struct {
+	state MaturationState
+}
+
+// StartSilent makes the synapse silent until its net potentiation reaches
+// threshold (0 selects MATURATION_DEFAULT_UNSILENCE_THRESHOLD). Restarting
+// an active or consolidated synapse silences it again with no potentiation.
+func (s *BasicSynapse) StartSilent(threshold float64) error {
+	if threshold < 0 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+		return fmt.Errorf("synapse %s: unsilencing threshold must be non-negative and finite: %f", s.id, threshold)
+	}
+	if threshold == 0 {
+		threshold = MATURATION_DEFAULT_UNSILENCE_THRESHOLD
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.maturation = &maturationTracker{state: MaturationState{Stage: StageSilent, Threshold: threshold}}
+	return nil
+}
+
+// GetMaturationStage returns the synapse's lifecycle stage.
+func (s *BasicSynapse) GetMaturationStage() MaturationStage {
+	return s.GetMaturationState().Stage
+}
+
+// GetMaturationState returns the lifecycle stage and unsilencing progress.
+func (s *BasicSynapse) GetMaturationState() MaturationState {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	var state MaturationState
+	if s.maturation != nil {
+		state = s.maturation.state
+	}
+	state.Stage = s.stageUnsafe()
+	return state
+}
+
+// IsSilent reports whether the synapse is still NMDA-only.
+func (s *BasicSynapse) IsSilent() bool {
+	return s.GetMaturationStage() == StageSilent
+}
+
+// stageUnsafe derives the stage. The synapse mutex must be held.
+func (s *BasicSynapse) stageUnsafe() MaturationStage {
+	switch {
+	case s.maturation != nil && s.maturation.state.Stage == StageSilent:
+		return StageSilent
+	case s.consolidation != nil && s.consolidation.state.Consolidated:
+		return StageConsolidated
+	}
+	return StageActive
+}
+
+// observeWeightChangeUnsafe advances unsilencing and consolidation after a
+// weight change at time at and queues the resulting stage changes. The
+// synapse mutex must be held.
+func (s *BasicSynapse) observeWeightChangeUnsafe(oldWeight, newWeight float64, at time.Time) {
+	before := s.stageUnsafe()
+	if m := s.maturation; m != nil && m.state.Stage == StageSilent {
+		m.state.Potentiation = math.Max(0, m.state.Potentiation+newWeight-oldWeight)
+		if m.state.Potentiation >= m.state.Threshold {
+			m.state.Stage = StageActive
+			m.state.UnsilencedAt = at
+		}
+	}
+	if s.consolidation != nil && before != StageSilent {
+		wasConsolidated := s.consolidation.state.Consolidated
+		s.consolidation.observe(newWeight, at)
+		if s.consolidation.state.Consolidated != wasConsolidated {
+			// Consolidated synapses forget more slowly from now on
+			s.settleForgettingUnsafe()
+		}
+	}
+	if after := s.stageUnsafe(); after != before {
+		s.stageChanges = append(s.stageChanges, StageChange{From: before, To: after, Weight: newWeight, At: at})
+	}
+}
+
+// silentSpikeUnsafe counts a spike held back by a silent synapse and reports
+// whether the synapse is silent. The synapse mutex must be held.
+func (s *BasicSynapse) silentSpikeUnsafe() bool {
+	if m := s.maturation; m != nil && m.state.Stage == StageSilent {
+		m.state.SilentSpikes++
+		return true
+	}
+	return false
+}
+
+// takeStageChangesUnsafe returns and clears the queued stage changes. The
+// synapse mutex must be held.
+func (s *BasicSynapse) takeStageChangesUnsafe() []StageChange {
+	changes := s.stageChanges
+	s.stageChanges = nil
+	return changes
+}
+
+// reportStageChanges logs and emits stage changes. Must be called without
+// the synapse mutex held.
+func (s *BasicSynapse) reportStageChanges(changes []StageChange) {
+	if len(changes) == 0 {
+		return
+	}
+	s.mutex.RLock()
+	observer := s.observer
+	s.mutex.RUnlock()
+
+	for _, change := range changes {
+		eventType, description := types.SynapseUnsilenced, "silent synapse unsilenced by potentiation"
+		if change.To == StageConsolidated {
+			eventType, description = types.SynapseConsolidated, "synaptic tag captured; synapse consolidated"
+		}
+		s.logf(slog.LevelInfo, logging.RecordPlasticity, description,
+			"from", string(change.From), "to", string(change.To), "weight", change.Weight)
+		if observer != nil {
+			observer.Emit(types.BiologicalEvent{
+				Timestamp:   change.At,
+				EventType:   eventType,
+				SourceID:    s.id,
+				TargetID:    s.postSynapticNeuron.ID(),
+				Description: description,
+				Data: map[string]interface{}{
+					"from":   string(change.From),
+					"to":     string(change.To),
+					"weight": change.Weight,
+				},
+			})
+		}
+	}
+}
+
+// Maturing is implemented by synapses with a silent → active → consolidated
+// lifecycle.
+type Maturing interface {
+	StartSilent(threshold float64) error
+	GetMaturationStage() MaturationStage
+}
@@ -0,0 +1,27 @@
+package synapse
+
+import (
+	"time"
+	"unsafe"
+
+	"github.com/SynapticNetworks/temporal-neuron/memory"
+)
+
+// =================================================================================
+// MEMORY ACCOUNTING
+// =================================================================================
+//
+// A synapse owns its struct (synapses) and the pre- and postsynaptic spike
+// times it keeps for STDP (spike history), counted at capacity.
+
+// MemoryUsage implements memory.Reporter.
+func (s *BasicSynapse) MemoryUsage() memory.Usage {
+	s.spikeTimingMutex.RLock()
+	history := int64(cap(s.preSpikeTimes) + cap(s.postSpikeTimes))
+	s.spikeTimingMutex.RUnlock()
+
+	return memory.Usage{
+		memory.CategorySynapses:     int64(unsafe.Sizeof(*s)),
+		memory.CategorySpikeHistory: history * int64(unsafe.Sizeof(time.Time{})),
+	}
+}
@@ -0,0 +1,178 @@
+package synapse
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// =================================================================================
+// METAPLASTICITY - BCM-LIKE SLIDING MODIFICATION THRESHOLD
+// =================================================================================
+
+// Pure pair-based STDP is unstable under varying input rates: a neuron driven
+// harder fires more, collects more causal pairs and keeps potentiating. In the
+// BCM theory (Bienenstock, Cooper & Munro 1982) the crossover between LTD and
+// LTP is not fixed but slides with the square of recent post-synaptic
+// activity, so an over-active neuron shifts towards depression and a silent
+// one towards potentiation.
+//
+// The synapse keeps an exponentially averaged estimate ν̄ of its
+// post-synaptic firing rate, fed by RecordPostSpike. The modification
+// threshold is θ_M = ν̄² / TargetRate, which equals TargetRate when the neuron
+// fires at its target. STDP contributions are then scaled by
+//
+//	LTP × TargetRate/θ_M   and   LTD × θ_M/TargetRate
+//
+// bounded to [1/MaxScale, MaxScale]. At the target rate the STDP rule is
+// unchanged; the asymmetry only moves when activity drifts. Rate estimates
+// use spike and adjustment timestamps, so the rule also works on virtual
+// clocks.
+
+// MetaplasticityConfig configures the sliding modification threshold.
+type MetaplasticityConfig struct {
+	Enabled      bool          `json:"enabled"`
+	TargetRate   float64       `json:"target_rate"`   // Post-synaptic rate (Hz) at which STDP is unmodified
+	TimeConstant time.Duration `json:"time_constant"` // Averaging window of the activity estimate
+	MaxScale     float64       `json:"max_scale"`     // Bound on the LTP/LTD scaling factors (>= 1)
+}
+
+// MetaplasticityState is a snapshot of the sliding threshold.
+type MetaplasticityState struct {
+	RateEstimate float64   `json:"rate_estimate"` // Averaged post-synaptic rate (Hz)
+	Threshold    float64   `json:"threshold"`     // Modification threshold θ_M (Hz)
+	LTPScale     float64   `json:"ltp_scale"`     // Current factor applied to potentiation
+	LTDScale     float64   `json:"ltd_scale"`     // Current factor applied to depression
+	UpdatedAt    time.Time `json:"updated_at"`    // Time of the last rate update
+}
+
+// metaplasticityTracker holds the activity estimate. Guarded by the synapse mutex.
+type metaplasticityTracker struct {
+	config    MetaplasticityConfig
+	rate      float64
+	updatedAt time.Time
+}
+
+// CreateDefaultMetaplasticityConfig returns an enabled configuration with
+// biologically typical values.
+func CreateDefaultMetaplasticityConfig() MetaplasticityConfig {
+	return MetaplasticityConfig{
+		Enabled:      true,
+		TargetRate:   METAPLASTICITY_DEFAULT_TARGET_RATE,
+		TimeConstant: METAPLASTICITY_DEFAULT_TIME_CONSTANT,
+		MaxScale:     METAPLASTICITY_DEFAULT_MAX_SCALE,
+	}
+}
+
+// validateMetaplasticityConfig checks an enabled configuration.
+func validateMetaplasticityConfig(config MetaplasticityConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if math.IsNaN(config.TargetRate) || config.TargetRate <= 0 {
+		return fmt.Errorf("metaplasticity target rate must be positive: %f", config.TargetRate)
+	}
+	if config.TimeConstant <= 0 {
+		return fmt.Errorf("metaplasticity time constant must be positive: %v", config.TimeConstant)
+	}
+	if math.IsNaN(config.MaxScale) || config.MaxScale < 1 {
+		return fmt.Errorf("metaplasticity max scale must be at least 1: %f", config.MaxScale)
+	}
+	return nil
+}
+
+// SetMetaplasticity enables (or, with Enabled false, disables) the sliding
+// modification threshold. The activity estimate starts at the target rate.
+func (s *BasicSynapse) SetMetaplasticity(config MetaplasticityConfig) error {
+	if err := validateMetaplasticityConfig(config); err != nil {
+		return fmt.Errorf("synapse %s: %w", s.id, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !config.Enabled {
+		s.metaplasticity = nil
+		return nil
+	}
+	s.metaplasticity = &metaplasticityTracker{config: config, rate: config.TargetRate}
+	return nil
+}
+
+// GetMetaplasticityConfig returns the metaplasticity configuration
+// (Enabled is false when it is off).
+func (s *BasicSynapse) GetMetaplasticityConfig() MetaplasticityConfig {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.metaplasticity == nil {
+		return MetaplasticityConfig{}
+	}
+	return s.metaplasticity.config
+}
+
+// GetMetaplasticityState returns the sliding threshold as of the last
+// post-synaptic spike or plasticity event, and false when metaplasticity is off.
+func (s *BasicSynapse) GetMetaplasticityState() (MetaplasticityState, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	m := s.metaplasticity
+	if m == nil {
+		return MetaplasticityState{}, false
+	}
+	ltp, ltd := m.scales()
+	return MetaplasticityState{
+		RateEstimate: m.rate,
+		Threshold:    m.threshold(),
+		LTPScale:     ltp,
+		LTDScale:     ltd,
+		UpdatedAt:    m.updatedAt,
+	}, true
+}
+
+// decayTo advances the activity estimate to t. Out-of-order times are ignored.
+func (m *metaplasticityTracker) decayTo(t time.Time) {
+	if m.updatedAt.IsZero() {
+		m.updatedAt = t
+		return
+	}
+	if elapsed := t.Sub(m.updatedAt); elapsed > 0 {
+		m.rate *= math.Exp(-float64(elapsed) / float64(m.config.TimeConstant))
+		m.updatedAt = t
+	}
+}
+
+// rateAt returns the activity estimate decayed to t without updating it.
+func (m *metaplasticityTracker) rateAt(t time.Time) float64 {
+	if elapsed := t.Sub(m.updatedAt); !m.updatedAt.IsZero() && elapsed > 0 {
+		return m.rate * math.Exp(-float64(elapsed)/float64(m.config.TimeConstant))
+	}
+	return m.rate
+}
+
+// recordSpike adds a post-synaptic spike at t to the rate estimate.
+func (m *metaplasticityTracker) recordSpike(t time.Time) {
+	m.decayTo(t)
+	m.rate += 1 / m.config.TimeConstant.Seconds()
+}
+
+// threshold returns θ_M = ν̄² / TargetRate.
+func (m *metaplasticityTracker) threshold() float64 {
+	return m.rate * m.rate / m.config.TargetRate
+}
+
+// scales returns the factors applied to potentiation and depression.
+func (m *metaplasticityTracker) scales() (ltp, ltd float64) {
+	ratio := m.threshold() / m.config.TargetRate
+	bound := m.config.MaxScale
+	ratio = math.Max(1/bound, math.Min(bound, ratio))
+	return 1 / ratio, ratio
+}
+
+// scale applies the sliding threshold to an STDP contribution at time t.
+func (m *metaplasticityTracker) scale(contribution float64, t time.Time) float64 {
+	m.decayTo(t)
+	ltp, ltd := m.scales()
+	if contribution > 0 {
+		return contribution * ltp
+	}
+	return contribution * ltd
+}
@@ -0,0 +1,129 @@
+package synapse
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// TRANSMISSION MIDDLEWARE
+// =================================================================================
+//
+// Middleware functions see every message a synapse transmits, after weight
+// and inhibition are applied and before fault injection and delivery. Each
+// returns the message to pass on, so a chain can log, record, scale or add
+// noise to transmission without subclassing BasicSynapse:
+//
+//	syn.UseMiddleware(synapse.ScaleMiddleware(0.5), func(msg types.NeuralSignal) types.NeuralSignal {
+//	    recorder.Add(msg)
+//	    return msg
+//	})
+//
+// Middleware runs on the transmitting goroutine with no synapse lock held, so
+// it may call back into the synapse. A synapse without middleware pays one
+// atomic load per transmission.
+
+// Middleware transforms a transmitted message.
+type Middleware func(msg types.NeuralSignal) types.NeuralSignal
+
+// UseMiddleware appends middleware to the synapse's chain. Middleware runs in
+// the order it was added.
+func (s *BasicSynapse) UseMiddleware(middleware ...Middleware) {
+	s.middlewareMutex.Lock()
+	defer s.middlewareMutex.Unlock()
+
+	chain := []Middleware{}
+	if current := s.middleware.Load(); current != nil {
+		chain = append(chain, *current...)
+	}
+	for _, mw := range middleware {
+		if mw != nil {
+			chain = append(chain, mw)
+		}
+	}
+	if len(chain) == 0 {
+		return
+	}
+	s.middleware.Store(&chain)
+}
+
+// ClearMiddleware removes all middleware.
+func (s *BasicSynapse) ClearMiddleware() {
+	s.middlewareMutex.Lock()
+	defer s.middlewareMutex.Unlock()
+	s.middleware.Store(nil)
+}
+
+// GetMiddlewareCount returns the length of the middleware chain.
+func (s *BasicSynapse) GetMiddlewareCount() int {
+	if chain := s.middleware.Load(); chain != nil {
+		return len(*chain)
+	}
+	return 0
+}
+
+// applyMiddleware runs the chain on msg.
+func (s *BasicSynapse) applyMiddleware(msg types.NeuralSignal) types.NeuralSignal {
+	chain := s.middleware.Load()
+	if chain == nil {
+		return msg
+	}
+	for _, mw := range *chain {
+		msg = mw(msg)
+	}
+	return msg
+}
+
+// MiddlewareUser is implemented by synapses that support middleware.
+type MiddlewareUser interface {
+	UseMiddleware(middleware ...Middleware)
+	ClearMiddleware()
+}
+
+// UseMiddlewareOn appends middleware to every synapse accepted by selector
+// (nil selects all), for example all synapses of a projection. Synapses that
+// do not support middleware are skipped. Returns the number configured.
+func UseMiddlewareOn(synapses []component.SynapticProcessor, selector func(component.SynapticProcessor) bool,
+	middleware ...Middleware) int {
+
+	configured := 0
+	for _, syn := range synapses {
+		if selector != nil && !selector(syn) {
+			continue
+		}
+		if user, ok := syn.(MiddlewareUser); ok {
+			user.UseMiddleware(middleware...)
+			configured++
+		}
+	}
+	return configured
+}
+
+// ScaleMiddleware multiplies every message value by factor.
+func ScaleMiddleware(factor float64) Middleware {
+	return func(msg types.NeuralSignal) types.NeuralSignal {
+		msg.Value *= factor
+		return msg
+	}
+}
+
+// NoiseMiddleware adds Gaussian noise with standard deviation stdDev to every
+// message value. A zero seed is time-based. The returned middleware may be
+// shared between synapses.
+func NoiseMiddleware(stdDev float64, seed int64) Middleware {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	var mu sync.Mutex
+	return func(msg types.NeuralSignal) types.NeuralSignal {
+		mu.Lock()
+		msg.Value += rng.NormFloat64() * stdDev
+		mu.Unlock()
+		return msg
+	}
+}
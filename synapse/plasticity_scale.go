@@ -0,0 +1,43 @@
+package synapse
+
+import (
+	"fmt"
+	"math"
+)
+
+// =================================================================================
+// PLASTICITY SCALE
+// =================================================================================
+//
+// How much a synapse can learn changes over development and with brain
+// state: sensory cortex is highly plastic during its critical period and
+// much less so in the adult. The plasticity scale multiplies the learning
+// rate of every weight-changing rule (STDP, including neuron-driven STDP
+// with its own learning rate, reward-modulated eligibility and BTSP), on
+// top of the protection of consolidated synapses. 1 leaves learning
+// unchanged and 0 stops it without touching the rules' configuration, so
+// schedules can drive it over simulated time (see network.PlasticitySchedule).
+
+// SetPlasticityScale sets the learning rate multiplier (default 1).
+func (s *BasicSynapse) SetPlasticityScale(scale float64) error {
+	if scale < 0 || math.IsNaN(scale) || math.IsInf(scale, 0) {
+		return fmt.Errorf("plasticity scale must be non-negative and finite: %f", scale)
+	}
+	s.plasticityScale.Store(&scale)
+	return nil
+}
+
+// GetPlasticityScale returns the learning rate multiplier.
+func (s *BasicSynapse) GetPlasticityScale() float64 {
+	if scale := s.plasticityScale.Load(); scale != nil {
+		return *scale
+	}
+	return 1
+}
+
+// PlasticityScalable is implemented by synapses whose learning rate can be
+// scaled.
+type PlasticityScalable interface {
+	SetPlasticityScale(scale float64) error
+	GetPlasticityScale() float64
+}
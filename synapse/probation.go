@@ -0,0 +1,84 @@
+package synapse
+
+import (
+	"fmt"
+	"time"
+)
+
+// =================================================================================
+// PRUNING HYSTERESIS AND PROBATION
+// =================================================================================
+//
+// With the plain criteria, ShouldPrune follows the weight from call to call: a
+// synapse that dips below the threshold for a moment, e.g. right after a
+// depressing pairing, is removed before it has a chance to recover. Real
+// terminals are not eliminated that quickly; weakened synapses are tagged
+// (complement C1q/C3 deposition) and only engulfed if they stay weak.
+//
+// When PruningConfig.ProbationPeriod is set, ShouldPrune therefore works in two
+// stages:
+//
+//  1. Mark: the first evaluation that meets the pruning criteria marks the
+//     synapse and returns false.
+//  2. Confirm: the synapse is pruned once it has stayed marked for
+//     ProbationPeriod. The mark is cleared as soon as the criteria no longer
+//     hold and the effective weight is back at ConfirmThreshold.
+//
+// A ConfirmThreshold above WeightThreshold is the hysteresis band: a synapse
+// hovering just above the mark threshold stays on probation, it must recover
+// clearly to be cleared. Neuromodulatory threshold modifiers shift both
+// thresholds. Recent activity protects a synapse at each evaluation but does
+// not clear its mark. Marks advance only when ShouldPrune is called, so the
+// structural-plasticity pass (e.g. neuron.PruneDysfunctionalSynapses) must run
+// repeatedly over the probation period. Dead-target pruning is immediate.
+
+// validatePruningProbation checks the hysteresis settings of a pruning
+// configuration.
+func validatePruningProbation(config PruningConfig) error {
+	if config.ProbationPeriod < 0 {
+		return fmt.Errorf("pruning probation period cannot be negative: %v", config.ProbationPeriod)
+	}
+	if config.ConfirmThreshold < 0 {
+		return fmt.Errorf("pruning confirm threshold cannot be negative: %f", config.ConfirmThreshold)
+	}
+	if config.ConfirmThreshold > 0 && config.ConfirmThreshold < config.WeightThreshold {
+		return fmt.Errorf("pruning confirm threshold %f is below the weight threshold %f",
+			config.ConfirmThreshold, config.WeightThreshold)
+	}
+	return nil
+}
+
+// GetPruningMark reports whether the synapse is on pruning probation and
+// since when.
+func (s *BasicSynapse) GetPruningMark() (time.Time, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.pruneMarkedAt, !s.pruneMarkedAt.IsZero()
+}
+
+// probationUnsafe turns a pruning candidate into a decision using the mark
+// and the probation period. threshold is the effective mark threshold.
+// Must be called with the mutex held.
+func (s *BasicSynapse) probationUnsafe(candidate bool, weight, threshold float64, now time.Time) bool {
+	cfg := s.pruningConfig
+	if cfg.ProbationPeriod <= 0 {
+		return candidate
+	}
+
+	if s.pruneMarkedAt.IsZero() {
+		if candidate {
+			s.pruneMarkedAt = now
+		}
+		return false
+	}
+
+	confirm := threshold
+	if cfg.ConfirmThreshold > 0 {
+		confirm += cfg.ConfirmThreshold - cfg.WeightThreshold
+	}
+	if !candidate && weight >= confirm {
+		s.pruneMarkedAt = time.Time{}
+		return false
+	}
+	return now.Sub(s.pruneMarkedAt) >= cfg.ProbationPeriod
+}
@@ -0,0 +1,183 @@
+package synapse
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// =================================================================================
+// QUANTAL RELEASE AND VESICLE POOL DYNAMICS
+// =================================================================================
+
+// Transmitter is released in quanta: each vesicle produces a miniature
+// post-synaptic potential of roughly fixed size, and an action potential
+// releases an integer number of vesicles (del Castillo & Katz 1954). Only a
+// small readily-releasable pool (RRP) is docked at the active zone; each
+// docked vesicle is released with probability p, and emptied sites refill
+// with a time constant of seconds. The model follows the binomial release
+// model with stochastic recovery (Vere-Jones 1966; Tsodyks & Markram 1997):
+//
+//   - On a spike, each of the n vesicles still available is released with
+//     probability ReleaseProbability, so released ~ Binomial(n, p).
+//   - Between spikes, each empty site refills with probability
+//     1 - exp(-Δt / RecoveryTime).
+//   - The transmitted amplitude is released × QuantalSize × weight.
+//
+// A rested synapse therefore transmits Sites × p × QuantalSize × weight on
+// average, with binomial trial-to-trial variability. At high rates the pool
+// empties faster than it refills and the amplitude depresses. Zero released
+// vesicles is a transmission failure: nothing is delivered.
+//
+// CreateDefaultQuantalConfig sets QuantalSize = 1 / (Sites × p), so the mean
+// rested amplitude equals the deterministic weight-scaled amplitude and
+// existing weights keep their meaning.
+
+// QuantalConfig configures quantal release.
+type QuantalConfig struct {
+	Enabled            bool          `json:"enabled"`
+	Sites              int           `json:"sites"`               // Readily-releasable pool size (vesicles)
+	ReleaseProbability float64       `json:"release_probability"` // Per-vesicle release probability (0-1]
+	QuantalSize        float64       `json:"quantal_size"`        // Amplitude of one vesicle, before weight scaling
+	RecoveryTime       time.Duration `json:"recovery_time"`       // Refill time constant of an empty site
+	Seed               int64         `json:"seed"`                // RNG seed (0 = time-based)
+}
+
+// QuantalState reports the vesicle pool and release counters.
+type QuantalState struct {
+	Available   int   `json:"available"`   // Docked vesicles after the last update
+	Sites       int   `json:"sites"`       // Pool size
+	Transmitted int64 `json:"transmitted"` // Spikes that reached the pool
+	Failures    int64 `json:"failures"`    // Spikes that released no vesicle
+	Released    int64 `json:"released"`    // Vesicles released in total
+}
+
+// quantalPool holds the vesicle pool. Guarded by the synapse mutex.
+type quantalPool struct {
+	config    QuantalConfig
+	rng       *rand.Rand
+	available int
+	updatedAt time.Time // Time of the last refill (zero = never)
+	stats     QuantalState
+}
+
+// CreateDefaultQuantalConfig returns an enabled configuration for a typical
+// cortical synapse, normalized so the rested mean amplitude equals the weight.
+func CreateDefaultQuantalConfig() QuantalConfig {
+	return QuantalConfig{
+		Enabled:            true,
+		Sites:              QUANTAL_DEFAULT_SITES,
+		ReleaseProbability: QUANTAL_DEFAULT_RELEASE_PROBABILITY,
+		QuantalSize:        1 / (float64(QUANTAL_DEFAULT_SITES) * QUANTAL_DEFAULT_RELEASE_PROBABILITY),
+		RecoveryTime:       QUANTAL_DEFAULT_RECOVERY_TIME,
+	}
+}
+
+// validateQuantalConfig checks an enabled configuration.
+func validateQuantalConfig(config QuantalConfig) error {
+	if !config.Enabled {
+		return nil
+	}
+	if config.Sites <= 0 {
+		return fmt.Errorf("quantal release needs at least one release site: %d", config.Sites)
+	}
+	if math.IsNaN(config.ReleaseProbability) || config.ReleaseProbability <= 0 || config.ReleaseProbability > 1 {
+		return fmt.Errorf("release probability must be in (0, 1]: %f", config.ReleaseProbability)
+	}
+	if math.IsNaN(config.QuantalSize) || math.IsInf(config.QuantalSize, 0) || config.QuantalSize <= 0 {
+		return fmt.Errorf("quantal size must be positive: %f", config.QuantalSize)
+	}
+	if config.RecoveryTime <= 0 {
+		return fmt.Errorf("vesicle recovery time must be positive: %v", config.RecoveryTime)
+	}
+	return nil
+}
+
+// SetQuantalRelease enables (or, with Enabled false, disables) quantal
+// release. The pool starts full.
+func (s *BasicSynapse) SetQuantalRelease(config QuantalConfig) error {
+	if err := validateQuantalConfig(config); err != nil {
+		return fmt.Errorf("synapse %s: %w", s.id, err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !config.Enabled {
+		s.quantal = nil
+		return nil
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	s.quantal = &quantalPool{
+		config:    config,
+		rng:       rand.New(rand.NewSource(seed)),
+		available: config.Sites,
+	}
+	return nil
+}
+
+// GetQuantalConfig returns the quantal release configuration (Enabled is
+// false when it is off).
+func (s *BasicSynapse) GetQuantalConfig() QuantalConfig {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.quantal == nil {
+		return QuantalConfig{}
+	}
+	return s.quantal.config
+}
+
+// GetQuantalState returns the vesicle pool and release counters. The second
+// result is false when quantal release is off.
+func (s *BasicSynapse) GetQuantalState() (QuantalState, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.quantal == nil {
+		return QuantalState{}, false
+	}
+	state := s.quantal.stats
+	state.Available = s.quantal.available
+	state.Sites = s.quantal.config.Sites
+	return state, true
+}
+
+// releaseQuantaUnsafe refills the pool up to now, releases vesicles for one
+// spike and returns the amplitude factor (released × QuantalSize) that
+// replaces the constant factor 1. The synapse mutex must be held.
+func (s *BasicSynapse) releaseQuantaUnsafe(now time.Time) float64 {
+	q := s.quantal
+	q.refill(now)
+
+	released := 0
+	for i := 0; i < q.available; i++ {
+		if q.rng.Float64() < q.config.ReleaseProbability {
+			released++
+		}
+	}
+	q.available -= released
+
+	q.stats.Transmitted++
+	q.stats.Released += int64(released)
+	if released == 0 {
+		q.stats.Failures++
+	}
+	return float64(released) * q.config.QuantalSize
+}
+
+// refill lets each empty site recover independently since the last update.
+func (q *quantalPool) refill(now time.Time) {
+	if !q.updatedAt.IsZero() && now.After(q.updatedAt) {
+		p := 1 - math.Exp(-now.Sub(q.updatedAt).Seconds()/q.config.RecoveryTime.Seconds())
+		for empty := q.config.Sites - q.available; empty > 0; empty-- {
+			if q.rng.Float64() < p {
+				q.available++
+			}
+		}
+	}
+	if now.After(q.updatedAt) {
+		q.updatedAt = now
+	}
+}
@@ -0,0 +1,194 @@
+package synapse
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/invariant"
+)
+
+// =================================================================================
+// SPIKE HISTORY RETENTION
+// =================================================================================
+
+// STDP pairs each spike with the spikes of the other side that are still
+// inside the plasticity window, and trace reconstruction sums the decayed
+// contributions of the retained spikes. Both need recent spikes exactly and
+// old spikes hardly at all, yet a history that keeps everything grows with
+// activity. A RetentionPolicy bounds the pre- and post-synaptic histories
+// with three independent rules, applied in order after every recorded spike:
+//
+//   - MaxAge drops spikes older than MaxAge, measured from the newest spike
+//     of the same side (or from the time passed to CompactSpikeHistory).
+//   - Exponential thinning keeps spikes older than ThinAfter only if they
+//     lie at least ThinningRatio × age after the previous kept spike.
+//     Resolution falls in proportion to age, so a history spanning time T
+//     keeps O(log T) old spikes, matching the exponential decay of their
+//     influence.
+//   - MaxCount keeps only the newest MaxCount spikes.
+//
+// A zero field disables its rule; the zero policy keeps every spike. The
+// default policy keeps the newest SPIKE_HISTORY_DEFAULT_MAX_COUNT spikes per
+// side, as synapses always have. Histories are compacted in place, so their
+// capacity stays bounded by what the policy retains.
+
+// RetentionPolicy bounds the spike histories of a synapse.
+type RetentionPolicy struct {
+	MaxCount      int           `json:"max_count"`      // Newest spikes kept per side (0 = unlimited)
+	MaxAge        time.Duration `json:"max_age"`        // Oldest spike kept, relative to the newest (0 = unlimited)
+	ThinAfter     time.Duration `json:"thin_after"`     // Age from which thinning applies
+	ThinningRatio float64       `json:"thinning_ratio"` // Minimum spacing of old spikes as a fraction of their age (0 = no thinning)
+}
+
+// RetentionStats counts the spikes compaction removed.
+type RetentionStats struct {
+	Compactions    int64 `json:"compactions"`      // Compactions that removed at least one spike
+	DroppedByAge   int64 `json:"dropped_by_age"`   // Spikes older than MaxAge
+	Thinned        int64 `json:"thinned"`          // Spikes removed by exponential thinning
+	DroppedByCount int64 `json:"dropped_by_count"` // Spikes beyond MaxCount
+	PreRetained    int   `json:"pre_retained"`     // Pre-synaptic spikes currently kept
+	PostRetained   int   `json:"post_retained"`    // Post-synaptic spikes currently kept
+}
+
+// Dropped returns the total number of spikes removed.
+func (rs RetentionStats) Dropped() int64 {
+	return rs.DroppedByAge + rs.Thinned + rs.DroppedByCount
+}
+
+// CreateDefaultRetentionPolicy returns the count limit synapses use by default.
+func CreateDefaultRetentionPolicy() RetentionPolicy {
+	return RetentionPolicy{MaxCount: SPIKE_HISTORY_DEFAULT_MAX_COUNT}
+}
+
+// validateRetentionPolicy checks a policy.
+func validateRetentionPolicy(policy RetentionPolicy) error {
+	if policy.MaxCount < 0 {
+		return fmt.Errorf("spike history max count cannot be negative: %d", policy.MaxCount)
+	}
+	if policy.MaxAge < 0 || policy.ThinAfter < 0 {
+		return fmt.Errorf("spike history ages cannot be negative: max age %v, thin after %v", policy.MaxAge, policy.ThinAfter)
+	}
+	if math.IsNaN(policy.ThinningRatio) || math.IsInf(policy.ThinningRatio, 0) || policy.ThinningRatio < 0 {
+		return fmt.Errorf("thinning ratio must be finite and non-negative: %f", policy.ThinningRatio)
+	}
+	return nil
+}
+
+// SetRetentionPolicy replaces the retention policy and compacts both
+// histories under it.
+func (s *BasicSynapse) SetRetentionPolicy(policy RetentionPolicy) error {
+	if err := validateRetentionPolicy(policy); err != nil {
+		return fmt.Errorf("synapse %s: %w", s.id, err)
+	}
+	s.spikeTimingMutex.Lock()
+	defer s.spikeTimingMutex.Unlock()
+	s.retention = policy
+	s.compactUnsafe(&s.preSpikeTimes, time.Time{})
+	s.compactUnsafe(&s.postSpikeTimes, time.Time{})
+	return nil
+}
+
+// GetRetentionPolicy returns the retention policy.
+func (s *BasicSynapse) GetRetentionPolicy() RetentionPolicy {
+	s.spikeTimingMutex.RLock()
+	defer s.spikeTimingMutex.RUnlock()
+	return s.retention
+}
+
+// GetRetentionStats returns the compaction counters and current history sizes.
+func (s *BasicSynapse) GetRetentionStats() RetentionStats {
+	s.spikeTimingMutex.RLock()
+	defer s.spikeTimingMutex.RUnlock()
+	stats := s.retentionStats
+	stats.PreRetained = len(s.preSpikeTimes)
+	stats.PostRetained = len(s.postSpikeTimes)
+	return stats
+}
+
+// CompactSpikeHistory compacts both histories with ages measured from now
+// rather than from the newest spike, so a synapse that has fallen silent
+// also sheds its old spikes. Returns the number of spikes removed.
+func (s *BasicSynapse) CompactSpikeHistory(now time.Time) int {
+	s.spikeTimingMutex.Lock()
+	defer s.spikeTimingMutex.Unlock()
+	return s.compactUnsafe(&s.preSpikeTimes, now) + s.compactUnsafe(&s.postSpikeTimes, now)
+}
+
+// recordSpikeUnsafe appends a spike to history and compacts it. Must be
+// called with spikeTimingMutex held.
+func (s *BasicSynapse) recordSpikeUnsafe(history *[]time.Time, at time.Time) {
+	*history = append(*history, at)
+	s.compactUnsafe(history, time.Time{})
+}
+
+// recordPreSpikeUnsafe records a pre-synaptic spike. Pre-synaptic spikes are
+// recorded by the synapse itself as they arrive, so unlike post-synaptic
+// spikes, which are reported through feedback and may arrive late, they are
+// asserted to be in time order. Must be called with spikeTimingMutex held.
+func (s *BasicSynapse) recordPreSpikeUnsafe(at time.Time) {
+	if invariant.Enabled && len(s.preSpikeTimes) > 0 {
+		invariant.SpikeOrder(s.id, s.preSpikeTimes[len(s.preSpikeTimes)-1], at)
+	}
+	s.recordSpikeUnsafe(&s.preSpikeTimes, at)
+}
+
+// compactUnsafe applies the retention policy to history in place, measuring
+// ages from now or, if now is zero, from the newest spike. Returns the
+// number of spikes removed. Must be called with spikeTimingMutex held.
+func (s *BasicSynapse) compactUnsafe(history *[]time.Time, now time.Time) int {
+	h := *history
+	if len(h) == 0 {
+		return 0
+	}
+	policy := s.retention
+	if now.IsZero() {
+		now = h[len(h)-1]
+	}
+
+	// Age: the history is in recording order, so old spikes form a prefix
+	byAge := 0
+	if policy.MaxAge > 0 {
+		for byAge < len(h) && now.Sub(h[byAge]) > policy.MaxAge {
+			byAge++
+		}
+	}
+	kept := h[byAge:]
+
+	// Thinning: walk from the oldest spike forward, keeping old spikes only
+	// if they are far enough from the last spike kept. Comparing with the
+	// older neighbour keeps the result stable as the history ages: when a
+	// spike is dropped, the gap of the next one grows.
+	thinned := 0
+	if policy.ThinningRatio > 0 && len(kept) > 1 {
+		write := 0
+		for read := 1; read < len(kept); read++ {
+			age := now.Sub(kept[read])
+			if age > policy.ThinAfter && float64(kept[read].Sub(kept[write])) < policy.ThinningRatio*float64(age) {
+				thinned++
+				continue
+			}
+			write++
+			kept[write] = kept[read]
+		}
+		kept = kept[:write+1]
+	}
+
+	// Count: keep the newest
+	byCount := 0
+	if policy.MaxCount > 0 && len(kept) > policy.MaxCount {
+		byCount = len(kept) - policy.MaxCount
+		kept = kept[byCount:]
+	}
+
+	removed := byAge + thinned + byCount
+	if removed == 0 {
+		return 0
+	}
+	*history = h[:copy(h, kept)]
+	s.retentionStats.Compactions++
+	s.retentionStats.DroppedByAge += int64(byAge)
+	s.retentionStats.Thinned += int64(thinned)
+	s.retentionStats.DroppedByCount += int64(byCount)
+	return removed
+}
@@ -0,0 +1,34 @@
+package synapse
+
+// =================================================================================
+// CONDUCTION BLOCK
+// =================================================================================
+//
+// Optogenetic and pharmacological experiments silence a pathway for a period
+// and observe what the rest of the circuit does without it. A silenced synapse
+// drops every spike before transmission: nothing is released, no plasticity
+// trace is updated and the post-synaptic neuron sees no input, as if the
+// action potential failed to invade the terminal. Unlike fault injection, the
+// block is deterministic and leaves the synapse state untouched, so releasing
+// it restores normal transmission exactly.
+
+// SetSilenced blocks (true) or restores (false) transmission.
+func (s *BasicSynapse) SetSilenced(silenced bool) {
+	s.silenced.Store(silenced)
+}
+
+// IsSilenced reports whether transmission is blocked.
+func (s *BasicSynapse) IsSilenced() bool {
+	return s.silenced.Load()
+}
+
+// GetSilencedSpikes returns the number of spikes dropped while silenced.
+func (s *BasicSynapse) GetSilencedSpikes() int64 {
+	return s.silencedSpikes.Load()
+}
+
+// Silenceable is implemented by synapses that support conduction block.
+type Silenceable interface {
+	SetSilenced(silenced bool)
+	IsSilenced() bool
+}
@@ -0,0 +1,67 @@
+package synapse
+
+import (
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// BATCHED SPIKE DELIVERY
+// =================================================================================
+//
+// A dense projection turns one pre-synaptic spike into one channel send per
+// synapse. A spike sink takes over delivery instead: Transmit still computes
+// the message and delay as usual, then hands both to the sink, which packs
+// the spikes of many synapses into one message for the receiving side (see
+// network.SpikeOutbox).
+//
+// Spikes bypass the sink when the synapse must see delivery itself: with
+// latency tracking enabled, or when the pre-synaptic neuron orders its
+// deliveries per target. A sink may also decline a spike, which is then
+// delivered directly.
+
+// SpikeSink accepts spikes for batched delivery.
+type SpikeSink interface {
+	// EnqueueSpike takes msg for delivery to msg.TargetID after delay. It
+	// returns false if the synapse should deliver the spike itself.
+	EnqueueSpike(msg types.NeuralSignal, delay time.Duration) bool
+}
+
+// spikeSinkRef boxes a sink for atomic storage.
+type spikeSinkRef struct {
+	sink SpikeSink
+}
+
+// SetSpikeSink routes the synapse's outgoing spikes through sink (nil =
+// direct delivery).
+func (s *BasicSynapse) SetSpikeSink(sink SpikeSink) {
+	if sink == nil {
+		s.spikeSink.Store(nil)
+		return
+	}
+	s.spikeSink.Store(&spikeSinkRef{sink: sink})
+}
+
+// GetSpikeSink returns the sink set by SetSpikeSink, or nil.
+func (s *BasicSynapse) GetSpikeSink() SpikeSink {
+	if ref := s.spikeSink.Load(); ref != nil {
+		return ref.sink
+	}
+	return nil
+}
+
+// sinkSpike offers a spike to the sink and reports whether it took it.
+func (s *BasicSynapse) sinkSpike(msg types.NeuralSignal, delay time.Duration) bool {
+	ref := s.spikeSink.Load()
+	if ref == nil || s.latency.Load() != nil || s.orderedDelivery() {
+		return false
+	}
+	return ref.sink.EnqueueSpike(msg, delay)
+}
+
+// SpikeSinkUser is implemented by synapses that support batched delivery.
+type SpikeSinkUser interface {
+	SetSpikeSink(sink SpikeSink)
+	GetSpikeSink() SpikeSink
+}
@@ -1,11 +1,17 @@
 package synapse
 
 import (
+	"fmt"
+	"log/slog"
 	"math"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+	"github.com/SynapticNetworks/temporal-neuron/invariant"
+	"github.com/SynapticNetworks/temporal-neuron/logging"
 	"github.com/SynapticNetworks/temporal-neuron/types"
 )
 
@@ -34,7 +40,7 @@ import (
 type BasicSynapse struct {
 	*component.BaseComponent
 	// === IDENTIFICATION ===
-	id string // Unique identifier for this synapse
+	id string // Unique identifier for this synapse (interned)
 
 	// === NETWORK CONNECTIONS ===
 	// These maintain references to the neurons this synapse connects
@@ -46,8 +52,19 @@ type BasicSynapse struct {
 
 	// === SYNAPTIC PROPERTIES ===
 	// These define the core transmission characteristics of the synapse
-	weight float64       // Current synaptic weight (the "strength" of the connection)
-	delay  time.Duration // Axonal + synaptic transmission delay
+	//
+	// The weight is stored as the IEEE-754 bit pattern of a float64 inside an
+	// atomic word (see loadWeight/storeWeight). Memory model:
+	//   - Readers (GetWeight, Transmit, monitoring) load atomically and never
+	//     take the mutex, so frequent polling cannot stall plasticity.
+	//   - Writers (ApplyPlasticity, ProcessNeuromodulation, SetWeight) still
+	//     hold the write lock so read-modify-write sequences are serialized,
+	//     then publish the result with a single atomic store.
+	// A reader therefore always observes either the old or the new weight,
+	// never a torn value, and the store happens-before any load that sees it.
+	weightBits    atomic.Uint64 // Current synaptic weight (the "strength" of the connection)
+	weightVersion atomic.Uint64 // Number of weight changes, for compare-and-set writers
+	delay         time.Duration // Axonal + synaptic transmission delay
 
 	// === PLASTICITY CONFIGURATION ===
 	// These control how the synapse learns and adapts over time
@@ -80,7 +97,8 @@ type BasicSynapse struct {
 	preSpikeTimes    []time.Time // Recent pre-synaptic spikes
 	postSpikeTimes   []time.Time // Recent post-synaptic spikes
 	spikeTimingMutex sync.RWMutex
-	maxSpikeHistory  int // How many recent spikes to keep (e.g., 20)
+	retention        RetentionPolicy // Bounds both histories (see retention.go)
+	retentionStats   RetentionStats  // Spikes removed by compaction
 
 	// === ACTIVITY TRACKING ===
 	// These track the synapse's recent activity for plasticity and pruning decisions
@@ -91,6 +109,91 @@ type BasicSynapse struct {
 	// These enable dynamic threshold adjustment based on neuromodulatory state
 	pruningThresholdModifier float64   // Temporary adjustment to pruning threshold (+ makes pruning more likely, - makes it less likely)
 	pruningModifierDecayTime time.Time // When the modifier should begin decaying back to baseline
+	pruneMarkedAt            time.Time // When the synapse was marked for pruning (zero = not marked, see probation.go)
+
+	// === FAULT INJECTION ===
+	// Optional robustness-testing faults applied during Transmit (nil = disabled)
+	faults atomic.Pointer[faultInjector]
+
+	// Conduction block for perturbation experiments (see silencing.go)
+	silenced       atomic.Bool
+	silencedSpikes atomic.Int64
+
+	// Learning rate multiplier (nil = 1, see plasticity_scale.go)
+	plasticityScale atomic.Pointer[float64]
+
+	// Nested pauses of all learning during external weight writes (see
+	// transaction.go)
+	plasticityPauses atomic.Int32
+
+	// Optional delivery latency instrumentation (nil = disabled)
+	latency atomic.Pointer[latencyTracker]
+
+	// Optional batched delivery of outgoing spikes (nil = direct, see
+	// spike_sink.go)
+	spikeSink atomic.Pointer[spikeSinkRef]
+
+	// Optional transmission middleware chain (nil = none)
+	middleware      atomic.Pointer[[]Middleware]
+	middlewareMutex sync.Mutex // Serializes chain updates
+
+	// Optional conduction-based delay (nil = fixed delay)
+	conduction *ConductionConfig
+
+	// Optional BCM-like sliding modification threshold (nil = disabled)
+	metaplasticity *metaplasticityTracker
+
+	// Optional synaptic tagging and capture (nil = disabled)
+	consolidation *consolidationTracker
+
+	// Optional passive decay toward a baseline (nil = disabled, see
+	// forgetting.go)
+	forgetting atomic.Pointer[forgettingTracker]
+
+	// Optional silent stage (nil = started active, see maturation.go)
+	maturation   *maturationTracker
+	stageChanges []StageChange // Lifecycle transitions not yet reported
+
+	// Optional behavioral-timescale plasticity (nil = disabled)
+	btsp *btspTracker
+
+	// Optional inhibitory STDP replacing pair-based STDP (nil = disabled,
+	// see istdp.go)
+	istdp *istdpTracker
+
+	// Optional quantal release from a finite vesicle pool (nil = disabled)
+	quantal *quantalPool
+
+	// Input port on the post-synaptic neuron ("" = soma), stamped on every
+	// delivered signal
+	targetPort string
+
+	// Delivery class under load (see SetPriority), stamped on every
+	// delivered signal
+	priority types.Priority
+
+	// Route index assigned by the post-synaptic neuron (0 = none), stamped
+	// on every delivered signal so the receiver skips the ID lookup
+	routeHandle atomic.Uint32
+
+	// Optional structured logging (nil = disabled)
+	logger atomic.Pointer[slog.Logger]
+
+	// Optional energy accounting (nil = disabled)
+	energyMeter atomic.Pointer[energy.Meter]
+
+	// Time source (nil = wall clock, see clock.go)
+	clock atomic.Pointer[func() time.Time]
+
+	// Optional plasticity audit sink (nil = disabled)
+	audit atomic.Pointer[auditTarget]
+
+	// === DEAD TARGET HANDLING ===
+	// Detects a closed post-synaptic neuron and optionally self-prunes
+	observer          types.BiologicalObserver // Receives SynapseDeadTarget events (nil = none)
+	pruneOnDeadTarget bool                     // Detach and report for pruning once the target is closed
+	targetDead        bool                     // Target was found closed
+	deadTargetDrops   int64                    // Spikes not delivered to the closed target
 
 	// === THREAD SAFETY ===
 	// A Read-Write mutex ensures thread-safe updates and reads of the synapse's state.
@@ -163,22 +266,24 @@ func NewBasicSynapseWithMatrix(id string, pre component.MessageScheduler, post c
 
 	now := time.Now()
 
-	return &BasicSynapse{
+	// The base component interns the ID; keep its shared copy
+	base := component.NewBaseComponent(id, types.TypeSynapse, synapsePosition)
+
+	synapse := &BasicSynapse{
 		// Initialize the embedded BaseComponent!
-		BaseComponent: component.NewBaseComponent(id, types.TypeSynapse, synapsePosition),
+		BaseComponent: base,
 
 		// Identity and connections
-		id:                 id,
+		id:                 base.ID(),
 		preSynapticNeuron:  pre,
 		postSynapticNeuron: post,
 
 		// Transmission properties
-		weight: initialWeight,
-		delay:  delay,
+		delay: delay,
 
-		preSpikeTimes:   make([]time.Time, 0, 20),
-		postSpikeTimes:  make([]time.Time, 0, 20),
-		maxSpikeHistory: 20,
+		preSpikeTimes:  make([]time.Time, 0, SPIKE_HISTORY_DEFAULT_MAX_COUNT),
+		postSpikeTimes: make([]time.Time, 0, SPIKE_HISTORY_DEFAULT_MAX_COUNT),
+		retention:      CreateDefaultRetentionPolicy(),
 
 		// Learning and plasticity configurations
 		stdpConfig:    stdpConfig,
@@ -214,6 +319,9 @@ func NewBasicSynapseWithMatrix(id string, pre component.MessageScheduler, post c
 
 		extracellularMatrix: extracellular,
 	}
+	synapse.storeWeight(initialWeight)
+
+	return synapse
 }
 
 // =================================================================================
@@ -249,55 +357,97 @@ func (s *BasicSynapse) ID() string {
 //
 // Enhanced version that accounts for GABA inhibition effects.
 func (s *BasicSynapse) Transmit(signalValue float64) {
-	//fmt.Printf("SYNAPSE DEBUG: Synapse %s received transmission signal of strength %.2f\n", s.id, signalValue)
+	// === CONDUCTION BLOCK ===
+	// A silenced synapse behaves as if the spike never reached its terminal
+	if s.silenced.Load() {
+		s.silencedSpikes.Add(1)
+		return
+	}
 
 	// === THREAD-SAFE STATE ACCESS ===
 	// Read current synapse state without holding lock during message delivery
 	s.mutex.RLock()
 
 	// Apply weight scaling (basic efficacy)
-	effectiveSignal := signalValue * s.weight
+	effectiveSignal := signalValue * s.currentWeight()
 
 	// Apply any active GABA inhibition
 	effectiveSignal *= (1.0 - s.getCurrentGABAInhibition())
 
 	baseSynapticDelay := s.delay // Base synaptic transmission delay
+	conductionTiming := s.conduction != nil
+	targetPort := s.targetPort
+	priority := s.priority
 	s.mutex.RUnlock()
 
 	// === ACTIVITY TRACKING FOR PLASTICITY ===
 	// Update last transmission time for pruning and plasticity decisions
 	s.mutex.Lock()
-	s.lastTransmission = time.Now() // TODO Clean up?
+	s.lastTransmission = s.now() // TODO Clean up?
+
+	// Bring a forgetting weight up to date before the learning rules read it
+	s.settleForgettingUnsafe()
 
 	// Create a small positive eligibility trace for pre-synaptic activity
 	s.updateEligibilityTrace(0.2)
+
+	// Feed behavioral-timescale plasticity (potentiates after a plateau)
+	var btspUpdate *PlasticityRecord
+	if s.btsp != nil {
+		btspUpdate = s.recordBTSPSpikeUnsafe(s.lastTransmission)
+	}
+
+	// Feed inhibitory STDP (depresses unless the target fired recently)
+	var istdpUpdate *PlasticityRecord
+	if s.istdp != nil {
+		istdpUpdate = s.recordISTDPPreSpikeUnsafe(s.lastTransmission)
+	}
+
+	// Draw the released vesicles; none released is a transmission failure
+	releaseFailed := false
+	if s.quantal != nil {
+		quanta := s.releaseQuantaUnsafe(s.lastTransmission)
+		effectiveSignal *= quanta
+		releaseFailed = quanta == 0
+	}
+
+	// Silent synapses learn from the spike but pass no current
+	silent := s.silentSpikeUnsafe()
+	stageChanges := s.takeStageChangesUnsafe()
 	s.mutex.Unlock()
+	s.reportBTSP(btspUpdate)
+	s.reportISTDP(istdpUpdate)
+	s.reportStageChanges(stageChanges)
 
-	// Record pre-synaptic spike
-	now := time.Now()
+	// Record pre-synaptic spike (timestamped under the lock, so concurrent
+	// transmissions are recorded in time order)
 	s.spikeTimingMutex.Lock()
-	s.preSpikeTimes = append(s.preSpikeTimes, now)
+	s.recordPreSpikeUnsafe(s.now())
+	s.spikeTimingMutex.Unlock()
 
-	// Maintain limited history size
-	if len(s.preSpikeTimes) > s.maxSpikeHistory {
-		s.preSpikeTimes = s.preSpikeTimes[len(s.preSpikeTimes)-s.maxSpikeHistory:]
+	if releaseFailed || silent {
+		return
 	}
-	s.spikeTimingMutex.Unlock()
 
 	// === MESSAGE CREATION ===
 	// Create neural signal with complete metadata for downstream processing
 	msg := types.NeuralSignal{
 		Value:     effectiveSignal,           // Signal scaled by synaptic weight and inhibition
-		Timestamp: time.Now(),                // When signal was generated by synapse
+		Timestamp: s.now(),                   // When signal was generated by synapse
 		SourceID:  s.preSynapticNeuron.ID(),  // Original sending neuron
 		SynapseID: s.id,                      // This synapse's identifier
 		TargetID:  s.postSynapticNeuron.ID(), // Intended receiving neuron
+		Port:      targetPort,                // Input port on the receiving neuron
+		Priority:  priority,                  // Delivery class under load
+
+		SynapseHandle:   s.routeHandle.Load(), // Receiver's route index for this synapse
+		SynapseIDHandle: s.IDHandle(),         // Receivers key their routes by the interned ID
 	}
 
 	// === DELAY CALCULATION ===
 	// Combine synaptic properties with spatial propagation delays
 	var totalDelay time.Duration
-	if s.extracellularMatrix != nil {
+	if s.extracellularMatrix != nil && !conductionTiming {
 		// ENHANCED DELAY: Synaptic + spatial components
 		// Models both neurotransmitter kinetics and axonal propagation distance
 		totalDelay = s.extracellularMatrix.SynapticDelay(
@@ -307,22 +457,74 @@ func (s *BasicSynapse) Transmit(signalValue float64) {
 			baseSynapticDelay,
 		)
 	} else {
-		// BASIC DELAY: Only synaptic properties
+		// BASIC DELAY: Only synaptic properties (conduction-based delays
+		// already include the axon)
 		totalDelay = baseSynapticDelay
 	}
 
+	// === MIDDLEWARE ===
+	// User-supplied logging, recording or signal transforms
+	msg = s.applyMiddleware(msg)
+
+	// === FAULT INJECTION ===
+	// Robustness testing: probabilistically drop, corrupt, delay or duplicate
+	copies := 1
+	if injector := s.faults.Load(); injector != nil {
+		msg, totalDelay, copies = injector.apply(msg, totalDelay)
+	}
+
+	// === DEAD TARGET CHECK ===
+	// Closed targets would only reject the spike; stop here instead
+	if s.handleDeadTarget() {
+		return
+	}
+
+	if s.logEnabled(logging.LevelTrace) {
+		s.logf(logging.LevelTrace, logging.RecordTransmission, "spike transmitted",
+			"input", signalValue, "effective", msg.Value, "delay", totalDelay, "copies", copies)
+	}
+
+	if copies > 0 {
+		s.chargeTransmission()
+	}
+	for i := 0; i < copies; i++ {
+		s.deliver(msg, totalDelay)
+	}
+}
+
+// deliver hands a message to the post-synaptic neuron, either immediately or
+// through the pre-synaptic neuron's delayed delivery queue.
+func (s *BasicSynapse) deliver(msg types.NeuralSignal, totalDelay time.Duration) {
 	// === MESSAGE DELIVERY STRATEGY ===
-	if totalDelay <= 0 {
+	if s.sinkSpike(msg, totalDelay) {
+		// BATCHED DELIVERY: The spike travels with others to the target's shard
+		return
+	}
+	if totalDelay <= 0 && !s.orderedDelivery() {
 		// IMMEDIATE DELIVERY: Zero delay, deliver directly to post-synaptic neuron
 		// This is the most common case for fast synapses
-		s.postSynapticNeuron.Receive(msg)
+		s.deliveryTarget(totalDelay).Receive(msg)
 	} else {
 		// Use neuron's centralized delay management
 		// No goroutines created here - neuron manages its own delivery queue
-		s.preSynapticNeuron.ScheduleDelayedDelivery(msg, s.postSynapticNeuron, totalDelay)
+		s.preSynapticNeuron.ScheduleDelayedDelivery(msg, s.deliveryTarget(totalDelay), totalDelay)
 	}
 }
 
+// orderedScheduler is implemented by pre-synaptic neurons that can enforce
+// per-target FIFO delivery (see neuron.SetFIFODelivery).
+type orderedScheduler interface {
+	FIFODelivery() bool
+}
+
+// orderedDelivery reports whether the pre-synaptic neuron orders its
+// deliveries. Zero-delay spikes then go through its queue as well, so they
+// cannot overtake delayed spikes to the same target.
+func (s *BasicSynapse) orderedDelivery() bool {
+	scheduler, ok := s.preSynapticNeuron.(orderedScheduler)
+	return ok && scheduler.FIFODelivery()
+}
+
 // ApplyPlasticity modifies the synapse's weight based on STDP rules.
 // This method implements the core learning mechanism that allows synapses
 // to strengthen or weaken based on the timing of pre- and post-synaptic activity.
@@ -340,11 +542,28 @@ func (s *BasicSynapse) Transmit(signalValue float64) {
 // The method is thread-safe and respects the STDP configuration parameters
 // to ensure biologically plausible learning dynamics.
 func (s *BasicSynapse) ApplyPlasticity(adjustment types.PlasticityAdjustment) {
+	// Plasticity record is emitted after the lock is released
+	var record []any
+	var audit *PlasticityRecord
+	var updated bool
+	var stageChanges []StageChange
+	defer func() {
+		if record != nil {
+			s.logf(slog.LevelDebug, logging.RecordPlasticity, "weight updated", record...)
+		}
+		if updated {
+			s.chargePlasticity()
+		}
+		s.auditChange(audit)
+		s.reportStageChanges(stageChanges)
+	}()
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	// Skip plasticity if STDP is disabled for this synapse
-	if !s.stdpConfig.Enabled {
+	// Skip plasticity if STDP is disabled for this synapse, or if it learns
+	// with the inhibitory rule from its own spike traces instead
+	if !s.stdpConfig.Enabled || s.istdp != nil {
 		return
 	}
 
@@ -352,6 +571,19 @@ func (s *BasicSynapse) ApplyPlasticity(adjustment types.PlasticityAdjustment) {
 	// Calculate the weight change based on spike timing
 	stdpContribution := s.calculateModulatedSTDPWeightChange(adjustment.DeltaT, s.stdpConfig)
 
+	// Activity-dependent rules use the adjustment's time (virtual clocks);
+	// forgetting runs on its own clock
+	at := adjustment.Timestamp
+	if at.IsZero() {
+		at = s.now()
+	}
+	s.settleForgettingUnsafe()
+
+	// Slide the LTP/LTD balance with recent post-synaptic activity
+	if s.metaplasticity != nil {
+		stdpContribution = s.metaplasticity.scale(stdpContribution, at)
+	}
+
 	// Apply immediate weight change (smaller effect without modulation)
 	modulationFactor := STDP_DEFAULT_MODULATION_FACTOR // Default factor for non-modulated plasticity
 
@@ -368,12 +600,15 @@ func (s *BasicSynapse) ApplyPlasticity(adjustment types.PlasticityAdjustment) {
 		learningRate = s.stdpConfig.LearningRate
 	}
 
+	// Consolidated synapses learn at a reduced rate
+	learningRate *= s.learningRateScaleUnsafe()
+
 	// Calculate weight change
 	weightDelta := learningRate * stdpContribution * modulationFactor
 
 	// Apply the weight change with boundary enforcement
-	//oldWeight := s.weight
-	newWeight := s.weight + weightDelta
+	oldWeight := s.loadWeight()
+	newWeight := oldWeight + weightDelta
 	if newWeight < s.stdpConfig.MinWeight {
 		newWeight = s.stdpConfig.MinWeight
 	} else if newWeight > s.stdpConfig.MaxWeight {
@@ -381,17 +616,26 @@ func (s *BasicSynapse) ApplyPlasticity(adjustment types.PlasticityAdjustment) {
 	}
 
 	// Apply the weight change and update tracking
-	s.weight = newWeight
-	s.lastPlasticityEvent = time.Now()
+	s.storeWeight(newWeight)
+	s.lastPlasticityEvent = s.now()
+	updated = true
+	s.observeWeightChangeUnsafe(oldWeight, newWeight, at)
+	stageChanges = s.takeStageChangesUnsafe()
+	if s.logEnabled(slog.LevelDebug) {
+		record = []any{"delta_t", adjustment.DeltaT, "old_weight", oldWeight, "new_weight", newWeight}
+	}
+	if s.auditing() {
+		audit = newPlasticityRecord(AuditRuleSTDP, at, oldWeight, newWeight).withSpikes(at.Add(adjustment.DeltaT), at)
+	}
 
 	// Update eligibility trace for future neuromodulation
 	// Calculate decay for existing trace
-	elapsed := time.Since(s.eligibilityTimestamp)
+	elapsed := s.now().Sub(s.eligibilityTimestamp)
 	decayFactor := math.Exp(-float64(elapsed) / float64(s.eligibilityDecay))
 
 	// Accumulate the trace - apply decay to existing and add new contribution
 	s.eligibilityTrace = s.eligibilityTrace*decayFactor + stdpContribution
-	s.eligibilityTimestamp = time.Now()
+	s.eligibilityTimestamp = s.now()
 }
 
 // calculateWeightDelta calculates a weight change consistently
@@ -429,11 +673,18 @@ func (s *BasicSynapse) calculateWeightDelta(contribution float64, learningRateOv
 //
 // Modified ShouldPrune function with improved GABA comparison
 func (s *BasicSynapse) ShouldPrune() bool {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// A synapse onto a closed neuron is removed when self-pruning is enabled,
+	// independently of the regular pruning criteria
+	if s.targetDead && s.pruneOnDeadTarget {
+		return true
+	}
 
 	// If pruning is disabled, never prune
 	if !s.pruningConfig.Enabled {
+		s.pruneMarkedAt = time.Time{}
 		return false
 	}
 
@@ -444,9 +695,10 @@ func (s *BasicSynapse) ShouldPrune() bool {
 		mostRecentActivity = s.lastTransmission
 	}
 
-	timeSinceActivity := time.Since(mostRecentActivity)
+	now := s.now()
+	timeSinceActivity := now.Sub(mostRecentActivity)
 	if timeSinceActivity < s.pruningConfig.InactivityThreshold/time.Duration(ACTIVITY_RESCUE_DIVISOR) {
-		return false // Recent activity provides protection
+		return false // Recent activity provides protection (a probation mark is kept)
 	}
 
 	// === WEIGHT EVALUATION ===
@@ -460,9 +712,19 @@ func (s *BasicSynapse) ShouldPrune() bool {
 		effectiveThreshold = PRUNING_THRESHOLD_MAX
 	}
 
-	// Include long-term GABA weakening effect on effective weight
-	effectiveWeight := s.weight - s.gabaLongTermWeakening
+	// Include long-term GABA weakening effect on effective weight (a
+	// forgotten weight can fall below the pruning threshold)
+	s.settleForgettingUnsafe()
+	effectiveWeight := s.loadWeight() - s.gabaLongTermWeakening
 
+	// === HYSTERESIS AND PROBATION ===
+	candidate := s.pruneCriteriaUnsafe(effectiveWeight, effectiveThreshold, timeSinceActivity)
+	return s.probationUnsafe(candidate, effectiveWeight, effectiveThreshold, now)
+}
+
+// pruneCriteriaUnsafe evaluates the pruning criteria for the effective weight
+// and threshold. Must be called with the mutex held.
+func (s *BasicSynapse) pruneCriteriaUnsafe(effectiveWeight, effectiveThreshold float64, timeSinceActivity time.Duration) bool {
 	// === PRUNING DECISION FACTORS ===
 	// 1. Weight-based pruning: Synapses significantly below threshold are pruned
 	if effectiveWeight < effectiveThreshold*0.5 {
@@ -543,16 +805,26 @@ func (s *BasicSynapse) ShouldPrune() bool {
 //
 //	The actual weight change that occurred
 func (s *BasicSynapse) ProcessNeuromodulation(ligandType types.LigandType, concentration float64) float64 {
+	var updated bool
+	var audit *PlasticityRecord
+	defer func() {
+		if updated {
+			s.chargePlasticity()
+		}
+		s.auditChange(audit)
+	}()
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+	s.settleForgettingUnsafe()
 
 	// Get current eligibility trace with decay
-	elapsed := time.Since(s.eligibilityTimestamp)
+	elapsed := s.now().Sub(s.eligibilityTimestamp)
 	decayFactor := math.Exp(-float64(elapsed) / float64(s.eligibilityDecay))
 	currentEligibility := s.eligibilityTrace * decayFactor
 
 	// Store original weight for calculating change
-	oldWeight := s.weight
+	oldWeight := s.loadWeight()
 
 	// Initialize weight change to zero
 	var weightDelta float64 = 0.0
@@ -582,10 +854,10 @@ func (s *BasicSynapse) ProcessNeuromodulation(ligandType types.LigandType, conce
 		// IMPORTANT: Calculate and apply weight change immediately for dopamine
 		// This ensures dopamine effects are properly applied
 		if math.Abs(currentEligibility) >= ELIGIBILITY_TRACE_THRESHOLD {
-			dopamineWeightDelta := s.stdpConfig.LearningRate * currentEligibility * modulationFactor
+			dopamineWeightDelta := s.stdpConfig.LearningRate * s.learningRateScaleUnsafe() * currentEligibility * modulationFactor
 
 			// Update weight with boundary enforcement
-			newWeight := s.weight + dopamineWeightDelta
+			newWeight := s.loadWeight() + dopamineWeightDelta
 			if newWeight < s.stdpConfig.MinWeight {
 				newWeight = s.stdpConfig.MinWeight
 			} else if newWeight > s.stdpConfig.MaxWeight {
@@ -593,12 +865,16 @@ func (s *BasicSynapse) ProcessNeuromodulation(ligandType types.LigandType, conce
 			}
 
 			// Apply the change
-			weightDelta = newWeight - s.weight // Store for return value
-			s.weight = newWeight               // Actually update the weight
+			weightDelta = newWeight - s.loadWeight() // Store for return value
+			s.storeWeight(newWeight)                 // Actually update the weight
+			updated = true
+			if s.auditing() {
+				audit = newPlasticityRecord(AuditRuleNeuromodulation, s.now(), oldWeight, newWeight).withModulator(ligandType, concentration)
+			}
 		}
 
 		// Skip the general weight update code since we already did it
-		return s.weight - oldWeight
+		return s.loadWeight() - oldWeight
 
 	case types.LigandGABA:
 		// GABA is inhibitory - it acts as a penalty signal (opposite of dopamine)
@@ -661,10 +937,10 @@ func (s *BasicSynapse) ProcessNeuromodulation(ligandType types.LigandType, conce
 	// Δw = learning_rate * eligibility_trace * modulation
 	if math.Abs(currentEligibility) >= ELIGIBILITY_TRACE_THRESHOLD {
 		// Calculate weight change
-		weightDelta = s.stdpConfig.LearningRate * currentEligibility * modulationFactor
+		weightDelta = s.stdpConfig.LearningRate * s.learningRateScaleUnsafe() * currentEligibility * modulationFactor
 
 		// Apply the weight change - create temporary variables for clarity
-		newWeight := s.weight + weightDelta
+		newWeight := s.loadWeight() + weightDelta
 
 		// Apply boundary enforcement
 		if newWeight < s.stdpConfig.MinWeight {
@@ -674,14 +950,18 @@ func (s *BasicSynapse) ProcessNeuromodulation(ligandType types.LigandType, conce
 		}
 
 		// Actually update the weight field
-		s.weight = newWeight
+		s.storeWeight(newWeight)
+		updated = true
+		if s.auditing() {
+			audit = newPlasticityRecord(AuditRuleNeuromodulation, s.now(), oldWeight, newWeight).withModulator(ligandType, concentration)
+		}
 	}
 
 	// Record plasticity event
-	s.lastPlasticityEvent = time.Now()
+	s.lastPlasticityEvent = s.now()
 
 	// Return actual weight change
-	return s.weight - oldWeight
+	return s.loadWeight() - oldWeight
 }
 
 // =================================================================================
@@ -700,10 +980,37 @@ func (s *BasicSynapse) ProcessNeuromodulation(ligandType types.LigandType, conce
 // - Learning progress analysis
 // - Debugging connectivity issues
 // - Research data collection
+//
+// The read is a single atomic load and never blocks on the synapse mutex, so
+// polling from monitoring tools does not contend with plasticity updates.
+// With forgetting enabled the decay is computed from an atomically published
+// anchor, still without the mutex (see forgetting.go).
 func (s *BasicSynapse) GetWeight() float64 {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-	return s.weight
+	return s.currentWeight()
+}
+
+// loadWeight atomically reads the current synaptic weight.
+// Safe to call with or without the synapse mutex held.
+func (s *BasicSynapse) loadWeight() float64 {
+	return math.Float64frombits(s.weightBits.Load())
+}
+
+// storeWeight atomically publishes a new synaptic weight and advances the
+// weight version when the value changes (see transaction.go).
+// Callers must hold the write lock so read-modify-write updates stay serialized.
+func (s *BasicSynapse) storeWeight(weight float64) {
+	invariant.Weight(s.id, weight, s.stdpConfig.MinWeight, s.stdpConfig.MaxWeight)
+	if s.weightBits.Swap(math.Float64bits(weight)) != math.Float64bits(weight) {
+		s.weightVersion.Add(1)
+	}
+	// Forgetting restarts from an explicitly written weight
+	if f := s.forgetting.Load(); f != nil {
+		at := f.now()
+		if anchor := f.anchor.Load(); at.Before(anchor.at) {
+			at = anchor.at
+		}
+		s.anchorForgettingUnsafe(f, weight, at)
+	}
 }
 
 // SetWeight provides a thread-safe way to manually set the synaptic weight.
@@ -720,11 +1027,21 @@ func (s *BasicSynapse) GetWeight() float64 {
 // - Simulating various learning scenarios
 //
 // The method enforces weight bounds to prevent values that could destabilize
-// the network or violate biological constraints.
+// the network or violate biological constraints. NaN is ignored, as it has
+// no place within the bounds.
 func (s *BasicSynapse) SetWeight(weight float64) {
+	if math.IsNaN(weight) {
+		return
+	}
+	var audit *PlasticityRecord
+	defer func() { s.auditChange(audit) }()
+
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
+	// The new weight replaces whatever has been forgotten until now
+	s.settleForgettingUnsafe()
+
 	// Enforce weight boundaries to maintain network stability
 	if weight < s.stdpConfig.MinWeight {
 		weight = s.stdpConfig.MinWeight
@@ -733,8 +1050,11 @@ func (s *BasicSynapse) SetWeight(weight float64) {
 	}
 
 	// Update the weight and record this as a plasticity event
-	s.weight = weight
-	s.lastPlasticityEvent = time.Now() // Reset activity tracking
+	if s.auditing() {
+		audit = newPlasticityRecord(AuditRuleSetWeight, s.now(), s.loadWeight(), weight)
+	}
+	s.storeWeight(weight)
+	s.lastPlasticityEvent = s.now() // Reset activity tracking
 }
 
 // GetDelay returns the current transmission delay for this synapse.
@@ -768,6 +1088,7 @@ func (s *BasicSynapse) SetDelay(delay time.Duration) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	s.delay = delay
+	s.conduction = nil // A fixed delay replaces conduction-based timing
 }
 
 // GetEligibilityTrace returns the current eligibility trace value
@@ -777,7 +1098,7 @@ func (s *BasicSynapse) GetEligibilityTrace() float64 {
 	defer s.mutex.RUnlock()
 
 	// Calculate decay since last update
-	elapsed := time.Since(s.eligibilityTimestamp)
+	elapsed := s.now().Sub(s.eligibilityTimestamp)
 	decayFactor := math.Exp(-float64(elapsed) / float64(s.eligibilityDecay))
 
 	return s.eligibilityTrace * decayFactor
@@ -812,6 +1133,29 @@ func (s *BasicSynapse) GetPlasticityConfig() types.PlasticityConfig {
 	}
 }
 
+// SetPlasticityConfig replaces the plasticity configuration, e.g. to switch
+// learning regimes between phases of an experiment. The current weight is
+// clamped to the new bounds.
+func (s *BasicSynapse) SetPlasticityConfig(config types.PlasticityConfig) error {
+	if math.IsNaN(config.MinWeight) || math.IsNaN(config.MaxWeight) || config.MinWeight > config.MaxWeight {
+		return fmt.Errorf("synapse %s: invalid weight bounds [%f, %f]", s.id, config.MinWeight, config.MaxWeight)
+	}
+	if math.IsNaN(config.LearningRate) || config.LearningRate < 0 {
+		return fmt.Errorf("synapse %s: learning rate cannot be negative: %f", s.id, config.LearningRate)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.settleForgettingUnsafe()
+	s.stdpConfig = config
+	if weight := s.loadWeight(); weight < config.MinWeight {
+		s.storeWeight(config.MinWeight)
+	} else if weight > config.MaxWeight {
+		s.storeWeight(config.MaxWeight)
+	}
+	return nil
+}
+
 // UpdateWeight applies plasticity events to modify synaptic strength
 func (s *BasicSynapse) UpdateWeight(event types.PlasticityEvent) {
 	adjustment := types.PlasticityAdjustment{
@@ -833,16 +1177,17 @@ func (s *BasicSynapse) UpdateWeight(event types.PlasticityEvent) {
 // This method provides read-only access to activity metrics for monitoring
 // and analysis purposes using a proper struct instead of a map.
 func (s *BasicSynapse) GetActivityInfo() types.ActivityInfo {
+	s.settleForgetting()
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
-	now := time.Now()
+	now := s.now()
 
 	return types.ActivityInfo{
 		ComponentID:           s.id,
 		LastTransmission:      s.lastTransmission,
 		LastPlasticity:        s.lastPlasticityEvent,
-		Weight:                s.weight,
+		Weight:                s.loadWeight(),
 		ActivityLevel:         0.0, // TODO: Calculate actual activity level
 		TimeSinceTransmission: now.Sub(s.lastTransmission),
 		TimeSincePlasticity:   now.Sub(s.lastPlasticityEvent),
@@ -864,7 +1209,7 @@ func (s *BasicSynapse) GetActivityInfo() types.ActivityInfo {
 func (s *BasicSynapse) IsActiveInWindow(threshold time.Duration) bool {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
-	return time.Since(s.lastTransmission) <= threshold
+	return s.now().Sub(s.lastTransmission) <= threshold
 }
 
 // IsActive returns true if the synapse is considered generally active.
@@ -875,7 +1220,7 @@ func (s *BasicSynapse) IsActive() bool {
 	defer s.mutex.RUnlock()
 	// Use a sensible default threshold, perhaps from constants.go or a configurable field.
 	// For example, SYNAPSE_ACTIVITY_THRESHOLD (defined in synapse/constants.go)
-	return time.Since(s.lastTransmission) <= SYNAPSE_ACTIVITY_THRESHOLD
+	return s.now().Sub(s.lastTransmission) <= SYNAPSE_ACTIVITY_THRESHOLD
 }
 
 // GetLastActivity returns the timestamp of the most recent activity
@@ -912,7 +1257,7 @@ func (s *BasicSynapse) GetPostsynapticID() string {
 // updateEligibilityTrace updates the eligibility trace with a new contribution
 // while handling decay of the existing trace
 func (s *BasicSynapse) updateEligibilityTrace(contribution float64) {
-	now := time.Now()
+	now := s.now()
 
 	// Calculate decay since last update
 	elapsed := now.Sub(s.eligibilityTimestamp)
@@ -927,7 +1272,7 @@ func (s *BasicSynapse) updateEligibilityTrace(contribution float64) {
 // with decay applied since the last update
 func (s *BasicSynapse) getCurrentGABAInhibition() float64 {
 	// Calculate decay since last GABA application
-	elapsed := time.Since(s.gabaTimestamp)
+	elapsed := s.now().Sub(s.gabaTimestamp)
 	decayFactor := math.Exp(-float64(elapsed) / float64(s.gabaDecayTime))
 
 	// Apply exponential decay
@@ -949,7 +1294,7 @@ func (s *BasicSynapse) applyGABAInhibition(concentration float64) {
 	}
 
 	// Update timestamp and increment exposure count
-	s.gabaTimestamp = time.Now()
+	s.gabaTimestamp = s.now()
 	s.gabaExposureCount++
 }
 
@@ -981,16 +1326,16 @@ func (s *BasicSynapse) applyGABALongTermWeakening(concentration float64) {
 
 	// Cap the weakening effect to prevent complete silencing
 	// The cap depends on the current weight to maintain biological plausibility
-	maxWeakening := s.weight * GABA_MAX_WEAKENING_RATIO
+	maxWeakening := s.loadWeight() * GABA_MAX_WEAKENING_RATIO
 	if newWeakening > maxWeakening {
 		newWeakening = maxWeakening
 	}
 
 	s.gabaLongTermWeakening = newWeakening
-	s.gabaLongTermRecoveryTime = time.Now()
+	s.gabaLongTermRecoveryTime = s.now()
 
 	// Reset exposure count if it's been a long time since the last exposure
-	elapsedSinceRecovery := time.Since(s.gabaLongTermRecoveryTime)
+	elapsedSinceRecovery := s.now().Sub(s.gabaLongTermRecoveryTime)
 	if elapsedSinceRecovery > GABA_RECOVERY_THRESHOLD {
 		s.gabaExposureCount = 1 // Reset but count this exposure
 
@@ -1002,7 +1347,7 @@ func (s *BasicSynapse) applyGABALongTermWeakening(concentration float64) {
 // updateGABASTDPModulation sets GABA's modulatory effect on STDP
 func (s *BasicSynapse) updateGABASTDPModulation(concentration float64) {
 	// Calculate decay since last update
-	elapsed := time.Since(s.gabaSTDPTimestamp)
+	elapsed := s.now().Sub(s.gabaSTDPTimestamp)
 	decayFactor := math.Exp(-float64(elapsed) / float64(s.gabaSTDPDecayTime))
 
 	// Decay existing modulation and add new contribution
@@ -1015,7 +1360,7 @@ func (s *BasicSynapse) updateGABASTDPModulation(concentration float64) {
 	}
 
 	// Update timestamp
-	s.gabaSTDPTimestamp = time.Now()
+	s.gabaSTDPTimestamp = s.now()
 
 	// Calculate specific STDP effects
 	s.stdpWindowNarrowing = s.gabaSTDPModulation * GABA_STDP_MAX_WINDOW_NARROWING
@@ -1025,7 +1370,7 @@ func (s *BasicSynapse) updateGABASTDPModulation(concentration float64) {
 // getCurrentGABASTDPModulation returns the current GABA STDP modulation with decay
 func (s *BasicSynapse) getCurrentGABASTDPModulation() (modulation, windowNarrowing, asymmetryModulation float64) {
 	// Calculate decay since last update
-	elapsed := time.Since(s.gabaSTDPTimestamp)
+	elapsed := s.now().Sub(s.gabaSTDPTimestamp)
 	decayFactor := math.Exp(-float64(elapsed) / float64(s.gabaSTDPDecayTime))
 
 	// Apply decay to current modulation
@@ -1070,7 +1415,7 @@ func (s *BasicSynapse) calculateModulatedSTDPWeightChange(timeDifference time.Du
 // lowering the threshold.
 func (s *BasicSynapse) adjustPruningThreshold(adjustment float64) {
 	// Check if previous modulation has started decaying
-	elapsed := time.Since(s.pruningModifierDecayTime)
+	elapsed := s.now().Sub(s.pruningModifierDecayTime)
 	if elapsed > PRUNING_MODIFIER_DECAY_THRESHOLD {
 		// Allow decay of previous modulation before adding new one
 		s.pruningThresholdModifier *= PRUNING_MODIFIER_DECAY_RATE
@@ -1087,7 +1432,7 @@ func (s *BasicSynapse) adjustPruningThreshold(adjustment float64) {
 	}
 
 	// Update decay time
-	s.pruningModifierDecayTime = time.Now()
+	s.pruningModifierDecayTime = s.now()
 }
 
 // =================================================================================
@@ -1124,9 +1469,6 @@ func calculateSTDPWeightChange(timeDifference time.Duration, config types.Plasti
 	deltaTNs := timeDifference.Nanoseconds()
 	deltaTMs := float64(deltaTNs) / float64(time.Millisecond.Nanoseconds())
 
-	// Debug print
-	//fmt.Printf("STDP CALC: timeDifference=%v, nanoseconds=%d, deltaTMs=%.6f\n", timeDifference, deltaTNs, deltaTMs)
-
 	windowMs := float64(config.WindowSize.Nanoseconds()) / float64(time.Millisecond.Nanoseconds())
 
 	// Check if the timing difference is within the STDP window
@@ -1162,17 +1504,90 @@ func (s *BasicSynapse) GetLastTransmissionTime() time.Time {
 }
 
 func (s *BasicSynapse) RecordPostSpike(time time.Time) {
-	//fmt.Printf("SYNAPSE RECORDING: %s recording post-spike at %v\n", s.id, time)
+	s.spikeTimingMutex.Lock()
+	s.recordSpikeUnsafe(&s.postSpikeTimes, time)
+	s.spikeTimingMutex.Unlock()
 
+	// Feed the metaplasticity activity estimate (after releasing the timing
+	// lock: GetSynapseInfo acquires mutex before spikeTimingMutex)
+	var update *PlasticityRecord
+	s.mutex.Lock()
+	if s.metaplasticity != nil {
+		s.metaplasticity.recordSpike(time)
+	}
+	if s.istdp != nil {
+		update = s.recordISTDPPostSpikeUnsafe(time)
+	}
+	s.mutex.Unlock()
+	s.reportISTDP(update)
+}
+
+// RecordPreSpike records a pre-synaptic spike at the given time without
+// transmitting, for simulations that run on a virtual clock. The spike feeds
+// the spike history and behavioral-timescale plasticity like a Transmit.
+func (s *BasicSynapse) RecordPreSpike(at time.Time) {
 	s.spikeTimingMutex.Lock()
-	defer s.spikeTimingMutex.Unlock()
+	s.recordPreSpikeUnsafe(at)
+	s.spikeTimingMutex.Unlock()
+
+	var update, istdpUpdate *PlasticityRecord
+	s.mutex.Lock()
+	s.settleForgettingUnsafe()
+	if s.btsp != nil {
+		update = s.recordBTSPSpikeUnsafe(at)
+	}
+	if s.istdp != nil {
+		istdpUpdate = s.recordISTDPPreSpikeUnsafe(at)
+	}
+	s.mutex.Unlock()
+	s.reportBTSP(update)
+	s.reportISTDP(istdpUpdate)
+}
 
-	s.postSpikeTimes = append(s.postSpikeTimes, time)
+// SetTargetPort selects the input port of the post-synaptic neuron this
+// synapse contacts ("" = soma), e.g. "apical" or "modulatory".
+func (s *BasicSynapse) SetTargetPort(port string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.targetPort = port
+}
 
-	// Maintain limited history size
-	if len(s.postSpikeTimes) > s.maxSpikeHistory {
-		s.postSpikeTimes = s.postSpikeTimes[len(s.postSpikeTimes)-s.maxSpikeHistory:]
+// GetTargetPort returns the post-synaptic input port ("" = soma).
+func (s *BasicSynapse) GetTargetPort() string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.targetPort
+}
+
+// SetPriority sets the delivery class of this synapse's signals. Receivers
+// deliver critical pathways ahead of other input and shed background input
+// first when they fall behind.
+func (s *BasicSynapse) SetPriority(priority types.Priority) error {
+	if err := priority.Validate(); err != nil {
+		return err
 	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.priority = priority
+	return nil
+}
+
+// GetPriority returns the delivery class of this synapse's signals.
+func (s *BasicSynapse) GetPriority() types.Priority {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.priority
+}
+
+// SetRouteHandle stores the route index the post-synaptic neuron assigned to
+// this synapse (0 clears it). Delivered signals carry it as SynapseHandle.
+func (s *BasicSynapse) SetRouteHandle(handle uint32) {
+	s.routeHandle.Store(handle)
+}
+
+// GetRouteHandle returns the route index assigned by the post-synaptic neuron.
+func (s *BasicSynapse) GetRouteHandle() uint32 {
+	return s.routeHandle.Load()
 }
 
 // GetPreSpikeTimes returns a copy of pre-synaptic spike times
@@ -1197,6 +1612,7 @@ func (s *BasicSynapse) GetPostSpikeTimes() []time.Time {
 
 // GetSynapseInfo returns information about the synapse including spike history
 func (s *BasicSynapse) GetSynapseInfo() types.SynapseInfo {
+	s.settleForgetting()
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
@@ -1212,7 +1628,7 @@ func (s *BasicSynapse) GetSynapseInfo() types.SynapseInfo {
 		ID:               s.id,
 		SourceID:         s.preSynapticNeuron.ID(),
 		TargetID:         s.postSynapticNeuron.ID(),
-		Weight:           s.weight,
+		Weight:           s.loadWeight(),
 		LastActivity:     lastPreSpikeTime,   // Use most recent pre-spike
 		LastTransmission: s.lastTransmission, // Keep this for compatibility
 	}
@@ -0,0 +1,141 @@
+package synapse
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestAudit_RecordsCausesAndLinks verifies that every rule records its
+// cause and that records of one synapse are chained through Previous.
+func TestAudit_RecordsCausesAndLinks(t *testing.T) {
+	log := NewAuditLog(0)
+	syn, err := NewSynapse("audited", NewMockNeuron("audit_pre"), NewMockNeuron("audit_post"),
+		WithWeight(0.5), WithAuditSink(log))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	post := time.Unix(50, 0)
+	syn.ApplyPlasticity(types.PlasticityAdjustment{DeltaT: -10 * time.Millisecond, LearningRate: 0.05, Timestamp: post})
+	syn.ProcessNeuromodulation(types.LigandDopamine, 2.0)
+	syn.SetWeight(0.7)
+
+	records := log.Synapse("audited")
+	if len(records) != 3 {
+		t.Fatalf("Expected 3 records, got %d: %+v", len(records), records)
+	}
+
+	stdp := records[0]
+	if stdp.Rule != AuditRuleSTDP || stdp.PreID != "audit_pre" || stdp.PostID != "audit_post" {
+		t.Errorf("Expected an STDP record between the mock neurons, got %+v", stdp)
+	}
+	if !stdp.PostSpike.Equal(post) || !stdp.PreSpike.Equal(post.Add(-10*time.Millisecond)) || stdp.DeltaT != -10*time.Millisecond {
+		t.Errorf("Expected the triggering spike pair, got pre %v post %v Δt %v", stdp.PreSpike, stdp.PostSpike, stdp.DeltaT)
+	}
+	if stdp.OldWeight != 0.5 || stdp.Change() <= 0 {
+		t.Errorf("Expected potentiation from 0.5, got %f -> %f", stdp.OldWeight, stdp.NewWeight)
+	}
+
+	reward := records[1]
+	if reward.Rule != AuditRuleNeuromodulation || reward.Modulator != types.LigandDopamine || reward.Concentration != 2.0 {
+		t.Errorf("Expected a dopamine record, got %+v", reward)
+	}
+	if reward.OldWeight != stdp.NewWeight {
+		t.Errorf("Expected the dopamine change to start at %f, got %f", stdp.NewWeight, reward.OldWeight)
+	}
+
+	manual := records[2]
+	if manual.Rule != AuditRuleSetWeight || manual.NewWeight != 0.7 {
+		t.Errorf("Expected a SetWeight record to 0.7, got %+v", manual)
+	}
+
+	history := log.History(manual.Seq)
+	if len(history) != 3 || history[0].Seq != manual.Seq || history[2].Seq != stdp.Seq || history[2].Previous != 0 {
+		t.Errorf("Expected the chain back to the STDP record, got %+v", history)
+	}
+}
+
+// TestAudit_BTSPRecordsSpikeAndPlateau verifies that BTSP records name the
+// input spike and the plateau, and that synapses sharing a log keep
+// separate chains.
+func TestAudit_BTSPRecordsSpikeAndPlateau(t *testing.T) {
+	log := NewAuditLog(0)
+	first := btspSynapse(t, "btsp_a")
+	second := btspSynapse(t, "btsp_b")
+	synapses := []component.SynapticProcessor{first, second}
+	if n := SetAuditSinkOn(synapses, nil, log); n != 2 {
+		t.Fatalf("Expected 2 synapses configured, got %d", n)
+	}
+
+	plateau := time.Unix(100, 0)
+	first.RecordPreSpike(plateau.Add(-time.Second))
+	second.RecordPreSpike(plateau.Add(-500 * time.Millisecond))
+	first.ApplyPlateau(plateau)
+	second.ApplyPlateau(plateau)
+	first.RecordPreSpike(plateau.Add(time.Second))
+
+	records := log.Synapse("btsp_a")
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 BTSP records, got %+v", records)
+	}
+	before, after := records[0], records[1]
+	if before.Rule != AuditRuleBTSP || before.DeltaT != -time.Second || !before.PostSpike.Equal(plateau) {
+		t.Errorf("Expected the plateau record to pair the spike 1s before, got %+v", before)
+	}
+	if after.DeltaT != time.Second || !after.Time.Equal(plateau.Add(time.Second)) {
+		t.Errorf("Expected the later spike 1s after the plateau, got %+v", after)
+	}
+	if after.Previous != before.Seq {
+		t.Errorf("Expected the record to link to %d, got %d", before.Seq, after.Previous)
+	}
+	if other := log.Synapse("btsp_b"); len(other) != 1 || other[0].Previous != 0 {
+		t.Errorf("Expected one unlinked record for btsp_b, got %+v", other)
+	}
+
+	// Removing the sink stops recording
+	SetAuditSinkOn(synapses, nil, nil)
+	first.SetWeight(0.2)
+	if log.Total() != 3 || first.GetAuditSink() != nil {
+		t.Errorf("Expected no records after the sink was removed, got %d", log.Total())
+	}
+}
+
+// TestAudit_RingAndWriter verifies ring retention and the JSON-lines round
+// trip.
+func TestAudit_RingAndWriter(t *testing.T) {
+	log := NewAuditLog(3)
+	var buf bytes.Buffer
+	writer := NewAuditWriter(&buf)
+	previous := uint64(0)
+	for i := 0; i < 5; i++ {
+		record := PlasticityRecord{SynapseID: "ring", Rule: AuditRuleSetWeight, Previous: previous, NewWeight: float64(i)}
+		previous = log.Append(record)
+		writer.Append(record)
+	}
+
+	records := log.Records()
+	if len(records) != 3 || records[0].Seq != 3 || records[2].Seq != 5 || log.Total() != 5 {
+		t.Fatalf("Expected records 3-5 of 5, got %+v", records)
+	}
+	if _, ok := log.Lookup(2); ok {
+		t.Error("Expected overwritten record 2 to be gone")
+	}
+	if record, ok := log.Lookup(4); !ok || record.NewWeight != 3 {
+		t.Errorf("Expected record 4 with weight 3, got %+v (%v)", record, ok)
+	}
+	if history := log.History(5); len(history) != 3 {
+		t.Errorf("Expected history to stop at the retained records, got %d", len(history))
+	}
+
+	read, err := ReadAuditRecords(&buf)
+	if err != nil || writer.Err() != nil {
+		t.Fatalf("Unexpected error: %v / %v", err, writer.Err())
+	}
+	if len(read) != 5 || read[4].Seq != 5 || read[4].Previous != 4 || read[4].Rule != AuditRuleSetWeight {
+		t.Errorf("Expected 5 records read back, got %+v", read)
+	}
+}
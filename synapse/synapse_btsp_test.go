@@ -0,0 +1,106 @@
+package synapse
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// btspSynapse creates a synapse with default BTSP at weight 0.5.
+func btspSynapse(t *testing.T, id string) *BasicSynapse {
+	syn, err := NewSynapse(id, NewMockNeuron(id+"_pre"), NewMockNeuron(id+"_post"),
+		WithWeight(0.5), WithBTSP(CreateDefaultBTSPConfig()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return syn
+}
+
+// preSpikeAt feeds a pre-synaptic spike at a virtual time into the rule,
+// as Transmit does with the wall clock.
+func preSpikeAt(syn *BasicSynapse, at time.Time) {
+	syn.mutex.Lock()
+	update := syn.recordBTSPSpikeUnsafe(at)
+	syn.mutex.Unlock()
+	syn.reportBTSP(update)
+}
+
+// TestBTSP_AsymmetricKernel verifies that a plateau potentiates inputs
+// active seconds before and after it, favouring inputs before the plateau.
+func TestBTSP_AsymmetricKernel(t *testing.T) {
+	plateau := time.Unix(100, 0)
+	maxWeight := btspSynapse(t, "probe").GetPlasticityConfig().MaxWeight
+	expected := func(offset, tau time.Duration) float64 {
+		return BTSP_DEFAULT_LEARNING_RATE * math.Exp(-offset.Seconds()/tau.Seconds()) * (maxWeight - 0.5)
+	}
+
+	before := btspSynapse(t, "before")
+	preSpikeAt(before, plateau.Add(-time.Second))
+	before.ApplyPlateau(plateau)
+	gainBefore := before.GetWeight() - 0.5
+	if math.Abs(gainBefore-expected(time.Second, BTSP_DEFAULT_TAU_BEFORE)) > 1e-9 {
+		t.Errorf("Expected gain %g for input 1s before, got %g", expected(time.Second, BTSP_DEFAULT_TAU_BEFORE), gainBefore)
+	}
+
+	after := btspSynapse(t, "after")
+	after.ApplyPlateau(plateau)
+	if after.GetWeight() != 0.5 {
+		t.Fatalf("Expected no change without earlier input, got %f", after.GetWeight())
+	}
+	preSpikeAt(after, plateau.Add(time.Second))
+	gainAfter := after.GetWeight() - 0.5
+	if math.Abs(gainAfter-expected(time.Second, BTSP_DEFAULT_TAU_AFTER)) > 1e-9 {
+		t.Errorf("Expected gain %g for input 1s after, got %g", expected(time.Second, BTSP_DEFAULT_TAU_AFTER), gainAfter)
+	}
+	if gainAfter <= 0 || gainBefore <= gainAfter {
+		t.Errorf("Expected asymmetric kernel, got before %g after %g", gainBefore, gainAfter)
+	}
+
+	// Input far outside the window is not potentiated
+	late := btspSynapse(t, "late")
+	late.ApplyPlateau(plateau)
+	preSpikeAt(late, plateau.Add(10*time.Second))
+	if late.GetWeight() != 0.5 {
+		t.Errorf("Expected no potentiation 10s after the plateau, got %f", late.GetWeight())
+	}
+}
+
+// TestBTSP_SaturationAndGating verifies the soft bound, that freezing
+// plasticity stops BTSP, and that synapses without BTSP ignore plateaus.
+func TestBTSP_SaturationAndGating(t *testing.T) {
+	now := time.Unix(100, 0)
+	syn := btspSynapse(t, "saturate")
+	maxWeight := syn.GetPlasticityConfig().MaxWeight
+	for i := 0; i < 100; i++ {
+		now = now.Add(10 * time.Second)
+		preSpikeAt(syn, now.Add(-100*time.Millisecond))
+		syn.ApplyPlateau(now)
+	}
+	if w := syn.GetWeight(); w > maxWeight || maxWeight-w > 0.01 {
+		t.Errorf("Expected weight to saturate just below %f, got %f", maxWeight, w)
+	}
+
+	frozen := btspSynapse(t, "frozen")
+	config := frozen.GetPlasticityConfig()
+	config.Enabled = false
+	if err := frozen.SetPlasticityConfig(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	preSpikeAt(frozen, now)
+	frozen.ApplyPlateau(now)
+	if frozen.GetWeight() != 0.5 {
+		t.Errorf("Expected frozen synapse unchanged, got %f", frozen.GetWeight())
+	}
+
+	plain, _ := NewSynapse("plain", NewMockNeuron("pre"), NewMockNeuron("post"), WithWeight(0.5))
+	plain.Transmit(1.0)
+	plain.ApplyPlateau(time.Now())
+	if plain.GetWeight() != 0.5 || plain.GetBTSPConfig().Enabled {
+		t.Errorf("Expected synapse without BTSP to ignore plateaus, got %f", plain.GetWeight())
+	}
+
+	if _, err := NewSynapse("bad", NewMockNeuron("pre"), NewMockNeuron("post"),
+		WithBTSP(BTSPConfig{Enabled: true, LearningRate: 0.1})); err == nil {
+		t.Error("Expected error for missing time constants")
+	}
+}
@@ -0,0 +1,154 @@
+package synapse
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/topology"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestNewSynapseDefaultsAndOverrides verifies defaults and option ordering.
+func TestNewSynapseDefaultsAndOverrides(t *testing.T) {
+	pre := NewMockNeuron("builder_pre")
+	post := NewMockNeuron("builder_post")
+
+	syn, err := NewSynapse("builder_default", pre, post)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if syn.GetWeight() != PRESET_CORTICAL_EXCITATORY_WEIGHT {
+		t.Errorf("Expected default weight %f, got %f", PRESET_CORTICAL_EXCITATORY_WEIGHT, syn.GetWeight())
+	}
+	if syn.GetDelay() != SYNAPSE_DEFAULT_TRANSMISSION_DELAY {
+		t.Errorf("Expected default delay %v, got %v", SYNAPSE_DEFAULT_TRANSMISSION_DELAY, syn.GetDelay())
+	}
+
+	// Overrides after a preset win
+	syn, err = NewSynapse("builder_override", pre, post,
+		WithGABAergicFast(),
+		WithWeight(1.1),
+		WithLearningRate(0.02))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if syn.GetWeight() != 1.1 {
+		t.Errorf("Expected overridden weight 1.1, got %f", syn.GetWeight())
+	}
+	if syn.GetPlasticityConfig().LearningRate != 0.02 {
+		t.Errorf("Expected overridden learning rate 0.02, got %f", syn.GetPlasticityConfig().LearningRate)
+	}
+	if syn.GetDelay() != PRESET_GABA_FAST_DELAY {
+		t.Errorf("Expected preset delay %v, got %v", PRESET_GABA_FAST_DELAY, syn.GetDelay())
+	}
+}
+
+// TestNewSynapseDistanceDelay verifies delays derived from neuron positions.
+func TestNewSynapseDistanceDelay(t *testing.T) {
+	pre := NewMockNeuron("spatial_pre")
+	post := NewMockNeuron("spatial_post")
+	pre.SetPosition(topology.Planar(0, 0))
+	post.SetPosition(topology.Planar(1000, 0))
+
+	model := topology.DelayModel{SynapticDelay: time.Millisecond, Velocity: topology.VELOCITY_UNMYELINATED_FAST}
+	syn, err := NewSynapse("spatial", pre, post, WithDistanceDelay(model))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// 1000 μm at 2000 μm/ms = 0.5ms conduction + 1ms synaptic
+	if syn.GetDelay() != 1500*time.Microsecond {
+		t.Errorf("Expected distance-derived delay 1.5ms, got %v", syn.GetDelay())
+	}
+
+	// A later fixed delay overrides the model
+	syn, err = NewSynapse("spatial_fixed", pre, post, WithDistanceDelay(model), WithDelay(2*time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if syn.GetDelay() != 2*time.Millisecond {
+		t.Errorf("Expected fixed delay 2ms, got %v", syn.GetDelay())
+	}
+}
+
+// TestNewSynapseValidation verifies that nonsensical combinations are rejected
+// instead of being silently clamped.
+func TestNewSynapseValidation(t *testing.T) {
+	pre := NewMockNeuron("validate_pre")
+	post := NewMockNeuron("validate_post")
+
+	cases := []struct {
+		name    string
+		opts    []SynapseOption
+		wantErr string
+	}{
+		{"NegativeDelay", []SynapseOption{WithDelay(-time.Millisecond)}, "delay"},
+		{"InvertedBounds", []SynapseOption{WithWeightBounds(2.0, 1.0), WithWeight(1.5)}, "exceeds MaxWeight"},
+		{"WeightOutOfBounds", []SynapseOption{WithWeight(5.0)}, "outside bounds"},
+		{"NegativeLearningRate", []SynapseOption{WithLearningRate(-0.1)}, "learning rate"},
+		{"ZeroEligibility", []SynapseOption{WithEligibilityDecay(0)}, "eligibility"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewSynapse("invalid", pre, post, tc.opts...)
+			if err == nil {
+				t.Fatal("Expected validation error")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Expected error mentioning %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := NewSynapse("", pre, post); err == nil {
+		t.Error("Expected error for empty ID")
+	}
+	if _, err := NewSynapse("nil_pre", nil, post); err == nil {
+		t.Error("Expected error for nil pre-synaptic neuron")
+	}
+
+	// Disabled plasticity does not require valid STDP timing parameters
+	fixedConfig := types.PlasticityConfig{Enabled: false, MinWeight: 0, MaxWeight: 1}
+	if _, err := NewSynapse("fixed", pre, post, WithSTDPConfig(fixedConfig), WithWeight(0.5)); err != nil {
+		t.Errorf("Unexpected error for fixed-weight synapse: %v", err)
+	}
+}
+
+// TestSynapsePresets verifies the biological character of each preset.
+func TestSynapsePresets(t *testing.T) {
+	pre := NewMockNeuron("preset_pre")
+	post := NewMockNeuron("preset_post")
+
+	excitatory, err := NewSynapse("cortical", pre, post, WithCorticalExcitatory())
+	if err != nil {
+		t.Fatalf("Cortical preset failed: %v", err)
+	}
+	inhibitory, err := NewSynapse("gaba", pre, post, WithGABAergicFast())
+	if err != nil {
+		t.Fatalf("GABA preset failed: %v", err)
+	}
+	modulatory, err := NewSynapse("neuromod", pre, post, WithNeuromodulatorySlow())
+	if err != nil {
+		t.Fatalf("Neuromodulatory preset failed: %v", err)
+	}
+
+	// Fast inhibition is quicker than excitation, which is quicker than modulation
+	if !(inhibitory.GetDelay() < excitatory.GetDelay() && excitatory.GetDelay() < modulatory.GetDelay()) {
+		t.Errorf("Unexpected delay ordering: gaba=%v cortical=%v neuromod=%v",
+			inhibitory.GetDelay(), excitatory.GetDelay(), modulatory.GetDelay())
+	}
+
+	// Modulatory synapses learn slowly over a broad window
+	if modulatory.GetPlasticityConfig().WindowSize <= excitatory.GetPlasticityConfig().WindowSize {
+		t.Error("Expected neuromodulatory preset to have a broader STDP window")
+	}
+	if modulatory.GetPlasticityConfig().LearningRate >= excitatory.GetPlasticityConfig().LearningRate {
+		t.Error("Expected neuromodulatory preset to learn more slowly")
+	}
+
+	// Inhibitory STDP is symmetric
+	if inhibitory.GetPlasticityConfig().AsymmetryRatio != PRESET_GABA_FAST_ASYMMETRY {
+		t.Errorf("Expected symmetric GABA STDP, got %f", inhibitory.GetPlasticityConfig().AsymmetryRatio)
+	}
+}
@@ -0,0 +1,61 @@
+package synapse
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSynapseClock_TimestampsFollowInjectedClock verifies that a synapse with
+// WithClock stamps transmissions and spike history with the injected time.
+func TestSynapseClock_TimestampsFollowInjectedClock(t *testing.T) {
+	virtual := time.Unix(0, 0).Add(10 * time.Millisecond)
+	pre := NewMockNeuron("clock_pre")
+	post := NewMockNeuron("clock_post")
+	syn, err := NewSynapse("clock_syn", pre, post, WithDelay(0), WithClock(func() time.Time { return virtual }))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	syn.Transmit(1.0)
+	msgs := post.GetReceivedMessages()
+	if len(msgs) != 1 || !msgs[0].Timestamp.Equal(virtual) {
+		t.Fatalf("Expected one message stamped with the virtual time, got %+v", msgs)
+	}
+	if spikes := syn.GetPreSpikeTimes(); len(spikes) != 1 || !spikes[0].Equal(virtual) {
+		t.Errorf("Expected the pre-spike at the virtual time, got %v", spikes)
+	}
+	if info := syn.GetActivityInfo(); !info.LastTransmission.Equal(virtual) {
+		t.Errorf("Expected the last transmission at the virtual time, got %v", info.LastTransmission)
+	}
+
+	// Moving the clock is what ages the activity, not wall time
+	if !syn.IsActive() {
+		t.Error("Expected the synapse active right after transmitting")
+	}
+	virtual = virtual.Add(time.Hour)
+	if syn.IsActive() {
+		t.Error("Expected the synapse inactive an hour of virtual time later")
+	}
+}
+
+// TestSynapseClock_SetClockRestartsTimers verifies that switching clocks
+// clears the spike history recorded on the old one.
+func TestSynapseClock_SetClockRestartsTimers(t *testing.T) {
+	pre := NewMockNeuron("switch_pre")
+	post := NewMockNeuron("switch_post")
+	syn := NewBasicSynapse("switch_syn", pre, post, CreateDefaultSTDPConfig(), CreateDefaultPruningConfig(), 0.5, 0)
+
+	syn.Transmit(1.0)
+	if len(syn.GetPreSpikeTimes()) != 1 {
+		t.Fatal("Expected a wall-clock pre-spike")
+	}
+
+	epoch := time.Unix(0, 0)
+	syn.SetClock(func() time.Time { return epoch })
+	if spikes := syn.GetPreSpikeTimes(); len(spikes) != 0 {
+		t.Errorf("Expected the wall-clock history cleared, got %v", spikes)
+	}
+	if info := syn.GetActivityInfo(); !info.LastTransmission.Equal(epoch) {
+		t.Errorf("Expected timers restarted at the new clock, got %v", info.LastTransmission)
+	}
+}
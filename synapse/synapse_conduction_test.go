@@ -0,0 +1,62 @@
+package synapse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/topology"
+)
+
+// TestConductionDelay verifies delays from path length and velocity, the
+// straight-line default and runtime myelination changes.
+func TestConductionDelay(t *testing.T) {
+	pre, post := NewMockNeuron("axon_pre"), NewMockNeuron("axon_post")
+	pre.SetPosition(topology.Planar(0, 0))
+	post.SetPosition(topology.Planar(1000, 0))
+
+	// 4000 μm of bare axon at 2000 μm/ms = 2ms, plus 0.5ms synaptic
+	config := CreateDefaultConductionConfig()
+	config.PathLength = 4000
+	syn, err := NewSynapse("axon", pre, post, WithConduction(config))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if syn.GetDelay() != 2500*time.Microsecond {
+		t.Errorf("Expected 2.5ms, got %v", syn.GetDelay())
+	}
+
+	// Myelination speeds conduction up; the synaptic component stays
+	if err := syn.SetMyelination(10); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if syn.GetDelay() != 700*time.Microsecond || syn.GetMyelination() != 10 {
+		t.Errorf("Expected 0.7ms at 10× myelination, got %v", syn.GetDelay())
+	}
+	if got, ok := syn.GetConduction(); !ok || got.EffectiveVelocity() != 20000 {
+		t.Errorf("Expected effective velocity 20000 μm/ms, got %+v", got)
+	}
+	if err := syn.SetMyelination(CONDUCTION_MAX_MYELINATION + 1); err == nil {
+		t.Error("Expected error above the maximum myelination")
+	}
+
+	// Zero path length uses the distance between the neurons
+	straight, err := NewSynapse("straight", pre, post, WithConduction(CreateDefaultConductionConfig()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if straight.GetDelay() != time.Millisecond {
+		t.Errorf("Expected 1000 μm straight-line delay of 1ms, got %v", straight.GetDelay())
+	}
+
+	// A fixed delay ends conduction-based timing
+	straight.SetDelay(3 * time.Millisecond)
+	if _, ok := straight.GetConduction(); ok || straight.SetMyelination(2) == nil {
+		t.Error("Expected fixed delay to remove conduction parameters")
+	}
+
+	bad := CreateDefaultConductionConfig()
+	bad.Velocity = 0
+	if _, err := NewSynapse("bad", pre, post, WithConduction(bad)); err == nil {
+		t.Error("Expected error for zero velocity")
+	}
+}
@@ -0,0 +1,119 @@
+package synapse
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// consolidatingSynapse creates a synapse with fast tagging and capture.
+func consolidatingSynapse(t *testing.T, id string, weight float64) *BasicSynapse {
+	config := CreateDefaultConsolidationConfig()
+	config.Duration = 5 * time.Second
+	config.ActivityWindow = time.Second
+	syn, err := NewSynapse(id, NewMockNeuron(id+"_pre"), NewMockNeuron(id+"_post"),
+		WithWeight(weight), WithConsolidation(config))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return syn
+}
+
+// pair applies a causal STDP pairing at the given time.
+func pair(syn *BasicSynapse, at time.Time) float64 {
+	before := syn.GetWeight()
+	syn.ApplyPlasticity(types.PlasticityAdjustment{
+		DeltaT:       -10 * time.Millisecond,
+		LearningRate: syn.GetPlasticityConfig().LearningRate,
+		Timestamp:    at,
+		EventType:    types.PlasticitySTDP,
+	})
+	return syn.GetWeight() - before
+}
+
+// TestConsolidation_TaggingAndCapture verifies that a strong, active synapse
+// is consolidated after the configured period and then learns more slowly.
+func TestConsolidation_TaggingAndCapture(t *testing.T) {
+	start := time.Unix(0, 0)
+	syn := consolidatingSynapse(t, "strong", 1.2)
+
+	initial := pair(syn, start)
+	if state := syn.GetConsolidationState(); !state.Tagged || state.Consolidated {
+		t.Fatalf("Expected a tag without capture, got %+v", state)
+	}
+	for i := 1; i <= 5; i++ {
+		pair(syn, start.Add(time.Duration(i)*time.Second))
+	}
+	state := syn.GetConsolidationState()
+	if !state.Consolidated || !syn.IsConsolidated() || !state.ConsolidatedAt.Equal(start.Add(5*time.Second)) {
+		t.Fatalf("Expected capture after 5s, got %+v", state)
+	}
+
+	protected := pair(syn, start.Add(6*time.Second))
+	expected := initial * CONSOLIDATION_DEFAULT_PROTECTION_FACTOR
+	if math.Abs(protected-expected) > 1e-12 {
+		t.Errorf("Expected protected change %g, got %g", expected, protected)
+	}
+
+	syn.ResetConsolidation()
+	if syn.IsConsolidated() {
+		t.Error("Expected reset to clear consolidation")
+	}
+}
+
+// TestConsolidation_TagLoss verifies that weak or inactive synapses lose their tag.
+func TestConsolidation_TagLoss(t *testing.T) {
+	start := time.Unix(0, 0)
+
+	weak := consolidatingSynapse(t, "weak", 0.5)
+	for i := 0; i <= 10; i++ {
+		pair(weak, start.Add(time.Duration(i)*time.Second))
+	}
+	if state := weak.GetConsolidationState(); state.Tagged || state.Consolidated {
+		t.Errorf("Expected a weak synapse to stay untagged, got %+v", state)
+	}
+
+	// Gaps longer than the activity window restart the tag
+	idle := consolidatingSynapse(t, "idle", 1.2)
+	for i := 0; i <= 10; i++ {
+		pair(idle, start.Add(time.Duration(i)*2*time.Second))
+	}
+	if state := idle.GetConsolidationState(); !state.Tagged || state.Consolidated {
+		t.Errorf("Expected an intermittently active synapse to remain unconsolidated, got %+v", state)
+	}
+
+	if err := idle.SetConsolidation(ConsolidationConfig{Enabled: true, WeightThreshold: 1, Duration: time.Second,
+		ActivityWindow: time.Second, ProtectionFactor: 2}); err == nil {
+		t.Error("Expected error for protection factor above 1")
+	}
+}
+
+// TestPlasticityScale_ScalesLearning verifies that the plasticity scale
+// multiplies weight changes, that zero freezes the weight and that invalid
+// scales are rejected.
+func TestPlasticityScale_ScalesLearning(t *testing.T) {
+	start := time.Unix(0, 0)
+	syn, _ := NewSynapse("scaled", NewMockNeuron("pre"), NewMockNeuron("post"), WithWeight(0.5))
+	if syn.GetPlasticityScale() != 1 {
+		t.Fatalf("Expected default scale 1, got %f", syn.GetPlasticityScale())
+	}
+	base := pair(syn, start)
+
+	if err := syn.SetPlasticityScale(3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if boosted := pair(syn, start.Add(time.Second)); math.Abs(boosted-3*base) > 1e-12 {
+		t.Errorf("Expected change %g at scale 3, got %g", 3*base, boosted)
+	}
+	syn.SetPlasticityScale(0)
+	if frozen := pair(syn, start.Add(2*time.Second)); frozen != 0 {
+		t.Errorf("Expected no change at scale 0, got %g", frozen)
+	}
+	for _, bad := range []float64{-1, math.NaN(), math.Inf(1)} {
+		if err := syn.SetPlasticityScale(bad); err == nil {
+			t.Errorf("Expected scale %f to be rejected", bad)
+		}
+	}
+}
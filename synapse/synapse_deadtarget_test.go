@@ -0,0 +1,98 @@
+package synapse
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// eventCollector records biological events.
+type eventCollector struct {
+	mu     sync.Mutex
+	events []types.BiologicalEvent
+}
+
+func (c *eventCollector) Emit(event types.BiologicalEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, event)
+}
+
+// detachableMockNeuron records output callback removal.
+type detachableMockNeuron struct {
+	*MockNeuron
+	removed []string
+}
+
+func (d *detachableMockNeuron) RemoveOutputCallback(synapseID string) {
+	d.removed = append(d.removed, synapseID)
+}
+
+// TestSynapseDeadTarget_DetectsClosedTarget verifies that transmission to a
+// stopped neuron is dropped and reported once.
+func TestSynapseDeadTarget_DetectsClosedTarget(t *testing.T) {
+	pre, post := NewMockNeuron("pre"), NewMockNeuron("post")
+	observer := &eventCollector{}
+	syn, err := NewSynapse("dead_target", pre, post, WithDelay(0), WithBiologicalObserver(observer))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	syn.Transmit(1.0)
+	if len(post.GetReceivedMessages()) != 1 || syn.IsTargetDead() {
+		t.Fatal("Expected normal delivery to a live target")
+	}
+
+	post.SetState(types.StateStopped)
+	syn.Transmit(1.0)
+	syn.Transmit(1.0)
+
+	if len(post.GetReceivedMessages()) != 1 {
+		t.Errorf("Expected no delivery to a closed target, got %d messages", len(post.GetReceivedMessages()))
+	}
+	if !syn.IsTargetDead() || syn.GetDeadTargetDrops() != 2 {
+		t.Errorf("Expected dead target with 2 drops, got dead=%v drops=%d", syn.IsTargetDead(), syn.GetDeadTargetDrops())
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if len(observer.events) != 1 {
+		t.Fatalf("Expected exactly one event, got %d", len(observer.events))
+	}
+	if ev := observer.events[0]; ev.EventType != types.SynapseDeadTarget || ev.SourceID != "dead_target" || ev.TargetID != "post" {
+		t.Errorf("Unexpected event %+v", ev)
+	}
+}
+
+// TestSynapseDeadTarget_SelfPruning verifies that self-pruning detaches the
+// synapse from its pre-synaptic neuron and marks it for removal.
+func TestSynapseDeadTarget_SelfPruning(t *testing.T) {
+	pre := &detachableMockNeuron{MockNeuron: NewMockNeuron("pre")}
+	post := NewMockNeuron("post")
+
+	pruning := CreateDefaultPruningConfig()
+	pruning.Enabled = false // dead-target pruning applies regardless
+	syn, err := NewSynapse("pruned", pre, post, WithPruningConfig(pruning), WithDeadTargetPruning(),
+		WithDelay(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if syn.ShouldPrune() {
+		t.Fatal("Live synapse must not be pruned")
+	}
+
+	post.SetState(types.StateStopped)
+	syn.Transmit(1.0)
+
+	if pre.GetQueuedMessageCount() != 0 {
+		t.Error("Expected no delayed delivery scheduled for a closed target")
+	}
+	if len(pre.removed) != 1 || pre.removed[0] != "pruned" {
+		t.Errorf("Expected synapse to detach from pre-synaptic neuron, got %v", pre.removed)
+	}
+	if !syn.ShouldPrune() {
+		t.Error("Expected ShouldPrune after dead target with self-pruning")
+	}
+}
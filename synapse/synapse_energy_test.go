@@ -0,0 +1,42 @@
+package synapse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/energy"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestEnergyAccounting verifies that transmissions and weight updates are
+// charged, and dropped transmissions are not.
+func TestEnergyAccounting(t *testing.T) {
+	pre, post := NewMockNeuron("pre"), NewMockNeuron("post")
+	syn := NewBasicSynapse("energy", pre, post, CreateDefaultSTDPConfig(), CreateDefaultPruningConfig(), 1.0, 0)
+	meter, err := energy.NewMeter(energy.Costs{Spike: 10, Transmission: 2, Plasticity: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	syn.Transmit(1.0)
+	if meter.Total().Energy != 0 {
+		t.Error("Expected no charge without a meter")
+	}
+	syn.SetEnergyMeter(meter)
+	syn.Transmit(1.0)
+	syn.Transmit(1.0)
+	syn.ApplyPlasticity(types.PlasticityAdjustment{DeltaT: -5 * time.Millisecond, LearningRate: 0.01})
+
+	if got := meter.Neuron("pre"); got.Transmissions != 2 || got.Energy != 4 {
+		t.Errorf("Expected transmissions charged to the presynaptic neuron, got %+v", got)
+	}
+	if got := meter.Neuron("post"); got.PlasticityEvents != 1 || got.Energy != 1 {
+		t.Errorf("Expected plasticity charged to the postsynaptic neuron, got %+v", got)
+	}
+
+	syn.SetFaultInjection(FaultConfig{DropProbability: 1})
+	syn.Transmit(1.0)
+	if got := meter.Synapse("energy"); got.Transmissions != 2 || got.Energy != 5 {
+		t.Errorf("Expected dropped transmission to be free, got %+v", got)
+	}
+}
@@ -0,0 +1,216 @@
+/*
+=================================================================================
+SYNAPSE FAULT INJECTION TESTS
+=================================================================================
+
+Verifies that transmission faults (drop, duplicate, delay, corrupt) are applied
+at the configured rates, are reproducible with a fixed seed, and can be
+switched off again without side effects on normal transmission.
+=================================================================================
+*/
+
+package synapse
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+)
+
+// newFaultTestSynapse creates a zero-delay synapse with weight 1.0.
+func newFaultTestSynapse(id string) (*BasicSynapse, *MockNeuron, *MockNeuron) {
+	pre := NewMockNeuron(id + "_pre")
+	post := NewMockNeuron(id + "_post")
+	syn := NewBasicSynapse(id, pre, post, CreateDefaultSTDPConfig(), CreateDefaultPruningConfig(), 1.0, 0)
+	return syn, pre, post
+}
+
+func TestFaultInjectionValidation(t *testing.T) {
+	syn, _, _ := newFaultTestSynapse("fault_validation")
+
+	invalid := []FaultConfig{
+		{DropProbability: 1.5},
+		{DuplicateProbability: -0.1},
+		{MaxExtraDelay: -time.Millisecond},
+		{CorruptionMagnitude: -1},
+		{DropProbability: math.NaN()},
+		{CorruptProbability: 0.5, CorruptionMagnitude: math.NaN()},
+	}
+	for i, config := range invalid {
+		if err := syn.SetFaultInjection(config); err == nil {
+			t.Errorf("Config %d: expected validation error", i)
+		}
+	}
+}
+
+func TestFaultInjectionDropRate(t *testing.T) {
+	syn, _, post := newFaultTestSynapse("fault_drop")
+	syn.SetFaultInjection(FaultConfig{DropProbability: 0.3, Seed: 7})
+
+	const transmissions = 2000
+	for i := 0; i < transmissions; i++ {
+		syn.Transmit(1.0)
+	}
+
+	stats := syn.GetFaultStats()
+	delivered := len(post.GetReceivedMessages())
+
+	if stats.Transmissions != transmissions {
+		t.Errorf("Expected %d transmissions, got %d", transmissions, stats.Transmissions)
+	}
+	if int64(delivered) != transmissions-stats.Dropped {
+		t.Errorf("Delivered %d but stats report %d dropped", delivered, stats.Dropped)
+	}
+	dropRate := float64(stats.Dropped) / transmissions
+	if math.Abs(dropRate-0.3) > 0.05 {
+		t.Errorf("Expected drop rate ~0.3, got %.3f", dropRate)
+	}
+}
+
+func TestFaultInjectionDuplicateAndCorrupt(t *testing.T) {
+	syn, _, post := newFaultTestSynapse("fault_dup")
+	syn.SetFaultInjection(FaultConfig{
+		DuplicateProbability: 1.0,
+		CorruptProbability:   1.0,
+		CorruptionMagnitude:  0.2,
+		Seed:                 11,
+	})
+
+	syn.Transmit(1.0)
+
+	msgs := post.GetReceivedMessages()
+	if len(msgs) != 2 {
+		t.Fatalf("Expected duplicated delivery, got %d messages", len(msgs))
+	}
+	if msgs[0].Value != msgs[1].Value {
+		t.Error("Duplicate should carry the same (corrupted) value")
+	}
+	if msgs[0].Value < 0.8 || msgs[0].Value > 1.2 {
+		t.Errorf("Corrupted value %f outside ±20%% band", msgs[0].Value)
+	}
+}
+
+func TestFaultInjectionExtraDelay(t *testing.T) {
+	syn, pre, post := newFaultTestSynapse("fault_delay")
+	syn.SetFaultInjection(FaultConfig{DelayProbability: 1.0, MaxExtraDelay: 10 * time.Millisecond, Seed: 3})
+
+	// Extra delay forces scheduling through the pre-synaptic delivery queue
+	for i := 0; i < 20; i++ {
+		syn.Transmit(1.0)
+	}
+
+	delayed := syn.GetFaultStats().Delayed
+	queued := pre.GetQueuedMessageCount()
+	immediate := len(post.GetReceivedMessages())
+	if delayed != 20 {
+		t.Errorf("Expected all 20 spikes delayed, got %d", delayed)
+	}
+	if queued+immediate != 20 || queued == 0 {
+		t.Errorf("Expected delayed spikes to be queued, got %d queued and %d immediate", queued, immediate)
+	}
+}
+
+func TestFaultInjectionReproducibleAndClearable(t *testing.T) {
+	run := func() []float64 {
+		syn, _, post := newFaultTestSynapse("fault_repro")
+		syn.SetFaultInjection(FaultConfig{DropProbability: 0.5, CorruptProbability: 0.5, CorruptionMagnitude: 0.5, Seed: 99})
+		for i := 0; i < 50; i++ {
+			syn.Transmit(1.0)
+		}
+		values := make([]float64, 0)
+		for _, msg := range post.GetReceivedMessages() {
+			values = append(values, msg.Value)
+		}
+		return values
+	}
+
+	first, second := run(), run()
+	if len(first) != len(second) {
+		t.Fatalf("Seeded runs differ in length: %d vs %d", len(first), len(second))
+	}
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Seeded runs differ at %d: %f vs %f", i, first[i], second[i])
+		}
+	}
+
+	syn, _, post := newFaultTestSynapse("fault_clear")
+	syn.SetFaultInjection(FaultConfig{DropProbability: 1.0})
+	syn.Transmit(1.0)
+	syn.ClearFaultInjection()
+	syn.Transmit(1.0)
+
+	if len(post.GetReceivedMessages()) != 1 {
+		t.Errorf("Expected only the post-clear transmission to arrive")
+	}
+	if syn.GetFaultStats() != (FaultStats{}) {
+		t.Error("Expected zero stats after clearing injection")
+	}
+}
+
+func TestInjectFaultsSelectsSynapses(t *testing.T) {
+	synA, _, _ := newFaultTestSynapse("select_a")
+	synB, _, _ := newFaultTestSynapse("select_b")
+	all := []component.SynapticProcessor{synA, synB}
+	count, err := InjectFaults(all, FaultConfig{DropProbability: 1.0, Seed: 1},
+		func(s component.SynapticProcessor) bool { return s.ID() != "select_b" })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 synapse configured, got %d", count)
+	}
+
+	synA.Transmit(1.0)
+	synB.Transmit(1.0)
+	if synA.GetFaultStats().Dropped != 1 {
+		t.Error("Expected selected synapse to drop")
+	}
+	if synB.GetFaultStats().Transmissions != 0 {
+		t.Error("Expected unselected synapse to be untouched")
+	}
+}
+
+func TestInjectFaultsReproducibleWithNegativeSeed(t *testing.T) {
+	// Seed -3 once gave the synapse at index 3 the seed 0, i.e. a time-based one
+	run := func() [][]float64 {
+		synapses := make([]component.SynapticProcessor, 5)
+		posts := make([]*MockNeuron, len(synapses))
+		for i := range synapses {
+			syn, _, post := newFaultTestSynapse("negative_seed")
+			synapses[i], posts[i] = syn, post
+		}
+		if _, err := InjectFaults(synapses, FaultConfig{DropProbability: 0.5, Seed: -3}, nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		values := make([][]float64, len(synapses))
+		for i, syn := range synapses {
+			for j := 0; j < 64; j++ {
+				syn.Transmit(float64(j))
+			}
+			for _, msg := range posts[i].GetReceivedMessages() {
+				values[i] = append(values[i], msg.Value)
+			}
+		}
+		return values
+	}
+
+	first, second := run(), run()
+	for i := range first {
+		if len(first[i]) != len(second[i]) {
+			t.Fatalf("Synapse %d: seeded runs differ in length: %d vs %d", i, len(first[i]), len(second[i]))
+		}
+		for j := range first[i] {
+			if first[i][j] != second[i][j] {
+				t.Fatalf("Synapse %d: seeded runs differ at %d", i, j)
+			}
+		}
+	}
+	for i := 0; i < 5; i++ {
+		if faultSeed(-3, i) == 0 || faultSeed(int64(-i-1), i) == 0 {
+			t.Errorf("Expected non-zero derived seeds for index %d", i)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package synapse
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// virtualClock is a settable clock for forgetting over simulated hours.
+type virtualClock struct{ now time.Time }
+
+func (c *virtualClock) Now() time.Time                { return c.now }
+func (c *virtualClock) Advance(elapsed time.Duration) { c.now = c.now.Add(elapsed) }
+
+// TestForgetting_DecaysLazilyTowardBaseline verifies the exponential decay
+// over virtual hours, that explicit writes restart it, and that settling is
+// not a write for optimistic updates.
+func TestForgetting_DecaysLazilyTowardBaseline(t *testing.T) {
+	clock := &virtualClock{now: time.Unix(0, 0)}
+	syn, err := NewSynapse("fading", NewMockNeuron("pre"), NewMockNeuron("post"),
+		WithWeight(1.5),
+		WithForgetting(ForgettingConfig{Enabled: true, Baseline: 0.5, TimeConstant: time.Hour, Now: clock.Now}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	weight, version := syn.GetWeightVersion()
+	clock.Advance(time.Hour)
+	if expected := 0.5 + math.Exp(-1); math.Abs(syn.GetWeight()-expected) > 1e-12 {
+		t.Errorf("Expected %g after one time constant, got %g", expected, syn.GetWeight())
+	}
+	// Two half-hour settles compose to the same decay as one
+	clock.Advance(30 * time.Minute)
+	syn.GetWeight()
+	clock.Advance(30 * time.Minute)
+	if expected := 0.5 + math.Exp(-2); math.Abs(syn.GetWeight()-expected) > 1e-12 {
+		t.Errorf("Expected %g after two time constants, got %g", expected, syn.GetWeight())
+	}
+	if _, ok := syn.CompareAndSetWeight(version, weight); !ok {
+		t.Error("Expected decay not to invalidate the weight version")
+	}
+
+	syn.SetWeight(1.0)
+	clock.Advance(48 * time.Hour)
+	if math.Abs(syn.GetWeight()-0.5) > 1e-9 {
+		t.Errorf("Expected the weight to settle at the baseline, got %g", syn.GetWeight())
+	}
+
+	if err := syn.SetForgetting(ForgettingConfig{}); err != nil {
+		t.Fatalf("Unexpected error disabling: %v", err)
+	}
+	syn.SetWeight(1.0)
+	clock.Advance(48 * time.Hour)
+	if syn.GetWeight() != 1.0 || syn.GetForgettingConfig().Enabled {
+		t.Errorf("Expected no decay once disabled, got %g", syn.GetWeight())
+	}
+
+	if err := syn.SetForgetting(ForgettingConfig{Enabled: true, Baseline: 0.5}); err == nil {
+		t.Error("Expected error for a zero time constant")
+	}
+}
+
+// TestForgetting_ConsolidationProtects verifies that a consolidated synapse
+// forgets ProtectionFactor times as fast.
+func TestForgetting_ConsolidationProtects(t *testing.T) {
+	start := time.Unix(0, 0)
+	syn := consolidatingSynapse(t, "remembered", 1.2)
+	for i := 0; i <= 5; i++ {
+		pair(syn, start.Add(time.Duration(i)*time.Second))
+	}
+	if !syn.IsConsolidated() {
+		t.Fatal("Expected the synapse to be consolidated")
+	}
+
+	clock := &virtualClock{now: start.Add(5 * time.Second)}
+	config := CreateDefaultForgettingConfig()
+	config.Now = clock.Now
+	if err := syn.SetForgetting(config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	before := syn.GetWeight()
+	clock.Advance(config.TimeConstant)
+
+	retained := math.Exp(-CONSOLIDATION_DEFAULT_PROTECTION_FACTOR)
+	expected := config.Baseline + (before-config.Baseline)*retained
+	if math.Abs(syn.GetWeight()-expected) > 1e-12 {
+		t.Errorf("Expected protected decay to %g, got %g", expected, syn.GetWeight())
+	}
+}
+
+// TestForgetting_PlasticityKeepsVirtualClock verifies that wall-clock
+// plasticity timestamps neither apply decay nor stall the virtual forgetting
+// clock, and that GetWeight does not wait for the synapse mutex.
+func TestForgetting_PlasticityKeepsVirtualClock(t *testing.T) {
+	clock := &virtualClock{now: time.Unix(0, 0)}
+	syn, err := NewSynapse("learning", NewMockNeuron("pre"), NewMockNeuron("post"),
+		WithWeight(1.5),
+		WithForgetting(ForgettingConfig{Enabled: true, Baseline: 0.5, TimeConstant: time.Hour, Now: clock.Now}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	change := pair(syn, time.Now())
+	if change <= 0 || math.Abs(syn.GetWeight()-(1.5+change)) > 1e-12 {
+		t.Fatalf("Expected only the STDP change at a wall-clock timestamp, got %g", syn.GetWeight())
+	}
+	syn.RecordPreSpike(time.Now())
+
+	learned := syn.GetWeight()
+	clock.Advance(time.Hour)
+	if expected := 0.5 + (learned-0.5)*math.Exp(-1); math.Abs(syn.GetWeight()-expected) > 1e-12 {
+		t.Errorf("Expected decay to continue on the virtual clock to %g, got %g", expected, syn.GetWeight())
+	}
+
+	read := make(chan float64)
+	syn.mutex.Lock()
+	go func() { read <- syn.GetWeight() }()
+	select {
+	case <-read:
+	case <-time.After(time.Second):
+		t.Error("Expected GetWeight not to block on the synapse mutex")
+	}
+	syn.mutex.Unlock()
+}
@@ -0,0 +1,118 @@
+package synapse
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestInhibitorySTDP_RuleKeepsSign verifies both halves of the rule on a
+// positive-magnitude and a negative-weight inhibitory synapse, and that
+// pair-based adjustments are ignored.
+func TestInhibitorySTDP_RuleKeepsSign(t *testing.T) {
+	config := CreateDefaultInhibitorySTDPConfig()
+	alpha := 2 * config.TargetRate * config.TimeConstant.Seconds()
+	start := time.Unix(0, 0)
+
+	// Inhibitory synapses built by NewBasicSynapse may carry negative weights
+	signed := CreateDefaultSTDPConfig()
+	signed.MinWeight, signed.MaxWeight = -2, 2
+
+	for _, initial := range []float64{0.8, -0.8} {
+		syn := NewBasicSynapse("gaba", NewMockNeuron("pv"), NewMockNeuron("pyr"),
+			signed, CreateDefaultPruningConfig(), initial, time.Millisecond)
+		if err := syn.SetInhibitorySTDP(config); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		sign := math.Copysign(1, initial)
+
+		// A lone pre spike only depresses
+		syn.RecordPreSpike(start)
+		expected := initial - sign*config.LearningRate*alpha
+		if math.Abs(syn.GetWeight()-expected) > 1e-12 {
+			t.Errorf("Expected %g after a pre spike, got %g", expected, syn.GetWeight())
+		}
+
+		// A post spike 5ms later potentiates by the decayed pre trace
+		syn.RecordPostSpike(start.Add(5 * time.Millisecond))
+		expected += sign * config.LearningRate * math.Exp(-0.25)
+		if math.Abs(syn.GetWeight()-expected) > 1e-12 {
+			t.Errorf("Expected %g after a post spike, got %g", expected, syn.GetWeight())
+		}
+
+		syn.ApplyPlasticity(types.PlasticityAdjustment{DeltaT: -10 * time.Millisecond, LearningRate: 0.1, Timestamp: start})
+		if syn.GetWeight() != expected {
+			t.Errorf("Expected pair-based STDP to be ignored, got %g", syn.GetWeight())
+		}
+	}
+
+	// The magnitude stops at zero instead of crossing to excitation
+	syn := NewBasicSynapse("weak", NewMockNeuron("pv"), NewMockNeuron("pyr"),
+		signed, CreateDefaultPruningConfig(), 0.0005, time.Millisecond)
+	syn.SetInhibitorySTDP(config)
+	syn.RecordPreSpike(start)
+	if syn.GetWeight() != 0 {
+		t.Errorf("Expected the weight to stop at 0, got %g", syn.GetWeight())
+	}
+
+	if _, err := NewSynapse("bad", NewMockNeuron("pv"), NewMockNeuron("pyr"),
+		WithInhibitorySTDP(InhibitorySTDPConfig{Enabled: true, LearningRate: 0.01})); err == nil {
+		t.Error("Expected error for a zero time constant")
+	}
+}
+
+// TestInhibitorySTDP_ReachesRateSetpoint drives an integrate-and-fire neuron
+// with constant excitation and one plastic inhibitory input through a
+// decaying conductance, and verifies that inhibition settles where the
+// neuron fires at the target rate, from both too little and too much
+// inhibition.
+func TestInhibitorySTDP_ReachesRateSetpoint(t *testing.T) {
+	config := CreateDefaultInhibitorySTDPConfig()
+	config.TargetRate = 10
+	config.LearningRate = 0.02
+
+	const (
+		step        = time.Millisecond
+		excitation  = 0.05                  // Per step: 50Hz without inhibition
+		prePeriod   = 25 * time.Millisecond // Interneuron fires at 40Hz
+		conductance = 0.1                   // Inhibition per step per unit conductance
+		duration    = 200 * time.Second
+		measured    = 20 * time.Second // Final stretch the rate is measured over
+	)
+	decay := math.Exp(-float64(step) / float64(10*time.Millisecond))
+
+	for _, initial := range []float64{0.05, 2.0} {
+		syn, err := NewSynapse("gaba", NewMockNeuron("pv"), NewMockNeuron("pyr"),
+			WithGABAergicFast(), WithWeight(initial), WithInhibitorySTDP(config))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		start := time.Unix(0, 0)
+		potential, inhibition, spikes := 0.0, 0.0, 0
+		for elapsed := time.Duration(0); elapsed < duration; elapsed += step {
+			now := start.Add(elapsed)
+			if elapsed%prePeriod == 0 {
+				syn.RecordPreSpike(now)
+				inhibition += syn.GetWeight()
+			}
+			inhibition *= decay
+			potential = math.Max(-1, potential+excitation-conductance*inhibition)
+			if potential >= 1 {
+				potential = 0
+				syn.RecordPostSpike(now)
+				if elapsed >= duration-measured {
+					spikes++
+				}
+			}
+		}
+
+		rate := float64(spikes) / measured.Seconds()
+		if math.Abs(rate-config.TargetRate) > 2 {
+			t.Errorf("Starting from weight %g: expected about %gHz, got %gHz (weight %g)",
+				initial, config.TargetRate, rate, syn.GetWeight())
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package synapse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// wallClockScheduler delivers delayed messages on real timers, optionally
+// adding a fixed lag to simulate a loaded scheduler.
+type wallClockScheduler struct {
+	*MockNeuron
+	lag time.Duration
+}
+
+func (w *wallClockScheduler) ScheduleDelayedDelivery(msg types.NeuralSignal, target component.MessageReceiver, delay time.Duration) {
+	time.AfterFunc(delay+w.lag, func() { target.Receive(msg) })
+}
+
+// waitForMessages polls until the mock has received count messages.
+func waitForMessages(t *testing.T, post *MockNeuron, count int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if len(post.GetReceivedMessages()) >= count {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d messages, got %d", count, len(post.GetReceivedMessages()))
+}
+
+// TestLatencyTrackingDisabledByDefault verifies no measurements are taken
+// unless tracking is enabled.
+func TestLatencyTrackingDisabledByDefault(t *testing.T) {
+	pre := NewMockNeuron("lat_pre")
+	post := NewMockNeuron("lat_post")
+	syn := NewBasicSynapse("lat_off", pre, post, CreateDefaultSTDPConfig(), CreateDefaultPruningConfig(), 0.5, 0)
+
+	syn.Transmit(1.0)
+	if stats := syn.GetLatencyStats(); stats.Samples != 0 {
+		t.Errorf("Expected no samples while disabled, got %d", stats.Samples)
+	}
+	if len(post.GetReceivedMessages()) != 1 {
+		t.Errorf("Expected delivery to be unaffected, got %d messages", len(post.GetReceivedMessages()))
+	}
+}
+
+// TestLatencyTrackingMeasuresDelay verifies latency percentiles track the
+// configured delay and that jitter stays small on an unloaded scheduler.
+func TestLatencyTrackingMeasuresDelay(t *testing.T) {
+	pre := &wallClockScheduler{MockNeuron: NewMockNeuron("lat_pre")}
+	post := NewMockNeuron("lat_post")
+	delay := 2 * time.Millisecond
+	syn := NewBasicSynapse("lat_on", pre, post, CreateDefaultSTDPConfig(), CreateDefaultPruningConfig(), 0.5, delay)
+	syn.EnableLatencyTracking(64)
+
+	const spikes = 20
+	for i := 0; i < spikes; i++ {
+		syn.Transmit(1.0)
+	}
+	waitForMessages(t, post, spikes)
+
+	stats := syn.GetLatencyStats()
+	if stats.Samples != spikes {
+		t.Fatalf("Expected %d samples, got %d", spikes, stats.Samples)
+	}
+	if stats.ConfiguredDelay != delay {
+		t.Errorf("Expected configured delay %v, got %v", delay, stats.ConfiguredDelay)
+	}
+	if stats.P50Latency < delay {
+		t.Errorf("Median latency %v shorter than configured delay %v", stats.P50Latency, delay)
+	}
+	if !(stats.P50Latency <= stats.P90Latency && stats.P90Latency <= stats.P99Latency && stats.P99Latency <= stats.MaxLatency) {
+		t.Errorf("Percentiles not monotonic: %+v", stats)
+	}
+
+	var histogramTotal int64
+	for _, bucket := range stats.Histogram {
+		histogramTotal += bucket.Count
+	}
+	if histogramTotal != spikes {
+		t.Errorf("Expected histogram to count %d deliveries, got %d", spikes, histogramTotal)
+	}
+}
+
+// TestLatencyTrackingDetectsJitter verifies that a lagging scheduler shows up
+// as positive jitter above the configured delay.
+func TestLatencyTrackingDetectsJitter(t *testing.T) {
+	lag := 5 * time.Millisecond
+	pre := &wallClockScheduler{MockNeuron: NewMockNeuron("jit_pre"), lag: lag}
+	post := NewMockNeuron("jit_post")
+	syn := NewBasicSynapse("jit", pre, post, CreateDefaultSTDPConfig(), CreateDefaultPruningConfig(), 0.5, time.Millisecond)
+	syn.EnableLatencyTracking(0)
+
+	for i := 0; i < 10; i++ {
+		syn.Transmit(1.0)
+	}
+	waitForMessages(t, post, 10)
+
+	stats := syn.GetLatencyStats()
+	if stats.MeanJitter < lag {
+		t.Errorf("Expected mean jitter >= %v, got %v", lag, stats.MeanJitter)
+	}
+	if stats.P99Jitter < lag {
+		t.Errorf("Expected p99 jitter >= %v, got %v", lag, stats.P99Jitter)
+	}
+
+	syn.DisableLatencyTracking()
+	if stats := syn.GetLatencyStats(); stats.Samples != 0 {
+		t.Errorf("Expected stats cleared after disabling, got %d samples", stats.Samples)
+	}
+}
@@ -0,0 +1,79 @@
+package synapse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestMaturation_SilentActiveConsolidated verifies that a silent synapse
+// learns without transmitting, is unsilenced by potentiation, is later
+// consolidated, and that each transition is emitted once.
+func TestMaturation_SilentActiveConsolidated(t *testing.T) {
+	start := time.Unix(0, 0)
+	observer := &eventCollector{}
+	post := NewMockNeuron("post")
+	config := CreateDefaultConsolidationConfig()
+	config.WeightThreshold = 0.5
+	config.Duration = 3 * time.Second
+	syn, err := NewSynapse("nascent", NewMockNeuron("pre"), post, WithWeight(0.45), WithDelay(0),
+		WithConsolidation(config), WithBiologicalObserver(observer), WithSilentStart(0.03))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !syn.IsSilent() {
+		t.Fatalf("Expected a silent synapse, got %s", syn.GetMaturationStage())
+	}
+
+	syn.Transmit(1.0)
+	if len(post.GetReceivedMessages()) != 0 || len(syn.GetPreSpikeTimes()) != 1 {
+		t.Fatal("Expected a silent synapse to record the spike without delivering it")
+	}
+
+	// Depression does not count towards unsilencing
+	before := syn.GetWeight()
+	syn.ApplyPlasticity(types.PlasticityAdjustment{DeltaT: 10 * time.Millisecond, LearningRate: syn.GetPlasticityConfig().LearningRate, Timestamp: start})
+	if state := syn.GetMaturationState(); state.Potentiation != 0 || syn.GetWeight() >= before {
+		t.Errorf("Expected depression without progress, got %+v", state)
+	}
+	pairs := 0
+	for syn.IsSilent() && pairs < 100 {
+		pairs++
+		pair(syn, start.Add(time.Duration(pairs)*time.Second))
+	}
+	state := syn.GetMaturationState()
+	if state.Stage != StageActive || state.SilentSpikes != 1 || !state.UnsilencedAt.Equal(start.Add(time.Duration(pairs)*time.Second)) {
+		t.Fatalf("Expected the synapse unsilenced after %d pairings, got %+v", pairs, state)
+	}
+	syn.Transmit(1.0)
+	if len(post.GetReceivedMessages()) != 1 {
+		t.Error("Expected an active synapse to transmit")
+	}
+
+	// Tagging starts once active; capture follows
+	for i := 1; syn.GetMaturationStage() != StageConsolidated && i < 100; i++ {
+		pair(syn, start.Add(time.Duration(pairs+i)*time.Second))
+	}
+	if !syn.IsConsolidated() {
+		t.Fatalf("Expected consolidation, got %+v", syn.GetMaturationState())
+	}
+
+	observer.mu.Lock()
+	defer observer.mu.Unlock()
+	if len(observer.events) != 2 || observer.events[0].EventType != types.SynapseUnsilenced ||
+		observer.events[1].EventType != types.SynapseConsolidated {
+		t.Fatalf("Expected unsilencing and consolidation events, got %+v", observer.events)
+	}
+	if data := observer.events[0].Data.(map[string]interface{}); data["from"] != "silent" || data["to"] != "active" {
+		t.Errorf("Unexpected event data %v", data)
+	}
+
+	plain, _ := NewSynapse("grown", NewMockNeuron("pre2"), NewMockNeuron("post2"))
+	if plain.GetMaturationStage() != StageActive {
+		t.Error("Expected synapses to start active by default")
+	}
+	if err := plain.StartSilent(-1); err == nil {
+		t.Error("Expected error for a negative threshold")
+	}
+}
@@ -0,0 +1,106 @@
+package synapse
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// metaplasticSynapse creates a synapse with default metaplasticity at weight 1.0.
+func metaplasticSynapse(t *testing.T, id string) *BasicSynapse {
+	syn, err := NewSynapse(id, NewMockNeuron(id+"_pre"), NewMockNeuron(id+"_post"),
+		WithWeight(1.0), WithMetaplasticity(CreateDefaultMetaplasticityConfig()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return syn
+}
+
+// weightChange applies one STDP pairing at the given time and returns Δw.
+func weightChange(syn *BasicSynapse, deltaT time.Duration, at time.Time) float64 {
+	before := syn.GetWeight()
+	syn.ApplyPlasticity(types.PlasticityAdjustment{
+		DeltaT:       deltaT,
+		LearningRate: syn.GetPlasticityConfig().LearningRate,
+		Timestamp:    at,
+		EventType:    types.PlasticitySTDP,
+	})
+	return syn.GetWeight() - before
+}
+
+// TestMetaplasticity_SlidingThreshold verifies that high post-synaptic
+// activity shifts STDP towards depression, silence shifts it towards
+// potentiation, and firing at the target rate leaves STDP unchanged.
+func TestMetaplasticity_SlidingThreshold(t *testing.T) {
+	start := time.Unix(0, 0)
+	plain, err := NewSynapse("plain", NewMockNeuron("p_pre"), NewMockNeuron("p_post"), WithWeight(1.0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	baseLTP := weightChange(plain, -10*time.Millisecond, start)
+	baseLTD := weightChange(plain, 10*time.Millisecond, start)
+
+	// At the target rate the rule is unmodified
+	target := metaplasticSynapse(t, "target")
+	if ltp := weightChange(target, -10*time.Millisecond, start); math.Abs(ltp-baseLTP) > 1e-12 {
+		t.Errorf("Expected unmodified LTP %g at the target rate, got %g", baseLTP, ltp)
+	}
+
+	// 50Hz for 20s: threshold slides far above the target
+	busy := metaplasticSynapse(t, "busy")
+	now := start
+	for i := 0; i < 1000; i++ {
+		now = now.Add(20 * time.Millisecond)
+		busy.RecordPostSpike(now)
+	}
+	state, ok := busy.GetMetaplasticityState()
+	if !ok || state.RateEstimate < 40 || state.Threshold <= METAPLASTICITY_DEFAULT_TARGET_RATE {
+		t.Fatalf("Expected a raised threshold, got %+v", state)
+	}
+	ltp, ltd := weightChange(busy, -10*time.Millisecond, now), weightChange(busy, 10*time.Millisecond, now)
+	if math.Abs(ltp-baseLTP/METAPLASTICITY_DEFAULT_MAX_SCALE) > 1e-9 || math.Abs(ltd-baseLTD*METAPLASTICITY_DEFAULT_MAX_SCALE) > 1e-9 {
+		t.Errorf("Expected LTP %g and LTD %g for a busy neuron, got %g and %g",
+			baseLTP/METAPLASTICITY_DEFAULT_MAX_SCALE, baseLTD*METAPLASTICITY_DEFAULT_MAX_SCALE, ltp, ltd)
+	}
+
+	// 30s of silence: the estimate decays and potentiation dominates
+	quiet := metaplasticSynapse(t, "quiet")
+	weightChange(quiet, 0, start)
+	ltp = weightChange(quiet, -10*time.Millisecond, start.Add(30*time.Second))
+	if math.Abs(ltp-baseLTP*METAPLASTICITY_DEFAULT_MAX_SCALE) > 1e-9 {
+		t.Errorf("Expected boosted LTP %g for a silent neuron, got %g", baseLTP*METAPLASTICITY_DEFAULT_MAX_SCALE, ltp)
+	}
+	if state, _ := quiet.GetMetaplasticityState(); state.LTPScale != METAPLASTICITY_DEFAULT_MAX_SCALE || state.Threshold >= 1 {
+		t.Errorf("Unexpected quiet state %+v", state)
+	}
+}
+
+// TestMetaplasticity_Configuration verifies enabling, disabling and validation.
+func TestMetaplasticity_Configuration(t *testing.T) {
+	syn := metaplasticSynapse(t, "config")
+	if !syn.GetMetaplasticityConfig().Enabled {
+		t.Error("Expected metaplasticity to be enabled")
+	}
+	if err := syn.SetMetaplasticity(MetaplasticityConfig{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := syn.GetMetaplasticityState(); ok {
+		t.Error("Expected metaplasticity to be disabled")
+	}
+
+	invalid := []MetaplasticityConfig{
+		{Enabled: true, TargetRate: 0, TimeConstant: time.Second, MaxScale: 2},
+		{Enabled: true, TargetRate: 5, TimeConstant: 0, MaxScale: 2},
+		{Enabled: true, TargetRate: 5, TimeConstant: time.Second, MaxScale: 0.5},
+	}
+	for _, config := range invalid {
+		if err := syn.SetMetaplasticity(config); err == nil {
+			t.Errorf("Expected error for %+v", config)
+		}
+		if _, err := NewSynapse("bad", NewMockNeuron("b_pre"), NewMockNeuron("b_post"), WithMetaplasticity(config)); err == nil {
+			t.Errorf("Expected builder error for %+v", config)
+		}
+	}
+}
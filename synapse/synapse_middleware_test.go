@@ -0,0 +1,73 @@
+package synapse
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestTransmissionMiddleware verifies chain order, recording, option setup,
+// bulk configuration and removal.
+func TestTransmissionMiddleware(t *testing.T) {
+	pre, post := NewMockNeuron("mw_pre"), NewMockNeuron("mw_post")
+	var seen []float64
+	record := func(msg types.NeuralSignal) types.NeuralSignal {
+		seen = append(seen, msg.Value)
+		return msg
+	}
+	syn, err := NewSynapse("mw", pre, post, WithWeight(0.5), WithDelay(0),
+		WithMiddleware(ScaleMiddleware(4), record))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	syn.Transmit(1.0)
+	received := post.GetReceivedMessages()
+	if len(received) != 1 || received[0].Value != 2.0 {
+		t.Fatalf("Expected scaled value 2.0, got %+v", received)
+	}
+	if len(seen) != 1 || seen[0] != 2.0 {
+		t.Errorf("Expected recorder to run after scaling, saw %v", seen)
+	}
+
+	// Bulk configuration appends to existing chains
+	other := NewBasicSynapse("other", pre, post, CreateDefaultSTDPConfig(), CreateDefaultPruningConfig(), 0.5, 0)
+	count := UseMiddlewareOn([]component.SynapticProcessor{syn, other}, nil, ScaleMiddleware(0.5))
+	if count != 2 || syn.GetMiddlewareCount() != 3 || other.GetMiddlewareCount() != 1 {
+		t.Errorf("Unexpected chain lengths: %d configured, %d and %d", count, syn.GetMiddlewareCount(), other.GetMiddlewareCount())
+	}
+	post.ClearReceivedMessages()
+	syn.Transmit(1.0)
+	if got := post.GetReceivedMessages()[0].Value; got != 1.0 {
+		t.Errorf("Expected 0.5 × 4 × 0.5 = 1.0, got %f", got)
+	}
+
+	syn.ClearMiddleware()
+	post.ClearReceivedMessages()
+	syn.Transmit(1.0)
+	if got := post.GetReceivedMessages()[0].Value; got != 0.5 || syn.GetMiddlewareCount() != 0 {
+		t.Errorf("Expected plain transmission after clearing, got %f", got)
+	}
+}
+
+// TestNoiseMiddleware verifies the noise is reproducible and has the
+// requested spread.
+func TestNoiseMiddleware(t *testing.T) {
+	a, b := NoiseMiddleware(0.1, 3), NoiseMiddleware(0.1, 3)
+	sum, sumSq := 0.0, 0.0
+	const samples = 5000
+	for i := 0; i < samples; i++ {
+		x, y := a(types.NeuralSignal{Value: 1}), b(types.NeuralSignal{Value: 1})
+		if x.Value != y.Value {
+			t.Fatal("Expected identical noise for identical seeds")
+		}
+		sum += x.Value - 1
+		sumSq += (x.Value - 1) * (x.Value - 1)
+	}
+	mean := sum / samples
+	if std := math.Sqrt(sumSq/samples - mean*mean); math.Abs(mean) > 0.01 || math.Abs(std-0.1) > 0.01 {
+		t.Errorf("Expected zero-mean noise with std 0.1, got mean %f std %f", mean, std)
+	}
+}
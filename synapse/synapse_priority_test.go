@@ -0,0 +1,33 @@
+package synapse
+
+import (
+	"testing"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// TestPriority_StampedOnDeliveredSignals verifies that a synapse's priority
+// class reaches the receiver and that unknown classes are rejected.
+func TestPriority_StampedOnDeliveredSignals(t *testing.T) {
+	post := NewMockNeuron("post")
+	syn, err := NewSynapse("sensory", NewMockNeuron("pre"), post, WithDelay(0), WithPriority(types.PriorityCritical))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	syn.Transmit(1.0)
+	if err := syn.SetPriority(types.PriorityBackground); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	syn.Transmit(1.0)
+
+	messages := post.GetReceivedMessages()
+	if len(messages) != 2 || messages[0].Priority != types.PriorityCritical || messages[1].Priority != types.PriorityBackground {
+		t.Errorf("Expected critical then background signals, got %+v", messages)
+	}
+	if err := syn.SetPriority(types.Priority(5)); err == nil || syn.GetPriority() != types.PriorityBackground {
+		t.Error("Expected unknown priority rejected")
+	}
+	if _, err := NewSynapse("bad", NewMockNeuron("pre"), post, WithPriority(types.Priority(-2))); err == nil {
+		t.Error("Expected error for unknown priority option")
+	}
+}
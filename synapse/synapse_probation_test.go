@@ -0,0 +1,98 @@
+package synapse
+
+import (
+	"testing"
+	"time"
+)
+
+// probationSynapse returns a weak synapse with a pruning probation period.
+// Activity protection lasts InactivityThreshold/10 = 1ms.
+func probationSynapse(t *testing.T, probation time.Duration) *BasicSynapse {
+	t.Helper()
+	syn, err := NewSynapse("probation", NewMockNeuron("pre"), NewMockNeuron("post"),
+		WithWeight(0.02),
+		WithPruningConfig(PruningConfig{
+			Enabled:             true,
+			WeightThreshold:     0.1,
+			InactivityThreshold: 10 * time.Millisecond,
+			ConfirmThreshold:    0.2,
+			ProbationPeriod:     probation,
+		}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return syn
+}
+
+// TestPruningProbation_MarkRecoverConfirm verifies that a marked synapse is
+// only pruned after the probation period and that recovery must clear the
+// confirm threshold, not just the mark threshold.
+func TestPruningProbation_MarkRecoverConfirm(t *testing.T) {
+	syn := probationSynapse(t, 30*time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+
+	if syn.ShouldPrune() {
+		t.Fatal("Expected the first failing evaluation to mark, not prune")
+	}
+	markedAt, marked := syn.GetPruningMark()
+	if !marked {
+		t.Fatal("Expected the weak synapse to be marked")
+	}
+
+	// Recovery into the hysteresis band keeps the mark
+	syn.SetWeight(0.15)
+	time.Sleep(2 * time.Millisecond)
+	if syn.ShouldPrune() {
+		t.Error("Expected no pruning during probation")
+	}
+	if at, ok := syn.GetPruningMark(); !ok || !at.Equal(markedAt) {
+		t.Errorf("Expected the mark from %v kept in the hysteresis band, got %v (marked %v)", markedAt, at, ok)
+	}
+
+	// Still marked at the end of probation: pruned
+	time.Sleep(30 * time.Millisecond)
+	if !syn.ShouldPrune() {
+		t.Error("Expected pruning after the probation period")
+	}
+
+	// Recovery above the confirm threshold clears the mark
+	syn = probationSynapse(t, 30*time.Millisecond)
+	time.Sleep(2 * time.Millisecond)
+	syn.ShouldPrune()
+	syn.SetWeight(0.25)
+	time.Sleep(2 * time.Millisecond)
+	if syn.ShouldPrune() {
+		t.Error("Expected a recovered synapse to be kept")
+	}
+	if _, marked := syn.GetPruningMark(); marked {
+		t.Error("Expected recovery above the confirm threshold to clear the mark")
+	}
+	time.Sleep(30 * time.Millisecond)
+	if syn.ShouldPrune() {
+		t.Error("Expected a recovered synapse to survive past the old probation period")
+	}
+}
+
+// TestPruningProbation_DefaultsAndValidation verifies that pruning without a
+// probation period is immediate and that invalid settings are rejected.
+func TestPruningProbation_DefaultsAndValidation(t *testing.T) {
+	syn := probationSynapse(t, 0)
+	time.Sleep(2 * time.Millisecond)
+	if !syn.ShouldPrune() {
+		t.Error("Expected immediate pruning without a probation period")
+	}
+	if _, marked := syn.GetPruningMark(); marked {
+		t.Error("Expected no mark without a probation period")
+	}
+
+	invalid := []PruningConfig{
+		{Enabled: true, WeightThreshold: 0.1, ProbationPeriod: -time.Second},
+		{Enabled: true, WeightThreshold: 0.1, ConfirmThreshold: -0.1},
+		{Enabled: true, WeightThreshold: 0.1, ConfirmThreshold: 0.05},
+	}
+	for _, config := range invalid {
+		if _, err := NewSynapse("invalid", NewMockNeuron("pre"), NewMockNeuron("post"), WithPruningConfig(config)); err == nil {
+			t.Errorf("Expected pruning config %+v to be rejected", config)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package synapse
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// releaseAt draws one release at a virtual time, as Transmit does with the
+// wall clock, and returns the amplitude factor.
+func releaseAt(syn *BasicSynapse, at time.Time) float64 {
+	syn.mutex.Lock()
+	defer syn.mutex.Unlock()
+	return syn.releaseQuantaUnsafe(at)
+}
+
+// TestQuantal_DepressionAndRecovery verifies that the rested mean amplitude
+// matches the deterministic one, that a high-rate train depletes the pool and
+// that the pool refills during rest.
+func TestQuantal_DepressionAndRecovery(t *testing.T) {
+	config := CreateDefaultQuantalConfig()
+	config.Seed = 42
+	start := time.Unix(100, 0)
+
+	// Rested synapses: first spike of many fresh pools
+	sum, sumSquares := 0.0, 0.0
+	const trials = 2000
+	for i := 0; i < trials; i++ {
+		config.Seed = int64(i + 1)
+		syn, err := NewSynapse("rested", NewMockNeuron("pre"), NewMockNeuron("post"), WithQuantalRelease(config))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		a := releaseAt(syn, start)
+		sum += a
+		sumSquares += a * a
+	}
+	mean := sum / trials
+	if math.Abs(mean-1) > 0.05 {
+		t.Errorf("Expected rested mean amplitude near 1, got %f", mean)
+	}
+	if variance := sumSquares/trials - mean*mean; variance <= 0.01 {
+		t.Errorf("Expected trial-to-trial variability, got variance %f", variance)
+	}
+
+	// 100Hz train: the pool empties faster than it refills
+	config.Seed = 7
+	syn, _ := NewSynapse("train", NewMockNeuron("pre"), NewMockNeuron("post"), WithQuantalRelease(config))
+	at := start
+	early, late := 0.0, 0.0
+	for i := 0; i < 100; i++ {
+		a := releaseAt(syn, at)
+		if i < 3 {
+			early += a / 3
+		}
+		if i >= 80 {
+			late += a / 20
+		}
+		at = at.Add(10 * time.Millisecond)
+	}
+	if late >= early/2 {
+		t.Errorf("Expected depression at 100Hz, early %f late %f", early, late)
+	}
+	state, ok := syn.GetQuantalState()
+	if !ok || state.Transmitted != 100 || state.Failures == 0 || state.Available > state.Sites {
+		t.Errorf("Unexpected pool state after train: %+v", state)
+	}
+
+	// Five recovery time constants of rest refill almost every site
+	recovered := 0.0
+	for i := 0; i < 200; i++ {
+		syn.mutex.Lock()
+		syn.quantal.available = 0
+		syn.quantal.updatedAt = at
+		syn.mutex.Unlock()
+		recovered += releaseAt(syn, at.Add(5*config.RecoveryTime)) / 200
+	}
+	if math.Abs(recovered-1) > 0.1 {
+		t.Errorf("Expected recovered mean amplitude near 1, got %f", recovered)
+	}
+}
+
+// TestQuantal_TransmitScalesWithWeight verifies that Transmit delivers whole
+// quanta scaled by the weight, drops failures and rejects invalid configs.
+func TestQuantal_TransmitScalesWithWeight(t *testing.T) {
+	config := QuantalConfig{
+		Enabled:            true,
+		Sites:              4,
+		ReleaseProbability: 0.5,
+		QuantalSize:        0.25,
+		RecoveryTime:       time.Hour, // no refill during the test
+		Seed:               3,
+	}
+	post := NewMockNeuron("post")
+	syn, err := NewSynapse("quantal", NewMockNeuron("pre"), post, WithWeight(0.8), WithDelay(0), WithQuantalRelease(config))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		syn.Transmit(1.0)
+	}
+
+	state, _ := syn.GetQuantalState()
+	messages := post.GetReceivedMessages()
+	if int64(len(messages)) != state.Transmitted-state.Failures {
+		t.Errorf("Expected %d deliveries, got %d", state.Transmitted-state.Failures, len(messages))
+	}
+	if state.Released > int64(config.Sites) || int64(state.Available)+state.Released != int64(config.Sites) {
+		t.Errorf("Expected a finite pool of %d vesicles, got %+v", config.Sites, state)
+	}
+	total := 0.0
+	for _, msg := range messages {
+		quanta := msg.Value / (0.8 * config.QuantalSize)
+		if quanta < 1 || math.Abs(quanta-math.Round(quanta)) > 1e-9 {
+			t.Errorf("Expected a whole number of quanta, got %f", quanta)
+		}
+		total += math.Round(quanta)
+	}
+	if int64(total) != state.Released {
+		t.Errorf("Expected %d delivered quanta, got %f", state.Released, total)
+	}
+
+	// Disabled configs are ignored; invalid enabled ones are rejected
+	if _, err := NewSynapse("off", NewMockNeuron("pre"), post, WithQuantalRelease(QuantalConfig{})); err != nil {
+		t.Errorf("Unexpected error for disabled config: %v", err)
+	}
+	bad := config
+	bad.ReleaseProbability = 1.5
+	if _, err := NewSynapse("bad", NewMockNeuron("pre"), post, WithQuantalRelease(bad)); err == nil {
+		t.Error("Expected error for release probability above 1")
+	}
+}
@@ -0,0 +1,81 @@
+package synapse
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSpikeHistoryRetention verifies the default count limit, age limit,
+// exponential thinning and explicit compaction with their statistics.
+func TestSpikeHistoryRetention(t *testing.T) {
+	pre, post := NewMockNeuron("pre"), NewMockNeuron("post")
+	syn, err := NewSynapse("retention", pre, post)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	base := time.Now()
+	for i := 0; i < 50; i++ {
+		syn.RecordPostSpike(base.Add(time.Duration(i) * time.Millisecond))
+	}
+	post20 := syn.GetPostSpikeTimes()
+	if len(post20) != SPIKE_HISTORY_DEFAULT_MAX_COUNT || !post20[0].Equal(base.Add(30*time.Millisecond)) {
+		t.Fatalf("Expected the newest %d spikes by default, got %d from %v", SPIKE_HISTORY_DEFAULT_MAX_COUNT, len(post20), post20[0].Sub(base))
+	}
+	if stats := syn.GetRetentionStats(); stats.DroppedByCount != 30 || stats.PostRetained != 20 {
+		t.Errorf("Expected 30 spikes dropped by count, got %+v", stats)
+	}
+
+	// Age limit, applied to the existing history at once
+	if err := syn.SetRetentionPolicy(RetentionPolicy{MaxAge: 5 * time.Millisecond}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := len(syn.GetPostSpikeTimes()); n != 6 {
+		t.Errorf("Expected 6 spikes within 5ms of the newest, got %d", n)
+	}
+
+	// Thinning keeps recent spikes exactly and old ones ever sparser
+	policy := RetentionPolicy{ThinAfter: 100 * time.Millisecond, ThinningRatio: 0.1}
+	if err := syn.SetRetentionPolicy(policy); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	thinnedBefore := syn.GetRetentionStats().Thinned
+	for i := 0; i < 10000; i++ {
+		syn.RecordPostSpike(base.Add(time.Second + time.Duration(i)*time.Millisecond))
+	}
+	history := syn.GetPostSpikeTimes()
+	newest := history[len(history)-1]
+	if len(history) < 120 || len(history) > 160 {
+		t.Errorf("Expected about 100 recent and 30 thinned spikes, got %d", len(history))
+	}
+	recent := 0
+	for i, spike := range history {
+		age := newest.Sub(spike)
+		if age <= policy.ThinAfter {
+			recent++
+		} else if i > 0 && float64(spike.Sub(history[i-1])) < policy.ThinningRatio*float64(age) {
+			t.Fatalf("Spike of age %v kept %v after the previous, below the thinning ratio", age, spike.Sub(history[i-1]))
+		}
+	}
+	if recent != 101 {
+		t.Errorf("Expected all 101 spikes within %v kept, got %d", policy.ThinAfter, recent)
+	}
+	if syn.GetRetentionStats().Thinned <= thinnedBefore {
+		t.Error("Expected thinning to be counted")
+	}
+
+	// Explicit compaction measures age from the given time
+	if err := syn.SetRetentionPolicy(RetentionPolicy{MaxAge: time.Second}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	syn.Transmit(1.0)
+	if removed := syn.CompactSpikeHistory(newest.Add(time.Hour)); removed == 0 || len(syn.GetPostSpikeTimes()) != 0 {
+		t.Errorf("Expected the stale post history to be cleared, removed %d", removed)
+	}
+
+	if err := syn.SetRetentionPolicy(RetentionPolicy{MaxCount: -1}); err == nil {
+		t.Error("Expected error for a negative max count")
+	}
+	if _, err := NewSynapse("bad", pre, post, WithRetentionPolicy(RetentionPolicy{ThinningRatio: -1})); err == nil {
+		t.Error("Expected error for a negative thinning ratio")
+	}
+}
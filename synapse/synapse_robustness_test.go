@@ -618,6 +618,62 @@ func TestNumericalStabilityEdgeCases(t *testing.T) {
 	}
 }
 
+// TestConcurrentWeightReadsDuringPlasticity verifies that lock-free weight reads
+// always observe a valid, in-bounds weight while plasticity is being applied
+// from other goroutines.
+func TestConcurrentWeightReadsDuringPlasticity(t *testing.T) {
+	preNeuron := NewMockNeuron("weight_pre")
+	postNeuron := NewMockNeuron("weight_post")
+
+	stdpConfig := CreateDefaultSTDPConfig()
+	synapse := NewBasicSynapse("weight_reader_test", preNeuron, postNeuron,
+		stdpConfig, CreateDefaultPruningConfig(), 0.5, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var reads int64
+
+	// Writers: alternate LTP and LTD adjustments
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			deltaT := -10 * time.Millisecond
+			if id%2 == 1 {
+				deltaT = 10 * time.Millisecond
+			}
+			for ctx.Err() == nil {
+				synapse.ApplyPlasticity(types.PlasticityAdjustment{DeltaT: deltaT, LearningRate: 0.01})
+			}
+		}(i)
+	}
+
+	// Readers: poll the weight as a monitoring tool would
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				w := synapse.GetWeight()
+				if math.IsNaN(w) || w < stdpConfig.MinWeight || w > stdpConfig.MaxWeight {
+					t.Errorf("Observed invalid weight %g outside [%g, %g]", w, stdpConfig.MinWeight, stdpConfig.MaxWeight)
+					return
+				}
+				atomic.AddInt64(&reads, 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if atomic.LoadInt64(&reads) == 0 {
+		t.Fatal("Expected weight reads to make progress during plasticity")
+	}
+	t.Logf("Completed %d concurrent weight reads", reads)
+}
+
 // TestResourceExhaustionRecovery tests behavior under resource pressure
 func TestResourceExhaustionRecovery(t *testing.T) {
 	if testing.Short() {
@@ -0,0 +1,41 @@
+package synapse
+
+import (
+	"testing"
+)
+
+// TestSilencing_BlocksAndRestoresTransmission verifies that a silenced
+// synapse drops spikes without touching its state and transmits normally
+// once restored.
+func TestSilencing_BlocksAndRestoresTransmission(t *testing.T) {
+	post := NewMockNeuron("post")
+	syn, err := NewSynapse("opto", NewMockNeuron("pre"), post, WithWeight(0.5), WithDelay(0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lastTransmission := syn.lastTransmission
+	syn.SetSilenced(true)
+	if !syn.IsSilenced() {
+		t.Fatal("Expected synapse silenced")
+	}
+	for i := 0; i < 3; i++ {
+		syn.Transmit(1.0)
+	}
+	if got := len(post.GetReceivedMessages()); got != 0 {
+		t.Errorf("Expected no deliveries while silenced, got %d", got)
+	}
+	if got := syn.GetSilencedSpikes(); got != 3 {
+		t.Errorf("Expected 3 silenced spikes, got %d", got)
+	}
+	if !syn.lastTransmission.Equal(lastTransmission) {
+		t.Error("Expected silenced spikes to leave activity tracking untouched")
+	}
+
+	syn.SetSilenced(false)
+	syn.Transmit(1.0)
+	messages := post.GetReceivedMessages()
+	if len(messages) != 1 || messages[0].Value != 0.5 {
+		t.Errorf("Expected one delivery of 0.5 after release, got %+v", messages)
+	}
+}
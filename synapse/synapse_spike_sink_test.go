@@ -0,0 +1,60 @@
+package synapse
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// recordingSink collects spikes, optionally declining them.
+type recordingSink struct {
+	accept bool
+	spikes []types.NeuralSignal
+	delays []time.Duration
+}
+
+func (r *recordingSink) EnqueueSpike(msg types.NeuralSignal, delay time.Duration) bool {
+	if !r.accept {
+		return false
+	}
+	r.spikes = append(r.spikes, msg)
+	r.delays = append(r.delays, delay)
+	return true
+}
+
+// TestSpikeSink_TakesDelivery verifies that a sink receives the finished
+// message and delay instead of the target, that declined spikes are
+// delivered directly, and that clearing the sink restores direct delivery.
+func TestSpikeSink_TakesDelivery(t *testing.T) {
+	post := NewMockNeuron("post")
+	syn, err := NewSynapse("batched", NewMockNeuron("pre"), post, WithWeight(0.5), WithDelay(0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	sink := &recordingSink{accept: true}
+	syn.SetSpikeSink(sink)
+	syn.Transmit(1.0)
+	if len(post.GetReceivedMessages()) != 0 {
+		t.Error("Expected the sink to take the spike")
+	}
+	if len(sink.spikes) != 1 || sink.spikes[0].Value != 0.5 || sink.spikes[0].TargetID != "post" || sink.delays[0] != 0 {
+		t.Fatalf("Expected one weighted spike for post, got %+v %v", sink.spikes, sink.delays)
+	}
+
+	sink.accept = false
+	syn.Transmit(1.0)
+	if len(post.GetReceivedMessages()) != 1 {
+		t.Error("Expected a declined spike delivered directly")
+	}
+
+	syn.SetSpikeSink(nil)
+	if syn.GetSpikeSink() != nil {
+		t.Error("Expected the sink cleared")
+	}
+	syn.Transmit(1.0)
+	if len(post.GetReceivedMessages()) != 2 {
+		t.Error("Expected direct delivery without a sink")
+	}
+}
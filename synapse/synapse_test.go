@@ -205,6 +205,12 @@ func TestSynapse_Transmission(t *testing.T) {
 		t.Errorf("Expected synapse ID '%s', got '%s'", synapse.ID(), receivedMsg.SynapseID)
 	}
 
+	// VERIFICATION 4b: Check the interned synapse ID handle
+	// Receivers key their routes by it instead of the string on hot paths
+	if receivedMsg.SynapseIDHandle == 0 || receivedMsg.SynapseIDHandle != synapse.IDHandle() {
+		t.Errorf("Expected ID handle %d, got %d", synapse.IDHandle(), receivedMsg.SynapseIDHandle)
+	}
+
 	// VERIFICATION 5: Check timing information
 	// The timestamp should be recent (within the last second)
 	// This validates that timing information is properly captured
@@ -291,6 +297,13 @@ func TestSynapse_WeightModification(t *testing.T) {
 			expectedMinWeight, synapse.GetWeight())
 	}
 
+	// VERIFICATION 5: NaN has no place within the bounds and is ignored
+	synapse.SetWeight(math.NaN())
+	if synapse.GetWeight() != expectedMinWeight {
+		t.Errorf("Expected NaN to leave the weight at %f, got %f",
+			expectedMinWeight, synapse.GetWeight())
+	}
+
 	// BIOLOGICAL SIGNIFICANCE:
 	// This test validates critical safety mechanisms:
 	// - Upper bounds prevent synapses from becoming pathologically strong
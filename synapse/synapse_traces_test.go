@@ -0,0 +1,81 @@
+package synapse
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestTraceState verifies the reconstructed pre/post traces and the decayed
+// eligibility and rate estimates at a chosen time.
+func TestTraceState(t *testing.T) {
+	pre, post := NewMockNeuron("pre"), NewMockNeuron("post")
+	syn, err := NewSynapse("traces", pre, post, WithMetaplasticity(CreateDefaultMetaplasticityConfig()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	tau := syn.GetPlasticityConfig().TimeConstant
+
+	syn.Transmit(1.0)
+	firstPre := syn.GetPreSpikeTimes()[0]
+	syn.RecordPostSpike(firstPre.Add(tau))
+
+	at := firstPre.Add(2 * tau)
+	state := syn.GetTraceStateAt(at)
+	if math.Abs(state.PreTrace-math.Exp(-2)) > 1e-9 {
+		t.Errorf("Expected pre trace e^-2, got %f", state.PreTrace)
+	}
+	if math.Abs(state.PostTrace-math.Exp(-1)) > 1e-9 {
+		t.Errorf("Expected post trace e^-1, got %f", state.PostTrace)
+	}
+	if state.TraceTimeConstant != tau || state.Weight != syn.GetWeight() {
+		t.Errorf("Unexpected snapshot %+v", state)
+	}
+
+	// Spikes after the sample time do not count
+	if early := syn.GetTraceStateAt(firstPre.Add(tau / 2)); early.PostTrace != 0 || early.PreTrace == 0 {
+		t.Errorf("Expected only the pre spike before its post spike, got %+v", early)
+	}
+
+	// Slow traces decay on their own timescales
+	later := syn.GetTraceStateAt(at.Add(state.EligibilityDecay))
+	if state.Eligibility <= 0 || math.Abs(later.Eligibility-state.Eligibility/math.E) > 1e-9 {
+		t.Errorf("Expected eligibility to fall by 1/e over its decay, got %f -> %f", state.Eligibility, later.Eligibility)
+	}
+	if later.RateEstimate >= state.RateEstimate || state.RateTimeConstant != METAPLASTICITY_DEFAULT_TIME_CONSTANT {
+		t.Errorf("Expected the rate estimate to decay, got %f -> %f", state.RateEstimate, later.RateEstimate)
+	}
+}
+
+// TestTraceSampling verifies the sampling stream and its validation.
+func TestTraceSampling(t *testing.T) {
+	syn, _, _ := newFaultTestSynapse("sampled")
+	if _, err := syn.StartTraceSampling(0, make(chan TraceState)); err == nil {
+		t.Error("Expected error for a zero interval")
+	}
+
+	samples := make(chan TraceState, 16)
+	stop, err := syn.StartTraceSampling(time.Millisecond, samples)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	syn.Transmit(1.0)
+	time.Sleep(20 * time.Millisecond)
+	stop()
+	stop()
+
+	if len(samples) < 2 {
+		t.Fatalf("Expected several samples, got %d", len(samples))
+	}
+	var sawSpike bool
+	for len(samples) > 0 {
+		sample := <-samples
+		sawSpike = sawSpike || sample.PreTrace > 0
+		if sample.SynapseID != "sampled" {
+			t.Errorf("Unexpected sample %+v", sample)
+		}
+	}
+	if !sawSpike {
+		t.Error("Expected the pre-synaptic spike to show up in the samples")
+	}
+}
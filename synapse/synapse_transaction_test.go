@@ -0,0 +1,53 @@
+package synapse
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTransaction_CompareAndSetAndPause verifies that learning advances the
+// weight version, that stale compare-and-set writes are refused and that
+// paused synapses do not learn.
+func TestTransaction_CompareAndSetAndPause(t *testing.T) {
+	start := time.Unix(0, 0)
+	syn, _ := NewSynapse("cas", NewMockNeuron("pre"), NewMockNeuron("post"), WithWeight(0.5))
+	weight, version := syn.GetWeightVersion()
+	if weight != 0.5 {
+		t.Fatalf("Expected weight 0.5, got %f", weight)
+	}
+
+	pair(syn, start)
+	if _, ok := syn.CompareAndSetWeight(version, 0.8); ok || syn.GetWeight() == 0.8 {
+		t.Fatal("Expected a write based on a stale version to be refused")
+	}
+	_, version = syn.GetWeightVersion()
+	next, ok := syn.CompareAndSetWeight(version, 0.8)
+	if !ok || syn.GetWeight() != 0.8 || next == version {
+		t.Fatalf("Expected a current write to succeed and advance the version, got %v (version %d -> %d)", ok, version, next)
+	}
+	if _, ok := syn.CompareAndSetWeight(next, 100); !ok || syn.GetWeight() != syn.GetPlasticityConfig().MaxWeight {
+		t.Errorf("Expected the write clamped to the maximum weight, got %f", syn.GetWeight())
+	}
+
+	syn.SetWeight(0.5)
+	syn.PausePlasticity()
+	syn.PausePlasticity()
+	_, paused := syn.GetWeightVersion()
+	if change := pair(syn, start.Add(time.Second)); change != 0 {
+		t.Errorf("Expected no learning while paused, got %g", change)
+	}
+	if _, version := syn.GetWeightVersion(); version != paused {
+		t.Error("Expected a paused synapse to keep its weight version")
+	}
+	syn.ResumePlasticity()
+	if !syn.IsPlasticityPaused() {
+		t.Error("Expected pauses to nest")
+	}
+	syn.ResumePlasticity()
+	if change := pair(syn, start.Add(2*time.Second)); change <= 0 {
+		t.Errorf("Expected learning after resuming, got %g", change)
+	}
+	if err := syn.ResumePlasticity(); err == nil {
+		t.Error("Expected error resuming an unpaused synapse")
+	}
+}
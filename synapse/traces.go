@@ -0,0 +1,125 @@
+package synapse
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// =================================================================================
+// SYNAPTIC TRACE INSPECTION
+// =================================================================================
+//
+// Trace-based formulations of STDP (Morrison et al. 2008) keep one decaying
+// variable per side of the synapse. The pre-synaptic trace jumps by 1 at every
+// pre spike and decays with the STDP time constant; the post-synaptic trace
+// does the same for post spikes. LTP at a post spike is proportional to the
+// pre trace and LTD at a pre spike to the post trace. The eligibility trace
+// decays on a much slower timescale and gates neuromodulated learning, and the
+// metaplasticity rate estimate is slower still.
+//
+// The synapse keeps spike times rather than explicit traces, so the pre and
+// post traces are reconstructed from the spike history the retention policy
+// keeps (by default the last 20 spikes per side). Older spikes have decayed to
+// a negligible value for any realistic retention and time constant.
+
+// TraceState is a snapshot of a synapse's learning traces at one time.
+type TraceState struct {
+	SynapseID string    `json:"synapse_id"`
+	Time      time.Time `json:"time"`
+	Weight    float64   `json:"weight"`
+
+	PreTrace          float64       `json:"pre_trace"`           // Σ exp(-(t - t_pre)/τ)
+	PostTrace         float64       `json:"post_trace"`          // Σ exp(-(t - t_post)/τ)
+	TraceTimeConstant time.Duration `json:"trace_time_constant"` // τ: the STDP time constant
+
+	Eligibility      float64       `json:"eligibility"`
+	EligibilityDecay time.Duration `json:"eligibility_decay"`
+
+	RateEstimate     float64       `json:"rate_estimate"`                // Metaplasticity activity estimate (Hz), 0 if disabled
+	RateTimeConstant time.Duration `json:"rate_time_constant,omitempty"` // Metaplasticity averaging time
+}
+
+// GetTraceState returns the synapse's traces now.
+func (s *BasicSynapse) GetTraceState() TraceState {
+	return s.GetTraceStateAt(s.now())
+}
+
+// GetTraceStateAt returns the synapse's traces at time at. Spikes after at
+// are ignored, so a virtual-clock simulation can sample its own timeline.
+func (s *BasicSynapse) GetTraceStateAt(at time.Time) TraceState {
+	s.settleForgetting()
+	s.mutex.RLock()
+	state := TraceState{
+		SynapseID:         s.id,
+		Time:              at,
+		TraceTimeConstant: s.stdpConfig.TimeConstant,
+		EligibilityDecay:  s.eligibilityDecay,
+		Weight:            s.loadWeight(),
+	}
+	if s.eligibilityDecay > 0 {
+		elapsed := at.Sub(s.eligibilityTimestamp)
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		state.Eligibility = s.eligibilityTrace * math.Exp(-float64(elapsed)/float64(s.eligibilityDecay))
+	}
+	if s.metaplasticity != nil {
+		state.RateEstimate = s.metaplasticity.rateAt(at)
+		state.RateTimeConstant = s.metaplasticity.config.TimeConstant
+	}
+	s.mutex.RUnlock()
+
+	s.spikeTimingMutex.RLock()
+	state.PreTrace = spikeTrace(s.preSpikeTimes, at, state.TraceTimeConstant)
+	state.PostTrace = spikeTrace(s.postSpikeTimes, at, state.TraceTimeConstant)
+	s.spikeTimingMutex.RUnlock()
+	return state
+}
+
+// spikeTrace sums exponentially decayed contributions of spikes up to at.
+func spikeTrace(spikes []time.Time, at time.Time, tau time.Duration) float64 {
+	if tau <= 0 {
+		return 0
+	}
+	trace := 0.0
+	for _, spike := range spikes {
+		if elapsed := at.Sub(spike); elapsed >= 0 {
+			trace += math.Exp(-float64(elapsed) / float64(tau))
+		}
+	}
+	return trace
+}
+
+// StartTraceSampling sends a TraceState to ch every interval until the
+// returned stop function is called. Sends never block: samples are dropped
+// while the channel is full.
+func (s *BasicSynapse) StartTraceSampling(interval time.Duration, ch chan<- TraceState) (stop func(), err error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("trace sampling interval must be positive: %v", interval)
+	}
+	if ch == nil {
+		return nil, fmt.Errorf("trace sampling needs a channel")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				select {
+				case ch <- s.GetTraceStateAt(now):
+				default:
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }, nil
+}
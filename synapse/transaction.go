@@ -0,0 +1,89 @@
+package synapse
+
+import (
+	"fmt"
+	"math"
+)
+
+// =================================================================================
+// OPTIMISTIC WEIGHT UPDATES
+// =================================================================================
+//
+// An external optimizer (a gradient step, a weight import, a pruning tool)
+// typically reads a weight, computes a new one and writes it back. If STDP
+// changes the weight in between, SetWeight silently overwrites the learned
+// change, or the optimizer's step is computed from a stale value. Every
+// weight change advances the synapse's weight version, so writers can use
+// optimistic concurrency instead:
+//
+//	weight, version := syn.GetWeightVersion()
+//	next := optimize(weight)
+//	if _, ok := syn.CompareAndSetWeight(version, next); !ok {
+//	    // Learning moved the weight: read again and retry
+//	}
+//
+// PausePlasticity suspends all learning rules (STDP, neuromodulation and
+// BTSP run at a learning rate of zero) until the matching ResumePlasticity,
+// so a multi-synapse update can be applied without interference. Pauses
+// nest and leave the plasticity configuration untouched. Spike histories
+// and eligibility traces keep being recorded while paused.
+
+// GetWeightVersion returns the weight and its version, read consistently.
+func (s *BasicSynapse) GetWeightVersion() (weight float64, version uint64) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.currentWeight(), s.weightVersion.Load()
+}
+
+// CompareAndSetWeight sets the weight (clamped to the configured bounds)
+// only if no weight change happened since version was read. It returns the
+// current version and whether the weight was written.
+func (s *BasicSynapse) CompareAndSetWeight(version uint64, weight float64) (uint64, bool) {
+	if math.IsNaN(weight) {
+		return s.weightVersion.Load(), false
+	}
+	var audit *PlasticityRecord
+	defer func() { s.auditChange(audit) }()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if current := s.weightVersion.Load(); current != version {
+		return current, false
+	}
+	s.settleForgettingUnsafe()
+	weight = math.Max(s.stdpConfig.MinWeight, math.Min(s.stdpConfig.MaxWeight, weight))
+	if s.auditing() {
+		audit = newPlasticityRecord(AuditRuleSetWeight, s.now(), s.loadWeight(), weight)
+	}
+	s.storeWeight(weight)
+	s.lastPlasticityEvent = s.now()
+	return s.weightVersion.Load(), true
+}
+
+// PausePlasticity suspends learning until the matching ResumePlasticity.
+func (s *BasicSynapse) PausePlasticity() {
+	s.plasticityPauses.Add(1)
+}
+
+// ResumePlasticity ends a PausePlasticity.
+func (s *BasicSynapse) ResumePlasticity() error {
+	if s.plasticityPauses.Add(-1) < 0 {
+		s.plasticityPauses.Add(1)
+		return fmt.Errorf("synapse %s: plasticity is not paused", s.id)
+	}
+	return nil
+}
+
+// IsPlasticityPaused reports whether a pause is in effect.
+func (s *BasicSynapse) IsPlasticityPaused() bool {
+	return s.plasticityPauses.Load() > 0
+}
+
+// WeightTransactional is implemented by synapses that support optimistic
+// weight updates.
+type WeightTransactional interface {
+	GetWeightVersion() (weight float64, version uint64)
+	CompareAndSetWeight(version uint64, weight float64) (uint64, bool)
+	PausePlasticity()
+	ResumePlasticity() error
+}
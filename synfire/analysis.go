@@ -0,0 +1,187 @@
+package synfire
+
+import (
+	"math"
+	"time"
+)
+
+// =================================================================================
+// PROPAGATION ANALYSIS
+// =================================================================================
+//
+// A pool's response to a volley is summarized the way Diesmann et al. (1999)
+// describe pulse packets: the number of spikes a and their temporal spread
+// σ (standard deviation of the spike times). Following (a_k, σ_k) from pool
+// to pool traces the packet's trajectory through state space: a stable chain
+// converges to a fixed point of large a and small σ, a failing one decays
+// towards a = 0. Across trials, each link's transmission probability is the
+// fraction of trials in which pool k+1 became active given that pool k did.
+
+// PoolResponse is one pool's activity during a trial.
+type PoolResponse struct {
+	Spikes     int           // Spikes fired by the pool (a)
+	Neurons    int           // Distinct neurons that fired
+	Active     bool          // Neurons reached ActiveFraction of the pool
+	Latency    time.Duration // Mean spike time after the packet time
+	Dispersion time.Duration // Standard deviation of the spike times (σ)
+}
+
+// Propagation is the response of every pool to one pulse packet.
+type Propagation struct {
+	Packet PulsePacket
+	Pools  []PoolResponse
+
+	// Reached is the number of consecutive pools, from the first, that
+	// became active. Reached == len(Pools) means the packet crossed the
+	// whole chain.
+	Reached int
+}
+
+// Succeeded reports whether the packet reached the last pool.
+func (p *Propagation) Succeeded() bool {
+	return p.Reached == len(p.Pools)
+}
+
+// Trajectory returns the packet's (a_k, σ_k) for every pool.
+func (p *Propagation) Trajectory() []PulsePacket {
+	trajectory := make([]PulsePacket, len(p.Pools))
+	for k, pool := range p.Pools {
+		trajectory[k] = PulsePacket{Spikes: pool.Spikes, Dispersion: pool.Dispersion}
+	}
+	return trajectory
+}
+
+// response summarizes the recorded spikes of the trial that started at
+// packetTime.
+func (c *Chain) response(packet PulsePacket, packetTime time.Time) *Propagation {
+	active := int(math.Ceil(c.config.ActiveFraction * float64(c.config.PoolSize)))
+	if active < 1 {
+		active = 1
+	}
+	run := &Propagation{Packet: packet, Pools: make([]PoolResponse, len(c.spikes))}
+	propagating := true
+	for k, spikes := range c.spikes {
+		pool := &run.Pools[k]
+		pool.Spikes = len(spikes)
+		neurons := make(map[int]bool, len(spikes))
+		var sum, sumSq float64
+		for _, s := range spikes {
+			neurons[s.neuron] = true
+			t := float64(s.at.Sub(packetTime))
+			sum += t
+			sumSq += t * t
+		}
+		pool.Neurons = len(neurons)
+		pool.Active = pool.Neurons >= active
+		if pool.Spikes > 0 {
+			mean := sum / float64(pool.Spikes)
+			pool.Latency = time.Duration(mean)
+			pool.Dispersion = time.Duration(math.Sqrt(math.Max(0, sumSq/float64(pool.Spikes)-mean*mean)))
+		}
+		if propagating && pool.Active {
+			run.Reached++
+		} else {
+			propagating = false
+		}
+	}
+	return run
+}
+
+// PoolStats averages a pool's response over the trials in which it was
+// active.
+type PoolStats struct {
+	ActiveRate float64       // Fraction of trials in which the pool was active
+	Spikes     float64       // Mean spike count
+	Latency    time.Duration // Mean latency after the packet time
+	Dispersion time.Duration // Mean temporal dispersion
+}
+
+// LinkStats describes the link from pool k to pool k+1.
+type LinkStats struct {
+	// Transmission is P(pool k+1 active | pool k active); 0 if pool k was
+	// never active.
+	Transmission float64
+
+	// Delay is the mean latency difference between the two pools over
+	// trials in which both were active.
+	Delay time.Duration
+
+	// DispersionChange is the mean σ_{k+1} - σ_k over the same trials;
+	// negative values mean the link re-synchronizes the volley.
+	DispersionChange time.Duration
+}
+
+// Stats summarizes propagation over repeated trials.
+type Stats struct {
+	Trials      int
+	Reliability float64 // Fraction of trials that reached the last pool
+	MeanReached float64 // Mean number of consecutive active pools
+	Pools       []PoolStats
+	Links       []LinkStats
+}
+
+// Summarize computes propagation statistics over trials of the same chain.
+func Summarize(runs []*Propagation) *Stats {
+	stats := &Stats{Trials: len(runs)}
+	if len(runs) == 0 {
+		return stats
+	}
+	pools := len(runs[0].Pools)
+	stats.Pools = make([]PoolStats, pools)
+	if pools > 1 {
+		stats.Links = make([]LinkStats, pools-1)
+	}
+
+	activeCount := make([]int, pools)
+	latency, dispersion := make([]float64, pools), make([]float64, pools)
+	linkBoth := make([]int, len(stats.Links))
+	linkDelay := make([]float64, len(stats.Links))
+	linkDispersion := make([]float64, len(stats.Links))
+
+	for _, run := range runs {
+		if run.Succeeded() {
+			stats.Reliability++
+		}
+		stats.MeanReached += float64(run.Reached)
+		for k, pool := range run.Pools {
+			if !pool.Active {
+				continue
+			}
+			activeCount[k]++
+			stats.Pools[k].Spikes += float64(pool.Spikes)
+			latency[k] += float64(pool.Latency)
+			dispersion[k] += float64(pool.Dispersion)
+			if k+1 < pools && run.Pools[k+1].Active {
+				next := run.Pools[k+1]
+				linkBoth[k]++
+				linkDelay[k] += float64(next.Latency - pool.Latency)
+				linkDispersion[k] += float64(next.Dispersion - pool.Dispersion)
+			}
+		}
+	}
+
+	n := float64(len(runs))
+	stats.Reliability /= n
+	stats.MeanReached /= n
+	for k := range stats.Pools {
+		pool := &stats.Pools[k]
+		pool.ActiveRate = float64(activeCount[k]) / n
+		if activeCount[k] > 0 {
+			a := float64(activeCount[k])
+			pool.Spikes /= a
+			pool.Latency = time.Duration(latency[k] / a)
+			pool.Dispersion = time.Duration(dispersion[k] / a)
+		}
+	}
+	for k := range stats.Links {
+		link := &stats.Links[k]
+		if activeCount[k] > 0 {
+			link.Transmission = float64(linkBoth[k]) / float64(activeCount[k])
+		}
+		if linkBoth[k] > 0 {
+			link.Delay = time.Duration(linkDelay[k] / float64(linkBoth[k]))
+			link.DispersionChange = time.Duration(linkDispersion[k] / float64(linkBoth[k]))
+		}
+	}
+	return stats
+}
@@ -0,0 +1,413 @@
+/*
+=================================================================================
+SYNFIRE - SYNFIRE CHAIN CONSTRUCTION AND PROPAGATION ANALYSIS
+=================================================================================
+
+A synfire chain (Abeles 1982) is a sequence of neuron pools in which every
+pool projects feedforward onto the next. A synchronous volley in the first
+pool - a pulse packet of a spikes with temporal spread σ - either travels
+down the chain, each link re-synchronizing it, or dies out. Diesmann et al.
+(1999) showed that the (a, σ) plane has a stable attractor of synchronous
+propagation and a basin of extinction separated by a separatrix; the chain is
+the classic benchmark for whether a network can transmit precise spike
+timing.
+
+This package builds chains and measures them:
+
+  - Builder: Pools pools of PoolSize leaky integrate-and-fire neurons
+    (batch.Population per pool) on a cosim.LockStep virtual clock. Each neuron
+    receives Convergence inputs from the previous pool (0 = all of it)
+    through synapse.BasicSynapse connections whose delays jitter around
+    Delay. Optional Poisson background noise makes the membranes fluctuate
+    like in cortex.
+  - Stimulus: Propagate delivers a pulse packet to the first pool and records
+    every pool's response on the virtual clock.
+  - Analysis: per pool spike count and temporal dispersion (the state-space
+    trajectory (a_k, σ_k)), per link transmission probability and latency,
+    and end-to-end reliability over repeated trials.
+
+	chain, _ := synfire.NewChain(synfire.DefaultConfig())
+	stats, _ := chain.Measure(synfire.PulsePacket{Spikes: 60, Dispersion: time.Millisecond}, 20)
+	fmt.Println(stats.Reliability, stats.Links[0].Transmission, stats.Pools[5].Dispersion)
+
+Runs are reproducible from Seed and take the time of the computation, not of
+the simulated interval.
+=================================================================================
+*/
+
+package synfire
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/batch"
+	"github.com/SynapticNetworks/temporal-neuron/cosim"
+	"github.com/SynapticNetworks/temporal-neuron/synapse"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// Chain defaults after Diesmann et al. (1999): 100-neuron pools with full
+// feedforward connectivity, a 10ms membrane and a pulse packet of 50 or
+// more spikes needed to fire the next pool reliably.
+const (
+	SYNFIRE_DEFAULT_POOLS           = 10
+	SYNFIRE_DEFAULT_POOL_SIZE       = 100
+	SYNFIRE_DEFAULT_WEIGHT          = 0.03
+	SYNFIRE_DEFAULT_DELAY           = 2 * time.Millisecond
+	SYNFIRE_DEFAULT_DELAY_JITTER    = 200 * time.Microsecond
+	SYNFIRE_DEFAULT_THRESHOLD       = 1.0
+	SYNFIRE_DEFAULT_MEMBRANE_TAU    = 10 * time.Millisecond
+	SYNFIRE_DEFAULT_REFRACTORY      = 2 * time.Millisecond
+	SYNFIRE_DEFAULT_RESOLUTION      = 100 * time.Microsecond
+	SYNFIRE_DEFAULT_SETTLE_TIME     = 50 * time.Millisecond
+	SYNFIRE_DEFAULT_ACTIVE_FRACTION = 0.5
+
+	// SYNFIRE_LINK_WINDOW is the time allowed per link for a volley to
+	// arrive and integrate, beyond the synaptic delay.
+	SYNFIRE_LINK_WINDOW = 5 * time.Millisecond
+
+	synfireSpikeCallbackID = "synfire_recorder"
+	synfireStimulusID      = "synfire_stimulus"
+	synfireNoiseID         = "synfire_noise"
+)
+
+// =================================================================================
+// CONFIGURATION
+// =================================================================================
+
+// Config describes a chain. Zero values select the defaults, except
+// Convergence (0 = full connectivity) and the noise settings (0 = none).
+type Config struct {
+	Pools       int           // Number of pools
+	PoolSize    int           // Neurons per pool (the pool width w)
+	Convergence int           // Inputs per neuron from the previous pool (0 = all)
+	Weight      float64       // Feedforward synaptic weight
+	Delay       time.Duration // Mean synaptic delay of a link
+	DelayJitter time.Duration // Delays are uniform in Delay ± DelayJitter
+
+	Threshold   float64       // Firing threshold
+	MembraneTau time.Duration // Membrane time constant
+	Refractory  time.Duration // Absolute refractory period
+	Resolution  time.Duration // Virtual clock tick
+
+	NoiseRate   float64 // Poisson background input per neuron (Hz)
+	NoiseWeight float64 // Size of one background input (negative = inhibitory)
+
+	ActiveFraction float64       // Fraction of a pool that must fire for the pool to count as active
+	SettleTime     time.Duration // Quiet time after each trial so the chain returns to rest
+
+	Seed int64 // Seed for wiring, stimuli and noise
+}
+
+// DefaultConfig returns a fully connected 10-pool chain without noise.
+func DefaultConfig() Config {
+	return Config{
+		Pools:          SYNFIRE_DEFAULT_POOLS,
+		PoolSize:       SYNFIRE_DEFAULT_POOL_SIZE,
+		Weight:         SYNFIRE_DEFAULT_WEIGHT,
+		Delay:          SYNFIRE_DEFAULT_DELAY,
+		DelayJitter:    SYNFIRE_DEFAULT_DELAY_JITTER,
+		Threshold:      SYNFIRE_DEFAULT_THRESHOLD,
+		MembraneTau:    SYNFIRE_DEFAULT_MEMBRANE_TAU,
+		Refractory:     SYNFIRE_DEFAULT_REFRACTORY,
+		Resolution:     SYNFIRE_DEFAULT_RESOLUTION,
+		ActiveFraction: SYNFIRE_DEFAULT_ACTIVE_FRACTION,
+		SettleTime:     SYNFIRE_DEFAULT_SETTLE_TIME,
+	}
+}
+
+// applyDefaults fills zero settings and validates the rest.
+func applyDefaults(c *Config) error {
+	defaults := DefaultConfig()
+	if c.Pools < 0 || c.PoolSize < 0 || c.Convergence < 0 || c.Weight < 0 || c.Delay < 0 || c.DelayJitter < 0 ||
+		c.Threshold < 0 || c.MembraneTau < 0 || c.Refractory < 0 || c.Resolution < 0 || c.NoiseRate < 0 || c.SettleTime < 0 {
+		return fmt.Errorf("synfire chain settings cannot be negative")
+	}
+	if c.Pools == 0 {
+		c.Pools = defaults.Pools
+	}
+	if c.PoolSize == 0 {
+		c.PoolSize = defaults.PoolSize
+	}
+	if c.Weight == 0 {
+		c.Weight = defaults.Weight
+	}
+	if c.Delay == 0 {
+		c.Delay = defaults.Delay
+	}
+	if c.Threshold == 0 {
+		c.Threshold = defaults.Threshold
+	}
+	if c.MembraneTau == 0 {
+		c.MembraneTau = defaults.MembraneTau
+	}
+	if c.Refractory == 0 {
+		c.Refractory = defaults.Refractory
+	}
+	if c.Resolution == 0 {
+		c.Resolution = defaults.Resolution
+	}
+	if c.ActiveFraction == 0 {
+		c.ActiveFraction = defaults.ActiveFraction
+	}
+	if c.SettleTime == 0 {
+		c.SettleTime = defaults.SettleTime
+	}
+
+	if c.Pools < 2 {
+		return fmt.Errorf("synfire chain needs at least two pools: %d", c.Pools)
+	}
+	if c.Convergence > c.PoolSize {
+		return fmt.Errorf("convergence %d exceeds the pool size %d", c.Convergence, c.PoolSize)
+	}
+	if c.DelayJitter >= c.Delay {
+		return fmt.Errorf("delay jitter %v must be smaller than the delay %v", c.DelayJitter, c.Delay)
+	}
+	if c.ActiveFraction < 0 || c.ActiveFraction > 1 {
+		return fmt.Errorf("active fraction must be in [0, 1]: %f", c.ActiveFraction)
+	}
+	return nil
+}
+
+// =================================================================================
+// CHAIN
+// =================================================================================
+
+// Chain is a synfire chain on its own virtual clock. It is not safe for
+// concurrent use.
+type Chain struct {
+	config   Config
+	runner   *cosim.LockStep
+	epoch    time.Time
+	pools    []*batch.Population
+	synapses [][]*synapse.BasicSynapse // [link] synapses from pool link to pool link+1
+	rng      *rand.Rand
+
+	recording bool
+	spikes    [][]spikeEvent // [pool] spikes of the current trial
+}
+
+// spikeEvent is one recorded spike.
+type spikeEvent struct {
+	neuron int
+	at     time.Time
+}
+
+// NewChain builds the pools and the feedforward links.
+func NewChain(config Config) (*Chain, error) {
+	if err := applyDefaults(&config); err != nil {
+		return nil, err
+	}
+	epoch := time.Unix(0, 0)
+	runner, err := cosim.NewLockStep(epoch, config.Resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Chain{
+		config: config,
+		runner: runner,
+		epoch:  epoch,
+		rng:    rand.New(rand.NewSource(config.Seed)),
+		spikes: make([][]spikeEvent, config.Pools),
+	}
+	decay := math.Exp(-float64(config.Resolution) / float64(config.MembraneTau))
+	for k := 0; k < config.Pools; k++ {
+		pool, err := batch.NewPopulation(fmt.Sprintf("synfire_pool_%d", k), batch.PopulationConfig{
+			Size:             config.PoolSize,
+			Threshold:        config.Threshold,
+			DecayRate:        decay,
+			RefractoryPeriod: config.Refractory,
+			FireFactor:       1.0,
+			DelayScheduler:   runner.Schedule,
+		})
+		if err != nil {
+			return nil, err
+		}
+		runner.AddPopulation(pool)
+		c.pools = append(c.pools, pool)
+	}
+	for k := 0; k+1 < config.Pools; k++ {
+		if err := c.link(k); err != nil {
+			return nil, err
+		}
+	}
+	for k := range c.pools {
+		c.record(k)
+	}
+	if config.NoiseRate > 0 && config.NoiseWeight != 0 {
+		runner.AddStepper(synfireNoiseID, c.noise)
+	}
+	return c, nil
+}
+
+// link wires pool k to pool k+1: every target neuron draws Convergence
+// distinct sources.
+func (c *Chain) link(k int) error {
+	cfg := c.config
+	pre, post := c.pools[k], c.pools[k+1]
+	convergence := cfg.Convergence
+	if convergence == 0 {
+		convergence = cfg.PoolSize
+	}
+
+	outgoing := make([][]*synapse.BasicSynapse, cfg.PoolSize)
+	var all []*synapse.BasicSynapse
+	for j := 0; j < cfg.PoolSize; j++ {
+		sources := c.rng.Perm(cfg.PoolSize)[:convergence]
+		sort.Ints(sources)
+		for _, i := range sources {
+			delay := cfg.Delay
+			if cfg.DelayJitter > 0 {
+				delay += time.Duration((2*c.rng.Float64() - 1) * float64(cfg.DelayJitter))
+			}
+			syn, err := synapse.NewSynapse(fmt.Sprintf("synfire_%d_%d_%d", k, i, j), pre.Member(i), post.Member(j),
+				synapse.WithWeight(cfg.Weight), synapse.WithWeightBounds(0, math.Max(cfg.Weight, 1)),
+				synapse.WithDelay(delay), synapse.WithPlasticityDisabled())
+			if err != nil {
+				return fmt.Errorf("synfire link %d: %w", k, err)
+			}
+			outgoing[i] = append(outgoing[i], syn)
+			all = append(all, syn)
+		}
+	}
+	c.synapses = append(c.synapses, all)
+
+	for i := 0; i < cfg.PoolSize; i++ {
+		targets := outgoing[i]
+		pre.AddOutputCallback(i, fmt.Sprintf("synfire_link_%d", k), types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				for _, syn := range targets {
+					syn.Transmit(msg.Value)
+				}
+				return nil
+			},
+		})
+	}
+	return nil
+}
+
+// record attaches the spike recorder to every neuron of pool k.
+func (c *Chain) record(k int) {
+	for i := 0; i < c.config.PoolSize; i++ {
+		i := i
+		c.pools[k].AddOutputCallback(i, synfireSpikeCallbackID, types.OutputCallback{
+			TransmitMessage: func(msg types.NeuralSignal) error {
+				if c.recording {
+					c.spikes[k] = append(c.spikes[k], spikeEvent{neuron: i, at: msg.Timestamp})
+				}
+				return nil
+			},
+		})
+	}
+}
+
+// noise injects Poisson background input into every neuron.
+func (c *Chain) noise(now time.Time) {
+	p := c.config.NoiseRate * c.config.Resolution.Seconds()
+	for _, pool := range c.pools {
+		for i := 0; i < c.config.PoolSize; i++ {
+			if c.rng.Float64() < p {
+				pool.Inject(i, c.config.NoiseWeight)
+			}
+		}
+	}
+}
+
+// Config returns the configuration with defaults applied.
+func (c *Chain) Config() Config {
+	return c.config
+}
+
+// Pool returns the population of pool k.
+func (c *Chain) Pool(k int) *batch.Population {
+	return c.pools[k]
+}
+
+// Synapses returns the synapses of link k (pool k to pool k+1).
+func (c *Chain) Synapses(link int) []*synapse.BasicSynapse {
+	return append([]*synapse.BasicSynapse(nil), c.synapses[link]...)
+}
+
+// Elapsed returns the virtual time consumed so far.
+func (c *Chain) Elapsed() time.Duration {
+	return c.runner.Now().Sub(c.epoch)
+}
+
+// =================================================================================
+// STIMULUS
+// =================================================================================
+
+// PulsePacket is a volley of Spikes spikes in the first pool, one per
+// distinct neuron, with Gaussian spike times of standard deviation
+// Dispersion around the packet time.
+type PulsePacket struct {
+	Spikes     int
+	Dispersion time.Duration
+}
+
+// Propagate delivers one pulse packet to the first pool, runs the chain
+// until the volley has had time to reach the last pool, lets it settle and
+// returns every pool's response. Spike times are relative to the packet
+// time.
+func (c *Chain) Propagate(packet PulsePacket) (*Propagation, error) {
+	cfg := c.config
+	if packet.Spikes <= 0 || packet.Spikes > cfg.PoolSize {
+		return nil, fmt.Errorf("pulse packet needs 1 to %d spikes: %d", cfg.PoolSize, packet.Spikes)
+	}
+	if packet.Dispersion < 0 {
+		return nil, fmt.Errorf("pulse packet dispersion cannot be negative: %v", packet.Dispersion)
+	}
+
+	// Start the packet after its earliest spikes so none falls in the past
+	lead := 4*packet.Dispersion + cfg.Resolution
+	packetTime := c.runner.Now().Add(lead)
+	for _, i := range c.rng.Perm(cfg.PoolSize)[:packet.Spikes] {
+		delay := lead + time.Duration(c.rng.NormFloat64()*float64(packet.Dispersion))
+		if delay < cfg.Resolution {
+			delay = cfg.Resolution
+		}
+		msg := types.NeuralSignal{
+			Value:     cfg.Threshold,
+			Timestamp: c.runner.Now().Add(delay),
+			SourceID:  synfireStimulusID,
+			TargetID:  c.pools[0].Member(i).ID(),
+		}
+		c.runner.Schedule(msg, c.pools[0].Member(i), delay)
+	}
+
+	for k := range c.spikes {
+		c.spikes[k] = c.spikes[k][:0]
+	}
+	c.recording = true
+	window := lead + 4*packet.Dispersion +
+		time.Duration(cfg.Pools)*(cfg.Delay+cfg.DelayJitter+SYNFIRE_LINK_WINDOW)
+	if err := c.runner.Step(window); err != nil {
+		return nil, err
+	}
+	c.recording = false
+	if err := c.runner.Step(cfg.SettleTime); err != nil {
+		return nil, err
+	}
+	return c.response(packet, packetTime), nil
+}
+
+// Measure runs trials pulse packets and summarizes propagation.
+func (c *Chain) Measure(packet PulsePacket, trials int) (*Stats, error) {
+	if trials <= 0 {
+		return nil, fmt.Errorf("measurement needs at least one trial: %d", trials)
+	}
+	runs := make([]*Propagation, trials)
+	for t := range runs {
+		run, err := c.Propagate(packet)
+		if err != nil {
+			return nil, err
+		}
+		runs[t] = run
+	}
+	return Summarize(runs), nil
+}
@@ -0,0 +1,83 @@
+package synfire
+
+import (
+	"testing"
+	"time"
+)
+
+// TestChainPropagationThreshold checks the classic synfire behaviour: a
+// large pulse packet crosses the chain and is re-synchronized on the way,
+// a small one dies out in the first links.
+func TestChainPropagationThreshold(t *testing.T) {
+	config := Config{Pools: 5, PoolSize: 40, Weight: 0.05, NoiseRate: 300, NoiseWeight: 0.06, Seed: 1}
+
+	chain, err := NewChain(config)
+	if err != nil {
+		t.Fatalf("Failed to build chain: %v", err)
+	}
+	strong, err := chain.Measure(PulsePacket{Spikes: 35, Dispersion: 2 * time.Millisecond}, 5)
+	if err != nil {
+		t.Fatalf("Failed to measure strong packet: %v", err)
+	}
+	if strong.Reliability != 1 {
+		t.Errorf("Expected the strong packet to cross the chain every time, reliability %.2f", strong.Reliability)
+	}
+	for k, link := range strong.Links {
+		if link.Transmission != 1 {
+			t.Errorf("Expected link %d to transmit every volley, got %.2f", k, link.Transmission)
+		}
+		if link.Delay < config.Delay-SYNFIRE_DEFAULT_DELAY_JITTER {
+			t.Errorf("Expected link %d delay of at least the synaptic delay, got %v", k, link.Delay)
+		}
+	}
+	first, last := strong.Pools[0], strong.Pools[len(strong.Pools)-1]
+	if last.Dispersion >= first.Dispersion {
+		t.Errorf("Expected the chain to synchronize the volley: σ %v -> %v", first.Dispersion, last.Dispersion)
+	}
+	t.Logf("Strong packet: σ %v -> %v, latency to last pool %v", first.Dispersion, last.Dispersion, last.Latency)
+
+	chain, err = NewChain(config)
+	if err != nil {
+		t.Fatalf("Failed to build chain: %v", err)
+	}
+	weak, err := chain.Measure(PulsePacket{Spikes: 5, Dispersion: 2 * time.Millisecond}, 5)
+	if err != nil {
+		t.Fatalf("Failed to measure weak packet: %v", err)
+	}
+	if weak.Reliability != 0 || weak.Pools[len(weak.Pools)-1].ActiveRate != 0 {
+		t.Errorf("Expected the weak packet to die out, reliability %.2f", weak.Reliability)
+	}
+}
+
+// TestSummarizeLinks checks the per-link statistics on hand-built trials.
+func TestSummarizeLinks(t *testing.T) {
+	pool := func(active bool, latency, dispersion time.Duration) PoolResponse {
+		spikes := 0
+		if active {
+			spikes = 10
+		}
+		return PoolResponse{Spikes: spikes, Neurons: spikes, Active: active, Latency: latency, Dispersion: dispersion}
+	}
+	runs := []*Propagation{
+		{Pools: []PoolResponse{pool(true, 0, 2*time.Millisecond), pool(true, 3*time.Millisecond, time.Millisecond), pool(true, 6*time.Millisecond, time.Millisecond)}, Reached: 3},
+		{Pools: []PoolResponse{pool(true, 0, 2*time.Millisecond), pool(true, 5*time.Millisecond, 3*time.Millisecond), pool(false, 0, 0)}, Reached: 2},
+	}
+	stats := Summarize(runs)
+
+	if stats.Reliability != 0.5 || stats.MeanReached != 2.5 {
+		t.Errorf("Expected reliability 0.5 and 2.5 pools reached, got %.2f and %.2f", stats.Reliability, stats.MeanReached)
+	}
+	if stats.Links[0].Transmission != 1 || stats.Links[1].Transmission != 0.5 {
+		t.Errorf("Unexpected transmission: %.2f, %.2f", stats.Links[0].Transmission, stats.Links[1].Transmission)
+	}
+	if stats.Links[0].Delay != 4*time.Millisecond || stats.Links[0].DispersionChange != 0 {
+		t.Errorf("Unexpected first link: delay %v, dispersion change %v", stats.Links[0].Delay, stats.Links[0].DispersionChange)
+	}
+	if stats.Pools[2].ActiveRate != 0.5 || stats.Pools[2].Latency != 6*time.Millisecond {
+		t.Errorf("Unexpected last pool: %+v", stats.Pools[2])
+	}
+
+	if _, err := NewChain(Config{Pools: 1}); err == nil {
+		t.Error("Expected a single-pool chain to be rejected")
+	}
+}
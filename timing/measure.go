@@ -0,0 +1,109 @@
+package timing
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// =================================================================================
+// RESOLUTION MEASUREMENT
+// =================================================================================
+//
+// Platforms do not advertise their sleep granularity, and it changes with
+// load, power settings and virtualization, so it has to be measured where
+// the network runs. Measure times a series of sleeps of the requested
+// length under a mode and reports how far they overshoot, together with the
+// smallest step of the clock itself. The overshoot is the error every delay
+// of that length carries.
+
+// Resolution is the measured timing quality of a mode.
+type Resolution struct {
+	Mode      Mode          `json:"mode"`
+	Requested time.Duration `json:"requested"` // Sleep length measured
+	Samples   int           `json:"samples"`
+	Clock     time.Duration `json:"clock"`     // Smallest observed step of the monotonic clock
+	Monotonic bool          `json:"monotonic"` // No clock reading went backwards
+	Median    time.Duration `json:"median"`    // Median overshoot beyond Requested
+	P99       time.Duration `json:"p99"`       // 99th percentile overshoot
+	Max       time.Duration `json:"max"`       // Largest overshoot
+}
+
+// Achieved returns the typical length of a requested sleep.
+func (r Resolution) Achieved() time.Duration {
+	return r.Requested + r.Median
+}
+
+// Meets reports whether 99% of sleeps overshoot by at most tolerance.
+func (r Resolution) Meets(tolerance time.Duration) bool {
+	return r.P99 <= tolerance
+}
+
+// String formats the resolution on one line.
+func (r Resolution) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %v sleeps overshoot by %v (p99 %v, max %v over %d samples), clock step %v",
+		r.Mode, r.Requested, r.Median, r.P99, r.Max, r.Samples, r.Clock)
+	if !r.Monotonic {
+		b.WriteString(", clock NOT monotonic")
+	}
+	return b.String()
+}
+
+// Measure times samples sleeps of request under config (0 samples =
+// TIMING_DEFAULT_SAMPLES). It takes about samples × (request + overshoot).
+func Measure(config Config, request time.Duration, samples int) (Resolution, error) {
+	if err := config.Validate(); err != nil {
+		return Resolution{}, err
+	}
+	if request <= 0 {
+		return Resolution{}, fmt.Errorf("requested sleep must be positive: %v", request)
+	}
+	if samples < 0 {
+		return Resolution{}, fmt.Errorf("samples cannot be negative: %d", samples)
+	}
+	if samples == 0 {
+		samples = TIMING_DEFAULT_SAMPLES
+	}
+
+	resolution := Resolution{Mode: config.Mode, Requested: request, Samples: samples, Monotonic: true}
+	resolution.Clock, resolution.Monotonic = clockStep()
+
+	overshoots := make([]time.Duration, samples)
+	for i := range overshoots {
+		start := time.Now()
+		config.Sleep(request)
+		elapsed := time.Since(start)
+		if elapsed < 0 {
+			resolution.Monotonic = false
+		}
+		overshoots[i] = elapsed - request
+	}
+	sort.Slice(overshoots, func(i, j int) bool { return overshoots[i] < overshoots[j] })
+	resolution.Median = overshoots[samples/2]
+	resolution.P99 = overshoots[(samples*99)/100]
+	resolution.Max = overshoots[samples-1]
+	return resolution, nil
+}
+
+// clockStep returns the smallest non-zero difference between consecutive
+// clock readings, and whether any difference was negative.
+func clockStep() (time.Duration, bool) {
+	const readings = 1000
+	var step time.Duration
+	monotonic := true
+	previous := time.Now()
+	for i := 0; i < readings; i++ {
+		now := time.Now()
+		d := now.Sub(previous)
+		if d < 0 {
+			monotonic = false
+		}
+		if d > 0 && (step == 0 || d < step) {
+			step = d
+		}
+		previous = now
+	}
+	return step, monotonic
+}
@@ -0,0 +1,224 @@
+/*
+=================================================================================
+TIMING - PRECISE SLEEPS AND TICKS ACROSS PLATFORMS
+=================================================================================
+
+Axonal and synaptic delays of a few hundred microseconds only mean something
+if the runtime can wait that long. Go's timers are fine-grained on Linux, but
+on other platforms (and on loaded or virtualized machines) a sleep or ticker
+can overshoot by a millisecond or more, so every sub-millisecond delay
+silently becomes the platform's granularity. The timing package puts the
+choice of how to wait in one place, with three modes:
+
+	ModeSystem   Runtime timers as they are. No overhead; resolution is
+	             whatever the platform gives.
+	ModePrecise  Sleep until SpinThreshold before the deadline, then spin
+	             (yielding to the scheduler) until it passes. Sub-millisecond
+	             resolution everywhere, paid for with CPU time spent
+	             spinning. Tickers spin through at most a quarter of each
+	             interval. For small networks and benchmarks.
+	ModeCoarse   Wait in whole ticks of Granularity and do all the work that
+	             fell due since the last tick at once. Cheapest and most
+	             predictable on coarse platforms; every delay is rounded up
+	             to the tick, so timing differences below Granularity are lost.
+
+The clock itself is Go's monotonic clock, which never runs backwards: all
+timing uses time.Now and Time.Sub, never wall-clock readings. Measure
+reports the resolution a mode actually achieves on the running machine, so
+a network can log it at startup and refuse to run a model whose delays it
+cannot honour:
+
+	config := timing.Config{Mode: timing.ModePrecise}
+	resolution, _ := timing.Measure(config, 100*time.Microsecond, 0)
+	log.Println(resolution) // precise: 100µs sleeps overshoot by 2µs (p99 9µs, ...)
+=================================================================================
+*/
+
+package timing
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const (
+	// TIMING_DEFAULT_SPIN_THRESHOLD is how long before a deadline precise
+	// mode stops sleeping and starts spinning. It should exceed the
+	// platform's sleep overshoot; 1ms covers common desktop systems.
+	TIMING_DEFAULT_SPIN_THRESHOLD = time.Millisecond
+
+	// TIMING_MAX_TICKER_SPIN_FRACTION caps the spinning phase of a precise
+	// ticker at this fraction of its interval. Without the cap a ticker
+	// shorter than the spin threshold (the 100µs axon tick) would spin
+	// through every interval and keep a core busy for good.
+	TIMING_MAX_TICKER_SPIN_FRACTION = 0.25
+
+	// TIMING_DEFAULT_COARSE_GRANULARITY is the tick of coarse mode.
+	TIMING_DEFAULT_COARSE_GRANULARITY = time.Millisecond
+
+	// TIMING_DEFAULT_SAMPLES is the number of sleeps Measure times.
+	TIMING_DEFAULT_SAMPLES = 50
+)
+
+// Mode selects how waits are implemented.
+type Mode int
+
+const (
+	ModeSystem Mode = iota
+	ModePrecise
+	ModeCoarse
+)
+
+// String returns the mode name.
+func (m Mode) String() string {
+	switch m {
+	case ModeSystem:
+		return "system"
+	case ModePrecise:
+		return "precise"
+	case ModeCoarse:
+		return "coarse"
+	default:
+		return fmt.Sprintf("mode(%d)", int(m))
+	}
+}
+
+// Config selects a timing mode. The zero value is ModeSystem.
+type Config struct {
+	Mode          Mode
+	SpinThreshold time.Duration // Precise: spinning phase before each deadline (0 = TIMING_DEFAULT_SPIN_THRESHOLD)
+	Granularity   time.Duration // Coarse: tick length (0 = TIMING_DEFAULT_COARSE_GRANULARITY)
+}
+
+// Validate checks the configuration.
+func (c Config) Validate() error {
+	if c.Mode < ModeSystem || c.Mode > ModeCoarse {
+		return fmt.Errorf("unknown timing mode: %v", c.Mode)
+	}
+	if c.SpinThreshold < 0 || c.Granularity < 0 {
+		return fmt.Errorf("spin threshold and granularity cannot be negative: %v, %v", c.SpinThreshold, c.Granularity)
+	}
+	return nil
+}
+
+// spinThreshold returns the effective spin threshold.
+func (c Config) spinThreshold() time.Duration {
+	if c.SpinThreshold > 0 {
+		return c.SpinThreshold
+	}
+	return TIMING_DEFAULT_SPIN_THRESHOLD
+}
+
+// tickerSpin returns the spinning phase of a precise ticker with the given
+// interval: the spin threshold, capped at a fraction of the interval.
+func (c Config) tickerSpin(interval time.Duration) time.Duration {
+	spin := c.spinThreshold()
+	if limit := time.Duration(float64(interval) * TIMING_MAX_TICKER_SPIN_FRACTION); spin > limit {
+		spin = limit
+	}
+	return max(spin, 1)
+}
+
+// granularity returns the effective coarse tick.
+func (c Config) granularity() time.Duration {
+	if c.Granularity > 0 {
+		return c.Granularity
+	}
+	return TIMING_DEFAULT_COARSE_GRANULARITY
+}
+
+// TickInterval returns the interval a ticker requested at interval
+// actually runs at: coarse mode never ticks faster than its granularity.
+func (c Config) TickInterval(interval time.Duration) time.Duration {
+	if c.Mode == ModeCoarse && interval < c.granularity() {
+		return c.granularity()
+	}
+	return interval
+}
+
+// Sleep waits for d. Coarse mode rounds d up to whole ticks.
+func (c Config) Sleep(d time.Duration) {
+	c.SleepUntil(time.Now().Add(d))
+}
+
+// SleepUntil waits until deadline on the monotonic clock.
+func (c Config) SleepUntil(deadline time.Time) {
+	switch c.Mode {
+	case ModePrecise:
+		spin := c.spinThreshold()
+		for {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return
+			}
+			if remaining > spin {
+				time.Sleep(remaining - spin)
+				continue
+			}
+			runtime.Gosched()
+		}
+	case ModeCoarse:
+		if remaining := time.Until(deadline); remaining > 0 {
+			tick := c.granularity()
+			time.Sleep((remaining + tick - 1) / tick * tick)
+		}
+	default:
+		time.Sleep(time.Until(deadline))
+	}
+}
+
+// Ticker delivers ticks like time.Ticker: ticks are dropped while the
+// receiver is busy, so a slow receiver gets one tick for all it missed.
+type Ticker struct {
+	C    <-chan time.Time
+	stop func()
+}
+
+// Stop turns the ticker off. A precise ticker's goroutine exits after its
+// current wait, at most one interval later.
+func (t *Ticker) Stop() {
+	t.stop()
+}
+
+// NewTicker returns a ticker with the given interval under this mode.
+// Precise tickers run their own goroutine, which spins through the last
+// SpinThreshold of every interval, but at most through
+// TIMING_MAX_TICKER_SPIN_FRACTION of it.
+func (c Config) NewTicker(interval time.Duration) *Ticker {
+	if interval <= 0 {
+		panic(fmt.Sprintf("timing: non-positive ticker interval %v", interval))
+	}
+	if c.Mode != ModePrecise {
+		ticker := time.NewTicker(c.TickInterval(interval))
+		return &Ticker{C: ticker.C, stop: ticker.Stop}
+	}
+	c.SpinThreshold = c.tickerSpin(interval)
+
+	ticks := make(chan time.Time, 1)
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		next := time.Now().Add(interval)
+		for {
+			c.SleepUntil(next)
+			select {
+			case <-done:
+				return
+			default:
+			}
+			now := time.Now()
+			select {
+			case ticks <- now:
+			default:
+			}
+			// Skip ticks that are already past rather than bursting
+			next = next.Add(interval)
+			if next.Before(now) {
+				next = now.Add(interval)
+			}
+		}
+	}()
+	return &Ticker{C: ticks, stop: func() { once.Do(func() { close(done) }) }}
+}
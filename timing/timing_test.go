@@ -0,0 +1,84 @@
+package timing
+
+import (
+	"testing"
+	"time"
+)
+
+// TestModes verifies sleeps, tickers and measurement in every mode.
+func TestModes(t *testing.T) {
+	for _, config := range []Config{
+		{Mode: ModeSystem},
+		{Mode: ModePrecise},
+		{Mode: ModeCoarse, Granularity: 2 * time.Millisecond},
+	} {
+		start := time.Now()
+		config.Sleep(300 * time.Microsecond)
+		if elapsed := time.Since(start); elapsed < 300*time.Microsecond {
+			t.Errorf("%s: sleep returned early after %v", config.Mode, elapsed)
+		}
+
+		ticker := config.NewTicker(500 * time.Microsecond)
+		start = time.Now()
+		for i := 0; i < 3; i++ {
+			<-ticker.C
+		}
+		ticker.Stop()
+		if elapsed := time.Since(start); elapsed < config.TickInterval(500*time.Microsecond)*3-time.Millisecond {
+			t.Errorf("%s: 3 ticks after only %v", config.Mode, elapsed)
+		}
+
+		resolution, err := Measure(config, 200*time.Microsecond, 10)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", config.Mode, err)
+		}
+		if resolution.Samples != 10 || !resolution.Monotonic || resolution.Median < 0 || resolution.Max < resolution.P99 {
+			t.Errorf("%s: implausible resolution %+v", config.Mode, resolution)
+		}
+		t.Log(resolution)
+	}
+
+	// Coarse mode waits whole ticks
+	coarse := Config{Mode: ModeCoarse, Granularity: 5 * time.Millisecond}
+	if resolution, _ := Measure(coarse, time.Millisecond, 5); resolution.Median < 4*time.Millisecond {
+		t.Errorf("Expected coarse sleeps rounded up to 5ms, got %v", resolution.Achieved())
+	}
+	if coarse.TickInterval(time.Millisecond) != 5*time.Millisecond || coarse.TickInterval(time.Second) != time.Second {
+		t.Error("Coarse ticks should be at least one granule")
+	}
+
+	if _, err := Measure(Config{Mode: Mode(7)}, time.Millisecond, 1); err == nil {
+		t.Error("Expected error for an unknown mode")
+	}
+	if _, err := Measure(Config{}, 0, 1); err == nil {
+		t.Error("Expected error for a zero request")
+	}
+}
+
+// TestPreciseTickerSpinsPartOfInterval verifies that a precise ticker
+// shorter than the spin threshold sleeps through most of each interval
+// instead of spinning through all of it.
+func TestPreciseTickerSpinsPartOfInterval(t *testing.T) {
+	config := Config{Mode: ModePrecise}
+	if got := config.tickerSpin(100 * time.Microsecond); got != 25*time.Microsecond {
+		t.Errorf("Expected a 100µs ticker to spin 25µs, got %v", got)
+	}
+	if got := config.tickerSpin(10 * time.Millisecond); got != TIMING_DEFAULT_SPIN_THRESHOLD {
+		t.Errorf("Expected a 10ms ticker to keep the default threshold, got %v", got)
+	}
+	config.SpinThreshold = 10 * time.Microsecond
+	if got := config.tickerSpin(100 * time.Microsecond); got != 10*time.Microsecond {
+		t.Errorf("Expected a shorter configured threshold to be kept, got %v", got)
+	}
+
+	// The capped ticker still ticks at its interval
+	ticker := Config{Mode: ModePrecise}.NewTicker(100 * time.Microsecond)
+	defer ticker.Stop()
+	start := time.Now()
+	for i := 0; i < 20; i++ {
+		<-ticker.C
+	}
+	if elapsed := time.Since(start); elapsed < 2*time.Millisecond {
+		t.Errorf("Expected 20 ticks to take at least 2ms, took %v", elapsed)
+	}
+}
@@ -0,0 +1,157 @@
+package topology
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// SPATIAL LAYOUTS
+// =================================================================================
+
+// GridLayout places n positions on a regular grid with the given spacing (μm).
+// dims selects a 2D sheet (Z = 0) or a 3D lattice.
+func GridLayout(n int, spacing float64, dims int) []types.Position3D {
+	if n <= 0 {
+		return nil
+	}
+	positions := make([]types.Position3D, 0, n)
+	if dims == 2 {
+		side := int(math.Ceil(math.Sqrt(float64(n))))
+		for i := 0; i < n; i++ {
+			positions = append(positions, Planar(float64(i%side)*spacing, float64(i/side)*spacing))
+		}
+		return positions
+	}
+
+	side := int(math.Ceil(math.Cbrt(float64(n))))
+	for i := 0; i < n; i++ {
+		positions = append(positions, types.Position3D{
+			X: float64(i%side) * spacing,
+			Y: float64((i/side)%side) * spacing,
+			Z: float64(i/(side*side)) * spacing,
+		})
+	}
+	return positions
+}
+
+// RandomLayout places n positions uniformly inside box. A box with equal Min.Z
+// and Max.Z produces a 2D layout.
+func RandomLayout(n int, box types.BoundingBox, rng *rand.Rand) []types.Position3D {
+	positions := make([]types.Position3D, n)
+	for i := range positions {
+		positions[i] = types.Position3D{
+			X: box.Min.X + rng.Float64()*(box.Max.X-box.Min.X),
+			Y: box.Min.Y + rng.Float64()*(box.Max.Y-box.Min.Y),
+			Z: box.Min.Z + rng.Float64()*(box.Max.Z-box.Min.Z),
+		}
+	}
+	return positions
+}
+
+// =================================================================================
+// SPATIAL CONNECTION GENERATOR
+// =================================================================================
+
+// Positioned is any component with an ID and a spatial position.
+// Neurons, batch members and mocks all satisfy it through BaseComponent.
+type Positioned interface {
+	ID() string
+	Position() types.Position3D
+}
+
+// Connection describes one generated pre→post connection.
+type Connection struct {
+	PreID    string
+	PostID   string
+	Distance float64       // Euclidean distance (μm)
+	Delay    time.Duration // From DelayDistribution, or derived from the DelayModel
+	Weight   float64       // Drawn from SpatialConfig.Weights (0 without one)
+}
+
+// SynapseFactory creates the synapse for a generated connection. Returning an
+// error aborts generation.
+type SynapseFactory func(pre, post Positioned, conn Connection) error
+
+// SpatialConfig controls distance-dependent connection generation.
+type SpatialConfig struct {
+	Kernel        DistanceKernel // Connection probability as a function of distance
+	Delays        DelayModel     // Transmission delay as a function of distance
+	AllowAutapses bool           // Permit self-connections
+	Seed          int64          // RNG seed (0 = time-based)
+
+	// Weights optionally draws each connection's weight from the generator's
+	// RNG given its distance, e.g. network.DistanceDecayWeight.
+	Weights func(rng *rand.Rand, distance float64) float64
+
+	// DelayDistribution optionally draws each connection's delay from the
+	// generator's RNG instead of Delays, e.g. network.CorticalLongRangeDelay.
+	DelayDistribution func(rng *rand.Rand, distance float64) time.Duration
+}
+
+// ConnectSpatially samples connections between every ordered pair of
+// components according to the distance kernel, and calls factory (if not nil)
+// for each connection with its distance-derived delay and weight.
+//
+// Returns:
+//
+//	The generated connections, or an error if the factory fails
+func ConnectSpatially(pre, post []Positioned, config SpatialConfig, factory SynapseFactory) ([]Connection, error) {
+	if config.Kernel == nil {
+		return nil, fmt.Errorf("spatial connection requires a distance kernel")
+	}
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	var connections []Connection
+	for _, source := range pre {
+		for _, target := range post {
+			if !config.AllowAutapses && source.ID() == target.ID() {
+				continue
+			}
+			distance := Distance(source.Position(), target.Position())
+			if rng.Float64() >= config.Kernel.Probability(distance) {
+				continue
+			}
+
+			conn := Connection{
+				PreID:    source.ID(),
+				PostID:   target.ID(),
+				Distance: distance,
+				Delay:    config.Delays.Delay(source.Position(), target.Position()),
+			}
+			if config.Weights != nil {
+				conn.Weight = config.Weights(rng, distance)
+			}
+			if config.DelayDistribution != nil {
+				conn.Delay = config.DelayDistribution(rng, distance)
+			}
+			if factory != nil {
+				if err := factory(source, target, conn); err != nil {
+					return connections, fmt.Errorf("creating %s→%s: %w", conn.PreID, conn.PostID, err)
+				}
+			}
+			connections = append(connections, conn)
+		}
+	}
+	return connections, nil
+}
+
+// PlaceComponents assigns positions to components in order. Extra positions
+// are ignored; components beyond len(positions) keep their current position.
+func PlaceComponents(components []component.Component, positions []types.Position3D) {
+	for i, c := range components {
+		if i >= len(positions) {
+			return
+		}
+		c.SetPosition(positions[i])
+	}
+}
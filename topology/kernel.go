@@ -0,0 +1,67 @@
+package topology
+
+import "math"
+
+// =================================================================================
+// DISTANCE-DEPENDENT CONNECTION KERNELS
+// =================================================================================
+
+// DistanceKernel maps the distance between two neurons (μm) to the probability
+// that they are connected. Cortical connectivity falls off roughly
+// exponentially or as a Gaussian with a length scale of 100-300 μm.
+type DistanceKernel interface {
+	Probability(distance float64) float64
+}
+
+// ExponentialKernel: p(d) = Peak * exp(-d / LengthConstant)
+type ExponentialKernel struct {
+	Peak           float64 // Probability at zero distance (0-1)
+	LengthConstant float64 // Distance over which p falls by 1/e (μm)
+}
+
+// Probability implements DistanceKernel.
+func (k ExponentialKernel) Probability(distance float64) float64 {
+	if k.LengthConstant <= 0 {
+		return 0
+	}
+	return clampProbability(k.Peak * math.Exp(-distance/k.LengthConstant))
+}
+
+// GaussianKernel: p(d) = Peak * exp(-d² / 2σ²)
+type GaussianKernel struct {
+	Peak  float64 // Probability at zero distance (0-1)
+	Sigma float64 // Spatial spread (μm)
+}
+
+// Probability implements DistanceKernel.
+func (k GaussianKernel) Probability(distance float64) float64 {
+	if k.Sigma <= 0 {
+		return 0
+	}
+	return clampProbability(k.Peak * math.Exp(-distance*distance/(2*k.Sigma*k.Sigma)))
+}
+
+// StepKernel connects with fixed probability within Radius and never beyond.
+type StepKernel struct {
+	P      float64 // Probability inside the radius (0-1)
+	Radius float64 // Maximum connection distance (μm)
+}
+
+// Probability implements DistanceKernel.
+func (k StepKernel) Probability(distance float64) float64 {
+	if distance > k.Radius {
+		return 0
+	}
+	return clampProbability(k.P)
+}
+
+// clampProbability bounds p to [0, 1].
+func clampProbability(p float64) float64 {
+	if p < 0 || math.IsNaN(p) {
+		return 0
+	}
+	if p > 1 {
+		return 1
+	}
+	return p
+}
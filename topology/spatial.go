@@ -0,0 +1,80 @@
+/*
+=================================================================================
+SPATIAL EMBEDDING - DISTANCES AND CONDUCTION DELAYS
+=================================================================================
+
+Neurons carry an optional types.Position3D (micrometres). Planar (2D) networks
+simply leave Z at zero. This file provides the geometry used by topology
+generators and synapse factories: Euclidean distance and axonal conduction
+delay derived from distance and conduction velocity.
+
+Conduction velocities are expressed in μm/ms, which is numerically equal to
+mm/s. The extracellular matrix uses the same unit for its axon speed.
+=================================================================================
+*/
+
+package topology
+
+import (
+	"math"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// Biological conduction velocities (μm/ms)
+const (
+	VELOCITY_UNMYELINATED_SLOW = 500.0   // 0.5 m/s - C fibres
+	VELOCITY_UNMYELINATED_FAST = 2000.0  // 2 m/s - cortical local axons
+	VELOCITY_MYELINATED_MEDIUM = 10000.0 // 10 m/s - A-delta fibres
+	VELOCITY_MYELINATED_FAST   = 80000.0 // 80 m/s - A-alpha fibres
+
+	// DEFAULT_CONDUCTION_VELOCITY matches the matrix default (unmyelinated cortical)
+	DEFAULT_CONDUCTION_VELOCITY = VELOCITY_UNMYELINATED_FAST
+
+	// DEFAULT_SYNAPTIC_DELAY is the fixed vesicle release and diffusion delay
+	// added on top of axonal conduction.
+	DEFAULT_SYNAPTIC_DELAY = 500 * time.Microsecond
+)
+
+// Distance returns the Euclidean distance between two positions in μm.
+func Distance(a, b types.Position3D) float64 {
+	dx := a.X - b.X
+	dy := a.Y - b.Y
+	dz := a.Z - b.Z
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// Planar returns a 2D position (Z = 0) for sheet-like networks such as retina
+// or cortical surface models.
+func Planar(x, y float64) types.Position3D {
+	return types.Position3D{X: x, Y: y}
+}
+
+// ConductionDelay returns the axonal propagation time over distance (μm) at
+// velocity (μm/ms). A non-positive velocity yields zero delay.
+func ConductionDelay(distance, velocity float64) time.Duration {
+	if distance <= 0 || velocity <= 0 {
+		return 0
+	}
+	return time.Duration(distance / velocity * float64(time.Millisecond))
+}
+
+// DelayModel derives total transmission delay from distance.
+type DelayModel struct {
+	SynapticDelay time.Duration // Fixed synaptic component
+	Velocity      float64       // Axonal conduction velocity (μm/ms)
+}
+
+// DefaultDelayModel returns the cortical default delay model.
+func DefaultDelayModel() DelayModel {
+	return DelayModel{
+		SynapticDelay: DEFAULT_SYNAPTIC_DELAY,
+		Velocity:      DEFAULT_CONDUCTION_VELOCITY,
+	}
+}
+
+// Delay returns the synaptic plus conduction delay between two positions.
+func (m DelayModel) Delay(pre, post types.Position3D) time.Duration {
+	return m.SynapticDelay + ConductionDelay(Distance(pre, post), m.Velocity)
+}
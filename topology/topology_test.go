@@ -0,0 +1,176 @@
+package topology
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// testNodes creates positioned components at the given positions.
+func testNodes(positions []types.Position3D) []Positioned {
+	nodes := make([]Positioned, len(positions))
+	for i, p := range positions {
+		nodes[i] = component.NewBaseComponent(fmt.Sprintf("n%d", i), types.TypeNeuron, p)
+	}
+	return nodes
+}
+
+// TestDistanceAndConductionDelay verifies geometry and delay derivation.
+func TestDistanceAndConductionDelay(t *testing.T) {
+	if d := Distance(Planar(0, 0), Planar(3, 4)); d != 5 {
+		t.Errorf("Expected planar distance 5, got %f", d)
+	}
+	if d := Distance(types.Position3D{}, types.Position3D{X: 1, Y: 2, Z: 2}); d != 3 {
+		t.Errorf("Expected 3D distance 3, got %f", d)
+	}
+
+	// 2000 μm at 2000 μm/ms = 1ms
+	if delay := ConductionDelay(2000, VELOCITY_UNMYELINATED_FAST); delay != time.Millisecond {
+		t.Errorf("Expected 1ms conduction delay, got %v", delay)
+	}
+	if delay := ConductionDelay(100, 0); delay != 0 {
+		t.Errorf("Expected zero delay for zero velocity, got %v", delay)
+	}
+
+	model := DelayModel{SynapticDelay: time.Millisecond, Velocity: 1000}
+	if delay := model.Delay(Planar(0, 0), Planar(500, 0)); delay != 1500*time.Microsecond {
+		t.Errorf("Expected 1.5ms total delay, got %v", delay)
+	}
+}
+
+// TestDistanceKernels verifies kernel shapes.
+func TestDistanceKernels(t *testing.T) {
+	exp := ExponentialKernel{Peak: 0.8, LengthConstant: 100}
+	if p := exp.Probability(100); math.Abs(p-0.8/math.E) > 1e-9 {
+		t.Errorf("Expected exponential p(λ) = peak/e, got %f", p)
+	}
+
+	gauss := GaussianKernel{Peak: 1.0, Sigma: 50}
+	if gauss.Probability(0) != 1.0 || gauss.Probability(200) >= gauss.Probability(50) {
+		t.Error("Expected Gaussian kernel to peak at zero and decrease with distance")
+	}
+
+	step := StepKernel{P: 0.5, Radius: 10}
+	if step.Probability(10) != 0.5 || step.Probability(10.1) != 0 {
+		t.Error("Expected step kernel to cut off at its radius")
+	}
+
+	if (ExponentialKernel{Peak: 5, LengthConstant: 1}).Probability(0) != 1 {
+		t.Error("Expected probabilities to be clamped to 1")
+	}
+}
+
+// TestConnectSpatiallyFavoursNearbyNeurons verifies distance-dependent
+// connectivity and delays on a 2D sheet.
+func TestConnectSpatiallyFavoursNearbyNeurons(t *testing.T) {
+	nodes := testNodes(GridLayout(100, 50, 2))
+	config := SpatialConfig{
+		Kernel: ExponentialKernel{Peak: 0.9, LengthConstant: 60},
+		Delays: DefaultDelayModel(),
+		Seed:   3,
+	}
+
+	created := 0
+	conns, err := ConnectSpatially(nodes, nodes, config, func(pre, post Positioned, conn Connection) error {
+		created++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created != len(conns) || len(conns) == 0 {
+		t.Fatalf("Expected factory call per connection, got %d calls for %d connections", created, len(conns))
+	}
+
+	var near, far int
+	for _, c := range conns {
+		if c.PreID == c.PostID {
+			t.Fatal("Unexpected autapse")
+		}
+		if c.Delay != config.Delays.Delay(Planar(0, 0), Planar(c.Distance, 0)) {
+			t.Errorf("Delay %v does not match distance %f", c.Delay, c.Distance)
+		}
+		if c.Distance <= 100 {
+			near++
+		} else if c.Distance > 300 {
+			far++
+		}
+	}
+	if near <= far {
+		t.Errorf("Expected more short-range than long-range connections, near=%d far=%d", near, far)
+	}
+
+	// Factory errors abort generation
+	_, err = ConnectSpatially(nodes, nodes, config, func(pre, post Positioned, conn Connection) error {
+		return fmt.Errorf("no resources")
+	})
+	if err == nil {
+		t.Error("Expected factory error to be returned")
+	}
+}
+
+// TestLayouts verifies grid and random layouts.
+func TestLayouts(t *testing.T) {
+	grid := GridLayout(27, 10, 3)
+	if len(grid) != 27 || grid[26] != (types.Position3D{X: 20, Y: 20, Z: 20}) {
+		t.Errorf("Unexpected 3D grid corner: %v", grid[len(grid)-1])
+	}
+	for _, p := range GridLayout(10, 10, 2) {
+		if p.Z != 0 {
+			t.Fatal("Expected 2D grid to lie in the Z=0 plane")
+		}
+	}
+
+	box := types.BoundingBox{Min: types.Position3D{X: -10}, Max: types.Position3D{X: 10, Y: 20}}
+	for _, p := range RandomLayout(50, box, rand.New(rand.NewSource(1))) {
+		if p.X < -10 || p.X > 10 || p.Y < 0 || p.Y > 20 || p.Z != 0 {
+			t.Fatalf("Random position %v outside planar box", p)
+		}
+	}
+
+	nodes := testNodes(make([]types.Position3D, 3))
+	components := []component.Component{nodes[0].(component.Component), nodes[1].(component.Component), nodes[2].(component.Component)}
+	PlaceComponents(components, grid[:2])
+	if nodes[1].Position() != grid[1] || nodes[2].Position() != (types.Position3D{}) {
+		t.Error("Expected PlaceComponents to position only the first len(positions) components")
+	}
+}
+
+// TestConnectSpatiallyWeights verifies that generated weights and delays
+// follow the distance and are reproducible from the seed.
+func TestConnectSpatiallyWeights(t *testing.T) {
+	nodes := testNodes(GridLayout(25, 50, 2))
+	config := SpatialConfig{
+		Kernel: StepKernel{P: 0.5, Radius: 500},
+		Seed:   5,
+		Weights: func(rng *rand.Rand, distance float64) float64 {
+			return (1 + 0.1*rng.Float64()) * math.Exp(-distance/100)
+		},
+		DelayDistribution: func(rng *rand.Rand, distance float64) time.Duration {
+			return ConductionDelay(distance, 100) + time.Duration(rng.Int63n(int64(time.Millisecond)))
+		},
+	}
+	first, err := ConnectSpatially(nodes, nodes, config, nil)
+	if err != nil || len(first) == 0 {
+		t.Fatalf("Expected connections, got %d (%v)", len(first), err)
+	}
+	for _, c := range first {
+		if decay := math.Exp(-c.Distance / 100); c.Weight < decay || c.Weight > 1.1*decay {
+			t.Errorf("Weight %f does not follow distance %f", c.Weight, c.Distance)
+		}
+		if base := ConductionDelay(c.Distance, 100); c.Delay < base || c.Delay >= base+time.Millisecond {
+			t.Errorf("Delay %v does not follow distance %f", c.Delay, c.Distance)
+		}
+	}
+	again, _ := ConnectSpatially(nodes, nodes, config, nil)
+	for i := range first {
+		if first[i] != again[i] {
+			t.Fatalf("Expected the same seed to reproduce connection %d: %+v vs %+v", i, first[i], again[i])
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package triggers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// =================================================================================
+// RULE ENGINE
+// =================================================================================
+//
+// Whether a neuron fired more than N times within T only depends on its
+// N+1 most recent spikes: the count exceeds N exactly when the oldest of
+// them is within T of the newest. Each rule therefore keeps only the last N+1
+// spike times per neuron (or one per pool), so memory does not grow with
+// activity or window length.
+//
+// Webhooks go through a bounded queue served by at most
+// TRIGGER_WEBHOOK_WORKERS goroutines, which exit when the queue drains. A
+// rule firing on every spike therefore costs a fixed number of goroutines
+// and connections; firings that find the queue full are dropped and
+// reported as failed actions.
+
+const (
+	// TRIGGER_CALLBACK_ID is the output callback Attach registers.
+	TRIGGER_CALLBACK_ID = "trigger_engine"
+
+	// TRIGGER_SOURCE_ID is the SourceID of stimulus inputs.
+	TRIGGER_SOURCE_ID = "trigger_engine"
+
+	// TRIGGER_WEBHOOK_TIMEOUT bounds each webhook request.
+	TRIGGER_WEBHOOK_TIMEOUT = 5 * time.Second
+
+	// TRIGGER_WEBHOOK_QUEUE_SIZE is the number of webhooks that may wait
+	// for a worker before further firings are dropped.
+	TRIGGER_WEBHOOK_QUEUE_SIZE = 256
+
+	// TRIGGER_WEBHOOK_WORKERS is the maximum number of concurrent webhook
+	// requests.
+	TRIGGER_WEBHOOK_WORKERS = 4
+)
+
+// ErrWebhookQueueFull is reported for webhooks dropped because the queue
+// was full.
+var ErrWebhookQueueFull = errors.New("webhook queue full")
+
+// webhookJob is a queued webhook.
+type webhookJob struct {
+	url    string
+	firing Firing
+}
+
+// Firing describes one rule firing. Webhooks receive it as JSON.
+type Firing struct {
+	Rule     string        `json:"rule"`
+	NeuronID string        `json:"neuron_id"` // Neuron whose spike fired the rule
+	Count    int           `json:"count"`     // Spikes counted, MoreThan + 1
+	Within   time.Duration `json:"within"`    // Time those spikes took
+	At       time.Time     `json:"at"`        // Time of the firing spike
+}
+
+// SpikeSource is a neuron whose spikes the engine can observe.
+type SpikeSource interface {
+	ID() string
+	AddOutputCallback(synapseID string, callback types.OutputCallback)
+	RemoveOutputCallback(synapseID string)
+}
+
+// EngineConfig configures an Engine.
+type EngineConfig struct {
+	Rules     []Rule
+	Callbacks map[string]func(Firing)                   // Named functions for callback actions
+	Targets   func(id string) component.MessageReceiver // Resolves stimulus targets (required for stimulus actions)
+	Client    *http.Client                              // Webhook client (nil = TRIGGER_WEBHOOK_TIMEOUT timeout)
+	OnError   func(firing Firing, err error)            // Failed actions, possibly from a webhook worker
+}
+
+// window holds the newest spikes of one neuron or pool, oldest first.
+type window struct {
+	spikes []time.Time
+	hold   time.Time // No firing before this (cooldown)
+}
+
+// ruleState is a rule and its windows.
+type ruleState struct {
+	rule    Rule
+	neurons map[string]bool // nil = every neuron
+	windows map[string]*window
+	firings int64
+}
+
+// Engine evaluates rules against spikes and runs their actions. It is safe
+// for concurrent use.
+type Engine struct {
+	config EngineConfig
+	client *http.Client
+
+	mu       sync.Mutex
+	rules    []*ruleState
+	attached map[string]SpikeSource
+	spikes   int64
+	failures int64
+
+	pending  []webhookJob // Queued webhooks, oldest first
+	workers  int          // Running webhook workers
+	dropped  int64        // Webhooks dropped on a full queue
+	webhooks sync.WaitGroup
+}
+
+// NewEngine validates the rules and creates an engine.
+func NewEngine(config EngineConfig) (*Engine, error) {
+	engine := &Engine{config: config, client: config.Client, attached: make(map[string]SpikeSource)}
+	if engine.client == nil {
+		engine.client = &http.Client{Timeout: TRIGGER_WEBHOOK_TIMEOUT}
+	}
+	names := make(map[string]bool, len(config.Rules))
+	for _, rule := range config.Rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("duplicate rule name: %s", rule.Name)
+		}
+		names[rule.Name] = true
+		for i, action := range rule.Actions {
+			if action.Type == ActionCallback && config.Callbacks[action.Callback] == nil {
+				return nil, fmt.Errorf("rule %s: action %d: no callback named %s", rule.Name, i, action.Callback)
+			}
+			if action.Type == ActionStimulus && config.Targets == nil {
+				return nil, fmt.Errorf("rule %s: action %d: stimulus actions need Targets", rule.Name, i)
+			}
+		}
+
+		state := &ruleState{rule: rule, windows: make(map[string]*window)}
+		state.rule.Neurons = append([]string(nil), rule.Neurons...)
+		state.rule.Actions = append([]Action(nil), rule.Actions...)
+		if len(rule.Neurons) > 0 {
+			state.neurons = make(map[string]bool, len(rule.Neurons))
+			for _, id := range rule.Neurons {
+				state.neurons[id] = true
+			}
+		}
+		engine.rules = append(engine.rules, state)
+	}
+	return engine, nil
+}
+
+// RecordSpike reports a spike of neuronID at time at, firing every rule it
+// completes. Spikes of one neuron must be reported in time order.
+func (e *Engine) RecordSpike(neuronID string, at time.Time) {
+	var fired []Firing
+	var actions [][]Action
+
+	e.mu.Lock()
+	e.spikes++
+	for _, state := range e.rules {
+		if state.neurons != nil && !state.neurons[neuronID] {
+			continue
+		}
+		key := neuronID
+		if state.rule.Pooled {
+			key = ""
+		}
+		w := state.windows[key]
+		if w == nil {
+			w = &window{spikes: make([]time.Time, 0, state.rule.MoreThan+1)}
+			state.windows[key] = w
+		}
+		if len(w.spikes) == state.rule.MoreThan+1 {
+			copy(w.spikes, w.spikes[1:])
+			w.spikes = w.spikes[:len(w.spikes)-1]
+		}
+		w.spikes = append(w.spikes, at)
+
+		if len(w.spikes) <= state.rule.MoreThan || at.Before(w.hold) {
+			continue
+		}
+		span := at.Sub(w.spikes[0])
+		if span > state.rule.Within {
+			continue
+		}
+		state.firings++
+		w.spikes = w.spikes[:0]
+		w.hold = at.Add(state.rule.Cooldown)
+		fired = append(fired, Firing{Rule: state.rule.Name, NeuronID: neuronID, Count: state.rule.MoreThan + 1, Within: span, At: at})
+		actions = append(actions, state.rule.Actions)
+	}
+	e.mu.Unlock()
+
+	for i, firing := range fired {
+		for _, action := range actions[i] {
+			e.run(action, firing)
+		}
+	}
+}
+
+// run performs one action.
+func (e *Engine) run(action Action, firing Firing) {
+	switch action.Type {
+	case ActionCallback:
+		e.config.Callbacks[action.Callback](firing)
+	case ActionStimulus:
+		target := e.config.Targets(action.Target)
+		if target == nil {
+			e.fail(firing, fmt.Errorf("rule %s: unknown stimulus target %s", firing.Rule, action.Target))
+			return
+		}
+		target.Receive(types.NeuralSignal{
+			Value:     action.Value,
+			Timestamp: time.Now(),
+			SourceID:  TRIGGER_SOURCE_ID,
+			TargetID:  action.Target,
+		})
+	case ActionWebhook:
+		e.enqueueWebhook(webhookJob{url: action.URL, firing: firing})
+	}
+}
+
+// enqueueWebhook queues a webhook, starting a worker if fewer than
+// TRIGGER_WEBHOOK_WORKERS are running. A full queue drops the webhook.
+func (e *Engine) enqueueWebhook(job webhookJob) {
+	e.mu.Lock()
+	if len(e.pending) >= TRIGGER_WEBHOOK_QUEUE_SIZE {
+		e.dropped++
+		e.mu.Unlock()
+		e.fail(job.firing, fmt.Errorf("rule %s: webhook %s: %w", job.firing.Rule, job.url, ErrWebhookQueueFull))
+		return
+	}
+	e.pending = append(e.pending, job)
+	e.webhooks.Add(1)
+	start := e.workers < TRIGGER_WEBHOOK_WORKERS
+	if start {
+		e.workers++
+	}
+	e.mu.Unlock()
+
+	if start {
+		go e.webhookWorker()
+	}
+}
+
+// webhookWorker sends queued webhooks until the queue is empty.
+func (e *Engine) webhookWorker() {
+	for {
+		e.mu.Lock()
+		if len(e.pending) == 0 {
+			e.workers--
+			e.mu.Unlock()
+			return
+		}
+		job := e.pending[0]
+		e.pending[0] = webhookJob{}
+		e.pending = e.pending[1:]
+		e.mu.Unlock()
+
+		if err := e.post(job.url, job.firing); err != nil {
+			e.fail(job.firing, fmt.Errorf("rule %s: webhook %s: %w", job.firing.Rule, job.url, err))
+		}
+		e.webhooks.Done()
+	}
+}
+
+// post sends firing to url as JSON.
+func (e *Engine) post(url string, firing Firing) error {
+	body, err := json.Marshal(firing)
+	if err != nil {
+		return err
+	}
+	resp, err := e.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	// Drain the body so the connection can be reused
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("status %s", resp.Status)
+	}
+	return nil
+}
+
+// fail counts a failed action and reports it.
+func (e *Engine) fail(firing Firing, err error) {
+	e.mu.Lock()
+	e.failures++
+	e.mu.Unlock()
+	if e.config.OnError != nil {
+		e.config.OnError(firing, err)
+	}
+}
+
+// Wait blocks until every webhook queued so far has completed.
+func (e *Engine) Wait() {
+	e.webhooks.Wait()
+}
+
+// Attach evaluates the rules on src's spikes via an output callback, which
+// counts as one extra output connection until Detach.
+func (e *Engine) Attach(src SpikeSource) {
+	id := src.ID()
+	src.AddOutputCallback(TRIGGER_CALLBACK_ID, types.OutputCallback{
+		TransmitMessage: func(msg types.NeuralSignal) error {
+			e.RecordSpike(id, msg.Timestamp)
+			return nil
+		},
+		GetWeight:   func() float64 { return 0 },
+		GetDelay:    func() time.Duration { return 0 },
+		GetTargetID: func() string { return "" },
+	})
+
+	e.mu.Lock()
+	e.attached[id] = src
+	e.mu.Unlock()
+}
+
+// Detach stops observing all attached neurons.
+func (e *Engine) Detach() {
+	e.mu.Lock()
+	attached := e.attached
+	e.attached = make(map[string]SpikeSource)
+	e.mu.Unlock()
+
+	for _, src := range attached {
+		src.RemoveOutputCallback(TRIGGER_CALLBACK_ID)
+	}
+}
+
+// Firings returns how often the named rule has fired.
+func (e *Engine) Firings(rule string) int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, state := range e.rules {
+		if state.rule.Name == rule {
+			return state.firings
+		}
+	}
+	return 0
+}
+
+// GetStats returns spike, firing and failure counts. Dropped webhooks are
+// also counted as failed actions.
+func (e *Engine) GetStats() map[string]interface{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	firings := make(map[string]int64, len(e.rules))
+	var total int64
+	for _, state := range e.rules {
+		firings[state.rule.Name] = state.firings
+		total += state.firings
+	}
+	return map[string]interface{}{
+		"rules":            len(e.rules),
+		"attached":         len(e.attached),
+		"spikes":           e.spikes,
+		"firings":          total,
+		"rule_firings":     firings,
+		"failed_actions":   e.failures,
+		"dropped_webhooks": e.dropped,
+	}
+}
+
+// Receivers returns a Targets function resolving the given receivers by ID.
+func Receivers(receivers ...component.MessageReceiver) func(id string) component.MessageReceiver {
+	byID := make(map[string]component.MessageReceiver, len(receivers))
+	for _, receiver := range receivers {
+		byID[receiver.ID()] = receiver
+	}
+	return func(id string) component.MessageReceiver { return byID[id] }
+}
@@ -0,0 +1,170 @@
+/*
+=================================================================================
+TRIGGERS - SPIKE-CONDITIONAL RULES FOR EXTERNAL ACTIONS
+=================================================================================
+
+Integrations often need to react to activity: log when a detector neuron
+bursts, tell a robot controller that an action population has committed,
+or close a loop by stimulating one population when another fires. Instead
+of a bespoke monitoring goroutine per integration, an Engine evaluates
+declarative rules against the spike stream:
+
+	rules, _ := triggers.ParseRules([]byte(`[
+	  {"name": "burst", "neurons": ["d1"], "more_than": 3, "within": "20ms",
+	   "actions": [{"type": "webhook", "url": "http://localhost:8080/burst"},
+	               {"type": "stimulus", "target": "inh1", "value": 1.5}]}
+	]`))
+	engine, _ := triggers.NewEngine(triggers.EngineConfig{Rules: rules, Targets: targets})
+	engine.Attach(d1) // or engine.RecordSpike(id, at) from a simulation loop
+
+A rule fires when a neuron fires more than MoreThan times within Within.
+Pooled rules count the spikes of all their neurons together. After firing,
+the rule's count for that neuron (or pool) starts over, and Cooldown can
+hold it off for longer. Each firing runs the rule's actions in order:
+
+	callback  call a function registered under a name in EngineConfig.Callbacks
+	stimulus  send an input of Value to the Target neuron
+	webhook   POST the Firing as JSON to URL, asynchronously
+
+Callbacks and stimuli run on the goroutine that reported the spike, so they
+should be quick. Webhooks never block the spike path: they are queued for a
+small pool of workers, and dropped when the queue is full. Failures and
+drops are counted and passed to OnError.
+=================================================================================
+*/
+
+package triggers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ActionType names what an action does.
+type ActionType string
+
+const (
+	ActionCallback ActionType = "callback"
+	ActionStimulus ActionType = "stimulus"
+	ActionWebhook  ActionType = "webhook"
+)
+
+// Action is one external effect of a rule.
+type Action struct {
+	Type     ActionType `json:"type"`
+	Callback string     `json:"callback,omitempty"` // Callback: name in EngineConfig.Callbacks
+	Target   string     `json:"target,omitempty"`   // Stimulus: neuron receiving the input
+	Value    float64    `json:"value,omitempty"`    // Stimulus: input amplitude
+	URL      string     `json:"url,omitempty"`      // Webhook: endpoint receiving the Firing
+}
+
+// Rule fires its actions when a neuron fires more than MoreThan times
+// within Within.
+type Rule struct {
+	Name     string        `json:"name"`
+	Neurons  []string      `json:"neurons,omitempty"` // Neurons watched (empty = every neuron reported)
+	Pooled   bool          `json:"pooled,omitempty"`  // Count the spikes of all Neurons together
+	MoreThan int           `json:"more_than"`         // Spike count that must be exceeded
+	Within   time.Duration `json:"-"`                 // Window of the count
+	Cooldown time.Duration `json:"-"`                 // Minimum time between firings (0 = none)
+	Actions  []Action      `json:"actions"`
+}
+
+// MarshalJSON writes durations as strings ("20ms").
+func (r Rule) MarshalJSON() ([]byte, error) {
+	type plain Rule
+	wire := struct {
+		plain
+		Within   string `json:"within"`
+		Cooldown string `json:"cooldown,omitempty"`
+	}{plain: plain(r), Within: r.Within.String()}
+	if r.Cooldown > 0 {
+		wire.Cooldown = r.Cooldown.String()
+	}
+	return json.Marshal(wire)
+}
+
+// UnmarshalJSON reads durations written as strings.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	type plain Rule
+	var wire struct {
+		plain
+		Within   string `json:"within"`
+		Cooldown string `json:"cooldown,omitempty"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	*r = Rule(wire.plain)
+	var err error
+	if wire.Within != "" {
+		if r.Within, err = time.ParseDuration(wire.Within); err != nil {
+			return fmt.Errorf("rule %s: window: %w", r.Name, err)
+		}
+	}
+	if wire.Cooldown != "" {
+		if r.Cooldown, err = time.ParseDuration(wire.Cooldown); err != nil {
+			return fmt.Errorf("rule %s: cooldown: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// ParseRules reads a JSON array of rules and validates each.
+func ParseRules(data []byte) ([]Rule, error) {
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parsing rules: %w", err)
+	}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return rules, nil
+}
+
+// Validate checks the rule on its own; NewEngine also checks that its
+// callbacks and stimulus targets can be resolved.
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule name cannot be empty")
+	}
+	if r.MoreThan < 0 {
+		return fmt.Errorf("rule %s: spike count cannot be negative: %d", r.Name, r.MoreThan)
+	}
+	if r.Within <= 0 {
+		return fmt.Errorf("rule %s: window must be positive: %v", r.Name, r.Within)
+	}
+	if r.Cooldown < 0 {
+		return fmt.Errorf("rule %s: cooldown cannot be negative: %v", r.Name, r.Cooldown)
+	}
+	if r.Pooled && len(r.Neurons) == 0 {
+		return fmt.Errorf("rule %s: a pooled rule needs its neurons", r.Name)
+	}
+	if len(r.Actions) == 0 {
+		return fmt.Errorf("rule %s: no actions", r.Name)
+	}
+	for i, action := range r.Actions {
+		switch action.Type {
+		case ActionCallback:
+			if action.Callback == "" {
+				return fmt.Errorf("rule %s: action %d: callback name cannot be empty", r.Name, i)
+			}
+		case ActionStimulus:
+			if action.Target == "" {
+				return fmt.Errorf("rule %s: action %d: stimulus target cannot be empty", r.Name, i)
+			}
+		case ActionWebhook:
+			u, err := url.Parse(action.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("rule %s: action %d: webhook needs an http(s) URL: %q", r.Name, i, action.URL)
+			}
+		default:
+			return fmt.Errorf("rule %s: action %d: unknown type %q", r.Name, i, action.Type)
+		}
+	}
+	return nil
+}
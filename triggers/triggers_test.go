@@ -0,0 +1,220 @@
+package triggers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SynapticNetworks/temporal-neuron/component"
+	"github.com/SynapticNetworks/temporal-neuron/types"
+)
+
+// stimulusReceiver records the inputs a stimulus action sends.
+type stimulusReceiver struct {
+	*component.BaseComponent
+	mu     sync.Mutex
+	inputs []types.NeuralSignal
+}
+
+func (r *stimulusReceiver) Receive(msg types.NeuralSignal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.inputs = append(r.inputs, msg)
+}
+
+// TestEngineFiresActions verifies the spike count condition, cooldown,
+// pooled counting and all three action types.
+func TestEngineFiresActions(t *testing.T) {
+	var mu sync.Mutex
+	var posted []Firing
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var firing Firing
+		if err := json.NewDecoder(r.Body).Decode(&firing); err != nil {
+			t.Errorf("Webhook body is not a firing: %v", err)
+		}
+		mu.Lock()
+		posted = append(posted, firing)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	rules, err := ParseRules([]byte(`[
+	  {"name": "burst", "neurons": ["d1", "d2"], "more_than": 2, "within": "10ms", "cooldown": "50ms",
+	   "actions": [{"type": "callback", "callback": "count"},
+	               {"type": "stimulus", "target": "inh", "value": 1.5},
+	               {"type": "webhook", "url": "` + server.URL + `"}]},
+	  {"name": "population", "neurons": ["d1", "d2"], "pooled": true, "more_than": 3, "within": "10ms",
+	   "actions": [{"type": "callback", "callback": "count"}]}
+	]`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rules[0].Within != 10*time.Millisecond || rules[0].Cooldown != 50*time.Millisecond {
+		t.Fatalf("Durations not parsed: %+v", rules[0])
+	}
+
+	inh := &stimulusReceiver{BaseComponent: component.NewBaseComponent("inh", types.TypeNeuron, types.Position3D{})}
+	var called []Firing
+	engine, err := NewEngine(EngineConfig{
+		Rules:     rules,
+		Callbacks: map[string]func(Firing){"count": func(f Firing) { called = append(called, f) }},
+		Targets:   Receivers(inh),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	base := time.Now()
+	ms := func(n int) time.Time { return base.Add(time.Duration(n) * time.Millisecond) }
+
+	// Spread out: never more than 2 spikes in 10ms
+	for _, at := range []int{0, 11, 22, 33} {
+		engine.RecordSpike("d1", ms(at))
+	}
+	if engine.Firings("burst") != 0 {
+		t.Fatal("Burst rule fired on sparse spikes")
+	}
+	// Three spikes within 10ms
+	engine.RecordSpike("d1", ms(34))
+	engine.RecordSpike("d1", ms(36))
+	if engine.Firings("burst") != 1 {
+		t.Fatalf("Expected the burst rule to fire, fired %d times", engine.Firings("burst"))
+	}
+	// Within the cooldown nothing fires, whatever the count
+	for i := 0; i < 6; i++ {
+		engine.RecordSpike("d1", ms(40+i))
+	}
+	if engine.Firings("burst") != 1 {
+		t.Errorf("Expected the cooldown to hold, fired %d times", engine.Firings("burst"))
+	}
+	// Neurons outside the rule are ignored; d2 counts separately but pools with d1
+	engine.RecordSpike("other", ms(80))
+	engine.RecordSpike("d2", ms(80))
+	if engine.Firings("burst") != 1 {
+		t.Error("One spike of d2 should not fire the burst rule")
+	}
+	if pooled := engine.Firings("population"); pooled == 0 {
+		t.Error("Expected the pooled rule to fire on d1 and d2 together")
+	}
+
+	engine.Wait()
+	mu.Lock()
+	if len(posted) != 1 || posted[0].Rule != "burst" || posted[0].NeuronID != "d1" || posted[0].Count != 3 || posted[0].Within != 3*time.Millisecond {
+		t.Errorf("Expected one webhook for the burst, got %+v", posted)
+	}
+	mu.Unlock()
+	if len(inh.inputs) != 1 || inh.inputs[0].Value != 1.5 || inh.inputs[0].SourceID != TRIGGER_SOURCE_ID {
+		t.Errorf("Expected one stimulus of 1.5, got %+v", inh.inputs)
+	}
+	if int64(len(called)) != engine.Firings("burst")+engine.Firings("population") {
+		t.Errorf("Expected a callback per firing, got %d", len(called))
+	}
+	stats := engine.GetStats()
+	if stats["spikes"].(int64) != 14 || stats["failed_actions"].(int64) != 0 {
+		t.Errorf("Unexpected stats %v", stats)
+	}
+
+	// Failing webhooks are counted and reported
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	var failures []error
+	engine, _ = NewEngine(EngineConfig{
+		Rules:   []Rule{{Name: "any", Within: time.Second, Actions: []Action{{Type: ActionWebhook, URL: failing.URL}}}},
+		OnError: func(_ Firing, err error) { mu.Lock(); failures = append(failures, err); mu.Unlock() },
+	})
+	engine.RecordSpike("x", base)
+	engine.Wait()
+	if len(failures) != 1 || engine.GetStats()["failed_actions"].(int64) != 1 {
+		t.Errorf("Expected one failed webhook, got %v", failures)
+	}
+
+	for _, bad := range []EngineConfig{
+		{Rules: []Rule{{Name: "r", Within: time.Second, Actions: []Action{{Type: ActionCallback, Callback: "missing"}}}}},
+		{Rules: []Rule{{Name: "r", Within: time.Second, Actions: []Action{{Type: ActionStimulus, Target: "inh"}}}}},
+		{Rules: []Rule{{Name: "r", Within: time.Second, Actions: []Action{{Type: ActionWebhook, URL: "ftp://x"}}}}},
+		{Rules: []Rule{{Name: "r", Actions: []Action{{Type: ActionWebhook, URL: server.URL}}}}},
+	} {
+		if _, err := NewEngine(bad); err == nil {
+			t.Errorf("Expected error for %+v", bad.Rules[0])
+		}
+	}
+}
+
+// TestWebhookQueueIsBounded verifies that webhooks run on at most
+// TRIGGER_WEBHOOK_WORKERS concurrent requests and that firings beyond the
+// queue are dropped and reported.
+func TestWebhookQueueIsBounded(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	inFlight, maxInFlight, posted := 0, 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		inFlight--
+		posted++
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	var dropped int
+	engine, err := NewEngine(EngineConfig{
+		Rules: []Rule{{Name: "every", Within: time.Second, Actions: []Action{{Type: ActionWebhook, URL: server.URL}}}},
+		OnError: func(_ Firing, err error) {
+			if errors.Is(err, ErrWebhookQueueFull) {
+				mu.Lock()
+				dropped++
+				mu.Unlock()
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Occupy every worker, then fill the queue and overflow it
+	base := time.Now()
+	for i := 0; i < TRIGGER_WEBHOOK_WORKERS; i++ {
+		engine.RecordSpike("n", base.Add(time.Duration(i)*time.Millisecond))
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		mu.Lock()
+		busy := inFlight
+		mu.Unlock()
+		if busy == TRIGGER_WEBHOOK_WORKERS {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d webhooks in flight, got %d", TRIGGER_WEBHOOK_WORKERS, busy)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	for i := 0; i < TRIGGER_WEBHOOK_QUEUE_SIZE+10; i++ {
+		engine.RecordSpike("n", base.Add(time.Second+time.Duration(i)*time.Millisecond))
+	}
+
+	close(release)
+	engine.Wait()
+	mu.Lock()
+	defer mu.Unlock()
+	if maxInFlight != TRIGGER_WEBHOOK_WORKERS {
+		t.Errorf("Expected at most %d concurrent webhooks, saw %d", TRIGGER_WEBHOOK_WORKERS, maxInFlight)
+	}
+	if posted != TRIGGER_WEBHOOK_WORKERS+TRIGGER_WEBHOOK_QUEUE_SIZE || dropped != 10 {
+		t.Errorf("Expected %d webhooks sent and 10 dropped, got %d and %d",
+			TRIGGER_WEBHOOK_WORKERS+TRIGGER_WEBHOOK_QUEUE_SIZE, posted, dropped)
+	}
+	if stats := engine.GetStats(); stats["dropped_webhooks"].(int64) != 10 {
+		t.Errorf("Expected 10 dropped webhooks in the stats, got %v", stats["dropped_webhooks"])
+	}
+}
@@ -247,11 +247,16 @@ const (
 	NeuronCreated  EventType = "neuron.created"
 	NeuronFired    EventType = "neuron.fired"
 	NeuronReceived EventType = "neuron.received"
+	NeuronRemoved  EventType = "neuron.removed" // Neuron died or was removed, with all its synapses
+	NeuronBorn     EventType = "neuron.born"    // Adult-born neuron added by neurogenesis
 
 	// --- Synapse Events ---
 	SynapseCreated       EventType = "synapse.created"
 	SynapseTransmitted   EventType = "synapse.transmitted"
 	SynapseWeightChanged EventType = "synapse.weight.changed"
+	SynapseDeadTarget    EventType = "synapse.dead.target"  // Post-synaptic neuron found closed
+	SynapseUnsilenced    EventType = "synapse.unsilenced"   // Silent synapse began transmitting
+	SynapseConsolidated  EventType = "synapse.consolidated" // Synaptic tag captured
 )
 
 // BiologicalEvent represents a single, significant functional occurrence within the matrix.
@@ -0,0 +1,219 @@
+// types/ids.go
+package types
+
+import (
+	"runtime"
+	"sync"
+)
+
+// =================================================================================
+// ID INTERNING
+// =================================================================================
+
+// Component IDs are strings at the API, but a network with a million
+// synapses repeats the same neuron IDs in every synapse, message and event.
+// Interning stores each distinct ID once and gives it a compact IDHandle:
+//
+//   - Components intern their ID at construction (component.NewBaseComponent)
+//     and keep the registry's copy of the string, so every holder of the ID
+//     shares one allocation.
+//   - Hot paths key their maps by handle: a neuron's input routes and output
+//     synapses (neuron/routing.go, neuron/outputs.go). Strings are looked up
+//     only at API boundaries (registration, inspection).
+//   - Signals carry the handle of their synapse next to the string.
+//     Receivers use it when it is non-zero and fall back to the string
+//     otherwise (a signal from another process or an older sender).
+//
+// Interning is reference counted. Every Intern takes a reference and every
+// Release drops one; the ID and its slot are freed with the last reference,
+// so networks with turnover (pruning, synaptogenesis, short-lived
+// namespaces) do not accumulate IDs. Components hold theirs through an IDRef,
+// released when the component is garbage collected. A freed slot is reused
+// with a new generation, so a stale handle never resolves to another ID. A
+// slot whose generation is exhausted is retired instead of wrapping around.
+//
+// Handles are only meaningful inside one process and are never serialized.
+
+// IDHandle is a compact process-local handle for an interned ID (0 = none).
+// The low 24 bits select a slot and the high 8 bits its generation.
+type IDHandle uint32
+
+const (
+	idSlotBits = 24
+	idSlotMask = 1<<idSlotBits - 1 // Also the number of slots
+
+	idMaxGeneration = 1<<(32-idSlotBits) - 1 // Last generation of a slot
+)
+
+// idEntry is one registry slot.
+type idEntry struct {
+	id         string
+	refs       int
+	generation uint8
+}
+
+// IDRegistry interns IDs. The zero value is not usable; use NewIDRegistry.
+type IDRegistry struct {
+	mu      sync.RWMutex
+	handles map[string]IDHandle
+	entries []idEntry // entries[slot]
+	free    []uint32  // Slots of released IDs, reused first
+}
+
+// NewIDRegistry creates an empty registry.
+func NewIDRegistry() *IDRegistry {
+	return &IDRegistry{handles: make(map[string]IDHandle)}
+}
+
+// DefaultIDs is the process-wide registry used by components.
+var DefaultIDs = NewIDRegistry()
+
+// Intern takes a reference to id, assigning a handle on first use, and
+// returns the handle and the registry's shared copy of the string. Each
+// Intern must be paired with a Release. The empty ID has handle 0, as has
+// any ID once all 2^24 slots are live or retired (callers then fall back
+// to strings).
+func (r *IDRegistry) Intern(id string) (IDHandle, string) {
+	if id == "" {
+		return 0, ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if handle, ok := r.handles[id]; ok {
+		entry := &r.entries[handle.slot()]
+		entry.refs++
+		return handle, entry.id
+	}
+
+	var slot uint32
+	if n := len(r.free); n > 0 {
+		slot, r.free = r.free[n-1], r.free[:n-1]
+	} else if len(r.entries) < idSlotMask {
+		r.entries = append(r.entries, idEntry{})
+		slot = uint32(len(r.entries) - 1)
+	} else {
+		return 0, id
+	}
+	entry := &r.entries[slot]
+	entry.id, entry.refs = id, 1
+	handle := IDHandle(uint32(entry.generation)<<idSlotBits | (slot + 1))
+	r.handles[id] = handle
+	return handle, id
+}
+
+// Release drops a reference taken by Intern. The last release frees the ID;
+// its handle then resolves to nothing. Stale and zero handles are ignored.
+func (r *IDRegistry) Release(handle IDHandle) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry := r.entryUnsafe(handle)
+	if entry == nil {
+		return
+	}
+	if entry.refs--; entry.refs > 0 {
+		return
+	}
+	delete(r.handles, entry.id)
+	entry.id = ""
+	if entry.generation == idMaxGeneration {
+		// Reusing the slot would wrap the generation and let a stale
+		// handle resolve again, so it is retired
+		return
+	}
+	entry.generation++
+	r.free = append(r.free, handle.slot())
+}
+
+// Lookup returns the handle of an interned ID without taking a reference.
+func (r *IDRegistry) Lookup(id string) (IDHandle, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	handle, ok := r.handles[id]
+	return handle, ok
+}
+
+// String returns the ID of a handle ("" for 0, a stale or an unknown handle).
+func (r *IDRegistry) String(handle IDHandle) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if entry := r.entryUnsafe(handle); entry != nil {
+		return entry.id
+	}
+	return ""
+}
+
+// Len returns the number of live interned IDs.
+func (r *IDRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.handles)
+}
+
+// entryUnsafe returns the live entry of handle, or nil. Must be called with
+// mu held.
+func (r *IDRegistry) entryUnsafe(handle IDHandle) *idEntry {
+	if handle == 0 {
+		return nil
+	}
+	slot := handle.slot()
+	if int(slot) >= len(r.entries) {
+		return nil
+	}
+	entry := &r.entries[slot]
+	if entry.refs == 0 || entry.generation != uint8(handle>>idSlotBits) {
+		return nil
+	}
+	return entry
+}
+
+// slot returns the registry slot of a non-zero handle.
+func (h IDHandle) slot() uint32 {
+	return uint32(h)&idSlotMask - 1
+}
+
+// IDRef is one reference to an interned ID for holders without an explicit
+// end of life. The reference is released when the IDRef is garbage
+// collected, so the holder keeps the IDRef for as long as it uses the ID.
+type IDRef struct {
+	registry *IDRegistry
+	handle   IDHandle
+}
+
+// Acquire interns id and returns a reference released on garbage
+// collection, and the registry's shared copy of the string.
+func (r *IDRegistry) Acquire(id string) (*IDRef, string) {
+	handle, id := r.Intern(id)
+	ref := &IDRef{registry: r, handle: handle}
+	if handle != 0 {
+		runtime.SetFinalizer(ref, func(ref *IDRef) { ref.registry.Release(ref.handle) })
+	}
+	return ref, id
+}
+
+// Handle returns the referenced handle (0 if the ID was not interned).
+func (ref *IDRef) Handle() IDHandle {
+	if ref == nil {
+		return 0
+	}
+	return ref.handle
+}
+
+// InternID takes a reference to id in DefaultIDs (see IDRegistry.Intern).
+func InternID(id string) (IDHandle, string) {
+	return DefaultIDs.Intern(id)
+}
+
+// ReleaseID drops a reference taken by InternID.
+func ReleaseID(handle IDHandle) {
+	DefaultIDs.Release(handle)
+}
+
+// SameID reports whether two IDs are equal. Equal non-zero handles decide
+// without touching the strings; otherwise the strings are compared, which
+// also covers handles that went stale and were re-interned.
+func SameID(a IDHandle, aID string, b IDHandle, bID string) bool {
+	if a != 0 && a == b {
+		return true
+	}
+	return aID == bID
+}
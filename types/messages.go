@@ -1,7 +1,10 @@
 // types/messages.go
 package types
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // =================================================================================
 // CORE NEURAL MESSAGING STRUCTURES
@@ -15,8 +18,150 @@ type NeuralSignal struct {
 	SourceID             string     `json:"source_id"`              // ID of sending component
 	TargetID             string     `json:"target_id"`              // ID of receiving component
 	SynapseID            string     `json:"synapse_id,omitempty"`   // ID of transmitting synapse (if applicable)
+	SynapseHandle        uint32     `json:"-"`                      // Route index assigned by the receiving neuron (0 = none; local, never serialized)
+	SynapseIDHandle      IDHandle   `json:"-"`                      // Interned SynapseID (0 = unknown; see ids.go)
 	NeurotransmitterType LigandType `json:"neurotransmitter_type"`  // Chemical messenger type
 	MessageType          string     `json:"message_type,omitempty"` // Optional message classification
+	Port                 string     `json:"port,omitempty"`         // Input port on the receiving neuron ("" = soma)
+	Priority             Priority   `json:"priority,omitempty"`     // Delivery class under load (0 = PriorityNormal)
+
+	// === EXTENSIONS (schema version 2) ===
+	Version  int               `json:"version,omitempty"`  // Schema version (0 = unversioned, read as SignalSchemaV1)
+	Metadata map[string]string `json:"metadata,omitempty"` // Model-specific attributes, e.g. receptor type
+	Payload  []byte            `json:"payload,omitempty"`  // Small opaque blob (at most MaxSignalPayloadBytes)
+}
+
+// =================================================================================
+// SIGNAL PRIORITY
+// =================================================================================
+//
+// When a neuron falls behind, not every input matters equally: a sensory or
+// feedback pathway that closes a control loop must get through, background
+// noise can be dropped. Priority classifies a signal for delivery under load.
+// Synapses stamp their priority class on every signal they deliver; the zero
+// value is PriorityNormal, so existing signals keep their behaviour.
+
+// Priority is the delivery class of a signal. Higher values are delivered
+// first and dropped last.
+type Priority int
+
+const (
+	// PriorityBackground marks noise and tonic drive, shed first under load.
+	PriorityBackground Priority = -1
+
+	// PriorityNormal is the default class.
+	PriorityNormal Priority = 0
+
+	// PriorityCritical marks sensory and feedback pathways, delivered ahead
+	// of other input and never shed.
+	PriorityCritical Priority = 1
+)
+
+// String returns the priority class name.
+func (p Priority) String() string {
+	switch p {
+	case PriorityBackground:
+		return "background"
+	case PriorityNormal:
+		return "normal"
+	case PriorityCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("priority(%d)", int(p))
+	}
+}
+
+// Validate reports whether p is a known priority class.
+func (p Priority) Validate() error {
+	if p < PriorityBackground || p > PriorityCritical {
+		return fmt.Errorf("unknown signal priority: %d", int(p))
+	}
+	return nil
+}
+
+// =================================================================================
+// SIGNAL SCHEMA VERSIONING
+// =================================================================================
+//
+// Custom models need to attach information the core fields do not cover: the
+// identity of a co-released neuromodulator, the receptor subtype a synapse
+// targets, a model-specific tag. Metadata holds named string attributes and
+// Payload holds a small binary blob. Consumers that do not know an attribute
+// ignore it, so adding one never breaks existing code.
+//
+// Version records which schema produced a signal. Existing literals leave it
+// zero, which reads as SignalSchemaV1. WithMetadata and WithPayload raise it
+// to SignalSchemaV2. A receiver that gets a version newer than
+// SignalSchemaCurrent, e.g. from a newer peer in a distributed run, can use
+// Validate to reject the signal instead of misreading it.
+//
+// NeuralSignal is passed by value, and copies share the Metadata map and the
+// Payload slice. The With* methods therefore return a copy with fresh
+// storage. Treat attached metadata as read-only.
+
+const (
+	SignalSchemaV1        = 1              // Value, timing, IDs and ligand only
+	SignalSchemaV2        = 2              // Adds Metadata and Payload
+	SignalSchemaCurrent   = SignalSchemaV2 // Newest schema this build understands
+	MaxSignalPayloadBytes = 256            // Payloads are per-spike; keep them small
+)
+
+// SchemaVersion returns the signal's schema version, reading an unset
+// version as SignalSchemaV1.
+func (s NeuralSignal) SchemaVersion() int {
+	if s.Version == 0 {
+		return SignalSchemaV1
+	}
+	return s.Version
+}
+
+// WithMetadata returns a copy of the signal with attribute key set to value.
+// The original signal and its map are not modified.
+func (s NeuralSignal) WithMetadata(key, value string) NeuralSignal {
+	metadata := make(map[string]string, len(s.Metadata)+1)
+	for k, v := range s.Metadata {
+		metadata[k] = v
+	}
+	metadata[key] = value
+	s.Metadata = metadata
+	s.upgradeVersion()
+	return s
+}
+
+// GetMetadata returns attribute key and whether it is set.
+func (s NeuralSignal) GetMetadata(key string) (string, bool) {
+	value, ok := s.Metadata[key]
+	return value, ok
+}
+
+// WithPayload returns a copy of the signal carrying a copy of payload.
+// Returns an error if payload exceeds MaxSignalPayloadBytes.
+func (s NeuralSignal) WithPayload(payload []byte) (NeuralSignal, error) {
+	if len(payload) > MaxSignalPayloadBytes {
+		return s, fmt.Errorf("signal payload of %d bytes exceeds %d", len(payload), MaxSignalPayloadBytes)
+	}
+	s.Payload = append([]byte(nil), payload...)
+	s.upgradeVersion()
+	return s, nil
+}
+
+// Validate reports whether this build can interpret the signal: the schema
+// version must be known and the payload within MaxSignalPayloadBytes.
+func (s NeuralSignal) Validate() error {
+	if s.Version < 0 || s.Version > SignalSchemaCurrent {
+		return fmt.Errorf("unsupported signal schema version %d (newest known %d)", s.Version, SignalSchemaCurrent)
+	}
+	if len(s.Payload) > MaxSignalPayloadBytes {
+		return fmt.Errorf("signal payload of %d bytes exceeds %d", len(s.Payload), MaxSignalPayloadBytes)
+	}
+	return nil
+}
+
+// upgradeVersion marks the signal as using the extension fields.
+func (s *NeuralSignal) upgradeVersion() {
+	if s.Version < SignalSchemaV2 {
+		s.Version = SignalSchemaV2
+	}
 }
 
 // SynapseMessage represents a message transmitted through a synapse